@@ -3,7 +3,7 @@ package vmcommon
 import (
 	"bytes"
 
-	"github.com/Reshusk23/sr-me-core/core"
+	"github.com/Reshusk23/sr-vm-common-go/protectedkeys"
 )
 
 // SystemAccountAddress is the hard-coded address in which we save global settings on all shards
@@ -22,14 +22,9 @@ const ShardIdentiferLen = 2
 const metaChainShardIdentifier uint8 = 255
 const numInitCharactersForOnMetachainSC = 15
 
-const numInitCharactersForSystemAccountAddress = 30
-
-// IsSystemAccountAddress returns true if given address is system account address
+// IsSystemAccountAddress returns true if given address is the default system account address
 func IsSystemAccountAddress(address []byte) bool {
-	if len(address) < numInitCharactersForSystemAccountAddress {
-		return false
-	}
-	return bytes.Equal(address[:numInitCharactersForSystemAccountAddress], SystemAccountAddress[:numInitCharactersForSystemAccountAddress])
+	return DefaultSystemAddresses().IsSystemAccountAddress(address)
 }
 
 // IsSmartContractAddress verifies if a set address is of type smart contract
@@ -90,11 +85,5 @@ func IsSmartContractOnMetachain(identifier []byte, rcvAddress []byte) bool {
 
 // IsAllowedToSaveUnderKey returns if saving key-value in data tries under given key is allowed
 func IsAllowedToSaveUnderKey(key []byte) bool {
-	prefixLen := len(core.ProtectedKeyPrefix)
-	if len(key) < prefixLen {
-		return true
-	}
-
-	trimmedKey := key[:prefixLen]
-	return !bytes.Equal(trimmedKey, []byte(core.ProtectedKeyPrefix))
+	return !protectedkeys.IsProtectedKey(key)
 }
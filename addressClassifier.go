@@ -0,0 +1,111 @@
+package vmcommon
+
+import (
+	"bytes"
+
+	"github.com/Reshusk23/sr-me-core/core"
+	"github.com/Reshusk23/sr-me-core/core/sharding"
+)
+
+// DefaultAddressLength is the length of the addresses used on chain
+const DefaultAddressLength = 32
+
+// ArgsAddressClassifier holds the arguments needed to create an address classifier
+type ArgsAddressClassifier struct {
+	AddressLength                 int
+	NumInitCharactersForScAddress int
+}
+
+type addressClassifier struct {
+	addressLength                 int
+	numInitCharactersForScAddress int
+}
+
+// NewAddressClassifier creates a new address classifier
+func NewAddressClassifier(args ArgsAddressClassifier) (*addressClassifier, error) {
+	if args.AddressLength <= 0 {
+		return nil, ErrInvalidAddressLength
+	}
+	if args.NumInitCharactersForScAddress <= VMTypeLen || args.NumInitCharactersForScAddress >= args.AddressLength {
+		return nil, ErrInvalidSCAddressPrefixLength
+	}
+
+	return &addressClassifier{
+		addressLength:                 args.AddressLength,
+		numInitCharactersForScAddress: args.NumInitCharactersForScAddress,
+	}, nil
+}
+
+// NewDefaultAddressClassifier creates an address classifier using the default address length and smart contract prefix
+func NewDefaultAddressClassifier() *addressClassifier {
+	return &addressClassifier{
+		addressLength:                 DefaultAddressLength,
+		numInitCharactersForScAddress: NumInitCharactersForScAddress,
+	}
+}
+
+// IsSmartContract returns true if the address is of type smart contract
+func (ac *addressClassifier) IsSmartContract(address []byte) bool {
+	if len(address) <= ac.numInitCharactersForScAddress {
+		return false
+	}
+
+	if IsEmptyAddress(address) {
+		return true
+	}
+
+	numOfZeros := ac.numInitCharactersForScAddress - VMTypeLen
+	return bytes.Equal(address[:numOfZeros], make([]byte, numOfZeros))
+}
+
+// IsSystemSC returns true if the address is of a system smart contract, deployed on metachain
+func (ac *addressClassifier) IsSystemSC(address []byte) bool {
+	if len(address) <= ac.numInitCharactersForScAddress+numInitCharactersForOnMetachainSC {
+		return false
+	}
+	if !IsMetachainIdentifier(address[len(address)-ShardIdentiferLen:]) {
+		return false
+	}
+	if !ac.IsSmartContract(address) {
+		return false
+	}
+
+	leftSide := address[ac.numInitCharactersForScAddress : ac.numInitCharactersForScAddress+numInitCharactersForOnMetachainSC]
+	return bytes.Equal(leftSide, make([]byte, numInitCharactersForOnMetachainSC))
+}
+
+// IsDCTSystemSC returns true if the address is the DCT system smart contract address
+func (ac *addressClassifier) IsDCTSystemSC(address []byte) bool {
+	return bytes.Equal(address, core.DCTSCAddress)
+}
+
+// IsUserAddress returns true if the address has the configured length and belongs neither to a smart contract
+// nor to the system account
+func (ac *addressClassifier) IsUserAddress(address []byte) bool {
+	if len(address) != ac.addressLength {
+		return false
+	}
+
+	return !ac.IsSmartContract(address) && !IsSystemAccountAddress(address)
+}
+
+// IsEmpty returns true if the address has the configured length and is made only of zeros, as the receiver
+// of a smart contract deploy is
+func (ac *addressClassifier) IsEmpty(address []byte) bool {
+	return len(address) == ac.addressLength && IsEmptyAddress(address)
+}
+
+// ShardOf returns the shard of the address for the provided number of shards, system smart contracts
+// being placed on metachain
+func (ac *addressClassifier) ShardOf(address []byte, numOfShards uint32) uint32 {
+	if ac.IsSystemSC(address) {
+		return core.MetachainShardId
+	}
+
+	return sharding.ComputeShardID(address, numOfShards)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (ac *addressClassifier) IsInterfaceNil() bool {
+	return ac == nil
+}
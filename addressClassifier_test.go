@@ -0,0 +1,66 @@
+package vmcommon
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Reshusk23/sr-me-core/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAddressClassifier(t *testing.T) {
+	t.Parallel()
+
+	ac, err := NewAddressClassifier(ArgsAddressClassifier{AddressLength: 0, NumInitCharactersForScAddress: NumInitCharactersForScAddress})
+	assert.Nil(t, ac)
+	assert.Equal(t, ErrInvalidAddressLength, err)
+
+	ac, err = NewAddressClassifier(ArgsAddressClassifier{AddressLength: 32, NumInitCharactersForScAddress: VMTypeLen})
+	assert.Nil(t, ac)
+	assert.Equal(t, ErrInvalidSCAddressPrefixLength, err)
+
+	ac, err = NewAddressClassifier(ArgsAddressClassifier{AddressLength: 32, NumInitCharactersForScAddress: 32})
+	assert.Nil(t, ac)
+	assert.Equal(t, ErrInvalidSCAddressPrefixLength, err)
+
+	ac, err = NewAddressClassifier(ArgsAddressClassifier{AddressLength: 32, NumInitCharactersForScAddress: NumInitCharactersForScAddress})
+	assert.Nil(t, err)
+	assert.False(t, ac.IsInterfaceNil())
+}
+
+func TestAddressClassifier_Classify(t *testing.T) {
+	t.Parallel()
+
+	ac := NewDefaultAddressClassifier()
+	userAddress := bytes.Repeat([]byte{1}, 32)
+	scAddress := append(make([]byte, NumInitCharactersForScAddress), bytes.Repeat([]byte{1}, 22)...)
+	emptyAddress := make([]byte, 32)
+
+	assert.True(t, ac.IsUserAddress(userAddress))
+	assert.False(t, ac.IsSmartContract(userAddress))
+	assert.False(t, ac.IsUserAddress(userAddress[:20]))
+	assert.False(t, ac.IsUserAddress(SystemAccountAddress))
+
+	assert.True(t, ac.IsSmartContract(scAddress))
+	assert.False(t, ac.IsUserAddress(scAddress))
+	assert.False(t, ac.IsSystemSC(scAddress))
+
+	assert.True(t, ac.IsSystemSC(core.DCTSCAddress))
+	assert.True(t, ac.IsDCTSystemSC(core.DCTSCAddress))
+	assert.False(t, ac.IsDCTSystemSC(scAddress))
+
+	assert.True(t, ac.IsEmpty(emptyAddress))
+	assert.True(t, ac.IsSmartContract(emptyAddress))
+	assert.False(t, ac.IsEmpty(emptyAddress[:20]))
+	assert.False(t, ac.IsEmpty(userAddress))
+}
+
+func TestAddressClassifier_ShardOf(t *testing.T) {
+	t.Parallel()
+
+	ac := NewDefaultAddressClassifier()
+	assert.Equal(t, core.MetachainShardId, ac.ShardOf(core.DCTSCAddress, 3))
+
+	address := bytes.Repeat([]byte{1}, 32)
+	assert.Equal(t, uint32(1), ac.ShardOf(address, 3))
+}
@@ -0,0 +1,155 @@
+package attributes
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// MetadataKey is the key of the metadata, usually an IPFS CID, in the attributes
+	MetadataKey = "metadata"
+	// TagsKey is the key of the comma separated tags in the attributes
+	TagsKey = "tags"
+
+	// IPFSScheme is the scheme which may prefix the metadata CID
+	IPFSScheme = "ipfs://"
+
+	pairsSeparator = ";"
+	keySeparator   = ":"
+	tagsSeparator  = ","
+)
+
+// Attributes holds the decoded attributes of an NFT
+type Attributes struct {
+	Metadata string            `json:"metadata,omitempty"`
+	Tags     []string          `json:"tags,omitempty"`
+	Extra    map[string]string `json:"-"`
+}
+
+// Decode decodes the attributes, either in the conventional "metadata:CID;tags:a,b,c" format or, if the attributes
+// start with '{', in the JSON format. The JSON format only accepts the metadata and tags fields, the other keys of the
+// conventional format being kept in Extra
+func Decode(data []byte) (*Attributes, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		return decodeJSON(trimmed)
+	}
+
+	attributes := &Attributes{}
+	if len(trimmed) == 0 {
+		return attributes, nil
+	}
+
+	seenKeys := make(map[string]struct{})
+	for _, pair := range strings.Split(string(trimmed), pairsSeparator) {
+		if len(strings.TrimSpace(pair)) == 0 {
+			continue
+		}
+
+		key, value, found := strings.Cut(pair, keySeparator)
+		key = strings.TrimSpace(key)
+		if !found || len(key) == 0 {
+			return nil, fmt.Errorf("%w, pair %q", ErrInvalidAttributes, pair)
+		}
+		_, isDuplicated := seenKeys[key]
+		if isDuplicated {
+			return nil, fmt.Errorf("%w, key %q", ErrDuplicatedKey, key)
+		}
+		seenKeys[key] = struct{}{}
+
+		attributes.setValue(key, strings.TrimSpace(value))
+	}
+
+	return attributes, nil
+}
+
+func decodeJSON(data []byte) (*Attributes, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	attributes := &Attributes{}
+	err := decoder.Decode(attributes)
+	if err != nil {
+		return nil, fmt.Errorf("%w, %s", ErrInvalidJSONAttributes, err.Error())
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("%w, trailing data", ErrInvalidJSONAttributes)
+	}
+
+	return attributes, nil
+}
+
+func (a *Attributes) setValue(key string, value string) {
+	switch key {
+	case MetadataKey:
+		a.Metadata = value
+	case TagsKey:
+		a.Tags = splitTags(value)
+	default:
+		if a.Extra == nil {
+			a.Extra = make(map[string]string)
+		}
+		a.Extra[key] = value
+	}
+}
+
+func splitTags(value string) []string {
+	tags := make([]string, 0)
+	for _, tag := range strings.Split(value, tagsSeparator) {
+		tag = strings.TrimSpace(tag)
+		if len(tag) > 0 {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
+
+// Encode encodes the attributes in the conventional format, the metadata and the tags being followed by the extra
+// keys in lexicographic order. Empty values are skipped
+func (a *Attributes) Encode() []byte {
+	pairs := make([]string, 0, len(a.Extra)+2)
+	if len(a.Metadata) > 0 {
+		pairs = append(pairs, MetadataKey+keySeparator+a.Metadata)
+	}
+	if len(a.Tags) > 0 {
+		pairs = append(pairs, TagsKey+keySeparator+strings.Join(a.Tags, tagsSeparator))
+	}
+
+	extraKeys := make([]string, 0, len(a.Extra))
+	for key := range a.Extra {
+		extraKeys = append(extraKeys, key)
+	}
+	sort.Strings(extraKeys)
+	for _, key := range extraKeys {
+		if len(a.Extra[key]) > 0 {
+			pairs = append(pairs, key+keySeparator+a.Extra[key])
+		}
+	}
+
+	return []byte(strings.Join(pairs, pairsSeparator))
+}
+
+// EncodeJSON encodes the metadata and the tags in the JSON format, the extra keys not being part of it
+func (a *Attributes) EncodeJSON() ([]byte, error) {
+	return json.Marshal(a)
+}
+
+// CID returns the IPFS CID from the metadata, without the ipfs:// scheme
+func (a *Attributes) CID() string {
+	return strings.TrimPrefix(a.Metadata, IPFSScheme)
+}
+
+// HasTag returns true if the attributes hold the provided tag
+func (a *Attributes) HasTag(tag string) bool {
+	for _, existing := range a.Tags {
+		if existing == tag {
+			return true
+		}
+	}
+
+	return false
+}
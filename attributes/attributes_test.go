@@ -0,0 +1,112 @@
+package attributes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecode(t *testing.T) {
+	t.Parallel()
+
+	t.Run("conventional format", func(t *testing.T) {
+		t.Parallel()
+
+		attributes, err := Decode([]byte("metadata:ipfs://QmCID;tags:art, music,,photo;edition:1"))
+		require.Nil(t, err)
+		assert.Equal(t, &Attributes{
+			Metadata: "ipfs://QmCID",
+			Tags:     []string{"art", "music", "photo"},
+			Extra:    map[string]string{"edition": "1"},
+		}, attributes)
+		assert.Equal(t, "QmCID", attributes.CID())
+		assert.True(t, attributes.HasTag("music"))
+		assert.False(t, attributes.HasTag("video"))
+	})
+	t.Run("value holding the key separator", func(t *testing.T) {
+		t.Parallel()
+
+		attributes, err := Decode([]byte("metadata:QmCID;url:https://example.com;"))
+		require.Nil(t, err)
+		assert.Equal(t, "QmCID", attributes.CID())
+		assert.Equal(t, "https://example.com", attributes.Extra["url"])
+	})
+	t.Run("empty attributes", func(t *testing.T) {
+		t.Parallel()
+
+		attributes, err := Decode(nil)
+		require.Nil(t, err)
+		assert.Equal(t, &Attributes{}, attributes)
+	})
+	t.Run("invalid pairs should err", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Decode([]byte("metadata:QmCID;tags"))
+		assert.ErrorIs(t, err, ErrInvalidAttributes)
+
+		_, err = Decode([]byte(":value"))
+		assert.ErrorIs(t, err, ErrInvalidAttributes)
+
+		_, err = Decode([]byte("tags:a;tags:b"))
+		assert.ErrorIs(t, err, ErrDuplicatedKey)
+	})
+	t.Run("JSON format", func(t *testing.T) {
+		t.Parallel()
+
+		attributes, err := Decode([]byte(` {"metadata":"QmCID","tags":["art","music"]}`))
+		require.Nil(t, err)
+		assert.Equal(t, &Attributes{
+			Metadata: "QmCID",
+			Tags:     []string{"art", "music"},
+		}, attributes)
+	})
+	t.Run("JSON format should be strict", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Decode([]byte(`{"metadata":"QmCID","edition":1}`))
+		assert.ErrorIs(t, err, ErrInvalidJSONAttributes)
+
+		_, err = Decode([]byte(`{"metadata":"QmCID"}{}`))
+		assert.ErrorIs(t, err, ErrInvalidJSONAttributes)
+
+		_, err = Decode([]byte(`{"tags":"art"}`))
+		assert.ErrorIs(t, err, ErrInvalidJSONAttributes)
+	})
+}
+
+func TestAttributes_Encode(t *testing.T) {
+	t.Parallel()
+
+	attributes := &Attributes{
+		Metadata: "QmCID",
+		Tags:     []string{"art", "music"},
+		Extra:    map[string]string{"edition": "1", "artist": "someone", "empty": ""},
+	}
+	encoded := attributes.Encode()
+	assert.Equal(t, "metadata:QmCID;tags:art,music;artist:someone;edition:1", string(encoded))
+
+	decoded, err := Decode(encoded)
+	require.Nil(t, err)
+	delete(attributes.Extra, "empty")
+	assert.Equal(t, attributes, decoded)
+
+	assert.Empty(t, (&Attributes{}).Encode())
+}
+
+func TestAttributes_EncodeJSON(t *testing.T) {
+	t.Parallel()
+
+	attributes := &Attributes{
+		Metadata: "QmCID",
+		Tags:     []string{"art"},
+		Extra:    map[string]string{"edition": "1"},
+	}
+	encoded, err := attributes.EncodeJSON()
+	require.Nil(t, err)
+	assert.Equal(t, `{"metadata":"QmCID","tags":["art"]}`, string(encoded))
+
+	decoded, err := Decode(encoded)
+	require.Nil(t, err)
+	assert.Equal(t, &Attributes{Metadata: "QmCID", Tags: []string{"art"}}, decoded)
+}
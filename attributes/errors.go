@@ -0,0 +1,12 @@
+package attributes
+
+import "errors"
+
+// ErrInvalidAttributes signals that the attributes are not in the key:value;key:value format
+var ErrInvalidAttributes = errors.New("invalid attributes")
+
+// ErrDuplicatedKey signals that a key appears more than once in the attributes
+var ErrDuplicatedKey = errors.New("duplicated attributes key")
+
+// ErrInvalidJSONAttributes signals that the JSON attributes could not be decoded
+var ErrInvalidJSONAttributes = errors.New("invalid JSON attributes")
@@ -0,0 +1,136 @@
+package benchmarks
+
+import (
+	"context"
+	"sync"
+
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+)
+
+var _ vmcommon.AccountsAdapter = (*InMemoryAccounts)(nil)
+
+// InMemoryAccounts is an accounts adapter keeping all the accounts in a map, without any trie or journal, so the
+// benchmarks only measure the built-in functions and not the state implementation. It is also used by the tools which
+// execute the built-in functions on a state loaded from fixtures
+type InMemoryAccounts struct {
+	mut      sync.RWMutex
+	accounts map[string]*mock.Account
+}
+
+// NewInMemoryAccounts creates an empty in-memory accounts adapter
+func NewInMemoryAccounts(capacity int) *InMemoryAccounts {
+	return &InMemoryAccounts{
+		accounts: make(map[string]*mock.Account, capacity),
+	}
+}
+
+// GetExistingAccount returns the account stored at the provided address
+func (a *InMemoryAccounts) GetExistingAccount(address []byte) (vmcommon.AccountHandler, error) {
+	a.mut.RLock()
+	defer a.mut.RUnlock()
+
+	account, ok := a.accounts[string(address)]
+	if !ok {
+		return nil, ErrAccountNotFound
+	}
+
+	return account, nil
+}
+
+// LoadAccount returns the account stored at the provided address, creating it if it does not exist
+func (a *InMemoryAccounts) LoadAccount(address []byte) (vmcommon.AccountHandler, error) {
+	return a.LoadUserAccount(address), nil
+}
+
+// LoadUserAccount returns the user account stored at the provided address, creating it if it does not exist
+func (a *InMemoryAccounts) LoadUserAccount(address []byte) *mock.Account {
+	a.mut.Lock()
+	defer a.mut.Unlock()
+
+	account, ok := a.accounts[string(address)]
+	if !ok {
+		account = mock.NewUserAccount(address)
+		a.accounts[string(address)] = account
+	}
+
+	return account
+}
+
+// SaveAccount stores the provided account
+func (a *InMemoryAccounts) SaveAccount(account vmcommon.AccountHandler) error {
+	userAccount, ok := account.(*mock.Account)
+	if !ok {
+		return ErrWrongTypeAssertion
+	}
+
+	a.mut.Lock()
+	a.accounts[string(userAccount.AddressBytes())] = userAccount
+	a.mut.Unlock()
+
+	return nil
+}
+
+// RemoveAccount removes the account stored at the provided address
+func (a *InMemoryAccounts) RemoveAccount(address []byte) error {
+	a.mut.Lock()
+	delete(a.accounts, string(address))
+	a.mut.Unlock()
+
+	return nil
+}
+
+// Commit does nothing as the accounts are not backed by a trie
+func (a *InMemoryAccounts) Commit() ([]byte, error) {
+	return nil, nil
+}
+
+// JournalLen returns 0 as the modifications are not journaled
+func (a *InMemoryAccounts) JournalLen() int {
+	return 0
+}
+
+// RevertToSnapshot does nothing as the modifications are not journaled
+func (a *InMemoryAccounts) RevertToSnapshot(_ int) error {
+	return nil
+}
+
+// GetCode returns nil as no account holds code
+func (a *InMemoryAccounts) GetCode(_ []byte) []byte {
+	return nil
+}
+
+// RootHash returns nil as the accounts are not backed by a trie
+func (a *InMemoryAccounts) RootHash() ([]byte, error) {
+	return nil, nil
+}
+
+// Len returns the number of accounts in the state
+func (a *InMemoryAccounts) Len() int {
+	a.mut.RLock()
+	defer a.mut.RUnlock()
+
+	return len(a.accounts)
+}
+
+func (a *InMemoryAccounts) snapshot(ctx context.Context) ([]*vmcommon.AccountSnapshot, error) {
+	a.mut.RLock()
+	defer a.mut.RUnlock()
+
+	snapshots := make([]*vmcommon.AccountSnapshot, 0, len(a.accounts))
+	for _, account := range a.accounts {
+		snapshot, err := vmcommon.NewAccountSnapshot(ctx, account)
+		if err != nil {
+			return nil, err
+		}
+
+		snapshots = append(snapshots, snapshot)
+	}
+
+	return snapshots, nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (a *InMemoryAccounts) IsInterfaceNil() bool {
+	return a == nil
+}
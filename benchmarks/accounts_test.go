@@ -0,0 +1,37 @@
+package benchmarks
+
+import (
+	"testing"
+
+	"github.com/Reshusk23/sr-me-core/core/check"
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInMemoryAccounts(t *testing.T) {
+	t.Parallel()
+
+	accounts := NewInMemoryAccounts(1)
+	assert.False(t, check.IfNil(accounts))
+
+	address := []byte("address")
+	account, err := accounts.GetExistingAccount(address)
+	assert.Nil(t, account)
+	assert.Equal(t, ErrAccountNotFound, err)
+
+	loaded, err := accounts.LoadAccount(address)
+	assert.Nil(t, err)
+	account, err = accounts.GetExistingAccount(address)
+	assert.Nil(t, err)
+	assert.True(t, account == loaded)
+
+	err = accounts.SaveAccount(&mock.AccountWrapMock{})
+	assert.Equal(t, ErrWrongTypeAssertion, err)
+	err = accounts.SaveAccount(mock.NewUserAccount([]byte("other")))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, accounts.Len())
+
+	err = accounts.RemoveAccount(address)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, accounts.Len())
+}
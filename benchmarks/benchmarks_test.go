@@ -0,0 +1,188 @@
+package benchmarks
+
+import (
+	"encoding/hex"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Reshusk23/sr-me-core/core"
+	"github.com/Reshusk23/sr-me-core/marshal"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	datafield "github.com/Reshusk23/sr-vm-common-go/parsers/dataField"
+)
+
+const (
+	benchmarkNumAccounts      = 1_000_000
+	benchmarkNumAccountsShort = 10_000
+	benchmarkWorkloadSize     = 10_000
+	benchmarkSeed             = 1
+)
+
+var (
+	benchmarkState     *State
+	benchmarkStateErr  error
+	benchmarkStateOnce sync.Once
+)
+
+// getBenchmarkState returns the state shared by all the benchmarks, created once as creating a million accounts
+// takes a few seconds. Running the benchmarks with -short uses a smaller state
+func getBenchmarkState(b *testing.B) *State {
+	benchmarkStateOnce.Do(func() {
+		numAccounts := benchmarkNumAccounts
+		if testing.Short() {
+			numAccounts = benchmarkNumAccountsShort
+		}
+
+		benchmarkState, benchmarkStateErr = NewState(ArgsNewState{
+			NumAccounts:    numAccounts,
+			InitialBalance: big.NewInt(0).Exp(big.NewInt(10), big.NewInt(30), nil),
+		})
+	})
+	if benchmarkStateErr != nil {
+		b.Fatal(benchmarkStateErr)
+	}
+
+	return benchmarkState
+}
+
+func executeOrFatal(b *testing.B, state *State, call *Call) *vmcommon.VMOutput {
+	vmOutput, err := state.Execute(call)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	return vmOutput
+}
+
+func createNFTOrFatal(b *testing.B, state *State, account int, quantity int64) uint64 {
+	vmOutput := executeOrFatal(b, state, state.NFTCreateCall(account, quantity))
+
+	return big.NewInt(0).SetBytes(vmOutput.ReturnData[0]).Uint64()
+}
+
+func runCall(b *testing.B, state *State, call *Call) {
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		vmOutput := executeOrFatal(b, state, call)
+		vmcommon.ReleaseVMOutput(vmOutput)
+	}
+}
+
+func BenchmarkBuiltInFunctions(b *testing.B) {
+	state := getBenchmarkState(b)
+	lastAccount := state.NumAccounts() - 1
+
+	b.Run(core.BuiltInFunctionDCTTransfer, func(b *testing.B) {
+		runCall(b, state, state.TransferCall(0, lastAccount, 1))
+	})
+	b.Run(core.BuiltInFunctionDCTLocalMint, func(b *testing.B) {
+		runCall(b, state, state.LocalMintCall(1, 1))
+	})
+	b.Run(core.BuiltInFunctionDCTLocalBurn, func(b *testing.B) {
+		runCall(b, state, state.LocalBurnCall(2, 1))
+	})
+	b.Run(core.BuiltInFunctionDCTNFTCreate, func(b *testing.B) {
+		runCall(b, state, state.NFTCreateCall(3, 1))
+	})
+	b.Run(core.BuiltInFunctionDCTNFTAddQuantity, func(b *testing.B) {
+		nonce := createNFTOrFatal(b, state, 4, 1)
+		runCall(b, state, state.NFTAddQuantityCall(4, nonce, 1))
+	})
+	b.Run(core.BuiltInFunctionDCTNFTBurn, func(b *testing.B) {
+		nonce := createNFTOrFatal(b, state, 5, int64(b.N)+1)
+		runCall(b, state, state.NFTBurnCall(5, nonce, 1))
+	})
+	b.Run(core.BuiltInFunctionDCTNFTTransfer, func(b *testing.B) {
+		nonce := createNFTOrFatal(b, state, 6, int64(b.N)+1)
+		runCall(b, state, state.NFTTransferCall(6, lastAccount, nonce, 1))
+	})
+	b.Run(core.BuiltInFunctionMultiDCTNFTTransfer, func(b *testing.B) {
+		nonce := createNFTOrFatal(b, state, 7, int64(b.N)+1)
+		runCall(b, state, state.MultiTransferCall(7, lastAccount, 1, nonce, 1))
+	})
+}
+
+// BenchmarkBuiltInFunctions_InvalidInput measures, for every function of the container, the cost of rejecting a call
+// without arguments, which bounds the cost an attacker can impose with malformed transactions
+func BenchmarkBuiltInFunctions_InvalidInput(b *testing.B) {
+	state := getBenchmarkState(b)
+
+	keys := make([]string, 0, state.Container().Len())
+	for key := range state.Container().Keys() {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		call := state.newCall(key, 0, state.Address(0))
+		b.Run(key, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, _ = state.Execute(call)
+			}
+		})
+	}
+}
+
+func BenchmarkMixedTraffic(b *testing.B) {
+	state := getBenchmarkState(b)
+	calls := state.NewMixedWorkload(benchmarkWorkloadSize, DefaultWorkloadMix, benchmarkSeed)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		vmOutput := executeOrFatal(b, state, calls[i%len(calls)])
+		vmcommon.ReleaseVMOutput(vmOutput)
+	}
+}
+
+func createDataFields(state *State) [][]byte {
+	encode := func(parts ...[]byte) []byte {
+		encodedParts := make([]string, 0, len(parts))
+		for _, part := range parts {
+			encodedParts = append(encodedParts, hex.EncodeToString(part))
+		}
+
+		return []byte(strings.Join(encodedParts, "@"))
+	}
+	withFunction := func(function string, parts ...[]byte) []byte {
+		return append([]byte(function+"@"), encode(parts...)...)
+	}
+
+	receiver := state.Address(1)
+	return [][]byte{
+		[]byte("a plain text message"),
+		withFunction(core.BuiltInFunctionDCTTransfer, []byte(FungibleTokenID), big.NewInt(1000).Bytes()),
+		withFunction(core.BuiltInFunctionDCTTransfer, []byte(FungibleTokenID), big.NewInt(1000).Bytes(), []byte("swap"), big.NewInt(5).Bytes()),
+		withFunction(core.BuiltInFunctionDCTNFTTransfer, []byte(NFTTokenID), big.NewInt(1).Bytes(), big.NewInt(1).Bytes(), receiver),
+		withFunction(core.BuiltInFunctionMultiDCTNFTTransfer, receiver, big.NewInt(2).Bytes(),
+			[]byte(FungibleTokenID), big.NewInt(0).Bytes(), big.NewInt(10).Bytes(),
+			[]byte(NFTTokenID), big.NewInt(1).Bytes(), big.NewInt(1).Bytes()),
+		withFunction("claimRewards", big.NewInt(7).Bytes()),
+	}
+}
+
+func BenchmarkDataFieldParser(b *testing.B) {
+	state := getBenchmarkState(b)
+	parser, err := datafield.NewOperationDataFieldParser(&datafield.ArgsOperationDataFieldParser{
+		AddressLength: addressLength,
+		Marshalizer:   &marshal.GogoProtoMarshalizer{},
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	dataFields := createDataFields(state)
+	sender := state.Address(0)
+	receiver := state.Address(1)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = parser.Parse(dataFields[i%len(dataFields)], sender, receiver, 3)
+	}
+}
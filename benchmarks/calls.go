@@ -0,0 +1,136 @@
+package benchmarks
+
+import (
+	"math/big"
+
+	"github.com/Reshusk23/sr-me-core/core"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+)
+
+const gasProvided = 1_000_000_000
+
+// Call holds a built-in function call together with its input
+type Call struct {
+	Function string
+	Input    *vmcommon.ContractCallInput
+}
+
+func (s *State) newCall(function string, sender int, recipient []byte, arguments ...[]byte) *Call {
+	return &Call{
+		Function: function,
+		Input: &vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallerAddr:  s.Address(sender),
+				Arguments:   arguments,
+				CallValue:   big.NewInt(0),
+				GasProvided: gasProvided,
+			},
+			RecipientAddr: recipient,
+			Function:      function,
+		},
+	}
+}
+
+// TransferCall creates a call transferring the provided value of the fungible token between two accounts
+func (s *State) TransferCall(sender int, receiver int, value int64) *Call {
+	return s.newCall(
+		core.BuiltInFunctionDCTTransfer,
+		sender,
+		s.Address(receiver),
+		[]byte(FungibleTokenID),
+		big.NewInt(value).Bytes(),
+	)
+}
+
+// LocalMintCall creates a call minting the provided value of the fungible token
+func (s *State) LocalMintCall(account int, value int64) *Call {
+	return s.newCall(
+		core.BuiltInFunctionDCTLocalMint,
+		account,
+		s.Address(account),
+		[]byte(FungibleTokenID),
+		big.NewInt(value).Bytes(),
+	)
+}
+
+// LocalBurnCall creates a call burning the provided value of the fungible token
+func (s *State) LocalBurnCall(account int, value int64) *Call {
+	return s.newCall(
+		core.BuiltInFunctionDCTLocalBurn,
+		account,
+		s.Address(account),
+		[]byte(FungibleTokenID),
+		big.NewInt(value).Bytes(),
+	)
+}
+
+// NFTCreateCall creates a call creating a new nonce of the NFT collection with the provided quantity
+func (s *State) NFTCreateCall(account int, quantity int64) *Call {
+	return s.newCall(
+		core.BuiltInFunctionDCTNFTCreate,
+		account,
+		s.Address(account),
+		[]byte(NFTTokenID),
+		big.NewInt(quantity).Bytes(),
+		[]byte("name"),
+		big.NewInt(500).Bytes(),
+		[]byte("hash"),
+		[]byte("metadata:ipfsCID/1.json;tags:bench"),
+		[]byte("https://ipfs.io/ipfs/ipfsCID/1.png"),
+	)
+}
+
+// NFTAddQuantityCall creates a call adding the provided quantity to an NFT nonce
+func (s *State) NFTAddQuantityCall(account int, nonce uint64, quantity int64) *Call {
+	return s.newCall(
+		core.BuiltInFunctionDCTNFTAddQuantity,
+		account,
+		s.Address(account),
+		[]byte(NFTTokenID),
+		big.NewInt(0).SetUint64(nonce).Bytes(),
+		big.NewInt(quantity).Bytes(),
+	)
+}
+
+// NFTBurnCall creates a call burning the provided quantity of an NFT nonce
+func (s *State) NFTBurnCall(account int, nonce uint64, quantity int64) *Call {
+	return s.newCall(
+		core.BuiltInFunctionDCTNFTBurn,
+		account,
+		s.Address(account),
+		[]byte(NFTTokenID),
+		big.NewInt(0).SetUint64(nonce).Bytes(),
+		big.NewInt(quantity).Bytes(),
+	)
+}
+
+// NFTTransferCall creates a call transferring the provided quantity of an NFT nonce between two accounts
+func (s *State) NFTTransferCall(sender int, receiver int, nonce uint64, quantity int64) *Call {
+	return s.newCall(
+		core.BuiltInFunctionDCTNFTTransfer,
+		sender,
+		s.Address(sender),
+		[]byte(NFTTokenID),
+		big.NewInt(0).SetUint64(nonce).Bytes(),
+		big.NewInt(quantity).Bytes(),
+		s.Address(receiver),
+	)
+}
+
+// MultiTransferCall creates a call transferring, in a single multi transfer, the provided value of the fungible token
+// and the provided quantity of an NFT nonce between two accounts
+func (s *State) MultiTransferCall(sender int, receiver int, value int64, nonce uint64, quantity int64) *Call {
+	return s.newCall(
+		core.BuiltInFunctionMultiDCTNFTTransfer,
+		sender,
+		s.Address(sender),
+		s.Address(receiver),
+		big.NewInt(2).Bytes(),
+		[]byte(FungibleTokenID),
+		big.NewInt(0).Bytes(),
+		big.NewInt(value).Bytes(),
+		[]byte(NFTTokenID),
+		big.NewInt(0).SetUint64(nonce).Bytes(),
+		big.NewInt(quantity).Bytes(),
+	)
+}
@@ -0,0 +1,12 @@
+package benchmarks
+
+import "errors"
+
+// ErrAccountNotFound signals that the requested account does not exist in the state
+var ErrAccountNotFound = errors.New("account not found")
+
+// ErrWrongTypeAssertion signals that a wrong type assertion occurred
+var ErrWrongTypeAssertion = errors.New("wrong type assertion")
+
+// ErrInvalidNumberOfAccounts signals that an invalid number of accounts has been provided
+var ErrInvalidNumberOfAccounts = errors.New("invalid number of accounts")
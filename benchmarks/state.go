@@ -0,0 +1,188 @@
+package benchmarks
+
+import (
+	"context"
+	"encoding/binary"
+	"math/big"
+	"reflect"
+
+	"github.com/Reshusk23/sr-me-core/core"
+	"github.com/Reshusk23/sr-me-core/core/check"
+	"github.com/Reshusk23/sr-me-core/data/dct"
+	"github.com/Reshusk23/sr-me-core/marshal"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/builtInFunctions"
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/Reshusk23/sr-vm-common-go/protectedkeys"
+)
+
+const (
+	// FungibleTokenID is the fungible token held by all the accounts of the state
+	FungibleTokenID = "BENCH-abcdef"
+	// NFTTokenID is the collection on which all the accounts of the state hold the NFT roles
+	NFTTokenID = "BENCHNFT-abcdef"
+
+	addressLength = 32
+	gasPerUnit    = 1
+)
+
+// ArgsNewState holds the arguments needed to create a benchmarks state. EnableEpochsHandler is optional, when missing
+// all the flags are disabled
+type ArgsNewState struct {
+	NumAccounts         int
+	InitialBalance      *big.Int
+	EnableEpochsHandler vmcommon.EnableEpochsHandler
+}
+
+// State holds an in-memory accounts state in which every account holds a balance of the fungible token and the
+// local roles on both the fungible token and the NFT collection, together with the built-in functions operating on it
+type State struct {
+	accounts    *InMemoryAccounts
+	container   vmcommon.BuiltInFunctionContainer
+	numAccounts int
+}
+
+// NewState creates the accounts and the built-in functions container of a benchmarks state
+func NewState(args ArgsNewState) (*State, error) {
+	if args.NumAccounts <= 0 {
+		return nil, ErrInvalidNumberOfAccounts
+	}
+	enableEpochsHandler := args.EnableEpochsHandler
+	if check.IfNil(enableEpochsHandler) {
+		enableEpochsHandler = &mock.EnableEpochsHandlerStub{}
+	}
+	initialBalance := args.InitialBalance
+	if initialBalance == nil {
+		initialBalance = big.NewInt(0)
+	}
+
+	s := &State{
+		accounts:    NewInMemoryAccounts(args.NumAccounts),
+		numAccounts: args.NumAccounts,
+	}
+
+	marshaller := &marshal.GogoProtoMarshalizer{}
+	err := s.createAccounts(marshaller, initialBalance)
+	if err != nil {
+		return nil, err
+	}
+
+	creator, err := builtInFunctions.NewBuiltInFunctionsCreator(builtInFunctions.ArgsCreateBuiltInFunctionContainer{
+		GasMap:                           CreateGasMap(gasPerUnit),
+		MapDNSAddresses:                  make(map[string]struct{}),
+		Marshalizer:                      marshaller,
+		Accounts:                         s.accounts,
+		ShardCoordinator:                 mock.NewMultiShardsCoordinatorMock(1),
+		EnableEpochsHandler:              enableEpochsHandler,
+		MaxNumOfAddressesForTransferRole: 100,
+		ChainID:                          []byte("local"),
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = creator.CreateBuiltInFunctionContainer()
+	if err != nil {
+		return nil, err
+	}
+	// the accounts of the state are all user accounts, hence payable
+	err = creator.SetPayableHandler(&mock.PayableHandlerStub{})
+	if err != nil {
+		return nil, err
+	}
+	s.container = creator.BuiltInFunctionContainer()
+
+	return s, nil
+}
+
+func (s *State) createAccounts(marshaller marshal.Marshalizer, initialBalance *big.Int) error {
+	balance, err := marshaller.Marshal(&dct.DCToken{Value: initialBalance})
+	if err != nil {
+		return err
+	}
+	fungibleRoles, err := marshaller.Marshal(&dct.DCTRoles{
+		Roles: [][]byte{[]byte(core.DCTRoleLocalMint), []byte(core.DCTRoleLocalBurn)},
+	})
+	if err != nil {
+		return err
+	}
+	nftRoles, err := marshaller.Marshal(&dct.DCTRoles{
+		Roles: [][]byte{[]byte(core.DCTRoleNFTCreate), []byte(core.DCTRoleNFTAddQuantity), []byte(core.DCTRoleNFTBurn)},
+	})
+	if err != nil {
+		return err
+	}
+
+	balanceKey := []byte(protectedkeys.DCTPrefix + FungibleTokenID)
+	fungibleRolesKey := []byte(protectedkeys.DCTRolePrefix + FungibleTokenID)
+	nftRolesKey := []byte(protectedkeys.DCTRolePrefix + NFTTokenID)
+	for i := 0; i < s.numAccounts; i++ {
+		account := s.accounts.LoadUserAccount(s.Address(i))
+		account.Storage[string(balanceKey)] = balance
+		account.Storage[string(fungibleRolesKey)] = fungibleRoles
+		account.Storage[string(nftRolesKey)] = nftRoles
+	}
+
+	return nil
+}
+
+// CreateGasMap creates a gas schedule in which all the costs are set to the provided value
+func CreateGasMap(value uint64) map[string]map[string]uint64 {
+	return map[string]map[string]uint64{
+		core.BaseOperationCostString: createGasMapForStruct(vmcommon.BaseOperationCost{}, value),
+		core.BuiltInCostString:       createGasMapForStruct(vmcommon.BuiltInCost{}, value),
+	}
+}
+
+func createGasMapForStruct(costs interface{}, value uint64) map[string]uint64 {
+	costsType := reflect.TypeOf(costs)
+	gasMap := make(map[string]uint64, costsType.NumField())
+	for i := 0; i < costsType.NumField(); i++ {
+		gasMap[costsType.Field(i).Name] = value
+	}
+
+	return gasMap
+}
+
+// Address returns the address of the account at the provided index
+func (s *State) Address(index int) []byte {
+	address := make([]byte, addressLength)
+	// a non-zero first byte keeps the addresses out of the smart contract address space
+	address[0] = 1
+	binary.BigEndian.PutUint64(address[addressLength-8:], uint64(index))
+
+	return address
+}
+
+// NumAccounts returns the number of accounts created in the state
+func (s *State) NumAccounts() int {
+	return s.numAccounts
+}
+
+// Accounts returns the accounts adapter holding the state
+func (s *State) Accounts() vmcommon.AccountsAdapter {
+	return s.accounts
+}
+
+// Container returns the built-in functions operating on the state
+func (s *State) Container() vmcommon.BuiltInFunctionContainer {
+	return s.container
+}
+
+// Snapshot copies all the accounts of the state, so the output accounts of the calls executed afterwards can be
+// computed with vmcommon.ComputeOutputAccounts
+func (s *State) Snapshot(ctx context.Context) ([]*vmcommon.AccountSnapshot, error) {
+	return s.accounts.snapshot(ctx)
+}
+
+// Execute loads the caller and the recipient accounts of the provided call and processes it, as a node would
+func (s *State) Execute(call *Call) (*vmcommon.VMOutput, error) {
+	function, err := s.container.Get(call.Function)
+	if err != nil {
+		return nil, err
+	}
+
+	acntSnd := s.accounts.LoadUserAccount(call.Input.CallerAddr)
+	acntDst := s.accounts.LoadUserAccount(call.Input.RecipientAddr)
+
+	return function.ProcessBuiltinFunction(acntSnd, acntDst, call.Input)
+}
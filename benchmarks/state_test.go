@@ -0,0 +1,123 @@
+package benchmarks
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/Reshusk23/sr-me-core/data/dct"
+	"github.com/Reshusk23/sr-me-core/marshal"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/Reshusk23/sr-vm-common-go/protectedkeys"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getBalance(t *testing.T, state *State, index int) *big.Int {
+	account, err := state.Accounts().GetExistingAccount(state.Address(index))
+	require.Nil(t, err)
+
+	marshaledData, _, err := account.(vmcommon.UserAccountHandler).AccountDataHandler().RetrieveValue([]byte(protectedkeys.DCTPrefix + FungibleTokenID))
+	require.Nil(t, err)
+	token := &dct.DCToken{}
+	require.Nil(t, (&marshal.GogoProtoMarshalizer{}).Unmarshal(token, marshaledData))
+
+	return token.Value
+}
+
+func TestNewState(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid number of accounts should err", func(t *testing.T) {
+		t.Parallel()
+
+		state, err := NewState(ArgsNewState{})
+		assert.Nil(t, state)
+		assert.Equal(t, ErrInvalidNumberOfAccounts, err)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		state, err := NewState(ArgsNewState{
+			NumAccounts:         10,
+			InitialBalance:      big.NewInt(1000),
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+		})
+		require.Nil(t, err)
+		assert.Equal(t, 10, state.NumAccounts())
+		assert.Equal(t, 10, state.accounts.Len())
+		assert.NotEqual(t, state.Address(1), state.Address(2))
+		assert.Equal(t, big.NewInt(1000), getBalance(t, state, 9))
+		assert.NotZero(t, state.Container().Len())
+	})
+}
+
+func TestState_Execute(t *testing.T) {
+	t.Parallel()
+
+	state, err := NewState(ArgsNewState{
+		NumAccounts:    3,
+		InitialBalance: big.NewInt(1000),
+	})
+	require.Nil(t, err)
+
+	execute := func(call *Call) *vmcommon.VMOutput {
+		vmOutput, errExecute := state.Execute(call)
+		require.Nil(t, errExecute)
+		require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+
+		return vmOutput
+	}
+
+	execute(state.TransferCall(0, 1, 100))
+	assert.Equal(t, big.NewInt(900), getBalance(t, state, 0))
+	assert.Equal(t, big.NewInt(1100), getBalance(t, state, 1))
+
+	execute(state.LocalMintCall(2, 50))
+	execute(state.LocalBurnCall(2, 20))
+	assert.Equal(t, big.NewInt(1030), getBalance(t, state, 2))
+
+	vmOutput := execute(state.NFTCreateCall(0, 10))
+	nonce := big.NewInt(0).SetBytes(vmOutput.ReturnData[0]).Uint64()
+	assert.Equal(t, uint64(1), nonce)
+	execute(state.NFTAddQuantityCall(0, nonce, 5))
+	execute(state.NFTBurnCall(0, nonce, 3))
+	execute(state.NFTTransferCall(0, 1, nonce, 2))
+	execute(state.MultiTransferCall(0, 2, 10, nonce, 2))
+	assert.Equal(t, big.NewInt(890), getBalance(t, state, 0))
+	assert.Equal(t, big.NewInt(1040), getBalance(t, state, 2))
+}
+
+func TestState_Snapshot(t *testing.T) {
+	t.Parallel()
+
+	state, err := NewState(ArgsNewState{
+		NumAccounts:    3,
+		InitialBalance: big.NewInt(1000),
+	})
+	require.Nil(t, err)
+
+	pre, err := state.Snapshot(context.Background())
+	require.Nil(t, err)
+	assert.Equal(t, 3, len(pre))
+
+	vmOutput, err := state.Execute(state.TransferCall(0, 1, 100))
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+
+	post, err := state.Snapshot(context.Background())
+	require.Nil(t, err)
+
+	outputAccounts, err := vmcommon.ComputeOutputAccounts(pre, post)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(outputAccounts))
+
+	tokenKey := protectedkeys.DCTPrefix + FungibleTokenID
+	for _, index := range []int{0, 1} {
+		outputAccount := outputAccounts[string(state.Address(index))]
+		require.NotNil(t, outputAccount)
+		require.Equal(t, 1, len(outputAccount.StorageUpdates))
+		assert.NotNil(t, outputAccount.StorageUpdates[tokenKey])
+	}
+}
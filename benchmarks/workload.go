@@ -0,0 +1,41 @@
+package benchmarks
+
+import (
+	"math/rand"
+)
+
+// WorkloadMix defines the share, in percents, of each kind of call in a mixed workload. The percents not assigned
+// to transfers or creates are assigned to burns
+type WorkloadMix struct {
+	TransferPercent int
+	CreatePercent   int
+}
+
+// DefaultWorkloadMix is the mix of a regular block: mostly transfers, some NFT creates and a few burns
+var DefaultWorkloadMix = WorkloadMix{
+	TransferPercent: 80,
+	CreatePercent:   15,
+}
+
+// NewMixedWorkload creates the provided number of calls between random accounts of the state, following the provided
+// mix. The same seed always creates the same workload so the results of different runs are comparable
+func (s *State) NewMixedWorkload(numCalls int, mix WorkloadMix, seed int64) []*Call {
+	randomizer := rand.New(rand.NewSource(seed))
+
+	calls := make([]*Call, 0, numCalls)
+	for i := 0; i < numCalls; i++ {
+		sender := randomizer.Intn(s.numAccounts)
+		kind := randomizer.Intn(100)
+
+		switch {
+		case kind < mix.TransferPercent:
+			calls = append(calls, s.TransferCall(sender, randomizer.Intn(s.numAccounts), 1+randomizer.Int63n(100)))
+		case kind < mix.TransferPercent+mix.CreatePercent:
+			calls = append(calls, s.NFTCreateCall(sender, 1))
+		default:
+			calls = append(calls, s.LocalBurnCall(sender, 1))
+		}
+	}
+
+	return calls
+}
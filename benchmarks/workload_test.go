@@ -0,0 +1,33 @@
+package benchmarks
+
+import (
+	"testing"
+
+	"github.com/Reshusk23/sr-me-core/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestState_NewMixedWorkload(t *testing.T) {
+	t.Parallel()
+
+	state, err := NewState(ArgsNewState{NumAccounts: 100})
+	require.Nil(t, err)
+
+	calls := state.NewMixedWorkload(1000, DefaultWorkloadMix, 7)
+	require.Len(t, calls, 1000)
+	assert.Equal(t, calls, state.NewMixedWorkload(1000, DefaultWorkloadMix, 7))
+
+	numCallsPerFunction := make(map[string]int)
+	for _, call := range calls {
+		numCallsPerFunction[call.Function]++
+	}
+	assert.Len(t, numCallsPerFunction, 3)
+	assert.Greater(t, numCallsPerFunction[core.BuiltInFunctionDCTTransfer], numCallsPerFunction[core.BuiltInFunctionDCTNFTCreate])
+	assert.Greater(t, numCallsPerFunction[core.BuiltInFunctionDCTNFTCreate], numCallsPerFunction[core.BuiltInFunctionDCTLocalBurn])
+
+	calls = state.NewMixedWorkload(100, WorkloadMix{TransferPercent: 100}, 7)
+	for _, call := range calls {
+		assert.Equal(t, core.BuiltInFunctionDCTTransfer, call.Function)
+	}
+}
@@ -0,0 +1,233 @@
+package builtInFunctions
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/Reshusk23/sr-me-core/core/check"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+)
+
+// accountCache keeps the most recently loaded accounts of the current round, evicting the least recently used one
+// once its capacity is reached. It is emptied on each new round and kept consistent with the account state by the
+// accounts adapter it is bound to, see NewAccountsAdapterWithAccountCache
+type accountCache struct {
+	mutAccounts sync.Mutex
+	capacity    int
+	accounts    map[string]*list.Element
+	lru         *list.List
+}
+
+// NewAccountCache creates a new LRU account cache holding at most capacity accounts, emptied on each confirmed round
+func NewAccountCache(capacity int, roundNotifier vmcommon.RoundNotifier) (*accountCache, error) {
+	if capacity <= 0 {
+		return nil, ErrInvalidAccountCacheCapacity
+	}
+	if check.IfNil(roundNotifier) {
+		return nil, ErrNilRoundNotifier
+	}
+
+	cache := &accountCache{
+		capacity: capacity,
+		accounts: make(map[string]*list.Element, capacity),
+		lru:      list.New(),
+	}
+	roundNotifier.RegisterRoundHandler(cache)
+
+	return cache, nil
+}
+
+// Get returns the cached account for the given address, marking it as the most recently used
+func (cache *accountCache) Get(address []byte) (vmcommon.UserAccountHandler, bool) {
+	cache.mutAccounts.Lock()
+	defer cache.mutAccounts.Unlock()
+
+	element, found := cache.accounts[string(address)]
+	if !found {
+		return nil, false
+	}
+
+	cache.lru.MoveToFront(element)
+	return element.Value.(vmcommon.UserAccountHandler), true
+}
+
+// Put saves the account, evicting the least recently used account if the cache is full
+func (cache *accountCache) Put(account vmcommon.UserAccountHandler) {
+	if check.IfNil(account) {
+		return
+	}
+
+	cache.mutAccounts.Lock()
+	defer cache.mutAccounts.Unlock()
+
+	address := string(account.AddressBytes())
+	element, found := cache.accounts[address]
+	if found {
+		element.Value = account
+		cache.lru.MoveToFront(element)
+		return
+	}
+
+	if cache.lru.Len() >= cache.capacity {
+		oldest := cache.lru.Back()
+		cache.lru.Remove(oldest)
+		delete(cache.accounts, string(oldest.Value.(vmcommon.UserAccountHandler).AddressBytes()))
+	}
+	cache.accounts[address] = cache.lru.PushFront(account)
+}
+
+// Remove evicts the account of the given address
+func (cache *accountCache) Remove(address []byte) {
+	cache.mutAccounts.Lock()
+	defer cache.mutAccounts.Unlock()
+
+	element, found := cache.accounts[string(address)]
+	if !found {
+		return
+	}
+
+	cache.lru.Remove(element)
+	delete(cache.accounts, string(address))
+}
+
+// Clear evicts all the cached accounts
+func (cache *accountCache) Clear() {
+	cache.mutAccounts.Lock()
+	cache.accounts = make(map[string]*list.Element, cache.capacity)
+	cache.lru.Init()
+	cache.mutAccounts.Unlock()
+}
+
+// Len returns the number of cached accounts
+func (cache *accountCache) Len() int {
+	cache.mutAccounts.Lock()
+	defer cache.mutAccounts.Unlock()
+
+	return cache.lru.Len()
+}
+
+// RoundConfirmed is called whenever a new round is started and empties the cache
+func (cache *accountCache) RoundConfirmed(_ uint64, _ uint64) {
+	cache.Clear()
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (cache *accountCache) IsInterfaceNil() bool {
+	return cache == nil
+}
+
+// cacheBoundAccountsAdapter is implemented by the accounts adapters keeping a cache consistent with the state, so the
+// functions accepting a cache can check it is bound to their accounts adapter. The adapters can wrap each other
+type cacheBoundAccountsAdapter interface {
+	isBoundTo(cache interface{}) bool
+}
+
+func isCacheBoundToAccounts(accounts vmcommon.AccountsAdapter, cache interface{}) bool {
+	boundAccounts, ok := accounts.(cacheBoundAccountsAdapter)
+	return ok && boundAccounts.isBoundTo(cache)
+}
+
+// accountsAdapterWithAccountCache keeps an account cache consistent with the wrapped accounts adapter: the saved
+// accounts replace the cached ones, the removed accounts are evicted and the whole cache is emptied on commit and on
+// revert, as the cached instances might no longer match the state
+type accountsAdapterWithAccountCache struct {
+	vmcommon.AccountsAdapter
+	cache vmcommon.AccountCache
+}
+
+// NewAccountsAdapterWithAccountCache binds the account cache to the accounts adapter. All the account changes have to
+// go through the returned adapter, otherwise the cache can return stale accounts
+func NewAccountsAdapterWithAccountCache(
+	accounts vmcommon.AccountsAdapter,
+	cache vmcommon.AccountCache,
+) (*accountsAdapterWithAccountCache, error) {
+	if check.IfNil(accounts) {
+		return nil, ErrNilAccountsAdapter
+	}
+	if check.IfNil(cache) {
+		return nil, ErrNilAccountCache
+	}
+
+	return &accountsAdapterWithAccountCache{
+		AccountsAdapter: accounts,
+		cache:           cache,
+	}, nil
+}
+
+// SaveAccount saves the account and replaces the cached instance, if any
+func (adapter *accountsAdapterWithAccountCache) SaveAccount(account vmcommon.AccountHandler) error {
+	err := adapter.AccountsAdapter.SaveAccount(account)
+	if check.IfNil(account) {
+		return err
+	}
+	if err != nil {
+		adapter.cache.Remove(account.AddressBytes())
+		return err
+	}
+
+	_, isCached := adapter.cache.Get(account.AddressBytes())
+	if !isCached {
+		return nil
+	}
+
+	userAccount, ok := account.(vmcommon.UserAccountHandler)
+	if !ok {
+		adapter.cache.Remove(account.AddressBytes())
+		return nil
+	}
+	adapter.cache.Put(userAccount)
+
+	return nil
+}
+
+// RemoveAccount removes the account and evicts it from the cache
+func (adapter *accountsAdapterWithAccountCache) RemoveAccount(address []byte) error {
+	adapter.cache.Remove(address)
+	return adapter.AccountsAdapter.RemoveAccount(address)
+}
+
+// Commit commits the state and empties the cache
+func (adapter *accountsAdapterWithAccountCache) Commit() ([]byte, error) {
+	adapter.cache.Clear()
+	return adapter.AccountsAdapter.Commit()
+}
+
+// RevertToSnapshot reverts the state and empties the cache
+func (adapter *accountsAdapterWithAccountCache) RevertToSnapshot(snapshot int) error {
+	adapter.cache.Clear()
+	return adapter.AccountsAdapter.RevertToSnapshot(snapshot)
+}
+
+func (adapter *accountsAdapterWithAccountCache) isBoundTo(cache interface{}) bool {
+	return adapter.cache == cache || isCacheBoundToAccounts(adapter.AccountsAdapter, cache)
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (adapter *accountsAdapterWithAccountCache) IsInterfaceNil() bool {
+	return adapter == nil
+}
+
+type disabledAccountCache struct {
+}
+
+// Get returns false as nothing is cached
+func (d *disabledAccountCache) Get(_ []byte) (vmcommon.UserAccountHandler, bool) {
+	return nil, false
+}
+
+// Put does nothing
+func (d *disabledAccountCache) Put(_ vmcommon.UserAccountHandler) {
+}
+
+// Remove does nothing
+func (d *disabledAccountCache) Remove(_ []byte) {
+}
+
+// Clear does nothing
+func (d *disabledAccountCache) Clear() {
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (d *disabledAccountCache) IsInterfaceNil() bool {
+	return d == nil
+}
@@ -0,0 +1,196 @@
+package builtInFunctions
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Reshusk23/sr-me-core/core/check"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAccountCache(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid capacity should error", func(t *testing.T) {
+		t.Parallel()
+
+		cache, err := NewAccountCache(0, &mock.RoundNotifierStub{})
+		assert.True(t, check.IfNil(cache))
+		assert.Equal(t, ErrInvalidAccountCacheCapacity, err)
+	})
+	t.Run("nil round notifier should error", func(t *testing.T) {
+		t.Parallel()
+
+		cache, err := NewAccountCache(10, nil)
+		assert.True(t, check.IfNil(cache))
+		assert.Equal(t, ErrNilRoundNotifier, err)
+	})
+	t.Run("should work and register", func(t *testing.T) {
+		t.Parallel()
+
+		var registered vmcommon.RoundSubscriberHandler
+		cache, err := NewAccountCache(10, &mock.RoundNotifierStub{
+			RegisterRoundHandlerCalled: func(handler vmcommon.RoundSubscriberHandler) {
+				registered = handler
+			},
+		})
+		assert.False(t, check.IfNil(cache))
+		assert.Nil(t, err)
+		assert.True(t, registered == cache)
+	})
+}
+
+func TestAccountCache_PutGetRemove(t *testing.T) {
+	t.Parallel()
+
+	cache, _ := NewAccountCache(10, &mock.RoundNotifierStub{})
+
+	_, found := cache.Get([]byte("addr"))
+	assert.False(t, found)
+
+	account := mock.NewUserAccount([]byte("addr"))
+	cache.Put(account)
+	cache.Put(nil)
+	cachedAccount, found := cache.Get([]byte("addr"))
+	assert.True(t, found)
+	assert.True(t, cachedAccount == account)
+	assert.Equal(t, 1, cache.Len())
+
+	newAccount := mock.NewUserAccount([]byte("addr"))
+	cache.Put(newAccount)
+	cachedAccount, _ = cache.Get([]byte("addr"))
+	assert.True(t, cachedAccount == newAccount)
+	assert.Equal(t, 1, cache.Len())
+
+	cache.Remove([]byte("addr"))
+	cache.Remove([]byte("missing"))
+	_, found = cache.Get([]byte("addr"))
+	assert.False(t, found)
+	assert.Equal(t, 0, cache.Len())
+}
+
+func TestAccountCache_ShouldEvictTheLeastRecentlyUsed(t *testing.T) {
+	t.Parallel()
+
+	cache, _ := NewAccountCache(2, &mock.RoundNotifierStub{})
+	cache.Put(mock.NewUserAccount([]byte("addr1")))
+	cache.Put(mock.NewUserAccount([]byte("addr2")))
+
+	_, found := cache.Get([]byte("addr1"))
+	assert.True(t, found)
+
+	cache.Put(mock.NewUserAccount([]byte("addr3")))
+	assert.Equal(t, 2, cache.Len())
+
+	_, found = cache.Get([]byte("addr2"))
+	assert.False(t, found)
+	_, found = cache.Get([]byte("addr1"))
+	assert.True(t, found)
+	_, found = cache.Get([]byte("addr3"))
+	assert.True(t, found)
+}
+
+func TestAccountCache_RoundConfirmedShouldClear(t *testing.T) {
+	t.Parallel()
+
+	cache, _ := NewAccountCache(10, &mock.RoundNotifierStub{})
+	cache.Put(mock.NewUserAccount([]byte("addr")))
+
+	cache.RoundConfirmed(1, 0)
+	_, found := cache.Get([]byte("addr"))
+	assert.False(t, found)
+	assert.Equal(t, 0, cache.Len())
+
+	cache.Put(mock.NewUserAccount([]byte("addr")))
+	_, found = cache.Get([]byte("addr"))
+	assert.True(t, found)
+}
+
+func TestNewAccountsAdapterWithAccountCache(t *testing.T) {
+	t.Parallel()
+
+	cache, _ := NewAccountCache(10, &mock.RoundNotifierStub{})
+	adapter, err := NewAccountsAdapterWithAccountCache(nil, cache)
+	assert.True(t, check.IfNil(adapter))
+	assert.ErrorIs(t, err, ErrNilAccountsAdapter)
+
+	adapter, err = NewAccountsAdapterWithAccountCache(&mock.AccountsStub{}, nil)
+	assert.True(t, check.IfNil(adapter))
+	assert.Equal(t, ErrNilAccountCache, err)
+
+	adapter, err = NewAccountsAdapterWithAccountCache(&mock.AccountsStub{}, cache)
+	assert.False(t, check.IfNil(adapter))
+	assert.Nil(t, err)
+}
+
+func TestAccountsAdapterWithAccountCache_ShouldKeepTheCacheConsistent(t *testing.T) {
+	t.Parallel()
+
+	saveErr := errors.New("save error")
+	accounts := &mock.AccountsStub{
+		SaveAccountCalled: func(account vmcommon.AccountHandler) error {
+			if string(account.AddressBytes()) == "failing" {
+				return saveErr
+			}
+			return nil
+		},
+		RemoveAccountCalled: func(_ []byte) error {
+			return nil
+		},
+		RevertToSnapshotCalled: func(_ int) error {
+			return nil
+		},
+		CommitCalled: func() ([]byte, error) {
+			return []byte("root hash"), nil
+		},
+	}
+	cache, _ := NewAccountCache(10, &mock.RoundNotifierStub{})
+	adapter, _ := NewAccountsAdapterWithAccountCache(accounts, cache)
+
+	err := adapter.SaveAccount(mock.NewUserAccount([]byte("addr")))
+	assert.Nil(t, err)
+	assert.Equal(t, 0, cache.Len())
+
+	cache.Put(mock.NewUserAccount([]byte("addr")))
+	savedAccount := mock.NewUserAccount([]byte("addr"))
+	err = adapter.SaveAccount(savedAccount)
+	assert.Nil(t, err)
+	cachedAccount, _ := cache.Get([]byte("addr"))
+	assert.True(t, cachedAccount == savedAccount)
+
+	cache.Put(mock.NewUserAccount([]byte("failing")))
+	err = adapter.SaveAccount(mock.NewUserAccount([]byte("failing")))
+	assert.Equal(t, saveErr, err)
+	_, found := cache.Get([]byte("failing"))
+	assert.False(t, found)
+
+	err = adapter.RemoveAccount([]byte("addr"))
+	assert.Nil(t, err)
+	_, found = cache.Get([]byte("addr"))
+	assert.False(t, found)
+
+	cache.Put(mock.NewUserAccount([]byte("addr")))
+	err = adapter.RevertToSnapshot(0)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, cache.Len())
+
+	cache.Put(mock.NewUserAccount([]byte("addr")))
+	_, err = adapter.Commit()
+	assert.Nil(t, err)
+	assert.Equal(t, 0, cache.Len())
+}
+
+func TestDisabledAccountCache(t *testing.T) {
+	t.Parallel()
+
+	cache := &disabledAccountCache{}
+	cache.Put(mock.NewUserAccount([]byte("addr")))
+	_, found := cache.Get([]byte("addr"))
+	assert.False(t, found)
+
+	cache.Remove([]byte("addr"))
+	cache.Clear()
+	assert.False(t, check.IfNil(cache))
+}
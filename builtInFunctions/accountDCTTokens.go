@@ -0,0 +1,83 @@
+package builtInFunctions
+
+import (
+	"context"
+
+	"github.com/Reshusk23/sr-me-core/core/check"
+	"github.com/Reshusk23/sr-me-core/data/dct"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/tokenident"
+)
+
+// AccountDCTTokenKey identifies a DCT token stored in the data of an account
+type AccountDCTTokenKey struct {
+	TokenIdentifier []byte
+	Nonce           uint64
+}
+
+// DCTTokenHandler is called for each DCT token found while iterating the data of an account
+type DCTTokenHandler func(tokenKey AccountDCTTokenKey, token *dct.DCToken) error
+
+// GetAllDCTTokenKeys returns the keys of all the DCT tokens stored in the data of the account
+func GetAllDCTTokenKeys(ctx context.Context, account vmcommon.UserAccountHandler) ([]AccountDCTTokenKey, error) {
+	tokenKeys := make([]AccountDCTTokenKey, 0)
+	err := iterateDCTTokenKeys(ctx, account, func(tokenKey AccountDCTTokenKey, _ []byte) error {
+		tokenKeys = append(tokenKeys, tokenKey)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tokenKeys, nil
+}
+
+// IterateDCTTokens calls the handler for each DCT token stored in the data of the account. As the NFT metadata
+// is held by the system account, the tokens only contain the balance and the properties of the account
+func IterateDCTTokens(
+	ctx context.Context,
+	account vmcommon.UserAccountHandler,
+	marshaller vmcommon.Marshalizer,
+	handler DCTTokenHandler,
+) error {
+	if check.IfNil(marshaller) {
+		return ErrNilMarshalizer
+	}
+
+	return iterateDCTTokenKeys(ctx, account, func(tokenKey AccountDCTTokenKey, value []byte) error {
+		token := &dct.DCToken{}
+		err := marshaller.Unmarshal(token, value)
+		if err != nil {
+			return err
+		}
+
+		return handler(tokenKey, token)
+	})
+}
+
+func iterateDCTTokenKeys(
+	ctx context.Context,
+	account vmcommon.UserAccountHandler,
+	handler func(tokenKey AccountDCTTokenKey, value []byte) error,
+) error {
+	if check.IfNil(account) {
+		return ErrNilUserAccount
+	}
+	iterator, ok := account.AccountDataHandler().(vmcommon.AccountDataIterator)
+	if !ok || check.IfNil(iterator) {
+		return ErrAccountDataNotIterable
+	}
+
+	prefix := []byte(baseDCTKeyPrefix)
+	return iterator.GetAllLeaves(ctx, prefix, func(key []byte, value []byte) error {
+		if len(key) <= len(prefix) || len(value) == 0 {
+			return nil
+		}
+
+		tokenKey := make([]byte, len(key)-len(prefix))
+		copy(tokenKey, key[len(prefix):])
+		tokenIdentifier, nonce := tokenident.SplitCollectionAndNonce(tokenKey)
+
+		return handler(AccountDCTTokenKey{TokenIdentifier: tokenIdentifier, Nonce: nonce}, value)
+	})
+}
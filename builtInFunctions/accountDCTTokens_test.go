@@ -0,0 +1,108 @@
+package builtInFunctions
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/Reshusk23/sr-me-core/core"
+	"github.com/Reshusk23/sr-me-core/data/dct"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createAccountWithDCTTokens(marshaller vmcommon.Marshalizer) vmcommon.UserAccountHandler {
+	account := mock.NewUserAccount([]byte("address"))
+	fungibleData, _ := marshaller.Marshal(&dct.DCToken{Value: big.NewInt(100)})
+	_ = account.SaveKeyValue(append(keyPrefix, []byte("FNG-abcdef")...), fungibleData)
+	createDCTNFTToken([]byte("NFT-abcdef"), core.NonFungible, 2, big.NewInt(1), marshaller, account)
+	_ = account.SaveKeyValue(append(roleKeyPrefix, []byte("FNG-abcdef")...), []byte("roles"))
+	_ = account.SaveKeyValue(append(keyPrefix, []byte("DEL-abcdef")...), nil)
+
+	return account
+}
+
+func TestGetAllDCTTokenKeys(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil account should error", func(t *testing.T) {
+		t.Parallel()
+
+		tokenKeys, err := GetAllDCTTokenKeys(context.Background(), nil)
+		assert.Nil(t, tokenKeys)
+		assert.Equal(t, ErrNilUserAccount, err)
+	})
+	t.Run("not iterable account data should error", func(t *testing.T) {
+		t.Parallel()
+
+		account := &mock.UserAccountStub{
+			AccountDataHandlerCalled: func() vmcommon.AccountDataHandler {
+				return &mock.DataTrieTrackerStub{}
+			},
+		}
+		tokenKeys, err := GetAllDCTTokenKeys(context.Background(), account)
+		assert.Nil(t, tokenKeys)
+		assert.Equal(t, ErrAccountDataNotIterable, err)
+	})
+	t.Run("cancelled context should error", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		tokenKeys, err := GetAllDCTTokenKeys(ctx, createAccountWithDCTTokens(&mock.MarshalizerMock{}))
+		assert.Nil(t, tokenKeys)
+		assert.Equal(t, context.Canceled, err)
+	})
+	t.Run("should return only the token keys", func(t *testing.T) {
+		t.Parallel()
+
+		tokenKeys, err := GetAllDCTTokenKeys(context.Background(), createAccountWithDCTTokens(&mock.MarshalizerMock{}))
+		require.Nil(t, err)
+		assert.Equal(t, []AccountDCTTokenKey{
+			{TokenIdentifier: []byte("FNG-abcdef"), Nonce: 0},
+			{TokenIdentifier: []byte("NFT-abcdef"), Nonce: 2},
+		}, tokenKeys)
+	})
+}
+
+func TestIterateDCTTokens(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil marshaller should error", func(t *testing.T) {
+		t.Parallel()
+
+		err := IterateDCTTokens(context.Background(), mock.NewUserAccount([]byte("address")), nil, nil)
+		assert.ErrorIs(t, err, ErrNilMarshalizer)
+	})
+	t.Run("handler error should stop the iteration", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		marshaller := &mock.MarshalizerMock{}
+		numCalls := 0
+		err := IterateDCTTokens(context.Background(), createAccountWithDCTTokens(marshaller), marshaller, func(_ AccountDCTTokenKey, _ *dct.DCToken) error {
+			numCalls++
+			return expectedErr
+		})
+		assert.Equal(t, expectedErr, err)
+		assert.Equal(t, 1, numCalls)
+	})
+	t.Run("should unmarshal the tokens", func(t *testing.T) {
+		t.Parallel()
+
+		marshaller := &mock.MarshalizerMock{}
+		values := make(map[string]*big.Int)
+		err := IterateDCTTokens(context.Background(), createAccountWithDCTTokens(marshaller), marshaller, func(tokenKey AccountDCTTokenKey, token *dct.DCToken) error {
+			values[string(tokenKey.TokenIdentifier)] = token.Value
+			return nil
+		})
+		require.Nil(t, err)
+		assert.Equal(t, map[string]*big.Int{
+			"FNG-abcdef": big.NewInt(100),
+			"NFT-abcdef": big.NewInt(1),
+		}, values)
+	})
+}
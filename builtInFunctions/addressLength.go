@@ -0,0 +1,95 @@
+package builtInFunctions
+
+import (
+	"context"
+	"fmt"
+
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+)
+
+// baseAddressLengthHandler holds the length against which a function validates the addresses it receives
+type baseAddressLengthHandler struct {
+	addressLength int
+}
+
+// SetAddressLength sets the length of the addresses accepted by the function
+func (b *baseAddressLengthHandler) SetAddressLength(addressLength int) error {
+	if addressLength <= 0 {
+		return ErrInvalidAddressLength
+	}
+
+	b.addressLength = addressLength
+	return nil
+}
+
+// getAddressLength returns the configured address length. Functions created outside of the built-in functions
+// factory are not configured, so they fall back to the length of the caller address
+func (b *baseAddressLengthHandler) getAddressLength(vmInput *vmcommon.ContractCallInput) int {
+	if b.addressLength > 0 {
+		return b.addressLength
+	}
+
+	return len(vmInput.CallerAddr)
+}
+
+// addressLengthFunction wraps a built-in function and rejects the calls made by malformed caller addresses before
+// the wrapped function processes them
+type addressLengthFunction struct {
+	baseFunctionWrapper
+	name          string
+	addressLength int
+}
+
+func newAddressLengthFunction(name string, function vmcommon.BuiltinFunction, addressLength int) *addressLengthFunction {
+	return &addressLengthFunction{
+		baseFunctionWrapper: baseFunctionWrapper{function: function},
+		name:                name,
+		addressLength:       addressLength,
+	}
+}
+
+// ProcessBuiltinFunction checks the caller address length and then calls the wrapped function
+func (alf *addressLengthFunction) ProcessBuiltinFunction(
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	return alf.processBuiltinFunctionWithContext(context.Background(), acntSnd, acntDst, vmInput)
+}
+
+func (alf *addressLengthFunction) processBuiltinFunctionWithContext(
+	ctx context.Context,
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	err := alf.checkCallerAddressLength(vmInput)
+	if err != nil {
+		return nil, err
+	}
+
+	return callWithContext(ctx, alf.function, acntSnd, acntDst, vmInput)
+}
+
+// CheckIsExecutable checks the caller address length and then forwards the validation to the wrapped function
+func (alf *addressLengthFunction) CheckIsExecutable(vmInput *vmcommon.ContractCallInput) error {
+	err := alf.checkCallerAddressLength(vmInput)
+	if err != nil {
+		return err
+	}
+
+	return checkIsExecutable(alf.function, vmInput)
+}
+
+func (alf *addressLengthFunction) checkCallerAddressLength(vmInput *vmcommon.ContractCallInput) error {
+	if vmInput == nil || len(vmInput.CallerAddr) == alf.addressLength {
+		return nil
+	}
+
+	log.Debug("built-in function called by a malformed caller address", withCorrelationID(vmInput,
+		"function", alf.name, "caller length", len(vmInput.CallerAddr), "expected length", alf.addressLength)...)
+	return fmt.Errorf("%w, caller address of %d bytes, expected %d", ErrInvalidAddressLength, len(vmInput.CallerAddr), alf.addressLength)
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (alf *addressLengthFunction) IsInterfaceNil() bool {
+	return alf == nil
+}
@@ -0,0 +1,92 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBaseAddressLengthHandler(t *testing.T) {
+	t.Parallel()
+
+	handler := &baseAddressLengthHandler{}
+	vmInput := &vmcommon.ContractCallInput{VMInput: vmcommon.VMInput{CallerAddr: []byte("caller")}}
+	assert.Equal(t, len("caller"), handler.getAddressLength(vmInput))
+
+	assert.Equal(t, ErrInvalidAddressLength, handler.SetAddressLength(0))
+	assert.Equal(t, ErrInvalidAddressLength, handler.SetAddressLength(-1))
+
+	require.Nil(t, handler.SetAddressLength(32))
+	assert.Equal(t, 32, handler.getAddressLength(vmInput))
+}
+
+func TestAddressLengthFunction_ProcessBuiltinFunction(t *testing.T) {
+	t.Parallel()
+
+	wasCalled := false
+	alf := newAddressLengthFunction("key", &mock.BuiltInFunctionStub{
+		ProcessBuiltinFunctionCalled: func(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+			wasCalled = true
+			return &vmcommon.VMOutput{}, nil
+		},
+	}, 32)
+
+	vmInput := &vmcommon.ContractCallInput{VMInput: vmcommon.VMInput{CallerAddr: []byte("short caller")}}
+	_, err := alf.ProcessBuiltinFunction(nil, nil, vmInput)
+	assert.ErrorIs(t, err, ErrInvalidAddressLength)
+	assert.False(t, wasCalled)
+
+	vmInput.CallerAddr = bytes.Repeat([]byte{1}, 32)
+	_, err = alf.ProcessBuiltinFunction(nil, nil, vmInput)
+	assert.Nil(t, err)
+	assert.True(t, wasCalled)
+}
+
+func TestAddressLengthFunction_CheckIsExecutable(t *testing.T) {
+	t.Parallel()
+
+	wasCalled := false
+	alf := newAddressLengthFunction("key", &mock.BuiltInFunctionStub{
+		CheckIsExecutableCalled: func(vmInput *vmcommon.ContractCallInput) error {
+			wasCalled = true
+			return nil
+		},
+	}, 32)
+
+	vmInput := &vmcommon.ContractCallInput{VMInput: vmcommon.VMInput{CallerAddr: []byte("short caller")}}
+	err := alf.CheckIsExecutable(vmInput)
+	assert.ErrorIs(t, err, ErrInvalidAddressLength)
+	assert.False(t, wasCalled)
+
+	vmInput.CallerAddr = bytes.Repeat([]byte{1}, 32)
+	err = alf.CheckIsExecutable(vmInput)
+	assert.Nil(t, err)
+	assert.True(t, wasCalled)
+}
+
+func TestAddressLength_ValidationsShouldUseTheConfiguredLength(t *testing.T) {
+	t.Parallel()
+
+	changeOwner := NewChangeOwnerAddressFunc(10)
+	vmInput := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  []byte("caller"),
+			CallValue:   big.NewInt(0),
+			Arguments:   [][]byte{[]byte("new owner")},
+			GasProvided: 10,
+		},
+	}
+	acnt := mock.NewUserAccount([]byte("caller"))
+
+	_, err := changeOwner.ProcessBuiltinFunction(nil, acnt, vmInput)
+	assert.Equal(t, ErrInvalidAddressLength, err)
+
+	_ = changeOwner.SetAddressLength(len("new owner"))
+	_, err = changeOwner.ProcessBuiltinFunction(nil, acnt, vmInput)
+	assert.NotEqual(t, ErrInvalidAddressLength, err)
+}
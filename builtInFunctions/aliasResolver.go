@@ -0,0 +1,57 @@
+package builtInFunctions
+
+import (
+	"github.com/Reshusk23/sr-me-core/core/check"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/validation"
+)
+
+// resolveAddressArgument returns the address found at the provided argument index, resolving it first if the argument
+// is an alias. The address is validated against the provided length either way
+func resolveAddressArgument(
+	aliasResolver vmcommon.AliasResolver,
+	arguments [][]byte,
+	index int,
+	addressLength int,
+) ([]byte, error) {
+	isAlias := !check.IfNil(aliasResolver) && index < len(arguments) && aliasResolver.IsAlias(arguments[index])
+	if isAlias {
+		address, err := aliasResolver.ResolveAlias(arguments[index])
+		if err != nil {
+			return nil, err
+		}
+
+		resolvedArguments := make([][]byte, len(arguments))
+		copy(resolvedArguments, arguments)
+		resolvedArguments[index] = address
+		arguments = resolvedArguments
+	}
+
+	err := checkFunctionArguments(arguments, validation.RequireAddress(index, addressLength))
+	if err != nil {
+		return nil, err
+	}
+
+	return arguments[index], nil
+}
+
+// checkAddressArgument validates the address found at the provided argument index without resolving the aliases, as
+// the resolution may read the state. It returns nil for an alias, which is checked only when processing
+func checkAddressArgument(
+	aliasResolver vmcommon.AliasResolver,
+	arguments [][]byte,
+	index int,
+	addressLength int,
+) ([]byte, error) {
+	isAlias := !check.IfNil(aliasResolver) && index < len(arguments) && aliasResolver.IsAlias(arguments[index])
+	if isAlias {
+		return nil, nil
+	}
+
+	err := checkFunctionArguments(arguments, validation.RequireAddress(index, addressLength))
+	if err != nil {
+		return nil, err
+	}
+
+	return arguments[index], nil
+}
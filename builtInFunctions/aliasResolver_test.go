@@ -0,0 +1,98 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/Reshusk23/sr-vm-common-go/validation"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveAddressArgument(t *testing.T) {
+	t.Parallel()
+
+	address := bytes.Repeat([]byte{1}, 32)
+	alias := []byte("alias")
+	expectedErr := errors.New("unknown alias")
+	aliasResolver := &mock.AliasResolverStub{
+		IsAliasCalled: func(value []byte) bool {
+			return len(value) == len(alias)
+		},
+		ResolveAliasCalled: func(value []byte) ([]byte, error) {
+			if bytes.Equal(value, alias) {
+				return address, nil
+			}
+			return nil, expectedErr
+		},
+	}
+
+	t.Run("address without resolver", func(t *testing.T) {
+		t.Parallel()
+
+		resolved, err := resolveAddressArgument(nil, [][]byte{[]byte("token"), address}, 1, 32)
+		assert.Nil(t, err)
+		assert.Equal(t, address, resolved)
+
+		_, err = resolveAddressArgument(nil, [][]byte{[]byte("token"), alias}, 1, 32)
+		assert.ErrorIs(t, err, validation.ErrInvalidAddress)
+	})
+	t.Run("missing argument", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := resolveAddressArgument(aliasResolver, [][]byte{[]byte("token")}, 1, 32)
+		assert.ErrorIs(t, err, validation.ErrMissingArgument)
+	})
+	t.Run("alias should resolve", func(t *testing.T) {
+		t.Parallel()
+
+		arguments := [][]byte{[]byte("token"), alias}
+		resolved, err := resolveAddressArgument(aliasResolver, arguments, 1, 32)
+		assert.Nil(t, err)
+		assert.Equal(t, address, resolved)
+		assert.Equal(t, alias, arguments[1])
+	})
+	t.Run("unknown alias should error", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := resolveAddressArgument(aliasResolver, [][]byte{[]byte("token"), []byte("other")}, 1, 32)
+		assert.Equal(t, expectedErr, err)
+	})
+	t.Run("alias resolved to a malformed address should error", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := resolveAddressArgument(aliasResolver, [][]byte{[]byte("token"), alias}, 1, 20)
+		assert.ErrorIs(t, err, validation.ErrInvalidAddress)
+	})
+}
+
+func TestCheckAddressArgument(t *testing.T) {
+	t.Parallel()
+
+	address := bytes.Repeat([]byte{1}, 32)
+	alias := []byte("alias")
+	aliasResolver := &mock.AliasResolverStub{
+		IsAliasCalled: func(value []byte) bool {
+			return bytes.Equal(value, alias)
+		},
+		ResolveAliasCalled: func(value []byte) ([]byte, error) {
+			assert.Fail(t, "should not resolve the alias")
+			return nil, nil
+		},
+	}
+
+	checked, err := checkAddressArgument(aliasResolver, [][]byte{[]byte("token"), address}, 1, 32)
+	assert.Nil(t, err)
+	assert.Equal(t, address, checked)
+
+	checked, err = checkAddressArgument(aliasResolver, [][]byte{[]byte("token"), alias}, 1, 32)
+	assert.Nil(t, err)
+	assert.Nil(t, checked)
+
+	_, err = checkAddressArgument(aliasResolver, [][]byte{[]byte("token"), address[1:]}, 1, 32)
+	assert.ErrorIs(t, err, validation.ErrInvalidAddress)
+
+	_, err = checkAddressArgument(nil, [][]byte{[]byte("token"), alias}, 1, 32)
+	assert.ErrorIs(t, err, validation.ErrInvalidAddress)
+}
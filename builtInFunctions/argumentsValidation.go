@@ -0,0 +1,61 @@
+package builtInFunctions
+
+import (
+	"fmt"
+
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/validation"
+)
+
+// checkFunctionArguments runs the validators over the arguments of a built-in function, the returned error being an
+// invalid arguments error which also names the offending argument
+func checkFunctionArguments(arguments [][]byte, validators ...validation.Validator) error {
+	err := validation.CheckFunctionArguments(arguments, validators...)
+	if err != nil {
+		return fmt.Errorf("%w, %w", ErrInvalidArguments, err)
+	}
+
+	return nil
+}
+
+// uint64Argument returns the argument at the provided index as an uint64, the values which do not fit being rejected
+// with an invalid arguments error instead of truncated
+func uint64Argument(arguments [][]byte, index int) (uint64, error) {
+	value, err := vmcommon.SafeUint64FromBytes(arguments[index])
+	if err != nil {
+		return 0, fmt.Errorf("%w, argument %d: %w", ErrInvalidArguments, index, err)
+	}
+
+	return value, nil
+}
+
+// uint32Argument returns the argument at the provided index as an uint32, the values which do not fit being rejected
+// with an invalid arguments error instead of truncated
+func uint32Argument(arguments [][]byte, index int) (uint32, error) {
+	value, err := vmcommon.SafeUint32FromBytes(arguments[index])
+	if err != nil {
+		return 0, fmt.Errorf("%w, argument %d: %w", ErrInvalidArguments, index, err)
+	}
+
+	return value, nil
+}
+
+// flaggedUint64Argument returns the argument at the provided index as an uint64 for the built-in functions which
+// truncated their oversized arguments before the safe argument conversion flag, keeping that behaviour until the flag
+// is enabled so the already executed transactions still replay to the same result
+func flaggedUint64Argument(enableEpochsHandler vmcommon.EnableEpochsHandler, arguments [][]byte, index int) (uint64, error) {
+	if !enableEpochsHandler.IsSafeArgumentConversionFlagEnabled() {
+		return bytesToUint64(arguments[index]), nil
+	}
+
+	return uint64Argument(arguments, index)
+}
+
+// flaggedUint32Argument is the uint32 counterpart of flaggedUint64Argument
+func flaggedUint32Argument(enableEpochsHandler vmcommon.EnableEpochsHandler, arguments [][]byte, index int) (uint32, error) {
+	if !enableEpochsHandler.IsSafeArgumentConversionFlagEnabled() {
+		return uint32(bytesToUint64(arguments[index])), nil
+	}
+
+	return uint32Argument(arguments, index)
+}
@@ -0,0 +1,85 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/Reshusk23/sr-vm-common-go/validation"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckFunctionArguments(t *testing.T) {
+	t.Parallel()
+
+	err := checkFunctionArguments([][]byte{[]byte("addr")}, validation.RequireMinArgs(1))
+	assert.Nil(t, err)
+
+	err = checkFunctionArguments([][]byte{[]byte("addr")}, validation.RequireAddress(0, 5))
+	assert.True(t, errors.Is(err, ErrInvalidArguments))
+	assert.True(t, errors.Is(err, validation.ErrInvalidAddress))
+	assert.Contains(t, err.Error(), "argument 0: invalid address")
+}
+
+func TestUint64Argument(t *testing.T) {
+	t.Parallel()
+
+	value, err := uint64Argument([][]byte{{1}, {0, 0, 1, 0}}, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(256), value)
+
+	_, err = uint64Argument([][]byte{{1}, bytes.Repeat([]byte{1}, 9)}, 1)
+	assert.True(t, errors.Is(err, ErrInvalidArguments))
+	assert.True(t, errors.Is(err, vmcommon.ErrValueOutOfRange))
+	assert.Contains(t, err.Error(), "argument 1: value out of range")
+}
+
+func TestUint32Argument(t *testing.T) {
+	t.Parallel()
+
+	value, err := uint32Argument([][]byte{{0x27, 0x10}}, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(10000), value)
+
+	_, err = uint32Argument([][]byte{{1, 0, 0, 0, 0}}, 0)
+	assert.True(t, errors.Is(err, ErrInvalidArguments))
+	assert.True(t, errors.Is(err, vmcommon.ErrValueOutOfRange))
+}
+
+func TestFlaggedUint64Argument(t *testing.T) {
+	t.Parallel()
+
+	arguments := [][]byte{{1}, {1, 0, 0, 0, 0, 0, 0, 0, 2}}
+	enableEpochsHandler := &mock.EnableEpochsHandlerStub{}
+
+	value, err := flaggedUint64Argument(enableEpochsHandler, arguments, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(2), value)
+
+	enableEpochsHandler.IsSafeArgumentConversionFlagEnabledField = true
+	value, err = flaggedUint64Argument(enableEpochsHandler, arguments, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), value)
+
+	_, err = flaggedUint64Argument(enableEpochsHandler, arguments, 1)
+	assert.True(t, errors.Is(err, ErrInvalidArguments))
+	assert.True(t, errors.Is(err, vmcommon.ErrValueOutOfRange))
+}
+
+func TestFlaggedUint32Argument(t *testing.T) {
+	t.Parallel()
+
+	arguments := [][]byte{{1, 0, 0, 0x27, 0x10}}
+	enableEpochsHandler := &mock.EnableEpochsHandlerStub{}
+
+	value, err := flaggedUint32Argument(enableEpochsHandler, arguments, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(10000), value)
+
+	enableEpochsHandler.IsSafeArgumentConversionFlagEnabledField = true
+	_, err = flaggedUint32Argument(enableEpochsHandler, arguments, 0)
+	assert.True(t, errors.Is(err, ErrInvalidArguments))
+	assert.True(t, errors.Is(err, vmcommon.ErrValueOutOfRange))
+}
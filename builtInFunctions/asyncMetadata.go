@@ -0,0 +1,34 @@
+package builtInFunctions
+
+import (
+	"github.com/Reshusk23/sr-me-core/data/vm"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+)
+
+// addAsyncMetadataToVMOutput mirrors in typed form the asynchronous call or callback carried by the output transfer,
+// so hosts do not have to decode it from the transfer data
+func addAsyncMetadataToVMOutput(
+	callID []byte,
+	destination []byte,
+	outTransfer vmcommon.OutputTransfer,
+	vmOutput *vmcommon.VMOutput,
+) {
+	switch outTransfer.CallType {
+	case vm.AsynchronousCall:
+		vmOutput.AsyncCalls = append(vmOutput.AsyncCalls, &vmcommon.AsyncCall{
+			CallID:      callID,
+			Destination: destination,
+			Data:        outTransfer.Data,
+			GasLimit:    outTransfer.GasLimit,
+			GasLocked:   outTransfer.GasLocked,
+		})
+	case vm.AsynchronousCallBack:
+		vmOutput.AsyncCallback = &vmcommon.AsyncCallback{
+			CallID:      callID,
+			Destination: destination,
+			Data:        outTransfer.Data,
+			GasLimit:    outTransfer.GasLimit + outTransfer.GasLocked,
+			ReturnCode:  vmcommon.Ok,
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package builtInFunctions
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Reshusk23/sr-me-core/data/vm"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddAsyncMetadataToVMOutput(t *testing.T) {
+	t.Parallel()
+
+	callID := []byte("hash")
+	destination := []byte("dest")
+	outTransfer := vmcommon.OutputTransfer{
+		Value:     big.NewInt(0),
+		GasLimit:  100,
+		GasLocked: 20,
+		Data:      []byte("func@01"),
+	}
+
+	t.Run("direct call should not add metadata", func(t *testing.T) {
+		t.Parallel()
+
+		vmOutput := &vmcommon.VMOutput{}
+		transfer := outTransfer
+		transfer.CallType = vm.DirectCall
+		addAsyncMetadataToVMOutput(callID, destination, transfer, vmOutput)
+		assert.Nil(t, vmOutput.AsyncCalls)
+		assert.Nil(t, vmOutput.AsyncCallback)
+	})
+	t.Run("asynchronous call should add async call", func(t *testing.T) {
+		t.Parallel()
+
+		vmOutput := &vmcommon.VMOutput{}
+		transfer := outTransfer
+		transfer.CallType = vm.AsynchronousCall
+		addAsyncMetadataToVMOutput(callID, destination, transfer, vmOutput)
+		assert.Equal(t, []*vmcommon.AsyncCall{{
+			CallID:      callID,
+			Destination: destination,
+			Data:        transfer.Data,
+			GasLimit:    100,
+			GasLocked:   20,
+		}}, vmOutput.AsyncCalls)
+		assert.Nil(t, vmOutput.AsyncCallback)
+	})
+	t.Run("asynchronous callback should set the callback", func(t *testing.T) {
+		t.Parallel()
+
+		vmOutput := &vmcommon.VMOutput{}
+		transfer := outTransfer
+		transfer.CallType = vm.AsynchronousCallBack
+		addAsyncMetadataToVMOutput(callID, destination, transfer, vmOutput)
+		assert.Nil(t, vmOutput.AsyncCalls)
+		assert.Equal(t, &vmcommon.AsyncCallback{
+			CallID:      callID,
+			Destination: destination,
+			Data:        transfer.Data,
+			GasLimit:    120,
+			ReturnCode:  vmcommon.Ok,
+		}, vmOutput.AsyncCallback)
+	})
+}
@@ -1,5 +1,10 @@
 package builtInFunctions
 
+import (
+	"github.com/Reshusk23/sr-me-core/core/check"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+)
+
 type baseAlwaysActiveHandler struct {
 }
 
@@ -26,3 +31,54 @@ func (b *baseActiveHandler) IsActive() bool {
 func (b *baseActiveHandler) IsInterfaceNil() bool {
 	return b == nil
 }
+
+// activeBetweenEpochs is an activation handler to be embedded by the built-in functions active only from an
+// activation epoch until a deactivation epoch, so a deprecated function can be switched off at a hard fork while its
+// code is kept for replaying the history. A zero deactivation epoch means the function is never deactivated. The
+// optional active handler, usually an enable epochs flag, must also be true for the function to be active
+type activeBetweenEpochs struct {
+	enableEpochsHandler vmcommon.EnableEpochsHandler
+	activationEpoch     uint32
+	deactivationEpoch   uint32
+	activeHandler       func() bool
+}
+
+func newActiveBetweenEpochs(
+	enableEpochsHandler vmcommon.EnableEpochsHandler,
+	activationEpoch uint32,
+	deactivationEpoch uint32,
+	activeHandler func() bool,
+) (activeBetweenEpochs, error) {
+	if check.IfNil(enableEpochsHandler) {
+		return activeBetweenEpochs{}, ErrNilEnableEpochsHandler
+	}
+	if deactivationEpoch != 0 && deactivationEpoch <= activationEpoch {
+		return activeBetweenEpochs{}, ErrInvalidEpochsInterval
+	}
+
+	return activeBetweenEpochs{
+		enableEpochsHandler: enableEpochsHandler,
+		activationEpoch:     activationEpoch,
+		deactivationEpoch:   deactivationEpoch,
+		activeHandler:       activeHandler,
+	}, nil
+}
+
+// IsActive returns true if the current epoch is between the activation epoch, inclusive, and the deactivation
+// epoch, exclusive
+func (a *activeBetweenEpochs) IsActive() bool {
+	currentEpoch := a.enableEpochsHandler.GetCurrentEpoch()
+	if currentEpoch < a.activationEpoch {
+		return false
+	}
+	if a.deactivationEpoch != 0 && currentEpoch >= a.deactivationEpoch {
+		return false
+	}
+
+	return a.activeHandler == nil || a.activeHandler()
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (a *activeBetweenEpochs) IsInterfaceNil() bool {
+	return a == nil
+}
@@ -39,3 +39,68 @@ func TestBaseAlwaysActiveHandler_IsActive(t *testing.T) {
 	assert.False(t, check.IfNil(handler))
 	assert.True(t, handler.IsActive())
 }
+
+func TestNewActiveBetweenEpochs(t *testing.T) {
+	t.Parallel()
+
+	_, err := newActiveBetweenEpochs(nil, 0, 0, nil)
+	assert.ErrorIs(t, err, ErrNilEnableEpochsHandler)
+
+	_, err = newActiveBetweenEpochs(&mock.EnableEpochsHandlerStub{}, 5, 5, nil)
+	assert.Equal(t, ErrInvalidEpochsInterval, err)
+
+	_, err = newActiveBetweenEpochs(&mock.EnableEpochsHandlerStub{}, 5, 0, nil)
+	assert.Nil(t, err)
+}
+
+func TestActiveBetweenEpochs_IsActive(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should be active between the epochs", func(t *testing.T) {
+		t.Parallel()
+
+		enableEpochsHandler := &mock.EnableEpochsHandlerStub{}
+		handler, _ := newActiveBetweenEpochs(enableEpochsHandler, 2, 4, nil)
+		assert.False(t, check.IfNil(&handler))
+
+		expectedActive := []bool{false, false, true, true, false, false}
+		for epoch, expected := range expectedActive {
+			enableEpochsHandler.CurrentEpochField = uint32(epoch)
+			assert.Equal(t, expected, handler.IsActive(), "epoch %d", epoch)
+		}
+	})
+	t.Run("no deactivation epoch should stay active", func(t *testing.T) {
+		t.Parallel()
+
+		enableEpochsHandler := &mock.EnableEpochsHandlerStub{CurrentEpochField: 1000}
+		handler, _ := newActiveBetweenEpochs(enableEpochsHandler, 0, 0, nil)
+		assert.True(t, handler.IsActive())
+	})
+	t.Run("active handler should also be checked", func(t *testing.T) {
+		t.Parallel()
+
+		enableEpochsHandler := &mock.EnableEpochsHandlerStub{CurrentEpochField: 3}
+		handler, _ := newActiveBetweenEpochs(enableEpochsHandler, 2, 4, enableEpochsHandler.IsSCDeployFlagEnabled)
+		assert.False(t, handler.IsActive())
+
+		enableEpochsHandler.IsSCDeployFlagEnabledField = true
+		assert.True(t, handler.IsActive())
+
+		enableEpochsHandler.CurrentEpochField = 4
+		assert.False(t, handler.IsActive())
+	})
+	t.Run("embedding function should be deactivated", func(t *testing.T) {
+		t.Parallel()
+
+		type deprecatedFunction struct {
+			activeBetweenEpochs
+		}
+		enableEpochsHandler := &mock.EnableEpochsHandlerStub{CurrentEpochField: 10}
+		activeHandler, _ := newActiveBetweenEpochs(enableEpochsHandler, 0, 11, nil)
+		function := &deprecatedFunction{activeBetweenEpochs: activeHandler}
+		assert.True(t, function.IsActive())
+
+		enableEpochsHandler.CurrentEpochField = 11
+		assert.False(t, function.IsActive())
+	})
+}
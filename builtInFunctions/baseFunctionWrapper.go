@@ -0,0 +1,183 @@
+package builtInFunctions
+
+import vmcommon "github.com/Reshusk23/sr-vm-common-go"
+
+// baseFunctionWrapper forwards to the wrapped built-in function all the calls which are not intercepted
+type baseFunctionWrapper struct {
+	function vmcommon.BuiltinFunction
+}
+
+// CheckIsExecutable forwards the stateless validation to the wrapped function
+func (bfw *baseFunctionWrapper) CheckIsExecutable(vmInput *vmcommon.ContractCallInput) error {
+	return checkIsExecutable(bfw.function, vmInput)
+}
+
+// checkIsExecutable runs the stateless validation of the function, if it has one
+func checkIsExecutable(function vmcommon.BuiltinFunction, vmInput *vmcommon.ContractCallInput) error {
+	checker, ok := function.(vmcommon.StatelessExecutableChecker)
+	if !ok {
+		return nil
+	}
+
+	return checker.CheckIsExecutable(vmInput)
+}
+
+// SetNewGasConfig is called whenever gas cost is changed
+func (bfw *baseFunctionWrapper) SetNewGasConfig(gasCost *vmcommon.GasCost) {
+	bfw.function.SetNewGasConfig(gasCost)
+}
+
+// SetPayableChecker forwards the payable checker to the wrapped function, if it accepts one
+func (bfw *baseFunctionWrapper) SetPayableChecker(payableHandler vmcommon.PayableChecker) error {
+	acceptPayableChecker, ok := bfw.function.(vmcommon.AcceptPayableChecker)
+	if !ok {
+		return ErrWrongTypeAssertion
+	}
+
+	return acceptPayableChecker.SetPayableChecker(payableHandler)
+}
+
+// SetLatestNonceCache forwards the latest nonce cache to the wrapped function, if it accepts one
+func (bfw *baseFunctionWrapper) SetLatestNonceCache(nonceCache vmcommon.LatestNonceCache) error {
+	acceptNonceCache, ok := bfw.function.(vmcommon.AcceptLatestNonceCache)
+	if !ok {
+		return ErrWrongTypeAssertion
+	}
+
+	return acceptNonceCache.SetLatestNonceCache(nonceCache)
+}
+
+// SetAccountCache forwards the account cache to the wrapped function, if it accepts one
+func (bfw *baseFunctionWrapper) SetAccountCache(accountCache vmcommon.AccountCache) error {
+	acceptAccountCache, ok := bfw.function.(vmcommon.AcceptAccountCache)
+	if !ok {
+		return ErrWrongTypeAssertion
+	}
+
+	return acceptAccountCache.SetAccountCache(accountCache)
+}
+
+// SetAddressClassifier forwards the address classifier to the wrapped function, if it accepts one
+func (bfw *baseFunctionWrapper) SetAddressClassifier(addressClassifier vmcommon.AddressClassifier) error {
+	acceptAddressClassifier, ok := bfw.function.(vmcommon.AcceptAddressClassifier)
+	if !ok {
+		return ErrWrongTypeAssertion
+	}
+
+	return acceptAddressClassifier.SetAddressClassifier(addressClassifier)
+}
+
+// SetFreezeAccountHandler forwards the freeze account handler to the wrapped function, if it accepts one
+func (bfw *baseFunctionWrapper) SetFreezeAccountHandler(freezeAccountHandler vmcommon.FreezeAccountHandler) error {
+	acceptFreezeAccountHandler, ok := bfw.function.(vmcommon.AcceptFreezeAccountHandler)
+	if !ok {
+		return ErrWrongTypeAssertion
+	}
+
+	return acceptFreezeAccountHandler.SetFreezeAccountHandler(freezeAccountHandler)
+}
+
+// SetTransferInterceptor forwards the transfer interceptor to the wrapped function, if it accepts one
+func (bfw *baseFunctionWrapper) SetTransferInterceptor(transferInterceptor vmcommon.TransferInterceptor) error {
+	acceptTransferInterceptor, ok := bfw.function.(vmcommon.AcceptTransferInterceptor)
+	if !ok {
+		return ErrWrongTypeAssertion
+	}
+
+	return acceptTransferInterceptor.SetTransferInterceptor(transferInterceptor)
+}
+
+// SetAccountActivityHandler forwards the account activity handler to the wrapped function, if it accepts one
+func (bfw *baseFunctionWrapper) SetAccountActivityHandler(accountActivityHandler vmcommon.AccountActivityHandler) error {
+	acceptAccountActivityHandler, ok := bfw.function.(vmcommon.AcceptAccountActivityHandler)
+	if !ok {
+		return ErrWrongTypeAssertion
+	}
+
+	return acceptAccountActivityHandler.SetAccountActivityHandler(accountActivityHandler)
+}
+
+// SetProtectedKeysHandler forwards the protected keys handler to the wrapped function, if it accepts one
+func (bfw *baseFunctionWrapper) SetProtectedKeysHandler(protectedKeysHandler vmcommon.ProtectedKeysHandler) error {
+	acceptProtectedKeysHandler, ok := bfw.function.(vmcommon.AcceptProtectedKeysHandler)
+	if !ok {
+		return ErrWrongTypeAssertion
+	}
+
+	return acceptProtectedKeysHandler.SetProtectedKeysHandler(protectedKeysHandler)
+}
+
+// SetAddressLength forwards the address length to the wrapped function, if it accepts one
+func (bfw *baseFunctionWrapper) SetAddressLength(addressLength int) error {
+	acceptAddressLength, ok := bfw.function.(vmcommon.AcceptAddressLength)
+	if !ok {
+		return ErrWrongTypeAssertion
+	}
+
+	return acceptAddressLength.SetAddressLength(addressLength)
+}
+
+// SetSystemAddresses forwards the system addresses to the wrapped function, if it accepts them
+func (bfw *baseFunctionWrapper) SetSystemAddresses(systemAddresses vmcommon.SystemAddresses) error {
+	acceptSystemAddresses, ok := bfw.function.(vmcommon.AcceptSystemAddresses)
+	if !ok {
+		return ErrWrongTypeAssertion
+	}
+
+	return acceptSystemAddresses.SetSystemAddresses(systemAddresses)
+}
+
+// SetMultiSigVerifier forwards the multisig verifier to the wrapped function, if it accepts one
+func (bfw *baseFunctionWrapper) SetMultiSigVerifier(multiSigVerifier vmcommon.MultiSigVerifier) error {
+	acceptMultiSigVerifier, ok := bfw.function.(vmcommon.AcceptMultiSigVerifier)
+	if !ok {
+		return ErrWrongTypeAssertion
+	}
+
+	return acceptMultiSigVerifier.SetMultiSigVerifier(multiSigVerifier)
+}
+
+// SetProofVerifier forwards the proof verifier to the wrapped function, if it accepts one
+func (bfw *baseFunctionWrapper) SetProofVerifier(proofVerifier vmcommon.ProofVerifier) error {
+	acceptProofVerifier, ok := bfw.function.(vmcommon.AcceptProofVerifier)
+	if !ok {
+		return ErrWrongTypeAssertion
+	}
+
+	return acceptProofVerifier.SetProofVerifier(proofVerifier)
+}
+
+// SetAliasResolver forwards the alias resolver to the wrapped function, if it accepts one
+func (bfw *baseFunctionWrapper) SetAliasResolver(aliasResolver vmcommon.AliasResolver) error {
+	acceptAliasResolver, ok := bfw.function.(vmcommon.AcceptAliasResolver)
+	if !ok {
+		return ErrWrongTypeAssertion
+	}
+
+	return acceptAliasResolver.SetAliasResolver(aliasResolver)
+}
+
+// SetHasher forwards the hasher to the wrapped function, if it accepts one
+func (bfw *baseFunctionWrapper) SetHasher(hasher vmcommon.Hasher) error {
+	acceptHasher, ok := bfw.function.(vmcommon.AcceptHasher)
+	if !ok {
+		return ErrWrongTypeAssertion
+	}
+
+	return acceptHasher.SetHasher(hasher)
+}
+
+// SetStorageUsageTracker forwards the storage usage tracker to the wrapped function, if it accepts one
+func (bfw *baseFunctionWrapper) SetStorageUsageTracker(storageUsageTracker vmcommon.StorageUsageTracker) error {
+	acceptStorageUsageTracker, ok := bfw.function.(vmcommon.AcceptStorageUsageTracker)
+	if !ok {
+		return ErrWrongTypeAssertion
+	}
+
+	return acceptStorageUsageTracker.SetStorageUsageTracker(storageUsageTracker)
+}
+
+// IsActive returns true if the wrapped function is active
+func (bfw *baseFunctionWrapper) IsActive() bool {
+	return bfw.function.IsActive()
+}
@@ -0,0 +1,190 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/Reshusk23/sr-me-core/core/check"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+)
+
+// SetAccountsAdapter sets the accounts adapter against which the batches of calls are executed and reverted, together
+// with the shard coordinator deciding which of the accounts are handled by this shard
+func (f *functionContainer) SetAccountsAdapter(accounts vmcommon.AccountsAdapter, shardCoordinator vmcommon.Coordinator) error {
+	if check.IfNil(accounts) {
+		return ErrNilAccountsAdapter
+	}
+	if check.IfNil(shardCoordinator) {
+		return ErrNilShardCoordinator
+	}
+
+	f.mutBatch.Lock()
+	f.accounts = accounts
+	f.shardCoordinator = shardCoordinator
+	f.mutBatch.Unlock()
+
+	return nil
+}
+
+// SetLatestNonceCache sets the latest nonce cache emptied whenever a batch is reverted
+func (f *functionContainer) SetLatestNonceCache(nonceCache vmcommon.LatestNonceCache) error {
+	if check.IfNil(nonceCache) {
+		return ErrNilLatestNonceCache
+	}
+
+	f.mutBatch.Lock()
+	f.nonceCache = nonceCache
+	f.mutBatch.Unlock()
+
+	return nil
+}
+
+// SetAccountCache sets the account cache emptied whenever a batch is reverted
+func (f *functionContainer) SetAccountCache(accountCache vmcommon.AccountCache) error {
+	if check.IfNil(accountCache) {
+		return ErrNilAccountCache
+	}
+
+	f.mutBatch.Lock()
+	f.accountCache = accountCache
+	f.mutBatch.Unlock()
+
+	return nil
+}
+
+// ProcessBuiltinFunctions executes a batch of built-in function calls atomically. All the calls go through the
+// stateless checks of their functions before any of them is executed, so a malformed call fails the batch without
+// touching any account. Then the calls are executed in order, each one observing the state changes of the previous
+// ones. If any call fails, the accounts are reverted to the state before the batch and the error names the failing call
+func (f *functionContainer) ProcessBuiltinFunctions(calls []*vmcommon.ContractCallInput) ([]*vmcommon.VMOutput, error) {
+	f.mutBatch.Lock()
+	defer f.mutBatch.Unlock()
+
+	if check.IfNil(f.accounts) {
+		return nil, ErrBatchProcessingNotEnabled
+	}
+
+	functions := make([]vmcommon.BuiltinFunction, 0, len(calls))
+	for index, call := range calls {
+		function, err := f.getBatchFunction(call)
+		if err != nil {
+			return nil, fmt.Errorf("%w in call %d of the batch", err, index)
+		}
+
+		functions = append(functions, function)
+	}
+
+	snapshot := f.accounts.JournalLen()
+	vmOutputs := make([]*vmcommon.VMOutput, 0, len(calls))
+	for index, call := range calls {
+		vmOutput, err := f.processBatchCall(functions[index], call)
+		if err != nil {
+			// the outputs of the reverted calls are not handed over to the caller
+			for _, previousOutput := range vmOutputs {
+				vmcommon.ReleaseVMOutput(previousOutput)
+			}
+			return nil, f.revertBatch(snapshot, fmt.Errorf("%w in call %d of the batch", err, index))
+		}
+
+		vmOutputs = append(vmOutputs, vmOutput)
+	}
+
+	return vmOutputs, nil
+}
+
+func (f *functionContainer) getBatchFunction(call *vmcommon.ContractCallInput) (vmcommon.BuiltinFunction, error) {
+	if call == nil {
+		return nil, ErrNilVmInput
+	}
+
+	if f.shardCoordinator.ComputeId(call.CallerAddr) != f.shardCoordinator.SelfId() {
+		return nil, ErrBatchCallerInAnotherShard
+	}
+
+	function, err := f.Get(call.Function)
+	if err != nil {
+		return nil, err
+	}
+	if !function.IsActive() {
+		return nil, fmt.Errorf("%w, function %s", ErrBuiltInFunctionNotActive, call.Function)
+	}
+	err = checkIsExecutable(function, call)
+	if err != nil {
+		return nil, err
+	}
+
+	return function, nil
+}
+
+func (f *functionContainer) processBatchCall(function vmcommon.BuiltinFunction, call *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	acntSnd, err := f.loadBatchAccount(call.CallerAddr)
+	if err != nil {
+		return nil, err
+	}
+	// as for any other call, the destination account is provided only if it is handled by this shard, the
+	// cross-shard destinations being reached through the output transfers
+	var acntDst vmcommon.UserAccountHandler
+	if bytes.Equal(call.CallerAddr, call.RecipientAddr) {
+		acntDst = acntSnd
+	} else if f.shardCoordinator.SameShard(call.CallerAddr, call.RecipientAddr) {
+		acntDst, err = f.loadBatchAccount(call.RecipientAddr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	vmOutput, err := function.ProcessBuiltinFunction(acntSnd, acntDst, call)
+	if err != nil {
+		return nil, err
+	}
+	if vmOutput == nil {
+		return nil, ErrBatchCallFailed
+	}
+	if vmOutput.ReturnCode != vmcommon.Ok {
+		err = fmt.Errorf("%w, return code %s, %s", ErrBatchCallFailed, vmOutput.ReturnCode.String(), vmOutput.ReturnMessage)
+		vmcommon.ReleaseVMOutput(vmOutput)
+		return nil, err
+	}
+
+	err = f.accounts.SaveAccount(acntSnd)
+	if err != nil {
+		vmcommon.ReleaseVMOutput(vmOutput)
+		return nil, err
+	}
+	if !check.IfNil(acntDst) && acntDst != acntSnd {
+		err = f.accounts.SaveAccount(acntDst)
+		if err != nil {
+			vmcommon.ReleaseVMOutput(vmOutput)
+			return nil, err
+		}
+	}
+
+	return vmOutput, nil
+}
+
+func (f *functionContainer) loadBatchAccount(address []byte) (vmcommon.UserAccountHandler, error) {
+	account, err := f.accounts.LoadAccount(address)
+	if err != nil {
+		return nil, err
+	}
+
+	userAccount, ok := account.(vmcommon.UserAccountHandler)
+	if !ok {
+		return nil, ErrWrongTypeAssertion
+	}
+
+	return userAccount, nil
+}
+
+func (f *functionContainer) revertBatch(snapshot int, callErr error) error {
+	// the caches might hold values written by the reverted calls
+	f.nonceCache.Clear()
+	f.accountCache.Clear()
+
+	err := f.accounts.RevertToSnapshot(snapshot)
+	if err != nil {
+		return fmt.Errorf("%w, reverting the batch failed: %s", callErr, err.Error())
+	}
+
+	return callErr
+}
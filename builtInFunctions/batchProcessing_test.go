@@ -0,0 +1,292 @@
+package builtInFunctions
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/Reshusk23/sr-me-core/core/check"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type batchAccountsForTest struct {
+	*mock.AccountsStub
+	numSaves     int
+	revertedTo   []int
+	loadedByAddr map[string]int
+}
+
+func createBatchAccountsForTest(journalLen int) *batchAccountsForTest {
+	accounts := createAccountsAdapterWithMap().(*mock.AccountsStub)
+	batchAccounts := &batchAccountsForTest{
+		AccountsStub: accounts,
+		loadedByAddr: make(map[string]int),
+	}
+
+	loadAccount := accounts.LoadAccountCalled
+	accounts.LoadAccountCalled = func(address []byte) (vmcommon.AccountHandler, error) {
+		batchAccounts.loadedByAddr[string(address)]++
+		return loadAccount(address)
+	}
+	saveAccount := accounts.SaveAccountCalled
+	accounts.SaveAccountCalled = func(account vmcommon.AccountHandler) error {
+		batchAccounts.numSaves++
+		return saveAccount(account)
+	}
+	accounts.JournalLenCalled = func() int {
+		return journalLen
+	}
+	accounts.RevertToSnapshotCalled = func(snapshot int) error {
+		batchAccounts.revertedTo = append(batchAccounts.revertedTo, snapshot)
+		return nil
+	}
+
+	return batchAccounts
+}
+
+func createBatchCall(function string, caller string, recipient string) *vmcommon.ContractCallInput {
+	return &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr: []byte(caller),
+			CallValue:  big.NewInt(0),
+		},
+		RecipientAddr: []byte(recipient),
+		Function:      function,
+	}
+}
+
+func TestBuiltInFunctionContainer_SetAccountsAdapter(t *testing.T) {
+	t.Parallel()
+
+	c := NewBuiltInFunctionContainer()
+	_, err := c.ProcessBuiltinFunctions([]*vmcommon.ContractCallInput{})
+	assert.Equal(t, ErrBatchProcessingNotEnabled, err)
+
+	err = c.SetAccountsAdapter(nil, mock.NewMultiShardsCoordinatorMock(1))
+	assert.ErrorIs(t, err, ErrNilAccountsAdapter)
+
+	err = c.SetAccountsAdapter(createBatchAccountsForTest(0), nil)
+	assert.ErrorIs(t, err, ErrNilShardCoordinator)
+
+	err = c.SetAccountsAdapter(createBatchAccountsForTest(0), mock.NewMultiShardsCoordinatorMock(1))
+	assert.Nil(t, err)
+
+	vmOutputs, err := c.ProcessBuiltinFunctions([]*vmcommon.ContractCallInput{})
+	assert.Nil(t, err)
+	assert.Empty(t, vmOutputs)
+}
+
+func TestBuiltInFunctionContainer_ProcessBuiltinFunctionsValidatesAllCallsFirst(t *testing.T) {
+	t.Parallel()
+
+	numCalls := 0
+	function := &mock.BuiltInFunctionStub{
+		ProcessBuiltinFunctionCalled: func(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+			numCalls++
+			return &vmcommon.VMOutput{ReturnCode: vmcommon.Ok}, nil
+		},
+	}
+	inactiveFunction := &mock.BuiltInFunctionStub{
+		IsActiveCalled: func() bool {
+			return false
+		},
+	}
+	malformedFunction := &mock.BuiltInFunctionStub{
+		CheckIsExecutableCalled: func(vmInput *vmcommon.ContractCallInput) error {
+			return ErrInvalidArguments
+		},
+	}
+	accounts := createBatchAccountsForTest(0)
+	c := NewBuiltInFunctionContainer()
+	_ = c.Add("function", function)
+	_ = c.Add("inactive", inactiveFunction)
+	_ = c.Add("malformed", malformedFunction)
+	_ = c.SetAccountsAdapter(accounts, mock.NewMultiShardsCoordinatorMock(1))
+
+	_, err := c.ProcessBuiltinFunctions([]*vmcommon.ContractCallInput{createBatchCall("function", "caller", "caller"), nil})
+	assert.ErrorIs(t, err, ErrNilVmInput)
+	assert.Contains(t, err.Error(), "call 1")
+
+	_, err = c.ProcessBuiltinFunctions([]*vmcommon.ContractCallInput{createBatchCall("function", "caller", "caller"), createBatchCall("missing", "caller", "caller")})
+	assert.ErrorIs(t, err, ErrInvalidContainerKey)
+
+	_, err = c.ProcessBuiltinFunctions([]*vmcommon.ContractCallInput{createBatchCall("function", "caller", "caller"), createBatchCall("inactive", "caller", "caller")})
+	assert.ErrorIs(t, err, ErrBuiltInFunctionNotActive)
+
+	_, err = c.ProcessBuiltinFunctions([]*vmcommon.ContractCallInput{createBatchCall("function", "caller", "caller"), createBatchCall("malformed", "caller", "caller")})
+	assert.ErrorIs(t, err, ErrInvalidArguments)
+	assert.Contains(t, err.Error(), "call 1")
+
+	assert.Equal(t, 0, numCalls)
+	assert.Empty(t, accounts.loadedByAddr)
+	assert.Equal(t, 0, accounts.numSaves)
+	assert.Empty(t, accounts.revertedTo)
+}
+
+func TestBuiltInFunctionContainer_ProcessBuiltinFunctionsShouldWork(t *testing.T) {
+	t.Parallel()
+
+	key := []byte("key")
+	function := &mock.BuiltInFunctionStub{
+		ProcessBuiltinFunctionCalled: func(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+			previous, _, _ := acntDst.AccountDataHandler().RetrieveValue(key)
+			_ = acntDst.AccountDataHandler().SaveKeyValue(key, append(previous, 1))
+			return &vmcommon.VMOutput{ReturnCode: vmcommon.Ok, ReturnMessage: string(vmInput.CallerAddr)}, nil
+		},
+	}
+	accounts := createBatchAccountsForTest(0)
+	c := NewBuiltInFunctionContainer()
+	_ = c.Add("function", function)
+	_ = c.SetAccountsAdapter(accounts, mock.NewMultiShardsCoordinatorMock(1))
+
+	vmOutputs, err := c.ProcessBuiltinFunctions([]*vmcommon.ContractCallInput{
+		createBatchCall("function", "caller", "caller"),
+		createBatchCall("function", "other", "caller"),
+	})
+	require.Nil(t, err)
+	require.Len(t, vmOutputs, 2)
+	assert.Equal(t, "caller", vmOutputs[0].ReturnMessage)
+	assert.Equal(t, "other", vmOutputs[1].ReturnMessage)
+	assert.Equal(t, 2, accounts.loadedByAddr["caller"])
+	assert.Equal(t, 1, accounts.loadedByAddr["other"])
+	assert.Equal(t, 3, accounts.numSaves)
+	assert.Empty(t, accounts.revertedTo)
+
+	account, _ := accounts.LoadAccount([]byte("caller"))
+	value, _, _ := account.(vmcommon.UserAccountHandler).AccountDataHandler().RetrieveValue(key)
+	assert.Equal(t, []byte{1, 1}, value)
+}
+
+func TestBuiltInFunctionContainer_ProcessBuiltinFunctionsShouldRevertOnFailure(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("expected error")
+	numCalls := 0
+	function := &mock.BuiltInFunctionStub{
+		ProcessBuiltinFunctionCalled: func(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+			numCalls++
+			return &vmcommon.VMOutput{ReturnCode: vmcommon.Ok}, nil
+		},
+	}
+
+	t.Run("call error", func(t *testing.T) {
+		t.Parallel()
+
+		accounts := createBatchAccountsForTest(7)
+		c := NewBuiltInFunctionContainer()
+		_ = c.Add("function", &mock.BuiltInFunctionStub{})
+		_ = c.Add("failing", createFunctionStubReturningError(expectedErr))
+		_ = c.SetAccountsAdapter(accounts, mock.NewMultiShardsCoordinatorMock(1))
+
+		vmOutputs, err := c.ProcessBuiltinFunctions([]*vmcommon.ContractCallInput{
+			createBatchCall("function", "caller", "caller"),
+			createBatchCall("failing", "caller", "caller"),
+			createBatchCall("function", "caller", "caller"),
+		})
+		assert.Nil(t, vmOutputs)
+		assert.ErrorIs(t, err, expectedErr)
+		assert.Contains(t, err.Error(), "call 1")
+		assert.Equal(t, []int{7}, accounts.revertedTo)
+		assert.Equal(t, 1, accounts.numSaves)
+	})
+	t.Run("user error returned as vm output", func(t *testing.T) {
+		t.Parallel()
+
+		accounts := createBatchAccountsForTest(3)
+		c := NewBuiltInFunctionContainer()
+		c.SetUserErrorsAsVMOutputs(true)
+		_ = c.Add("function", function)
+		_ = c.Add("failing", createFunctionStubReturningError(ErrInvalidArguments))
+		_ = c.SetAccountsAdapter(accounts, mock.NewMultiShardsCoordinatorMock(1))
+
+		_, err := c.ProcessBuiltinFunctions([]*vmcommon.ContractCallInput{
+			createBatchCall("function", "caller", "caller"),
+			createBatchCall("failing", "caller", "caller"),
+		})
+		assert.ErrorIs(t, err, ErrBatchCallFailed)
+		assert.Equal(t, []int{3}, accounts.revertedTo)
+	})
+	t.Run("revert error", func(t *testing.T) {
+		t.Parallel()
+
+		accounts := createBatchAccountsForTest(0)
+		accounts.RevertToSnapshotCalled = func(snapshot int) error {
+			return errors.New("revert error")
+		}
+		c := NewBuiltInFunctionContainer()
+		_ = c.Add("failing", createFunctionStubReturningError(expectedErr))
+		_ = c.SetAccountsAdapter(accounts, mock.NewMultiShardsCoordinatorMock(1))
+
+		_, err := c.ProcessBuiltinFunctions([]*vmcommon.ContractCallInput{createBatchCall("failing", "caller", "caller")})
+		assert.ErrorIs(t, err, expectedErr)
+		assert.Contains(t, err.Error(), "revert error")
+	})
+}
+
+func TestBuiltInFunctionContainer_ProcessBuiltinFunctionsShouldLoadOnlyTheAccountsOfTheShard(t *testing.T) {
+	t.Parallel()
+
+	shardCoordinator := &mock.ShardCoordinatorStub{
+		ComputeIdCalled: func(address []byte) uint32 {
+			if string(address) == "remote" {
+				return 1
+			}
+			return 0
+		},
+	}
+	shardCoordinator.SameShardCalled = func(firstAddress, secondAddress []byte) bool {
+		return shardCoordinator.ComputeId(firstAddress) == shardCoordinator.ComputeId(secondAddress)
+	}
+
+	var receivedDst vmcommon.UserAccountHandler
+	function := &mock.BuiltInFunctionStub{
+		ProcessBuiltinFunctionCalled: func(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+			receivedDst = acntDst
+			return &vmcommon.VMOutput{ReturnCode: vmcommon.Ok}, nil
+		},
+	}
+	accounts := createBatchAccountsForTest(0)
+	c := NewBuiltInFunctionContainer()
+	_ = c.Add("function", function)
+	_ = c.SetAccountsAdapter(accounts, shardCoordinator)
+
+	_, err := c.ProcessBuiltinFunctions([]*vmcommon.ContractCallInput{createBatchCall("function", "remote", "caller")})
+	assert.ErrorIs(t, err, ErrBatchCallerInAnotherShard)
+	assert.Equal(t, 0, accounts.loadedByAddr["remote"])
+
+	vmOutputs, err := c.ProcessBuiltinFunctions([]*vmcommon.ContractCallInput{createBatchCall("function", "caller", "remote")})
+	require.Nil(t, err)
+	require.Len(t, vmOutputs, 1)
+	assert.True(t, check.IfNil(receivedDst))
+	assert.Equal(t, 0, accounts.loadedByAddr["remote"])
+	assert.Equal(t, 1, accounts.numSaves)
+}
+
+func TestBuiltInFunctionContainer_ProcessBuiltinFunctionsRevertShouldClearTheCaches(t *testing.T) {
+	t.Parallel()
+
+	accounts := createBatchAccountsForTest(0)
+	c := NewBuiltInFunctionContainer()
+	_ = c.Add("failing", createFunctionStubReturningError(errors.New("expected error")))
+	_ = c.SetAccountsAdapter(accounts, mock.NewMultiShardsCoordinatorMock(1))
+
+	assert.Equal(t, ErrNilLatestNonceCache, c.SetLatestNonceCache(nil))
+	assert.Equal(t, ErrNilAccountCache, c.SetAccountCache(nil))
+
+	nonceCache, _ := NewLatestNonceCache(&mock.RoundNotifierStub{})
+	accountCache, _ := NewAccountCache(10, &mock.RoundNotifierStub{})
+	require.Nil(t, c.SetLatestNonceCache(nonceCache))
+	require.Nil(t, c.SetAccountCache(accountCache))
+
+	nonceCache.Put([]byte("caller"), []byte("token"), 5)
+	accountCache.Put(mock.NewUserAccount([]byte("caller")))
+
+	_, err := c.ProcessBuiltinFunctions([]*vmcommon.ContractCallInput{createBatchCall("failing", "caller", "caller")})
+	assert.NotNil(t, err)
+	_, found := nonceCache.Get([]byte("caller"), []byte("token"))
+	assert.False(t, found)
+	assert.Equal(t, 0, accountCache.Len())
+}
@@ -0,0 +1,73 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"math/big"
+	"math/bits"
+	"sync"
+)
+
+const maxBytesInUint64 = 8
+
+var bigIntPool = sync.Pool{
+	New: func() interface{} {
+		return big.NewInt(0)
+	},
+}
+
+// getBigInt returns a big int set to zero, reused from the pool whenever possible. The value is meant for scratch
+// computations only: the caller must give it back with putBigInt and must not keep it, or store it in an account,
+// a token or an output, as the next getBigInt call may hand the same instance to another caller
+func getBigInt() *big.Int {
+	return bigIntPool.Get().(*big.Int).SetUint64(0)
+}
+
+// putBigInt gives the provided big int back to the pool, nil values being ignored. The caller must not use the value
+// afterwards, nor give back a value it did not take with getBigInt, as somebody else may still reference it
+func putBigInt(value *big.Int) {
+	if value == nil {
+		return
+	}
+
+	bigIntPool.Put(value)
+}
+
+// bytesToUint64 interprets the provided big endian bytes as an unsigned integer and keeps only its lowest 64 bits,
+// returning the same value as big.NewInt(0).SetBytes(buff).Uint64() without allocating. Empty or nil bytes give 0
+func bytesToUint64(buff []byte) uint64 {
+	if len(buff) > maxBytesInUint64 {
+		buff = buff[len(buff)-maxBytesInUint64:]
+	}
+
+	value := uint64(0)
+	for _, b := range buff {
+		value = value<<8 | uint64(b)
+	}
+
+	return value
+}
+
+// bytesToNonce interprets the provided big endian bytes as a nonce. Unlike bytesToUint64 it never truncates: leading
+// zero bytes are ignored and ErrNonceOverflow is returned if the value does not fit an uint64
+func bytesToNonce(buff []byte) (uint64, error) {
+	significant := bytes.TrimLeft(buff, "\x00")
+	if len(significant) > maxBytesInUint64 {
+		return 0, ErrNonceOverflow
+	}
+
+	return bytesToUint64(significant), nil
+}
+
+// uint64ToBytes returns the minimal big endian representation of the provided value, without leading zero bytes, so
+// the result equals big.NewInt(0).SetUint64(value).Bytes() and is empty for 0. The returned slice is newly allocated
+// and may be kept by the caller
+func uint64ToBytes(value uint64) []byte {
+	numBytes := (bits.Len64(value) + 7) / 8
+	buff := make([]byte, numBytes)
+	for i := numBytes - 1; i >= 0; i-- {
+		buff[i] = byte(value)
+		value >>= 8
+	}
+
+	return buff
+}
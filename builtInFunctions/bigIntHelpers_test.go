@@ -0,0 +1,61 @@
+package builtInFunctions
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetBigInt_ShouldReturnZeroValue(t *testing.T) {
+	t.Parallel()
+
+	value := getBigInt()
+	value.SetUint64(1000)
+	putBigInt(value)
+	putBigInt(nil)
+
+	assert.Equal(t, 0, getBigInt().Cmp(zero))
+}
+
+func TestBytesToUint64_ShouldMatchBigIntConversion(t *testing.T) {
+	t.Parallel()
+
+	testValues := [][]byte{
+		nil,
+		{},
+		{0},
+		{1},
+		{1, 0},
+		{255, 255, 255, 255, 255, 255, 255, 255},
+		{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+	}
+	for _, buff := range testValues {
+		assert.Equal(t, big.NewInt(0).SetBytes(buff).Uint64(), bytesToUint64(buff), "value %v", buff)
+	}
+}
+
+func TestUint64ToBytes_ShouldMatchBigIntConversion(t *testing.T) {
+	t.Parallel()
+
+	testValues := []uint64{0, 1, 255, 256, 65535, 1 << 40, math.MaxUint64}
+	for _, value := range testValues {
+		assert.Equal(t, big.NewInt(0).SetUint64(value).Bytes(), uint64ToBytes(value), "value %d", value)
+	}
+}
+
+func TestBytesToNonce(t *testing.T) {
+	t.Parallel()
+
+	nonce, err := bytesToNonce(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0), nonce)
+
+	nonce, err = bytesToNonce([]byte{0, 0, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff})
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(math.MaxUint64), nonce)
+
+	_, err = bytesToNonce([]byte{1, 0, 0, 0, 0, 0, 0, 0, 0})
+	assert.Equal(t, ErrNonceOverflow, err)
+}
@@ -0,0 +1,90 @@
+package builtInFunctions
+
+import (
+	"context"
+
+	"github.com/Reshusk23/sr-me-core/core/check"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+)
+
+var _ vmcommon.BuiltinFunctionV2 = (*builtinFunctionV2Adapter)(nil)
+
+// contextAwareFunction is implemented by the built-in functions, and by the function wrappers, able to stop processing
+// when the context is done
+type contextAwareFunction interface {
+	processBuiltinFunctionWithContext(
+		ctx context.Context,
+		acntSnd, acntDst vmcommon.UserAccountHandler,
+		vmInput *vmcommon.ContractCallInput,
+	) (*vmcommon.VMOutput, error)
+}
+
+// builtinFunctionV2Adapter exposes a built-in function through the context aware interface. The functions which are
+// not context aware are only checked against the context before being called
+type builtinFunctionV2Adapter struct {
+	function vmcommon.BuiltinFunction
+}
+
+// NewBuiltinFunctionV2Adapter creates a context aware built-in function out of the provided built-in function
+func NewBuiltinFunctionV2Adapter(function vmcommon.BuiltinFunction) (*builtinFunctionV2Adapter, error) {
+	if check.IfNil(function) {
+		return nil, ErrNilBuiltInFunction
+	}
+
+	return &builtinFunctionV2Adapter{
+		function: function,
+	}, nil
+}
+
+// ProcessBuiltinFunction processes the built-in function, returning the context error if the context is done before
+// the processing ends
+func (adapter *builtinFunctionV2Adapter) ProcessBuiltinFunction(
+	ctx context.Context,
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	if ctx == nil {
+		return nil, ErrNilContext
+	}
+
+	return callWithContext(ctx, adapter.function, acntSnd, acntDst, vmInput)
+}
+
+// CheckIsExecutable forwards the stateless validation to the adapted function
+func (adapter *builtinFunctionV2Adapter) CheckIsExecutable(vmInput *vmcommon.ContractCallInput) error {
+	return checkIsExecutable(adapter.function, vmInput)
+}
+
+// SetNewGasConfig is called whenever gas cost is changed
+func (adapter *builtinFunctionV2Adapter) SetNewGasConfig(gasCost *vmcommon.GasCost) {
+	adapter.function.SetNewGasConfig(gasCost)
+}
+
+// IsActive returns true if the adapted function is active
+func (adapter *builtinFunctionV2Adapter) IsActive() bool {
+	return adapter.function.IsActive()
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (adapter *builtinFunctionV2Adapter) IsInterfaceNil() bool {
+	return adapter == nil
+}
+
+func callWithContext(
+	ctx context.Context,
+	function vmcommon.BuiltinFunction,
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	err := ctx.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	contextAware, ok := function.(contextAwareFunction)
+	if ok {
+		return contextAware.processBuiltinFunctionWithContext(ctx, acntSnd, acntDst, vmInput)
+	}
+
+	return function.ProcessBuiltinFunction(acntSnd, acntDst, vmInput)
+}
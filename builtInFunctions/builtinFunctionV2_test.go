@@ -0,0 +1,168 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/Reshusk23/sr-me-core/core"
+	"github.com/Reshusk23/sr-me-core/core/check"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// contextCancelledAfter reports the context as cancelled after the provided number of checks
+type contextCancelledAfter struct {
+	context.Context
+	numChecks int
+}
+
+func (ctx *contextCancelledAfter) Err() error {
+	if ctx.numChecks == 0 {
+		return context.Canceled
+	}
+	ctx.numChecks--
+
+	return nil
+}
+
+type contextKey struct{}
+
+type contextAwareFunctionStub struct {
+	mock.BuiltInFunctionStub
+	receivedContext context.Context
+}
+
+func (stub *contextAwareFunctionStub) processBuiltinFunctionWithContext(
+	ctx context.Context,
+	_, _ vmcommon.UserAccountHandler,
+	_ *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	stub.receivedContext = ctx
+	return &vmcommon.VMOutput{ReturnCode: vmcommon.Ok}, nil
+}
+
+func TestNewBuiltinFunctionV2Adapter(t *testing.T) {
+	t.Parallel()
+
+	adapter, err := NewBuiltinFunctionV2Adapter(nil)
+	assert.Equal(t, ErrNilBuiltInFunction, err)
+	assert.True(t, check.IfNil(adapter))
+
+	gasConfigSet := false
+	adapter, err = NewBuiltinFunctionV2Adapter(&mock.BuiltInFunctionStub{
+		SetNewGasConfigCalled: func(gasCost *vmcommon.GasCost) {
+			gasConfigSet = true
+		},
+		IsActiveCalled: func() bool {
+			return true
+		},
+	})
+	assert.Nil(t, err)
+	assert.False(t, check.IfNil(adapter))
+	assert.True(t, adapter.IsActive())
+
+	adapter.SetNewGasConfig(&vmcommon.GasCost{})
+	assert.True(t, gasConfigSet)
+}
+
+func TestBuiltinFunctionV2Adapter_ProcessBuiltinFunction(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil context should err", func(t *testing.T) {
+		t.Parallel()
+
+		adapter, _ := NewBuiltinFunctionV2Adapter(createFunctionStubReturning("message"))
+		var ctx context.Context
+		vmOutput, err := adapter.ProcessBuiltinFunction(ctx, nil, nil, &vmcommon.ContractCallInput{})
+		assert.Nil(t, vmOutput)
+		assert.Equal(t, ErrNilContext, err)
+	})
+	t.Run("done context should not call the function", func(t *testing.T) {
+		t.Parallel()
+
+		wasCalled := false
+		adapter, _ := NewBuiltinFunctionV2Adapter(&mock.BuiltInFunctionStub{
+			ProcessBuiltinFunctionCalled: func(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+				wasCalled = true
+				return &vmcommon.VMOutput{}, nil
+			},
+		})
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		vmOutput, err := adapter.ProcessBuiltinFunction(ctx, nil, nil, &vmcommon.ContractCallInput{})
+		assert.Nil(t, vmOutput)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.False(t, wasCalled)
+	})
+	t.Run("function should be called", func(t *testing.T) {
+		t.Parallel()
+
+		adapter, _ := NewBuiltinFunctionV2Adapter(createFunctionStubReturning("message"))
+		vmOutput, err := adapter.ProcessBuiltinFunction(context.Background(), nil, nil, &vmcommon.ContractCallInput{})
+		assert.Nil(t, err)
+		assert.Equal(t, "message", vmOutput.ReturnMessage)
+	})
+	t.Run("context should reach the function through the container wrappers", func(t *testing.T) {
+		t.Parallel()
+
+		function := &contextAwareFunctionStub{}
+		c := NewBuiltInFunctionContainer()
+		_ = c.Add("key", function)
+		_ = c.SetMetrics(&mock.MetricsStub{})
+		c.SetUserErrorsAsVMOutputs(true)
+		c.SetLimitsConfig(vmcommon.LimitsConfig{MaxNumArguments: 10})
+
+		wrapped, _ := c.Get("key")
+		adapter, _ := NewBuiltinFunctionV2Adapter(wrapped)
+		ctx := context.WithValue(context.Background(), contextKey{}, "value")
+
+		_, err := adapter.ProcessBuiltinFunction(ctx, nil, nil, &vmcommon.ContractCallInput{})
+		assert.Nil(t, err)
+		assert.Equal(t, ctx, function.receivedContext)
+	})
+}
+
+func TestDCTNFTMultiTransfer_ProcessBuiltinFunctionWithContextShouldStopBetweenTransfers(t *testing.T) {
+	t.Parallel()
+
+	multiTransfer := createDCTNFTMultiTransferWithMockArguments(0, 1, &mock.GlobalSettingsHandlerStub{})
+	_ = multiTransfer.SetPayableChecker(&mock.PayableHandlerStub{})
+	senderAddress := bytes.Repeat([]byte{2}, 32)
+	destinationAddress := bytes.Repeat([]byte{0}, 32)
+	destinationAddress[25] = 1
+	sender, _ := multiTransfer.accounts.LoadAccount(senderAddress)
+
+	token1 := []byte("token1")
+	token2 := []byte("token2")
+	initialTokens := big.NewInt(3)
+	createDCTNFTToken(token1, core.Fungible, 0, initialTokens, multiTransfer.marshaller, sender.(vmcommon.UserAccountHandler))
+	createDCTNFTToken(token2, core.Fungible, 0, initialTokens, multiTransfer.marshaller, sender.(vmcommon.UserAccountHandler))
+
+	quantityBytes := big.NewInt(1).Bytes()
+	vmInput := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallValue:   big.NewInt(0),
+			CallerAddr:  senderAddress,
+			Arguments:   [][]byte{destinationAddress, big.NewInt(2).Bytes(), token1, {}, quantityBytes, token2, {}, quantityBytes},
+			GasProvided: 100000,
+		},
+		RecipientAddr: senderAddress,
+	}
+
+	ctx := &contextCancelledAfter{Context: context.Background(), numChecks: 1}
+	vmOutput, err := multiTransfer.processBuiltinFunctionWithContext(ctx, sender.(vmcommon.UserAccountHandler), nil, vmInput)
+	assert.Nil(t, vmOutput)
+	assert.Equal(t, context.Canceled, err)
+
+	testNFTTokenShouldExist(t, multiTransfer.marshaller, sender, token1, 0, big.NewInt(2))
+	testNFTTokenShouldExist(t, multiTransfer.marshaller, sender, token2, 0, initialTokens)
+
+	vmOutput, err = multiTransfer.processBuiltinFunctionWithContext(context.Background(), sender.(vmcommon.UserAccountHandler), nil, vmInput)
+	require.Nil(t, err)
+	assert.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+}
@@ -0,0 +1,89 @@
+package builtInFunctions
+
+import (
+	"context"
+	"math/big"
+
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+)
+
+// callValuePolicyFunction wraps a built-in function and rejects the calls not complying with the call value policy
+// declared for it before the wrapped function processes them
+type callValuePolicyFunction struct {
+	baseFunctionWrapper
+	policy vmcommon.CallValuePolicy
+}
+
+func newCallValuePolicyFunction(function vmcommon.BuiltinFunction, policy vmcommon.CallValuePolicy) *callValuePolicyFunction {
+	return &callValuePolicyFunction{
+		baseFunctionWrapper: baseFunctionWrapper{function: function},
+		policy:              policy,
+	}
+}
+
+// ProcessBuiltinFunction checks the call value against the policy and then calls the wrapped function
+func (cvf *callValuePolicyFunction) ProcessBuiltinFunction(
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	return cvf.processBuiltinFunctionWithContext(context.Background(), acntSnd, acntDst, vmInput)
+}
+
+func (cvf *callValuePolicyFunction) processBuiltinFunctionWithContext(
+	ctx context.Context,
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	if vmInput != nil {
+		err := checkCallValue(cvf.policy, vmInput.CallValue)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return callWithContext(ctx, cvf.function, acntSnd, acntDst, vmInput)
+}
+
+// CheckIsExecutable checks the call value against the policy and then forwards the validation to the wrapped function
+func (cvf *callValuePolicyFunction) CheckIsExecutable(vmInput *vmcommon.ContractCallInput) error {
+	if vmInput != nil {
+		err := checkCallValue(cvf.policy, vmInput.CallValue)
+		if err != nil {
+			return err
+		}
+	}
+
+	return checkIsExecutable(cvf.function, vmInput)
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (cvf *callValuePolicyFunction) IsInterfaceNil() bool {
+	return cvf == nil
+}
+
+// checkCallValue checks the native value of a call against the provided policy
+func checkCallValue(policy vmcommon.CallValuePolicy, callValue *big.Int) error {
+	if callValue == nil {
+		return ErrNilValue
+	}
+
+	switch policy {
+	case vmcommon.CallValueAllowed:
+		if callValue.Sign() < 0 {
+			return ErrNegativeValue
+		}
+	case vmcommon.CallValueRequired:
+		if callValue.Sign() < 0 {
+			return ErrNegativeValue
+		}
+		if callValue.Sign() == 0 {
+			return ErrCallValueRequired
+		}
+	default:
+		if callValue.Sign() != 0 {
+			return ErrBuiltInFunctionCalledWithValue
+		}
+	}
+
+	return nil
+}
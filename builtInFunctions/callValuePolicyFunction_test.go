@@ -0,0 +1,109 @@
+package builtInFunctions
+
+import (
+	"math/big"
+	"testing"
+
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func unwrapCallValuePolicy(function vmcommon.BuiltinFunction) vmcommon.BuiltinFunction {
+	wrapped, ok := function.(*callValuePolicyFunction)
+	if !ok {
+		return function
+	}
+
+	return wrapped.function
+}
+
+func TestCheckCallValue(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, ErrNilValue, checkCallValue(vmcommon.CallValueAllowed, nil))
+
+	assert.Nil(t, checkCallValue(vmcommon.CallValueForbidden, big.NewInt(0)))
+	assert.Equal(t, ErrBuiltInFunctionCalledWithValue, checkCallValue(vmcommon.CallValueForbidden, big.NewInt(1)))
+	assert.Equal(t, ErrBuiltInFunctionCalledWithValue, checkCallValue(vmcommon.CallValueForbidden, big.NewInt(-1)))
+
+	assert.Nil(t, checkCallValue(vmcommon.CallValueAllowed, big.NewInt(0)))
+	assert.Nil(t, checkCallValue(vmcommon.CallValueAllowed, big.NewInt(1)))
+	assert.Equal(t, ErrNegativeValue, checkCallValue(vmcommon.CallValueAllowed, big.NewInt(-1)))
+
+	assert.Equal(t, ErrCallValueRequired, checkCallValue(vmcommon.CallValueRequired, big.NewInt(0)))
+	assert.Nil(t, checkCallValue(vmcommon.CallValueRequired, big.NewInt(1)))
+	assert.Equal(t, ErrNegativeValue, checkCallValue(vmcommon.CallValueRequired, big.NewInt(-1)))
+}
+
+func TestCallValuePolicyFunction_ProcessBuiltinFunction(t *testing.T) {
+	t.Parallel()
+
+	numCalls := 0
+	function := &mock.BuiltInFunctionStub{
+		ProcessBuiltinFunctionCalled: func(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+			numCalls++
+			return &vmcommon.VMOutput{}, nil
+		},
+	}
+	cvf := newCallValuePolicyFunction(function, vmcommon.CallValueRequired)
+
+	_, err := cvf.ProcessBuiltinFunction(nil, nil, &vmcommon.ContractCallInput{VMInput: vmcommon.VMInput{CallValue: big.NewInt(0)}})
+	assert.Equal(t, ErrCallValueRequired, err)
+	assert.Equal(t, 0, numCalls)
+
+	vmOutput, err := cvf.ProcessBuiltinFunction(nil, nil, &vmcommon.ContractCallInput{VMInput: vmcommon.VMInput{CallValue: big.NewInt(5)}})
+	require.Nil(t, err)
+	assert.NotNil(t, vmOutput)
+	assert.Equal(t, 1, numCalls)
+
+	_, _ = cvf.ProcessBuiltinFunction(nil, nil, nil)
+	assert.Equal(t, 2, numCalls)
+}
+
+func TestCallValuePolicyFunction_CheckIsExecutable(t *testing.T) {
+	t.Parallel()
+
+	numCalls := 0
+	cvf := newCallValuePolicyFunction(&mock.BuiltInFunctionStub{
+		CheckIsExecutableCalled: func(vmInput *vmcommon.ContractCallInput) error {
+			numCalls++
+			return nil
+		},
+	}, vmcommon.CallValueForbidden)
+
+	err := cvf.CheckIsExecutable(&vmcommon.ContractCallInput{VMInput: vmcommon.VMInput{CallValue: big.NewInt(5)}})
+	assert.Equal(t, ErrBuiltInFunctionCalledWithValue, err)
+	assert.Equal(t, 0, numCalls)
+
+	err = cvf.CheckIsExecutable(&vmcommon.ContractCallInput{VMInput: vmcommon.VMInput{CallValue: big.NewInt(0)}})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, numCalls)
+}
+
+func TestBuiltInFunctionContainer_SetCallValuePolicy(t *testing.T) {
+	t.Parallel()
+
+	function := createFunctionStubReturning("message")
+	c := NewBuiltInFunctionContainer()
+	_ = c.Add("key", function)
+
+	_, found := c.CallValuePolicy("key")
+	assert.False(t, found)
+	valRecovered, _ := c.Get("key")
+	assert.True(t, unwrapExecutionGuard(valRecovered) == function)
+
+	c.SetCallValuePolicy("key", vmcommon.CallValueForbidden)
+	policy, found := c.CallValuePolicy("key")
+	assert.True(t, found)
+	assert.Equal(t, vmcommon.CallValueForbidden, policy)
+
+	valRecovered, _ = c.Get("key")
+	_, err := valRecovered.ProcessBuiltinFunction(nil, nil, &vmcommon.ContractCallInput{VMInput: vmcommon.VMInput{CallValue: big.NewInt(1)}})
+	assert.Equal(t, ErrBuiltInFunctionCalledWithValue, err)
+
+	c.Remove("key")
+	_, found = c.CallValuePolicy("key")
+	assert.False(t, found)
+}
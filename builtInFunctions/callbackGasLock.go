@@ -0,0 +1,31 @@
+package builtInFunctions
+
+import (
+	"github.com/Reshusk23/sr-me-core/data/vm"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+)
+
+// lockGasForCallback returns the gas to be forwarded with a transfer followed by a smart contract call together with
+// the gas locked for the callback. Asynchronous calls lock at least the amount required by the gas schedule, the part
+// not already locked by the host being taken out of the forwarded gas.
+func lockGasForCallback(
+	vmInput *vmcommon.ContractCallInput,
+	gasToForward uint64,
+	asyncCallbackCost vmcommon.AsyncCallbackCost,
+) (uint64, uint64, error) {
+	if vmInput.CallType != vm.AsynchronousCall {
+		return gasToForward, vmInput.GasLocked, nil
+	}
+
+	gasToLock := vmcommon.ComputeGasLockedForCallback(vmInput.GasProvided, asyncCallbackCost)
+	if vmInput.GasLocked >= gasToLock {
+		return gasToForward, vmInput.GasLocked, nil
+	}
+
+	gasToForward, err := vmcommon.SafeSubUint64(gasToForward, gasToLock-vmInput.GasLocked)
+	if err != nil {
+		return 0, 0, ErrNotEnoughGas
+	}
+
+	return gasToForward, gasToLock, nil
+}
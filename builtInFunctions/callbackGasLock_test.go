@@ -0,0 +1,60 @@
+package builtInFunctions
+
+import (
+	"testing"
+
+	"github.com/Reshusk23/sr-me-core/data/vm"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLockGasForCallback(t *testing.T) {
+	t.Parallel()
+
+	asyncCallbackCost := vmcommon.AsyncCallbackCost{
+		AsyncCallStep:        100,
+		AsyncCallbackGasLock: 900,
+	}
+	createInput := func(callType vm.CallType, gasLocked uint64) *vmcommon.ContractCallInput {
+		return &vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallType:    callType,
+				GasProvided: 10000,
+				GasLocked:   gasLocked,
+			},
+		}
+	}
+
+	t.Run("direct call should keep the host values", func(t *testing.T) {
+		t.Parallel()
+
+		gasToForward, gasLocked, err := lockGasForCallback(createInput(vm.DirectCall, 5), 9000, asyncCallbackCost)
+		assert.Nil(t, err)
+		assert.Equal(t, uint64(9000), gasToForward)
+		assert.Equal(t, uint64(5), gasLocked)
+	})
+	t.Run("enough gas locked by host should keep the host values", func(t *testing.T) {
+		t.Parallel()
+
+		gasToForward, gasLocked, err := lockGasForCallback(createInput(vm.AsynchronousCall, 2000), 9000, asyncCallbackCost)
+		assert.Nil(t, err)
+		assert.Equal(t, uint64(9000), gasToForward)
+		assert.Equal(t, uint64(2000), gasLocked)
+	})
+	t.Run("missing gas lock should be taken out of the forwarded gas", func(t *testing.T) {
+		t.Parallel()
+
+		gasToForward, gasLocked, err := lockGasForCallback(createInput(vm.AsynchronousCall, 400), 9000, asyncCallbackCost)
+		assert.Nil(t, err)
+		assert.Equal(t, uint64(8400), gasToForward)
+		assert.Equal(t, uint64(1000), gasLocked)
+	})
+	t.Run("not enough gas to forward should error", func(t *testing.T) {
+		t.Parallel()
+
+		gasToForward, gasLocked, err := lockGasForCallback(createInput(vm.AsynchronousCall, 0), 500, asyncCallbackCost)
+		assert.Equal(t, ErrNotEnoughGas, err)
+		assert.Equal(t, uint64(0), gasToForward)
+		assert.Equal(t, uint64(0), gasLocked)
+	})
+}
@@ -11,6 +11,7 @@ import (
 
 type changeOwnerAddress struct {
 	baseAlwaysActiveHandler
+	baseAddressLengthHandler
 	gasCost      uint64
 	mutExecution sync.RWMutex
 }
@@ -45,10 +46,11 @@ func (c *changeOwnerAddress) ProcessBuiltinFunction(
 	if len(vmInput.Arguments) == 0 {
 		return nil, ErrInvalidArguments
 	}
-	if vmInput.CallValue.Cmp(zero) != 0 {
-		return nil, ErrBuiltInFunctionCalledWithValue
+	err := checkCallValue(vmcommon.CallValueForbidden, vmInput.CallValue)
+	if err != nil {
+		return nil, err
 	}
-	if len(vmInput.Arguments[0]) != len(vmInput.CallerAddr) {
+	if len(vmInput.Arguments[0]) != c.getAddressLength(vmInput) {
 		return nil, ErrInvalidAddressLength
 	}
 	if vmInput.GasProvided < c.gasCost {
@@ -64,7 +66,7 @@ func (c *changeOwnerAddress) ProcessBuiltinFunction(
 		return nil, fmt.Errorf("%w not the owner of the account", ErrOperationNotPermitted)
 	}
 
-	err := acntDst.ChangeOwnerAddress(vmInput.CallerAddr, vmInput.Arguments[0])
+	err = acntDst.ChangeOwnerAddress(vmInput.CallerAddr, vmInput.Arguments[0])
 	if err != nil {
 		return nil, err
 	}
@@ -43,8 +43,9 @@ func (c *claimDeveloperRewards) ProcessBuiltinFunction(
 	if vmInput == nil {
 		return nil, ErrNilVmInput
 	}
-	if vmInput.CallValue.Cmp(zero) != 0 {
-		return nil, ErrBuiltInFunctionCalledWithValue
+	err := checkCallValue(vmcommon.CallValueForbidden, vmInput.CallValue)
+	if err != nil {
+		return nil, err
 	}
 	gasRemaining := computeGasRemaining(acntSnd, vmInput.GasProvided, c.gasCost)
 	if check.IfNil(acntDst) {
@@ -86,6 +87,7 @@ func (c *claimDeveloperRewards) ProcessBuiltinFunction(
 
 	vmOutput.OutputAccounts = make(map[string]*vmcommon.OutputAccount)
 	vmOutput.OutputAccounts[string(outputAcc.Address)] = outputAcc
+	addAsyncMetadataToVMOutput(vmInput.CurrentTxHash, vmInput.CallerAddr, outTransfer, vmOutput)
 
 	if check.IfNil(acntSnd) {
 		return vmOutput, nil
@@ -98,6 +100,7 @@ func (c *claimDeveloperRewards) ProcessBuiltinFunction(
 
 	if vmcommon.IsSmartContractAddress(vmInput.CallerAddr) {
 		vmOutput.OutputAccounts = make(map[string]*vmcommon.OutputAccount)
+		vmOutput.AsyncCallback = nil
 	}
 
 	return vmOutput, nil
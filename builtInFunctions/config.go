@@ -0,0 +1,75 @@
+package builtInFunctions
+
+import (
+	"fmt"
+
+	"github.com/Reshusk23/sr-me-core/core/check"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+)
+
+// configComponent selects the components of the Config needed by a built-in function
+type configComponent uint32
+
+const (
+	requireMarshalizer configComponent = 1 << iota
+	requireAccounts
+	requireShardCoordinator
+	requireGlobalSettingsHandler
+	requireRolesHandler
+	requireDCTStorageHandler
+	requireEnableEpochsHandler
+	requireEpochNotifier
+)
+
+// Config holds the components shared by the built-in functions of the package. It is embedded by the arguments of
+// the built-in functions, each one requiring only the components it uses, so a component added to a function does not
+// change its constructor
+type Config struct {
+	Marshalizer           vmcommon.Marshalizer
+	Accounts              vmcommon.AccountsAdapter
+	ShardCoordinator      vmcommon.Coordinator
+	GlobalSettingsHandler vmcommon.ExtendedDCTGlobalSettingsHandler
+	RolesHandler          vmcommon.DCTRoleHandler
+	DCTStorageHandler     vmcommon.DCTNFTStorageHandler
+	EnableEpochsHandler   vmcommon.EnableEpochsHandler
+	EpochNotifier         vmcommon.EpochNotifier
+}
+
+// checkComponents returns an error naming the built-in function if any of the required components is nil
+func (config *Config) checkComponents(function string, required configComponent) error {
+	err := config.checkRequired(required)
+	if err != nil {
+		return fmt.Errorf("%w in the config of %s", err, function)
+	}
+
+	return nil
+}
+
+func (config *Config) checkRequired(required configComponent) error {
+	if required&requireMarshalizer != 0 && check.IfNil(config.Marshalizer) {
+		return ErrNilMarshalizer
+	}
+	if required&requireAccounts != 0 && check.IfNil(config.Accounts) {
+		return ErrNilAccountsAdapter
+	}
+	if required&requireShardCoordinator != 0 && check.IfNil(config.ShardCoordinator) {
+		return ErrNilShardCoordinator
+	}
+	if required&requireGlobalSettingsHandler != 0 && check.IfNil(config.GlobalSettingsHandler) {
+		return ErrNilGlobalSettingsHandler
+	}
+	if required&requireRolesHandler != 0 && check.IfNil(config.RolesHandler) {
+		return ErrNilRolesHandler
+	}
+	if required&requireDCTStorageHandler != 0 && check.IfNil(config.DCTStorageHandler) {
+		return ErrNilDCTNFTStorageHandler
+	}
+	if required&requireEnableEpochsHandler != 0 && check.IfNil(config.EnableEpochsHandler) {
+		return ErrNilEnableEpochsHandler
+	}
+	if required&requireEpochNotifier != 0 && check.IfNil(config.EpochNotifier) {
+		return ErrNilEpochNotifier
+	}
+
+	return nil
+}
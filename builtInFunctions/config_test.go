@@ -0,0 +1,67 @@
+package builtInFunctions
+
+import (
+	"testing"
+
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func createMockConfig() Config {
+	return Config{
+		Marshalizer:           &mock.MarshalizerMock{},
+		Accounts:              &mock.AccountsStub{},
+		ShardCoordinator:      &mock.ShardCoordinatorStub{},
+		GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+		RolesHandler:          &mock.DCTRoleHandlerStub{},
+		DCTStorageHandler:     &mock.DCTNFTStorageHandlerStub{},
+		EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+		EpochNotifier:         &mock.EpochNotifierStub{},
+	}
+}
+
+func TestConfig_CheckComponents(t *testing.T) {
+	t.Parallel()
+
+	allComponents := requireMarshalizer | requireAccounts | requireShardCoordinator | requireGlobalSettingsHandler |
+		requireRolesHandler | requireDCTStorageHandler | requireEnableEpochsHandler | requireEpochNotifier
+
+	tests := []struct {
+		name        string
+		required    configComponent
+		setNil      func(config *Config)
+		expectedErr error
+	}{
+		{"nil marshaller", requireMarshalizer, func(config *Config) { config.Marshalizer = nil }, ErrNilMarshalizer},
+		{"nil accounts", requireAccounts, func(config *Config) { config.Accounts = nil }, ErrNilAccountsAdapter},
+		{"nil shard coordinator", requireShardCoordinator, func(config *Config) { config.ShardCoordinator = nil }, ErrNilShardCoordinator},
+		{"nil global settings handler", requireGlobalSettingsHandler, func(config *Config) { config.GlobalSettingsHandler = nil }, ErrNilGlobalSettingsHandler},
+		{"nil roles handler", requireRolesHandler, func(config *Config) { config.RolesHandler = nil }, ErrNilRolesHandler},
+		{"nil storage handler", requireDCTStorageHandler, func(config *Config) { config.DCTStorageHandler = nil }, ErrNilDCTNFTStorageHandler},
+		{"nil enable epochs handler", requireEnableEpochsHandler, func(config *Config) { config.EnableEpochsHandler = nil }, ErrNilEnableEpochsHandler},
+		{"nil epoch notifier", requireEpochNotifier, func(config *Config) { config.EpochNotifier = nil }, ErrNilEpochNotifier},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name+" should error only if required", func(t *testing.T) {
+			t.Parallel()
+
+			config := createMockConfig()
+			tt.setNil(&config)
+
+			err := config.checkComponents("function", allComponents)
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Equal(t, tt.expectedErr.Error()+" in the config of function", err.Error())
+
+			err = config.checkComponents("function", allComponents&^tt.required)
+			assert.Nil(t, err)
+		})
+	}
+
+	t.Run("all components set should work", func(t *testing.T) {
+		t.Parallel()
+
+		config := createMockConfig()
+		assert.Nil(t, config.checkComponents("function", allComponents))
+	})
+}
@@ -2,40 +2,402 @@ package builtInFunctions
 
 import (
 	"fmt"
+	"sync"
 
+	"github.com/Reshusk23/sr-me-core/core"
 	"github.com/Reshusk23/sr-me-core/core/check"
 	vmcommon "github.com/Reshusk23/sr-vm-common-go"
 	"github.com/Reshusk23/sr-vm-common-go/container"
 )
 
 var _ vmcommon.BuiltInFunctionContainer = (*functionContainer)(nil)
+var _ vmcommon.HistoricalReplayContainer = (*functionContainer)(nil)
+var _ vmcommon.GasConfigurableContainer = (*functionContainer)(nil)
+var _ vmcommon.CallValuePolicyContainer = (*functionContainer)(nil)
+var _ vmcommon.BatchProcessingContainer = (*functionContainer)(nil)
+var _ vmcommon.StatelessCheckContainer = (*functionContainer)(nil)
 
 // functionContainer is an interceptors holder organized by type
 type functionContainer struct {
-	objects *container.MutexMap
+	objects               *container.MutexMap
+	mutExecution          sync.RWMutex
+	mutWrappers           sync.RWMutex
+	metrics               vmcommon.Metrics
+	logPublisher          vmcommon.LogPublisher
+	functionResolver      vmcommon.FunctionResolver
+	quotaHandler          vmcommon.QuotaHandler
+	userErrorsAsVMOutputs bool
+	limits                vmcommon.LimitsConfig
+	outputAggregation     vmcommon.OutputTransferAggregationConfig
+	addressLength         int
+	shardFunctions        vmcommon.ShardFunctionsConfig
+	selfShardID           uint32
+	callValuePolicies     map[string]vmcommon.CallValuePolicy
+	logAddressFormat      vmcommon.LogAddressFormat
+	pubkeyConverter       core.PubkeyConverter
+	mutReplay             sync.Mutex
+	replayFactory         vmcommon.EnableEpochsHandlerFactory
+	replayHandler         *epochPinnedEnableEpochsHandler
+	mutBatch              sync.Mutex
+	accounts              vmcommon.AccountsAdapter
+	shardCoordinator      vmcommon.Coordinator
+	nonceCache            vmcommon.LatestNonceCache
+	accountCache          vmcommon.AccountCache
+	mutEpoch              sync.RWMutex
+	isEpochConfirmed      bool
+	currentEpoch          uint32
+	currentTimestamp      uint64
 }
 
 // NewBuiltInFunctionContainer will create a new instance of a container
 func NewBuiltInFunctionContainer() *functionContainer {
 	return &functionContainer{
-		objects: container.NewMutexMap(),
+		objects:           container.NewMutexMap(),
+		callValuePolicies: make(map[string]vmcommon.CallValuePolicy),
+		nonceCache:        &disabledLatestNonceCache{},
+		accountCache:      &disabledAccountCache{},
 	}
 }
 
-// Get returns the object stored at a certain key.
+// Get returns the object stored at a certain key, resolved to its canonical function when a function resolver is set.
 // Returns an error if the element does not exist
 func (f *functionContainer) Get(key string) (vmcommon.BuiltinFunction, error) {
+	key, function, err := f.getStored(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return f.wrapFunction(key, function), nil
+}
+
+func (f *functionContainer) getStored(key string) (string, vmcommon.BuiltinFunction, error) {
+	key = f.resolveFunction(key)
 	value, ok := f.objects.Get(key)
 	if !ok {
-		return nil, fmt.Errorf("%w in function container for key %v", ErrInvalidContainerKey, key)
+		return "", nil, fmt.Errorf("%w in function container for key %v", ErrInvalidContainerKey, key)
 	}
 
 	function, ok := value.(vmcommon.BuiltinFunction)
 	if !ok {
-		return nil, ErrWrongTypeInContainer
+		return "", nil, ErrWrongTypeInContainer
+	}
+
+	return key, function, nil
+}
+
+func (f *functionContainer) resolveFunction(key string) string {
+	f.mutWrappers.RLock()
+	defer f.mutWrappers.RUnlock()
+
+	if check.IfNil(f.functionResolver) {
+		return key
+	}
+
+	function, _ := f.functionResolver.ResolveFunction(key)
+	return function
+}
+
+func (f *functionContainer) wrapFunction(key string, function vmcommon.BuiltinFunction) vmcommon.BuiltinFunction {
+	return newExecutionGuardFunction(f.decorateFunction(key, function), &f.mutExecution)
+}
+
+// decorateFunction applies all the configured wrappers except the execution guard
+func (f *functionContainer) decorateFunction(key string, function vmcommon.BuiltinFunction) vmcommon.BuiltinFunction {
+	f.mutWrappers.RLock()
+	defer f.mutWrappers.RUnlock()
+
+	policy, hasPolicy := f.callValuePolicies[key]
+	if hasPolicy {
+		function = newCallValuePolicyFunction(function, policy)
+	}
+	if f.addressLength > 0 {
+		function = newAddressLengthFunction(key, function, f.addressLength)
+	}
+	if f.limits.HasLimits() {
+		function = newLimitsFunction(key, function, f.limits)
+	}
+	if !check.IfNil(f.quotaHandler) {
+		function = newQuotaFunction(key, function, f.quotaHandler)
+	}
+	if !f.shardFunctions.IsFunctionAllowed(key, f.selfShardID) {
+		function = newNotAllowedOnShardFunction(key, function, f.selfShardID)
+	}
+	if f.userErrorsAsVMOutputs {
+		function = newUserErrorOutputFunction(function)
+	}
+	if f.outputAggregation.IsEnabled() {
+		function = newOutputAggregationFunction(function, f.outputAggregation)
+	}
+	if f.logAddressFormat.HasEncodedAddresses() {
+		function = newLogAddressFunction(function, f.logAddressFormat, f.pubkeyConverter)
+	}
+	function = newCorrelationFunction(key, function)
+	if !check.IfNil(f.logPublisher) {
+		function = newLogPublisherFunction(key, function, f.logPublisher)
+	}
+	if !check.IfNil(f.metrics) {
+		function = newMetricsFunction(key, function, f.metrics)
+	}
+
+	return function
+}
+
+// ApplyGasConfig sets the provided gas costs to all the functions of the container at once: it waits for the calls in
+// progress to finish and blocks new calls until all the functions charge the new costs, so no call, even one spanning
+// several functions, observes a mix of old and new costs
+func (f *functionContainer) ApplyGasConfig(gasCost *vmcommon.GasCost) {
+	f.mutExecution.Lock()
+	defer f.mutExecution.Unlock()
+
+	for _, value := range f.objects.Values() {
+		function, ok := value.(vmcommon.BuiltinFunction)
+		if !ok {
+			continue
+		}
+
+		function.SetNewGasConfig(gasCost)
+	}
+}
+
+// SetUserErrorsAsVMOutputs sets whether the functions returned by the container convert the errors caused by the
+// user into a VMOutput holding the return code and the error message, instead of returning them as errors
+func (f *functionContainer) SetUserErrorsAsVMOutputs(enabled bool) {
+	f.mutWrappers.Lock()
+	f.userErrorsAsVMOutputs = enabled
+	f.mutWrappers.Unlock()
+}
+
+// SetLimitsConfig sets the hard limits on the input which the functions returned by the container enforce before
+// computing any gas
+func (f *functionContainer) SetLimitsConfig(limits vmcommon.LimitsConfig) {
+	f.mutWrappers.Lock()
+	f.limits = limits
+	f.mutWrappers.Unlock()
+}
+
+// SetOutputTransferAggregationConfig sets which output transfers produced by the functions returned by the container
+// are merged before the output is handed to the host
+func (f *functionContainer) SetOutputTransferAggregationConfig(config vmcommon.OutputTransferAggregationConfig) {
+	f.mutWrappers.Lock()
+	f.outputAggregation = config
+	f.mutWrappers.Unlock()
+}
+
+// SetAddressLength sets the length of the caller addresses accepted by the functions returned by the container, 0
+// meaning the caller addresses are not checked
+func (f *functionContainer) SetAddressLength(addressLength int) {
+	f.mutWrappers.Lock()
+	f.addressLength = addressLength
+	f.mutWrappers.Unlock()
+}
+
+// SetShardFunctionsConfig sets the shards on which the functions returned by the container can be executed. The
+// functions not allowed on the provided self shard reject all the calls with ErrFunctionNotAllowedOnShard
+func (f *functionContainer) SetShardFunctionsConfig(shardFunctions vmcommon.ShardFunctionsConfig, selfShardID uint32) {
+	f.mutWrappers.Lock()
+	f.shardFunctions = shardFunctions
+	f.selfShardID = selfShardID
+	f.mutWrappers.Unlock()
+}
+
+// SetCallValuePolicy declares the call value policy of the function stored at the provided key, enforced by the
+// container before dispatching the calls. The functions without a declared policy check the call value themselves
+func (f *functionContainer) SetCallValuePolicy(key string, policy vmcommon.CallValuePolicy) {
+	f.mutWrappers.Lock()
+	f.callValuePolicies[key] = policy
+	f.mutWrappers.Unlock()
+}
+
+// CallValuePolicy returns the call value policy declared for the function stored at the provided key, if any
+func (f *functionContainer) CallValuePolicy(key string) (vmcommon.CallValuePolicy, bool) {
+	f.mutWrappers.RLock()
+	defer f.mutWrappers.RUnlock()
+
+	policy, found := f.callValuePolicies[key]
+	return policy, found
+}
+
+// SetLogPublisher sets the publisher to which all the functions returned by the container publish the log entries
+// of their successful calls
+func (f *functionContainer) SetLogPublisher(logPublisher vmcommon.LogPublisher) error {
+	if check.IfNil(logPublisher) {
+		return ErrNilLogPublisher
+	}
+
+	f.mutWrappers.Lock()
+	f.logPublisher = logPublisher
+	f.mutWrappers.Unlock()
+
+	return nil
+}
+
+// SetLogAddressFormat sets how the functions returned by the container write the addresses carried by the topics of
+// their log entries, the converter encoding the addresses being required by the formats holding encoded addresses
+func (f *functionContainer) SetLogAddressFormat(format vmcommon.LogAddressFormat, converter core.PubkeyConverter) error {
+	err := checkLogAddressFormat(format, converter)
+	if err != nil {
+		return err
+	}
+
+	f.mutWrappers.Lock()
+	f.logAddressFormat = format
+	f.pubkeyConverter = converter
+	f.mutWrappers.Unlock()
+
+	return nil
+}
+
+// SetMetrics sets the metrics handler to which all the functions returned by the container report
+func (f *functionContainer) SetMetrics(metrics vmcommon.Metrics) error {
+	if check.IfNil(metrics) {
+		return ErrNilMetrics
+	}
+
+	f.mutWrappers.Lock()
+	f.metrics = metrics
+	f.mutWrappers.Unlock()
+
+	return nil
+}
+
+// SetFunctionResolver sets the resolver mapping the aliases and the deprecated function names to the canonical keys
+// of the container
+func (f *functionContainer) SetFunctionResolver(functionResolver vmcommon.FunctionResolver) error {
+	if check.IfNil(functionResolver) {
+		return ErrNilFunctionResolver
+	}
+
+	f.mutWrappers.Lock()
+	f.functionResolver = functionResolver
+	f.mutWrappers.Unlock()
+
+	return nil
+}
+
+// SetQuotaHandler sets the handler enforcing the per block quotas of the functions returned by the container
+func (f *functionContainer) SetQuotaHandler(quotaHandler vmcommon.QuotaHandler) error {
+	if check.IfNil(quotaHandler) {
+		return ErrNilQuotaHandler
+	}
+
+	f.mutWrappers.Lock()
+	f.quotaHandler = quotaHandler
+	f.mutWrappers.Unlock()
+
+	return nil
+}
+
+// SetEpochNotifier subscribes the container to the provided epoch notifier, the confirmed epochs being forwarded to
+// all the functions of the container implementing EpochConfirmedHandler
+func (f *functionContainer) SetEpochNotifier(epochNotifier vmcommon.EpochNotifier) error {
+	if check.IfNil(epochNotifier) {
+		return ErrNilEpochNotifier
+	}
+
+	epochNotifier.RegisterNotifyHandler(f)
+
+	return nil
+}
+
+// EpochConfirmed is called whenever a new epoch is confirmed and notifies the functions of the container
+func (f *functionContainer) EpochConfirmed(epoch uint32, timestamp uint64) {
+	f.mutEpoch.Lock()
+	defer f.mutEpoch.Unlock()
+
+	f.isEpochConfirmed = true
+	f.currentEpoch = epoch
+	f.currentTimestamp = timestamp
+	for _, value := range f.objects.Values() {
+		notifyEpochConfirmed(value, epoch, timestamp)
+	}
+}
+
+// notifyAddedFunction brings a function added after an epoch was confirmed to the current epoch
+func (f *functionContainer) notifyAddedFunction(function vmcommon.BuiltinFunction) {
+	f.mutEpoch.RLock()
+	defer f.mutEpoch.RUnlock()
+
+	if f.isEpochConfirmed {
+		notifyEpochConfirmed(function, f.currentEpoch, f.currentTimestamp)
 	}
+}
+
+func notifyEpochConfirmed(value interface{}, epoch uint32, timestamp uint64) {
+	handler, ok := value.(vmcommon.EpochConfirmedHandler)
+	if !ok || check.IfNil(handler) {
+		return
+	}
+
+	handler.EpochConfirmed(epoch, timestamp)
+}
 
-	return function, nil
+func (f *functionContainer) setHistoricalReplay(factory vmcommon.EnableEpochsHandlerFactory, handler *epochPinnedEnableEpochsHandler) {
+	f.mutReplay.Lock()
+	f.replayFactory = factory
+	f.replayHandler = handler
+	f.mutReplay.Unlock()
+}
+
+// ProcessBuiltinFunctionAsOfEpoch executes the function stored at the provided key with all the flags resolving
+// against the provided epoch, as needed when re-processing old blocks. The replay holds the execution lock of the
+// container exclusively, so no other call of the container runs while the flags of all the functions resolve against
+// the pinned epoch. The state which follows the epoch is pinned as well: the functions reading the current epoch get
+// it from the pinned enable epochs handler and a function router runs the version active in the replayed epoch
+func (f *functionContainer) ProcessBuiltinFunctionAsOfEpoch(
+	key string,
+	epoch uint32,
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	f.mutReplay.Lock()
+	defer f.mutReplay.Unlock()
+
+	if check.IfNil(f.replayFactory) || f.replayHandler == nil {
+		return nil, ErrHistoricalReplayNotEnabled
+	}
+
+	function, err := f.getAsOfEpoch(key, epoch)
+	if err != nil {
+		return nil, err
+	}
+	handler, err := f.replayFactory.CreateEnableEpochsHandler(epoch)
+	if err != nil {
+		return nil, err
+	}
+	if check.IfNil(handler) {
+		return nil, ErrNilEnableEpochsHandler
+	}
+
+	f.mutExecution.Lock()
+	defer f.mutExecution.Unlock()
+
+	f.replayHandler.pin(handler)
+	defer f.replayHandler.unpin()
+
+	if !function.IsActive() {
+		return nil, fmt.Errorf("%w, function %s in epoch %d", ErrBuiltInFunctionNotActive, key, epoch)
+	}
+
+	return function.ProcessBuiltinFunction(acntSnd, acntDst, vmInput)
+}
+
+// getAsOfEpoch returns the function stored at the key as it was in the provided epoch. The returned function is not
+// guarded, the caller holding the execution lock for the whole call
+func (f *functionContainer) getAsOfEpoch(key string, epoch uint32) (vmcommon.BuiltinFunction, error) {
+	key, function, err := f.getStored(key)
+	if err != nil {
+		return nil, err
+	}
+
+	router, ok := function.(*functionRouter)
+	if ok {
+		function, err = router.versionForEpoch(epoch)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return f.decorateFunction(key, function), nil
 }
 
 // Add will add an object at a given key. Returns
@@ -52,6 +414,7 @@ func (f *functionContainer) Add(key string, function vmcommon.BuiltinFunction) e
 	if !ok {
 		return ErrContainerKeyAlreadyExists
 	}
+	f.notifyAddedFunction(function)
 
 	return nil
 }
@@ -66,12 +429,29 @@ func (f *functionContainer) Replace(key string, function vmcommon.BuiltinFunctio
 	}
 
 	f.objects.Set(key, function)
+	f.notifyAddedFunction(function)
+
 	return nil
 }
 
 // Remove will remove an object at a given key
 func (f *functionContainer) Remove(key string) {
 	f.objects.Remove(key)
+
+	f.mutWrappers.Lock()
+	delete(f.callValuePolicies, key)
+	f.mutWrappers.Unlock()
+}
+
+// CheckIsExecutable validates the call of the function stored at the key without reading or changing any state. The
+// functions without stateless checks accept all the calls
+func (f *functionContainer) CheckIsExecutable(key string, vmInput *vmcommon.ContractCallInput) error {
+	function, err := f.Get(key)
+	if err != nil {
+		return err
+	}
+
+	return checkIsExecutable(function, vmInput)
 }
 
 // Len returns the length of the added objects
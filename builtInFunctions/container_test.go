@@ -2,11 +2,18 @@ package builtInFunctions
 
 import (
 	"errors"
+	"fmt"
+	"math/big"
+	"sync"
 	"testing"
 
+	"github.com/Reshusk23/sr-me-core/core"
 	"github.com/Reshusk23/sr-me-core/core/check"
+	"github.com/Reshusk23/sr-me-core/core/pubkeyConverter"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
 	"github.com/Reshusk23/sr-vm-common-go/mock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewBuiltInFunctionContainer_ShouldWork(t *testing.T) {
@@ -80,7 +87,7 @@ func TestBuiltInFunctionContainer_GetShouldWork(t *testing.T) {
 	_ = c.Add(key, val)
 	valRecovered, err := c.Get(key)
 
-	assert.True(t, val == valRecovered)
+	assert.True(t, val == unwrapExecutionGuard(valRecovered))
 	assert.Nil(t, err)
 }
 
@@ -100,7 +107,7 @@ func TestBuiltInFunctionContainer_ReplaceNilValueShouldErrAndNotModify(t *testin
 	valRecovered, _ := c.Get(key)
 
 	assert.Equal(t, ErrNilContainerElement, err)
-	assert.Equal(t, val, valRecovered)
+	assert.Equal(t, val, unwrapExecutionGuard(valRecovered))
 }
 
 func TestBuiltInFunctionContainer_ReplaceShouldWork(t *testing.T) {
@@ -117,7 +124,7 @@ func TestBuiltInFunctionContainer_ReplaceShouldWork(t *testing.T) {
 
 	valRecovered, _ := c.Get(key)
 
-	assert.True(t, val2 == valRecovered)
+	assert.True(t, val2 == unwrapExecutionGuard(valRecovered))
 	assert.Nil(t, err)
 }
 
@@ -156,3 +163,474 @@ func TestBuiltInFunctionContainer_LenShouldWork(t *testing.T) {
 	c.Remove("key1")
 	assert.Equal(t, 1, c.Len())
 }
+
+func TestBuiltInFunctionContainer_SetMetrics(t *testing.T) {
+	t.Parallel()
+
+	c := NewBuiltInFunctionContainer()
+	function := &mock.BuiltInFunctionStub{}
+	_ = c.Add("key", function)
+
+	valRecovered, _ := c.Get("key")
+	assert.True(t, unwrapExecutionGuard(valRecovered) == function)
+
+	err := c.SetMetrics(nil)
+	assert.Equal(t, ErrNilMetrics, err)
+
+	err = c.SetMetrics(&mock.MetricsStub{})
+	assert.Nil(t, err)
+
+	valRecovered, _ = c.Get("key")
+	wrapped, ok := unwrapExecutionGuard(valRecovered).(*metricsFunction)
+	assert.True(t, ok)
+	assert.True(t, unwrapCorrelation(wrapped.function) == function)
+	assert.Equal(t, "key", wrapped.name)
+}
+
+func TestBuiltInFunctionContainer_SetLogAddressFormat(t *testing.T) {
+	t.Parallel()
+
+	c := NewBuiltInFunctionContainer()
+	function := &mock.BuiltInFunctionStub{}
+	_ = c.Add("key", function)
+
+	err := c.SetLogAddressFormat(vmcommon.LogAddressRawAndEncoded, nil)
+	assert.Equal(t, ErrNilPubkeyConverter, err)
+
+	err = c.SetLogAddressFormat(vmcommon.LogAddressRaw, nil)
+	assert.Nil(t, err)
+	valRecovered, _ := c.Get("key")
+	assert.True(t, unwrapExecutionGuard(valRecovered) == function)
+
+	converter, _ := pubkeyConverter.NewBech32PubkeyConverter(32, log)
+	err = c.SetLogAddressFormat(vmcommon.LogAddressRawAndEncoded, converter)
+	assert.Nil(t, err)
+
+	valRecovered, _ = c.Get("key")
+	wrapped, ok := unwrapExecutionGuard(valRecovered).(*logAddressFunction)
+	assert.True(t, ok)
+	assert.True(t, wrapped.function == function)
+	assert.Equal(t, vmcommon.LogAddressRawAndEncoded, wrapped.format)
+}
+
+func TestBuiltInFunctionContainer_SetLogPublisher(t *testing.T) {
+	t.Parallel()
+
+	c := NewBuiltInFunctionContainer()
+	function := &mock.BuiltInFunctionStub{}
+	_ = c.Add("key", function)
+
+	err := c.SetLogPublisher(nil)
+	assert.Equal(t, ErrNilLogPublisher, err)
+
+	err = c.SetLogPublisher(&mock.LogPublisherStub{})
+	assert.Nil(t, err)
+
+	valRecovered, _ := c.Get("key")
+	wrapped, ok := unwrapExecutionGuard(valRecovered).(*logPublisherFunction)
+	assert.True(t, ok)
+	assert.True(t, unwrapCorrelation(wrapped.function) == function)
+	assert.Equal(t, "key", wrapped.name)
+}
+
+func TestBuiltInFunctionContainer_PublishedLogsShouldHoldTheCorrelationID(t *testing.T) {
+	t.Parallel()
+
+	c := NewBuiltInFunctionContainer()
+	function := &mock.BuiltInFunctionStub{
+		ProcessBuiltinFunctionCalled: func(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+			return &vmcommon.VMOutput{ReturnCode: vmcommon.Ok, Logs: []*vmcommon.LogEntry{{Identifier: []byte("key")}}}, nil
+		},
+	}
+	_ = c.Add("key", function)
+
+	var publishedLogs []*vmcommon.LogEntry
+	_ = c.SetLogPublisher(&mock.LogPublisherStub{
+		PublishLogsCalled: func(function string, logs []*vmcommon.LogEntry) {
+			publishedLogs = logs
+		},
+	})
+
+	valRecovered, _ := c.Get("key")
+	_, err := valRecovered.ProcessBuiltinFunction(nil, nil, &vmcommon.ContractCallInput{CorrelationID: "tx hash"})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(publishedLogs))
+	assert.Equal(t, "tx hash", publishedLogs[0].CorrelationID)
+}
+
+func TestBuiltInFunctionContainer_SetQuotaHandler(t *testing.T) {
+	t.Parallel()
+
+	c := NewBuiltInFunctionContainer()
+	function := &mock.BuiltInFunctionStub{}
+	_ = c.Add("key", function)
+
+	err := c.SetQuotaHandler(nil)
+	assert.Equal(t, ErrNilQuotaHandler, err)
+
+	err = c.SetQuotaHandler(&mock.QuotaHandlerStub{})
+	assert.Nil(t, err)
+
+	valRecovered, _ := c.Get("key")
+	wrapped, ok := unwrapExecutionGuard(valRecovered).(*quotaFunction)
+	assert.True(t, ok)
+	assert.True(t, wrapped.function == function)
+	assert.Equal(t, "key", wrapped.name)
+}
+
+func TestBuiltInFunctionContainer_SetFunctionResolver(t *testing.T) {
+	t.Parallel()
+
+	c := NewBuiltInFunctionContainer()
+	function := &mock.BuiltInFunctionStub{}
+	_ = c.Add("key", function)
+
+	_, err := c.Get("alias")
+	assert.ErrorIs(t, err, ErrInvalidContainerKey)
+
+	err = c.SetFunctionResolver(nil)
+	assert.Equal(t, ErrNilFunctionResolver, err)
+
+	err = c.SetFunctionResolver(&mock.FunctionResolverStub{
+		ResolveFunctionCalled: func(name string) (string, bool) {
+			if name == "alias" {
+				return "key", true
+			}
+			return name, false
+		},
+	})
+	assert.Nil(t, err)
+
+	valRecovered, err := c.Get("alias")
+	assert.Nil(t, err)
+	assert.True(t, unwrapExecutionGuard(valRecovered) == function)
+
+	valRecovered, err = c.Get("key")
+	assert.Nil(t, err)
+	assert.True(t, unwrapExecutionGuard(valRecovered) == function)
+
+	_, err = c.Get("unknown")
+	assert.ErrorIs(t, err, ErrInvalidContainerKey)
+}
+
+func TestBuiltInFunctionContainer_SetAddressLength(t *testing.T) {
+	t.Parallel()
+
+	c := NewBuiltInFunctionContainer()
+	function := &mock.BuiltInFunctionStub{}
+	_ = c.Add("key", function)
+
+	c.SetAddressLength(32)
+	valRecovered, _ := c.Get("key")
+	wrapped, ok := unwrapExecutionGuard(valRecovered).(*addressLengthFunction)
+	assert.True(t, ok)
+	assert.True(t, wrapped.function == function)
+	assert.Equal(t, 32, wrapped.addressLength)
+
+	c.SetAddressLength(0)
+	valRecovered, _ = c.Get("key")
+	assert.True(t, unwrapExecutionGuard(valRecovered) == function)
+}
+
+func TestBuiltInFunctionContainer_SetShardFunctionsConfig(t *testing.T) {
+	t.Parallel()
+
+	c := NewBuiltInFunctionContainer()
+	function := &mock.BuiltInFunctionStub{}
+	_ = c.Add("metaOnly", function)
+	_ = c.Add("other", function)
+
+	c.SetShardFunctionsConfig(vmcommon.ShardFunctionsConfig{
+		AllowedShards: map[string][]uint32{"metaOnly": {core.MetachainShardId}},
+	}, 0)
+	valRecovered, _ := c.Get("metaOnly")
+	wrapped, ok := unwrapExecutionGuard(valRecovered).(*notAllowedOnShardFunction)
+	assert.True(t, ok)
+	assert.True(t, wrapped.function == function)
+	valRecovered, _ = c.Get("other")
+	assert.True(t, unwrapExecutionGuard(valRecovered) == function)
+
+	c.SetShardFunctionsConfig(vmcommon.ShardFunctionsConfig{
+		AllowedShards: map[string][]uint32{"metaOnly": {core.MetachainShardId}},
+	}, core.MetachainShardId)
+	valRecovered, _ = c.Get("metaOnly")
+	assert.True(t, unwrapExecutionGuard(valRecovered) == function)
+}
+
+func TestBuiltInFunctionContainer_CheckIsExecutable(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("expected error")
+	c := NewBuiltInFunctionContainer()
+	_ = c.Add("checked", &mock.BuiltInFunctionStub{
+		CheckIsExecutableCalled: func(vmInput *vmcommon.ContractCallInput) error {
+			return expectedErr
+		},
+	})
+	_ = c.Add("unchecked", struct{ vmcommon.BuiltinFunction }{&mock.BuiltInFunctionStub{}})
+	_ = c.Add("metaOnly", &mock.BuiltInFunctionStub{})
+	c.SetShardFunctionsConfig(vmcommon.ShardFunctionsConfig{
+		AllowedShards: map[string][]uint32{"metaOnly": {core.MetachainShardId}},
+	}, 0)
+
+	assert.Equal(t, expectedErr, c.CheckIsExecutable("checked", &vmcommon.ContractCallInput{}))
+	assert.Nil(t, c.CheckIsExecutable("unchecked", &vmcommon.ContractCallInput{}))
+	assert.ErrorIs(t, c.CheckIsExecutable("metaOnly", &vmcommon.ContractCallInput{}), ErrFunctionNotAllowedOnShard)
+	assert.ErrorIs(t, c.CheckIsExecutable("missing", &vmcommon.ContractCallInput{}), ErrInvalidContainerKey)
+}
+
+func TestBuiltInFunctionContainer_ProcessBuiltinFunctionAsOfEpoch(t *testing.T) {
+	t.Parallel()
+
+	t.Run("historical replay not enabled should err", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewBuiltInFunctionContainer()
+		_ = c.Add("key", &mock.BuiltInFunctionStub{})
+
+		vmOutput, err := c.ProcessBuiltinFunctionAsOfEpoch("key", 5, nil, nil, &vmcommon.ContractCallInput{})
+		assert.Nil(t, vmOutput)
+		assert.Equal(t, ErrHistoricalReplayNotEnabled, err)
+	})
+	t.Run("factory error should err", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		c := NewBuiltInFunctionContainer()
+		_ = c.Add("key", &mock.BuiltInFunctionStub{})
+		c.setHistoricalReplay(&mock.EnableEpochsHandlerFactoryStub{
+			CreateEnableEpochsHandlerCalled: func(epoch uint32) (vmcommon.EnableEpochsHandler, error) {
+				return nil, expectedErr
+			},
+		}, newEpochPinnedEnableEpochsHandler(&mock.EnableEpochsHandlerStub{}))
+
+		_, err := c.ProcessBuiltinFunctionAsOfEpoch("key", 5, nil, nil, &vmcommon.ContractCallInput{})
+		assert.Equal(t, expectedErr, err)
+	})
+	t.Run("function not active in the epoch should err", func(t *testing.T) {
+		t.Parallel()
+
+		handler := newEpochPinnedEnableEpochsHandler(&mock.EnableEpochsHandlerStub{IsSoulboundFlagEnabledField: true})
+		c := NewBuiltInFunctionContainer()
+		_ = c.Add("key", &mock.BuiltInFunctionStub{
+			IsActiveCalled: handler.IsSoulboundFlagEnabled,
+		})
+		c.setHistoricalReplay(&mock.EnableEpochsHandlerFactoryStub{}, handler)
+
+		_, err := c.ProcessBuiltinFunctionAsOfEpoch("key", 5, nil, nil, &vmcommon.ContractCallInput{})
+		assert.ErrorIs(t, err, ErrBuiltInFunctionNotActive)
+		assert.True(t, handler.IsSoulboundFlagEnabled())
+	})
+	t.Run("should process with the flags pinned to the epoch", func(t *testing.T) {
+		t.Parallel()
+
+		handler := newEpochPinnedEnableEpochsHandler(&mock.EnableEpochsHandlerStub{CurrentEpochField: 20})
+		processedInEpoch := uint32(0)
+		c := NewBuiltInFunctionContainer()
+		_ = c.Add("key", &mock.BuiltInFunctionStub{
+			ProcessBuiltinFunctionCalled: func(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+				processedInEpoch = handler.GetCurrentEpoch()
+				return &vmcommon.VMOutput{}, nil
+			},
+		})
+		c.setHistoricalReplay(&mock.EnableEpochsHandlerFactoryStub{}, handler)
+
+		vmOutput, err := c.ProcessBuiltinFunctionAsOfEpoch("key", 5, nil, nil, &vmcommon.ContractCallInput{})
+		assert.Nil(t, err)
+		assert.NotNil(t, vmOutput)
+		assert.Equal(t, uint32(5), processedInEpoch)
+		assert.Equal(t, uint32(20), handler.GetCurrentEpoch())
+	})
+	t.Run("should hold the other calls for the duration of the replay", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewBuiltInFunctionContainer()
+		isExecutionLocked := false
+		_ = c.Add("key", &mock.BuiltInFunctionStub{
+			ProcessBuiltinFunctionCalled: func(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+				isExecutionLocked = !c.mutExecution.TryRLock()
+				if !isExecutionLocked {
+					c.mutExecution.RUnlock()
+				}
+				return &vmcommon.VMOutput{}, nil
+			},
+		})
+		c.setHistoricalReplay(&mock.EnableEpochsHandlerFactoryStub{}, newEpochPinnedEnableEpochsHandler(&mock.EnableEpochsHandlerStub{}))
+
+		_, err := c.ProcessBuiltinFunctionAsOfEpoch("key", 5, nil, nil, &vmcommon.ContractCallInput{})
+		assert.Nil(t, err)
+		assert.True(t, isExecutionLocked)
+
+		function, _ := c.Get("key")
+		_, err = function.ProcessBuiltinFunction(nil, nil, &vmcommon.ContractCallInput{})
+		assert.Nil(t, err)
+		assert.False(t, isExecutionLocked)
+	})
+	t.Run("should process the version of a function router active in the epoch", func(t *testing.T) {
+		t.Parallel()
+
+		processedVersion := ""
+		createVersion := func(name string) *mock.BuiltInFunctionStub {
+			return &mock.BuiltInFunctionStub{
+				ProcessBuiltinFunctionCalled: func(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+					processedVersion = name
+					return &vmcommon.VMOutput{}, nil
+				},
+			}
+		}
+		router, _ := NewFunctionRouter(&mock.EpochNotifierStub{})
+		_ = router.AddVersion(0, createVersion("v1"))
+		_ = router.AddVersion(10, createVersion("v2"))
+		router.EpochConfirmed(20, 0)
+
+		c := NewBuiltInFunctionContainer()
+		_ = c.Add("key", router)
+		c.setHistoricalReplay(&mock.EnableEpochsHandlerFactoryStub{}, newEpochPinnedEnableEpochsHandler(&mock.EnableEpochsHandlerStub{}))
+
+		_, err := c.ProcessBuiltinFunctionAsOfEpoch("key", 5, nil, nil, &vmcommon.ContractCallInput{})
+		assert.Nil(t, err)
+		assert.Equal(t, "v1", processedVersion)
+
+		function, _ := c.Get("key")
+		_, _ = function.ProcessBuiltinFunction(nil, nil, &vmcommon.ContractCallInput{})
+		assert.Equal(t, "v2", processedVersion)
+	})
+}
+
+type epochConfirmedFunctionStub struct {
+	mock.BuiltInFunctionStub
+	epochs []uint32
+}
+
+// EpochConfirmed -
+func (stub *epochConfirmedFunctionStub) EpochConfirmed(epoch uint32, _ uint64) {
+	stub.epochs = append(stub.epochs, epoch)
+}
+
+func TestBuiltInFunctionContainer_SetEpochNotifier(t *testing.T) {
+	t.Parallel()
+
+	c := NewBuiltInFunctionContainer()
+	err := c.SetEpochNotifier(nil)
+	assert.ErrorIs(t, err, ErrNilEpochNotifier)
+
+	var registeredHandler vmcommon.EpochSubscriberHandler
+	err = c.SetEpochNotifier(&mock.EpochNotifierStub{
+		RegisterNotifyHandlerCalled: func(handler vmcommon.EpochSubscriberHandler) {
+			registeredHandler = handler
+		},
+	})
+	require.Nil(t, err)
+	assert.True(t, registeredHandler == c)
+
+	firstFunction := &epochConfirmedFunctionStub{}
+	_ = c.Add("first", firstFunction)
+	_ = c.Add("not a handler", &mock.BuiltInFunctionStub{})
+	assert.Empty(t, firstFunction.epochs)
+
+	registeredHandler.EpochConfirmed(3, 0)
+	registeredHandler.EpochConfirmed(4, 0)
+	assert.Equal(t, []uint32{3, 4}, firstFunction.epochs)
+
+	// the functions added later are brought to the current epoch
+	secondFunction := &epochConfirmedFunctionStub{}
+	_ = c.Replace("second", secondFunction)
+	assert.Equal(t, []uint32{4}, secondFunction.epochs)
+
+	c.Remove("first")
+	registeredHandler.EpochConfirmed(5, 0)
+	assert.Equal(t, []uint32{3, 4}, firstFunction.epochs)
+	assert.Equal(t, []uint32{4, 5}, secondFunction.epochs)
+}
+
+func TestBuiltInFunctionContainer_ApplyGasConfig(t *testing.T) {
+	t.Parallel()
+
+	numCalls := 0
+	function := &mock.BuiltInFunctionStub{
+		SetNewGasConfigCalled: func(gasCost *vmcommon.GasCost) {
+			numCalls++
+		},
+	}
+	c := NewBuiltInFunctionContainer()
+	_ = c.Add("key1", function)
+	_ = c.Add("key2", function)
+	_ = c.SetMetrics(&mock.MetricsStub{})
+
+	c.ApplyGasConfig(&vmcommon.GasCost{})
+	assert.Equal(t, 2, numCalls)
+}
+
+func TestBuiltInFunctionContainer_ApplyGasConfigConcurrentWithCreates(t *testing.T) {
+	t.Parallel()
+
+	createGasCost := func(value uint64) *vmcommon.GasCost {
+		return &vmcommon.GasCost{
+			BaseOperationCost: vmcommon.BaseOperationCost{StorePerByte: value},
+			BuiltInCost:       vmcommon.BuiltInCost{DCTNFTCreate: 10 * value},
+		}
+	}
+	gasCosts := []*vmcommon.GasCost{createGasCost(1), createGasCost(2)}
+
+	numCreators := 8
+	numCreatesPerCreator := 200
+	c := NewBuiltInFunctionContainer()
+	// every creator uses its own function and account as the storage mocks are not safe for concurrent use
+	for i := 0; i < numCreators; i++ {
+		_ = c.Add(fmt.Sprintf("create%d", i), createNftCreateWithStubArguments())
+	}
+
+	createNFT := func(key string, sender vmcommon.UserAccountHandler) (uint64, error) {
+		vmInput := &vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallerAddr:  sender.AddressBytes(),
+				CallValue:   big.NewInt(0),
+				GasProvided: 1_000_000,
+				Arguments:   [][]byte{[]byte("token"), {1}, []byte("name"), nil, []byte("hash"), []byte("attributes"), []byte("uri")},
+			},
+			RecipientAddr: sender.AddressBytes(),
+		}
+		function, err := c.Get(key)
+		if err != nil {
+			return 0, err
+		}
+		vmOutput, err := function.ProcessBuiltinFunction(sender, nil, vmInput)
+		if err != nil {
+			return 0, err
+		}
+
+		return vmInput.GasProvided - vmOutput.GasRemaining, nil
+	}
+
+	expectedGasUsed := make(map[uint64]struct{})
+	for _, gasCost := range gasCosts {
+		c.ApplyGasConfig(gasCost)
+		gasUsed, err := createNFT("create0", mock.NewUserAccount([]byte("sender")))
+		require.Nil(t, err)
+		expectedGasUsed[gasUsed] = struct{}{}
+	}
+	require.Len(t, expectedGasUsed, len(gasCosts))
+
+	wg := sync.WaitGroup{}
+	wg.Add(numCreators + 1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < numCreators*numCreatesPerCreator; i++ {
+			c.ApplyGasConfig(gasCosts[i%len(gasCosts)])
+		}
+	}()
+	for i := 0; i < numCreators; i++ {
+		go func(idx int) {
+			defer wg.Done()
+			key := fmt.Sprintf("create%d", idx)
+			sender := mock.NewUserAccount([]byte{byte(idx)})
+			for j := 0; j < numCreatesPerCreator; j++ {
+				gasUsed, err := createNFT(key, sender)
+				assert.Nil(t, err)
+				_, ok := expectedGasUsed[gasUsed]
+				assert.True(t, ok, "gas used %d does not match any of the applied gas configs", gasUsed)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,69 @@
+package builtInFunctions
+
+import (
+	"context"
+
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+)
+
+const correlationIDLogKey = "correlationID"
+
+// correlationFunction wraps a built-in function and tags the log entries of its output with the correlation ID of
+// the call. The outcome of each correlated call is also logged, so the activity of one transaction can be followed
+// across functions
+type correlationFunction struct {
+	baseFunctionWrapper
+	name string
+}
+
+func newCorrelationFunction(name string, function vmcommon.BuiltinFunction) *correlationFunction {
+	return &correlationFunction{
+		baseFunctionWrapper: baseFunctionWrapper{function: function},
+		name:                name,
+	}
+}
+
+// ProcessBuiltinFunction calls the wrapped function and tags the log entries of the output
+func (cf *correlationFunction) ProcessBuiltinFunction(
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	return cf.processBuiltinFunctionWithContext(context.Background(), acntSnd, acntDst, vmInput)
+}
+
+func (cf *correlationFunction) processBuiltinFunctionWithContext(
+	ctx context.Context,
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	vmOutput, err := callWithContext(ctx, cf.function, acntSnd, acntDst, vmInput)
+	if vmInput == nil || len(vmInput.CorrelationID) == 0 {
+		return vmOutput, err
+	}
+
+	if vmOutput != nil {
+		for _, logEntry := range vmOutput.Logs {
+			// the entries of the nested calls keep the correlation ID they were produced with
+			if logEntry != nil && len(logEntry.CorrelationID) == 0 {
+				logEntry.CorrelationID = vmInput.CorrelationID
+			}
+		}
+	}
+	log.Trace("built-in function called", withCorrelationID(vmInput, "function", cf.name, "error", err)...)
+
+	return vmOutput, err
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (cf *correlationFunction) IsInterfaceNil() bool {
+	return cf == nil
+}
+
+// withCorrelationID prepends the correlation ID of the call, if any, to the key-value pairs of a log line
+func withCorrelationID(vmInput *vmcommon.ContractCallInput, args ...interface{}) []interface{} {
+	if vmInput == nil || len(vmInput.CorrelationID) == 0 {
+		return args
+	}
+
+	return append([]interface{}{correlationIDLogKey, vmInput.CorrelationID}, args...)
+}
@@ -0,0 +1,80 @@
+package builtInFunctions
+
+import (
+	"testing"
+
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func unwrapCorrelation(function vmcommon.BuiltinFunction) vmcommon.BuiltinFunction {
+	wrapped, ok := function.(*correlationFunction)
+	if !ok {
+		return function
+	}
+
+	return wrapped.function
+}
+
+func TestCorrelationFunction_ProcessBuiltinFunction(t *testing.T) {
+	t.Parallel()
+
+	createFunction := func(vmOutput *vmcommon.VMOutput, err error) *mock.BuiltInFunctionStub {
+		return &mock.BuiltInFunctionStub{
+			ProcessBuiltinFunctionCalled: func(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+				return vmOutput, err
+			},
+		}
+	}
+
+	t.Run("log entries should be tagged with the correlation ID", func(t *testing.T) {
+		t.Parallel()
+
+		nestedEntry := &vmcommon.LogEntry{Identifier: []byte("nested"), CorrelationID: "nested call"}
+		expectedOutput := &vmcommon.VMOutput{
+			ReturnCode: vmcommon.Ok,
+			Logs:       []*vmcommon.LogEntry{{Identifier: []byte("identifier")}, nil, nestedEntry},
+		}
+		cf := newCorrelationFunction("function", createFunction(expectedOutput, nil))
+
+		vmOutput, err := cf.ProcessBuiltinFunction(nil, nil, &vmcommon.ContractCallInput{CorrelationID: "tx hash"})
+		assert.Nil(t, err)
+		assert.True(t, vmOutput == expectedOutput)
+		assert.Equal(t, "tx hash", vmOutput.Logs[0].CorrelationID)
+		assert.Equal(t, "nested call", vmOutput.Logs[2].CorrelationID)
+	})
+	t.Run("calls without correlation ID should not tag the entries", func(t *testing.T) {
+		t.Parallel()
+
+		expectedOutput := &vmcommon.VMOutput{Logs: []*vmcommon.LogEntry{{Identifier: []byte("identifier")}}}
+		cf := newCorrelationFunction("function", createFunction(expectedOutput, nil))
+
+		vmOutput, err := cf.ProcessBuiltinFunction(nil, nil, &vmcommon.ContractCallInput{})
+		assert.Nil(t, err)
+		assert.Empty(t, vmOutput.Logs[0].CorrelationID)
+
+		_, err = cf.ProcessBuiltinFunction(nil, nil, nil)
+		assert.Nil(t, err)
+	})
+	t.Run("errors should be returned", func(t *testing.T) {
+		t.Parallel()
+
+		cf := newCorrelationFunction("function", createFunction(nil, ErrNotEnoughGas))
+
+		vmOutput, err := cf.ProcessBuiltinFunction(nil, nil, &vmcommon.ContractCallInput{CorrelationID: "tx hash"})
+		assert.Nil(t, vmOutput)
+		assert.Equal(t, ErrNotEnoughGas, err)
+	})
+}
+
+func TestWithCorrelationID(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []interface{}{"error", nil}, withCorrelationID(nil, "error", nil))
+	assert.Equal(t, []interface{}{"error", nil}, withCorrelationID(&vmcommon.ContractCallInput{}, "error", nil))
+
+	vmInput := &vmcommon.ContractCallInput{CorrelationID: "tx hash"}
+	assert.Equal(t, []interface{}{"correlationID", "tx hash", "error", nil}, withCorrelationID(vmInput, "error", nil))
+	assert.Equal(t, []interface{}{"correlationID", "tx hash"}, withCorrelationID(vmInput))
+}
@@ -1,6 +1,8 @@
 package builtInFunctions
 
 import (
+	"fmt"
+
 	"github.com/Reshusk23/sr-me-core/core"
 	"github.com/Reshusk23/sr-me-core/core/check"
 	vmcommon "github.com/Reshusk23/sr-vm-common-go"
@@ -12,6 +14,14 @@ var _ vmcommon.BuiltInFunctionFactory = (*builtInFuncCreator)(nil)
 var trueHandler = func() bool { return true }
 var falseHandler = func() bool { return false }
 
+// callValueAllowedFunctions holds the built-in functions accepting native value, which check themselves whether the
+// value can be moved in the current epoch
+var callValueAllowedFunctions = map[string]struct{}{
+	core.BuiltInFunctionDCTTransfer: {},
+}
+
+const defaultMinInactiveEpochsForDormantSweep = 365
+
 // ArgsCreateBuiltInFunctionContainer defines the input arguments to create built in functions container
 type ArgsCreateBuiltInFunctionContainer struct {
 	GasMap                           map[string]map[string]uint64
@@ -23,6 +33,30 @@ type ArgsCreateBuiltInFunctionContainer struct {
 	EnableEpochsHandler              vmcommon.EnableEpochsHandler
 	MaxNumOfAddressesForTransferRole uint32
 	ConfigAddress                    []byte
+	Metrics                          vmcommon.Metrics
+	LogPublisher                     vmcommon.LogPublisher
+	FunctionResolver                 vmcommon.FunctionResolver
+	QuotaHandler                     vmcommon.QuotaHandler
+	Hasher                           vmcommon.Hasher
+	UserErrorsAsVMOutputs            bool
+	MinInactiveEpochsForDormantSweep uint32
+	EpochNotifier                    vmcommon.EpochNotifier
+	Limits                           vmcommon.LimitsConfig
+	OutputTransferAggregation        vmcommon.OutputTransferAggregationConfig
+	AddressLength                    int
+	ShardFunctions                   vmcommon.ShardFunctionsConfig
+	EnableEpochsHandlerFactory       vmcommon.EnableEpochsHandlerFactory
+	WrappedNativeTokenID             []byte
+	BridgeAddresses                  [][]byte
+	SameShardMultiTransferCalls      bool
+	SystemAddresses                  vmcommon.SystemAddresses
+	RoyaltiesDenominator             uint32
+	StorageUsageTracker              vmcommon.StorageUsageTracker
+	MetaDataCompressor               vmcommon.Compressor
+	MetaDataCompressionThreshold     uint32
+	LogAddressFormat                 vmcommon.LogAddressFormat
+	PubkeyConverter                  core.PubkeyConverter
+	ChainID                          []byte
 }
 
 type builtInFuncCreator struct {
@@ -38,6 +72,31 @@ type builtInFuncCreator struct {
 	enableEpochsHandler              vmcommon.EnableEpochsHandler
 	maxNumOfAddressesForTransferRole uint32
 	configAddress                    []byte
+	metrics                          vmcommon.Metrics
+	logPublisher                     vmcommon.LogPublisher
+	functionResolver                 vmcommon.FunctionResolver
+	quotaHandler                     vmcommon.QuotaHandler
+	hasher                           vmcommon.Hasher
+	userErrorsAsVMOutputs            bool
+	minInactiveEpochsForDormantSweep uint32
+	epochNotifier                    vmcommon.EpochNotifier
+	limits                           vmcommon.LimitsConfig
+	outputTransferAggregation        vmcommon.OutputTransferAggregationConfig
+	addressLength                    int
+	shardFunctions                   vmcommon.ShardFunctionsConfig
+	enableEpochsHandlerFactory       vmcommon.EnableEpochsHandlerFactory
+	wrappedNativeTokenID             []byte
+	sameShardMultiTransferCalls      bool
+	bridgeAddresses                  [][]byte
+	replayHandler                    *epochPinnedEnableEpochsHandler
+	systemAddresses                  vmcommon.SystemAddresses
+	royaltiesDenominator             uint32
+	storageUsageTracker              vmcommon.StorageUsageTracker
+	metaDataCompressor               vmcommon.Compressor
+	metaDataCompressionThreshold     uint32
+	logAddressFormat                 vmcommon.LogAddressFormat
+	pubkeyConverter                  core.PubkeyConverter
+	chainID                          []byte
 }
 
 // NewBuiltInFunctionsCreator creates a component which will instantiate the built in functions contracts
@@ -57,6 +116,20 @@ func NewBuiltInFunctionsCreator(args ArgsCreateBuiltInFunctionContainer) (*built
 	if check.IfNil(args.EnableEpochsHandler) {
 		return nil, ErrNilEnableEpochsHandler
 	}
+	if len(args.ChainID) == 0 {
+		return nil, ErrEmptyChainID
+	}
+	if args.AddressLength < 0 {
+		return nil, ErrInvalidAddressLength
+	}
+	err := checkLogAddressFormat(args.LogAddressFormat, args.PubkeyConverter)
+	if err != nil {
+		return nil, err
+	}
+	systemAddresses, err := createSystemAddresses(args.SystemAddresses, args.AddressLength)
+	if err != nil {
+		return nil, err
+	}
 
 	b := &builtInFuncCreator{
 		mapDNSAddresses:                  args.MapDNSAddresses,
@@ -67,9 +140,50 @@ func NewBuiltInFunctionsCreator(args ArgsCreateBuiltInFunctionContainer) (*built
 		enableEpochsHandler:              args.EnableEpochsHandler,
 		maxNumOfAddressesForTransferRole: args.MaxNumOfAddressesForTransferRole,
 		configAddress:                    args.ConfigAddress,
+		metrics:                          args.Metrics,
+		logPublisher:                     args.LogPublisher,
+		functionResolver:                 args.FunctionResolver,
+		quotaHandler:                     args.QuotaHandler,
+		hasher:                           args.Hasher,
+		userErrorsAsVMOutputs:            args.UserErrorsAsVMOutputs,
+		minInactiveEpochsForDormantSweep: args.MinInactiveEpochsForDormantSweep,
+		epochNotifier:                    args.EpochNotifier,
+		limits:                           args.Limits,
+		outputTransferAggregation:        args.OutputTransferAggregation,
+		addressLength:                    args.AddressLength,
+		shardFunctions:                   args.ShardFunctions,
+		enableEpochsHandlerFactory:       args.EnableEpochsHandlerFactory,
+		wrappedNativeTokenID:             args.WrappedNativeTokenID,
+		bridgeAddresses:                  args.BridgeAddresses,
+		sameShardMultiTransferCalls:      args.SameShardMultiTransferCalls,
+		systemAddresses:                  systemAddresses,
+		royaltiesDenominator:             args.RoyaltiesDenominator,
+		storageUsageTracker:              args.StorageUsageTracker,
+		metaDataCompressor:               args.MetaDataCompressor,
+		metaDataCompressionThreshold:     args.MetaDataCompressionThreshold,
+		logAddressFormat:                 args.LogAddressFormat,
+		pubkeyConverter:                  args.PubkeyConverter,
+		chainID:                          args.ChainID,
+	}
+	if b.royaltiesDenominator == 0 {
+		b.royaltiesDenominator = vmcommon.DefaultRoyaltiesDenominator
+	}
+	err = checkRoyaltiesDenominator(b.royaltiesDenominator)
+	if err != nil {
+		return nil, err
+	}
+	if b.minInactiveEpochsForDormantSweep == 0 {
+		b.minInactiveEpochsForDormantSweep = defaultMinInactiveEpochsForDormantSweep
+	}
+	if check.IfNil(b.epochNotifier) {
+		b.epochNotifier = &disabledEpochNotifier{}
+	}
+	if !check.IfNil(b.enableEpochsHandlerFactory) {
+		// all the components resolve the flags through the pinnable handler so the replayed calls can pin a past epoch
+		b.replayHandler = newEpochPinnedEnableEpochsHandler(args.EnableEpochsHandler)
+		b.enableEpochsHandler = b.replayHandler
 	}
 
-	var err error
 	b.gasConfig, err = createGasConfig(args.GasMap)
 	if err != nil {
 		return nil, err
@@ -79,6 +193,24 @@ func NewBuiltInFunctionsCreator(args ArgsCreateBuiltInFunctionContainer) (*built
 	return b, nil
 }
 
+// createSystemAddresses returns the default system addresses if none are provided, otherwise it checks the provided
+// ones, which must also have the configured address length
+func createSystemAddresses(systemAddresses vmcommon.SystemAddresses, addressLength int) (vmcommon.SystemAddresses, error) {
+	if len(systemAddresses.DCTSCAddress) == 0 && len(systemAddresses.SystemAccountAddress) == 0 {
+		return vmcommon.DefaultSystemAddresses(), nil
+	}
+
+	err := systemAddresses.Check()
+	if err != nil {
+		return vmcommon.SystemAddresses{}, err
+	}
+	if addressLength > 0 && len(systemAddresses.DCTSCAddress) != addressLength {
+		return vmcommon.SystemAddresses{}, fmt.Errorf("%w of %d bytes, expected %d", vmcommon.ErrInvalidDCTSCAddress, len(systemAddresses.DCTSCAddress), addressLength)
+	}
+
+	return systemAddresses, nil
+}
+
 // GasScheduleChange is called when gas schedule is changed, thus all contracts must be updated
 func (b *builtInFuncCreator) GasScheduleChange(gasSchedule map[string]map[string]uint64) {
 	newGasConfig, err := createGasConfig(gasSchedule)
@@ -87,6 +219,80 @@ func (b *builtInFuncCreator) GasScheduleChange(gasSchedule map[string]map[string
 	}
 
 	b.gasConfig = newGasConfig
+	b.setGasConfigToAllFunctions()
+}
+
+// createWrapNativeFunctions adds the wrap/unwrap native functions, provided the wrapped native token is configured
+func (b *builtInFuncCreator) createWrapNativeFunctions(config Config) error {
+	if len(b.wrappedNativeTokenID) == 0 {
+		return nil
+	}
+
+	newFunc, err := NewWrapNativeFunc(ArgsNewWrapNative{
+		Config:         config,
+		FuncGasCost:    b.gasConfig.BuiltInCost.DCTLocalMint,
+		WrappedTokenID: b.wrappedNativeTokenID,
+		Wrap:           true,
+	})
+	if err != nil {
+		return err
+	}
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionWrapNative, newFunc)
+	if err != nil {
+		return err
+	}
+
+	newFunc, err = NewWrapNativeFunc(ArgsNewWrapNative{
+		Config:         config,
+		FuncGasCost:    b.gasConfig.BuiltInCost.DCTLocalBurn,
+		WrappedTokenID: b.wrappedNativeTokenID,
+		Wrap:           false,
+	})
+	if err != nil {
+		return err
+	}
+
+	return b.builtInFunctions.Add(vmcommon.BuiltInFunctionUnwrapNative, newFunc)
+}
+
+// createBridgeFunctions adds the bridge mint/burn functions, provided the bridge addresses are configured
+func (b *builtInFuncCreator) createBridgeFunctions(config Config) error {
+	if len(b.bridgeAddresses) == 0 {
+		return nil
+	}
+
+	newFunc, err := NewDCTBridgeMintFunc(ArgsNewDCTBridge{
+		Config:          config,
+		FuncGasCost:     b.gasConfig.BuiltInCost.DCTLocalMint,
+		BridgeAddresses: b.bridgeAddresses,
+	})
+	if err != nil {
+		return err
+	}
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionDCTBridgeMint, newFunc)
+	if err != nil {
+		return err
+	}
+
+	newFunc, err = NewDCTBridgeBurnFunc(ArgsNewDCTBridge{
+		Config:          config,
+		FuncGasCost:     b.gasConfig.BuiltInCost.DCTLocalBurn,
+		BridgeAddresses: b.bridgeAddresses,
+	})
+	if err != nil {
+		return err
+	}
+
+	return b.builtInFunctions.Add(vmcommon.BuiltInFunctionDCTBridgeBurn, newFunc)
+}
+
+func (b *builtInFuncCreator) setGasConfigToAllFunctions() {
+	gasConfigurableContainer, ok := b.builtInFunctions.(vmcommon.GasConfigurableContainer)
+	if ok {
+		gasConfigurableContainer.ApplyGasConfig(b.gasConfig)
+		return
+	}
+
 	for key := range b.builtInFunctions.Keys() {
 		builtInFunc, errGet := b.builtInFunctions.Get(key)
 		if errGet != nil {
@@ -114,11 +320,58 @@ func (b *builtInFuncCreator) BuiltInFunctionContainer() vmcommon.BuiltInFunction
 
 // CreateBuiltInFunctionContainer will create the list of built-in functions
 func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
+	functionContainer := NewBuiltInFunctionContainer()
+	if !check.IfNil(b.metrics) {
+		err := functionContainer.SetMetrics(b.metrics)
+		if err != nil {
+			return err
+		}
+	}
+	if !check.IfNil(b.logPublisher) {
+		err := functionContainer.SetLogPublisher(b.logPublisher)
+		if err != nil {
+			return err
+		}
+	}
+	if !check.IfNil(b.functionResolver) {
+		err := functionContainer.SetFunctionResolver(b.functionResolver)
+		if err != nil {
+			return err
+		}
+	}
+	if !check.IfNil(b.quotaHandler) {
+		err := functionContainer.SetQuotaHandler(b.quotaHandler)
+		if err != nil {
+			return err
+		}
+	}
+	if !check.IfNil(b.accounts) {
+		err := functionContainer.SetAccountsAdapter(b.accounts, b.shardCoordinator)
+		if err != nil {
+			return err
+		}
+	}
+	err := functionContainer.SetEpochNotifier(b.epochNotifier)
+	if err != nil {
+		return err
+	}
+	functionContainer.SetUserErrorsAsVMOutputs(b.userErrorsAsVMOutputs)
+	functionContainer.SetLimitsConfig(b.limits)
+	functionContainer.SetOutputTransferAggregationConfig(b.outputTransferAggregation)
+	functionContainer.SetAddressLength(b.addressLength)
+	err = functionContainer.SetLogAddressFormat(b.logAddressFormat, b.pubkeyConverter)
+	if err != nil {
+		return err
+	}
+	functionContainer.SetShardFunctionsConfig(b.shardFunctions, b.shardCoordinator.SelfId())
+	if b.replayHandler != nil {
+		functionContainer.setHistoricalReplay(b.enableEpochsHandlerFactory, b.replayHandler)
+	}
+	b.builtInFunctions = functionContainer
 
-	b.builtInFunctions = NewBuiltInFunctionContainer()
 	var newFunc vmcommon.BuiltinFunction
 	newFunc = NewClaimDeveloperRewardsFunc(b.gasConfig.BuiltInCost.ClaimDeveloperRewards)
-	err := b.builtInFunctions.Add(core.BuiltInFunctionClaimDeveloperRewards, newFunc)
+	err = b.builtInFunctions.Add(core.BuiltInFunctionClaimDeveloperRewards, newFunc)
 	if err != nil {
 		return err
 	}
@@ -147,7 +400,22 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 		return err
 	}
 
-	globalSettingsFunc, err := NewDCTGlobalSettingsFunc(b.accounts, b.marshaller, true, core.BuiltInFunctionDCTPause, trueHandler)
+	// the handlers built here are added to the config as soon as they are created. The container forwards the confirmed
+	// epochs to the functions needing them, such as the rental ones
+	config := Config{
+		Marshalizer:         b.marshaller,
+		Accounts:            b.accounts,
+		ShardCoordinator:    b.shardCoordinator,
+		EnableEpochsHandler: b.enableEpochsHandler,
+		EpochNotifier:       &disabledEpochNotifier{},
+	}
+
+	globalSettingsFunc, err := NewDCTGlobalSettingsFunc(ArgsNewDCTGlobalSettings{
+		Config:        config,
+		Set:           true,
+		Function:      core.BuiltInFunctionDCTPause,
+		ActiveHandler: trueHandler,
+	})
 	if err != nil {
 		return err
 	}
@@ -156,23 +424,25 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 		return err
 	}
 	b.dctGlobalSettingsHandler = globalSettingsFunc
+	config.GlobalSettingsHandler = globalSettingsFunc
 
-	setRoleFunc, err := NewDCTRolesFunc(b.marshaller, true)
+	setRoleFunc, err := NewDCTRolesFunc(ArgsNewDCTRoles{
+		Config: config,
+		Set:    true,
+	})
 	if err != nil {
 		return err
 	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionSetDCTRole, setRoleFunc)
+	config.RolesHandler = setRoleFunc
+	err = b.builtInFunctions.Add(core.BuiltInFunctionSetDCTRole, newMultiSigFunction(setRoleFunc, globalSettingsFunc, b.accounts, b.chainID))
 	if err != nil {
 		return err
 	}
 
-	newFunc, err = NewDCTTransferFunc(
-		b.gasConfig.BuiltInCost.DCTTransfer,
-		b.marshaller,
-		globalSettingsFunc,
-		b.shardCoordinator,
-		setRoleFunc,
-		b.enableEpochsHandler)
+	newFunc, err = NewDCTTransferFunc(ArgsNewDCTTransfer{
+		Config:      config,
+		FuncGasCost: b.gasConfig.BuiltInCost.DCTTransfer,
+	})
 	if err != nil {
 		return err
 	}
@@ -181,7 +451,10 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 		return err
 	}
 
-	newFunc, err = NewDCTBurnFunc(b.gasConfig.BuiltInCost.DCTBurn, b.marshaller, globalSettingsFunc, b.enableEpochsHandler)
+	newFunc, err = NewDCTBurnFunc(ArgsNewDCTBurn{
+		Config:      config,
+		FuncGasCost: b.gasConfig.BuiltInCost.DCTBurn,
+	})
 	if err != nil {
 		return err
 	}
@@ -190,25 +463,36 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 		return err
 	}
 
-	newFunc, err = NewDCTGlobalSettingsFunc(b.accounts, b.marshaller, false, core.BuiltInFunctionDCTUnPause, trueHandler)
+	newFunc, err = NewDCTGlobalSettingsFunc(ArgsNewDCTGlobalSettings{
+		Config:        config,
+		Set:           false,
+		Function:      core.BuiltInFunctionDCTUnPause,
+		ActiveHandler: trueHandler,
+	})
 	if err != nil {
 		return err
 	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionDCTUnPause, newFunc)
+	err = b.builtInFunctions.Add(core.BuiltInFunctionDCTUnPause, newMultiSigFunction(newFunc, globalSettingsFunc, b.accounts, b.chainID))
 	if err != nil {
 		return err
 	}
 
-	newFunc, err = NewDCTRolesFunc(b.marshaller, false)
+	newFunc, err = NewDCTRolesFunc(ArgsNewDCTRoles{
+		Config: config,
+		Set:    false,
+	})
 	if err != nil {
 		return err
 	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionUnSetDCTRole, newFunc)
+	err = b.builtInFunctions.Add(core.BuiltInFunctionUnSetDCTRole, newMultiSigFunction(newFunc, globalSettingsFunc, b.accounts, b.chainID))
 	if err != nil {
 		return err
 	}
 
-	newFunc, err = NewDCTLocalBurnFunc(b.gasConfig.BuiltInCost.DCTLocalBurn, b.marshaller, globalSettingsFunc, setRoleFunc)
+	newFunc, err = NewDCTLocalBurnFunc(ArgsNewDCTLocalBurn{
+		Config:      config,
+		FuncGasCost: b.gasConfig.BuiltInCost.DCTLocalBurn,
+	})
 	if err != nil {
 		return err
 	}
@@ -217,7 +501,10 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 		return err
 	}
 
-	newFunc, err = NewDCTLocalMintFunc(b.gasConfig.BuiltInCost.DCTLocalMint, b.marshaller, globalSettingsFunc, setRoleFunc)
+	newFunc, err = NewDCTLocalMintFunc(ArgsNewDCTLocalMint{
+		Config:      config,
+		FuncGasCost: b.gasConfig.BuiltInCost.DCTLocalMint,
+	})
 	if err != nil {
 		return err
 	}
@@ -232,13 +519,20 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 		Marshalizer:           b.marshaller,
 		EnableEpochsHandler:   b.enableEpochsHandler,
 		ShardCoordinator:      b.shardCoordinator,
+		Compressor:            b.metaDataCompressor,
+		CompressionThreshold:  b.metaDataCompressionThreshold,
 	}
 	b.dctStorageHandler, err = NewDCTDataStorage(args)
 	if err != nil {
 		return err
 	}
 
-	newFunc, err = NewDCTNFTAddQuantityFunc(b.gasConfig.BuiltInCost.DCTNFTAddQuantity, b.dctStorageHandler, globalSettingsFunc, setRoleFunc, b.enableEpochsHandler)
+	config.DCTStorageHandler = b.dctStorageHandler
+
+	newFunc, err = NewDCTNFTAddQuantityFunc(ArgsNewDCTNFTAddQuantity{
+		Config:      config,
+		FuncGasCost: b.gasConfig.BuiltInCost.DCTNFTAddQuantity,
+	})
 	if err != nil {
 		return err
 	}
@@ -247,7 +541,10 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 		return err
 	}
 
-	newFunc, err = NewDCTNFTBurnFunc(b.gasConfig.BuiltInCost.DCTNFTBurn, b.dctStorageHandler, globalSettingsFunc, setRoleFunc)
+	newFunc, err = NewDCTNFTBurnFunc(ArgsNewDCTNFTBurn{
+		Config:      config,
+		FuncGasCost: b.gasConfig.BuiltInCost.DCTNFTBurn,
+	})
 	if err != nil {
 		return err
 	}
@@ -256,7 +553,12 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 		return err
 	}
 
-	newFunc, err = NewDCTNFTCreateFunc(b.gasConfig.BuiltInCost.DCTNFTCreate, b.gasConfig.BaseOperationCost, b.marshaller, globalSettingsFunc, setRoleFunc, b.dctStorageHandler, b.accounts, b.enableEpochsHandler)
+	argsNFTCreate := ArgsNewDCTNFTCreate{
+		Config:      config,
+		FuncGasCost: b.gasConfig.BuiltInCost.DCTNFTCreate,
+		GasConfig:   b.gasConfig.BaseOperationCost,
+	}
+	newFunc, err = NewDCTNFTCreateFunc(argsNFTCreate)
 	if err != nil {
 		return err
 	}
@@ -265,42 +567,59 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 		return err
 	}
 
-	newFunc, err = NewDCTFreezeWipeFunc(b.dctStorageHandler, b.enableEpochsHandler, b.marshaller, true, false)
+	newFunc, err = NewDCTNFTCreateOnBehalfFunc(argsNFTCreate)
+	if err != nil {
+		return err
+	}
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionDCTNFTCreateOnBehalf, newFunc)
+	if err != nil {
+		return err
+	}
+
+	newFunc, err = NewDCTFreezeWipeFunc(ArgsNewDCTFreezeWipe{
+		Config: config,
+		Freeze: true,
+		Wipe:   false,
+	})
 	if err != nil {
 		return err
 	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionDCTFreeze, newFunc)
+	err = b.builtInFunctions.Add(core.BuiltInFunctionDCTFreeze, newMultiSigFunction(newFunc, globalSettingsFunc, b.accounts, b.chainID))
 	if err != nil {
 		return err
 	}
 
-	newFunc, err = NewDCTFreezeWipeFunc(b.dctStorageHandler, b.enableEpochsHandler, b.marshaller, false, false)
+	newFunc, err = NewDCTFreezeWipeFunc(ArgsNewDCTFreezeWipe{
+		Config: config,
+		Freeze: false,
+		Wipe:   false,
+	})
 	if err != nil {
 		return err
 	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionDCTUnFreeze, newFunc)
+	err = b.builtInFunctions.Add(core.BuiltInFunctionDCTUnFreeze, newMultiSigFunction(newFunc, globalSettingsFunc, b.accounts, b.chainID))
 	if err != nil {
 		return err
 	}
 
-	newFunc, err = NewDCTFreezeWipeFunc(b.dctStorageHandler, b.enableEpochsHandler, b.marshaller, false, true)
+	newFunc, err = NewDCTFreezeWipeFunc(ArgsNewDCTFreezeWipe{
+		Config: config,
+		Freeze: false,
+		Wipe:   true,
+	})
 	if err != nil {
 		return err
 	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionDCTWipe, newFunc)
+	err = b.builtInFunctions.Add(core.BuiltInFunctionDCTWipe, newMultiSigFunction(newFunc, globalSettingsFunc, b.accounts, b.chainID))
 	if err != nil {
 		return err
 	}
 
-	newFunc, err = NewDCTNFTTransferFunc(b.gasConfig.BuiltInCost.DCTNFTTransfer,
-		b.marshaller,
-		globalSettingsFunc,
-		b.accounts,
-		b.shardCoordinator,
-		b.gasConfig.BaseOperationCost,
-		setRoleFunc,
-		b.dctStorageHandler,
-		b.enableEpochsHandler)
+	newFunc, err = NewDCTNFTTransferFunc(ArgsNewDCTNFTTransfer{
+		Config:      config,
+		FuncGasCost: b.gasConfig.BuiltInCost.DCTNFTTransfer,
+		GasConfig:   b.gasConfig.BaseOperationCost,
+	})
 	if err != nil {
 		return err
 	}
@@ -309,7 +628,9 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 		return err
 	}
 
-	newFunc, err = NewDCTNFTCreateRoleTransfer(b.marshaller, b.accounts, b.shardCoordinator)
+	newFunc, err = NewDCTNFTCreateRoleTransfer(ArgsNewDCTNFTCreateRoleTransfer{
+		Config: config,
+	})
 	if err != nil {
 		return err
 	}
@@ -318,7 +639,11 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 		return err
 	}
 
-	newFunc, err = NewDCTNFTUpdateAttributesFunc(b.gasConfig.BuiltInCost.DCTNFTUpdateAttributes, b.gasConfig.BaseOperationCost, b.dctStorageHandler, globalSettingsFunc, setRoleFunc, b.enableEpochsHandler)
+	newFunc, err = NewDCTNFTUpdateAttributesFunc(ArgsNewDCTNFTUpdateAttributes{
+		Config:      config,
+		FuncGasCost: b.gasConfig.BuiltInCost.DCTNFTUpdateAttributes,
+		GasConfig:   b.gasConfig.BaseOperationCost,
+	})
 	if err != nil {
 		return err
 	}
@@ -327,7 +652,11 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 		return err
 	}
 
-	newFunc, err = NewDCTNFTAddUriFunc(b.gasConfig.BuiltInCost.DCTNFTAddURI, b.gasConfig.BaseOperationCost, b.dctStorageHandler, globalSettingsFunc, setRoleFunc, b.enableEpochsHandler)
+	newFunc, err = NewDCTNFTAddUriFunc(ArgsNewDCTNFTAddUri{
+		Config:      config,
+		FuncGasCost: b.gasConfig.BuiltInCost.DCTNFTAddURI,
+		GasConfig:   b.gasConfig.BaseOperationCost,
+	})
 	if err != nil {
 		return err
 	}
@@ -336,48 +665,55 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 		return err
 	}
 
-	newFunc, err = NewDCTNFTMultiTransferFunc(b.gasConfig.BuiltInCost.DCTNFTMultiTransfer,
-		b.marshaller,
-		globalSettingsFunc,
-		b.accounts,
-		b.shardCoordinator,
-		b.gasConfig.BaseOperationCost,
-		b.enableEpochsHandler,
-		setRoleFunc,
-		b.dctStorageHandler)
+	multiTransferFunc, err := NewDCTNFTMultiTransferFunc(ArgsNewDCTNFTMultiTransfer{
+		Config:      config,
+		FuncGasCost: b.gasConfig.BuiltInCost.DCTNFTMultiTransfer,
+		GasConfig:   b.gasConfig.BaseOperationCost,
+	})
 	if err != nil {
 		return err
 	}
+	multiTransferFunc.SetSameShardCallReceivers(b.sameShardMultiTransferCalls)
+	newFunc = multiTransferFunc
+	newFunc.SetNewGasConfig(b.gasConfig)
 	err = b.builtInFunctions.Add(core.BuiltInFunctionMultiDCTNFTTransfer, newFunc)
 	if err != nil {
 		return err
 	}
 
-	newFunc, err = NewDCTGlobalSettingsFunc(b.accounts, b.marshaller, true, core.BuiltInFunctionDCTSetLimitedTransfer, b.enableEpochsHandler.IsDCTTransferRoleFlagEnabled)
+	newFunc, err = NewDCTGlobalSettingsFunc(ArgsNewDCTGlobalSettings{
+		Config:        config,
+		Set:           true,
+		Function:      core.BuiltInFunctionDCTSetLimitedTransfer,
+		ActiveHandler: b.enableEpochsHandler.IsDCTTransferRoleFlagEnabled,
+	})
 	if err != nil {
 		return err
 	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionDCTSetLimitedTransfer, newFunc)
+	err = b.builtInFunctions.Add(core.BuiltInFunctionDCTSetLimitedTransfer, newMultiSigFunction(newFunc, globalSettingsFunc, b.accounts, b.chainID))
 	if err != nil {
 		return err
 	}
 
-	newFunc, err = NewDCTGlobalSettingsFunc(b.accounts, b.marshaller, false, core.BuiltInFunctionDCTUnSetLimitedTransfer, b.enableEpochsHandler.IsDCTTransferRoleFlagEnabled)
+	newFunc, err = NewDCTGlobalSettingsFunc(ArgsNewDCTGlobalSettings{
+		Config:        config,
+		Set:           false,
+		Function:      core.BuiltInFunctionDCTUnSetLimitedTransfer,
+		ActiveHandler: b.enableEpochsHandler.IsDCTTransferRoleFlagEnabled,
+	})
 	if err != nil {
 		return err
 	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionDCTUnSetLimitedTransfer, newFunc)
+	err = b.builtInFunctions.Add(core.BuiltInFunctionDCTUnSetLimitedTransfer, newMultiSigFunction(newFunc, globalSettingsFunc, b.accounts, b.chainID))
 	if err != nil {
 		return err
 	}
 
 	argsNewDeleteFunc := ArgsNewDCTDeleteMetadata{
-		FuncGasCost:         b.gasConfig.BuiltInCost.DCTNFTBurn,
-		Marshalizer:         b.marshaller,
-		Accounts:            b.accounts,
-		AllowedAddress:      b.configAddress,
-		Delete:              true,
-		EnableEpochsHandler: b.enableEpochsHandler,
+		Config:         config,
+		FuncGasCost:    b.gasConfig.BuiltInCost.DCTNFTBurn,
+		AllowedAddress: b.configAddress,
+		Delete:         true,
 	}
 	newFunc, err = NewDCTDeleteMetadataFunc(argsNewDeleteFunc)
 	if err != nil {
@@ -398,103 +734,907 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 		return err
 	}
 
-	newFunc, err = NewDCTGlobalSettingsFunc(b.accounts, b.marshaller, true, vmcommon.BuiltInFunctionDCTSetBurnRoleForAll, b.enableEpochsHandler.IsSendAlwaysFlagEnabled)
+	newFunc, err = NewDCTGlobalSettingsFunc(ArgsNewDCTGlobalSettings{
+		Config:        config,
+		Set:           true,
+		Function:      vmcommon.BuiltInFunctionDCTSetBurnRoleForAll,
+		ActiveHandler: b.enableEpochsHandler.IsSendAlwaysFlagEnabled,
+	})
 	if err != nil {
 		return err
 	}
-	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionDCTSetBurnRoleForAll, newFunc)
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionDCTSetBurnRoleForAll, newMultiSigFunction(newFunc, globalSettingsFunc, b.accounts, b.chainID))
 	if err != nil {
 		return err
 	}
 
-	newFunc, err = NewDCTGlobalSettingsFunc(b.accounts, b.marshaller, false, vmcommon.BuiltInFunctionDCTUnSetBurnRoleForAll, b.enableEpochsHandler.IsSendAlwaysFlagEnabled)
+	newFunc, err = NewDCTGlobalSettingsFunc(ArgsNewDCTGlobalSettings{
+		Config:        config,
+		Set:           false,
+		Function:      vmcommon.BuiltInFunctionDCTUnSetBurnRoleForAll,
+		ActiveHandler: b.enableEpochsHandler.IsSendAlwaysFlagEnabled,
+	})
 	if err != nil {
 		return err
 	}
-	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionDCTUnSetBurnRoleForAll, newFunc)
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionDCTUnSetBurnRoleForAll, newMultiSigFunction(newFunc, globalSettingsFunc, b.accounts, b.chainID))
 	if err != nil {
 		return err
 	}
 
-	newFunc, err = NewDCTTransferRoleAddressFunc(b.accounts, b.marshaller, b.maxNumOfAddressesForTransferRole, false, b.enableEpochsHandler)
+	newFunc, err = NewDCTTransferRoleAddressFunc(ArgsNewDCTTransferRoleAddress{
+		Config:          config,
+		MaxNumAddresses: b.maxNumOfAddressesForTransferRole,
+		Set:             false,
+	})
 	if err != nil {
 		return err
 	}
-	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionDCTTransferRoleDeleteAddress, newFunc)
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionDCTTransferRoleDeleteAddress, newMultiSigFunction(newFunc, globalSettingsFunc, b.accounts, b.chainID))
 	if err != nil {
 		return err
 	}
 
-	newFunc, err = NewDCTTransferRoleAddressFunc(b.accounts, b.marshaller, b.maxNumOfAddressesForTransferRole, true, b.enableEpochsHandler)
+	newFunc, err = NewDCTTransferRoleAddressFunc(ArgsNewDCTTransferRoleAddress{
+		Config:          config,
+		MaxNumAddresses: b.maxNumOfAddressesForTransferRole,
+		Set:             true,
+	})
 	if err != nil {
 		return err
 	}
-	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionDCTTransferRoleAddAddress, newFunc)
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionDCTTransferRoleAddAddress, newMultiSigFunction(newFunc, globalSettingsFunc, b.accounts, b.chainID))
 	if err != nil {
 		return err
 	}
 
-	return nil
-}
+	setCollectionConfigFunc, err := NewDCTCollectionConfigFunc(ArgsNewDCTCollectionConfig{
+		Config: config,
+		Set:    true,
+	})
+	if err != nil {
+		return err
+	}
+	err = setCollectionConfigFunc.SetRoyaltiesDenominator(b.royaltiesDenominator)
+	if err != nil {
+		return err
+	}
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionDCTSetCollectionConfig, newMultiSigFunction(setCollectionConfigFunc, globalSettingsFunc, b.accounts, b.chainID))
+	if err != nil {
+		return err
+	}
 
-func createGasConfig(gasMap map[string]map[string]uint64) (*vmcommon.GasCost, error) {
-	baseOps := &vmcommon.BaseOperationCost{}
-	err := mapstructure.Decode(gasMap[core.BaseOperationCostString], baseOps)
+	newFunc, err = NewDCTCollectionConfigFunc(ArgsNewDCTCollectionConfig{
+		Config: config,
+		Set:    false,
+	})
 	if err != nil {
-		return nil, err
+		return err
+	}
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionDCTUnSetCollectionConfig, newMultiSigFunction(newFunc, globalSettingsFunc, b.accounts, b.chainID))
+	if err != nil {
+		return err
 	}
 
-	err = check.ForZeroUintFields(*baseOps)
+	newFunc, err = NewDCTGlobalSettingsFunc(ArgsNewDCTGlobalSettings{
+		Config:        config,
+		Set:           true,
+		Function:      vmcommon.BuiltInFunctionDCTSetDormantSweep,
+		ActiveHandler: b.enableEpochsHandler.IsDormantSweepFlagEnabled,
+	})
 	if err != nil {
-		return nil, err
+		return err
+	}
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionDCTSetDormantSweep, newMultiSigFunction(newFunc, globalSettingsFunc, b.accounts, b.chainID))
+	if err != nil {
+		return err
 	}
 
-	builtInOps := &vmcommon.BuiltInCost{}
-	err = mapstructure.Decode(gasMap[core.BuiltInCostString], builtInOps)
+	newFunc, err = NewDCTGlobalSettingsFunc(ArgsNewDCTGlobalSettings{
+		Config:        config,
+		Set:           false,
+		Function:      vmcommon.BuiltInFunctionDCTUnSetDormantSweep,
+		ActiveHandler: b.enableEpochsHandler.IsDormantSweepFlagEnabled,
+	})
 	if err != nil {
-		return nil, err
+		return err
+	}
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionDCTUnSetDormantSweep, newMultiSigFunction(newFunc, globalSettingsFunc, b.accounts, b.chainID))
+	if err != nil {
+		return err
 	}
 
-	err = check.ForZeroUintFields(*builtInOps)
+	newFunc, err = NewDCTDormantSweepFunc(ArgsNewDCTDormantSweep{
+		Config:            config,
+		MinInactiveEpochs: b.minInactiveEpochsForDormantSweep,
+	})
 	if err != nil {
-		return nil, err
+		return err
+	}
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionDCTSweepDormant, newFunc)
+	if err != nil {
+		return err
 	}
 
-	gasCost := vmcommon.GasCost{
-		BaseOperationCost: *baseOps,
-		BuiltInCost:       *builtInOps,
+	newFunc, err = NewDCTGlobalSettingsFunc(ArgsNewDCTGlobalSettings{
+		Config:        config,
+		Set:           true,
+		Function:      vmcommon.BuiltInFunctionDCTSetMultiSigManaged,
+		ActiveHandler: b.enableEpochsHandler.IsMultiSigManagementFlagEnabled,
+	})
+	if err != nil {
+		return err
+	}
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionDCTSetMultiSigManaged, newFunc)
+	if err != nil {
+		return err
 	}
 
-	return &gasCost, nil
-}
+	newFunc, err = NewDCTGlobalSettingsFunc(ArgsNewDCTGlobalSettings{
+		Config:        config,
+		Set:           false,
+		Function:      vmcommon.BuiltInFunctionDCTUnSetMultiSigManaged,
+		ActiveHandler: b.enableEpochsHandler.IsMultiSigManagementFlagEnabled,
+	})
+	if err != nil {
+		return err
+	}
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionDCTUnSetMultiSigManaged, newMultiSigFunction(newFunc, globalSettingsFunc, b.accounts, b.chainID))
+	if err != nil {
+		return err
+	}
 
-// SetPayableHandler sets the payableCheck interface to the needed functions
-func (b *builtInFuncCreator) SetPayableHandler(payableHandler vmcommon.PayableHandler) error {
-	payableChecker, err := NewPayableCheckFunc(
-		payableHandler,
-		b.enableEpochsHandler,
-	)
+	newFunc, err = NewDCTGlobalSettingsFunc(ArgsNewDCTGlobalSettings{
+		Config:        config,
+		Set:           true,
+		Function:      vmcommon.BuiltInFunctionDCTSetSoulbound,
+		ActiveHandler: b.enableEpochsHandler.IsSoulboundFlagEnabled,
+	})
+	if err != nil {
+		return err
+	}
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionDCTSetSoulbound, newMultiSigFunction(newFunc, globalSettingsFunc, b.accounts, b.chainID))
 	if err != nil {
 		return err
 	}
 
-	listOfTransferFunc := []string{
-		core.BuiltInFunctionMultiDCTNFTTransfer,
-		core.BuiltInFunctionDCTNFTTransfer,
-		core.BuiltInFunctionDCTTransfer}
+	newFunc, err = NewDCTGlobalSettingsFunc(ArgsNewDCTGlobalSettings{
+		Config:        config,
+		Set:           false,
+		Function:      vmcommon.BuiltInFunctionDCTUnSetSoulbound,
+		ActiveHandler: b.enableEpochsHandler.IsSoulboundFlagEnabled,
+	})
+	if err != nil {
+		return err
+	}
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionDCTUnSetSoulbound, newMultiSigFunction(newFunc, globalSettingsFunc, b.accounts, b.chainID))
+	if err != nil {
+		return err
+	}
 
-	for _, transferFunc := range listOfTransferFunc {
-		builtInFunc, err := b.builtInFunctions.Get(transferFunc)
-		if err != nil {
-			return err
-		}
+	newFunc, err = NewDCTRentNFTFunc(ArgsNewDCTRentNFT{
+		Config:      config,
+		FuncGasCost: b.gasConfig.BuiltInCost.DCTNFTTransfer,
+	})
+	if err != nil {
+		return err
+	}
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionDCTRentNFT, newFunc)
+	if err != nil {
+		return err
+	}
 
-		dctTransferFunc, ok := builtInFunc.(vmcommon.AcceptPayableChecker)
-		if !ok {
-			return ErrWrongTypeAssertion
-		}
+	newFunc, err = NewDCTReclaimRentedNFTFunc(ArgsNewDCTReclaimRentedNFT{
+		Config:      config,
+		FuncGasCost: b.gasConfig.BuiltInCost.DCTNFTTransfer,
+	})
+	if err != nil {
+		return err
+	}
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionDCTReclaimRentedNFT, newFunc)
+	if err != nil {
+		return err
+	}
 
-		err = dctTransferFunc.SetPayableChecker(payableChecker)
+	newFunc, err = NewDCTGlobalSettingsFunc(ArgsNewDCTGlobalSettings{
+		Config:        config,
+		Set:           true,
+		Function:      vmcommon.BuiltInFunctionDCTStopNFTCreate,
+		ActiveHandler: b.enableEpochsHandler.IsStopNFTCreateFlagEnabled,
+	})
+	if err != nil {
+		return err
+	}
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionDCTStopNFTCreate, newMultiSigFunction(newFunc, globalSettingsFunc, b.accounts, b.chainID))
+	if err != nil {
+		return err
+	}
+
+	newFunc, err = NewDCTGlobalSettingsFunc(ArgsNewDCTGlobalSettings{
+		Config:        config,
+		Set:           false,
+		Function:      vmcommon.BuiltInFunctionDCTResumeNFTCreate,
+		ActiveHandler: b.enableEpochsHandler.IsStopNFTCreateFlagEnabled,
+	})
+	if err != nil {
+		return err
+	}
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionDCTResumeNFTCreate, newMultiSigFunction(newFunc, globalSettingsFunc, b.accounts, b.chainID))
+	if err != nil {
+		return err
+	}
+
+	newFunc, err = NewDCTModifyCreatorFunc(ArgsNewDCTModifyCreator{
+		Config:      config,
+		FuncGasCost: b.gasConfig.BuiltInCost.DCTNFTUpdateAttributes,
+		GasConfig:   b.gasConfig.BaseOperationCost,
+	})
+	if err != nil {
+		return err
+	}
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionDCTModifyCreator, newFunc)
+	if err != nil {
+		return err
+	}
+
+	newFunc, err = NewDCTSetNewURIsFunc(ArgsNewDCTSetNewURIs{
+		Config:      config,
+		FuncGasCost: b.gasConfig.BuiltInCost.DCTNFTAddURI,
+		GasConfig:   b.gasConfig.BaseOperationCost,
+	})
+	if err != nil {
+		return err
+	}
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionDCTSetNewURIs, newFunc)
+	if err != nil {
+		return err
+	}
+
+	newFunc, err = NewDCTNFTUpdateFunc(ArgsNewDCTNFTUpdate{
+		Config:      config,
+		FuncGasCost: b.gasConfig.BuiltInCost.DCTNFTUpdateAttributes,
+		GasConfig:   b.gasConfig.BaseOperationCost,
+	})
+	if err != nil {
+		return err
+	}
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionDCTNFTUpdate, newFunc)
+	if err != nil {
+		return err
+	}
+
+	newFunc, err = NewDCTTransferAndLockFunc(ArgsNewDCTTransferAndLock{
+		Config:      config,
+		FuncGasCost: b.gasConfig.BuiltInCost.DCTTransfer,
+	})
+	if err != nil {
+		return err
+	}
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionDCTTransferAndLock, newFunc)
+	if err != nil {
+		return err
+	}
+
+	newFunc, err = NewDCTSetMetaDCTFunc(ArgsNewDCTSetMetaDCT{
+		Config: config,
+	})
+	if err != nil {
+		return err
+	}
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionDCTSetMetaDCT, newFunc)
+	if err != nil {
+		return err
+	}
+
+	newFunc, err = NewDCTApproveFunc(ArgsNewDCTApprove{
+		Config:      config,
+		FuncGasCost: b.gasConfig.BuiltInCost.DCTTransfer,
+	})
+	if err != nil {
+		return err
+	}
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionDCTApprove, newFunc)
+	if err != nil {
+		return err
+	}
+
+	newFunc, err = NewDCTTransferFromFunc(ArgsNewDCTTransferFrom{
+		Config:      config,
+		FuncGasCost: b.gasConfig.BuiltInCost.DCTTransfer,
+	})
+	if err != nil {
+		return err
+	}
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionDCTTransferFrom, newFunc)
+	if err != nil {
+		return err
+	}
+
+	err = b.createWrapNativeFunctions(config)
+	if err != nil {
+		return err
+	}
+
+	err = b.createBridgeFunctions(config)
+	if err != nil {
+		return err
+	}
+
+	declareCallValuePolicies(functionContainer)
+
+	// the constructors only receive the costs they charge, the rest of the gas schedule is applied afterwards
+	b.setGasConfigToAllFunctions()
+
+	if !check.IfNil(b.hasher) {
+		err = b.SetHasher(b.hasher)
+		if err != nil {
+			return err
+		}
+	}
+	if !check.IfNil(b.storageUsageTracker) {
+		err = b.SetStorageUsageTracker(b.storageUsageTracker)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = b.setAddressLengthToAllFunctions()
+	if err != nil {
+		return err
+	}
+
+	return b.setSystemAddressesToAllFunctions()
+}
+
+// declareCallValuePolicies declares the call value policies of the created functions, all of them forbidding native
+// value except the ones in callValueAllowedFunctions
+func declareCallValuePolicies(functionContainer *functionContainer) {
+	for key := range functionContainer.Keys() {
+		policy := vmcommon.CallValueForbidden
+		_, isAllowed := callValueAllowedFunctions[key]
+		if isAllowed {
+			policy = vmcommon.CallValueAllowed
+		}
+
+		functionContainer.SetCallValuePolicy(key, policy)
+	}
+}
+
+func (b *builtInFuncCreator) setAddressLengthToAllFunctions() error {
+	addressLength := b.addressLength
+	if addressLength == 0 {
+		addressLength = vmcommon.DefaultAddressLength
+	}
+
+	for key := range b.builtInFunctions.Keys() {
+		builtInFunc, err := b.builtInFunctions.Get(key)
+		if err != nil {
+			return err
+		}
+
+		acceptAddressLength, ok := builtInFunc.(vmcommon.AcceptAddressLength)
+		if !ok {
+			continue
+		}
+		err = acceptAddressLength.SetAddressLength(addressLength)
+		if err != nil && err != ErrWrongTypeAssertion {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (b *builtInFuncCreator) setSystemAddressesToAllFunctions() error {
+	acceptSystemAddresses, ok := b.dctStorageHandler.(vmcommon.AcceptSystemAddresses)
+	if ok {
+		err := acceptSystemAddresses.SetSystemAddresses(b.systemAddresses)
+		if err != nil {
+			return err
+		}
+	}
+
+	for key := range b.builtInFunctions.Keys() {
+		builtInFunc, err := b.builtInFunctions.Get(key)
+		if err != nil {
+			return err
+		}
+
+		acceptSystemAddresses, ok = builtInFunc.(vmcommon.AcceptSystemAddresses)
+		if !ok {
+			continue
+		}
+		err = acceptSystemAddresses.SetSystemAddresses(b.systemAddresses)
+		if err != nil && err != ErrWrongTypeAssertion {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SystemAddresses returns the addresses of the system accounts the built-in functions depend on
+func (b *builtInFuncCreator) SystemAddresses() vmcommon.SystemAddresses {
+	return b.systemAddresses
+}
+
+func createGasConfig(gasMap map[string]map[string]uint64) (*vmcommon.GasCost, error) {
+	baseOps := &vmcommon.BaseOperationCost{}
+	err := mapstructure.Decode(gasMap[core.BaseOperationCostString], baseOps)
+	if err != nil {
+		return nil, err
+	}
+
+	err = check.ForZeroUintFields(*baseOps)
+	if err != nil {
+		return nil, err
+	}
+
+	builtInOps := &vmcommon.BuiltInCost{}
+	err = mapstructure.Decode(gasMap[core.BuiltInCostString], builtInOps)
+	if err != nil {
+		return nil, err
+	}
+
+	err = check.ForZeroUintFields(*builtInOps)
+	if err != nil {
+		return nil, err
+	}
+
+	asyncCallbackOps := &vmcommon.AsyncCallbackCost{}
+	err = mapstructure.Decode(gasMap[vmcommon.AsyncCallbackCostString], asyncCallbackOps)
+	if err != nil {
+		return nil, err
+	}
+
+	gasCost := vmcommon.GasCost{
+		BaseOperationCost: *baseOps,
+		BuiltInCost:       *builtInOps,
+		AsyncCallbackCost: *asyncCallbackOps,
+	}
+
+	return &gasCost, nil
+}
+
+// SetPayableHandler sets the payableCheck interface to the needed functions
+func (b *builtInFuncCreator) SetPayableHandler(payableHandler vmcommon.PayableHandler) error {
+	payableChecker, err := NewPayableCheckFunc(
+		payableHandler,
+		b.enableEpochsHandler,
+	)
+	if err != nil {
+		return err
+	}
+	err = payableChecker.SetSystemAddresses(b.systemAddresses)
+	if err != nil {
+		return err
+	}
+
+	listOfTransferFunc := []string{
+		core.BuiltInFunctionMultiDCTNFTTransfer,
+		core.BuiltInFunctionDCTNFTTransfer,
+		core.BuiltInFunctionDCTTransfer}
+
+	for _, transferFunc := range listOfTransferFunc {
+		builtInFunc, err := b.builtInFunctions.Get(transferFunc)
+		if err != nil {
+			return err
+		}
+
+		dctTransferFunc, ok := builtInFunc.(vmcommon.AcceptPayableChecker)
+		if !ok {
+			return ErrWrongTypeAssertion
+		}
+
+		err = dctTransferFunc.SetPayableChecker(payableChecker)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SetLatestNonceCache sets the latest nonce cache to the functions reading or changing the latest NFT nonce
+func (b *builtInFuncCreator) SetLatestNonceCache(nonceCache vmcommon.LatestNonceCache) error {
+	if check.IfNil(nonceCache) {
+		return ErrNilLatestNonceCache
+	}
+
+	listOfNonceFunc := []string{
+		core.BuiltInFunctionDCTNFTCreate,
+		vmcommon.BuiltInFunctionDCTNFTCreateOnBehalf,
+		core.BuiltInFunctionDCTNFTCreateRoleTransfer,
+		core.BuiltInFunctionSetDCTRole,
+		core.BuiltInFunctionUnSetDCTRole}
+
+	for _, nonceFunc := range listOfNonceFunc {
+		builtInFunc, err := b.builtInFunctions.Get(nonceFunc)
+		if err != nil {
+			return err
+		}
+
+		acceptNonceCache, ok := builtInFunc.(vmcommon.AcceptLatestNonceCache)
+		if !ok {
+			return ErrWrongTypeAssertion
+		}
+
+		err = acceptNonceCache.SetLatestNonceCache(nonceCache)
+		if err != nil {
+			return err
+		}
+	}
+
+	acceptNonceCache, ok := b.builtInFunctions.(vmcommon.AcceptLatestNonceCache)
+	if !ok {
+		return nil
+	}
+
+	return acceptNonceCache.SetLatestNonceCache(nonceCache)
+}
+
+// SetAccountCache sets the account cache to the functions loading the account holding the roles of the calls
+// executed on its behalf
+func (b *builtInFuncCreator) SetAccountCache(accountCache vmcommon.AccountCache) error {
+	if check.IfNil(accountCache) {
+		return ErrNilAccountCache
+	}
+
+	listOfAccountFunc := []string{
+		core.BuiltInFunctionDCTNFTCreate,
+		vmcommon.BuiltInFunctionDCTNFTCreateOnBehalf}
+
+	for _, accountFunc := range listOfAccountFunc {
+		builtInFunc, err := b.builtInFunctions.Get(accountFunc)
+		if err != nil {
+			return err
+		}
+
+		acceptAccountCache, ok := builtInFunc.(vmcommon.AcceptAccountCache)
+		if !ok {
+			return ErrWrongTypeAssertion
+		}
+
+		err = acceptAccountCache.SetAccountCache(accountCache)
+		if err != nil {
+			return err
+		}
+	}
+
+	acceptAccountCache, ok := b.builtInFunctions.(vmcommon.AcceptAccountCache)
+	if !ok {
+		return nil
+	}
+
+	return acceptAccountCache.SetAccountCache(accountCache)
+}
+
+// SetAddressClassifier sets the address classifier to the transfer functions deciding on smart contract receivers
+func (b *builtInFuncCreator) SetAddressClassifier(addressClassifier vmcommon.AddressClassifier) error {
+	if check.IfNil(addressClassifier) {
+		return ErrNilAddressClassifier
+	}
+
+	listOfTransferFunc := []string{
+		core.BuiltInFunctionDCTTransfer,
+		core.BuiltInFunctionDCTNFTTransfer,
+		core.BuiltInFunctionMultiDCTNFTTransfer}
+
+	for _, transferFunc := range listOfTransferFunc {
+		builtInFunc, err := b.builtInFunctions.Get(transferFunc)
+		if err != nil {
+			return err
+		}
+
+		acceptAddressClassifier, ok := builtInFunc.(vmcommon.AcceptAddressClassifier)
+		if !ok {
+			return ErrWrongTypeAssertion
+		}
+
+		err = acceptAddressClassifier.SetAddressClassifier(addressClassifier)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SetAliasResolver sets the resolver of the receivers referenced by alias to the NFT transfer functions
+func (b *builtInFuncCreator) SetAliasResolver(aliasResolver vmcommon.AliasResolver) error {
+	if check.IfNil(aliasResolver) {
+		return ErrNilAliasResolver
+	}
+
+	listOfTransferFunc := []string{
+		core.BuiltInFunctionDCTNFTTransfer,
+		core.BuiltInFunctionMultiDCTNFTTransfer}
+
+	for _, transferFunc := range listOfTransferFunc {
+		builtInFunc, err := b.builtInFunctions.Get(transferFunc)
+		if err != nil {
+			return err
+		}
+
+		acceptAliasResolver, ok := builtInFunc.(vmcommon.AcceptAliasResolver)
+		if !ok {
+			return ErrWrongTypeAssertion
+		}
+
+		err = acceptAliasResolver.SetAliasResolver(aliasResolver)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SetHasher sets the hasher verifying the content hash of the created tokens to the NFT create functions
+func (b *builtInFuncCreator) SetHasher(hasher vmcommon.Hasher) error {
+	if check.IfNil(hasher) {
+		return ErrNilHasher
+	}
+
+	listOfCreateFunc := []string{
+		core.BuiltInFunctionDCTNFTCreate,
+		vmcommon.BuiltInFunctionDCTNFTCreateOnBehalf}
+
+	for _, createFunc := range listOfCreateFunc {
+		builtInFunc, err := b.builtInFunctions.Get(createFunc)
+		if err != nil {
+			return err
+		}
+
+		acceptHasher, ok := builtInFunc.(vmcommon.AcceptHasher)
+		if !ok {
+			return ErrWrongTypeAssertion
+		}
+
+		err = acceptHasher.SetHasher(hasher)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SetStorageUsageTracker sets the tracker metering and limiting the bytes stored on behalf of the accounts to the
+// functions storing user provided data, the save key value and all the functions writing NFT metadata, and to the
+// functions releasing the NFT metadata, the NFT burn and the wipe
+func (b *builtInFuncCreator) SetStorageUsageTracker(storageUsageTracker vmcommon.StorageUsageTracker) error {
+	if check.IfNil(storageUsageTracker) {
+		return ErrNilStorageUsageTracker
+	}
+
+	listOfFunc := []string{
+		core.BuiltInFunctionDCTNFTCreate,
+		vmcommon.BuiltInFunctionDCTNFTCreateOnBehalf,
+		core.BuiltInFunctionDCTNFTAddURI,
+		core.BuiltInFunctionDCTNFTUpdateAttributes,
+		vmcommon.BuiltInFunctionDCTSetNewURIs,
+		vmcommon.BuiltInFunctionDCTNFTUpdate,
+		core.BuiltInFunctionDCTNFTBurn,
+		core.BuiltInFunctionDCTWipe,
+		core.BuiltInFunctionSaveKeyValue}
+
+	for _, funcName := range listOfFunc {
+		builtInFunc, err := b.builtInFunctions.Get(funcName)
+		if err != nil {
+			return err
+		}
+
+		acceptStorageUsageTracker, ok := builtInFunc.(vmcommon.AcceptStorageUsageTracker)
+		if !ok {
+			return ErrWrongTypeAssertion
+		}
+
+		err = acceptStorageUsageTracker.SetStorageUsageTracker(storageUsageTracker)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// tokenMovingFunctions returns the functions moving tokens out of the account of the caller, which all check both
+// the frozen accounts and the transfer interceptor. DCTReclaimRentedNFT and DCTSweepDormant are not listed, as they
+// take the tokens back under the protocol rules instead of on behalf of the holder
+func (b *builtInFuncCreator) tokenMovingFunctions() []string {
+	functions := []string{
+		core.BuiltInFunctionDCTTransfer,
+		core.BuiltInFunctionDCTNFTTransfer,
+		core.BuiltInFunctionMultiDCTNFTTransfer,
+		vmcommon.BuiltInFunctionDCTTransferFrom,
+		vmcommon.BuiltInFunctionDCTTransferAndLock,
+		vmcommon.BuiltInFunctionDCTRentNFT,
+	}
+	if len(b.wrappedNativeTokenID) > 0 {
+		functions = append(functions, vmcommon.BuiltInFunctionWrapNative, vmcommon.BuiltInFunctionUnwrapNative)
+	}
+
+	return functions
+}
+
+// SetFreezeAccountHandler sets the freeze account handler, gated by the freeze account flag, to the functions moving
+// assets out of an account
+func (b *builtInFuncCreator) SetFreezeAccountHandler(freezeAccountHandler vmcommon.FreezeAccountHandler) error {
+	gatedFreezeAccountHandler, err := NewEpochGatedFreezeAccountHandler(freezeAccountHandler, b.enableEpochsHandler)
+	if err != nil {
+		return err
+	}
+
+	listOfFunc := append(b.tokenMovingFunctions(),
+		core.BuiltInFunctionDCTBurn,
+		core.BuiltInFunctionDCTLocalBurn,
+		core.BuiltInFunctionDCTNFTBurn,
+		core.BuiltInFunctionDCTNFTCreate,
+		vmcommon.BuiltInFunctionDCTNFTCreateOnBehalf)
+
+	for _, funcName := range listOfFunc {
+		builtInFunc, errGet := b.builtInFunctions.Get(funcName)
+		if errGet != nil {
+			return errGet
+		}
+
+		acceptFreezeAccountHandler, ok := builtInFunc.(vmcommon.AcceptFreezeAccountHandler)
+		if !ok {
+			return ErrWrongTypeAssertion
+		}
+
+		err = acceptFreezeAccountHandler.SetFreezeAccountHandler(gatedFreezeAccountHandler)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SetTransferInterceptor sets the interceptor able to veto the transfers to the functions moving tokens between
+// accounts. Until it is set, the transfers are not intercepted
+func (b *builtInFuncCreator) SetTransferInterceptor(transferInterceptor vmcommon.TransferInterceptor) error {
+	if check.IfNil(transferInterceptor) {
+		return ErrNilTransferInterceptor
+	}
+
+	for _, funcName := range b.tokenMovingFunctions() {
+		builtInFunc, err := b.builtInFunctions.Get(funcName)
+		if err != nil {
+			return err
+		}
+
+		acceptTransferInterceptor, ok := builtInFunc.(vmcommon.AcceptTransferInterceptor)
+		if !ok {
+			return ErrWrongTypeAssertion
+		}
+
+		err = acceptTransferInterceptor.SetTransferInterceptor(transferInterceptor)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SetAccountActivityHandler sets the handler deciding which accounts are dormant to the dormant sweep function
+func (b *builtInFuncCreator) SetAccountActivityHandler(accountActivityHandler vmcommon.AccountActivityHandler) error {
+	builtInFunc, err := b.builtInFunctions.Get(vmcommon.BuiltInFunctionDCTSweepDormant)
+	if err != nil {
+		return err
+	}
+
+	acceptAccountActivityHandler, ok := builtInFunc.(vmcommon.AcceptAccountActivityHandler)
+	if !ok {
+		return ErrWrongTypeAssertion
+	}
+
+	return acceptAccountActivityHandler.SetAccountActivityHandler(accountActivityHandler)
+}
+
+// SetProtectedKeysHandler sets the handler deciding which storage keys can not be written through the save key value
+// built-in function, allowing the host to reserve chain specific prefixes
+func (b *builtInFuncCreator) SetProtectedKeysHandler(protectedKeysHandler vmcommon.ProtectedKeysHandler) error {
+	if check.IfNil(protectedKeysHandler) {
+		return ErrNilProtectedKeysHandler
+	}
+
+	builtInFunc, err := b.builtInFunctions.Get(core.BuiltInFunctionSaveKeyValue)
+	if err != nil {
+		return err
+	}
+
+	acceptProtectedKeysHandler, ok := builtInFunc.(vmcommon.AcceptProtectedKeysHandler)
+	if !ok {
+		return ErrWrongTypeAssertion
+	}
+
+	return acceptProtectedKeysHandler.SetProtectedKeysHandler(protectedKeysHandler)
+}
+
+// multiSigManagedFunctions are the token management functions requiring the signatures of the managers of multisig
+// managed tokens. DCTPause is excluded, so a token can be stopped in an emergency without waiting for the signers, as
+// is DCTSetMultiSigManaged, which enables the protection. The metadata functions are restricted to the config address
+// and the role holder functions are not management operations
+var multiSigManagedFunctions = []string{
+	core.BuiltInFunctionSetDCTRole,
+	core.BuiltInFunctionUnSetDCTRole,
+	core.BuiltInFunctionDCTFreeze,
+	core.BuiltInFunctionDCTUnFreeze,
+	core.BuiltInFunctionDCTWipe,
+	core.BuiltInFunctionDCTUnPause,
+	core.BuiltInFunctionDCTSetLimitedTransfer,
+	core.BuiltInFunctionDCTUnSetLimitedTransfer,
+	vmcommon.BuiltInFunctionDCTTransferRoleAddAddress,
+	vmcommon.BuiltInFunctionDCTTransferRoleDeleteAddress,
+	vmcommon.BuiltInFunctionDCTSetBurnRoleForAll,
+	vmcommon.BuiltInFunctionDCTUnSetBurnRoleForAll,
+	vmcommon.BuiltInFunctionDCTSetCollectionConfig,
+	vmcommon.BuiltInFunctionDCTUnSetCollectionConfig,
+	vmcommon.BuiltInFunctionDCTSetDormantSweep,
+	vmcommon.BuiltInFunctionDCTUnSetDormantSweep,
+	vmcommon.BuiltInFunctionDCTUnSetMultiSigManaged,
+	vmcommon.BuiltInFunctionDCTSetSoulbound,
+	vmcommon.BuiltInFunctionDCTUnSetSoulbound,
+	vmcommon.BuiltInFunctionDCTStopNFTCreate,
+	vmcommon.BuiltInFunctionDCTResumeNFTCreate,
+}
+
+// SetMultiSigVerifier sets the verifier of the signatures required by the management functions of multisig managed tokens
+func (b *builtInFuncCreator) SetMultiSigVerifier(multiSigVerifier vmcommon.MultiSigVerifier) error {
+	if check.IfNil(multiSigVerifier) {
+		return ErrNilMultiSigVerifier
+	}
+
+	for _, funcName := range multiSigManagedFunctions {
+		builtInFunc, err := b.builtInFunctions.Get(funcName)
+		if err != nil {
+			return err
+		}
+
+		acceptMultiSigVerifier, ok := builtInFunc.(vmcommon.AcceptMultiSigVerifier)
+		if !ok {
+			return ErrWrongTypeAssertion
+		}
+
+		err = acceptMultiSigVerifier.SetMultiSigVerifier(multiSigVerifier)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SetProofVerifier sets the verifier of the external chain proofs required by the bridge functions. It is a no-op
+// when no bridge address is configured
+func (b *builtInFuncCreator) SetProofVerifier(proofVerifier vmcommon.ProofVerifier) error {
+	if check.IfNil(proofVerifier) {
+		return ErrNilProofVerifier
+	}
+	if len(b.bridgeAddresses) == 0 {
+		return nil
+	}
+
+	listOfFunc := []string{
+		vmcommon.BuiltInFunctionDCTBridgeMint,
+		vmcommon.BuiltInFunctionDCTBridgeBurn}
+
+	for _, funcName := range listOfFunc {
+		builtInFunc, err := b.builtInFunctions.Get(funcName)
+		if err != nil {
+			return err
+		}
+
+		acceptProofVerifier, ok := builtInFunc.(vmcommon.AcceptProofVerifier)
+		if !ok {
+			return ErrWrongTypeAssertion
+		}
+
+		err = acceptProofVerifier.SetProofVerifier(proofVerifier)
 		if err != nil {
 			return err
 		}
@@ -1,12 +1,17 @@
 package builtInFunctions
 
 import (
+	"bytes"
+	"math/big"
 	"testing"
 
 	"github.com/Reshusk23/sr-me-core/core"
 	"github.com/Reshusk23/sr-me-core/core/check"
+	"github.com/Reshusk23/sr-me-core/core/pubkeyConverter"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
 	"github.com/Reshusk23/sr-vm-common-go/mock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func createMockArguments() ArgsCreateBuiltInFunctionContainer {
@@ -22,6 +27,7 @@ func createMockArguments() ArgsCreateBuiltInFunctionContainer {
 		ShardCoordinator:                 mock.NewMultiShardsCoordinatorMock(1),
 		EnableEpochsHandler:              &mock.EnableEpochsHandlerStub{},
 		MaxNumOfAddressesForTransferRole: 100,
+		ChainID:                          []byte("chain"),
 	}
 
 	return args
@@ -85,22 +91,27 @@ func TestCreateBuiltInFunctionContainer_Errors(t *testing.T) {
 	args = createMockArguments()
 	args.ShardCoordinator = nil
 	_, err = NewBuiltInFunctionsCreator(args)
-	assert.Equal(t, err, ErrNilShardCoordinator)
+	assert.ErrorIs(t, err, ErrNilShardCoordinator)
 
 	args = createMockArguments()
 	args.EnableEpochsHandler = nil
 	_, err = NewBuiltInFunctionsCreator(args)
-	assert.Equal(t, err, ErrNilEnableEpochsHandler)
+	assert.ErrorIs(t, err, ErrNilEnableEpochsHandler)
 
 	args = createMockArguments()
 	args.Marshalizer = nil
 	_, err = NewBuiltInFunctionsCreator(args)
-	assert.Equal(t, err, ErrNilMarshalizer)
+	assert.ErrorIs(t, err, ErrNilMarshalizer)
 
 	args = createMockArguments()
 	args.Accounts = nil
 	_, err = NewBuiltInFunctionsCreator(args)
-	assert.Equal(t, err, ErrNilAccountsAdapter)
+	assert.ErrorIs(t, err, ErrNilAccountsAdapter)
+
+	args = createMockArguments()
+	args.ChainID = nil
+	_, err = NewBuiltInFunctionsCreator(args)
+	assert.Equal(t, err, ErrEmptyChainID)
 
 	args = createMockArguments()
 	f, err = NewBuiltInFunctionsCreator(args)
@@ -122,13 +133,42 @@ func TestCreateBuiltInContainter_GasScheduleChange(t *testing.T) {
 	assert.Equal(t, f.gasConfig.BuiltInCost.ClaimDeveloperRewards, uint64(5))
 }
 
+func TestCreateBuiltInContainter_AsyncCallbackCost(t *testing.T) {
+	args := createMockArguments()
+	args.GasMap[vmcommon.AsyncCallbackCostString] = map[string]uint64{
+		"AsyncCallStep":        10,
+		"AsyncCallbackGasLock": 20,
+	}
+	f, _ := NewBuiltInFunctionsCreator(args)
+	expectedCost := vmcommon.AsyncCallbackCost{
+		AsyncCallStep:        10,
+		AsyncCallbackGasLock: 20,
+	}
+	assert.Equal(t, expectedCost, f.gasConfig.AsyncCallbackCost)
+
+	err := f.CreateBuiltInFunctionContainer()
+	assert.Nil(t, err)
+
+	builtInFunc, _ := f.BuiltInFunctionContainer().Get(core.BuiltInFunctionDCTNFTTransfer)
+	nftTransferFunc := unwrapCallValuePolicy(unwrapExecutionGuard(builtInFunc)).(*dctNFTTransfer)
+	assert.Equal(t, expectedCost, nftTransferFunc.asyncCallbackCost)
+
+	args.GasMap[vmcommon.AsyncCallbackCostString]["AsyncCallbackGasLock"] = 30
+	f.GasScheduleChange(args.GasMap)
+	assert.Equal(t, uint64(30), nftTransferFunc.asyncCallbackCost.AsyncCallbackGasLock)
+}
+
 func TestCreateBuiltInContainter_Create(t *testing.T) {
 	args := createMockArguments()
+	accountCache, _ := NewAccountCache(100, &mock.RoundNotifierStub{})
+	args.Accounts, _ = NewAccountsAdapterWithAccountCache(args.Accounts, accountCache)
+	nonceCache, _ := NewLatestNonceCache(&mock.RoundNotifierStub{})
+	args.Accounts, _ = NewAccountsAdapterWithLatestNonceCache(args.Accounts, nonceCache)
 	f, _ := NewBuiltInFunctionsCreator(args)
 
 	err := f.CreateBuiltInFunctionContainer()
 	assert.Nil(t, err)
-	assert.Equal(t, f.BuiltInFunctionContainer().Len(), 31)
+	assert.Equal(t, f.BuiltInFunctionContainer().Len(), 52)
 
 	err = f.SetPayableHandler(nil)
 	assert.NotNil(t, err)
@@ -136,6 +176,84 @@ func TestCreateBuiltInContainter_Create(t *testing.T) {
 	err = f.SetPayableHandler(&mock.PayableHandlerStub{})
 	assert.Nil(t, err)
 
+	err = f.SetLatestNonceCache(nil)
+	assert.Equal(t, ErrNilLatestNonceCache, err)
+
+	err = f.SetLatestNonceCache(&disabledLatestNonceCache{})
+	assert.Equal(t, ErrLatestNonceCacheNotBoundToAccounts, err)
+
+	err = f.SetLatestNonceCache(nonceCache)
+	assert.Nil(t, err)
+
+	err = f.SetAccountCache(nil)
+	assert.Equal(t, ErrNilAccountCache, err)
+
+	err = f.SetAccountCache(&disabledAccountCache{})
+	assert.Equal(t, ErrAccountCacheNotBoundToAccounts, err)
+
+	err = f.SetAccountCache(accountCache)
+	assert.Nil(t, err)
+
+	err = f.SetAccountActivityHandler(nil)
+	assert.Equal(t, ErrNilAccountActivityHandler, err)
+
+	err = f.SetAccountActivityHandler(&mock.AccountActivityHandlerStub{})
+	assert.Nil(t, err)
+
+	err = f.SetMultiSigVerifier(nil)
+	assert.Equal(t, ErrNilMultiSigVerifier, err)
+
+	err = f.SetMultiSigVerifier(&mock.MultiSigVerifierStub{})
+	assert.Nil(t, err)
+
+	err = f.SetProofVerifier(nil)
+	assert.Equal(t, ErrNilProofVerifier, err)
+
+	err = f.SetProofVerifier(&mock.ProofVerifierStub{})
+	assert.Nil(t, err)
+
+	err = f.SetProtectedKeysHandler(nil)
+	assert.Equal(t, ErrNilProtectedKeysHandler, err)
+
+	err = f.SetProtectedKeysHandler(&mock.ProtectedKeysHandlerStub{})
+	assert.Nil(t, err)
+
+	err = f.SetAddressClassifier(nil)
+	assert.Equal(t, ErrNilAddressClassifier, err)
+
+	err = f.SetAddressClassifier(vmcommon.NewDefaultAddressClassifier())
+	assert.Nil(t, err)
+
+	err = f.SetAliasResolver(nil)
+	assert.Equal(t, ErrNilAliasResolver, err)
+
+	err = f.SetAliasResolver(&mock.AliasResolverStub{})
+	assert.Nil(t, err)
+
+	err = f.SetHasher(nil)
+	assert.Equal(t, ErrNilHasher, err)
+
+	err = f.SetHasher(&mock.HasherStub{})
+	assert.Nil(t, err)
+
+	err = f.SetStorageUsageTracker(nil)
+	assert.Equal(t, ErrNilStorageUsageTracker, err)
+
+	err = f.SetStorageUsageTracker(&mock.StorageUsageTrackerStub{})
+	assert.Nil(t, err)
+
+	err = f.SetFreezeAccountHandler(nil)
+	assert.Equal(t, ErrNilFreezeAccountHandler, err)
+
+	err = f.SetFreezeAccountHandler(&mock.FreezeAccountHandlerStub{})
+	assert.Nil(t, err)
+
+	err = f.SetTransferInterceptor(nil)
+	assert.Equal(t, ErrNilTransferInterceptor, err)
+
+	err = f.SetTransferInterceptor(&mock.TransferInterceptorStub{})
+	assert.Nil(t, err)
+
 	fillGasMapInternal(args.GasMap, 5)
 	f.GasScheduleChange(args.GasMap)
 	assert.Equal(t, f.gasConfig.BuiltInCost.ClaimDeveloperRewards, uint64(5))
@@ -143,3 +261,560 @@ func TestCreateBuiltInContainter_Create(t *testing.T) {
 	nftStorageHandler := f.NFTStorageHandler()
 	assert.False(t, check.IfNil(nftStorageHandler))
 }
+
+func TestCreateBuiltInContainter_CreateWithMetrics(t *testing.T) {
+	args := createMockArguments()
+	numCalls := 0
+	args.Metrics = &mock.MetricsStub{
+		IncrementCounterCalled: func(name string, labels vmcommon.MetricLabels) {
+			if name == vmcommon.MetricBuiltInFunctionCalls {
+				numCalls++
+			}
+		},
+	}
+	f, _ := NewBuiltInFunctionsCreator(args)
+
+	err := f.CreateBuiltInFunctionContainer()
+	assert.Nil(t, err)
+
+	err = f.SetPayableHandler(&mock.PayableHandlerStub{})
+	assert.Nil(t, err)
+
+	err = f.SetProtectedKeysHandler(&mock.ProtectedKeysHandlerStub{})
+	assert.Nil(t, err)
+
+	function, _ := f.BuiltInFunctionContainer().Get(core.BuiltInFunctionDCTTransfer)
+	_, _ = function.ProcessBuiltinFunction(nil, nil, &vmcommon.ContractCallInput{})
+	assert.Equal(t, 1, numCalls)
+}
+
+func TestCreateBuiltInContainter_CreateWithFunctionResolver(t *testing.T) {
+	args := createMockArguments()
+	args.FunctionResolver = &mock.FunctionResolverStub{
+		ResolveFunctionCalled: func(name string) (string, bool) {
+			if name == "DCTSend" {
+				return core.BuiltInFunctionDCTTransfer, true
+			}
+			return name, false
+		},
+	}
+	f, _ := NewBuiltInFunctionsCreator(args)
+
+	err := f.CreateBuiltInFunctionContainer()
+	assert.Nil(t, err)
+
+	function, err := f.BuiltInFunctionContainer().Get("DCTSend")
+	assert.Nil(t, err)
+	_, ok := unwrapCallValuePolicy(unwrapExecutionGuard(function)).(*dctTransfer)
+	assert.True(t, ok)
+}
+
+func TestCreateBuiltInContainter_CreateWithQuotaHandler(t *testing.T) {
+	args := createMockArguments()
+	args.QuotaHandler = &mock.QuotaHandlerStub{
+		CheckQuotaCalled: func(function string, gasProvided uint64) error {
+			if function == core.BuiltInFunctionDCTNFTCreate {
+				return ErrQuotaExceeded
+			}
+			return nil
+		},
+	}
+	f, _ := NewBuiltInFunctionsCreator(args)
+
+	err := f.CreateBuiltInFunctionContainer()
+	assert.Nil(t, err)
+
+	function, _ := f.BuiltInFunctionContainer().Get(core.BuiltInFunctionDCTNFTCreate)
+	_, err = function.ProcessBuiltinFunction(nil, nil, &vmcommon.ContractCallInput{})
+	assert.ErrorIs(t, err, ErrQuotaExceeded)
+}
+
+func TestCreateBuiltInContainter_CreateWithHasher(t *testing.T) {
+	args := createMockArguments()
+	hasher := &mock.HasherStub{}
+	args.Hasher = hasher
+	f, _ := NewBuiltInFunctionsCreator(args)
+
+	err := f.CreateBuiltInFunctionContainer()
+	assert.Nil(t, err)
+
+	for _, key := range []string{core.BuiltInFunctionDCTNFTCreate, vmcommon.BuiltInFunctionDCTNFTCreateOnBehalf} {
+		function, _ := f.BuiltInFunctionContainer().Get(key)
+		nftCreate, ok := unwrapCallValuePolicy(unwrapExecutionGuard(function)).(*dctNFTCreate)
+		require.True(t, ok)
+		assert.True(t, nftCreate.hasher == hasher)
+	}
+}
+
+func TestCreateBuiltInContainter_CreateWithStorageUsageTracker(t *testing.T) {
+	args := createMockArguments()
+	tracker := &mock.StorageUsageTrackerStub{}
+	args.StorageUsageTracker = tracker
+	f, _ := NewBuiltInFunctionsCreator(args)
+
+	err := f.CreateBuiltInFunctionContainer()
+	assert.Nil(t, err)
+
+	for _, key := range []string{core.BuiltInFunctionDCTNFTCreate, vmcommon.BuiltInFunctionDCTNFTCreateOnBehalf} {
+		function, _ := f.BuiltInFunctionContainer().Get(key)
+		nftCreate, ok := unwrapCallValuePolicy(unwrapExecutionGuard(function)).(*dctNFTCreate)
+		require.True(t, ok)
+		assert.True(t, nftCreate.storageUsageTracker == tracker)
+	}
+
+	function, _ := f.BuiltInFunctionContainer().Get(core.BuiltInFunctionDCTNFTAddURI)
+	addURI, ok := unwrapCallValuePolicy(unwrapExecutionGuard(function)).(*dctNFTAddUri)
+	require.True(t, ok)
+	assert.True(t, addURI.storageUsageTracker == tracker)
+
+	function, _ = f.BuiltInFunctionContainer().Get(core.BuiltInFunctionSaveKeyValue)
+	saveKeyValue, ok := unwrapCallValuePolicy(unwrapExecutionGuard(function)).(*saveKeyValueStorage)
+	require.True(t, ok)
+	assert.True(t, saveKeyValue.storageUsageTracker == tracker)
+
+	metaDataFunctions := []string{
+		core.BuiltInFunctionDCTNFTUpdateAttributes,
+		vmcommon.BuiltInFunctionDCTSetNewURIs,
+		vmcommon.BuiltInFunctionDCTNFTUpdate,
+		core.BuiltInFunctionDCTNFTBurn,
+		core.BuiltInFunctionDCTWipe,
+	}
+	for _, key := range metaDataFunctions {
+		function, _ = f.BuiltInFunctionContainer().Get(key)
+		function = unwrapCallValuePolicy(unwrapExecutionGuard(function))
+		multiSig, isMultiSig := function.(*multiSigFunction)
+		if isMultiSig {
+			function = multiSig.function
+		}
+
+		var functionTracker vmcommon.StorageUsageTracker
+		switch typedFunction := function.(type) {
+		case *dctNFTupdate:
+			functionTracker = typedFunction.storageUsageTracker
+		case *dctSetNewURIs:
+			functionTracker = typedFunction.storageUsageTracker
+		case *dctNFTUpdate:
+			functionTracker = typedFunction.storageUsageTracker
+		case *dctNFTBurn:
+			functionTracker = typedFunction.storageUsageTracker
+		case *dctFreezeWipe:
+			functionTracker = typedFunction.storageUsageTracker
+		}
+		assert.True(t, functionTracker == tracker, key)
+	}
+}
+
+func TestCreateBuiltInContainter_CreateWithMetaDataCompressor(t *testing.T) {
+	args := createMockArguments()
+	compressor := &mock.CompressorStub{}
+	args.MetaDataCompressor = compressor
+	args.MetaDataCompressionThreshold = 256
+	f, _ := NewBuiltInFunctionsCreator(args)
+
+	err := f.CreateBuiltInFunctionContainer()
+	require.Nil(t, err)
+
+	dataStorage, ok := f.NFTStorageHandler().(*dctDataStorage)
+	require.True(t, ok)
+	assert.True(t, dataStorage.metaDataCompression.compressor == compressor)
+	assert.Equal(t, 256, dataStorage.metaDataCompression.threshold)
+}
+
+func TestCreateBuiltInContainter_CreateWithEpochNotifier(t *testing.T) {
+	args := createMockArguments()
+	var registeredHandlers []vmcommon.EpochSubscriberHandler
+	args.EpochNotifier = &mock.EpochNotifierStub{
+		RegisterNotifyHandlerCalled: func(handler vmcommon.EpochSubscriberHandler) {
+			registeredHandlers = append(registeredHandlers, handler)
+		},
+	}
+	f, _ := NewBuiltInFunctionsCreator(args)
+
+	err := f.CreateBuiltInFunctionContainer()
+	require.Nil(t, err)
+	require.Equal(t, 1, len(registeredHandlers))
+	assert.Equal(t, f.BuiltInFunctionContainer(), registeredHandlers[0])
+
+}
+
+func TestCreateBuiltInContainter_RentalFunctionsReadTheEpochFromTheEnableEpochsHandler(t *testing.T) {
+	args := createMockArguments()
+	args.EnableEpochsHandler = &mock.EnableEpochsHandlerStub{CurrentEpochField: 7}
+	f, _ := NewBuiltInFunctionsCreator(args)
+
+	err := f.CreateBuiltInFunctionContainer()
+	require.Nil(t, err)
+
+	function, _ := f.BuiltInFunctionContainer().Get(vmcommon.BuiltInFunctionDCTRentNFT)
+	rentFunc, ok := unwrapCallValuePolicy(unwrapExecutionGuard(function)).(*dctRentNFT)
+	require.True(t, ok)
+	assert.Equal(t, uint32(7), rentFunc.enableEpochsHandler.GetCurrentEpoch())
+
+	function, _ = f.BuiltInFunctionContainer().Get(vmcommon.BuiltInFunctionDCTReclaimRentedNFT)
+	reclaimFunc, ok := unwrapCallValuePolicy(unwrapExecutionGuard(function)).(*dctReclaimRentedNFT)
+	require.True(t, ok)
+	assert.Equal(t, uint32(7), reclaimFunc.enableEpochsHandler.GetCurrentEpoch())
+}
+
+func TestCreateBuiltInContainter_CreateWithSameShardMultiTransferCalls(t *testing.T) {
+	args := createMockArguments()
+	args.SameShardMultiTransferCalls = true
+	f, _ := NewBuiltInFunctionsCreator(args)
+
+	err := f.CreateBuiltInFunctionContainer()
+	assert.Nil(t, err)
+
+	function, _ := f.BuiltInFunctionContainer().Get(core.BuiltInFunctionMultiDCTNFTTransfer)
+	multiTransfer, ok := unwrapCallValuePolicy(unwrapExecutionGuard(function)).(*dctNFTMultiTransfer)
+	assert.True(t, ok)
+	assert.True(t, multiTransfer.sameShardCallReceivers)
+}
+
+func TestCreateBuiltInContainter_CreateWithUserErrorsAsVMOutputs(t *testing.T) {
+	args := createMockArguments()
+	args.UserErrorsAsVMOutputs = true
+	f, _ := NewBuiltInFunctionsCreator(args)
+
+	err := f.CreateBuiltInFunctionContainer()
+	assert.Nil(t, err)
+
+	function, _ := f.BuiltInFunctionContainer().Get(core.BuiltInFunctionDCTTransfer)
+	vmOutput, err := function.ProcessBuiltinFunction(nil, nil, &vmcommon.ContractCallInput{})
+	assert.Nil(t, err)
+	assert.Equal(t, vmcommon.UserError, vmOutput.ReturnCode)
+	assert.NotEmpty(t, vmOutput.ReturnMessage)
+}
+
+func TestCreateBuiltInContainter_CreateWithLimits(t *testing.T) {
+	args := createMockArguments()
+	args.Limits = vmcommon.LimitsConfig{MaxNumArguments: 1}
+	f, _ := NewBuiltInFunctionsCreator(args)
+
+	err := f.CreateBuiltInFunctionContainer()
+	assert.Nil(t, err)
+
+	function, _ := f.BuiltInFunctionContainer().Get(core.BuiltInFunctionDCTTransfer)
+	input := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			Arguments: [][]byte{[]byte("token"), {1}},
+		},
+	}
+	_, err = function.ProcessBuiltinFunction(nil, nil, input)
+	assert.ErrorIs(t, err, ErrTooManyArguments)
+}
+
+func TestCreateBuiltInContainter_CreateWithWrappedNativeToken(t *testing.T) {
+	args := createMockArguments()
+	args.WrappedNativeTokenID = []byte("invalid")
+	f, _ := NewBuiltInFunctionsCreator(args)
+
+	err := f.CreateBuiltInFunctionContainer()
+	assert.Equal(t, ErrInvalidTokenID, err)
+
+	args.WrappedNativeTokenID = []byte("WREWA-abcdef")
+	f, _ = NewBuiltInFunctionsCreator(args)
+
+	err = f.CreateBuiltInFunctionContainer()
+	assert.Nil(t, err)
+	assert.Equal(t, 54, f.BuiltInFunctionContainer().Len())
+
+	_, err = f.BuiltInFunctionContainer().Get(vmcommon.BuiltInFunctionWrapNative)
+	assert.Nil(t, err)
+	_, err = f.BuiltInFunctionContainer().Get(vmcommon.BuiltInFunctionUnwrapNative)
+	assert.Nil(t, err)
+
+	err = f.SetFreezeAccountHandler(&mock.FreezeAccountHandlerStub{})
+	assert.Nil(t, err)
+}
+
+func TestCreateBuiltInContainter_TokenMovingFunctionsShouldListAllTheTransferFunctions(t *testing.T) {
+	args := createMockArguments()
+	args.WrappedNativeTokenID = []byte("WREWA-abcdef")
+	f, _ := NewBuiltInFunctionsCreator(args)
+	err := f.CreateBuiltInFunctionContainer()
+	require.Nil(t, err)
+
+	tokenMovingFunctions := make(map[string]struct{})
+	for _, funcName := range f.tokenMovingFunctions() {
+		tokenMovingFunctions[funcName] = struct{}{}
+	}
+	for _, funcName := range []string{
+		core.BuiltInFunctionDCTTransfer,
+		core.BuiltInFunctionMultiDCTNFTTransfer,
+		vmcommon.BuiltInFunctionDCTTransferAndLock,
+		vmcommon.BuiltInFunctionDCTRentNFT,
+		vmcommon.BuiltInFunctionWrapNative,
+		vmcommon.BuiltInFunctionUnwrapNative,
+	} {
+		assert.Contains(t, tokenMovingFunctions, funcName)
+	}
+
+	// the functions checking the transfer interceptor or the frozen accounts, apart from the burns and the creates,
+	// move tokens and have to be listed, so that both checks are configured for them
+	freezeOnlyFunctions := map[string]struct{}{
+		core.BuiltInFunctionDCTBurn:                  {},
+		core.BuiltInFunctionDCTLocalBurn:             {},
+		core.BuiltInFunctionDCTNFTBurn:               {},
+		core.BuiltInFunctionDCTNFTCreate:             {},
+		vmcommon.BuiltInFunctionDCTNFTCreateOnBehalf: {},
+	}
+	for funcName := range f.BuiltInFunctionContainer().Keys() {
+		builtInFunc, _ := f.BuiltInFunctionContainer().Get(funcName)
+
+		acceptsTransferInterceptor := builtInFunc.(vmcommon.AcceptTransferInterceptor).SetTransferInterceptor(&mock.TransferInterceptorStub{}) == nil
+		acceptsFreezeAccountHandler := builtInFunc.(vmcommon.AcceptFreezeAccountHandler).SetFreezeAccountHandler(&mock.FreezeAccountHandlerStub{}) == nil
+		_, isFreezeOnly := freezeOnlyFunctions[funcName]
+		_, isTokenMoving := tokenMovingFunctions[funcName]
+
+		isTransferFunction := acceptsTransferInterceptor || (acceptsFreezeAccountHandler && !isFreezeOnly)
+		assert.Equal(t, isTransferFunction, isTokenMoving, "function %s", funcName)
+		if isTokenMoving {
+			assert.True(t, acceptsTransferInterceptor && acceptsFreezeAccountHandler, "function %s", funcName)
+		}
+	}
+}
+
+func TestCreateBuiltInContainter_CreateWithBridgeAddresses(t *testing.T) {
+	args := createMockArguments()
+	args.BridgeAddresses = [][]byte{bytes.Repeat([]byte{1}, 32)}
+	f, _ := NewBuiltInFunctionsCreator(args)
+
+	err := f.CreateBuiltInFunctionContainer()
+	assert.Nil(t, err)
+	assert.Equal(t, 54, f.BuiltInFunctionContainer().Len())
+
+	err = f.SetProofVerifier(&mock.ProofVerifierStub{})
+	assert.Nil(t, err)
+
+	_, err = f.BuiltInFunctionContainer().Get(vmcommon.BuiltInFunctionDCTBridgeMint)
+	assert.Nil(t, err)
+	_, err = f.BuiltInFunctionContainer().Get(vmcommon.BuiltInFunctionDCTBridgeBurn)
+	assert.Nil(t, err)
+}
+
+func TestCreateBuiltInContainter_CreateWithLogAddressFormat(t *testing.T) {
+	t.Run("invalid format should err", func(t *testing.T) {
+		args := createMockArguments()
+		args.LogAddressFormat = vmcommon.LogAddressFormat(3)
+		f, err := NewBuiltInFunctionsCreator(args)
+		assert.Nil(t, f)
+		assert.Equal(t, ErrInvalidLogAddressFormat, err)
+	})
+	t.Run("encoded format without converter should err", func(t *testing.T) {
+		args := createMockArguments()
+		args.LogAddressFormat = vmcommon.LogAddressEncoded
+		f, err := NewBuiltInFunctionsCreator(args)
+		assert.Nil(t, f)
+		assert.Equal(t, ErrNilPubkeyConverter, err)
+	})
+	t.Run("encoded format should wrap the functions", func(t *testing.T) {
+		args := createMockArguments()
+		args.LogAddressFormat = vmcommon.LogAddressEncoded
+		args.PubkeyConverter, _ = pubkeyConverter.NewBech32PubkeyConverter(32, log)
+		f, _ := NewBuiltInFunctionsCreator(args)
+		err := f.CreateBuiltInFunctionContainer()
+		require.Nil(t, err)
+
+		function, _ := f.BuiltInFunctionContainer().Get(core.BuiltInFunctionDCTTransfer)
+		wrapped, ok := unwrapExecutionGuard(function).(*logAddressFunction)
+		require.True(t, ok)
+		assert.Equal(t, vmcommon.LogAddressEncoded, wrapped.format)
+	})
+}
+
+func TestCreateBuiltInContainter_CreateWithAddressLength(t *testing.T) {
+	t.Run("negative address length should err", func(t *testing.T) {
+		args := createMockArguments()
+		args.AddressLength = -1
+		f, err := NewBuiltInFunctionsCreator(args)
+		assert.Nil(t, f)
+		assert.Equal(t, ErrInvalidAddressLength, err)
+	})
+	t.Run("default address length should not check the caller", func(t *testing.T) {
+		f, _ := NewBuiltInFunctionsCreator(createMockArguments())
+		err := f.CreateBuiltInFunctionContainer()
+		require.Nil(t, err)
+
+		function, _ := f.BuiltInFunctionContainer().Get(core.BuiltInFunctionMultiDCTNFTTransfer)
+		multiTransfer, ok := unwrapCallValuePolicy(unwrapExecutionGuard(function)).(*dctNFTMultiTransfer)
+		require.True(t, ok)
+		assert.Equal(t, vmcommon.DefaultAddressLength, multiTransfer.addressLength)
+	})
+	t.Run("configured address length should reject malformed callers", func(t *testing.T) {
+		args := createMockArguments()
+		args.AddressLength = 20
+		f, _ := NewBuiltInFunctionsCreator(args)
+		err := f.CreateBuiltInFunctionContainer()
+		require.Nil(t, err)
+
+		function, _ := f.BuiltInFunctionContainer().Get(core.BuiltInFunctionDCTTransfer)
+		input := &vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallerAddr: bytes.Repeat([]byte{1}, 32),
+				Arguments:  [][]byte{[]byte("token"), {1}},
+			},
+		}
+		_, err = function.ProcessBuiltinFunction(nil, nil, input)
+		assert.ErrorIs(t, err, ErrInvalidAddressLength)
+
+		function, _ = f.BuiltInFunctionContainer().Get(core.BuiltInFunctionMultiDCTNFTTransfer)
+		multiTransfer := unwrapCallValuePolicy(unwrapExecutionGuard(function).(*addressLengthFunction).function).(*dctNFTMultiTransfer)
+		assert.Equal(t, 20, multiTransfer.addressLength)
+	})
+}
+
+func TestCreateBuiltInContainter_CreateWithRoyaltiesDenominator(t *testing.T) {
+	t.Run("denominator not multiple of the default one should err", func(t *testing.T) {
+		args := createMockArguments()
+		args.RoyaltiesDenominator = 15000
+		f, err := NewBuiltInFunctionsCreator(args)
+		assert.Nil(t, f)
+		assert.Equal(t, ErrInvalidRoyaltiesDenominator, err)
+	})
+	t.Run("finer denominator should be set on the collection config function", func(t *testing.T) {
+		args := createMockArguments()
+		args.RoyaltiesDenominator = 1000000
+		f, err := NewBuiltInFunctionsCreator(args)
+		require.Nil(t, err)
+		err = f.CreateBuiltInFunctionContainer()
+		require.Nil(t, err)
+
+		function, _ := f.BuiltInFunctionContainer().Get(vmcommon.BuiltInFunctionDCTSetCollectionConfig)
+		multiSig, ok := unwrapCallValuePolicy(unwrapExecutionGuard(function)).(*multiSigFunction)
+		require.True(t, ok)
+		collectionConfig, ok := multiSig.function.(*dctCollectionConfig)
+		require.True(t, ok)
+		assert.Equal(t, uint32(1000000), collectionConfig.royaltiesDenominator)
+	})
+}
+
+func TestCreateBuiltInContainter_CreateWithSystemAddresses(t *testing.T) {
+	customAddresses := vmcommon.SystemAddresses{
+		DCTSCAddress:         bytes.Repeat([]byte{1}, 32),
+		SystemAccountAddress: bytes.Repeat([]byte{2}, 32),
+	}
+	createPauseInput := func(systemAddresses vmcommon.SystemAddresses) *vmcommon.ContractCallInput {
+		return &vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallValue:  big.NewInt(0),
+				CallerAddr: systemAddresses.DCTSCAddress,
+				Arguments:  [][]byte{[]byte("TOKEN-abcdef")},
+			},
+			RecipientAddr: systemAddresses.SystemAccountAddress,
+		}
+	}
+
+	t.Run("invalid system addresses should err", func(t *testing.T) {
+		args := createMockArguments()
+		args.SystemAddresses = vmcommon.SystemAddresses{SystemAccountAddress: customAddresses.SystemAccountAddress}
+		f, err := NewBuiltInFunctionsCreator(args)
+		assert.Nil(t, f)
+		assert.Equal(t, vmcommon.ErrInvalidDCTSCAddress, err)
+	})
+	t.Run("system addresses not matching the address length should err", func(t *testing.T) {
+		args := createMockArguments()
+		args.AddressLength = 20
+		args.SystemAddresses = customAddresses
+		f, err := NewBuiltInFunctionsCreator(args)
+		assert.Nil(t, f)
+		assert.ErrorIs(t, err, vmcommon.ErrInvalidDCTSCAddress)
+	})
+	t.Run("missing system addresses should use the default ones", func(t *testing.T) {
+		f, _ := NewBuiltInFunctionsCreator(createMockArguments())
+		assert.Equal(t, vmcommon.DefaultSystemAddresses(), f.SystemAddresses())
+		err := f.CreateBuiltInFunctionContainer()
+		require.Nil(t, err)
+
+		function, _ := f.BuiltInFunctionContainer().Get(core.BuiltInFunctionDCTPause)
+		_, err = function.ProcessBuiltinFunction(nil, nil, createPauseInput(vmcommon.DefaultSystemAddresses()))
+		// the caller is accepted, the mocked accounts failing afterwards on loading the system account
+		assert.NotEqual(t, ErrAddressIsNotDCTSystemSC, err)
+	})
+	t.Run("configured system addresses should be set to the functions", func(t *testing.T) {
+		args := createMockArguments()
+		args.SystemAddresses = customAddresses
+		f, _ := NewBuiltInFunctionsCreator(args)
+		assert.Equal(t, customAddresses, f.SystemAddresses())
+		err := f.CreateBuiltInFunctionContainer()
+		require.Nil(t, err)
+
+		function, _ := f.BuiltInFunctionContainer().Get(core.BuiltInFunctionDCTPause)
+		_, err = function.ProcessBuiltinFunction(nil, nil, createPauseInput(customAddresses))
+		assert.NotEqual(t, ErrAddressIsNotDCTSystemSC, err)
+		_, err = function.ProcessBuiltinFunction(nil, nil, createPauseInput(vmcommon.DefaultSystemAddresses()))
+		assert.Equal(t, ErrAddressIsNotDCTSystemSC, err)
+
+		dctStorageHandler := f.NFTStorageHandler().(*dctDataStorage)
+		assert.Equal(t, customAddresses, dctStorageHandler.getSystemAddresses())
+	})
+}
+
+func TestCreateBuiltInContainter_CreateWithShardFunctions(t *testing.T) {
+	args := createMockArguments()
+	args.ShardFunctions = vmcommon.ShardFunctionsConfig{
+		DeniedShards: map[string][]uint32{core.BuiltInFunctionDCTTransfer: {args.ShardCoordinator.SelfId()}},
+	}
+	f, _ := NewBuiltInFunctionsCreator(args)
+	err := f.CreateBuiltInFunctionContainer()
+	require.Nil(t, err)
+
+	function, _ := f.BuiltInFunctionContainer().Get(core.BuiltInFunctionDCTTransfer)
+	_, err = function.ProcessBuiltinFunction(nil, nil, &vmcommon.ContractCallInput{})
+	assert.ErrorIs(t, err, ErrFunctionNotAllowedOnShard)
+
+	function, _ = f.BuiltInFunctionContainer().Get(core.BuiltInFunctionDCTNFTTransfer)
+	_, ok := unwrapCallValuePolicy(unwrapExecutionGuard(function)).(*dctNFTTransfer)
+	assert.True(t, ok)
+}
+
+func TestCreateBuiltInContainter_CreateWithHistoricalReplay(t *testing.T) {
+	args := createMockArguments()
+	args.EnableEpochsHandlerFactory = &mock.EnableEpochsHandlerFactoryStub{
+		CreateEnableEpochsHandlerCalled: func(epoch uint32) (vmcommon.EnableEpochsHandler, error) {
+			return &mock.EnableEpochsHandlerStub{
+				IsSoulboundFlagEnabledField: epoch >= 10,
+				CurrentEpochField:           epoch,
+			}, nil
+		},
+	}
+	f, _ := NewBuiltInFunctionsCreator(args)
+	err := f.CreateBuiltInFunctionContainer()
+	require.Nil(t, err)
+
+	replayContainer, ok := f.BuiltInFunctionContainer().(vmcommon.HistoricalReplayContainer)
+	require.True(t, ok)
+
+	_, err = replayContainer.ProcessBuiltinFunctionAsOfEpoch(vmcommon.BuiltInFunctionDCTSetSoulbound, 5, nil, nil, &vmcommon.ContractCallInput{})
+	assert.ErrorIs(t, err, ErrBuiltInFunctionNotActive)
+
+	_, err = replayContainer.ProcessBuiltinFunctionAsOfEpoch(vmcommon.BuiltInFunctionDCTSetSoulbound, 10, nil, nil, &vmcommon.ContractCallInput{VMInput: vmcommon.VMInput{CallValue: big.NewInt(0)}})
+	assert.NotNil(t, err)
+	assert.NotErrorIs(t, err, ErrBuiltInFunctionNotActive)
+
+	function, _ := f.BuiltInFunctionContainer().Get(vmcommon.BuiltInFunctionDCTSetSoulbound)
+	assert.False(t, function.IsActive())
+}
+
+func TestCreateBuiltInContainter_CallValuePolicies(t *testing.T) {
+	f, _ := NewBuiltInFunctionsCreator(createMockArguments())
+	err := f.CreateBuiltInFunctionContainer()
+	require.Nil(t, err)
+
+	policyContainer, ok := f.BuiltInFunctionContainer().(vmcommon.CallValuePolicyContainer)
+	require.True(t, ok)
+
+	for key := range f.BuiltInFunctionContainer().Keys() {
+		policy, found := policyContainer.CallValuePolicy(key)
+		assert.True(t, found, key)
+
+		expectedPolicy := vmcommon.CallValueForbidden
+		if key == core.BuiltInFunctionDCTTransfer {
+			expectedPolicy = vmcommon.CallValueAllowed
+		}
+		assert.Equal(t, expectedPolicy, policy, key)
+	}
+
+	function, _ := f.BuiltInFunctionContainer().Get(core.BuiltInFunctionDCTBurn)
+	_, err = function.ProcessBuiltinFunction(nil, nil, &vmcommon.ContractCallInput{VMInput: vmcommon.VMInput{CallValue: big.NewInt(1)}})
+	assert.Equal(t, ErrBuiltInFunctionCalledWithValue, err)
+}
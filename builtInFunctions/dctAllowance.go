@@ -0,0 +1,316 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"sync"
+
+	"github.com/Reshusk23/sr-me-core/core"
+	"github.com/Reshusk23/sr-me-core/core/check"
+	"github.com/Reshusk23/sr-me-core/data/vm"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/protectedkeys"
+	"github.com/Reshusk23/sr-vm-common-go/validation"
+)
+
+const numArgumentsDCTAllowance = 3
+
+var allowanceKeyPrefix = []byte(protectedkeys.AllowancePrefix)
+
+type dctApprove struct {
+	baseActiveHandler
+	baseAddressLengthHandler
+	funcGasCost  uint64
+	mutExecution sync.RWMutex
+}
+
+// ArgsNewDCTApprove defines the argument list for new dct approve built in function
+type ArgsNewDCTApprove struct {
+	Config
+	FuncGasCost uint64
+}
+
+// NewDCTApproveFunc returns the dct approve built-in function component, which sets the amount of a fungible token
+// another address is allowed to spend from the balance of the caller
+func NewDCTApproveFunc(args ArgsNewDCTApprove) (*dctApprove, error) {
+	err := args.checkComponents(vmcommon.BuiltInFunctionDCTApprove, requireEnableEpochsHandler)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &dctApprove{
+		funcGasCost: args.FuncGasCost,
+	}
+
+	e.baseActiveHandler.activeHandler = args.EnableEpochsHandler.IsDCTAllowanceFlagEnabled
+
+	return e, nil
+}
+
+// SetNewGasConfig is called whenever gas cost is changed
+func (e *dctApprove) SetNewGasConfig(gasCost *vmcommon.GasCost) {
+	if gasCost == nil {
+		return
+	}
+
+	e.mutExecution.Lock()
+	e.funcGasCost = gasCost.BuiltInCost.DCTTransfer
+	e.mutExecution.Unlock()
+}
+
+// ProcessBuiltinFunction resolves DCT approve function call
+// Requires 3 arguments:
+// arg0 - token identifier
+// arg1 - address of the spender
+// arg2 - allowed amount, replacing the previous allowance. A zero amount revokes the allowance
+func (e *dctApprove) ProcessBuiltinFunction(
+	acntSnd, _ vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	e.mutExecution.RLock()
+	defer e.mutExecution.RUnlock()
+
+	if vmInput == nil {
+		return nil, ErrNilVmInput
+	}
+	err := checkCallValue(vmcommon.CallValueForbidden, vmInput.CallValue)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(vmInput.CallerAddr, vmInput.RecipientAddr) {
+		return nil, ErrInvalidRcvAddr
+	}
+	if check.IfNil(acntSnd) {
+		return nil, ErrNilUserAccount
+	}
+	if len(vmInput.Arguments) != numArgumentsDCTAllowance {
+		return nil, ErrInvalidArguments
+	}
+	if vmInput.GasProvided < e.funcGasCost {
+		return nil, ErrNotEnoughGas
+	}
+	err = checkFunctionArguments(
+		vmInput.Arguments,
+		validation.RequireAddress(1, e.getAddressLength(vmInput)),
+		validation.RequireBigIntMaxBytes(2, core.MaxLenForDCTIssueMint),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenID := vmInput.Arguments[0]
+	spender := vmInput.Arguments[1]
+	amount := big.NewInt(0).SetBytes(vmInput.Arguments[2])
+	err = saveAllowance(acntSnd, tokenID, spender, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	vmOutput := &vmcommon.VMOutput{ReturnCode: vmcommon.Ok, GasRemaining: vmInput.GasProvided - e.funcGasCost}
+	addDCTEntryInVMOutput(vmOutput, []byte(vmcommon.BuiltInFunctionDCTApprove), tokenID, 0, amount, vmInput.CallerAddr, spender)
+
+	return vmOutput, nil
+}
+
+// IsInterfaceNil returns true if underlying object in nil
+func (e *dctApprove) IsInterfaceNil() bool {
+	return e == nil
+}
+
+type dctTransferFrom struct {
+	baseActiveHandler
+	baseAddressLengthHandler
+	freezeAccountChecker
+	lockedBalanceChecker
+	transferInterceptorChecker
+	keyPrefix             []byte
+	marshaller            vmcommon.Marshalizer
+	globalSettingsHandler vmcommon.ExtendedDCTGlobalSettingsHandler
+	rolesHandler          vmcommon.DCTRoleHandler
+	funcGasCost           uint64
+	mutExecution          sync.RWMutex
+}
+
+// ArgsNewDCTTransferFrom defines the argument list for new dct transfer from built in function
+type ArgsNewDCTTransferFrom struct {
+	Config
+	FuncGasCost uint64
+}
+
+// NewDCTTransferFromFunc returns the dct transfer from built-in function component, which spends the allowance
+// granted to the caller by sending the tokens of the owner to a destination
+func NewDCTTransferFromFunc(args ArgsNewDCTTransferFrom) (*dctTransferFrom, error) {
+	err := args.checkComponents(vmcommon.BuiltInFunctionDCTTransferFrom, requireMarshalizer|requireGlobalSettingsHandler|requireRolesHandler|requireEnableEpochsHandler)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &dctTransferFrom{
+		lockedBalanceChecker:  lockedBalanceChecker{enableEpochsHandler: args.EnableEpochsHandler},
+		keyPrefix:             []byte(baseDCTKeyPrefix),
+		marshaller:            args.Marshalizer,
+		globalSettingsHandler: args.GlobalSettingsHandler,
+		rolesHandler:          args.RolesHandler,
+		funcGasCost:           args.FuncGasCost,
+	}
+
+	e.baseActiveHandler.activeHandler = args.EnableEpochsHandler.IsDCTAllowanceFlagEnabled
+
+	return e, nil
+}
+
+// SetNewGasConfig is called whenever gas cost is changed
+func (e *dctTransferFrom) SetNewGasConfig(gasCost *vmcommon.GasCost) {
+	if gasCost == nil {
+		return
+	}
+
+	e.mutExecution.Lock()
+	e.funcGasCost = gasCost.BuiltInCost.DCTTransfer
+	e.mutExecution.Unlock()
+}
+
+// ProcessBuiltinFunction resolves DCT transfer from function call. The call is sent by the spender to the owner of
+// the tokens, which are debited in the shard of the owner and sent to the destination as a DCT transfer
+// Requires 3 arguments:
+// arg0 - token identifier
+// arg1 - amount to transfer
+// arg2 - address of the destination
+func (e *dctTransferFrom) ProcessBuiltinFunction(
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	e.mutExecution.RLock()
+	defer e.mutExecution.RUnlock()
+
+	if vmInput == nil {
+		return nil, ErrNilVmInput
+	}
+	err := checkCallValue(vmcommon.CallValueForbidden, vmInput.CallValue)
+	if err != nil {
+		return nil, err
+	}
+	if len(vmInput.Arguments) != numArgumentsDCTAllowance {
+		return nil, ErrInvalidArguments
+	}
+	err = checkFunctionArguments(
+		vmInput.Arguments,
+		validation.RequireBigIntMaxBytes(1, core.MaxLenForDCTIssueMint),
+		validation.RequireAddress(2, e.getAddressLength(vmInput)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	amount := big.NewInt(0).SetBytes(vmInput.Arguments[1])
+	if amount.Cmp(zero) <= 0 {
+		return nil, ErrNegativeValue
+	}
+	if !check.IfNil(acntSnd) && vmInput.GasProvided < e.funcGasCost {
+		// gas is paid only by the spender
+		return nil, ErrNotEnoughGas
+	}
+
+	vmOutput := &vmcommon.VMOutput{ReturnCode: vmcommon.Ok, GasRemaining: computeGasRemaining(acntSnd, vmInput.GasProvided, e.funcGasCost)}
+	if check.IfNil(acntDst) {
+		// the owner is in another shard, the allowance is spent when the call gets there
+		return vmOutput, nil
+	}
+
+	tokenID := vmInput.Arguments[0]
+	destination := vmInput.Arguments[2]
+	dctTokenKey := append(e.keyPrefix, tokenID...)
+	err = e.checkAccountIsNotFrozen(acntDst.AddressBytes(), vmInput.ReturnCallAfterError)
+	if err != nil {
+		return nil, err
+	}
+	// the destination account is not loaded in the shard of the owner, so for limited transfer tokens it can only be
+	// allowed through the transfer role addresses, the transfer role of the owner being checked otherwise
+	err = checkIfTransferCanHappenWithLimitedTransfer(tokenID, dctTokenKey, acntDst.AddressBytes(), destination, e.globalSettingsHandler, e.rolesHandler, acntDst, acntDst, vmInput.ReturnCallAfterError)
+	if err != nil {
+		return nil, err
+	}
+	err = checkIfTransferCanHappenWithSoulbound(dctTokenKey, e.globalSettingsHandler, acntDst, vmInput.ReturnCallAfterError)
+	if err != nil {
+		return nil, err
+	}
+	err = e.checkTransferIsAllowed(acntDst.AddressBytes(), destination, tokenID, 0, amount, vmInput.ReturnCallAfterError)
+	if err != nil {
+		return nil, err
+	}
+	err = spendAllowance(acntDst, tokenID, vmInput.CallerAddr, amount)
+	if err != nil {
+		return nil, err
+	}
+	err = e.subFromDCTBalance(acntDst, dctTokenKey, amount, e.marshaller, e.globalSettingsHandler, e.getSystemAddresses(), vmInput.ReturnCallAfterError)
+	if err != nil {
+		return nil, err
+	}
+
+	addTransferFromOutputToVMOutput(acntDst.AddressBytes(), destination, tokenID, amount, vmOutput)
+	addDCTEntryInVMOutput(vmOutput, []byte(vmcommon.BuiltInFunctionDCTTransferFrom), tokenID, 0, amount, acntDst.AddressBytes(), destination, vmInput.CallerAddr)
+
+	return vmOutput, nil
+}
+
+// IsInterfaceNil returns true if underlying object in nil
+func (e *dctTransferFrom) IsInterfaceNil() bool {
+	return e == nil
+}
+
+// addTransferFromOutputToVMOutput sends the debited tokens from the owner to the destination as a DCT transfer
+func addTransferFromOutputToVMOutput(owner []byte, destination []byte, tokenID []byte, amount *big.Int, vmOutput *vmcommon.VMOutput) {
+	data := core.BuiltInFunctionDCTTransfer + "@" + hex.EncodeToString(tokenID) + "@" + hex.EncodeToString(amount.Bytes())
+	outTransfer := vmcommon.OutputTransfer{
+		Value:         big.NewInt(0),
+		Data:          []byte(data),
+		CallType:      vm.DirectCall,
+		SenderAddress: owner,
+	}
+	vmOutput.OutputAccounts = map[string]*vmcommon.OutputAccount{
+		string(destination): {
+			Address:         destination,
+			OutputTransfers: []vmcommon.OutputTransfer{outTransfer},
+		},
+	}
+}
+
+func computeAllowanceKey(tokenID []byte, spender []byte) []byte {
+	key := make([]byte, 0, len(allowanceKeyPrefix)+len(tokenID)+len(spender))
+	key = append(key, allowanceKeyPrefix...)
+	key = append(key, tokenID...)
+
+	return append(key, spender...)
+}
+
+// getAllowance returns the amount of the token the owner allows the spender to transfer
+func getAllowance(owner vmcommon.UserAccountHandler, tokenID []byte, spender []byte) (*big.Int, error) {
+	val, _, err := owner.AccountDataHandler().RetrieveValue(computeAllowanceKey(tokenID, spender))
+	if err != nil {
+		return nil, err
+	}
+
+	return big.NewInt(0).SetBytes(val), nil
+}
+
+// saveAllowance stores the allowance of the spender, a zero amount removing it
+func saveAllowance(owner vmcommon.UserAccountHandler, tokenID []byte, spender []byte, amount *big.Int) error {
+	var val []byte
+	if amount.Cmp(zero) > 0 {
+		val = amount.Bytes()
+	}
+
+	return owner.AccountDataHandler().SaveKeyValue(computeAllowanceKey(tokenID, spender), val)
+}
+
+func spendAllowance(owner vmcommon.UserAccountHandler, tokenID []byte, spender []byte, amount *big.Int) error {
+	allowance, err := getAllowance(owner, tokenID, spender)
+	if err != nil {
+		return err
+	}
+	if allowance.Cmp(amount) < 0 {
+		return ErrInsufficientAllowance
+	}
+
+	return saveAllowance(owner, tokenID, spender, allowance.Sub(allowance, amount))
+}
@@ -0,0 +1,294 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/Reshusk23/sr-me-core/core"
+	"github.com/Reshusk23/sr-me-core/core/check"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var allowanceTokenID = []byte("ALLOW-abcdef")
+
+func createDCTAllowanceInput(caller []byte, recipient []byte, arguments ...[]byte) *vmcommon.ContractCallInput {
+	return &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  caller,
+			CallValue:   big.NewInt(0),
+			Arguments:   arguments,
+			GasProvided: 100,
+		},
+		RecipientAddr: recipient,
+	}
+}
+
+func TestNewDCTApproveFunc(t *testing.T) {
+	t.Parallel()
+
+	e, err := NewDCTApproveFunc(ArgsNewDCTApprove{
+		FuncGasCost: 10,
+	})
+	assert.True(t, check.IfNil(e))
+	assert.ErrorIs(t, err, ErrNilEnableEpochsHandler)
+
+	enableEpochsHandler := &mock.EnableEpochsHandlerStub{}
+	e, err = NewDCTApproveFunc(ArgsNewDCTApprove{
+		Config: Config{
+			EnableEpochsHandler: enableEpochsHandler,
+		},
+		FuncGasCost: 10,
+	})
+	assert.False(t, check.IfNil(e))
+	assert.Nil(t, err)
+	assert.False(t, e.IsActive())
+
+	enableEpochsHandler.IsDCTAllowanceFlagEnabledField = true
+	assert.True(t, e.IsActive())
+}
+
+func TestNewDCTTransferFromFunc(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil marshaller should error", func(t *testing.T) {
+		t.Parallel()
+
+		e, err := NewDCTTransferFromFunc(ArgsNewDCTTransferFrom{
+			Config: Config{
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+				EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			},
+			FuncGasCost: 10,
+		})
+		assert.True(t, check.IfNil(e))
+		assert.ErrorIs(t, err, ErrNilMarshalizer)
+	})
+	t.Run("nil global settings handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		e, err := NewDCTTransferFromFunc(ArgsNewDCTTransferFrom{
+			Config: Config{
+				Marshalizer:         &mock.MarshalizerMock{},
+				RolesHandler:        &mock.DCTRoleHandlerStub{},
+				EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+			},
+			FuncGasCost: 10,
+		})
+		assert.True(t, check.IfNil(e))
+		assert.ErrorIs(t, err, ErrNilGlobalSettingsHandler)
+	})
+	t.Run("nil roles handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		e, err := NewDCTTransferFromFunc(ArgsNewDCTTransferFrom{
+			Config: Config{
+				Marshalizer:           &mock.MarshalizerMock{},
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			},
+			FuncGasCost: 10,
+		})
+		assert.True(t, check.IfNil(e))
+		assert.ErrorIs(t, err, ErrNilRolesHandler)
+	})
+	t.Run("nil enable epochs handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		e, err := NewDCTTransferFromFunc(ArgsNewDCTTransferFrom{
+			Config: Config{
+				Marshalizer:           &mock.MarshalizerMock{},
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+			},
+			FuncGasCost: 10,
+		})
+		assert.True(t, check.IfNil(e))
+		assert.ErrorIs(t, err, ErrNilEnableEpochsHandler)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		enableEpochsHandler := &mock.EnableEpochsHandlerStub{}
+		e, err := NewDCTTransferFromFunc(ArgsNewDCTTransferFrom{
+			Config: Config{
+				Marshalizer:           &mock.MarshalizerMock{},
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+				EnableEpochsHandler:   enableEpochsHandler,
+			},
+			FuncGasCost: 10,
+		})
+		assert.False(t, check.IfNil(e))
+		assert.Nil(t, err)
+		assert.False(t, e.IsActive())
+
+		enableEpochsHandler.IsDCTAllowanceFlagEnabledField = true
+		assert.True(t, e.IsActive())
+	})
+}
+
+func TestDCTApprove_ProcessBuiltinFunction(t *testing.T) {
+	t.Parallel()
+
+	owner := mock.NewUserAccount(bytes.Repeat([]byte{1}, 32))
+	spender := bytes.Repeat([]byte{2}, 32)
+	e, _ := NewDCTApproveFunc(ArgsNewDCTApprove{
+		Config: Config{
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+		},
+		FuncGasCost: 10,
+	})
+
+	_, err := e.ProcessBuiltinFunction(owner, nil, nil)
+	assert.Equal(t, ErrNilVmInput, err)
+
+	_, err = e.ProcessBuiltinFunction(owner, nil, createDCTAllowanceInput(owner.AddressBytes(), spender, allowanceTokenID, spender, []byte{10}))
+	assert.Equal(t, ErrInvalidRcvAddr, err)
+
+	_, err = e.ProcessBuiltinFunction(owner, nil, createDCTAllowanceInput(owner.AddressBytes(), owner.AddressBytes(), allowanceTokenID, spender))
+	assert.Equal(t, ErrInvalidArguments, err)
+
+	_, err = e.ProcessBuiltinFunction(owner, nil, createDCTAllowanceInput(owner.AddressBytes(), owner.AddressBytes(), allowanceTokenID, spender[1:], []byte{10}))
+	assert.ErrorIs(t, err, ErrInvalidArguments)
+
+	vmOutput, err := e.ProcessBuiltinFunction(owner, nil, createDCTAllowanceInput(owner.AddressBytes(), owner.AddressBytes(), allowanceTokenID, spender, []byte{10}))
+	require.Nil(t, err)
+	assert.Equal(t, uint64(90), vmOutput.GasRemaining)
+	require.Equal(t, 1, len(vmOutput.Logs))
+	assert.Equal(t, []byte(vmcommon.BuiltInFunctionDCTApprove), vmOutput.Logs[0].Identifier)
+	assert.Equal(t, [][]byte{allowanceTokenID, {}, {10}, spender}, vmOutput.Logs[0].Topics)
+
+	allowance, err := getAllowance(owner, allowanceTokenID, spender)
+	require.Nil(t, err)
+	assert.Equal(t, big.NewInt(10), allowance)
+
+	_, err = e.ProcessBuiltinFunction(owner, nil, createDCTAllowanceInput(owner.AddressBytes(), owner.AddressBytes(), allowanceTokenID, spender, []byte{}))
+	require.Nil(t, err)
+	val, _, _ := owner.AccountDataHandler().RetrieveValue(computeAllowanceKey(allowanceTokenID, spender))
+	assert.Empty(t, val)
+}
+
+func TestDCTTransferFrom_ProcessBuiltinFunction(t *testing.T) {
+	t.Parallel()
+
+	marshaller := &mock.MarshalizerMock{}
+	owner := mock.NewUserAccount(bytes.Repeat([]byte{1}, 32))
+	spender := mock.NewUserAccount(bytes.Repeat([]byte{2}, 32))
+	destination := bytes.Repeat([]byte{3}, 32)
+	dctTokenKey := append([]byte(baseDCTKeyPrefix), allowanceTokenID...)
+	require.Nil(t, addToDCTBalance(owner, dctTokenKey, big.NewInt(100), marshaller, &mock.GlobalSettingsHandlerStub{}, vmcommon.DefaultSystemAddresses(), false))
+	require.Nil(t, saveAllowance(owner, allowanceTokenID, spender.AddressBytes(), big.NewInt(30)))
+
+	globalSettingsHandler := &mock.GlobalSettingsHandlerStub{}
+	e, _ := NewDCTTransferFromFunc(ArgsNewDCTTransferFrom{
+		Config: Config{
+			Marshalizer:           marshaller,
+			GlobalSettingsHandler: globalSettingsHandler,
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+		},
+		FuncGasCost: 10,
+	})
+
+	_, err := e.ProcessBuiltinFunction(spender, owner, nil)
+	assert.Equal(t, ErrNilVmInput, err)
+
+	vmInput := createDCTAllowanceInput(spender.AddressBytes(), owner.AddressBytes(), allowanceTokenID, []byte{20}, destination)
+	vmOutput, err := e.ProcessBuiltinFunction(spender, nil, vmInput)
+	require.Nil(t, err)
+	assert.Equal(t, uint64(90), vmOutput.GasRemaining)
+	assert.Empty(t, vmOutput.OutputAccounts)
+
+	_, err = e.ProcessBuiltinFunction(spender, owner, createDCTAllowanceInput(spender.AddressBytes(), owner.AddressBytes(), allowanceTokenID, []byte{31}, destination))
+	assert.Equal(t, ErrInsufficientAllowance, err)
+
+	globalSettingsHandler.IsSoulboundCalled = func(token []byte) bool {
+		return true
+	}
+	_, err = e.ProcessBuiltinFunction(spender, owner, vmInput)
+	assert.NotNil(t, err)
+	globalSettingsHandler.IsSoulboundCalled = nil
+
+	vmOutput, err = e.ProcessBuiltinFunction(nil, owner, vmInput)
+	require.Nil(t, err)
+	assert.Equal(t, uint64(0), vmOutput.GasRemaining)
+	assert.Equal(t, big.NewInt(80), getDCTBalanceForTest(t, owner, dctTokenKey, marshaller))
+	allowance, _ := getAllowance(owner, allowanceTokenID, spender.AddressBytes())
+	assert.Equal(t, big.NewInt(10), allowance)
+
+	require.Equal(t, 1, len(vmOutput.OutputAccounts))
+	outputAccount := vmOutput.OutputAccounts[string(destination)]
+	require.Equal(t, 1, len(outputAccount.OutputTransfers))
+	expectedData := core.BuiltInFunctionDCTTransfer + "@" + hex.EncodeToString(allowanceTokenID) + "@" + hex.EncodeToString([]byte{20})
+	assert.Equal(t, []byte(expectedData), outputAccount.OutputTransfers[0].Data)
+	assert.Equal(t, owner.AddressBytes(), outputAccount.OutputTransfers[0].SenderAddress)
+
+	require.Equal(t, 1, len(vmOutput.Logs))
+	assert.Equal(t, []byte(vmcommon.BuiltInFunctionDCTTransferFrom), vmOutput.Logs[0].Identifier)
+	assert.Equal(t, [][]byte{allowanceTokenID, {}, {20}, destination, spender.AddressBytes()}, vmOutput.Logs[0].Topics)
+}
+
+func TestDCTTransferFrom_ProcessBuiltinFunctionLimitedTransferAndFrozenOwner(t *testing.T) {
+	t.Parallel()
+
+	marshaller := &mock.MarshalizerMock{}
+	owner := mock.NewUserAccount(bytes.Repeat([]byte{1}, 32))
+	spender := bytes.Repeat([]byte{2}, 32)
+	destination := bytes.Repeat([]byte{3}, 32)
+	dctTokenKey := append([]byte(baseDCTKeyPrefix), allowanceTokenID...)
+	require.Nil(t, addToDCTBalance(owner, dctTokenKey, big.NewInt(100), marshaller, &mock.GlobalSettingsHandlerStub{}, vmcommon.DefaultSystemAddresses(), false))
+	require.Nil(t, saveAllowance(owner, allowanceTokenID, spender, big.NewInt(30)))
+	vmInput := createDCTAllowanceInput(spender, owner.AddressBytes(), allowanceTokenID, []byte{20}, destination)
+
+	t.Run("limited transfer without transfer role should error", func(t *testing.T) {
+		globalSettingsHandler := &mock.GlobalSettingsHandlerStub{
+			IsLimiterTransferCalled: func(token []byte) bool {
+				return true
+			},
+		}
+		rolesHandler := &mock.DCTRoleHandlerStub{
+			CheckAllowedToExecuteCalled: func(account vmcommon.UserAccountHandler, tokenID []byte, action []byte) error {
+				return ErrActionNotAllowed
+			},
+		}
+		e, _ := NewDCTTransferFromFunc(ArgsNewDCTTransferFrom{
+			Config: Config{
+				Marshalizer:           marshaller,
+				GlobalSettingsHandler: globalSettingsHandler,
+				RolesHandler:          rolesHandler,
+				EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			},
+			FuncGasCost: 10,
+		})
+
+		_, err := e.ProcessBuiltinFunction(nil, owner, vmInput)
+		assert.Equal(t, ErrActionNotAllowed, err)
+		assert.Equal(t, big.NewInt(100), getDCTBalanceForTest(t, owner, dctTokenKey, marshaller))
+	})
+	t.Run("frozen owner should error", func(t *testing.T) {
+		e, _ := NewDCTTransferFromFunc(ArgsNewDCTTransferFrom{
+			Config: Config{
+				Marshalizer:           marshaller,
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+				EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			},
+			FuncGasCost: 10,
+		})
+		_ = e.SetFreezeAccountHandler(&mock.FreezeAccountHandlerStub{
+			IsAccountFrozenCalled: func(address []byte) bool {
+				return bytes.Equal(address, owner.AddressBytes())
+			},
+		})
+
+		_, err := e.ProcessBuiltinFunction(nil, owner, vmInput)
+		assert.Equal(t, ErrAccountIsFrozen, err)
+		assert.Equal(t, big.NewInt(100), getDCTBalanceForTest(t, owner, dctTokenKey, marshaller))
+	})
+}
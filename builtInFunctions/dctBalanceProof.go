@@ -0,0 +1,48 @@
+package builtInFunctions
+
+import (
+	"github.com/Reshusk23/sr-me-core/core/check"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/dctkeys"
+)
+
+// DCTBalanceProof holds the Merkle proof of a DCT balance, the key being the one of the account data trie under which
+// the token is stored
+type DCTBalanceProof struct {
+	RootHash []byte
+	Address  []byte
+	TokenKey AccountDCTTokenKey
+	Key      []byte
+	Proof    [][]byte
+}
+
+// GetDCTBalanceProof requests from the provider the Merkle proof of the balance of a token held by an account, as
+// committed under the provided root hash. It is meant to be called by the hosts after the built-in function calls,
+// the storage key being computed the same way as by the built-in functions writing the balance
+func GetDCTBalanceProof(
+	provider vmcommon.StateProofProvider,
+	rootHash []byte,
+	address []byte,
+	tokenKey AccountDCTTokenKey,
+) (*DCTBalanceProof, error) {
+	if check.IfNil(provider) {
+		return nil, ErrNilStateProofProvider
+	}
+	if len(tokenKey.TokenIdentifier) == 0 {
+		return nil, ErrInvalidTokenID
+	}
+
+	key := dctkeys.ComputeDCTBalanceKey(tokenKey.TokenIdentifier, tokenKey.Nonce)
+	proof, err := provider.GetProof(rootHash, address, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DCTBalanceProof{
+		RootHash: rootHash,
+		Address:  address,
+		TokenKey: tokenKey,
+		Key:      key,
+		Proof:    proof,
+	}, nil
+}
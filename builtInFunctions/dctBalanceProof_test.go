@@ -0,0 +1,71 @@
+package builtInFunctions
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Reshusk23/sr-vm-common-go/dctkeys"
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetDCTBalanceProof(t *testing.T) {
+	t.Parallel()
+
+	rootHash := []byte("root hash")
+	address := []byte("address")
+	tokenKey := AccountDCTTokenKey{TokenIdentifier: []byte("NFT-abcdef"), Nonce: 7}
+
+	t.Run("nil provider should error", func(t *testing.T) {
+		t.Parallel()
+
+		proof, err := GetDCTBalanceProof(nil, rootHash, address, tokenKey)
+		require.Nil(t, proof)
+		require.Equal(t, ErrNilStateProofProvider, err)
+	})
+	t.Run("empty token identifier should error", func(t *testing.T) {
+		t.Parallel()
+
+		proof, err := GetDCTBalanceProof(&mock.StateProofProviderStub{}, rootHash, address, AccountDCTTokenKey{Nonce: 7})
+		require.Nil(t, proof)
+		require.Equal(t, ErrInvalidTokenID, err)
+	})
+	t.Run("provider error should be returned", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		provider := &mock.StateProofProviderStub{
+			GetProofCalled: func(_ []byte, _ []byte, _ []byte) ([][]byte, error) {
+				return nil, expectedErr
+			},
+		}
+
+		proof, err := GetDCTBalanceProof(provider, rootHash, address, tokenKey)
+		require.Nil(t, proof)
+		require.Equal(t, expectedErr, err)
+	})
+	t.Run("should request the proof of the balance key", func(t *testing.T) {
+		t.Parallel()
+
+		expectedProof := [][]byte{[]byte("node 1"), []byte("node 2")}
+		expectedKey := dctkeys.ComputeDCTNFTTokenKey([]byte(baseDCTKeyPrefix+"NFT-abcdef"), 7)
+		provider := &mock.StateProofProviderStub{
+			GetProofCalled: func(providedRootHash []byte, providedAddress []byte, key []byte) ([][]byte, error) {
+				require.Equal(t, rootHash, providedRootHash)
+				require.Equal(t, address, providedAddress)
+				require.Equal(t, expectedKey, key)
+				return expectedProof, nil
+			},
+		}
+
+		proof, err := GetDCTBalanceProof(provider, rootHash, address, tokenKey)
+		require.Nil(t, err)
+		require.Equal(t, &DCTBalanceProof{
+			RootHash: rootHash,
+			Address:  address,
+			TokenKey: tokenKey,
+			Key:      expectedKey,
+			Proof:    expectedProof,
+		}, proof)
+	})
+}
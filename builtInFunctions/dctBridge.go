@@ -0,0 +1,204 @@
+package builtInFunctions
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/Reshusk23/sr-me-core/core"
+	"github.com/Reshusk23/sr-me-core/core/check"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/protectedkeys"
+	"github.com/Reshusk23/sr-vm-common-go/validation"
+)
+
+var bridgeProofKeyPrefix = []byte(protectedkeys.BridgeProofPrefix)
+
+const numArgumentsDCTBridge = 3
+
+type dctBridge struct {
+	baseActiveHandler
+	baseSystemAddressesHandler
+	lockedBalanceChecker
+	mint                  bool
+	function              string
+	keyPrefix             []byte
+	bridgeAddresses       map[string]struct{}
+	accounts              vmcommon.AccountsAdapter
+	marshaller            vmcommon.Marshalizer
+	globalSettingsHandler vmcommon.DCTGlobalSettingsHandler
+	proofVerifier         vmcommon.ProofVerifier
+	funcGasCost           uint64
+	mutExecution          sync.RWMutex
+}
+
+// ArgsNewDCTBridge defines the argument list for new dct bridge mint/burn built in functions
+type ArgsNewDCTBridge struct {
+	Config
+	FuncGasCost     uint64
+	BridgeAddresses [][]byte
+}
+
+// NewDCTBridgeMintFunc returns the dct bridge mint built-in function component, which credits the calling bridge
+// address with the tokens locked on an external chain
+func NewDCTBridgeMintFunc(args ArgsNewDCTBridge) (*dctBridge, error) {
+	return newDCTBridgeFunc(args, true)
+}
+
+// NewDCTBridgeBurnFunc returns the dct bridge burn built-in function component, which debits the calling bridge
+// address with the tokens released on an external chain
+func NewDCTBridgeBurnFunc(args ArgsNewDCTBridge) (*dctBridge, error) {
+	return newDCTBridgeFunc(args, false)
+}
+
+func newDCTBridgeFunc(args ArgsNewDCTBridge, mint bool) (*dctBridge, error) {
+	function := vmcommon.BuiltInFunctionDCTBridgeBurn
+	if mint {
+		function = vmcommon.BuiltInFunctionDCTBridgeMint
+	}
+	err := args.checkComponents(function, requireMarshalizer|requireAccounts|requireGlobalSettingsHandler|requireEnableEpochsHandler)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &dctBridge{
+		lockedBalanceChecker:  lockedBalanceChecker{enableEpochsHandler: args.EnableEpochsHandler},
+		mint:                  mint,
+		function:              function,
+		keyPrefix:             []byte(baseDCTKeyPrefix),
+		bridgeAddresses:       make(map[string]struct{}, len(args.BridgeAddresses)),
+		accounts:              args.Accounts,
+		marshaller:            args.Marshalizer,
+		globalSettingsHandler: args.GlobalSettingsHandler,
+		funcGasCost:           args.FuncGasCost,
+	}
+	for _, address := range args.BridgeAddresses {
+		e.bridgeAddresses[string(address)] = struct{}{}
+	}
+
+	e.baseActiveHandler.activeHandler = args.EnableEpochsHandler.IsDCTBridgeFlagEnabled
+
+	return e, nil
+}
+
+// SetNewGasConfig is called whenever gas cost is changed
+func (e *dctBridge) SetNewGasConfig(gasCost *vmcommon.GasCost) {
+	if gasCost == nil {
+		return
+	}
+
+	e.mutExecution.Lock()
+	e.funcGasCost = gasCost.BuiltInCost.DCTLocalMint
+	if !e.mint {
+		e.funcGasCost = gasCost.BuiltInCost.DCTLocalBurn
+	}
+	e.mutExecution.Unlock()
+}
+
+// SetProofVerifier sets the verifier of the external chain proofs
+func (e *dctBridge) SetProofVerifier(proofVerifier vmcommon.ProofVerifier) error {
+	if check.IfNil(proofVerifier) {
+		return ErrNilProofVerifier
+	}
+
+	e.mutExecution.Lock()
+	e.proofVerifier = proofVerifier
+	e.mutExecution.Unlock()
+
+	return nil
+}
+
+// ProcessBuiltinFunction resolves DCT bridge mint and burn function calls
+// Requires 3 arguments:
+// arg0 - token identifier
+// arg1 - amount to mint or burn
+// arg2 - external chain proof, validated by the proof verifier and consumed by the call
+func (e *dctBridge) ProcessBuiltinFunction(
+	acntSnd, _ vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	e.mutExecution.RLock()
+	defer e.mutExecution.RUnlock()
+
+	err := checkInputArgumentsForLocalAction(acntSnd, vmInput, e.funcGasCost)
+	if err != nil {
+		return nil, err
+	}
+	if len(vmInput.Arguments) != numArgumentsDCTBridge {
+		return nil, ErrInvalidArguments
+	}
+	_, isBridge := e.bridgeAddresses[string(vmInput.CallerAddr)]
+	if !isBridge {
+		return nil, ErrAddressIsNotBridge
+	}
+	if check.IfNil(e.proofVerifier) {
+		return nil, ErrProofVerifierNotSet
+	}
+
+	err = checkFunctionArguments(vmInput.Arguments, validation.RequireBigIntMaxBytes(1, core.MaxLenForDCTIssueMint))
+	if err != nil {
+		return nil, err
+	}
+
+	tokenID := vmInput.Arguments[0]
+	value := big.NewInt(0).SetBytes(vmInput.Arguments[1])
+	if value.Cmp(zero) <= 0 {
+		return nil, ErrNegativeValue
+	}
+	proofID, err := e.proofVerifier.VerifyProof(e.function, tokenID, value, vmInput.CallerAddr, vmInput.Arguments[2])
+	if err != nil {
+		return nil, err
+	}
+
+	systemAcc, err := getSystemAccount(e.accounts, e.getSystemAddresses().SystemAccountAddress)
+	if err != nil {
+		return nil, err
+	}
+	proofKey := e.computeProofKey(proofID)
+	consumed, _, err := systemAcc.AccountDataHandler().RetrieveValue(proofKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(consumed) > 0 {
+		return nil, fmt.Errorf("%w for %s", ErrBridgeProofAlreadyConsumed, e.function)
+	}
+
+	dctTokenKey := append(e.keyPrefix, tokenID...)
+	if e.mint {
+		err = addToDCTBalance(acntSnd, dctTokenKey, value, e.marshaller, e.globalSettingsHandler, e.getSystemAddresses(), vmInput.ReturnCallAfterError)
+	} else {
+		err = e.subFromDCTBalance(acntSnd, dctTokenKey, value, e.marshaller, e.globalSettingsHandler, e.getSystemAddresses(), vmInput.ReturnCallAfterError)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = systemAcc.AccountDataHandler().SaveKeyValue(proofKey, []byte{1})
+	if err != nil {
+		return nil, err
+	}
+	err = e.accounts.SaveAccount(systemAcc)
+	if err != nil {
+		return nil, err
+	}
+
+	vmOutput := &vmcommon.VMOutput{ReturnCode: vmcommon.Ok, GasRemaining: vmInput.GasProvided - e.funcGasCost}
+	addDCTEntryInVMOutput(vmOutput, []byte(e.function), tokenID, 0, value, vmInput.CallerAddr)
+
+	return vmOutput, nil
+}
+
+// computeProofKey returns the system account key marking the external chain operation as consumed, the mint and the
+// burn operations being kept apart
+func (e *dctBridge) computeProofKey(proofID []byte) []byte {
+	key := make([]byte, 0, len(bridgeProofKeyPrefix)+len(e.function)+len(proofID))
+	key = append(key, bridgeProofKeyPrefix...)
+	key = append(key, e.function...)
+
+	return append(key, proofID...)
+}
+
+// IsInterfaceNil returns true if underlying object in nil
+func (e *dctBridge) IsInterfaceNil() bool {
+	return e == nil
+}
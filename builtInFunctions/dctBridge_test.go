@@ -0,0 +1,333 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/Reshusk23/sr-me-core/core/check"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/Reshusk23/sr-vm-common-go/protectedkeys"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var bridgeTokenID = []byte("BRIDGE-abcdef")
+
+func createDCTBridgeInput(caller []byte, amount int64, proof []byte) *vmcommon.ContractCallInput {
+	return &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  caller,
+			CallValue:   big.NewInt(0),
+			Arguments:   [][]byte{bridgeTokenID, big.NewInt(amount).Bytes(), proof},
+			GasProvided: 100,
+		},
+		RecipientAddr: caller,
+	}
+}
+
+func createMockAccountsForBridge(systemAcc vmcommon.UserAccountHandler) *mock.AccountsStub {
+	return &mock.AccountsStub{
+		LoadAccountCalled: func(address []byte) (vmcommon.AccountHandler, error) {
+			return systemAcc, nil
+		},
+	}
+}
+
+func TestNewDCTBridgeFunc(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil accounts adapter should error", func(t *testing.T) {
+		t.Parallel()
+
+		e, err := NewDCTBridgeMintFunc(ArgsNewDCTBridge{
+			Config: Config{
+				Marshalizer:           &mock.MarshalizerMock{},
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			},
+			FuncGasCost:     10,
+			BridgeAddresses: nil,
+		})
+		assert.True(t, check.IfNil(e))
+		assert.ErrorIs(t, err, ErrNilAccountsAdapter)
+	})
+	t.Run("nil marshaller should error", func(t *testing.T) {
+		t.Parallel()
+
+		e, err := NewDCTBridgeMintFunc(ArgsNewDCTBridge{
+			Config: Config{
+				Accounts:              &mock.AccountsStub{},
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			},
+			FuncGasCost:     10,
+			BridgeAddresses: nil,
+		})
+		assert.True(t, check.IfNil(e))
+		assert.ErrorIs(t, err, ErrNilMarshalizer)
+	})
+	t.Run("nil global settings handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		e, err := NewDCTBridgeBurnFunc(ArgsNewDCTBridge{
+			Config: Config{
+				Accounts:            &mock.AccountsStub{},
+				Marshalizer:         &mock.MarshalizerMock{},
+				EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+			},
+			FuncGasCost:     10,
+			BridgeAddresses: nil,
+		})
+		assert.True(t, check.IfNil(e))
+		assert.ErrorIs(t, err, ErrNilGlobalSettingsHandler)
+	})
+	t.Run("nil enable epochs handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		e, err := NewDCTBridgeMintFunc(ArgsNewDCTBridge{
+			Config: Config{
+				Accounts:              &mock.AccountsStub{},
+				Marshalizer:           &mock.MarshalizerMock{},
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			},
+			FuncGasCost:     10,
+			BridgeAddresses: nil,
+		})
+		assert.True(t, check.IfNil(e))
+		assert.ErrorIs(t, err, ErrNilEnableEpochsHandler)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		enableEpochsHandler := &mock.EnableEpochsHandlerStub{}
+		e, err := NewDCTBridgeMintFunc(ArgsNewDCTBridge{
+			Config: Config{
+				Accounts:              &mock.AccountsStub{},
+				Marshalizer:           &mock.MarshalizerMock{},
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				EnableEpochsHandler:   enableEpochsHandler,
+			},
+			FuncGasCost:     10,
+			BridgeAddresses: nil,
+		})
+		assert.False(t, check.IfNil(e))
+		assert.Nil(t, err)
+		assert.False(t, e.IsActive())
+
+		enableEpochsHandler.IsDCTBridgeFlagEnabledField = true
+		assert.True(t, e.IsActive())
+	})
+}
+
+func TestDCTBridge_SetNewGasConfig(t *testing.T) {
+	t.Parallel()
+
+	mintFunc, _ := NewDCTBridgeMintFunc(ArgsNewDCTBridge{
+		Config: Config{
+			Accounts:              &mock.AccountsStub{},
+			Marshalizer:           &mock.MarshalizerMock{},
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+		},
+		FuncGasCost:     10,
+		BridgeAddresses: nil,
+	})
+	burnFunc, _ := NewDCTBridgeBurnFunc(ArgsNewDCTBridge{
+		Config: Config{
+			Accounts:              &mock.AccountsStub{},
+			Marshalizer:           &mock.MarshalizerMock{},
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+		},
+		FuncGasCost:     10,
+		BridgeAddresses: nil,
+	})
+
+	gasCost := &vmcommon.GasCost{BuiltInCost: vmcommon.BuiltInCost{DCTLocalMint: 20, DCTLocalBurn: 30}}
+	mintFunc.SetNewGasConfig(gasCost)
+	burnFunc.SetNewGasConfig(gasCost)
+	assert.Equal(t, uint64(20), mintFunc.funcGasCost)
+	assert.Equal(t, uint64(30), burnFunc.funcGasCost)
+}
+
+func TestDCTBridge_ProcessBuiltinFunctionErrors(t *testing.T) {
+	t.Parallel()
+
+	bridge := mock.NewUserAccount([]byte("bridge"))
+	accounts := createMockAccountsForBridge(mock.NewUserAccount(vmcommon.SystemAccountAddress))
+	e, _ := NewDCTBridgeMintFunc(ArgsNewDCTBridge{
+		Config: Config{
+			Accounts:              accounts,
+			Marshalizer:           &mock.MarshalizerMock{},
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+		},
+		FuncGasCost:     10,
+		BridgeAddresses: [][]byte{bridge.AddressBytes()},
+	})
+
+	_, err := e.ProcessBuiltinFunction(bridge, nil, nil)
+	assert.Equal(t, ErrNilVmInput, err)
+
+	vmInput := createDCTBridgeInput(bridge.AddressBytes(), 10, []byte("proof"))
+	vmInput.Arguments = vmInput.Arguments[:2]
+	_, err = e.ProcessBuiltinFunction(bridge, nil, vmInput)
+	assert.Equal(t, ErrInvalidArguments, err)
+
+	caller := mock.NewUserAccount([]byte("caller"))
+	_, err = e.ProcessBuiltinFunction(caller, nil, createDCTBridgeInput(caller.AddressBytes(), 10, []byte("proof")))
+	assert.Equal(t, ErrAddressIsNotBridge, err)
+
+	_, err = e.ProcessBuiltinFunction(bridge, nil, createDCTBridgeInput(bridge.AddressBytes(), 10, []byte("proof")))
+	assert.Equal(t, ErrProofVerifierNotSet, err)
+
+	err = e.SetProofVerifier(&mock.ProofVerifierStub{})
+	require.Nil(t, err)
+	_, err = e.ProcessBuiltinFunction(bridge, nil, createDCTBridgeInput(bridge.AddressBytes(), 0, []byte("proof")))
+	assert.Equal(t, ErrNegativeValue, err)
+
+	err = e.SetProofVerifier(nil)
+	assert.Equal(t, ErrNilProofVerifier, err)
+
+	expectedErr := errors.New("invalid proof")
+	err = e.SetProofVerifier(&mock.ProofVerifierStub{
+		VerifyProofCalled: func(operation string, tokenID []byte, amount *big.Int, address []byte, proof []byte) ([]byte, error) {
+			return nil, expectedErr
+		},
+	})
+	require.Nil(t, err)
+
+	_, err = e.ProcessBuiltinFunction(bridge, nil, createDCTBridgeInput(bridge.AddressBytes(), 10, []byte("proof")))
+	assert.Equal(t, expectedErr, err)
+}
+
+func TestDCTBridge_ProcessBuiltinFunctionShouldWork(t *testing.T) {
+	t.Parallel()
+
+	bridge := mock.NewUserAccount([]byte("bridge"))
+	marshaller := &mock.MarshalizerMock{}
+	accounts := createMockAccountsForBridge(mock.NewUserAccount(vmcommon.SystemAccountAddress))
+	mintFunc, _ := NewDCTBridgeMintFunc(ArgsNewDCTBridge{
+		Config: Config{
+			Accounts:              accounts,
+			Marshalizer:           marshaller,
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+		},
+		FuncGasCost:     10,
+		BridgeAddresses: [][]byte{bridge.AddressBytes()},
+	})
+	burnFunc, _ := NewDCTBridgeBurnFunc(ArgsNewDCTBridge{
+		Config: Config{
+			Accounts:              accounts,
+			Marshalizer:           marshaller,
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+		},
+		FuncGasCost:     10,
+		BridgeAddresses: [][]byte{bridge.AddressBytes()},
+	})
+
+	var verifiedOperations []string
+	proofVerifier := &mock.ProofVerifierStub{
+		VerifyProofCalled: func(operation string, tokenID []byte, amount *big.Int, address []byte, proof []byte) ([]byte, error) {
+			assert.Equal(t, bridgeTokenID, tokenID)
+			assert.Equal(t, bridge.AddressBytes(), address)
+			verifiedOperations = append(verifiedOperations, operation)
+			return proof, nil
+		},
+	}
+	_ = mintFunc.SetProofVerifier(proofVerifier)
+	_ = burnFunc.SetProofVerifier(proofVerifier)
+	dctTokenKey := append([]byte(baseDCTKeyPrefix), bridgeTokenID...)
+
+	vmOutput, err := mintFunc.ProcessBuiltinFunction(bridge, nil, createDCTBridgeInput(bridge.AddressBytes(), 60, []byte("proof")))
+	require.Nil(t, err)
+	assert.Equal(t, uint64(90), vmOutput.GasRemaining)
+	assert.Equal(t, big.NewInt(60), getDCTBalanceForTest(t, bridge, dctTokenKey, marshaller))
+	require.Equal(t, 1, len(vmOutput.Logs))
+	assert.Equal(t, []byte(vmcommon.BuiltInFunctionDCTBridgeMint), vmOutput.Logs[0].Identifier)
+	assert.Equal(t, [][]byte{bridgeTokenID, {}, big.NewInt(60).Bytes()}, vmOutput.Logs[0].Topics)
+
+	vmOutput, err = burnFunc.ProcessBuiltinFunction(bridge, nil, createDCTBridgeInput(bridge.AddressBytes(), 25, []byte("proof")))
+	require.Nil(t, err)
+	assert.Equal(t, big.NewInt(35), getDCTBalanceForTest(t, bridge, dctTokenKey, marshaller))
+	assert.Equal(t, []byte(vmcommon.BuiltInFunctionDCTBridgeBurn), vmOutput.Logs[0].Identifier)
+
+	_, err = burnFunc.ProcessBuiltinFunction(bridge, nil, createDCTBridgeInput(bridge.AddressBytes(), 36, []byte("proof2")))
+	assert.Equal(t, ErrInsufficientFunds, err)
+
+	expectedOperations := []string{vmcommon.BuiltInFunctionDCTBridgeMint, vmcommon.BuiltInFunctionDCTBridgeBurn, vmcommon.BuiltInFunctionDCTBridgeBurn}
+	assert.Equal(t, expectedOperations, verifiedOperations)
+}
+
+func TestDCTBridge_ProcessBuiltinFunctionLockedBalanceShouldErr(t *testing.T) {
+	t.Parallel()
+
+	bridge := mock.NewUserAccount([]byte("bridge"))
+	marshaller := &mock.MarshalizerMock{}
+	burnFunc, _ := NewDCTBridgeBurnFunc(ArgsNewDCTBridge{
+		Config: Config{
+			Accounts:              createMockAccountsForBridge(mock.NewUserAccount(vmcommon.SystemAccountAddress)),
+			Marshalizer:           marshaller,
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+		},
+		FuncGasCost:     10,
+		BridgeAddresses: [][]byte{bridge.AddressBytes()},
+	})
+	_ = burnFunc.SetProofVerifier(&mock.ProofVerifierStub{
+		VerifyProofCalled: func(operation string, tokenID []byte, amount *big.Int, address []byte, proof []byte) ([]byte, error) {
+			return proof, nil
+		},
+	})
+	burnFunc.enableEpochsHandler.(*mock.EnableEpochsHandlerStub).CurrentEpochField = 5
+	dctTokenKey := append([]byte(baseDCTKeyPrefix), bridgeTokenID...)
+	err := addToDCTBalance(bridge, dctTokenKey, big.NewInt(30), marshaller, &mock.GlobalSettingsHandlerStub{}, vmcommon.DefaultSystemAddresses(), false)
+	require.Nil(t, err)
+	lockDCTBalanceForTest(t, bridge, dctTokenKey, marshaller, 20, 10)
+
+	_, err = burnFunc.ProcessBuiltinFunction(bridge, nil, createDCTBridgeInput(bridge.AddressBytes(), 15, []byte("proof")))
+	assert.Equal(t, ErrDCTBalanceIsLocked, err)
+
+	_, err = burnFunc.ProcessBuiltinFunction(bridge, nil, createDCTBridgeInput(bridge.AddressBytes(), 10, []byte("proof2")))
+	require.Nil(t, err)
+	assert.Equal(t, big.NewInt(20), getDCTBalanceForTest(t, bridge, dctTokenKey, marshaller))
+}
+
+func TestDCTBridge_ProcessBuiltinFunctionReusedProofShouldErr(t *testing.T) {
+	t.Parallel()
+
+	bridge := mock.NewUserAccount([]byte("bridge"))
+	marshaller := &mock.MarshalizerMock{}
+	systemAcc := mock.NewUserAccount(vmcommon.SystemAccountAddress)
+	mintFunc, _ := NewDCTBridgeMintFunc(ArgsNewDCTBridge{
+		Config: Config{
+			Accounts:              createMockAccountsForBridge(systemAcc),
+			Marshalizer:           marshaller,
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+		},
+		FuncGasCost:     10,
+		BridgeAddresses: [][]byte{bridge.AddressBytes()},
+	})
+	_ = mintFunc.SetProofVerifier(&mock.ProofVerifierStub{
+		VerifyProofCalled: func(operation string, tokenID []byte, amount *big.Int, address []byte, proof []byte) ([]byte, error) {
+			return []byte("deposit nonce 7"), nil
+		},
+	})
+	dctTokenKey := append([]byte(baseDCTKeyPrefix), bridgeTokenID...)
+
+	_, err := mintFunc.ProcessBuiltinFunction(bridge, nil, createDCTBridgeInput(bridge.AddressBytes(), 60, []byte("proof")))
+	require.Nil(t, err)
+	proofKey := mintFunc.computeProofKey([]byte("deposit nonce 7"))
+	assert.True(t, bytes.HasPrefix(proofKey, []byte(protectedkeys.BridgeProofPrefix)))
+	assert.NotEmpty(t, systemAcc.Storage[string(proofKey)])
+
+	_, err = mintFunc.ProcessBuiltinFunction(bridge, nil, createDCTBridgeInput(bridge.AddressBytes(), 60, []byte("another encoding of the proof")))
+	assert.True(t, errors.Is(err, ErrBridgeProofAlreadyConsumed))
+	assert.Equal(t, big.NewInt(60), getDCTBalanceForTest(t, bridge, dctTokenKey, marshaller))
+}
@@ -1,7 +1,6 @@
 package builtInFunctions
 
 import (
-	"bytes"
 	"math/big"
 	"sync"
 
@@ -12,6 +11,8 @@ import (
 
 type dctBurn struct {
 	baseActiveHandler
+	freezeAccountChecker
+	lockedBalanceChecker
 	funcGasCost           uint64
 	marshaller            vmcommon.Marshalizer
 	keyPrefix             []byte
@@ -19,31 +20,28 @@ type dctBurn struct {
 	mutExecution          sync.RWMutex
 }
 
+// ArgsNewDCTBurn defines the argument list for new dct burn built in function
+type ArgsNewDCTBurn struct {
+	Config
+	FuncGasCost uint64
+}
+
 // NewDCTBurnFunc returns the dct burn built-in function component
-func NewDCTBurnFunc(
-	funcGasCost uint64,
-	marshaller vmcommon.Marshalizer,
-	globalSettingsHandler vmcommon.DCTGlobalSettingsHandler,
-	enableEpochsHandler vmcommon.EnableEpochsHandler,
-) (*dctBurn, error) {
-	if check.IfNil(marshaller) {
-		return nil, ErrNilMarshalizer
-	}
-	if check.IfNil(globalSettingsHandler) {
-		return nil, ErrNilGlobalSettingsHandler
-	}
-	if check.IfNil(enableEpochsHandler) {
-		return nil, ErrNilEnableEpochsHandler
+func NewDCTBurnFunc(args ArgsNewDCTBurn) (*dctBurn, error) {
+	err := args.checkComponents(core.BuiltInFunctionDCTBurn, requireMarshalizer|requireGlobalSettingsHandler|requireEnableEpochsHandler)
+	if err != nil {
+		return nil, err
 	}
 
 	e := &dctBurn{
-		funcGasCost:           funcGasCost,
-		marshaller:            marshaller,
+		lockedBalanceChecker:  lockedBalanceChecker{enableEpochsHandler: args.EnableEpochsHandler},
+		funcGasCost:           args.FuncGasCost,
+		marshaller:            args.Marshalizer,
 		keyPrefix:             []byte(baseDCTKeyPrefix),
-		globalSettingsHandler: globalSettingsHandler,
+		globalSettingsHandler: args.GlobalSettingsHandler,
 	}
 
-	e.baseActiveHandler.activeHandler = enableEpochsHandler.IsGlobalMintBurnFlagEnabled
+	e.baseActiveHandler.activeHandler = args.EnableEpochsHandler.IsGlobalMintBurnFlagEnabled
 
 	return e, nil
 }
@@ -78,12 +76,16 @@ func (e *dctBurn) ProcessBuiltinFunction(
 	if value.Cmp(zero) <= 0 {
 		return nil, ErrNegativeValue
 	}
-	if !bytes.Equal(vmInput.RecipientAddr, core.DCTSCAddress) {
+	if !e.getSystemAddresses().IsDCTSCAddress(vmInput.RecipientAddr) {
 		return nil, ErrAddressIsNotDCTSystemSC
 	}
 	if check.IfNil(acntSnd) {
 		return nil, ErrNilUserAccount
 	}
+	err = e.checkAccountIsNotFrozen(vmInput.CallerAddr, vmInput.ReturnCallAfterError)
+	if err != nil {
+		return nil, err
+	}
 
 	dctTokenKey := append(e.keyPrefix, vmInput.Arguments[0]...)
 
@@ -91,7 +93,7 @@ func (e *dctBurn) ProcessBuiltinFunction(
 		return nil, ErrNotEnoughGas
 	}
 
-	err = addToDCTBalance(acntSnd, dctTokenKey, big.NewInt(0).Neg(value), e.marshaller, e.globalSettingsHandler, vmInput.ReturnCallAfterError)
+	err = e.subFromDCTBalance(acntSnd, dctTokenKey, value, e.marshaller, e.globalSettingsHandler, e.getSystemAddresses(), vmInput.ReturnCallAfterError)
 	if err != nil {
 		return nil, err
 	}
@@ -100,12 +102,12 @@ func (e *dctBurn) ProcessBuiltinFunction(
 	vmOutput := &vmcommon.VMOutput{GasRemaining: gasRemaining, ReturnCode: vmcommon.Ok}
 	if vmcommon.IsSmartContractAddress(vmInput.CallerAddr) {
 		addOutputTransferToVMOutput(
-			vmInput.CallerAddr,
+			vmInput,
 			core.BuiltInFunctionDCTBurn,
 			vmInput.Arguments,
 			vmInput.RecipientAddr,
+			zero,
 			vmInput.GasLocked,
-			vmInput.CallType,
 			vmOutput)
 	}
 
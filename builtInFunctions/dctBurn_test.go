@@ -18,24 +18,43 @@ func TestNewDCTBurnFunc(t *testing.T) {
 	t.Run("nil marshaller should error", func(t *testing.T) {
 		t.Parallel()
 
-		burnFunc, err := NewDCTBurnFunc(10, nil, &mock.GlobalSettingsHandlerStub{}, &mock.EnableEpochsHandlerStub{
-			IsGlobalMintBurnFlagEnabledField: true,
+		burnFunc, err := NewDCTBurnFunc(ArgsNewDCTBurn{
+			Config: Config{
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+					IsGlobalMintBurnFlagEnabledField: true,
+				},
+			},
+			FuncGasCost: 10,
 		})
-		assert.Equal(t, ErrNilMarshalizer, err)
+		assert.ErrorIs(t, err, ErrNilMarshalizer)
 		assert.True(t, check.IfNil(burnFunc))
 	})
 	t.Run("nil enable epochs handler should error", func(t *testing.T) {
 		t.Parallel()
 
-		burnFunc, err := NewDCTBurnFunc(10, &mock.MarshalizerMock{}, &mock.GlobalSettingsHandlerStub{}, nil)
-		assert.Equal(t, ErrNilEnableEpochsHandler, err)
+		burnFunc, err := NewDCTBurnFunc(ArgsNewDCTBurn{
+			Config: Config{
+				Marshalizer:           &mock.MarshalizerMock{},
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			},
+			FuncGasCost: 10,
+		})
+		assert.ErrorIs(t, err, ErrNilEnableEpochsHandler)
 		assert.True(t, check.IfNil(burnFunc))
 	})
 	t.Run("should work", func(t *testing.T) {
 		t.Parallel()
 
-		burnFunc, err := NewDCTBurnFunc(10, &mock.MarshalizerMock{}, &mock.GlobalSettingsHandlerStub{}, &mock.EnableEpochsHandlerStub{
-			IsGlobalMintBurnFlagEnabledField: true,
+		burnFunc, err := NewDCTBurnFunc(ArgsNewDCTBurn{
+			Config: Config{
+				Marshalizer:           &mock.MarshalizerMock{},
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+					IsGlobalMintBurnFlagEnabledField: true,
+				},
+			},
+			FuncGasCost: 10,
 		})
 		assert.Nil(t, err)
 		assert.False(t, check.IfNil(burnFunc))
@@ -46,8 +65,15 @@ func TestDCTBurn_ProcessBuiltInFunctionErrors(t *testing.T) {
 	t.Parallel()
 
 	globalSettingsHandler := &mock.GlobalSettingsHandlerStub{}
-	burnFunc, _ := NewDCTBurnFunc(10, &mock.MarshalizerMock{}, globalSettingsHandler, &mock.EnableEpochsHandlerStub{
-		IsGlobalMintBurnFlagEnabledField: true,
+	burnFunc, _ := NewDCTBurnFunc(ArgsNewDCTBurn{
+		Config: Config{
+			Marshalizer:           &mock.MarshalizerMock{},
+			GlobalSettingsHandler: globalSettingsHandler,
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+				IsGlobalMintBurnFlagEnabledField: true,
+			},
+		},
+		FuncGasCost: 10,
 	})
 	_, err := burnFunc.ProcessBuiltinFunction(nil, nil, nil)
 	assert.Equal(t, err, ErrNilVmInput)
@@ -94,8 +120,15 @@ func TestDCTBurn_ProcessBuiltInFunctionSenderBurns(t *testing.T) {
 
 	marshaller := &mock.MarshalizerMock{}
 	globalSettingsHandler := &mock.GlobalSettingsHandlerStub{}
-	burnFunc, _ := NewDCTBurnFunc(10, marshaller, globalSettingsHandler, &mock.EnableEpochsHandlerStub{
-		IsGlobalMintBurnFlagEnabledField: true,
+	burnFunc, _ := NewDCTBurnFunc(ArgsNewDCTBurn{
+		Config: Config{
+			Marshalizer:           marshaller,
+			GlobalSettingsHandler: globalSettingsHandler,
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+				IsGlobalMintBurnFlagEnabledField: true,
+			},
+		},
+		FuncGasCost: 10,
 	})
 
 	input := &vmcommon.ContractCallInput{
@@ -0,0 +1,306 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/Reshusk23/sr-me-core/core/check"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/protectedkeys"
+)
+
+const numArgumentsSetCollectionConfig = 4
+
+var collectionConfigKeyPrefix = []byte(protectedkeys.CollectionConfigPrefix)
+
+type dctCollectionConfig struct {
+	baseActiveHandler
+	baseSystemAddressesHandler
+	set                  bool
+	accounts             vmcommon.AccountsAdapter
+	enableEpochsHandler  vmcommon.EnableEpochsHandler
+	royaltiesDenominator uint32
+}
+
+// ArgsNewDCTCollectionConfig defines the argument list for new dct set/unset collection config built in functions
+type ArgsNewDCTCollectionConfig struct {
+	Config
+	Set bool
+}
+
+// NewDCTCollectionConfigFunc returns the dct set/unset collection config built-in function component
+func NewDCTCollectionConfigFunc(args ArgsNewDCTCollectionConfig) (*dctCollectionConfig, error) {
+	function := vmcommon.BuiltInFunctionDCTUnSetCollectionConfig
+	if args.Set {
+		function = vmcommon.BuiltInFunctionDCTSetCollectionConfig
+	}
+	err := args.checkComponents(function, requireAccounts|requireEnableEpochsHandler)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &dctCollectionConfig{
+		set:                 args.Set,
+		accounts:            args.Accounts,
+		enableEpochsHandler: args.EnableEpochsHandler,
+	}
+
+	e.baseActiveHandler.activeHandler = args.EnableEpochsHandler.IsCollectionConfigFlagEnabled
+
+	return e, nil
+}
+
+// SetRoyaltiesDenominator sets the royalties denominator recorded by the set function in the config of the collections
+// configured for the first time. The default denominator is never recorded, so the collection configs stay unchanged
+// on the chains keeping it
+func (e *dctCollectionConfig) SetRoyaltiesDenominator(royaltiesDenominator uint32) error {
+	err := checkRoyaltiesDenominator(royaltiesDenominator)
+	if err != nil {
+		return err
+	}
+
+	e.royaltiesDenominator = royaltiesDenominator
+	return nil
+}
+
+// SetNewGasConfig is called whenever gas cost is changed
+func (e *dctCollectionConfig) SetNewGasConfig(_ *vmcommon.GasCost) {
+}
+
+// ProcessBuiltinFunction resolves DCT set/unset collection config function call
+// Set requires 4 arguments:
+// arg0 - collection identifier
+// arg1 - max number of URIs, 0 meaning unlimited
+// arg2 - max attributes length, 0 meaning unlimited
+// arg3 - allow add quantity, any non-zero value meaning allowed
+// Once the content hash is enabled, an optional arg4 - require content hash, any non-zero value meaning the hash of
+// the created tokens must be the hash of their attributes
+// Once the nonce ranges are enabled, pairs of (first nonce - last nonce) reserved ranges may follow
+// Unset requires only the collection identifier
+func (e *dctCollectionConfig) ProcessBuiltinFunction(
+	_, _ vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	if vmInput == nil {
+		return nil, ErrNilVmInput
+	}
+	err := checkCallValue(vmcommon.CallValueForbidden, vmInput.CallValue)
+	if err != nil {
+		return nil, err
+	}
+	if !e.getSystemAddresses().IsDCTSCAddress(vmInput.CallerAddr) {
+		return nil, ErrAddressIsNotDCTSystemSC
+	}
+	if !e.getSystemAddresses().IsSystemAccountAddress(vmInput.RecipientAddr) {
+		return nil, ErrOnlySystemAccountAccepted
+	}
+
+	config, err := e.createConfig(vmInput.Arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	systemAcc, err := getSystemAccount(e.accounts, e.getSystemAddresses().SystemAccountAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	// the meta dct type and the royalties denominator of the collection are not part of the limits set by the owner, so
+	// they are kept. The denominator is recorded only for the collections configured for the first time and applies to
+	// the tokens created afterwards, each created token recording the denominator its royalties are relative to
+	key := append(collectionConfigKeyPrefix, vmInput.Arguments[0]...)
+	currentConfigBytes, _, _ := systemAcc.AccountDataHandler().RetrieveValue(key)
+	currentConfig := vmcommon.CollectionConfigFromBytes(currentConfigBytes)
+	config.IsMetaDCT = currentConfig.IsMetaDCT
+	config.NumDecimals = currentConfig.NumDecimals
+	config.RoyaltiesDenominator = currentConfig.RoyaltiesDenominator
+	if e.set && len(currentConfigBytes) == 0 && e.royaltiesDenominator != vmcommon.DefaultRoyaltiesDenominator {
+		config.RoyaltiesDenominator = e.royaltiesDenominator
+	}
+	var configBytes []byte
+	if e.set || config.IsMetaDCT || config.RoyaltiesDenominator != 0 {
+		configBytes = config.ToBytes()
+	}
+
+	err = systemAcc.AccountDataHandler().SaveKeyValue(key, configBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	err = e.accounts.SaveAccount(systemAcc)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vmcommon.VMOutput{ReturnCode: vmcommon.Ok}, nil
+}
+
+func (e *dctCollectionConfig) createConfig(arguments [][]byte) (*vmcommon.CollectionConfig, error) {
+	if !e.set {
+		if len(arguments) != 1 {
+			return nil, ErrInvalidArguments
+		}
+
+		return &vmcommon.CollectionConfig{}, nil
+	}
+
+	contentHashRequired, nonceRangesStartIndex, err := e.getContentHashRequired(arguments)
+	if err != nil {
+		return nil, err
+	}
+	reservedNonceRanges, err := e.createReservedNonceRanges(arguments, nonceRangesStartIndex)
+	if err != nil {
+		return nil, err
+	}
+	maxNumURIs, err := uint32Argument(arguments, 1)
+	if err != nil {
+		return nil, err
+	}
+	maxAttributesLength, err := uint32Argument(arguments, 2)
+	if err != nil {
+		return nil, err
+	}
+	addQuantity, err := uint64Argument(arguments, 3)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vmcommon.CollectionConfig{
+		MaxNumURIs:          maxNumURIs,
+		MaxAttributesLength: maxAttributesLength,
+		AddQuantityDisabled: addQuantity == 0,
+		ContentHashRequired: contentHashRequired,
+		ReservedNonceRanges: reservedNonceRanges,
+	}, nil
+}
+
+// getContentHashRequired returns the optional content hash requirement, which is present when the arguments
+// following the fixed ones can not be only nonce ranges, and the index of the first nonce range argument
+func (e *dctCollectionConfig) getContentHashRequired(arguments [][]byte) (bool, int, error) {
+	hasContentHashArgument := len(arguments) > numArgumentsSetCollectionConfig && (len(arguments)-numArgumentsSetCollectionConfig)%2 == 1
+	if !hasContentHashArgument || !e.enableEpochsHandler.IsNFTContentHashFlagEnabled() {
+		return false, numArgumentsSetCollectionConfig, nil
+	}
+
+	contentHashRequired, err := uint64Argument(arguments, numArgumentsSetCollectionConfig)
+	if err != nil {
+		return false, 0, err
+	}
+
+	return contentHashRequired != 0, numArgumentsSetCollectionConfig + 1, nil
+}
+
+func (e *dctCollectionConfig) createReservedNonceRanges(arguments [][]byte, startIndex int) ([]vmcommon.NonceRange, error) {
+	if len(arguments) == startIndex {
+		return nil, nil
+	}
+	hasNonceRanges := len(arguments) > startIndex && (len(arguments)-startIndex)%2 == 0
+	if !hasNonceRanges || !e.enableEpochsHandler.IsNFTNonceRangesFlagEnabled() {
+		return nil, ErrInvalidArguments
+	}
+
+	reservedNonceRanges := make([]vmcommon.NonceRange, 0, (len(arguments)-startIndex)/2)
+	for i := startIndex; i < len(arguments); i += 2 {
+		start, err := bytesToNonce(arguments[i])
+		if err != nil {
+			return nil, err
+		}
+		end, err := bytesToNonce(arguments[i+1])
+		if err != nil {
+			return nil, err
+		}
+		if start == 0 || start > end {
+			return nil, fmt.Errorf("%w, invalid nonce range", ErrInvalidArguments)
+		}
+
+		reservedNonceRanges = append(reservedNonceRanges, vmcommon.NonceRange{Start: start, End: end})
+	}
+
+	return reservedNonceRanges, nil
+}
+
+// IsInterfaceNil returns true if underlying object in nil
+func (e *dctCollectionConfig) IsInterfaceNil() bool {
+	return e == nil
+}
+
+func getSystemAccount(accounts vmcommon.AccountsAdapter, systemAccountAddress []byte) (vmcommon.UserAccountHandler, error) {
+	systemSCAccount, err := accounts.LoadAccount(systemAccountAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	userAcc, ok := systemSCAccount.(vmcommon.UserAccountHandler)
+	if !ok {
+		return nil, ErrWrongTypeAssertion
+	}
+
+	return userAcc, nil
+}
+
+func getCollectionConfig(systemAcc vmcommon.UserAccountHandler, tokenID []byte) vmcommon.CollectionConfig {
+	key := append(collectionConfigKeyPrefix, tokenID...)
+	val, _, _ := systemAcc.AccountDataHandler().RetrieveValue(key)
+	return vmcommon.CollectionConfigFromBytes(val)
+}
+
+func checkRoyaltiesDenominator(royaltiesDenominator uint32) error {
+	if royaltiesDenominator == 0 || royaltiesDenominator%vmcommon.DefaultRoyaltiesDenominator != 0 {
+		return ErrInvalidRoyaltiesDenominator
+	}
+
+	return nil
+}
+
+func getRoyaltiesDenominator(globalSettingsHandler vmcommon.DCTGlobalSettingsHandler, tokenID []byte) uint32 {
+	config := globalSettingsHandler.GetCollectionConfig(tokenID)
+	return config.GetRoyaltiesDenominator()
+}
+
+func checkCollectionURIs(globalSettingsHandler vmcommon.DCTGlobalSettingsHandler, tokenID []byte, numURIs int) error {
+	config := globalSettingsHandler.GetCollectionConfig(tokenID)
+	if !config.IsNumURIsAllowed(numURIs) {
+		return ErrTooManyURIs
+	}
+
+	return nil
+}
+
+func checkCollectionAttributes(globalSettingsHandler vmcommon.DCTGlobalSettingsHandler, tokenID []byte, attributes []byte) error {
+	config := globalSettingsHandler.GetCollectionConfig(tokenID)
+	if !config.IsAttributesLengthAllowed(len(attributes)) {
+		return ErrAttributesTooLong
+	}
+
+	return nil
+}
+
+func checkCollectionContentHash(
+	globalSettingsHandler vmcommon.DCTGlobalSettingsHandler,
+	hasher vmcommon.Hasher,
+	tokenID []byte,
+	hash []byte,
+	attributes []byte,
+) error {
+	config := globalSettingsHandler.GetCollectionConfig(tokenID)
+	if !config.ContentHashRequired {
+		return nil
+	}
+	if check.IfNil(hasher) {
+		return ErrHasherNotSet
+	}
+	if !bytes.Equal(hasher.Compute(string(attributes)), hash) {
+		return ErrInvalidContentHash
+	}
+
+	return nil
+}
+
+func checkCollectionAddQuantity(globalSettingsHandler vmcommon.DCTGlobalSettingsHandler, tokenID []byte) error {
+	config := globalSettingsHandler.GetCollectionConfig(tokenID)
+	if config.AddQuantityDisabled {
+		return ErrAddQuantityNotAllowed
+	}
+
+	return nil
+}
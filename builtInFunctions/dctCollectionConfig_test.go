@@ -0,0 +1,377 @@
+package builtInFunctions
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Reshusk23/sr-me-core/core"
+	"github.com/Reshusk23/sr-me-core/core/check"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createCollectionConfigInput(arguments ...[]byte) *vmcommon.ContractCallInput {
+	return &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr: core.DCTSCAddress,
+			CallValue:  big.NewInt(0),
+			Arguments:  arguments,
+		},
+		RecipientAddr: vmcommon.SystemAccountAddress,
+	}
+}
+
+func TestNewDCTCollectionConfigFunc(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil accounts should error", func(t *testing.T) {
+		t.Parallel()
+
+		e, err := NewDCTCollectionConfigFunc(ArgsNewDCTCollectionConfig{
+			Config: Config{
+				EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+			},
+			Set: true,
+		})
+		assert.True(t, check.IfNil(e))
+		assert.ErrorIs(t, err, ErrNilAccountsAdapter)
+	})
+	t.Run("nil enable epochs handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		e, err := NewDCTCollectionConfigFunc(ArgsNewDCTCollectionConfig{
+			Config: Config{
+				Accounts: &mock.AccountsStub{},
+			},
+			Set: true,
+		})
+		assert.True(t, check.IfNil(e))
+		assert.ErrorIs(t, err, ErrNilEnableEpochsHandler)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		enableEpochsHandler := &mock.EnableEpochsHandlerStub{}
+		e, err := NewDCTCollectionConfigFunc(ArgsNewDCTCollectionConfig{
+			Config: Config{
+				Accounts:            &mock.AccountsStub{},
+				EnableEpochsHandler: enableEpochsHandler,
+			},
+			Set: true,
+		})
+		assert.False(t, check.IfNil(e))
+		assert.Nil(t, err)
+		assert.False(t, e.IsActive())
+
+		enableEpochsHandler.IsCollectionConfigFlagEnabledField = true
+		assert.True(t, e.IsActive())
+	})
+}
+
+func TestDCTCollectionConfig_ProcessBuiltinFunctionErrors(t *testing.T) {
+	t.Parallel()
+
+	e, _ := NewDCTCollectionConfigFunc(ArgsNewDCTCollectionConfig{
+		Config: Config{
+			Accounts:            &mock.AccountsStub{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+		},
+		Set: true,
+	})
+
+	_, err := e.ProcessBuiltinFunction(nil, nil, nil)
+	assert.Equal(t, ErrNilVmInput, err)
+
+	vmInput := createCollectionConfigInput([]byte("COL-abcdef"), []byte{2}, []byte{10}, []byte{1})
+	vmInput.CallValue = big.NewInt(1)
+	_, err = e.ProcessBuiltinFunction(nil, nil, vmInput)
+	assert.Equal(t, ErrBuiltInFunctionCalledWithValue, err)
+
+	vmInput = createCollectionConfigInput([]byte("COL-abcdef"), []byte{2}, []byte{10}, []byte{1})
+	vmInput.CallerAddr = []byte("caller")
+	_, err = e.ProcessBuiltinFunction(nil, nil, vmInput)
+	assert.Equal(t, ErrAddressIsNotDCTSystemSC, err)
+
+	vmInput = createCollectionConfigInput([]byte("COL-abcdef"), []byte{2}, []byte{10}, []byte{1})
+	vmInput.RecipientAddr = []byte("recipient")
+	_, err = e.ProcessBuiltinFunction(nil, nil, vmInput)
+	assert.Equal(t, ErrOnlySystemAccountAccepted, err)
+
+	vmInput = createCollectionConfigInput([]byte("COL-abcdef"), []byte{2})
+	_, err = e.ProcessBuiltinFunction(nil, nil, vmInput)
+	assert.Equal(t, ErrInvalidArguments, err)
+
+	vmInput = createCollectionConfigInput([]byte("COL-abcdef"), big.NewInt(0).Lsh(big.NewInt(1), 32).Bytes(), []byte{10}, []byte{1})
+	_, err = e.ProcessBuiltinFunction(nil, nil, vmInput)
+	assert.ErrorIs(t, err, ErrInvalidArguments)
+	assert.ErrorIs(t, err, vmcommon.ErrValueOutOfRange)
+
+	unsetFunc, _ := NewDCTCollectionConfigFunc(ArgsNewDCTCollectionConfig{
+		Config: Config{
+			Accounts:            &mock.AccountsStub{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+		},
+		Set: false,
+	})
+	vmInput = createCollectionConfigInput([]byte("COL-abcdef"), []byte{2})
+	_, err = unsetFunc.ProcessBuiltinFunction(nil, nil, vmInput)
+	assert.Equal(t, ErrInvalidArguments, err)
+}
+
+func TestDCTCollectionConfig_ProcessBuiltinFunctionSetAndUnset(t *testing.T) {
+	t.Parallel()
+
+	systemAcc := mock.NewUserAccount(vmcommon.SystemAccountAddress)
+	accounts := &mock.AccountsStub{
+		LoadAccountCalled: func(address []byte) (vmcommon.AccountHandler, error) {
+			return systemAcc, nil
+		},
+		SaveAccountCalled: func(account vmcommon.AccountHandler) error {
+			return nil
+		},
+	}
+	globalSettings, _ := NewDCTGlobalSettingsFunc(ArgsNewDCTGlobalSettings{
+		Config: Config{
+			Accounts:            accounts,
+			Marshalizer:         &mock.MarshalizerMock{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+		},
+		Set:           true,
+		Function:      core.BuiltInFunctionDCTPause,
+		ActiveHandler: trueHandler,
+	})
+	setFunc, _ := NewDCTCollectionConfigFunc(ArgsNewDCTCollectionConfig{
+		Config: Config{
+			Accounts:            accounts,
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+		},
+		Set: true,
+	})
+	unsetFunc, _ := NewDCTCollectionConfigFunc(ArgsNewDCTCollectionConfig{
+		Config: Config{
+			Accounts:            accounts,
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+		},
+		Set: false,
+	})
+
+	tokenID := []byte("COL-abcdef")
+	vmOutput, err := setFunc.ProcessBuiltinFunction(nil, nil, createCollectionConfigInput(tokenID, []byte{2}, []byte{10}, []byte{}))
+	assert.Nil(t, err)
+	assert.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+
+	expectedConfig := vmcommon.CollectionConfig{
+		MaxNumURIs:          2,
+		MaxAttributesLength: 10,
+		AddQuantityDisabled: true,
+	}
+	assert.Equal(t, expectedConfig, globalSettings.GetCollectionConfig(tokenID))
+	assert.Equal(t, vmcommon.CollectionConfig{}, globalSettings.GetCollectionConfig([]byte("OTHER-abcdef")))
+
+	_, err = unsetFunc.ProcessBuiltinFunction(nil, nil, createCollectionConfigInput(tokenID))
+	assert.Nil(t, err)
+	assert.Equal(t, vmcommon.CollectionConfig{}, globalSettings.GetCollectionConfig(tokenID))
+}
+
+func TestDCTCollectionConfig_ProcessBuiltinFunctionRoyaltiesDenominator(t *testing.T) {
+	t.Parallel()
+
+	systemAcc := mock.NewUserAccount(vmcommon.SystemAccountAddress)
+	accounts := &mock.AccountsStub{
+		LoadAccountCalled: func(address []byte) (vmcommon.AccountHandler, error) {
+			return systemAcc, nil
+		},
+		SaveAccountCalled: func(account vmcommon.AccountHandler) error {
+			return nil
+		},
+	}
+	globalSettings, _ := NewDCTGlobalSettingsFunc(ArgsNewDCTGlobalSettings{
+		Config: Config{
+			Accounts:            accounts,
+			Marshalizer:         &mock.MarshalizerMock{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+		},
+		Set:           true,
+		Function:      core.BuiltInFunctionDCTPause,
+		ActiveHandler: trueHandler,
+	})
+	setFunc, _ := NewDCTCollectionConfigFunc(ArgsNewDCTCollectionConfig{
+		Config: Config{
+			Accounts:            accounts,
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+		},
+		Set: true,
+	})
+	unsetFunc, _ := NewDCTCollectionConfigFunc(ArgsNewDCTCollectionConfig{
+		Config: Config{
+			Accounts:            accounts,
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+		},
+		Set: false,
+	})
+
+	assert.Equal(t, ErrInvalidRoyaltiesDenominator, setFunc.SetRoyaltiesDenominator(0))
+	assert.Equal(t, ErrInvalidRoyaltiesDenominator, setFunc.SetRoyaltiesDenominator(15000))
+
+	tokenID := []byte("COL-abcdef")
+	_, err := setFunc.ProcessBuiltinFunction(nil, nil, createCollectionConfigInput(tokenID, []byte{2}, []byte{10}, []byte{}))
+	require.Nil(t, err)
+	assert.Equal(t, uint32(0), globalSettings.GetCollectionConfig(tokenID).RoyaltiesDenominator)
+
+	require.Nil(t, setFunc.SetRoyaltiesDenominator(1000000))
+	_, err = setFunc.ProcessBuiltinFunction(nil, nil, createCollectionConfigInput(tokenID, []byte{3}, []byte{10}, []byte{}))
+	require.Nil(t, err)
+	config := globalSettings.GetCollectionConfig(tokenID)
+	assert.Equal(t, vmcommon.DefaultRoyaltiesDenominator, config.GetRoyaltiesDenominator(), "the denominator of the existing collections should not be migrated")
+
+	newTokenID := []byte("NEW-abcdef")
+	_, err = setFunc.ProcessBuiltinFunction(nil, nil, createCollectionConfigInput(newTokenID, []byte{2}, []byte{10}, []byte{}))
+	require.Nil(t, err)
+	assert.Equal(t, uint32(1000000), globalSettings.GetCollectionConfig(newTokenID).RoyaltiesDenominator)
+
+	require.Nil(t, setFunc.SetRoyaltiesDenominator(vmcommon.DefaultRoyaltiesDenominator))
+	_, err = setFunc.ProcessBuiltinFunction(nil, nil, createCollectionConfigInput(newTokenID, []byte{5}, []byte{10}, []byte{}))
+	require.Nil(t, err)
+	assert.Equal(t, uint32(1000000), globalSettings.GetCollectionConfig(newTokenID).RoyaltiesDenominator)
+
+	_, err = unsetFunc.ProcessBuiltinFunction(nil, nil, createCollectionConfigInput(newTokenID))
+	require.Nil(t, err)
+	assert.Equal(t, vmcommon.CollectionConfig{RoyaltiesDenominator: 1000000}, globalSettings.GetCollectionConfig(newTokenID))
+}
+
+func TestDCTCollectionConfig_ProcessBuiltinFunctionReservedNonceRanges(t *testing.T) {
+	t.Parallel()
+
+	systemAcc := mock.NewUserAccount(vmcommon.SystemAccountAddress)
+	accounts := &mock.AccountsStub{
+		LoadAccountCalled: func(address []byte) (vmcommon.AccountHandler, error) {
+			return systemAcc, nil
+		},
+	}
+	globalSettings, _ := NewDCTGlobalSettingsFunc(ArgsNewDCTGlobalSettings{
+		Config: Config{
+			Accounts:            accounts,
+			Marshalizer:         &mock.MarshalizerMock{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+		},
+		Set:           true,
+		Function:      core.BuiltInFunctionDCTPause,
+		ActiveHandler: trueHandler,
+	})
+	enableEpochsHandler := &mock.EnableEpochsHandlerStub{}
+	setFunc, _ := NewDCTCollectionConfigFunc(ArgsNewDCTCollectionConfig{
+		Config: Config{
+			Accounts:            accounts,
+			EnableEpochsHandler: enableEpochsHandler,
+		},
+		Set: true,
+	})
+
+	tokenID := []byte("COL-abcdef")
+	vmInput := createCollectionConfigInput(tokenID, []byte{2}, []byte{10}, []byte{1}, []byte{10}, []byte{20})
+	_, err := setFunc.ProcessBuiltinFunction(nil, nil, vmInput)
+	assert.Equal(t, ErrInvalidArguments, err)
+
+	enableEpochsHandler.IsNFTNonceRangesFlagEnabledField = true
+	_, err = setFunc.ProcessBuiltinFunction(nil, nil, vmInput)
+	assert.Nil(t, err)
+	expectedConfig := vmcommon.CollectionConfig{
+		MaxNumURIs:          2,
+		MaxAttributesLength: 10,
+		ReservedNonceRanges: []vmcommon.NonceRange{{Start: 10, End: 20}},
+	}
+	assert.Equal(t, expectedConfig, globalSettings.GetCollectionConfig(tokenID))
+
+	invalidInputs := [][][]byte{
+		{tokenID, {2}, {10}, {1}, {10}},
+		{tokenID, {2}, {10}, {1}, {20}, {10}},
+		{tokenID, {2}, {10}, {1}, {}, {10}},
+	}
+	for _, arguments := range invalidInputs {
+		_, err = setFunc.ProcessBuiltinFunction(nil, nil, createCollectionConfigInput(arguments...))
+		assert.ErrorIs(t, err, ErrInvalidArguments)
+	}
+
+	_, err = setFunc.ProcessBuiltinFunction(nil, nil, createCollectionConfigInput(tokenID, []byte{2}, []byte{10}, []byte{1}, []byte{1}, make([]byte, 9)))
+	assert.ErrorIs(t, err, ErrInvalidArguments)
+	overflowingNonce := append([]byte{1}, make([]byte, 8)...)
+	_, err = setFunc.ProcessBuiltinFunction(nil, nil, createCollectionConfigInput(tokenID, []byte{2}, []byte{10}, []byte{1}, []byte{1}, overflowingNonce))
+	assert.Equal(t, ErrNonceOverflow, err)
+}
+
+func TestDCTCollectionConfig_ProcessBuiltinFunctionContentHash(t *testing.T) {
+	t.Parallel()
+
+	systemAcc := mock.NewUserAccount(vmcommon.SystemAccountAddress)
+	accounts := &mock.AccountsStub{
+		LoadAccountCalled: func(address []byte) (vmcommon.AccountHandler, error) {
+			return systemAcc, nil
+		},
+	}
+	globalSettings, _ := NewDCTGlobalSettingsFunc(ArgsNewDCTGlobalSettings{
+		Config: Config{
+			Accounts:            accounts,
+			Marshalizer:         &mock.MarshalizerMock{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+		},
+		Set:           true,
+		Function:      core.BuiltInFunctionDCTPause,
+		ActiveHandler: trueHandler,
+	})
+	enableEpochsHandler := &mock.EnableEpochsHandlerStub{IsNFTNonceRangesFlagEnabledField: true}
+	setFunc, _ := NewDCTCollectionConfigFunc(ArgsNewDCTCollectionConfig{
+		Config: Config{
+			Accounts:            accounts,
+			EnableEpochsHandler: enableEpochsHandler,
+		},
+		Set: true,
+	})
+
+	tokenID := []byte("COL-abcdef")
+	vmInput := createCollectionConfigInput(tokenID, []byte{2}, []byte{10}, []byte{1}, []byte{1}, []byte{10}, []byte{20})
+	_, err := setFunc.ProcessBuiltinFunction(nil, nil, vmInput)
+	assert.Equal(t, ErrInvalidArguments, err)
+
+	enableEpochsHandler.IsNFTContentHashFlagEnabledField = true
+	_, err = setFunc.ProcessBuiltinFunction(nil, nil, vmInput)
+	require.Nil(t, err)
+	expectedConfig := vmcommon.CollectionConfig{
+		MaxNumURIs:          2,
+		MaxAttributesLength: 10,
+		ContentHashRequired: true,
+		ReservedNonceRanges: []vmcommon.NonceRange{{Start: 10, End: 20}},
+	}
+	assert.Equal(t, expectedConfig, globalSettings.GetCollectionConfig(tokenID))
+
+	_, err = setFunc.ProcessBuiltinFunction(nil, nil, createCollectionConfigInput(tokenID, []byte{2}, []byte{10}, []byte{1}, []byte{}))
+	require.Nil(t, err)
+	assert.False(t, globalSettings.GetCollectionConfig(tokenID).ContentHashRequired)
+
+	_, err = setFunc.ProcessBuiltinFunction(nil, nil, createCollectionConfigInput(tokenID, []byte{2}, []byte{10}, []byte{1}, append([]byte{1}, make([]byte, 8)...)))
+	assert.ErrorIs(t, err, ErrInvalidArguments)
+}
+
+func TestCheckCollectionConfig(t *testing.T) {
+	t.Parallel()
+
+	globalSettingsHandler := &mock.GlobalSettingsHandlerStub{
+		GetCollectionConfigCalled: func(tokenID []byte) vmcommon.CollectionConfig {
+			return vmcommon.CollectionConfig{
+				MaxNumURIs:          1,
+				MaxAttributesLength: 3,
+				AddQuantityDisabled: true,
+			}
+		},
+	}
+	tokenID := []byte("COL-abcdef")
+
+	assert.Nil(t, checkCollectionURIs(globalSettingsHandler, tokenID, 1))
+	assert.Equal(t, ErrTooManyURIs, checkCollectionURIs(globalSettingsHandler, tokenID, 2))
+	assert.Nil(t, checkCollectionAttributes(globalSettingsHandler, tokenID, []byte("abc")))
+	assert.Equal(t, ErrAttributesTooLong, checkCollectionAttributes(globalSettingsHandler, tokenID, []byte("abcd")))
+	assert.Equal(t, ErrAddQuantityNotAllowed, checkCollectionAddQuantity(globalSettingsHandler, tokenID))
+	assert.Nil(t, checkCollectionAddQuantity(&mock.GlobalSettingsHandlerStub{}, tokenID))
+	assert.Nil(t, checkCollectionContentHash(globalSettingsHandler, nil, tokenID, []byte("hash"), []byte("abc")))
+}
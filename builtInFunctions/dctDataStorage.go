@@ -10,6 +10,7 @@ import (
 	"github.com/Reshusk23/sr-me-core/data"
 	"github.com/Reshusk23/sr-me-core/data/dct"
 	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/dctkeys"
 	"github.com/Reshusk23/sr-vm-common-go/parsers"
 )
 
@@ -25,6 +26,7 @@ func defaultQueryOptions() queryOptions {
 }
 
 type dctDataStorage struct {
+	baseSystemAddressesHandler
 	accounts              vmcommon.AccountsAdapter
 	globalSettingsHandler vmcommon.DCTGlobalSettingsHandler
 	marshaller            vmcommon.Marshalizer
@@ -32,15 +34,20 @@ type dctDataStorage struct {
 	shardCoordinator      vmcommon.Coordinator
 	txDataParser          vmcommon.CallArgsParser
 	enableEpochsHandler   vmcommon.EnableEpochsHandler
+	metaDataCompression   *metaDataCompression
 }
 
-// ArgsNewDCTDataStorage defines the argument list for new dct data storage handler
+// ArgsNewDCTDataStorage defines the argument list for new dct data storage handler. The compressor is optional, the
+// metadata saved on the system account being compressed only if it is provided and it is larger than the compression
+// threshold, 1024 bytes if not set
 type ArgsNewDCTDataStorage struct {
 	Accounts              vmcommon.AccountsAdapter
 	GlobalSettingsHandler vmcommon.DCTGlobalSettingsHandler
 	Marshalizer           vmcommon.Marshalizer
 	EnableEpochsHandler   vmcommon.EnableEpochsHandler
 	ShardCoordinator      vmcommon.Coordinator
+	Compressor            vmcommon.Compressor
+	CompressionThreshold  uint32
 }
 
 // NewDCTDataStorage creates a new dct data storage handler
@@ -69,6 +76,7 @@ func NewDCTDataStorage(args ArgsNewDCTDataStorage) (*dctDataStorage, error) {
 		shardCoordinator:      args.ShardCoordinator,
 		txDataParser:          parsers.NewCallArgsParser(),
 		enableEpochsHandler:   args.EnableEpochsHandler,
+		metaDataCompression:   newMetaDataCompression(args.Compressor, args.CompressionThreshold),
 	}
 
 	return e, nil
@@ -125,7 +133,7 @@ func (e *dctDataStorage) getDCTNFTTokenOnDestinationWithAccountsAdapterOptions(
 	nonce uint64,
 	options queryOptions,
 ) (*dct.DCToken, bool, error) {
-	dctNFTTokenKey := computeDCTNFTTokenKey(dctTokenKey, nonce)
+	dctNFTTokenKey := dctkeys.ComputeDCTNFTTokenKey(dctTokenKey, nonce)
 	dctData := &dct.DCToken{
 		Value: big.NewInt(0),
 		Type:  uint32(core.Fungible),
@@ -135,7 +143,7 @@ func (e *dctDataStorage) getDCTNFTTokenOnDestinationWithAccountsAdapterOptions(
 		return dctData, true, nil
 	}
 
-	err = e.marshaller.Unmarshal(dctData, marshaledData)
+	err = e.unmarshalStoredData(dctData, marshaledData)
 	if err != nil {
 		return nil, false, err
 	}
@@ -170,7 +178,7 @@ func (e *dctDataStorage) getDCTDigitalTokenDataFromSystemAccount(
 	}
 
 	dctData := &dct.DCToken{}
-	err = e.marshaller.Unmarshal(dctData, marshaledData)
+	err = e.unmarshalStoredData(dctData, marshaledData)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -216,7 +224,7 @@ func (e *dctDataStorage) checkCollectionIsFrozenForAccount(
 		return nil
 	}
 
-	err = e.marshaller.Unmarshal(dctData, marshaledData)
+	err = e.unmarshalStoredData(dctData, marshaledData)
 	if err != nil {
 		return err
 	}
@@ -236,13 +244,13 @@ func (e *dctDataStorage) checkFrozenPauseProperties(
 	dctData *dct.DCToken,
 	isReturnWithError bool,
 ) error {
-	err := checkFrozeAndPause(acnt.AddressBytes(), dctTokenKey, dctData, e.globalSettingsHandler, isReturnWithError)
+	err := checkFrozeAndPause(acnt.AddressBytes(), dctTokenKey, dctData, e.globalSettingsHandler, e.getSystemAddresses(), isReturnWithError)
 	if err != nil {
 		return err
 	}
 
-	dctNFTTokenKey := computeDCTNFTTokenKey(dctTokenKey, nonce)
-	err = checkFrozeAndPause(acnt.AddressBytes(), dctNFTTokenKey, dctData, e.globalSettingsHandler, isReturnWithError)
+	dctNFTTokenKey := dctkeys.ComputeDCTNFTTokenKey(dctTokenKey, nonce)
+	err = checkFrozeAndPause(acnt.AddressBytes(), dctNFTTokenKey, dctData, e.globalSettingsHandler, e.getSystemAddresses(), isReturnWithError)
 	if err != nil {
 		return err
 	}
@@ -267,7 +275,7 @@ func (e *dctDataStorage) AddToLiquiditySystemAcc(
 		return nil
 	}
 
-	dctNFTTokenKey := computeDCTNFTTokenKey(dctTokenKey, nonce)
+	dctNFTTokenKey := dctkeys.ComputeDCTNFTTokenKey(dctTokenKey, nonce)
 	dctData, systemAcc, err := e.getDCTDigitalTokenDataFromSystemAccount(dctNFTTokenKey, defaultQueryOptions())
 	if err != nil {
 		return err
@@ -327,7 +335,7 @@ func (e *dctDataStorage) SaveDCTNFTToken(
 		return nil, err
 	}
 
-	dctNFTTokenKey := computeDCTNFTTokenKey(dctTokenKey, nonce)
+	dctNFTTokenKey := dctkeys.ComputeDCTNFTTokenKey(dctTokenKey, nonce)
 	senderShardID := e.shardCoordinator.ComputeId(senderAddress)
 	if e.enableEpochsHandler.IsSaveToSystemAccountFlagEnabled() {
 		err = e.saveDCTMetaDataToSystemAccount(acnt, senderShardID, dctNFTTokenKey, nonce, dctData, mustUpdateAllFields)
@@ -362,6 +370,34 @@ func (e *dctDataStorage) SaveDCTNFTToken(
 	return marshaledData, acnt.AccountDataHandler().SaveKeyValue(dctNFTTokenKey, marshaledData)
 }
 
+// unmarshalStoredData decodes the token stored on an account, decompressing it if needed. Only the tokens saved on
+// the system account, holding the metadata, are compressed
+func (e *dctDataStorage) unmarshalStoredData(dctData *dct.DCToken, payload []byte) error {
+	marshaledData, err := e.metaDataCompression.decompress(payload)
+	if err != nil {
+		return err
+	}
+
+	return e.marshaller.Unmarshal(dctData, marshaledData)
+}
+
+// GetStoredMetaDataSize returns the number of bytes the token of the NFT nonce occupies on the system account, after
+// the compression. It returns 0 for the nonces without data on the system account
+func (e *dctDataStorage) GetStoredMetaDataSize(dctTokenKey []byte, nonce uint64) (int, error) {
+	systemAcc, err := e.getSystemAccount(defaultQueryOptions())
+	if err != nil {
+		return 0, err
+	}
+
+	dctNFTTokenKey := dctkeys.ComputeDCTNFTTokenKey(dctTokenKey, nonce)
+	payload, _, err := systemAcc.AccountDataHandler().RetrieveValue(dctNFTTokenKey)
+	if err != nil {
+		return 0, nil
+	}
+
+	return len(payload), nil
+}
+
 func (e *dctDataStorage) saveDCTMetaDataToSystemAccount(
 	userAcc vmcommon.UserAccountHandler,
 	senderShardID uint32,
@@ -441,7 +477,7 @@ func (e *dctDataStorage) saveMetadataIfRequired(
 	}
 
 	dctDataOnSystemAcc := &dct.DCToken{}
-	err := e.marshaller.Unmarshal(dctDataOnSystemAcc, currentSaveData)
+	err := e.unmarshalStoredData(dctDataOnSystemAcc, currentSaveData)
 	if err != nil {
 		return err
 	}
@@ -472,7 +508,7 @@ func (e *dctDataStorage) setReservedToNilForOldToken(
 	}
 
 	dctDataOnUserAcc := &dct.DCToken{}
-	err := e.marshaller.Unmarshal(dctDataOnUserAcc, dataOnUserAcc)
+	err := e.unmarshalStoredData(dctDataOnUserAcc, dataOnUserAcc)
 	if err != nil {
 		return err
 	}
@@ -495,8 +531,45 @@ func (e *dctDataStorage) marshalAndSaveData(
 	if err != nil {
 		return err
 	}
+	payload, err := e.metaDataCompression.compress(marshaledData)
+	if err != nil {
+		return err
+	}
+
+	err = systemAcc.AccountDataHandler().SaveKeyValue(dctNFTTokenKey, payload)
+	if err != nil {
+		return err
+	}
+
+	return e.accounts.SaveAccount(systemAcc)
+}
+
+// SaveNFTMaxSupply records on the system account the max supply of a newly created NFT nonce, together with its
+// initial quantity. A zero max supply leaves the nonce uncapped
+func (e *dctDataStorage) SaveNFTMaxSupply(
+	dctTokenKey []byte,
+	nonce uint64,
+	maxSupply *big.Int,
+	initialQuantity *big.Int,
+) error {
+	if maxSupply == nil || maxSupply.Sign() == 0 {
+		return nil
+	}
+	if initialQuantity.Cmp(maxSupply) > 0 {
+		return ErrMaxSupplyExceeded
+	}
+
+	systemAcc, err := e.loadSystemAccount()
+	if err != nil {
+		return err
+	}
 
-	err = systemAcc.AccountDataHandler().SaveKeyValue(dctNFTTokenKey, marshaledData)
+	supply := &nftMaxSupply{
+		maxSupply: maxSupply,
+		minted:    initialQuantity,
+	}
+	key := computeNFTMaxSupplyKey(dctTokenKey[len(e.keyPrefix):], nonce)
+	err = systemAcc.AccountDataHandler().SaveKeyValue(key, supply.toBytes())
 	if err != nil {
 		return err
 	}
@@ -504,6 +577,84 @@ func (e *dctDataStorage) marshalAndSaveData(
 	return e.accounts.SaveAccount(systemAcc)
 }
 
+// AddToNFTMintedSupply adds the value to the quantity minted for the NFT nonce, returning ErrMaxSupplyExceeded if
+// the max supply set at creation would be exceeded. Nonces created without a max supply are not tracked
+func (e *dctDataStorage) AddToNFTMintedSupply(
+	dctTokenKey []byte,
+	nonce uint64,
+	value *big.Int,
+) error {
+	systemAcc, err := e.loadSystemAccount()
+	if err != nil {
+		return err
+	}
+
+	key := computeNFTMaxSupplyKey(dctTokenKey[len(e.keyPrefix):], nonce)
+	val, _, err := systemAcc.AccountDataHandler().RetrieveValue(key)
+	if err != nil || len(val) == 0 {
+		return nil
+	}
+
+	supply, err := nftMaxSupplyFromBytes(val)
+	if err != nil {
+		return err
+	}
+	supply.minted.Add(supply.minted, value)
+	if supply.minted.Cmp(supply.maxSupply) > 0 {
+		return ErrMaxSupplyExceeded
+	}
+
+	err = systemAcc.AccountDataHandler().SaveKeyValue(key, supply.toBytes())
+	if err != nil {
+		return err
+	}
+
+	return e.accounts.SaveAccount(systemAcc)
+}
+
+// SaveNFTRoyaltiesDenominator records on the system account the royalties denominator a newly created NFT nonce was
+// created with, so the royalties of the nonce keep their meaning if the collection config changes. The default
+// denominator is never recorded
+func (e *dctDataStorage) SaveNFTRoyaltiesDenominator(
+	dctTokenKey []byte,
+	nonce uint64,
+	royaltiesDenominator uint32,
+) error {
+	if royaltiesDenominator == 0 || royaltiesDenominator == vmcommon.DefaultRoyaltiesDenominator {
+		return nil
+	}
+
+	systemAcc, err := e.loadSystemAccount()
+	if err != nil {
+		return err
+	}
+
+	key := computeNFTRoyaltiesDenominatorKey(dctTokenKey[len(e.keyPrefix):], nonce)
+	err = systemAcc.AccountDataHandler().SaveKeyValue(key, nftRoyaltiesDenominatorToBytes(royaltiesDenominator))
+	if err != nil {
+		return err
+	}
+
+	return e.accounts.SaveAccount(systemAcc)
+}
+
+// GetNFTRoyaltiesDenominator returns the royalties denominator the NFT nonce was created with. The nonces without a
+// recorded one, created before their collection got a denominator or created on another shard, use the default one
+func (e *dctDataStorage) GetNFTRoyaltiesDenominator(dctTokenKey []byte, nonce uint64) (uint32, error) {
+	systemAcc, err := e.loadSystemAccount()
+	if err != nil {
+		return 0, err
+	}
+
+	key := computeNFTRoyaltiesDenominatorKey(dctTokenKey[len(e.keyPrefix):], nonce)
+	val, _, err := systemAcc.AccountDataHandler().RetrieveValue(key)
+	if err != nil || len(val) == 0 {
+		return vmcommon.DefaultRoyaltiesDenominator, nil
+	}
+
+	return nftRoyaltiesDenominatorFromBytes(val)
+}
+
 func (e *dctDataStorage) getSystemAccount(options queryOptions) (vmcommon.UserAccountHandler, error) {
 	if options.isCustomSystemAccountSet && !check.IfNil(options.customSystemAccount) {
 		return options.customSystemAccount, nil
@@ -512,8 +663,18 @@ func (e *dctDataStorage) getSystemAccount(options queryOptions) (vmcommon.UserAc
 	return e.loadSystemAccount()
 }
 
+// withBufferedSystemAccount returns a view of the storage handler whose writes to the system account are buffered,
+// together with the buffer the caller commits once the whole call succeeded. The view is meant for a single call
+func (e *dctDataStorage) withBufferedSystemAccount() (*dctDataStorage, *systemAccountBuffer) {
+	buffer := newSystemAccountBuffer(e.accounts, e.getSystemAddresses().SystemAccountAddress)
+	view := *e
+	view.accounts = buffer
+
+	return &view, buffer
+}
+
 func (e *dctDataStorage) loadSystemAccount() (vmcommon.UserAccountHandler, error) {
-	systemSCAccount, err := e.accounts.LoadAccount(vmcommon.SystemAccountAddress)
+	systemSCAccount, err := e.accounts.LoadAccount(e.getSystemAddresses().SystemAccountAddress)
 	if err != nil {
 		return nil, err
 	}
@@ -555,7 +716,7 @@ func (e *dctDataStorage) WasAlreadySentToDestinationShardAndUpdateState(
 		return true, nil
 	}
 	dctTokenKey := append(e.keyPrefix, tickerID...)
-	dctNFTTokenKey := computeDCTNFTTokenKey(dctTokenKey, nonce)
+	dctNFTTokenKey := dctkeys.ComputeDCTNFTTokenKey(dctTokenKey, nonce)
 
 	dctData, systemAcc, err := e.getDCTDigitalTokenDataFromSystemAccount(dctNFTTokenKey, defaultQueryOptions())
 	if err != nil {
@@ -631,8 +792,11 @@ func (e *dctDataStorage) addMetaDataToSystemAccountFromNFTTransfer(
 			return err
 		}
 		dctTokenKey := append(e.keyPrefix, arguments[0]...)
-		nonce := big.NewInt(0).SetBytes(arguments[1]).Uint64()
-		dctNFTTokenKey := computeDCTNFTTokenKey(dctTokenKey, nonce)
+		nonce, err := flaggedUint64Argument(e.enableEpochsHandler, arguments, 1)
+		if err != nil {
+			return err
+		}
+		dctNFTTokenKey := dctkeys.ComputeDCTNFTTokenKey(dctTokenKey, nonce)
 
 		return e.saveDCTMetaDataToSystemAccount(nil, sndShardID, dctNFTTokenKey, nonce, dctTransferData, true)
 	}
@@ -643,7 +807,10 @@ func (e *dctDataStorage) addMetaDataToSystemAccountFromMultiTransfer(
 	sndShardID uint32,
 	arguments [][]byte,
 ) error {
-	numOfTransfers := big.NewInt(0).SetBytes(arguments[0]).Uint64()
+	numOfTransfers, err := flaggedUint64Argument(e.enableEpochsHandler, arguments, 0)
+	if err != nil {
+		return err
+	}
 	if numOfTransfers == 0 {
 		return fmt.Errorf("%w, 0 tokens to transfer", ErrInvalidArguments)
 	}
@@ -656,7 +823,10 @@ func (e *dctDataStorage) addMetaDataToSystemAccountFromMultiTransfer(
 	for i := uint64(0); i < numOfTransfers; i++ {
 		tokenStartIndex := startIndex + i*argumentsPerTransfer
 		tokenID := arguments[tokenStartIndex]
-		nonce := big.NewInt(0).SetBytes(arguments[tokenStartIndex+1]).Uint64()
+		nonce, err := flaggedUint64Argument(e.enableEpochsHandler, arguments, int(tokenStartIndex+1))
+		if err != nil {
+			return err
+		}
 
 		if nonce > 0 && len(arguments[tokenStartIndex+2]) > vmcommon.MaxLengthForValueToOptTransfer {
 			dctTransferData := &dct.DCToken{}
@@ -667,7 +837,7 @@ func (e *dctDataStorage) addMetaDataToSystemAccountFromMultiTransfer(
 			}
 
 			dctTokenKey := append(e.keyPrefix, tokenID...)
-			dctNFTTokenKey := computeDCTNFTTokenKey(dctTokenKey, nonce)
+			dctNFTTokenKey := dctkeys.ComputeDCTNFTTokenKey(dctTokenKey, nonce)
 			err = e.saveDCTMetaDataToSystemAccount(nil, sndShardID, dctNFTTokenKey, nonce, dctTransferData, true)
 			if err != nil {
 				return err
@@ -11,8 +11,10 @@ import (
 	"github.com/Reshusk23/sr-me-core/data/dct"
 	"github.com/Reshusk23/sr-me-core/data/smartContractResult"
 	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/dctkeys"
 	"github.com/Reshusk23/sr-vm-common-go/mock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func createNewDCTDataStorageHandler() *dctDataStorage {
@@ -75,31 +77,31 @@ func TestNewDCTDataStorage(t *testing.T) {
 	args.Marshalizer = nil
 	e, err := NewDCTDataStorage(args)
 	assert.Nil(t, e)
-	assert.Equal(t, err, ErrNilMarshalizer)
+	assert.ErrorIs(t, err, ErrNilMarshalizer)
 
 	args = createMockArgsForNewDCTDataStorage()
 	args.Accounts = nil
 	e, err = NewDCTDataStorage(args)
 	assert.Nil(t, e)
-	assert.Equal(t, err, ErrNilAccountsAdapter)
+	assert.ErrorIs(t, err, ErrNilAccountsAdapter)
 
 	args = createMockArgsForNewDCTDataStorage()
 	args.ShardCoordinator = nil
 	e, err = NewDCTDataStorage(args)
 	assert.Nil(t, e)
-	assert.Equal(t, err, ErrNilShardCoordinator)
+	assert.ErrorIs(t, err, ErrNilShardCoordinator)
 
 	args = createMockArgsForNewDCTDataStorage()
 	args.GlobalSettingsHandler = nil
 	e, err = NewDCTDataStorage(args)
 	assert.Nil(t, e)
-	assert.Equal(t, err, ErrNilGlobalSettingsHandler)
+	assert.ErrorIs(t, err, ErrNilGlobalSettingsHandler)
 
 	args = createMockArgsForNewDCTDataStorage()
 	args.EnableEpochsHandler = nil
 	e, err = NewDCTDataStorage(args)
 	assert.Nil(t, e)
-	assert.Equal(t, err, ErrNilEnableEpochsHandler)
+	assert.ErrorIs(t, err, ErrNilEnableEpochsHandler)
 
 	args = createMockArgsForNewDCTDataStorage()
 	e, err = NewDCTDataStorage(args)
@@ -781,7 +783,7 @@ func TestDctDataStorage_AddToLiquiditySystemAcc(t *testing.T) {
 	dctData := &dct.DCToken{Value: big.NewInt(0)}
 	marshalledData, _ := e.marshaller.Marshal(dctData)
 
-	dctNFTTokenKey := computeDCTNFTTokenKey(tokenKey, nonce)
+	dctNFTTokenKey := dctkeys.ComputeDCTNFTTokenKey(tokenKey, nonce)
 	_ = systemAcc.AccountDataHandler().SaveKeyValue(dctNFTTokenKey, marshalledData)
 
 	err = e.AddToLiquiditySystemAcc(tokenKey, nonce, big.NewInt(10))
@@ -803,3 +805,106 @@ func TestDctDataStorage_AddToLiquiditySystemAcc(t *testing.T) {
 	dctData, _, _ = e.getDCTDigitalTokenDataFromSystemAccount(dctNFTTokenKey, defaultQueryOptions())
 	assert.Nil(t, dctData)
 }
+
+func TestDctDataStorage_NFTMaxSupply(t *testing.T) {
+	t.Parallel()
+
+	dctTokenKey := []byte(baseDCTKeyPrefix + "token")
+
+	t.Run("zero max supply should not track the nonce", func(t *testing.T) {
+		t.Parallel()
+
+		e := createNewDCTDataStorageHandler()
+		err := e.SaveNFTMaxSupply(dctTokenKey, 1, big.NewInt(0), big.NewInt(5))
+		require.Nil(t, err)
+
+		err = e.AddToNFTMintedSupply(dctTokenKey, 1, big.NewInt(1000))
+		assert.Nil(t, err)
+	})
+	t.Run("initial quantity above max supply should err", func(t *testing.T) {
+		t.Parallel()
+
+		e := createNewDCTDataStorageHandler()
+		err := e.SaveNFTMaxSupply(dctTokenKey, 1, big.NewInt(4), big.NewInt(5))
+		assert.Equal(t, ErrMaxSupplyExceeded, err)
+	})
+	t.Run("minted supply should be capped per nonce", func(t *testing.T) {
+		t.Parallel()
+
+		e := createNewDCTDataStorageHandler()
+		err := e.SaveNFTMaxSupply(dctTokenKey, 1, big.NewInt(10), big.NewInt(5))
+		require.Nil(t, err)
+
+		assert.Nil(t, e.AddToNFTMintedSupply(dctTokenKey, 1, big.NewInt(4)))
+		assert.Nil(t, e.AddToNFTMintedSupply(dctTokenKey, 1, big.NewInt(1)))
+		assert.Equal(t, ErrMaxSupplyExceeded, e.AddToNFTMintedSupply(dctTokenKey, 1, big.NewInt(1)))
+		assert.Nil(t, e.AddToNFTMintedSupply(dctTokenKey, 2, big.NewInt(100)))
+
+		systemAcc, _ := e.loadSystemAccount()
+		val, _, _ := systemAcc.AccountDataHandler().RetrieveValue(computeNFTMaxSupplyKey([]byte("token"), 1))
+		supply, err := nftMaxSupplyFromBytes(val)
+		require.Nil(t, err)
+		assert.Equal(t, big.NewInt(10), supply.minted)
+	})
+}
+
+func TestDctDataStorage_SaveDCTNFTTokenWithCompression(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgsForNewDCTDataStorage()
+	args.Compressor = createFlateCompressor()
+	args.CompressionThreshold = 400
+	e, _ := NewDCTDataStorage(args)
+
+	userAcc := mock.NewAccountWrapMock([]byte("addr"))
+	dctTokenKey := []byte(baseDCTKeyPrefix + "testTkn")
+	createToken := func(nonce uint64, attributes []byte) *dct.DCToken {
+		return &dct.DCToken{
+			Type:  uint32(core.NonFungible),
+			Value: big.NewInt(1),
+			TokenMetaData: &dct.MetaData{
+				Nonce:      nonce,
+				Name:       []byte("name"),
+				Attributes: attributes,
+			},
+		}
+	}
+
+	largeAttributes := bytes.Repeat([]byte("attributes"), 50)
+	_, err := e.SaveDCTNFTToken(userAcc.AddressBytes(), userAcc, dctTokenKey, 1, createToken(1, largeAttributes), true, false)
+	require.Nil(t, err)
+	_, err = e.SaveDCTNFTToken(userAcc.AddressBytes(), userAcc, dctTokenKey, 2, createToken(2, []byte("small")), true, false)
+	require.Nil(t, err)
+
+	systemAcc, _ := e.getSystemAccount(defaultQueryOptions())
+	payload, _, _ := systemAcc.AccountDataHandler().RetrieveValue(dctkeys.ComputeDCTNFTTokenKey(dctTokenKey, 1))
+	assert.True(t, isCompressedPayload(payload))
+	size, err := e.GetStoredMetaDataSize(dctTokenKey, 1)
+	require.Nil(t, err)
+	assert.Equal(t, len(payload), size)
+	assert.Less(t, size, len(largeAttributes))
+
+	payload, _, _ = systemAcc.AccountDataHandler().RetrieveValue(dctkeys.ComputeDCTNFTTokenKey(dctTokenKey, 2))
+	assert.False(t, isCompressedPayload(payload))
+
+	dctData, _, err := e.GetDCTNFTTokenOnDestination(userAcc, dctTokenKey, 1)
+	require.Nil(t, err)
+	assert.Equal(t, largeAttributes, dctData.TokenMetaData.Attributes)
+
+	// the liquidity update rewrites the compressed token
+	err = e.AddToLiquiditySystemAcc(dctTokenKey, 1, big.NewInt(2))
+	require.Nil(t, err)
+	dctData, _, err = e.GetDCTNFTTokenOnDestination(userAcc, dctTokenKey, 1)
+	require.Nil(t, err)
+	assert.Equal(t, largeAttributes, dctData.TokenMetaData.Attributes)
+
+	size, err = e.GetStoredMetaDataSize(dctTokenKey, 3)
+	require.Nil(t, err)
+	assert.Equal(t, 0, size)
+
+	args.Compressor = nil
+	withoutCompressor, _ := NewDCTDataStorage(args)
+	dctData, _, err = withoutCompressor.GetDCTNFTTokenOnDestination(userAcc, dctTokenKey, 1)
+	assert.Equal(t, ErrCompressorNotSet, err)
+	assert.Nil(t, dctData)
+}
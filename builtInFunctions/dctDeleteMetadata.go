@@ -5,15 +5,17 @@ import (
 	"math/big"
 
 	"github.com/Reshusk23/sr-me-core/core"
-	"github.com/Reshusk23/sr-me-core/core/check"
 	"github.com/Reshusk23/sr-me-core/data/dct"
 	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/dctkeys"
+	"github.com/Reshusk23/sr-vm-common-go/tokenident"
 )
 
 const numArgsPerAdd = 3
 
 type dctDeleteMetaData struct {
 	baseActiveHandler
+	baseSystemAddressesHandler
 	allowedAddress []byte
 	delete         bool
 	accounts       vmcommon.AccountsAdapter
@@ -21,30 +23,29 @@ type dctDeleteMetaData struct {
 	marshaller     vmcommon.Marshalizer
 	funcGasCost    uint64
 	function       string
+
+	enableEpochsHandler vmcommon.EnableEpochsHandler
 }
 
 // ArgsNewDCTDeleteMetadata defines the argument list for new dct delete metadata built in function
 type ArgsNewDCTDeleteMetadata struct {
-	FuncGasCost         uint64
-	Marshalizer         vmcommon.Marshalizer
-	Accounts            vmcommon.AccountsAdapter
-	AllowedAddress      []byte
-	Delete              bool
-	EnableEpochsHandler vmcommon.EnableEpochsHandler
+	Config
+	FuncGasCost    uint64
+	AllowedAddress []byte
+	Delete         bool
 }
 
 // NewDCTDeleteMetadataFunc returns the dct metadata deletion built-in function component
 func NewDCTDeleteMetadataFunc(
 	args ArgsNewDCTDeleteMetadata,
 ) (*dctDeleteMetaData, error) {
-	if check.IfNil(args.Marshalizer) {
-		return nil, ErrNilMarshalizer
-	}
-	if check.IfNil(args.Accounts) {
-		return nil, ErrNilAccountsAdapter
+	function := vmcommon.DCTAddMetadata
+	if args.Delete {
+		function = vmcommon.DCTDeleteMetadata
 	}
-	if check.IfNil(args.EnableEpochsHandler) {
-		return nil, ErrNilEnableEpochsHandler
+	err := args.checkComponents(function, requireMarshalizer|requireAccounts|requireEnableEpochsHandler)
+	if err != nil {
+		return nil, err
 	}
 
 	e := &dctDeleteMetaData{
@@ -55,6 +56,8 @@ func NewDCTDeleteMetadataFunc(
 		allowedAddress: args.AllowedAddress,
 		delete:         args.Delete,
 		function:       core.BuiltInFunctionMultiDCTNFTTransfer,
+
+		enableEpochsHandler: args.EnableEpochsHandler,
 	}
 
 	e.baseActiveHandler.activeHandler = args.EnableEpochsHandler.IsSendAlwaysFlagEnabled
@@ -74,8 +77,9 @@ func (e *dctDeleteMetaData) ProcessBuiltinFunction(
 	if vmInput == nil {
 		return nil, ErrNilVmInput
 	}
-	if vmInput.CallValue.Cmp(zero) != 0 {
-		return nil, ErrBuiltInFunctionCalledWithValue
+	err := checkCallValue(vmcommon.CallValueForbidden, vmInput.CallValue)
+	if err != nil {
+		return nil, err
 	}
 	if !bytes.Equal(vmInput.CallerAddr, e.allowedAddress) {
 		return nil, ErrAddressIsNotAllowed
@@ -115,10 +119,13 @@ func (e *dctDeleteMetaData) deleteMetadata(args [][]byte) error {
 
 	for i := uint64(0); i+1 < uint64(len(args)); {
 		tokenID := args[i]
-		numIntervals := big.NewInt(0).SetBytes(args[i+1]).Uint64()
+		numIntervals, err := flaggedUint64Argument(e.enableEpochsHandler, args, int(i+1))
+		if err != nil {
+			return err
+		}
 		i += 2
 
-		if !vmcommon.ValidateToken(tokenID) {
+		if !tokenident.ValidateTokenIdentifier(tokenID) {
 			return ErrInvalidTokenID
 		}
 
@@ -154,10 +161,16 @@ func (e *dctDeleteMetaData) deleteMetadataForListIntervals(
 			return ErrInvalidNumOfArgs
 		}
 
-		startIndex := big.NewInt(0).SetBytes(args[j]).Uint64()
-		endIndex := big.NewInt(0).SetBytes(args[j+1]).Uint64()
+		startIndex, err := flaggedUint64Argument(e.enableEpochsHandler, args, int(j))
+		if err != nil {
+			return err
+		}
+		endIndex, err := flaggedUint64Argument(e.enableEpochsHandler, args, int(j+1))
+		if err != nil {
+			return err
+		}
 
-		err := e.deleteMetadataForInterval(systemAcc, tokenID, startIndex, endIndex)
+		err = e.deleteMetadataForInterval(systemAcc, tokenID, startIndex, endIndex)
 		if err != nil {
 			return err
 		}
@@ -180,7 +193,7 @@ func (e *dctDeleteMetaData) deleteMetadataForInterval(
 
 	dctTokenKey := append(e.keyPrefix, tokenID...)
 	for nonce := startIndex; nonce <= endIndex; nonce++ {
-		dctNFTTokenKey := computeDCTNFTTokenKey(dctTokenKey, nonce)
+		dctNFTTokenKey := dctkeys.ComputeDCTNFTTokenKey(dctTokenKey, nonce)
 
 		err := systemAcc.AccountDataHandler().SaveKeyValue(dctNFTTokenKey, nil)
 		if err != nil {
@@ -204,17 +217,20 @@ func (e *dctDeleteMetaData) addMetadata(args [][]byte) error {
 
 	for i := 0; i < len(args); i += numArgsPerAdd {
 		tokenID := args[i]
-		nonce := big.NewInt(0).SetBytes(args[i+1]).Uint64()
+		nonce, err := flaggedUint64Argument(e.enableEpochsHandler, args, i+1)
+		if err != nil {
+			return err
+		}
 		if nonce == 0 {
 			return ErrInvalidNonce
 		}
 
-		if !vmcommon.ValidateToken(tokenID) {
+		if !tokenident.ValidateTokenIdentifier(tokenID) {
 			return ErrInvalidTokenID
 		}
 
 		dctTokenKey := append(e.keyPrefix, tokenID...)
-		dctNFTTokenKey := computeDCTNFTTokenKey(dctTokenKey, nonce)
+		dctNFTTokenKey := dctkeys.ComputeDCTNFTTokenKey(dctTokenKey, nonce)
 		metaData := &dct.MetaData{}
 		err = e.marshaller.Unmarshal(metaData, args[i+2])
 		if err != nil {
@@ -263,6 +279,10 @@ func (e *dctDeleteMetaData) getDCTDigitalTokenDataFromSystemAccount(
 	if err != nil || len(marshaledData) == 0 {
 		return nil, nil
 	}
+	if isCompressedPayload(marshaledData) {
+		// only the tokens holding large metadata are compressed
+		return nil, ErrTokenHasValidMetadata
+	}
 
 	dctData := &dct.DCToken{}
 	err = e.marshaller.Unmarshal(dctData, marshaledData)
@@ -274,7 +294,7 @@ func (e *dctDeleteMetaData) getDCTDigitalTokenDataFromSystemAccount(
 }
 
 func (e *dctDeleteMetaData) getSystemAccount() (vmcommon.UserAccountHandler, error) {
-	systemSCAccount, err := e.accounts.LoadAccount(vmcommon.SystemAccountAddress)
+	systemSCAccount, err := e.accounts.LoadAccount(e.getSystemAddresses().SystemAccountAddress)
 	if err != nil {
 		return nil, err
 	}
@@ -8,20 +8,23 @@ import (
 
 	"github.com/Reshusk23/sr-me-core/data/dct"
 	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/dctkeys"
 	"github.com/Reshusk23/sr-vm-common-go/mock"
 	"github.com/stretchr/testify/assert"
 )
 
 func createMockArgsForNewDCTDelete() ArgsNewDCTDeleteMetadata {
 	return ArgsNewDCTDeleteMetadata{
+		Config: Config{
+			Marshalizer: &mock.MarshalizerMock{},
+			Accounts:    &mock.AccountsStub{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+				IsSendAlwaysFlagEnabledField: true,
+			},
+		},
 		FuncGasCost:    1,
-		Marshalizer:    &mock.MarshalizerMock{},
-		Accounts:       &mock.AccountsStub{},
 		AllowedAddress: bytes.Repeat([]byte{1}, 32),
 		Delete:         true,
-		EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
-			IsSendAlwaysFlagEnabledField: true,
-		},
 	}
 }
 
@@ -34,7 +37,7 @@ func TestNewDCTDeleteMetadataFunc(t *testing.T) {
 		args := createMockArgsForNewDCTDelete()
 		args.Marshalizer = nil
 		_, err := NewDCTDeleteMetadataFunc(args)
-		assert.Equal(t, err, ErrNilMarshalizer)
+		assert.ErrorIs(t, err, ErrNilMarshalizer)
 	})
 	t.Run("nil accounts adapter should error", func(t *testing.T) {
 		t.Parallel()
@@ -42,7 +45,7 @@ func TestNewDCTDeleteMetadataFunc(t *testing.T) {
 		args := createMockArgsForNewDCTDelete()
 		args.Accounts = nil
 		_, err := NewDCTDeleteMetadataFunc(args)
-		assert.Equal(t, err, ErrNilAccountsAdapter)
+		assert.ErrorIs(t, err, ErrNilAccountsAdapter)
 	})
 	t.Run("nil enable epochs handler should error", func(t *testing.T) {
 		t.Parallel()
@@ -50,7 +53,7 @@ func TestNewDCTDeleteMetadataFunc(t *testing.T) {
 		args := createMockArgsForNewDCTDelete()
 		args.EnableEpochsHandler = nil
 		_, err := NewDCTDeleteMetadataFunc(args)
-		assert.Equal(t, err, ErrNilEnableEpochsHandler)
+		assert.ErrorIs(t, err, ErrNilEnableEpochsHandler)
 	})
 	t.Run("should work", func(t *testing.T) {
 		t.Parallel()
@@ -142,7 +145,7 @@ func TestDctDeleteMetaData_ProcessBuiltinFunctionErrors(t *testing.T) {
 	copy(vmInput.Arguments[2], marshalledData)
 
 	dctTokenKey := append(e.keyPrefix, vmInput.Arguments[0]...)
-	dctNftTokenKey := computeDCTNFTTokenKey(dctTokenKey, 1)
+	dctNftTokenKey := dctkeys.ComputeDCTNFTTokenKey(dctTokenKey, 1)
 	err = acnt.SaveKeyValue(dctNftTokenKey, []byte("t"))
 	assert.Nil(t, err)
 
@@ -159,6 +162,13 @@ func TestDctDeleteMetaData_ProcessBuiltinFunctionErrors(t *testing.T) {
 	assert.Nil(t, vmOutput)
 	assert.NotNil(t, ErrTokenHasValidMetadata)
 
+	err = acnt.SaveKeyValue(dctNftTokenKey, append([]byte{compressedPayloadVersion}, []byte("compressed")...))
+	assert.Nil(t, err)
+
+	vmOutput, err = e.ProcessBuiltinFunction(nil, nil, vmInput)
+	assert.Nil(t, vmOutput)
+	assert.Equal(t, ErrTokenHasValidMetadata, err)
+
 	_ = acnt.SaveKeyValue(dctNftTokenKey, nil)
 	testErr := errors.New("testError")
 	accounts.SaveAccountCalled = func(account vmcommon.AccountHandler) error {
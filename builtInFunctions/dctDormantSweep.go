@@ -0,0 +1,215 @@
+package builtInFunctions
+
+import (
+	"encoding/hex"
+	"math/big"
+	"sync"
+
+	"github.com/Reshusk23/sr-me-core/core"
+	"github.com/Reshusk23/sr-me-core/core/check"
+	"github.com/Reshusk23/sr-me-core/data/dct"
+	"github.com/Reshusk23/sr-me-core/data/vm"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/tokenident"
+)
+
+type dctDormantSweep struct {
+	baseActiveHandler
+	baseSystemAddressesHandler
+	dctStorageHandler      vmcommon.DCTNFTStorageHandler
+	globalSettingsHandler  vmcommon.ExtendedDCTGlobalSettingsHandler
+	accountActivityHandler vmcommon.AccountActivityHandler
+	enableEpochsHandler    vmcommon.EnableEpochsHandler
+	marshaller             vmcommon.Marshalizer
+	keyPrefix              []byte
+	minInactiveEpochs      uint32
+	mutExecution           sync.RWMutex
+}
+
+// ArgsNewDCTDormantSweep defines the argument list for new dct dormant sweep built in function
+type ArgsNewDCTDormantSweep struct {
+	Config
+	MinInactiveEpochs uint32
+}
+
+// NewDCTDormantSweepFunc returns the built-in function component which reclaims token balances from dormant accounts
+func NewDCTDormantSweepFunc(args ArgsNewDCTDormantSweep) (*dctDormantSweep, error) {
+	err := args.checkComponents(vmcommon.BuiltInFunctionDCTSweepDormant, requireMarshalizer|requireGlobalSettingsHandler|requireDCTStorageHandler|requireEnableEpochsHandler)
+	if err != nil {
+		return nil, err
+	}
+	if args.MinInactiveEpochs == 0 {
+		return nil, ErrInvalidMinInactiveEpochs
+	}
+
+	e := &dctDormantSweep{
+		dctStorageHandler:      args.DCTStorageHandler,
+		globalSettingsHandler:  args.GlobalSettingsHandler,
+		accountActivityHandler: &disabledAccountActivityHandler{},
+		enableEpochsHandler:    args.EnableEpochsHandler,
+		marshaller:             args.Marshalizer,
+		keyPrefix:              []byte(baseDCTKeyPrefix),
+		minInactiveEpochs:      args.MinInactiveEpochs,
+	}
+
+	e.baseActiveHandler.activeHandler = args.EnableEpochsHandler.IsDormantSweepFlagEnabled
+
+	return e, nil
+}
+
+// SetAccountActivityHandler sets the handler reporting for how many epochs an account has been inactive
+func (e *dctDormantSweep) SetAccountActivityHandler(accountActivityHandler vmcommon.AccountActivityHandler) error {
+	if check.IfNil(accountActivityHandler) {
+		return ErrNilAccountActivityHandler
+	}
+
+	e.mutExecution.Lock()
+	e.accountActivityHandler = accountActivityHandler
+	e.mutExecution.Unlock()
+
+	return nil
+}
+
+// SetNewGasConfig is called whenever gas cost is changed
+func (e *dctDormantSweep) SetNewGasConfig(_ *vmcommon.GasCost) {
+}
+
+// ProcessBuiltinFunction resolves DCT dormant sweep function call, executed on the shard of the dormant account
+// Requires 2 arguments:
+// arg0 - token identifier, followed by the nonce for non-fungible tokens
+// arg1 - address receiving the reclaimed balance
+func (e *dctDormantSweep) ProcessBuiltinFunction(
+	_, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	e.mutExecution.RLock()
+	defer e.mutExecution.RUnlock()
+
+	if vmInput == nil {
+		return nil, ErrNilVmInput
+	}
+	err := checkCallValue(vmcommon.CallValueForbidden, vmInput.CallValue)
+	if err != nil {
+		return nil, err
+	}
+	if len(vmInput.Arguments) != 2 {
+		return nil, ErrInvalidArguments
+	}
+	if !e.getSystemAddresses().IsDCTSCAddress(vmInput.CallerAddr) {
+		return nil, ErrAddressIsNotDCTSystemSC
+	}
+	if check.IfNil(acntDst) {
+		return nil, ErrNilUserAccount
+	}
+
+	receiver := vmInput.Arguments[1]
+	if len(receiver) != len(acntDst.AddressBytes()) {
+		return nil, ErrInvalidAddressLength
+	}
+
+	identifier, nonce := tokenident.SplitCollectionAndNonce(vmInput.Arguments[0])
+	dctTokenKey := append(e.keyPrefix, identifier...)
+	if !e.globalSettingsHandler.IsDormantSweepAllowed(dctTokenKey) {
+		return nil, ErrDormantSweepNotAllowed
+	}
+
+	inactiveEpochs, err := e.accountActivityHandler.GetInactiveEpochs(acntDst.AddressBytes(), e.enableEpochsHandler.GetCurrentEpoch())
+	if err != nil {
+		return nil, err
+	}
+	if inactiveEpochs < e.minInactiveEpochs {
+		return nil, ErrAccountNotDormant
+	}
+
+	var transferData []byte
+	var value *big.Int
+	if nonce == 0 {
+		value, err = e.sweepFungible(acntDst, dctTokenKey)
+		if err != nil {
+			return nil, err
+		}
+
+		transferData = []byte(core.BuiltInFunctionDCTTransfer + "@" + hex.EncodeToString(identifier) + "@" + hex.EncodeToString(value.Bytes()))
+	} else {
+		var marshalledToken []byte
+		value, marshalledToken, err = e.sweepNonFungible(acntDst, dctTokenKey, nonce)
+		if err != nil {
+			return nil, err
+		}
+
+		transferData = computeNFTTransferData(identifier, nonce, value, marshalledToken)
+	}
+
+	outTransfer := vmcommon.OutputTransfer{
+		Value:         big.NewInt(0),
+		Data:          transferData,
+		CallType:      vm.DirectCall,
+		SenderAddress: acntDst.AddressBytes(),
+	}
+	vmOutput := &vmcommon.VMOutput{ReturnCode: vmcommon.Ok}
+	vmOutput.OutputAccounts = map[string]*vmcommon.OutputAccount{
+		string(receiver): {
+			Address:         receiver,
+			OutputTransfers: []vmcommon.OutputTransfer{outTransfer},
+		},
+	}
+	addDCTEntryInVMOutput(vmOutput, []byte(vmcommon.BuiltInFunctionDCTSweepDormant), identifier, nonce, value, acntDst.AddressBytes(), receiver)
+
+	return vmOutput, nil
+}
+
+func (e *dctDormantSweep) sweepFungible(acntDst vmcommon.UserAccountHandler, dctTokenKey []byte) (*big.Int, error) {
+	tokenData, err := getDCTDataFromKey(acntDst, dctTokenKey, e.marshaller)
+	if err != nil {
+		return nil, err
+	}
+	if tokenData.Value.Cmp(zero) <= 0 {
+		return nil, ErrNoBalanceToSweep
+	}
+
+	err = acntDst.AccountDataHandler().SaveKeyValue(dctTokenKey, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return tokenData.Value, nil
+}
+
+func (e *dctDormantSweep) sweepNonFungible(acntDst vmcommon.UserAccountHandler, dctTokenKey []byte, nonce uint64) (*big.Int, []byte, error) {
+	tokenData, err := e.dctStorageHandler.GetDCTNFTTokenOnSender(acntDst, dctTokenKey, nonce)
+	if err != nil {
+		return nil, nil, err
+	}
+	value := vmcommon.ZeroValueIfNil(tokenData.Value)
+	if value.Cmp(zero) <= 0 {
+		return nil, nil, ErrNoBalanceToSweep
+	}
+
+	marshalledToken, err := e.marshaller.Marshal(tokenData)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sweptToken := &dct.DCToken{
+		Type:          tokenData.Type,
+		Value:         big.NewInt(0),
+		Properties:    tokenData.Properties,
+		TokenMetaData: tokenData.TokenMetaData,
+	}
+	_, err = e.dctStorageHandler.SaveDCTNFTToken(acntDst.AddressBytes(), acntDst, dctTokenKey, nonce, sweptToken, false, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = e.dctStorageHandler.AddToLiquiditySystemAcc(dctTokenKey, nonce, big.NewInt(0).Neg(value))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return value, marshalledToken, nil
+}
+
+// IsInterfaceNil returns true if underlying object in nil
+func (e *dctDormantSweep) IsInterfaceNil() bool {
+	return e == nil
+}
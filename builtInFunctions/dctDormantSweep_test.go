@@ -0,0 +1,304 @@
+package builtInFunctions
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/Reshusk23/sr-me-core/core"
+	"github.com/Reshusk23/sr-me-core/core/check"
+	"github.com/Reshusk23/sr-me-core/data/dct"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var dormantAddress = []byte("dormant-address-with-32-bytes---")
+var sweepReceiver = []byte("token-manager-with-32-bytes-----")
+
+func createDormantSweepFunc(dctStorageHandler vmcommon.DCTNFTStorageHandler, sweepAllowed bool, inactiveEpochs uint32) *dctDormantSweep {
+	globalSettingsHandler := &mock.GlobalSettingsHandlerStub{
+		IsDormantSweepAllowedCalled: func(token []byte) bool {
+			return sweepAllowed
+		},
+	}
+	e, _ := NewDCTDormantSweepFunc(ArgsNewDCTDormantSweep{
+		Config: Config{
+			DCTStorageHandler:     dctStorageHandler,
+			GlobalSettingsHandler: globalSettingsHandler,
+			Marshalizer:           &mock.MarshalizerMock{},
+			EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+		},
+		MinInactiveEpochs: 10,
+	})
+	_ = e.SetAccountActivityHandler(&mock.AccountActivityHandlerStub{
+		GetInactiveEpochsCalled: func(address []byte, epoch uint32) (uint32, error) {
+			return inactiveEpochs, nil
+		},
+	})
+
+	return e
+}
+
+func createDormantSweepInput(tokenKey []byte) *vmcommon.ContractCallInput {
+	return &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr: core.DCTSCAddress,
+			CallValue:  big.NewInt(0),
+			Arguments:  [][]byte{tokenKey, sweepReceiver},
+		},
+		RecipientAddr: dormantAddress,
+		Function:      vmcommon.BuiltInFunctionDCTSweepDormant,
+	}
+}
+
+func TestNewDCTDormantSweepFunc(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil dct storage handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		e, err := NewDCTDormantSweepFunc(ArgsNewDCTDormantSweep{
+			Config: Config{
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				Marshalizer:           &mock.MarshalizerMock{},
+				EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			},
+			MinInactiveEpochs: 10,
+		})
+		assert.True(t, check.IfNil(e))
+		assert.ErrorIs(t, err, ErrNilDCTNFTStorageHandler)
+	})
+	t.Run("nil global settings handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		e, err := NewDCTDormantSweepFunc(ArgsNewDCTDormantSweep{
+			Config: Config{
+				DCTStorageHandler:   createNewDCTDataStorageHandler(),
+				Marshalizer:         &mock.MarshalizerMock{},
+				EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+			},
+			MinInactiveEpochs: 10,
+		})
+		assert.True(t, check.IfNil(e))
+		assert.ErrorIs(t, err, ErrNilGlobalSettingsHandler)
+	})
+	t.Run("nil marshaller should error", func(t *testing.T) {
+		t.Parallel()
+
+		e, err := NewDCTDormantSweepFunc(ArgsNewDCTDormantSweep{
+			Config: Config{
+				DCTStorageHandler:     createNewDCTDataStorageHandler(),
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			},
+			MinInactiveEpochs: 10,
+		})
+		assert.True(t, check.IfNil(e))
+		assert.ErrorIs(t, err, ErrNilMarshalizer)
+	})
+	t.Run("zero min inactive epochs should error", func(t *testing.T) {
+		t.Parallel()
+
+		e, err := NewDCTDormantSweepFunc(ArgsNewDCTDormantSweep{
+			Config: Config{
+				DCTStorageHandler:     createNewDCTDataStorageHandler(),
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				Marshalizer:           &mock.MarshalizerMock{},
+				EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			},
+			MinInactiveEpochs: 0,
+		})
+		assert.True(t, check.IfNil(e))
+		assert.Equal(t, ErrInvalidMinInactiveEpochs, err)
+	})
+	t.Run("nil enable epochs handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		e, err := NewDCTDormantSweepFunc(ArgsNewDCTDormantSweep{
+			Config: Config{
+				DCTStorageHandler:     createNewDCTDataStorageHandler(),
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				Marshalizer:           &mock.MarshalizerMock{},
+			},
+			MinInactiveEpochs: 10,
+		})
+		assert.True(t, check.IfNil(e))
+		assert.ErrorIs(t, err, ErrNilEnableEpochsHandler)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		enableEpochsHandler := &mock.EnableEpochsHandlerStub{}
+		e, err := NewDCTDormantSweepFunc(ArgsNewDCTDormantSweep{
+			Config: Config{
+				DCTStorageHandler:     createNewDCTDataStorageHandler(),
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				Marshalizer:           &mock.MarshalizerMock{},
+				EnableEpochsHandler:   enableEpochsHandler,
+			},
+			MinInactiveEpochs: 10,
+		})
+		assert.False(t, check.IfNil(e))
+		assert.Nil(t, err)
+		assert.False(t, e.IsActive())
+
+		enableEpochsHandler.IsDormantSweepFlagEnabledField = true
+		assert.True(t, e.IsActive())
+
+		err = e.SetAccountActivityHandler(nil)
+		assert.Equal(t, ErrNilAccountActivityHandler, err)
+	})
+}
+
+func TestDCTDormantSweep_ProcessBuiltinFunctionErrors(t *testing.T) {
+	t.Parallel()
+
+	tokenKey := []byte("GAME-abcdef")
+	acntDst := mock.NewUserAccount(dormantAddress)
+
+	e := createDormantSweepFunc(createNewDCTDataStorageHandler(), true, 20)
+	_, err := e.ProcessBuiltinFunction(nil, acntDst, nil)
+	assert.Equal(t, ErrNilVmInput, err)
+
+	vmInput := createDormantSweepInput(tokenKey)
+	vmInput.CallValue = big.NewInt(1)
+	_, err = e.ProcessBuiltinFunction(nil, acntDst, vmInput)
+	assert.Equal(t, ErrBuiltInFunctionCalledWithValue, err)
+
+	vmInput = createDormantSweepInput(tokenKey)
+	vmInput.Arguments = vmInput.Arguments[:1]
+	_, err = e.ProcessBuiltinFunction(nil, acntDst, vmInput)
+	assert.Equal(t, ErrInvalidArguments, err)
+
+	vmInput = createDormantSweepInput(tokenKey)
+	vmInput.CallerAddr = sweepReceiver
+	_, err = e.ProcessBuiltinFunction(nil, acntDst, vmInput)
+	assert.Equal(t, ErrAddressIsNotDCTSystemSC, err)
+
+	_, err = e.ProcessBuiltinFunction(nil, nil, createDormantSweepInput(tokenKey))
+	assert.Equal(t, ErrNilUserAccount, err)
+
+	vmInput = createDormantSweepInput(tokenKey)
+	vmInput.Arguments[1] = []byte("short")
+	_, err = e.ProcessBuiltinFunction(nil, acntDst, vmInput)
+	assert.Equal(t, ErrInvalidAddressLength, err)
+
+	_, err = e.ProcessBuiltinFunction(nil, acntDst, createDormantSweepInput(tokenKey))
+	assert.Equal(t, ErrNoBalanceToSweep, err)
+
+	e = createDormantSweepFunc(createNewDCTDataStorageHandler(), false, 20)
+	_, err = e.ProcessBuiltinFunction(nil, acntDst, createDormantSweepInput(tokenKey))
+	assert.Equal(t, ErrDormantSweepNotAllowed, err)
+
+	e = createDormantSweepFunc(createNewDCTDataStorageHandler(), true, 9)
+	_, err = e.ProcessBuiltinFunction(nil, acntDst, createDormantSweepInput(tokenKey))
+	assert.Equal(t, ErrAccountNotDormant, err)
+
+	expectedErr := errors.New("expected error")
+	e.enableEpochsHandler.(*mock.EnableEpochsHandlerStub).CurrentEpochField = 42
+	requestedEpoch := uint32(0)
+	_ = e.SetAccountActivityHandler(&mock.AccountActivityHandlerStub{
+		GetInactiveEpochsCalled: func(address []byte, epoch uint32) (uint32, error) {
+			requestedEpoch = epoch
+			return 0, expectedErr
+		},
+	})
+	_, err = e.ProcessBuiltinFunction(nil, acntDst, createDormantSweepInput(tokenKey))
+	assert.Equal(t, expectedErr, err)
+	assert.Equal(t, uint32(42), requestedEpoch)
+}
+
+func TestDCTDormantSweep_ProcessBuiltinFunctionWithoutActivityHandlerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	globalSettingsHandler := &mock.GlobalSettingsHandlerStub{
+		IsDormantSweepAllowedCalled: func(token []byte) bool {
+			return true
+		},
+	}
+	e, _ := NewDCTDormantSweepFunc(ArgsNewDCTDormantSweep{
+		Config: Config{
+			DCTStorageHandler:     createNewDCTDataStorageHandler(),
+			GlobalSettingsHandler: globalSettingsHandler,
+			Marshalizer:           &mock.MarshalizerMock{},
+			EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+		},
+		MinInactiveEpochs: 10,
+	})
+
+	_, err := e.ProcessBuiltinFunction(nil, mock.NewUserAccount(dormantAddress), createDormantSweepInput([]byte("GAME-abcdef")))
+	assert.Equal(t, ErrAccountNotDormant, err)
+}
+
+func TestDCTDormantSweep_ProcessBuiltinFunctionFungible(t *testing.T) {
+	t.Parallel()
+
+	marshaller := &mock.MarshalizerMock{}
+	tokenID := []byte("GAME-abcdef")
+	acntDst := mock.NewUserAccount(dormantAddress)
+	dctTokenKey := append([]byte(baseDCTKeyPrefix), tokenID...)
+	marshalledToken, _ := marshaller.Marshal(&dct.DCToken{Value: big.NewInt(100)})
+	_ = acntDst.AccountDataHandler().SaveKeyValue(dctTokenKey, marshalledToken)
+
+	e := createDormantSweepFunc(createNewDCTDataStorageHandler(), true, 10)
+	vmOutput, err := e.ProcessBuiltinFunction(nil, acntDst, createDormantSweepInput(tokenID))
+	require.Nil(t, err)
+	assert.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+
+	val, _, _ := acntDst.AccountDataHandler().RetrieveValue(dctTokenKey)
+	assert.Len(t, val, 0)
+
+	outAcc := vmOutput.OutputAccounts[string(sweepReceiver)]
+	require.NotNil(t, outAcc)
+	require.Len(t, outAcc.OutputTransfers, 1)
+	expectedData := core.BuiltInFunctionDCTTransfer + "@" + hex.EncodeToString(tokenID) + "@" + hex.EncodeToString(big.NewInt(100).Bytes())
+	assert.Equal(t, expectedData, string(outAcc.OutputTransfers[0].Data))
+	assert.Equal(t, dormantAddress, outAcc.OutputTransfers[0].SenderAddress)
+
+	require.Len(t, vmOutput.Logs, 1)
+	assert.Equal(t, []byte(vmcommon.BuiltInFunctionDCTSweepDormant), vmOutput.Logs[0].Identifier)
+	assert.Equal(t, [][]byte{tokenID, {}, big.NewInt(100).Bytes(), sweepReceiver}, vmOutput.Logs[0].Topics)
+}
+
+func TestDCTDormantSweep_ProcessBuiltinFunctionNonFungible(t *testing.T) {
+	t.Parallel()
+
+	tokenID := []byte("GAME-abcdef")
+	nonce := uint64(5)
+	dctStorageHandler := createNewDCTDataStorageHandler()
+	acntDst := mock.NewUserAccount(dormantAddress)
+	dctTokenKey := append([]byte(baseDCTKeyPrefix), tokenID...)
+	token := &dct.DCToken{
+		Type:  uint32(core.NonFungible),
+		Value: big.NewInt(1),
+		TokenMetaData: &dct.MetaData{
+			Nonce: nonce,
+			Name:  []byte("sword"),
+		},
+	}
+	_, err := dctStorageHandler.SaveDCTNFTToken(dormantAddress, acntDst, dctTokenKey, nonce, token, true, false)
+	require.Nil(t, err)
+	err = dctStorageHandler.AddToLiquiditySystemAcc(dctTokenKey, nonce, big.NewInt(1))
+	require.Nil(t, err)
+
+	e := createDormantSweepFunc(dctStorageHandler, true, 10)
+	tokenKey := append(append([]byte{}, tokenID...), big.NewInt(int64(nonce)).Bytes()...)
+	vmOutput, err := e.ProcessBuiltinFunction(nil, acntDst, createDormantSweepInput(tokenKey))
+	require.Nil(t, err)
+
+	_, err = dctStorageHandler.GetDCTNFTTokenOnSender(acntDst, dctTokenKey, nonce)
+	assert.Equal(t, ErrNewNFTDataOnSenderAddress, err)
+
+	outAcc := vmOutput.OutputAccounts[string(sweepReceiver)]
+	require.NotNil(t, outAcc)
+	marshalledToken, _ := e.marshaller.Marshal(token)
+	expectedData := core.BuiltInFunctionDCTNFTTransfer + "@" + hex.EncodeToString(tokenID) + "@" + hex.EncodeToString(big.NewInt(int64(nonce)).Bytes()) +
+		"@" + hex.EncodeToString(big.NewInt(1).Bytes()) + "@" + hex.EncodeToString(marshalledToken)
+	assert.Equal(t, expectedData, string(outAcc.OutputTransfers[0].Data))
+
+	require.Len(t, vmOutput.Logs, 1)
+	assert.Equal(t, [][]byte{tokenID, big.NewInt(int64(nonce)).Bytes(), big.NewInt(1).Bytes(), sweepReceiver}, vmOutput.Logs[0].Topics)
+}
@@ -1,49 +1,55 @@
 package builtInFunctions
 
 import (
-	"bytes"
 	"math/big"
 
 	"github.com/Reshusk23/sr-me-core/core"
 	"github.com/Reshusk23/sr-me-core/core/check"
 	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/tokenident"
 )
 
 type dctFreezeWipe struct {
 	baseAlwaysActiveHandler
+	baseSystemAddressesHandler
 	dctStorageHandler   vmcommon.DCTNFTStorageHandler
 	enableEpochsHandler vmcommon.EnableEpochsHandler
 	marshaller          vmcommon.Marshalizer
+	storageUsageTracker vmcommon.StorageUsageTracker
 	keyPrefix           []byte
 	wipe                bool
 	freeze              bool
 }
 
+// ArgsNewDCTFreezeWipe defines the argument list for new dct freeze/un-freeze/wipe built in functions
+type ArgsNewDCTFreezeWipe struct {
+	Config
+	Freeze bool
+	Wipe   bool
+}
+
 // NewDCTFreezeWipeFunc returns the dct freeze/un-freeze/wipe built-in function component
-func NewDCTFreezeWipeFunc(
-	dctStorageHandler vmcommon.DCTNFTStorageHandler,
-	enableEpochsHandler vmcommon.EnableEpochsHandler,
-	marshaller vmcommon.Marshalizer,
-	freeze bool,
-	wipe bool,
-) (*dctFreezeWipe, error) {
-	if check.IfNil(marshaller) {
-		return nil, ErrNilMarshalizer
+func NewDCTFreezeWipeFunc(args ArgsNewDCTFreezeWipe) (*dctFreezeWipe, error) {
+	function := core.BuiltInFunctionDCTUnFreeze
+	if args.Freeze {
+		function = core.BuiltInFunctionDCTFreeze
 	}
-	if check.IfNil(dctStorageHandler) {
-		return nil, ErrNilDCTNFTStorageHandler
+	if args.Wipe {
+		function = core.BuiltInFunctionDCTWipe
 	}
-	if check.IfNil(enableEpochsHandler) {
-		return nil, ErrNilEnableEpochsHandler
+	err := args.checkComponents(function, requireMarshalizer|requireDCTStorageHandler|requireEnableEpochsHandler)
+	if err != nil {
+		return nil, err
 	}
 
 	e := &dctFreezeWipe{
-		dctStorageHandler:   dctStorageHandler,
-		enableEpochsHandler: enableEpochsHandler,
-		marshaller:          marshaller,
+		dctStorageHandler:   args.DCTStorageHandler,
+		enableEpochsHandler: args.EnableEpochsHandler,
+		marshaller:          args.Marshalizer,
+		storageUsageTracker: &disabledStorageUsageTracker{},
 		keyPrefix:           []byte(baseDCTKeyPrefix),
-		freeze:              freeze,
-		wipe:                wipe,
+		freeze:              args.Freeze,
+		wipe:                args.Wipe,
 	}
 
 	return e, nil
@@ -53,6 +59,18 @@ func NewDCTFreezeWipeFunc(
 func (e *dctFreezeWipe) SetNewGasConfig(_ *vmcommon.GasCost) {
 }
 
+// SetStorageUsageTracker sets the tracker releasing the metadata bytes of the NFTs wiped from an account. The usage
+// never goes below zero, so releasing the bytes accounted to another account is harmless
+func (e *dctFreezeWipe) SetStorageUsageTracker(storageUsageTracker vmcommon.StorageUsageTracker) error {
+	if check.IfNil(storageUsageTracker) {
+		return ErrNilStorageUsageTracker
+	}
+
+	e.storageUsageTracker = storageUsageTracker
+
+	return nil
+}
+
 // ProcessBuiltinFunction resolves DCT transfer function call
 func (e *dctFreezeWipe) ProcessBuiltinFunction(
 	_, acntDst vmcommon.UserAccountHandler,
@@ -61,13 +79,14 @@ func (e *dctFreezeWipe) ProcessBuiltinFunction(
 	if vmInput == nil {
 		return nil, ErrNilVmInput
 	}
-	if vmInput.CallValue.Cmp(zero) != 0 {
-		return nil, ErrBuiltInFunctionCalledWithValue
+	err := checkCallValue(vmcommon.CallValueForbidden, vmInput.CallValue)
+	if err != nil {
+		return nil, err
 	}
 	if len(vmInput.Arguments) != 1 {
 		return nil, ErrInvalidArguments
 	}
-	if !bytes.Equal(vmInput.CallerAddr, core.DCTSCAddress) {
+	if !e.getSystemAddresses().IsDCTSCAddress(vmInput.CallerAddr) {
 		return nil, ErrAddressIsNotDCTSystemSC
 	}
 	if check.IfNil(acntDst) {
@@ -75,11 +94,9 @@ func (e *dctFreezeWipe) ProcessBuiltinFunction(
 	}
 
 	dctTokenKey := append(e.keyPrefix, vmInput.Arguments[0]...)
-	identifier, nonce := extractTokenIdentifierAndNonceDCTWipe(vmInput.Arguments[0])
+	identifier, nonce := tokenident.SplitCollectionAndNonce(vmInput.Arguments[0])
 
 	var amount *big.Int
-	var err error
-
 	if e.wipe {
 		amount, err = e.wipeIfApplicable(acntDst, dctTokenKey, identifier, nonce)
 		if err != nil {
@@ -110,6 +127,10 @@ func (e *dctFreezeWipe) wipeIfApplicable(acntDst vmcommon.UserAccountHandler, to
 		return nil, ErrCannotWipeAccountNotFrozen
 	}
 
+	err = e.releaseMetaDataStorageUsage(acntDst, identifier, nonce)
+	if err != nil {
+		return nil, err
+	}
 	err = acntDst.AccountDataHandler().SaveKeyValue(tokenKey, nil)
 	if err != nil {
 		return nil, err
@@ -124,6 +145,21 @@ func (e *dctFreezeWipe) wipeIfApplicable(acntDst vmcommon.UserAccountHandler, to
 	return wipedAmount, nil
 }
 
+// releaseMetaDataStorageUsage releases the metadata bytes of the wiped NFT, read before the token is removed as the
+// metadata may be kept on the system account. Wiping a token without readable metadata releases nothing
+func (e *dctFreezeWipe) releaseMetaDataStorageUsage(acntDst vmcommon.UserAccountHandler, identifier []byte, nonce uint64) error {
+	if nonce == 0 {
+		return nil
+	}
+
+	dctData, err := e.dctStorageHandler.GetDCTNFTTokenOnSender(acntDst, append(e.keyPrefix, identifier...), nonce)
+	if err != nil || dctData == nil || dctData.TokenMetaData == nil {
+		return nil
+	}
+
+	return e.storageUsageTracker.TrackStorageUsage(acntDst, -getMetaDataSize(dctData.TokenMetaData))
+}
+
 func (e *dctFreezeWipe) removeLiquidity(tokenIdentifier []byte, nonce uint64, value *big.Int) error {
 	if !e.enableEpochsHandler.IsWipeSingleNFTLiquidityDecreaseEnabled() {
 		return nil
@@ -16,7 +16,15 @@ func TestDCTFreezeWipe_ProcessBuiltInFunctionErrors(t *testing.T) {
 	t.Parallel()
 
 	marshaller := &mock.MarshalizerMock{}
-	freeze, _ := NewDCTFreezeWipeFunc(createNewDCTDataStorageHandler(), &mock.EnableEpochsHandlerStub{}, marshaller, true, false)
+	freeze, _ := NewDCTFreezeWipeFunc(ArgsNewDCTFreezeWipe{
+		Config: Config{
+			DCTStorageHandler:   createNewDCTDataStorageHandler(),
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+			Marshalizer:         marshaller,
+		},
+		Freeze: true,
+		Wipe:   false,
+	})
 	_, err := freeze.ProcessBuiltinFunction(nil, nil, nil)
 	assert.Equal(t, err, ErrNilVmInput)
 
@@ -75,7 +83,15 @@ func TestDCTFreezeWipe_ProcessBuiltInFunction(t *testing.T) {
 	t.Parallel()
 
 	marshaller := &mock.MarshalizerMock{}
-	freeze, _ := NewDCTFreezeWipeFunc(createNewDCTDataStorageHandler(), &mock.EnableEpochsHandlerStub{}, marshaller, true, false)
+	freeze, _ := NewDCTFreezeWipeFunc(ArgsNewDCTFreezeWipe{
+		Config: Config{
+			DCTStorageHandler:   createNewDCTDataStorageHandler(),
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+			Marshalizer:         marshaller,
+		},
+		Freeze: true,
+		Wipe:   false,
+	})
 	_, err := freeze.ProcessBuiltinFunction(nil, nil, nil)
 	assert.Equal(t, err, ErrNilVmInput)
 
@@ -105,7 +121,15 @@ func TestDCTFreezeWipe_ProcessBuiltInFunction(t *testing.T) {
 	dctUserData := DCTUserMetadataFromBytes(dctToken.Properties)
 	assert.True(t, dctUserData.Frozen)
 
-	unFreeze, _ := NewDCTFreezeWipeFunc(createNewDCTDataStorageHandler(), &mock.EnableEpochsHandlerStub{}, marshaller, false, false)
+	unFreeze, _ := NewDCTFreezeWipeFunc(ArgsNewDCTFreezeWipe{
+		Config: Config{
+			DCTStorageHandler:   createNewDCTDataStorageHandler(),
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+			Marshalizer:         marshaller,
+		},
+		Freeze: false,
+		Wipe:   false,
+	})
 	_, err = unFreeze.ProcessBuiltinFunction(nil, acnt, input)
 	assert.Nil(t, err)
 
@@ -116,7 +140,15 @@ func TestDCTFreezeWipe_ProcessBuiltInFunction(t *testing.T) {
 	assert.False(t, dctUserData.Frozen)
 
 	// cannot wipe if account is not frozen
-	wipe, _ := NewDCTFreezeWipeFunc(createNewDCTDataStorageHandler(), &mock.EnableEpochsHandlerStub{}, marshaller, false, true)
+	wipe, _ := NewDCTFreezeWipeFunc(ArgsNewDCTFreezeWipe{
+		Config: Config{
+			DCTStorageHandler:   createNewDCTDataStorageHandler(),
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+			Marshalizer:         marshaller,
+		},
+		Freeze: false,
+		Wipe:   true,
+	})
 	_, err = wipe.ProcessBuiltinFunction(nil, acnt, input)
 	assert.Equal(t, ErrCannotWipeAccountNotFrozen, err)
 
@@ -134,7 +166,15 @@ func TestDCTFreezeWipe_ProcessBuiltInFunction(t *testing.T) {
 	err = acnt.AccountDataHandler().SaveKeyValue(dctKey, dctTokenBytes)
 	assert.NoError(t, err)
 
-	wipe, _ = NewDCTFreezeWipeFunc(createNewDCTDataStorageHandler(), &mock.EnableEpochsHandlerStub{}, marshaller, false, true)
+	wipe, _ = NewDCTFreezeWipeFunc(ArgsNewDCTFreezeWipe{
+		Config: Config{
+			DCTStorageHandler:   createNewDCTDataStorageHandler(),
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+			Marshalizer:         marshaller,
+		},
+		Freeze: false,
+		Wipe:   true,
+	})
 	vmOutput, err := wipe.ProcessBuiltinFunction(nil, acnt, input)
 	assert.NoError(t, err)
 
@@ -158,7 +198,15 @@ func TestDctFreezeWipe_WipeShouldDecreaseLiquidityIfFlagIsEnabled(t *testing.T)
 	}
 
 	marshaller := &mock.MarshalizerMock{}
-	wipe, _ := NewDCTFreezeWipeFunc(dctStorage, &mock.EnableEpochsHandlerStub{}, marshaller, false, true)
+	wipe, _ := NewDCTFreezeWipeFunc(ArgsNewDCTFreezeWipe{
+		Config: Config{
+			DCTStorageHandler:   dctStorage,
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+			Marshalizer:         marshaller,
+		},
+		Freeze: false,
+		Wipe:   true,
+	})
 
 	acnt := mock.NewUserAccount([]byte("dst"))
 	metaData := DCTUserMetadata{Frozen: true}
@@ -203,3 +251,57 @@ func TestDctFreezeWipe_WipeShouldDecreaseLiquidityIfFlagIsEnabled(t *testing.T)
 	assert.Equal(t, 0, len(marshaledData))
 	assert.True(t, addToLiquiditySystemAccCalled)
 }
+
+func TestDctFreezeWipe_WipeShouldReleaseTheMetaDataStorageUsage(t *testing.T) {
+	t.Parallel()
+
+	metaData := &dct.MetaData{
+		Name: []byte("name"),
+		URIs: [][]byte{[]byte("uri")},
+	}
+	dctStorage := &mock.DCTNFTStorageHandlerStub{
+		GetDCTNFTTokenOnSenderCalled: func(_ vmcommon.UserAccountHandler, _ []byte, _ uint64) (*dct.DCToken, error) {
+			return &dct.DCToken{Value: big.NewInt(1), TokenMetaData: metaData}, nil
+		},
+	}
+	marshaller := &mock.MarshalizerMock{}
+	wipe, _ := NewDCTFreezeWipeFunc(ArgsNewDCTFreezeWipe{
+		Config: Config{
+			DCTStorageHandler:   dctStorage,
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+			Marshalizer:         marshaller,
+		},
+		Freeze: false,
+		Wipe:   true,
+	})
+	require.Equal(t, ErrNilStorageUsageTracker, wipe.SetStorageUsageTracker(nil))
+	tracker := NewStorageUsageTracker(vmcommon.StorageUsageConfig{})
+	require.Nil(t, wipe.SetStorageUsageTracker(tracker))
+
+	acnt := mock.NewUserAccount([]byte("dst"))
+	require.Nil(t, tracker.TrackStorageUsage(acnt, 10))
+	userMetadata := DCTUserMetadata{Frozen: true}
+	dctTokenBytes, _ := marshaller.Marshal(&dct.DCToken{Value: big.NewInt(1), Properties: userMetadata.ToBytes()})
+
+	wipeToken := func(key []byte) {
+		err := acnt.AccountDataHandler().SaveKeyValue(append(wipe.keyPrefix, key...), dctTokenBytes)
+		require.Nil(t, err)
+
+		input := &vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallValue:  big.NewInt(0),
+				CallerAddr: core.DCTSCAddress,
+				Arguments:  [][]byte{key},
+			},
+			RecipientAddr: []byte("dst"),
+		}
+		_, err = wipe.ProcessBuiltinFunction(nil, acnt, input)
+		require.Nil(t, err)
+	}
+
+	wipeToken([]byte("TKN-0a0a0a"))
+	assert.Equal(t, uint64(10), tracker.GetStorageUsage(acnt), "a fungible token has no metadata")
+
+	wipeToken(append([]byte("MYNFT-0a0a0a"), 5))
+	assert.Equal(t, uint64(10-len("name")-len("uri")), tracker.GetStorageUsage(acnt))
+}
@@ -4,50 +4,52 @@ import (
 	"bytes"
 
 	"github.com/Reshusk23/sr-me-core/core"
-	"github.com/Reshusk23/sr-me-core/core/check"
 	"github.com/Reshusk23/sr-me-core/marshal"
 	vmcommon "github.com/Reshusk23/sr-vm-common-go"
 )
 
 type dctGlobalSettings struct {
 	baseActiveHandler
-	keyPrefix  []byte
-	set        bool
-	accounts   vmcommon.AccountsAdapter
-	marshaller marshal.Marshalizer
-	function   string
+	baseSystemAddressesHandler
+	keyPrefix           []byte
+	set                 bool
+	accounts            vmcommon.AccountsAdapter
+	marshaller          marshal.Marshalizer
+	function            string
+	enableEpochsHandler vmcommon.EnableEpochsHandler
+}
+
+// ArgsNewDCTGlobalSettings defines the argument list for new dct global settings built in functions
+type ArgsNewDCTGlobalSettings struct {
+	Config
+	Set           bool
+	Function      string
+	ActiveHandler func() bool
 }
 
 // NewDCTGlobalSettingsFunc returns the dct pause/un-pause built-in function component
-func NewDCTGlobalSettingsFunc(
-	accounts vmcommon.AccountsAdapter,
-	marshaller marshal.Marshalizer,
-	set bool,
-	function string,
-	activeHandler func() bool,
-) (*dctGlobalSettings, error) {
-	if check.IfNil(accounts) {
-		return nil, ErrNilAccountsAdapter
-	}
-	if check.IfNil(marshaller) {
-		return nil, ErrNilMarshalizer
-	}
-	if activeHandler == nil {
+func NewDCTGlobalSettingsFunc(args ArgsNewDCTGlobalSettings) (*dctGlobalSettings, error) {
+	err := args.checkComponents(args.Function, requireMarshalizer|requireAccounts|requireEnableEpochsHandler)
+	if err != nil {
+		return nil, err
+	}
+	if args.ActiveHandler == nil {
 		return nil, ErrNilActiveHandler
 	}
-	if !isCorrectFunction(function) {
+	if !isCorrectFunction(args.Function) {
 		return nil, ErrInvalidArguments
 	}
 
 	e := &dctGlobalSettings{
-		keyPrefix:  []byte(baseDCTKeyPrefix),
-		set:        set,
-		accounts:   accounts,
-		marshaller: marshaller,
-		function:   function,
+		keyPrefix:           []byte(baseDCTKeyPrefix),
+		set:                 args.Set,
+		accounts:            args.Accounts,
+		marshaller:          args.Marshalizer,
+		function:            args.Function,
+		enableEpochsHandler: args.EnableEpochsHandler,
 	}
 
-	e.baseActiveHandler.activeHandler = activeHandler
+	e.baseActiveHandler.activeHandler = args.ActiveHandler
 
 	return e, nil
 }
@@ -58,6 +60,14 @@ func isCorrectFunction(function string) bool {
 		return true
 	case vmcommon.BuiltInFunctionDCTSetBurnRoleForAll, vmcommon.BuiltInFunctionDCTUnSetBurnRoleForAll:
 		return true
+	case vmcommon.BuiltInFunctionDCTSetDormantSweep, vmcommon.BuiltInFunctionDCTUnSetDormantSweep:
+		return true
+	case vmcommon.BuiltInFunctionDCTSetMultiSigManaged, vmcommon.BuiltInFunctionDCTUnSetMultiSigManaged:
+		return true
+	case vmcommon.BuiltInFunctionDCTSetSoulbound, vmcommon.BuiltInFunctionDCTUnSetSoulbound:
+		return true
+	case vmcommon.BuiltInFunctionDCTStopNFTCreate, vmcommon.BuiltInFunctionDCTResumeNFTCreate:
+		return true
 	default:
 		return false
 	}
@@ -75,30 +85,48 @@ func (e *dctGlobalSettings) ProcessBuiltinFunction(
 	if vmInput == nil {
 		return nil, ErrNilVmInput
 	}
-	if vmInput.CallValue.Cmp(zero) != 0 {
-		return nil, ErrBuiltInFunctionCalledWithValue
+	err := checkCallValue(vmcommon.CallValueForbidden, vmInput.CallValue)
+	if err != nil {
+		return nil, err
 	}
 	if len(vmInput.Arguments) != 1 {
 		return nil, ErrInvalidArguments
 	}
-	if !bytes.Equal(vmInput.CallerAddr, core.DCTSCAddress) {
+	if !e.getSystemAddresses().IsDCTSCAddress(vmInput.CallerAddr) {
 		return nil, ErrAddressIsNotDCTSystemSC
 	}
-	if !vmcommon.IsSystemAccountAddress(vmInput.RecipientAddr) {
+	if !e.getSystemAddresses().IsSystemAccountAddress(vmInput.RecipientAddr) {
 		return nil, ErrOnlySystemAccountAccepted
 	}
 
 	dctTokenKey := append(e.keyPrefix, vmInput.Arguments[0]...)
 
-	err := e.toggleSetting(dctTokenKey)
+	err = e.toggleSetting(dctTokenKey)
 	if err != nil {
 		return nil, err
 	}
 
 	vmOutput := &vmcommon.VMOutput{ReturnCode: vmcommon.Ok}
+	if e.isToggleWithEvent() {
+		addDCTEntryInVMOutput(vmOutput, []byte(e.function), vmInput.Arguments[0], 0, zero)
+	}
+
 	return vmOutput, nil
 }
 
+// isToggleWithEvent returns true for the settings the indexers follow, which are changed by the token manager after
+// the token was issued: the NFT creation stop and the burn role granted to all the holders
+func (e *dctGlobalSettings) isToggleWithEvent() bool {
+	switch e.function {
+	case vmcommon.BuiltInFunctionDCTStopNFTCreate, vmcommon.BuiltInFunctionDCTResumeNFTCreate:
+		return true
+	case vmcommon.BuiltInFunctionDCTSetBurnRoleForAll, vmcommon.BuiltInFunctionDCTUnSetBurnRoleForAll:
+		return true
+	default:
+		return false
+	}
+}
+
 func (e *dctGlobalSettings) toggleSetting(dctTokenKey []byte) error {
 	systemSCAccount, err := e.getSystemAccount()
 	if err != nil {
@@ -120,9 +148,21 @@ func (e *dctGlobalSettings) toggleSetting(dctTokenKey []byte) error {
 	case vmcommon.BuiltInFunctionDCTUnSetBurnRoleForAll, vmcommon.BuiltInFunctionDCTSetBurnRoleForAll:
 		dctMetaData.BurnRoleForAll = e.set
 		break
+	case vmcommon.BuiltInFunctionDCTSetDormantSweep, vmcommon.BuiltInFunctionDCTUnSetDormantSweep:
+		dctMetaData.DormantSweepAllowed = e.set
+		break
+	case vmcommon.BuiltInFunctionDCTSetMultiSigManaged, vmcommon.BuiltInFunctionDCTUnSetMultiSigManaged:
+		dctMetaData.MultiSigManaged = e.set
+		break
+	case vmcommon.BuiltInFunctionDCTSetSoulbound, vmcommon.BuiltInFunctionDCTUnSetSoulbound:
+		dctMetaData.Soulbound = e.set
+		break
+	case vmcommon.BuiltInFunctionDCTStopNFTCreate, vmcommon.BuiltInFunctionDCTResumeNFTCreate:
+		dctMetaData.NFTCreateStopped = e.set
+		break
 	}
 
-	err = systemSCAccount.AccountDataHandler().SaveKeyValue(dctTokenKey, dctMetaData.ToBytes())
+	err = systemSCAccount.AccountDataHandler().SaveKeyValue(dctTokenKey, e.encodeGlobalMetadata(dctMetaData))
 	if err != nil {
 		return err
 	}
@@ -130,8 +170,62 @@ func (e *dctGlobalSettings) toggleSetting(dctTokenKey []byte) error {
 	return e.accounts.SaveAccount(systemSCAccount)
 }
 
+// encodeGlobalMetadata returns the metadata in the versioned layout once the versioning is active, the entries being
+// thus migrated whenever a setting of the token changes
+func (e *dctGlobalSettings) encodeGlobalMetadata(dctMetaData *DCTGlobalMetadata) []byte {
+	if e.enableEpochsHandler.IsGlobalSettingsVersioningFlagEnabled() {
+		return dctMetaData.ToVersionedBytes()
+	}
+
+	return dctMetaData.ToBytes()
+}
+
+// MigrateGlobalMetadata rewrites in the versioned layout the global metadata of the provided tokens still stored in
+// the legacy layout and returns the number of migrated entries. The migration is allowed only once the versioning is
+// active, as the legacy readers can not decode the versioned layout
+func (e *dctGlobalSettings) MigrateGlobalMetadata(tokenIDs [][]byte) (int, error) {
+	if !e.enableEpochsHandler.IsGlobalSettingsVersioningFlagEnabled() {
+		return 0, ErrGlobalSettingsVersioningNotActive
+	}
+
+	systemSCAccount, err := e.getSystemAccount()
+	if err != nil {
+		return 0, err
+	}
+
+	numMigrated := 0
+	for _, tokenID := range tokenIDs {
+		dctTokenKey := append(e.keyPrefix, tokenID...)
+		val, _, errRetrieve := systemSCAccount.AccountDataHandler().RetrieveValue(dctTokenKey)
+		if errRetrieve != nil {
+			continue
+		}
+
+		migrated, wasMigrated := MigrateDCTGlobalMetadata(val)
+		if !wasMigrated {
+			continue
+		}
+
+		err = systemSCAccount.AccountDataHandler().SaveKeyValue(dctTokenKey, migrated)
+		if err != nil {
+			return 0, err
+		}
+		numMigrated++
+	}
+	if numMigrated == 0 {
+		return 0, nil
+	}
+
+	err = e.accounts.SaveAccount(systemSCAccount)
+	if err != nil {
+		return 0, err
+	}
+
+	return numMigrated, nil
+}
+
 func (e *dctGlobalSettings) getSystemAccount() (vmcommon.UserAccountHandler, error) {
-	systemSCAccount, err := e.accounts.LoadAccount(vmcommon.SystemAccountAddress)
+	systemSCAccount, err := e.accounts.LoadAccount(e.getSystemAddresses().SystemAccountAddress)
 	if err != nil {
 		return nil, err
 	}
@@ -174,6 +268,46 @@ func (e *dctGlobalSettings) IsBurnForAll(dctTokenKey []byte) bool {
 	return dctMetadata.BurnRoleForAll
 }
 
+// IsDormantSweepAllowed returns true if the dctTokenKey (prefixed) can be reclaimed from dormant accounts
+func (e *dctGlobalSettings) IsDormantSweepAllowed(dctTokenKey []byte) bool {
+	dctMetadata, err := e.getGlobalMetadata(dctTokenKey)
+	if err != nil {
+		return false
+	}
+
+	return dctMetadata.DormantSweepAllowed
+}
+
+// IsMultiSigManaged returns true if the management operations of the dctTokenKey (prefixed) require multiple signatures
+func (e *dctGlobalSettings) IsMultiSigManaged(dctTokenKey []byte) bool {
+	dctMetadata, err := e.getGlobalMetadata(dctTokenKey)
+	if err != nil {
+		return false
+	}
+
+	return dctMetadata.MultiSigManaged
+}
+
+// IsSoulbound returns true if the dctTokenKey (prefixed) can not be transferred between accounts
+func (e *dctGlobalSettings) IsSoulbound(dctTokenKey []byte) bool {
+	dctMetadata, err := e.getGlobalMetadata(dctTokenKey)
+	if err != nil {
+		return false
+	}
+
+	return dctMetadata.Soulbound
+}
+
+// IsNFTCreateStopped returns true if the token manager stopped the creation of new NFTs for the dctTokenKey (prefixed)
+func (e *dctGlobalSettings) IsNFTCreateStopped(dctTokenKey []byte) bool {
+	dctMetadata, err := e.getGlobalMetadata(dctTokenKey)
+	if err != nil {
+		return false
+	}
+
+	return dctMetadata.NFTCreateStopped
+}
+
 // IsSenderOrDestinationWithTransferRole returns true if we have transfer role on the system account
 func (e *dctGlobalSettings) IsSenderOrDestinationWithTransferRole(sender, destination, tokenID []byte) bool {
 	if !e.activeHandler() {
@@ -200,6 +334,22 @@ func (e *dctGlobalSettings) IsSenderOrDestinationWithTransferRole(sender, destin
 	return false
 }
 
+// GetCollectionConfig returns the limits set by the owner of the provided collection
+func (e *dctGlobalSettings) GetCollectionConfig(tokenID []byte) vmcommon.CollectionConfig {
+	systemAcc, err := e.getSystemAccount()
+	if err != nil {
+		return vmcommon.CollectionConfig{}
+	}
+
+	return getCollectionConfig(systemAcc, tokenID)
+}
+
+// IsMetaDCT returns true if the provided collection holds meta dct tokens
+func (e *dctGlobalSettings) IsMetaDCT(tokenID []byte) bool {
+	config := e.GetCollectionConfig(tokenID)
+	return config.IsMetaDCT
+}
+
 func (e *dctGlobalSettings) getGlobalMetadata(dctTokenKey []byte) (*DCTGlobalMetadata, error) {
 	systemSCAccount, err := e.getSystemAccount()
 	if err != nil {
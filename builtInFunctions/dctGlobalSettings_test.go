@@ -9,6 +9,7 @@ import (
 	vmcommon "github.com/Reshusk23/sr-vm-common-go"
 	"github.com/Reshusk23/sr-vm-common-go/mock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewDCTGlobalSettingsFunc(t *testing.T) {
@@ -17,28 +18,77 @@ func TestNewDCTGlobalSettingsFunc(t *testing.T) {
 	t.Run("nil accounts should error", func(t *testing.T) {
 		t.Parallel()
 
-		globalSettingsFunc, err := NewDCTGlobalSettingsFunc(nil, &mock.MarshalizerMock{}, true, core.BuiltInFunctionDCTPause, trueHandler)
-		assert.Equal(t, ErrNilAccountsAdapter, err)
+		globalSettingsFunc, err := NewDCTGlobalSettingsFunc(ArgsNewDCTGlobalSettings{
+			Config: Config{
+				Marshalizer:         &mock.MarshalizerMock{},
+				EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+			},
+			Set:           true,
+			Function:      core.BuiltInFunctionDCTPause,
+			ActiveHandler: trueHandler,
+		})
+		assert.ErrorIs(t, err, ErrNilAccountsAdapter)
 		assert.True(t, check.IfNil(globalSettingsFunc))
 	})
 	t.Run("nil marshaller should error", func(t *testing.T) {
 		t.Parallel()
 
-		globalSettingsFunc, err := NewDCTGlobalSettingsFunc(&mock.AccountsStub{}, nil, true, core.BuiltInFunctionDCTPause, trueHandler)
-		assert.Equal(t, ErrNilMarshalizer, err)
+		globalSettingsFunc, err := NewDCTGlobalSettingsFunc(ArgsNewDCTGlobalSettings{
+			Config: Config{
+				Accounts:            &mock.AccountsStub{},
+				EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+			},
+			Set:           true,
+			Function:      core.BuiltInFunctionDCTPause,
+			ActiveHandler: trueHandler,
+		})
+		assert.ErrorIs(t, err, ErrNilMarshalizer)
 		assert.True(t, check.IfNil(globalSettingsFunc))
 	})
 	t.Run("nil active handler should error", func(t *testing.T) {
 		t.Parallel()
 
-		globalSettingsFunc, err := NewDCTGlobalSettingsFunc(&mock.AccountsStub{}, &mock.MarshalizerMock{}, true, core.BuiltInFunctionDCTPause, nil)
+		globalSettingsFunc, err := NewDCTGlobalSettingsFunc(ArgsNewDCTGlobalSettings{
+			Config: Config{
+				Accounts:            &mock.AccountsStub{},
+				Marshalizer:         &mock.MarshalizerMock{},
+				EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+			},
+			Set:           true,
+			Function:      core.BuiltInFunctionDCTPause,
+			ActiveHandler: nil,
+		})
 		assert.Equal(t, ErrNilActiveHandler, err)
 		assert.True(t, check.IfNil(globalSettingsFunc))
 	})
+	t.Run("nil enable epochs handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		globalSettingsFunc, err := NewDCTGlobalSettingsFunc(ArgsNewDCTGlobalSettings{
+			Config: Config{
+				Accounts:    &mock.AccountsStub{},
+				Marshalizer: &mock.MarshalizerMock{},
+			},
+			Set:           true,
+			Function:      core.BuiltInFunctionDCTPause,
+			ActiveHandler: trueHandler,
+		})
+		assert.ErrorIs(t, err, ErrNilEnableEpochsHandler)
+		assert.True(t, check.IfNil(globalSettingsFunc))
+	})
 	t.Run("should work", func(t *testing.T) {
 		t.Parallel()
 
-		globalSettingsFunc, err := NewDCTGlobalSettingsFunc(&mock.AccountsStub{}, &mock.MarshalizerMock{}, true, core.BuiltInFunctionDCTPause, falseHandler)
+		globalSettingsFunc, err := NewDCTGlobalSettingsFunc(ArgsNewDCTGlobalSettings{
+			Config: Config{
+				Accounts:            &mock.AccountsStub{},
+				Marshalizer:         &mock.MarshalizerMock{},
+				EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+			},
+			Set:           true,
+			Function:      core.BuiltInFunctionDCTPause,
+			ActiveHandler: falseHandler,
+		})
 		assert.Nil(t, err)
 		assert.False(t, check.IfNil(globalSettingsFunc))
 	})
@@ -48,11 +98,20 @@ func TestDCTGlobalSettingsPause_ProcessBuiltInFunction(t *testing.T) {
 	t.Parallel()
 
 	acnt := mock.NewUserAccount(vmcommon.SystemAccountAddress)
-	globalSettingsFunc, _ := NewDCTGlobalSettingsFunc(&mock.AccountsStub{
-		LoadAccountCalled: func(address []byte) (vmcommon.AccountHandler, error) {
-			return acnt, nil
+	globalSettingsFunc, _ := NewDCTGlobalSettingsFunc(ArgsNewDCTGlobalSettings{
+		Config: Config{
+			Accounts: &mock.AccountsStub{
+				LoadAccountCalled: func(address []byte) (vmcommon.AccountHandler, error) {
+					return acnt, nil
+				},
+			},
+			Marshalizer:         &mock.MarshalizerMock{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
 		},
-	}, &mock.MarshalizerMock{}, true, core.BuiltInFunctionDCTPause, falseHandler)
+		Set:           true,
+		Function:      core.BuiltInFunctionDCTPause,
+		ActiveHandler: falseHandler,
+	})
 	_, err := globalSettingsFunc.ProcessBuiltinFunction(nil, nil, nil)
 	assert.Equal(t, err, ErrNilVmInput)
 
@@ -96,11 +155,20 @@ func TestDCTGlobalSettingsPause_ProcessBuiltInFunction(t *testing.T) {
 	assert.True(t, globalSettingsFunc.IsPaused(pauseKey))
 	assert.False(t, globalSettingsFunc.IsLimitedTransfer(pauseKey))
 
-	dctGlobalSettingsFalse, _ := NewDCTGlobalSettingsFunc(&mock.AccountsStub{
-		LoadAccountCalled: func(address []byte) (vmcommon.AccountHandler, error) {
-			return acnt, nil
+	dctGlobalSettingsFalse, _ := NewDCTGlobalSettingsFunc(ArgsNewDCTGlobalSettings{
+		Config: Config{
+			Accounts: &mock.AccountsStub{
+				LoadAccountCalled: func(address []byte) (vmcommon.AccountHandler, error) {
+					return acnt, nil
+				},
+			},
+			Marshalizer:         &mock.MarshalizerMock{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
 		},
-	}, &mock.MarshalizerMock{}, false, core.BuiltInFunctionDCTUnPause, falseHandler)
+		Set:           false,
+		Function:      core.BuiltInFunctionDCTUnPause,
+		ActiveHandler: falseHandler,
+	})
 
 	_, err = dctGlobalSettingsFalse.ProcessBuiltinFunction(nil, nil, input)
 	assert.Nil(t, err)
@@ -113,11 +181,20 @@ func TestDCTGlobalSettingsLimitedTransfer_ProcessBuiltInFunction(t *testing.T) {
 	t.Parallel()
 
 	acnt := mock.NewUserAccount(vmcommon.SystemAccountAddress)
-	globalSettingsFunc, _ := NewDCTGlobalSettingsFunc(&mock.AccountsStub{
-		LoadAccountCalled: func(address []byte) (vmcommon.AccountHandler, error) {
-			return acnt, nil
+	globalSettingsFunc, _ := NewDCTGlobalSettingsFunc(ArgsNewDCTGlobalSettings{
+		Config: Config{
+			Accounts: &mock.AccountsStub{
+				LoadAccountCalled: func(address []byte) (vmcommon.AccountHandler, error) {
+					return acnt, nil
+				},
+			},
+			Marshalizer:         &mock.MarshalizerMock{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
 		},
-	}, &mock.MarshalizerMock{}, true, core.BuiltInFunctionDCTSetLimitedTransfer, trueHandler)
+		Set:           true,
+		Function:      core.BuiltInFunctionDCTSetLimitedTransfer,
+		ActiveHandler: trueHandler,
+	})
 	_, err := globalSettingsFunc.ProcessBuiltinFunction(nil, nil, nil)
 	assert.Equal(t, err, ErrNilVmInput)
 
@@ -161,22 +238,40 @@ func TestDCTGlobalSettingsLimitedTransfer_ProcessBuiltInFunction(t *testing.T) {
 	assert.False(t, globalSettingsFunc.IsPaused(tokenID))
 	assert.True(t, globalSettingsFunc.IsLimitedTransfer(tokenID))
 
-	pauseFunc, _ := NewDCTGlobalSettingsFunc(&mock.AccountsStub{
-		LoadAccountCalled: func(address []byte) (vmcommon.AccountHandler, error) {
-			return acnt, nil
+	pauseFunc, _ := NewDCTGlobalSettingsFunc(ArgsNewDCTGlobalSettings{
+		Config: Config{
+			Accounts: &mock.AccountsStub{
+				LoadAccountCalled: func(address []byte) (vmcommon.AccountHandler, error) {
+					return acnt, nil
+				},
+			},
+			Marshalizer:         &mock.MarshalizerMock{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
 		},
-	}, &mock.MarshalizerMock{}, true, core.BuiltInFunctionDCTPause, falseHandler)
+		Set:           true,
+		Function:      core.BuiltInFunctionDCTPause,
+		ActiveHandler: falseHandler,
+	})
 
 	_, err = pauseFunc.ProcessBuiltinFunction(nil, nil, input)
 	assert.Nil(t, err)
 	assert.True(t, globalSettingsFunc.IsPaused(tokenID))
 	assert.True(t, globalSettingsFunc.IsLimitedTransfer(tokenID))
 
-	dctGlobalSettingsFalse, _ := NewDCTGlobalSettingsFunc(&mock.AccountsStub{
-		LoadAccountCalled: func(address []byte) (vmcommon.AccountHandler, error) {
-			return acnt, nil
+	dctGlobalSettingsFalse, _ := NewDCTGlobalSettingsFunc(ArgsNewDCTGlobalSettings{
+		Config: Config{
+			Accounts: &mock.AccountsStub{
+				LoadAccountCalled: func(address []byte) (vmcommon.AccountHandler, error) {
+					return acnt, nil
+				},
+			},
+			Marshalizer:         &mock.MarshalizerMock{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
 		},
-	}, &mock.MarshalizerMock{}, false, core.BuiltInFunctionDCTUnSetLimitedTransfer, trueHandler)
+		Set:           false,
+		Function:      core.BuiltInFunctionDCTUnSetLimitedTransfer,
+		ActiveHandler: trueHandler,
+	})
 
 	_, err = dctGlobalSettingsFalse.ProcessBuiltinFunction(nil, nil, input)
 	assert.Nil(t, err)
@@ -188,11 +283,20 @@ func TestDCTGlobalSettingsBurnForAll_ProcessBuiltInFunction(t *testing.T) {
 	t.Parallel()
 
 	acnt := mock.NewUserAccount(vmcommon.SystemAccountAddress)
-	globalSettingsFunc, _ := NewDCTGlobalSettingsFunc(&mock.AccountsStub{
-		LoadAccountCalled: func(address []byte) (vmcommon.AccountHandler, error) {
-			return acnt, nil
+	globalSettingsFunc, _ := NewDCTGlobalSettingsFunc(ArgsNewDCTGlobalSettings{
+		Config: Config{
+			Accounts: &mock.AccountsStub{
+				LoadAccountCalled: func(address []byte) (vmcommon.AccountHandler, error) {
+					return acnt, nil
+				},
+			},
+			Marshalizer:         &mock.MarshalizerMock{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
 		},
-	}, &mock.MarshalizerMock{}, true, vmcommon.BuiltInFunctionDCTSetBurnRoleForAll, falseHandler)
+		Set:           true,
+		Function:      vmcommon.BuiltInFunctionDCTSetBurnRoleForAll,
+		ActiveHandler: falseHandler,
+	})
 	_, err := globalSettingsFunc.ProcessBuiltinFunction(nil, nil, nil)
 	assert.Equal(t, err, ErrNilVmInput)
 
@@ -229,19 +333,31 @@ func TestDCTGlobalSettingsBurnForAll_ProcessBuiltInFunction(t *testing.T) {
 	assert.Equal(t, err, ErrOnlySystemAccountAccepted)
 
 	input.RecipientAddr = vmcommon.SystemAccountAddress
-	_, err = globalSettingsFunc.ProcessBuiltinFunction(nil, nil, input)
+	vmOutput, err := globalSettingsFunc.ProcessBuiltinFunction(nil, nil, input)
 	assert.Nil(t, err)
+	require.Len(t, vmOutput.Logs, 1)
+	assert.Equal(t, []byte(vmcommon.BuiltInFunctionDCTSetBurnRoleForAll), vmOutput.Logs[0].Identifier)
+	assert.Equal(t, key, vmOutput.Logs[0].Topics[0])
 
 	tokenID := []byte(baseDCTKeyPrefix + string(key))
 	assert.False(t, globalSettingsFunc.IsPaused(tokenID))
 	assert.False(t, globalSettingsFunc.IsLimitedTransfer(tokenID))
 	assert.True(t, globalSettingsFunc.IsBurnForAll(tokenID))
 
-	pauseFunc, _ := NewDCTGlobalSettingsFunc(&mock.AccountsStub{
-		LoadAccountCalled: func(address []byte) (vmcommon.AccountHandler, error) {
-			return acnt, nil
+	pauseFunc, _ := NewDCTGlobalSettingsFunc(ArgsNewDCTGlobalSettings{
+		Config: Config{
+			Accounts: &mock.AccountsStub{
+				LoadAccountCalled: func(address []byte) (vmcommon.AccountHandler, error) {
+					return acnt, nil
+				},
+			},
+			Marshalizer:         &mock.MarshalizerMock{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
 		},
-	}, &mock.MarshalizerMock{}, true, core.BuiltInFunctionDCTPause, falseHandler)
+		Set:           true,
+		Function:      core.BuiltInFunctionDCTPause,
+		ActiveHandler: falseHandler,
+	})
 
 	_, err = pauseFunc.ProcessBuiltinFunction(nil, nil, input)
 	assert.Nil(t, err)
@@ -249,14 +365,338 @@ func TestDCTGlobalSettingsBurnForAll_ProcessBuiltInFunction(t *testing.T) {
 	assert.False(t, globalSettingsFunc.IsLimitedTransfer(tokenID))
 	assert.True(t, globalSettingsFunc.IsBurnForAll(tokenID))
 
-	dctGlobalSettingsFalse, _ := NewDCTGlobalSettingsFunc(&mock.AccountsStub{
+	dctGlobalSettingsFalse, _ := NewDCTGlobalSettingsFunc(ArgsNewDCTGlobalSettings{
+		Config: Config{
+			Accounts: &mock.AccountsStub{
+				LoadAccountCalled: func(address []byte) (vmcommon.AccountHandler, error) {
+					return acnt, nil
+				},
+			},
+			Marshalizer:         &mock.MarshalizerMock{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+		},
+		Set:           false,
+		Function:      vmcommon.BuiltInFunctionDCTUnSetBurnRoleForAll,
+		ActiveHandler: falseHandler,
+	})
+
+	vmOutput, err = dctGlobalSettingsFalse.ProcessBuiltinFunction(nil, nil, input)
+	assert.Nil(t, err)
+	require.Len(t, vmOutput.Logs, 1)
+	assert.Equal(t, []byte(vmcommon.BuiltInFunctionDCTUnSetBurnRoleForAll), vmOutput.Logs[0].Identifier)
+
+	assert.False(t, globalSettingsFunc.IsLimitedTransfer(tokenID))
+	assert.False(t, globalSettingsFunc.IsBurnForAll(tokenID))
+	assert.True(t, globalSettingsFunc.IsPaused(tokenID))
+}
+
+func TestDCTGlobalSettingsDormantSweep_ProcessBuiltInFunction(t *testing.T) {
+	t.Parallel()
+
+	acnt := mock.NewUserAccount(vmcommon.SystemAccountAddress)
+	accounts := &mock.AccountsStub{
+		LoadAccountCalled: func(address []byte) (vmcommon.AccountHandler, error) {
+			return acnt, nil
+		},
+	}
+	setFunc, _ := NewDCTGlobalSettingsFunc(ArgsNewDCTGlobalSettings{
+		Config: Config{
+			Accounts:            accounts,
+			Marshalizer:         &mock.MarshalizerMock{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+		},
+		Set:           true,
+		Function:      vmcommon.BuiltInFunctionDCTSetDormantSweep,
+		ActiveHandler: falseHandler,
+	})
+	unSetFunc, _ := NewDCTGlobalSettingsFunc(ArgsNewDCTGlobalSettings{
+		Config: Config{
+			Accounts:            accounts,
+			Marshalizer:         &mock.MarshalizerMock{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+		},
+		Set:           false,
+		Function:      vmcommon.BuiltInFunctionDCTUnSetDormantSweep,
+		ActiveHandler: falseHandler,
+	})
+
+	key := []byte("key")
+	input := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr: core.DCTSCAddress,
+			CallValue:  big.NewInt(0),
+			Arguments:  [][]byte{key},
+		},
+		RecipientAddr: vmcommon.SystemAccountAddress,
+	}
+	tokenID := []byte(baseDCTKeyPrefix + string(key))
+
+	_, err := setFunc.ProcessBuiltinFunction(nil, nil, input)
+	assert.Nil(t, err)
+	assert.True(t, setFunc.IsDormantSweepAllowed(tokenID))
+	assert.False(t, setFunc.IsPaused(tokenID))
+
+	_, err = unSetFunc.ProcessBuiltinFunction(nil, nil, input)
+	assert.Nil(t, err)
+	assert.False(t, setFunc.IsDormantSweepAllowed(tokenID))
+}
+
+func TestDCTGlobalSettingsMultiSigManaged_ProcessBuiltInFunction(t *testing.T) {
+	t.Parallel()
+
+	acnt := mock.NewUserAccount(vmcommon.SystemAccountAddress)
+	accounts := &mock.AccountsStub{
 		LoadAccountCalled: func(address []byte) (vmcommon.AccountHandler, error) {
 			return acnt, nil
 		},
-	}, &mock.MarshalizerMock{}, false, vmcommon.BuiltInFunctionDCTUnSetBurnRoleForAll, falseHandler)
+	}
+	setFunc, _ := NewDCTGlobalSettingsFunc(ArgsNewDCTGlobalSettings{
+		Config: Config{
+			Accounts:            accounts,
+			Marshalizer:         &mock.MarshalizerMock{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+		},
+		Set:           true,
+		Function:      vmcommon.BuiltInFunctionDCTSetMultiSigManaged,
+		ActiveHandler: falseHandler,
+	})
+	unSetFunc, _ := NewDCTGlobalSettingsFunc(ArgsNewDCTGlobalSettings{
+		Config: Config{
+			Accounts:            accounts,
+			Marshalizer:         &mock.MarshalizerMock{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+		},
+		Set:           false,
+		Function:      vmcommon.BuiltInFunctionDCTUnSetMultiSigManaged,
+		ActiveHandler: falseHandler,
+	})
 
-	_, err = dctGlobalSettingsFalse.ProcessBuiltinFunction(nil, nil, input)
+	key := []byte("key")
+	input := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr: core.DCTSCAddress,
+			CallValue:  big.NewInt(0),
+			Arguments:  [][]byte{key},
+		},
+		RecipientAddr: vmcommon.SystemAccountAddress,
+	}
+	tokenID := []byte(baseDCTKeyPrefix + string(key))
+
+	_, err := setFunc.ProcessBuiltinFunction(nil, nil, input)
 	assert.Nil(t, err)
+	assert.True(t, setFunc.IsMultiSigManaged(tokenID))
+	assert.False(t, setFunc.IsPaused(tokenID))
 
-	assert.False(t, globalSettingsFunc.IsLimitedTransfer(tokenID))
+	_, err = unSetFunc.ProcessBuiltinFunction(nil, nil, input)
+	assert.Nil(t, err)
+	assert.False(t, setFunc.IsMultiSigManaged(tokenID))
+}
+
+func TestDCTGlobalSettingsSoulbound_ProcessBuiltInFunction(t *testing.T) {
+	t.Parallel()
+
+	acnt := mock.NewUserAccount(vmcommon.SystemAccountAddress)
+	accounts := &mock.AccountsStub{
+		LoadAccountCalled: func(address []byte) (vmcommon.AccountHandler, error) {
+			return acnt, nil
+		},
+	}
+	setFunc, _ := NewDCTGlobalSettingsFunc(ArgsNewDCTGlobalSettings{
+		Config: Config{
+			Accounts:            accounts,
+			Marshalizer:         &mock.MarshalizerMock{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+		},
+		Set:           true,
+		Function:      vmcommon.BuiltInFunctionDCTSetSoulbound,
+		ActiveHandler: falseHandler,
+	})
+	unSetFunc, _ := NewDCTGlobalSettingsFunc(ArgsNewDCTGlobalSettings{
+		Config: Config{
+			Accounts:            accounts,
+			Marshalizer:         &mock.MarshalizerMock{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+		},
+		Set:           false,
+		Function:      vmcommon.BuiltInFunctionDCTUnSetSoulbound,
+		ActiveHandler: falseHandler,
+	})
+
+	key := []byte("key")
+	input := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr: core.DCTSCAddress,
+			CallValue:  big.NewInt(0),
+			Arguments:  [][]byte{key},
+		},
+		RecipientAddr: vmcommon.SystemAccountAddress,
+	}
+	tokenID := []byte(baseDCTKeyPrefix + string(key))
+
+	_, err := setFunc.ProcessBuiltinFunction(nil, nil, input)
+	assert.Nil(t, err)
+	assert.True(t, setFunc.IsSoulbound(tokenID))
+	assert.False(t, setFunc.IsPaused(tokenID))
+
+	_, err = unSetFunc.ProcessBuiltinFunction(nil, nil, input)
+	assert.Nil(t, err)
+	assert.False(t, setFunc.IsSoulbound(tokenID))
+}
+
+func TestDCTGlobalSettingsNFTCreateStopped_ProcessBuiltInFunction(t *testing.T) {
+	t.Parallel()
+
+	acnt := mock.NewUserAccount(vmcommon.SystemAccountAddress)
+	accounts := &mock.AccountsStub{
+		LoadAccountCalled: func(address []byte) (vmcommon.AccountHandler, error) {
+			return acnt, nil
+		},
+	}
+	stopFunc, _ := NewDCTGlobalSettingsFunc(ArgsNewDCTGlobalSettings{
+		Config: Config{
+			Accounts:            accounts,
+			Marshalizer:         &mock.MarshalizerMock{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+		},
+		Set:           true,
+		Function:      vmcommon.BuiltInFunctionDCTStopNFTCreate,
+		ActiveHandler: falseHandler,
+	})
+	resumeFunc, _ := NewDCTGlobalSettingsFunc(ArgsNewDCTGlobalSettings{
+		Config: Config{
+			Accounts:            accounts,
+			Marshalizer:         &mock.MarshalizerMock{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+		},
+		Set:           false,
+		Function:      vmcommon.BuiltInFunctionDCTResumeNFTCreate,
+		ActiveHandler: falseHandler,
+	})
+	assert.False(t, stopFunc.IsActive())
+
+	key := []byte("key")
+	input := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr: core.DCTSCAddress,
+			CallValue:  big.NewInt(0),
+			Arguments:  [][]byte{key},
+		},
+		RecipientAddr: vmcommon.SystemAccountAddress,
+	}
+	tokenID := []byte(baseDCTKeyPrefix + string(key))
+
+	vmOutput, err := stopFunc.ProcessBuiltinFunction(nil, nil, input)
+	assert.Nil(t, err)
+	assert.True(t, stopFunc.IsNFTCreateStopped(tokenID))
+	assert.False(t, stopFunc.IsPaused(tokenID))
+	require.Len(t, vmOutput.Logs, 1)
+	assert.Equal(t, []byte(vmcommon.BuiltInFunctionDCTStopNFTCreate), vmOutput.Logs[0].Identifier)
+	assert.Equal(t, key, vmOutput.Logs[0].Topics[0])
+
+	vmOutput, err = resumeFunc.ProcessBuiltinFunction(nil, nil, input)
+	assert.Nil(t, err)
+	assert.False(t, stopFunc.IsNFTCreateStopped(tokenID))
+	require.Len(t, vmOutput.Logs, 1)
+	assert.Equal(t, []byte(vmcommon.BuiltInFunctionDCTResumeNFTCreate), vmOutput.Logs[0].Identifier)
+}
+
+func TestDCTGlobalSettings_VersionedEncoding(t *testing.T) {
+	t.Parallel()
+
+	acnt := mock.NewUserAccount(vmcommon.SystemAccountAddress)
+	accounts := &mock.AccountsStub{
+		LoadAccountCalled: func(address []byte) (vmcommon.AccountHandler, error) {
+			return acnt, nil
+		},
+	}
+	enableEpochsHandler := &mock.EnableEpochsHandlerStub{}
+	pauseFunc, _ := NewDCTGlobalSettingsFunc(ArgsNewDCTGlobalSettings{
+		Config: Config{
+			Accounts:            accounts,
+			Marshalizer:         &mock.MarshalizerMock{},
+			EnableEpochsHandler: enableEpochsHandler,
+		},
+		Set:           true,
+		Function:      core.BuiltInFunctionDCTPause,
+		ActiveHandler: trueHandler,
+	})
+	soulboundFunc, _ := NewDCTGlobalSettingsFunc(ArgsNewDCTGlobalSettings{
+		Config: Config{
+			Accounts:            accounts,
+			Marshalizer:         &mock.MarshalizerMock{},
+			EnableEpochsHandler: enableEpochsHandler,
+		},
+		Set:           true,
+		Function:      vmcommon.BuiltInFunctionDCTSetSoulbound,
+		ActiveHandler: trueHandler,
+	})
+
+	input := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr: core.DCTSCAddress,
+			CallValue:  big.NewInt(0),
+			Arguments:  [][]byte{[]byte("key")},
+		},
+		RecipientAddr: vmcommon.SystemAccountAddress,
+	}
+	dctTokenKey := []byte(baseDCTKeyPrefix + "key")
+
+	_, err := pauseFunc.ProcessBuiltinFunction(nil, nil, input)
+	require.Nil(t, err)
+	assert.Equal(t, []byte{MetadataPaused, 0}, acnt.Storage[string(dctTokenKey)])
+
+	enableEpochsHandler.IsGlobalSettingsVersioningFlagEnabledField = true
+	_, err = soulboundFunc.ProcessBuiltinFunction(nil, nil, input)
+	require.Nil(t, err)
+	assert.Equal(t, []byte{GlobalMetadataVersion1, 0, 0, 0, MetadataPaused | MetadataSoulbound}, acnt.Storage[string(dctTokenKey)])
+	assert.True(t, pauseFunc.IsPaused(dctTokenKey))
+	assert.True(t, pauseFunc.IsSoulbound(dctTokenKey))
+}
+
+func TestDCTGlobalSettings_MigrateGlobalMetadata(t *testing.T) {
+	t.Parallel()
+
+	acnt := mock.NewUserAccount(vmcommon.SystemAccountAddress)
+	acnt.Storage[baseDCTKeyPrefix+"LEGACY"] = []byte{MetadataPaused | MetadataLimitedTransfer, 0}
+	acnt.Storage[baseDCTKeyPrefix+"VERSIONED"] = []byte{GlobalMetadataVersion1, 0, 0, 0, MetadataSoulbound}
+	numSaves := 0
+	accounts := &mock.AccountsStub{
+		LoadAccountCalled: func(address []byte) (vmcommon.AccountHandler, error) {
+			return acnt, nil
+		},
+		SaveAccountCalled: func(account vmcommon.AccountHandler) error {
+			numSaves++
+			return nil
+		},
+	}
+	enableEpochsHandler := &mock.EnableEpochsHandlerStub{}
+	globalSettingsFunc, _ := NewDCTGlobalSettingsFunc(ArgsNewDCTGlobalSettings{
+		Config: Config{
+			Accounts:            accounts,
+			Marshalizer:         &mock.MarshalizerMock{},
+			EnableEpochsHandler: enableEpochsHandler,
+		},
+		Set:           true,
+		Function:      core.BuiltInFunctionDCTPause,
+		ActiveHandler: trueHandler,
+	})
+	tokenIDs := [][]byte{[]byte("LEGACY"), []byte("VERSIONED"), []byte("MISSING")}
+
+	numMigrated, err := globalSettingsFunc.MigrateGlobalMetadata(tokenIDs)
+	assert.Equal(t, ErrGlobalSettingsVersioningNotActive, err)
+	assert.Zero(t, numMigrated)
+
+	enableEpochsHandler.IsGlobalSettingsVersioningFlagEnabledField = true
+	numMigrated, err = globalSettingsFunc.MigrateGlobalMetadata(tokenIDs)
+	require.Nil(t, err)
+	assert.Equal(t, 1, numMigrated)
+	assert.Equal(t, 1, numSaves)
+	assert.Equal(t, []byte{GlobalMetadataVersion1, 0, 0, 0, MetadataPaused | MetadataLimitedTransfer}, acnt.Storage[baseDCTKeyPrefix+"LEGACY"])
+	assert.Equal(t, []byte{GlobalMetadataVersion1, 0, 0, 0, MetadataSoulbound}, acnt.Storage[baseDCTKeyPrefix+"VERSIONED"])
+	assert.True(t, globalSettingsFunc.IsLimitedTransfer([]byte(baseDCTKeyPrefix+"LEGACY")))
+
+	numMigrated, err = globalSettingsFunc.MigrateGlobalMetadata(tokenIDs)
+	require.Nil(t, err)
+	assert.Zero(t, numMigrated)
+	assert.Equal(t, 1, numSaves)
 }
@@ -12,6 +12,8 @@ import (
 
 type dctLocalBurn struct {
 	baseAlwaysActiveHandler
+	freezeAccountChecker
+	lockedBalanceChecker
 	keyPrefix             []byte
 	marshaller            vmcommon.Marshalizer
 	globalSettingsHandler vmcommon.ExtendedDCTGlobalSettingsHandler
@@ -20,29 +22,26 @@ type dctLocalBurn struct {
 	mutExecution          sync.RWMutex
 }
 
+// ArgsNewDCTLocalBurn defines the argument list for new dct local burn built in function
+type ArgsNewDCTLocalBurn struct {
+	Config
+	FuncGasCost uint64
+}
+
 // NewDCTLocalBurnFunc returns the dct local burn built-in function component
-func NewDCTLocalBurnFunc(
-	funcGasCost uint64,
-	marshaller vmcommon.Marshalizer,
-	globalSettingsHandler vmcommon.ExtendedDCTGlobalSettingsHandler,
-	rolesHandler vmcommon.DCTRoleHandler,
-) (*dctLocalBurn, error) {
-	if check.IfNil(marshaller) {
-		return nil, ErrNilMarshalizer
-	}
-	if check.IfNil(globalSettingsHandler) {
-		return nil, ErrNilGlobalSettingsHandler
-	}
-	if check.IfNil(rolesHandler) {
-		return nil, ErrNilRolesHandler
+func NewDCTLocalBurnFunc(args ArgsNewDCTLocalBurn) (*dctLocalBurn, error) {
+	err := args.checkComponents(core.BuiltInFunctionDCTLocalBurn, requireMarshalizer|requireGlobalSettingsHandler|requireRolesHandler|requireEnableEpochsHandler)
+	if err != nil {
+		return nil, err
 	}
 
 	e := &dctLocalBurn{
+		lockedBalanceChecker:  lockedBalanceChecker{enableEpochsHandler: args.EnableEpochsHandler},
 		keyPrefix:             []byte(baseDCTKeyPrefix),
-		marshaller:            marshaller,
-		globalSettingsHandler: globalSettingsHandler,
-		rolesHandler:          rolesHandler,
-		funcGasCost:           funcGasCost,
+		marshaller:            args.Marshalizer,
+		globalSettingsHandler: args.GlobalSettingsHandler,
+		rolesHandler:          args.RolesHandler,
+		funcGasCost:           args.FuncGasCost,
 		mutExecution:          sync.RWMutex{},
 	}
 
@@ -72,6 +71,10 @@ func (e *dctLocalBurn) ProcessBuiltinFunction(
 	if err != nil {
 		return nil, err
 	}
+	err = e.checkAccountIsNotFrozen(vmInput.CallerAddr, vmInput.ReturnCallAfterError)
+	if err != nil {
+		return nil, err
+	}
 
 	tokenID := vmInput.Arguments[0]
 	err = e.isAllowedToBurn(acntSnd, tokenID)
@@ -81,7 +84,7 @@ func (e *dctLocalBurn) ProcessBuiltinFunction(
 
 	value := big.NewInt(0).SetBytes(vmInput.Arguments[1])
 	dctTokenKey := append(e.keyPrefix, tokenID...)
-	err = addToDCTBalance(acntSnd, dctTokenKey, big.NewInt(0).Neg(value), e.marshaller, e.globalSettingsHandler, vmInput.ReturnCallAfterError)
+	err = e.subFromDCTBalance(acntSnd, dctTokenKey, value, e.marshaller, e.globalSettingsHandler, e.getSystemAddresses(), vmInput.ReturnCallAfterError)
 	if err != nil {
 		return nil, err
 	}
@@ -112,11 +115,9 @@ func checkBasicDCTArguments(vmInput *vmcommon.ContractCallInput) error {
 	if vmInput == nil {
 		return ErrNilVmInput
 	}
-	if vmInput.CallValue == nil {
-		return ErrNilValue
-	}
-	if vmInput.CallValue.Cmp(zero) != 0 {
-		return ErrBuiltInFunctionCalledWithValue
+	err := checkCallValue(vmcommon.CallValueForbidden, vmInput.CallValue)
+	if err != nil {
+		return err
 	}
 	if len(vmInput.Arguments) < core.MinLenArgumentsDCTTransfer {
 		return ErrInvalidArguments
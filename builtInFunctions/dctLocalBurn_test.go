@@ -18,34 +18,72 @@ func TestNewDCTLocalBurnFunc(t *testing.T) {
 
 	tests := []struct {
 		name     string
-		argsFunc func() (c uint64, m vmcommon.Marshalizer, p vmcommon.ExtendedDCTGlobalSettingsHandler, r vmcommon.DCTRoleHandler)
+		argsFunc func() ArgsNewDCTLocalBurn
 		exError  error
 	}{
 		{
 			name: "NilMarshalizer",
-			argsFunc: func() (c uint64, m vmcommon.Marshalizer, p vmcommon.ExtendedDCTGlobalSettingsHandler, r vmcommon.DCTRoleHandler) {
-				return 0, nil, &mock.GlobalSettingsHandlerStub{}, &mock.DCTRoleHandlerStub{}
+			argsFunc: func() ArgsNewDCTLocalBurn {
+				return ArgsNewDCTLocalBurn{
+					Config: Config{
+						EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+						GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+						RolesHandler:          &mock.DCTRoleHandlerStub{},
+					},
+				}
 			},
 			exError: ErrNilMarshalizer,
 		},
 		{
 			name: "NilGlobalSettingsHandler",
-			argsFunc: func() (c uint64, m vmcommon.Marshalizer, p vmcommon.ExtendedDCTGlobalSettingsHandler, r vmcommon.DCTRoleHandler) {
-				return 0, &mock.MarshalizerMock{}, nil, &mock.DCTRoleHandlerStub{}
+			argsFunc: func() ArgsNewDCTLocalBurn {
+				return ArgsNewDCTLocalBurn{
+					Config: Config{
+						EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+						Marshalizer:         &mock.MarshalizerMock{},
+						RolesHandler:        &mock.DCTRoleHandlerStub{},
+					},
+				}
 			},
 			exError: ErrNilGlobalSettingsHandler,
 		},
 		{
 			name: "NilRolesHandler",
-			argsFunc: func() (c uint64, m vmcommon.Marshalizer, p vmcommon.ExtendedDCTGlobalSettingsHandler, r vmcommon.DCTRoleHandler) {
-				return 0, &mock.MarshalizerMock{}, &mock.GlobalSettingsHandlerStub{}, nil
+			argsFunc: func() ArgsNewDCTLocalBurn {
+				return ArgsNewDCTLocalBurn{
+					Config: Config{
+						EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+						Marshalizer:           &mock.MarshalizerMock{},
+						GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+					},
+				}
 			},
 			exError: ErrNilRolesHandler,
 		},
+		{
+			name: "NilEnableEpochsHandler",
+			argsFunc: func() ArgsNewDCTLocalBurn {
+				return ArgsNewDCTLocalBurn{
+					Config: Config{
+						Marshalizer:           &mock.MarshalizerMock{},
+						GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+						RolesHandler:          &mock.DCTRoleHandlerStub{},
+					},
+				}
+			},
+			exError: ErrNilEnableEpochsHandler,
+		},
 		{
 			name: "Ok",
-			argsFunc: func() (c uint64, m vmcommon.Marshalizer, p vmcommon.ExtendedDCTGlobalSettingsHandler, r vmcommon.DCTRoleHandler) {
-				return 0, &mock.MarshalizerMock{}, &mock.GlobalSettingsHandlerStub{}, &mock.DCTRoleHandlerStub{}
+			argsFunc: func() ArgsNewDCTLocalBurn {
+				return ArgsNewDCTLocalBurn{
+					Config: Config{
+						EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+						Marshalizer:           &mock.MarshalizerMock{},
+						GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+						RolesHandler:          &mock.DCTRoleHandlerStub{},
+					},
+				}
 			},
 			exError: nil,
 		},
@@ -54,7 +92,7 @@ func TestNewDCTLocalBurnFunc(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			_, err := NewDCTLocalBurnFunc(tt.argsFunc())
-			require.Equal(t, err, tt.exError)
+			require.ErrorIs(t, err, tt.exError)
 		})
 	}
 }
@@ -62,7 +100,15 @@ func TestNewDCTLocalBurnFunc(t *testing.T) {
 func TestDctLocalBurn_ProcessBuiltinFunction_CalledWithValueShouldErr(t *testing.T) {
 	t.Parallel()
 
-	dctLocalBurnF, _ := NewDCTLocalBurnFunc(0, &mock.MarshalizerMock{}, &mock.GlobalSettingsHandlerStub{}, &mock.DCTRoleHandlerStub{})
+	dctLocalBurnF, _ := NewDCTLocalBurnFunc(ArgsNewDCTLocalBurn{
+		Config: Config{
+			EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			Marshalizer:           &mock.MarshalizerMock{},
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+		},
+		FuncGasCost: 0,
+	})
 
 	_, err := dctLocalBurnF.ProcessBuiltinFunction(&mock.AccountWrapMock{}, &mock.AccountWrapMock{}, &vmcommon.ContractCallInput{
 		VMInput: vmcommon.VMInput{
@@ -76,10 +122,18 @@ func TestDctLocalBurn_ProcessBuiltinFunction_CheckAllowToExecuteShouldErr(t *tes
 	t.Parallel()
 
 	localErr := errors.New("local err")
-	dctLocalBurnF, _ := NewDCTLocalBurnFunc(0, &mock.MarshalizerMock{}, &mock.GlobalSettingsHandlerStub{}, &mock.DCTRoleHandlerStub{
-		CheckAllowedToExecuteCalled: func(account vmcommon.UserAccountHandler, tokenID []byte, action []byte) error {
-			return localErr
+	dctLocalBurnF, _ := NewDCTLocalBurnFunc(ArgsNewDCTLocalBurn{
+		Config: Config{
+			EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			Marshalizer:           &mock.MarshalizerMock{},
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler: &mock.DCTRoleHandlerStub{
+				CheckAllowedToExecuteCalled: func(account vmcommon.UserAccountHandler, tokenID []byte, action []byte) error {
+					return localErr
+				},
+			},
 		},
+		FuncGasCost: 0,
 	})
 
 	_, err := dctLocalBurnF.ProcessBuiltinFunction(&mock.AccountWrapMock{}, &mock.AccountWrapMock{}, &vmcommon.ContractCallInput{
@@ -94,10 +148,18 @@ func TestDctLocalBurn_ProcessBuiltinFunction_CheckAllowToExecuteShouldErr(t *tes
 func TestDctLocalBurn_ProcessBuiltinFunction_CannotAddToDctBalanceShouldErr(t *testing.T) {
 	t.Parallel()
 
-	dctLocalBurnF, _ := NewDCTLocalBurnFunc(0, &mock.MarshalizerMock{}, &mock.GlobalSettingsHandlerStub{}, &mock.DCTRoleHandlerStub{
-		CheckAllowedToExecuteCalled: func(account vmcommon.UserAccountHandler, tokenID []byte, action []byte) error {
-			return nil
+	dctLocalBurnF, _ := NewDCTLocalBurnFunc(ArgsNewDCTLocalBurn{
+		Config: Config{
+			EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			Marshalizer:           &mock.MarshalizerMock{},
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler: &mock.DCTRoleHandlerStub{
+				CheckAllowedToExecuteCalled: func(account vmcommon.UserAccountHandler, tokenID []byte, action []byte) error {
+					return nil
+				},
+			},
 		},
+		FuncGasCost: 0,
 	})
 
 	localErr := errors.New("local err")
@@ -128,7 +190,15 @@ func TestDctLocalBurn_ProcessBuiltinFunction_ShouldWork(t *testing.T) {
 			return nil
 		},
 	}
-	dctLocalBurnF, _ := NewDCTLocalBurnFunc(50, marshaller, &mock.GlobalSettingsHandlerStub{}, dctRoleHandler)
+	dctLocalBurnF, _ := NewDCTLocalBurnFunc(ArgsNewDCTLocalBurn{
+		Config: Config{
+			EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			Marshalizer:           marshaller,
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          dctRoleHandler,
+		},
+		FuncGasCost: 50,
+	})
 
 	sndAccout := &mock.UserAccountStub{
 		AccountDataHandlerCalled: func() vmcommon.AccountDataHandler {
@@ -175,14 +245,22 @@ func TestDctLocalBurn_ProcessBuiltinFunction_WithGlobalBurn(t *testing.T) {
 	t.Parallel()
 
 	marshaller := &mock.MarshalizerMock{}
-	dctLocalBurnF, _ := NewDCTLocalBurnFunc(50, marshaller, &mock.GlobalSettingsHandlerStub{
-		IsBurnForAllCalled: func(token []byte) bool {
-			return true
-		},
-	}, &mock.DCTRoleHandlerStub{
-		CheckAllowedToExecuteCalled: func(account vmcommon.UserAccountHandler, tokenID []byte, action []byte) error {
-			return errors.New("no role")
+	dctLocalBurnF, _ := NewDCTLocalBurnFunc(ArgsNewDCTLocalBurn{
+		Config: Config{
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+			Marshalizer:         marshaller,
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{
+				IsBurnForAllCalled: func(token []byte) bool {
+					return true
+				},
+			},
+			RolesHandler: &mock.DCTRoleHandlerStub{
+				CheckAllowedToExecuteCalled: func(account vmcommon.UserAccountHandler, tokenID []byte, action []byte) error {
+					return errors.New("no role")
+				},
+			},
 		},
+		FuncGasCost: 50,
 	})
 
 	sndAccout := &mock.UserAccountStub{
@@ -229,7 +307,15 @@ func TestDctLocalBurn_ProcessBuiltinFunction_WithGlobalBurn(t *testing.T) {
 func TestDctLocalBurn_SetNewGasConfig(t *testing.T) {
 	t.Parallel()
 
-	dctLocalBurnF, _ := NewDCTLocalBurnFunc(0, &mock.MarshalizerMock{}, &mock.GlobalSettingsHandlerStub{}, &mock.DCTRoleHandlerStub{})
+	dctLocalBurnF, _ := NewDCTLocalBurnFunc(ArgsNewDCTLocalBurn{
+		Config: Config{
+			EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			Marshalizer:           &mock.MarshalizerMock{},
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+		},
+		FuncGasCost: 0,
+	})
 
 	dctLocalBurnF.SetNewGasConfig(&vmcommon.GasCost{BuiltInCost: vmcommon.BuiltInCost{
 		DCTLocalBurn: 500},
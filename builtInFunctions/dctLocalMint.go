@@ -1,17 +1,17 @@
 package builtInFunctions
 
 import (
-	"fmt"
 	"math/big"
 	"sync"
 
 	"github.com/Reshusk23/sr-me-core/core"
-	"github.com/Reshusk23/sr-me-core/core/check"
 	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/validation"
 )
 
 type dctLocalMint struct {
 	baseAlwaysActiveHandler
+	baseSystemAddressesHandler
 	keyPrefix             []byte
 	marshaller            vmcommon.Marshalizer
 	globalSettingsHandler vmcommon.DCTGlobalSettingsHandler
@@ -20,29 +20,25 @@ type dctLocalMint struct {
 	mutExecution          sync.RWMutex
 }
 
+// ArgsNewDCTLocalMint defines the argument list for new dct local mint built in function
+type ArgsNewDCTLocalMint struct {
+	Config
+	FuncGasCost uint64
+}
+
 // NewDCTLocalMintFunc returns the dct local mint built-in function component
-func NewDCTLocalMintFunc(
-	funcGasCost uint64,
-	marshaller vmcommon.Marshalizer,
-	globalSettingsHandler vmcommon.DCTGlobalSettingsHandler,
-	rolesHandler vmcommon.DCTRoleHandler,
-) (*dctLocalMint, error) {
-	if check.IfNil(marshaller) {
-		return nil, ErrNilMarshalizer
-	}
-	if check.IfNil(globalSettingsHandler) {
-		return nil, ErrNilGlobalSettingsHandler
-	}
-	if check.IfNil(rolesHandler) {
-		return nil, ErrNilRolesHandler
+func NewDCTLocalMintFunc(args ArgsNewDCTLocalMint) (*dctLocalMint, error) {
+	err := args.checkComponents(core.BuiltInFunctionDCTLocalMint, requireMarshalizer|requireGlobalSettingsHandler|requireRolesHandler)
+	if err != nil {
+		return nil, err
 	}
 
 	e := &dctLocalMint{
 		keyPrefix:             []byte(baseDCTKeyPrefix),
-		marshaller:            marshaller,
-		globalSettingsHandler: globalSettingsHandler,
-		rolesHandler:          rolesHandler,
-		funcGasCost:           funcGasCost,
+		marshaller:            args.Marshalizer,
+		globalSettingsHandler: args.GlobalSettingsHandler,
+		rolesHandler:          args.RolesHandler,
+		funcGasCost:           args.FuncGasCost,
 		mutExecution:          sync.RWMutex{},
 	}
 
@@ -79,13 +75,14 @@ func (e *dctLocalMint) ProcessBuiltinFunction(
 		return nil, err
 	}
 
-	if len(vmInput.Arguments[1]) > core.MaxLenForDCTIssueMint {
-		return nil, fmt.Errorf("%w max length for dct issue is %d", ErrInvalidArguments, core.MaxLenForDCTIssueMint)
+	err = checkFunctionArguments(vmInput.Arguments, validation.RequireBigIntMaxBytes(1, core.MaxLenForDCTIssueMint))
+	if err != nil {
+		return nil, err
 	}
 
 	value := big.NewInt(0).SetBytes(vmInput.Arguments[1])
 	dctTokenKey := append(e.keyPrefix, tokenID...)
-	err = addToDCTBalance(acntSnd, dctTokenKey, big.NewInt(0).Set(value), e.marshaller, e.globalSettingsHandler, vmInput.ReturnCallAfterError)
+	err = addToDCTBalance(acntSnd, dctTokenKey, big.NewInt(0).Set(value), e.marshaller, e.globalSettingsHandler, e.getSystemAddresses(), vmInput.ReturnCallAfterError)
 	if err != nil {
 		return nil, err
 	}
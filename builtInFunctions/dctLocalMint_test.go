@@ -18,34 +18,55 @@ func TestNewDCTLocalMintFunc(t *testing.T) {
 
 	tests := []struct {
 		name     string
-		argsFunc func() (c uint64, m vmcommon.Marshalizer, p vmcommon.DCTGlobalSettingsHandler, r vmcommon.DCTRoleHandler)
+		argsFunc func() ArgsNewDCTLocalMint
 		exError  error
 	}{
 		{
 			name: "NilMarshalizer",
-			argsFunc: func() (c uint64, m vmcommon.Marshalizer, p vmcommon.DCTGlobalSettingsHandler, r vmcommon.DCTRoleHandler) {
-				return 0, nil, &mock.GlobalSettingsHandlerStub{}, &mock.DCTRoleHandlerStub{}
+			argsFunc: func() ArgsNewDCTLocalMint {
+				return ArgsNewDCTLocalMint{
+					Config: Config{
+						GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+						RolesHandler:          &mock.DCTRoleHandlerStub{},
+					},
+				}
 			},
 			exError: ErrNilMarshalizer,
 		},
 		{
 			name: "NilGlobalSettingsHandler",
-			argsFunc: func() (c uint64, m vmcommon.Marshalizer, p vmcommon.DCTGlobalSettingsHandler, r vmcommon.DCTRoleHandler) {
-				return 0, &mock.MarshalizerMock{}, nil, &mock.DCTRoleHandlerStub{}
+			argsFunc: func() ArgsNewDCTLocalMint {
+				return ArgsNewDCTLocalMint{
+					Config: Config{
+						Marshalizer:  &mock.MarshalizerMock{},
+						RolesHandler: &mock.DCTRoleHandlerStub{},
+					},
+				}
 			},
 			exError: ErrNilGlobalSettingsHandler,
 		},
 		{
 			name: "NilRolesHandler",
-			argsFunc: func() (c uint64, m vmcommon.Marshalizer, p vmcommon.DCTGlobalSettingsHandler, r vmcommon.DCTRoleHandler) {
-				return 0, &mock.MarshalizerMock{}, &mock.GlobalSettingsHandlerStub{}, nil
+			argsFunc: func() ArgsNewDCTLocalMint {
+				return ArgsNewDCTLocalMint{
+					Config: Config{
+						Marshalizer:           &mock.MarshalizerMock{},
+						GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+					},
+				}
 			},
 			exError: ErrNilRolesHandler,
 		},
 		{
 			name: "Ok",
-			argsFunc: func() (c uint64, m vmcommon.Marshalizer, p vmcommon.DCTGlobalSettingsHandler, r vmcommon.DCTRoleHandler) {
-				return 0, &mock.MarshalizerMock{}, &mock.GlobalSettingsHandlerStub{}, &mock.DCTRoleHandlerStub{}
+			argsFunc: func() ArgsNewDCTLocalMint {
+				return ArgsNewDCTLocalMint{
+					Config: Config{
+						Marshalizer:           &mock.MarshalizerMock{},
+						GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+						RolesHandler:          &mock.DCTRoleHandlerStub{},
+					},
+				}
 			},
 			exError: nil,
 		},
@@ -54,7 +75,7 @@ func TestNewDCTLocalMintFunc(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			_, err := NewDCTLocalMintFunc(tt.argsFunc())
-			require.Equal(t, err, tt.exError)
+			require.ErrorIs(t, err, tt.exError)
 		})
 	}
 }
@@ -62,7 +83,14 @@ func TestNewDCTLocalMintFunc(t *testing.T) {
 func TestDctLocalMint_SetNewGasConfig(t *testing.T) {
 	t.Parallel()
 
-	dctLocalMintF, _ := NewDCTLocalMintFunc(0, &mock.MarshalizerMock{}, &mock.GlobalSettingsHandlerStub{}, &mock.DCTRoleHandlerStub{})
+	dctLocalMintF, _ := NewDCTLocalMintFunc(ArgsNewDCTLocalMint{
+		Config: Config{
+			Marshalizer:           &mock.MarshalizerMock{},
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+		},
+		FuncGasCost: 0,
+	})
 
 	dctLocalMintF.SetNewGasConfig(&vmcommon.GasCost{BuiltInCost: vmcommon.BuiltInCost{
 		DCTLocalMint: 500},
@@ -74,7 +102,14 @@ func TestDctLocalMint_SetNewGasConfig(t *testing.T) {
 func TestDctLocalMint_ProcessBuiltinFunction_CalledWithValueShouldErr(t *testing.T) {
 	t.Parallel()
 
-	dctLocalMintF, _ := NewDCTLocalMintFunc(0, &mock.MarshalizerMock{}, &mock.GlobalSettingsHandlerStub{}, &mock.DCTRoleHandlerStub{})
+	dctLocalMintF, _ := NewDCTLocalMintFunc(ArgsNewDCTLocalMint{
+		Config: Config{
+			Marshalizer:           &mock.MarshalizerMock{},
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+		},
+		FuncGasCost: 0,
+	})
 
 	_, err := dctLocalMintF.ProcessBuiltinFunction(&mock.AccountWrapMock{}, &mock.AccountWrapMock{}, &vmcommon.ContractCallInput{
 		VMInput: vmcommon.VMInput{
@@ -88,10 +123,17 @@ func TestDctLocalMint_ProcessBuiltinFunction_CheckAllowToExecuteShouldErr(t *tes
 	t.Parallel()
 
 	localErr := errors.New("local err")
-	dctLocalMintF, _ := NewDCTLocalMintFunc(0, &mock.MarshalizerMock{}, &mock.GlobalSettingsHandlerStub{}, &mock.DCTRoleHandlerStub{
-		CheckAllowedToExecuteCalled: func(account vmcommon.UserAccountHandler, tokenID []byte, action []byte) error {
-			return localErr
+	dctLocalMintF, _ := NewDCTLocalMintFunc(ArgsNewDCTLocalMint{
+		Config: Config{
+			Marshalizer:           &mock.MarshalizerMock{},
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler: &mock.DCTRoleHandlerStub{
+				CheckAllowedToExecuteCalled: func(account vmcommon.UserAccountHandler, tokenID []byte, action []byte) error {
+					return localErr
+				},
+			},
 		},
+		FuncGasCost: 0,
 	})
 
 	_, err := dctLocalMintF.ProcessBuiltinFunction(&mock.AccountWrapMock{}, &mock.AccountWrapMock{}, &vmcommon.ContractCallInput{
@@ -106,10 +148,17 @@ func TestDctLocalMint_ProcessBuiltinFunction_CheckAllowToExecuteShouldErr(t *tes
 func TestDctLocalMint_ProcessBuiltinFunction_CannotAddToDctBalanceShouldErr(t *testing.T) {
 	t.Parallel()
 
-	dctLocalMintF, _ := NewDCTLocalMintFunc(0, &mock.MarshalizerMock{}, &mock.GlobalSettingsHandlerStub{}, &mock.DCTRoleHandlerStub{
-		CheckAllowedToExecuteCalled: func(account vmcommon.UserAccountHandler, tokenID []byte, action []byte) error {
-			return nil
+	dctLocalMintF, _ := NewDCTLocalMintFunc(ArgsNewDCTLocalMint{
+		Config: Config{
+			Marshalizer:           &mock.MarshalizerMock{},
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler: &mock.DCTRoleHandlerStub{
+				CheckAllowedToExecuteCalled: func(account vmcommon.UserAccountHandler, tokenID []byte, action []byte) error {
+					return nil
+				},
+			},
 		},
+		FuncGasCost: 0,
 	})
 
 	localErr := errors.New("local err")
@@ -143,7 +192,14 @@ func TestDctLocalMint_ProcessBuiltinFunction_ShouldWork(t *testing.T) {
 			return nil
 		},
 	}
-	dctLocalMintF, _ := NewDCTLocalMintFunc(50, marshaller, &mock.GlobalSettingsHandlerStub{}, dctRoleHandler)
+	dctLocalMintF, _ := NewDCTLocalMintFunc(ArgsNewDCTLocalMint{
+		Config: Config{
+			Marshalizer:           marshaller,
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          dctRoleHandler,
+		},
+		FuncGasCost: 50,
+	})
 
 	sndAccout := &mock.UserAccountStub{
 		AccountDataHandlerCalled: func() vmcommon.AccountDataHandler {
@@ -1,5 +1,12 @@
 package builtInFunctions
 
+import (
+	"encoding/binary"
+	"math"
+	"math/big"
+	"sort"
+)
+
 const lengthOfDCTMetadata = 2
 
 const (
@@ -9,73 +16,329 @@ const (
 	MetadataLimitedTransfer = 2
 	// BurnRoleForAll is the location of burn role for all flag in the dct global meta data
 	BurnRoleForAll = 4
+	// MetadataDormantSweepAllowed is the location of dormant sweep allowed flag in the dct global meta data
+	MetadataDormantSweepAllowed = 8
+	// MetadataMultiSigManaged is the location of multisig managed flag in the dct global meta data
+	MetadataMultiSigManaged = 16
+	// MetadataSoulbound is the location of soulbound flag in the dct global meta data
+	MetadataSoulbound = 32
+	// MetadataNFTCreateStopped is the location of NFT create stopped flag in the dct global meta data
+	MetadataNFTCreateStopped = 64
 )
 
 const (
 	// MetadataFrozen is the location of frozen flag in the dct user meta data
 	MetadataFrozen = 1
+	// MetadataRented is the location of rented flag in the dct user meta data
+	MetadataRented = 2
+	// MetadataLocked is the location of locked flag in the dct user meta data
+	MetadataLocked = 4
+)
+
+const (
+	lengthOfReturnEpoch = 4
+	lengthOfUnlockEpoch = 4
+	// lengthOfLockedValueLength is the length of the prefix holding the length of each locked value
+	lengthOfLockedValueLength = 1
+	// maxLockedValueLength is the length of the longest locked value the one byte prefix can describe
+	maxLockedValueLength = math.MaxUint8
+	// maxLocksPerBalance bounds the number of locks with distinct unlock epochs a balance can hold
+	maxLocksPerBalance = 32
+)
+
+const (
+	// GlobalMetadataVersion1 is the first version of the versioned dct global meta data: the version byte followed by
+	// the flags as a big endian uint32. The later versions may only append fields after the flags, so the flags of
+	// any versioned entry can be read
+	GlobalMetadataVersion1 = 1
+
+	lengthOfGlobalMetadataFlags = 4
+	lengthOfGlobalMetadataV1    = 1 + lengthOfGlobalMetadataFlags
 )
 
 // DCTGlobalMetadata represents dct global metadata saved on system account
 type DCTGlobalMetadata struct {
-	Paused          bool
-	LimitedTransfer bool
-	BurnRoleForAll  bool
+	Paused              bool
+	LimitedTransfer     bool
+	BurnRoleForAll      bool
+	DormantSweepAllowed bool
+	MultiSigManaged     bool
+	Soulbound           bool
+	NFTCreateStopped    bool
 }
 
-// DCTGlobalMetadataFromBytes creates a metadata object from bytes
+// DCTGlobalMetadataFromBytes creates a metadata object from bytes, which can be either in the legacy layout, the
+// flags being held by the first of the two bytes, or in the versioned one
 func DCTGlobalMetadataFromBytes(bytes []byte) DCTGlobalMetadata {
-	if len(bytes) != lengthOfDCTMetadata {
+	if len(bytes) == lengthOfDCTMetadata {
+		return dctGlobalMetadataFromFlags(uint32(bytes[0]))
+	}
+	if !IsVersionedGlobalMetadata(bytes) {
 		return DCTGlobalMetadata{}
 	}
 
-	return DCTGlobalMetadata{
-		Paused:          (bytes[0] & MetadataPaused) != 0,
-		LimitedTransfer: (bytes[0] & MetadataLimitedTransfer) != 0,
-		BurnRoleForAll:  (bytes[0] & BurnRoleForAll) != 0,
+	return dctGlobalMetadataFromFlags(binary.BigEndian.Uint32(bytes[1:lengthOfGlobalMetadataV1]))
+}
+
+// IsVersionedGlobalMetadata returns true if the bytes hold dct global metadata in the versioned layout
+func IsVersionedGlobalMetadata(bytes []byte) bool {
+	return len(bytes) >= lengthOfGlobalMetadataV1 && bytes[0] >= GlobalMetadataVersion1
+}
+
+// MigrateDCTGlobalMetadata returns the provided dct global metadata in the versioned layout and true if the bytes
+// were in the legacy layout. The empty, invalid or already versioned bytes are returned unchanged
+func MigrateDCTGlobalMetadata(bytes []byte) ([]byte, bool) {
+	if len(bytes) != lengthOfDCTMetadata {
+		return bytes, false
 	}
+
+	metadata := DCTGlobalMetadataFromBytes(bytes)
+	return metadata.ToVersionedBytes(), true
 }
 
-// ToBytes converts the metadata to bytes
-func (metadata *DCTGlobalMetadata) ToBytes() []byte {
-	bytes := make([]byte, lengthOfDCTMetadata)
+func dctGlobalMetadataFromFlags(flags uint32) DCTGlobalMetadata {
+	return DCTGlobalMetadata{
+		Paused:              (flags & MetadataPaused) != 0,
+		LimitedTransfer:     (flags & MetadataLimitedTransfer) != 0,
+		BurnRoleForAll:      (flags & BurnRoleForAll) != 0,
+		DormantSweepAllowed: (flags & MetadataDormantSweepAllowed) != 0,
+		MultiSigManaged:     (flags & MetadataMultiSigManaged) != 0,
+		Soulbound:           (flags & MetadataSoulbound) != 0,
+		NFTCreateStopped:    (flags & MetadataNFTCreateStopped) != 0,
+	}
+}
 
+func (metadata *DCTGlobalMetadata) flags() uint32 {
+	flags := uint32(0)
 	if metadata.Paused {
-		bytes[0] |= MetadataPaused
+		flags |= MetadataPaused
 	}
 	if metadata.LimitedTransfer {
-		bytes[0] |= MetadataLimitedTransfer
+		flags |= MetadataLimitedTransfer
 	}
 	if metadata.BurnRoleForAll {
-		bytes[0] |= BurnRoleForAll
+		flags |= BurnRoleForAll
+	}
+	if metadata.DormantSweepAllowed {
+		flags |= MetadataDormantSweepAllowed
+	}
+	if metadata.MultiSigManaged {
+		flags |= MetadataMultiSigManaged
+	}
+	if metadata.Soulbound {
+		flags |= MetadataSoulbound
+	}
+	if metadata.NFTCreateStopped {
+		flags |= MetadataNFTCreateStopped
 	}
 
+	return flags
+}
+
+// ToBytes converts the metadata to bytes in the legacy layout, which holds only the flags fitting in a byte
+func (metadata *DCTGlobalMetadata) ToBytes() []byte {
+	bytes := make([]byte, lengthOfDCTMetadata)
+	bytes[0] = byte(metadata.flags())
+
+	return bytes
+}
+
+// ToVersionedBytes converts the metadata to bytes in the versioned layout
+func (metadata *DCTGlobalMetadata) ToVersionedBytes() []byte {
+	bytes := make([]byte, lengthOfGlobalMetadataV1)
+	bytes[0] = GlobalMetadataVersion1
+	binary.BigEndian.PutUint32(bytes[1:], metadata.flags())
+
 	return bytes
 }
 
 // DCTUserMetadata represents dct user metadata saved on every account
+// a rented NFT additionally holds the return epoch and the original owner after the flags, while a fungible balance
+// holding locked tokens additionally holds its locks, each one as the unlock epoch, the length of the locked value and
+// the locked value
 type DCTUserMetadata struct {
-	Frozen bool
+	Frozen      bool
+	RentedFrom  []byte
+	ReturnEpoch uint32
+	Locks       []DCTLock
+}
+
+// DCTLock is a portion of a fungible balance received through a transfer and lock, which can not be spent before its
+// unlock epoch
+type DCTLock struct {
+	Value       *big.Int
+	UnlockEpoch uint32
 }
 
 // DCTUserMetadataFromBytes creates a metadata object from bytes
 func DCTUserMetadataFromBytes(bytes []byte) DCTUserMetadata {
-	if len(bytes) != lengthOfDCTMetadata {
+	if len(bytes) < lengthOfDCTMetadata {
+		return DCTUserMetadata{}
+	}
+	isRented := (bytes[0] & MetadataRented) != 0
+	isLocked := (bytes[0] & MetadataLocked) != 0
+	if len(bytes) != lengthOfDCTMetadata && (isRented == isLocked || len(bytes) <= lengthOfDCTMetadata+lengthOfReturnEpoch) {
 		return DCTUserMetadata{}
 	}
 
-	return DCTUserMetadata{
+	metadata := DCTUserMetadata{
 		Frozen: (bytes[0] & MetadataFrozen) != 0,
 	}
+	if len(bytes) == lengthOfDCTMetadata {
+		return metadata
+	}
+
+	if isRented {
+		metadata.ReturnEpoch = binary.BigEndian.Uint32(bytes[lengthOfDCTMetadata:])
+		metadata.RentedFrom = bytes[lengthOfDCTMetadata+lengthOfReturnEpoch:]
+		return metadata
+	}
+
+	locks, ok := locksFromBytes(bytes[lengthOfDCTMetadata:])
+	if !ok {
+		return DCTUserMetadata{}
+	}
+	metadata.Locks = locks
+
+	return metadata
+}
+
+func locksFromBytes(bytes []byte) ([]DCTLock, bool) {
+	locks := make([]DCTLock, 0)
+	for len(bytes) > 0 {
+		if len(bytes) < lengthOfUnlockEpoch+lengthOfLockedValueLength {
+			return nil, false
+		}
+		unlockEpoch := binary.BigEndian.Uint32(bytes)
+		valueLength := int(bytes[lengthOfUnlockEpoch])
+		bytes = bytes[lengthOfUnlockEpoch+lengthOfLockedValueLength:]
+		if valueLength == 0 || len(bytes) < valueLength {
+			return nil, false
+		}
+
+		locks = append(locks, DCTLock{
+			Value:       big.NewInt(0).SetBytes(bytes[:valueLength]),
+			UnlockEpoch: unlockEpoch,
+		})
+		bytes = bytes[valueLength:]
+	}
+
+	return locks, true
+}
+
+// IsRented returns true if the token is held by a borrower and must be returned to its original owner
+func (metadata *DCTUserMetadata) IsRented() bool {
+	return len(metadata.RentedFrom) > 0
+}
+
+// IsLocked returns true if part of the balance was received through a transfer and lock, regardless of the unlock epochs
+func (metadata *DCTUserMetadata) IsLocked() bool {
+	for _, lock := range metadata.Locks {
+		if lock.Value != nil && lock.Value.Sign() > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// LockedValueAt returns the portion of the balance which can not be spent in the provided epoch
+func (metadata *DCTUserMetadata) LockedValueAt(epoch uint32) *big.Int {
+	lockedValue := big.NewInt(0)
+	for _, lock := range metadata.Locks {
+		if lock.Value != nil && epoch < lock.UnlockEpoch {
+			lockedValue.Add(lockedValue, lock.Value)
+		}
+	}
+
+	return lockedValue
+}
+
+// removeExpiredLocks drops the locks released in the provided epoch
+func (metadata *DCTUserMetadata) removeExpiredLocks(epoch uint32) {
+	activeLocks := make([]DCTLock, 0, len(metadata.Locks))
+	for _, lock := range metadata.Locks {
+		if lock.Value != nil && lock.Value.Sign() > 0 && epoch < lock.UnlockEpoch {
+			activeLocks = append(activeLocks, lock)
+		}
+	}
+
+	metadata.Locks = activeLocks
+}
+
+// addLockedValue locks the provided value until the unlock epoch. The locks are kept apart, so a transfer and lock can
+// never delay the release of the tokens locked before, only the locks with the same unlock epoch being merged
+func (metadata *DCTUserMetadata) addLockedValue(value *big.Int, unlockEpoch uint32, currentEpoch uint32) error {
+	metadata.removeExpiredLocks(currentEpoch)
+
+	for i := range metadata.Locks {
+		if metadata.Locks[i].UnlockEpoch == unlockEpoch {
+			lockedValue := big.NewInt(0).Add(metadata.Locks[i].Value, value)
+			if len(lockedValue.Bytes()) > maxLockedValueLength {
+				return ErrLockedValueTooLarge
+			}
+			metadata.Locks[i].Value = lockedValue
+			return nil
+		}
+	}
+	if len(value.Bytes()) > maxLockedValueLength {
+		return ErrLockedValueTooLarge
+	}
+	if len(metadata.Locks) >= maxLocksPerBalance {
+		return ErrTooManyDCTLocks
+	}
+
+	metadata.Locks = append(metadata.Locks, DCTLock{
+		Value:       big.NewInt(0).Set(value),
+		UnlockEpoch: unlockEpoch,
+	})
+	sort.Slice(metadata.Locks, func(i, j int) bool {
+		return metadata.Locks[i].UnlockEpoch < metadata.Locks[j].UnlockEpoch
+	})
+
+	return nil
 }
 
-// ToBytes converts the metadata to bytes
+// ToBytes converts the metadata to bytes. The rental and the lock exclude each other, the first applying only to
+// NFTs and the second only to fungible balances, so the rental is the one kept if both are set
 func (metadata *DCTUserMetadata) ToBytes() []byte {
-	bytes := make([]byte, lengthOfDCTMetadata)
+	isLocked := metadata.IsLocked() && !metadata.IsRented()
+	length := lengthOfDCTMetadata
+	if metadata.IsRented() {
+		length += lengthOfReturnEpoch
+	}
+	bytes := make([]byte, length, length+len(metadata.RentedFrom))
 
 	if metadata.Frozen {
 		bytes[0] |= MetadataFrozen
 	}
+	if isLocked {
+		bytes[0] |= MetadataLocked
+		return appendLocks(bytes, metadata.Locks)
+	}
+	if !metadata.IsRented() {
+		return bytes
+	}
+
+	bytes[0] |= MetadataRented
+	binary.BigEndian.PutUint32(bytes[lengthOfDCTMetadata:], metadata.ReturnEpoch)
+
+	return append(bytes, metadata.RentedFrom...)
+}
+
+// appendLocks encodes the locks after the flags. addLockedValue never creates a lock longer than maxLockedValueLength,
+// so the length of every locked value fits its one byte prefix
+func appendLocks(bytes []byte, locks []DCTLock) []byte {
+	for _, lock := range locks {
+		if lock.Value == nil || lock.Value.Sign() <= 0 {
+			continue
+		}
+
+		value := lock.Value.Bytes()
+		bytes = binary.BigEndian.AppendUint32(bytes, lock.UnlockEpoch)
+		bytes = append(bytes, byte(len(value)))
+		bytes = append(bytes, value...)
+	}
 
 	return bytes
 }
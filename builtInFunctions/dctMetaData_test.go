@@ -1,6 +1,8 @@
 package builtInFunctions
 
 import (
+	"bytes"
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -157,3 +159,144 @@ func TestDCTGlobalMetadata_FromBytes(t *testing.T) {
 	require.True(t, DCTGlobalMetadataFromBytes([]byte{3, 0}).Paused)
 	require.True(t, DCTGlobalMetadataFromBytes([]byte{3, 0}).LimitedTransfer)
 }
+
+func TestDCTGlobalMetadata_DormantSweepAllowed(t *testing.T) {
+	metadata := DCTGlobalMetadata{DormantSweepAllowed: true}
+	require.Equal(t, []byte{MetadataDormantSweepAllowed, 0}, metadata.ToBytes())
+	require.True(t, DCTGlobalMetadataFromBytes([]byte{8, 0}).DormantSweepAllowed)
+	require.False(t, DCTGlobalMetadataFromBytes([]byte{8, 0}).Paused)
+	require.False(t, DCTGlobalMetadataFromBytes([]byte{7, 0}).DormantSweepAllowed)
+}
+
+func TestDCTGlobalMetadata_MultiSigManaged(t *testing.T) {
+	metadata := DCTGlobalMetadata{MultiSigManaged: true}
+	require.Equal(t, []byte{MetadataMultiSigManaged, 0}, metadata.ToBytes())
+	require.True(t, DCTGlobalMetadataFromBytes([]byte{16, 0}).MultiSigManaged)
+	require.False(t, DCTGlobalMetadataFromBytes([]byte{16, 0}).DormantSweepAllowed)
+	require.False(t, DCTGlobalMetadataFromBytes([]byte{15, 0}).MultiSigManaged)
+}
+
+func TestDCTGlobalMetadata_Soulbound(t *testing.T) {
+	metadata := DCTGlobalMetadata{Soulbound: true}
+	require.Equal(t, []byte{MetadataSoulbound, 0}, metadata.ToBytes())
+	require.True(t, DCTGlobalMetadataFromBytes([]byte{32, 0}).Soulbound)
+	require.False(t, DCTGlobalMetadataFromBytes([]byte{32, 0}).MultiSigManaged)
+	require.False(t, DCTGlobalMetadataFromBytes([]byte{31, 0}).Soulbound)
+}
+
+func TestDCTUserMetadata_Rented(t *testing.T) {
+	t.Parallel()
+
+	owner := []byte("owner address")
+	metadata := DCTUserMetadata{Frozen: true, RentedFrom: owner, ReturnEpoch: 258}
+	buff := metadata.ToBytes()
+	require.Equal(t, append([]byte{MetadataFrozen | MetadataRented, 0, 0, 0, 1, 2}, owner...), buff)
+
+	fromBytes := DCTUserMetadataFromBytes(buff)
+	require.True(t, fromBytes.IsRented())
+	require.True(t, fromBytes.Frozen)
+	require.Equal(t, owner, fromBytes.RentedFrom)
+	require.Equal(t, uint32(258), fromBytes.ReturnEpoch)
+
+	notRented := DCTUserMetadata{Frozen: true}
+	require.Equal(t, []byte{MetadataFrozen, 0}, notRented.ToBytes())
+	require.Empty(t, DCTUserMetadataFromBytes([]byte{MetadataFrozen | MetadataRented, 0}).RentedFrom)
+	require.False(t, DCTUserMetadataFromBytes([]byte{MetadataFrozen, 0, 0, 0, 0, 1, 2}).Frozen)
+	require.Empty(t, DCTUserMetadataFromBytes([]byte{MetadataRented, 0, 0, 0, 0, 1}).RentedFrom)
+}
+
+func TestDCTGlobalMetadata_NFTCreateStopped(t *testing.T) {
+	metadata := DCTGlobalMetadata{NFTCreateStopped: true}
+	require.Equal(t, []byte{MetadataNFTCreateStopped, 0}, metadata.ToBytes())
+	require.True(t, DCTGlobalMetadataFromBytes([]byte{64, 0}).NFTCreateStopped)
+	require.False(t, DCTGlobalMetadataFromBytes([]byte{64, 0}).Soulbound)
+	require.False(t, DCTGlobalMetadataFromBytes([]byte{63, 0}).NFTCreateStopped)
+}
+
+func TestDCTGlobalMetadata_VersionedBytes(t *testing.T) {
+	t.Parallel()
+
+	metadata := DCTGlobalMetadata{Paused: true, NFTCreateStopped: true}
+	versioned := metadata.ToVersionedBytes()
+	require.Equal(t, []byte{GlobalMetadataVersion1, 0, 0, 0, MetadataPaused | MetadataNFTCreateStopped}, versioned)
+	require.True(t, IsVersionedGlobalMetadata(versioned))
+	require.False(t, IsVersionedGlobalMetadata(metadata.ToBytes()))
+	require.Equal(t, metadata, DCTGlobalMetadataFromBytes(versioned))
+
+	// the later versions append their fields after the flags
+	require.Equal(t, metadata, DCTGlobalMetadataFromBytes(append([]byte{2, 0, 0, 0, MetadataPaused | MetadataNFTCreateStopped}, 1, 2, 3)))
+	require.Equal(t, DCTGlobalMetadata{}, DCTGlobalMetadataFromBytes([]byte{0, 0, 0, 0, MetadataPaused}))
+	require.Equal(t, DCTGlobalMetadata{}, DCTGlobalMetadataFromBytes([]byte{GlobalMetadataVersion1, 0, 0}))
+}
+
+func TestMigrateDCTGlobalMetadata(t *testing.T) {
+	t.Parallel()
+
+	migrated, wasMigrated := MigrateDCTGlobalMetadata([]byte{MetadataSoulbound | MetadataPaused, 0})
+	require.True(t, wasMigrated)
+	require.Equal(t, []byte{GlobalMetadataVersion1, 0, 0, 0, MetadataSoulbound | MetadataPaused}, migrated)
+
+	for _, notMigrated := range [][]byte{nil, {1}, migrated} {
+		result, wasMigrated := MigrateDCTGlobalMetadata(notMigrated)
+		require.False(t, wasMigrated)
+		require.Equal(t, notMigrated, result)
+	}
+}
+
+func TestDCTUserMetadata_Locked(t *testing.T) {
+	t.Parallel()
+
+	metadata := DCTUserMetadata{Frozen: true, Locks: []DCTLock{{Value: big.NewInt(300), UnlockEpoch: 258}}}
+	buff := metadata.ToBytes()
+	require.Equal(t, []byte{MetadataFrozen | MetadataLocked, 0, 0, 0, 1, 2, 2, 1, 44}, buff)
+
+	fromBytes := DCTUserMetadataFromBytes(buff)
+	require.True(t, fromBytes.IsLocked())
+	require.False(t, fromBytes.IsRented())
+	require.True(t, fromBytes.Frozen)
+	require.Equal(t, metadata.Locks, fromBytes.Locks)
+	require.Equal(t, big.NewInt(300), fromBytes.LockedValueAt(257))
+	require.Equal(t, big.NewInt(0), fromBytes.LockedValueAt(258))
+
+	require.Nil(t, fromBytes.addLockedValue(big.NewInt(5), 200, 10))
+	require.Nil(t, fromBytes.addLockedValue(big.NewInt(7), 258, 10))
+	require.Equal(t, []DCTLock{{Value: big.NewInt(5), UnlockEpoch: 200}, {Value: big.NewInt(307), UnlockEpoch: 258}}, fromBytes.Locks)
+	require.Equal(t, big.NewInt(312), fromBytes.LockedValueAt(199))
+	require.Equal(t, big.NewInt(307), fromBytes.LockedValueAt(200))
+
+	fromBytes = DCTUserMetadataFromBytes(fromBytes.ToBytes())
+	require.Nil(t, fromBytes.addLockedValue(big.NewInt(5), 300, 258))
+	require.Equal(t, []DCTLock{{Value: big.NewInt(5), UnlockEpoch: 300}}, fromBytes.Locks)
+
+	require.Equal(t, []byte{0, 0}, (&DCTUserMetadata{Locks: []DCTLock{{Value: big.NewInt(0), UnlockEpoch: 258}}}).ToBytes())
+	require.Nil(t, DCTUserMetadataFromBytes([]byte{MetadataLocked | MetadataRented, 0, 0, 0, 0, 1, 2}).Locks)
+	require.Nil(t, DCTUserMetadataFromBytes([]byte{MetadataLocked, 0, 0, 0, 0, 1}).Locks)
+	require.Nil(t, DCTUserMetadataFromBytes([]byte{MetadataLocked, 0, 0, 0, 0, 1, 2, 1}).Locks)
+}
+
+func TestDCTUserMetadata_TooManyLocks(t *testing.T) {
+	t.Parallel()
+
+	metadata := DCTUserMetadata{}
+	for i := 0; i < maxLocksPerBalance; i++ {
+		require.Nil(t, metadata.addLockedValue(big.NewInt(1), uint32(100+i), 10))
+	}
+	require.Equal(t, ErrTooManyDCTLocks, metadata.addLockedValue(big.NewInt(1), 1000, 10))
+	require.Nil(t, metadata.addLockedValue(big.NewInt(1), 100, 10))
+	require.Nil(t, metadata.addLockedValue(big.NewInt(1), 1000, 100))
+	require.Equal(t, maxLocksPerBalance, len(metadata.Locks))
+}
+
+func TestDCTUserMetadata_LockedValueLength(t *testing.T) {
+	t.Parallel()
+
+	maxValue := big.NewInt(0).SetBytes(bytes.Repeat([]byte{0xff}, maxLockedValueLength))
+	metadata := DCTUserMetadata{}
+	require.Nil(t, metadata.addLockedValue(maxValue, 100, 10))
+	fromBytes := DCTUserMetadataFromBytes(metadata.ToBytes())
+	require.Equal(t, metadata.Locks, fromBytes.Locks)
+
+	require.Equal(t, ErrLockedValueTooLarge, fromBytes.addLockedValue(big.NewInt(1), 100, 10))
+	require.Equal(t, ErrLockedValueTooLarge, fromBytes.addLockedValue(big.NewInt(0).Add(maxValue, big.NewInt(1)), 200, 10))
+	require.Equal(t, metadata.Locks, fromBytes.Locks)
+}
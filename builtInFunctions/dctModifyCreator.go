@@ -0,0 +1,144 @@
+package builtInFunctions
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/Reshusk23/sr-me-core/core/check"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/validation"
+)
+
+const numArgumentsModifyCreator = 3
+
+type dctModifyCreator struct {
+	baseActiveHandler
+	baseAddressLengthHandler
+	keyPrefix         []byte
+	dctStorageHandler vmcommon.DCTNFTStorageHandler
+	rolesHandler      vmcommon.DCTRoleHandler
+	gasConfig         vmcommon.BaseOperationCost
+	funcGasCost       uint64
+	mutExecution      sync.RWMutex
+}
+
+// ArgsNewDCTModifyCreator defines the argument list for new dct modify creator built in function
+type ArgsNewDCTModifyCreator struct {
+	Config
+	FuncGasCost uint64
+	GasConfig   vmcommon.BaseOperationCost
+}
+
+// NewDCTModifyCreatorFunc returns the built-in function component which changes the creator recorded in the
+// metadata of an NFT, needed when the creator wallets are rotated
+func NewDCTModifyCreatorFunc(args ArgsNewDCTModifyCreator) (*dctModifyCreator, error) {
+	err := args.checkComponents(vmcommon.BuiltInFunctionDCTModifyCreator, requireRolesHandler|requireDCTStorageHandler|requireEnableEpochsHandler)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &dctModifyCreator{
+		keyPrefix:         []byte(baseDCTKeyPrefix),
+		dctStorageHandler: args.DCTStorageHandler,
+		rolesHandler:      args.RolesHandler,
+		gasConfig:         args.GasConfig,
+		funcGasCost:       args.FuncGasCost,
+	}
+
+	e.baseActiveHandler.activeHandler = args.EnableEpochsHandler.IsDCTModifyCreatorFlagEnabled
+
+	return e, nil
+}
+
+// SetNewGasConfig is called whenever gas cost is changed
+func (e *dctModifyCreator) SetNewGasConfig(gasCost *vmcommon.GasCost) {
+	if gasCost == nil {
+		return
+	}
+
+	e.mutExecution.Lock()
+	e.funcGasCost = gasCost.BuiltInCost.DCTNFTUpdateAttributes
+	e.gasConfig = gasCost.BaseOperationCost
+	e.mutExecution.Unlock()
+}
+
+// ProcessBuiltinFunction resolves DCT modify creator function call
+// Requires 3 arguments:
+// arg0 - token identifier
+// arg1 - nonce
+// arg2 - address of the new creator
+func (e *dctModifyCreator) ProcessBuiltinFunction(
+	acntSnd, _ vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	e.mutExecution.RLock()
+	defer e.mutExecution.RUnlock()
+
+	err := checkDCTNFTCreateBurnAddInput(acntSnd, vmInput, e.funcGasCost)
+	if err != nil {
+		return nil, err
+	}
+	if len(vmInput.Arguments) != numArgumentsModifyCreator {
+		return nil, ErrInvalidArguments
+	}
+	if check.IfNil(acntSnd) {
+		return nil, ErrNilUserAccount
+	}
+	err = checkFunctionArguments(
+		vmInput.Arguments,
+		validation.RequireUint64(1),
+		validation.RequireAddress(2, e.getAddressLength(vmInput)),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = e.rolesHandler.CheckAllowedToExecute(acntSnd, vmInput.Arguments[0], []byte(vmcommon.DCTRoleModifyCreator))
+	if err != nil {
+		return nil, err
+	}
+
+	newCreator := vmInput.Arguments[2]
+	gasCostForStore := uint64(len(newCreator)) * e.gasConfig.StorePerByte
+	if vmInput.GasProvided < e.funcGasCost+gasCostForStore {
+		return nil, ErrNotEnoughGas
+	}
+
+	dctTokenKey := append(e.keyPrefix, vmInput.Arguments[0]...)
+	nonce, err := uint64Argument(vmInput.Arguments, 1)
+	if err != nil {
+		return nil, err
+	}
+	if nonce == 0 {
+		return nil, ErrNFTDoesNotHaveMetadata
+	}
+	dctData, err := e.dctStorageHandler.GetDCTNFTTokenOnSender(acntSnd, dctTokenKey, nonce)
+	if err != nil {
+		return nil, err
+	}
+	if dctData.TokenMetaData == nil {
+		return nil, ErrNFTDoesNotHaveMetadata
+	}
+
+	previousCreator := dctData.TokenMetaData.Creator
+	dctData.TokenMetaData.Creator = newCreator
+
+	_, err = e.dctStorageHandler.SaveDCTNFTToken(acntSnd.AddressBytes(), acntSnd, dctTokenKey, nonce, dctData, true, vmInput.ReturnCallAfterError)
+	if err != nil {
+		return nil, err
+	}
+
+	vmOutput := &vmcommon.VMOutput{
+		ReturnCode:   vmcommon.Ok,
+		GasRemaining: vmInput.GasProvided - e.funcGasCost - gasCostForStore,
+	}
+
+	addDCTEntryInVMOutput(vmOutput, []byte(vmcommon.BuiltInFunctionDCTModifyCreator), vmInput.Arguments[0], nonce, big.NewInt(0), vmInput.CallerAddr, previousCreator, newCreator)
+
+	return vmOutput, nil
+}
+
+// IsInterfaceNil returns true if underlying object in nil
+func (e *dctModifyCreator) IsInterfaceNil() bool {
+	return e == nil
+}
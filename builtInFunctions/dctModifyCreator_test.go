@@ -0,0 +1,297 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/Reshusk23/sr-me-core/core"
+	"github.com/Reshusk23/sr-me-core/core/check"
+	"github.com/Reshusk23/sr-me-core/data/dct"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/dctkeys"
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func createModifyCreatorInput(tokenID []byte, nonce uint64, newCreator []byte) *vmcommon.ContractCallInput {
+	return &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallValue:   big.NewInt(0),
+			Arguments:   [][]byte{tokenID, big.NewInt(0).SetUint64(nonce).Bytes(), newCreator},
+			CallerAddr:  bytes.Repeat([]byte{1}, 32),
+			GasProvided: 100,
+		},
+		RecipientAddr: bytes.Repeat([]byte{1}, 32),
+	}
+}
+
+func TestNewDCTModifyCreatorFunc(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil storage handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		e, err := NewDCTModifyCreatorFunc(ArgsNewDCTModifyCreator{
+			Config: Config{
+				RolesHandler:        &mock.DCTRoleHandlerStub{},
+				EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+			},
+			FuncGasCost: 10,
+			GasConfig:   vmcommon.BaseOperationCost{},
+		})
+		require.True(t, check.IfNil(e))
+		require.ErrorIs(t, err, ErrNilDCTNFTStorageHandler)
+	})
+	t.Run("nil roles handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		e, err := NewDCTModifyCreatorFunc(ArgsNewDCTModifyCreator{
+			Config: Config{
+				DCTStorageHandler:   createNewDCTDataStorageHandler(),
+				EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+			},
+			FuncGasCost: 10,
+			GasConfig:   vmcommon.BaseOperationCost{},
+		})
+		require.True(t, check.IfNil(e))
+		require.ErrorIs(t, err, ErrNilRolesHandler)
+	})
+	t.Run("nil enable epochs handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		e, err := NewDCTModifyCreatorFunc(ArgsNewDCTModifyCreator{
+			Config: Config{
+				DCTStorageHandler: createNewDCTDataStorageHandler(),
+				RolesHandler:      &mock.DCTRoleHandlerStub{},
+			},
+			FuncGasCost: 10,
+			GasConfig:   vmcommon.BaseOperationCost{},
+		})
+		require.True(t, check.IfNil(e))
+		require.ErrorIs(t, err, ErrNilEnableEpochsHandler)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		enableEpochsHandler := &mock.EnableEpochsHandlerStub{}
+		e, err := NewDCTModifyCreatorFunc(ArgsNewDCTModifyCreator{
+			Config: Config{
+				DCTStorageHandler:   createNewDCTDataStorageHandler(),
+				RolesHandler:        &mock.DCTRoleHandlerStub{},
+				EnableEpochsHandler: enableEpochsHandler,
+			},
+			FuncGasCost: 10,
+			GasConfig:   vmcommon.BaseOperationCost{},
+		})
+		require.False(t, check.IfNil(e))
+		require.Nil(t, err)
+		require.False(t, e.IsActive())
+
+		enableEpochsHandler.IsDCTModifyCreatorFlagEnabledField = true
+		require.True(t, e.IsActive())
+	})
+}
+
+func TestDCTModifyCreator_SetNewGasConfig(t *testing.T) {
+	t.Parallel()
+
+	e, _ := NewDCTModifyCreatorFunc(ArgsNewDCTModifyCreator{
+		Config: Config{
+			DCTStorageHandler:   createNewDCTDataStorageHandler(),
+			RolesHandler:        &mock.DCTRoleHandlerStub{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+		},
+		FuncGasCost: 10,
+		GasConfig:   vmcommon.BaseOperationCost{},
+	})
+
+	e.SetNewGasConfig(nil)
+	require.Equal(t, uint64(10), e.funcGasCost)
+
+	e.SetNewGasConfig(&vmcommon.GasCost{
+		BaseOperationCost: vmcommon.BaseOperationCost{StorePerByte: 2},
+		BuiltInCost:       vmcommon.BuiltInCost{DCTNFTUpdateAttributes: 20},
+	})
+	require.Equal(t, uint64(20), e.funcGasCost)
+	require.Equal(t, uint64(2), e.gasConfig.StorePerByte)
+}
+
+func TestDCTModifyCreator_ProcessBuiltinFunctionErrors(t *testing.T) {
+	t.Parallel()
+
+	tokenID := []byte("NFT-abcdef")
+	newCreator := bytes.Repeat([]byte{2}, 32)
+
+	t.Run("invalid number of arguments should error", func(t *testing.T) {
+		t.Parallel()
+
+		e, _ := NewDCTModifyCreatorFunc(ArgsNewDCTModifyCreator{
+			Config: Config{
+				DCTStorageHandler:   createNewDCTDataStorageHandler(),
+				RolesHandler:        &mock.DCTRoleHandlerStub{},
+				EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+			},
+			FuncGasCost: 10,
+			GasConfig:   vmcommon.BaseOperationCost{},
+		})
+		input := createModifyCreatorInput(tokenID, 1, newCreator)
+		input.Arguments = input.Arguments[:2]
+
+		output, err := e.ProcessBuiltinFunction(mock.NewAccountWrapMock(input.CallerAddr), nil, input)
+		require.Nil(t, output)
+		require.Equal(t, ErrInvalidArguments, err)
+	})
+	t.Run("invalid new creator should error", func(t *testing.T) {
+		t.Parallel()
+
+		e, _ := NewDCTModifyCreatorFunc(ArgsNewDCTModifyCreator{
+			Config: Config{
+				DCTStorageHandler:   createNewDCTDataStorageHandler(),
+				RolesHandler:        &mock.DCTRoleHandlerStub{},
+				EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+			},
+			FuncGasCost: 10,
+			GasConfig:   vmcommon.BaseOperationCost{},
+		})
+		input := createModifyCreatorInput(tokenID, 1, []byte("short"))
+
+		output, err := e.ProcessBuiltinFunction(mock.NewAccountWrapMock(input.CallerAddr), nil, input)
+		require.Nil(t, output)
+		require.True(t, errors.Is(err, ErrInvalidArguments))
+	})
+	t.Run("missing role should error", func(t *testing.T) {
+		t.Parallel()
+
+		rolesHandler := &mock.DCTRoleHandlerStub{
+			CheckAllowedToExecuteCalled: func(_ vmcommon.UserAccountHandler, _ []byte, action []byte) error {
+				require.Equal(t, vmcommon.DCTRoleModifyCreator, string(action))
+				return ErrActionNotAllowed
+			},
+		}
+		e, _ := NewDCTModifyCreatorFunc(ArgsNewDCTModifyCreator{
+			Config: Config{
+				DCTStorageHandler:   createNewDCTDataStorageHandler(),
+				RolesHandler:        rolesHandler,
+				EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+			},
+			FuncGasCost: 10,
+			GasConfig:   vmcommon.BaseOperationCost{},
+		})
+		input := createModifyCreatorInput(tokenID, 1, newCreator)
+
+		output, err := e.ProcessBuiltinFunction(mock.NewAccountWrapMock(input.CallerAddr), nil, input)
+		require.Nil(t, output)
+		require.Equal(t, ErrActionNotAllowed, err)
+	})
+	t.Run("not enough gas for store should error", func(t *testing.T) {
+		t.Parallel()
+
+		e, _ := NewDCTModifyCreatorFunc(ArgsNewDCTModifyCreator{
+			Config: Config{
+				DCTStorageHandler:   createNewDCTDataStorageHandler(),
+				RolesHandler:        &mock.DCTRoleHandlerStub{},
+				EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+			},
+			FuncGasCost: 10,
+			GasConfig:   vmcommon.BaseOperationCost{StorePerByte: 3},
+		})
+		input := createModifyCreatorInput(tokenID, 1, newCreator)
+
+		output, err := e.ProcessBuiltinFunction(mock.NewAccountWrapMock(input.CallerAddr), nil, input)
+		require.Nil(t, output)
+		require.Equal(t, ErrNotEnoughGas, err)
+	})
+	t.Run("zero nonce should error", func(t *testing.T) {
+		t.Parallel()
+
+		e, _ := NewDCTModifyCreatorFunc(ArgsNewDCTModifyCreator{
+			Config: Config{
+				DCTStorageHandler:   createNewDCTDataStorageHandler(),
+				RolesHandler:        &mock.DCTRoleHandlerStub{},
+				EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+			},
+			FuncGasCost: 10,
+			GasConfig:   vmcommon.BaseOperationCost{},
+		})
+		input := createModifyCreatorInput(tokenID, 0, newCreator)
+
+		output, err := e.ProcessBuiltinFunction(mock.NewAccountWrapMock(input.CallerAddr), nil, input)
+		require.Nil(t, output)
+		require.Equal(t, ErrNFTDoesNotHaveMetadata, err)
+	})
+	t.Run("NFT not owned should error", func(t *testing.T) {
+		t.Parallel()
+
+		e, _ := NewDCTModifyCreatorFunc(ArgsNewDCTModifyCreator{
+			Config: Config{
+				DCTStorageHandler:   createNewDCTDataStorageHandler(),
+				RolesHandler:        &mock.DCTRoleHandlerStub{},
+				EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+			},
+			FuncGasCost: 10,
+			GasConfig:   vmcommon.BaseOperationCost{},
+		})
+		input := createModifyCreatorInput(tokenID, 1, newCreator)
+
+		output, err := e.ProcessBuiltinFunction(mock.NewAccountWrapMock(input.CallerAddr), nil, input)
+		require.Nil(t, output)
+		require.Equal(t, ErrNewNFTDataOnSenderAddress, err)
+	})
+}
+
+func TestDCTModifyCreator_ProcessBuiltinFunctionShouldWork(t *testing.T) {
+	t.Parallel()
+
+	tokenID := []byte("NFT-abcdef")
+	nonce := uint64(7)
+	previousCreator := bytes.Repeat([]byte{3}, 32)
+	newCreator := bytes.Repeat([]byte{2}, 32)
+
+	dctDataStorage := createNewDCTDataStorageHandler()
+	e, _ := NewDCTModifyCreatorFunc(ArgsNewDCTModifyCreator{
+		Config: Config{
+			DCTStorageHandler:   dctDataStorage,
+			RolesHandler:        &mock.DCTRoleHandlerStub{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+		},
+		FuncGasCost: 10,
+		GasConfig:   vmcommon.BaseOperationCost{StorePerByte: 1},
+	})
+
+	input := createModifyCreatorInput(tokenID, nonce, newCreator)
+	userAcc := mock.NewAccountWrapMock(input.CallerAddr)
+	dctTokenKey := append([]byte(baseDCTKeyPrefix), tokenID...)
+	dctData := &dct.DCToken{
+		Type:  uint32(core.NonFungible),
+		Value: big.NewInt(1),
+		TokenMetaData: &dct.MetaData{
+			Nonce:   nonce,
+			Name:    []byte("name"),
+			Creator: previousCreator,
+		},
+	}
+	_, err := dctDataStorage.SaveDCTNFTToken(userAcc.AddressBytes(), userAcc, dctTokenKey, nonce, dctData, true, false)
+	require.Nil(t, err)
+
+	output, err := e.ProcessBuiltinFunction(userAcc, nil, input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, output.ReturnCode)
+	require.Equal(t, uint64(100-10-32), output.GasRemaining)
+
+	tokenKey := dctkeys.ComputeDCTNFTTokenKey(dctTokenKey, nonce)
+	metaData, _ := dctDataStorage.getDCTMetaDataFromSystemAccount(tokenKey, defaultQueryOptions())
+	require.Equal(t, newCreator, metaData.Creator)
+	require.Equal(t, []byte("name"), metaData.Name)
+
+	savedData, err := dctDataStorage.GetDCTNFTTokenOnSender(userAcc, dctTokenKey, nonce)
+	require.Nil(t, err)
+	require.Equal(t, newCreator, savedData.TokenMetaData.Creator)
+	require.Equal(t, big.NewInt(1), savedData.Value)
+
+	require.Equal(t, 1, len(output.Logs))
+	require.Equal(t, []byte(vmcommon.BuiltInFunctionDCTModifyCreator), output.Logs[0].Identifier)
+	require.Equal(t, input.CallerAddr, output.Logs[0].Address)
+	require.Equal(t, [][]byte{tokenID, big.NewInt(0).SetUint64(nonce).Bytes(), {}, previousCreator, newCreator}, output.Logs[0].Topics)
+}
@@ -1,13 +1,12 @@
 package builtInFunctions
 
 import (
-	"fmt"
 	"math/big"
 	"sync"
 
 	"github.com/Reshusk23/sr-me-core/core"
-	"github.com/Reshusk23/sr-me-core/core/check"
 	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/validation"
 )
 
 const maxLenForAddNFTQuantity = 32
@@ -23,35 +22,27 @@ type dctNFTAddQuantity struct {
 	mutExecution          sync.RWMutex
 }
 
+// ArgsNewDCTNFTAddQuantity defines the argument list for new dct NFT add quantity built in function
+type ArgsNewDCTNFTAddQuantity struct {
+	Config
+	FuncGasCost uint64
+}
+
 // NewDCTNFTAddQuantityFunc returns the dct NFT add quantity built-in function component
-func NewDCTNFTAddQuantityFunc(
-	funcGasCost uint64,
-	dctStorageHandler vmcommon.DCTNFTStorageHandler,
-	globalSettingsHandler vmcommon.DCTGlobalSettingsHandler,
-	rolesHandler vmcommon.DCTRoleHandler,
-	enableEpochsHandler vmcommon.EnableEpochsHandler,
-) (*dctNFTAddQuantity, error) {
-	if check.IfNil(dctStorageHandler) {
-		return nil, ErrNilDCTNFTStorageHandler
-	}
-	if check.IfNil(globalSettingsHandler) {
-		return nil, ErrNilGlobalSettingsHandler
-	}
-	if check.IfNil(rolesHandler) {
-		return nil, ErrNilRolesHandler
-	}
-	if check.IfNil(enableEpochsHandler) {
-		return nil, ErrNilEnableEpochsHandler
+func NewDCTNFTAddQuantityFunc(args ArgsNewDCTNFTAddQuantity) (*dctNFTAddQuantity, error) {
+	err := args.checkComponents(core.BuiltInFunctionDCTNFTAddQuantity, requireGlobalSettingsHandler|requireRolesHandler|requireDCTStorageHandler|requireEnableEpochsHandler)
+	if err != nil {
+		return nil, err
 	}
 
 	e := &dctNFTAddQuantity{
 		keyPrefix:             []byte(baseDCTKeyPrefix),
-		globalSettingsHandler: globalSettingsHandler,
-		rolesHandler:          rolesHandler,
-		funcGasCost:           funcGasCost,
+		globalSettingsHandler: args.GlobalSettingsHandler,
+		rolesHandler:          args.RolesHandler,
+		funcGasCost:           args.FuncGasCost,
 		mutExecution:          sync.RWMutex{},
-		dctStorageHandler:     dctStorageHandler,
-		enableEpochsHandler:   enableEpochsHandler,
+		dctStorageHandler:     args.DCTStorageHandler,
+		enableEpochsHandler:   args.EnableEpochsHandler,
 	}
 
 	return e, nil
@@ -92,9 +83,16 @@ func (e *dctNFTAddQuantity) ProcessBuiltinFunction(
 	if err != nil {
 		return nil, err
 	}
+	err = checkCollectionAddQuantity(e.globalSettingsHandler, vmInput.Arguments[0])
+	if err != nil {
+		return nil, err
+	}
 
 	dctTokenKey := append(e.keyPrefix, vmInput.Arguments[0]...)
-	nonce := big.NewInt(0).SetBytes(vmInput.Arguments[1]).Uint64()
+	nonce, err := flaggedUint64Argument(e.enableEpochsHandler, vmInput.Arguments, 1)
+	if err != nil {
+		return nil, err
+	}
 	dctData, err := e.dctStorageHandler.GetDCTNFTTokenOnSender(acntSnd, dctTokenKey, nonce)
 	if err != nil {
 		return nil, err
@@ -104,11 +102,20 @@ func (e *dctNFTAddQuantity) ProcessBuiltinFunction(
 	}
 
 	isValueLengthCheckFlagEnabled := e.enableEpochsHandler.IsValueLengthCheckFlagEnabled()
-	if isValueLengthCheckFlagEnabled && len(vmInput.Arguments[2]) > maxLenForAddNFTQuantity {
-		return nil, fmt.Errorf("%w max length for add nft quantity is %d", ErrInvalidArguments, maxLenForAddNFTQuantity)
+	if isValueLengthCheckFlagEnabled || dctData.Type == vmcommon.MetaFungible {
+		err = checkFunctionArguments(vmInput.Arguments, validation.RequireBigIntMaxBytes(2, getMaxValueLength(dctData.Type)))
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	value := big.NewInt(0).SetBytes(vmInput.Arguments[2])
+	if e.enableEpochsHandler.IsNFTMaxSupplyFlagEnabled() {
+		err = e.dctStorageHandler.AddToNFTMintedSupply(dctTokenKey, nonce, value)
+		if err != nil {
+			return nil, err
+		}
+	}
 	dctData.Value.Add(dctData.Value, value)
 
 	_, err = e.dctStorageHandler.SaveDCTNFTToken(acntSnd.AddressBytes(), acntSnd, dctTokenKey, nonce, dctData, false, vmInput.ReturnCallAfterError)
@@ -9,6 +9,7 @@ import (
 	"github.com/Reshusk23/sr-me-core/core/check"
 	"github.com/Reshusk23/sr-me-core/data/dct"
 	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/dctkeys"
 	"github.com/Reshusk23/sr-vm-common-go/mock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -17,38 +18,70 @@ import (
 func TestNewDCTNFTAddQuantityFunc(t *testing.T) {
 	t.Parallel()
 
-	t.Run("nil marshaller should error", func(t *testing.T) {
+	t.Run("nil dct storage handler should error", func(t *testing.T) {
 		t.Parallel()
 
-		eqf, err := NewDCTNFTAddQuantityFunc(10, nil, nil, nil, nil)
+		eqf, err := NewDCTNFTAddQuantityFunc(ArgsNewDCTNFTAddQuantity{
+			Config: Config{
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+			},
+			FuncGasCost: 10,
+		})
 		require.True(t, check.IfNil(eqf))
-		require.Equal(t, ErrNilDCTNFTStorageHandler, err)
+		require.ErrorIs(t, err, ErrNilDCTNFTStorageHandler)
 	})
 	t.Run("nil global settings handler should error", func(t *testing.T) {
 		t.Parallel()
 
-		eqf, err := NewDCTNFTAddQuantityFunc(10, createNewDCTDataStorageHandler(), nil, nil, nil)
+		eqf, err := NewDCTNFTAddQuantityFunc(ArgsNewDCTNFTAddQuantity{
+			Config: Config{
+				DCTStorageHandler: createNewDCTDataStorageHandler(),
+			},
+			FuncGasCost: 10,
+		})
 		require.True(t, check.IfNil(eqf))
-		require.Equal(t, ErrNilGlobalSettingsHandler, err)
+		require.ErrorIs(t, err, ErrNilGlobalSettingsHandler)
 	})
 	t.Run("nil roles handler should error", func(t *testing.T) {
 		t.Parallel()
 
-		eqf, err := NewDCTNFTAddQuantityFunc(10, createNewDCTDataStorageHandler(), &mock.GlobalSettingsHandlerStub{}, nil, nil)
+		eqf, err := NewDCTNFTAddQuantityFunc(ArgsNewDCTNFTAddQuantity{
+			Config: Config{
+				DCTStorageHandler:     createNewDCTDataStorageHandler(),
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			},
+			FuncGasCost: 10,
+		})
 		require.True(t, check.IfNil(eqf))
-		require.Equal(t, ErrNilRolesHandler, err)
+		require.ErrorIs(t, err, ErrNilRolesHandler)
 	})
 	t.Run("nil enable epochs handler should error", func(t *testing.T) {
 		t.Parallel()
 
-		eqf, err := NewDCTNFTAddQuantityFunc(10, createNewDCTDataStorageHandler(), &mock.GlobalSettingsHandlerStub{}, &mock.DCTRoleHandlerStub{}, nil)
+		eqf, err := NewDCTNFTAddQuantityFunc(ArgsNewDCTNFTAddQuantity{
+			Config: Config{
+				DCTStorageHandler:     createNewDCTDataStorageHandler(),
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+			},
+			FuncGasCost: 10,
+		})
 		require.True(t, check.IfNil(eqf))
-		require.Equal(t, ErrNilEnableEpochsHandler, err)
+		require.ErrorIs(t, err, ErrNilEnableEpochsHandler)
 	})
 	t.Run("should work", func(t *testing.T) {
 		t.Parallel()
 
-		eqf, err := NewDCTNFTAddQuantityFunc(10, createNewDCTDataStorageHandler(), &mock.GlobalSettingsHandlerStub{}, &mock.DCTRoleHandlerStub{}, &mock.EnableEpochsHandlerStub{})
+		eqf, err := NewDCTNFTAddQuantityFunc(ArgsNewDCTNFTAddQuantity{
+			Config: Config{
+				DCTStorageHandler:     createNewDCTDataStorageHandler(),
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+				EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			},
+			FuncGasCost: 10,
+		})
 		require.False(t, check.IfNil(eqf))
 		require.NoError(t, err)
 	})
@@ -58,8 +91,16 @@ func TestDctNFTAddQuantity_SetNewGasConfig_NilGasCost(t *testing.T) {
 	t.Parallel()
 
 	defaultGasCost := uint64(10)
-	eqf, _ := NewDCTNFTAddQuantityFunc(defaultGasCost, createNewDCTDataStorageHandler(), &mock.GlobalSettingsHandlerStub{}, &mock.DCTRoleHandlerStub{}, &mock.EnableEpochsHandlerStub{
-		IsValueLengthCheckFlagEnabledField: true,
+	eqf, _ := NewDCTNFTAddQuantityFunc(ArgsNewDCTNFTAddQuantity{
+		Config: Config{
+			DCTStorageHandler:     createNewDCTDataStorageHandler(),
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+				IsValueLengthCheckFlagEnabledField: true,
+			},
+		},
+		FuncGasCost: defaultGasCost,
 	})
 
 	eqf.SetNewGasConfig(nil)
@@ -71,8 +112,16 @@ func TestDctNFTAddQuantity_SetNewGasConfig_ShouldWork(t *testing.T) {
 
 	defaultGasCost := uint64(10)
 	newGasCost := uint64(37)
-	eqf, _ := NewDCTNFTAddQuantityFunc(defaultGasCost, createNewDCTDataStorageHandler(), &mock.GlobalSettingsHandlerStub{}, &mock.DCTRoleHandlerStub{}, &mock.EnableEpochsHandlerStub{
-		IsValueLengthCheckFlagEnabledField: true,
+	eqf, _ := NewDCTNFTAddQuantityFunc(ArgsNewDCTNFTAddQuantity{
+		Config: Config{
+			DCTStorageHandler:     createNewDCTDataStorageHandler(),
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+				IsValueLengthCheckFlagEnabledField: true,
+			},
+		},
+		FuncGasCost: defaultGasCost,
 	})
 
 	eqf.SetNewGasConfig(
@@ -89,8 +138,16 @@ func TestDctNFTAddQuantity_SetNewGasConfig_ShouldWork(t *testing.T) {
 func TestDctNFTAddQuantity_ProcessBuiltinFunctionErrorOnCheckDCTNFTCreateBurnAddInput(t *testing.T) {
 	t.Parallel()
 
-	eqf, _ := NewDCTNFTAddQuantityFunc(10, createNewDCTDataStorageHandler(), &mock.GlobalSettingsHandlerStub{}, &mock.DCTRoleHandlerStub{}, &mock.EnableEpochsHandlerStub{
-		IsValueLengthCheckFlagEnabledField: true,
+	eqf, _ := NewDCTNFTAddQuantityFunc(ArgsNewDCTNFTAddQuantity{
+		Config: Config{
+			DCTStorageHandler:     createNewDCTDataStorageHandler(),
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+				IsValueLengthCheckFlagEnabledField: true,
+			},
+		},
+		FuncGasCost: 10,
 	})
 
 	// nil vm input
@@ -192,8 +249,16 @@ func TestDctNFTAddQuantity_ProcessBuiltinFunctionErrorOnCheckDCTNFTCreateBurnAdd
 func TestDctNFTAddQuantity_ProcessBuiltinFunctionInvalidNumberOfArguments(t *testing.T) {
 	t.Parallel()
 
-	eqf, _ := NewDCTNFTAddQuantityFunc(10, createNewDCTDataStorageHandler(), &mock.GlobalSettingsHandlerStub{}, &mock.DCTRoleHandlerStub{}, &mock.EnableEpochsHandlerStub{
-		IsValueLengthCheckFlagEnabledField: true,
+	eqf, _ := NewDCTNFTAddQuantityFunc(ArgsNewDCTNFTAddQuantity{
+		Config: Config{
+			DCTStorageHandler:     createNewDCTDataStorageHandler(),
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+				IsValueLengthCheckFlagEnabledField: true,
+			},
+		},
+		FuncGasCost: 10,
 	})
 	output, err := eqf.ProcessBuiltinFunction(
 		mock.NewAccountWrapMock([]byte("addr")),
@@ -221,8 +286,16 @@ func TestDctNFTAddQuantity_ProcessBuiltinFunctionCheckAllowedToExecuteError(t *t
 			return localErr
 		},
 	}
-	eqf, _ := NewDCTNFTAddQuantityFunc(10, createNewDCTDataStorageHandler(), &mock.GlobalSettingsHandlerStub{}, rolesHandler, &mock.EnableEpochsHandlerStub{
-		IsValueLengthCheckFlagEnabledField: true,
+	eqf, _ := NewDCTNFTAddQuantityFunc(ArgsNewDCTNFTAddQuantity{
+		Config: Config{
+			DCTStorageHandler:     createNewDCTDataStorageHandler(),
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          rolesHandler,
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+				IsValueLengthCheckFlagEnabledField: true,
+			},
+		},
+		FuncGasCost: 10,
 	})
 	output, err := eqf.ProcessBuiltinFunction(
 		mock.NewAccountWrapMock([]byte("addr")),
@@ -245,8 +318,16 @@ func TestDctNFTAddQuantity_ProcessBuiltinFunctionCheckAllowedToExecuteError(t *t
 func TestDctNFTAddQuantity_ProcessBuiltinFunctionNewSenderShouldErr(t *testing.T) {
 	t.Parallel()
 
-	eqf, _ := NewDCTNFTAddQuantityFunc(10, createNewDCTDataStorageHandler(), &mock.GlobalSettingsHandlerStub{}, &mock.DCTRoleHandlerStub{}, &mock.EnableEpochsHandlerStub{
-		IsValueLengthCheckFlagEnabledField: true,
+	eqf, _ := NewDCTNFTAddQuantityFunc(ArgsNewDCTNFTAddQuantity{
+		Config: Config{
+			DCTStorageHandler:     createNewDCTDataStorageHandler(),
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+				IsValueLengthCheckFlagEnabledField: true,
+			},
+		},
+		FuncGasCost: 10,
 	})
 	output, err := eqf.ProcessBuiltinFunction(
 		mock.NewAccountWrapMock([]byte("addr")),
@@ -271,8 +352,16 @@ func TestDctNFTAddQuantity_ProcessBuiltinFunctionMetaDataMissing(t *testing.T) {
 	t.Parallel()
 
 	marshaller := &mock.MarshalizerMock{}
-	eqf, _ := NewDCTNFTAddQuantityFunc(10, createNewDCTDataStorageHandler(), &mock.GlobalSettingsHandlerStub{}, &mock.DCTRoleHandlerStub{}, &mock.EnableEpochsHandlerStub{
-		IsValueLengthCheckFlagEnabledField: true,
+	eqf, _ := NewDCTNFTAddQuantityFunc(ArgsNewDCTNFTAddQuantity{
+		Config: Config{
+			DCTStorageHandler:     createNewDCTDataStorageHandler(),
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+				IsValueLengthCheckFlagEnabledField: true,
+			},
+		},
+		FuncGasCost: 10,
 	})
 
 	userAcc := mock.NewAccountWrapMock([]byte("addr"))
@@ -307,7 +396,15 @@ func TestDctNFTAddQuantity_ProcessBuiltinFunctionShouldErrOnSaveBecauseTokenIsPa
 		},
 	}
 	enableEpochsHandler := &mock.EnableEpochsHandlerStub{}
-	eqf, _ := NewDCTNFTAddQuantityFunc(10, createNewDCTDataStorageHandlerWithArgs(globalSettingsHandler, &mock.AccountsStub{}, enableEpochsHandler), globalSettingsHandler, &mock.DCTRoleHandlerStub{}, enableEpochsHandler)
+	eqf, _ := NewDCTNFTAddQuantityFunc(ArgsNewDCTNFTAddQuantity{
+		Config: Config{
+			DCTStorageHandler:     createNewDCTDataStorageHandlerWithArgs(globalSettingsHandler, &mock.AccountsStub{}, enableEpochsHandler),
+			GlobalSettingsHandler: globalSettingsHandler,
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			EnableEpochsHandler:   enableEpochsHandler,
+		},
+		FuncGasCost: 10,
+	})
 
 	userAcc := mock.NewAccountWrapMock([]byte("addr"))
 	dctData := &dct.DCToken{
@@ -358,7 +455,15 @@ func TestDctNFTAddQuantity_ProcessBuiltinFunctionShouldWork(t *testing.T) {
 	enableEpochsHandler := &mock.EnableEpochsHandlerStub{
 		IsValueLengthCheckFlagEnabledField: true,
 	}
-	eqf, _ := NewDCTNFTAddQuantityFunc(10, createNewDCTDataStorageHandler(), &mock.GlobalSettingsHandlerStub{}, dctRoleHandler, enableEpochsHandler)
+	eqf, _ := NewDCTNFTAddQuantityFunc(ArgsNewDCTNFTAddQuantity{
+		Config: Config{
+			DCTStorageHandler:     createNewDCTDataStorageHandler(),
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          dctRoleHandler,
+			EnableEpochsHandler:   enableEpochsHandler,
+		},
+		FuncGasCost: 10,
+	})
 
 	userAcc := mock.NewAccountWrapMock([]byte("addr"))
 	dctData := &dct.DCToken{
@@ -397,3 +502,120 @@ func TestDctNFTAddQuantity_ProcessBuiltinFunctionShouldWork(t *testing.T) {
 	_ = marshaller.Unmarshal(&finalTokenData, res)
 	require.Equal(t, expectedValue.Bytes(), finalTokenData.Value.Bytes())
 }
+
+func TestDctNFTAddQuantity_ProcessBuiltinFunctionCollectionAddQuantityDisabledShouldErr(t *testing.T) {
+	t.Parallel()
+
+	globalSettingsHandler := &mock.GlobalSettingsHandlerStub{
+		GetCollectionConfigCalled: func(tokenID []byte) vmcommon.CollectionConfig {
+			return vmcommon.CollectionConfig{AddQuantityDisabled: true}
+		},
+	}
+	eqf, _ := NewDCTNFTAddQuantityFunc(ArgsNewDCTNFTAddQuantity{
+		Config: Config{
+			DCTStorageHandler:     createNewDCTDataStorageHandler(),
+			GlobalSettingsHandler: globalSettingsHandler,
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+				IsValueLengthCheckFlagEnabledField: true,
+			},
+		},
+		FuncGasCost: 10,
+	})
+	output, err := eqf.ProcessBuiltinFunction(
+		mock.NewAccountWrapMock([]byte("addr")),
+		nil,
+		&vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallValue:   big.NewInt(0),
+				Arguments:   [][]byte{[]byte("arg0"), []byte("arg1"), []byte("arg2")},
+				CallerAddr:  []byte("address 1"),
+				GasProvided: 12,
+			},
+			RecipientAddr: []byte("address 1"),
+		},
+	)
+
+	require.Nil(t, output)
+	require.Equal(t, ErrAddQuantityNotAllowed, err)
+}
+
+func TestDctNFTAddQuantity_ProcessBuiltinFunctionWithMaxSupply(t *testing.T) {
+	t.Parallel()
+
+	tokenIdentifier := "testTkn"
+	dctTokenKey := []byte(baseDCTKeyPrefix + tokenIdentifier)
+	nonce := uint64(33)
+	marshaller := &mock.MarshalizerMock{}
+
+	createAccount := func() vmcommon.UserAccountHandler {
+		userAcc := mock.NewAccountWrapMock([]byte("addr"))
+		dctData := &dct.DCToken{
+			TokenMetaData: &dct.MetaData{Name: []byte("test")},
+			Value:         big.NewInt(5),
+		}
+		dctDataBytes, _ := marshaller.Marshal(dctData)
+		_ = userAcc.AccountDataHandler().SaveKeyValue(dctkeys.ComputeDCTNFTTokenKey(dctTokenKey, nonce), dctDataBytes)
+		return userAcc
+	}
+	createInput := func(value int64) *vmcommon.ContractCallInput {
+		return &vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallValue:   big.NewInt(0),
+				Arguments:   [][]byte{[]byte(tokenIdentifier), uint64ToBytes(nonce), big.NewInt(value).Bytes()},
+				CallerAddr:  []byte("address 1"),
+				GasProvided: 12,
+			},
+			RecipientAddr: []byte("address 1"),
+		}
+	}
+
+	t.Run("quantity within the max supply should work", func(t *testing.T) {
+		t.Parallel()
+
+		dctDataStorage := createNewDCTDataStorageHandler()
+		err := dctDataStorage.SaveNFTMaxSupply(dctTokenKey, nonce, big.NewInt(50), big.NewInt(5))
+		require.Nil(t, err)
+		eqf, _ := NewDCTNFTAddQuantityFunc(ArgsNewDCTNFTAddQuantity{
+			Config: Config{
+				DCTStorageHandler:     dctDataStorage,
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+				EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+					IsNFTMaxSupplyFlagEnabledField: true,
+				},
+			},
+			FuncGasCost: 10,
+		})
+		userAcc := createAccount()
+
+		_, err = eqf.ProcessBuiltinFunction(userAcc, nil, createInput(45))
+		require.Nil(t, err)
+
+		_, err = eqf.ProcessBuiltinFunction(userAcc, nil, createInput(1))
+		assert.Equal(t, ErrMaxSupplyExceeded, err)
+	})
+	t.Run("flag not enabled should ignore the max supply", func(t *testing.T) {
+		t.Parallel()
+
+		dctStorageHandler := &mock.DCTNFTStorageHandlerStub{
+			GetDCTNFTTokenOnSenderCalled: createNewDCTDataStorageHandler().GetDCTNFTTokenOnSender,
+			AddToNFTMintedSupplyCalled: func(dctTokenKey []byte, nonce uint64, value *big.Int) error {
+				assert.Fail(t, "should have not been called")
+				return nil
+			},
+		}
+		eqf, _ := NewDCTNFTAddQuantityFunc(ArgsNewDCTNFTAddQuantity{
+			Config: Config{
+				DCTStorageHandler:     dctStorageHandler,
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+				EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			},
+			FuncGasCost: 10,
+		})
+
+		_, err := eqf.ProcessBuiltinFunction(createAccount(), nil, createInput(100))
+		assert.Nil(t, err)
+	})
+}
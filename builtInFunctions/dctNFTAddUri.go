@@ -17,42 +17,38 @@ type dctNFTAddUri struct {
 	rolesHandler          vmcommon.DCTRoleHandler
 	gasConfig             vmcommon.BaseOperationCost
 	funcGasCost           uint64
+	storageUsageTracker   vmcommon.StorageUsageTracker
+	enableEpochsHandler   vmcommon.EnableEpochsHandler
 	mutExecution          sync.RWMutex
 }
 
+// ArgsNewDCTNFTAddUri defines the argument list for new dct NFT add URI built in function
+type ArgsNewDCTNFTAddUri struct {
+	Config
+	FuncGasCost uint64
+	GasConfig   vmcommon.BaseOperationCost
+}
+
 // NewDCTNFTAddUriFunc returns the dct NFT add URI built-in function component
-func NewDCTNFTAddUriFunc(
-	funcGasCost uint64,
-	gasConfig vmcommon.BaseOperationCost,
-	dctStorageHandler vmcommon.DCTNFTStorageHandler,
-	globalSettingsHandler vmcommon.DCTGlobalSettingsHandler,
-	rolesHandler vmcommon.DCTRoleHandler,
-	enableEpochsHandler vmcommon.EnableEpochsHandler,
-) (*dctNFTAddUri, error) {
-	if check.IfNil(dctStorageHandler) {
-		return nil, ErrNilDCTNFTStorageHandler
-	}
-	if check.IfNil(globalSettingsHandler) {
-		return nil, ErrNilGlobalSettingsHandler
-	}
-	if check.IfNil(rolesHandler) {
-		return nil, ErrNilRolesHandler
-	}
-	if check.IfNil(enableEpochsHandler) {
-		return nil, ErrNilEnableEpochsHandler
+func NewDCTNFTAddUriFunc(args ArgsNewDCTNFTAddUri) (*dctNFTAddUri, error) {
+	err := args.checkComponents(core.BuiltInFunctionDCTNFTAddURI, requireGlobalSettingsHandler|requireRolesHandler|requireDCTStorageHandler|requireEnableEpochsHandler)
+	if err != nil {
+		return nil, err
 	}
 
 	e := &dctNFTAddUri{
 		keyPrefix:             []byte(baseDCTKeyPrefix),
-		dctStorageHandler:     dctStorageHandler,
-		funcGasCost:           funcGasCost,
+		dctStorageHandler:     args.DCTStorageHandler,
+		funcGasCost:           args.FuncGasCost,
 		mutExecution:          sync.RWMutex{},
-		globalSettingsHandler: globalSettingsHandler,
-		gasConfig:             gasConfig,
-		rolesHandler:          rolesHandler,
+		globalSettingsHandler: args.GlobalSettingsHandler,
+		gasConfig:             args.GasConfig,
+		rolesHandler:          args.RolesHandler,
+		storageUsageTracker:   &disabledStorageUsageTracker{},
+		enableEpochsHandler:   args.EnableEpochsHandler,
 	}
 
-	e.baseActiveHandler.activeHandler = enableEpochsHandler.IsDCTNFTImprovementV1FlagEnabled
+	e.baseActiveHandler.activeHandler = args.EnableEpochsHandler.IsDCTNFTImprovementV1FlagEnabled
 
 	return e, nil
 }
@@ -69,6 +65,19 @@ func (e *dctNFTAddUri) SetNewGasConfig(gasCost *vmcommon.GasCost) {
 	e.mutExecution.Unlock()
 }
 
+// SetStorageUsageTracker sets the tracker metering the added URIs, which are accounted to the caller
+func (e *dctNFTAddUri) SetStorageUsageTracker(storageUsageTracker vmcommon.StorageUsageTracker) error {
+	if check.IfNil(storageUsageTracker) {
+		return ErrNilStorageUsageTracker
+	}
+
+	e.mutExecution.Lock()
+	e.storageUsageTracker = storageUsageTracker
+	e.mutExecution.Unlock()
+
+	return nil
+}
+
 // ProcessBuiltinFunction resolves DCT NFT add uris function call
 // Requires 3 arguments:
 // arg0 - token identifier
@@ -100,7 +109,10 @@ func (e *dctNFTAddUri) ProcessBuiltinFunction(
 	}
 
 	dctTokenKey := append(e.keyPrefix, vmInput.Arguments[0]...)
-	nonce := big.NewInt(0).SetBytes(vmInput.Arguments[1]).Uint64()
+	nonce, err := flaggedUint64Argument(e.enableEpochsHandler, vmInput.Arguments, 1)
+	if err != nil {
+		return nil, err
+	}
 	if nonce == 0 {
 		return nil, ErrNFTDoesNotHaveMetadata
 	}
@@ -110,7 +122,15 @@ func (e *dctNFTAddUri) ProcessBuiltinFunction(
 	}
 
 	dctData.TokenMetaData.URIs = append(dctData.TokenMetaData.URIs, vmInput.Arguments[2:]...)
+	err = checkCollectionURIs(e.globalSettingsHandler, vmInput.Arguments[0], len(dctData.TokenMetaData.URIs))
+	if err != nil {
+		return nil, err
+	}
 
+	err = e.storageUsageTracker.TrackStorageUsage(acntSnd, int64(getLengthOfURIs(vmInput.Arguments[2:])))
+	if err != nil {
+		return nil, err
+	}
 	_, err = e.dctStorageHandler.SaveDCTNFTToken(acntSnd.AddressBytes(), acntSnd, dctTokenKey, nonce, dctData, true, vmInput.ReturnCallAfterError)
 	if err != nil {
 		return nil, err
@@ -120,6 +140,10 @@ func (e *dctNFTAddUri) ProcessBuiltinFunction(
 		ReturnCode:   vmcommon.Ok,
 		GasRemaining: vmInput.GasProvided - e.funcGasCost - gasCostForStore,
 	}
+	addGasBreakdownToVMOutput(vmInput, vmOutput, vmcommon.GasBreakdown{
+		BaseCost:    e.funcGasCost,
+		StorageCost: gasCostForStore,
+	})
 
 	extraTopics := append([][]byte{vmInput.CallerAddr}, vmInput.Arguments[2:]...)
 	addDCTEntryInVMOutput(vmOutput, []byte(core.BuiltInFunctionDCTNFTAddURI), vmInput.Arguments[0], nonce, big.NewInt(0), extraTopics...)
@@ -128,11 +152,15 @@ func (e *dctNFTAddUri) ProcessBuiltinFunction(
 }
 
 func (e *dctNFTAddUri) getGasCostForURIStore(vmInput *vmcommon.ContractCallInput) uint64 {
+	return uint64(getLengthOfURIs(vmInput.Arguments[2:])) * e.gasConfig.StorePerByte
+}
+
+func getLengthOfURIs(uris [][]byte) int {
 	lenURIs := 0
-	for _, uri := range vmInput.Arguments[2:] {
+	for _, uri := range uris {
 		lenURIs += len(uri)
 	}
-	return uint64(lenURIs) * e.gasConfig.StorePerByte
+	return lenURIs
 }
 
 // IsInterfaceNil returns true if underlying object in nil
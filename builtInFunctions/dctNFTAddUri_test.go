@@ -17,38 +17,75 @@ import (
 func TestNewDCTNFTAddUriFunc(t *testing.T) {
 	t.Parallel()
 
-	t.Run("nil marshaller should error", func(t *testing.T) {
+	t.Run("nil dct storage handler should error", func(t *testing.T) {
 		t.Parallel()
 
-		e, err := NewDCTNFTAddUriFunc(10, vmcommon.BaseOperationCost{}, nil, nil, nil, nil)
+		e, err := NewDCTNFTAddUriFunc(ArgsNewDCTNFTAddUri{
+			Config: Config{
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+			},
+			FuncGasCost: 10,
+			GasConfig:   vmcommon.BaseOperationCost{},
+		})
 		require.True(t, check.IfNil(e))
-		require.Equal(t, ErrNilDCTNFTStorageHandler, err)
+		require.ErrorIs(t, err, ErrNilDCTNFTStorageHandler)
 	})
 	t.Run("nil global settings handler should error", func(t *testing.T) {
 		t.Parallel()
 
-		e, err := NewDCTNFTAddUriFunc(10, vmcommon.BaseOperationCost{}, createNewDCTDataStorageHandler(), nil, nil, nil)
+		e, err := NewDCTNFTAddUriFunc(ArgsNewDCTNFTAddUri{
+			Config: Config{
+				DCTStorageHandler: createNewDCTDataStorageHandler(),
+			},
+			FuncGasCost: 10,
+			GasConfig:   vmcommon.BaseOperationCost{},
+		})
 		require.True(t, check.IfNil(e))
-		require.Equal(t, ErrNilGlobalSettingsHandler, err)
+		require.ErrorIs(t, err, ErrNilGlobalSettingsHandler)
 	})
 	t.Run("nil roles handler should error", func(t *testing.T) {
 		t.Parallel()
 
-		e, err := NewDCTNFTAddUriFunc(10, vmcommon.BaseOperationCost{}, createNewDCTDataStorageHandler(), &mock.GlobalSettingsHandlerStub{}, nil, nil)
+		e, err := NewDCTNFTAddUriFunc(ArgsNewDCTNFTAddUri{
+			Config: Config{
+				DCTStorageHandler:     createNewDCTDataStorageHandler(),
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			},
+			FuncGasCost: 10,
+			GasConfig:   vmcommon.BaseOperationCost{},
+		})
 		require.True(t, check.IfNil(e))
-		require.Equal(t, ErrNilRolesHandler, err)
+		require.ErrorIs(t, err, ErrNilRolesHandler)
 	})
 	t.Run("nil enable epochs handler should error", func(t *testing.T) {
 		t.Parallel()
 
-		e, err := NewDCTNFTAddUriFunc(10, vmcommon.BaseOperationCost{}, createNewDCTDataStorageHandler(), &mock.GlobalSettingsHandlerStub{}, &mock.DCTRoleHandlerStub{}, nil)
+		e, err := NewDCTNFTAddUriFunc(ArgsNewDCTNFTAddUri{
+			Config: Config{
+				DCTStorageHandler:     createNewDCTDataStorageHandler(),
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+			},
+			FuncGasCost: 10,
+			GasConfig:   vmcommon.BaseOperationCost{},
+		})
 		require.True(t, check.IfNil(e))
-		require.Equal(t, ErrNilEnableEpochsHandler, err)
+		require.ErrorIs(t, err, ErrNilEnableEpochsHandler)
 	})
 	t.Run("should work", func(t *testing.T) {
 		t.Parallel()
 
-		e, err := NewDCTNFTAddUriFunc(10, vmcommon.BaseOperationCost{}, createNewDCTDataStorageHandler(), &mock.GlobalSettingsHandlerStub{}, &mock.DCTRoleHandlerStub{}, &mock.EnableEpochsHandlerStub{})
+		e, err := NewDCTNFTAddUriFunc(ArgsNewDCTNFTAddUri{
+			Config: Config{
+				DCTStorageHandler:     createNewDCTDataStorageHandler(),
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+				EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			},
+			FuncGasCost: 10,
+			GasConfig:   vmcommon.BaseOperationCost{},
+		})
 		require.False(t, check.IfNil(e))
 		require.NoError(t, err)
 		require.False(t, e.IsActive())
@@ -59,8 +96,17 @@ func TestDCTNFTAddUri_SetNewGasConfig_NilGasCost(t *testing.T) {
 	t.Parallel()
 
 	defaultGasCost := uint64(10)
-	e, _ := NewDCTNFTAddUriFunc(defaultGasCost, vmcommon.BaseOperationCost{}, createNewDCTDataStorageHandler(), &mock.GlobalSettingsHandlerStub{}, &mock.DCTRoleHandlerStub{}, &mock.EnableEpochsHandlerStub{
-		IsDCTNFTImprovementV1FlagEnabledField: true,
+	e, _ := NewDCTNFTAddUriFunc(ArgsNewDCTNFTAddUri{
+		Config: Config{
+			DCTStorageHandler:     createNewDCTDataStorageHandler(),
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+				IsDCTNFTImprovementV1FlagEnabledField: true,
+			},
+		},
+		FuncGasCost: defaultGasCost,
+		GasConfig:   vmcommon.BaseOperationCost{},
 	})
 
 	e.SetNewGasConfig(nil)
@@ -72,8 +118,17 @@ func TestDCTNFTAddUri_SetNewGasConfig_ShouldWork(t *testing.T) {
 
 	defaultGasCost := uint64(10)
 	newGasCost := uint64(37)
-	e, _ := NewDCTNFTAddUriFunc(defaultGasCost, vmcommon.BaseOperationCost{}, createNewDCTDataStorageHandler(), &mock.GlobalSettingsHandlerStub{}, &mock.DCTRoleHandlerStub{}, &mock.EnableEpochsHandlerStub{
-		IsDCTNFTImprovementV1FlagEnabledField: true,
+	e, _ := NewDCTNFTAddUriFunc(ArgsNewDCTNFTAddUri{
+		Config: Config{
+			DCTStorageHandler:     createNewDCTDataStorageHandler(),
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+				IsDCTNFTImprovementV1FlagEnabledField: true,
+			},
+		},
+		FuncGasCost: defaultGasCost,
+		GasConfig:   vmcommon.BaseOperationCost{},
 	})
 
 	e.SetNewGasConfig(
@@ -90,8 +145,17 @@ func TestDCTNFTAddUri_SetNewGasConfig_ShouldWork(t *testing.T) {
 func TestDCTNFTAddUri_ProcessBuiltinFunctionErrorOnCheckInput(t *testing.T) {
 	t.Parallel()
 
-	e, _ := NewDCTNFTAddUriFunc(10, vmcommon.BaseOperationCost{}, createNewDCTDataStorageHandler(), &mock.GlobalSettingsHandlerStub{}, &mock.DCTRoleHandlerStub{}, &mock.EnableEpochsHandlerStub{
-		IsDCTNFTImprovementV1FlagEnabledField: true,
+	e, _ := NewDCTNFTAddUriFunc(ArgsNewDCTNFTAddUri{
+		Config: Config{
+			DCTStorageHandler:     createNewDCTDataStorageHandler(),
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+				IsDCTNFTImprovementV1FlagEnabledField: true,
+			},
+		},
+		FuncGasCost: 10,
+		GasConfig:   vmcommon.BaseOperationCost{},
 	})
 
 	// nil vm input
@@ -193,8 +257,17 @@ func TestDCTNFTAddUri_ProcessBuiltinFunctionErrorOnCheckInput(t *testing.T) {
 func TestDCTNFTAddUri_ProcessBuiltinFunctionInvalidNumberOfArguments(t *testing.T) {
 	t.Parallel()
 
-	e, _ := NewDCTNFTAddUriFunc(10, vmcommon.BaseOperationCost{}, createNewDCTDataStorageHandler(), &mock.GlobalSettingsHandlerStub{}, &mock.DCTRoleHandlerStub{}, &mock.EnableEpochsHandlerStub{
-		IsDCTNFTImprovementV1FlagEnabledField: true,
+	e, _ := NewDCTNFTAddUriFunc(ArgsNewDCTNFTAddUri{
+		Config: Config{
+			DCTStorageHandler:     createNewDCTDataStorageHandler(),
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+				IsDCTNFTImprovementV1FlagEnabledField: true,
+			},
+		},
+		FuncGasCost: 10,
+		GasConfig:   vmcommon.BaseOperationCost{},
 	})
 	output, err := e.ProcessBuiltinFunction(
 		mock.NewAccountWrapMock([]byte("addr")),
@@ -222,8 +295,17 @@ func TestDCTNFTAddUri_ProcessBuiltinFunctionCheckAllowedToExecuteError(t *testin
 			return localErr
 		},
 	}
-	e, _ := NewDCTNFTAddUriFunc(10, vmcommon.BaseOperationCost{}, createNewDCTDataStorageHandler(), &mock.GlobalSettingsHandlerStub{}, rolesHandler, &mock.EnableEpochsHandlerStub{
-		IsDCTNFTImprovementV1FlagEnabledField: true,
+	e, _ := NewDCTNFTAddUriFunc(ArgsNewDCTNFTAddUri{
+		Config: Config{
+			DCTStorageHandler:     createNewDCTDataStorageHandler(),
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          rolesHandler,
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+				IsDCTNFTImprovementV1FlagEnabledField: true,
+			},
+		},
+		FuncGasCost: 10,
+		GasConfig:   vmcommon.BaseOperationCost{},
 	})
 	output, err := e.ProcessBuiltinFunction(
 		mock.NewAccountWrapMock([]byte("addr")),
@@ -246,8 +328,17 @@ func TestDCTNFTAddUri_ProcessBuiltinFunctionCheckAllowedToExecuteError(t *testin
 func TestDCTNFTAddUri_ProcessBuiltinFunctionNewSenderShouldErr(t *testing.T) {
 	t.Parallel()
 
-	e, _ := NewDCTNFTAddUriFunc(10, vmcommon.BaseOperationCost{}, createNewDCTDataStorageHandler(), &mock.GlobalSettingsHandlerStub{}, &mock.DCTRoleHandlerStub{}, &mock.EnableEpochsHandlerStub{
-		IsDCTNFTImprovementV1FlagEnabledField: true,
+	e, _ := NewDCTNFTAddUriFunc(ArgsNewDCTNFTAddUri{
+		Config: Config{
+			DCTStorageHandler:     createNewDCTDataStorageHandler(),
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+				IsDCTNFTImprovementV1FlagEnabledField: true,
+			},
+		},
+		FuncGasCost: 10,
+		GasConfig:   vmcommon.BaseOperationCost{},
 	})
 	output, err := e.ProcessBuiltinFunction(
 		mock.NewAccountWrapMock([]byte("addr")),
@@ -272,8 +363,17 @@ func TestDCTNFTAddUri_ProcessBuiltinFunctionMetaDataMissing(t *testing.T) {
 	t.Parallel()
 
 	marshaller := &mock.MarshalizerMock{}
-	e, _ := NewDCTNFTAddUriFunc(10, vmcommon.BaseOperationCost{}, createNewDCTDataStorageHandler(), &mock.GlobalSettingsHandlerStub{}, &mock.DCTRoleHandlerStub{}, &mock.EnableEpochsHandlerStub{
-		IsDCTNFTImprovementV1FlagEnabledField: true,
+	e, _ := NewDCTNFTAddUriFunc(ArgsNewDCTNFTAddUri{
+		Config: Config{
+			DCTStorageHandler:     createNewDCTDataStorageHandler(),
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+				IsDCTNFTImprovementV1FlagEnabledField: true,
+			},
+		},
+		FuncGasCost: 10,
+		GasConfig:   vmcommon.BaseOperationCost{},
 	})
 
 	userAcc := mock.NewAccountWrapMock([]byte("addr"))
@@ -310,7 +410,16 @@ func TestDCTNFTAddUri_ProcessBuiltinFunctionShouldErrOnSaveBecauseTokenIsPaused(
 	enableEpochsHandler := &mock.EnableEpochsHandlerStub{
 		IsDCTNFTImprovementV1FlagEnabledField: true,
 	}
-	e, _ := NewDCTNFTAddUriFunc(10, vmcommon.BaseOperationCost{}, createNewDCTDataStorageHandlerWithArgs(globalSettingsHandler, &mock.AccountsStub{}, enableEpochsHandler), globalSettingsHandler, &mock.DCTRoleHandlerStub{}, enableEpochsHandler)
+	e, _ := NewDCTNFTAddUriFunc(ArgsNewDCTNFTAddUri{
+		Config: Config{
+			DCTStorageHandler:     createNewDCTDataStorageHandlerWithArgs(globalSettingsHandler, &mock.AccountsStub{}, enableEpochsHandler),
+			GlobalSettingsHandler: globalSettingsHandler,
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			EnableEpochsHandler:   enableEpochsHandler,
+		},
+		FuncGasCost: 10,
+		GasConfig:   vmcommon.BaseOperationCost{},
+	})
 
 	userAcc := mock.NewAccountWrapMock([]byte("addr"))
 	dctData := &dct.DCToken{
@@ -361,7 +470,16 @@ func TestDCTNFTAddUri_ProcessBuiltinFunctionShouldWork(t *testing.T) {
 	enableEpochsHandler := &mock.EnableEpochsHandlerStub{
 		IsDCTNFTImprovementV1FlagEnabledField: true,
 	}
-	e, _ := NewDCTNFTAddUriFunc(10, vmcommon.BaseOperationCost{}, dctDataStorage, &mock.GlobalSettingsHandlerStub{}, dctRoleHandler, enableEpochsHandler)
+	e, _ := NewDCTNFTAddUriFunc(ArgsNewDCTNFTAddUri{
+		Config: Config{
+			DCTStorageHandler:     dctDataStorage,
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          dctRoleHandler,
+			EnableEpochsHandler:   enableEpochsHandler,
+		},
+		FuncGasCost: 10,
+		GasConfig:   vmcommon.BaseOperationCost{},
+	})
 
 	userAcc := mock.NewAccountWrapMock([]byte("addr"))
 	dctData := &dct.DCToken{
@@ -399,3 +517,119 @@ func TestDCTNFTAddUri_ProcessBuiltinFunctionShouldWork(t *testing.T) {
 	metaData, _ := dctDataStorage.getDCTMetaDataFromSystemAccount(tokenKey, defaultQueryOptions())
 	require.Equal(t, metaData.URIs[0], URIToAdd)
 }
+
+func TestDCTNFTAddUri_ProcessBuiltinFunctionCollectionURIsLimitShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tokenIdentifier := "testTkn"
+	nonce := big.NewInt(33)
+	marshaller := &mock.MarshalizerMock{}
+	globalSettingsHandler := &mock.GlobalSettingsHandlerStub{
+		GetCollectionConfigCalled: func(tokenID []byte) vmcommon.CollectionConfig {
+			assert.Equal(t, tokenIdentifier, string(tokenID))
+			return vmcommon.CollectionConfig{MaxNumURIs: 1}
+		},
+	}
+	enableEpochsHandler := &mock.EnableEpochsHandlerStub{
+		IsDCTNFTImprovementV1FlagEnabledField: true,
+	}
+	e, _ := NewDCTNFTAddUriFunc(ArgsNewDCTNFTAddUri{
+		Config: Config{
+			DCTStorageHandler:     createNewDCTDataStorageHandler(),
+			GlobalSettingsHandler: globalSettingsHandler,
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			EnableEpochsHandler:   enableEpochsHandler,
+		},
+		FuncGasCost: 10,
+		GasConfig:   vmcommon.BaseOperationCost{},
+	})
+
+	userAcc := mock.NewAccountWrapMock([]byte("addr"))
+	dctData := &dct.DCToken{
+		TokenMetaData: &dct.MetaData{
+			Name: []byte("test"),
+			URIs: [][]byte{[]byte("uri")},
+		},
+		Value: big.NewInt(5),
+	}
+	dctDataBytes, _ := marshaller.Marshal(dctData)
+	tokenKey := append([]byte(baseDCTKeyPrefix+tokenIdentifier), nonce.Bytes()...)
+	_ = userAcc.AccountDataHandler().SaveKeyValue(tokenKey, dctDataBytes)
+
+	output, err := e.ProcessBuiltinFunction(
+		userAcc,
+		nil,
+		&vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallValue:   big.NewInt(0),
+				Arguments:   [][]byte{[]byte(tokenIdentifier), nonce.Bytes(), []byte("NewURI")},
+				CallerAddr:  []byte("address 1"),
+				GasProvided: 12,
+			},
+			RecipientAddr: []byte("address 1"),
+		},
+	)
+
+	require.Nil(t, output)
+	require.Equal(t, ErrTooManyURIs, err)
+}
+
+func TestDCTNFTAddUri_ProcessBuiltinFunctionStorageUsageTracker(t *testing.T) {
+	t.Parallel()
+
+	tokenIdentifier := "testTkn"
+	nonce := big.NewInt(33)
+	enableEpochsHandler := &mock.EnableEpochsHandlerStub{
+		IsDCTNFTImprovementV1FlagEnabledField: true,
+	}
+	e, _ := NewDCTNFTAddUriFunc(ArgsNewDCTNFTAddUri{
+		Config: Config{
+			DCTStorageHandler:     createNewDCTDataStorageHandler(),
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			EnableEpochsHandler:   enableEpochsHandler,
+		},
+		FuncGasCost: 10,
+		GasConfig:   vmcommon.BaseOperationCost{},
+	})
+	require.Equal(t, ErrNilStorageUsageTracker, e.SetStorageUsageTracker(nil))
+
+	userAcc := mock.NewAccountWrapMock([]byte("addr"))
+	dctData := &dct.DCToken{
+		TokenMetaData: &dct.MetaData{
+			Name: []byte("test"),
+		},
+		Value: big.NewInt(5),
+	}
+	dctDataBytes, _ := (&mock.MarshalizerMock{}).Marshal(dctData)
+	tokenKey := append([]byte(baseDCTKeyPrefix+tokenIdentifier), nonce.Bytes()...)
+	_ = userAcc.AccountDataHandler().SaveKeyValue(tokenKey, dctDataBytes)
+
+	var trackedDelta int64
+	err := e.SetStorageUsageTracker(&mock.StorageUsageTrackerStub{
+		TrackStorageUsageCalled: func(account vmcommon.UserAccountHandler, bytesDelta int64) error {
+			assert.Equal(t, userAcc, account)
+			trackedDelta = bytesDelta
+			return ErrStorageLimitExceeded
+		},
+	})
+	require.Nil(t, err)
+
+	output, err := e.ProcessBuiltinFunction(
+		userAcc,
+		nil,
+		&vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallValue:   big.NewInt(0),
+				Arguments:   [][]byte{[]byte(tokenIdentifier), nonce.Bytes(), []byte("uri1"), []byte("uri22")},
+				CallerAddr:  []byte("address 1"),
+				GasProvided: 12,
+			},
+			RecipientAddr: []byte("address 1"),
+		},
+	)
+
+	require.Nil(t, output)
+	require.Equal(t, ErrStorageLimitExceeded, err)
+	require.Equal(t, int64(9), trackedDelta)
+}
@@ -11,38 +11,39 @@ import (
 
 type dctNFTBurn struct {
 	baseAlwaysActiveHandler
+	freezeAccountChecker
 	keyPrefix             []byte
 	dctStorageHandler     vmcommon.DCTNFTStorageHandler
 	globalSettingsHandler vmcommon.ExtendedDCTGlobalSettingsHandler
 	rolesHandler          vmcommon.DCTRoleHandler
+	storageUsageTracker   vmcommon.StorageUsageTracker
+	enableEpochsHandler   vmcommon.EnableEpochsHandler
 	funcGasCost           uint64
 	mutExecution          sync.RWMutex
 }
 
+// ArgsNewDCTNFTBurn defines the argument list for new dct NFT burn built in function
+type ArgsNewDCTNFTBurn struct {
+	Config
+	FuncGasCost uint64
+}
+
 // NewDCTNFTBurnFunc returns the dct NFT burn built-in function component
-func NewDCTNFTBurnFunc(
-	funcGasCost uint64,
-	dctStorageHandler vmcommon.DCTNFTStorageHandler,
-	globalSettingsHandler vmcommon.ExtendedDCTGlobalSettingsHandler,
-	rolesHandler vmcommon.DCTRoleHandler,
-) (*dctNFTBurn, error) {
-	if check.IfNil(dctStorageHandler) {
-		return nil, ErrNilDCTNFTStorageHandler
-	}
-	if check.IfNil(globalSettingsHandler) {
-		return nil, ErrNilGlobalSettingsHandler
-	}
-	if check.IfNil(rolesHandler) {
-		return nil, ErrNilRolesHandler
+func NewDCTNFTBurnFunc(args ArgsNewDCTNFTBurn) (*dctNFTBurn, error) {
+	err := args.checkComponents(core.BuiltInFunctionDCTNFTBurn, requireGlobalSettingsHandler|requireRolesHandler|requireDCTStorageHandler|requireEnableEpochsHandler)
+	if err != nil {
+		return nil, err
 	}
 
 	e := &dctNFTBurn{
 		keyPrefix:             []byte(baseDCTKeyPrefix),
-		dctStorageHandler:     dctStorageHandler,
-		globalSettingsHandler: globalSettingsHandler,
-		rolesHandler:          rolesHandler,
-		funcGasCost:           funcGasCost,
+		dctStorageHandler:     args.DCTStorageHandler,
+		globalSettingsHandler: args.GlobalSettingsHandler,
+		rolesHandler:          args.RolesHandler,
+		funcGasCost:           args.FuncGasCost,
 		mutExecution:          sync.RWMutex{},
+		storageUsageTracker:   &disabledStorageUsageTracker{},
+		enableEpochsHandler:   args.EnableEpochsHandler,
 	}
 
 	return e, nil
@@ -59,6 +60,20 @@ func (e *dctNFTBurn) SetNewGasConfig(gasCost *vmcommon.GasCost) {
 	e.mutExecution.Unlock()
 }
 
+// SetStorageUsageTracker sets the tracker releasing the metadata bytes of the NFTs once the caller burns all its
+// quantity. The usage never goes below zero, so releasing the bytes accounted to another account is harmless
+func (e *dctNFTBurn) SetStorageUsageTracker(storageUsageTracker vmcommon.StorageUsageTracker) error {
+	if check.IfNil(storageUsageTracker) {
+		return ErrNilStorageUsageTracker
+	}
+
+	e.mutExecution.Lock()
+	e.storageUsageTracker = storageUsageTracker
+	e.mutExecution.Unlock()
+
+	return nil
+}
+
 // ProcessBuiltinFunction resolves DCT NFT burn function call
 // Requires 3 arguments:
 // arg0 - token identifier
@@ -75,6 +90,10 @@ func (e *dctNFTBurn) ProcessBuiltinFunction(
 	if err != nil {
 		return nil, err
 	}
+	err = e.checkAccountIsNotFrozen(vmInput.CallerAddr, vmInput.ReturnCallAfterError)
+	if err != nil {
+		return nil, err
+	}
 	if len(vmInput.Arguments) < 3 {
 		return nil, ErrInvalidArguments
 	}
@@ -85,7 +104,10 @@ func (e *dctNFTBurn) ProcessBuiltinFunction(
 		return nil, err
 	}
 
-	nonce := big.NewInt(0).SetBytes(vmInput.Arguments[1]).Uint64()
+	nonce, err := flaggedUint64Argument(e.enableEpochsHandler, vmInput.Arguments, 1)
+	if err != nil {
+		return nil, err
+	}
 	dctData, err := e.dctStorageHandler.GetDCTNFTTokenOnSender(acntSnd, dctTokenKey, nonce)
 	if err != nil {
 		return nil, err
@@ -94,12 +116,23 @@ func (e *dctNFTBurn) ProcessBuiltinFunction(
 		return nil, ErrNFTDoesNotHaveMetadata
 	}
 
+	err = checkMetaDCTValueLength(dctData.Type, vmInput.Arguments[2])
+	if err != nil {
+		return nil, err
+	}
+
 	quantityToBurn := big.NewInt(0).SetBytes(vmInput.Arguments[2])
 	if dctData.Value.Cmp(quantityToBurn) < 0 {
 		return nil, ErrInvalidNFTQuantity
 	}
 
 	dctData.Value.Sub(dctData.Value, quantityToBurn)
+	if dctData.Value.Sign() == 0 && dctData.TokenMetaData != nil {
+		err = e.storageUsageTracker.TrackStorageUsage(acntSnd, -getMetaDataSize(dctData.TokenMetaData))
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	_, err = e.dctStorageHandler.SaveDCTNFTToken(acntSnd.AddressBytes(), acntSnd, dctTokenKey, nonce, dctData, false, vmInput.ReturnCallAfterError)
 	if err != nil {
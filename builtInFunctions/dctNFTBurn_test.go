@@ -17,23 +17,63 @@ import (
 func TestNewDCTNFTBurnFunc(t *testing.T) {
 	t.Parallel()
 
-	// nil marshaller
-	ebf, err := NewDCTNFTBurnFunc(10, nil, nil, nil)
+	// nil dct storage handler
+	ebf, err := NewDCTNFTBurnFunc(ArgsNewDCTNFTBurn{
+		Config: Config{
+			EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+		},
+		FuncGasCost: 10,
+	})
 	require.True(t, check.IfNil(ebf))
-	require.Equal(t, ErrNilDCTNFTStorageHandler, err)
+	require.ErrorIs(t, err, ErrNilDCTNFTStorageHandler)
 
 	// nil pause handler
-	ebf, err = NewDCTNFTBurnFunc(10, createNewDCTDataStorageHandler(), nil, nil)
+	ebf, err = NewDCTNFTBurnFunc(ArgsNewDCTNFTBurn{
+		Config: Config{
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+			DCTStorageHandler:   createNewDCTDataStorageHandler(),
+		},
+		FuncGasCost: 10,
+	})
 	require.True(t, check.IfNil(ebf))
-	require.Equal(t, ErrNilGlobalSettingsHandler, err)
+	require.ErrorIs(t, err, ErrNilGlobalSettingsHandler)
 
 	// nil roles handler
-	ebf, err = NewDCTNFTBurnFunc(10, createNewDCTDataStorageHandler(), &mock.GlobalSettingsHandlerStub{}, nil)
+	ebf, err = NewDCTNFTBurnFunc(ArgsNewDCTNFTBurn{
+		Config: Config{
+			EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			DCTStorageHandler:     createNewDCTDataStorageHandler(),
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+		},
+		FuncGasCost: 10,
+	})
 	require.True(t, check.IfNil(ebf))
-	require.Equal(t, ErrNilRolesHandler, err)
+	require.ErrorIs(t, err, ErrNilRolesHandler)
+
+	// nil enable epochs handler
+	ebf, err = NewDCTNFTBurnFunc(ArgsNewDCTNFTBurn{
+		Config: Config{
+			DCTStorageHandler:     createNewDCTDataStorageHandler(),
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+		},
+		FuncGasCost: 10,
+	})
+	require.True(t, check.IfNil(ebf))
+	require.ErrorIs(t, err, ErrNilEnableEpochsHandler)
 
 	// should work
-	ebf, err = NewDCTNFTBurnFunc(10, createNewDCTDataStorageHandler(), &mock.GlobalSettingsHandlerStub{}, &mock.DCTRoleHandlerStub{})
+	ebf, err = NewDCTNFTBurnFunc(ArgsNewDCTNFTBurn{
+		Config: Config{
+			EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			DCTStorageHandler:     createNewDCTDataStorageHandler(),
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+		},
+		FuncGasCost: 10,
+	})
 	require.False(t, check.IfNil(ebf))
 	require.NoError(t, err)
 }
@@ -42,7 +82,15 @@ func TestDCTNFTBurn_SetNewGasConfig_NilGasCost(t *testing.T) {
 	t.Parallel()
 
 	defaultGasCost := uint64(10)
-	ebf, _ := NewDCTNFTBurnFunc(defaultGasCost, createNewDCTDataStorageHandler(), &mock.GlobalSettingsHandlerStub{}, &mock.DCTRoleHandlerStub{})
+	ebf, _ := NewDCTNFTBurnFunc(ArgsNewDCTNFTBurn{
+		Config: Config{
+			EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			DCTStorageHandler:     createNewDCTDataStorageHandler(),
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+		},
+		FuncGasCost: defaultGasCost,
+	})
 
 	ebf.SetNewGasConfig(nil)
 	require.Equal(t, defaultGasCost, ebf.funcGasCost)
@@ -53,7 +101,15 @@ func TestDctNFTBurnFunc_SetNewGasConfig_ShouldWork(t *testing.T) {
 
 	defaultGasCost := uint64(10)
 	newGasCost := uint64(37)
-	ebf, _ := NewDCTNFTBurnFunc(defaultGasCost, createNewDCTDataStorageHandler(), &mock.GlobalSettingsHandlerStub{}, &mock.DCTRoleHandlerStub{})
+	ebf, _ := NewDCTNFTBurnFunc(ArgsNewDCTNFTBurn{
+		Config: Config{
+			EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			DCTStorageHandler:     createNewDCTDataStorageHandler(),
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+		},
+		FuncGasCost: defaultGasCost,
+	})
 
 	ebf.SetNewGasConfig(
 		&vmcommon.GasCost{
@@ -69,7 +125,15 @@ func TestDctNFTBurnFunc_SetNewGasConfig_ShouldWork(t *testing.T) {
 func TestDctNFTBurnFunc_ProcessBuiltinFunctionErrorOnCheckDCTNFTCreateBurnAddInput(t *testing.T) {
 	t.Parallel()
 
-	ebf, _ := NewDCTNFTBurnFunc(10, createNewDCTDataStorageHandler(), &mock.GlobalSettingsHandlerStub{}, &mock.DCTRoleHandlerStub{})
+	ebf, _ := NewDCTNFTBurnFunc(ArgsNewDCTNFTBurn{
+		Config: Config{
+			EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			DCTStorageHandler:     createNewDCTDataStorageHandler(),
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+		},
+		FuncGasCost: 10,
+	})
 
 	// nil vm input
 	output, err := ebf.ProcessBuiltinFunction(mock.NewAccountWrapMock([]byte("addr")), nil, nil)
@@ -170,7 +234,15 @@ func TestDctNFTBurnFunc_ProcessBuiltinFunctionErrorOnCheckDCTNFTCreateBurnAddInp
 func TestDctNFTBurnFunc_ProcessBuiltinFunctionInvalidNumberOfArguments(t *testing.T) {
 	t.Parallel()
 
-	ebf, _ := NewDCTNFTBurnFunc(10, createNewDCTDataStorageHandler(), &mock.GlobalSettingsHandlerStub{}, &mock.DCTRoleHandlerStub{})
+	ebf, _ := NewDCTNFTBurnFunc(ArgsNewDCTNFTBurn{
+		Config: Config{
+			EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			DCTStorageHandler:     createNewDCTDataStorageHandler(),
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+		},
+		FuncGasCost: 10,
+	})
 	output, err := ebf.ProcessBuiltinFunction(
 		mock.NewAccountWrapMock([]byte("addr")),
 		nil,
@@ -197,7 +269,15 @@ func TestDctNFTBurnFunc_ProcessBuiltinFunctionCheckAllowedToExecuteError(t *test
 			return localErr
 		},
 	}
-	ebf, _ := NewDCTNFTBurnFunc(10, createNewDCTDataStorageHandler(), &mock.GlobalSettingsHandlerStub{}, rolesHandler)
+	ebf, _ := NewDCTNFTBurnFunc(ArgsNewDCTNFTBurn{
+		Config: Config{
+			EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			DCTStorageHandler:     createNewDCTDataStorageHandler(),
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          rolesHandler,
+		},
+		FuncGasCost: 10,
+	})
 	output, err := ebf.ProcessBuiltinFunction(
 		mock.NewAccountWrapMock([]byte("addr")),
 		nil,
@@ -219,7 +299,15 @@ func TestDctNFTBurnFunc_ProcessBuiltinFunctionCheckAllowedToExecuteError(t *test
 func TestDctNFTBurnFunc_ProcessBuiltinFunctionNewSenderShouldErr(t *testing.T) {
 	t.Parallel()
 
-	ebf, _ := NewDCTNFTBurnFunc(10, createNewDCTDataStorageHandler(), &mock.GlobalSettingsHandlerStub{}, &mock.DCTRoleHandlerStub{})
+	ebf, _ := NewDCTNFTBurnFunc(ArgsNewDCTNFTBurn{
+		Config: Config{
+			EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			DCTStorageHandler:     createNewDCTDataStorageHandler(),
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+		},
+		FuncGasCost: 10,
+	})
 	output, err := ebf.ProcessBuiltinFunction(
 		mock.NewAccountWrapMock([]byte("addr")),
 		nil,
@@ -243,7 +331,15 @@ func TestDctNFTBurnFunc_ProcessBuiltinFunctionMetaDataMissing(t *testing.T) {
 	t.Parallel()
 
 	marshaller := &mock.MarshalizerMock{}
-	ebf, _ := NewDCTNFTBurnFunc(10, createNewDCTDataStorageHandler(), &mock.GlobalSettingsHandlerStub{}, &mock.DCTRoleHandlerStub{})
+	ebf, _ := NewDCTNFTBurnFunc(ArgsNewDCTNFTBurn{
+		Config: Config{
+			EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			DCTStorageHandler:     createNewDCTDataStorageHandler(),
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+		},
+		FuncGasCost: 10,
+	})
 
 	userAcc := mock.NewAccountWrapMock([]byte("addr"))
 	dctData := &dct.DCToken{}
@@ -267,6 +363,56 @@ func TestDctNFTBurnFunc_ProcessBuiltinFunctionMetaDataMissing(t *testing.T) {
 	require.Equal(t, ErrNFTDoesNotHaveMetadata, err)
 }
 
+func TestDctNFTBurnFunc_ProcessBuiltinFunctionOversizedNonce(t *testing.T) {
+	t.Parallel()
+
+	processOversizedNonce := func(enableEpochsHandler vmcommon.EnableEpochsHandler) (*vmcommon.VMOutput, error) {
+		ebf, _ := NewDCTNFTBurnFunc(ArgsNewDCTNFTBurn{
+			Config: Config{
+				EnableEpochsHandler:   enableEpochsHandler,
+				DCTStorageHandler:     createNewDCTDataStorageHandler(),
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+			},
+			FuncGasCost: 10,
+		})
+
+		return ebf.ProcessBuiltinFunction(
+			mock.NewAccountWrapMock([]byte("addr")),
+			nil,
+			&vmcommon.ContractCallInput{
+				VMInput: vmcommon.VMInput{
+					CallValue:   big.NewInt(0),
+					Arguments:   [][]byte{[]byte("arg0"), {1, 0, 0, 0, 0, 0, 0, 0, 1}, []byte("arg2")},
+					CallerAddr:  []byte("address 1"),
+					GasProvided: 12,
+				},
+				RecipientAddr: []byte("address 1"),
+			},
+		)
+	}
+
+	t.Run("flag not enabled should truncate the nonce", func(t *testing.T) {
+		t.Parallel()
+
+		// the truncated nonce 1 is looked up and, as the account holds no such token, the burn fails later on
+		output, err := processOversizedNonce(&mock.EnableEpochsHandlerStub{})
+		require.Nil(t, output)
+		require.Error(t, err)
+		require.NotErrorIs(t, err, ErrInvalidArguments)
+	})
+	t.Run("flag enabled should error", func(t *testing.T) {
+		t.Parallel()
+
+		output, err := processOversizedNonce(&mock.EnableEpochsHandlerStub{
+			IsSafeArgumentConversionFlagEnabledField: true,
+		})
+		require.Nil(t, output)
+		require.ErrorIs(t, err, ErrInvalidArguments)
+		require.ErrorIs(t, err, vmcommon.ErrValueOutOfRange)
+	})
+}
+
 func TestDctNFTBurnFunc_ProcessBuiltinFunctionInvalidBurnQuantity(t *testing.T) {
 	t.Parallel()
 
@@ -275,7 +421,15 @@ func TestDctNFTBurnFunc_ProcessBuiltinFunctionInvalidBurnQuantity(t *testing.T)
 
 	marshaller := &mock.MarshalizerMock{}
 
-	ebf, _ := NewDCTNFTBurnFunc(10, createNewDCTDataStorageHandler(), &mock.GlobalSettingsHandlerStub{}, &mock.DCTRoleHandlerStub{})
+	ebf, _ := NewDCTNFTBurnFunc(ArgsNewDCTNFTBurn{
+		Config: Config{
+			EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			DCTStorageHandler:     createNewDCTDataStorageHandler(),
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+		},
+		FuncGasCost: 10,
+	})
 
 	userAcc := mock.NewAccountWrapMock([]byte("addr"))
 	dctData := &dct.DCToken{
@@ -314,7 +468,15 @@ func TestDctNFTBurnFunc_ProcessBuiltinFunctionShouldErrOnSaveBecauseTokenIsPause
 		},
 	}
 
-	ebf, _ := NewDCTNFTBurnFunc(10, createNewDCTDataStorageHandlerWithArgs(globalSettingsHandler, &mock.AccountsStub{}, &mock.EnableEpochsHandlerStub{}), globalSettingsHandler, &mock.DCTRoleHandlerStub{})
+	ebf, _ := NewDCTNFTBurnFunc(ArgsNewDCTNFTBurn{
+		Config: Config{
+			EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			DCTStorageHandler:     createNewDCTDataStorageHandlerWithArgs(globalSettingsHandler, &mock.AccountsStub{}, &mock.EnableEpochsHandlerStub{}),
+			GlobalSettingsHandler: globalSettingsHandler,
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+		},
+		FuncGasCost: 10,
+	})
 
 	userAcc := mock.NewAccountWrapMock([]byte("addr"))
 	dctData := &dct.DCToken{
@@ -362,7 +524,15 @@ func TestDctNFTBurnFunc_ProcessBuiltinFunctionShouldWork(t *testing.T) {
 		},
 	}
 	storageHandler := createNewDCTDataStorageHandler()
-	ebf, _ := NewDCTNFTBurnFunc(10, storageHandler, &mock.GlobalSettingsHandlerStub{}, dctRoleHandler)
+	ebf, _ := NewDCTNFTBurnFunc(ArgsNewDCTNFTBurn{
+		Config: Config{
+			EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			DCTStorageHandler:     storageHandler,
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          dctRoleHandler,
+		},
+		FuncGasCost: 10,
+	})
 
 	userAcc := mock.NewAccountWrapMock([]byte("addr"))
 	dctData := &dct.DCToken{
@@ -417,14 +587,22 @@ func TestDctNFTBurnFunc_ProcessBuiltinFunctionWithGlobalBurn(t *testing.T) {
 
 	marshaller := &mock.MarshalizerMock{}
 	storageHandler := createNewDCTDataStorageHandler()
-	ebf, _ := NewDCTNFTBurnFunc(10, storageHandler, &mock.GlobalSettingsHandlerStub{
-		IsBurnForAllCalled: func(token []byte) bool {
-			return true
-		},
-	}, &mock.DCTRoleHandlerStub{
-		CheckAllowedToExecuteCalled: func(account vmcommon.UserAccountHandler, tokenID []byte, action []byte) error {
-			return errors.New("no burn allowed")
+	ebf, _ := NewDCTNFTBurnFunc(ArgsNewDCTNFTBurn{
+		Config: Config{
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+			DCTStorageHandler:   storageHandler,
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{
+				IsBurnForAllCalled: func(token []byte) bool {
+					return true
+				},
+			},
+			RolesHandler: &mock.DCTRoleHandlerStub{
+				CheckAllowedToExecuteCalled: func(account vmcommon.UserAccountHandler, tokenID []byte, action []byte) error {
+					return errors.New("no burn allowed")
+				},
+			},
 		},
+		FuncGasCost: 10,
 	})
 
 	userAcc := mock.NewAccountWrapMock([]byte("addr"))
@@ -466,3 +644,65 @@ func TestDctNFTBurnFunc_ProcessBuiltinFunctionWithGlobalBurn(t *testing.T) {
 	_ = marshaller.Unmarshal(&finalTokenData, res)
 	require.Equal(t, expectedQuantity.Bytes(), finalTokenData.Value.Bytes())
 }
+
+func TestDctNFTBurnFunc_ProcessBuiltinFunctionStorageUsageTracker(t *testing.T) {
+	t.Parallel()
+
+	tokenIdentifier := "testTkn"
+	key := baseDCTKeyPrefix + tokenIdentifier
+	nonce := big.NewInt(33)
+
+	storageHandler := createNewDCTDataStorageHandler()
+	ebf, _ := NewDCTNFTBurnFunc(ArgsNewDCTNFTBurn{
+		Config: Config{
+			EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			DCTStorageHandler:     storageHandler,
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+		},
+		FuncGasCost: 10,
+	})
+	require.Equal(t, ErrNilStorageUsageTracker, ebf.SetStorageUsageTracker(nil))
+	trackedDeltas := make([]int64, 0)
+	err := ebf.SetStorageUsageTracker(&mock.StorageUsageTrackerStub{
+		TrackStorageUsageCalled: func(account vmcommon.UserAccountHandler, bytesDelta int64) error {
+			trackedDeltas = append(trackedDeltas, bytesDelta)
+			return nil
+		},
+	})
+	require.Nil(t, err)
+
+	userAcc := mock.NewAccountWrapMock([]byte("addr"))
+	dctData := &dct.DCToken{
+		TokenMetaData: &dct.MetaData{
+			Name:       []byte("test"),
+			Attributes: []byte("attributes"),
+		},
+		Value: big.NewInt(10),
+	}
+	dctDataBytes, _ := (&mock.MarshalizerMock{}).Marshal(dctData)
+	nftTokenKey := append([]byte(key), nonce.Bytes()...)
+	_ = userAcc.AccountDataHandler().SaveKeyValue(nftTokenKey, dctDataBytes)
+	_ = storageHandler.saveDCTMetaDataToSystemAccount(userAcc, 0, nftTokenKey, nonce.Uint64(), dctData, true)
+	_ = storageHandler.AddToLiquiditySystemAcc([]byte(key), nonce.Uint64(), dctData.Value)
+
+	burn := func(quantity int64) {
+		output, errBurn := ebf.ProcessBuiltinFunction(userAcc, nil, &vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallValue:   big.NewInt(0),
+				Arguments:   [][]byte{[]byte(tokenIdentifier), nonce.Bytes(), big.NewInt(quantity).Bytes()},
+				CallerAddr:  []byte("address 1"),
+				GasProvided: 12,
+			},
+			RecipientAddr: []byte("address 1"),
+		})
+		require.Nil(t, errBurn)
+		require.Equal(t, vmcommon.Ok, output.ReturnCode)
+	}
+
+	burn(4)
+	assert.Empty(t, trackedDeltas, "the metadata is still held after a partial burn")
+
+	burn(6)
+	assert.Equal(t, []int64{-int64(len("test") + len("attributes"))}, trackedDeltas)
+}
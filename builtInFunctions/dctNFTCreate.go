@@ -2,6 +2,7 @@ package builtInFunctions
 
 import (
 	"bytes"
+	"encoding/hex"
 	"fmt"
 	"math/big"
 	"sync"
@@ -12,15 +13,30 @@ import (
 	"github.com/Reshusk23/sr-me-core/data/vm"
 	logger "github.com/Reshusk23/sr-me-logger"
 	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/dctkeys"
+	"github.com/Reshusk23/sr-vm-common-go/validation"
 )
 
+const minNumArgumentsNFTCreateNotify = 3
+
 var (
-	log         = logger.GetOrCreate("builtInFunctions")
-	noncePrefix = []byte(core.ProtectedKeyPrefix + core.DCTNFTLatestNonceIdentifier)
+	log          = logger.GetOrCreate("builtInFunctions")
+	notifyMarker = []byte(vmcommon.DCTNFTCreateNotifyMarker)
 )
 
+// nftCreateNotifyCall is the call notifying a registry contract of the created nonce
+type nftCreateNotifyCall struct {
+	registry  []byte
+	function  string
+	gasLimit  uint64
+	arguments [][]byte
+}
+
 type dctNFTCreate struct {
-	baseAlwaysActiveHandler
+	baseActiveHandler
+	baseAddressLengthHandler
+	freezeAccountChecker
+	onBehalf              bool
 	keyPrefix             []byte
 	accounts              vmcommon.AccountsAdapter
 	marshaller            vmcommon.Marshalizer
@@ -30,52 +46,60 @@ type dctNFTCreate struct {
 	gasConfig             vmcommon.BaseOperationCost
 	dctStorageHandler     vmcommon.DCTNFTStorageHandler
 	enableEpochsHandler   vmcommon.EnableEpochsHandler
+	nonceCache            vmcommon.LatestNonceCache
+	accountCache          vmcommon.AccountCache
+	hasher                vmcommon.Hasher
+	storageUsageTracker   vmcommon.StorageUsageTracker
 	mutExecution          sync.RWMutex
 }
 
+// ArgsNewDCTNFTCreate defines the argument list for new dct NFT create built in functions
+type ArgsNewDCTNFTCreate struct {
+	Config
+	FuncGasCost uint64
+	GasConfig   vmcommon.BaseOperationCost
+}
+
 // NewDCTNFTCreateFunc returns the dct NFT create built-in function component
-func NewDCTNFTCreateFunc(
-	funcGasCost uint64,
-	gasConfig vmcommon.BaseOperationCost,
-	marshaller vmcommon.Marshalizer,
-	globalSettingsHandler vmcommon.DCTGlobalSettingsHandler,
-	rolesHandler vmcommon.DCTRoleHandler,
-	dctStorageHandler vmcommon.DCTNFTStorageHandler,
-	accounts vmcommon.AccountsAdapter,
-	enableEpochsHandler vmcommon.EnableEpochsHandler,
-) (*dctNFTCreate, error) {
-	if check.IfNil(marshaller) {
-		return nil, ErrNilMarshalizer
-	}
-	if check.IfNil(globalSettingsHandler) {
-		return nil, ErrNilGlobalSettingsHandler
-	}
-	if check.IfNil(rolesHandler) {
-		return nil, ErrNilRolesHandler
-	}
-	if check.IfNil(dctStorageHandler) {
-		return nil, ErrNilDCTNFTStorageHandler
-	}
-	if check.IfNil(enableEpochsHandler) {
-		return nil, ErrNilEnableEpochsHandler
-	}
-	if check.IfNil(accounts) {
-		return nil, ErrNilAccountsAdapter
+func NewDCTNFTCreateFunc(args ArgsNewDCTNFTCreate) (*dctNFTCreate, error) {
+	err := args.checkComponents(core.BuiltInFunctionDCTNFTCreate, requireMarshalizer|requireAccounts|requireGlobalSettingsHandler|
+		requireRolesHandler|requireDCTStorageHandler|requireEnableEpochsHandler)
+	if err != nil {
+		return nil, err
 	}
 
 	e := &dctNFTCreate{
 		keyPrefix:             []byte(baseDCTKeyPrefix),
-		marshaller:            marshaller,
-		globalSettingsHandler: globalSettingsHandler,
-		rolesHandler:          rolesHandler,
-		funcGasCost:           funcGasCost,
-		gasConfig:             gasConfig,
-		dctStorageHandler:     dctStorageHandler,
-		enableEpochsHandler:   enableEpochsHandler,
+		marshaller:            args.Marshalizer,
+		globalSettingsHandler: args.GlobalSettingsHandler,
+		rolesHandler:          args.RolesHandler,
+		funcGasCost:           args.FuncGasCost,
+		gasConfig:             args.GasConfig,
+		dctStorageHandler:     args.DCTStorageHandler,
+		enableEpochsHandler:   args.EnableEpochsHandler,
+		nonceCache:            &disabledLatestNonceCache{},
+		accountCache:          &disabledAccountCache{},
+		storageUsageTracker:   &disabledStorageUsageTracker{},
 		mutExecution:          sync.RWMutex{},
-		accounts:              accounts,
+		accounts:              args.Accounts,
+	}
+
+	e.baseActiveHandler.activeHandler = trueHandler
+
+	return e, nil
+}
+
+// NewDCTNFTCreateOnBehalfFunc returns the dct NFT create built-in function component which records as creator the
+// address given as argument instead of the caller
+func NewDCTNFTCreateOnBehalfFunc(args ArgsNewDCTNFTCreate) (*dctNFTCreate, error) {
+	e, err := NewDCTNFTCreateFunc(args)
+	if err != nil {
+		return nil, err
 	}
 
+	e.onBehalf = true
+	e.baseActiveHandler.activeHandler = args.EnableEpochsHandler.IsNFTCreateOnBehalfFlagEnabled
+
 	return e, nil
 }
 
@@ -91,15 +115,81 @@ func (e *dctNFTCreate) SetNewGasConfig(gasCost *vmcommon.GasCost) {
 	e.mutExecution.Unlock()
 }
 
+// SetLatestNonceCache sets the cache used to avoid reading the latest nonce from the account data trie on each create.
+// The cache must be bound to the accounts adapter of the function, see NewAccountsAdapterWithLatestNonceCache
+func (e *dctNFTCreate) SetLatestNonceCache(nonceCache vmcommon.LatestNonceCache) error {
+	if check.IfNil(nonceCache) {
+		return ErrNilLatestNonceCache
+	}
+	if !isCacheBoundToAccounts(e.accounts, nonceCache) {
+		return ErrLatestNonceCacheNotBoundToAccounts
+	}
+
+	e.mutExecution.Lock()
+	e.nonceCache = nonceCache
+	e.mutExecution.Unlock()
+
+	return nil
+}
+
+// SetAccountCache sets the cache used to avoid loading the account holding the roles on each create executed on its
+// behalf. The cache must be bound to the accounts adapter of the function, see NewAccountsAdapterWithAccountCache
+func (e *dctNFTCreate) SetAccountCache(accountCache vmcommon.AccountCache) error {
+	if check.IfNil(accountCache) {
+		return ErrNilAccountCache
+	}
+	if !isCacheBoundToAccounts(e.accounts, accountCache) {
+		return ErrAccountCacheNotBoundToAccounts
+	}
+
+	e.mutExecution.Lock()
+	e.accountCache = accountCache
+	e.mutExecution.Unlock()
+
+	return nil
+}
+
+// SetHasher sets the hasher verifying the content hash of the tokens created in the collections requiring it
+func (e *dctNFTCreate) SetHasher(hasher vmcommon.Hasher) error {
+	if check.IfNil(hasher) {
+		return ErrNilHasher
+	}
+
+	e.mutExecution.Lock()
+	e.hasher = hasher
+	e.mutExecution.Unlock()
+
+	return nil
+}
+
+// SetStorageUsageTracker sets the tracker metering the metadata of the created tokens, which is accounted to the
+// account holding the create role for good, as the tokens can be moved away from it
+func (e *dctNFTCreate) SetStorageUsageTracker(storageUsageTracker vmcommon.StorageUsageTracker) error {
+	if check.IfNil(storageUsageTracker) {
+		return ErrNilStorageUsageTracker
+	}
+
+	e.mutExecution.Lock()
+	e.storageUsageTracker = storageUsageTracker
+	e.mutExecution.Unlock()
+
+	return nil
+}
+
 // ProcessBuiltinFunction resolves DCT NFT create function call
 // Requires at least 7 arguments:
 // arg0 - token identifier
-// arg1 - initial quantity
+// arg1 - initial quantity, a fungible amount for the collections of meta dct tokens
 // arg2 - NFT name
-// arg3 - Royalties - max 10000
-// arg4 - hash
+// arg3 - Royalties - max the royalties denominator of the collection, 10000 by default
+// arg4 - hash, the hash of the attributes for the collections requiring the content hash
 // arg5 - attributes
 // arg6+ - multiple entries of URI (minimum 1)
+// The create on behalf function expects the creator address as arg6, the URIs following it
+// Once the NFT max supply is enabled, the max supply of the nonce, 0 meaning uncapped, precedes the URIs
+// Once the NFT create notify is enabled, the URIs can be followed by the notify marker and a call to a registry contract:
+// registry address, function, gas limit forwarded to the call and the extra arguments of the call. The call receives
+// the token identifier, the created nonce and the quantity before the extra arguments
 func (e *dctNFTCreate) ProcessBuiltinFunction(
 	acntSnd, _ vmcommon.UserAccountHandler,
 	vmInput *vmcommon.ContractCallInput,
@@ -111,23 +201,42 @@ func (e *dctNFTCreate) ProcessBuiltinFunction(
 	if err != nil {
 		return nil, err
 	}
+	err = e.checkAccountIsNotFrozen(vmInput.CallerAddr, vmInput.ReturnCallAfterError)
+	if err != nil {
+		return nil, err
+	}
 
-	minNumOfArgs := 7
+	urisStartIndex := 6
+	if e.onBehalf {
+		urisStartIndex++
+	}
+	maxSupplyIndex := -1
+	if e.enableEpochsHandler.IsNFTMaxSupplyFlagEnabled() {
+		maxSupplyIndex = urisStartIndex
+		urisStartIndex++
+	}
+	minNumOfArgs := urisStartIndex + 1
 	if vmInput.CallType == vm.ExecOnDestByCaller {
-		minNumOfArgs = 8
+		minNumOfArgs++
+	}
+	err = checkFunctionArguments(vmInput.Arguments, validation.RequireMinArgs(minNumOfArgs))
+	if err != nil {
+		return nil, err
 	}
 	lenArgs := len(vmInput.Arguments)
-	if lenArgs < minNumOfArgs {
-		return nil, fmt.Errorf("%w, wrong number of arguments", ErrInvalidArguments)
+
+	dctTokenKey := append(e.keyPrefix, vmInput.Arguments[0]...)
+	if e.enableEpochsHandler.IsStopNFTCreateFlagEnabled() && e.globalSettingsHandler.IsNFTCreateStopped(dctTokenKey) {
+		return nil, ErrNFTCreateStopped
 	}
 
 	accountWithRoles := acntSnd
-	uris := vmInput.Arguments[6:]
+	uris := vmInput.Arguments[urisStartIndex:]
 	if vmInput.CallType == vm.ExecOnDestByCaller {
 		scAddressWithRoles := vmInput.Arguments[lenArgs-1]
-		uris = vmInput.Arguments[6 : lenArgs-1]
+		uris = vmInput.Arguments[urisStartIndex : lenArgs-1]
 
-		if len(scAddressWithRoles) != len(vmInput.CallerAddr) {
+		if len(scAddressWithRoles) != e.getAddressLength(vmInput) {
 			return nil, ErrInvalidAddressLength
 		}
 		if bytes.Equal(scAddressWithRoles, vmInput.CallerAddr) {
@@ -139,14 +248,29 @@ func (e *dctNFTCreate) ProcessBuiltinFunction(
 			return nil, err
 		}
 	}
+	notifyCall, uris, err := e.extractNotifyCall(vmInput, uris)
+	if err != nil {
+		return nil, err
+	}
+	if check.IfNil(accountWithRoles) {
+		return nil, ErrNilUserAccount
+	}
+	// the token balance and the latest nonce must be saved together, so they are buffered until the create succeeds
+	trackableAccountWithRoles := newTrackableAccount(accountWithRoles)
+	// so are the liquidity, the max supply and the royalties denominator saved on the system account
+	dctStorageHandler, systemAccountBuffer := e.bufferSystemAccountWrites()
 
 	tokenID := vmInput.Arguments[0]
-	err = e.rolesHandler.CheckAllowedToExecute(accountWithRoles, vmInput.Arguments[0], []byte(core.DCTRoleNFTCreate))
+	err = e.rolesHandler.CheckAllowedToExecute(trackableAccountWithRoles, vmInput.Arguments[0], []byte(core.DCTRoleNFTCreate))
+	if err != nil {
+		return nil, err
+	}
+	creator, err := e.getCreator(vmInput, trackableAccountWithRoles)
 	if err != nil {
 		return nil, err
 	}
 
-	nonce, err := getLatestNonce(accountWithRoles, tokenID)
+	nonce, err := e.getLatestNonce(trackableAccountWithRoles, tokenID)
 	if err != nil {
 		return nil, err
 	}
@@ -159,36 +283,70 @@ func (e *dctNFTCreate) ProcessBuiltinFunction(
 	if vmInput.GasProvided < gasToUse {
 		return nil, ErrNotEnoughGas
 	}
+	if notifyCall != nil && vmInput.GasProvided-gasToUse < notifyCall.gasLimit {
+		return nil, ErrNotEnoughGas
+	}
 
-	royalties := uint32(big.NewInt(0).SetBytes(vmInput.Arguments[3]).Uint64())
-	if royalties > core.MaxRoyalty {
+	royalties, err := flaggedUint32Argument(e.enableEpochsHandler, vmInput.Arguments, 3)
+	if err != nil {
+		return nil, err
+	}
+	royaltiesDenominator := getRoyaltiesDenominator(e.globalSettingsHandler, tokenID)
+	if royalties > royaltiesDenominator {
 		return nil, fmt.Errorf("%w, invalid max royality value", ErrInvalidArguments)
 	}
 
-	dctTokenKey := append(e.keyPrefix, vmInput.Arguments[0]...)
 	quantity := big.NewInt(0).SetBytes(vmInput.Arguments[1])
 	if quantity.Cmp(zero) <= 0 {
 		return nil, fmt.Errorf("%w, invalid quantity", ErrInvalidArguments)
 	}
-	if quantity.Cmp(big.NewInt(1)) > 0 {
-		err = e.rolesHandler.CheckAllowedToExecute(accountWithRoles, vmInput.Arguments[0], []byte(core.DCTRoleNFTAddQuantity))
+	if quantity.Cmp(oneValue) > 0 {
+		err = e.rolesHandler.CheckAllowedToExecute(trackableAccountWithRoles, vmInput.Arguments[0], []byte(core.DCTRoleNFTAddQuantity))
+		if err != nil {
+			return nil, err
+		}
+		err = checkCollectionAddQuantity(e.globalSettingsHandler, tokenID)
 		if err != nil {
 			return nil, err
 		}
 	}
+	err = checkCollectionURIs(e.globalSettingsHandler, tokenID, len(uris))
+	if err != nil {
+		return nil, err
+	}
+	err = checkCollectionAttributes(e.globalSettingsHandler, tokenID, vmInput.Arguments[5])
+	if err != nil {
+		return nil, err
+	}
+	err = checkCollectionContentHash(e.globalSettingsHandler, e.hasher, tokenID, vmInput.Arguments[4], vmInput.Arguments[5])
+	if err != nil {
+		return nil, err
+	}
+	tokenType := getNFTTokenType(e.globalSettingsHandler, e.enableEpochsHandler, tokenID)
+	maxValueLength := getMaxValueLength(tokenType)
 	isValueLengthCheckFlagEnabled := e.enableEpochsHandler.IsValueLengthCheckFlagEnabled()
-	if isValueLengthCheckFlagEnabled && len(vmInput.Arguments[1]) > maxLenForAddNFTQuantity {
-		return nil, fmt.Errorf("%w max length for quantity in nft create is %d", ErrInvalidArguments, maxLenForAddNFTQuantity)
+	if isValueLengthCheckFlagEnabled || tokenType == vmcommon.MetaFungible {
+		err = checkFunctionArguments(vmInput.Arguments, validation.RequireBigIntMaxBytes(1, maxValueLength))
+		if err != nil {
+			return nil, err
+		}
+	}
+	maxSupply, err := getMaxSupply(vmInput.Arguments, maxSupplyIndex, maxValueLength, quantity)
+	if err != nil {
+		return nil, err
 	}
 
-	nextNonce := nonce + 1
+	nextNonce, err := e.getNextNonce(tokenID, nonce)
+	if err != nil {
+		return nil, err
+	}
 	dctData := &dct.DCToken{
-		Type:  uint32(core.NonFungible),
+		Type:  tokenType,
 		Value: quantity,
 		TokenMetaData: &dct.MetaData{
 			Nonce:      nextNonce,
 			Name:       vmInput.Arguments[2],
-			Creator:    vmInput.CallerAddr,
+			Creator:    creator,
 			Royalties:  royalties,
 			Hash:       vmInput.Arguments[4],
 			Attributes: vmInput.Arguments[5],
@@ -196,19 +354,42 @@ func (e *dctNFTCreate) ProcessBuiltinFunction(
 		},
 	}
 
-	_, err = e.dctStorageHandler.SaveDCTNFTToken(accountWithRoles.AddressBytes(), accountWithRoles, dctTokenKey, nextNonce, dctData, true, vmInput.ReturnCallAfterError)
+	err = e.storageUsageTracker.TrackStorageUsage(trackableAccountWithRoles, getMetaDataSize(dctData.TokenMetaData))
+	if err != nil {
+		return nil, err
+	}
+	_, err = dctStorageHandler.SaveDCTNFTToken(accountWithRoles.AddressBytes(), trackableAccountWithRoles, dctTokenKey, nextNonce, dctData, true, vmInput.ReturnCallAfterError)
+	if err != nil {
+		return nil, err
+	}
+	err = dctStorageHandler.AddToLiquiditySystemAcc(dctTokenKey, nextNonce, quantity)
+	if err != nil {
+		return nil, err
+	}
+	err = dctStorageHandler.SaveNFTMaxSupply(dctTokenKey, nextNonce, maxSupply, quantity)
+	if err != nil {
+		return nil, err
+	}
+	err = dctStorageHandler.SaveNFTRoyaltiesDenominator(dctTokenKey, nextNonce, royaltiesDenominator)
 	if err != nil {
 		return nil, err
 	}
-	err = e.dctStorageHandler.AddToLiquiditySystemAcc(dctTokenKey, nextNonce, quantity)
+
+	err = saveLatestNonce(trackableAccountWithRoles, tokenID, nextNonce)
 	if err != nil {
 		return nil, err
 	}
 
-	err = saveLatestNonce(accountWithRoles, tokenID, nextNonce)
+	err = trackableAccountWithRoles.Commit()
 	if err != nil {
 		return nil, err
 	}
+	if systemAccountBuffer != nil {
+		err = systemAccountBuffer.commitSystemAccount()
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	if vmInput.CallType == vm.ExecOnDestByCaller {
 		err = e.accounts.SaveAccount(accountWithRoles)
@@ -216,24 +397,172 @@ func (e *dctNFTCreate) ProcessBuiltinFunction(
 			return nil, err
 		}
 	}
+	e.nonceCache.Put(accountWithRoles.AddressBytes(), tokenID, nextNonce)
 
-	vmOutput := &vmcommon.VMOutput{
-		ReturnCode:   vmcommon.Ok,
-		GasRemaining: vmInput.GasProvided - gasToUse,
-		ReturnData:   [][]byte{big.NewInt(0).SetUint64(nextNonce).Bytes()},
-	}
+	vmOutput := vmcommon.NewVMOutputFromPool()
+	vmOutput.ReturnCode = vmcommon.Ok
+	vmOutput.GasRemaining = vmInput.GasProvided - gasToUse
+	addGasBreakdownToVMOutput(vmInput, vmOutput, vmcommon.GasBreakdown{
+		BaseCost:    e.funcGasCost,
+		StorageCost: gasToUse - e.funcGasCost,
+	})
+	vmOutput.ReturnData = append(vmOutput.ReturnData, uint64ToBytes(nextNonce))
 
 	dctDataBytes, err := e.marshaller.Marshal(dctData)
 	if err != nil {
-		log.Warn("dctNFTCreate.ProcessBuiltinFunction: cannot marshall dct data for log", "error", err)
+		log.Warn("dctNFTCreate.ProcessBuiltinFunction: cannot marshall dct data for log", withCorrelationID(vmInput, "error", err)...)
 	}
 
-	addDCTEntryInVMOutput(vmOutput, []byte(core.BuiltInFunctionDCTNFTCreate), vmInput.Arguments[0], nextNonce, quantity, vmInput.CallerAddr, dctDataBytes)
+	logArguments := [][]byte{vmInput.CallerAddr, dctDataBytes}
+	if royaltiesDenominator != vmcommon.DefaultRoyaltiesDenominator {
+		// the royalties of the logged token are relative to the denominator of the collection
+		logArguments = append(logArguments, uint64ToBytes(uint64(royaltiesDenominator)))
+	}
+	addDCTEntryInVMOutput(vmOutput, []byte(core.BuiltInFunctionDCTNFTCreate), vmInput.Arguments[0], nextNonce, quantity, logArguments...)
+	if notifyCall != nil {
+		addNotifyCallToVMOutput(notifyCall, accountWithRoles.AddressBytes(), tokenID, nextNonce, quantity, vmOutput)
+	}
 
 	return vmOutput, nil
 }
 
+// getMetaDataSize returns the number of bytes of the metadata fields provided by the creator of the token
+func getMetaDataSize(metaData *dct.MetaData) int64 {
+	size := len(metaData.Name) + len(metaData.Hash) + len(metaData.Attributes)
+	for _, uri := range metaData.URIs {
+		size += len(uri)
+	}
+
+	return int64(size)
+}
+
+// extractNotifyCall splits the URIs from the call notifying the registry contract, if the notify marker is present
+func (e *dctNFTCreate) extractNotifyCall(vmInput *vmcommon.ContractCallInput, uris [][]byte) (*nftCreateNotifyCall, [][]byte, error) {
+	if !e.enableEpochsHandler.IsNFTCreateNotifyFlagEnabled() {
+		return nil, uris, nil
+	}
+
+	markerIndex := -1
+	for i, uri := range uris {
+		if bytes.Equal(uri, notifyMarker) {
+			markerIndex = i
+			break
+		}
+	}
+	if markerIndex < 0 {
+		return nil, uris, nil
+	}
+	if markerIndex == 0 {
+		return nil, nil, fmt.Errorf("%w, no URIs before the notify marker", ErrInvalidArguments)
+	}
+
+	callArguments := uris[markerIndex+1:]
+	if len(callArguments) < minNumArgumentsNFTCreateNotify {
+		return nil, nil, fmt.Errorf("%w, invalid number of notify arguments", ErrInvalidArguments)
+	}
+	registry := callArguments[0]
+	if len(registry) != e.getAddressLength(vmInput) || !vmcommon.IsSmartContractAddress(registry) {
+		return nil, nil, ErrInvalidRcvAddr
+	}
+	if len(callArguments[1]) == 0 {
+		return nil, nil, fmt.Errorf("%w, empty notify function", ErrInvalidArguments)
+	}
+	gasLimit, err := uint64Argument(callArguments, 2)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	notifyCall := &nftCreateNotifyCall{
+		registry:  registry,
+		function:  string(callArguments[1]),
+		gasLimit:  gasLimit,
+		arguments: callArguments[minNumArgumentsNFTCreateNotify:],
+	}
+
+	return notifyCall, uris[:markerIndex], nil
+}
+
+// addNotifyCallToVMOutput adds the call notifying the registry contract, paid from the remaining gas
+func addNotifyCallToVMOutput(
+	notifyCall *nftCreateNotifyCall,
+	sender []byte,
+	tokenID []byte,
+	nonce uint64,
+	quantity *big.Int,
+	vmOutput *vmcommon.VMOutput,
+) {
+	data := notifyCall.function + "@" + hex.EncodeToString(tokenID) + "@" + hex.EncodeToString(big.NewInt(0).SetUint64(nonce).Bytes()) +
+		"@" + hex.EncodeToString(quantity.Bytes())
+	for _, arg := range notifyCall.arguments {
+		data += "@" + hex.EncodeToString(arg)
+	}
+
+	outTransfer := vmcommon.OutputTransfer{
+		Value:         big.NewInt(0),
+		GasLimit:      notifyCall.gasLimit,
+		Data:          []byte(data),
+		CallType:      vm.DirectCall,
+		SenderAddress: sender,
+	}
+	vmOutput.OutputAccounts = map[string]*vmcommon.OutputAccount{
+		string(notifyCall.registry): {
+			Address:         notifyCall.registry,
+			OutputTransfers: []vmcommon.OutputTransfer{outTransfer},
+		},
+	}
+	vmOutput.GasRemaining -= notifyCall.gasLimit
+}
+
+// getMaxSupply returns the max supply of the created nonce, or zero if the nonce is uncapped or the max supply is
+// not enabled
+func getMaxSupply(arguments [][]byte, maxSupplyIndex int, maxValueLength int, quantity *big.Int) (*big.Int, error) {
+	if maxSupplyIndex < 0 {
+		return zero, nil
+	}
+	err := checkFunctionArguments(arguments, validation.RequireBigIntMaxBytes(maxSupplyIndex, maxValueLength))
+	if err != nil {
+		return nil, err
+	}
+
+	maxSupply := big.NewInt(0).SetBytes(arguments[maxSupplyIndex])
+	if maxSupply.Sign() > 0 && quantity.Cmp(maxSupply) > 0 {
+		return nil, fmt.Errorf("%w, quantity above max supply", ErrInvalidArguments)
+	}
+
+	return maxSupply, nil
+}
+
+// getCreator returns the creator to be recorded for the new NFT. The create on behalf function records the address
+// given as argument, provided the account with roles is allowed to, while a create executed on destination by
+// caller records the account with roles, once enabled. Otherwise the caller is the creator
+func (e *dctNFTCreate) getCreator(vmInput *vmcommon.ContractCallInput, accountWithRoles vmcommon.UserAccountHandler) ([]byte, error) {
+	if e.onBehalf {
+		err := checkFunctionArguments(vmInput.Arguments, validation.RequireAddress(6, e.getAddressLength(vmInput)))
+		if err != nil {
+			return nil, err
+		}
+		err = e.rolesHandler.CheckAllowedToExecute(accountWithRoles, vmInput.Arguments[0], []byte(vmcommon.DCTRoleNFTCreateOnBehalf))
+		if err != nil {
+			return nil, err
+		}
+
+		return vmInput.Arguments[6], nil
+	}
+
+	isCreatedOnBehalfOfRolesHolder := vmInput.CallType == vm.ExecOnDestByCaller && e.enableEpochsHandler.IsNFTCreateOnBehalfFlagEnabled()
+	if isCreatedOnBehalfOfRolesHolder {
+		return accountWithRoles.AddressBytes(), nil
+	}
+
+	return vmInput.CallerAddr, nil
+}
+
 func (e *dctNFTCreate) getAccount(address []byte) (vmcommon.UserAccountHandler, error) {
+	cachedAccount, found := e.accountCache.Get(address)
+	if found {
+		return cachedAccount, nil
+	}
+
 	account, err := e.accounts.LoadAccount(address)
 	if err != nil {
 		return nil, err
@@ -243,12 +572,49 @@ func (e *dctNFTCreate) getAccount(address []byte) (vmcommon.UserAccountHandler,
 	if !ok {
 		return nil, ErrWrongTypeAssertion
 	}
+	e.accountCache.Put(userAcc)
 
 	return userAcc, nil
 }
 
+// bufferSystemAccountWrites returns the storage handler to create the token through, buffering the writes to the system
+// account when the storage handler supports it, together with the buffer to commit, if any
+func (e *dctNFTCreate) bufferSystemAccountWrites() (vmcommon.DCTNFTStorageHandler, *systemAccountBuffer) {
+	dataStorage, ok := e.dctStorageHandler.(*dctDataStorage)
+	if !ok {
+		return e.dctStorageHandler, nil
+	}
+
+	return dataStorage.withBufferedSystemAccount()
+}
+
+func (e *dctNFTCreate) getLatestNonce(acnt vmcommon.UserAccountHandler, tokenID []byte) (uint64, error) {
+	nonce, found := e.nonceCache.Get(acnt.AddressBytes(), tokenID)
+	if found {
+		return nonce, nil
+	}
+
+	return getLatestNonce(acnt, tokenID)
+}
+
+// getNextNonce returns the nonce of the token to be created, skipping the nonces reserved by the collection once the
+// nonce ranges are enabled
+func (e *dctNFTCreate) getNextNonce(tokenID []byte, latestNonce uint64) (uint64, error) {
+	if !e.enableEpochsHandler.IsNFTNonceRangesFlagEnabled() {
+		return latestNonce + 1, nil
+	}
+
+	config := e.globalSettingsHandler.GetCollectionConfig(tokenID)
+	nextNonce, ok := config.NextAvailableNonce(latestNonce)
+	if !ok {
+		return 0, ErrNonceOverflow
+	}
+
+	return nextNonce, nil
+}
+
 func getLatestNonce(acnt vmcommon.UserAccountHandler, tokenID []byte) (uint64, error) {
-	nonceKey := getNonceKey(tokenID)
+	nonceKey := dctkeys.ComputeNonceKey(tokenID)
 	nonceData, _, err := acnt.AccountDataHandler().RetrieveValue(nonceKey)
 	if err != nil {
 		return 0, err
@@ -258,16 +624,12 @@ func getLatestNonce(acnt vmcommon.UserAccountHandler, tokenID []byte) (uint64, e
 		return 0, nil
 	}
 
-	return big.NewInt(0).SetBytes(nonceData).Uint64(), nil
+	return bytesToNonce(nonceData)
 }
 
 func saveLatestNonce(acnt vmcommon.UserAccountHandler, tokenID []byte, nonce uint64) error {
-	nonceKey := getNonceKey(tokenID)
-	return acnt.AccountDataHandler().SaveKeyValue(nonceKey, big.NewInt(0).SetUint64(nonce).Bytes())
-}
-
-func computeDCTNFTTokenKey(dctTokenKey []byte, nonce uint64) []byte {
-	return append(dctTokenKey, big.NewInt(0).SetUint64(nonce).Bytes()...)
+	nonceKey := dctkeys.ComputeNonceKey(tokenID)
+	return acnt.AccountDataHandler().SaveKeyValue(nonceKey, uint64ToBytes(nonce))
 }
 
 func checkDCTNFTCreateBurnAddInput(
@@ -291,10 +653,6 @@ func checkDCTNFTCreateBurnAddInput(
 	return nil
 }
 
-func getNonceKey(tokenID []byte) []byte {
-	return append(noncePrefix, tokenID...)
-}
-
 // IsInterfaceNil returns true if underlying object in nil
 func (e *dctNFTCreate) IsInterfaceNil() bool {
 	return e == nil
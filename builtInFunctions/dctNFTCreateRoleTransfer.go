@@ -13,33 +13,33 @@ import (
 
 type dctNFTCreateRoleTransfer struct {
 	baseAlwaysActiveHandler
+	baseAddressLengthHandler
+	baseSystemAddressesHandler
 	keyPrefix        []byte
 	marshaller       vmcommon.Marshalizer
 	accounts         vmcommon.AccountsAdapter
 	shardCoordinator vmcommon.Coordinator
+	nonceCache       vmcommon.LatestNonceCache
+}
+
+// ArgsNewDCTNFTCreateRoleTransfer defines the argument list for new dct NFT create role transfer built in function
+type ArgsNewDCTNFTCreateRoleTransfer struct {
+	Config
 }
 
 // NewDCTNFTCreateRoleTransfer returns the dct NFT create role transfer built-in function component
-func NewDCTNFTCreateRoleTransfer(
-	marshaller vmcommon.Marshalizer,
-	accounts vmcommon.AccountsAdapter,
-	shardCoordinator vmcommon.Coordinator,
-) (*dctNFTCreateRoleTransfer, error) {
-	if check.IfNil(marshaller) {
-		return nil, ErrNilMarshalizer
-	}
-	if check.IfNil(accounts) {
-		return nil, ErrNilAccountsAdapter
-	}
-	if check.IfNil(shardCoordinator) {
-		return nil, ErrNilShardCoordinator
+func NewDCTNFTCreateRoleTransfer(args ArgsNewDCTNFTCreateRoleTransfer) (*dctNFTCreateRoleTransfer, error) {
+	err := args.checkComponents(core.BuiltInFunctionDCTNFTCreateRoleTransfer, requireMarshalizer|requireAccounts|requireShardCoordinator)
+	if err != nil {
+		return nil, err
 	}
 
 	e := &dctNFTCreateRoleTransfer{
 		keyPrefix:        []byte(baseDCTKeyPrefix),
-		marshaller:       marshaller,
-		accounts:         accounts,
-		shardCoordinator: shardCoordinator,
+		marshaller:       args.Marshalizer,
+		accounts:         args.Accounts,
+		shardCoordinator: args.ShardCoordinator,
+		nonceCache:       &disabledLatestNonceCache{},
 	}
 
 	return e, nil
@@ -49,6 +49,16 @@ func NewDCTNFTCreateRoleTransfer(
 func (e *dctNFTCreateRoleTransfer) SetNewGasConfig(_ *vmcommon.GasCost) {
 }
 
+// SetLatestNonceCache sets the latest nonce cache which is updated whenever the latest nonce is changed
+func (e *dctNFTCreateRoleTransfer) SetLatestNonceCache(nonceCache vmcommon.LatestNonceCache) error {
+	if check.IfNil(nonceCache) {
+		return ErrNilLatestNonceCache
+	}
+
+	e.nonceCache = nonceCache
+	return nil
+}
+
 // ProcessBuiltinFunction resolves DCT create role transfer function call
 func (e *dctNFTCreateRoleTransfer) ProcessBuiltinFunction(
 	acntSnd, acntDst vmcommon.UserAccountHandler,
@@ -66,21 +76,28 @@ func (e *dctNFTCreateRoleTransfer) ProcessBuiltinFunction(
 		return nil, ErrNilUserAccount
 	}
 
+	// the latest nonce and the roles must be changed together, so they are buffered until the transfer succeeds
+	trackableAcntDst := newTrackableAccount(acntDst)
 	vmOutput := &vmcommon.VMOutput{ReturnCode: vmcommon.Ok}
-	if bytes.Equal(vmInput.CallerAddr, core.DCTSCAddress) {
-		outAcc, errExec := e.executeTransferNFTCreateChangeAtCurrentOwner(vmOutput, acntDst, vmInput)
+	if e.getSystemAddresses().IsDCTSCAddress(vmInput.CallerAddr) {
+		outAcc, errExec := e.executeTransferNFTCreateChangeAtCurrentOwner(vmOutput, trackableAcntDst, vmInput)
 		if errExec != nil {
 			return nil, errExec
 		}
 		vmOutput.OutputAccounts = make(map[string]*vmcommon.OutputAccount)
 		vmOutput.OutputAccounts[string(outAcc.Address)] = outAcc
 	} else {
-		err = e.executeTransferNFTCreateChangeAtNextOwner(vmOutput, acntDst, vmInput)
+		err = e.executeTransferNFTCreateChangeAtNextOwner(vmOutput, trackableAcntDst, vmInput)
 		if err != nil {
 			return nil, err
 		}
 	}
 
+	err = trackableAcntDst.Commit()
+	if err != nil {
+		return nil, err
+	}
+
 	return vmOutput, nil
 }
 
@@ -92,7 +109,7 @@ func (e *dctNFTCreateRoleTransfer) executeTransferNFTCreateChangeAtCurrentOwner(
 	if len(vmInput.Arguments) != 2 {
 		return nil, ErrInvalidArguments
 	}
-	if len(vmInput.Arguments[1]) != len(vmInput.CallerAddr) {
+	if len(vmInput.Arguments[1]) != e.getAddressLength(vmInput) {
 		return nil, ErrInvalidArguments
 	}
 
@@ -106,6 +123,7 @@ func (e *dctNFTCreateRoleTransfer) executeTransferNFTCreateChangeAtCurrentOwner(
 	if err != nil {
 		return nil, err
 	}
+	e.nonceCache.Remove(acntDst.AddressBytes(), tokenID)
 
 	dctTokenRoleKey := append(roleKeyPrefix, tokenID...)
 	err = e.deleteCreateRoleFromAccount(acntDst, dctTokenRoleKey)
@@ -115,6 +133,7 @@ func (e *dctNFTCreateRoleTransfer) executeTransferNFTCreateChangeAtCurrentOwner(
 
 	logData := [][]byte{acntDst.AddressBytes(), boolToSlice(false)}
 	addDCTEntryInVMOutput(vmOutput, []byte(vmInput.Function), tokenID, 0, big.NewInt(0), logData...)
+	addRolesChangedEntryInVMOutput(vmOutput, vmInput.Function, acntDst.AddressBytes(), tokenID, false, [][]byte{[]byte(core.DCTRoleNFTCreate)})
 
 	destAddress := vmInput.Arguments[1]
 	if e.shardCoordinator.ComputeId(destAddress) == e.shardCoordinator.SelfId() {
@@ -126,13 +145,20 @@ func (e *dctNFTCreateRoleTransfer) executeTransferNFTCreateChangeAtCurrentOwner(
 		if !ok {
 			return nil, ErrWrongTypeAssertion
 		}
+		trackableNewDestUserAcc := newTrackableAccount(newDestUserAcc)
 
-		err = saveLatestNonce(newDestUserAcc, tokenID, nonce)
+		err = saveLatestNonce(trackableNewDestUserAcc, tokenID, nonce)
 		if err != nil {
 			return nil, err
 		}
+		e.nonceCache.Remove(newDestUserAcc.AddressBytes(), tokenID)
 
-		err = e.addCreateRoleToAccount(newDestUserAcc, dctTokenRoleKey)
+		err = e.addCreateRoleToAccount(trackableNewDestUserAcc, dctTokenRoleKey)
+		if err != nil {
+			return nil, err
+		}
+
+		err = trackableNewDestUserAcc.Commit()
 		if err != nil {
 			return nil, err
 		}
@@ -144,6 +170,7 @@ func (e *dctNFTCreateRoleTransfer) executeTransferNFTCreateChangeAtCurrentOwner(
 
 		logData = [][]byte{destAddress, boolToSlice(true)}
 		addDCTEntryInVMOutput(vmOutput, []byte(vmInput.Function), tokenID, 0, big.NewInt(0), logData...)
+		addRolesChangedEntryInVMOutput(vmOutput, vmInput.Function, destAddress, tokenID, true, [][]byte{[]byte(core.DCTRoleNFTCreate)})
 	}
 
 	outAcc := &vmcommon.OutputAccount{
@@ -155,7 +182,7 @@ func (e *dctNFTCreateRoleTransfer) executeTransferNFTCreateChangeAtCurrentOwner(
 	outTransfer := vmcommon.OutputTransfer{
 		Value: big.NewInt(0),
 		Data: []byte(core.BuiltInFunctionDCTNFTCreateRoleTransfer + "@" +
-			hex.EncodeToString(tokenID) + "@" + hex.EncodeToString(big.NewInt(0).SetUint64(nonce).Bytes())),
+			hex.EncodeToString(tokenID) + "@" + hex.EncodeToString(uint64ToBytes(nonce))),
 		SenderAddress: vmInput.CallerAddr,
 	}
 	outAcc.OutputTransfers = append(outAcc.OutputTransfers, outTransfer)
@@ -223,12 +250,16 @@ func (e *dctNFTCreateRoleTransfer) executeTransferNFTCreateChangeAtNextOwner(
 	}
 
 	tokenID := vmInput.Arguments[0]
-	nonce := big.NewInt(0).SetBytes(vmInput.Arguments[1]).Uint64()
+	nonce, err := bytesToNonce(vmInput.Arguments[1])
+	if err != nil {
+		return err
+	}
 
-	err := saveLatestNonce(acntDst, tokenID, nonce)
+	err = saveLatestNonce(acntDst, tokenID, nonce)
 	if err != nil {
 		return err
 	}
+	e.nonceCache.Remove(acntDst.AddressBytes(), tokenID)
 
 	dctTokenRoleKey := append(roleKeyPrefix, tokenID...)
 	err = e.addCreateRoleToAccount(acntDst, dctTokenRoleKey)
@@ -238,6 +269,7 @@ func (e *dctNFTCreateRoleTransfer) executeTransferNFTCreateChangeAtNextOwner(
 
 	logData := [][]byte{acntDst.AddressBytes(), boolToSlice(true)}
 	addDCTEntryInVMOutput(vmOutput, []byte(vmInput.Function), tokenID, 0, big.NewInt(0), logData...)
+	addRolesChangedEntryInVMOutput(vmOutput, vmInput.Function, acntDst.AddressBytes(), tokenID, true, [][]byte{[]byte(core.DCTRoleNFTCreate)})
 
 	return nil
 }
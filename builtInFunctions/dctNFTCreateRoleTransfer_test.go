@@ -10,24 +10,46 @@ import (
 	vmcommon "github.com/Reshusk23/sr-vm-common-go"
 	"github.com/Reshusk23/sr-vm-common-go/mock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDctNFTCreateRoleTransfer_Constructor(t *testing.T) {
 	t.Parallel()
 
-	e, err := NewDCTNFTCreateRoleTransfer(nil, &mock.AccountsStub{}, mock.NewMultiShardsCoordinatorMock(2))
+	e, err := NewDCTNFTCreateRoleTransfer(ArgsNewDCTNFTCreateRoleTransfer{
+		Config: Config{
+			Accounts:         &mock.AccountsStub{},
+			ShardCoordinator: mock.NewMultiShardsCoordinatorMock(2),
+		},
+	})
 	assert.Nil(t, e)
-	assert.Equal(t, err, ErrNilMarshalizer)
+	assert.ErrorIs(t, err, ErrNilMarshalizer)
 
-	e, err = NewDCTNFTCreateRoleTransfer(&mock.MarshalizerMock{}, nil, mock.NewMultiShardsCoordinatorMock(2))
+	e, err = NewDCTNFTCreateRoleTransfer(ArgsNewDCTNFTCreateRoleTransfer{
+		Config: Config{
+			Marshalizer:      &mock.MarshalizerMock{},
+			ShardCoordinator: mock.NewMultiShardsCoordinatorMock(2),
+		},
+	})
 	assert.Nil(t, e)
-	assert.Equal(t, err, ErrNilAccountsAdapter)
+	assert.ErrorIs(t, err, ErrNilAccountsAdapter)
 
-	e, err = NewDCTNFTCreateRoleTransfer(&mock.MarshalizerMock{}, &mock.AccountsStub{}, nil)
+	e, err = NewDCTNFTCreateRoleTransfer(ArgsNewDCTNFTCreateRoleTransfer{
+		Config: Config{
+			Marshalizer: &mock.MarshalizerMock{},
+			Accounts:    &mock.AccountsStub{},
+		},
+	})
 	assert.Nil(t, e)
-	assert.Equal(t, err, ErrNilShardCoordinator)
+	assert.ErrorIs(t, err, ErrNilShardCoordinator)
 
-	e, err = NewDCTNFTCreateRoleTransfer(&mock.MarshalizerMock{}, &mock.AccountsStub{}, mock.NewMultiShardsCoordinatorMock(2))
+	e, err = NewDCTNFTCreateRoleTransfer(ArgsNewDCTNFTCreateRoleTransfer{
+		Config: Config{
+			Marshalizer:      &mock.MarshalizerMock{},
+			Accounts:         &mock.AccountsStub{},
+			ShardCoordinator: mock.NewMultiShardsCoordinatorMock(2),
+		},
+	})
 	assert.Nil(t, err)
 	assert.NotNil(t, e)
 	assert.False(t, e.IsInterfaceNil())
@@ -38,7 +60,13 @@ func TestDctNFTCreateRoleTransfer_Constructor(t *testing.T) {
 func TestDCTNFTCreateRoleTransfer_ProcessWithErrors(t *testing.T) {
 	t.Parallel()
 
-	e, err := NewDCTNFTCreateRoleTransfer(&mock.MarshalizerMock{}, &mock.AccountsStub{}, mock.NewMultiShardsCoordinatorMock(2))
+	e, err := NewDCTNFTCreateRoleTransfer(ArgsNewDCTNFTCreateRoleTransfer{
+		Config: Config{
+			Marshalizer:      &mock.MarshalizerMock{},
+			Accounts:         &mock.AccountsStub{},
+			ShardCoordinator: mock.NewMultiShardsCoordinatorMock(2),
+		},
+	})
 	assert.Nil(t, err)
 	assert.NotNil(t, e)
 
@@ -101,7 +129,13 @@ func createDCTNFTCreateRoleTransferComponent(t *testing.T) *dctNFTCreateRoleTran
 		},
 	}
 
-	e, err := NewDCTNFTCreateRoleTransfer(marshaller, accounts, shardCoordinator)
+	e, err := NewDCTNFTCreateRoleTransfer(ArgsNewDCTNFTCreateRoleTransfer{
+		Config: Config{
+			Marshalizer:      marshaller,
+			Accounts:         accounts,
+			ShardCoordinator: shardCoordinator,
+		},
+	})
 	assert.Nil(t, err)
 	assert.NotNil(t, e)
 	return e
@@ -135,6 +169,14 @@ func TestDCTNFTCreateRoleTransfer_ProcessAtCurrentShard(t *testing.T) {
 	vmOutput, err := e.ProcessBuiltinFunction(nil, userAcc, vmInput)
 	assert.Nil(t, err)
 	assert.Equal(t, len(vmOutput.OutputAccounts), 1)
+	require.Len(t, vmOutput.Logs, 4)
+	createRole := []byte(core.DCTRoleNFTCreate)
+	assert.Equal(t, []byte(vmcommon.DCTRolesChangedIdentifier), vmOutput.Logs[1].Identifier)
+	assert.Equal(t, currentOwner, vmOutput.Logs[1].Address)
+	assert.Equal(t, [][]byte{tokenID, []byte(vmcommon.DCTRolesChangedUnset), createRole}, vmOutput.Logs[1].Topics)
+	assert.Equal(t, []byte(vmcommon.DCTRolesChangedIdentifier), vmOutput.Logs[3].Identifier)
+	assert.Equal(t, destinationAddr, vmOutput.Logs[3].Address)
+	assert.Equal(t, [][]byte{tokenID, []byte(vmcommon.DCTRolesChangedSet), createRole}, vmOutput.Logs[3].Topics)
 
 	_ = e.accounts.SaveAccount(userAcc)
 	_, _ = e.accounts.Commit()
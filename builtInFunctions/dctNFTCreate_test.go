@@ -3,6 +3,7 @@ package builtInFunctions
 import (
 	"bytes"
 	"errors"
+	"math"
 	"math/big"
 	"testing"
 
@@ -10,25 +11,28 @@ import (
 	"github.com/Reshusk23/sr-me-core/core/check"
 	"github.com/Reshusk23/sr-me-core/data/dct"
 	"github.com/Reshusk23/sr-me-core/data/vm"
+	"github.com/Reshusk23/sr-me-core/hashing/keccak"
 	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/dctkeys"
 	"github.com/Reshusk23/sr-vm-common-go/mock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 func createNftCreateWithStubArguments() *dctNFTCreate {
-	nftCreate, _ := NewDCTNFTCreateFunc(
-		1,
-		vmcommon.BaseOperationCost{},
-		&mock.MarshalizerMock{},
-		&mock.GlobalSettingsHandlerStub{},
-		&mock.DCTRoleHandlerStub{},
-		createNewDCTDataStorageHandler(),
-		&mock.AccountsStub{},
-		&mock.EnableEpochsHandlerStub{
-			IsValueLengthCheckFlagEnabledField: true,
+	nftCreate, _ := NewDCTNFTCreateFunc(ArgsNewDCTNFTCreate{
+		Config: Config{
+			Marshalizer:           &mock.MarshalizerMock{},
+			Accounts:              &mock.AccountsStub{},
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			DCTStorageHandler:     createNewDCTDataStorageHandler(),
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+				IsValueLengthCheckFlagEnabledField: true,
+			},
 		},
-	)
+		FuncGasCost: 1,
+	})
 
 	return nftCreate
 }
@@ -39,96 +43,91 @@ func TestNewDCTNFTCreateFunc_NilArgumentsShouldErr(t *testing.T) {
 	t.Run("nil marshaller should error", func(t *testing.T) {
 		t.Parallel()
 
-		nftCreate, err := NewDCTNFTCreateFunc(
-			0,
-			vmcommon.BaseOperationCost{},
-			nil,
-			&mock.GlobalSettingsHandlerStub{},
-			&mock.DCTRoleHandlerStub{},
-			createNewDCTDataStorageHandler(),
-			&mock.AccountsStub{},
-			&mock.EnableEpochsHandlerStub{},
-		)
+		nftCreate, err := NewDCTNFTCreateFunc(ArgsNewDCTNFTCreate{
+			Config: Config{
+				Accounts:              &mock.AccountsStub{},
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+				DCTStorageHandler:     createNewDCTDataStorageHandler(),
+				EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			},
+		})
 		assert.True(t, check.IfNil(nftCreate))
-		assert.Equal(t, ErrNilMarshalizer, err)
+		assert.ErrorIs(t, err, ErrNilMarshalizer)
 	})
 	t.Run("nil global settings handler should error", func(t *testing.T) {
 		t.Parallel()
 
-		nftCreate, err := NewDCTNFTCreateFunc(
-			0,
-			vmcommon.BaseOperationCost{},
-			&mock.MarshalizerMock{},
-			nil,
-			&mock.DCTRoleHandlerStub{},
-			createNewDCTDataStorageHandler(),
-			&mock.AccountsStub{},
-			&mock.EnableEpochsHandlerStub{},
-		)
+		nftCreate, err := NewDCTNFTCreateFunc(ArgsNewDCTNFTCreate{
+			Config: Config{
+				Marshalizer:         &mock.MarshalizerMock{},
+				Accounts:            &mock.AccountsStub{},
+				RolesHandler:        &mock.DCTRoleHandlerStub{},
+				DCTStorageHandler:   createNewDCTDataStorageHandler(),
+				EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+			},
+		})
 		assert.True(t, check.IfNil(nftCreate))
-		assert.Equal(t, ErrNilGlobalSettingsHandler, err)
+		assert.ErrorIs(t, err, ErrNilGlobalSettingsHandler)
 	})
 	t.Run("nil roles handler should error", func(t *testing.T) {
 		t.Parallel()
 
-		nftCreate, err := NewDCTNFTCreateFunc(
-			0,
-			vmcommon.BaseOperationCost{},
-			&mock.MarshalizerMock{},
-			&mock.GlobalSettingsHandlerStub{},
-			nil,
-			createNewDCTDataStorageHandler(),
-			&mock.AccountsStub{},
-			&mock.EnableEpochsHandlerStub{},
-		)
+		nftCreate, err := NewDCTNFTCreateFunc(ArgsNewDCTNFTCreate{
+			Config: Config{
+				Marshalizer:           &mock.MarshalizerMock{},
+				Accounts:              &mock.AccountsStub{},
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				DCTStorageHandler:     createNewDCTDataStorageHandler(),
+				EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			},
+		})
 		assert.True(t, check.IfNil(nftCreate))
-		assert.Equal(t, ErrNilRolesHandler, err)
+		assert.ErrorIs(t, err, ErrNilRolesHandler)
 	})
 	t.Run("nil dct storage handler should error", func(t *testing.T) {
 		t.Parallel()
 
-		nftCreate, err := NewDCTNFTCreateFunc(
-			0,
-			vmcommon.BaseOperationCost{},
-			&mock.MarshalizerMock{},
-			&mock.GlobalSettingsHandlerStub{},
-			&mock.DCTRoleHandlerStub{},
-			nil,
-			&mock.AccountsStub{},
-			&mock.EnableEpochsHandlerStub{},
-		)
+		nftCreate, err := NewDCTNFTCreateFunc(ArgsNewDCTNFTCreate{
+			Config: Config{
+				Marshalizer:           &mock.MarshalizerMock{},
+				Accounts:              &mock.AccountsStub{},
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+				EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			},
+		})
 		assert.True(t, check.IfNil(nftCreate))
-		assert.Equal(t, ErrNilDCTNFTStorageHandler, err)
+		assert.ErrorIs(t, err, ErrNilDCTNFTStorageHandler)
 	})
 	t.Run("nil enable epochs handler should error", func(t *testing.T) {
 		t.Parallel()
 
-		nftCreate, err := NewDCTNFTCreateFunc(
-			0,
-			vmcommon.BaseOperationCost{},
-			&mock.MarshalizerMock{},
-			&mock.GlobalSettingsHandlerStub{},
-			&mock.DCTRoleHandlerStub{},
-			createNewDCTDataStorageHandler(),
-			&mock.AccountsStub{},
-			nil,
-		)
+		nftCreate, err := NewDCTNFTCreateFunc(ArgsNewDCTNFTCreate{
+			Config: Config{
+				Marshalizer:           &mock.MarshalizerMock{},
+				Accounts:              &mock.AccountsStub{},
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+				DCTStorageHandler:     createNewDCTDataStorageHandler(),
+			},
+		})
 		assert.True(t, check.IfNil(nftCreate))
-		assert.Equal(t, ErrNilEnableEpochsHandler, err)
+		assert.ErrorIs(t, err, ErrNilEnableEpochsHandler)
 	})
 	t.Run("should work", func(t *testing.T) {
 		t.Parallel()
 
-		nftCreate, err := NewDCTNFTCreateFunc(
-			0,
-			vmcommon.BaseOperationCost{},
-			&mock.MarshalizerMock{},
-			&mock.GlobalSettingsHandlerStub{},
-			&mock.DCTRoleHandlerStub{},
-			createNewDCTDataStorageHandler(),
-			&mock.AccountsStub{},
-			&mock.EnableEpochsHandlerStub{},
-		)
+		nftCreate, err := NewDCTNFTCreateFunc(ArgsNewDCTNFTCreate{
+			Config: Config{
+				Marshalizer:           &mock.MarshalizerMock{},
+				Accounts:              &mock.AccountsStub{},
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+				DCTStorageHandler:     createNewDCTDataStorageHandler(),
+				EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			},
+		})
 		assert.Nil(t, err)
 		assert.False(t, check.IfNil(nftCreate))
 	})
@@ -137,18 +136,18 @@ func TestNewDCTNFTCreateFunc_NilArgumentsShouldErr(t *testing.T) {
 func TestNewDCTNFTCreateFunc(t *testing.T) {
 	t.Parallel()
 
-	nftCreate, err := NewDCTNFTCreateFunc(
-		0,
-		vmcommon.BaseOperationCost{},
-		&mock.MarshalizerMock{},
-		&mock.GlobalSettingsHandlerStub{},
-		&mock.DCTRoleHandlerStub{},
-		createNewDCTDataStorageHandler(),
-		&mock.AccountsStub{},
-		&mock.EnableEpochsHandlerStub{
-			IsValueLengthCheckFlagEnabledField: true,
+	nftCreate, err := NewDCTNFTCreateFunc(ArgsNewDCTNFTCreate{
+		Config: Config{
+			Marshalizer:           &mock.MarshalizerMock{},
+			Accounts:              &mock.AccountsStub{},
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			DCTStorageHandler:     createNewDCTDataStorageHandler(),
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+				IsValueLengthCheckFlagEnabledField: true,
+			},
 		},
-	)
+	})
 	assert.False(t, check.IfNil(nftCreate))
 	assert.Nil(t, err)
 }
@@ -231,22 +230,22 @@ func TestDctNFTCreate_ProcessBuiltinFunctionNotAllowedToExecute(t *testing.T) {
 
 	expectedErr := errors.New("expected error")
 	dctDataStorage := createNewDCTDataStorageHandler()
-	nftCreate, _ := NewDCTNFTCreateFunc(
-		0,
-		vmcommon.BaseOperationCost{},
-		&mock.MarshalizerMock{},
-		&mock.GlobalSettingsHandlerStub{},
-		&mock.DCTRoleHandlerStub{
-			CheckAllowedToExecuteCalled: func(account vmcommon.UserAccountHandler, tokenID []byte, action []byte) error {
-				return expectedErr
+	nftCreate, _ := NewDCTNFTCreateFunc(ArgsNewDCTNFTCreate{
+		Config: Config{
+			Marshalizer:           &mock.MarshalizerMock{},
+			Accounts:              dctDataStorage.accounts,
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler: &mock.DCTRoleHandlerStub{
+				CheckAllowedToExecuteCalled: func(account vmcommon.UserAccountHandler, tokenID []byte, action []byte) error {
+					return expectedErr
+				},
+			},
+			DCTStorageHandler: dctDataStorage,
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+				IsValueLengthCheckFlagEnabledField: true,
 			},
 		},
-		dctDataStorage,
-		dctDataStorage.accounts,
-		&mock.EnableEpochsHandlerStub{
-			IsValueLengthCheckFlagEnabledField: true,
-		},
-	)
+	})
 	sender := mock.NewAccountWrapMock([]byte("address"))
 	vmInput := &vmcommon.ContractCallInput{
 		VMInput: vmcommon.VMInput{
@@ -277,18 +276,18 @@ func TestDctNFTCreate_ProcessBuiltinFunctionShouldWork(t *testing.T) {
 			return nil
 		},
 	}
-	nftCreate, _ := NewDCTNFTCreateFunc(
-		0,
-		vmcommon.BaseOperationCost{},
-		&mock.MarshalizerMock{},
-		&mock.GlobalSettingsHandlerStub{},
-		dctRoleHandler,
-		dctDataStorage,
-		dctDataStorage.accounts,
-		&mock.EnableEpochsHandlerStub{
-			IsValueLengthCheckFlagEnabledField: true,
+	nftCreate, _ := NewDCTNFTCreateFunc(ArgsNewDCTNFTCreate{
+		Config: Config{
+			Marshalizer:           &mock.MarshalizerMock{},
+			Accounts:              dctDataStorage.accounts,
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          dctRoleHandler,
+			DCTStorageHandler:     dctDataStorage,
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+				IsValueLengthCheckFlagEnabledField: true,
+			},
 		},
-	)
+	})
 	address := bytes.Repeat([]byte{1}, 32)
 	sender := mock.NewUserAccount(address)
 	//add some data in the trie, otherwise the creation will fail (it won't happen in real case usage as the create NFT
@@ -364,16 +363,16 @@ func TestDctNFTCreate_ProcessBuiltinFunctionWithExecByCaller(t *testing.T) {
 		IsCheckFrozenCollectionFlagEnabledField: true,
 	}
 	dctDataStorage := createNewDCTDataStorageHandlerWithArgs(&mock.GlobalSettingsHandlerStub{}, accounts, enableEpochsHandler)
-	nftCreate, _ := NewDCTNFTCreateFunc(
-		0,
-		vmcommon.BaseOperationCost{},
-		&mock.MarshalizerMock{},
-		&mock.GlobalSettingsHandlerStub{},
-		&mock.DCTRoleHandlerStub{},
-		dctDataStorage,
-		dctDataStorage.accounts,
-		enableEpochsHandler,
-	)
+	nftCreate, _ := NewDCTNFTCreateFunc(ArgsNewDCTNFTCreate{
+		Config: Config{
+			Marshalizer:           &mock.MarshalizerMock{},
+			Accounts:              dctDataStorage.accounts,
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			DCTStorageHandler:     dctDataStorage,
+			EnableEpochsHandler:   enableEpochsHandler,
+		},
+	})
 	address := bytes.Repeat([]byte{1}, 32)
 	userAddress := bytes.Repeat([]byte{2}, 32)
 	token := "token"
@@ -433,15 +432,232 @@ func TestDctNFTCreate_ProcessBuiltinFunctionWithExecByCaller(t *testing.T) {
 	assert.Equal(t, tokenMetaData, metaData)
 }
 
+func TestDctNFTCreate_ProcessBuiltinFunctionWithAccountCache(t *testing.T) {
+	t.Parallel()
+
+	accounts := createAccountsAdapterWithMap()
+	numLoads := 0
+	countingAccounts := &mock.AccountsStub{
+		LoadAccountCalled: func(address []byte) (vmcommon.AccountHandler, error) {
+			numLoads++
+			return accounts.LoadAccount(address)
+		},
+		SaveAccountCalled: accounts.SaveAccount,
+	}
+	enableEpochsHandler := &mock.EnableEpochsHandlerStub{
+		IsValueLengthCheckFlagEnabledField:    true,
+		IsSaveToSystemAccountFlagEnabledField: true,
+	}
+	accountCache, _ := NewAccountCache(10, &mock.RoundNotifierStub{})
+	cachedAccounts, _ := NewAccountsAdapterWithAccountCache(countingAccounts, accountCache)
+	dctDataStorage := createNewDCTDataStorageHandlerWithArgs(&mock.GlobalSettingsHandlerStub{}, accounts, enableEpochsHandler)
+	nftCreate, _ := NewDCTNFTCreateFunc(ArgsNewDCTNFTCreate{
+		Config: Config{
+			Marshalizer:           &mock.MarshalizerMock{},
+			Accounts:              cachedAccounts,
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			DCTStorageHandler:     dctDataStorage,
+			EnableEpochsHandler:   enableEpochsHandler,
+		},
+	})
+	err := nftCreate.SetAccountCache(nil)
+	assert.Equal(t, ErrNilAccountCache, err)
+
+	unboundCache, _ := NewAccountCache(10, &mock.RoundNotifierStub{})
+	err = nftCreate.SetAccountCache(unboundCache)
+	assert.Equal(t, ErrAccountCacheNotBoundToAccounts, err)
+
+	err = nftCreate.SetAccountCache(accountCache)
+	assert.Nil(t, err)
+
+	address := bytes.Repeat([]byte{1}, 32)
+	userAddress := bytes.Repeat([]byte{2}, 32)
+	token := []byte("token")
+	createVMInput := func() *vmcommon.ContractCallInput {
+		return &vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallerAddr: userAddress,
+				CallValue:  big.NewInt(0),
+				Arguments: [][]byte{
+					token,
+					big.NewInt(1).Bytes(),
+					[]byte("name"),
+					big.NewInt(100).Bytes(),
+					[]byte("hash"),
+					[]byte("attributes"),
+					[]byte("uri"),
+					address,
+				},
+				CallType: vm.ExecOnDestByCaller,
+			},
+			RecipientAddr: userAddress,
+		}
+	}
+
+	_, err = nftCreate.ProcessBuiltinFunction(nil, nil, createVMInput())
+	require.Nil(t, err)
+	_, err = nftCreate.ProcessBuiltinFunction(nil, nil, createVMInput())
+	require.Nil(t, err)
+	assert.Equal(t, 1, numLoads)
+
+	roleAcc, found := accountCache.Get(address)
+	require.True(t, found)
+	_, latestNonce := readNFTData(t, roleAcc, nftCreate.marshaller, token, 2, address)
+	assert.Equal(t, uint64(2), latestNonce)
+
+	accountCache.Remove(address)
+	_, err = nftCreate.ProcessBuiltinFunction(nil, nil, createVMInput())
+	require.Nil(t, err)
+	assert.Equal(t, 2, numLoads)
+
+	t.Run("account changed between two calls should not be read from cache", func(t *testing.T) {
+		changedAccount := mock.NewUserAccount(address)
+		changedAccount.Storage[string(dctkeys.ComputeNonceKey(token))] = big.NewInt(10).Bytes()
+		err = cachedAccounts.SaveAccount(changedAccount)
+		require.Nil(t, err)
+
+		_, err = nftCreate.ProcessBuiltinFunction(nil, nil, createVMInput())
+		require.Nil(t, err)
+		roleAcc, _ = accountCache.Get(address)
+		assert.True(t, roleAcc == changedAccount)
+		_, latestNonce = readNFTData(t, roleAcc, nftCreate.marshaller, token, 11, address)
+		assert.Equal(t, uint64(11), latestNonce)
+	})
+	t.Run("reverted state should not be read from cache", func(t *testing.T) {
+		revertedAccount := mock.NewUserAccount(address)
+		countingAccounts.RevertToSnapshotCalled = func(_ int) error {
+			return accounts.SaveAccount(revertedAccount)
+		}
+		err = cachedAccounts.RevertToSnapshot(0)
+		require.Nil(t, err)
+		assert.Equal(t, 0, accountCache.Len())
+
+		_, err = nftCreate.ProcessBuiltinFunction(nil, nil, createVMInput())
+		require.Nil(t, err)
+		assert.Equal(t, 3, numLoads)
+		roleAcc, _ = accountCache.Get(address)
+		assert.True(t, roleAcc == revertedAccount)
+		_, latestNonce = readNFTData(t, roleAcc, nftCreate.marshaller, token, 1, address)
+		assert.Equal(t, uint64(1), latestNonce)
+	})
+}
+
+func TestDctNFTCreate_ProcessBuiltinFunctionWithLatestNonceCache(t *testing.T) {
+	t.Parallel()
+
+	dctDataStorage := createNewDCTDataStorageHandler()
+	nonceCache, _ := NewLatestNonceCache(&mock.RoundNotifierStub{})
+	cachedAccounts, _ := NewAccountsAdapterWithLatestNonceCache(dctDataStorage.accounts, nonceCache)
+	nftCreate, _ := NewDCTNFTCreateFunc(ArgsNewDCTNFTCreate{
+		Config: Config{
+			Marshalizer:           &mock.MarshalizerMock{},
+			Accounts:              cachedAccounts,
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			DCTStorageHandler:     dctDataStorage,
+			EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+		},
+	})
+	err := nftCreate.SetLatestNonceCache(nil)
+	assert.Equal(t, ErrNilLatestNonceCache, err)
+
+	unboundCache, _ := NewLatestNonceCache(&mock.RoundNotifierStub{})
+	err = nftCreate.SetLatestNonceCache(unboundCache)
+	assert.Equal(t, ErrLatestNonceCacheNotBoundToAccounts, err)
+
+	err = nftCreate.SetLatestNonceCache(nonceCache)
+	assert.Nil(t, err)
+
+	address := bytes.Repeat([]byte{1}, 32)
+	sender := mock.NewUserAccount(address)
+	_ = sender.AccountDataHandler().SaveKeyValue([]byte("key"), []byte("value"))
+
+	token := []byte("token")
+	createVMInput := func(royalties int64) *vmcommon.ContractCallInput {
+		return &vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallerAddr: address,
+				CallValue:  big.NewInt(0),
+				Arguments: [][]byte{
+					token,
+					big.NewInt(1).Bytes(),
+					[]byte("name"),
+					big.NewInt(royalties).Bytes(),
+					[]byte("hash"),
+					[]byte("attributes"),
+					[]byte("uri"),
+				},
+			},
+			RecipientAddr: address,
+		}
+	}
+
+	t.Run("nonce is cached after create", func(t *testing.T) {
+		vmOutput, errProcess := nftCreate.ProcessBuiltinFunction(sender, nil, createVMInput(100))
+		require.Nil(t, errProcess)
+		assert.Equal(t, big.NewInt(1).Bytes(), vmOutput.ReturnData[0])
+
+		cachedNonce, found := nonceCache.Get(address, token)
+		assert.True(t, found)
+		assert.Equal(t, uint64(1), cachedNonce)
+
+		// the cached value is used instead of the one from the account data trie
+		_ = saveLatestNonce(sender, token, 100)
+		vmOutput, errProcess = nftCreate.ProcessBuiltinFunction(sender, nil, createVMInput(100))
+		require.Nil(t, errProcess)
+		assert.Equal(t, big.NewInt(2).Bytes(), vmOutput.ReturnData[0])
+	})
+	t.Run("failed create should not change the cache", func(t *testing.T) {
+		_, errProcess := nftCreate.ProcessBuiltinFunction(sender, nil, createVMInput(int64(core.MaxRoyalty)+1))
+		require.NotNil(t, errProcess)
+
+		cachedNonce, _ := nonceCache.Get(address, token)
+		assert.Equal(t, uint64(2), cachedNonce)
+	})
+	t.Run("reverted state should empty the cache and reuse the nonce", func(t *testing.T) {
+		// the host reverts the account state to the one before the second create
+		_ = saveLatestNonce(sender, token, 1)
+		_ = cachedAccounts.RevertToSnapshot(0)
+		_, found := nonceCache.Get(address, token)
+		assert.False(t, found)
+
+		vmOutput, errProcess := nftCreate.ProcessBuiltinFunction(sender, nil, createVMInput(100))
+		require.Nil(t, errProcess)
+		assert.Equal(t, big.NewInt(2).Bytes(), vmOutput.ReturnData[0])
+
+		_, latestNonce := readNFTData(t, sender, nftCreate.marshaller, token, 2, nil)
+		assert.Equal(t, uint64(2), latestNonce)
+	})
+	t.Run("role transfer should evict the cached nonce", func(t *testing.T) {
+		roleTransfer, _ := NewDCTNFTCreateRoleTransfer(ArgsNewDCTNFTCreateRoleTransfer{
+			Config: Config{
+				Marshalizer:      &mock.MarshalizerMock{},
+				Accounts:         dctDataStorage.accounts,
+				ShardCoordinator: mock.NewMultiShardsCoordinatorMock(2),
+			},
+		})
+		_ = roleTransfer.SetLatestNonceCache(nonceCache)
+
+		err = roleTransfer.executeTransferNFTCreateChangeAtNextOwner(&vmcommon.VMOutput{}, sender, &vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{Arguments: [][]byte{token, big.NewInt(10).Bytes()}},
+		})
+		require.Nil(t, err)
+
+		_, found := nonceCache.Get(address, token)
+		assert.False(t, found)
+	})
+}
+
 func readNFTData(t *testing.T, account vmcommon.UserAccountHandler, marshaller vmcommon.Marshalizer, tokenID []byte, nonce uint64, _ []byte) (*dct.DCToken, uint64) {
-	nonceKey := getNonceKey(tokenID)
+	nonceKey := dctkeys.ComputeNonceKey(tokenID)
 	latestNonceBytes, _, err := account.(vmcommon.UserAccountHandler).AccountDataHandler().RetrieveValue(nonceKey)
 	require.Nil(t, err)
 	latestNonce := big.NewInt(0).SetBytes(latestNonceBytes).Uint64()
 
 	createdTokenID := []byte(baseDCTKeyPrefix)
 	createdTokenID = append(createdTokenID, tokenID...)
-	tokenKey := computeDCTNFTTokenKey(createdTokenID, nonce)
+	tokenKey := dctkeys.ComputeDCTNFTTokenKey(createdTokenID, nonce)
 	data, _, err := account.(vmcommon.UserAccountHandler).AccountDataHandler().RetrieveValue(tokenKey)
 	require.Nil(t, err)
 
@@ -451,3 +667,823 @@ func readNFTData(t *testing.T, account vmcommon.UserAccountHandler, marshaller v
 
 	return dctData, latestNonce
 }
+
+func BenchmarkDctNFTCreate_ProcessBuiltinFunction(b *testing.B) {
+	dctDataStorage := createNewDCTDataStorageHandler()
+	nftCreate, _ := NewDCTNFTCreateFunc(ArgsNewDCTNFTCreate{
+		Config: Config{
+			Marshalizer:           &mock.MarshalizerMock{},
+			Accounts:              dctDataStorage.accounts,
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			DCTStorageHandler:     dctDataStorage,
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+				IsValueLengthCheckFlagEnabledField: true,
+			},
+		},
+	})
+	sender := mock.NewUserAccount(bytes.Repeat([]byte{1}, 32))
+	_ = sender.AccountDataHandler().SaveKeyValue([]byte("key"), []byte("value"))
+
+	vmInput := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr: sender.AddressBytes(),
+			CallValue:  big.NewInt(0),
+			Arguments: [][]byte{
+				[]byte("token"),
+				big.NewInt(1).Bytes(),
+				[]byte("name"),
+				big.NewInt(100).Bytes(),
+				[]byte("12345678901234567890123456789012"),
+				[]byte("attributes"),
+				[]byte("uri"),
+			},
+		},
+		RecipientAddr: sender.AddressBytes(),
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		vmOutput, err := nftCreate.ProcessBuiltinFunction(sender, nil, vmInput)
+		if err != nil {
+			b.Fatal(err)
+		}
+		vmcommon.ReleaseVMOutput(vmOutput)
+	}
+}
+
+func TestDctNFTCreate_CreatorOnBehalf(t *testing.T) {
+	t.Parallel()
+
+	address := bytes.Repeat([]byte{1}, 32)
+	userAddress := bytes.Repeat([]byte{2}, 32)
+	artistAddress := bytes.Repeat([]byte{3}, 32)
+	token := []byte("token")
+	createComponents := func(flagEnabled bool, onBehalf bool, rolesHandler vmcommon.DCTRoleHandler) (*dctNFTCreate, *dctDataStorage) {
+		accounts := createAccountsAdapterWithMap()
+		enableEpochsHandler := &mock.EnableEpochsHandlerStub{
+			IsValueLengthCheckFlagEnabledField:      true,
+			IsSaveToSystemAccountFlagEnabledField:   true,
+			IsCheckFrozenCollectionFlagEnabledField: true,
+			IsNFTCreateOnBehalfFlagEnabledField:     flagEnabled,
+		}
+		dctDataStorage := createNewDCTDataStorageHandlerWithArgs(&mock.GlobalSettingsHandlerStub{}, accounts, enableEpochsHandler)
+		constructor := NewDCTNFTCreateFunc
+		if onBehalf {
+			constructor = NewDCTNFTCreateOnBehalfFunc
+		}
+		nftCreate, _ := constructor(ArgsNewDCTNFTCreate{
+			Config: Config{
+				Marshalizer:           &mock.MarshalizerMock{},
+				Accounts:              dctDataStorage.accounts,
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          rolesHandler,
+				DCTStorageHandler:     dctDataStorage,
+				EnableEpochsHandler:   enableEpochsHandler,
+			},
+		})
+		return nftCreate, dctDataStorage
+	}
+	createInput := func(extraArguments ...[]byte) *vmcommon.ContractCallInput {
+		arguments := [][]byte{token, big.NewInt(1).Bytes(), []byte("name"), big.NewInt(100).Bytes(), []byte("hash"), []byte("attributes")}
+		return &vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallerAddr: userAddress,
+				CallValue:  big.NewInt(0),
+				Arguments:  append(arguments, extraArguments...),
+			},
+			RecipientAddr: userAddress,
+		}
+	}
+	getCreator := func(dctDataStorage *dctDataStorage) []byte {
+		tokenKey := append([]byte(baseDCTKeyPrefix), token...)
+		metaData, _ := dctDataStorage.getDCTMetaDataFromSystemAccount(dctkeys.ComputeDCTNFTTokenKey(tokenKey, 1), defaultQueryOptions())
+		require.NotNil(t, metaData)
+		return metaData.Creator
+	}
+
+	t.Run("on behalf function should be gated by the flag", func(t *testing.T) {
+		t.Parallel()
+
+		nftCreate, _ := createComponents(false, true, &mock.DCTRoleHandlerStub{})
+		assert.False(t, nftCreate.IsActive())
+
+		nftCreate, _ = createComponents(true, true, &mock.DCTRoleHandlerStub{})
+		assert.True(t, nftCreate.IsActive())
+
+		nftCreate, _ = createComponents(false, false, &mock.DCTRoleHandlerStub{})
+		assert.True(t, nftCreate.IsActive())
+	})
+	t.Run("exec on destination by caller without flag should record the caller", func(t *testing.T) {
+		t.Parallel()
+
+		nftCreate, dctDataStorage := createComponents(false, false, &mock.DCTRoleHandlerStub{})
+		input := createInput([]byte("uri"), address)
+		input.CallType = vm.ExecOnDestByCaller
+		_, err := nftCreate.ProcessBuiltinFunction(nil, nil, input)
+		require.Nil(t, err)
+		assert.Equal(t, userAddress, getCreator(dctDataStorage))
+	})
+	t.Run("exec on destination by caller with flag should record the roles holder", func(t *testing.T) {
+		t.Parallel()
+
+		nftCreate, dctDataStorage := createComponents(true, false, &mock.DCTRoleHandlerStub{})
+		input := createInput([]byte("uri"), address)
+		input.CallType = vm.ExecOnDestByCaller
+		_, err := nftCreate.ProcessBuiltinFunction(nil, nil, input)
+		require.Nil(t, err)
+		assert.Equal(t, address, getCreator(dctDataStorage))
+	})
+	t.Run("on behalf with invalid creator should err", func(t *testing.T) {
+		t.Parallel()
+
+		nftCreate, _ := createComponents(true, true, &mock.DCTRoleHandlerStub{})
+		acntSnd, _ := nftCreate.getAccount(userAddress)
+		_, err := nftCreate.ProcessBuiltinFunction(acntSnd, nil, createInput([]byte("short"), []byte("uri")))
+		assert.ErrorIs(t, err, ErrInvalidArguments)
+	})
+	t.Run("on behalf without the role should err", func(t *testing.T) {
+		t.Parallel()
+
+		rolesHandler := &mock.DCTRoleHandlerStub{
+			CheckAllowedToExecuteCalled: func(account vmcommon.UserAccountHandler, tokenID []byte, action []byte) error {
+				if string(action) == vmcommon.DCTRoleNFTCreateOnBehalf {
+					return ErrActionNotAllowed
+				}
+				return nil
+			},
+		}
+		nftCreate, _ := createComponents(true, true, rolesHandler)
+		acntSnd, _ := nftCreate.getAccount(userAddress)
+		_, err := nftCreate.ProcessBuiltinFunction(acntSnd, nil, createInput(artistAddress, []byte("uri")))
+		assert.Equal(t, ErrActionNotAllowed, err)
+	})
+	t.Run("on behalf should record the explicit creator", func(t *testing.T) {
+		t.Parallel()
+
+		nftCreate, dctDataStorage := createComponents(true, true, &mock.DCTRoleHandlerStub{})
+		acntSnd, _ := nftCreate.getAccount(userAddress)
+		_, err := nftCreate.ProcessBuiltinFunction(acntSnd, nil, createInput(artistAddress, []byte("uri")))
+		require.Nil(t, err)
+		assert.Equal(t, artistAddress, getCreator(dctDataStorage))
+
+		tokenKey := append([]byte(baseDCTKeyPrefix), token...)
+		metaData, _ := dctDataStorage.getDCTMetaDataFromSystemAccount(dctkeys.ComputeDCTNFTTokenKey(tokenKey, 1), defaultQueryOptions())
+		assert.Equal(t, [][]byte{[]byte("uri")}, metaData.URIs)
+	})
+	t.Run("on behalf executed on destination by caller should record the explicit creator", func(t *testing.T) {
+		t.Parallel()
+
+		nftCreate, dctDataStorage := createComponents(true, true, &mock.DCTRoleHandlerStub{})
+		input := createInput(artistAddress, []byte("uri"), address)
+		input.CallType = vm.ExecOnDestByCaller
+		_, err := nftCreate.ProcessBuiltinFunction(nil, nil, input)
+		require.Nil(t, err)
+		assert.Equal(t, artistAddress, getCreator(dctDataStorage))
+	})
+}
+
+func TestDctNFTCreate_ProcessBuiltinFunctionWithNFTCreateStopped(t *testing.T) {
+	t.Parallel()
+
+	createNFTCreate := func(flagEnabled bool, rolesChecked *bool) *dctNFTCreate {
+		dctDataStorage := createNewDCTDataStorageHandler()
+		nftCreate, _ := NewDCTNFTCreateFunc(ArgsNewDCTNFTCreate{
+			Config: Config{
+				Marshalizer: &mock.MarshalizerMock{},
+				Accounts:    dctDataStorage.accounts,
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{
+					IsNFTCreateStoppedCalled: func(token []byte) bool {
+						return bytes.Equal(token, []byte(baseDCTKeyPrefix+"token"))
+					},
+				},
+				RolesHandler: &mock.DCTRoleHandlerStub{
+					CheckAllowedToExecuteCalled: func(account vmcommon.UserAccountHandler, tokenID []byte, action []byte) error {
+						*rolesChecked = true
+						return ErrActionNotAllowed
+					},
+				},
+				DCTStorageHandler: dctDataStorage,
+				EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+					IsValueLengthCheckFlagEnabledField: true,
+					IsStopNFTCreateFlagEnabledField:    flagEnabled,
+				},
+			},
+		})
+		return nftCreate
+	}
+	sender := mock.NewAccountWrapMock([]byte("address"))
+	arguments := make([][]byte, 7)
+	arguments[0] = []byte("token")
+	vmInput := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr: sender.AddressBytes(),
+			CallValue:  big.NewInt(0),
+			Arguments:  arguments,
+		},
+		RecipientAddr: sender.AddressBytes(),
+	}
+
+	t.Run("stopped collection should err before checking the roles", func(t *testing.T) {
+		t.Parallel()
+
+		rolesChecked := false
+		vmOutput, err := createNFTCreate(true, &rolesChecked).ProcessBuiltinFunction(sender, nil, vmInput)
+		assert.Nil(t, vmOutput)
+		assert.Equal(t, ErrNFTCreateStopped, err)
+		assert.False(t, rolesChecked)
+	})
+	t.Run("flag not enabled should ignore the setting", func(t *testing.T) {
+		t.Parallel()
+
+		rolesChecked := false
+		_, err := createNFTCreate(false, &rolesChecked).ProcessBuiltinFunction(sender, nil, vmInput)
+		assert.Equal(t, ErrActionNotAllowed, err)
+		assert.True(t, rolesChecked)
+	})
+}
+
+func TestDctNFTCreate_ProcessBuiltinFunctionWithMaxSupply(t *testing.T) {
+	t.Parallel()
+
+	createNFTCreate := func(flagEnabled bool, savedToken **dct.DCToken, savedMaxSupply **big.Int) *dctNFTCreate {
+		dctStorageHandler := &mock.DCTNFTStorageHandlerStub{
+			SaveDCTNFTTokenCalled: func(_ []byte, _ vmcommon.UserAccountHandler, _ []byte, _ uint64, dctData *dct.DCToken, _ bool, _ bool) ([]byte, error) {
+				*savedToken = dctData
+				return nil, nil
+			},
+			SaveNFTMaxSupplyCalled: func(dctTokenKey []byte, nonce uint64, maxSupply *big.Int, initialQuantity *big.Int) error {
+				assert.Equal(t, []byte(baseDCTKeyPrefix+"token"), dctTokenKey)
+				assert.Equal(t, uint64(1), nonce)
+				assert.Equal(t, big.NewInt(2), initialQuantity)
+				*savedMaxSupply = maxSupply
+				return nil
+			},
+		}
+		nftCreate, _ := NewDCTNFTCreateFunc(ArgsNewDCTNFTCreate{
+			Config: Config{
+				Marshalizer:           &mock.MarshalizerMock{},
+				Accounts:              &mock.AccountsStub{},
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+				DCTStorageHandler:     dctStorageHandler,
+				EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+					IsValueLengthCheckFlagEnabledField: true,
+					IsNFTMaxSupplyFlagEnabledField:     flagEnabled,
+				},
+			},
+		})
+		return nftCreate
+	}
+	createInput := func(maxSupply []byte) *vmcommon.ContractCallInput {
+		arguments := [][]byte{[]byte("token"), big.NewInt(2).Bytes(), []byte("name"), nil, []byte("hash"), []byte("attributes")}
+		if maxSupply != nil {
+			arguments = append(arguments, maxSupply)
+		}
+		arguments = append(arguments, []byte("uri1"), []byte("uri2"))
+		return &vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallerAddr: []byte("address"),
+				CallValue:  big.NewInt(0),
+				Arguments:  arguments,
+			},
+			RecipientAddr: []byte("address"),
+		}
+	}
+
+	t.Run("max supply should be saved and not be an URI", func(t *testing.T) {
+		t.Parallel()
+
+		var savedToken *dct.DCToken
+		var savedMaxSupply *big.Int
+		nftCreate := createNFTCreate(true, &savedToken, &savedMaxSupply)
+		_, err := nftCreate.ProcessBuiltinFunction(mock.NewUserAccount([]byte("address")), nil, createInput(big.NewInt(10).Bytes()))
+		require.Nil(t, err)
+		assert.Equal(t, big.NewInt(10), savedMaxSupply)
+		assert.Equal(t, [][]byte{[]byte("uri1"), []byte("uri2")}, savedToken.TokenMetaData.URIs)
+	})
+	t.Run("empty max supply should leave the nonce uncapped", func(t *testing.T) {
+		t.Parallel()
+
+		var savedToken *dct.DCToken
+		var savedMaxSupply *big.Int
+		nftCreate := createNFTCreate(true, &savedToken, &savedMaxSupply)
+		_, err := nftCreate.ProcessBuiltinFunction(mock.NewUserAccount([]byte("address")), nil, createInput([]byte{}))
+		require.Nil(t, err)
+		assert.Equal(t, 0, savedMaxSupply.Sign())
+	})
+	t.Run("quantity above max supply should err", func(t *testing.T) {
+		t.Parallel()
+
+		var savedToken *dct.DCToken
+		var savedMaxSupply *big.Int
+		nftCreate := createNFTCreate(true, &savedToken, &savedMaxSupply)
+		_, err := nftCreate.ProcessBuiltinFunction(mock.NewUserAccount([]byte("address")), nil, createInput(big.NewInt(1).Bytes()))
+		assert.ErrorIs(t, err, ErrInvalidArguments)
+		assert.Nil(t, savedToken)
+	})
+	t.Run("flag not enabled should keep all the arguments as URIs", func(t *testing.T) {
+		t.Parallel()
+
+		var savedToken *dct.DCToken
+		var savedMaxSupply *big.Int
+		nftCreate := createNFTCreate(false, &savedToken, &savedMaxSupply)
+		_, err := nftCreate.ProcessBuiltinFunction(mock.NewUserAccount([]byte("address")), nil, createInput(nil))
+		require.Nil(t, err)
+		assert.Equal(t, 0, savedMaxSupply.Sign())
+		assert.Equal(t, [][]byte{[]byte("uri1"), []byte("uri2")}, savedToken.TokenMetaData.URIs)
+	})
+}
+
+func TestDctNFTCreate_ProcessBuiltinFunctionNonceRanges(t *testing.T) {
+	t.Parallel()
+
+	token := []byte("token")
+	createNFTCreate := func(flagEnabled bool, config vmcommon.CollectionConfig) *dctNFTCreate {
+		dctDataStorage := createNewDCTDataStorageHandler()
+		nftCreate, _ := NewDCTNFTCreateFunc(ArgsNewDCTNFTCreate{
+			Config: Config{
+				Marshalizer: &mock.MarshalizerMock{},
+				Accounts:    dctDataStorage.accounts,
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{
+					GetCollectionConfigCalled: func(tokenID []byte) vmcommon.CollectionConfig {
+						assert.Equal(t, token, tokenID)
+						return config
+					},
+				},
+				RolesHandler:      &mock.DCTRoleHandlerStub{},
+				DCTStorageHandler: dctDataStorage,
+				EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+					IsValueLengthCheckFlagEnabledField: true,
+					IsNFTNonceRangesFlagEnabledField:   flagEnabled,
+				},
+			},
+		})
+		return nftCreate
+	}
+	createNFT := func(nftCreate *dctNFTCreate, latestNonce uint64) (*vmcommon.VMOutput, error) {
+		sender := mock.NewUserAccount([]byte("address"))
+		_ = saveLatestNonce(sender, token, latestNonce)
+		vmInput := &vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallerAddr: sender.AddressBytes(),
+				CallValue:  big.NewInt(0),
+				Arguments:  [][]byte{token, {1}, []byte("name"), nil, []byte("hash"), []byte("attributes"), []byte("uri")},
+			},
+			RecipientAddr: sender.AddressBytes(),
+		}
+		return nftCreate.ProcessBuiltinFunction(sender, nil, vmInput)
+	}
+	config := vmcommon.CollectionConfig{
+		ReservedNonceRanges: []vmcommon.NonceRange{{Start: 5, End: 10}},
+	}
+
+	t.Run("reserved nonces should be skipped", func(t *testing.T) {
+		t.Parallel()
+
+		vmOutput, err := createNFT(createNFTCreate(true, config), 4)
+		require.Nil(t, err)
+		assert.Equal(t, [][]byte{{11}}, vmOutput.ReturnData)
+	})
+	t.Run("flag not enabled should not skip reserved nonces", func(t *testing.T) {
+		t.Parallel()
+
+		vmOutput, err := createNFT(createNFTCreate(false, config), 4)
+		require.Nil(t, err)
+		assert.Equal(t, [][]byte{{5}}, vmOutput.ReturnData)
+	})
+	t.Run("last nonce should be created", func(t *testing.T) {
+		t.Parallel()
+
+		vmOutput, err := createNFT(createNFTCreate(true, vmcommon.CollectionConfig{}), math.MaxUint64-1)
+		require.Nil(t, err)
+		assert.Equal(t, [][]byte{uint64ToBytes(math.MaxUint64)}, vmOutput.ReturnData)
+	})
+	t.Run("exhausted nonces should err", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := createNFT(createNFTCreate(true, vmcommon.CollectionConfig{}), math.MaxUint64)
+		assert.Equal(t, ErrNonceOverflow, err)
+
+		reservedUntilTheEnd := vmcommon.CollectionConfig{
+			ReservedNonceRanges: []vmcommon.NonceRange{{Start: math.MaxUint64 - 10, End: math.MaxUint64}},
+		}
+		_, err = createNFT(createNFTCreate(true, reservedUntilTheEnd), math.MaxUint64-11)
+		assert.Equal(t, ErrNonceOverflow, err)
+	})
+	t.Run("stored nonce not fitting an uint64 should err", func(t *testing.T) {
+		t.Parallel()
+
+		sender := mock.NewUserAccount([]byte("address"))
+		_ = sender.AccountDataHandler().SaveKeyValue(dctkeys.ComputeNonceKey(token), []byte{1, 0, 0, 0, 0, 0, 0, 0, 0})
+		_, err := getLatestNonce(sender, token)
+		assert.Equal(t, ErrNonceOverflow, err)
+	})
+}
+
+func TestDctNFTCreate_ProcessBuiltinFunctionContentHash(t *testing.T) {
+	t.Parallel()
+
+	token := []byte("token")
+	attributes := []byte("attributes")
+	hasher := keccak.NewKeccak()
+	createNFTCreate := func(config vmcommon.CollectionConfig) *dctNFTCreate {
+		dctDataStorage := createNewDCTDataStorageHandler()
+		nftCreate, _ := NewDCTNFTCreateFunc(ArgsNewDCTNFTCreate{
+			Config: Config{
+				Marshalizer: &mock.MarshalizerMock{},
+				Accounts:    dctDataStorage.accounts,
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{
+					GetCollectionConfigCalled: func(tokenID []byte) vmcommon.CollectionConfig {
+						return config
+					},
+				},
+				RolesHandler:        &mock.DCTRoleHandlerStub{},
+				DCTStorageHandler:   dctDataStorage,
+				EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+			},
+		})
+		return nftCreate
+	}
+	createNFT := func(nftCreate *dctNFTCreate, hash []byte) error {
+		sender := mock.NewUserAccount([]byte("address"))
+		vmInput := &vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallerAddr: sender.AddressBytes(),
+				CallValue:  big.NewInt(0),
+				Arguments:  [][]byte{token, {1}, []byte("name"), nil, hash, attributes, []byte("uri")},
+			},
+			RecipientAddr: sender.AddressBytes(),
+		}
+		_, err := nftCreate.ProcessBuiltinFunction(sender, nil, vmInput)
+		return err
+	}
+	contentHashRequired := vmcommon.CollectionConfig{ContentHashRequired: true}
+
+	t.Run("nil hasher should err", func(t *testing.T) {
+		t.Parallel()
+
+		err := createNFTCreate(contentHashRequired).SetHasher(nil)
+		assert.Equal(t, ErrNilHasher, err)
+	})
+	t.Run("collection not requiring the content hash should accept any hash", func(t *testing.T) {
+		t.Parallel()
+
+		err := createNFT(createNFTCreate(vmcommon.CollectionConfig{}), []byte("hash"))
+		assert.Nil(t, err)
+	})
+	t.Run("hasher not set should err", func(t *testing.T) {
+		t.Parallel()
+
+		err := createNFT(createNFTCreate(contentHashRequired), hasher.Compute(string(attributes)))
+		assert.Equal(t, ErrHasherNotSet, err)
+	})
+	t.Run("hash of other content should err", func(t *testing.T) {
+		t.Parallel()
+
+		nftCreate := createNFTCreate(contentHashRequired)
+		require.Nil(t, nftCreate.SetHasher(hasher))
+		err := createNFT(nftCreate, hasher.Compute("other attributes"))
+		assert.Equal(t, ErrInvalidContentHash, err)
+	})
+	t.Run("hash of the attributes should work", func(t *testing.T) {
+		t.Parallel()
+
+		nftCreate := createNFTCreate(contentHashRequired)
+		require.Nil(t, nftCreate.SetHasher(hasher))
+		err := createNFT(nftCreate, hasher.Compute(string(attributes)))
+		assert.Nil(t, err)
+	})
+}
+
+func TestDctNFTCreate_ProcessBuiltinFunctionRoyaltiesDenominator(t *testing.T) {
+	t.Parallel()
+
+	token := []byte("token")
+	createNFT := func(config vmcommon.CollectionConfig, royalties uint32) (*vmcommon.VMOutput, *dctDataStorage, error) {
+		dctDataStorage := createNewDCTDataStorageHandler()
+		nftCreate, _ := NewDCTNFTCreateFunc(ArgsNewDCTNFTCreate{
+			Config: Config{
+				Marshalizer: &mock.MarshalizerMock{},
+				Accounts:    dctDataStorage.accounts,
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{
+					GetCollectionConfigCalled: func(tokenID []byte) vmcommon.CollectionConfig {
+						return config
+					},
+				},
+				RolesHandler:        &mock.DCTRoleHandlerStub{},
+				DCTStorageHandler:   dctDataStorage,
+				EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+			},
+		})
+		sender := mock.NewUserAccount([]byte("address"))
+		vmInput := &vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallerAddr: sender.AddressBytes(),
+				CallValue:  big.NewInt(0),
+				Arguments:  [][]byte{token, {1}, []byte("name"), big.NewInt(int64(royalties)).Bytes(), []byte("hash"), []byte("attributes"), []byte("uri")},
+			},
+			RecipientAddr: sender.AddressBytes(),
+		}
+		vmOutput, err := nftCreate.ProcessBuiltinFunction(sender, nil, vmInput)
+		return vmOutput, dctDataStorage, err
+	}
+
+	t.Run("default denominator should not accept finer royalties", func(t *testing.T) {
+		t.Parallel()
+
+		vmOutput, _, err := createNFT(vmcommon.CollectionConfig{}, core.MaxRoyalty+1)
+		assert.Nil(t, vmOutput)
+		assert.ErrorIs(t, err, ErrInvalidArguments)
+
+		vmOutput, dctDataStorage, err := createNFT(vmcommon.CollectionConfig{}, core.MaxRoyalty)
+		require.Nil(t, err)
+		require.Len(t, vmOutput.Logs, 1)
+		assert.Len(t, vmOutput.Logs[0].Topics, 4)
+
+		dctTokenKey := append([]byte(baseDCTKeyPrefix), token...)
+		royaltiesDenominator, err := dctDataStorage.GetNFTRoyaltiesDenominator(dctTokenKey, 1)
+		require.Nil(t, err)
+		assert.Equal(t, vmcommon.DefaultRoyaltiesDenominator, royaltiesDenominator)
+		systemAcc, _ := dctDataStorage.loadSystemAccount()
+		val, _, _ := systemAcc.AccountDataHandler().RetrieveValue(computeNFTRoyaltiesDenominatorKey(token, 1))
+		assert.Empty(t, val, "the default denominator should not be recorded")
+	})
+	t.Run("collection denominator should bound the royalties and be logged", func(t *testing.T) {
+		t.Parallel()
+
+		config := vmcommon.CollectionConfig{RoyaltiesDenominator: 1000000}
+		vmOutput, _, err := createNFT(config, 1000001)
+		assert.Nil(t, vmOutput)
+		assert.ErrorIs(t, err, ErrInvalidArguments)
+
+		vmOutput, dctDataStorage, err := createNFT(config, 12345)
+		require.Nil(t, err)
+		require.Len(t, vmOutput.Logs, 1)
+		topics := vmOutput.Logs[0].Topics
+		require.Len(t, topics, 5)
+		assert.Equal(t, big.NewInt(1000000).Bytes(), topics[4])
+
+		dctTokenKey := append([]byte(baseDCTKeyPrefix), token...)
+		royaltiesDenominator, err := dctDataStorage.GetNFTRoyaltiesDenominator(dctTokenKey, 1)
+		require.Nil(t, err)
+		assert.Equal(t, uint32(1000000), royaltiesDenominator, "the token should keep the denominator it was created with")
+	})
+}
+
+func TestDctNFTCreate_ProcessBuiltinFunctionStorageUsageTracker(t *testing.T) {
+	t.Parallel()
+
+	dctDataStorage := createNewDCTDataStorageHandler()
+	nftCreate, _ := NewDCTNFTCreateFunc(ArgsNewDCTNFTCreate{
+		Config: Config{
+			Marshalizer:           &mock.MarshalizerMock{},
+			Accounts:              dctDataStorage.accounts,
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			DCTStorageHandler:     dctDataStorage,
+			EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+		},
+	})
+	require.Equal(t, ErrNilStorageUsageTracker, nftCreate.SetStorageUsageTracker(nil))
+	tracker := NewStorageUsageTracker(vmcommon.StorageUsageConfig{MaxBytesPerAccount: 50})
+	require.Nil(t, nftCreate.SetStorageUsageTracker(tracker))
+
+	sender := mock.NewUserAccount([]byte("address"))
+	createNFT := func(attributes []byte) error {
+		vmInput := &vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallerAddr: sender.AddressBytes(),
+				CallValue:  big.NewInt(0),
+				Arguments:  [][]byte{[]byte("token"), {1}, []byte("name"), nil, []byte("hash"), attributes, []byte("uri")},
+			},
+			RecipientAddr: sender.AddressBytes(),
+		}
+		_, err := nftCreate.ProcessBuiltinFunction(sender, nil, vmInput)
+		return err
+	}
+
+	err := createNFT([]byte("attributes"))
+	require.Nil(t, err)
+	assert.Equal(t, uint64(21), tracker.GetStorageUsage(sender))
+
+	err = createNFT(bytes.Repeat([]byte{1}, 30))
+	assert.ErrorIs(t, err, ErrStorageLimitExceeded)
+	assert.Equal(t, uint64(21), tracker.GetStorageUsage(sender))
+	latestNonce, _, _ := sender.AccountDataHandler().RetrieveValue(dctkeys.ComputeNonceKey([]byte("token")))
+	assert.Equal(t, []byte{1}, latestNonce, "the failed create should not be saved")
+}
+
+func TestDctNFTCreate_ProcessBuiltinFunctionMetaDCT(t *testing.T) {
+	t.Parallel()
+
+	token := []byte("META-abcdef")
+	createMetaDCT := func(flagEnabled bool, quantity []byte) (*dct.DCToken, error) {
+		dctDataStorage := createNewDCTDataStorageHandler()
+		nftCreate, _ := NewDCTNFTCreateFunc(ArgsNewDCTNFTCreate{
+			Config: Config{
+				Marshalizer: &mock.MarshalizerMock{},
+				Accounts:    dctDataStorage.accounts,
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{
+					GetCollectionConfigCalled: func(tokenID []byte) vmcommon.CollectionConfig {
+						return vmcommon.CollectionConfig{IsMetaDCT: true, NumDecimals: 18}
+					},
+				},
+				RolesHandler:      &mock.DCTRoleHandlerStub{},
+				DCTStorageHandler: dctDataStorage,
+				EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+					IsValueLengthCheckFlagEnabledField: true,
+					IsMetaDCTFlagEnabledField:          flagEnabled,
+				},
+			},
+		})
+		sender := mock.NewUserAccount([]byte("address"))
+		vmInput := &vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallerAddr: sender.AddressBytes(),
+				CallValue:  big.NewInt(0),
+				Arguments:  [][]byte{token, quantity, []byte("name"), nil, []byte("hash"), []byte("attributes"), []byte("uri")},
+			},
+			RecipientAddr: sender.AddressBytes(),
+		}
+		_, err := nftCreate.ProcessBuiltinFunction(sender, nil, vmInput)
+		if err != nil {
+			return nil, err
+		}
+
+		dctData, _ := readNFTData(t, sender, nftCreate.marshaller, token, 1, nil)
+		return dctData, nil
+	}
+	fungibleAmount := bytes.Repeat([]byte{1}, maxLenForAddNFTQuantity+1)
+
+	t.Run("meta dct amount should be created", func(t *testing.T) {
+		t.Parallel()
+
+		dctData, err := createMetaDCT(true, fungibleAmount)
+		require.Nil(t, err)
+		assert.Equal(t, uint32(vmcommon.MetaFungible), dctData.Type)
+		assert.Equal(t, big.NewInt(0).SetBytes(fungibleAmount), dctData.Value)
+	})
+	t.Run("flag not enabled should create a non fungible token", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := createMetaDCT(false, fungibleAmount)
+		assert.ErrorIs(t, err, ErrInvalidArguments)
+
+		dctData, err := createMetaDCT(false, []byte{1})
+		require.Nil(t, err)
+		assert.Equal(t, uint32(core.NonFungible), dctData.Type)
+	})
+	t.Run("amount above the fungible limit should err", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := createMetaDCT(true, bytes.Repeat([]byte{1}, core.MaxLenForDCTIssueMint+1))
+		assert.ErrorIs(t, err, ErrInvalidArguments)
+	})
+}
+
+func TestDctNFTCreate_ProcessBuiltinFunctionWithNotifyCall(t *testing.T) {
+	t.Parallel()
+
+	caller := bytes.Repeat([]byte{1}, 32)
+	registry := make([]byte, 32)
+	registry[31] = 1
+	createNFTCreate := func(flagEnabled bool, savedToken **dct.DCToken) *dctNFTCreate {
+		dctStorageHandler := &mock.DCTNFTStorageHandlerStub{
+			SaveDCTNFTTokenCalled: func(_ []byte, _ vmcommon.UserAccountHandler, _ []byte, _ uint64, dctData *dct.DCToken, _ bool, _ bool) ([]byte, error) {
+				*savedToken = dctData
+				return nil, nil
+			},
+		}
+		nftCreate, _ := NewDCTNFTCreateFunc(ArgsNewDCTNFTCreate{
+			Config: Config{
+				Marshalizer:           &mock.MarshalizerMock{},
+				Accounts:              &mock.AccountsStub{},
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+				DCTStorageHandler:     dctStorageHandler,
+				EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+					IsValueLengthCheckFlagEnabledField: true,
+					IsNFTCreateNotifyFlagEnabledField:  flagEnabled,
+				},
+			},
+			FuncGasCost: 10,
+		})
+		return nftCreate
+	}
+	createInput := func(notifyArguments ...[]byte) *vmcommon.ContractCallInput {
+		arguments := [][]byte{[]byte("token"), big.NewInt(2).Bytes(), []byte("name"), nil, []byte("hash"), []byte("attributes"), []byte("uri")}
+		arguments = append(arguments, notifyArguments...)
+		return &vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallerAddr:  caller,
+				CallValue:   big.NewInt(0),
+				Arguments:   arguments,
+				GasProvided: 100,
+			},
+			RecipientAddr: caller,
+		}
+	}
+	marker := []byte(vmcommon.DCTNFTCreateNotifyMarker)
+
+	t.Run("notify call should be added to the output", func(t *testing.T) {
+		t.Parallel()
+
+		var savedToken *dct.DCToken
+		nftCreate := createNFTCreate(true, &savedToken)
+		vmInput := createInput(marker, registry, []byte("onMint"), big.NewInt(50).Bytes(), []byte("extra"))
+		vmOutput, err := nftCreate.ProcessBuiltinFunction(mock.NewUserAccount(caller), nil, vmInput)
+		require.Nil(t, err)
+		assert.Equal(t, [][]byte{[]byte("uri")}, savedToken.TokenMetaData.URIs)
+		assert.Equal(t, uint64(40), vmOutput.GasRemaining)
+
+		outputAccount := vmOutput.OutputAccounts[string(registry)]
+		require.NotNil(t, outputAccount)
+		require.Equal(t, 1, len(outputAccount.OutputTransfers))
+		outTransfer := outputAccount.OutputTransfers[0]
+		assert.Equal(t, []byte("onMint@746f6b656e@01@02@6578747261"), outTransfer.Data)
+		assert.Equal(t, uint64(50), outTransfer.GasLimit)
+		assert.Equal(t, caller, outTransfer.SenderAddress)
+		assert.Equal(t, vm.DirectCall, outTransfer.CallType)
+	})
+	t.Run("not enough gas for the notify call should err", func(t *testing.T) {
+		t.Parallel()
+
+		var savedToken *dct.DCToken
+		nftCreate := createNFTCreate(true, &savedToken)
+		vmInput := createInput(marker, registry, []byte("onMint"), big.NewInt(91).Bytes())
+		_, err := nftCreate.ProcessBuiltinFunction(mock.NewUserAccount(caller), nil, vmInput)
+		assert.Equal(t, ErrNotEnoughGas, err)
+		assert.Nil(t, savedToken)
+	})
+	t.Run("invalid notify arguments should err", func(t *testing.T) {
+		t.Parallel()
+
+		var savedToken *dct.DCToken
+		nftCreate := createNFTCreate(true, &savedToken)
+		_, err := nftCreate.ProcessBuiltinFunction(mock.NewUserAccount(caller), nil, createInput(marker, registry, []byte("onMint")))
+		assert.ErrorIs(t, err, ErrInvalidArguments)
+
+		_, err = nftCreate.ProcessBuiltinFunction(mock.NewUserAccount(caller), nil, createInput(marker, caller, []byte("onMint"), []byte{1}))
+		assert.Equal(t, ErrInvalidRcvAddr, err)
+
+		_, err = nftCreate.ProcessBuiltinFunction(mock.NewUserAccount(caller), nil, createInput(marker, registry, nil, []byte{1}))
+		assert.ErrorIs(t, err, ErrInvalidArguments)
+
+		vmInput := createInput(marker, registry, []byte("onMint"), []byte{1})
+		vmInput.Arguments = append(vmInput.Arguments[:6], vmInput.Arguments[7:]...)
+		_, err = nftCreate.ProcessBuiltinFunction(mock.NewUserAccount(caller), nil, vmInput)
+		assert.ErrorIs(t, err, ErrInvalidArguments)
+		assert.Nil(t, savedToken)
+	})
+	t.Run("flag not enabled should keep the marker as URI", func(t *testing.T) {
+		t.Parallel()
+
+		var savedToken *dct.DCToken
+		nftCreate := createNFTCreate(false, &savedToken)
+		vmInput := createInput(marker, registry, []byte("onMint"), []byte{1})
+		vmOutput, err := nftCreate.ProcessBuiltinFunction(mock.NewUserAccount(caller), nil, vmInput)
+		require.Nil(t, err)
+		assert.Equal(t, vmInput.Arguments[6:], savedToken.TokenMetaData.URIs)
+		assert.Empty(t, vmOutput.OutputAccounts)
+	})
+}
+
+func TestDctNFTCreate_ProcessBuiltinFunctionOversizedRoyalties(t *testing.T) {
+	t.Parallel()
+
+	address := bytes.Repeat([]byte{1}, 32)
+	// would be truncated to a valid royalties value of 100
+	royalties := big.NewInt(0).Add(big.NewInt(0).Lsh(big.NewInt(1), 32), big.NewInt(100)).Bytes()
+	createInput := func() *vmcommon.ContractCallInput {
+		return &vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallerAddr:  address,
+				CallValue:   big.NewInt(0),
+				Arguments:   [][]byte{[]byte("token"), {1}, []byte("name"), royalties, []byte("hash"), []byte("attributes"), []byte("uri")},
+				GasProvided: 100,
+			},
+			RecipientAddr: address,
+		}
+	}
+
+	t.Run("flag not enabled should truncate the royalties", func(t *testing.T) {
+		t.Parallel()
+
+		nftCreate := createNftCreateWithStubArguments()
+		vmOutput, err := nftCreate.ProcessBuiltinFunction(mock.NewUserAccount(address), nil, createInput())
+		require.Nil(t, err)
+		assert.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	})
+	t.Run("flag enabled should error", func(t *testing.T) {
+		t.Parallel()
+
+		nftCreate := createNftCreateWithStubArguments()
+		nftCreate.enableEpochsHandler.(*mock.EnableEpochsHandlerStub).IsSafeArgumentConversionFlagEnabledField = true
+		vmOutput, err := nftCreate.ProcessBuiltinFunction(mock.NewUserAccount(address), nil, createInput())
+		assert.Nil(t, vmOutput)
+		assert.ErrorIs(t, err, ErrInvalidArguments)
+		assert.ErrorIs(t, err, vmcommon.ErrValueOutOfRange)
+	})
+}
@@ -11,17 +11,20 @@ import (
 	"github.com/Reshusk23/sr-me-core/core"
 	"github.com/Reshusk23/sr-me-core/core/check"
 	"github.com/Reshusk23/sr-me-core/data/dct"
-	"github.com/Reshusk23/sr-me-core/data/vm"
 	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/dctkeys"
 )
 
-const baseDCTKeyPrefix = core.ProtectedKeyPrefix + core.DCTKeyIdentifier
+const baseDCTKeyPrefix = dctkeys.DCTKeyPrefix
 
 var oneValue = big.NewInt(1)
 var zeroByteArray = []byte{0}
 
 type dctNFTTransfer struct {
 	baseAlwaysActiveHandler
+	baseAddressLengthHandler
+	freezeAccountChecker
+	transferInterceptorChecker
 	keyPrefix             []byte
 	marshaller            vmcommon.Marshalizer
 	globalSettingsHandler vmcommon.ExtendedDCTGlobalSettingsHandler
@@ -29,60 +32,45 @@ type dctNFTTransfer struct {
 	funcGasCost           uint64
 	accounts              vmcommon.AccountsAdapter
 	shardCoordinator      vmcommon.Coordinator
+	addressClassifier     vmcommon.AddressClassifier
+	aliasResolver         vmcommon.AliasResolver
 	gasConfig             vmcommon.BaseOperationCost
+	asyncCallbackCost     vmcommon.AsyncCallbackCost
 	mutExecution          sync.RWMutex
 	rolesHandler          vmcommon.DCTRoleHandler
 	dctStorageHandler     vmcommon.DCTNFTStorageHandler
 	enableEpochsHandler   vmcommon.EnableEpochsHandler
 }
 
+// ArgsNewDCTNFTTransfer defines the argument list for new dct NFT transfer built in function
+type ArgsNewDCTNFTTransfer struct {
+	Config
+	FuncGasCost uint64
+	GasConfig   vmcommon.BaseOperationCost
+}
+
 // NewDCTNFTTransferFunc returns the dct NFT transfer built-in function component
-func NewDCTNFTTransferFunc(
-	funcGasCost uint64,
-	marshaller vmcommon.Marshalizer,
-	globalSettingsHandler vmcommon.ExtendedDCTGlobalSettingsHandler,
-	accounts vmcommon.AccountsAdapter,
-	shardCoordinator vmcommon.Coordinator,
-	gasConfig vmcommon.BaseOperationCost,
-	rolesHandler vmcommon.DCTRoleHandler,
-	dctStorageHandler vmcommon.DCTNFTStorageHandler,
-	enableEpochsHandler vmcommon.EnableEpochsHandler,
-) (*dctNFTTransfer, error) {
-	if check.IfNil(marshaller) {
-		return nil, ErrNilMarshalizer
-	}
-	if check.IfNil(globalSettingsHandler) {
-		return nil, ErrNilGlobalSettingsHandler
-	}
-	if check.IfNil(accounts) {
-		return nil, ErrNilAccountsAdapter
-	}
-	if check.IfNil(shardCoordinator) {
-		return nil, ErrNilShardCoordinator
-	}
-	if check.IfNil(rolesHandler) {
-		return nil, ErrNilRolesHandler
-	}
-	if check.IfNil(enableEpochsHandler) {
-		return nil, ErrNilEnableEpochsHandler
-	}
-	if check.IfNil(dctStorageHandler) {
-		return nil, ErrNilDCTNFTStorageHandler
+func NewDCTNFTTransferFunc(args ArgsNewDCTNFTTransfer) (*dctNFTTransfer, error) {
+	err := args.checkComponents(core.BuiltInFunctionDCTNFTTransfer, requireMarshalizer|requireAccounts|requireShardCoordinator|
+		requireGlobalSettingsHandler|requireRolesHandler|requireDCTStorageHandler|requireEnableEpochsHandler)
+	if err != nil {
+		return nil, err
 	}
 
 	e := &dctNFTTransfer{
 		keyPrefix:             []byte(baseDCTKeyPrefix),
-		marshaller:            marshaller,
-		globalSettingsHandler: globalSettingsHandler,
-		funcGasCost:           funcGasCost,
-		accounts:              accounts,
-		shardCoordinator:      shardCoordinator,
-		gasConfig:             gasConfig,
+		marshaller:            args.Marshalizer,
+		globalSettingsHandler: args.GlobalSettingsHandler,
+		funcGasCost:           args.FuncGasCost,
+		accounts:              args.Accounts,
+		shardCoordinator:      args.ShardCoordinator,
+		gasConfig:             args.GasConfig,
 		mutExecution:          sync.RWMutex{},
 		payableHandler:        &disabledPayableHandler{},
-		rolesHandler:          rolesHandler,
-		enableEpochsHandler:   enableEpochsHandler,
-		dctStorageHandler:     dctStorageHandler,
+		addressClassifier:     vmcommon.NewDefaultAddressClassifier(),
+		rolesHandler:          args.RolesHandler,
+		enableEpochsHandler:   args.EnableEpochsHandler,
+		dctStorageHandler:     args.DCTStorageHandler,
 	}
 
 	return e, nil
@@ -98,6 +86,32 @@ func (e *dctNFTTransfer) SetPayableChecker(payableHandler vmcommon.PayableChecke
 	return nil
 }
 
+// SetAddressClassifier sets the classifier deciding whether the receiver of the transfer is a smart contract
+func (e *dctNFTTransfer) SetAddressClassifier(addressClassifier vmcommon.AddressClassifier) error {
+	if check.IfNil(addressClassifier) {
+		return ErrNilAddressClassifier
+	}
+
+	e.mutExecution.Lock()
+	e.addressClassifier = addressClassifier
+	e.mutExecution.Unlock()
+
+	return nil
+}
+
+// SetAliasResolver sets the resolver of the destinations referenced by alias
+func (e *dctNFTTransfer) SetAliasResolver(aliasResolver vmcommon.AliasResolver) error {
+	if check.IfNil(aliasResolver) {
+		return ErrNilAliasResolver
+	}
+
+	e.mutExecution.Lock()
+	e.aliasResolver = aliasResolver
+	e.mutExecution.Unlock()
+
+	return nil
+}
+
 // SetNewGasConfig is called whenever gas cost is changed
 func (e *dctNFTTransfer) SetNewGasConfig(gasCost *vmcommon.GasCost) {
 	if gasCost == nil {
@@ -107,9 +121,57 @@ func (e *dctNFTTransfer) SetNewGasConfig(gasCost *vmcommon.GasCost) {
 	e.mutExecution.Lock()
 	e.funcGasCost = gasCost.BuiltInCost.DCTNFTTransfer
 	e.gasConfig = gasCost.BaseOperationCost
+	e.asyncCallbackCost = gasCost.AsyncCallbackCost
 	e.mutExecution.Unlock()
 }
 
+// CheckIsExecutable validates the arguments, the destination and the gas of the DCT NFT transfer, without reading any
+// account or global setting. Only the calls made on the shard of the sender are validated
+func (e *dctNFTTransfer) CheckIsExecutable(vmInput *vmcommon.ContractCallInput) error {
+	e.mutExecution.RLock()
+	defer e.mutExecution.RUnlock()
+
+	err := checkBasicDCTArguments(vmInput)
+	if err != nil {
+		return err
+	}
+	if len(vmInput.Arguments) < 4 {
+		return ErrInvalidArguments
+	}
+	if !bytes.Equal(vmInput.CallerAddr, vmInput.RecipientAddr) {
+		return nil
+	}
+
+	dstAddress, err := checkAddressArgument(e.aliasResolver, vmInput.Arguments, 3, e.getAddressLength(vmInput))
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(dstAddress, vmInput.CallerAddr) {
+		return fmt.Errorf("%w, can not transfer to self", ErrInvalidArguments)
+	}
+	isInvalidTransferToMeta := len(dstAddress) > 0 && e.shardCoordinator.ComputeId(dstAddress) == core.MetachainShardId && !e.enableEpochsHandler.IsTransferToMetaFlagEnabled()
+	if isInvalidTransferToMeta {
+		return ErrInvalidRcvAddr
+	}
+	if vmInput.GasProvided < e.funcGasCost {
+		return ErrNotEnoughGas
+	}
+
+	nonce, err := flaggedUint64Argument(e.enableEpochsHandler, vmInput.Arguments, 1)
+	if err != nil {
+		return err
+	}
+	if nonce == 0 {
+		return ErrNFTDoesNotHaveMetadata
+	}
+	quantityToTransfer := big.NewInt(0).SetBytes(vmInput.Arguments[2])
+	if e.enableEpochsHandler.IsCheckTransferFlagEnabled() && quantityToTransfer.Cmp(zero) <= 0 {
+		return ErrInvalidNFTQuantity
+	}
+
+	return nil
+}
+
 // ProcessBuiltinFunction resolves DCT NFT transfer roles function call
 // Requires 4 arguments:
 // arg0 - token identifier
@@ -133,6 +195,11 @@ func (e *dctNFTTransfer) ProcessBuiltinFunction(
 	}
 
 	if bytes.Equal(vmInput.CallerAddr, vmInput.RecipientAddr) {
+		err = e.checkAccountIsNotFrozen(vmInput.CallerAddr, vmInput.ReturnCallAfterError)
+		if err != nil {
+			return nil, err
+		}
+
 		return e.processNFTTransferOnSenderShard(acntSnd, vmInput)
 	}
 
@@ -146,7 +213,10 @@ func (e *dctNFTTransfer) ProcessBuiltinFunction(
 
 	tickerID := vmInput.Arguments[0]
 	dctTokenKey := append(e.keyPrefix, tickerID...)
-	nonce := big.NewInt(0).SetBytes(vmInput.Arguments[1]).Uint64()
+	nonce, err := flaggedUint64Argument(e.enableEpochsHandler, vmInput.Arguments, 1)
+	if err != nil {
+		return nil, err
+	}
 	value := big.NewInt(0).SetBytes(vmInput.Arguments[2])
 
 	dctTransferData := &dct.DCToken{}
@@ -158,7 +228,7 @@ func (e *dctNFTTransfer) ProcessBuiltinFunction(
 		}
 	} else {
 		dctTransferData.Value = big.NewInt(0).Set(value)
-		dctTransferData.Type = uint32(core.NonFungible)
+		dctTransferData.Type = getNFTTokenType(e.globalSettingsHandler, e.enableEpochsHandler, tickerID)
 	}
 
 	err = e.payableHandler.CheckPayable(vmInput, vmInput.RecipientAddr, core.MinLenArgumentsDCTNFTTransfer)
@@ -172,19 +242,25 @@ func (e *dctNFTTransfer) ProcessBuiltinFunction(
 
 	// no need to consume gas on destination - sender already paid for it
 	vmOutput := &vmcommon.VMOutput{GasRemaining: vmInput.GasProvided}
-	if len(vmInput.Arguments) > core.MinLenArgumentsDCTNFTTransfer && vmcommon.IsSmartContractAddress(vmInput.RecipientAddr) {
+	if len(vmInput.Arguments) > core.MinLenArgumentsDCTNFTTransfer && e.addressClassifier.IsSmartContract(vmInput.RecipientAddr) {
 		var callArgs [][]byte
 		if len(vmInput.Arguments) > core.MinLenArgumentsDCTNFTTransfer+1 {
 			callArgs = vmInput.Arguments[core.MinLenArgumentsDCTNFTTransfer+1:]
 		}
 
-		addOutputTransferToVMOutput(
-			vmInput.CallerAddr,
+		dctTransfers := []*vmcommon.DCTTransfer{{
+			DCTValue:      value,
+			DCTTokenName:  tickerID,
+			DCTTokenType:  dctTransferData.Type,
+			DCTTokenNonce: nonce,
+		}}
+		addSCCallAfterTransferToVMOutput(
+			vmInput,
 			string(vmInput.Arguments[core.MinLenArgumentsDCTNFTTransfer]),
 			callArgs,
 			vmInput.RecipientAddr,
+			dctTransfers,
 			vmInput.GasLocked,
-			vmInput.CallType,
 			vmOutput)
 	}
 
@@ -197,9 +273,9 @@ func (e *dctNFTTransfer) processNFTTransferOnSenderShard(
 	acntSnd vmcommon.UserAccountHandler,
 	vmInput *vmcommon.ContractCallInput,
 ) (*vmcommon.VMOutput, error) {
-	dstAddress := vmInput.Arguments[3]
-	if len(dstAddress) != len(vmInput.CallerAddr) {
-		return nil, fmt.Errorf("%w, not a valid destination address", ErrInvalidArguments)
+	dstAddress, err := resolveAddressArgument(e.aliasResolver, vmInput.Arguments, 3, e.getAddressLength(vmInput))
+	if err != nil {
+		return nil, err
 	}
 	if bytes.Equal(dstAddress, vmInput.CallerAddr) {
 		return nil, fmt.Errorf("%w, can not transfer to self", ErrInvalidArguments)
@@ -215,7 +291,10 @@ func (e *dctNFTTransfer) processNFTTransferOnSenderShard(
 
 	tickerID := vmInput.Arguments[0]
 	dctTokenKey := append(e.keyPrefix, tickerID...)
-	nonce := big.NewInt(0).SetBytes(vmInput.Arguments[1]).Uint64()
+	nonce, err := flaggedUint64Argument(e.enableEpochsHandler, vmInput.Arguments, 1)
+	if err != nil {
+		return nil, err
+	}
 	dctData, err := e.dctStorageHandler.GetDCTNFTTokenOnSender(acntSnd, dctTokenKey, nonce)
 	if err != nil {
 		return nil, err
@@ -223,6 +302,14 @@ func (e *dctNFTTransfer) processNFTTransferOnSenderShard(
 	if nonce == 0 {
 		return nil, ErrNFTDoesNotHaveMetadata
 	}
+	err = checkNFTIsNotRented(dctData, vmInput.ReturnCallAfterError)
+	if err != nil {
+		return nil, err
+	}
+	err = checkMetaDCTValueLength(dctData.Type, vmInput.Arguments[2])
+	if err != nil {
+		return nil, err
+	}
 
 	quantityToTransfer := big.NewInt(0).SetBytes(vmInput.Arguments[2])
 	if dctData.Value.Cmp(quantityToTransfer) < 0 {
@@ -232,6 +319,10 @@ func (e *dctNFTTransfer) processNFTTransferOnSenderShard(
 	if isCheckTransferFlagEnabled && quantityToTransfer.Cmp(zero) <= 0 {
 		return nil, ErrInvalidNFTQuantity
 	}
+	err = e.checkTransferIsAllowed(acntSnd.AddressBytes(), dstAddress, tickerID, nonce, quantityToTransfer, vmInput.ReturnCallAfterError)
+	if err != nil {
+		return nil, err
+	}
 	dctData.Value.Sub(dctData.Value, quantityToTransfer)
 
 	_, err = e.dctStorageHandler.SaveDCTNFTToken(acntSnd.AddressBytes(), acntSnd, dctTokenKey, nonce, dctData, false, vmInput.ReturnCallAfterError)
@@ -284,10 +375,16 @@ func (e *dctNFTTransfer) processNFTTransferOnSenderShard(
 		return nil, err
 	}
 
+	err = checkIfTransferCanHappenWithSoulbound(dctTokenKey, e.globalSettingsHandler, acntSnd, vmInput.ReturnCallAfterError)
+	if err != nil {
+		return nil, err
+	}
+
 	vmOutput := &vmcommon.VMOutput{
 		ReturnCode:   vmcommon.Ok,
 		GasRemaining: vmInput.GasProvided - e.funcGasCost,
 	}
+	addGasBreakdownToVMOutput(vmInput, vmOutput, vmcommon.GasBreakdown{TransferCost: e.funcGasCost})
 	err = e.createNFTOutputTransfers(vmInput, vmOutput, dctData, dstAddress, tickerID, nonce)
 	if err != nil {
 		return nil, err
@@ -325,6 +422,7 @@ func (e *dctNFTTransfer) createNFTOutputTransfers(
 			return ErrNotEnoughGas
 		}
 		vmOutput.GasRemaining -= gasForTransfer
+		addGasBreakdownToVMOutput(vmInput, vmOutput, vmcommon.GasBreakdown{PerByteCost: gasForTransfer})
 		nftTransferCallArgs = append(nftTransferCallArgs, marshaledNFTTransfer)
 	} else {
 		nftTransferCallArgs = append(nftTransferCallArgs, zeroByteArray)
@@ -338,18 +436,21 @@ func (e *dctNFTTransfer) createNFTOutputTransfers(
 
 	if e.shardCoordinator.SelfId() != e.shardCoordinator.ComputeId(dstAddress) {
 		gasToTransfer := uint64(0)
+		gasLocked := vmInput.GasLocked
 		if isSCCallAfter {
-			gasToTransfer = vmOutput.GasRemaining
+			gasToTransfer, gasLocked, err = lockGasForCallback(vmInput, vmOutput.GasRemaining, e.asyncCallbackCost)
+			if err != nil {
+				return err
+			}
 			vmOutput.GasRemaining = 0
 		}
 		addNFTTransferToVMOutput(
-			vmInput.CallerAddr,
+			vmInput,
 			dstAddress,
 			core.BuiltInFunctionDCTNFTTransfer,
 			nftTransferCallArgs,
-			vmInput.GasLocked,
 			gasToTransfer,
-			vmInput.CallType,
+			gasLocked,
 			vmOutput,
 		)
 
@@ -362,13 +463,24 @@ func (e *dctNFTTransfer) createNFTOutputTransfers(
 			callArgs = vmInput.Arguments[core.MinLenArgumentsDCTNFTTransfer+1:]
 		}
 
-		addOutputTransferToVMOutput(
-			vmInput.CallerAddr,
+		dctTransfers := []*vmcommon.DCTTransfer{{
+			DCTValue:      big.NewInt(0).SetBytes(vmInput.Arguments[2]),
+			DCTTokenName:  tickerID,
+			DCTTokenType:  dctTransferData.Type,
+			DCTTokenNonce: nonce,
+		}}
+		var gasLocked uint64
+		vmOutput.GasRemaining, gasLocked, err = lockGasForCallback(vmInput, vmOutput.GasRemaining, e.asyncCallbackCost)
+		if err != nil {
+			return err
+		}
+		addSCCallAfterTransferToVMOutput(
+			vmInput,
 			string(vmInput.Arguments[core.MinLenArgumentsDCTNFTTransfer]),
 			callArgs,
 			dstAddress,
-			vmInput.GasLocked,
-			vmInput.CallType,
+			dctTransfers,
+			gasLocked,
 			vmOutput)
 	}
 
@@ -388,7 +500,7 @@ func (e *dctNFTTransfer) addNFTToDestination(
 	if err != nil && !errors.Is(err, ErrNFTTokenDoesNotExist) {
 		return err
 	}
-	err = checkFrozeAndPause(dstAddress, dctTokenKey, currentDCTData, e.globalSettingsHandler, isReturnWithError)
+	err = checkFrozeAndPause(dstAddress, dctTokenKey, currentDCTData, e.globalSettingsHandler, e.getSystemAddresses(), isReturnWithError)
 	if err != nil {
 		return err
 	}
@@ -412,13 +524,12 @@ func (e *dctNFTTransfer) addNFTToDestination(
 }
 
 func addNFTTransferToVMOutput(
-	senderAddress []byte,
+	vmInput *vmcommon.ContractCallInput,
 	recipient []byte,
 	funcToCall string,
 	arguments [][]byte,
-	gasLocked uint64,
 	gasLimit uint64,
-	callType vm.CallType,
+	gasLocked uint64,
 	vmOutput *vmcommon.VMOutput,
 ) {
 	nftTransferTxData := funcToCall
@@ -430,14 +541,36 @@ func addNFTTransferToVMOutput(
 		GasLimit:      gasLimit,
 		GasLocked:     gasLocked,
 		Data:          []byte(nftTransferTxData),
-		CallType:      callType,
-		SenderAddress: senderAddress,
+		CallType:      vmInput.CallType,
+		SenderAddress: vmInput.CallerAddr,
 	}
 	vmOutput.OutputAccounts = make(map[string]*vmcommon.OutputAccount)
 	vmOutput.OutputAccounts[string(recipient)] = &vmcommon.OutputAccount{
 		Address:         recipient,
 		OutputTransfers: []vmcommon.OutputTransfer{outTransfer},
 	}
+
+	addAsyncMetadataToVMOutput(vmInput.CurrentTxHash, recipient, outTransfer, vmOutput)
+}
+
+// computeNFTTransferData returns the data of the DCTNFTTransfer call which adds the provided token on the destination shard
+func computeNFTTransferData(tickerID []byte, nonce uint64, quantity *big.Int, marshalledToken []byte) []byte {
+	return []byte(core.BuiltInFunctionDCTNFTTransfer + "@" + hex.EncodeToString(tickerID) + "@" +
+		hex.EncodeToString(big.NewInt(0).SetUint64(nonce).Bytes()) + "@" + hex.EncodeToString(quantity.Bytes()) + "@" +
+		hex.EncodeToString(marshalledToken))
+}
+
+func checkNFTIsNotRented(dctData *dct.DCToken, isReturnWithError bool) error {
+	if isReturnWithError {
+		return nil
+	}
+
+	dctUserMetadata := DCTUserMetadataFromBytes(dctData.Properties)
+	if dctUserMetadata.IsRented() {
+		return ErrRentedNFTNotTransferable
+	}
+
+	return nil
 }
 
 // IsInterfaceNil returns true if underlying object in nil
@@ -3,6 +3,7 @@ package builtInFunctions
 import (
 	"bytes"
 	"encoding/hex"
+	"errors"
 	"math/big"
 	"strings"
 	"testing"
@@ -11,6 +12,7 @@ import (
 	"github.com/Reshusk23/sr-me-core/core/check"
 	"github.com/Reshusk23/sr-me-core/data/dct"
 	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/dctkeys"
 	"github.com/Reshusk23/sr-vm-common-go/mock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -19,21 +21,21 @@ import (
 var keyPrefix = []byte(baseDCTKeyPrefix)
 
 func createNftTransferWithStubArguments() *dctNFTTransfer {
-	nftTransfer, _ := NewDCTNFTTransferFunc(
-		0,
-		&mock.MarshalizerMock{},
-		&mock.GlobalSettingsHandlerStub{},
-		&mock.AccountsStub{},
-		&mock.ShardCoordinatorStub{},
-		vmcommon.BaseOperationCost{},
-		&mock.DCTRoleHandlerStub{},
-		createNewDCTDataStorageHandler(),
-		&mock.EnableEpochsHandlerStub{
-			IsTransferToMetaFlagEnabledField:                     false,
-			IsSaveToSystemAccountFlagEnabledField:                true,
-			IsCheckCorrectTokenIDForTransferRoleFlagEnabledField: true,
+	nftTransfer, _ := NewDCTNFTTransferFunc(ArgsNewDCTNFTTransfer{
+		Config: Config{
+			Marshalizer:           &mock.MarshalizerMock{},
+			Accounts:              &mock.AccountsStub{},
+			ShardCoordinator:      &mock.ShardCoordinatorStub{},
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			DCTStorageHandler:     createNewDCTDataStorageHandler(),
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+				IsTransferToMetaFlagEnabledField:                     false,
+				IsSaveToSystemAccountFlagEnabledField:                true,
+				IsCheckCorrectTokenIDForTransferRoleFlagEnabledField: true,
+			},
 		},
-	)
+	})
 
 	return nftTransfer
 }
@@ -65,24 +67,25 @@ func createNFTTransferAndStorageHandler(selfShard, numShards uint32, globalSetti
 	}
 
 	dctStorageHandler := createNewDCTDataStorageHandlerWithArgs(globalSettingsHandler, accounts, enableEpochsHandler)
-	nftTransfer, _ := NewDCTNFTTransferFunc(
-		1,
-		marshaller,
-		globalSettingsHandler,
-		accounts,
-		shardCoordinator,
-		vmcommon.BaseOperationCost{},
-		&mock.DCTRoleHandlerStub{
-			CheckAllowedToExecuteCalled: func(account vmcommon.UserAccountHandler, tokenID []byte, action []byte) error {
-				if bytes.Equal(action, []byte(core.DCTRoleTransfer)) {
-					return ErrActionNotAllowed
-				}
-				return nil
+	nftTransfer, _ := NewDCTNFTTransferFunc(ArgsNewDCTNFTTransfer{
+		Config: Config{
+			Marshalizer:           marshaller,
+			Accounts:              accounts,
+			ShardCoordinator:      shardCoordinator,
+			GlobalSettingsHandler: globalSettingsHandler,
+			RolesHandler: &mock.DCTRoleHandlerStub{
+				CheckAllowedToExecuteCalled: func(account vmcommon.UserAccountHandler, tokenID []byte, action []byte) error {
+					if bytes.Equal(action, []byte(core.DCTRoleTransfer)) {
+						return ErrActionNotAllowed
+					}
+					return nil
+				},
 			},
+			DCTStorageHandler:   dctStorageHandler,
+			EnableEpochsHandler: enableEpochsHandler,
 		},
-		dctStorageHandler,
-		enableEpochsHandler,
-	)
+		FuncGasCost: 1,
+	})
 
 	return nftTransfer, dctStorageHandler
 }
@@ -136,7 +139,7 @@ func createDCTNFTToken(
 	account vmcommon.UserAccountHandler,
 ) {
 	tokenId := append(keyPrefix, tokenName...)
-	dctNFTTokenKey := computeDCTNFTTokenKey(tokenId, nonce)
+	dctNFTTokenKey := dctkeys.ComputeDCTNFTTokenKey(tokenId, nonce)
 	dctData := &dct.DCToken{
 		Type:  uint32(nftType),
 		Value: value,
@@ -164,7 +167,7 @@ func testNFTTokenShouldExist(
 	expectedValue *big.Int,
 ) {
 	tokenId := append(keyPrefix, tokenName...)
-	dctNFTTokenKey := computeDCTNFTTokenKey(tokenId, nonce)
+	dctNFTTokenKey := dctkeys.ComputeDCTNFTTokenKey(tokenId, nonce)
 	dctData := &dct.DCToken{Value: big.NewInt(0), Type: uint32(core.Fungible)}
 	marshaledData, _, _ := account.(vmcommon.UserAccountHandler).AccountDataHandler().RetrieveValue(dctNFTTokenKey)
 	_ = marshaller.Unmarshal(dctData, marshaledData)
@@ -177,136 +180,129 @@ func TestNewDCTNFTTransferFunc(t *testing.T) {
 	t.Run("nil marshaller should error", func(t *testing.T) {
 		t.Parallel()
 
-		nftTransfer, err := NewDCTNFTTransferFunc(
-			0,
-			nil,
-			&mock.GlobalSettingsHandlerStub{},
-			&mock.AccountsStub{},
-			&mock.ShardCoordinatorStub{},
-			vmcommon.BaseOperationCost{},
-			&mock.DCTRoleHandlerStub{},
-			createNewDCTDataStorageHandler(),
-			&mock.EnableEpochsHandlerStub{},
-		)
+		nftTransfer, err := NewDCTNFTTransferFunc(ArgsNewDCTNFTTransfer{
+			Config: Config{
+				Accounts:              &mock.AccountsStub{},
+				ShardCoordinator:      &mock.ShardCoordinatorStub{},
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+				DCTStorageHandler:     createNewDCTDataStorageHandler(),
+				EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			},
+		})
 		assert.True(t, check.IfNil(nftTransfer))
-		assert.Equal(t, ErrNilMarshalizer, err)
+		assert.ErrorIs(t, err, ErrNilMarshalizer)
 	})
 	t.Run("nil global settings handler should error", func(t *testing.T) {
 		t.Parallel()
 
-		nftTransfer, err := NewDCTNFTTransferFunc(
-			0,
-			&mock.MarshalizerMock{},
-			nil,
-			&mock.AccountsStub{},
-			&mock.ShardCoordinatorStub{},
-			vmcommon.BaseOperationCost{},
-			&mock.DCTRoleHandlerStub{},
-			createNewDCTDataStorageHandler(),
-			&mock.EnableEpochsHandlerStub{},
-		)
+		nftTransfer, err := NewDCTNFTTransferFunc(ArgsNewDCTNFTTransfer{
+			Config: Config{
+				Marshalizer:         &mock.MarshalizerMock{},
+				Accounts:            &mock.AccountsStub{},
+				ShardCoordinator:    &mock.ShardCoordinatorStub{},
+				RolesHandler:        &mock.DCTRoleHandlerStub{},
+				DCTStorageHandler:   createNewDCTDataStorageHandler(),
+				EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+			},
+		})
 		assert.True(t, check.IfNil(nftTransfer))
-		assert.Equal(t, ErrNilGlobalSettingsHandler, err)
+		assert.ErrorIs(t, err, ErrNilGlobalSettingsHandler)
 	})
 	t.Run("nil accounts adapter should error", func(t *testing.T) {
 		t.Parallel()
 
-		nftTransfer, err := NewDCTNFTTransferFunc(
-			0,
-			&mock.MarshalizerMock{},
-			&mock.GlobalSettingsHandlerStub{},
-			nil,
-			&mock.ShardCoordinatorStub{},
-			vmcommon.BaseOperationCost{},
-			&mock.DCTRoleHandlerStub{},
-			createNewDCTDataStorageHandler(),
-			&mock.EnableEpochsHandlerStub{},
-		)
+		nftTransfer, err := NewDCTNFTTransferFunc(ArgsNewDCTNFTTransfer{
+			Config: Config{
+				Marshalizer:           &mock.MarshalizerMock{},
+				ShardCoordinator:      &mock.ShardCoordinatorStub{},
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+				DCTStorageHandler:     createNewDCTDataStorageHandler(),
+				EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			},
+		})
 		assert.True(t, check.IfNil(nftTransfer))
-		assert.Equal(t, ErrNilAccountsAdapter, err)
+		assert.ErrorIs(t, err, ErrNilAccountsAdapter)
 	})
 	t.Run("nil shard coordinator should error", func(t *testing.T) {
 		t.Parallel()
 
-		nftTransfer, err := NewDCTNFTTransferFunc(
-			0,
-			&mock.MarshalizerMock{},
-			&mock.GlobalSettingsHandlerStub{},
-			&mock.AccountsStub{},
-			nil,
-			vmcommon.BaseOperationCost{},
-			&mock.DCTRoleHandlerStub{},
-			createNewDCTDataStorageHandler(),
-			&mock.EnableEpochsHandlerStub{},
-		)
+		nftTransfer, err := NewDCTNFTTransferFunc(ArgsNewDCTNFTTransfer{
+			Config: Config{
+				Marshalizer:           &mock.MarshalizerMock{},
+				Accounts:              &mock.AccountsStub{},
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+				DCTStorageHandler:     createNewDCTDataStorageHandler(),
+				EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			},
+		})
 		assert.True(t, check.IfNil(nftTransfer))
-		assert.Equal(t, ErrNilShardCoordinator, err)
+		assert.ErrorIs(t, err, ErrNilShardCoordinator)
 	})
 	t.Run("nil roles handler should error", func(t *testing.T) {
 		t.Parallel()
 
-		nftTransfer, err := NewDCTNFTTransferFunc(
-			0,
-			&mock.MarshalizerMock{},
-			&mock.GlobalSettingsHandlerStub{},
-			&mock.AccountsStub{},
-			&mock.ShardCoordinatorStub{},
-			vmcommon.BaseOperationCost{},
-			nil,
-			createNewDCTDataStorageHandler(),
-			&mock.EnableEpochsHandlerStub{},
-		)
+		nftTransfer, err := NewDCTNFTTransferFunc(ArgsNewDCTNFTTransfer{
+			Config: Config{
+				Marshalizer:           &mock.MarshalizerMock{},
+				Accounts:              &mock.AccountsStub{},
+				ShardCoordinator:      &mock.ShardCoordinatorStub{},
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				DCTStorageHandler:     createNewDCTDataStorageHandler(),
+				EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			},
+		})
 		assert.True(t, check.IfNil(nftTransfer))
-		assert.Equal(t, ErrNilRolesHandler, err)
+		assert.ErrorIs(t, err, ErrNilRolesHandler)
 	})
 	t.Run("nil dct storage handler should error", func(t *testing.T) {
 		t.Parallel()
 
-		nftTransfer, err := NewDCTNFTTransferFunc(
-			0,
-			&mock.MarshalizerMock{},
-			&mock.GlobalSettingsHandlerStub{},
-			&mock.AccountsStub{},
-			&mock.ShardCoordinatorStub{},
-			vmcommon.BaseOperationCost{},
-			&mock.DCTRoleHandlerStub{},
-			nil,
-			&mock.EnableEpochsHandlerStub{},
-		)
+		nftTransfer, err := NewDCTNFTTransferFunc(ArgsNewDCTNFTTransfer{
+			Config: Config{
+				Marshalizer:           &mock.MarshalizerMock{},
+				Accounts:              &mock.AccountsStub{},
+				ShardCoordinator:      &mock.ShardCoordinatorStub{},
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+				EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			},
+		})
 		assert.True(t, check.IfNil(nftTransfer))
-		assert.Equal(t, ErrNilDCTNFTStorageHandler, err)
+		assert.ErrorIs(t, err, ErrNilDCTNFTStorageHandler)
 	})
 	t.Run("nil enable epochs handler should error", func(t *testing.T) {
 		t.Parallel()
 
-		nftTransfer, err := NewDCTNFTTransferFunc(
-			0,
-			&mock.MarshalizerMock{},
-			&mock.GlobalSettingsHandlerStub{},
-			&mock.AccountsStub{},
-			&mock.ShardCoordinatorStub{},
-			vmcommon.BaseOperationCost{},
-			&mock.DCTRoleHandlerStub{},
-			createNewDCTDataStorageHandler(),
-			nil,
-		)
+		nftTransfer, err := NewDCTNFTTransferFunc(ArgsNewDCTNFTTransfer{
+			Config: Config{
+				Marshalizer:           &mock.MarshalizerMock{},
+				Accounts:              &mock.AccountsStub{},
+				ShardCoordinator:      &mock.ShardCoordinatorStub{},
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+				DCTStorageHandler:     createNewDCTDataStorageHandler(),
+			},
+		})
 		assert.True(t, check.IfNil(nftTransfer))
-		assert.Equal(t, ErrNilEnableEpochsHandler, err)
+		assert.ErrorIs(t, err, ErrNilEnableEpochsHandler)
 	})
 	t.Run("should work", func(t *testing.T) {
 		t.Parallel()
 
-		nftTransfer, err := NewDCTNFTTransferFunc(
-			0,
-			&mock.MarshalizerMock{},
-			&mock.GlobalSettingsHandlerStub{},
-			&mock.AccountsStub{},
-			&mock.ShardCoordinatorStub{},
-			vmcommon.BaseOperationCost{},
-			&mock.DCTRoleHandlerStub{},
-			createNewDCTDataStorageHandler(),
-			&mock.EnableEpochsHandlerStub{},
-		)
+		nftTransfer, err := NewDCTNFTTransferFunc(ArgsNewDCTNFTTransfer{
+			Config: Config{
+				Marshalizer:           &mock.MarshalizerMock{},
+				Accounts:              &mock.AccountsStub{},
+				ShardCoordinator:      &mock.ShardCoordinatorStub{},
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+				DCTStorageHandler:     createNewDCTDataStorageHandler(),
+				EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			},
+		})
 		assert.False(t, check.IfNil(nftTransfer))
 		assert.Nil(t, err)
 	})
@@ -377,6 +373,83 @@ func TestDctNFTTransfer_ProcessBuiltinFunctionInvalidArgumentsShouldErr(t *testi
 	assert.Equal(t, ErrInvalidRcvAddr, err)
 }
 
+func TestDctNFTTransfer_CheckIsExecutable(t *testing.T) {
+	t.Parallel()
+
+	nftTransfer := createNftTransferWithStubArguments()
+	nftTransfer.funcGasCost = 10
+	err := nftTransfer.CheckIsExecutable(nil)
+	assert.Equal(t, ErrNilVmInput, err)
+
+	tokenName := []byte("token")
+	senderAddress := bytes.Repeat([]byte{2}, 32)
+	destinationAddress := bytes.Repeat([]byte{1}, 32)
+	vmInput := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallValue:   big.NewInt(0),
+			CallerAddr:  senderAddress,
+			Arguments:   [][]byte{tokenName, big.NewInt(1).Bytes(), big.NewInt(1).Bytes()},
+			GasProvided: 10,
+		},
+		RecipientAddr: senderAddress,
+	}
+	err = nftTransfer.CheckIsExecutable(vmInput)
+	assert.Equal(t, ErrInvalidArguments, err)
+
+	vmInput.Arguments = append(vmInput.Arguments, senderAddress)
+	err = nftTransfer.CheckIsExecutable(vmInput)
+	assert.ErrorIs(t, err, ErrInvalidArguments)
+
+	vmInput.Arguments[3] = destinationAddress
+	vmInput.GasProvided = 9
+	err = nftTransfer.CheckIsExecutable(vmInput)
+	assert.Equal(t, ErrNotEnoughGas, err)
+
+	vmInput.GasProvided = 10
+	vmInput.Arguments[1] = big.NewInt(0).Bytes()
+	err = nftTransfer.CheckIsExecutable(vmInput)
+	assert.Equal(t, ErrNFTDoesNotHaveMetadata, err)
+
+	vmInput.Arguments[1] = big.NewInt(1).Bytes()
+	err = nftTransfer.CheckIsExecutable(vmInput)
+	assert.Nil(t, err)
+
+	nftTransfer.shardCoordinator = &mock.ShardCoordinatorStub{ComputeIdCalled: func(address []byte) uint32 {
+		return core.MetachainShardId
+	}}
+	err = nftTransfer.CheckIsExecutable(vmInput)
+	assert.Equal(t, ErrInvalidRcvAddr, err)
+
+	// the destination shard is not validated, the sender already paid for the transfer
+	vmInput.RecipientAddr = destinationAddress
+	vmInput.GasProvided = 0
+	err = nftTransfer.CheckIsExecutable(vmInput)
+	assert.Nil(t, err)
+}
+
+func TestDctNFTTransfer_WithValueShouldErr(t *testing.T) {
+	t.Parallel()
+
+	nftTransfer := createNftTransferWithStubArguments()
+	// only DCTTransfer accepts native value
+	nftTransfer.enableEpochsHandler.(*mock.EnableEpochsHandlerStub).IsDCTTransferWithValueFlagEnabledField = true
+
+	senderAddress := bytes.Repeat([]byte{2}, 32)
+	vmInput := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallValue:   big.NewInt(1),
+			CallerAddr:  senderAddress,
+			Arguments:   [][]byte{[]byte("token"), big.NewInt(1).Bytes(), big.NewInt(1).Bytes(), bytes.Repeat([]byte{1}, 32)},
+			GasProvided: 10,
+		},
+		RecipientAddr: senderAddress,
+	}
+	vmOutput, err := nftTransfer.ProcessBuiltinFunction(&mock.UserAccountStub{}, &mock.UserAccountStub{}, vmInput)
+	assert.Nil(t, vmOutput)
+	assert.Equal(t, ErrBuiltInFunctionCalledWithValue, err)
+	assert.Equal(t, ErrBuiltInFunctionCalledWithValue, nftTransfer.CheckIsExecutable(vmInput))
+}
+
 func TestDctNFTTransfer_SenderDoesNotHaveNFT(t *testing.T) {
 	t.Parallel()
 
@@ -760,7 +833,7 @@ func TestDCTNFTTransfer_SndDstFrozen(t *testing.T) {
 
 	destination, _ := transferFunc.accounts.LoadAccount(destinationAddress)
 	tokenId := append(keyPrefix, tokenName...)
-	dctKey := computeDCTNFTTokenKey(tokenId, tokenNonce)
+	dctKey := dctkeys.ComputeDCTNFTTokenKey(tokenId, tokenNonce)
 	dctToken := &dct.DCToken{Value: big.NewInt(0), Properties: dctFrozen.ToBytes()}
 	marshaledData, _ := transferFunc.marshaller.Marshal(dctToken)
 	_ = destination.(vmcommon.UserAccountHandler).AccountDataHandler().SaveKeyValue(dctKey, marshaledData)
@@ -824,6 +897,104 @@ func TestDCTNFTTransfer_WithLimitedTransfer(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestDCTNFTTransfer_SoulboundShouldErr(t *testing.T) {
+	t.Parallel()
+
+	globalSettings := &mock.GlobalSettingsHandlerStub{}
+	transferFunc := createNftTransferWithMockArguments(0, 1, globalSettings)
+	_ = transferFunc.SetPayableChecker(&mock.PayableHandlerStub{})
+
+	senderAddress := bytes.Repeat([]byte{2}, 32) // sender is in the same shard
+	destinationAddress := bytes.Repeat([]byte{1}, 32)
+	destinationAddress[31] = 0
+	sender, err := transferFunc.accounts.LoadAccount(senderAddress)
+	require.Nil(t, err)
+
+	tokenName := []byte("token")
+	tokenNonce := uint64(1)
+
+	initialTokens := big.NewInt(3)
+	createDCTNFTToken(tokenName, core.NonFungible, tokenNonce, initialTokens, transferFunc.marshaller, sender.(vmcommon.UserAccountHandler))
+
+	_ = transferFunc.accounts.SaveAccount(sender)
+	_, _ = transferFunc.accounts.Commit()
+	// reload sender account
+	sender, err = transferFunc.accounts.LoadAccount(senderAddress)
+	require.Nil(t, err)
+
+	nonceBytes := big.NewInt(int64(tokenNonce)).Bytes()
+	quantityBytes := big.NewInt(1).Bytes()
+	vmInput := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallValue:   big.NewInt(0),
+			CallerAddr:  senderAddress,
+			Arguments:   [][]byte{tokenName, nonceBytes, quantityBytes, destinationAddress},
+			GasProvided: 1,
+		},
+		RecipientAddr: senderAddress,
+	}
+
+	destination, _ := transferFunc.accounts.LoadAccount(destinationAddress)
+	globalSettings.IsSoulboundCalled = func(token []byte) bool {
+		return true
+	}
+	_, err = transferFunc.ProcessBuiltinFunction(sender.(vmcommon.UserAccountHandler), destination.(vmcommon.UserAccountHandler), vmInput)
+	assert.Equal(t, ErrTokenNotTransferable, err)
+
+	vmInput.ReturnCallAfterError = true
+	_, err = transferFunc.ProcessBuiltinFunction(sender.(vmcommon.UserAccountHandler), destination.(vmcommon.UserAccountHandler), vmInput)
+	assert.Nil(t, err)
+}
+
+func TestDCTNFTTransfer_RentedNFTShouldErr(t *testing.T) {
+	t.Parallel()
+
+	globalSettings := &mock.GlobalSettingsHandlerStub{}
+	transferFunc := createNftTransferWithMockArguments(0, 1, globalSettings)
+	_ = transferFunc.SetPayableChecker(&mock.PayableHandlerStub{})
+
+	senderAddress := bytes.Repeat([]byte{2}, 32) // sender is in the same shard
+	destinationAddress := bytes.Repeat([]byte{1}, 32)
+	destinationAddress[31] = 0
+	sender, err := transferFunc.accounts.LoadAccount(senderAddress)
+	require.Nil(t, err)
+
+	tokenName := []byte("token")
+	tokenNonce := uint64(1)
+
+	initialTokens := big.NewInt(3)
+	rentalMetadata := DCTUserMetadata{RentedFrom: bytes.Repeat([]byte{3}, 32), ReturnEpoch: 10}
+	rentedToken := &dct.DCToken{Type: uint32(core.NonFungible), Value: initialTokens, Properties: rentalMetadata.ToBytes()}
+	marshalledToken, _ := transferFunc.marshaller.Marshal(rentedToken)
+	_ = sender.(vmcommon.UserAccountHandler).AccountDataHandler().SaveKeyValue(dctkeys.ComputeDCTNFTTokenKey(append(keyPrefix, tokenName...), tokenNonce), marshalledToken)
+
+	_ = transferFunc.accounts.SaveAccount(sender)
+	_, _ = transferFunc.accounts.Commit()
+	// reload sender account
+	sender, err = transferFunc.accounts.LoadAccount(senderAddress)
+	require.Nil(t, err)
+
+	nonceBytes := big.NewInt(int64(tokenNonce)).Bytes()
+	quantityBytes := big.NewInt(1).Bytes()
+	vmInput := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallValue:   big.NewInt(0),
+			CallerAddr:  senderAddress,
+			Arguments:   [][]byte{tokenName, nonceBytes, quantityBytes, destinationAddress},
+			GasProvided: 1,
+		},
+		RecipientAddr: senderAddress,
+	}
+
+	destination, _ := transferFunc.accounts.LoadAccount(destinationAddress)
+	_, err = transferFunc.ProcessBuiltinFunction(sender.(vmcommon.UserAccountHandler), destination.(vmcommon.UserAccountHandler), vmInput)
+	assert.Equal(t, ErrRentedNFTNotTransferable, err)
+
+	vmInput.ReturnCallAfterError = true
+	_, err = transferFunc.ProcessBuiltinFunction(sender.(vmcommon.UserAccountHandler), destination.(vmcommon.UserAccountHandler), vmInput)
+	assert.Nil(t, err)
+}
+
 func TestDCTNFTTransfer_NotEnoughGas(t *testing.T) {
 	t.Parallel()
 
@@ -862,6 +1033,70 @@ func TestDCTNFTTransfer_NotEnoughGas(t *testing.T) {
 	assert.Equal(t, err, ErrNotEnoughGas)
 }
 
+func TestDCTNFTTransfer_ProcessBuiltinFunctionWithAliasDestination(t *testing.T) {
+	t.Parallel()
+
+	transferFunc := createNftTransferWithMockArguments(0, 1, &mock.GlobalSettingsHandlerStub{})
+	_ = transferFunc.SetPayableChecker(&mock.PayableHandlerStub{})
+
+	senderAddress := bytes.Repeat([]byte{2}, 32)
+	destinationAddress := bytes.Repeat([]byte{1}, 32)
+	destinationAddress[31] = 0 // destination is in the same shard
+	alias := []byte("alias")
+	err := transferFunc.SetAliasResolver(nil)
+	assert.Equal(t, ErrNilAliasResolver, err)
+	err = transferFunc.SetAliasResolver(&mock.AliasResolverStub{
+		IsAliasCalled: func(value []byte) bool {
+			return len(value) == len(alias)
+		},
+		ResolveAliasCalled: func(value []byte) ([]byte, error) {
+			if !bytes.Equal(value, alias) {
+				return nil, errors.New("unknown alias")
+			}
+			return destinationAddress, nil
+		},
+	})
+	require.Nil(t, err)
+
+	sender, err := transferFunc.accounts.LoadAccount(senderAddress)
+	require.Nil(t, err)
+	tokenName := []byte("token")
+	tokenNonce := uint64(1)
+	destination, err := transferFunc.accounts.LoadAccount(destinationAddress)
+	require.Nil(t, err)
+	createDCTNFTToken(tokenName, core.NonFungible, tokenNonce, big.NewInt(3), transferFunc.marshaller, sender.(vmcommon.UserAccountHandler))
+	_ = transferFunc.accounts.SaveAccount(sender)
+	_ = transferFunc.accounts.SaveAccount(destination)
+	_, _ = transferFunc.accounts.Commit()
+	sender, err = transferFunc.accounts.LoadAccount(senderAddress)
+	require.Nil(t, err)
+
+	nonceBytes := big.NewInt(int64(tokenNonce)).Bytes()
+	vmInput := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallValue:   big.NewInt(0),
+			CallerAddr:  senderAddress,
+			Arguments:   [][]byte{tokenName, nonceBytes, big.NewInt(1).Bytes(), []byte("other")},
+			GasProvided: 1,
+		},
+		RecipientAddr: senderAddress,
+	}
+	_, err = transferFunc.ProcessBuiltinFunction(sender.(vmcommon.UserAccountHandler), nil, vmInput)
+	assert.NotNil(t, err)
+
+	vmInput.Arguments[3] = alias
+	vmOutput, err := transferFunc.ProcessBuiltinFunction(sender.(vmcommon.UserAccountHandler), nil, vmInput)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	assert.Equal(t, [][]byte{tokenName, nonceBytes, {1}, destinationAddress}, vmOutput.Logs[0].Topics)
+
+	_ = transferFunc.accounts.SaveAccount(sender)
+	_, _ = transferFunc.accounts.Commit()
+	destination, err = transferFunc.accounts.LoadAccount(destinationAddress)
+	require.Nil(t, err)
+	testNFTTokenShouldExist(t, transferFunc.marshaller, destination, tokenName, tokenNonce, big.NewInt(1))
+}
+
 func extractScResultsFromVmOutput(t testing.TB, vmOutput *vmcommon.VMOutput) (string, [][]byte) {
 	require.NotNil(t, vmOutput)
 	require.Equal(t, 1, len(vmOutput.OutputAccounts))
@@ -0,0 +1,305 @@
+package builtInFunctions
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/Reshusk23/sr-me-core/core/check"
+	"github.com/Reshusk23/sr-me-core/data/dct"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+)
+
+const (
+	minNumArgumentsNFTUpdate = 4
+	nftUpdateMaskIndex       = 2
+	allNFTUpdateFields       = vmcommon.DCTNFTUpdateName | vmcommon.DCTNFTUpdateRoyalties | vmcommon.DCTNFTUpdateHash |
+		vmcommon.DCTNFTUpdateAttributes | vmcommon.DCTNFTUpdateURIs
+)
+
+// nftUpdateSingleValueFields holds, in the order of their values, the fields updated from a single argument
+var nftUpdateSingleValueFields = []uint64{
+	vmcommon.DCTNFTUpdateName,
+	vmcommon.DCTNFTUpdateRoyalties,
+	vmcommon.DCTNFTUpdateHash,
+	vmcommon.DCTNFTUpdateAttributes,
+}
+
+type dctNFTUpdate struct {
+	baseActiveHandler
+	keyPrefix             []byte
+	dctStorageHandler     vmcommon.DCTNFTStorageHandler
+	globalSettingsHandler vmcommon.DCTGlobalSettingsHandler
+	rolesHandler          vmcommon.DCTRoleHandler
+	gasConfig             vmcommon.BaseOperationCost
+	storageUsageTracker   vmcommon.StorageUsageTracker
+	funcGasCost           uint64
+	mutExecution          sync.RWMutex
+}
+
+// ArgsNewDCTNFTUpdate defines the argument list for new dct NFT update built in function
+type ArgsNewDCTNFTUpdate struct {
+	Config
+	FuncGasCost uint64
+	GasConfig   vmcommon.BaseOperationCost
+}
+
+// NewDCTNFTUpdateFunc returns the dct NFT update built-in function component, patching in a single call the metadata
+// fields of an NFT selected by a bitmask
+func NewDCTNFTUpdateFunc(args ArgsNewDCTNFTUpdate) (*dctNFTUpdate, error) {
+	err := args.checkComponents(vmcommon.BuiltInFunctionDCTNFTUpdate, requireGlobalSettingsHandler|requireRolesHandler|requireDCTStorageHandler|requireEnableEpochsHandler)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &dctNFTUpdate{
+		keyPrefix:             []byte(baseDCTKeyPrefix),
+		dctStorageHandler:     args.DCTStorageHandler,
+		globalSettingsHandler: args.GlobalSettingsHandler,
+		rolesHandler:          args.RolesHandler,
+		gasConfig:             args.GasConfig,
+		funcGasCost:           args.FuncGasCost,
+		storageUsageTracker:   &disabledStorageUsageTracker{},
+	}
+
+	e.baseActiveHandler.activeHandler = args.EnableEpochsHandler.IsDCTNFTUpdateFlagEnabled
+
+	return e, nil
+}
+
+// SetNewGasConfig is called whenever gas cost is changed
+func (e *dctNFTUpdate) SetNewGasConfig(gasCost *vmcommon.GasCost) {
+	if gasCost == nil {
+		return
+	}
+
+	e.mutExecution.Lock()
+	e.funcGasCost = gasCost.BuiltInCost.DCTNFTUpdateAttributes
+	e.gasConfig = gasCost.BaseOperationCost
+	e.mutExecution.Unlock()
+}
+
+// SetStorageUsageTracker sets the tracker metering the bytes the updated fields add over the replaced ones, which are accounted to the caller
+func (e *dctNFTUpdate) SetStorageUsageTracker(storageUsageTracker vmcommon.StorageUsageTracker) error {
+	if check.IfNil(storageUsageTracker) {
+		return ErrNilStorageUsageTracker
+	}
+
+	e.mutExecution.Lock()
+	e.storageUsageTracker = storageUsageTracker
+	e.mutExecution.Unlock()
+
+	return nil
+}
+
+// ProcessBuiltinFunction resolves DCT NFT update function call
+// Requires at least 4 arguments:
+// arg0 - token identifier
+// arg1 - nonce
+// arg2 - mask of the updated fields, see the DCTNFTUpdate constants
+// arg[3:] - the new values of the selected fields, in the order of the mask bits. When selected, the URIs take all
+// the remaining arguments
+func (e *dctNFTUpdate) ProcessBuiltinFunction(
+	acntSnd, _ vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	e.mutExecution.RLock()
+	defer e.mutExecution.RUnlock()
+
+	err := checkDCTNFTCreateBurnAddInput(acntSnd, vmInput, e.funcGasCost)
+	if err != nil {
+		return nil, err
+	}
+	if len(vmInput.Arguments) < minNumArgumentsNFTUpdate {
+		return nil, ErrInvalidArguments
+	}
+	if check.IfNil(acntSnd) {
+		return nil, ErrNilUserAccount
+	}
+
+	err = e.rolesHandler.CheckAllowedToExecute(acntSnd, vmInput.Arguments[0], []byte(vmcommon.DCTRoleNFTUpdate))
+	if err != nil {
+		return nil, err
+	}
+
+	mask, err := uint64Argument(vmInput.Arguments, nftUpdateMaskIndex)
+	if err != nil {
+		return nil, err
+	}
+	update, err := parseNFTMetaDataUpdate(mask, vmInput.Arguments)
+	if err != nil {
+		return nil, err
+	}
+	dctTokenKey := append(e.keyPrefix, vmInput.Arguments[0]...)
+	nonce, err := uint64Argument(vmInput.Arguments, 1)
+	if err != nil {
+		return nil, err
+	}
+	if nonce == 0 {
+		return nil, ErrNFTDoesNotHaveMetadata
+	}
+	err = e.checkCollectionConfig(dctTokenKey, nonce, update)
+	if err != nil {
+		return nil, err
+	}
+
+	gasCostForStore := uint64(update.length()) * e.gasConfig.StorePerByte
+	if vmInput.GasProvided < e.funcGasCost+gasCostForStore {
+		return nil, ErrNotEnoughGas
+	}
+	dctData, err := e.dctStorageHandler.GetDCTNFTTokenOnSender(acntSnd, dctTokenKey, nonce)
+	if err != nil {
+		return nil, err
+	}
+	if dctData.TokenMetaData == nil {
+		return nil, ErrNFTDoesNotHaveMetadata
+	}
+
+	sizeBeforeUpdate := getMetaDataSize(dctData.TokenMetaData)
+	update.apply(dctData.TokenMetaData)
+	err = e.storageUsageTracker.TrackStorageUsage(acntSnd, getMetaDataSize(dctData.TokenMetaData)-sizeBeforeUpdate)
+	if err != nil {
+		return nil, err
+	}
+	_, err = e.dctStorageHandler.SaveDCTNFTToken(acntSnd.AddressBytes(), acntSnd, dctTokenKey, nonce, dctData, true, vmInput.ReturnCallAfterError)
+	if err != nil {
+		return nil, err
+	}
+
+	vmOutput := &vmcommon.VMOutput{
+		ReturnCode:   vmcommon.Ok,
+		GasRemaining: vmInput.GasProvided - e.funcGasCost - gasCostForStore,
+	}
+
+	extraTopics := append([][]byte{vmInput.CallerAddr}, vmInput.Arguments[nftUpdateMaskIndex:]...)
+	addDCTEntryInVMOutput(vmOutput, []byte(vmcommon.BuiltInFunctionDCTNFTUpdate), vmInput.Arguments[0], nonce, big.NewInt(0), extraTopics...)
+
+	return vmOutput, nil
+}
+
+// nftMetaDataUpdate holds the new values of the metadata fields selected by the mask
+type nftMetaDataUpdate struct {
+	mask       uint64
+	name       []byte
+	royalties  uint32
+	hash       []byte
+	attributes []byte
+	uris       [][]byte
+}
+
+// parseNFTMetaDataUpdate reads the new values following the mask, in the order of the mask bits
+func parseNFTMetaDataUpdate(mask uint64, arguments [][]byte) (*nftMetaDataUpdate, error) {
+	if mask == 0 || mask&^allNFTUpdateFields != 0 {
+		return nil, fmt.Errorf("%w, invalid mask of the updated fields", ErrInvalidArguments)
+	}
+
+	update := &nftMetaDataUpdate{mask: mask}
+	index := nftUpdateMaskIndex + 1
+	for _, field := range nftUpdateSingleValueFields {
+		if !update.has(field) {
+			continue
+		}
+		if index >= len(arguments) {
+			return nil, fmt.Errorf("%w, missing value of an updated field", ErrInvalidArguments)
+		}
+
+		err := update.setValue(field, arguments, index)
+		if err != nil {
+			return nil, err
+		}
+		index++
+	}
+
+	remaining := arguments[index:]
+	if !update.has(vmcommon.DCTNFTUpdateURIs) {
+		if len(remaining) > 0 {
+			return nil, fmt.Errorf("%w, too many values for the updated fields", ErrInvalidArguments)
+		}
+		return update, nil
+	}
+	if len(remaining) == 0 {
+		return nil, fmt.Errorf("%w, missing value of an updated field", ErrInvalidArguments)
+	}
+	update.uris = remaining
+
+	return update, nil
+}
+
+// checkCollectionConfig checks the update against the limits of the collection. The royalties are checked against the
+// denominator the token was created with, not against the current one of the collection
+func (e *dctNFTUpdate) checkCollectionConfig(dctTokenKey []byte, nonce uint64, update *nftMetaDataUpdate) error {
+	tokenID := dctTokenKey[len(e.keyPrefix):]
+	if update.has(vmcommon.DCTNFTUpdateRoyalties) {
+		royaltiesDenominator, err := e.dctStorageHandler.GetNFTRoyaltiesDenominator(dctTokenKey, nonce)
+		if err != nil {
+			return err
+		}
+		if update.royalties > royaltiesDenominator {
+			return fmt.Errorf("%w, invalid max royality value", ErrInvalidArguments)
+		}
+	}
+	if update.has(vmcommon.DCTNFTUpdateAttributes) {
+		err := checkCollectionAttributes(e.globalSettingsHandler, tokenID, update.attributes)
+		if err != nil {
+			return err
+		}
+	}
+	if update.has(vmcommon.DCTNFTUpdateURIs) {
+		return checkCollectionURIs(e.globalSettingsHandler, tokenID, len(update.uris))
+	}
+
+	return nil
+}
+
+func (u *nftMetaDataUpdate) setValue(field uint64, arguments [][]byte, index int) error {
+	var err error
+	switch field {
+	case vmcommon.DCTNFTUpdateName:
+		u.name = arguments[index]
+	case vmcommon.DCTNFTUpdateRoyalties:
+		u.royalties, err = uint32Argument(arguments, index)
+	case vmcommon.DCTNFTUpdateHash:
+		u.hash = arguments[index]
+	case vmcommon.DCTNFTUpdateAttributes:
+		u.attributes = arguments[index]
+	}
+
+	return err
+}
+
+func (u *nftMetaDataUpdate) has(field uint64) bool {
+	return u.mask&field != 0
+}
+
+// length returns the number of bytes stored by the update, the royalties being a fixed size field
+func (u *nftMetaDataUpdate) length() int {
+	length := len(u.name) + len(u.hash) + len(u.attributes)
+	for _, uri := range u.uris {
+		length += len(uri)
+	}
+
+	return length
+}
+
+func (u *nftMetaDataUpdate) apply(metaData *dct.MetaData) {
+	if u.has(vmcommon.DCTNFTUpdateName) {
+		metaData.Name = u.name
+	}
+	if u.has(vmcommon.DCTNFTUpdateRoyalties) {
+		metaData.Royalties = u.royalties
+	}
+	if u.has(vmcommon.DCTNFTUpdateHash) {
+		metaData.Hash = u.hash
+	}
+	if u.has(vmcommon.DCTNFTUpdateAttributes) {
+		metaData.Attributes = u.attributes
+	}
+	if u.has(vmcommon.DCTNFTUpdateURIs) {
+		metaData.URIs = u.uris
+	}
+}
+
+// IsInterfaceNil returns true if underlying object in nil
+func (e *dctNFTUpdate) IsInterfaceNil() bool {
+	return e == nil
+}
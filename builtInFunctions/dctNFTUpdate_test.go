@@ -0,0 +1,409 @@
+package builtInFunctions
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/Reshusk23/sr-me-core/core"
+	"github.com/Reshusk23/sr-me-core/core/check"
+	"github.com/Reshusk23/sr-me-core/data/dct"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/dctkeys"
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func createNFTUpdateInput(tokenID []byte, nonce uint64, mask uint64, values ...[]byte) *vmcommon.ContractCallInput {
+	arguments := [][]byte{tokenID, big.NewInt(0).SetUint64(nonce).Bytes(), big.NewInt(0).SetUint64(mask).Bytes()}
+	return &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallValue:   big.NewInt(0),
+			Arguments:   append(arguments, values...),
+			CallerAddr:  []byte("address 1"),
+			GasProvided: 100,
+		},
+		RecipientAddr: []byte("address 1"),
+	}
+}
+
+func createNFTUpdateAccount(t *testing.T, dctDataStorage *dctDataStorage, tokenID []byte, nonce uint64) vmcommon.UserAccountHandler {
+	userAcc := mock.NewAccountWrapMock([]byte("address 1"))
+	dctData := &dct.DCToken{
+		Type:  uint32(core.NonFungible),
+		Value: big.NewInt(1),
+		TokenMetaData: &dct.MetaData{
+			Nonce:      nonce,
+			Name:       []byte("name"),
+			Royalties:  100,
+			Hash:       []byte("hash"),
+			Attributes: []byte("attributes"),
+			URIs:       [][]byte{[]byte("uri")},
+		},
+	}
+	dctTokenKey := append([]byte(baseDCTKeyPrefix), tokenID...)
+	_, err := dctDataStorage.SaveDCTNFTToken(userAcc.AddressBytes(), userAcc, dctTokenKey, nonce, dctData, true, false)
+	require.Nil(t, err)
+
+	return userAcc
+}
+
+func createNFTUpdateFunc(dctDataStorage *dctDataStorage, storePerByte uint64) *dctNFTUpdate {
+	e, _ := NewDCTNFTUpdateFunc(ArgsNewDCTNFTUpdate{
+		Config: Config{
+			DCTStorageHandler:     dctDataStorage,
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+		},
+		FuncGasCost: 10,
+		GasConfig:   vmcommon.BaseOperationCost{StorePerByte: storePerByte},
+	})
+	return e
+}
+
+func TestNewDCTNFTUpdateFunc(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil storage handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		e, err := NewDCTNFTUpdateFunc(ArgsNewDCTNFTUpdate{
+			Config: Config{
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+				EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			},
+			FuncGasCost: 10,
+			GasConfig:   vmcommon.BaseOperationCost{},
+		})
+		require.True(t, check.IfNil(e))
+		require.ErrorIs(t, err, ErrNilDCTNFTStorageHandler)
+	})
+	t.Run("nil global settings handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		e, err := NewDCTNFTUpdateFunc(ArgsNewDCTNFTUpdate{
+			Config: Config{
+				DCTStorageHandler:   createNewDCTDataStorageHandler(),
+				RolesHandler:        &mock.DCTRoleHandlerStub{},
+				EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+			},
+			FuncGasCost: 10,
+			GasConfig:   vmcommon.BaseOperationCost{},
+		})
+		require.True(t, check.IfNil(e))
+		require.ErrorIs(t, err, ErrNilGlobalSettingsHandler)
+	})
+	t.Run("nil roles handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		e, err := NewDCTNFTUpdateFunc(ArgsNewDCTNFTUpdate{
+			Config: Config{
+				DCTStorageHandler:     createNewDCTDataStorageHandler(),
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			},
+			FuncGasCost: 10,
+			GasConfig:   vmcommon.BaseOperationCost{},
+		})
+		require.True(t, check.IfNil(e))
+		require.ErrorIs(t, err, ErrNilRolesHandler)
+	})
+	t.Run("nil enable epochs handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		e, err := NewDCTNFTUpdateFunc(ArgsNewDCTNFTUpdate{
+			Config: Config{
+				DCTStorageHandler:     createNewDCTDataStorageHandler(),
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+			},
+			FuncGasCost: 10,
+			GasConfig:   vmcommon.BaseOperationCost{},
+		})
+		require.True(t, check.IfNil(e))
+		require.ErrorIs(t, err, ErrNilEnableEpochsHandler)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		enableEpochsHandler := &mock.EnableEpochsHandlerStub{}
+		e, err := NewDCTNFTUpdateFunc(ArgsNewDCTNFTUpdate{
+			Config: Config{
+				DCTStorageHandler:     createNewDCTDataStorageHandler(),
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+				EnableEpochsHandler:   enableEpochsHandler,
+			},
+			FuncGasCost: 10,
+			GasConfig:   vmcommon.BaseOperationCost{},
+		})
+		require.False(t, check.IfNil(e))
+		require.Nil(t, err)
+		require.False(t, e.IsActive())
+
+		enableEpochsHandler.IsDCTNFTUpdateFlagEnabledField = true
+		require.True(t, e.IsActive())
+	})
+}
+
+func TestDCTNFTUpdate_SetNewGasConfig(t *testing.T) {
+	t.Parallel()
+
+	e := createNFTUpdateFunc(createNewDCTDataStorageHandler(), 0)
+
+	e.SetNewGasConfig(nil)
+	require.Equal(t, uint64(10), e.funcGasCost)
+
+	e.SetNewGasConfig(&vmcommon.GasCost{
+		BaseOperationCost: vmcommon.BaseOperationCost{StorePerByte: 2},
+		BuiltInCost:       vmcommon.BuiltInCost{DCTNFTUpdateAttributes: 20},
+	})
+	require.Equal(t, uint64(20), e.funcGasCost)
+	require.Equal(t, uint64(2), e.gasConfig.StorePerByte)
+}
+
+func TestDCTNFTUpdate_ProcessBuiltinFunctionErrors(t *testing.T) {
+	t.Parallel()
+
+	tokenID := []byte("NFT-abcdef")
+
+	t.Run("missing mask should error", func(t *testing.T) {
+		t.Parallel()
+
+		e := createNFTUpdateFunc(createNewDCTDataStorageHandler(), 0)
+		input := createNFTUpdateInput(tokenID, 1, 0)
+		input.Arguments = input.Arguments[:2]
+
+		output, err := e.ProcessBuiltinFunction(mock.NewAccountWrapMock(input.CallerAddr), nil, input)
+		require.Nil(t, output)
+		require.Equal(t, ErrInvalidArguments, err)
+	})
+	t.Run("missing role should error", func(t *testing.T) {
+		t.Parallel()
+
+		rolesHandler := &mock.DCTRoleHandlerStub{
+			CheckAllowedToExecuteCalled: func(_ vmcommon.UserAccountHandler, _ []byte, action []byte) error {
+				require.Equal(t, vmcommon.DCTRoleNFTUpdate, string(action))
+				return ErrActionNotAllowed
+			},
+		}
+		e, _ := NewDCTNFTUpdateFunc(ArgsNewDCTNFTUpdate{
+			Config: Config{
+				DCTStorageHandler:     createNewDCTDataStorageHandler(),
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          rolesHandler,
+				EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			},
+			FuncGasCost: 10,
+			GasConfig:   vmcommon.BaseOperationCost{},
+		})
+		input := createNFTUpdateInput(tokenID, 1, vmcommon.DCTNFTUpdateName, []byte("new name"))
+
+		output, err := e.ProcessBuiltinFunction(mock.NewAccountWrapMock(input.CallerAddr), nil, input)
+		require.Nil(t, output)
+		require.Equal(t, ErrActionNotAllowed, err)
+	})
+	t.Run("invalid mask should error", func(t *testing.T) {
+		t.Parallel()
+
+		e := createNFTUpdateFunc(createNewDCTDataStorageHandler(), 0)
+		for _, mask := range []uint64{0, vmcommon.DCTNFTUpdateURIs << 1} {
+			input := createNFTUpdateInput(tokenID, 1, mask, []byte("value"))
+
+			output, err := e.ProcessBuiltinFunction(mock.NewAccountWrapMock(input.CallerAddr), nil, input)
+			require.Nil(t, output)
+			require.True(t, errors.Is(err, ErrInvalidArguments))
+		}
+	})
+	t.Run("values not matching the mask should error", func(t *testing.T) {
+		t.Parallel()
+
+		e := createNFTUpdateFunc(createNewDCTDataStorageHandler(), 0)
+		inputs := []*vmcommon.ContractCallInput{
+			createNFTUpdateInput(tokenID, 1, vmcommon.DCTNFTUpdateName|vmcommon.DCTNFTUpdateHash, []byte("new name")),
+			createNFTUpdateInput(tokenID, 1, vmcommon.DCTNFTUpdateName, []byte("new name"), []byte("extra")),
+			createNFTUpdateInput(tokenID, 1, vmcommon.DCTNFTUpdateName|vmcommon.DCTNFTUpdateURIs, []byte("new name")),
+			createNFTUpdateInput(tokenID, 1, vmcommon.DCTNFTUpdateRoyalties, []byte{1, 0, 0, 0, 0}),
+		}
+		for _, input := range inputs {
+			output, err := e.ProcessBuiltinFunction(mock.NewAccountWrapMock(input.CallerAddr), nil, input)
+			require.Nil(t, output)
+			require.True(t, errors.Is(err, ErrInvalidArguments))
+		}
+	})
+	t.Run("collection config should be checked", func(t *testing.T) {
+		t.Parallel()
+
+		globalSettingsHandler := &mock.GlobalSettingsHandlerStub{
+			GetCollectionConfigCalled: func(tokenID []byte) vmcommon.CollectionConfig {
+				return vmcommon.CollectionConfig{MaxNumURIs: 1, MaxAttributesLength: 2}
+			},
+		}
+		e, _ := NewDCTNFTUpdateFunc(ArgsNewDCTNFTUpdate{
+			Config: Config{
+				DCTStorageHandler:     createNewDCTDataStorageHandler(),
+				GlobalSettingsHandler: globalSettingsHandler,
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+				EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			},
+			FuncGasCost: 10,
+			GasConfig:   vmcommon.BaseOperationCost{},
+		})
+
+		input := createNFTUpdateInput(tokenID, 1, vmcommon.DCTNFTUpdateURIs, []byte("uri1"), []byte("uri2"))
+		output, err := e.ProcessBuiltinFunction(mock.NewAccountWrapMock(input.CallerAddr), nil, input)
+		require.Nil(t, output)
+		require.Equal(t, ErrTooManyURIs, err)
+
+		input = createNFTUpdateInput(tokenID, 1, vmcommon.DCTNFTUpdateAttributes, []byte("attributes"))
+		output, err = e.ProcessBuiltinFunction(mock.NewAccountWrapMock(input.CallerAddr), nil, input)
+		require.Nil(t, output)
+		require.Equal(t, ErrAttributesTooLong, err)
+
+		input = createNFTUpdateInput(tokenID, 1, vmcommon.DCTNFTUpdateRoyalties, big.NewInt(int64(vmcommon.DefaultRoyaltiesDenominator)+1).Bytes())
+		output, err = e.ProcessBuiltinFunction(mock.NewAccountWrapMock(input.CallerAddr), nil, input)
+		require.Nil(t, output)
+		require.True(t, errors.Is(err, ErrInvalidArguments))
+	})
+	t.Run("zero nonce should error", func(t *testing.T) {
+		t.Parallel()
+
+		e := createNFTUpdateFunc(createNewDCTDataStorageHandler(), 0)
+		input := createNFTUpdateInput(tokenID, 0, vmcommon.DCTNFTUpdateName, []byte("new name"))
+
+		output, err := e.ProcessBuiltinFunction(mock.NewAccountWrapMock(input.CallerAddr), nil, input)
+		require.Nil(t, output)
+		require.Equal(t, ErrNFTDoesNotHaveMetadata, err)
+	})
+	t.Run("not enough gas for the stored bytes should error", func(t *testing.T) {
+		t.Parallel()
+
+		dctDataStorage := createNewDCTDataStorageHandler()
+		e := createNFTUpdateFunc(dctDataStorage, 20)
+		userAcc := createNFTUpdateAccount(t, dctDataStorage, tokenID, 1)
+		input := createNFTUpdateInput(tokenID, 1, vmcommon.DCTNFTUpdateName, []byte("new name"))
+
+		output, err := e.ProcessBuiltinFunction(userAcc, nil, input)
+		require.Nil(t, output)
+		require.Equal(t, ErrNotEnoughGas, err)
+	})
+}
+
+func TestDCTNFTUpdate_ProcessBuiltinFunctionShouldWork(t *testing.T) {
+	t.Parallel()
+
+	tokenID := []byte("NFT-abcdef")
+	nonce := uint64(5)
+	dctTokenKey := append([]byte(baseDCTKeyPrefix), tokenID...)
+
+	t.Run("royalties should be checked against the denominator of the token", func(t *testing.T) {
+		t.Parallel()
+
+		globalSettingsHandler := &mock.GlobalSettingsHandlerStub{
+			GetCollectionConfigCalled: func(tokenID []byte) vmcommon.CollectionConfig {
+				return vmcommon.CollectionConfig{RoyaltiesDenominator: 1000000}
+			},
+		}
+		dctDataStorage := createNewDCTDataStorageHandler()
+		e, _ := NewDCTNFTUpdateFunc(ArgsNewDCTNFTUpdate{
+			Config: Config{
+				DCTStorageHandler:     dctDataStorage,
+				GlobalSettingsHandler: globalSettingsHandler,
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+				EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			},
+			FuncGasCost: 10,
+			GasConfig:   vmcommon.BaseOperationCost{},
+		})
+		royalties := big.NewInt(int64(vmcommon.DefaultRoyaltiesDenominator) + 1).Bytes()
+
+		oldTokenAcc := createNFTUpdateAccount(t, dctDataStorage, tokenID, nonce)
+		input := createNFTUpdateInput(tokenID, nonce, vmcommon.DCTNFTUpdateRoyalties, royalties)
+		output, err := e.ProcessBuiltinFunction(oldTokenAcc, nil, input)
+		require.Nil(t, output)
+		require.True(t, errors.Is(err, ErrInvalidArguments), "a token without a recorded denominator should keep the default one")
+
+		newTokenAcc := createNFTUpdateAccount(t, dctDataStorage, tokenID, nonce+1)
+		require.Nil(t, dctDataStorage.SaveNFTRoyaltiesDenominator(dctTokenKey, nonce+1, 1000000))
+		input = createNFTUpdateInput(tokenID, nonce+1, vmcommon.DCTNFTUpdateRoyalties, royalties)
+		output, err = e.ProcessBuiltinFunction(newTokenAcc, nil, input)
+		require.Nil(t, err)
+		require.Equal(t, vmcommon.Ok, output.ReturnCode)
+
+		metaData, _ := dctDataStorage.getDCTMetaDataFromSystemAccount(dctkeys.ComputeDCTNFTTokenKey(dctTokenKey, nonce+1), defaultQueryOptions())
+		require.Equal(t, vmcommon.DefaultRoyaltiesDenominator+1, metaData.Royalties)
+	})
+	t.Run("storage usage should follow the size of the metadata", func(t *testing.T) {
+		t.Parallel()
+
+		dctDataStorage := createNewDCTDataStorageHandler()
+		e := createNFTUpdateFunc(dctDataStorage, 0)
+		require.Equal(t, ErrNilStorageUsageTracker, e.SetStorageUsageTracker(nil))
+		tracker := NewStorageUsageTracker(vmcommon.StorageUsageConfig{})
+		require.Nil(t, e.SetStorageUsageTracker(tracker))
+		userAcc := createNFTUpdateAccount(t, dctDataStorage, tokenID, nonce)
+		require.Nil(t, tracker.TrackStorageUsage(userAcc, 25))
+
+		mask := vmcommon.DCTNFTUpdateName | vmcommon.DCTNFTUpdateURIs
+		input := createNFTUpdateInput(tokenID, nonce, mask, []byte("longer name"), []byte("uri1"), []byte("uri2"))
+		output, err := e.ProcessBuiltinFunction(userAcc, nil, input)
+		require.Nil(t, err)
+		require.Equal(t, vmcommon.Ok, output.ReturnCode)
+		require.Equal(t, uint64(25+len("longer name")-len("name")+len("uri1uri2")-len("uri")), tracker.GetStorageUsage(userAcc))
+
+		input = createNFTUpdateInput(tokenID, nonce, vmcommon.DCTNFTUpdateAttributes, []byte("a"))
+		output, err = e.ProcessBuiltinFunction(userAcc, nil, input)
+		require.Nil(t, err)
+		require.Equal(t, vmcommon.Ok, output.ReturnCode)
+		require.Equal(t, uint64(37-len("attributes")+len("a")), tracker.GetStorageUsage(userAcc))
+	})
+	t.Run("only the selected fields should be updated", func(t *testing.T) {
+		t.Parallel()
+
+		dctDataStorage := createNewDCTDataStorageHandler()
+		e := createNFTUpdateFunc(dctDataStorage, 1)
+		userAcc := createNFTUpdateAccount(t, dctDataStorage, tokenID, nonce)
+		mask := vmcommon.DCTNFTUpdateRoyalties | vmcommon.DCTNFTUpdateAttributes
+		input := createNFTUpdateInput(tokenID, nonce, mask, big.NewInt(250).Bytes(), []byte("new attributes"))
+
+		output, err := e.ProcessBuiltinFunction(userAcc, nil, input)
+		require.Nil(t, err)
+		require.Equal(t, vmcommon.Ok, output.ReturnCode)
+		require.Equal(t, uint64(100-10-len("new attributes")), output.GasRemaining)
+
+		metaData, _ := dctDataStorage.getDCTMetaDataFromSystemAccount(dctkeys.ComputeDCTNFTTokenKey(dctTokenKey, nonce), defaultQueryOptions())
+		require.Equal(t, []byte("name"), metaData.Name)
+		require.Equal(t, uint32(250), metaData.Royalties)
+		require.Equal(t, []byte("hash"), metaData.Hash)
+		require.Equal(t, []byte("new attributes"), metaData.Attributes)
+		require.Equal(t, [][]byte{[]byte("uri")}, metaData.URIs)
+
+		require.Equal(t, 1, len(output.Logs))
+		require.Equal(t, []byte(vmcommon.BuiltInFunctionDCTNFTUpdate), output.Logs[0].Identifier)
+		expectedTopics := append([][]byte{tokenID, {byte(nonce)}, {}}, input.Arguments[2:]...)
+		require.Equal(t, expectedTopics, output.Logs[0].Topics)
+	})
+	t.Run("all the fields should be updated", func(t *testing.T) {
+		t.Parallel()
+
+		dctDataStorage := createNewDCTDataStorageHandler()
+		e := createNFTUpdateFunc(dctDataStorage, 0)
+		userAcc := createNFTUpdateAccount(t, dctDataStorage, tokenID, nonce)
+		newURIs := [][]byte{[]byte("uri1"), []byte("uri2")}
+		values := append([][]byte{[]byte("new name"), {}, []byte("new hash"), []byte("new attributes")}, newURIs...)
+		input := createNFTUpdateInput(tokenID, nonce, allNFTUpdateFields, values...)
+
+		output, err := e.ProcessBuiltinFunction(userAcc, nil, input)
+		require.Nil(t, err)
+		require.Equal(t, vmcommon.Ok, output.ReturnCode)
+
+		metaData, _ := dctDataStorage.getDCTMetaDataFromSystemAccount(dctkeys.ComputeDCTNFTTokenKey(dctTokenKey, nonce), defaultQueryOptions())
+		require.Equal(t, []byte("new name"), metaData.Name)
+		require.Equal(t, uint32(0), metaData.Royalties)
+		require.Equal(t, []byte("new hash"), metaData.Hash)
+		require.Equal(t, []byte("new attributes"), metaData.Attributes)
+		require.Equal(t, newURIs, metaData.URIs)
+	})
+}
@@ -0,0 +1,189 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"math/big"
+	"sync"
+
+	"github.com/Reshusk23/sr-me-core/core/check"
+	"github.com/Reshusk23/sr-me-core/data/dct"
+	"github.com/Reshusk23/sr-me-core/data/vm"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/dctkeys"
+	"github.com/Reshusk23/sr-vm-common-go/validation"
+)
+
+const numArgumentsReclaimRentedNFT = 2
+
+type dctReclaimRentedNFT struct {
+	baseActiveHandler
+	keyPrefix           []byte
+	marshaller          vmcommon.Marshalizer
+	dctStorageHandler   vmcommon.DCTNFTStorageHandler
+	enableEpochsHandler vmcommon.EnableEpochsHandler
+	funcGasCost         uint64
+	mutExecution        sync.RWMutex
+}
+
+// ArgsNewDCTReclaimRentedNFT defines the argument list for new dct reclaim rented NFT built in function
+type ArgsNewDCTReclaimRentedNFT struct {
+	Config
+	FuncGasCost uint64
+}
+
+// NewDCTReclaimRentedNFTFunc returns the built-in function component which returns a rented NFT to its owner
+func NewDCTReclaimRentedNFTFunc(args ArgsNewDCTReclaimRentedNFT) (*dctReclaimRentedNFT, error) {
+	err := args.checkComponents(vmcommon.BuiltInFunctionDCTReclaimRentedNFT, requireMarshalizer|requireDCTStorageHandler|requireEnableEpochsHandler)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &dctReclaimRentedNFT{
+		keyPrefix:           []byte(baseDCTKeyPrefix),
+		marshaller:          args.Marshalizer,
+		dctStorageHandler:   args.DCTStorageHandler,
+		enableEpochsHandler: args.EnableEpochsHandler,
+		funcGasCost:         args.FuncGasCost,
+	}
+
+	e.baseActiveHandler.activeHandler = args.EnableEpochsHandler.IsNFTRentalFlagEnabled
+
+	return e, nil
+}
+
+// SetNewGasConfig is called whenever gas cost is changed
+func (e *dctReclaimRentedNFT) SetNewGasConfig(gasCost *vmcommon.GasCost) {
+	if gasCost == nil {
+		return
+	}
+
+	e.mutExecution.Lock()
+	e.funcGasCost = gasCost.BuiltInCost.DCTNFTTransfer
+	e.mutExecution.Unlock()
+}
+
+// ProcessBuiltinFunction resolves DCT reclaim rented NFT function call, sent by the owner to the borrower
+// Requires 2 arguments:
+// arg0 - token identifier
+// arg1 - nonce
+func (e *dctReclaimRentedNFT) ProcessBuiltinFunction(
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	e.mutExecution.RLock()
+	defer e.mutExecution.RUnlock()
+
+	err := checkBasicDCTArguments(vmInput)
+	if err != nil {
+		return nil, err
+	}
+	if len(vmInput.Arguments) != numArgumentsReclaimRentedNFT {
+		return nil, ErrInvalidArguments
+	}
+	err = checkFunctionArguments(vmInput.Arguments, validation.RequireUint64(1))
+	if err != nil {
+		return nil, err
+	}
+
+	vmOutput := &vmcommon.VMOutput{ReturnCode: vmcommon.Ok, GasRemaining: vmInput.GasProvided}
+	if !check.IfNil(acntSnd) {
+		// gas is paid only by the owner
+		if vmInput.GasProvided < e.funcGasCost {
+			return nil, ErrNotEnoughGas
+		}
+		vmOutput.GasRemaining -= e.funcGasCost
+	}
+	if check.IfNil(acntDst) {
+		// the rented NFT is checked and returned on the shard of the borrower
+		return vmOutput, nil
+	}
+
+	tickerID := vmInput.Arguments[0]
+	dctTokenKey := append(e.keyPrefix, tickerID...)
+	nonce, err := uint64Argument(vmInput.Arguments, 1)
+	if err != nil {
+		return nil, err
+	}
+	dctData, err := e.dctStorageHandler.GetDCTNFTTokenOnSender(acntDst, dctTokenKey, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	rentalMetadata := DCTUserMetadataFromBytes(dctData.Properties)
+	if !rentalMetadata.IsRented() {
+		return nil, ErrNFTNotRented
+	}
+	if !bytes.Equal(rentalMetadata.RentedFrom, vmInput.CallerAddr) {
+		return nil, ErrCallerIsNotRentalOwner
+	}
+	if e.enableEpochsHandler.GetCurrentEpoch() < rentalMetadata.ReturnEpoch {
+		return nil, ErrRentalNotExpired
+	}
+
+	dctNFTTokenKey := dctkeys.ComputeDCTNFTTokenKey(dctTokenKey, nonce)
+	err = acntDst.AccountDataHandler().SaveKeyValue(dctNFTTokenKey, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	returnedData := &dct.DCToken{
+		Type:          dctData.Type,
+		Value:         big.NewInt(1),
+		TokenMetaData: dctData.TokenMetaData,
+	}
+	if check.IfNil(acntSnd) {
+		err = e.sendReturnedNFTCrossShard(vmOutput, acntDst.AddressBytes(), vmInput.CallerAddr, returnedData, tickerID, dctTokenKey, nonce)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		_, err = e.dctStorageHandler.SaveDCTNFTToken(acntDst.AddressBytes(), acntSnd, dctTokenKey, nonce, returnedData, false, false)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	addDCTEntryInVMOutput(vmOutput, []byte(vmcommon.BuiltInFunctionDCTReclaimRentedNFT), tickerID, nonce, oneValue, acntDst.AddressBytes(), vmInput.CallerAddr)
+
+	return vmOutput, nil
+}
+
+func (e *dctReclaimRentedNFT) sendReturnedNFTCrossShard(
+	vmOutput *vmcommon.VMOutput,
+	borrower []byte,
+	owner []byte,
+	returnedData *dct.DCToken,
+	tickerID []byte,
+	dctTokenKey []byte,
+	nonce uint64,
+) error {
+	err := e.dctStorageHandler.AddToLiquiditySystemAcc(dctTokenKey, nonce, big.NewInt(-1))
+	if err != nil {
+		return err
+	}
+
+	marshalledToken, err := e.marshaller.Marshal(returnedData)
+	if err != nil {
+		return err
+	}
+
+	outTransfer := vmcommon.OutputTransfer{
+		Value:         big.NewInt(0),
+		Data:          computeNFTTransferData(tickerID, nonce, oneValue, marshalledToken),
+		CallType:      vm.DirectCall,
+		SenderAddress: borrower,
+	}
+	vmOutput.OutputAccounts = map[string]*vmcommon.OutputAccount{
+		string(owner): {
+			Address:         owner,
+			OutputTransfers: []vmcommon.OutputTransfer{outTransfer},
+		},
+	}
+
+	return nil
+}
+
+// IsInterfaceNil returns true if underlying object in nil
+func (e *dctReclaimRentedNFT) IsInterfaceNil() bool {
+	return e == nil
+}
@@ -0,0 +1,181 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/Reshusk23/sr-me-core/core"
+	"github.com/Reshusk23/sr-me-core/core/check"
+	"github.com/Reshusk23/sr-me-core/data/dct"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/dctkeys"
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createReclaimRentedNFTInput(owner []byte, borrower []byte, tokenName []byte, nonce uint64) *vmcommon.ContractCallInput {
+	return &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  owner,
+			CallValue:   big.NewInt(0),
+			GasProvided: 100,
+			Arguments:   [][]byte{tokenName, big.NewInt(0).SetUint64(nonce).Bytes()},
+		},
+		RecipientAddr: borrower,
+	}
+}
+
+func TestNewDCTReclaimRentedNFTFunc(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil marshaller should error", func(t *testing.T) {
+		t.Parallel()
+
+		e, err := NewDCTReclaimRentedNFTFunc(ArgsNewDCTReclaimRentedNFT{
+			Config: Config{
+				DCTStorageHandler:   &mock.DCTNFTStorageHandlerStub{},
+				EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+			},
+			FuncGasCost: 10,
+		})
+		assert.True(t, check.IfNil(e))
+		assert.ErrorIs(t, err, ErrNilMarshalizer)
+	})
+	t.Run("nil storage handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		e, err := NewDCTReclaimRentedNFTFunc(ArgsNewDCTReclaimRentedNFT{
+			Config: Config{
+				Marshalizer:         &mock.MarshalizerMock{},
+				EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+			},
+			FuncGasCost: 10,
+		})
+		assert.True(t, check.IfNil(e))
+		assert.ErrorIs(t, err, ErrNilDCTNFTStorageHandler)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		e, err := NewDCTReclaimRentedNFTFunc(ArgsNewDCTReclaimRentedNFT{
+			Config: Config{
+				Marshalizer:         &mock.MarshalizerMock{},
+				DCTStorageHandler:   &mock.DCTNFTStorageHandlerStub{},
+				EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+			},
+			FuncGasCost: 10,
+		})
+		assert.Nil(t, err)
+		assert.False(t, check.IfNil(e))
+	})
+}
+
+func TestDCTReclaimRentedNFT_ProcessBuiltinFunctionSameShard(t *testing.T) {
+	t.Parallel()
+
+	owner := bytes.Repeat([]byte{1}, 32)
+	owner[31] = 0
+	borrower := bytes.Repeat([]byte{2}, 32)
+	borrower[31] = 0
+	other := bytes.Repeat([]byte{3}, 32)
+	other[31] = 0
+	tokenName := []byte("NFT-abcdef")
+
+	components := createRentalTestComponents(0)
+	acntOwner := components.loadAccount(owner)
+	acntBorrower := components.loadAccount(borrower)
+	createDCTNFTToken(tokenName, core.NonFungible, 1, big.NewInt(1), components.marshaller, acntOwner)
+	createDCTNFTToken(tokenName, core.NonFungible, 2, big.NewInt(1), components.marshaller, acntBorrower)
+	_, err := components.rentFunc.ProcessBuiltinFunction(acntOwner, nil, createRentNFTInput(owner, borrower, tokenName, 1, 10))
+	require.Nil(t, err)
+
+	input := createReclaimRentedNFTInput(owner, borrower, tokenName, 1)
+	input.Arguments = input.Arguments[:1]
+	_, err = components.reclaimFunc.ProcessBuiltinFunction(acntOwner, acntBorrower, input)
+	assert.Equal(t, ErrInvalidArguments, err)
+
+	input = createReclaimRentedNFTInput(owner, borrower, tokenName, 1)
+	input.GasProvided = 1
+	_, err = components.reclaimFunc.ProcessBuiltinFunction(acntOwner, acntBorrower, input)
+	assert.Equal(t, ErrNotEnoughGas, err)
+
+	_, err = components.reclaimFunc.ProcessBuiltinFunction(acntOwner, acntBorrower, createReclaimRentedNFTInput(owner, borrower, tokenName, 2))
+	assert.Equal(t, ErrNFTNotRented, err)
+
+	components.enableEpochsHandler.CurrentEpochField = 9
+	_, err = components.reclaimFunc.ProcessBuiltinFunction(acntOwner, acntBorrower, createReclaimRentedNFTInput(owner, borrower, tokenName, 1))
+	assert.Equal(t, ErrRentalNotExpired, err)
+
+	components.enableEpochsHandler.CurrentEpochField = 10
+	_, err = components.reclaimFunc.ProcessBuiltinFunction(components.loadAccount(other), acntBorrower, createReclaimRentedNFTInput(other, borrower, tokenName, 1))
+	assert.Equal(t, ErrCallerIsNotRentalOwner, err)
+
+	vmOutput, err := components.reclaimFunc.ProcessBuiltinFunction(acntOwner, acntBorrower, createReclaimRentedNFTInput(owner, borrower, tokenName, 1))
+	require.Nil(t, err)
+	assert.Equal(t, uint64(90), vmOutput.GasRemaining)
+	require.Len(t, vmOutput.Logs, 1)
+	assert.Equal(t, []byte(vmcommon.BuiltInFunctionDCTReclaimRentedNFT), vmOutput.Logs[0].Identifier)
+
+	assert.Nil(t, components.getToken(borrower, tokenName, 1))
+	returnedToken := components.getToken(owner, tokenName, 1)
+	require.NotNil(t, returnedToken)
+	assert.Equal(t, big.NewInt(1), returnedToken.Value)
+	returnedMetadata := DCTUserMetadataFromBytes(returnedToken.Properties)
+	assert.False(t, returnedMetadata.IsRented())
+}
+
+func TestDCTReclaimRentedNFT_ProcessBuiltinFunctionCrossShard(t *testing.T) {
+	t.Parallel()
+
+	owner := bytes.Repeat([]byte{1}, 32)
+	owner[31] = 1
+	borrower := bytes.Repeat([]byte{2}, 32)
+	borrower[31] = 0
+	tokenName := []byte("NFT-abcdef")
+
+	t.Run("sender shard should only consume gas", func(t *testing.T) {
+		t.Parallel()
+
+		components := createRentalTestComponents(1)
+		vmOutput, err := components.reclaimFunc.ProcessBuiltinFunction(components.loadAccount(owner), nil, createReclaimRentedNFTInput(owner, borrower, tokenName, 1))
+		require.Nil(t, err)
+		assert.Equal(t, uint64(90), vmOutput.GasRemaining)
+		assert.Equal(t, 0, len(vmOutput.OutputAccounts))
+	})
+	t.Run("destination shard should return the NFT to the owner", func(t *testing.T) {
+		t.Parallel()
+
+		components := createRentalTestComponents(0)
+		components.enableEpochsHandler.CurrentEpochField = 10
+		acntBorrower := components.loadAccount(borrower)
+		rentalMetadata := DCTUserMetadata{RentedFrom: owner, ReturnEpoch: 10}
+		rentedToken := &dct.DCToken{
+			Type:          uint32(core.NonFungible),
+			Value:         big.NewInt(1),
+			Properties:    rentalMetadata.ToBytes(),
+			TokenMetaData: &dct.MetaData{Nonce: 1, Hash: []byte("NFT hash")},
+		}
+		marshalledToken, _ := components.marshaller.Marshal(rentedToken)
+		_ = acntBorrower.AccountDataHandler().SaveKeyValue(dctkeys.ComputeDCTNFTTokenKey(append(keyPrefix, tokenName...), 1), marshalledToken)
+
+		vmOutput, err := components.reclaimFunc.ProcessBuiltinFunction(nil, acntBorrower, createReclaimRentedNFTInput(owner, borrower, tokenName, 1))
+		require.Nil(t, err)
+		assert.Equal(t, uint64(100), vmOutput.GasRemaining)
+		assert.Nil(t, components.getToken(borrower, tokenName, 1))
+		assert.Equal(t, big.NewInt(-1), components.liquidity)
+
+		outAcc := vmOutput.OutputAccounts[string(owner)]
+		require.NotNil(t, outAcc)
+		require.Len(t, outAcc.OutputTransfers, 1)
+		returnedToken := &dct.DCToken{
+			Type:          rentedToken.Type,
+			Value:         big.NewInt(1),
+			TokenMetaData: rentedToken.TokenMetaData,
+		}
+		marshalledToken, _ = components.marshaller.Marshal(returnedToken)
+		assert.Equal(t, computeNFTTransferData(tokenName, 1, big.NewInt(1), marshalledToken), outAcc.OutputTransfers[0].Data)
+		assert.Equal(t, borrower, outAcc.OutputTransfers[0].SenderAddress)
+	})
+}
@@ -0,0 +1,265 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/big"
+	"sync"
+
+	"github.com/Reshusk23/sr-me-core/core"
+	"github.com/Reshusk23/sr-me-core/core/check"
+	"github.com/Reshusk23/sr-me-core/data/dct"
+	"github.com/Reshusk23/sr-me-core/data/vm"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/validation"
+)
+
+const numArgumentsRentNFT = 4
+
+type dctRentNFT struct {
+	baseActiveHandler
+	baseAddressLengthHandler
+	freezeAccountChecker
+	transferInterceptorChecker
+	keyPrefix             []byte
+	marshaller            vmcommon.Marshalizer
+	globalSettingsHandler vmcommon.ExtendedDCTGlobalSettingsHandler
+	rolesHandler          vmcommon.DCTRoleHandler
+	accounts              vmcommon.AccountsAdapter
+	shardCoordinator      vmcommon.Coordinator
+	dctStorageHandler     vmcommon.DCTNFTStorageHandler
+	enableEpochsHandler   vmcommon.EnableEpochsHandler
+	funcGasCost           uint64
+	mutExecution          sync.RWMutex
+}
+
+// ArgsNewDCTRentNFT defines the argument list for new dct rent NFT built in function
+type ArgsNewDCTRentNFT struct {
+	Config
+	FuncGasCost uint64
+}
+
+// NewDCTRentNFTFunc returns the built-in function component which lends an NFT until a return epoch
+func NewDCTRentNFTFunc(args ArgsNewDCTRentNFT) (*dctRentNFT, error) {
+	err := args.checkComponents(vmcommon.BuiltInFunctionDCTRentNFT, requireMarshalizer|requireAccounts|requireShardCoordinator|
+		requireGlobalSettingsHandler|requireRolesHandler|requireDCTStorageHandler|requireEnableEpochsHandler)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &dctRentNFT{
+		keyPrefix:             []byte(baseDCTKeyPrefix),
+		marshaller:            args.Marshalizer,
+		globalSettingsHandler: args.GlobalSettingsHandler,
+		rolesHandler:          args.RolesHandler,
+		accounts:              args.Accounts,
+		shardCoordinator:      args.ShardCoordinator,
+		dctStorageHandler:     args.DCTStorageHandler,
+		enableEpochsHandler:   args.EnableEpochsHandler,
+		funcGasCost:           args.FuncGasCost,
+	}
+
+	e.baseActiveHandler.activeHandler = args.EnableEpochsHandler.IsNFTRentalFlagEnabled
+
+	return e, nil
+}
+
+// SetNewGasConfig is called whenever gas cost is changed
+func (e *dctRentNFT) SetNewGasConfig(gasCost *vmcommon.GasCost) {
+	if gasCost == nil {
+		return
+	}
+
+	e.mutExecution.Lock()
+	e.funcGasCost = gasCost.BuiltInCost.DCTNFTTransfer
+	e.mutExecution.Unlock()
+}
+
+// ProcessBuiltinFunction resolves DCT rent NFT function call, executed on the shard of the owner
+// Requires 4 arguments:
+// arg0 - token identifier
+// arg1 - nonce
+// arg2 - address of the borrower
+// arg3 - epoch starting with which the owner can reclaim the NFT
+func (e *dctRentNFT) ProcessBuiltinFunction(
+	acntSnd, _ vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	e.mutExecution.RLock()
+	defer e.mutExecution.RUnlock()
+
+	err := checkBasicDCTArguments(vmInput)
+	if err != nil {
+		return nil, err
+	}
+	if len(vmInput.Arguments) != numArgumentsRentNFT {
+		return nil, ErrInvalidArguments
+	}
+	if !bytes.Equal(vmInput.CallerAddr, vmInput.RecipientAddr) {
+		return nil, ErrInvalidRcvAddr
+	}
+	if check.IfNil(acntSnd) {
+		return nil, ErrNilUserAccount
+	}
+	if vmInput.GasProvided < e.funcGasCost {
+		return nil, ErrNotEnoughGas
+	}
+
+	err = checkFunctionArguments(
+		vmInput.Arguments,
+		validation.RequireUint64(1),
+		validation.RequireAddress(2, e.getAddressLength(vmInput)),
+		validation.RequireUint64(3),
+	)
+	if err != nil {
+		return nil, err
+	}
+	dstAddress := vmInput.Arguments[2]
+	if bytes.Equal(dstAddress, vmInput.CallerAddr) {
+		return nil, fmt.Errorf("%w, can not rent to self", ErrInvalidArguments)
+	}
+	returnEpoch, err := uint64Argument(vmInput.Arguments, 3)
+	if err != nil {
+		return nil, err
+	}
+	if returnEpoch <= uint64(e.enableEpochsHandler.GetCurrentEpoch()) || returnEpoch > math.MaxUint32 {
+		return nil, ErrInvalidReturnEpoch
+	}
+
+	tickerID := vmInput.Arguments[0]
+	dctTokenKey := append(e.keyPrefix, tickerID...)
+	nonce, err := uint64Argument(vmInput.Arguments, 1)
+	if err != nil {
+		return nil, err
+	}
+	if nonce == 0 {
+		return nil, ErrNFTDoesNotHaveMetadata
+	}
+	dctData, err := e.dctStorageHandler.GetDCTNFTTokenOnSender(acntSnd, dctTokenKey, nonce)
+	if err != nil {
+		return nil, err
+	}
+	if dctData.Type != uint32(core.NonFungible) || dctData.Value.Cmp(oneValue) != 0 {
+		return nil, ErrInvalidNFTQuantity
+	}
+	err = checkNFTIsNotRented(dctData, vmInput.ReturnCallAfterError)
+	if err != nil {
+		return nil, err
+	}
+	err = checkIfTransferCanHappenWithSoulbound(dctTokenKey, e.globalSettingsHandler, acntSnd, vmInput.ReturnCallAfterError)
+	if err != nil {
+		return nil, err
+	}
+	err = checkIfTransferCanHappenWithLimitedTransfer(tickerID, dctTokenKey, vmInput.CallerAddr, dstAddress, e.globalSettingsHandler, e.rolesHandler, acntSnd, nil, vmInput.ReturnCallAfterError)
+	if err != nil {
+		return nil, err
+	}
+	err = e.checkAccountIsNotFrozen(vmInput.CallerAddr, vmInput.ReturnCallAfterError)
+	if err != nil {
+		return nil, err
+	}
+	err = e.checkTransferIsAllowed(vmInput.CallerAddr, dstAddress, tickerID, nonce, oneValue, vmInput.ReturnCallAfterError)
+	if err != nil {
+		return nil, err
+	}
+
+	rentedData := &dct.DCToken{
+		Type:          dctData.Type,
+		Value:         big.NewInt(1),
+		TokenMetaData: dctData.TokenMetaData,
+	}
+	rentalMetadata := DCTUserMetadata{
+		RentedFrom:  vmInput.CallerAddr,
+		ReturnEpoch: uint32(returnEpoch),
+	}
+	rentedData.Properties = rentalMetadata.ToBytes()
+
+	dctData.Value = big.NewInt(0)
+	_, err = e.dctStorageHandler.SaveDCTNFTToken(acntSnd.AddressBytes(), acntSnd, dctTokenKey, nonce, dctData, false, vmInput.ReturnCallAfterError)
+	if err != nil {
+		return nil, err
+	}
+
+	vmOutput := &vmcommon.VMOutput{
+		ReturnCode:   vmcommon.Ok,
+		GasRemaining: vmInput.GasProvided - e.funcGasCost,
+	}
+	err = e.addRentedNFTToBorrower(vmInput, vmOutput, rentedData, dctTokenKey, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	addDCTEntryInVMOutput(vmOutput, []byte(vmcommon.BuiltInFunctionDCTRentNFT), tickerID, nonce, oneValue, vmInput.CallerAddr, dstAddress, vmInput.Arguments[3])
+
+	return vmOutput, nil
+}
+
+func (e *dctRentNFT) addRentedNFTToBorrower(
+	vmInput *vmcommon.ContractCallInput,
+	vmOutput *vmcommon.VMOutput,
+	rentedData *dct.DCToken,
+	dctTokenKey []byte,
+	nonce uint64,
+) error {
+	dstAddress := vmInput.Arguments[2]
+	if e.shardCoordinator.SelfId() != e.shardCoordinator.ComputeId(dstAddress) {
+		return e.sendRentedNFTCrossShard(vmInput, vmOutput, rentedData, dctTokenKey, nonce)
+	}
+
+	accountHandler, err := e.accounts.LoadAccount(dstAddress)
+	if err != nil {
+		return err
+	}
+	borrower, ok := accountHandler.(vmcommon.UserAccountHandler)
+	if !ok {
+		return ErrWrongTypeAssertion
+	}
+
+	_, err = e.dctStorageHandler.SaveDCTNFTToken(vmInput.CallerAddr, borrower, dctTokenKey, nonce, rentedData, false, vmInput.ReturnCallAfterError)
+	if err != nil {
+		return err
+	}
+
+	return e.accounts.SaveAccount(borrower)
+}
+
+func (e *dctRentNFT) sendRentedNFTCrossShard(
+	vmInput *vmcommon.ContractCallInput,
+	vmOutput *vmcommon.VMOutput,
+	rentedData *dct.DCToken,
+	dctTokenKey []byte,
+	nonce uint64,
+) error {
+	err := e.dctStorageHandler.AddToLiquiditySystemAcc(dctTokenKey, nonce, big.NewInt(-1))
+	if err != nil {
+		return err
+	}
+
+	marshalledToken, err := e.marshaller.Marshal(rentedData)
+	if err != nil {
+		return err
+	}
+
+	dstAddress := vmInput.Arguments[2]
+	outTransfer := vmcommon.OutputTransfer{
+		Value:         big.NewInt(0),
+		GasLocked:     vmInput.GasLocked,
+		Data:          computeNFTTransferData(vmInput.Arguments[0], nonce, oneValue, marshalledToken),
+		CallType:      vm.DirectCall,
+		SenderAddress: vmInput.CallerAddr,
+	}
+	vmOutput.OutputAccounts = map[string]*vmcommon.OutputAccount{
+		string(dstAddress): {
+			Address:         dstAddress,
+			OutputTransfers: []vmcommon.OutputTransfer{outTransfer},
+		},
+	}
+
+	return nil
+}
+
+// IsInterfaceNil returns true if underlying object in nil
+func (e *dctRentNFT) IsInterfaceNil() bool {
+	return e == nil
+}
@@ -0,0 +1,386 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/Reshusk23/sr-me-core/core"
+	"github.com/Reshusk23/sr-me-core/core/check"
+	"github.com/Reshusk23/sr-me-core/data/dct"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/dctkeys"
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/Reshusk23/sr-vm-common-go/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type rentalTestComponents struct {
+	rentFunc            *dctRentNFT
+	reclaimFunc         *dctReclaimRentedNFT
+	accounts            vmcommon.AccountsAdapter
+	marshaller          vmcommon.Marshalizer
+	liquidity           *big.Int
+	enableEpochsHandler *mock.EnableEpochsHandlerStub
+}
+
+func createRentalTestComponents(selfShard uint32) *rentalTestComponents {
+	shardCoordinator := mock.NewMultiShardsCoordinatorMock(2)
+	shardCoordinator.CurrentShard = selfShard
+	shardCoordinator.ComputeIdCalled = func(address []byte) uint32 {
+		return uint32(address[len(address)-1])
+	}
+	mapAccounts := make(map[string]vmcommon.UserAccountHandler)
+	accounts := &mock.AccountsStub{
+		LoadAccountCalled: func(address []byte) (vmcommon.AccountHandler, error) {
+			_, ok := mapAccounts[string(address)]
+			if !ok {
+				mapAccounts[string(address)] = mock.NewUserAccount(address)
+			}
+			return mapAccounts[string(address)], nil
+		},
+	}
+	components := &rentalTestComponents{
+		accounts:   accounts,
+		marshaller: &mock.MarshalizerMock{},
+		liquidity:  big.NewInt(0),
+	}
+	dctStorageHandler := &mock.DCTNFTStorageHandlerStub{}
+	realStorageHandler := createNewDCTDataStorageHandlerWithArgs(&mock.GlobalSettingsHandlerStub{}, accounts, &mock.EnableEpochsHandlerStub{})
+	dctStorageHandler.GetDCTNFTTokenOnSenderCalled = realStorageHandler.GetDCTNFTTokenOnSender
+	dctStorageHandler.SaveDCTNFTTokenCalled = realStorageHandler.SaveDCTNFTToken
+	dctStorageHandler.AddToLiquiditySystemAccCalled = func(_ []byte, _ uint64, transferValue *big.Int) error {
+		components.liquidity.Add(components.liquidity, transferValue)
+		return nil
+	}
+
+	enableEpochsHandler := &mock.EnableEpochsHandlerStub{IsNFTRentalFlagEnabledField: true}
+	components.enableEpochsHandler = enableEpochsHandler
+	components.rentFunc, _ = NewDCTRentNFTFunc(ArgsNewDCTRentNFT{
+		Config: Config{
+			Marshalizer:           components.marshaller,
+			Accounts:              accounts,
+			ShardCoordinator:      shardCoordinator,
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			DCTStorageHandler:     dctStorageHandler,
+			EnableEpochsHandler:   enableEpochsHandler,
+		},
+		FuncGasCost: 10,
+	})
+	components.reclaimFunc, _ = NewDCTReclaimRentedNFTFunc(ArgsNewDCTReclaimRentedNFT{
+		Config: Config{
+			Marshalizer:         components.marshaller,
+			DCTStorageHandler:   dctStorageHandler,
+			EnableEpochsHandler: enableEpochsHandler,
+		},
+		FuncGasCost: 10,
+	})
+
+	return components
+}
+
+func (components *rentalTestComponents) loadAccount(address []byte) vmcommon.UserAccountHandler {
+	acnt, _ := components.accounts.LoadAccount(address)
+	return acnt.(vmcommon.UserAccountHandler)
+}
+
+func (components *rentalTestComponents) getToken(address []byte, tokenName []byte, nonce uint64) *dct.DCToken {
+	dctNFTTokenKey := dctkeys.ComputeDCTNFTTokenKey(append(keyPrefix, tokenName...), nonce)
+	marshalledData, _, _ := components.loadAccount(address).AccountDataHandler().RetrieveValue(dctNFTTokenKey)
+	if len(marshalledData) == 0 {
+		return nil
+	}
+
+	dctData := &dct.DCToken{}
+	_ = components.marshaller.Unmarshal(dctData, marshalledData)
+	return dctData
+}
+
+func createRentNFTInput(owner []byte, borrower []byte, tokenName []byte, nonce uint64, returnEpoch uint64) *vmcommon.ContractCallInput {
+	return &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  owner,
+			CallValue:   big.NewInt(0),
+			GasProvided: 100,
+			Arguments: [][]byte{
+				tokenName,
+				big.NewInt(0).SetUint64(nonce).Bytes(),
+				borrower,
+				big.NewInt(0).SetUint64(returnEpoch).Bytes(),
+			},
+		},
+		RecipientAddr: owner,
+	}
+}
+
+func TestNewDCTRentNFTFunc(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil marshaller should error", func(t *testing.T) {
+		t.Parallel()
+
+		e, err := NewDCTRentNFTFunc(ArgsNewDCTRentNFT{
+			Config: Config{
+				Accounts:              &mock.AccountsStub{},
+				ShardCoordinator:      &mock.ShardCoordinatorStub{},
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+				DCTStorageHandler:     &mock.DCTNFTStorageHandlerStub{},
+				EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			},
+			FuncGasCost: 10,
+		})
+		assert.True(t, check.IfNil(e))
+		assert.ErrorIs(t, err, ErrNilMarshalizer)
+	})
+	t.Run("nil enable epochs handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		e, err := NewDCTRentNFTFunc(ArgsNewDCTRentNFT{
+			Config: Config{
+				Marshalizer:           &mock.MarshalizerMock{},
+				Accounts:              &mock.AccountsStub{},
+				ShardCoordinator:      &mock.ShardCoordinatorStub{},
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+				DCTStorageHandler:     &mock.DCTNFTStorageHandlerStub{},
+			},
+			FuncGasCost: 10,
+		})
+		assert.True(t, check.IfNil(e))
+		assert.ErrorIs(t, err, ErrNilEnableEpochsHandler)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		enableEpochsHandler := &mock.EnableEpochsHandlerStub{}
+		e, err := NewDCTRentNFTFunc(ArgsNewDCTRentNFT{
+			Config: Config{
+				Marshalizer:           &mock.MarshalizerMock{},
+				Accounts:              &mock.AccountsStub{},
+				ShardCoordinator:      &mock.ShardCoordinatorStub{},
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+				DCTStorageHandler:     &mock.DCTNFTStorageHandlerStub{},
+				EnableEpochsHandler:   enableEpochsHandler,
+			},
+			FuncGasCost: 10,
+		})
+		assert.Nil(t, err)
+		assert.False(t, check.IfNil(e))
+
+		assert.False(t, e.IsActive())
+		enableEpochsHandler.IsNFTRentalFlagEnabledField = true
+		assert.True(t, e.IsActive())
+	})
+}
+
+func TestDCTRentNFT_SetNewGasConfig(t *testing.T) {
+	t.Parallel()
+
+	components := createRentalTestComponents(0)
+	components.rentFunc.SetNewGasConfig(nil)
+	assert.Equal(t, uint64(10), components.rentFunc.funcGasCost)
+
+	gasCost := createMockGasCost()
+	components.rentFunc.SetNewGasConfig(&gasCost)
+	assert.Equal(t, gasCost.BuiltInCost.DCTNFTTransfer, components.rentFunc.funcGasCost)
+}
+
+func TestDCTRentNFT_ProcessBuiltinFunctionErrors(t *testing.T) {
+	t.Parallel()
+
+	owner := bytes.Repeat([]byte{1}, 32)
+	owner[31] = 0
+	borrower := bytes.Repeat([]byte{2}, 32)
+	borrower[31] = 0
+	tokenName := []byte("NFT-abcdef")
+
+	components := createRentalTestComponents(0)
+	components.enableEpochsHandler.CurrentEpochField = 5
+	acntOwner := components.loadAccount(owner)
+	createDCTNFTToken(tokenName, core.NonFungible, 1, big.NewInt(1), components.marshaller, acntOwner)
+	createDCTNFTToken(tokenName, core.NonFungible, 2, big.NewInt(5), components.marshaller, acntOwner)
+
+	_, err := components.rentFunc.ProcessBuiltinFunction(acntOwner, nil, nil)
+	assert.Equal(t, ErrNilVmInput, err)
+
+	input := createRentNFTInput(owner, borrower, tokenName, 1, 10)
+	input.Arguments = input.Arguments[:3]
+	_, err = components.rentFunc.ProcessBuiltinFunction(acntOwner, nil, input)
+	assert.Equal(t, ErrInvalidArguments, err)
+
+	input = createRentNFTInput(owner, borrower, tokenName, 1, 10)
+	input.RecipientAddr = borrower
+	_, err = components.rentFunc.ProcessBuiltinFunction(acntOwner, nil, input)
+	assert.Equal(t, ErrInvalidRcvAddr, err)
+
+	_, err = components.rentFunc.ProcessBuiltinFunction(nil, nil, createRentNFTInput(owner, borrower, tokenName, 1, 10))
+	assert.Equal(t, ErrNilUserAccount, err)
+
+	input = createRentNFTInput(owner, borrower, tokenName, 1, 10)
+	input.GasProvided = 1
+	_, err = components.rentFunc.ProcessBuiltinFunction(acntOwner, nil, input)
+	assert.Equal(t, ErrNotEnoughGas, err)
+
+	_, err = components.rentFunc.ProcessBuiltinFunction(acntOwner, nil, createRentNFTInput(owner, owner, tokenName, 1, 10))
+	assert.ErrorIs(t, err, ErrInvalidArguments)
+
+	input = createRentNFTInput(owner, borrower, tokenName, 1, 10)
+	input.Arguments[2] = borrower[:5]
+	_, err = components.rentFunc.ProcessBuiltinFunction(acntOwner, nil, input)
+	assert.ErrorIs(t, err, validation.ErrInvalidAddress)
+	assert.Contains(t, err.Error(), "argument 2")
+
+	input = createRentNFTInput(owner, borrower, tokenName, 1, 10)
+	input.Arguments[3] = make([]byte, 9)
+	_, err = components.rentFunc.ProcessBuiltinFunction(acntOwner, nil, input)
+	assert.ErrorIs(t, err, validation.ErrValueTooLong)
+	assert.Contains(t, err.Error(), "argument 3")
+
+	_, err = components.rentFunc.ProcessBuiltinFunction(acntOwner, nil, createRentNFTInput(owner, borrower, tokenName, 1, 5))
+	assert.Equal(t, ErrInvalidReturnEpoch, err)
+
+	_, err = components.rentFunc.ProcessBuiltinFunction(acntOwner, nil, createRentNFTInput(owner, borrower, tokenName, 0, 10))
+	assert.Equal(t, ErrNFTDoesNotHaveMetadata, err)
+
+	_, err = components.rentFunc.ProcessBuiltinFunction(acntOwner, nil, createRentNFTInput(owner, borrower, tokenName, 2, 10))
+	assert.Equal(t, ErrInvalidNFTQuantity, err)
+}
+
+func TestDCTRentNFT_ProcessBuiltinFunctionSoulboundShouldErr(t *testing.T) {
+	t.Parallel()
+
+	owner := bytes.Repeat([]byte{1}, 32)
+	owner[31] = 0
+	borrower := bytes.Repeat([]byte{2}, 32)
+	borrower[31] = 0
+	tokenName := []byte("NFT-abcdef")
+
+	components := createRentalTestComponents(0)
+	components.rentFunc.globalSettingsHandler = &mock.GlobalSettingsHandlerStub{
+		IsSoulboundCalled: func(token []byte) bool {
+			return true
+		},
+	}
+	acntOwner := components.loadAccount(owner)
+	createDCTNFTToken(tokenName, core.NonFungible, 1, big.NewInt(1), components.marshaller, acntOwner)
+
+	_, err := components.rentFunc.ProcessBuiltinFunction(acntOwner, nil, createRentNFTInput(owner, borrower, tokenName, 1, 10))
+	assert.Equal(t, ErrTokenNotTransferable, err)
+}
+
+func TestDCTRentNFT_ProcessBuiltinFunctionFrozenOrInterceptedOwner(t *testing.T) {
+	t.Parallel()
+
+	owner := bytes.Repeat([]byte{1}, 32)
+	owner[31] = 0
+	borrower := bytes.Repeat([]byte{2}, 32)
+	borrower[31] = 0
+	tokenName := []byte("NFT-abcdef")
+
+	components := createRentalTestComponents(0)
+	acntOwner := components.loadAccount(owner)
+	createDCTNFTToken(tokenName, core.NonFungible, 1, big.NewInt(1), components.marshaller, acntOwner)
+
+	_ = components.rentFunc.SetFreezeAccountHandler(&mock.FreezeAccountHandlerStub{
+		IsAccountFrozenCalled: func(address []byte) bool {
+			return bytes.Equal(address, owner)
+		},
+	})
+	_, err := components.rentFunc.ProcessBuiltinFunction(acntOwner, nil, createRentNFTInput(owner, borrower, tokenName, 1, 10))
+	assert.Equal(t, ErrAccountIsFrozen, err)
+
+	components = createRentalTestComponents(0)
+	acntOwner = components.loadAccount(owner)
+	createDCTNFTToken(tokenName, core.NonFungible, 1, big.NewInt(1), components.marshaller, acntOwner)
+
+	expectedErr := errors.New("rental vetoed")
+	_ = components.rentFunc.SetTransferInterceptor(&mock.TransferInterceptorStub{
+		PreTransferCalled: func(sender []byte, receiver []byte, token []byte, nonce uint64, amount *big.Int) error {
+			assert.Equal(t, owner, sender)
+			assert.Equal(t, borrower, receiver)
+			assert.Equal(t, tokenName, token)
+			assert.Equal(t, uint64(1), nonce)
+			assert.Equal(t, big.NewInt(1), amount)
+			return expectedErr
+		},
+	})
+	_, err = components.rentFunc.ProcessBuiltinFunction(acntOwner, nil, createRentNFTInput(owner, borrower, tokenName, 1, 10))
+	assert.Equal(t, expectedErr, err)
+	assert.NotNil(t, components.getToken(owner, tokenName, 1))
+	assert.Nil(t, components.getToken(borrower, tokenName, 1))
+}
+
+func TestDCTRentNFT_ProcessBuiltinFunctionSameShard(t *testing.T) {
+	t.Parallel()
+
+	owner := bytes.Repeat([]byte{1}, 32)
+	owner[31] = 0
+	borrower := bytes.Repeat([]byte{2}, 32)
+	borrower[31] = 0
+	tokenName := []byte("NFT-abcdef")
+
+	components := createRentalTestComponents(0)
+	acntOwner := components.loadAccount(owner)
+	createDCTNFTToken(tokenName, core.NonFungible, 1, big.NewInt(1), components.marshaller, acntOwner)
+
+	vmOutput, err := components.rentFunc.ProcessBuiltinFunction(acntOwner, nil, createRentNFTInput(owner, borrower, tokenName, 1, 10))
+	require.Nil(t, err)
+	assert.Equal(t, uint64(90), vmOutput.GasRemaining)
+	assert.Equal(t, 0, len(vmOutput.OutputAccounts))
+	require.Len(t, vmOutput.Logs, 1)
+	assert.Equal(t, []byte(vmcommon.BuiltInFunctionDCTRentNFT), vmOutput.Logs[0].Identifier)
+
+	assert.Nil(t, components.getToken(owner, tokenName, 1))
+	rentedToken := components.getToken(borrower, tokenName, 1)
+	require.NotNil(t, rentedToken)
+	assert.Equal(t, big.NewInt(1), rentedToken.Value)
+	rentalMetadata := DCTUserMetadataFromBytes(rentedToken.Properties)
+	assert.Equal(t, owner, rentalMetadata.RentedFrom)
+	assert.Equal(t, uint32(10), rentalMetadata.ReturnEpoch)
+	assert.Equal(t, 0, components.liquidity.Sign())
+
+	_, err = components.rentFunc.ProcessBuiltinFunction(components.loadAccount(borrower), nil, createRentNFTInput(borrower, owner, tokenName, 1, 10))
+	assert.Equal(t, ErrRentedNFTNotTransferable, err)
+}
+
+func TestDCTRentNFT_ProcessBuiltinFunctionCrossShard(t *testing.T) {
+	t.Parallel()
+
+	owner := bytes.Repeat([]byte{1}, 32)
+	owner[31] = 0
+	borrower := bytes.Repeat([]byte{2}, 32)
+	borrower[31] = 1
+	tokenName := []byte("NFT-abcdef")
+
+	components := createRentalTestComponents(0)
+	acntOwner := components.loadAccount(owner)
+	createDCTNFTToken(tokenName, core.NonFungible, 1, big.NewInt(1), components.marshaller, acntOwner)
+
+	vmOutput, err := components.rentFunc.ProcessBuiltinFunction(acntOwner, nil, createRentNFTInput(owner, borrower, tokenName, 1, 10))
+	require.Nil(t, err)
+	assert.Nil(t, components.getToken(owner, tokenName, 1))
+	assert.Equal(t, big.NewInt(-1), components.liquidity)
+
+	outAcc := vmOutput.OutputAccounts[string(borrower)]
+	require.NotNil(t, outAcc)
+	require.Len(t, outAcc.OutputTransfers, 1)
+	rentalMetadata := DCTUserMetadata{RentedFrom: owner, ReturnEpoch: 10}
+	rentedToken := &dct.DCToken{
+		Type:       uint32(core.NonFungible),
+		Value:      big.NewInt(1),
+		Properties: rentalMetadata.ToBytes(),
+		TokenMetaData: &dct.MetaData{
+			URIs:  [][]byte{[]byte("uri")},
+			Nonce: 1,
+			Hash:  []byte("NFT hash"),
+		},
+	}
+	marshalledToken, _ := components.marshaller.Marshal(rentedToken)
+	assert.Equal(t, computeNFTTransferData(tokenName, 1, big.NewInt(1), marshalledToken), outAcc.OutputTransfers[0].Data)
+	assert.Equal(t, owner, outAcc.OutputTransfers[0].SenderAddress)
+}
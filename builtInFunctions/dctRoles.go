@@ -9,28 +9,40 @@ import (
 	"github.com/Reshusk23/sr-me-core/core/check"
 	"github.com/Reshusk23/sr-me-core/data/dct"
 	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/protectedkeys"
 )
 
-var roleKeyPrefix = []byte(core.ProtectedKeyPrefix + core.DCTRoleIdentifier + core.DCTKeyIdentifier)
+var roleKeyPrefix = []byte(protectedkeys.DCTRolePrefix)
 
 type dctRoles struct {
 	baseAlwaysActiveHandler
+	baseSystemAddressesHandler
 	set        bool
 	marshaller vmcommon.Marshalizer
+	nonceCache vmcommon.LatestNonceCache
+}
+
+// ArgsNewDCTRoles defines the argument list for new dct set/unset roles built in functions
+type ArgsNewDCTRoles struct {
+	Config
+	Set bool
 }
 
 // NewDCTRolesFunc returns the dct change roles built-in function component
-func NewDCTRolesFunc(
-	marshaller vmcommon.Marshalizer,
-	set bool,
-) (*dctRoles, error) {
-	if check.IfNil(marshaller) {
-		return nil, ErrNilMarshalizer
+func NewDCTRolesFunc(args ArgsNewDCTRoles) (*dctRoles, error) {
+	function := core.BuiltInFunctionUnSetDCTRole
+	if args.Set {
+		function = core.BuiltInFunctionSetDCTRole
+	}
+	err := args.checkComponents(function, requireMarshalizer)
+	if err != nil {
+		return nil, err
 	}
 
 	e := &dctRoles{
-		set:        set,
-		marshaller: marshaller,
+		set:        args.Set,
+		marshaller: args.Marshalizer,
+		nonceCache: &disabledLatestNonceCache{},
 	}
 
 	return e, nil
@@ -40,6 +52,16 @@ func NewDCTRolesFunc(
 func (e *dctRoles) SetNewGasConfig(_ *vmcommon.GasCost) {
 }
 
+// SetLatestNonceCache sets the latest nonce cache which is updated whenever the latest nonce is changed
+func (e *dctRoles) SetLatestNonceCache(nonceCache vmcommon.LatestNonceCache) error {
+	if check.IfNil(nonceCache) {
+		return ErrNilLatestNonceCache
+	}
+
+	e.nonceCache = nonceCache
+	return nil
+}
+
 // ProcessBuiltinFunction resolves DCT change roles function call
 func (e *dctRoles) ProcessBuiltinFunction(
 	_, acntDst vmcommon.UserAccountHandler,
@@ -49,16 +71,18 @@ func (e *dctRoles) ProcessBuiltinFunction(
 	if err != nil {
 		return nil, err
 	}
-	if !bytes.Equal(vmInput.CallerAddr, core.DCTSCAddress) {
+	if !e.getSystemAddresses().IsDCTSCAddress(vmInput.CallerAddr) {
 		return nil, ErrAddressIsNotDCTSystemSC
 	}
 	if check.IfNil(acntDst) {
 		return nil, ErrNilUserAccount
 	}
 
+	// the latest nonce and the roles must be changed together, so they are buffered until the roles are saved
+	trackableAcntDst := newTrackableAccount(acntDst)
 	dctTokenRoleKey := append(roleKeyPrefix, vmInput.Arguments[0]...)
 
-	roles, _, err := getDCTRolesForAcnt(e.marshaller, acntDst, dctTokenRoleKey)
+	roles, _, err := getDCTRolesForAcnt(e.marshaller, trackableAcntDst, dctTokenRoleKey)
 	if err != nil {
 		return nil, err
 	}
@@ -74,15 +98,21 @@ func (e *dctRoles) ProcessBuiltinFunction(
 			continue
 		}
 
-		err = saveLatestNonce(acntDst, vmInput.Arguments[0], computeStartNonce(vmInput.RecipientAddr))
+		err = saveLatestNonce(trackableAcntDst, vmInput.Arguments[0], computeStartNonce(vmInput.RecipientAddr))
 		if err != nil {
 			return nil, err
 		}
+		e.nonceCache.Remove(acntDst.AddressBytes(), vmInput.Arguments[0])
 
 		break
 	}
 
-	err = saveRolesToAccount(acntDst, dctTokenRoleKey, roles, e.marshaller)
+	err = saveRolesToAccount(trackableAcntDst, dctTokenRoleKey, roles, e.marshaller)
+	if err != nil {
+		return nil, err
+	}
+
+	err = trackableAcntDst.Commit()
 	if err != nil {
 		return nil, err
 	}
@@ -91,6 +121,7 @@ func (e *dctRoles) ProcessBuiltinFunction(
 
 	logData := append([][]byte{acntDst.AddressBytes()}, vmInput.Arguments[1:]...)
 	addDCTEntryInVMOutput(vmOutput, []byte(vmInput.Function), vmInput.Arguments[0], 0, big.NewInt(0), logData...)
+	addRolesChangedEntryInVMOutput(vmOutput, vmInput.Function, acntDst.AddressBytes(), vmInput.Arguments[0], e.set, vmInput.Arguments[1:])
 
 	return vmOutput, nil
 }
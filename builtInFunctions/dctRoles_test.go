@@ -10,6 +10,7 @@ import (
 	"github.com/Reshusk23/sr-me-core/core"
 	"github.com/Reshusk23/sr-me-core/data/dct"
 	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/dctkeys"
 	"github.com/Reshusk23/sr-vm-common-go/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -17,16 +18,20 @@ import (
 func TestNewDCTRolesFunc_NilMarshalizerShouldErr(t *testing.T) {
 	t.Parallel()
 
-	dctRolesF, err := NewDCTRolesFunc(nil, false)
+	dctRolesF, err := NewDCTRolesFunc(ArgsNewDCTRoles{
+		Set: false,
+	})
 
-	require.Equal(t, ErrNilMarshalizer, err)
+	require.ErrorIs(t, err, ErrNilMarshalizer)
 	require.Nil(t, dctRolesF)
 }
 
 func TestDctRoles_ProcessBuiltinFunction_NilVMInputShouldErr(t *testing.T) {
 	t.Parallel()
 
-	dctRolesF, _ := NewDCTRolesFunc(nil, false)
+	dctRolesF, _ := NewDCTRolesFunc(ArgsNewDCTRoles{
+		Set: false,
+	})
 
 	_, err := dctRolesF.ProcessBuiltinFunction(nil, &mock.UserAccountStub{}, nil)
 	require.Equal(t, ErrNilVmInput, err)
@@ -35,7 +40,12 @@ func TestDctRoles_ProcessBuiltinFunction_NilVMInputShouldErr(t *testing.T) {
 func TestDctRoles_ProcessBuiltinFunction_WrongCalledShouldErr(t *testing.T) {
 	t.Parallel()
 
-	dctRolesF, _ := NewDCTRolesFunc(nil, false)
+	dctRolesF, _ := NewDCTRolesFunc(ArgsNewDCTRoles{
+		Config: Config{
+			Marshalizer: &mock.MarshalizerMock{},
+		},
+		Set: false,
+	})
 
 	_, err := dctRolesF.ProcessBuiltinFunction(nil, &mock.UserAccountStub{}, &vmcommon.ContractCallInput{
 		VMInput: vmcommon.VMInput{
@@ -50,7 +60,12 @@ func TestDctRoles_ProcessBuiltinFunction_WrongCalledShouldErr(t *testing.T) {
 func TestDctRoles_ProcessBuiltinFunction_NilAccountDestShouldErr(t *testing.T) {
 	t.Parallel()
 
-	dctRolesF, _ := NewDCTRolesFunc(nil, false)
+	dctRolesF, _ := NewDCTRolesFunc(ArgsNewDCTRoles{
+		Config: Config{
+			Marshalizer: &mock.MarshalizerMock{},
+		},
+		Set: false,
+	})
 
 	_, err := dctRolesF.ProcessBuiltinFunction(nil, nil, &vmcommon.ContractCallInput{
 		VMInput: vmcommon.VMInput{
@@ -65,7 +80,12 @@ func TestDctRoles_ProcessBuiltinFunction_NilAccountDestShouldErr(t *testing.T) {
 func TestDctRoles_ProcessBuiltinFunction_GetRolesFailShouldErr(t *testing.T) {
 	t.Parallel()
 
-	dctRolesF, _ := NewDCTRolesFunc(&mock.MarshalizerMock{Fail: true}, false)
+	dctRolesF, _ := NewDCTRolesFunc(ArgsNewDCTRoles{
+		Config: Config{
+			Marshalizer: &mock.MarshalizerMock{Fail: true},
+		},
+		Set: false,
+	})
 
 	_, err := dctRolesF.ProcessBuiltinFunction(nil, &mock.UserAccountStub{
 		AccountDataHandlerCalled: func() vmcommon.AccountDataHandler {
@@ -89,7 +109,12 @@ func TestDctRoles_ProcessBuiltinFunction_GetRolesFailShouldWorkEvenIfAccntTrieIs
 	t.Parallel()
 
 	saveKeyWasCalled := false
-	dctRolesF, _ := NewDCTRolesFunc(&mock.MarshalizerMock{}, false)
+	dctRolesF, _ := NewDCTRolesFunc(ArgsNewDCTRoles{
+		Config: Config{
+			Marshalizer: &mock.MarshalizerMock{},
+		},
+		Set: false,
+	})
 
 	_, err := dctRolesF.ProcessBuiltinFunction(nil, &mock.UserAccountStub{
 		AccountDataHandlerCalled: func() vmcommon.AccountDataHandler {
@@ -118,7 +143,12 @@ func TestDctRoles_ProcessBuiltinFunction_SetRolesShouldWork(t *testing.T) {
 	t.Parallel()
 
 	marshaller := &mock.MarshalizerMock{}
-	dctRolesF, _ := NewDCTRolesFunc(marshaller, true)
+	dctRolesF, _ := NewDCTRolesFunc(ArgsNewDCTRoles{
+		Config: Config{
+			Marshalizer: marshaller,
+		},
+		Set: true,
+	})
 
 	acc := &mock.UserAccountStub{
 		AccountDataHandlerCalled: func() vmcommon.AccountDataHandler {
@@ -137,21 +167,87 @@ func TestDctRoles_ProcessBuiltinFunction_SetRolesShouldWork(t *testing.T) {
 			}
 		},
 	}
-	_, err := dctRolesF.ProcessBuiltinFunction(nil, acc, &vmcommon.ContractCallInput{
+	vmOutput, err := dctRolesF.ProcessBuiltinFunction(nil, acc, &vmcommon.ContractCallInput{
 		VMInput: vmcommon.VMInput{
 			CallValue:  big.NewInt(0),
 			CallerAddr: core.DCTSCAddress,
 			Arguments:  [][]byte{[]byte("1"), []byte(core.DCTRoleLocalMint)},
 		},
+		Function: core.BuiltInFunctionSetDCTRole,
 	})
 	require.Nil(t, err)
+	require.Len(t, vmOutput.Logs, 2)
+	require.Equal(t, &vmcommon.LogEntry{
+		Identifier: []byte(vmcommon.DCTRolesChangedIdentifier),
+		Address:    acc.AddressBytes(),
+		Topics:     [][]byte{[]byte("1"), []byte(vmcommon.DCTRolesChangedSet), []byte(core.DCTRoleLocalMint)},
+		Data:       []byte(core.BuiltInFunctionSetDCTRole),
+	}, vmOutput.Logs[1])
+}
+
+func TestDctRoles_ProcessBuiltinFunction_LogsDoNotDependOnTheRolesOrder(t *testing.T) {
+	t.Parallel()
+
+	marshaller := &mock.MarshalizerMock{}
+	dctRolesF, _ := NewDCTRolesFunc(ArgsNewDCTRoles{
+		Config: Config{
+			Marshalizer: marshaller,
+		},
+		Set: true,
+	})
+
+	setRoles := func(roles ...string) *vmcommon.VMOutput {
+		acc := &mock.UserAccountStub{
+			AccountDataHandlerCalled: func() vmcommon.AccountDataHandler {
+				return &mock.DataTrieTrackerStub{
+					RetrieveValueCalled: func(_ []byte) ([]byte, uint32, error) {
+						serializedRoles, err := marshaller.Marshal(&dct.DCTRoles{})
+						return serializedRoles, 0, err
+					},
+					SaveKeyValueCalled: func(_ []byte, _ []byte) error {
+						return nil
+					},
+				}
+			},
+		}
+		arguments := [][]byte{[]byte("1")}
+		for _, role := range roles {
+			arguments = append(arguments, []byte(role))
+		}
+
+		vmOutput, err := dctRolesF.ProcessBuiltinFunction(nil, acc, &vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallValue:  big.NewInt(0),
+				CallerAddr: core.DCTSCAddress,
+				Arguments:  arguments,
+			},
+			Function: core.BuiltInFunctionSetDCTRole,
+		})
+		require.Nil(t, err)
+		require.Len(t, vmOutput.Logs, 2)
+
+		return vmOutput
+	}
+
+	vmOutput := setRoles(core.DCTRoleLocalMint, core.DCTRoleLocalBurn)
+	vmOutputReversed := setRoles(core.DCTRoleLocalBurn, core.DCTRoleLocalMint)
+
+	require.Equal(t, []byte(core.BuiltInFunctionSetDCTRole), vmOutput.Logs[0].Identifier)
+	require.Equal(t, []byte(vmcommon.DCTRolesChangedIdentifier), vmOutput.Logs[1].Identifier)
+	require.Equal(t, [][]byte{[]byte("1"), []byte(vmcommon.DCTRolesChangedSet), []byte(core.DCTRoleLocalBurn), []byte(core.DCTRoleLocalMint)}, vmOutput.Logs[1].Topics)
+	require.Equal(t, vmOutput.Logs[1], vmOutputReversed.Logs[1])
 }
 
 func TestDctRoles_ProcessBuiltinFunction_SetRolesMultiNFT(t *testing.T) {
 	t.Parallel()
 
 	marshaller := &mock.MarshalizerMock{}
-	dctRolesF, _ := NewDCTRolesFunc(marshaller, true)
+	dctRolesF, _ := NewDCTRolesFunc(ArgsNewDCTRoles{
+		Config: Config{
+			Marshalizer: marshaller,
+		},
+		Set: true,
+	})
 
 	tokenID := []byte("tokenID")
 	roleKey := append(roleKeyPrefix, tokenID...)
@@ -173,7 +269,7 @@ func TestDctRoles_ProcessBuiltinFunction_SetRolesMultiNFT(t *testing.T) {
 						return nil
 					}
 
-					if bytes.Equal(key, getNonceKey(tokenID)) {
+					if bytes.Equal(key, dctkeys.ComputeNonceKey(tokenID)) {
 						saveNonceCalled = true
 						require.Equal(t, uint64(math.MaxUint64/256), big.NewInt(0).SetBytes(value).Uint64())
 					}
@@ -201,7 +297,12 @@ func TestDctRoles_ProcessBuiltinFunction_SaveFailedShouldErr(t *testing.T) {
 	t.Parallel()
 
 	marshaller := &mock.MarshalizerMock{}
-	dctRolesF, _ := NewDCTRolesFunc(marshaller, true)
+	dctRolesF, _ := NewDCTRolesFunc(ArgsNewDCTRoles{
+		Config: Config{
+			Marshalizer: marshaller,
+		},
+		Set: true,
+	})
 
 	localErr := errors.New("local err")
 	acc := &mock.UserAccountStub{
@@ -232,7 +333,12 @@ func TestDctRoles_ProcessBuiltinFunction_UnsetRolesDoesNotExistsShouldWork(t *te
 	t.Parallel()
 
 	marshaller := &mock.MarshalizerMock{}
-	dctRolesF, _ := NewDCTRolesFunc(marshaller, false)
+	dctRolesF, _ := NewDCTRolesFunc(ArgsNewDCTRoles{
+		Config: Config{
+			Marshalizer: marshaller,
+		},
+		Set: false,
+	})
 
 	acc := &mock.UserAccountStub{
 		AccountDataHandlerCalled: func() vmcommon.AccountDataHandler {
@@ -265,7 +371,12 @@ func TestDctRoles_ProcessBuiltinFunction_UnsetRolesShouldWork(t *testing.T) {
 	t.Parallel()
 
 	marshaller := &mock.MarshalizerMock{}
-	dctRolesF, _ := NewDCTRolesFunc(marshaller, false)
+	dctRolesF, _ := NewDCTRolesFunc(ArgsNewDCTRoles{
+		Config: Config{
+			Marshalizer: marshaller,
+		},
+		Set: false,
+	})
 
 	acc := &mock.UserAccountStub{
 		AccountDataHandlerCalled: func() vmcommon.AccountDataHandler {
@@ -286,21 +397,34 @@ func TestDctRoles_ProcessBuiltinFunction_UnsetRolesShouldWork(t *testing.T) {
 			}
 		},
 	}
-	_, err := dctRolesF.ProcessBuiltinFunction(nil, acc, &vmcommon.ContractCallInput{
+	vmOutput, err := dctRolesF.ProcessBuiltinFunction(nil, acc, &vmcommon.ContractCallInput{
 		VMInput: vmcommon.VMInput{
 			CallValue:  big.NewInt(0),
 			CallerAddr: core.DCTSCAddress,
 			Arguments:  [][]byte{[]byte("1"), []byte(core.DCTRoleLocalMint)},
 		},
+		Function: core.BuiltInFunctionUnSetDCTRole,
 	})
 	require.Nil(t, err)
+	require.Len(t, vmOutput.Logs, 2)
+	require.Equal(t, &vmcommon.LogEntry{
+		Identifier: []byte(vmcommon.DCTRolesChangedIdentifier),
+		Address:    acc.AddressBytes(),
+		Topics:     [][]byte{[]byte("1"), []byte(vmcommon.DCTRolesChangedUnset), []byte(core.DCTRoleLocalMint)},
+		Data:       []byte(core.BuiltInFunctionUnSetDCTRole),
+	}, vmOutput.Logs[1])
 }
 
 func TestDctRoles_CheckAllowedToExecuteNilAccountShouldErr(t *testing.T) {
 	t.Parallel()
 
 	marshaller := &mock.MarshalizerMock{}
-	dctRolesF, _ := NewDCTRolesFunc(marshaller, false)
+	dctRolesF, _ := NewDCTRolesFunc(ArgsNewDCTRoles{
+		Config: Config{
+			Marshalizer: marshaller,
+		},
+		Set: false,
+	})
 
 	err := dctRolesF.CheckAllowedToExecute(nil, []byte("ID"), []byte(core.DCTRoleLocalBurn))
 	require.Equal(t, ErrNilUserAccount, err)
@@ -310,7 +434,12 @@ func TestDctRoles_CheckAllowedToExecuteCannotGetDCTRole(t *testing.T) {
 	t.Parallel()
 
 	marshaller := &mock.MarshalizerMock{Fail: true}
-	dctRolesF, _ := NewDCTRolesFunc(marshaller, false)
+	dctRolesF, _ := NewDCTRolesFunc(ArgsNewDCTRoles{
+		Config: Config{
+			Marshalizer: marshaller,
+		},
+		Set: false,
+	})
 
 	err := dctRolesF.CheckAllowedToExecute(&mock.UserAccountStub{
 		AccountDataHandlerCalled: func() vmcommon.AccountDataHandler {
@@ -328,7 +457,12 @@ func TestDctRoles_CheckAllowedToExecuteIsNewNotAllowed(t *testing.T) {
 	t.Parallel()
 
 	marshaller := &mock.MarshalizerMock{}
-	dctRolesF, _ := NewDCTRolesFunc(marshaller, false)
+	dctRolesF, _ := NewDCTRolesFunc(ArgsNewDCTRoles{
+		Config: Config{
+			Marshalizer: marshaller,
+		},
+		Set: false,
+	})
 
 	err := dctRolesF.CheckAllowedToExecute(&mock.UserAccountStub{
 		AccountDataHandlerCalled: func() vmcommon.AccountDataHandler {
@@ -346,7 +480,12 @@ func TestDctRoles_CheckAllowed_ShouldWork(t *testing.T) {
 	t.Parallel()
 
 	marshaller := &mock.MarshalizerMock{}
-	dctRolesF, _ := NewDCTRolesFunc(marshaller, false)
+	dctRolesF, _ := NewDCTRolesFunc(ArgsNewDCTRoles{
+		Config: Config{
+			Marshalizer: marshaller,
+		},
+		Set: false,
+	})
 
 	err := dctRolesF.CheckAllowedToExecute(&mock.UserAccountStub{
 		AccountDataHandlerCalled: func() vmcommon.AccountDataHandler {
@@ -368,7 +507,12 @@ func TestDctRoles_CheckAllowedToExecuteRoleNotFind(t *testing.T) {
 	t.Parallel()
 
 	marshaller := &mock.MarshalizerMock{}
-	dctRolesF, _ := NewDCTRolesFunc(marshaller, false)
+	dctRolesF, _ := NewDCTRolesFunc(ArgsNewDCTRoles{
+		Config: Config{
+			Marshalizer: marshaller,
+		},
+		Set: false,
+	})
 
 	err := dctRolesF.CheckAllowedToExecute(&mock.UserAccountStub{
 		AccountDataHandlerCalled: func() vmcommon.AccountDataHandler {
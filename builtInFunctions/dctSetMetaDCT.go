@@ -0,0 +1,146 @@
+package builtInFunctions
+
+import (
+	"fmt"
+
+	"github.com/Reshusk23/sr-me-core/core"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/validation"
+)
+
+const numArgumentsSetMetaDCT = 2
+
+const maxNumDecimalsMetaDCT = 18
+
+type dctSetMetaDCT struct {
+	baseActiveHandler
+	baseSystemAddressesHandler
+	accounts vmcommon.AccountsAdapter
+}
+
+// ArgsNewDCTSetMetaDCT defines the argument list for new dct set meta dct built in function
+type ArgsNewDCTSetMetaDCT struct {
+	Config
+}
+
+// NewDCTSetMetaDCTFunc returns the dct set meta dct built-in function component, which marks a collection as
+// holding meta dct tokens and records its number of decimals
+func NewDCTSetMetaDCTFunc(args ArgsNewDCTSetMetaDCT) (*dctSetMetaDCT, error) {
+	err := args.checkComponents(vmcommon.BuiltInFunctionDCTSetMetaDCT, requireAccounts|requireEnableEpochsHandler)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &dctSetMetaDCT{
+		accounts: args.Accounts,
+	}
+
+	e.baseActiveHandler.activeHandler = args.EnableEpochsHandler.IsMetaDCTFlagEnabled
+
+	return e, nil
+}
+
+// SetNewGasConfig is called whenever gas cost is changed
+func (e *dctSetMetaDCT) SetNewGasConfig(_ *vmcommon.GasCost) {
+}
+
+// ProcessBuiltinFunction resolves DCT set meta dct function call
+// Requires 2 arguments:
+// arg0 - collection identifier
+// arg1 - number of decimals, max 18
+// The meta dct type is set once, when the collection is issued
+func (e *dctSetMetaDCT) ProcessBuiltinFunction(
+	_, _ vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	if vmInput == nil {
+		return nil, ErrNilVmInput
+	}
+	err := checkCallValue(vmcommon.CallValueForbidden, vmInput.CallValue)
+	if err != nil {
+		return nil, err
+	}
+	if !e.getSystemAddresses().IsDCTSCAddress(vmInput.CallerAddr) {
+		return nil, ErrAddressIsNotDCTSystemSC
+	}
+	if !e.getSystemAddresses().IsSystemAccountAddress(vmInput.RecipientAddr) {
+		return nil, ErrOnlySystemAccountAccepted
+	}
+	if len(vmInput.Arguments) != numArgumentsSetMetaDCT {
+		return nil, ErrInvalidArguments
+	}
+	numDecimals, err := uint64Argument(vmInput.Arguments, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(vmInput.Arguments[1]) > 8 || numDecimals > maxNumDecimalsMetaDCT {
+		return nil, ErrInvalidNumDecimals
+	}
+
+	systemAcc, err := getSystemAccount(e.accounts, e.getSystemAddresses().SystemAccountAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	config := getCollectionConfig(systemAcc, vmInput.Arguments[0])
+	if config.IsMetaDCT {
+		return nil, ErrMetaDCTAlreadySet
+	}
+	config.IsMetaDCT = true
+	config.NumDecimals = uint8(numDecimals)
+
+	key := append(collectionConfigKeyPrefix, vmInput.Arguments[0]...)
+	err = systemAcc.AccountDataHandler().SaveKeyValue(key, config.ToBytes())
+	if err != nil {
+		return nil, err
+	}
+
+	err = e.accounts.SaveAccount(systemAcc)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vmcommon.VMOutput{ReturnCode: vmcommon.Ok}, nil
+}
+
+// IsInterfaceNil returns true if underlying object in nil
+func (e *dctSetMetaDCT) IsInterfaceNil() bool {
+	return e == nil
+}
+
+// getNFTTokenType returns the type of the tokens with nonce of the collection, once the meta dct tokens are enabled
+func getNFTTokenType(
+	globalSettingsHandler vmcommon.DCTGlobalSettingsHandler,
+	enableEpochsHandler vmcommon.EnableEpochsHandler,
+	tokenID []byte,
+) uint32 {
+	if !enableEpochsHandler.IsMetaDCTFlagEnabled() {
+		return uint32(core.NonFungible)
+	}
+
+	config := globalSettingsHandler.GetCollectionConfig(tokenID)
+	if config.IsMetaDCT {
+		return vmcommon.MetaFungible
+	}
+
+	return uint32(core.NonFungible)
+}
+
+// getMaxValueLength returns the max length in bytes of a quantity of the provided token type, the meta dct tokens
+// holding fungible amounts
+func getMaxValueLength(tokenType uint32) int {
+	if tokenType == vmcommon.MetaFungible {
+		return core.MaxLenForDCTIssueMint
+	}
+
+	return maxLenForAddNFTQuantity
+}
+
+// checkMetaDCTValueLength rejects the quantities of meta dct tokens which do not fit a fungible amount
+func checkMetaDCTValueLength(tokenType uint32, value []byte) error {
+	if tokenType != vmcommon.MetaFungible || len(value) <= core.MaxLenForDCTIssueMint {
+		return nil
+	}
+
+	return fmt.Errorf("%w, %w, max length is %d", ErrInvalidArguments, validation.ErrValueTooLong, core.MaxLenForDCTIssueMint)
+}
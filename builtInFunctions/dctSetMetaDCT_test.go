@@ -0,0 +1,195 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/Reshusk23/sr-me-core/core"
+	"github.com/Reshusk23/sr-me-core/core/check"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDCTSetMetaDCTFunc(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil accounts should error", func(t *testing.T) {
+		t.Parallel()
+
+		e, err := NewDCTSetMetaDCTFunc(ArgsNewDCTSetMetaDCT{
+			Config: Config{
+				EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+			},
+		})
+		assert.True(t, check.IfNil(e))
+		assert.ErrorIs(t, err, ErrNilAccountsAdapter)
+	})
+	t.Run("nil enable epochs handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		e, err := NewDCTSetMetaDCTFunc(ArgsNewDCTSetMetaDCT{
+			Config: Config{
+				Accounts: &mock.AccountsStub{},
+			},
+		})
+		assert.True(t, check.IfNil(e))
+		assert.ErrorIs(t, err, ErrNilEnableEpochsHandler)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		enableEpochsHandler := &mock.EnableEpochsHandlerStub{}
+		e, err := NewDCTSetMetaDCTFunc(ArgsNewDCTSetMetaDCT{
+			Config: Config{
+				Accounts:            &mock.AccountsStub{},
+				EnableEpochsHandler: enableEpochsHandler,
+			},
+		})
+		assert.False(t, check.IfNil(e))
+		assert.Nil(t, err)
+		assert.False(t, e.IsActive())
+
+		enableEpochsHandler.IsMetaDCTFlagEnabledField = true
+		assert.True(t, e.IsActive())
+	})
+}
+
+func TestDCTSetMetaDCT_ProcessBuiltinFunctionErrors(t *testing.T) {
+	t.Parallel()
+
+	e, _ := NewDCTSetMetaDCTFunc(ArgsNewDCTSetMetaDCT{
+		Config: Config{
+			Accounts:            &mock.AccountsStub{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+		},
+	})
+	_, err := e.ProcessBuiltinFunction(nil, nil, nil)
+	assert.Equal(t, ErrNilVmInput, err)
+
+	vmInput := createCollectionConfigInput([]byte("META-abcdef"), []byte{18})
+	vmInput.CallValue = big.NewInt(1)
+	_, err = e.ProcessBuiltinFunction(nil, nil, vmInput)
+	assert.Equal(t, ErrBuiltInFunctionCalledWithValue, err)
+
+	vmInput = createCollectionConfigInput([]byte("META-abcdef"), []byte{18})
+	vmInput.CallerAddr = []byte("caller")
+	_, err = e.ProcessBuiltinFunction(nil, nil, vmInput)
+	assert.Equal(t, ErrAddressIsNotDCTSystemSC, err)
+
+	vmInput = createCollectionConfigInput([]byte("META-abcdef"), []byte{18})
+	vmInput.RecipientAddr = []byte("recipient")
+	_, err = e.ProcessBuiltinFunction(nil, nil, vmInput)
+	assert.Equal(t, ErrOnlySystemAccountAccepted, err)
+
+	_, err = e.ProcessBuiltinFunction(nil, nil, createCollectionConfigInput([]byte("META-abcdef")))
+	assert.Equal(t, ErrInvalidArguments, err)
+
+	_, err = e.ProcessBuiltinFunction(nil, nil, createCollectionConfigInput([]byte("META-abcdef"), []byte{19}))
+	assert.Equal(t, ErrInvalidNumDecimals, err)
+
+	_, err = e.ProcessBuiltinFunction(nil, nil, createCollectionConfigInput([]byte("META-abcdef"), append(make([]byte, 8), 18)))
+	assert.Equal(t, ErrInvalidNumDecimals, err)
+}
+
+func TestDCTSetMetaDCT_ProcessBuiltinFunctionShouldWork(t *testing.T) {
+	t.Parallel()
+
+	systemAcc := mock.NewUserAccount(vmcommon.SystemAccountAddress)
+	accounts := &mock.AccountsStub{
+		LoadAccountCalled: func(address []byte) (vmcommon.AccountHandler, error) {
+			return systemAcc, nil
+		},
+	}
+	globalSettings, _ := NewDCTGlobalSettingsFunc(ArgsNewDCTGlobalSettings{
+		Config: Config{
+			Accounts:            accounts,
+			Marshalizer:         &mock.MarshalizerMock{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+		},
+		Set:           true,
+		Function:      core.BuiltInFunctionDCTPause,
+		ActiveHandler: trueHandler,
+	})
+	setMetaFunc, _ := NewDCTSetMetaDCTFunc(ArgsNewDCTSetMetaDCT{
+		Config: Config{
+			Accounts:            accounts,
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+		},
+	})
+	setConfigFunc, _ := NewDCTCollectionConfigFunc(ArgsNewDCTCollectionConfig{
+		Config: Config{
+			Accounts:            accounts,
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+		},
+		Set: true,
+	})
+	unsetConfigFunc, _ := NewDCTCollectionConfigFunc(ArgsNewDCTCollectionConfig{
+		Config: Config{
+			Accounts:            accounts,
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+		},
+		Set: false,
+	})
+
+	tokenID := []byte("META-abcdef")
+	_, err := setConfigFunc.ProcessBuiltinFunction(nil, nil, createCollectionConfigInput(tokenID, []byte{2}, []byte{10}, []byte{1}))
+	assert.Nil(t, err)
+	assert.False(t, globalSettings.IsMetaDCT(tokenID))
+
+	vmOutput, err := setMetaFunc.ProcessBuiltinFunction(nil, nil, createCollectionConfigInput(tokenID, []byte{18}))
+	assert.Nil(t, err)
+	assert.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	assert.True(t, globalSettings.IsMetaDCT(tokenID))
+	expectedConfig := vmcommon.CollectionConfig{
+		MaxNumURIs:          2,
+		MaxAttributesLength: 10,
+		IsMetaDCT:           true,
+		NumDecimals:         18,
+	}
+	assert.Equal(t, expectedConfig, globalSettings.GetCollectionConfig(tokenID))
+
+	_, err = setMetaFunc.ProcessBuiltinFunction(nil, nil, createCollectionConfigInput(tokenID, []byte{6}))
+	assert.Equal(t, ErrMetaDCTAlreadySet, err)
+
+	_, err = setConfigFunc.ProcessBuiltinFunction(nil, nil, createCollectionConfigInput(tokenID, []byte{3}, []byte{}, []byte{}))
+	assert.Nil(t, err)
+	expectedConfig = vmcommon.CollectionConfig{
+		MaxNumURIs:          3,
+		AddQuantityDisabled: true,
+		IsMetaDCT:           true,
+		NumDecimals:         18,
+	}
+	assert.Equal(t, expectedConfig, globalSettings.GetCollectionConfig(tokenID))
+
+	_, err = unsetConfigFunc.ProcessBuiltinFunction(nil, nil, createCollectionConfigInput(tokenID))
+	assert.Nil(t, err)
+	assert.Equal(t, vmcommon.CollectionConfig{IsMetaDCT: true, NumDecimals: 18}, globalSettings.GetCollectionConfig(tokenID))
+}
+
+func TestGetNFTTokenType(t *testing.T) {
+	t.Parallel()
+
+	globalSettingsHandler := &mock.GlobalSettingsHandlerStub{
+		GetCollectionConfigCalled: func(tokenID []byte) vmcommon.CollectionConfig {
+			return vmcommon.CollectionConfig{IsMetaDCT: string(tokenID) == "META-abcdef"}
+		},
+	}
+	enableEpochsHandler := &mock.EnableEpochsHandlerStub{}
+
+	assert.Equal(t, uint32(core.NonFungible), getNFTTokenType(globalSettingsHandler, enableEpochsHandler, []byte("META-abcdef")))
+
+	enableEpochsHandler.IsMetaDCTFlagEnabledField = true
+	assert.Equal(t, uint32(vmcommon.MetaFungible), getNFTTokenType(globalSettingsHandler, enableEpochsHandler, []byte("META-abcdef")))
+	assert.Equal(t, uint32(core.NonFungible), getNFTTokenType(globalSettingsHandler, enableEpochsHandler, []byte("NFT-abcdef")))
+}
+
+func TestCheckMetaDCTValueLength(t *testing.T) {
+	t.Parallel()
+
+	longValue := bytes.Repeat([]byte{1}, core.MaxLenForDCTIssueMint+1)
+	assert.Nil(t, checkMetaDCTValueLength(uint32(core.NonFungible), longValue))
+	assert.Nil(t, checkMetaDCTValueLength(vmcommon.MetaFungible, longValue[1:]))
+	assert.ErrorIs(t, checkMetaDCTValueLength(vmcommon.MetaFungible, longValue), ErrInvalidArguments)
+}
@@ -0,0 +1,175 @@
+package builtInFunctions
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/Reshusk23/sr-me-core/core/check"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+)
+
+const minNumArgumentsSetNewURIs = 3
+
+type dctSetNewURIs struct {
+	baseActiveHandler
+	keyPrefix             []byte
+	dctStorageHandler     vmcommon.DCTNFTStorageHandler
+	globalSettingsHandler vmcommon.DCTGlobalSettingsHandler
+	rolesHandler          vmcommon.DCTRoleHandler
+	gasConfig             vmcommon.BaseOperationCost
+	storageUsageTracker   vmcommon.StorageUsageTracker
+	funcGasCost           uint64
+	mutExecution          sync.RWMutex
+}
+
+// ArgsNewDCTSetNewURIs defines the argument list for new dct set new URIs built in function
+type ArgsNewDCTSetNewURIs struct {
+	Config
+	FuncGasCost uint64
+	GasConfig   vmcommon.BaseOperationCost
+}
+
+// NewDCTSetNewURIsFunc returns the dct set new URIs built-in function component, replacing all the URIs of an NFT
+func NewDCTSetNewURIsFunc(args ArgsNewDCTSetNewURIs) (*dctSetNewURIs, error) {
+	err := args.checkComponents(vmcommon.BuiltInFunctionDCTSetNewURIs, requireGlobalSettingsHandler|requireRolesHandler|requireDCTStorageHandler|requireEnableEpochsHandler)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &dctSetNewURIs{
+		keyPrefix:             []byte(baseDCTKeyPrefix),
+		dctStorageHandler:     args.DCTStorageHandler,
+		globalSettingsHandler: args.GlobalSettingsHandler,
+		rolesHandler:          args.RolesHandler,
+		gasConfig:             args.GasConfig,
+		funcGasCost:           args.FuncGasCost,
+		storageUsageTracker:   &disabledStorageUsageTracker{},
+	}
+
+	e.baseActiveHandler.activeHandler = args.EnableEpochsHandler.IsDCTSetNewURIsFlagEnabled
+
+	return e, nil
+}
+
+// SetNewGasConfig is called whenever gas cost is changed
+func (e *dctSetNewURIs) SetNewGasConfig(gasCost *vmcommon.GasCost) {
+	if gasCost == nil {
+		return
+	}
+
+	e.mutExecution.Lock()
+	e.funcGasCost = gasCost.BuiltInCost.DCTNFTAddURI
+	e.gasConfig = gasCost.BaseOperationCost
+	e.mutExecution.Unlock()
+}
+
+// SetStorageUsageTracker sets the tracker metering the bytes the new URIs add over the replaced ones, which are accounted to the caller
+func (e *dctSetNewURIs) SetStorageUsageTracker(storageUsageTracker vmcommon.StorageUsageTracker) error {
+	if check.IfNil(storageUsageTracker) {
+		return ErrNilStorageUsageTracker
+	}
+
+	e.mutExecution.Lock()
+	e.storageUsageTracker = storageUsageTracker
+	e.mutExecution.Unlock()
+
+	return nil
+}
+
+// ProcessBuiltinFunction resolves DCT set new URIs function call
+// Requires at least 3 arguments:
+// arg0 - token identifier
+// arg1 - nonce
+// arg[2:] - uris replacing the current ones
+func (e *dctSetNewURIs) ProcessBuiltinFunction(
+	acntSnd, _ vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	e.mutExecution.RLock()
+	defer e.mutExecution.RUnlock()
+
+	err := checkDCTNFTCreateBurnAddInput(acntSnd, vmInput, e.funcGasCost)
+	if err != nil {
+		return nil, err
+	}
+	if len(vmInput.Arguments) < minNumArgumentsSetNewURIs {
+		return nil, ErrInvalidArguments
+	}
+	if check.IfNil(acntSnd) {
+		return nil, ErrNilUserAccount
+	}
+
+	err = e.rolesHandler.CheckAllowedToExecute(acntSnd, vmInput.Arguments[0], []byte(vmcommon.DCTRoleSetNewURI))
+	if err != nil {
+		return nil, err
+	}
+
+	newURIs := vmInput.Arguments[2:]
+	err = checkCollectionURIs(e.globalSettingsHandler, vmInput.Arguments[0], len(newURIs))
+	if err != nil {
+		return nil, err
+	}
+
+	dctTokenKey := append(e.keyPrefix, vmInput.Arguments[0]...)
+	nonce, err := uint64Argument(vmInput.Arguments, 1)
+	if err != nil {
+		return nil, err
+	}
+	if nonce == 0 {
+		return nil, ErrNFTDoesNotHaveMetadata
+	}
+	dctData, err := e.dctStorageHandler.GetDCTNFTTokenOnSender(acntSnd, dctTokenKey, nonce)
+	if err != nil {
+		return nil, err
+	}
+	if dctData.TokenMetaData == nil {
+		return nil, ErrNFTDoesNotHaveMetadata
+	}
+
+	gasCostForStore := e.getGasCostForURIsStore(dctData.TokenMetaData.URIs, newURIs)
+	if vmInput.GasProvided < e.funcGasCost+gasCostForStore {
+		return nil, ErrNotEnoughGas
+	}
+
+	err = e.storageUsageTracker.TrackStorageUsage(acntSnd, int64(getLengthOfURIs(newURIs)-getLengthOfURIs(dctData.TokenMetaData.URIs)))
+	if err != nil {
+		return nil, err
+	}
+	dctData.TokenMetaData.URIs = newURIs
+	_, err = e.dctStorageHandler.SaveDCTNFTToken(acntSnd.AddressBytes(), acntSnd, dctTokenKey, nonce, dctData, true, vmInput.ReturnCallAfterError)
+	if err != nil {
+		return nil, err
+	}
+
+	vmOutput := &vmcommon.VMOutput{
+		ReturnCode:   vmcommon.Ok,
+		GasRemaining: vmInput.GasProvided - e.funcGasCost - gasCostForStore,
+	}
+
+	extraTopics := append([][]byte{vmInput.CallerAddr}, newURIs...)
+	addDCTEntryInVMOutput(vmOutput, []byte(vmcommon.BuiltInFunctionDCTSetNewURIs), vmInput.Arguments[0], nonce, big.NewInt(0), extraTopics...)
+
+	return vmOutput, nil
+}
+
+// getGasCostForURIsStore charges the store cost only for the bytes the new URIs add over the replaced ones
+func (e *dctSetNewURIs) getGasCostForURIsStore(currentURIs [][]byte, newURIs [][]byte) uint64 {
+	lenCurrentURIs := 0
+	for _, uri := range currentURIs {
+		lenCurrentURIs += len(uri)
+	}
+	lenNewURIs := 0
+	for _, uri := range newURIs {
+		lenNewURIs += len(uri)
+	}
+	if lenNewURIs <= lenCurrentURIs {
+		return 0
+	}
+
+	return uint64(lenNewURIs-lenCurrentURIs) * e.gasConfig.StorePerByte
+}
+
+// IsInterfaceNil returns true if underlying object in nil
+func (e *dctSetNewURIs) IsInterfaceNil() bool {
+	return e == nil
+}
@@ -0,0 +1,373 @@
+package builtInFunctions
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Reshusk23/sr-me-core/core"
+	"github.com/Reshusk23/sr-me-core/core/check"
+	"github.com/Reshusk23/sr-me-core/data/dct"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/dctkeys"
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func createSetNewURIsInput(tokenID []byte, nonce uint64, uris ...[]byte) *vmcommon.ContractCallInput {
+	return &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallValue:   big.NewInt(0),
+			Arguments:   append([][]byte{tokenID, big.NewInt(0).SetUint64(nonce).Bytes()}, uris...),
+			CallerAddr:  []byte("address 1"),
+			GasProvided: 100,
+		},
+		RecipientAddr: []byte("address 1"),
+	}
+}
+
+func createSetNewURIsAccount(t *testing.T, dctDataStorage *dctDataStorage, tokenID []byte, nonce uint64, uris ...[]byte) vmcommon.UserAccountHandler {
+	userAcc := mock.NewAccountWrapMock([]byte("address 1"))
+	dctData := &dct.DCToken{
+		Type:  uint32(core.NonFungible),
+		Value: big.NewInt(1),
+		TokenMetaData: &dct.MetaData{
+			Nonce: nonce,
+			Name:  []byte("name"),
+			URIs:  uris,
+		},
+	}
+	dctTokenKey := append([]byte(baseDCTKeyPrefix), tokenID...)
+	_, err := dctDataStorage.SaveDCTNFTToken(userAcc.AddressBytes(), userAcc, dctTokenKey, nonce, dctData, true, false)
+	require.Nil(t, err)
+
+	return userAcc
+}
+
+func TestNewDCTSetNewURIsFunc(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil storage handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		e, err := NewDCTSetNewURIsFunc(ArgsNewDCTSetNewURIs{
+			Config: Config{
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+				EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			},
+			FuncGasCost: 10,
+			GasConfig:   vmcommon.BaseOperationCost{},
+		})
+		require.True(t, check.IfNil(e))
+		require.ErrorIs(t, err, ErrNilDCTNFTStorageHandler)
+	})
+	t.Run("nil global settings handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		e, err := NewDCTSetNewURIsFunc(ArgsNewDCTSetNewURIs{
+			Config: Config{
+				DCTStorageHandler:   createNewDCTDataStorageHandler(),
+				RolesHandler:        &mock.DCTRoleHandlerStub{},
+				EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+			},
+			FuncGasCost: 10,
+			GasConfig:   vmcommon.BaseOperationCost{},
+		})
+		require.True(t, check.IfNil(e))
+		require.ErrorIs(t, err, ErrNilGlobalSettingsHandler)
+	})
+	t.Run("nil roles handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		e, err := NewDCTSetNewURIsFunc(ArgsNewDCTSetNewURIs{
+			Config: Config{
+				DCTStorageHandler:     createNewDCTDataStorageHandler(),
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			},
+			FuncGasCost: 10,
+			GasConfig:   vmcommon.BaseOperationCost{},
+		})
+		require.True(t, check.IfNil(e))
+		require.ErrorIs(t, err, ErrNilRolesHandler)
+	})
+	t.Run("nil enable epochs handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		e, err := NewDCTSetNewURIsFunc(ArgsNewDCTSetNewURIs{
+			Config: Config{
+				DCTStorageHandler:     createNewDCTDataStorageHandler(),
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+			},
+			FuncGasCost: 10,
+			GasConfig:   vmcommon.BaseOperationCost{},
+		})
+		require.True(t, check.IfNil(e))
+		require.ErrorIs(t, err, ErrNilEnableEpochsHandler)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		enableEpochsHandler := &mock.EnableEpochsHandlerStub{}
+		e, err := NewDCTSetNewURIsFunc(ArgsNewDCTSetNewURIs{
+			Config: Config{
+				DCTStorageHandler:     createNewDCTDataStorageHandler(),
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+				EnableEpochsHandler:   enableEpochsHandler,
+			},
+			FuncGasCost: 10,
+			GasConfig:   vmcommon.BaseOperationCost{},
+		})
+		require.False(t, check.IfNil(e))
+		require.Nil(t, err)
+		require.False(t, e.IsActive())
+
+		enableEpochsHandler.IsDCTSetNewURIsFlagEnabledField = true
+		require.True(t, e.IsActive())
+	})
+}
+
+func TestDCTSetNewURIs_SetNewGasConfig(t *testing.T) {
+	t.Parallel()
+
+	e, _ := NewDCTSetNewURIsFunc(ArgsNewDCTSetNewURIs{
+		Config: Config{
+			DCTStorageHandler:     createNewDCTDataStorageHandler(),
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+		},
+		FuncGasCost: 10,
+		GasConfig:   vmcommon.BaseOperationCost{},
+	})
+
+	e.SetNewGasConfig(nil)
+	require.Equal(t, uint64(10), e.funcGasCost)
+
+	e.SetNewGasConfig(&vmcommon.GasCost{
+		BaseOperationCost: vmcommon.BaseOperationCost{StorePerByte: 2},
+		BuiltInCost:       vmcommon.BuiltInCost{DCTNFTAddURI: 20},
+	})
+	require.Equal(t, uint64(20), e.funcGasCost)
+	require.Equal(t, uint64(2), e.gasConfig.StorePerByte)
+}
+
+func TestDCTSetNewURIs_ProcessBuiltinFunctionErrors(t *testing.T) {
+	t.Parallel()
+
+	tokenID := []byte("NFT-abcdef")
+
+	t.Run("no URI should error", func(t *testing.T) {
+		t.Parallel()
+
+		e, _ := NewDCTSetNewURIsFunc(ArgsNewDCTSetNewURIs{
+			Config: Config{
+				DCTStorageHandler:     createNewDCTDataStorageHandler(),
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+				EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			},
+			FuncGasCost: 10,
+			GasConfig:   vmcommon.BaseOperationCost{},
+		})
+		input := createSetNewURIsInput(tokenID, 1)
+
+		output, err := e.ProcessBuiltinFunction(mock.NewAccountWrapMock(input.CallerAddr), nil, input)
+		require.Nil(t, output)
+		require.Equal(t, ErrInvalidArguments, err)
+	})
+	t.Run("missing role should error", func(t *testing.T) {
+		t.Parallel()
+
+		rolesHandler := &mock.DCTRoleHandlerStub{
+			CheckAllowedToExecuteCalled: func(_ vmcommon.UserAccountHandler, _ []byte, action []byte) error {
+				require.Equal(t, vmcommon.DCTRoleSetNewURI, string(action))
+				return ErrActionNotAllowed
+			},
+		}
+		e, _ := NewDCTSetNewURIsFunc(ArgsNewDCTSetNewURIs{
+			Config: Config{
+				DCTStorageHandler:     createNewDCTDataStorageHandler(),
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          rolesHandler,
+				EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			},
+			FuncGasCost: 10,
+			GasConfig:   vmcommon.BaseOperationCost{},
+		})
+		input := createSetNewURIsInput(tokenID, 1, []byte("uri"))
+
+		output, err := e.ProcessBuiltinFunction(mock.NewAccountWrapMock(input.CallerAddr), nil, input)
+		require.Nil(t, output)
+		require.Equal(t, ErrActionNotAllowed, err)
+	})
+	t.Run("collection URIs limit should error", func(t *testing.T) {
+		t.Parallel()
+
+		globalSettingsHandler := &mock.GlobalSettingsHandlerStub{
+			GetCollectionConfigCalled: func(tokenID []byte) vmcommon.CollectionConfig {
+				return vmcommon.CollectionConfig{MaxNumURIs: 1}
+			},
+		}
+		e, _ := NewDCTSetNewURIsFunc(ArgsNewDCTSetNewURIs{
+			Config: Config{
+				DCTStorageHandler:     createNewDCTDataStorageHandler(),
+				GlobalSettingsHandler: globalSettingsHandler,
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+				EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			},
+			FuncGasCost: 10,
+			GasConfig:   vmcommon.BaseOperationCost{},
+		})
+		input := createSetNewURIsInput(tokenID, 1, []byte("uri1"), []byte("uri2"))
+
+		output, err := e.ProcessBuiltinFunction(mock.NewAccountWrapMock(input.CallerAddr), nil, input)
+		require.Nil(t, output)
+		require.Equal(t, ErrTooManyURIs, err)
+	})
+	t.Run("zero nonce should error", func(t *testing.T) {
+		t.Parallel()
+
+		e, _ := NewDCTSetNewURIsFunc(ArgsNewDCTSetNewURIs{
+			Config: Config{
+				DCTStorageHandler:     createNewDCTDataStorageHandler(),
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+				EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			},
+			FuncGasCost: 10,
+			GasConfig:   vmcommon.BaseOperationCost{},
+		})
+		input := createSetNewURIsInput(tokenID, 0, []byte("uri"))
+
+		output, err := e.ProcessBuiltinFunction(mock.NewAccountWrapMock(input.CallerAddr), nil, input)
+		require.Nil(t, output)
+		require.Equal(t, ErrNFTDoesNotHaveMetadata, err)
+	})
+	t.Run("not enough gas for the added bytes should error", func(t *testing.T) {
+		t.Parallel()
+
+		dctDataStorage := createNewDCTDataStorageHandler()
+		e, _ := NewDCTSetNewURIsFunc(ArgsNewDCTSetNewURIs{
+			Config: Config{
+				DCTStorageHandler:     dctDataStorage,
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+				EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			},
+			FuncGasCost: 10,
+			GasConfig:   vmcommon.BaseOperationCost{StorePerByte: 20},
+		})
+		userAcc := createSetNewURIsAccount(t, dctDataStorage, tokenID, 1, []byte("uri"))
+		input := createSetNewURIsInput(tokenID, 1, []byte("longer uri"))
+
+		output, err := e.ProcessBuiltinFunction(userAcc, nil, input)
+		require.Nil(t, output)
+		require.Equal(t, ErrNotEnoughGas, err)
+	})
+}
+
+func TestDCTSetNewURIs_ProcessBuiltinFunctionShouldWork(t *testing.T) {
+	t.Parallel()
+
+	tokenID := []byte("NFT-abcdef")
+	nonce := uint64(5)
+
+	t.Run("longer URIs should charge the added bytes", func(t *testing.T) {
+		t.Parallel()
+
+		dctDataStorage := createNewDCTDataStorageHandler()
+		e, _ := NewDCTSetNewURIsFunc(ArgsNewDCTSetNewURIs{
+			Config: Config{
+				DCTStorageHandler:     dctDataStorage,
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+				EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			},
+			FuncGasCost: 10,
+			GasConfig:   vmcommon.BaseOperationCost{StorePerByte: 2},
+		})
+		userAcc := createSetNewURIsAccount(t, dctDataStorage, tokenID, nonce, []byte("uri1"), []byte("uri2"))
+		newURIs := [][]byte{[]byte("new uri 1"), []byte("new uri 2")}
+		input := createSetNewURIsInput(tokenID, nonce, newURIs...)
+
+		output, err := e.ProcessBuiltinFunction(userAcc, nil, input)
+		require.Nil(t, err)
+		require.Equal(t, vmcommon.Ok, output.ReturnCode)
+		require.Equal(t, uint64(100-10-2*10), output.GasRemaining)
+
+		dctTokenKey := append([]byte(baseDCTKeyPrefix), tokenID...)
+		metaData, _ := dctDataStorage.getDCTMetaDataFromSystemAccount(dctkeys.ComputeDCTNFTTokenKey(dctTokenKey, nonce), defaultQueryOptions())
+		require.Equal(t, newURIs, metaData.URIs)
+		require.Equal(t, []byte("name"), metaData.Name)
+
+		require.Equal(t, 1, len(output.Logs))
+		require.Equal(t, []byte(vmcommon.BuiltInFunctionDCTSetNewURIs), output.Logs[0].Identifier)
+		require.Equal(t, append([][]byte{tokenID, {byte(nonce)}, {}}, newURIs...), output.Logs[0].Topics)
+	})
+	t.Run("shorter URIs should not charge store", func(t *testing.T) {
+		t.Parallel()
+
+		dctDataStorage := createNewDCTDataStorageHandler()
+		e, _ := NewDCTSetNewURIsFunc(ArgsNewDCTSetNewURIs{
+			Config: Config{
+				DCTStorageHandler:     dctDataStorage,
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+				EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			},
+			FuncGasCost: 10,
+			GasConfig:   vmcommon.BaseOperationCost{StorePerByte: 2},
+		})
+		userAcc := createSetNewURIsAccount(t, dctDataStorage, tokenID, nonce, []byte("uri1"), []byte("uri2"))
+		input := createSetNewURIsInput(tokenID, nonce, []byte("uri"))
+
+		output, err := e.ProcessBuiltinFunction(userAcc, nil, input)
+		require.Nil(t, err)
+		require.Equal(t, uint64(100-10), output.GasRemaining)
+
+		dctTokenKey := append([]byte(baseDCTKeyPrefix), tokenID...)
+		metaData, _ := dctDataStorage.getDCTMetaDataFromSystemAccount(dctkeys.ComputeDCTNFTTokenKey(dctTokenKey, nonce), defaultQueryOptions())
+		require.Equal(t, [][]byte{[]byte("uri")}, metaData.URIs)
+	})
+}
+
+func TestDCTSetNewURIs_ProcessBuiltinFunctionStorageUsageTracker(t *testing.T) {
+	t.Parallel()
+
+	tokenID := []byte("NFT-abcdef")
+	nonce := uint64(5)
+	dctDataStorage := createNewDCTDataStorageHandler()
+	e, _ := NewDCTSetNewURIsFunc(ArgsNewDCTSetNewURIs{
+		Config: Config{
+			DCTStorageHandler:     dctDataStorage,
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+		},
+		FuncGasCost: 10,
+		GasConfig:   vmcommon.BaseOperationCost{},
+	})
+	require.Equal(t, ErrNilStorageUsageTracker, e.SetStorageUsageTracker(nil))
+	tracker := NewStorageUsageTracker(vmcommon.StorageUsageConfig{MaxBytesPerAccount: 20})
+	require.Nil(t, e.SetStorageUsageTracker(tracker))
+
+	userAcc := createSetNewURIsAccount(t, dctDataStorage, tokenID, nonce, []byte("uri1"), []byte("uri2"))
+	require.Nil(t, tracker.TrackStorageUsage(userAcc, 8))
+
+	output, err := e.ProcessBuiltinFunction(userAcc, nil, createSetNewURIsInput(tokenID, nonce, []byte("new uri 1")))
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, output.ReturnCode)
+	require.Equal(t, uint64(9), tracker.GetStorageUsage(userAcc))
+
+	output, err = e.ProcessBuiltinFunction(userAcc, nil, createSetNewURIsInput(tokenID, nonce, []byte("uri")))
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, output.ReturnCode)
+	require.Equal(t, uint64(3), tracker.GetStorageUsage(userAcc))
+
+	output, err = e.ProcessBuiltinFunction(userAcc, nil, createSetNewURIsInput(tokenID, nonce, []byte("a very long new uri 1")))
+	require.Nil(t, output)
+	require.ErrorIs(t, err, ErrStorageLimitExceeded)
+}
@@ -17,52 +17,47 @@ var zero = big.NewInt(0)
 
 type dctTransfer struct {
 	baseAlwaysActiveHandler
+	freezeAccountChecker
+	lockedBalanceChecker
+	transferInterceptorChecker
 	funcGasCost           uint64
+	asyncCallbackCost     vmcommon.AsyncCallbackCost
 	marshaller            vmcommon.Marshalizer
 	keyPrefix             []byte
 	globalSettingsHandler vmcommon.ExtendedDCTGlobalSettingsHandler
 	payableHandler        vmcommon.PayableChecker
 	shardCoordinator      vmcommon.Coordinator
+	addressClassifier     vmcommon.AddressClassifier
 	mutExecution          sync.RWMutex
 
 	rolesHandler        vmcommon.DCTRoleHandler
 	enableEpochsHandler vmcommon.EnableEpochsHandler
 }
 
+// ArgsNewDCTTransfer defines the argument list for new dct transfer built in function
+type ArgsNewDCTTransfer struct {
+	Config
+	FuncGasCost uint64
+}
+
 // NewDCTTransferFunc returns the dct transfer built-in function component
-func NewDCTTransferFunc(
-	funcGasCost uint64,
-	marshaller vmcommon.Marshalizer,
-	globalSettingsHandler vmcommon.ExtendedDCTGlobalSettingsHandler,
-	shardCoordinator vmcommon.Coordinator,
-	rolesHandler vmcommon.DCTRoleHandler,
-	enableEpochsHandler vmcommon.EnableEpochsHandler,
-) (*dctTransfer, error) {
-	if check.IfNil(marshaller) {
-		return nil, ErrNilMarshalizer
-	}
-	if check.IfNil(globalSettingsHandler) {
-		return nil, ErrNilGlobalSettingsHandler
-	}
-	if check.IfNil(shardCoordinator) {
-		return nil, ErrNilShardCoordinator
-	}
-	if check.IfNil(rolesHandler) {
-		return nil, ErrNilRolesHandler
-	}
-	if check.IfNil(enableEpochsHandler) {
-		return nil, ErrNilEnableEpochsHandler
+func NewDCTTransferFunc(args ArgsNewDCTTransfer) (*dctTransfer, error) {
+	err := args.checkComponents(core.BuiltInFunctionDCTTransfer, requireMarshalizer|requireShardCoordinator|requireGlobalSettingsHandler|requireRolesHandler|requireEnableEpochsHandler)
+	if err != nil {
+		return nil, err
 	}
 
 	e := &dctTransfer{
-		funcGasCost:           funcGasCost,
-		marshaller:            marshaller,
+		lockedBalanceChecker:  lockedBalanceChecker{enableEpochsHandler: args.EnableEpochsHandler},
+		funcGasCost:           args.FuncGasCost,
+		marshaller:            args.Marshalizer,
 		keyPrefix:             []byte(baseDCTKeyPrefix),
-		globalSettingsHandler: globalSettingsHandler,
+		globalSettingsHandler: args.GlobalSettingsHandler,
 		payableHandler:        &disabledPayableHandler{},
-		shardCoordinator:      shardCoordinator,
-		rolesHandler:          rolesHandler,
-		enableEpochsHandler:   enableEpochsHandler,
+		shardCoordinator:      args.ShardCoordinator,
+		addressClassifier:     vmcommon.NewDefaultAddressClassifier(),
+		rolesHandler:          args.RolesHandler,
+		enableEpochsHandler:   args.EnableEpochsHandler,
 	}
 
 	return e, nil
@@ -76,9 +71,36 @@ func (e *dctTransfer) SetNewGasConfig(gasCost *vmcommon.GasCost) {
 
 	e.mutExecution.Lock()
 	e.funcGasCost = gasCost.BuiltInCost.DCTTransfer
+	e.asyncCallbackCost = gasCost.AsyncCallbackCost
 	e.mutExecution.Unlock()
 }
 
+// CheckIsExecutable validates the arguments, the value and the gas of the DCT transfer as checked on the shard of the
+// sender, without reading any account or global setting
+func (e *dctTransfer) CheckIsExecutable(vmInput *vmcommon.ContractCallInput) error {
+	e.mutExecution.RLock()
+	defer e.mutExecution.RUnlock()
+
+	err := checkBasicDCTTransferArguments(vmInput, e.enableEpochsHandler.IsDCTTransferWithValueFlagEnabled())
+	if err != nil {
+		return err
+	}
+	isInvalidTransferToMeta := e.shardCoordinator.ComputeId(vmInput.RecipientAddr) == core.MetachainShardId && !e.enableEpochsHandler.IsTransferToMetaFlagEnabled()
+	if isInvalidTransferToMeta {
+		return ErrInvalidRcvAddr
+	}
+
+	value := big.NewInt(0).SetBytes(vmInput.Arguments[1])
+	if value.Cmp(zero) <= 0 {
+		return ErrNegativeValue
+	}
+	if vmInput.GasProvided < e.funcGasCost {
+		return ErrNotEnoughGas
+	}
+
+	return nil
+}
+
 // ProcessBuiltinFunction resolves DCT transfer function calls
 func (e *dctTransfer) ProcessBuiltinFunction(
 	acntSnd, acntDst vmcommon.UserAccountHandler,
@@ -87,7 +109,7 @@ func (e *dctTransfer) ProcessBuiltinFunction(
 	e.mutExecution.RLock()
 	defer e.mutExecution.RUnlock()
 
-	err := checkBasicDCTArguments(vmInput)
+	err := checkBasicDCTTransferArguments(vmInput, e.enableEpochsHandler.IsDCTTransferWithValueFlagEnabled())
 	if err != nil {
 		return nil, err
 	}
@@ -115,48 +137,90 @@ func (e *dctTransfer) ProcessBuiltinFunction(
 		return nil, err
 	}
 
+	err = checkIfTransferCanHappenWithSoulbound(dctTokenKey, e.globalSettingsHandler, acntSnd, vmInput.ReturnCallAfterError)
+	if err != nil {
+		return nil, err
+	}
+
+	isSelfTransfer := e.enableEpochsHandler.IsDCTSelfTransferFlagEnabled() && isTransferToSelf(acntSnd, acntDst)
 	if !check.IfNil(acntSnd) {
 		// gas is paid only by sender
 		if vmInput.GasProvided < e.funcGasCost {
 			return nil, ErrNotEnoughGas
 		}
 
-		err = addToDCTBalance(acntSnd, dctTokenKey, big.NewInt(0).Neg(value), e.marshaller, e.globalSettingsHandler, vmInput.ReturnCallAfterError)
+		err = e.checkAccountIsNotFrozen(acntSnd.AddressBytes(), vmInput.ReturnCallAfterError)
+		if err != nil {
+			return nil, err
+		}
+		err = e.checkTransferIsAllowed(acntSnd.AddressBytes(), vmInput.RecipientAddr, tokenID, 0, value, vmInput.ReturnCallAfterError)
+		if err != nil {
+			return nil, err
+		}
+
+		if isSelfTransfer {
+			err = checkDCTSelfTransfer(acntSnd, dctTokenKey, value, e.marshaller, e.globalSettingsHandler, e.getSystemAddresses(), vmInput.ReturnCallAfterError)
+		} else {
+			err = e.subFromDCTBalance(acntSnd, dctTokenKey, value, e.marshaller, e.globalSettingsHandler, e.getSystemAddresses(), vmInput.ReturnCallAfterError)
+		}
 		if err != nil {
 			return nil, err
 		}
 	}
 
 	isSCCallAfter := e.payableHandler.DetermineIsSCCallAfter(vmInput, vmInput.RecipientAddr, core.MinLenArgumentsDCTTransfer)
-	vmOutput := &vmcommon.VMOutput{GasRemaining: gasRemaining, ReturnCode: vmcommon.Ok}
+	vmOutput := vmcommon.NewVMOutputFromPool()
+	vmOutput.GasRemaining = gasRemaining
+	vmOutput.ReturnCode = vmcommon.Ok
+	if !check.IfNil(acntSnd) {
+		addGasBreakdownToVMOutput(vmInput, vmOutput, vmcommon.GasBreakdown{TransferCost: e.funcGasCost})
+	}
 	if !check.IfNil(acntDst) {
 		err = e.payableHandler.CheckPayable(vmInput, vmInput.RecipientAddr, core.MinLenArgumentsDCTTransfer)
 		if err != nil {
+			vmcommon.ReleaseVMOutput(vmOutput)
 			return nil, err
 		}
 
-		err = addToDCTBalance(acntDst, dctTokenKey, value, e.marshaller, e.globalSettingsHandler, vmInput.ReturnCallAfterError)
-		if err != nil {
-			return nil, err
+		if !isSelfTransfer {
+			err = addToDCTBalance(acntDst, dctTokenKey, value, e.marshaller, e.globalSettingsHandler, e.getSystemAddresses(), vmInput.ReturnCallAfterError)
+			if err != nil {
+				vmcommon.ReleaseVMOutput(vmOutput)
+				return nil, err
+			}
 		}
 
 		if isSCCallAfter {
-			vmOutput.GasRemaining, err = vmcommon.SafeSubUint64(vmInput.GasProvided, e.funcGasCost)
+			vmOutput.GasRemaining, _ = vmcommon.SafeSubUint64(vmInput.GasProvided, e.funcGasCost)
+			var gasLocked uint64
+			vmOutput.GasRemaining, gasLocked, err = e.lockGasForCallback(vmInput, vmOutput.GasRemaining)
+			if err != nil {
+				vmcommon.ReleaseVMOutput(vmOutput)
+				return nil, err
+			}
+
 			var callArgs [][]byte
 			if len(vmInput.Arguments) > core.MinLenArgumentsDCTTransfer+1 {
 				callArgs = vmInput.Arguments[core.MinLenArgumentsDCTTransfer+1:]
 			}
 
-			addOutputTransferToVMOutput(
-				vmInput.CallerAddr,
+			dctTransfers := []*vmcommon.DCTTransfer{{
+				DCTValue:     value,
+				DCTTokenName: tokenID,
+				DCTTokenType: uint32(core.Fungible),
+			}}
+			addSCCallAfterTransferToVMOutput(
+				vmInput,
 				string(vmInput.Arguments[core.MinLenArgumentsDCTTransfer]),
 				callArgs,
 				vmInput.RecipientAddr,
-				vmInput.GasLocked,
-				vmInput.CallType,
+				dctTransfers,
+				gasLocked,
 				vmOutput)
 
 			addDCTEntryInVMOutput(vmOutput, []byte(core.BuiltInFunctionDCTTransfer), tokenID, 0, value, vmInput.CallerAddr, acntDst.AddressBytes())
+			markLastEntryAsSelfTransfer(vmOutput, isSelfTransfer)
+			addNativeValueEntryInVMOutput(vmOutput, vmInput, acntDst.AddressBytes())
 			return vmOutput, nil
 		}
 
@@ -166,32 +230,120 @@ func (e *dctTransfer) ProcessBuiltinFunction(
 		}
 
 		addDCTEntryInVMOutput(vmOutput, []byte(core.BuiltInFunctionDCTTransfer), tokenID, 0, value, vmInput.CallerAddr, acntDst.AddressBytes())
+		markLastEntryAsSelfTransfer(vmOutput, isSelfTransfer)
+		addNativeValueEntryInVMOutput(vmOutput, vmInput, acntDst.AddressBytes())
 		return vmOutput, nil
 	}
 
 	// cross-shard DCT transfer call through a smart contract
-	if vmcommon.IsSmartContractAddress(vmInput.CallerAddr) {
+	if e.addressClassifier.IsSmartContract(vmInput.CallerAddr) {
+		gasLocked := vmInput.GasLocked
+		if isSCCallAfter {
+			vmOutput.GasRemaining, gasLocked, err = e.lockGasForCallback(vmInput, vmOutput.GasRemaining)
+			if err != nil {
+				vmcommon.ReleaseVMOutput(vmOutput)
+				return nil, err
+			}
+		}
 		addOutputTransferToVMOutput(
-			vmInput.CallerAddr,
+			vmInput,
 			core.BuiltInFunctionDCTTransfer,
 			vmInput.Arguments,
 			vmInput.RecipientAddr,
-			vmInput.GasLocked,
-			vmInput.CallType,
+			vmInput.CallValue,
+			gasLocked,
 			vmOutput)
 	}
 
 	addDCTEntryInVMOutput(vmOutput, []byte(core.BuiltInFunctionDCTTransfer), tokenID, 0, value, vmInput.CallerAddr, vmInput.RecipientAddr)
+	addNativeValueEntryInVMOutput(vmOutput, vmInput, vmInput.RecipientAddr)
 	return vmOutput, nil
 }
 
+// lockGasForCallback locks the gas for the callback of the smart contract call following the transfer, as the NFT
+// transfers do, once the flag is enabled. Before it the gas locked by the host is forwarded as it is
+func (e *dctTransfer) lockGasForCallback(vmInput *vmcommon.ContractCallInput, gasToForward uint64) (uint64, uint64, error) {
+	if !e.enableEpochsHandler.IsDCTTransferCallbackGasLockFlagEnabled() {
+		return gasToForward, vmInput.GasLocked, nil
+	}
+
+	return lockGasForCallback(vmInput, gasToForward, e.asyncCallbackCost)
+}
+
+// checkBasicDCTTransferArguments checks the arguments of a DCTTransfer, the only transfer built-in function which might
+// also move native value. The native value is accepted only if allowed and never if negative, being moved by the
+// protocol and not by the built-in function itself. The NFT transfers keep rejecting any native value
+func checkBasicDCTTransferArguments(vmInput *vmcommon.ContractCallInput, isValueAllowed bool) error {
+	if vmInput == nil {
+		return ErrNilVmInput
+	}
+	if vmInput.CallValue == nil {
+		return ErrNilValue
+	}
+	if vmInput.CallValue.Sign() < 0 {
+		return ErrNegativeValue
+	}
+	if vmInput.CallValue.Sign() > 0 && !isValueAllowed {
+		return ErrBuiltInFunctionCalledWithValue
+	}
+	if len(vmInput.Arguments) < core.MinLenArgumentsDCTTransfer {
+		return ErrInvalidArguments
+	}
+
+	return nil
+}
+
+// addSCCallAfterTransferToVMOutput adds to the output both the output transfer holding the smart contract call which
+// follows the token transfer and the same call as a ready to execute contract call input. The call gets the gas
+// remaining after the transfer, keeps aside the gas locked for the eventual callback and carries no native value, the
+// transferred tokens being passed as DCT transfers. The native value sent together with the tokens, if any, was already
+// credited to the recipient by the protocol before the built-in function was called, so neither the call nor the
+// output transfer moves it again, the value being reported only by the native value log entry.
+func addSCCallAfterTransferToVMOutput(
+	vmInput *vmcommon.ContractCallInput,
+	function string,
+	arguments [][]byte,
+	recipient []byte,
+	dctTransfers []*vmcommon.DCTTransfer,
+	gasLocked uint64,
+	vmOutput *vmcommon.VMOutput,
+) {
+	vmOutput.FollowUpCall = &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:     vmInput.CallerAddr,
+			Arguments:      arguments,
+			CallValue:      big.NewInt(0),
+			CallType:       vmInput.CallType,
+			GasPrice:       vmInput.GasPrice,
+			GasProvided:    vmOutput.GasRemaining,
+			GasLocked:      gasLocked,
+			OriginalTxHash: vmInput.OriginalTxHash,
+			CurrentTxHash:  vmInput.CurrentTxHash,
+			PrevTxHash:     vmInput.PrevTxHash,
+			DCTTransfers:   dctTransfers,
+		},
+		RecipientAddr: recipient,
+		Function:      function,
+		CorrelationID: vmInput.CorrelationID,
+	}
+
+	addOutputTransferToVMOutput(
+		vmInput,
+		function,
+		arguments,
+		recipient,
+		zero,
+		gasLocked,
+		vmOutput)
+}
+
 func addOutputTransferToVMOutput(
-	senderAddress []byte,
+	vmInput *vmcommon.ContractCallInput,
 	function string,
 	arguments [][]byte,
 	recipient []byte,
+	value *big.Int,
 	gasLocked uint64,
-	callType vm.CallType,
 	vmOutput *vmcommon.VMOutput,
 ) {
 	dctTransferTxData := function
@@ -199,19 +351,24 @@ func addOutputTransferToVMOutput(
 		dctTransferTxData += "@" + hex.EncodeToString(arg)
 	}
 	outTransfer := vmcommon.OutputTransfer{
-		Value:         big.NewInt(0),
+		Value:         big.NewInt(0).Set(vmcommon.ZeroValueIfNil(value)),
 		GasLimit:      vmOutput.GasRemaining,
 		GasLocked:     gasLocked,
 		Data:          []byte(dctTransferTxData),
-		CallType:      callType,
-		SenderAddress: senderAddress,
+		CallType:      vmInput.CallType,
+		SenderAddress: vmInput.CallerAddr,
+	}
+	// a pooled output keeps the emptied map of its previous use
+	if vmOutput.OutputAccounts == nil {
+		vmOutput.OutputAccounts = make(map[string]*vmcommon.OutputAccount)
 	}
-	vmOutput.OutputAccounts = make(map[string]*vmcommon.OutputAccount)
 	vmOutput.OutputAccounts[string(recipient)] = &vmcommon.OutputAccount{
 		Address:         recipient,
 		OutputTransfers: []vmcommon.OutputTransfer{outTransfer},
 	}
 	vmOutput.GasRemaining = 0
+
+	addAsyncMetadataToVMOutput(vmInput.CurrentTxHash, recipient, outTransfer, vmOutput)
 }
 
 func addToDCTBalance(
@@ -220,7 +377,23 @@ func addToDCTBalance(
 	value *big.Int,
 	marshaller vmcommon.Marshalizer,
 	globalSettingsHandler vmcommon.DCTGlobalSettingsHandler,
+	systemAddresses vmcommon.SystemAddresses,
+	isReturnWithError bool,
+) error {
+	return updateDCTBalance(userAcnt, key, value, marshaller, globalSettingsHandler, systemAddresses, isReturnWithError, nil)
+}
+
+// updateDCTBalance adds the value, which might be negative, to the fungible balance of the account. If a locked
+// balance checker is provided, the balance left after a debit must still cover the locked portion
+func updateDCTBalance(
+	userAcnt vmcommon.UserAccountHandler,
+	key []byte,
+	value *big.Int,
+	marshaller vmcommon.Marshalizer,
+	globalSettingsHandler vmcommon.DCTGlobalSettingsHandler,
+	systemAddresses vmcommon.SystemAddresses,
 	isReturnWithError bool,
+	lockedChecker *lockedBalanceChecker,
 ) error {
 	dctData, err := getDCTDataFromKey(userAcnt, key, marshaller)
 	if err != nil {
@@ -231,7 +404,7 @@ func addToDCTBalance(
 		return ErrOnlyFungibleTokensHaveBalanceTransfer
 	}
 
-	err = checkFrozeAndPause(userAcnt.AddressBytes(), key, dctData, globalSettingsHandler, isReturnWithError)
+	err = checkFrozeAndPause(userAcnt.AddressBytes(), key, dctData, globalSettingsHandler, systemAddresses, isReturnWithError)
 	if err != nil {
 		return err
 	}
@@ -240,6 +413,12 @@ func addToDCTBalance(
 	if dctData.Value.Cmp(zero) < 0 {
 		return ErrInsufficientFunds
 	}
+	if lockedChecker != nil && value.Sign() < 0 {
+		err = lockedChecker.checkBalanceIsNotLocked(dctData, isReturnWithError)
+		if err != nil {
+			return err
+		}
+	}
 
 	err = saveDCTData(userAcnt, dctData, key, marshaller)
 	if err != nil {
@@ -249,17 +428,59 @@ func addToDCTBalance(
 	return nil
 }
 
+// isTransferToSelf returns true if both accounts are loaded and the sender is also the receiver of the transfer
+func isTransferToSelf(acntSnd, acntDst vmcommon.UserAccountHandler) bool {
+	if check.IfNil(acntSnd) || check.IfNil(acntDst) {
+		return false
+	}
+
+	return bytes.Equal(acntSnd.AddressBytes(), acntDst.AddressBytes())
+}
+
+// checkDCTSelfTransfer validates a transfer whose sender is also the receiver exactly as the debit and the credit of
+// a transfer would, without saving the unchanged balance
+func checkDCTSelfTransfer(
+	userAcnt vmcommon.UserAccountHandler,
+	key []byte,
+	value *big.Int,
+	marshaller vmcommon.Marshalizer,
+	globalSettingsHandler vmcommon.DCTGlobalSettingsHandler,
+	systemAddresses vmcommon.SystemAddresses,
+	isReturnWithError bool,
+) error {
+	dctData, err := getDCTDataFromKey(userAcnt, key, marshaller)
+	if err != nil {
+		return err
+	}
+
+	if dctData.Type != uint32(core.Fungible) {
+		return ErrOnlyFungibleTokensHaveBalanceTransfer
+	}
+
+	err = checkFrozeAndPause(userAcnt.AddressBytes(), key, dctData, globalSettingsHandler, systemAddresses, isReturnWithError)
+	if err != nil {
+		return err
+	}
+
+	if dctData.Value.Cmp(value) < 0 {
+		return ErrInsufficientFunds
+	}
+
+	return nil
+}
+
 func checkFrozeAndPause(
 	senderAddr []byte,
 	key []byte,
 	dctData *dct.DCToken,
 	globalSettingsHandler vmcommon.DCTGlobalSettingsHandler,
+	systemAddresses vmcommon.SystemAddresses,
 	isReturnWithError bool,
 ) error {
 	if isReturnWithError {
 		return nil
 	}
-	if bytes.Equal(senderAddr, core.DCTSCAddress) {
+	if systemAddresses.IsDCTSCAddress(senderAddr) {
 		return nil
 	}
 
@@ -357,6 +578,28 @@ func checkIfTransferCanHappenWithLimitedTransfer(
 	return errDestination
 }
 
+// will return nil if the token is not soulbound
+// soulbound tokens can only be created and burnt, the check being done at sender shard only, so that the transfers
+// already started before the token became soulbound can be completed at destination
+func checkIfTransferCanHappenWithSoulbound(
+	dctTokenKey []byte,
+	globalSettingsHandler vmcommon.ExtendedDCTGlobalSettingsHandler,
+	acntSnd vmcommon.UserAccountHandler,
+	isReturnWithError bool,
+) error {
+	if isReturnWithError {
+		return nil
+	}
+	if check.IfNil(acntSnd) {
+		return nil
+	}
+	if globalSettingsHandler.IsSoulbound(dctTokenKey) {
+		return ErrTokenNotTransferable
+	}
+
+	return nil
+}
+
 // SetPayableChecker will set the payableCheck handler to the function
 func (e *dctTransfer) SetPayableChecker(payableHandler vmcommon.PayableChecker) error {
 	if check.IfNil(payableHandler) {
@@ -367,6 +610,19 @@ func (e *dctTransfer) SetPayableChecker(payableHandler vmcommon.PayableChecker)
 	return nil
 }
 
+// SetAddressClassifier sets the classifier deciding whether the receiver of the transfer is a smart contract
+func (e *dctTransfer) SetAddressClassifier(addressClassifier vmcommon.AddressClassifier) error {
+	if check.IfNil(addressClassifier) {
+		return ErrNilAddressClassifier
+	}
+
+	e.mutExecution.Lock()
+	e.addressClassifier = addressClassifier
+	e.mutExecution.Unlock()
+
+	return nil
+}
+
 // IsInterfaceNil returns true if underlying object in nil
 func (e *dctTransfer) IsInterfaceNil() bool {
 	return e == nil
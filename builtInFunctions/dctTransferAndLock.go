@@ -0,0 +1,202 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"math"
+	"math/big"
+	"sync"
+
+	"github.com/Reshusk23/sr-me-core/core"
+	"github.com/Reshusk23/sr-me-core/core/check"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+)
+
+const numArgumentsTransferAndLock = 3
+
+type dctTransferAndLock struct {
+	baseActiveHandler
+	freezeAccountChecker
+	lockedBalanceChecker
+	transferInterceptorChecker
+	funcGasCost           uint64
+	marshaller            vmcommon.Marshalizer
+	keyPrefix             []byte
+	globalSettingsHandler vmcommon.ExtendedDCTGlobalSettingsHandler
+	rolesHandler          vmcommon.DCTRoleHandler
+	addressClassifier     vmcommon.AddressClassifier
+	mutExecution          sync.RWMutex
+}
+
+// ArgsNewDCTTransferAndLock defines the argument list for new dct transfer and lock built in function
+type ArgsNewDCTTransferAndLock struct {
+	Config
+	FuncGasCost uint64
+}
+
+// NewDCTTransferAndLockFunc returns the dct transfer and lock built-in function component, which moves fungible tokens
+// to a receiver that can not spend them before an unlock epoch, as needed by the payroll and vesting flows
+func NewDCTTransferAndLockFunc(args ArgsNewDCTTransferAndLock) (*dctTransferAndLock, error) {
+	err := args.checkComponents(vmcommon.BuiltInFunctionDCTTransferAndLock, requireMarshalizer|requireGlobalSettingsHandler|requireRolesHandler|requireEnableEpochsHandler)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &dctTransferAndLock{
+		lockedBalanceChecker:  lockedBalanceChecker{enableEpochsHandler: args.EnableEpochsHandler},
+		funcGasCost:           args.FuncGasCost,
+		marshaller:            args.Marshalizer,
+		keyPrefix:             []byte(baseDCTKeyPrefix),
+		globalSettingsHandler: args.GlobalSettingsHandler,
+		rolesHandler:          args.RolesHandler,
+		addressClassifier:     vmcommon.NewDefaultAddressClassifier(),
+	}
+
+	e.baseActiveHandler.activeHandler = args.EnableEpochsHandler.IsDCTTransferAndLockFlagEnabled
+
+	return e, nil
+}
+
+// SetNewGasConfig is called whenever gas cost is changed
+func (e *dctTransferAndLock) SetNewGasConfig(gasCost *vmcommon.GasCost) {
+	if gasCost == nil {
+		return
+	}
+
+	e.mutExecution.Lock()
+	e.funcGasCost = gasCost.BuiltInCost.DCTTransfer
+	e.mutExecution.Unlock()
+}
+
+// ProcessBuiltinFunction resolves DCT transfer and lock function call
+// Requires 3 arguments:
+// arg0 - token identifier
+// arg1 - value
+// arg2 - epoch starting with which the receiver can spend the transferred value
+func (e *dctTransferAndLock) ProcessBuiltinFunction(
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	e.mutExecution.RLock()
+	defer e.mutExecution.RUnlock()
+
+	err := checkBasicDCTArguments(vmInput)
+	if err != nil {
+		return nil, err
+	}
+	if len(vmInput.Arguments) != numArgumentsTransferAndLock {
+		return nil, ErrInvalidArguments
+	}
+	if bytes.Equal(vmInput.CallerAddr, vmInput.RecipientAddr) {
+		return nil, ErrInvalidRcvAddr
+	}
+
+	value := big.NewInt(0).SetBytes(vmInput.Arguments[1])
+	if value.Cmp(zero) <= 0 {
+		return nil, ErrNegativeValue
+	}
+	unlockEpoch, err := uint64Argument(vmInput.Arguments, 2)
+	if err != nil {
+		return nil, err
+	}
+	if unlockEpoch > math.MaxUint32 {
+		return nil, ErrInvalidUnlockEpoch
+	}
+
+	tokenID := vmInput.Arguments[0]
+	dctTokenKey := append(e.keyPrefix, tokenID...)
+	err = checkIfTransferCanHappenWithLimitedTransfer(tokenID, dctTokenKey, vmInput.CallerAddr, vmInput.RecipientAddr, e.globalSettingsHandler, e.rolesHandler, acntSnd, acntDst, vmInput.ReturnCallAfterError)
+	if err != nil {
+		return nil, err
+	}
+	err = checkIfTransferCanHappenWithSoulbound(dctTokenKey, e.globalSettingsHandler, acntSnd, vmInput.ReturnCallAfterError)
+	if err != nil {
+		return nil, err
+	}
+
+	if !check.IfNil(acntSnd) {
+		// gas is paid only by sender
+		if vmInput.GasProvided < e.funcGasCost {
+			return nil, ErrNotEnoughGas
+		}
+		// the epoch might change until a cross-shard call reaches the receiver, so the unlock epoch is only checked here
+		if unlockEpoch <= uint64(e.getCurrentEpoch()) {
+			return nil, ErrInvalidUnlockEpoch
+		}
+
+		err = e.checkAccountIsNotFrozen(acntSnd.AddressBytes(), vmInput.ReturnCallAfterError)
+		if err != nil {
+			return nil, err
+		}
+		err = e.checkTransferIsAllowed(acntSnd.AddressBytes(), vmInput.RecipientAddr, tokenID, 0, value, vmInput.ReturnCallAfterError)
+		if err != nil {
+			return nil, err
+		}
+		err = e.subFromDCTBalance(acntSnd, dctTokenKey, value, e.marshaller, e.globalSettingsHandler, e.getSystemAddresses(), vmInput.ReturnCallAfterError)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	vmOutput := &vmcommon.VMOutput{ReturnCode: vmcommon.Ok, GasRemaining: computeGasRemaining(acntSnd, vmInput.GasProvided, e.funcGasCost)}
+	if !check.IfNil(acntDst) {
+		err = e.addLockedDCTBalance(acntDst, dctTokenKey, value, uint32(unlockEpoch), vmInput.ReturnCallAfterError)
+		if err != nil {
+			return nil, err
+		}
+
+		addDCTEntryInVMOutput(vmOutput, []byte(vmcommon.BuiltInFunctionDCTTransferAndLock), tokenID, 0, value, vmInput.CallerAddr, acntDst.AddressBytes(), vmInput.Arguments[2])
+		return vmOutput, nil
+	}
+
+	// cross-shard DCT transfer and lock call through a smart contract
+	if e.addressClassifier.IsSmartContract(vmInput.CallerAddr) {
+		addOutputTransferToVMOutput(
+			vmInput,
+			vmcommon.BuiltInFunctionDCTTransferAndLock,
+			vmInput.Arguments,
+			vmInput.RecipientAddr,
+			vmInput.CallValue,
+			vmInput.GasLocked,
+			vmOutput)
+	}
+
+	addDCTEntryInVMOutput(vmOutput, []byte(vmcommon.BuiltInFunctionDCTTransferAndLock), tokenID, 0, value, vmInput.CallerAddr, vmInput.RecipientAddr, vmInput.Arguments[2])
+	return vmOutput, nil
+}
+
+// addLockedDCTBalance credits the receiver and locks the credited value until the unlock epoch
+func (e *dctTransferAndLock) addLockedDCTBalance(
+	acntDst vmcommon.UserAccountHandler,
+	key []byte,
+	value *big.Int,
+	unlockEpoch uint32,
+	isReturnWithError bool,
+) error {
+	dctData, err := getDCTDataFromKey(acntDst, key, e.marshaller)
+	if err != nil {
+		return err
+	}
+	if dctData.Type != uint32(core.Fungible) {
+		return ErrOnlyFungibleTokensHaveBalanceTransfer
+	}
+
+	err = checkFrozeAndPause(acntDst.AddressBytes(), key, dctData, e.globalSettingsHandler, e.getSystemAddresses(), isReturnWithError)
+	if err != nil {
+		return err
+	}
+
+	dctData.Value.Add(dctData.Value, value)
+	dctUserMetadata := DCTUserMetadataFromBytes(dctData.Properties)
+	err = dctUserMetadata.addLockedValue(value, unlockEpoch, e.getCurrentEpoch())
+	if err != nil {
+		return err
+	}
+	dctData.Properties = dctUserMetadata.ToBytes()
+
+	return saveDCTData(acntDst, dctData, key, e.marshaller)
+}
+
+// IsInterfaceNil returns true if underlying object in nil
+func (e *dctTransferAndLock) IsInterfaceNil() bool {
+	return e == nil
+}
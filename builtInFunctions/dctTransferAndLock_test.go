@@ -0,0 +1,356 @@
+package builtInFunctions
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/Reshusk23/sr-me-core/data/dct"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var transferAndLockTokenID = []byte("TKN-abcdef")
+
+func createDCTTransferAndLock(t *testing.T) *dctTransferAndLock {
+	transferAndLockFunc, err := NewDCTTransferAndLockFunc(ArgsNewDCTTransferAndLock{
+		Config: Config{
+			Marshalizer:           &mock.MarshalizerMock{},
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{IsDCTTransferAndLockFlagEnabledField: true},
+		},
+		FuncGasCost: 10,
+	})
+	require.Nil(t, err)
+	transferAndLockFunc.enableEpochsHandler.(*mock.EnableEpochsHandlerStub).CurrentEpochField = 5
+
+	return transferAndLockFunc
+}
+
+func createTransferAndLockInput(value int64, unlockEpoch uint64) *vmcommon.ContractCallInput {
+	return &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  []byte("snd"),
+			CallValue:   big.NewInt(0),
+			GasProvided: 50,
+			Arguments:   [][]byte{transferAndLockTokenID, big.NewInt(value).Bytes(), big.NewInt(0).SetUint64(unlockEpoch).Bytes()},
+		},
+		RecipientAddr: []byte("dst"),
+	}
+}
+
+func saveFungibleBalance(t *testing.T, account vmcommon.UserAccountHandler, dctData *dct.DCToken) {
+	marshaledData, err := (&mock.MarshalizerMock{}).Marshal(dctData)
+	require.Nil(t, err)
+	key := append([]byte(baseDCTKeyPrefix), transferAndLockTokenID...)
+	err = account.AccountDataHandler().SaveKeyValue(key, marshaledData)
+	require.Nil(t, err)
+}
+
+func loadFungibleBalance(t *testing.T, account vmcommon.UserAccountHandler) *dct.DCToken {
+	key := append([]byte(baseDCTKeyPrefix), transferAndLockTokenID...)
+	dctData, err := getDCTDataFromKey(account, key, &mock.MarshalizerMock{})
+	require.Nil(t, err)
+
+	return dctData
+}
+
+func TestNewDCTTransferAndLockFunc(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil marshaller should error", func(t *testing.T) {
+		t.Parallel()
+
+		transferAndLockFunc, err := NewDCTTransferAndLockFunc(ArgsNewDCTTransferAndLock{
+			Config: Config{
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+				EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			},
+			FuncGasCost: 10,
+		})
+		assert.ErrorIs(t, err, ErrNilMarshalizer)
+		assert.Nil(t, transferAndLockFunc)
+	})
+	t.Run("nil global settings handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		transferAndLockFunc, err := NewDCTTransferAndLockFunc(ArgsNewDCTTransferAndLock{
+			Config: Config{
+				Marshalizer:         &mock.MarshalizerMock{},
+				RolesHandler:        &mock.DCTRoleHandlerStub{},
+				EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+			},
+			FuncGasCost: 10,
+		})
+		assert.ErrorIs(t, err, ErrNilGlobalSettingsHandler)
+		assert.Nil(t, transferAndLockFunc)
+	})
+	t.Run("nil roles handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		transferAndLockFunc, err := NewDCTTransferAndLockFunc(ArgsNewDCTTransferAndLock{
+			Config: Config{
+				Marshalizer:           &mock.MarshalizerMock{},
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			},
+			FuncGasCost: 10,
+		})
+		assert.ErrorIs(t, err, ErrNilRolesHandler)
+		assert.Nil(t, transferAndLockFunc)
+	})
+	t.Run("nil enable epochs handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		transferAndLockFunc, err := NewDCTTransferAndLockFunc(ArgsNewDCTTransferAndLock{
+			Config: Config{
+				Marshalizer:           &mock.MarshalizerMock{},
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+			},
+			FuncGasCost: 10,
+		})
+		assert.ErrorIs(t, err, ErrNilEnableEpochsHandler)
+		assert.Nil(t, transferAndLockFunc)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		transferAndLockFunc, err := NewDCTTransferAndLockFunc(ArgsNewDCTTransferAndLock{
+			Config: Config{
+				Marshalizer:           &mock.MarshalizerMock{},
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+				EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			},
+			FuncGasCost: 10,
+		})
+		assert.Nil(t, err)
+		assert.False(t, transferAndLockFunc.IsInterfaceNil())
+		assert.False(t, transferAndLockFunc.IsActive())
+
+		transferAndLockFunc.SetNewGasConfig(&vmcommon.GasCost{BuiltInCost: vmcommon.BuiltInCost{DCTTransfer: 20}})
+		assert.Equal(t, uint64(20), transferAndLockFunc.funcGasCost)
+	})
+}
+
+func TestDCTTransferAndLock_ProcessBuiltinFunctionErrors(t *testing.T) {
+	t.Parallel()
+
+	transferAndLockFunc := createDCTTransferAndLock(t)
+	accSnd := mock.NewUserAccount([]byte("snd"))
+	accDst := mock.NewUserAccount([]byte("dst"))
+
+	_, err := transferAndLockFunc.ProcessBuiltinFunction(accSnd, accDst, nil)
+	assert.Equal(t, ErrNilVmInput, err)
+
+	input := createTransferAndLockInput(10, 10)
+	input.Arguments = input.Arguments[:2]
+	_, err = transferAndLockFunc.ProcessBuiltinFunction(accSnd, accDst, input)
+	assert.Equal(t, ErrInvalidArguments, err)
+
+	input = createTransferAndLockInput(10, 10)
+	input.RecipientAddr = input.CallerAddr
+	_, err = transferAndLockFunc.ProcessBuiltinFunction(accSnd, accDst, input)
+	assert.Equal(t, ErrInvalidRcvAddr, err)
+
+	_, err = transferAndLockFunc.ProcessBuiltinFunction(accSnd, accDst, createTransferAndLockInput(0, 10))
+	assert.Equal(t, ErrNegativeValue, err)
+
+	_, err = transferAndLockFunc.ProcessBuiltinFunction(accSnd, accDst, createTransferAndLockInput(10, 5))
+	assert.Equal(t, ErrInvalidUnlockEpoch, err)
+
+	_, err = transferAndLockFunc.ProcessBuiltinFunction(accSnd, accDst, createTransferAndLockInput(10, 1<<32))
+	assert.Equal(t, ErrInvalidUnlockEpoch, err)
+
+	input = createTransferAndLockInput(10, 10)
+	input.GasProvided = 1
+	_, err = transferAndLockFunc.ProcessBuiltinFunction(accSnd, accDst, input)
+	assert.Equal(t, ErrNotEnoughGas, err)
+
+	_, err = transferAndLockFunc.ProcessBuiltinFunction(accSnd, accDst, createTransferAndLockInput(10, 10))
+	assert.Equal(t, ErrInsufficientFunds, err)
+}
+
+func TestDCTTransferAndLock_ProcessBuiltinFunctionSingleShard(t *testing.T) {
+	t.Parallel()
+
+	transferAndLockFunc := createDCTTransferAndLock(t)
+	accSnd := mock.NewUserAccount([]byte("snd"))
+	accDst := mock.NewUserAccount([]byte("dst"))
+	saveFungibleBalance(t, accSnd, &dct.DCToken{Value: big.NewInt(100)})
+	saveFungibleBalance(t, accDst, &dct.DCToken{Value: big.NewInt(7)})
+
+	vmOutput, err := transferAndLockFunc.ProcessBuiltinFunction(accSnd, accDst, createTransferAndLockInput(30, 10))
+	require.Nil(t, err)
+	assert.Equal(t, uint64(40), vmOutput.GasRemaining)
+	require.Len(t, vmOutput.Logs, 1)
+	assert.Equal(t, []byte(vmcommon.BuiltInFunctionDCTTransferAndLock), vmOutput.Logs[0].Identifier)
+	assert.Equal(t, []byte{10}, vmOutput.Logs[0].Topics[4])
+
+	assert.Equal(t, big.NewInt(70), loadFungibleBalance(t, accSnd).Value)
+	dstData := loadFungibleBalance(t, accDst)
+	assert.Equal(t, big.NewInt(37), dstData.Value)
+	dstMetadata := DCTUserMetadataFromBytes(dstData.Properties)
+	assert.Equal(t, []DCTLock{{Value: big.NewInt(30), UnlockEpoch: 10}}, dstMetadata.Locks)
+
+	_, err = transferAndLockFunc.ProcessBuiltinFunction(accSnd, accDst, createTransferAndLockInput(20, 8))
+	require.Nil(t, err)
+	dstMetadata = DCTUserMetadataFromBytes(loadFungibleBalance(t, accDst).Properties)
+	assert.Equal(t, []DCTLock{{Value: big.NewInt(20), UnlockEpoch: 8}, {Value: big.NewInt(30), UnlockEpoch: 10}}, dstMetadata.Locks)
+}
+
+func TestDCTTransferAndLock_LaterLockDoesNotExtendTheExistingOnes(t *testing.T) {
+	t.Parallel()
+
+	transferAndLockFunc := createDCTTransferAndLock(t)
+	accSnd := mock.NewUserAccount([]byte("snd"))
+	accDst := mock.NewUserAccount([]byte("dst"))
+	saveFungibleBalance(t, accSnd, &dct.DCToken{Value: big.NewInt(100)})
+
+	_, err := transferAndLockFunc.ProcessBuiltinFunction(accSnd, accDst, createTransferAndLockInput(30, 10))
+	require.Nil(t, err)
+	// a third party locking a dust amount far in the future must not delay the release of the first lock
+	_, err = transferAndLockFunc.ProcessBuiltinFunction(accSnd, accDst, createTransferAndLockInput(1, 200))
+	require.Nil(t, err)
+
+	dstMetadata := DCTUserMetadataFromBytes(loadFungibleBalance(t, accDst).Properties)
+	assert.Equal(t, big.NewInt(31), dstMetadata.LockedValueAt(9))
+	assert.Equal(t, big.NewInt(1), dstMetadata.LockedValueAt(10))
+}
+
+func TestDCTTransferAndLock_ProcessBuiltinFunctionCrossShard(t *testing.T) {
+	t.Parallel()
+
+	transferAndLockFunc := createDCTTransferAndLock(t)
+	accSnd := mock.NewUserAccount([]byte("snd"))
+	accDst := mock.NewUserAccount([]byte("dst"))
+	saveFungibleBalance(t, accSnd, &dct.DCToken{Value: big.NewInt(100)})
+
+	input := createTransferAndLockInput(30, 10)
+	input.CallerAddr = make([]byte, 32)
+	vmOutput, err := transferAndLockFunc.ProcessBuiltinFunction(accSnd, nil, input)
+	require.Nil(t, err)
+	assert.Equal(t, big.NewInt(70), loadFungibleBalance(t, accSnd).Value)
+	require.Len(t, vmOutput.OutputAccounts, 1)
+	outTransfer := vmOutput.OutputAccounts[string(input.RecipientAddr)].OutputTransfers[0]
+	assert.Equal(t, []byte(vmcommon.BuiltInFunctionDCTTransferAndLock+"@544b4e2d616263646566@1e@0a"), outTransfer.Data)
+
+	// the epoch passed by the time the call reached the receiver, so the tokens are credited already unlocked
+	transferAndLockFunc.enableEpochsHandler.(*mock.EnableEpochsHandlerStub).CurrentEpochField = 10
+	vmOutput, err = transferAndLockFunc.ProcessBuiltinFunction(nil, accDst, input)
+	require.Nil(t, err)
+	assert.Equal(t, uint64(0), vmOutput.GasRemaining)
+	dstData := loadFungibleBalance(t, accDst)
+	assert.Equal(t, big.NewInt(30), dstData.Value)
+	dstMetadata := DCTUserMetadataFromBytes(dstData.Properties)
+	assert.Equal(t, big.NewInt(0), dstMetadata.LockedValueAt(10))
+}
+
+func TestDCTTransferAndLock_LockedPortionCanNotBeSpent(t *testing.T) {
+	t.Parallel()
+
+	marshaller := &mock.MarshalizerMock{}
+	transferAndLockFunc := createDCTTransferAndLock(t)
+	accSnd := mock.NewUserAccount([]byte("snd"))
+	accDst := mock.NewUserAccount([]byte("dst"))
+	saveFungibleBalance(t, accSnd, &dct.DCToken{Value: big.NewInt(100)})
+	saveFungibleBalance(t, accDst, &dct.DCToken{Value: big.NewInt(5)})
+
+	_, err := transferAndLockFunc.ProcessBuiltinFunction(accSnd, accDst, createTransferAndLockInput(30, 10))
+	require.Nil(t, err)
+
+	enableEpochsHandler := &mock.EnableEpochsHandlerStub{IsGlobalMintBurnFlagEnabledField: true}
+	transferFunc, _ := NewDCTTransferFunc(ArgsNewDCTTransfer{
+		Config: Config{
+			Marshalizer:           marshaller,
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			ShardCoordinator:      &mock.ShardCoordinatorStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			EnableEpochsHandler:   enableEpochsHandler,
+		},
+		FuncGasCost: 10,
+	})
+	_ = transferFunc.SetPayableChecker(&mock.PayableHandlerStub{})
+	rolesHandler := &mock.DCTRoleHandlerStub{}
+	localBurnFunc, _ := NewDCTLocalBurnFunc(ArgsNewDCTLocalBurn{
+		Config: Config{
+			Marshalizer:           marshaller,
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          rolesHandler,
+			EnableEpochsHandler:   enableEpochsHandler,
+		},
+		FuncGasCost: 10,
+	})
+	enableEpochsHandler.CurrentEpochField = 9
+
+	transferInput := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  []byte("dst"),
+			CallValue:   big.NewInt(0),
+			GasProvided: 50,
+			Arguments:   [][]byte{transferAndLockTokenID, big.NewInt(6).Bytes()},
+		},
+		RecipientAddr: []byte("snd"),
+	}
+	_, err = transferFunc.ProcessBuiltinFunction(accDst, accSnd, transferInput)
+	assert.Equal(t, ErrDCTBalanceIsLocked, err)
+
+	burnInput := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  []byte("dst"),
+			CallValue:   big.NewInt(0),
+			GasProvided: 50,
+			Arguments:   [][]byte{transferAndLockTokenID, big.NewInt(6).Bytes()},
+		},
+		RecipientAddr: []byte("dst"),
+	}
+	_, err = localBurnFunc.ProcessBuiltinFunction(accDst, nil, burnInput)
+	assert.Equal(t, ErrDCTBalanceIsLocked, err)
+
+	transferInput.Arguments[1] = big.NewInt(5).Bytes()
+	_, err = transferFunc.ProcessBuiltinFunction(accDst, accSnd, transferInput)
+	require.Nil(t, err)
+	assert.Equal(t, big.NewInt(30), loadFungibleBalance(t, accDst).Value)
+
+	enableEpochsHandler.CurrentEpochField = 10
+	transferInput.Arguments[1] = big.NewInt(30).Bytes()
+	_, err = transferFunc.ProcessBuiltinFunction(accDst, accSnd, transferInput)
+	require.Nil(t, err)
+
+	key := append([]byte(baseDCTKeyPrefix), transferAndLockTokenID...)
+	marshaledData, _, _ := accDst.AccountDataHandler().RetrieveValue(key)
+	assert.Empty(t, marshaledData)
+	assert.Equal(t, big.NewInt(105), loadFungibleBalance(t, accSnd).Value)
+}
+
+func TestDCTTransferAndLock_ProcessBuiltinFunctionFrozenOrInterceptedSender(t *testing.T) {
+	t.Parallel()
+
+	accSnd := mock.NewUserAccount([]byte("snd"))
+	saveFungibleBalance(t, accSnd, &dct.DCToken{Value: big.NewInt(100)})
+
+	transferAndLockFunc := createDCTTransferAndLock(t)
+	_ = transferAndLockFunc.SetFreezeAccountHandler(&mock.FreezeAccountHandlerStub{
+		IsAccountFrozenCalled: func(address []byte) bool {
+			return true
+		},
+	})
+	_, err := transferAndLockFunc.ProcessBuiltinFunction(accSnd, nil, createTransferAndLockInput(30, 10))
+	assert.Equal(t, ErrAccountIsFrozen, err)
+
+	expectedErr := errors.New("transfer vetoed")
+	transferAndLockFunc = createDCTTransferAndLock(t)
+	_ = transferAndLockFunc.SetTransferInterceptor(&mock.TransferInterceptorStub{
+		PreTransferCalled: func(sender []byte, receiver []byte, token []byte, nonce uint64, amount *big.Int) error {
+			return expectedErr
+		},
+	})
+	_, err = transferAndLockFunc.ProcessBuiltinFunction(accSnd, nil, createTransferAndLockInput(30, 10))
+	assert.Equal(t, expectedErr, err)
+	assert.Equal(t, big.NewInt(100), loadFungibleBalance(t, accSnd).Value)
+}
@@ -4,54 +4,51 @@ import (
 	"bytes"
 	"math/big"
 
-	"github.com/Reshusk23/sr-me-core/core"
-	"github.com/Reshusk23/sr-me-core/core/check"
 	"github.com/Reshusk23/sr-me-core/data/dct"
-	"github.com/Reshusk23/sr-me-core/marshal"
 	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/protectedkeys"
 )
 
-const transfer = "transfer"
-
-var transferAddressesKeyPrefix = []byte(core.ProtectedKeyPrefix + transfer + core.DCTKeyIdentifier)
+var transferAddressesKeyPrefix = []byte(protectedkeys.TransferAddressesPrefix)
 
 type dctTransferAddress struct {
 	baseActiveHandler
+	baseSystemAddressesHandler
 	set             bool
 	marshaller      vmcommon.Marshalizer
 	accounts        vmcommon.AccountsAdapter
 	maxNumAddresses uint32
 }
 
+// ArgsNewDCTTransferRoleAddress defines the argument list for new dct transfer role add/delete address built in functions
+type ArgsNewDCTTransferRoleAddress struct {
+	Config
+	MaxNumAddresses uint32
+	Set             bool
+}
+
 // NewDCTTransferRoleAddressFunc returns the dct transfer role address handler built-in function component
-func NewDCTTransferRoleAddressFunc(
-	accounts vmcommon.AccountsAdapter,
-	marshaller marshal.Marshalizer,
-	maxNumAddresses uint32,
-	set bool,
-	enableEpochsHandler vmcommon.EnableEpochsHandler,
-) (*dctTransferAddress, error) {
-	if check.IfNil(marshaller) {
-		return nil, ErrNilMarshalizer
-	}
-	if check.IfNil(accounts) {
-		return nil, ErrNilAccountsAdapter
-	}
-	if maxNumAddresses < 1 {
-		return nil, ErrInvalidMaxNumAddresses
+func NewDCTTransferRoleAddressFunc(args ArgsNewDCTTransferRoleAddress) (*dctTransferAddress, error) {
+	function := vmcommon.BuiltInFunctionDCTTransferRoleDeleteAddress
+	if args.Set {
+		function = vmcommon.BuiltInFunctionDCTTransferRoleAddAddress
 	}
-	if check.IfNil(enableEpochsHandler) {
-		return nil, ErrNilEnableEpochsHandler
+	err := args.checkComponents(function, requireMarshalizer|requireAccounts|requireEnableEpochsHandler)
+	if err != nil {
+		return nil, err
+	}
+	if args.MaxNumAddresses < 1 {
+		return nil, ErrInvalidMaxNumAddresses
 	}
 
 	e := &dctTransferAddress{
-		accounts:        accounts,
-		marshaller:      marshaller,
-		maxNumAddresses: maxNumAddresses,
-		set:             set,
+		accounts:        args.Accounts,
+		marshaller:      args.Marshalizer,
+		maxNumAddresses: args.MaxNumAddresses,
+		set:             args.Set,
 	}
 
-	e.baseActiveHandler.activeHandler = enableEpochsHandler.IsSendAlwaysFlagEnabled
+	e.baseActiveHandler.activeHandler = args.EnableEpochsHandler.IsSendAlwaysFlagEnabled
 
 	return e, nil
 }
@@ -69,10 +66,10 @@ func (e *dctTransferAddress) ProcessBuiltinFunction(
 	if err != nil {
 		return nil, err
 	}
-	if !bytes.Equal(vmInput.CallerAddr, core.DCTSCAddress) {
+	if !e.getSystemAddresses().IsDCTSCAddress(vmInput.CallerAddr) {
 		return nil, ErrAddressIsNotDCTSystemSC
 	}
-	if !vmcommon.IsSystemAccountAddress(vmInput.RecipientAddr) {
+	if !e.getSystemAddresses().IsSystemAccountAddress(vmInput.RecipientAddr) {
 		return nil, ErrOnlySystemAccountAccepted
 	}
 
@@ -136,7 +133,7 @@ func (e *dctTransferAddress) addNewAddresses(vmInput *vmcommon.ContractCallInput
 }
 
 func (e *dctTransferAddress) getSystemAccount() (vmcommon.UserAccountHandler, error) {
-	systemSCAccount, err := e.accounts.LoadAccount(vmcommon.SystemAccountAddress)
+	systemSCAccount, err := e.accounts.LoadAccount(e.getSystemAddresses().SystemAccountAddress)
 	if err != nil {
 		return nil, err
 	}
@@ -13,27 +13,64 @@ import (
 )
 
 func TestNewDCTTransferRoleAddressFunc(t *testing.T) {
-	_, err := NewDCTTransferRoleAddressFunc(nil, &mock.MarshalizerMock{}, 10, true, &mock.EnableEpochsHandlerStub{
-		IsSendAlwaysFlagEnabledField: true,
+	_, err := NewDCTTransferRoleAddressFunc(ArgsNewDCTTransferRoleAddress{
+		Config: Config{
+			Marshalizer: &mock.MarshalizerMock{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+				IsSendAlwaysFlagEnabledField: true,
+			},
+		},
+		MaxNumAddresses: 10,
+		Set:             true,
 	})
-	assert.Equal(t, err, ErrNilAccountsAdapter)
-
-	_, err = NewDCTTransferRoleAddressFunc(&mock.AccountsStub{}, nil, 10, true, &mock.EnableEpochsHandlerStub{
-		IsSendAlwaysFlagEnabledField: true,
+	assert.ErrorIs(t, err, ErrNilAccountsAdapter)
+
+	_, err = NewDCTTransferRoleAddressFunc(ArgsNewDCTTransferRoleAddress{
+		Config: Config{
+			Accounts: &mock.AccountsStub{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+				IsSendAlwaysFlagEnabledField: true,
+			},
+		},
+		MaxNumAddresses: 10,
+		Set:             true,
 	})
-	assert.Equal(t, err, ErrNilMarshalizer)
-
-	e, err := NewDCTTransferRoleAddressFunc(&mock.AccountsStub{}, &mock.MarshalizerMock{}, 0, true, &mock.EnableEpochsHandlerStub{
-		IsSendAlwaysFlagEnabledField: true,
+	assert.ErrorIs(t, err, ErrNilMarshalizer)
+
+	e, err := NewDCTTransferRoleAddressFunc(ArgsNewDCTTransferRoleAddress{
+		Config: Config{
+			Accounts:    &mock.AccountsStub{},
+			Marshalizer: &mock.MarshalizerMock{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+				IsSendAlwaysFlagEnabledField: true,
+			},
+		},
+		MaxNumAddresses: 0,
+		Set:             true,
 	})
 	assert.Equal(t, err, ErrInvalidMaxNumAddresses)
 
-	_, err = NewDCTTransferRoleAddressFunc(&mock.AccountsStub{}, &mock.MarshalizerMock{}, 10, true, nil)
-	assert.Equal(t, err, ErrNilEnableEpochsHandler)
+	_, err = NewDCTTransferRoleAddressFunc(ArgsNewDCTTransferRoleAddress{
+		Config: Config{
+			Accounts:    &mock.AccountsStub{},
+			Marshalizer: &mock.MarshalizerMock{},
+		},
+		MaxNumAddresses: 10,
+		Set:             true,
+	})
+	assert.ErrorIs(t, err, ErrNilEnableEpochsHandler)
 	assert.True(t, check.IfNil(e))
 
-	e, err = NewDCTTransferRoleAddressFunc(&mock.AccountsStub{}, &mock.MarshalizerMock{}, 10, true, &mock.EnableEpochsHandlerStub{
-		IsSendAlwaysFlagEnabledField: true,
+	e, err = NewDCTTransferRoleAddressFunc(ArgsNewDCTTransferRoleAddress{
+		Config: Config{
+			Accounts:    &mock.AccountsStub{},
+			Marshalizer: &mock.MarshalizerMock{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+				IsSendAlwaysFlagEnabledField: true,
+			},
+		},
+		MaxNumAddresses: 10,
+		Set:             true,
 	})
 	assert.Nil(t, err)
 
@@ -44,8 +81,16 @@ func TestNewDCTTransferRoleAddressFunc(t *testing.T) {
 func TestDCTTransferRoleProcessBuiltInFunction_Errors(t *testing.T) {
 	accounts := &mock.AccountsStub{}
 	marshaller := &mock.MarshalizerMock{}
-	e, err := NewDCTTransferRoleAddressFunc(accounts, marshaller, 10, true, &mock.EnableEpochsHandlerStub{
-		IsSendAlwaysFlagEnabledField: true,
+	e, err := NewDCTTransferRoleAddressFunc(ArgsNewDCTTransferRoleAddress{
+		Config: Config{
+			Accounts:    accounts,
+			Marshalizer: marshaller,
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+				IsSendAlwaysFlagEnabledField: true,
+			},
+		},
+		MaxNumAddresses: 10,
+		Set:             true,
 	})
 	assert.Nil(t, err)
 
@@ -103,8 +148,16 @@ func TestDCTTransferRoleProcessBuiltInFunction_Errors(t *testing.T) {
 func TestDCTTransferRoleProcessBuiltInFunction_AddNewAddresses(t *testing.T) {
 	accounts := &mock.AccountsStub{}
 	marshaller := &mock.MarshalizerMock{}
-	e, err := NewDCTTransferRoleAddressFunc(accounts, marshaller, 10, true, &mock.EnableEpochsHandlerStub{
-		IsSendAlwaysFlagEnabledField: true,
+	e, err := NewDCTTransferRoleAddressFunc(ArgsNewDCTTransferRoleAddress{
+		Config: Config{
+			Accounts:    accounts,
+			Marshalizer: marshaller,
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+				IsSendAlwaysFlagEnabledField: true,
+			},
+		},
+		MaxNumAddresses: 10,
+		Set:             true,
 	})
 	assert.Nil(t, err)
 
@@ -152,7 +205,15 @@ func TestDCTTransferRoleIsSenderOrDestinationWithTransferRole(t *testing.T) {
 	enableEpochsHandler := &mock.EnableEpochsHandlerStub{
 		IsSendAlwaysFlagEnabledField: true,
 	}
-	e, err := NewDCTTransferRoleAddressFunc(accounts, marshaller, 10, true, enableEpochsHandler)
+	e, err := NewDCTTransferRoleAddressFunc(ArgsNewDCTTransferRoleAddress{
+		Config: Config{
+			Accounts:            accounts,
+			Marshalizer:         marshaller,
+			EnableEpochsHandler: enableEpochsHandler,
+		},
+		MaxNumAddresses: 10,
+		Set:             true,
+	})
 	assert.Nil(t, err)
 
 	vmInput := &vmcommon.ContractCallInput{
@@ -178,7 +239,16 @@ func TestDCTTransferRoleIsSenderOrDestinationWithTransferRole(t *testing.T) {
 	addresses, _, _ := getDCTRolesForAcnt(e.marshaller, systemAcc, append(transferAddressesKeyPrefix, vmInput.Arguments[0]...))
 	assert.Equal(t, len(addresses.Roles), 3)
 
-	globalSettings, _ := NewDCTGlobalSettingsFunc(accounts, marshaller, true, vmcommon.BuiltInFunctionDCTSetBurnRoleForAll, enableEpochsHandler.IsSendAlwaysFlagEnabled)
+	globalSettings, _ := NewDCTGlobalSettingsFunc(ArgsNewDCTGlobalSettings{
+		Config: Config{
+			Accounts:            accounts,
+			Marshalizer:         marshaller,
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+		},
+		Set:           true,
+		Function:      vmcommon.BuiltInFunctionDCTSetBurnRoleForAll,
+		ActiveHandler: enableEpochsHandler.IsSendAlwaysFlagEnabled,
+	})
 	assert.False(t, globalSettings.IsSenderOrDestinationWithTransferRole(nil, nil, nil))
 	assert.False(t, globalSettings.IsSenderOrDestinationWithTransferRole(vmInput.Arguments[1], []byte("random"), []byte("random")))
 	assert.False(t, globalSettings.IsSenderOrDestinationWithTransferRole(vmInput.Arguments[1], vmInput.Arguments[2], []byte("random")))
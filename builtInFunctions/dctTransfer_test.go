@@ -20,42 +20,80 @@ func TestNewDCTTransferFunc(t *testing.T) {
 	t.Run("nil marshaller should error", func(t *testing.T) {
 		t.Parallel()
 
-		transferFunc, err := NewDCTTransferFunc(10, nil, nil, nil, nil, nil)
-		assert.Equal(t, ErrNilMarshalizer, err)
+		transferFunc, err := NewDCTTransferFunc(ArgsNewDCTTransfer{
+			FuncGasCost: 10,
+		})
+		assert.ErrorIs(t, err, ErrNilMarshalizer)
 		assert.True(t, check.IfNil(transferFunc))
 	})
 	t.Run("nil global settings handler should error", func(t *testing.T) {
 		t.Parallel()
 
-		transferFunc, err := NewDCTTransferFunc(10, &mock.MarshalizerMock{}, nil, nil, nil, nil)
-		assert.Equal(t, ErrNilGlobalSettingsHandler, err)
+		transferFunc, err := NewDCTTransferFunc(ArgsNewDCTTransfer{
+			Config: Config{
+				Marshalizer:      &mock.MarshalizerMock{},
+				ShardCoordinator: &mock.ShardCoordinatorStub{},
+			},
+			FuncGasCost: 10,
+		})
+		assert.ErrorIs(t, err, ErrNilGlobalSettingsHandler)
 		assert.True(t, check.IfNil(transferFunc))
 	})
 	t.Run("nil shard coordinator should error", func(t *testing.T) {
 		t.Parallel()
 
-		transferFunc, err := NewDCTTransferFunc(10, &mock.MarshalizerMock{}, &mock.GlobalSettingsHandlerStub{}, nil, nil, nil)
-		assert.Equal(t, ErrNilShardCoordinator, err)
+		transferFunc, err := NewDCTTransferFunc(ArgsNewDCTTransfer{
+			Config: Config{
+				Marshalizer:           &mock.MarshalizerMock{},
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			},
+			FuncGasCost: 10,
+		})
+		assert.ErrorIs(t, err, ErrNilShardCoordinator)
 		assert.True(t, check.IfNil(transferFunc))
 	})
 	t.Run("nil roles handler should error", func(t *testing.T) {
 		t.Parallel()
 
-		transferFunc, err := NewDCTTransferFunc(10, &mock.MarshalizerMock{}, &mock.GlobalSettingsHandlerStub{}, &mock.ShardCoordinatorStub{}, nil, nil)
-		assert.Equal(t, ErrNilRolesHandler, err)
+		transferFunc, err := NewDCTTransferFunc(ArgsNewDCTTransfer{
+			Config: Config{
+				Marshalizer:           &mock.MarshalizerMock{},
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				ShardCoordinator:      &mock.ShardCoordinatorStub{},
+			},
+			FuncGasCost: 10,
+		})
+		assert.ErrorIs(t, err, ErrNilRolesHandler)
 		assert.True(t, check.IfNil(transferFunc))
 	})
 	t.Run("nil enable epochs handler should error", func(t *testing.T) {
 		t.Parallel()
 
-		transferFunc, err := NewDCTTransferFunc(10, &mock.MarshalizerMock{}, &mock.GlobalSettingsHandlerStub{}, &mock.ShardCoordinatorStub{}, &mock.DCTRoleHandlerStub{}, nil)
-		assert.Equal(t, ErrNilEnableEpochsHandler, err)
+		transferFunc, err := NewDCTTransferFunc(ArgsNewDCTTransfer{
+			Config: Config{
+				Marshalizer:           &mock.MarshalizerMock{},
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				ShardCoordinator:      &mock.ShardCoordinatorStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+			},
+			FuncGasCost: 10,
+		})
+		assert.ErrorIs(t, err, ErrNilEnableEpochsHandler)
 		assert.True(t, check.IfNil(transferFunc))
 	})
 	t.Run("should work", func(t *testing.T) {
 		t.Parallel()
 
-		transferFunc, err := NewDCTTransferFunc(10, &mock.MarshalizerMock{}, &mock.GlobalSettingsHandlerStub{}, &mock.ShardCoordinatorStub{}, &mock.DCTRoleHandlerStub{}, &mock.EnableEpochsHandlerStub{})
+		transferFunc, err := NewDCTTransferFunc(ArgsNewDCTTransfer{
+			Config: Config{
+				Marshalizer:           &mock.MarshalizerMock{},
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				ShardCoordinator:      &mock.ShardCoordinatorStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+				EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			},
+			FuncGasCost: 10,
+		})
 		assert.Nil(t, err)
 		assert.False(t, check.IfNil(transferFunc))
 	})
@@ -64,9 +102,18 @@ func TestDCTTransfer_ProcessBuiltInFunctionErrors(t *testing.T) {
 	t.Parallel()
 
 	shardC := &mock.ShardCoordinatorStub{}
-	transferFunc, _ := NewDCTTransferFunc(10, &mock.MarshalizerMock{}, &mock.GlobalSettingsHandlerStub{}, shardC, &mock.DCTRoleHandlerStub{}, &mock.EnableEpochsHandlerStub{
-		IsTransferToMetaFlagEnabledField:                     false,
-		IsCheckCorrectTokenIDForTransferRoleFlagEnabledField: true,
+	transferFunc, _ := NewDCTTransferFunc(ArgsNewDCTTransfer{
+		Config: Config{
+			Marshalizer:           &mock.MarshalizerMock{},
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			ShardCoordinator:      shardC,
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+				IsTransferToMetaFlagEnabledField:                     false,
+				IsCheckCorrectTokenIDForTransferRoleFlagEnabledField: true,
+			},
+		},
+		FuncGasCost: 10,
 	})
 	_ = transferFunc.SetPayableChecker(&mock.PayableHandlerStub{})
 	_, err := transferFunc.ProcessBuiltinFunction(nil, nil, nil)
@@ -106,6 +153,54 @@ func TestDCTTransfer_ProcessBuiltInFunctionErrors(t *testing.T) {
 	assert.Equal(t, err, ErrInvalidRcvAddr)
 }
 
+func TestDCTTransfer_CheckIsExecutable(t *testing.T) {
+	t.Parallel()
+
+	shardC := &mock.ShardCoordinatorStub{}
+	transferFunc, _ := NewDCTTransferFunc(ArgsNewDCTTransfer{
+		Config: Config{
+			Marshalizer:           &mock.MarshalizerMock{},
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			ShardCoordinator:      shardC,
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+		},
+		FuncGasCost: 10,
+	})
+
+	err := transferFunc.CheckIsExecutable(nil)
+	assert.Equal(t, ErrNilVmInput, err)
+
+	input := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallValue:   big.NewInt(0),
+			Arguments:   [][]byte{[]byte("key")},
+			GasProvided: 10,
+		},
+	}
+	err = transferFunc.CheckIsExecutable(input)
+	assert.Equal(t, ErrInvalidArguments, err)
+
+	input.Arguments = [][]byte{[]byte("key"), {}}
+	err = transferFunc.CheckIsExecutable(input)
+	assert.Equal(t, ErrNegativeValue, err)
+
+	input.Arguments = [][]byte{[]byte("key"), big.NewInt(5).Bytes()}
+	input.GasProvided = 9
+	err = transferFunc.CheckIsExecutable(input)
+	assert.Equal(t, ErrNotEnoughGas, err)
+
+	input.GasProvided = 10
+	err = transferFunc.CheckIsExecutable(input)
+	assert.Nil(t, err)
+
+	shardC.ComputeIdCalled = func(address []byte) uint32 {
+		return core.MetachainShardId
+	}
+	err = transferFunc.CheckIsExecutable(input)
+	assert.Equal(t, ErrInvalidRcvAddr, err)
+}
+
 func TestDCTTransfer_ProcessBuiltInFunctionSingleShard(t *testing.T) {
 	t.Parallel()
 
@@ -120,7 +215,16 @@ func TestDCTTransfer_ProcessBuiltInFunctionSingleShard(t *testing.T) {
 		IsTransferToMetaFlagEnabledField:                     false,
 		IsCheckCorrectTokenIDForTransferRoleFlagEnabledField: true,
 	}
-	transferFunc, _ := NewDCTTransferFunc(10, marshaller, &mock.GlobalSettingsHandlerStub{}, &mock.ShardCoordinatorStub{}, dctRoleHandler, enableEpochsHandler)
+	transferFunc, _ := NewDCTTransferFunc(ArgsNewDCTTransfer{
+		Config: Config{
+			Marshalizer:           marshaller,
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			ShardCoordinator:      &mock.ShardCoordinatorStub{},
+			RolesHandler:          dctRoleHandler,
+			EnableEpochsHandler:   enableEpochsHandler,
+		},
+		FuncGasCost: 10,
+	})
 	_ = transferFunc.SetPayableChecker(&mock.PayableHandlerStub{})
 
 	input := &vmcommon.ContractCallInput{
@@ -154,13 +258,201 @@ func TestDCTTransfer_ProcessBuiltInFunctionSingleShard(t *testing.T) {
 	assert.True(t, dctToken.Value.Cmp(big.NewInt(10)) == 0)
 }
 
+func TestDCTTransfer_ProcessBuiltInFunctionGasBreakdown(t *testing.T) {
+	t.Parallel()
+
+	marshaller := &mock.MarshalizerMock{}
+	transferFunc, _ := NewDCTTransferFunc(ArgsNewDCTTransfer{
+		Config: Config{
+			Marshalizer:           marshaller,
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			ShardCoordinator:      &mock.ShardCoordinatorStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+		},
+		FuncGasCost: 10,
+	})
+	_ = transferFunc.SetPayableChecker(&mock.PayableHandlerStub{})
+
+	key := []byte("key")
+	input := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			GasProvided: 50,
+			CallValue:   big.NewInt(0),
+			Arguments:   [][]byte{key, big.NewInt(10).Bytes()},
+		},
+		RecordGasBreakdown: true,
+	}
+	accSnd := mock.NewUserAccount([]byte("snd"))
+	accDst := mock.NewUserAccount([]byte("dst"))
+	marshaledData, _ := marshaller.Marshal(&dct.DCToken{Value: big.NewInt(100)})
+	_ = accSnd.AccountDataHandler().SaveKeyValue(append(transferFunc.keyPrefix, key...), marshaledData)
+
+	vmOutput, err := transferFunc.ProcessBuiltinFunction(accSnd, accDst, input)
+	assert.Nil(t, err)
+	assert.Equal(t, &vmcommon.GasBreakdown{TransferCost: 10}, vmOutput.GasBreakdown)
+	assert.Equal(t, uint64(40), vmOutput.GasRemaining)
+
+	// the gas was already paid on the sender shard
+	vmOutput, err = transferFunc.ProcessBuiltinFunction(nil, accDst, input)
+	assert.Nil(t, err)
+	assert.Nil(t, vmOutput.GasBreakdown)
+}
+
+func TestDCTTransfer_ProcessBuiltInFunctionSoulbound(t *testing.T) {
+	t.Parallel()
+
+	marshaller := &mock.MarshalizerMock{}
+	globalSettings := &mock.GlobalSettingsHandlerStub{
+		IsSoulboundCalled: func(token []byte) bool {
+			return true
+		},
+	}
+	transferFunc, _ := NewDCTTransferFunc(ArgsNewDCTTransfer{
+		Config: Config{
+			Marshalizer:           marshaller,
+			GlobalSettingsHandler: globalSettings,
+			ShardCoordinator:      &mock.ShardCoordinatorStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+		},
+		FuncGasCost: 10,
+	})
+	_ = transferFunc.SetPayableChecker(&mock.PayableHandlerStub{})
+
+	key := []byte("key")
+	input := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			GasProvided: 50,
+			CallValue:   big.NewInt(0),
+			Arguments:   [][]byte{key, big.NewInt(10).Bytes()},
+		},
+	}
+	accSnd := mock.NewUserAccount([]byte("snd"))
+	accDst := mock.NewUserAccount([]byte("dst"))
+
+	dctKey := append(transferFunc.keyPrefix, key...)
+	marshaledData, _ := marshaller.Marshal(&dct.DCToken{Value: big.NewInt(100)})
+	_ = accSnd.AccountDataHandler().SaveKeyValue(dctKey, marshaledData)
+
+	t.Run("sender side should error", func(t *testing.T) {
+		_, err := transferFunc.ProcessBuiltinFunction(accSnd, accDst, input)
+		assert.Equal(t, ErrTokenNotTransferable, err)
+	})
+	t.Run("destination side should work", func(t *testing.T) {
+		_, err := transferFunc.ProcessBuiltinFunction(nil, accDst, input)
+		assert.Nil(t, err)
+	})
+}
+
+func TestDCTTransfer_ProcessBuiltInFunctionWithSCCallAfterLocksCallbackGas(t *testing.T) {
+	t.Parallel()
+
+	scAddress := append(make([]byte, vmcommon.NumInitCharactersForScAddress), bytes.Repeat([]byte{1}, 22)...)
+	key := []byte("key")
+	createTransferFunc := func(flagEnabled bool) *dctTransfer {
+		transferFunc, _ := NewDCTTransferFunc(ArgsNewDCTTransfer{
+			Config: Config{
+				Marshalizer:           &mock.MarshalizerMock{},
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				ShardCoordinator:      &mock.ShardCoordinatorStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+				EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+					IsDCTTransferCallbackGasLockFlagEnabledField: flagEnabled,
+				},
+			},
+		})
+		transferFunc.SetNewGasConfig(&vmcommon.GasCost{
+			BuiltInCost: vmcommon.BuiltInCost{DCTTransfer: 10},
+			AsyncCallbackCost: vmcommon.AsyncCallbackCost{
+				AsyncCallStep:        100,
+				AsyncCallbackGasLock: 900,
+			},
+		})
+		_ = transferFunc.SetPayableChecker(&mock.PayableHandlerStub{
+			DetermineIsSCCallAfterCalled: func(vmInput *vmcommon.ContractCallInput, dstAddress []byte, mintArgs int) bool {
+				return true
+			},
+		})
+
+		return transferFunc
+	}
+	createInput := func(gasProvided uint64) *vmcommon.ContractCallInput {
+		return &vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallerAddr:  scAddress,
+				CallType:    vm.AsynchronousCall,
+				GasProvided: gasProvided,
+				GasLocked:   400,
+				CallValue:   big.NewInt(0),
+				Arguments:   [][]byte{key, big.NewInt(10).Bytes(), []byte("deposit")},
+			},
+			RecipientAddr: scAddress,
+		}
+	}
+
+	t.Run("flag disabled should forward the gas locked by the host", func(t *testing.T) {
+		t.Parallel()
+
+		vmOutput, err := createTransferFunc(false).ProcessBuiltinFunction(nil, mock.NewUserAccount(scAddress), createInput(10000))
+		assert.Nil(t, err)
+		assert.Equal(t, uint64(9990), vmOutput.FollowUpCall.GasProvided)
+		assert.Equal(t, uint64(400), vmOutput.FollowUpCall.GasLocked)
+	})
+	t.Run("flag enabled should lock the callback gas on the destination shard", func(t *testing.T) {
+		t.Parallel()
+
+		vmOutput, err := createTransferFunc(true).ProcessBuiltinFunction(nil, mock.NewUserAccount(scAddress), createInput(10000))
+		assert.Nil(t, err)
+		assert.Equal(t, uint64(9390), vmOutput.FollowUpCall.GasProvided)
+		assert.Equal(t, uint64(1000), vmOutput.FollowUpCall.GasLocked)
+
+		outputTransfer := vmOutput.OutputAccounts[string(scAddress)].OutputTransfers[0]
+		assert.Equal(t, uint64(9390), outputTransfer.GasLimit)
+		assert.Equal(t, uint64(1000), outputTransfer.GasLocked)
+	})
+	t.Run("flag enabled should lock the callback gas on the sender shard", func(t *testing.T) {
+		t.Parallel()
+
+		transferFunc := createTransferFunc(true)
+		accSnd := mock.NewUserAccount(scAddress)
+		marshaledData, _ := transferFunc.marshaller.Marshal(&dct.DCToken{Value: big.NewInt(100)})
+		_ = accSnd.AccountDataHandler().SaveKeyValue(append(transferFunc.keyPrefix, key...), marshaledData)
+
+		vmOutput, err := transferFunc.ProcessBuiltinFunction(accSnd, nil, createInput(10000))
+		assert.Nil(t, err)
+
+		outputTransfer := vmOutput.OutputAccounts[string(scAddress)].OutputTransfers[0]
+		assert.Equal(t, uint64(9390), outputTransfer.GasLimit)
+		assert.Equal(t, uint64(1000), outputTransfer.GasLocked)
+	})
+	t.Run("flag enabled and not enough gas for the callback should error", func(t *testing.T) {
+		t.Parallel()
+
+		input := createInput(500)
+		input.GasLocked = 0
+		vmOutput, err := createTransferFunc(true).ProcessBuiltinFunction(nil, mock.NewUserAccount(scAddress), input)
+		assert.Equal(t, ErrNotEnoughGas, err)
+		assert.Nil(t, vmOutput)
+	})
+}
+
 func TestDCTTransfer_ProcessBuiltInFunctionSenderInShard(t *testing.T) {
 	t.Parallel()
 
 	marshaller := &mock.MarshalizerMock{}
-	transferFunc, _ := NewDCTTransferFunc(10, marshaller, &mock.GlobalSettingsHandlerStub{}, &mock.ShardCoordinatorStub{}, &mock.DCTRoleHandlerStub{}, &mock.EnableEpochsHandlerStub{
-		IsTransferToMetaFlagEnabledField:                     false,
-		IsCheckCorrectTokenIDForTransferRoleFlagEnabledField: true,
+	transferFunc, _ := NewDCTTransferFunc(ArgsNewDCTTransfer{
+		Config: Config{
+			Marshalizer:           marshaller,
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			ShardCoordinator:      &mock.ShardCoordinatorStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+				IsTransferToMetaFlagEnabledField:                     false,
+				IsCheckCorrectTokenIDForTransferRoleFlagEnabledField: true,
+			},
+		},
+		FuncGasCost: 10,
 	})
 	_ = transferFunc.SetPayableChecker(&mock.PayableHandlerStub{})
 
@@ -187,13 +479,131 @@ func TestDCTTransfer_ProcessBuiltInFunctionSenderInShard(t *testing.T) {
 	assert.True(t, dctToken.Value.Cmp(big.NewInt(90)) == 0)
 }
 
+func TestDCTTransfer_SetAddressClassifier(t *testing.T) {
+	t.Parallel()
+
+	marshaller := &mock.MarshalizerMock{}
+	transferFunc, _ := NewDCTTransferFunc(ArgsNewDCTTransfer{
+		Config: Config{
+			Marshalizer:           marshaller,
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			ShardCoordinator:      &mock.ShardCoordinatorStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+		},
+		FuncGasCost: 10,
+	})
+	_ = transferFunc.SetPayableChecker(&mock.PayableHandlerStub{})
+
+	err := transferFunc.SetAddressClassifier(nil)
+	assert.Equal(t, ErrNilAddressClassifier, err)
+
+	err = transferFunc.SetAddressClassifier(&mock.AddressClassifierStub{
+		IsSmartContractCalled: func(address []byte) bool {
+			return string(address) == "snd"
+		},
+	})
+	assert.Nil(t, err)
+
+	input := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  []byte("snd"),
+			GasProvided: 50,
+			CallValue:   big.NewInt(0),
+		},
+		RecipientAddr: []byte("dst"),
+	}
+	key := []byte("key")
+	input.Arguments = [][]byte{key, big.NewInt(10).Bytes()}
+	accSnd := mock.NewUserAccount([]byte("snd"))
+	dctKey := append(transferFunc.keyPrefix, key...)
+	marshaledData, _ := marshaller.Marshal(&dct.DCToken{Value: big.NewInt(100)})
+	_ = accSnd.AccountDataHandler().SaveKeyValue(dctKey, marshaledData)
+
+	vmOutput, err := transferFunc.ProcessBuiltinFunction(accSnd, nil, input)
+	assert.Nil(t, err)
+	assert.NotNil(t, vmOutput.OutputAccounts[string(input.RecipientAddr)])
+}
+
+func TestDCTTransfer_ProcessBuiltInFunctionDestInShardWithSCCallAfter(t *testing.T) {
+	t.Parallel()
+
+	marshaller := &mock.MarshalizerMock{}
+	transferFunc, _ := NewDCTTransferFunc(ArgsNewDCTTransfer{
+		Config: Config{
+			Marshalizer:           marshaller,
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			ShardCoordinator:      &mock.ShardCoordinatorStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+		},
+		FuncGasCost: 10,
+	})
+	_ = transferFunc.SetPayableChecker(&mock.PayableHandlerStub{
+		DetermineIsSCCallAfterCalled: func(vmInput *vmcommon.ContractCallInput, dstAddress []byte, mintArgs int) bool {
+			return true
+		},
+	})
+
+	scAddress := append(make([]byte, vmcommon.NumInitCharactersForScAddress), bytes.Repeat([]byte{1}, 22)...)
+	input := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:    []byte("snd"),
+			GasProvided:   50,
+			GasLocked:     5,
+			CallValue:     big.NewInt(0),
+			CurrentTxHash: []byte("hash"),
+		},
+		RecipientAddr: scAddress,
+	}
+	key := []byte("key")
+	input.Arguments = [][]byte{key, big.NewInt(10).Bytes(), []byte("deposit"), []byte("arg")}
+	accDst := mock.NewUserAccount(scAddress)
+
+	vmOutput, err := transferFunc.ProcessBuiltinFunction(nil, accDst, input)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0), vmOutput.GasRemaining)
+
+	expectedFollowUpCall := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:    []byte("snd"),
+			Arguments:     [][]byte{[]byte("arg")},
+			CallValue:     big.NewInt(0),
+			GasProvided:   40,
+			GasLocked:     5,
+			CurrentTxHash: []byte("hash"),
+			DCTTransfers: []*vmcommon.DCTTransfer{{
+				DCTValue:     big.NewInt(10),
+				DCTTokenName: key,
+				DCTTokenType: uint32(core.Fungible),
+			}},
+		},
+		RecipientAddr: scAddress,
+		Function:      "deposit",
+	}
+	assert.Equal(t, expectedFollowUpCall, vmOutput.FollowUpCall)
+
+	outputTransfer := vmOutput.OutputAccounts[string(scAddress)].OutputTransfers[0]
+	assert.Equal(t, expectedFollowUpCall.GasProvided, outputTransfer.GasLimit)
+	assert.Equal(t, []byte("deposit@617267"), outputTransfer.Data)
+}
+
 func TestDCTTransfer_ProcessBuiltInFunctionDestInShard(t *testing.T) {
 	t.Parallel()
 
 	marshaller := &mock.MarshalizerMock{}
-	transferFunc, _ := NewDCTTransferFunc(10, marshaller, &mock.GlobalSettingsHandlerStub{}, &mock.ShardCoordinatorStub{}, &mock.DCTRoleHandlerStub{}, &mock.EnableEpochsHandlerStub{
-		IsTransferToMetaFlagEnabledField:                     false,
-		IsCheckCorrectTokenIDForTransferRoleFlagEnabledField: true,
+	transferFunc, _ := NewDCTTransferFunc(ArgsNewDCTTransfer{
+		Config: Config{
+			Marshalizer:           marshaller,
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			ShardCoordinator:      &mock.ShardCoordinatorStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+				IsTransferToMetaFlagEnabledField:                     false,
+				IsCheckCorrectTokenIDForTransferRoleFlagEnabledField: true,
+			},
+		},
+		FuncGasCost: 10,
 	})
 	_ = transferFunc.SetPayableChecker(&mock.PayableHandlerStub{})
 
@@ -218,15 +628,130 @@ func TestDCTTransfer_ProcessBuiltInFunctionDestInShard(t *testing.T) {
 	assert.Equal(t, uint64(0), vmOutput.GasRemaining)
 }
 
-func TestDCTTransfer_SndDstFrozen(t *testing.T) {
+func TestDCTTransfer_ProcessBuiltInFunctionSelfTransfer(t *testing.T) {
 	t.Parallel()
 
 	marshaller := &mock.MarshalizerMock{}
-	accountStub := &mock.AccountsStub{}
-	dctGlobalSettingsFunc, _ := NewDCTGlobalSettingsFunc(accountStub, marshaller, true, core.BuiltInFunctionDCTPause, trueHandler)
-	transferFunc, _ := NewDCTTransferFunc(10, marshaller, dctGlobalSettingsFunc, &mock.ShardCoordinatorStub{}, &mock.DCTRoleHandlerStub{}, &mock.EnableEpochsHandlerStub{
+	enableEpochsHandler := &mock.EnableEpochsHandlerStub{
 		IsTransferToMetaFlagEnabledField:                     false,
 		IsCheckCorrectTokenIDForTransferRoleFlagEnabledField: true,
+		IsDCTSelfTransferFlagEnabledField:                    true,
+	}
+	transferFunc, _ := NewDCTTransferFunc(ArgsNewDCTTransfer{
+		Config: Config{
+			Marshalizer:           marshaller,
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			ShardCoordinator:      &mock.ShardCoordinatorStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			EnableEpochsHandler:   enableEpochsHandler,
+		},
+		FuncGasCost: 10,
+	})
+	_ = transferFunc.SetPayableChecker(&mock.PayableHandlerStub{})
+
+	key := []byte("key")
+	dctKey := append(transferFunc.keyPrefix, key...)
+	marshaledData, _ := marshaller.Marshal(&dct.DCToken{Value: big.NewInt(100)})
+	acnt := &mock.UserAccountStub{
+		AccountDataHandlerCalled: func() vmcommon.AccountDataHandler {
+			return &mock.DataTrieTrackerStub{
+				RetrieveValueCalled: func(key []byte) ([]byte, uint32, error) {
+					assert.Equal(t, dctKey, key)
+					return marshaledData, 0, nil
+				},
+				SaveKeyValueCalled: func(_ []byte, _ []byte) error {
+					assert.Fail(t, "self transfer should not save the balance")
+					return nil
+				},
+			}
+		},
+	}
+
+	input := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			GasProvided: 50,
+			CallValue:   big.NewInt(0),
+			CallerAddr:  []byte("snd"),
+		},
+		RecipientAddr: []byte("snd"),
+	}
+
+	input.Arguments = [][]byte{key, big.NewInt(101).Bytes()}
+	_, err := transferFunc.ProcessBuiltinFunction(acnt, acnt, input)
+	assert.Equal(t, ErrInsufficientFunds, err)
+
+	input.Arguments = [][]byte{key, big.NewInt(100).Bytes()}
+	vmOutput, err := transferFunc.ProcessBuiltinFunction(acnt, acnt, input)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(40), vmOutput.GasRemaining)
+	assert.Equal(t, 1, len(vmOutput.Logs))
+	assert.Equal(t, []byte(core.BuiltInFunctionDCTTransfer), vmOutput.Logs[0].Identifier)
+	assert.Equal(t, []byte(vmcommon.DCTSelfTransferLogData), vmOutput.Logs[0].Data)
+
+	accSnd := mock.NewUserAccount([]byte("snd"))
+	accDst := mock.NewUserAccount([]byte("dst"))
+	_ = accSnd.AccountDataHandler().SaveKeyValue(dctKey, marshaledData)
+	input.Arguments = [][]byte{key, big.NewInt(10).Bytes()}
+	vmOutput, err = transferFunc.ProcessBuiltinFunction(accSnd, accDst, input)
+	assert.Nil(t, err)
+	assert.Nil(t, vmOutput.Logs[0].Data)
+
+	// before the flag activation the self transfer debits and credits the same balance
+	enableEpochsHandler.IsDCTSelfTransferFlagEnabledField = false
+	numSaves := 0
+	selfAcnt := mock.NewUserAccount([]byte("snd"))
+	_ = selfAcnt.AccountDataHandler().SaveKeyValue(dctKey, marshaledData)
+	acnt.AccountDataHandlerCalled = func() vmcommon.AccountDataHandler {
+		return &mock.DataTrieTrackerStub{
+			RetrieveValueCalled: selfAcnt.AccountDataHandler().RetrieveValue,
+			SaveKeyValueCalled: func(key []byte, value []byte) error {
+				numSaves++
+				return selfAcnt.AccountDataHandler().SaveKeyValue(key, value)
+			},
+		}
+	}
+	input.Arguments = [][]byte{key, big.NewInt(101).Bytes()}
+	_, err = transferFunc.ProcessBuiltinFunction(acnt, acnt, input)
+	assert.Equal(t, ErrInsufficientFunds, err)
+
+	input.Arguments = [][]byte{key, big.NewInt(100).Bytes()}
+	vmOutput, err = transferFunc.ProcessBuiltinFunction(acnt, acnt, input)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, numSaves)
+	assert.Nil(t, vmOutput.Logs[0].Data)
+	balance, _, _ := selfAcnt.AccountDataHandler().RetrieveValue(dctKey)
+	dctData := &dct.DCToken{}
+	_ = marshaller.Unmarshal(dctData, balance)
+	assert.Equal(t, big.NewInt(100), dctData.Value)
+}
+
+func TestDCTTransfer_SndDstFrozen(t *testing.T) {
+	t.Parallel()
+
+	marshaller := &mock.MarshalizerMock{}
+	accountStub := &mock.AccountsStub{}
+	dctGlobalSettingsFunc, _ := NewDCTGlobalSettingsFunc(ArgsNewDCTGlobalSettings{
+		Config: Config{
+			Accounts:            accountStub,
+			Marshalizer:         marshaller,
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+		},
+		Set:           true,
+		Function:      core.BuiltInFunctionDCTPause,
+		ActiveHandler: trueHandler,
+	})
+	transferFunc, _ := NewDCTTransferFunc(ArgsNewDCTTransfer{
+		Config: Config{
+			Marshalizer:           marshaller,
+			GlobalSettingsHandler: dctGlobalSettingsFunc,
+			ShardCoordinator:      &mock.ShardCoordinatorStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+				IsTransferToMetaFlagEnabledField:                     false,
+				IsCheckCorrectTokenIDForTransferRoleFlagEnabledField: true,
+			},
+		},
+		FuncGasCost: 10,
 	})
 	_ = transferFunc.SetPayableChecker(&mock.PayableHandlerStub{})
 
@@ -310,10 +835,28 @@ func TestDCTTransfer_SndDstWithLimitedTransfer(t *testing.T) {
 			return nil
 		},
 	}
-	dctGlobalSettingsFunc, _ := NewDCTGlobalSettingsFunc(accountStub, marshaller, true, core.BuiltInFunctionDCTSetLimitedTransfer, trueHandler)
-	transferFunc, _ := NewDCTTransferFunc(10, marshaller, dctGlobalSettingsFunc, &mock.ShardCoordinatorStub{}, rolesHandler, &mock.EnableEpochsHandlerStub{
-		IsTransferToMetaFlagEnabledField:                     false,
-		IsCheckCorrectTokenIDForTransferRoleFlagEnabledField: true,
+	dctGlobalSettingsFunc, _ := NewDCTGlobalSettingsFunc(ArgsNewDCTGlobalSettings{
+		Config: Config{
+			Accounts:            accountStub,
+			Marshalizer:         marshaller,
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+		},
+		Set:           true,
+		Function:      core.BuiltInFunctionDCTSetLimitedTransfer,
+		ActiveHandler: trueHandler,
+	})
+	transferFunc, _ := NewDCTTransferFunc(ArgsNewDCTTransfer{
+		Config: Config{
+			Marshalizer:           marshaller,
+			GlobalSettingsHandler: dctGlobalSettingsFunc,
+			ShardCoordinator:      &mock.ShardCoordinatorStub{},
+			RolesHandler:          rolesHandler,
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+				IsTransferToMetaFlagEnabledField:                     false,
+				IsCheckCorrectTokenIDForTransferRoleFlagEnabledField: true,
+			},
+		},
+		FuncGasCost: 10,
 	})
 	_ = transferFunc.SetPayableChecker(&mock.PayableHandlerStub{})
 
@@ -389,9 +932,18 @@ func TestDCTTransfer_ProcessBuiltInFunctionOnAsyncCallBack(t *testing.T) {
 	t.Parallel()
 
 	marshaller := &mock.MarshalizerMock{}
-	transferFunc, _ := NewDCTTransferFunc(10, marshaller, &mock.GlobalSettingsHandlerStub{}, &mock.ShardCoordinatorStub{}, &mock.DCTRoleHandlerStub{}, &mock.EnableEpochsHandlerStub{
-		IsTransferToMetaFlagEnabledField:                     false,
-		IsCheckCorrectTokenIDForTransferRoleFlagEnabledField: true,
+	transferFunc, _ := NewDCTTransferFunc(ArgsNewDCTTransfer{
+		Config: Config{
+			Marshalizer:           marshaller,
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			ShardCoordinator:      &mock.ShardCoordinatorStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+				IsTransferToMetaFlagEnabledField:                     false,
+				IsCheckCorrectTokenIDForTransferRoleFlagEnabledField: true,
+			},
+		},
+		FuncGasCost: 10,
 	})
 	_ = transferFunc.SetPayableChecker(&mock.PayableHandlerStub{})
 
@@ -430,3 +982,153 @@ func TestDCTTransfer_ProcessBuiltInFunctionOnAsyncCallBack(t *testing.T) {
 	_ = marshaller.Unmarshal(dctToken, marshaledData)
 	assert.True(t, dctToken.Value.Cmp(big.NewInt(90)) == 0)
 }
+
+func BenchmarkDCTTransfer_ProcessBuiltinFunction(b *testing.B) {
+	marshaller := &mock.MarshalizerMock{}
+	transferFunc, _ := NewDCTTransferFunc(ArgsNewDCTTransfer{
+		Config: Config{
+			Marshalizer:           marshaller,
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			ShardCoordinator:      &mock.ShardCoordinatorStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+		},
+		FuncGasCost: 10,
+	})
+	_ = transferFunc.SetPayableChecker(&mock.PayableHandlerStub{})
+
+	key := []byte("key")
+	input := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			GasProvided: 50,
+			CallValue:   big.NewInt(0),
+			Arguments:   [][]byte{key, big.NewInt(1).Bytes()},
+		},
+	}
+	accSnd := mock.NewUserAccount([]byte("snd"))
+	accDst := mock.NewUserAccount([]byte("dst"))
+
+	dctKey := append(transferFunc.keyPrefix, key...)
+	marshaledData, _ := marshaller.Marshal(&dct.DCToken{Value: big.NewInt(int64(b.N) + 1)})
+	_ = accSnd.AccountDataHandler().SaveKeyValue(dctKey, marshaledData)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		vmOutput, err := transferFunc.ProcessBuiltinFunction(accSnd, accDst, input)
+		if err != nil {
+			b.Fatal(err)
+		}
+		vmcommon.ReleaseVMOutput(vmOutput)
+	}
+}
+
+func TestDCTTransfer_ProcessBuiltInFunctionWithValue(t *testing.T) {
+	t.Parallel()
+
+	key := []byte("key")
+	marshaller := &mock.MarshalizerMock{}
+	createTransferFunc := func(flagEnabled bool, isSCCallAfter bool) *dctTransfer {
+		transferFunc, _ := NewDCTTransferFunc(ArgsNewDCTTransfer{
+			Config: Config{
+				Marshalizer:           marshaller,
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				ShardCoordinator:      &mock.ShardCoordinatorStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+				EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+					IsDCTTransferWithValueFlagEnabledField: flagEnabled,
+				},
+			},
+			FuncGasCost: 10,
+		})
+		_ = transferFunc.SetPayableChecker(&mock.PayableHandlerStub{
+			DetermineIsSCCallAfterCalled: func(vmInput *vmcommon.ContractCallInput, dstAddress []byte, mintArgs int) bool {
+				return isSCCallAfter
+			},
+		})
+		return transferFunc
+	}
+	createInput := func(callValue *big.Int) *vmcommon.ContractCallInput {
+		return &vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallerAddr:  []byte("snd"),
+				GasProvided: 50,
+				CallValue:   callValue,
+				Arguments:   [][]byte{key, big.NewInt(10).Bytes()},
+			},
+			RecipientAddr: []byte("dst"),
+		}
+	}
+	createSender := func() vmcommon.UserAccountHandler {
+		accSnd := mock.NewUserAccount([]byte("snd"))
+		marshaledData, _ := marshaller.Marshal(&dct.DCToken{Value: big.NewInt(100)})
+		_ = accSnd.AccountDataHandler().SaveKeyValue(append([]byte(baseDCTKeyPrefix), key...), marshaledData)
+		return accSnd
+	}
+
+	t.Run("flag disabled should err", func(t *testing.T) {
+		t.Parallel()
+
+		transferFunc := createTransferFunc(false, false)
+		_, err := transferFunc.ProcessBuiltinFunction(createSender(), mock.NewUserAccount([]byte("dst")), createInput(big.NewInt(5)))
+		assert.Equal(t, ErrBuiltInFunctionCalledWithValue, err)
+	})
+	t.Run("negative value should err", func(t *testing.T) {
+		t.Parallel()
+
+		transferFunc := createTransferFunc(true, false)
+		_, err := transferFunc.ProcessBuiltinFunction(createSender(), mock.NewUserAccount([]byte("dst")), createInput(big.NewInt(-5)))
+		assert.Equal(t, ErrNegativeValue, err)
+	})
+	t.Run("zero value should not add the native value entry", func(t *testing.T) {
+		t.Parallel()
+
+		transferFunc := createTransferFunc(true, false)
+		vmOutput, err := transferFunc.ProcessBuiltinFunction(createSender(), mock.NewUserAccount([]byte("dst")), createInput(big.NewInt(0)))
+		assert.Nil(t, err)
+		assert.Len(t, vmOutput.Logs, 1)
+	})
+	t.Run("same shard should add the combined log entries", func(t *testing.T) {
+		t.Parallel()
+
+		transferFunc := createTransferFunc(true, false)
+		vmOutput, err := transferFunc.ProcessBuiltinFunction(createSender(), mock.NewUserAccount([]byte("dst")), createInput(big.NewInt(5)))
+		assert.Nil(t, err)
+		assert.Len(t, vmOutput.Logs, 2)
+		assert.Equal(t, []byte(core.BuiltInFunctionDCTTransfer), vmOutput.Logs[0].Identifier)
+		assert.Equal(t, &vmcommon.LogEntry{
+			Identifier: []byte(vmcommon.DCTTransferNativeValueIdentifier),
+			Address:    []byte("snd"),
+			Topics:     [][]byte{nil, big.NewInt(0).Bytes(), big.NewInt(5).Bytes(), []byte("dst")},
+		}, vmOutput.Logs[1])
+	})
+	t.Run("SC call after should not move the value again through the follow up call", func(t *testing.T) {
+		t.Parallel()
+
+		transferFunc := createTransferFunc(true, true)
+		input := createInput(big.NewInt(5))
+		input.Arguments = append(input.Arguments, []byte("deposit"))
+		vmOutput, err := transferFunc.ProcessBuiltinFunction(nil, mock.NewUserAccount([]byte("dst")), input)
+		assert.Nil(t, err)
+		assert.Equal(t, big.NewInt(0), vmOutput.FollowUpCall.CallValue)
+		assert.False(t, vmOutput.FollowUpCall.IsValueWithDCTTransfer())
+		assert.Equal(t, big.NewInt(0), vmOutput.OutputAccounts["dst"].OutputTransfers[0].Value)
+		assert.Len(t, vmOutput.Logs, 2)
+		assert.Equal(t, []byte(vmcommon.DCTTransferNativeValueIdentifier), vmOutput.Logs[1].Identifier)
+		assert.Equal(t, big.NewInt(5).Bytes(), vmOutput.Logs[1].Topics[2])
+	})
+	t.Run("cross shard from smart contract should send the value", func(t *testing.T) {
+		t.Parallel()
+
+		transferFunc := createTransferFunc(true, false)
+		_ = transferFunc.SetAddressClassifier(&mock.AddressClassifierStub{
+			IsSmartContractCalled: func(address []byte) bool {
+				return true
+			},
+		})
+		vmOutput, err := transferFunc.ProcessBuiltinFunction(createSender(), nil, createInput(big.NewInt(5)))
+		assert.Nil(t, err)
+		assert.Equal(t, big.NewInt(5), vmOutput.OutputAccounts["dst"].OutputTransfers[0].Value)
+		assert.Len(t, vmOutput.Logs, 2)
+	})
+}
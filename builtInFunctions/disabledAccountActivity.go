@@ -0,0 +1,15 @@
+package builtInFunctions
+
+// disabledAccountActivityHandler is used until a real account activity handler is set, it reports every account as active
+type disabledAccountActivityHandler struct {
+}
+
+// GetInactiveEpochs returns 0 as this is a disabled handler
+func (d *disabledAccountActivityHandler) GetInactiveEpochs(_ []byte, _ uint32) (uint32, error) {
+	return 0, nil
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (d *disabledAccountActivityHandler) IsInterfaceNil() bool {
+	return d == nil
+}
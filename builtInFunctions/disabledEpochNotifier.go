@@ -0,0 +1,16 @@
+package builtInFunctions
+
+import vmcommon "github.com/Reshusk23/sr-vm-common-go"
+
+// disabledEpochNotifier is used when no epoch notifier is provided, the registered handlers remaining in epoch 0
+type disabledEpochNotifier struct {
+}
+
+// RegisterNotifyHandler does nothing as this is a disabled notifier
+func (d *disabledEpochNotifier) RegisterNotifyHandler(_ vmcommon.EpochSubscriberHandler) {
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (d *disabledEpochNotifier) IsInterfaceNil() bool {
+	return d == nil
+}
@@ -0,0 +1,18 @@
+package builtInFunctions
+
+import vmcommon "github.com/Reshusk23/sr-vm-common-go"
+
+// disabledStorageUsageTracker is used until a real storage usage tracker is set, it neither meters nor limits the
+// stored bytes
+type disabledStorageUsageTracker struct {
+}
+
+// TrackStorageUsage returns nil as this is a disabled tracker
+func (d *disabledStorageUsageTracker) TrackStorageUsage(_ vmcommon.UserAccountHandler, _ int64) error {
+	return nil
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (d *disabledStorageUsageTracker) IsInterfaceNil() bool {
+	return d == nil
+}
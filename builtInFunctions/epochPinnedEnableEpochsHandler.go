@@ -0,0 +1,396 @@
+package builtInFunctions
+
+import (
+	"sync"
+
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+)
+
+var _ vmcommon.EnableEpochsHandler = (*epochPinnedEnableEpochsHandler)(nil)
+
+// epochPinnedEnableEpochsHandler resolves all the flags against the current enable epochs handler, unless an
+// enable epochs handler created for a past epoch is pinned, case in which the flags resolve against the pinned one
+type epochPinnedEnableEpochsHandler struct {
+	mut     sync.RWMutex
+	current vmcommon.EnableEpochsHandler
+	pinned  vmcommon.EnableEpochsHandler
+}
+
+func newEpochPinnedEnableEpochsHandler(current vmcommon.EnableEpochsHandler) *epochPinnedEnableEpochsHandler {
+	return &epochPinnedEnableEpochsHandler{
+		current: current,
+	}
+}
+
+func (e *epochPinnedEnableEpochsHandler) pin(handler vmcommon.EnableEpochsHandler) {
+	e.mut.Lock()
+	e.pinned = handler
+	e.mut.Unlock()
+}
+
+func (e *epochPinnedEnableEpochsHandler) unpin() {
+	e.mut.Lock()
+	e.pinned = nil
+	e.mut.Unlock()
+}
+
+func (e *epochPinnedEnableEpochsHandler) handler() vmcommon.EnableEpochsHandler {
+	e.mut.RLock()
+	defer e.mut.RUnlock()
+
+	if e.pinned != nil {
+		return e.pinned
+	}
+
+	return e.current
+}
+
+// IsGlobalMintBurnFlagEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsGlobalMintBurnFlagEnabled() bool {
+	return e.handler().IsGlobalMintBurnFlagEnabled()
+}
+
+// IsDCTTransferRoleFlagEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsDCTTransferRoleFlagEnabled() bool {
+	return e.handler().IsDCTTransferRoleFlagEnabled()
+}
+
+// IsBuiltInFunctionsFlagEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsBuiltInFunctionsFlagEnabled() bool {
+	return e.handler().IsBuiltInFunctionsFlagEnabled()
+}
+
+// IsCheckCorrectTokenIDForTransferRoleFlagEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsCheckCorrectTokenIDForTransferRoleFlagEnabled() bool {
+	return e.handler().IsCheckCorrectTokenIDForTransferRoleFlagEnabled()
+}
+
+// IsMultiDCTTransferFixOnCallBackFlagEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsMultiDCTTransferFixOnCallBackFlagEnabled() bool {
+	return e.handler().IsMultiDCTTransferFixOnCallBackFlagEnabled()
+}
+
+// IsFixOOGReturnCodeFlagEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsFixOOGReturnCodeFlagEnabled() bool {
+	return e.handler().IsFixOOGReturnCodeFlagEnabled()
+}
+
+// IsRemoveNonUpdatedStorageFlagEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsRemoveNonUpdatedStorageFlagEnabled() bool {
+	return e.handler().IsRemoveNonUpdatedStorageFlagEnabled()
+}
+
+// IsCreateNFTThroughExecByCallerFlagEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsCreateNFTThroughExecByCallerFlagEnabled() bool {
+	return e.handler().IsCreateNFTThroughExecByCallerFlagEnabled()
+}
+
+// IsStorageAPICostOptimizationFlagEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsStorageAPICostOptimizationFlagEnabled() bool {
+	return e.handler().IsStorageAPICostOptimizationFlagEnabled()
+}
+
+// IsFailExecutionOnEveryAPIErrorFlagEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsFailExecutionOnEveryAPIErrorFlagEnabled() bool {
+	return e.handler().IsFailExecutionOnEveryAPIErrorFlagEnabled()
+}
+
+// IsManagedCryptoAPIsFlagEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsManagedCryptoAPIsFlagEnabled() bool {
+	return e.handler().IsManagedCryptoAPIsFlagEnabled()
+}
+
+// IsSCDeployFlagEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsSCDeployFlagEnabled() bool {
+	return e.handler().IsSCDeployFlagEnabled()
+}
+
+// IsAheadOfTimeGasUsageFlagEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsAheadOfTimeGasUsageFlagEnabled() bool {
+	return e.handler().IsAheadOfTimeGasUsageFlagEnabled()
+}
+
+// IsRepairCallbackFlagEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsRepairCallbackFlagEnabled() bool {
+	return e.handler().IsRepairCallbackFlagEnabled()
+}
+
+// IsDisableExecByCallerFlagEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsDisableExecByCallerFlagEnabled() bool {
+	return e.handler().IsDisableExecByCallerFlagEnabled()
+}
+
+// IsRefactorContextFlagEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsRefactorContextFlagEnabled() bool {
+	return e.handler().IsRefactorContextFlagEnabled()
+}
+
+// IsCheckFunctionArgumentFlagEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsCheckFunctionArgumentFlagEnabled() bool {
+	return e.handler().IsCheckFunctionArgumentFlagEnabled()
+}
+
+// IsCheckExecuteOnReadOnlyFlagEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsCheckExecuteOnReadOnlyFlagEnabled() bool {
+	return e.handler().IsCheckExecuteOnReadOnlyFlagEnabled()
+}
+
+// IsFixAsyncCallbackCheckFlagEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsFixAsyncCallbackCheckFlagEnabled() bool {
+	return e.handler().IsFixAsyncCallbackCheckFlagEnabled()
+}
+
+// IsSaveToSystemAccountFlagEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsSaveToSystemAccountFlagEnabled() bool {
+	return e.handler().IsSaveToSystemAccountFlagEnabled()
+}
+
+// IsCheckFrozenCollectionFlagEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsCheckFrozenCollectionFlagEnabled() bool {
+	return e.handler().IsCheckFrozenCollectionFlagEnabled()
+}
+
+// IsSendAlwaysFlagEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsSendAlwaysFlagEnabled() bool {
+	return e.handler().IsSendAlwaysFlagEnabled()
+}
+
+// IsValueLengthCheckFlagEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsValueLengthCheckFlagEnabled() bool {
+	return e.handler().IsValueLengthCheckFlagEnabled()
+}
+
+// IsCheckTransferFlagEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsCheckTransferFlagEnabled() bool {
+	return e.handler().IsCheckTransferFlagEnabled()
+}
+
+// IsTransferToMetaFlagEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsTransferToMetaFlagEnabled() bool {
+	return e.handler().IsTransferToMetaFlagEnabled()
+}
+
+// IsDCTNFTImprovementV1FlagEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsDCTNFTImprovementV1FlagEnabled() bool {
+	return e.handler().IsDCTNFTImprovementV1FlagEnabled()
+}
+
+// IsFixOldTokenLiquidityEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsFixOldTokenLiquidityEnabled() bool {
+	return e.handler().IsFixOldTokenLiquidityEnabled()
+}
+
+// IsRuntimeMemStoreLimitEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsRuntimeMemStoreLimitEnabled() bool {
+	return e.handler().IsRuntimeMemStoreLimitEnabled()
+}
+
+// IsMaxBlockchainHookCountersFlagEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsMaxBlockchainHookCountersFlagEnabled() bool {
+	return e.handler().IsMaxBlockchainHookCountersFlagEnabled()
+}
+
+// IsWipeSingleNFTLiquidityDecreaseEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsWipeSingleNFTLiquidityDecreaseEnabled() bool {
+	return e.handler().IsWipeSingleNFTLiquidityDecreaseEnabled()
+}
+
+// IsAlwaysSaveTokenMetaDataEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsAlwaysSaveTokenMetaDataEnabled() bool {
+	return e.handler().IsAlwaysSaveTokenMetaDataEnabled()
+}
+
+// IsFreezeAccountFlagEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsFreezeAccountFlagEnabled() bool {
+	return e.handler().IsFreezeAccountFlagEnabled()
+}
+
+// IsGlobalSettingsVersioningFlagEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsGlobalSettingsVersioningFlagEnabled() bool {
+	return e.handler().IsGlobalSettingsVersioningFlagEnabled()
+}
+
+// IsCollectionConfigFlagEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsCollectionConfigFlagEnabled() bool {
+	return e.handler().IsCollectionConfigFlagEnabled()
+}
+
+// IsDormantSweepFlagEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsDormantSweepFlagEnabled() bool {
+	return e.handler().IsDormantSweepFlagEnabled()
+}
+
+// IsMultiSigManagementFlagEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsMultiSigManagementFlagEnabled() bool {
+	return e.handler().IsMultiSigManagementFlagEnabled()
+}
+
+// IsSoulboundFlagEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsSoulboundFlagEnabled() bool {
+	return e.handler().IsSoulboundFlagEnabled()
+}
+
+// IsNFTRentalFlagEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsNFTRentalFlagEnabled() bool {
+	return e.handler().IsNFTRentalFlagEnabled()
+}
+
+// IsDCTTransferWithValueFlagEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsDCTTransferWithValueFlagEnabled() bool {
+	return e.handler().IsDCTTransferWithValueFlagEnabled()
+}
+
+// IsDCTokenV2EncodingFlagEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsDCTokenV2EncodingFlagEnabled() bool {
+	return e.handler().IsDCTokenV2EncodingFlagEnabled()
+}
+
+// IsNFTCreateOnBehalfFlagEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsNFTCreateOnBehalfFlagEnabled() bool {
+	return e.handler().IsNFTCreateOnBehalfFlagEnabled()
+}
+
+// IsStopNFTCreateFlagEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsStopNFTCreateFlagEnabled() bool {
+	return e.handler().IsStopNFTCreateFlagEnabled()
+}
+
+// IsMultiTransferGasRepriceFlagEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsMultiTransferGasRepriceFlagEnabled() bool {
+	return e.handler().IsMultiTransferGasRepriceFlagEnabled()
+}
+
+// IsNFTMaxSupplyFlagEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsNFTMaxSupplyFlagEnabled() bool {
+	return e.handler().IsNFTMaxSupplyFlagEnabled()
+}
+
+// IsNFTNonceRangesFlagEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsNFTNonceRangesFlagEnabled() bool {
+	return e.handler().IsNFTNonceRangesFlagEnabled()
+}
+
+// IsDCTModifyCreatorFlagEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsDCTModifyCreatorFlagEnabled() bool {
+	return e.handler().IsDCTModifyCreatorFlagEnabled()
+}
+
+// IsDCTSetNewURIsFlagEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsDCTSetNewURIsFlagEnabled() bool {
+	return e.handler().IsDCTSetNewURIsFlagEnabled()
+}
+
+// IsDCTNFTUpdateFlagEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsDCTNFTUpdateFlagEnabled() bool {
+	return e.handler().IsDCTNFTUpdateFlagEnabled()
+}
+
+// IsDCTTransferAndLockFlagEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsDCTTransferAndLockFlagEnabled() bool {
+	return e.handler().IsDCTTransferAndLockFlagEnabled()
+}
+
+// IsMetaDCTFlagEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsMetaDCTFlagEnabled() bool {
+	return e.handler().IsMetaDCTFlagEnabled()
+}
+
+// IsWrapNativeFlagEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsWrapNativeFlagEnabled() bool {
+	return e.handler().IsWrapNativeFlagEnabled()
+}
+
+// IsDCTBridgeFlagEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsDCTBridgeFlagEnabled() bool {
+	return e.handler().IsDCTBridgeFlagEnabled()
+}
+
+// IsDCTAllowanceFlagEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsDCTAllowanceFlagEnabled() bool {
+	return e.handler().IsDCTAllowanceFlagEnabled()
+}
+
+// IsNFTCreateNotifyFlagEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsNFTCreateNotifyFlagEnabled() bool {
+	return e.handler().IsNFTCreateNotifyFlagEnabled()
+}
+
+// IsNFTContentHashFlagEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsNFTContentHashFlagEnabled() bool {
+	return e.handler().IsNFTContentHashFlagEnabled()
+}
+
+// IsDCTSelfTransferFlagEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsDCTSelfTransferFlagEnabled() bool {
+	return e.handler().IsDCTSelfTransferFlagEnabled()
+}
+
+// IsDCTTransferCallbackGasLockFlagEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsDCTTransferCallbackGasLockFlagEnabled() bool {
+	return e.handler().IsDCTTransferCallbackGasLockFlagEnabled()
+}
+
+// IsSafeArgumentConversionFlagEnabled resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) IsSafeArgumentConversionFlagEnabled() bool {
+	return e.handler().IsSafeArgumentConversionFlagEnabled()
+}
+
+// MultiDCTTransferAsyncCallBackEnableEpoch resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) MultiDCTTransferAsyncCallBackEnableEpoch() uint32 {
+	return e.handler().MultiDCTTransferAsyncCallBackEnableEpoch()
+}
+
+// FixOOGReturnCodeEnableEpoch resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) FixOOGReturnCodeEnableEpoch() uint32 {
+	return e.handler().FixOOGReturnCodeEnableEpoch()
+}
+
+// RemoveNonUpdatedStorageEnableEpoch resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) RemoveNonUpdatedStorageEnableEpoch() uint32 {
+	return e.handler().RemoveNonUpdatedStorageEnableEpoch()
+}
+
+// CreateNFTThroughExecByCallerEnableEpoch resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) CreateNFTThroughExecByCallerEnableEpoch() uint32 {
+	return e.handler().CreateNFTThroughExecByCallerEnableEpoch()
+}
+
+// FixFailExecutionOnErrorEnableEpoch resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) FixFailExecutionOnErrorEnableEpoch() uint32 {
+	return e.handler().FixFailExecutionOnErrorEnableEpoch()
+}
+
+// ManagedCryptoAPIEnableEpoch resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) ManagedCryptoAPIEnableEpoch() uint32 {
+	return e.handler().ManagedCryptoAPIEnableEpoch()
+}
+
+// DisableExecByCallerEnableEpoch resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) DisableExecByCallerEnableEpoch() uint32 {
+	return e.handler().DisableExecByCallerEnableEpoch()
+}
+
+// RefactorContextEnableEpoch resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) RefactorContextEnableEpoch() uint32 {
+	return e.handler().RefactorContextEnableEpoch()
+}
+
+// CheckExecuteReadOnlyEnableEpoch resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) CheckExecuteReadOnlyEnableEpoch() uint32 {
+	return e.handler().CheckExecuteReadOnlyEnableEpoch()
+}
+
+// StorageAPICostOptimizationEnableEpoch resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) StorageAPICostOptimizationEnableEpoch() uint32 {
+	return e.handler().StorageAPICostOptimizationEnableEpoch()
+}
+
+// GetCurrentEpoch resolves against the pinned handler, if any
+func (e *epochPinnedEnableEpochsHandler) GetCurrentEpoch() uint32 {
+	return e.handler().GetCurrentEpoch()
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (e *epochPinnedEnableEpochsHandler) IsInterfaceNil() bool {
+	return e == nil
+}
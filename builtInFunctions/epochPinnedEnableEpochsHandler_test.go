@@ -0,0 +1,37 @@
+package builtInFunctions
+
+import (
+	"testing"
+
+	"github.com/Reshusk23/sr-me-core/core/check"
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEpochPinnedEnableEpochsHandler(t *testing.T) {
+	t.Parallel()
+
+	current := &mock.EnableEpochsHandlerStub{
+		IsSoulboundFlagEnabledField: true,
+		CurrentEpochField:           20,
+	}
+	pinned := &mock.EnableEpochsHandlerStub{
+		CurrentEpochField: 5,
+	}
+	handler := newEpochPinnedEnableEpochsHandler(current)
+	assert.False(t, check.IfNil(handler))
+
+	t.Run("not pinned should resolve against the current handler", func(t *testing.T) {
+		assert.True(t, handler.IsSoulboundFlagEnabled())
+		assert.Equal(t, uint32(20), handler.GetCurrentEpoch())
+	})
+	t.Run("pinned should resolve against the pinned handler", func(t *testing.T) {
+		handler.pin(pinned)
+		assert.False(t, handler.IsSoulboundFlagEnabled())
+		assert.Equal(t, uint32(5), handler.GetCurrentEpoch())
+
+		handler.unpin()
+		assert.True(t, handler.IsSoulboundFlagEnabled())
+		assert.Equal(t, uint32(20), handler.GetCurrentEpoch())
+	})
+}
@@ -1,168 +1,417 @@
 package builtInFunctions
 
 import (
-	"errors"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
 )
 
 // ErrNilAccountsAdapter defines the error when trying to use a nil AccountsAddapter
-var ErrNilAccountsAdapter = errors.New("nil AccountsAdapter")
+var ErrNilAccountsAdapter = vmcommon.NewCodedError(5001, vmcommon.ErrorCategoryConfiguration, "nil AccountsAdapter")
 
 // ErrInsufficientFunds signals the funds are insufficient for the move balance operation but the
 // transaction fee is covered by the current balance
-var ErrInsufficientFunds = errors.New("insufficient funds")
+var ErrInsufficientFunds = vmcommon.NewCodedError(4001, vmcommon.ErrorCategoryState, "insufficient funds")
 
 // ErrNilValue signals the value is nil
-var ErrNilValue = errors.New("nil value")
+var ErrNilValue = vmcommon.NewCodedError(1001, vmcommon.ErrorCategoryValidation, "nil value")
 
 // ErrNilMarshalizer signals that an operation has been attempted to or with a nil Marshalizer implementation
-var ErrNilMarshalizer = errors.New("nil Marshalizer")
+var ErrNilMarshalizer = vmcommon.NewCodedError(5002, vmcommon.ErrorCategoryConfiguration, "nil Marshalizer")
 
 // ErrInvalidRcvAddr signals that an invalid receiver address was provided
-var ErrInvalidRcvAddr = errors.New("invalid receiver address")
+var ErrInvalidRcvAddr = vmcommon.NewCodedError(1002, vmcommon.ErrorCategoryValidation, "invalid receiver address")
 
 // ErrNegativeValue signals that a negative value has been detected and it is not allowed
-var ErrNegativeValue = errors.New("negative value")
+var ErrNegativeValue = vmcommon.NewCodedError(1003, vmcommon.ErrorCategoryValidation, "negative value")
 
 // ErrNilShardCoordinator signals that an operation has been attempted to or with a nil shard coordinator
-var ErrNilShardCoordinator = errors.New("nil shard coordinator")
+var ErrNilShardCoordinator = vmcommon.NewCodedError(5003, vmcommon.ErrorCategoryConfiguration, "nil shard coordinator")
 
 // ErrWrongTypeAssertion signals that an type assertion failed
-var ErrWrongTypeAssertion = errors.New("wrong type assertion")
+var ErrWrongTypeAssertion = vmcommon.NewCodedError(5004, vmcommon.ErrorCategoryConfiguration, "wrong type assertion")
 
 // ErrNilSCDestAccount signals that destination account is nil
-var ErrNilSCDestAccount = errors.New("nil destination SC account")
+var ErrNilSCDestAccount = vmcommon.NewCodedError(1004, vmcommon.ErrorCategoryValidation, "nil destination SC account")
 
 // ErrNotEnoughGas signals that not enough gas has been provided
-var ErrNotEnoughGas = errors.New("not enough gas was sent in the transaction")
+var ErrNotEnoughGas = vmcommon.NewCodedError(2001, vmcommon.ErrorCategoryGas, "not enough gas was sent in the transaction")
 
 // ErrInvalidArguments signals that invalid arguments were given to process built-in function
-var ErrInvalidArguments = errors.New("invalid arguments to process built-in function")
+var ErrInvalidArguments = vmcommon.NewCodedError(1005, vmcommon.ErrorCategoryValidation, "invalid arguments to process built-in function")
 
 // ErrOperationNotPermitted signals that operation is not permitted
-var ErrOperationNotPermitted = errors.New("operation in account not permitted")
+var ErrOperationNotPermitted = vmcommon.NewCodedError(3001, vmcommon.ErrorCategoryRole, "operation in account not permitted")
 
 // ErrInvalidAddressLength signals that address length is invalid
-var ErrInvalidAddressLength = errors.New("invalid address length")
+var ErrInvalidAddressLength = vmcommon.NewCodedError(1006, vmcommon.ErrorCategoryValidation, "invalid address length")
 
 // ErrNilVmInput signals that provided vm input is nil
-var ErrNilVmInput = errors.New("nil vm input")
+var ErrNilVmInput = vmcommon.NewCodedError(1007, vmcommon.ErrorCategoryValidation, "nil vm input")
 
 // ErrNilDnsAddresses signals that nil dns addresses map was provided
-var ErrNilDnsAddresses = errors.New("nil dns addresses map")
+var ErrNilDnsAddresses = vmcommon.NewCodedError(5005, vmcommon.ErrorCategoryConfiguration, "nil dns addresses map")
 
 // ErrCallerIsNotTheDNSAddress signals that called address is not the DNS address
-var ErrCallerIsNotTheDNSAddress = errors.New("not a dns address")
+var ErrCallerIsNotTheDNSAddress = vmcommon.NewCodedError(3002, vmcommon.ErrorCategoryRole, "not a dns address")
 
 // ErrUserNameChangeIsDisabled signals the user name change is not allowed
-var ErrUserNameChangeIsDisabled = errors.New("user name change is disabled")
+var ErrUserNameChangeIsDisabled = vmcommon.NewCodedError(3003, vmcommon.ErrorCategoryRole, "user name change is disabled")
 
 // ErrBuiltInFunctionCalledWithValue signals that builtin function was called with value that is not allowed
-var ErrBuiltInFunctionCalledWithValue = errors.New("built in function called with tx value is not allowed")
+var ErrBuiltInFunctionCalledWithValue = vmcommon.NewCodedError(1008, vmcommon.ErrorCategoryValidation, "built in function called with tx value is not allowed")
 
 // ErrAccountNotPayable will be sent when trying to send tokens to a non-payableCheck account
-var ErrAccountNotPayable = errors.New("sending value to non payable contract")
+var ErrAccountNotPayable = vmcommon.NewCodedError(4002, vmcommon.ErrorCategoryState, "sending value to non payable contract")
 
 // ErrNilUserAccount signals that nil user account was provided
-var ErrNilUserAccount = errors.New("nil user account")
+var ErrNilUserAccount = vmcommon.NewCodedError(1009, vmcommon.ErrorCategoryValidation, "nil user account")
 
 // ErrAddressIsNotDCTSystemSC signals that destination is not a system sc address
-var ErrAddressIsNotDCTSystemSC = errors.New("destination is not system sc address")
+var ErrAddressIsNotDCTSystemSC = vmcommon.NewCodedError(3004, vmcommon.ErrorCategoryRole, "destination is not system sc address")
 
 // ErrOnlySystemAccountAccepted signals that only system account is accepted
-var ErrOnlySystemAccountAccepted = errors.New("only system account is accepted")
+var ErrOnlySystemAccountAccepted = vmcommon.NewCodedError(3005, vmcommon.ErrorCategoryRole, "only system account is accepted")
 
 // ErrNilGlobalSettingsHandler signals that nil pause handler has been provided
-var ErrNilGlobalSettingsHandler = errors.New("nil pause handler")
+var ErrNilGlobalSettingsHandler = vmcommon.NewCodedError(5006, vmcommon.ErrorCategoryConfiguration, "nil pause handler")
 
 // ErrNilRolesHandler signals that nil roles handler has been provided
-var ErrNilRolesHandler = errors.New("nil roles handler")
+var ErrNilRolesHandler = vmcommon.NewCodedError(5007, vmcommon.ErrorCategoryConfiguration, "nil roles handler")
 
 // ErrDCTTokenIsPaused signals that dct token is paused
-var ErrDCTTokenIsPaused = errors.New("dct token is paused")
+var ErrDCTTokenIsPaused = vmcommon.NewCodedError(4003, vmcommon.ErrorCategoryState, "dct token is paused")
 
 // ErrDCTIsFrozenForAccount signals that account is frozen for given dct token
-var ErrDCTIsFrozenForAccount = errors.New("account is frozen for this dct token")
+var ErrDCTIsFrozenForAccount = vmcommon.NewCodedError(4004, vmcommon.ErrorCategoryState, "account is frozen for this dct token")
 
 // ErrCannotWipeAccountNotFrozen signals that account isn't frozen so the wipe is not possible
-var ErrCannotWipeAccountNotFrozen = errors.New("cannot wipe because the account is not frozen for this dct token")
+var ErrCannotWipeAccountNotFrozen = vmcommon.NewCodedError(4005, vmcommon.ErrorCategoryState, "cannot wipe because the account is not frozen for this dct token")
 
 // ErrNilPayableHandler signals that nil payableHandler was provided
-var ErrNilPayableHandler = errors.New("nil payableHandler was provided")
+var ErrNilPayableHandler = vmcommon.NewCodedError(5008, vmcommon.ErrorCategoryConfiguration, "nil payableHandler was provided")
 
 // ErrActionNotAllowed signals that action is not allowed
-var ErrActionNotAllowed = errors.New("action is not allowed")
+var ErrActionNotAllowed = vmcommon.NewCodedError(3006, vmcommon.ErrorCategoryRole, "action is not allowed")
 
 // ErrOnlyFungibleTokensHaveBalanceTransfer signals that only fungible tokens have balance transfer
-var ErrOnlyFungibleTokensHaveBalanceTransfer = errors.New("only fungible tokens have balance transfer")
+var ErrOnlyFungibleTokensHaveBalanceTransfer = vmcommon.NewCodedError(1010, vmcommon.ErrorCategoryValidation, "only fungible tokens have balance transfer")
 
 // ErrNFTTokenDoesNotExist signals that NFT token does not exist
-var ErrNFTTokenDoesNotExist = errors.New("NFT token does not exist")
+var ErrNFTTokenDoesNotExist = vmcommon.NewCodedError(4006, vmcommon.ErrorCategoryState, "NFT token does not exist")
 
 // ErrNFTDoesNotHaveMetadata signals that NFT does not have metadata
-var ErrNFTDoesNotHaveMetadata = errors.New("NFT does not have metadata")
+var ErrNFTDoesNotHaveMetadata = vmcommon.NewCodedError(4007, vmcommon.ErrorCategoryState, "NFT does not have metadata")
 
 // ErrInvalidNFTQuantity signals that invalid NFT quantity was provided
-var ErrInvalidNFTQuantity = errors.New("invalid NFT quantity")
+var ErrInvalidNFTQuantity = vmcommon.NewCodedError(1011, vmcommon.ErrorCategoryValidation, "invalid NFT quantity")
 
 // ErrNewNFTDataOnSenderAddress signals that a new NFT data was found on the sender address
-var ErrNewNFTDataOnSenderAddress = errors.New("new NFT data on sender")
+var ErrNewNFTDataOnSenderAddress = vmcommon.NewCodedError(4008, vmcommon.ErrorCategoryState, "new NFT data on sender")
 
 // ErrNilContainerElement signals when trying to add a nil element in the container
-var ErrNilContainerElement = errors.New("element cannot be nil")
+var ErrNilContainerElement = vmcommon.NewCodedError(5009, vmcommon.ErrorCategoryConfiguration, "element cannot be nil")
 
 // ErrInvalidContainerKey signals that an element does not exist in the container's map
-var ErrInvalidContainerKey = errors.New("element does not exist in container")
+var ErrInvalidContainerKey = vmcommon.NewCodedError(5010, vmcommon.ErrorCategoryConfiguration, "element does not exist in container")
 
 // ErrContainerKeyAlreadyExists signals that an element was already set in the container's map
-var ErrContainerKeyAlreadyExists = errors.New("provided key already exists in container")
+var ErrContainerKeyAlreadyExists = vmcommon.NewCodedError(5011, vmcommon.ErrorCategoryConfiguration, "provided key already exists in container")
 
 // ErrWrongTypeInContainer signals that a wrong type of object was found in container
-var ErrWrongTypeInContainer = errors.New("wrong type of object inside container")
+var ErrWrongTypeInContainer = vmcommon.NewCodedError(5012, vmcommon.ErrorCategoryConfiguration, "wrong type of object inside container")
 
 // ErrEmptyFunctionName signals that an empty function name has been provided
-var ErrEmptyFunctionName = errors.New("empty function name")
+var ErrEmptyFunctionName = vmcommon.NewCodedError(5013, vmcommon.ErrorCategoryConfiguration, "empty function name")
 
 // ErrInsufficientQuantityDCT signals the funds are insufficient for the DCT transfer
-var ErrInsufficientQuantityDCT = errors.New("insufficient quantity")
+var ErrInsufficientQuantityDCT = vmcommon.NewCodedError(4009, vmcommon.ErrorCategoryState, "insufficient quantity")
 
 // ErrNilDCTNFTStorageHandler signals that a nil nft storage handler has been provided
-var ErrNilDCTNFTStorageHandler = errors.New("nil dct nft storage handler")
+var ErrNilDCTNFTStorageHandler = vmcommon.NewCodedError(5014, vmcommon.ErrorCategoryConfiguration, "nil dct nft storage handler")
 
 // ErrNilTransactionHandler signals that a nil transaction handler has been provided
-var ErrNilTransactionHandler = errors.New("nil transaction handler")
+var ErrNilTransactionHandler = vmcommon.NewCodedError(5015, vmcommon.ErrorCategoryConfiguration, "nil transaction handler")
 
 // ErrAddressIsNotAllowed signals that sender is not allowed to do the action
-var ErrAddressIsNotAllowed = errors.New("address is not allowed to do the action")
+var ErrAddressIsNotAllowed = vmcommon.NewCodedError(3007, vmcommon.ErrorCategoryRole, "address is not allowed to do the action")
 
 // ErrInvalidNumOfArgs signals that the number of arguments is invalid
-var ErrInvalidNumOfArgs = errors.New("invalid number of arguments")
+var ErrInvalidNumOfArgs = vmcommon.NewCodedError(1012, vmcommon.ErrorCategoryValidation, "invalid number of arguments")
 
 // ErrInvalidNonce signals that invalid nonce for dct
-var ErrInvalidNonce = errors.New("invalid nonce for dct")
+var ErrInvalidNonce = vmcommon.NewCodedError(1013, vmcommon.ErrorCategoryValidation, "invalid nonce for dct")
 
 // ErrTokenHasValidMetadata signals that token has a valid metadata
-var ErrTokenHasValidMetadata = errors.New("token has valid metadata")
+var ErrTokenHasValidMetadata = vmcommon.NewCodedError(4010, vmcommon.ErrorCategoryState, "token has valid metadata")
 
 // ErrInvalidTokenID signals that invalid tokenID was provided
-var ErrInvalidTokenID = errors.New("invalid tokenID")
+var ErrInvalidTokenID = vmcommon.NewCodedError(1014, vmcommon.ErrorCategoryValidation, "invalid tokenID")
 
 // ErrNilDCTData signals that DCT data does not exist
-var ErrNilDCTData = errors.New("nil dct data")
+var ErrNilDCTData = vmcommon.NewCodedError(1015, vmcommon.ErrorCategoryValidation, "nil dct data")
 
 // ErrInvalidMetadata signals that invalid metadata was provided
-var ErrInvalidMetadata = errors.New("invalid metadata")
+var ErrInvalidMetadata = vmcommon.NewCodedError(1016, vmcommon.ErrorCategoryValidation, "invalid metadata")
 
 // ErrInvalidLiquidityForDCT signals that liquidity is invalid for DCT
-var ErrInvalidLiquidityForDCT = errors.New("invalid liquidity for DCT")
+var ErrInvalidLiquidityForDCT = vmcommon.NewCodedError(4011, vmcommon.ErrorCategoryState, "invalid liquidity for DCT")
 
 // ErrTooManyTransferAddresses signals that too many transfer address roles has been added
-var ErrTooManyTransferAddresses = errors.New("too many transfer addresses")
+var ErrTooManyTransferAddresses = vmcommon.NewCodedError(1017, vmcommon.ErrorCategoryValidation, "too many transfer addresses")
 
 // ErrInvalidMaxNumAddresses signals that there is an invalid max number of addresses
-var ErrInvalidMaxNumAddresses = errors.New("invalid max number of addresses")
+var ErrInvalidMaxNumAddresses = vmcommon.NewCodedError(5016, vmcommon.ErrorCategoryConfiguration, "invalid max number of addresses")
 
 // ErrNilEnableEpochsHandler signals that a nil enable epochs handler was provided
-var ErrNilEnableEpochsHandler = errors.New("nil enable epochs handler")
+var ErrNilEnableEpochsHandler = vmcommon.NewCodedError(5017, vmcommon.ErrorCategoryConfiguration, "nil enable epochs handler")
 
 // ErrNilActiveHandler signals that a nil active handler has been provided
-var ErrNilActiveHandler = errors.New("nil active handler")
+var ErrNilActiveHandler = vmcommon.NewCodedError(5018, vmcommon.ErrorCategoryConfiguration, "nil active handler")
+
+// ErrNilEpochNotifier signals that a nil epoch notifier has been provided
+var ErrNilEpochNotifier = vmcommon.NewCodedError(5019, vmcommon.ErrorCategoryConfiguration, "nil epoch notifier")
+
+// ErrFunctionVersionAlreadyExists signals that a function version with the same activation epoch already exists
+var ErrFunctionVersionAlreadyExists = vmcommon.NewCodedError(5020, vmcommon.ErrorCategoryConfiguration, "function version with the same activation epoch already exists")
+
+// ErrNoActiveFunctionVersion signals that no function version is active in the current epoch
+var ErrNoActiveFunctionVersion = vmcommon.NewCodedError(4012, vmcommon.ErrorCategoryState, "no active function version")
+
+// ErrNilRoundNotifier signals that a nil round notifier has been provided
+var ErrNilRoundNotifier = vmcommon.NewCodedError(5021, vmcommon.ErrorCategoryConfiguration, "nil round notifier")
+
+// ErrNilLatestNonceCache signals that a nil latest nonce cache has been provided
+var ErrNilLatestNonceCache = vmcommon.NewCodedError(5022, vmcommon.ErrorCategoryConfiguration, "nil latest nonce cache")
+
+// ErrNilMetrics signals that a nil metrics handler has been provided
+var ErrNilMetrics = vmcommon.NewCodedError(5023, vmcommon.ErrorCategoryConfiguration, "nil metrics handler")
+
+// ErrNilAddressClassifier signals that a nil address classifier has been provided
+var ErrNilAddressClassifier = vmcommon.NewCodedError(5024, vmcommon.ErrorCategoryConfiguration, "nil address classifier")
+
+// ErrAccountIsFrozen signals that the account is frozen and cannot move any assets
+var ErrAccountIsFrozen = vmcommon.NewCodedError(4013, vmcommon.ErrorCategoryState, "account is frozen")
+
+// ErrNilFreezeAccountHandler signals that a nil freeze account handler has been provided
+var ErrNilFreezeAccountHandler = vmcommon.NewCodedError(5025, vmcommon.ErrorCategoryConfiguration, "nil freeze account handler")
+
+// ErrTooManyURIs signals that the token would hold more URIs than allowed by the collection config
+var ErrTooManyURIs = vmcommon.NewCodedError(1018, vmcommon.ErrorCategoryValidation, "too many URIs for the collection")
+
+// ErrAttributesTooLong signals that the token attributes are longer than allowed by the collection config
+var ErrAttributesTooLong = vmcommon.NewCodedError(1019, vmcommon.ErrorCategoryValidation, "attributes too long for the collection")
+
+// ErrAddQuantityNotAllowed signals that the collection config does not allow adding quantity
+var ErrAddQuantityNotAllowed = vmcommon.NewCodedError(1020, vmcommon.ErrorCategoryValidation, "add quantity is not allowed for the collection")
+
+// ErrDormantSweepNotAllowed signals that the token did not opt in for reclaiming balances from dormant accounts
+var ErrDormantSweepNotAllowed = vmcommon.NewCodedError(1021, vmcommon.ErrorCategoryValidation, "dormant sweep is not allowed for the token")
+
+// ErrAccountNotDormant signals that the account was active more recently than the configured number of epochs
+var ErrAccountNotDormant = vmcommon.NewCodedError(4014, vmcommon.ErrorCategoryState, "account is not dormant")
+
+// ErrNoBalanceToSweep signals that the dormant account does not hold any balance of the token
+var ErrNoBalanceToSweep = vmcommon.NewCodedError(4015, vmcommon.ErrorCategoryState, "no balance to sweep")
+
+// ErrNilAccountActivityHandler signals that a nil account activity handler has been provided
+var ErrNilAccountActivityHandler = vmcommon.NewCodedError(5026, vmcommon.ErrorCategoryConfiguration, "nil account activity handler")
+
+// ErrInvalidMinInactiveEpochs signals that an invalid minimum number of inactive epochs has been provided
+var ErrInvalidMinInactiveEpochs = vmcommon.NewCodedError(5027, vmcommon.ErrorCategoryConfiguration, "invalid min inactive epochs")
+
+// ErrNilMultiSigVerifier signals that a nil multisig verifier has been provided
+var ErrNilMultiSigVerifier = vmcommon.NewCodedError(5028, vmcommon.ErrorCategoryConfiguration, "nil multisig verifier")
+
+// ErrMultiSigVerifierNotSet signals that a multisig managed token was used before a multisig verifier was set
+var ErrMultiSigVerifierNotSet = vmcommon.NewCodedError(5029, vmcommon.ErrorCategoryConfiguration, "multisig verifier not set")
+
+// ErrInvalidNumberOfSignatures signals that the number of signatures appended to the arguments is invalid
+var ErrInvalidNumberOfSignatures = vmcommon.NewCodedError(1022, vmcommon.ErrorCategoryValidation, "invalid number of signatures")
+
+// ErrTokenNotTransferable signals that a soulbound token was about to be transferred
+var ErrTokenNotTransferable = vmcommon.NewCodedError(1023, vmcommon.ErrorCategoryValidation, "token is soulbound and can not be transferred")
+
+// ErrRentedNFTNotTransferable signals that a rented NFT was about to be transferred by the borrower
+var ErrRentedNFTNotTransferable = vmcommon.NewCodedError(1024, vmcommon.ErrorCategoryValidation, "rented NFT can not be transferred")
+
+// ErrInvalidReturnEpoch signals that the return epoch of a rental is not in the future
+var ErrInvalidReturnEpoch = vmcommon.NewCodedError(1025, vmcommon.ErrorCategoryValidation, "invalid return epoch")
+
+// ErrNFTNotRented signals that the NFT to be reclaimed is not rented
+var ErrNFTNotRented = vmcommon.NewCodedError(4016, vmcommon.ErrorCategoryState, "NFT is not rented")
+
+// ErrRentalNotExpired signals that the NFT was reclaimed before its return epoch
+var ErrRentalNotExpired = vmcommon.NewCodedError(4017, vmcommon.ErrorCategoryState, "rental period did not expire")
+
+// ErrCallerIsNotRentalOwner signals that the NFT was reclaimed by someone other than its original owner
+var ErrCallerIsNotRentalOwner = vmcommon.NewCodedError(3008, vmcommon.ErrorCategoryRole, "caller is not the owner of the rented NFT")
+
+// ErrAccountDataNotIterable signals that the data handler of an account does not implement the account data iterator
+var ErrAccountDataNotIterable = vmcommon.NewCodedError(5030, vmcommon.ErrorCategoryConfiguration, "account data can not be iterated")
+
+// ErrNilProtectedKeysHandler signals that a nil protected keys handler has been provided
+var ErrNilProtectedKeysHandler = vmcommon.NewCodedError(5031, vmcommon.ErrorCategoryConfiguration, "nil protected keys handler")
+
+// ErrNilBuiltInFunction signals that a nil built-in function has been provided
+var ErrNilBuiltInFunction = vmcommon.NewCodedError(5032, vmcommon.ErrorCategoryConfiguration, "nil built-in function")
+
+// ErrTooManyArguments signals that the number of arguments exceeds the configured limit
+var ErrTooManyArguments = vmcommon.NewCodedError(1026, vmcommon.ErrorCategoryValidation, "too many arguments")
+
+// ErrArgumentTooLarge signals that an argument exceeds the configured maximum size
+var ErrArgumentTooLarge = vmcommon.NewCodedError(1027, vmcommon.ErrorCategoryValidation, "argument too large")
+
+// ErrDataTooLarge signals that the total size of the arguments exceeds the configured limit
+var ErrDataTooLarge = vmcommon.NewCodedError(1028, vmcommon.ErrorCategoryValidation, "data too large")
+
+// ErrURIsLimitExceeded signals that the number of URIs exceeds the configured limit
+var ErrURIsLimitExceeded = vmcommon.NewCodedError(1029, vmcommon.ErrorCategoryValidation, "too many URIs")
+
+// ErrNFTCreateStopped signals that the token manager stopped the creation of new NFTs for the collection
+var ErrNFTCreateStopped = vmcommon.NewCodedError(4018, vmcommon.ErrorCategoryState, "NFT creation is stopped for the collection")
+
+// ErrNilContext signals that a nil context has been provided
+var ErrNilContext = vmcommon.NewCodedError(5033, vmcommon.ErrorCategoryConfiguration, "nil context")
+
+// ErrMaxSupplyExceeded signals that the quantity would exceed the max supply set at the creation of the NFT
+var ErrMaxSupplyExceeded = vmcommon.NewCodedError(4019, vmcommon.ErrorCategoryState, "max supply exceeded")
+
+// ErrInvalidNFTMaxSupplyData signals that the stored max supply of an NFT could not be decoded
+var ErrInvalidNFTMaxSupplyData = vmcommon.NewCodedError(4020, vmcommon.ErrorCategoryState, "invalid NFT max supply data")
+
+// ErrNonceOverflow signals that an NFT nonce does not fit an uint64 or no nonce is left to be created
+var ErrNonceOverflow = vmcommon.NewCodedError(4021, vmcommon.ErrorCategoryState, "nonce overflow")
+
+// ErrFunctionNotAllowedOnShard signals that the built-in function is disabled on the current shard
+var ErrFunctionNotAllowedOnShard = vmcommon.NewCodedError(1030, vmcommon.ErrorCategoryValidation, "function not allowed on shard")
+
+// ErrInvalidEpochsInterval signals that the deactivation epoch is not after the activation epoch
+var ErrInvalidEpochsInterval = vmcommon.NewCodedError(5034, vmcommon.ErrorCategoryConfiguration, "invalid epochs interval")
+
+// ErrHistoricalReplayNotEnabled signals that a call as of a past epoch was requested but no enable epochs handler factory was provided
+var ErrHistoricalReplayNotEnabled = vmcommon.NewCodedError(5035, vmcommon.ErrorCategoryConfiguration, "historical replay not enabled")
+
+// ErrBuiltInFunctionNotActive signals that the built-in function is not active in the requested epoch
+var ErrBuiltInFunctionNotActive = vmcommon.NewCodedError(4022, vmcommon.ErrorCategoryState, "built in function is not active")
+
+// ErrMetaDCTAlreadySet signals that the collection was already set as a meta dct collection
+var ErrMetaDCTAlreadySet = vmcommon.NewCodedError(4023, vmcommon.ErrorCategoryState, "collection is already a meta dct collection")
+
+// ErrInvalidNumDecimals signals that the number of decimals of a meta dct collection is out of range
+var ErrInvalidNumDecimals = vmcommon.NewCodedError(1031, vmcommon.ErrorCategoryValidation, "invalid number of decimals")
+
+// ErrWrappedSupplyMismatch signals that the supply of the wrapped native token exceeds the locked native balance
+var ErrWrappedSupplyMismatch = vmcommon.NewCodedError(4024, vmcommon.ErrorCategoryState, "wrapped supply does not match the locked native balance")
+
+// ErrNilProofVerifier signals that a nil proof verifier was provided
+var ErrNilProofVerifier = vmcommon.NewCodedError(5036, vmcommon.ErrorCategoryConfiguration, "nil proof verifier")
+
+// ErrProofVerifierNotSet signals that a bridge operation was called before the proof verifier was set
+var ErrProofVerifierNotSet = vmcommon.NewCodedError(5037, vmcommon.ErrorCategoryConfiguration, "proof verifier not set")
+
+// ErrAddressIsNotBridge signals that the caller is not one of the configured bridge addresses
+var ErrAddressIsNotBridge = vmcommon.NewCodedError(3009, vmcommon.ErrorCategoryRole, "address is not a bridge address")
+
+// ErrInsufficientAllowance signals that the amount to transfer exceeds the allowance granted to the spender
+var ErrInsufficientAllowance = vmcommon.NewCodedError(4025, vmcommon.ErrorCategoryState, "insufficient allowance")
+
+// ErrNilAliasResolver signals that a nil alias resolver was provided
+var ErrNilAliasResolver = vmcommon.NewCodedError(5038, vmcommon.ErrorCategoryConfiguration, "nil alias resolver")
+
+// ErrNilLogPublisher signals that a nil log publisher has been provided
+var ErrNilLogPublisher = vmcommon.NewCodedError(5039, vmcommon.ErrorCategoryConfiguration, "nil log publisher")
+
+// ErrNilFunctionResolver signals that a nil function resolver has been provided
+var ErrNilFunctionResolver = vmcommon.NewCodedError(5040, vmcommon.ErrorCategoryConfiguration, "nil function resolver")
+
+// ErrNilAccountCache signals that a nil account cache has been provided
+var ErrNilAccountCache = vmcommon.NewCodedError(5041, vmcommon.ErrorCategoryConfiguration, "nil account cache")
+
+// ErrInvalidAccountCacheCapacity signals that an invalid account cache capacity has been provided
+var ErrInvalidAccountCacheCapacity = vmcommon.NewCodedError(5042, vmcommon.ErrorCategoryConfiguration, "invalid account cache capacity")
+
+// ErrBatchProcessingNotEnabled signals that a batch of calls was requested but no accounts adapter was provided
+var ErrBatchProcessingNotEnabled = vmcommon.NewCodedError(5043, vmcommon.ErrorCategoryConfiguration, "batch processing not enabled")
+
+// ErrBatchCallFailed signals that a call of a batch did not end successfully, so the whole batch was reverted
+var ErrBatchCallFailed = vmcommon.NewCodedError(4026, vmcommon.ErrorCategoryState, "batch call failed")
+
+// ErrCallValueRequired signals that a built-in function requiring native value was called without it
+var ErrCallValueRequired = vmcommon.NewCodedError(1032, vmcommon.ErrorCategoryValidation, "built in function requires tx value")
+
+// ErrReceiversInAnotherShard signals that receivers of a transfer with smart contract call are not in the shard of the sender
+var ErrReceiversInAnotherShard = vmcommon.NewCodedError(1033, vmcommon.ErrorCategoryValidation, "receivers of transfer with smart contract call in another shard")
+
+// ErrNilQuotaHandler signals that a nil quota handler has been provided
+var ErrNilQuotaHandler = vmcommon.NewCodedError(5044, vmcommon.ErrorCategoryConfiguration, "nil quota handler")
+
+// ErrNilHasher signals that a nil hasher has been provided
+var ErrNilHasher = vmcommon.NewCodedError(5045, vmcommon.ErrorCategoryConfiguration, "nil hasher")
+
+// ErrHasherNotSet signals that a collection requires the content hash but no hasher was set to verify it
+var ErrHasherNotSet = vmcommon.NewCodedError(5046, vmcommon.ErrorCategoryConfiguration, "hasher not set")
+
+// ErrInvalidContentHash signals that the hash of the created token is not the hash of its attributes
+var ErrInvalidContentHash = vmcommon.NewCodedError(1034, vmcommon.ErrorCategoryValidation, "hash does not match the attributes")
+
+// ErrQuotaExceeded signals that the call would exceed the per block quota of the built-in function
+var ErrQuotaExceeded = vmcommon.NewCodedError(4027, vmcommon.ErrorCategoryState, "quota exceeded")
+
+// ErrInvalidRoyaltiesDenominator signals that the royalties denominator is not a multiple of the default one
+var ErrInvalidRoyaltiesDenominator = vmcommon.NewCodedError(5047, vmcommon.ErrorCategoryConfiguration, "invalid royalties denominator")
+
+// ErrNilStorageUsageTracker signals that a nil storage usage tracker has been provided
+var ErrNilStorageUsageTracker = vmcommon.NewCodedError(5048, vmcommon.ErrorCategoryConfiguration, "nil storage usage tracker")
+
+// ErrStorageLimitExceeded signals that the call would store more bytes than allowed on behalf of the account
+var ErrStorageLimitExceeded = vmcommon.NewCodedError(4028, vmcommon.ErrorCategoryState, "storage limit exceeded")
+
+// ErrCompressorNotSet signals that a compressed payload was loaded while no compressor is set
+var ErrCompressorNotSet = vmcommon.NewCodedError(5049, vmcommon.ErrorCategoryConfiguration, "compressor not set")
+
+// ErrGlobalSettingsVersioningNotActive signals that the global settings were requested to be migrated before the versioning was activated
+var ErrGlobalSettingsVersioningNotActive = vmcommon.NewCodedError(4029, vmcommon.ErrorCategoryState, "global settings versioning is not active")
+
+// ErrNilTransferInterceptor signals that a nil transfer interceptor has been provided
+var ErrNilTransferInterceptor = vmcommon.NewCodedError(5050, vmcommon.ErrorCategoryConfiguration, "nil transfer interceptor")
+
+// ErrNilStateProofProvider signals that a nil state proof provider has been provided
+var ErrNilStateProofProvider = vmcommon.NewCodedError(5051, vmcommon.ErrorCategoryConfiguration, "nil state proof provider")
+
+// ErrNilPubkeyConverter signals that a nil pubkey converter has been provided
+var ErrNilPubkeyConverter = vmcommon.NewCodedError(5052, vmcommon.ErrorCategoryConfiguration, "nil pubkey converter")
+
+// ErrInvalidLogAddressFormat signals that an invalid log address format has been provided
+var ErrInvalidLogAddressFormat = vmcommon.NewCodedError(5053, vmcommon.ErrorCategoryConfiguration, "invalid log address format")
+
+// ErrDCTBalanceIsLocked signals that the operation would spend tokens which are locked until a future epoch
+var ErrDCTBalanceIsLocked = vmcommon.NewCodedError(4030, vmcommon.ErrorCategoryState, "dct balance is locked")
+
+// ErrInvalidUnlockEpoch signals that the unlock epoch of a transfer and lock is not in the future
+var ErrInvalidUnlockEpoch = vmcommon.NewCodedError(1035, vmcommon.ErrorCategoryValidation, "invalid unlock epoch")
+
+// ErrBridgeProofAlreadyConsumed signals that the external chain operation proved by the bridge call was already consumed
+var ErrBridgeProofAlreadyConsumed = vmcommon.NewCodedError(4031, vmcommon.ErrorCategoryState, "bridge proof already consumed")
+
+// ErrEmptyChainID signals that an empty chain ID was provided
+var ErrEmptyChainID = vmcommon.NewCodedError(5054, vmcommon.ErrorCategoryConfiguration, "empty chain ID")
+
+// ErrInsufficientWrappedSupply signals that the unwrapped amount exceeds the wrapped supply of the shard
+var ErrInsufficientWrappedSupply = vmcommon.NewCodedError(4032, vmcommon.ErrorCategoryState, "insufficient wrapped supply on this shard")
+
+// ErrTooManyDCTLocks signals that a balance already holds the maximum number of locks with distinct unlock epochs
+var ErrTooManyDCTLocks = vmcommon.NewCodedError(4033, vmcommon.ErrorCategoryState, "too many dct locks")
+
+// ErrAccountCacheNotBoundToAccounts signals that the account cache is not bound to the accounts adapter of the function
+var ErrAccountCacheNotBoundToAccounts = vmcommon.NewCodedError(5055, vmcommon.ErrorCategoryConfiguration, "account cache not bound to the accounts adapter")
+
+// ErrLatestNonceCacheNotBoundToAccounts signals that the latest nonce cache is not bound to the accounts adapter of the function
+var ErrLatestNonceCacheNotBoundToAccounts = vmcommon.NewCodedError(5056, vmcommon.ErrorCategoryConfiguration, "latest nonce cache not bound to the accounts adapter")
+
+// ErrBatchCallerInAnotherShard signals that a call of a batch has a caller which is not handled by this shard
+var ErrBatchCallerInAnotherShard = vmcommon.NewCodedError(1036, vmcommon.ErrorCategoryValidation, "batch caller in another shard")
+
+// ErrInvalidNFTRoyaltiesDenominatorData signals that the stored royalties denominator of an NFT could not be decoded
+var ErrInvalidNFTRoyaltiesDenominatorData = vmcommon.NewCodedError(4034, vmcommon.ErrorCategoryState, "invalid NFT royalties denominator data")
+
+// ErrLockedValueTooLarge signals that a locked value is too large to be encoded in the dct user metadata
+var ErrLockedValueTooLarge = vmcommon.NewCodedError(4035, vmcommon.ErrorCategoryState, "locked value too large")
@@ -0,0 +1,204 @@
+package builtInFunctions
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var updateGoldenFiles = flag.Bool("update", false, "update the golden files")
+
+// allErrors lists every coded error of the package, a new error must be added here to be checked
+var allErrors = []error{
+	ErrNilAccountsAdapter,
+	ErrInsufficientFunds,
+	ErrNilValue,
+	ErrNilMarshalizer,
+	ErrInvalidRcvAddr,
+	ErrNegativeValue,
+	ErrNilShardCoordinator,
+	ErrWrongTypeAssertion,
+	ErrNilSCDestAccount,
+	ErrNotEnoughGas,
+	ErrInvalidArguments,
+	ErrOperationNotPermitted,
+	ErrInvalidAddressLength,
+	ErrNilVmInput,
+	ErrNilDnsAddresses,
+	ErrCallerIsNotTheDNSAddress,
+	ErrUserNameChangeIsDisabled,
+	ErrBuiltInFunctionCalledWithValue,
+	ErrAccountNotPayable,
+	ErrNilUserAccount,
+	ErrAddressIsNotDCTSystemSC,
+	ErrOnlySystemAccountAccepted,
+	ErrNilGlobalSettingsHandler,
+	ErrNilRolesHandler,
+	ErrDCTTokenIsPaused,
+	ErrDCTIsFrozenForAccount,
+	ErrCannotWipeAccountNotFrozen,
+	ErrNilPayableHandler,
+	ErrActionNotAllowed,
+	ErrOnlyFungibleTokensHaveBalanceTransfer,
+	ErrNFTTokenDoesNotExist,
+	ErrNFTDoesNotHaveMetadata,
+	ErrInvalidNFTQuantity,
+	ErrNewNFTDataOnSenderAddress,
+	ErrNilContainerElement,
+	ErrInvalidContainerKey,
+	ErrContainerKeyAlreadyExists,
+	ErrWrongTypeInContainer,
+	ErrEmptyFunctionName,
+	ErrInsufficientQuantityDCT,
+	ErrNilDCTNFTStorageHandler,
+	ErrNilTransactionHandler,
+	ErrAddressIsNotAllowed,
+	ErrInvalidNumOfArgs,
+	ErrInvalidNonce,
+	ErrTokenHasValidMetadata,
+	ErrInvalidTokenID,
+	ErrNilDCTData,
+	ErrInvalidMetadata,
+	ErrInvalidLiquidityForDCT,
+	ErrTooManyTransferAddresses,
+	ErrInvalidMaxNumAddresses,
+	ErrNilEnableEpochsHandler,
+	ErrNilActiveHandler,
+	ErrNilEpochNotifier,
+	ErrFunctionVersionAlreadyExists,
+	ErrNoActiveFunctionVersion,
+	ErrNilRoundNotifier,
+	ErrNilLatestNonceCache,
+	ErrNilMetrics,
+	ErrNilFunctionResolver,
+	ErrNilAccountCache,
+	ErrInvalidAccountCacheCapacity,
+	ErrBatchProcessingNotEnabled,
+	ErrBatchCallFailed,
+	ErrNilAddressClassifier,
+	ErrAccountIsFrozen,
+	ErrNilFreezeAccountHandler,
+	ErrTooManyURIs,
+	ErrAttributesTooLong,
+	ErrAddQuantityNotAllowed,
+	ErrDormantSweepNotAllowed,
+	ErrAccountNotDormant,
+	ErrNoBalanceToSweep,
+	ErrNilAccountActivityHandler,
+	ErrInvalidMinInactiveEpochs,
+	ErrNilMultiSigVerifier,
+	ErrMultiSigVerifierNotSet,
+	ErrInvalidNumberOfSignatures,
+	ErrTokenNotTransferable,
+	ErrRentedNFTNotTransferable,
+	ErrInvalidReturnEpoch,
+	ErrNFTNotRented,
+	ErrRentalNotExpired,
+	ErrCallerIsNotRentalOwner,
+	ErrAccountDataNotIterable,
+	ErrNilProtectedKeysHandler,
+	ErrNilBuiltInFunction,
+	ErrTooManyArguments,
+	ErrArgumentTooLarge,
+	ErrDataTooLarge,
+	ErrURIsLimitExceeded,
+	ErrNFTCreateStopped,
+	ErrNilContext,
+	ErrMaxSupplyExceeded,
+	ErrInvalidNFTMaxSupplyData,
+	ErrNonceOverflow,
+	ErrFunctionNotAllowedOnShard,
+	ErrInvalidEpochsInterval,
+	ErrHistoricalReplayNotEnabled,
+	ErrBuiltInFunctionNotActive,
+	ErrMetaDCTAlreadySet,
+	ErrInvalidNumDecimals,
+	ErrWrappedSupplyMismatch,
+	ErrNilProofVerifier,
+	ErrProofVerifierNotSet,
+	ErrAddressIsNotBridge,
+	ErrInsufficientAllowance,
+	ErrNilAliasResolver,
+	ErrNilLogPublisher,
+	ErrCallValueRequired,
+	ErrReceiversInAnotherShard,
+	ErrNilQuotaHandler,
+	ErrQuotaExceeded,
+	ErrNilHasher,
+	ErrHasherNotSet,
+	ErrInvalidContentHash,
+	ErrInvalidRoyaltiesDenominator,
+	ErrNilStorageUsageTracker,
+	ErrStorageLimitExceeded,
+	ErrCompressorNotSet,
+	ErrGlobalSettingsVersioningNotActive,
+	ErrNilTransferInterceptor,
+	ErrNilStateProofProvider,
+	ErrNilPubkeyConverter,
+	ErrInvalidLogAddressFormat,
+	ErrDCTBalanceIsLocked,
+	ErrInvalidUnlockEpoch, ErrBridgeProofAlreadyConsumed, ErrEmptyChainID, ErrInsufficientWrappedSupply, ErrTooManyDCTLocks, ErrAccountCacheNotBoundToAccounts,
+	ErrLatestNonceCacheNotBoundToAccounts, ErrBatchCallerInAnotherShard, ErrInvalidNFTRoyaltiesDenominatorData,
+	ErrLockedValueTooLarge,
+}
+
+func TestErrors_CodesShouldBeUniqueAndMatchTheCategory(t *testing.T) {
+	t.Parallel()
+
+	categoryOfCodeRange := map[int]vmcommon.ErrorCategory{
+		1: vmcommon.ErrorCategoryValidation,
+		2: vmcommon.ErrorCategoryGas,
+		3: vmcommon.ErrorCategoryRole,
+		4: vmcommon.ErrorCategoryState,
+		5: vmcommon.ErrorCategoryConfiguration,
+	}
+	codes := make(map[int]error)
+	for _, err := range allErrors {
+		code := vmcommon.ErrorCode(err)
+		assert.NotEqual(t, vmcommon.ErrorCodeUnknown, code, err.Error())
+
+		previous, found := codes[code]
+		assert.False(t, found, fmt.Sprintf("code %d used by %v and %v", code, previous, err))
+		codes[code] = err
+
+		assert.Equal(t, categoryOfCodeRange[code/1000], vmcommon.ErrorCategoryOf(err), err.Error())
+	}
+}
+
+func TestErrors_WrappedErrorsShouldKeepTheCode(t *testing.T) {
+	t.Parallel()
+
+	err := fmt.Errorf("%w, wrong number of arguments", ErrInvalidArguments)
+	assert.Equal(t, vmcommon.ErrorCode(ErrInvalidArguments), vmcommon.ErrorCode(err))
+	assert.Equal(t, vmcommon.ErrorCategoryValidation, vmcommon.ErrorCategoryOf(err))
+	assert.Equal(t, vmcommon.OutOfGas, vmcommon.ReturnCodeFromError(fmt.Errorf("%w", ErrNotEnoughGas)))
+	assert.Equal(t, vmcommon.UserError, vmcommon.ReturnCodeFromError(err))
+}
+
+func TestErrors_ReturnMessagesShouldMatchTheGoldenFile(t *testing.T) {
+	t.Parallel()
+
+	lines := make([]string, 0, len(allErrors))
+	for _, err := range allErrors {
+		lines = append(lines, fmt.Sprintf("%d\t%s", vmcommon.ErrorCode(err), vmcommon.ReturnMessageFromError(err)))
+	}
+	sort.Strings(lines)
+	actual := strings.Join(lines, "\n") + "\n"
+
+	goldenFile := filepath.Join("testdata", "returnMessages.golden")
+	if *updateGoldenFiles {
+		require.Nil(t, os.WriteFile(goldenFile, []byte(actual), 0644))
+	}
+
+	expected, err := os.ReadFile(goldenFile)
+	require.Nil(t, err)
+	assert.Equal(t, string(expected), actual, "return messages must stay stable, run the test with -update only when adding errors")
+}
@@ -0,0 +1,55 @@
+package builtInFunctions
+
+import (
+	"context"
+	"sync"
+
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+)
+
+// executionGuardFunction wraps a built-in function and holds the execution lock of the container for the duration of
+// each call, so the container can swap the gas costs of all its functions, or replay a call of a past epoch, while none
+// of them is executing
+type executionGuardFunction struct {
+	baseFunctionWrapper
+	mutExecution *sync.RWMutex
+}
+
+func newExecutionGuardFunction(function vmcommon.BuiltinFunction, mutExecution *sync.RWMutex) *executionGuardFunction {
+	return &executionGuardFunction{
+		baseFunctionWrapper: baseFunctionWrapper{function: function},
+		mutExecution:        mutExecution,
+	}
+}
+
+// ProcessBuiltinFunction calls the wrapped function while holding the execution lock
+func (egf *executionGuardFunction) ProcessBuiltinFunction(
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	return egf.processBuiltinFunctionWithContext(context.Background(), acntSnd, acntDst, vmInput)
+}
+
+func (egf *executionGuardFunction) processBuiltinFunctionWithContext(
+	ctx context.Context,
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	egf.mutExecution.RLock()
+	defer egf.mutExecution.RUnlock()
+
+	return callWithContext(ctx, egf.function, acntSnd, acntDst, vmInput)
+}
+
+// CheckIsExecutable forwards the validation to the wrapped function while holding the execution lock
+func (egf *executionGuardFunction) CheckIsExecutable(vmInput *vmcommon.ContractCallInput) error {
+	egf.mutExecution.RLock()
+	defer egf.mutExecution.RUnlock()
+
+	return checkIsExecutable(egf.function, vmInput)
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (egf *executionGuardFunction) IsInterfaceNil() bool {
+	return egf == nil
+}
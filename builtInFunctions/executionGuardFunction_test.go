@@ -0,0 +1,53 @@
+package builtInFunctions
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Reshusk23/sr-me-core/core/check"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func unwrapExecutionGuard(function vmcommon.BuiltinFunction) vmcommon.BuiltinFunction {
+	guard, ok := function.(*executionGuardFunction)
+	if !ok {
+		return function
+	}
+
+	return unwrapCorrelation(guard.function)
+}
+
+func TestExecutionGuardFunction(t *testing.T) {
+	t.Parallel()
+
+	var mutExecution sync.RWMutex
+	released := make(chan struct{})
+	processed := make(chan struct{})
+	function := &mock.BuiltInFunctionStub{
+		ProcessBuiltinFunctionCalled: func(_, _ vmcommon.UserAccountHandler, _ *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+			close(processed)
+			return &vmcommon.VMOutput{}, nil
+		},
+	}
+	guard := newExecutionGuardFunction(function, &mutExecution)
+	assert.False(t, check.IfNil(guard))
+
+	mutExecution.Lock()
+	go func() {
+		_, _ = guard.ProcessBuiltinFunction(nil, nil, &vmcommon.ContractCallInput{})
+		close(released)
+	}()
+
+	select {
+	case <-processed:
+		assert.Fail(t, "the function should not execute while the execution lock is held")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	mutExecution.Unlock()
+	<-processed
+	<-released
+}
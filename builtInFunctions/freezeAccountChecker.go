@@ -0,0 +1,90 @@
+package builtInFunctions
+
+import (
+	"sync"
+
+	"github.com/Reshusk23/sr-me-core/core/check"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+)
+
+// freezeAccountChecker is embedded by the built-in functions moving assets out of an account. Until a freeze account
+// handler is set, no account is considered frozen. It also holds the system addresses of the embedding function, as the
+// DCT system smart contract is never frozen, so these functions must not embed baseSystemAddressesHandler themselves.
+type freezeAccountChecker struct {
+	baseSystemAddressesHandler
+	mutFreezeAccount     sync.RWMutex
+	freezeAccountHandler vmcommon.FreezeAccountHandler
+}
+
+// SetFreezeAccountHandler sets the handler telling whether an account is frozen as a whole
+func (fac *freezeAccountChecker) SetFreezeAccountHandler(freezeAccountHandler vmcommon.FreezeAccountHandler) error {
+	if check.IfNil(freezeAccountHandler) {
+		return ErrNilFreezeAccountHandler
+	}
+
+	fac.mutFreezeAccount.Lock()
+	fac.freezeAccountHandler = freezeAccountHandler
+	fac.mutFreezeAccount.Unlock()
+
+	return nil
+}
+
+func (fac *freezeAccountChecker) checkAccountIsNotFrozen(address []byte, isReturnWithError bool) error {
+	if isReturnWithError {
+		return nil
+	}
+	if fac.getSystemAddresses().IsDCTSCAddress(address) {
+		return nil
+	}
+
+	fac.mutFreezeAccount.RLock()
+	freezeAccountHandler := fac.freezeAccountHandler
+	fac.mutFreezeAccount.RUnlock()
+
+	if check.IfNil(freezeAccountHandler) {
+		return nil
+	}
+	if freezeAccountHandler.IsAccountFrozen(address) {
+		return ErrAccountIsFrozen
+	}
+
+	return nil
+}
+
+type epochGatedFreezeAccountHandler struct {
+	freezeAccountHandler vmcommon.FreezeAccountHandler
+	enableEpochsHandler  vmcommon.EnableEpochsHandler
+}
+
+// NewEpochGatedFreezeAccountHandler returns a freeze account handler which reports frozen accounts only after the
+// freeze account flag is enabled
+func NewEpochGatedFreezeAccountHandler(
+	freezeAccountHandler vmcommon.FreezeAccountHandler,
+	enableEpochsHandler vmcommon.EnableEpochsHandler,
+) (*epochGatedFreezeAccountHandler, error) {
+	if check.IfNil(freezeAccountHandler) {
+		return nil, ErrNilFreezeAccountHandler
+	}
+	if check.IfNil(enableEpochsHandler) {
+		return nil, ErrNilEnableEpochsHandler
+	}
+
+	return &epochGatedFreezeAccountHandler{
+		freezeAccountHandler: freezeAccountHandler,
+		enableEpochsHandler:  enableEpochsHandler,
+	}, nil
+}
+
+// IsAccountFrozen returns true if the freeze account flag is enabled and the account is frozen
+func (handler *epochGatedFreezeAccountHandler) IsAccountFrozen(address []byte) bool {
+	if !handler.enableEpochsHandler.IsFreezeAccountFlagEnabled() {
+		return false
+	}
+
+	return handler.freezeAccountHandler.IsAccountFrozen(address)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (handler *epochGatedFreezeAccountHandler) IsInterfaceNil() bool {
+	return handler == nil
+}
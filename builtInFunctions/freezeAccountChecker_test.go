@@ -0,0 +1,113 @@
+package builtInFunctions
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Reshusk23/sr-me-core/core"
+	"github.com/Reshusk23/sr-me-core/core/check"
+	"github.com/Reshusk23/sr-me-core/data/dct"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func createFrozenAccountHandler(frozenAddress []byte) *mock.FreezeAccountHandlerStub {
+	return &mock.FreezeAccountHandlerStub{
+		IsAccountFrozenCalled: func(address []byte) bool {
+			return string(address) == string(frozenAddress)
+		},
+	}
+}
+
+func TestFreezeAccountChecker_CheckAccountIsNotFrozen(t *testing.T) {
+	t.Parallel()
+
+	fac := &freezeAccountChecker{}
+	assert.Nil(t, fac.checkAccountIsNotFrozen([]byte("frozen"), false))
+
+	err := fac.SetFreezeAccountHandler(nil)
+	assert.Equal(t, ErrNilFreezeAccountHandler, err)
+
+	err = fac.SetFreezeAccountHandler(createFrozenAccountHandler([]byte("frozen")))
+	assert.Nil(t, err)
+
+	assert.Equal(t, ErrAccountIsFrozen, fac.checkAccountIsNotFrozen([]byte("frozen"), false))
+	assert.Nil(t, fac.checkAccountIsNotFrozen([]byte("frozen"), true))
+	assert.Nil(t, fac.checkAccountIsNotFrozen([]byte("other"), false))
+
+	err = fac.SetFreezeAccountHandler(createFrozenAccountHandler(core.DCTSCAddress))
+	assert.Nil(t, err)
+	assert.Nil(t, fac.checkAccountIsNotFrozen(core.DCTSCAddress, false))
+}
+
+func TestNewEpochGatedFreezeAccountHandler(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil freeze account handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		handler, err := NewEpochGatedFreezeAccountHandler(nil, &mock.EnableEpochsHandlerStub{})
+		assert.True(t, check.IfNil(handler))
+		assert.Equal(t, ErrNilFreezeAccountHandler, err)
+	})
+	t.Run("nil enable epochs handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		handler, err := NewEpochGatedFreezeAccountHandler(&mock.FreezeAccountHandlerStub{}, nil)
+		assert.True(t, check.IfNil(handler))
+		assert.ErrorIs(t, err, ErrNilEnableEpochsHandler)
+	})
+	t.Run("should report frozen accounts only after the flag is enabled", func(t *testing.T) {
+		t.Parallel()
+
+		enableEpochsHandler := &mock.EnableEpochsHandlerStub{}
+		handler, err := NewEpochGatedFreezeAccountHandler(createFrozenAccountHandler([]byte("frozen")), enableEpochsHandler)
+		assert.False(t, check.IfNil(handler))
+		assert.Nil(t, err)
+
+		assert.False(t, handler.IsAccountFrozen([]byte("frozen")))
+
+		enableEpochsHandler.IsFreezeAccountFlagEnabledField = true
+		assert.True(t, handler.IsAccountFrozen([]byte("frozen")))
+		assert.False(t, handler.IsAccountFrozen([]byte("other")))
+	})
+}
+
+func TestDCTTransfer_FrozenAccountCannotSend(t *testing.T) {
+	t.Parallel()
+
+	marshaller := &mock.MarshalizerMock{}
+	transferFunc, _ := NewDCTTransferFunc(ArgsNewDCTTransfer{
+		Config: Config{
+			Marshalizer:           marshaller,
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			ShardCoordinator:      &mock.ShardCoordinatorStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+		},
+		FuncGasCost: 10,
+	})
+	_ = transferFunc.SetPayableChecker(&mock.PayableHandlerStub{})
+	_ = transferFunc.SetFreezeAccountHandler(createFrozenAccountHandler([]byte("snd")))
+
+	input := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			GasProvided: 50,
+			CallValue:   big.NewInt(0),
+		},
+	}
+	key := []byte("key")
+	input.Arguments = [][]byte{key, big.NewInt(10).Bytes()}
+	accSnd := mock.NewUserAccount([]byte("snd"))
+	dctKey := append(transferFunc.keyPrefix, key...)
+	marshaledData, _ := marshaller.Marshal(&dct.DCToken{Value: big.NewInt(100)})
+	_ = accSnd.AccountDataHandler().SaveKeyValue(dctKey, marshaledData)
+
+	_, err := transferFunc.ProcessBuiltinFunction(accSnd, nil, input)
+	assert.Equal(t, ErrAccountIsFrozen, err)
+
+	accDst := mock.NewUserAccount([]byte("snd"))
+	_, err = transferFunc.ProcessBuiltinFunction(nil, accDst, input)
+	assert.Nil(t, err)
+}
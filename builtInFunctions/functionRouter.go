@@ -0,0 +1,179 @@
+package builtInFunctions
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/Reshusk23/sr-me-core/core/check"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+)
+
+type functionVersion struct {
+	activationEpoch uint32
+	function        vmcommon.BuiltinFunction
+}
+
+// functionRouter holds more implementations of the same built-in function and routes each call to the
+// implementation activated for the current epoch
+type functionRouter struct {
+	mutVersions  sync.RWMutex
+	versions     []functionVersion
+	currentEpoch uint32
+}
+
+// NewFunctionRouter creates a new function router which is notified on each confirmed epoch
+func NewFunctionRouter(epochNotifier vmcommon.EpochNotifier) (*functionRouter, error) {
+	if check.IfNil(epochNotifier) {
+		return nil, ErrNilEpochNotifier
+	}
+
+	fr := &functionRouter{
+		versions: make([]functionVersion, 0),
+	}
+	epochNotifier.RegisterNotifyHandler(fr)
+
+	return fr, nil
+}
+
+// AddVersion registers a new implementation which becomes active starting with the provided epoch
+func (fr *functionRouter) AddVersion(activationEpoch uint32, function vmcommon.BuiltinFunction) error {
+	if check.IfNil(function) {
+		return ErrNilContainerElement
+	}
+
+	fr.mutVersions.Lock()
+	defer fr.mutVersions.Unlock()
+
+	for _, version := range fr.versions {
+		if version.activationEpoch == activationEpoch {
+			return ErrFunctionVersionAlreadyExists
+		}
+	}
+
+	fr.versions = append(fr.versions, functionVersion{
+		activationEpoch: activationEpoch,
+		function:        function,
+	})
+	sort.Slice(fr.versions, func(i, j int) bool {
+		return fr.versions[i].activationEpoch < fr.versions[j].activationEpoch
+	})
+
+	return nil
+}
+
+// EpochConfirmed is called whenever a new epoch is confirmed
+func (fr *functionRouter) EpochConfirmed(epoch uint32, _ uint64) {
+	fr.mutVersions.Lock()
+	fr.currentEpoch = epoch
+	fr.mutVersions.Unlock()
+}
+
+// ProcessBuiltinFunction routes the call to the implementation active in the current epoch
+func (fr *functionRouter) ProcessBuiltinFunction(
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	function, err := fr.activeFunction()
+	if err != nil {
+		return nil, err
+	}
+
+	return function.ProcessBuiltinFunction(acntSnd, acntDst, vmInput)
+}
+
+// CheckIsExecutable validates the call with the version active for the current epoch
+func (fr *functionRouter) CheckIsExecutable(vmInput *vmcommon.ContractCallInput) error {
+	function, err := fr.activeFunction()
+	if err != nil {
+		return err
+	}
+
+	return checkIsExecutable(function, vmInput)
+}
+
+// SetNewGasConfig is called whenever gas cost is changed, all the versions are updated
+func (fr *functionRouter) SetNewGasConfig(gasCost *vmcommon.GasCost) {
+	fr.mutVersions.RLock()
+	defer fr.mutVersions.RUnlock()
+
+	for _, version := range fr.versions {
+		version.function.SetNewGasConfig(gasCost)
+	}
+}
+
+// SetPayableChecker will set the payableCheck handler to all the versions accepting one
+func (fr *functionRouter) SetPayableChecker(payableHandler vmcommon.PayableChecker) error {
+	if check.IfNil(payableHandler) {
+		return ErrNilPayableHandler
+	}
+
+	fr.mutVersions.RLock()
+	defer fr.mutVersions.RUnlock()
+
+	for _, version := range fr.versions {
+		acceptPayableChecker, ok := version.function.(vmcommon.AcceptPayableChecker)
+		if !ok {
+			continue
+		}
+
+		err := acceptPayableChecker.SetPayableChecker(payableHandler)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// IsActive returns true if there is an active version for the current epoch and that version is active
+func (fr *functionRouter) IsActive() bool {
+	function, err := fr.activeFunction()
+	if err != nil {
+		return false
+	}
+
+	return function.IsActive()
+}
+
+// ActivationEpochs returns the sorted list of epochs in which new versions get activated
+func (fr *functionRouter) ActivationEpochs() []uint32 {
+	fr.mutVersions.RLock()
+	defer fr.mutVersions.RUnlock()
+
+	epochs := make([]uint32, 0, len(fr.versions))
+	for _, version := range fr.versions {
+		epochs = append(epochs, version.activationEpoch)
+	}
+
+	return epochs
+}
+
+func (fr *functionRouter) activeFunction() (vmcommon.BuiltinFunction, error) {
+	fr.mutVersions.RLock()
+	defer fr.mutVersions.RUnlock()
+
+	return fr.functionForEpoch(fr.currentEpoch)
+}
+
+// versionForEpoch returns the version active in the provided epoch, regardless of the current epoch
+func (fr *functionRouter) versionForEpoch(epoch uint32) (vmcommon.BuiltinFunction, error) {
+	fr.mutVersions.RLock()
+	defer fr.mutVersions.RUnlock()
+
+	return fr.functionForEpoch(epoch)
+}
+
+func (fr *functionRouter) functionForEpoch(epoch uint32) (vmcommon.BuiltinFunction, error) {
+	for i := len(fr.versions) - 1; i >= 0; i-- {
+		if fr.versions[i].activationEpoch <= epoch {
+			return fr.versions[i].function, nil
+		}
+	}
+
+	return nil, ErrNoActiveFunctionVersion
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (fr *functionRouter) IsInterfaceNil() bool {
+	return fr == nil
+}
@@ -0,0 +1,179 @@
+package builtInFunctions
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Reshusk23/sr-me-core/core/check"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func createFunctionStubReturning(returnMessage string) *mock.BuiltInFunctionStub {
+	return &mock.BuiltInFunctionStub{
+		ProcessBuiltinFunctionCalled: func(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+			return &vmcommon.VMOutput{ReturnMessage: returnMessage}, nil
+		},
+	}
+}
+
+func TestNewFunctionRouter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil epoch notifier should error", func(t *testing.T) {
+		t.Parallel()
+
+		router, err := NewFunctionRouter(nil)
+		assert.True(t, check.IfNil(router))
+		assert.ErrorIs(t, err, ErrNilEpochNotifier)
+	})
+	t.Run("should work and register", func(t *testing.T) {
+		t.Parallel()
+
+		registered := false
+		router, err := NewFunctionRouter(&mock.EpochNotifierStub{
+			RegisterNotifyHandlerCalled: func(handler vmcommon.EpochSubscriberHandler) {
+				registered = true
+			},
+		})
+		assert.False(t, check.IfNil(router))
+		assert.Nil(t, err)
+		assert.True(t, registered)
+	})
+}
+
+func TestFunctionRouter_AddVersion(t *testing.T) {
+	t.Parallel()
+
+	router, _ := NewFunctionRouter(&mock.EpochNotifierStub{})
+
+	err := router.AddVersion(0, nil)
+	assert.Equal(t, ErrNilContainerElement, err)
+
+	err = router.AddVersion(10, &mock.BuiltInFunctionStub{})
+	assert.Nil(t, err)
+	err = router.AddVersion(0, &mock.BuiltInFunctionStub{})
+	assert.Nil(t, err)
+	err = router.AddVersion(10, &mock.BuiltInFunctionStub{})
+	assert.Equal(t, ErrFunctionVersionAlreadyExists, err)
+
+	assert.Equal(t, []uint32{0, 10}, router.ActivationEpochs())
+}
+
+func TestFunctionRouter_ProcessBuiltinFunctionRoutesByEpoch(t *testing.T) {
+	t.Parallel()
+
+	router, _ := NewFunctionRouter(&mock.EpochNotifierStub{})
+	_ = router.AddVersion(5, createFunctionStubReturning("v1"))
+	_ = router.AddVersion(20, createFunctionStubReturning("v2"))
+
+	vmOutput, err := router.ProcessBuiltinFunction(nil, nil, &vmcommon.ContractCallInput{})
+	assert.Nil(t, vmOutput)
+	assert.Equal(t, ErrNoActiveFunctionVersion, err)
+	assert.False(t, router.IsActive())
+
+	router.EpochConfirmed(5, 0)
+	vmOutput, err = router.ProcessBuiltinFunction(nil, nil, &vmcommon.ContractCallInput{})
+	assert.Nil(t, err)
+	assert.Equal(t, "v1", vmOutput.ReturnMessage)
+	assert.True(t, router.IsActive())
+
+	router.EpochConfirmed(19, 0)
+	vmOutput, _ = router.ProcessBuiltinFunction(nil, nil, &vmcommon.ContractCallInput{})
+	assert.Equal(t, "v1", vmOutput.ReturnMessage)
+
+	router.EpochConfirmed(25, 0)
+	vmOutput, _ = router.ProcessBuiltinFunction(nil, nil, &vmcommon.ContractCallInput{})
+	assert.Equal(t, "v2", vmOutput.ReturnMessage)
+}
+
+func TestFunctionRouter_IsActiveUsesRoutedVersion(t *testing.T) {
+	t.Parallel()
+
+	router, _ := NewFunctionRouter(&mock.EpochNotifierStub{})
+	_ = router.AddVersion(0, &mock.BuiltInFunctionStub{
+		IsActiveCalled: func() bool {
+			return false
+		},
+	})
+
+	assert.False(t, router.IsActive())
+}
+
+func TestFunctionRouter_CheckIsExecutableUsesRoutedVersion(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("not executable")
+	router, _ := NewFunctionRouter(&mock.EpochNotifierStub{})
+	_ = router.AddVersion(5, &mock.BuiltInFunctionStub{
+		CheckIsExecutableCalled: func(vmInput *vmcommon.ContractCallInput) error {
+			return expectedErr
+		},
+	})
+
+	err := router.CheckIsExecutable(&vmcommon.ContractCallInput{})
+	assert.Equal(t, ErrNoActiveFunctionVersion, err)
+
+	router.EpochConfirmed(5, 0)
+	err = router.CheckIsExecutable(&vmcommon.ContractCallInput{})
+	assert.Equal(t, expectedErr, err)
+}
+
+func TestFunctionRouter_SetNewGasConfigUpdatesAllVersions(t *testing.T) {
+	t.Parallel()
+
+	numCalls := 0
+	stub := &mock.BuiltInFunctionStub{
+		SetNewGasConfigCalled: func(gasCost *vmcommon.GasCost) {
+			numCalls++
+		},
+	}
+	router, _ := NewFunctionRouter(&mock.EpochNotifierStub{})
+	_ = router.AddVersion(0, stub)
+	_ = router.AddVersion(1, stub)
+
+	router.SetNewGasConfig(&vmcommon.GasCost{})
+	assert.Equal(t, 2, numCalls)
+}
+
+func TestFunctionRouter_SetPayableChecker(t *testing.T) {
+	t.Parallel()
+
+	router, _ := NewFunctionRouter(&mock.EpochNotifierStub{})
+	err := router.SetPayableChecker(nil)
+	assert.Equal(t, ErrNilPayableHandler, err)
+
+	transferFunc, _ := NewDCTTransferFunc(ArgsNewDCTTransfer{
+		Config: Config{
+			Marshalizer:           &mock.MarshalizerMock{},
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			ShardCoordinator:      &mock.ShardCoordinatorStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+		},
+		FuncGasCost: 10,
+	})
+	_ = router.AddVersion(0, &mock.BuiltInFunctionStub{})
+	_ = router.AddVersion(1, transferFunc)
+
+	payableChecker := &mock.PayableHandlerStub{}
+	err = router.SetPayableChecker(payableChecker)
+	assert.Nil(t, err)
+	assert.True(t, transferFunc.payableHandler == payableChecker)
+}
+
+func TestFunctionRouter_ProcessBuiltinFunctionPropagatesError(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("expected error")
+	router, _ := NewFunctionRouter(&mock.EpochNotifierStub{})
+	_ = router.AddVersion(0, &mock.BuiltInFunctionStub{
+		ProcessBuiltinFunctionCalled: func(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+			return nil, expectedErr
+		},
+	})
+
+	_, err := router.ProcessBuiltinFunction(nil, nil, &vmcommon.ContractCallInput{})
+	assert.Equal(t, expectedErr, err)
+}
@@ -0,0 +1,16 @@
+package builtInFunctions
+
+import vmcommon "github.com/Reshusk23/sr-vm-common-go"
+
+// addGasBreakdownToVMOutput adds the provided costs to the gas breakdown of the output, if the caller requested it.
+// The functions call it where the gas is charged, so the breakdown adds up to the consumed gas
+func addGasBreakdownToVMOutput(vmInput *vmcommon.ContractCallInput, vmOutput *vmcommon.VMOutput, breakdown vmcommon.GasBreakdown) {
+	if vmInput == nil || vmOutput == nil || !vmInput.RecordGasBreakdown {
+		return
+	}
+
+	if vmOutput.GasBreakdown == nil {
+		vmOutput.GasBreakdown = &vmcommon.GasBreakdown{}
+	}
+	vmOutput.GasBreakdown.Add(breakdown)
+}
@@ -0,0 +1,34 @@
+package builtInFunctions
+
+import (
+	"testing"
+
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddGasBreakdownToVMOutput(t *testing.T) {
+	t.Parallel()
+
+	t.Run("not requested should not set the breakdown", func(t *testing.T) {
+		t.Parallel()
+
+		vmOutput := &vmcommon.VMOutput{}
+		addGasBreakdownToVMOutput(&vmcommon.ContractCallInput{}, vmOutput, vmcommon.GasBreakdown{BaseCost: 1})
+		assert.Nil(t, vmOutput.GasBreakdown)
+
+		assert.NotPanics(t, func() {
+			addGasBreakdownToVMOutput(nil, vmOutput, vmcommon.GasBreakdown{BaseCost: 1})
+			addGasBreakdownToVMOutput(&vmcommon.ContractCallInput{RecordGasBreakdown: true}, nil, vmcommon.GasBreakdown{BaseCost: 1})
+		})
+	})
+	t.Run("requested should add the costs", func(t *testing.T) {
+		t.Parallel()
+
+		vmInput := &vmcommon.ContractCallInput{RecordGasBreakdown: true}
+		vmOutput := &vmcommon.VMOutput{}
+		addGasBreakdownToVMOutput(vmInput, vmOutput, vmcommon.GasBreakdown{TransferCost: 10})
+		addGasBreakdownToVMOutput(vmInput, vmOutput, vmcommon.GasBreakdown{PerByteCost: 5})
+		assert.Equal(t, &vmcommon.GasBreakdown{PerByteCost: 5, TransferCost: 10}, vmOutput.GasBreakdown)
+	})
+}
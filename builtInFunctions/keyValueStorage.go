@@ -8,13 +8,16 @@ import (
 
 	"github.com/Reshusk23/sr-me-core/core/check"
 	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/protectedkeys"
 )
 
 type saveKeyValueStorage struct {
 	baseAlwaysActiveHandler
-	gasConfig    vmcommon.BaseOperationCost
-	funcGasCost  uint64
-	mutExecution sync.RWMutex
+	gasConfig            vmcommon.BaseOperationCost
+	funcGasCost          uint64
+	protectedKeysHandler vmcommon.ProtectedKeysHandler
+	storageUsageTracker  vmcommon.StorageUsageTracker
+	mutExecution         sync.RWMutex
 }
 
 // NewSaveKeyValueStorageFunc returns the save key-value storage built in function
@@ -23,13 +26,43 @@ func NewSaveKeyValueStorageFunc(
 	funcGasCost uint64,
 ) (*saveKeyValueStorage, error) {
 	s := &saveKeyValueStorage{
-		gasConfig:   gasConfig,
-		funcGasCost: funcGasCost,
+		gasConfig:            gasConfig,
+		funcGasCost:          funcGasCost,
+		protectedKeysHandler: protectedkeys.NewProtectedKeysRegistry(),
+		storageUsageTracker:  &disabledStorageUsageTracker{},
 	}
 
 	return s, nil
 }
 
+// SetProtectedKeysHandler sets the handler deciding which other keys can not be written by the users, on top of the
+// keys starting with the protected key prefix
+func (k *saveKeyValueStorage) SetProtectedKeysHandler(protectedKeysHandler vmcommon.ProtectedKeysHandler) error {
+	if check.IfNil(protectedKeysHandler) {
+		return ErrNilProtectedKeysHandler
+	}
+
+	k.mutExecution.Lock()
+	k.protectedKeysHandler = protectedKeysHandler
+	k.mutExecution.Unlock()
+
+	return nil
+}
+
+// SetStorageUsageTracker sets the tracker metering the saved key-value pairs, the deleted or shrunk values releasing
+// their bytes
+func (k *saveKeyValueStorage) SetStorageUsageTracker(storageUsageTracker vmcommon.StorageUsageTracker) error {
+	if check.IfNil(storageUsageTracker) {
+		return ErrNilStorageUsageTracker
+	}
+
+	k.mutExecution.Lock()
+	k.storageUsageTracker = storageUsageTracker
+	k.mutExecution.Unlock()
+
+	return nil
+}
+
 // SetNewGasConfig is called whenever gas cost is changed
 func (k *saveKeyValueStorage) SetNewGasConfig(gasCost *vmcommon.GasCost) {
 	if gasCost == nil {
@@ -60,14 +93,14 @@ func (k *saveKeyValueStorage) ProcessBuiltinFunction(
 		GasRefund:    big.NewInt(0),
 	}
 
-	useGas := k.funcGasCost
+	gasBreakdown := vmcommon.GasBreakdown{BaseCost: k.funcGasCost}
 	for i := 0; i < len(input.Arguments); i += 2 {
 		key := input.Arguments[i]
 		value := input.Arguments[i+1]
 		length := uint64(len(value) + len(key))
-		useGas += length * k.gasConfig.PersistPerByte
+		gasBreakdown.PerByteCost += length * k.gasConfig.PersistPerByte
 
-		if !vmcommon.IsAllowedToSaveUnderKey(key) {
+		if !vmcommon.IsAllowedToSaveUnderKey(key) || k.protectedKeysHandler.IsProtectedKey(key) {
 			return nil, fmt.Errorf("%w it is not allowed to save under key %s", ErrOperationNotPermitted, key)
 		}
 
@@ -83,24 +116,38 @@ func (k *saveKeyValueStorage) ProcessBuiltinFunction(
 			lengthChange = lengthNewValue - lengthOldValue
 		}
 
-		useGas += k.gasConfig.StorePerByte * lengthChange
-		if input.GasProvided < useGas {
+		gasBreakdown.StorageCost += k.gasConfig.StorePerByte * lengthChange
+		if input.GasProvided < gasBreakdown.Total() {
 			return nil, ErrNotEnoughGas
 		}
 
 		//key-value test point
 
+		err = k.storageUsageTracker.TrackStorageUsage(acntDest, getKeyValueSize(key, value)-getKeyValueSize(key, oldValue))
+		if err != nil {
+			return nil, err
+		}
 		err = acntDest.AccountDataHandler().SaveKeyValue(key, value)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	vmOutput.GasRemaining -= useGas
+	vmOutput.GasRemaining -= gasBreakdown.Total()
+	addGasBreakdownToVMOutput(input, vmOutput, gasBreakdown)
 
 	return vmOutput, nil
 }
 
+// getKeyValueSize returns the number of bytes stored for the key-value pair, an empty value deleting the key
+func getKeyValueSize(key []byte, value []byte) int64 {
+	if len(value) == 0 {
+		return 0
+	}
+
+	return int64(len(key) + len(value))
+}
+
 func checkArgumentsForSaveKeyValue(acntDst vmcommon.UserAccountHandler, input *vmcommon.ContractCallInput) error {
 	if input == nil {
 		return ErrNilVmInput
@@ -111,8 +158,9 @@ func checkArgumentsForSaveKeyValue(acntDst vmcommon.UserAccountHandler, input *v
 	if len(input.Arguments)%2 != 0 {
 		return ErrInvalidArguments
 	}
-	if input.CallValue.Cmp(zero) != 0 {
-		return ErrBuiltInFunctionCalledWithValue
+	err := checkCallValue(vmcommon.CallValueForbidden, input.CallValue)
+	if err != nil {
+		return err
 	}
 	if check.IfNil(acntDst) {
 		return ErrNilSCDestAccount
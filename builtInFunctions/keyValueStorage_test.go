@@ -10,6 +10,7 @@ import (
 	"github.com/Reshusk23/sr-me-core/core/check"
 	vmcommon "github.com/Reshusk23/sr-vm-common-go"
 	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/Reshusk23/sr-vm-common-go/protectedkeys"
 	"github.com/stretchr/testify/require"
 )
 
@@ -188,3 +189,154 @@ func TestSaveKeyValue_ProcessBuiltinFunctionMultipleKeys(t *testing.T) {
 	_, err = skv.ProcessBuiltinFunction(acc, acc, vmInput)
 	require.Equal(t, err, ErrNotEnoughGas)
 }
+
+func TestSaveKeyValue_ProcessBuiltinFunctionGasBreakdown(t *testing.T) {
+	t.Parallel()
+
+	gasConfig := vmcommon.BaseOperationCost{
+		StorePerByte:   3,
+		PersistPerByte: 2,
+	}
+	skv, _ := NewSaveKeyValueStorageFunc(gasConfig, 10)
+
+	addr := []byte("addr")
+	acc := mock.NewUserAccount(addr)
+	_ = acc.AccountDataHandler().SaveKeyValue([]byte("key"), []byte("val"))
+	vmInput := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  addr,
+			GasProvided: 100,
+			CallValue:   big.NewInt(0),
+			Arguments:   [][]byte{[]byte("key"), []byte("value")},
+		},
+		RecipientAddr: addr,
+	}
+
+	vmOutput, err := skv.ProcessBuiltinFunction(acc, acc, vmInput)
+	require.Nil(t, err)
+	require.Nil(t, vmOutput.GasBreakdown)
+
+	vmInput.RecordGasBreakdown = true
+	vmInput.Arguments = [][]byte{[]byte("key"), []byte("value2"), []byte("k2"), []byte("v2")}
+	vmOutput, err = skv.ProcessBuiltinFunction(acc, acc, vmInput)
+	require.Nil(t, err)
+	expectedBreakdown := &vmcommon.GasBreakdown{
+		BaseCost:    10,
+		PerByteCost: 2 * (9 + 4),
+		StorageCost: 3 * (1 + 2),
+	}
+	require.Equal(t, expectedBreakdown, vmOutput.GasBreakdown)
+	require.Equal(t, vmInput.GasProvided-expectedBreakdown.Total(), vmOutput.GasRemaining)
+}
+
+func TestSaveKeyValue_SetProtectedKeysHandler(t *testing.T) {
+	t.Parallel()
+
+	skv, _ := NewSaveKeyValueStorageFunc(vmcommon.BaseOperationCost{}, 1)
+	err := skv.SetProtectedKeysHandler(nil)
+	require.Equal(t, ErrNilProtectedKeysHandler, err)
+
+	registry := protectedkeys.NewProtectedKeysRegistry()
+	_ = registry.RegisterPrefix([]byte("chain"))
+	err = skv.SetProtectedKeysHandler(registry)
+	require.Nil(t, err)
+
+	addr := []byte("addr")
+	acc := mock.NewUserAccount(addr)
+	vmInput := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  addr,
+			GasProvided: 50,
+			CallValue:   big.NewInt(0),
+			Arguments:   [][]byte{[]byte("chainKey"), []byte("value")},
+		},
+		RecipientAddr: addr,
+	}
+
+	_, err = skv.ProcessBuiltinFunction(acc, acc, vmInput)
+	require.True(t, errors.Is(err, ErrOperationNotPermitted))
+
+	vmInput.Arguments = [][]byte{[]byte(protectedkeys.DCTNFTLatestNoncePrefix + "TKN"), []byte("value")}
+	_, err = skv.ProcessBuiltinFunction(acc, acc, vmInput)
+	require.True(t, errors.Is(err, ErrOperationNotPermitted))
+
+	vmInput.Arguments = [][]byte{[]byte("key"), []byte("value")}
+	_, err = skv.ProcessBuiltinFunction(acc, acc, vmInput)
+	require.Nil(t, err)
+}
+
+func TestSaveKeyValue_ProtectedKeyPrefixAlwaysEnforced(t *testing.T) {
+	t.Parallel()
+
+	skv, _ := NewSaveKeyValueStorageFunc(vmcommon.BaseOperationCost{}, 1)
+	err := skv.SetProtectedKeysHandler(&mock.ProtectedKeysHandlerStub{
+		IsProtectedKeyCalled: func(key []byte) bool {
+			return false
+		},
+	})
+	require.Nil(t, err)
+
+	addr := []byte("addr")
+	acc := mock.NewUserAccount(addr)
+	vmInput := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  addr,
+			GasProvided: 50,
+			CallValue:   big.NewInt(0),
+			Arguments:   [][]byte{[]byte(core.ProtectedKeyPrefix + "key"), []byte("value")},
+		},
+		RecipientAddr: addr,
+	}
+
+	_, err = skv.ProcessBuiltinFunction(acc, acc, vmInput)
+	require.True(t, errors.Is(err, ErrOperationNotPermitted))
+}
+
+func TestSaveKeyValue_StorageUsageTracker(t *testing.T) {
+	t.Parallel()
+
+	skv, _ := NewSaveKeyValueStorageFunc(vmcommon.BaseOperationCost{}, 1)
+	require.Equal(t, ErrNilStorageUsageTracker, skv.SetStorageUsageTracker(nil))
+
+	tracker := NewStorageUsageTracker(vmcommon.StorageUsageConfig{MaxBytesPerAccount: 20})
+	require.Nil(t, skv.SetStorageUsageTracker(tracker))
+
+	addr := []byte("addr")
+	acc := mock.NewUserAccount(addr)
+	createInput := func(arguments ...[]byte) *vmcommon.ContractCallInput {
+		return &vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallerAddr:  addr,
+				GasProvided: 50,
+				CallValue:   big.NewInt(0),
+				Arguments:   arguments,
+			},
+			RecipientAddr: addr,
+		}
+	}
+
+	_, err := skv.ProcessBuiltinFunction(acc, acc, createInput([]byte("key"), []byte("value"), []byte("k2"), []byte("v2")))
+	require.Nil(t, err)
+	require.Equal(t, uint64(12), tracker.GetStorageUsage(acc))
+
+	_, err = skv.ProcessBuiltinFunction(acc, acc, createInput([]byte("key"), []byte("a longer value")))
+	require.True(t, errors.Is(err, ErrStorageLimitExceeded))
+	require.Equal(t, []byte("value"), acc.Storage["key"])
+
+	_, err = skv.ProcessBuiltinFunction(acc, acc, createInput([]byte("k2"), nil, []byte("key"), []byte("longer")))
+	require.Nil(t, err)
+	require.Equal(t, uint64(9), tracker.GetStorageUsage(acc))
+}
+
+func TestSaveKeyValue_AccountEnforcingProtectedKeys(t *testing.T) {
+	t.Parallel()
+
+	acc := mock.NewUserAccount([]byte("addr"))
+	acc.ProtectedKeys = protectedkeys.NewProtectedKeysRegistry()
+
+	err := acc.SaveKeyValue([]byte(protectedkeys.DCTPrefix+"TKN"), []byte("value"))
+	require.Equal(t, mock.ErrOperationNotPermitted, err)
+
+	err = acc.SaveKeyValue([]byte("key"), []byte("value"))
+	require.Nil(t, err)
+}
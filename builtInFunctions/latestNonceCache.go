@@ -0,0 +1,147 @@
+package builtInFunctions
+
+import (
+	"sync"
+
+	"github.com/Reshusk23/sr-me-core/core/check"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+)
+
+type latestNonceKey struct {
+	address string
+	tokenID string
+}
+
+// latestNonceCache keeps the latest NFT nonces read or written during the current round. It is emptied on each
+// new round and on each revert of the accounts adapter it is bound to, see NewAccountsAdapterWithLatestNonceCache
+type latestNonceCache struct {
+	mutNonces sync.RWMutex
+	nonces    map[latestNonceKey]uint64
+}
+
+// NewLatestNonceCache creates a new latest nonce cache which is emptied on each confirmed round
+func NewLatestNonceCache(roundNotifier vmcommon.RoundNotifier) (*latestNonceCache, error) {
+	if check.IfNil(roundNotifier) {
+		return nil, ErrNilRoundNotifier
+	}
+
+	cache := &latestNonceCache{
+		nonces: make(map[latestNonceKey]uint64),
+	}
+	roundNotifier.RegisterRoundHandler(cache)
+
+	return cache, nil
+}
+
+// Get returns the cached latest nonce of the token for the given address
+func (cache *latestNonceCache) Get(address []byte, tokenID []byte) (uint64, bool) {
+	cache.mutNonces.RLock()
+	defer cache.mutNonces.RUnlock()
+
+	nonce, found := cache.nonces[latestNonceKey{address: string(address), tokenID: string(tokenID)}]
+	return nonce, found
+}
+
+// Put saves the latest nonce of the token for the given address
+func (cache *latestNonceCache) Put(address []byte, tokenID []byte, nonce uint64) {
+	cache.mutNonces.Lock()
+	cache.nonces[latestNonceKey{address: string(address), tokenID: string(tokenID)}] = nonce
+	cache.mutNonces.Unlock()
+}
+
+// Remove evicts the latest nonce of the token for the given address
+func (cache *latestNonceCache) Remove(address []byte, tokenID []byte) {
+	cache.mutNonces.Lock()
+	delete(cache.nonces, latestNonceKey{address: string(address), tokenID: string(tokenID)})
+	cache.mutNonces.Unlock()
+}
+
+// Clear evicts all the cached nonces
+func (cache *latestNonceCache) Clear() {
+	cache.mutNonces.Lock()
+	cache.nonces = make(map[latestNonceKey]uint64)
+	cache.mutNonces.Unlock()
+}
+
+// RoundConfirmed is called whenever a new round is started and empties the cache
+func (cache *latestNonceCache) RoundConfirmed(_ uint64, _ uint64) {
+	cache.Clear()
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (cache *latestNonceCache) IsInterfaceNil() bool {
+	return cache == nil
+}
+
+// accountsAdapterWithLatestNonceCache empties the latest nonce cache whenever the wrapped accounts adapter reverts
+// its state or removes an account, as the cached nonces might be ahead of the ones left in the data tries. A commit
+// keeps the cache, the committed nonces being the cached ones
+type accountsAdapterWithLatestNonceCache struct {
+	vmcommon.AccountsAdapter
+	nonceCache vmcommon.LatestNonceCache
+}
+
+// NewAccountsAdapterWithLatestNonceCache binds the latest nonce cache to the accounts adapter. All the reverts have
+// to go through the returned adapter, otherwise the cache can return nonces which are no longer saved
+func NewAccountsAdapterWithLatestNonceCache(
+	accounts vmcommon.AccountsAdapter,
+	nonceCache vmcommon.LatestNonceCache,
+) (*accountsAdapterWithLatestNonceCache, error) {
+	if check.IfNil(accounts) {
+		return nil, ErrNilAccountsAdapter
+	}
+	if check.IfNil(nonceCache) {
+		return nil, ErrNilLatestNonceCache
+	}
+
+	return &accountsAdapterWithLatestNonceCache{
+		AccountsAdapter: accounts,
+		nonceCache:      nonceCache,
+	}, nil
+}
+
+// RemoveAccount removes the account and empties the cache
+func (adapter *accountsAdapterWithLatestNonceCache) RemoveAccount(address []byte) error {
+	adapter.nonceCache.Clear()
+	return adapter.AccountsAdapter.RemoveAccount(address)
+}
+
+// RevertToSnapshot reverts the state and empties the cache
+func (adapter *accountsAdapterWithLatestNonceCache) RevertToSnapshot(snapshot int) error {
+	adapter.nonceCache.Clear()
+	return adapter.AccountsAdapter.RevertToSnapshot(snapshot)
+}
+
+func (adapter *accountsAdapterWithLatestNonceCache) isBoundTo(cache interface{}) bool {
+	return adapter.nonceCache == cache || isCacheBoundToAccounts(adapter.AccountsAdapter, cache)
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (adapter *accountsAdapterWithLatestNonceCache) IsInterfaceNil() bool {
+	return adapter == nil
+}
+
+type disabledLatestNonceCache struct {
+}
+
+// Get returns false as nothing is cached
+func (d *disabledLatestNonceCache) Get(_ []byte, _ []byte) (uint64, bool) {
+	return 0, false
+}
+
+// Put does nothing
+func (d *disabledLatestNonceCache) Put(_ []byte, _ []byte, _ uint64) {
+}
+
+// Remove does nothing
+func (d *disabledLatestNonceCache) Remove(_ []byte, _ []byte) {
+}
+
+// Clear does nothing
+func (d *disabledLatestNonceCache) Clear() {
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (d *disabledLatestNonceCache) IsInterfaceNil() bool {
+	return d == nil
+}
@@ -0,0 +1,116 @@
+package builtInFunctions
+
+import (
+	"testing"
+
+	"github.com/Reshusk23/sr-me-core/core/check"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLatestNonceCache(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil round notifier should error", func(t *testing.T) {
+		t.Parallel()
+
+		cache, err := NewLatestNonceCache(nil)
+		assert.True(t, check.IfNil(cache))
+		assert.Equal(t, ErrNilRoundNotifier, err)
+	})
+	t.Run("should work and register", func(t *testing.T) {
+		t.Parallel()
+
+		var registered vmcommon.RoundSubscriberHandler
+		cache, err := NewLatestNonceCache(&mock.RoundNotifierStub{
+			RegisterRoundHandlerCalled: func(handler vmcommon.RoundSubscriberHandler) {
+				registered = handler
+			},
+		})
+		assert.False(t, check.IfNil(cache))
+		assert.Nil(t, err)
+		assert.True(t, registered == cache)
+	})
+}
+
+func TestLatestNonceCache_PutGetRemove(t *testing.T) {
+	t.Parallel()
+
+	cache, _ := NewLatestNonceCache(&mock.RoundNotifierStub{})
+
+	_, found := cache.Get([]byte("addr"), []byte("token"))
+	assert.False(t, found)
+
+	cache.Put([]byte("addr"), []byte("token"), 7)
+	nonce, found := cache.Get([]byte("addr"), []byte("token"))
+	assert.True(t, found)
+	assert.Equal(t, uint64(7), nonce)
+
+	_, found = cache.Get([]byte("addrt"), []byte("oken"))
+	assert.False(t, found)
+
+	cache.Remove([]byte("addr"), []byte("token"))
+	_, found = cache.Get([]byte("addr"), []byte("token"))
+	assert.False(t, found)
+}
+
+func TestLatestNonceCache_RoundConfirmedShouldClear(t *testing.T) {
+	t.Parallel()
+
+	cache, _ := NewLatestNonceCache(&mock.RoundNotifierStub{})
+	cache.Put([]byte("addr"), []byte("token"), 7)
+
+	cache.RoundConfirmed(1, 0)
+	_, found := cache.Get([]byte("addr"), []byte("token"))
+	assert.False(t, found)
+}
+
+func TestAccountsAdapterWithLatestNonceCache(t *testing.T) {
+	t.Parallel()
+
+	nonceCache, _ := NewLatestNonceCache(&mock.RoundNotifierStub{})
+	adapter, err := NewAccountsAdapterWithLatestNonceCache(nil, nonceCache)
+	assert.True(t, check.IfNil(adapter))
+	assert.ErrorIs(t, err, ErrNilAccountsAdapter)
+
+	adapter, err = NewAccountsAdapterWithLatestNonceCache(&mock.AccountsStub{}, nil)
+	assert.True(t, check.IfNil(adapter))
+	assert.Equal(t, ErrNilLatestNonceCache, err)
+
+	accountCache, _ := NewAccountCache(10, &mock.RoundNotifierStub{})
+	accounts, _ := NewAccountsAdapterWithAccountCache(&mock.AccountsStub{}, accountCache)
+	adapter, err = NewAccountsAdapterWithLatestNonceCache(accounts, nonceCache)
+	assert.False(t, check.IfNil(adapter))
+	assert.Nil(t, err)
+	assert.True(t, isCacheBoundToAccounts(adapter, nonceCache))
+	assert.True(t, isCacheBoundToAccounts(adapter, accountCache))
+	assert.False(t, isCacheBoundToAccounts(adapter, &disabledLatestNonceCache{}))
+
+	nonceCache.Put([]byte("addr"), []byte("token"), 7)
+	_, _ = adapter.Commit()
+	_, found := nonceCache.Get([]byte("addr"), []byte("token"))
+	assert.True(t, found)
+
+	_ = adapter.RevertToSnapshot(0)
+	_, found = nonceCache.Get([]byte("addr"), []byte("token"))
+	assert.False(t, found)
+
+	nonceCache.Put([]byte("addr"), []byte("token"), 7)
+	_ = adapter.RemoveAccount([]byte("addr"))
+	_, found = nonceCache.Get([]byte("addr"), []byte("token"))
+	assert.False(t, found)
+}
+
+func TestDisabledLatestNonceCache(t *testing.T) {
+	t.Parallel()
+
+	cache := &disabledLatestNonceCache{}
+	cache.Put([]byte("addr"), []byte("token"), 7)
+	_, found := cache.Get([]byte("addr"), []byte("token"))
+	assert.False(t, found)
+
+	cache.Remove([]byte("addr"), []byte("token"))
+	cache.Clear()
+	assert.False(t, check.IfNil(cache))
+}
@@ -0,0 +1,120 @@
+package builtInFunctions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Reshusk23/sr-me-core/core"
+	"github.com/Reshusk23/sr-me-core/data/vm"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+)
+
+// urisStartIndexes holds, for the functions receiving URIs, the index of the first URI argument. Once the NFT max
+// supply is enabled, the max supply argument preceding the URIs of the creates is counted as one, keeping the limit
+// conservative
+var urisStartIndexes = map[string]int{
+	core.BuiltInFunctionDCTNFTCreate:             6,
+	vmcommon.BuiltInFunctionDCTNFTCreateOnBehalf: 7,
+	core.BuiltInFunctionDCTNFTAddURI:             2,
+	vmcommon.BuiltInFunctionDCTSetNewURIs:        2,
+}
+
+// limitsFunction wraps a built-in function and rejects the inputs exceeding the configured hard limits before the
+// wrapped function computes any gas or allocates any buffer for them
+type limitsFunction struct {
+	baseFunctionWrapper
+	name   string
+	limits vmcommon.LimitsConfig
+}
+
+func newLimitsFunction(name string, function vmcommon.BuiltinFunction, limits vmcommon.LimitsConfig) *limitsFunction {
+	return &limitsFunction{
+		baseFunctionWrapper: baseFunctionWrapper{function: function},
+		name:                name,
+		limits:              limits,
+	}
+}
+
+// ProcessBuiltinFunction checks the input against the limits and then calls the wrapped function
+func (lf *limitsFunction) ProcessBuiltinFunction(
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	return lf.processBuiltinFunctionWithContext(context.Background(), acntSnd, acntDst, vmInput)
+}
+
+func (lf *limitsFunction) processBuiltinFunctionWithContext(
+	ctx context.Context,
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	if vmInput != nil {
+		err := lf.checkLimits(vmInput)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return callWithContext(ctx, lf.function, acntSnd, acntDst, vmInput)
+}
+
+// CheckIsExecutable checks the input against the limits and then forwards the validation to the wrapped function
+func (lf *limitsFunction) CheckIsExecutable(vmInput *vmcommon.ContractCallInput) error {
+	if vmInput != nil {
+		err := lf.checkLimits(vmInput)
+		if err != nil {
+			return err
+		}
+	}
+
+	return checkIsExecutable(lf.function, vmInput)
+}
+
+func (lf *limitsFunction) checkLimits(vmInput *vmcommon.ContractCallInput) error {
+	numArguments := len(vmInput.Arguments)
+	if lf.limits.MaxNumArguments > 0 && numArguments > int(lf.limits.MaxNumArguments) {
+		return fmt.Errorf("%w, %d arguments, maximum is %d", ErrTooManyArguments, numArguments, lf.limits.MaxNumArguments)
+	}
+
+	dataSize := uint64(0)
+	for index, argument := range vmInput.Arguments {
+		if lf.limits.MaxArgumentSize > 0 && len(argument) > int(lf.limits.MaxArgumentSize) {
+			return fmt.Errorf("%w, argument %d has %d bytes, maximum is %d", ErrArgumentTooLarge, index, len(argument), lf.limits.MaxArgumentSize)
+		}
+		dataSize += uint64(len(argument))
+	}
+	if lf.limits.MaxDataSize > 0 && dataSize > lf.limits.MaxDataSize {
+		return fmt.Errorf("%w, %d bytes, maximum is %d", ErrDataTooLarge, dataSize, lf.limits.MaxDataSize)
+	}
+
+	numURIs := lf.computeNumURIs(vmInput)
+	if lf.limits.MaxNumURIs > 0 && numURIs > int(lf.limits.MaxNumURIs) {
+		return fmt.Errorf("%w, %d URIs, maximum is %d", ErrURIsLimitExceeded, numURIs, lf.limits.MaxNumURIs)
+	}
+
+	return nil
+}
+
+func (lf *limitsFunction) computeNumURIs(vmInput *vmcommon.ContractCallInput) int {
+	urisStartIndex, ok := urisStartIndexes[lf.name]
+	if !ok {
+		return 0
+	}
+
+	urisEndIndex := len(vmInput.Arguments)
+	isCreate := lf.name == core.BuiltInFunctionDCTNFTCreate || lf.name == vmcommon.BuiltInFunctionDCTNFTCreateOnBehalf
+	if isCreate && vmInput.CallType == vm.ExecOnDestByCaller {
+		// the last argument is the address of the account holding the roles
+		urisEndIndex--
+	}
+	if urisEndIndex <= urisStartIndex {
+		return 0
+	}
+
+	return urisEndIndex - urisStartIndex
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (lf *limitsFunction) IsInterfaceNil() bool {
+	return lf == nil
+}
@@ -0,0 +1,157 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Reshusk23/sr-me-core/core"
+	"github.com/Reshusk23/sr-me-core/data/vm"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createLimitsFunction(name string, limits vmcommon.LimitsConfig, wasCalled *bool) *limitsFunction {
+	return newLimitsFunction(name, &mock.BuiltInFunctionStub{
+		ProcessBuiltinFunctionCalled: func(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+			*wasCalled = true
+			return &vmcommon.VMOutput{}, nil
+		},
+	}, limits)
+}
+
+func createInputWithArguments(arguments ...[]byte) *vmcommon.ContractCallInput {
+	return &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			Arguments: arguments,
+		},
+	}
+}
+
+func TestLimitsFunction_ProcessBuiltinFunction(t *testing.T) {
+	t.Parallel()
+
+	t.Run("too many arguments should err before calling the function", func(t *testing.T) {
+		t.Parallel()
+
+		wasCalled := false
+		lf := createLimitsFunction("key", vmcommon.LimitsConfig{MaxNumArguments: 2}, &wasCalled)
+		_, err := lf.ProcessBuiltinFunction(nil, nil, createInputWithArguments([]byte("a"), []byte("b"), []byte("c")))
+		assert.ErrorIs(t, err, ErrTooManyArguments)
+		assert.False(t, wasCalled)
+	})
+	t.Run("argument too large should err", func(t *testing.T) {
+		t.Parallel()
+
+		wasCalled := false
+		lf := createLimitsFunction("key", vmcommon.LimitsConfig{MaxArgumentSize: 3}, &wasCalled)
+		_, err := lf.ProcessBuiltinFunction(nil, nil, createInputWithArguments([]byte("a"), bytes.Repeat([]byte{1}, 4)))
+		assert.ErrorIs(t, err, ErrArgumentTooLarge)
+		assert.Contains(t, err.Error(), "argument 1")
+		assert.False(t, wasCalled)
+	})
+	t.Run("data too large should err", func(t *testing.T) {
+		t.Parallel()
+
+		wasCalled := false
+		lf := createLimitsFunction("key", vmcommon.LimitsConfig{MaxDataSize: 5}, &wasCalled)
+		_, err := lf.ProcessBuiltinFunction(nil, nil, createInputWithArguments([]byte("abc"), []byte("def")))
+		assert.ErrorIs(t, err, ErrDataTooLarge)
+		assert.False(t, wasCalled)
+	})
+	t.Run("too many URIs should err", func(t *testing.T) {
+		t.Parallel()
+
+		wasCalled := false
+		lf := createLimitsFunction(core.BuiltInFunctionDCTNFTAddURI, vmcommon.LimitsConfig{MaxNumURIs: 1}, &wasCalled)
+		_, err := lf.ProcessBuiltinFunction(nil, nil, createInputWithArguments([]byte("token"), []byte{1}, []byte("uri1"), []byte("uri2")))
+		assert.ErrorIs(t, err, ErrURIsLimitExceeded)
+		assert.False(t, wasCalled)
+	})
+	t.Run("too many new URIs should err", func(t *testing.T) {
+		t.Parallel()
+
+		wasCalled := false
+		lf := createLimitsFunction(vmcommon.BuiltInFunctionDCTSetNewURIs, vmcommon.LimitsConfig{MaxNumURIs: 1}, &wasCalled)
+		input := createInputWithArguments([]byte("token"), []byte{1}, []byte("uri1"), []byte("uri2"))
+		input.CallType = vm.ExecOnDestByCaller
+		_, err := lf.ProcessBuiltinFunction(nil, nil, input)
+		assert.ErrorIs(t, err, ErrURIsLimitExceeded)
+		assert.False(t, wasCalled)
+	})
+	t.Run("roles holder address should not be counted as URI", func(t *testing.T) {
+		t.Parallel()
+
+		wasCalled := false
+		lf := createLimitsFunction(core.BuiltInFunctionDCTNFTCreate, vmcommon.LimitsConfig{MaxNumURIs: 1}, &wasCalled)
+		input := createInputWithArguments([]byte("token"), []byte{1}, []byte("name"), []byte{1}, []byte("hash"), []byte("attributes"), []byte("uri"), []byte("address"))
+		input.CallType = vm.ExecOnDestByCaller
+		_, err := lf.ProcessBuiltinFunction(nil, nil, input)
+		require.Nil(t, err)
+		assert.True(t, wasCalled)
+	})
+	t.Run("URIs are not counted for other functions", func(t *testing.T) {
+		t.Parallel()
+
+		wasCalled := false
+		lf := createLimitsFunction(core.BuiltInFunctionDCTTransfer, vmcommon.LimitsConfig{MaxNumURIs: 1}, &wasCalled)
+		_, err := lf.ProcessBuiltinFunction(nil, nil, createInputWithArguments([]byte("a"), []byte("b"), []byte("c")))
+		require.Nil(t, err)
+		assert.True(t, wasCalled)
+	})
+	t.Run("input within limits should call the function", func(t *testing.T) {
+		t.Parallel()
+
+		wasCalled := false
+		limits := vmcommon.LimitsConfig{
+			MaxNumArguments: 3,
+			MaxArgumentSize: 4,
+			MaxDataSize:     8,
+			MaxNumURIs:      1,
+		}
+		lf := createLimitsFunction(core.BuiltInFunctionDCTNFTAddURI, limits, &wasCalled)
+		_, err := lf.ProcessBuiltinFunction(nil, nil, createInputWithArguments([]byte("tkn"), []byte{1}, []byte("uri")))
+		require.Nil(t, err)
+		assert.True(t, wasCalled)
+	})
+}
+
+func TestLimitsFunction_CheckIsExecutable(t *testing.T) {
+	t.Parallel()
+
+	numCalls := 0
+	lf := newLimitsFunction("key", &mock.BuiltInFunctionStub{
+		CheckIsExecutableCalled: func(vmInput *vmcommon.ContractCallInput) error {
+			numCalls++
+			return nil
+		},
+	}, vmcommon.LimitsConfig{MaxNumArguments: 2})
+
+	err := lf.CheckIsExecutable(createInputWithArguments([]byte("a"), []byte("b"), []byte("c")))
+	assert.ErrorIs(t, err, ErrTooManyArguments)
+	assert.Equal(t, 0, numCalls)
+
+	err = lf.CheckIsExecutable(createInputWithArguments([]byte("a"), []byte("b")))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, numCalls)
+}
+
+func TestBuiltInFunctionContainer_SetLimitsConfig(t *testing.T) {
+	t.Parallel()
+
+	c := NewBuiltInFunctionContainer()
+	_ = c.Add("key", createFunctionStubReturning("message"))
+
+	function, _ := c.Get("key")
+	_, ok := unwrapExecutionGuard(function).(*limitsFunction)
+	assert.False(t, ok)
+
+	c.SetLimitsConfig(vmcommon.LimitsConfig{MaxNumArguments: 1})
+	c.SetUserErrorsAsVMOutputs(true)
+	function, _ = c.Get("key")
+	vmOutput, err := function.ProcessBuiltinFunction(nil, nil, createInputWithArguments([]byte("a"), []byte("b")))
+	assert.Nil(t, err)
+	assert.Equal(t, vmcommon.UserError, vmOutput.ReturnCode)
+	assert.Contains(t, vmOutput.ReturnMessage, ErrTooManyArguments.Error())
+}
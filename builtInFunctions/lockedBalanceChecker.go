@@ -0,0 +1,61 @@
+package builtInFunctions
+
+import (
+	"math/big"
+
+	"github.com/Reshusk23/sr-me-core/data/dct"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+)
+
+// lockedBalanceChecker is embedded by the built-in functions spending fungible balances, which must not touch the
+// portion of the balance locked by a transfer and lock before its unlock epoch. The current epoch is read from the
+// mandatory enable epochs handler, so it never depends on an optional epoch notifier and follows the pinned epoch of
+// a replayed call
+type lockedBalanceChecker struct {
+	enableEpochsHandler vmcommon.EnableEpochsHandler
+}
+
+func (lbc *lockedBalanceChecker) getCurrentEpoch() uint32 {
+	return lbc.enableEpochsHandler.GetCurrentEpoch()
+}
+
+// subFromDCTBalance debits the fungible balance of the account, refusing to spend its locked portion
+func (lbc *lockedBalanceChecker) subFromDCTBalance(
+	userAcnt vmcommon.UserAccountHandler,
+	key []byte,
+	value *big.Int,
+	marshaller vmcommon.Marshalizer,
+	globalSettingsHandler vmcommon.DCTGlobalSettingsHandler,
+	systemAddresses vmcommon.SystemAddresses,
+	isReturnWithError bool,
+) error {
+	negValue := getBigInt().Neg(value)
+	defer putBigInt(negValue)
+
+	return updateDCTBalance(userAcnt, key, negValue, marshaller, globalSettingsHandler, systemAddresses, isReturnWithError, lbc)
+}
+
+// checkBalanceIsNotLocked is called after a debit and returns ErrDCTBalanceIsLocked if the remaining balance is lower
+// than its locked portion. The expired locks are cleared, so that a spent balance is not kept only for its metadata
+func (lbc *lockedBalanceChecker) checkBalanceIsNotLocked(dctData *dct.DCToken, isReturnWithError bool) error {
+	if isReturnWithError {
+		return nil
+	}
+
+	dctUserMetadata := DCTUserMetadataFromBytes(dctData.Properties)
+	if !dctUserMetadata.IsLocked() {
+		return nil
+	}
+
+	currentEpoch := lbc.getCurrentEpoch()
+	numLocks := len(dctUserMetadata.Locks)
+	dctUserMetadata.removeExpiredLocks(currentEpoch)
+	if len(dctUserMetadata.Locks) != numLocks {
+		dctData.Properties = dctUserMetadata.ToBytes()
+	}
+	if dctData.Value.Cmp(dctUserMetadata.LockedValueAt(currentEpoch)) < 0 {
+		return ErrDCTBalanceIsLocked
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package builtInFunctions
+
+import (
+	"context"
+
+	"github.com/Reshusk23/sr-me-core/core"
+	"github.com/Reshusk23/sr-me-core/core/check"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+)
+
+// addressTopicsIndexes holds, for each log entry identifier, the indexes of the topics holding addresses. The first
+// address of an entry is held by its Address field, which is never converted
+var addressTopicsIndexes = map[string][]int{
+	core.BuiltInFunctionDCTTransfer:             {3},
+	core.BuiltInFunctionDCTNFTTransfer:          {3},
+	core.BuiltInFunctionMultiDCTNFTTransfer:     {3},
+	vmcommon.DCTTransferNativeValueIdentifier:   {3},
+	core.BuiltInFunctionDCTFreeze:               {3},
+	core.BuiltInFunctionDCTUnFreeze:             {3},
+	core.BuiltInFunctionDCTWipe:                 {3},
+	vmcommon.BuiltInFunctionDCTModifyCreator:    {3, 4},
+	vmcommon.BuiltInFunctionDCTApprove:          {3},
+	vmcommon.BuiltInFunctionDCTTransferFrom:     {3, 4},
+	vmcommon.BuiltInFunctionDCTSweepDormant:     {3},
+	vmcommon.BuiltInFunctionDCTRentNFT:          {3},
+	vmcommon.BuiltInFunctionDCTReclaimRentedNFT: {3},
+}
+
+// logAddressFunction wraps a built-in function and converts the addresses carried by the topics of its log entries
+// with the configured converter, so the indexers receive them already encoded
+type logAddressFunction struct {
+	baseFunctionWrapper
+	format    vmcommon.LogAddressFormat
+	converter core.PubkeyConverter
+}
+
+func newLogAddressFunction(function vmcommon.BuiltinFunction, format vmcommon.LogAddressFormat, converter core.PubkeyConverter) *logAddressFunction {
+	return &logAddressFunction{
+		baseFunctionWrapper: baseFunctionWrapper{function: function},
+		format:              format,
+		converter:           converter,
+	}
+}
+
+// ProcessBuiltinFunction calls the wrapped function and converts the addresses of the output log entries
+func (laf *logAddressFunction) ProcessBuiltinFunction(
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	return laf.processBuiltinFunctionWithContext(context.Background(), acntSnd, acntDst, vmInput)
+}
+
+func (laf *logAddressFunction) processBuiltinFunctionWithContext(
+	ctx context.Context,
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	vmOutput, err := callWithContext(ctx, laf.function, acntSnd, acntDst, vmInput)
+	if err != nil || vmOutput == nil {
+		return vmOutput, err
+	}
+
+	for _, logEntry := range vmOutput.Logs {
+		if logEntry != nil {
+			laf.convertAddresses(logEntry)
+		}
+	}
+
+	return vmOutput, nil
+}
+
+func (laf *logAddressFunction) convertAddresses(logEntry *vmcommon.LogEntry) {
+	indexes, ok := addressTopicsIndexes[string(logEntry.Identifier)]
+	if !ok {
+		return
+	}
+
+	encodedTopics := make([][]byte, len(logEntry.Topics))
+	copy(encodedTopics, logEntry.Topics)
+	for _, index := range indexes {
+		// the empty or malformed addresses are left as they are
+		if index >= len(encodedTopics) || len(encodedTopics[index]) != laf.converter.Len() {
+			continue
+		}
+
+		encodedTopics[index] = []byte(laf.converter.Encode(encodedTopics[index]))
+	}
+
+	if laf.format == vmcommon.LogAddressRawAndEncoded {
+		logEntry.EncodedTopics = encodedTopics
+		return
+	}
+	logEntry.Topics = encodedTopics
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (laf *logAddressFunction) IsInterfaceNil() bool {
+	return laf == nil
+}
+
+func checkLogAddressFormat(format vmcommon.LogAddressFormat, converter core.PubkeyConverter) error {
+	if !format.IsValid() {
+		return ErrInvalidLogAddressFormat
+	}
+	if format.HasEncodedAddresses() && check.IfNil(converter) {
+		return ErrNilPubkeyConverter
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Reshusk23/sr-me-core/core"
+	"github.com/Reshusk23/sr-me-core/core/pubkeyConverter"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogAddressFunction_ProcessBuiltinFunction(t *testing.T) {
+	t.Parallel()
+
+	converter, err := pubkeyConverter.NewBech32PubkeyConverter(32, log)
+	require.Nil(t, err)
+
+	sender := bytes.Repeat([]byte{1}, 32)
+	receiver := bytes.Repeat([]byte{2}, 32)
+	encodedReceiver := []byte(converter.Encode(receiver))
+	createOutput := func() *vmcommon.VMOutput {
+		return &vmcommon.VMOutput{
+			ReturnCode: vmcommon.Ok,
+			Logs: []*vmcommon.LogEntry{
+				{
+					Identifier: []byte(core.BuiltInFunctionDCTTransfer),
+					Address:    sender,
+					Topics:     [][]byte{[]byte("TKN-abcdef"), {}, {10}, receiver},
+				},
+				{
+					Identifier: []byte(core.BuiltInFunctionDCTNFTUpdateAttributes),
+					Address:    sender,
+					Topics:     [][]byte{[]byte("NFT-abcdef"), {1}, {}, receiver},
+				},
+				{
+					Identifier: []byte(vmcommon.BuiltInFunctionDCTApprove),
+					Address:    sender,
+					Topics:     [][]byte{[]byte("TKN-abcdef"), {}, {10}, []byte("short")},
+				},
+				nil,
+			},
+		}
+	}
+	createFunction := func(vmOutput *vmcommon.VMOutput, err error) *mock.BuiltInFunctionStub {
+		return &mock.BuiltInFunctionStub{
+			ProcessBuiltinFunctionCalled: func(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+				return vmOutput, err
+			},
+		}
+	}
+
+	t.Run("encoded format should replace the address topics", func(t *testing.T) {
+		t.Parallel()
+
+		laf := newLogAddressFunction(createFunction(createOutput(), nil), vmcommon.LogAddressEncoded, converter)
+
+		vmOutput, err := laf.ProcessBuiltinFunction(nil, nil, &vmcommon.ContractCallInput{})
+		require.Nil(t, err)
+		assert.Equal(t, [][]byte{[]byte("TKN-abcdef"), {}, {10}, encodedReceiver}, vmOutput.Logs[0].Topics)
+		assert.Nil(t, vmOutput.Logs[0].EncodedTopics)
+		assert.Equal(t, sender, vmOutput.Logs[0].Address)
+		assert.Equal(t, receiver, vmOutput.Logs[1].Topics[3])
+		assert.Equal(t, []byte("short"), vmOutput.Logs[2].Topics[3])
+	})
+	t.Run("raw and encoded format should set the encoded topics", func(t *testing.T) {
+		t.Parallel()
+
+		laf := newLogAddressFunction(createFunction(createOutput(), nil), vmcommon.LogAddressRawAndEncoded, converter)
+
+		vmOutput, err := laf.ProcessBuiltinFunction(nil, nil, &vmcommon.ContractCallInput{})
+		require.Nil(t, err)
+		assert.Equal(t, [][]byte{[]byte("TKN-abcdef"), {}, {10}, receiver}, vmOutput.Logs[0].Topics)
+		assert.Equal(t, [][]byte{[]byte("TKN-abcdef"), {}, {10}, encodedReceiver}, vmOutput.Logs[0].EncodedTopics)
+		assert.Nil(t, vmOutput.Logs[1].EncodedTopics)
+	})
+	t.Run("errors should be returned", func(t *testing.T) {
+		t.Parallel()
+
+		laf := newLogAddressFunction(createFunction(nil, ErrNotEnoughGas), vmcommon.LogAddressEncoded, converter)
+
+		vmOutput, err := laf.ProcessBuiltinFunction(nil, nil, &vmcommon.ContractCallInput{})
+		assert.Nil(t, vmOutput)
+		assert.Equal(t, ErrNotEnoughGas, err)
+	})
+}
+
+func TestCheckLogAddressFormat(t *testing.T) {
+	t.Parallel()
+
+	converter, _ := pubkeyConverter.NewBech32PubkeyConverter(32, log)
+
+	assert.Nil(t, checkLogAddressFormat(vmcommon.LogAddressRaw, nil))
+	assert.Nil(t, checkLogAddressFormat(vmcommon.LogAddressEncoded, converter))
+	assert.Nil(t, checkLogAddressFormat(vmcommon.LogAddressRawAndEncoded, converter))
+	assert.Equal(t, ErrNilPubkeyConverter, checkLogAddressFormat(vmcommon.LogAddressEncoded, nil))
+	assert.Equal(t, ErrNilPubkeyConverter, checkLogAddressFormat(vmcommon.LogAddressRawAndEncoded, nil))
+	assert.Equal(t, ErrInvalidLogAddressFormat, checkLogAddressFormat(vmcommon.LogAddressFormat(3), converter))
+}
@@ -0,0 +1,50 @@
+package builtInFunctions
+
+import (
+	"context"
+
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+)
+
+// logPublisherFunction wraps a built-in function and publishes the log entries of its successful calls
+type logPublisherFunction struct {
+	baseFunctionWrapper
+	name         string
+	logPublisher vmcommon.LogPublisher
+}
+
+func newLogPublisherFunction(name string, function vmcommon.BuiltinFunction, logPublisher vmcommon.LogPublisher) *logPublisherFunction {
+	return &logPublisherFunction{
+		baseFunctionWrapper: baseFunctionWrapper{function: function},
+		name:                name,
+		logPublisher:        logPublisher,
+	}
+}
+
+// ProcessBuiltinFunction calls the wrapped function and publishes the log entries of the output
+func (lpf *logPublisherFunction) ProcessBuiltinFunction(
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	return lpf.processBuiltinFunctionWithContext(context.Background(), acntSnd, acntDst, vmInput)
+}
+
+func (lpf *logPublisherFunction) processBuiltinFunctionWithContext(
+	ctx context.Context,
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	vmOutput, err := callWithContext(ctx, lpf.function, acntSnd, acntDst, vmInput)
+	if err != nil || vmOutput == nil || vmOutput.ReturnCode != vmcommon.Ok || len(vmOutput.Logs) == 0 {
+		return vmOutput, err
+	}
+
+	lpf.logPublisher.PublishLogs(lpf.name, vmOutput.Logs)
+
+	return vmOutput, nil
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (lpf *logPublisherFunction) IsInterfaceNil() bool {
+	return lpf == nil
+}
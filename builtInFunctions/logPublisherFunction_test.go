@@ -0,0 +1,66 @@
+package builtInFunctions
+
+import (
+	"testing"
+
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogPublisherFunction_ProcessBuiltinFunction(t *testing.T) {
+	t.Parallel()
+
+	logs := []*vmcommon.LogEntry{{Identifier: []byte("identifier")}}
+	createFunction := func(vmOutput *vmcommon.VMOutput, err error) *mock.BuiltInFunctionStub {
+		return &mock.BuiltInFunctionStub{
+			ProcessBuiltinFunctionCalled: func(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+				return vmOutput, err
+			},
+		}
+	}
+
+	t.Run("successful call should publish the logs", func(t *testing.T) {
+		t.Parallel()
+
+		var publishedFunction string
+		var publishedLogs []*vmcommon.LogEntry
+		logPublisher := &mock.LogPublisherStub{
+			PublishLogsCalled: func(function string, logs []*vmcommon.LogEntry) {
+				publishedFunction = function
+				publishedLogs = logs
+			},
+		}
+		expectedOutput := &vmcommon.VMOutput{ReturnCode: vmcommon.Ok, Logs: logs}
+		lpf := newLogPublisherFunction("function", createFunction(expectedOutput, nil), logPublisher)
+
+		vmOutput, err := lpf.ProcessBuiltinFunction(nil, nil, &vmcommon.ContractCallInput{})
+		assert.Nil(t, err)
+		assert.True(t, vmOutput == expectedOutput)
+		assert.Equal(t, "function", publishedFunction)
+		assert.Equal(t, logs, publishedLogs)
+	})
+	t.Run("failed or empty calls should not publish", func(t *testing.T) {
+		t.Parallel()
+
+		numPublished := 0
+		logPublisher := &mock.LogPublisherStub{
+			PublishLogsCalled: func(function string, logs []*vmcommon.LogEntry) {
+				numPublished++
+			},
+		}
+
+		_, err := newLogPublisherFunction("function", createFunction(nil, ErrNotEnoughGas), logPublisher).ProcessBuiltinFunction(nil, nil, nil)
+		assert.Equal(t, ErrNotEnoughGas, err)
+
+		vmOutput := &vmcommon.VMOutput{ReturnCode: vmcommon.UserError, Logs: logs}
+		_, err = newLogPublisherFunction("function", createFunction(vmOutput, nil), logPublisher).ProcessBuiltinFunction(nil, nil, nil)
+		assert.Nil(t, err)
+
+		vmOutput = &vmcommon.VMOutput{ReturnCode: vmcommon.Ok}
+		_, err = newLogPublisherFunction("function", createFunction(vmOutput, nil), logPublisher).ProcessBuiltinFunction(nil, nil, nil)
+		assert.Nil(t, err)
+
+		assert.Equal(t, 0, numPublished)
+	})
+}
@@ -2,18 +2,17 @@ package builtInFunctions
 
 import (
 	"bytes"
-	"fmt"
 	"math/big"
+	"sort"
 	"strconv"
 
 	vmcommon "github.com/Reshusk23/sr-vm-common-go"
 )
 
-const (
-	dctIdentifierSeparator  = "-"
-	dctRandomSequenceLength = 6
-)
-
+// addDCTEntryInVMOutput appends the log entry at the end of the output logs. Together with
+// addRolesChangedEntryInVMOutput, it is the only way the built-in functions add their own entries: each one is added
+// right after the corresponding state change succeeded, in the order of the processed arguments, and never while
+// iterating a map, so the same call always produces the same logs order
 func addDCTEntryInVMOutput(vmOutput *vmcommon.VMOutput, identifier []byte, tokenID []byte, nonce uint64, value *big.Int, args ...[]byte) {
 	entry := newEntryForDCT(identifier, tokenID, nonce, value, args...)
 
@@ -24,12 +23,55 @@ func addDCTEntryInVMOutput(vmOutput *vmcommon.VMOutput, identifier []byte, token
 	vmOutput.Logs = append(vmOutput.Logs, entry)
 }
 
-func newEntryForDCT(identifier, tokenID []byte, nonce uint64, value *big.Int, args ...[]byte) *vmcommon.LogEntry {
-	nonceBig := big.NewInt(0).SetUint64(nonce)
+// addNativeValueEntryInVMOutput adds, right after the token transfer entry, the entry for the native value sent together
+// with the tokens. Nothing is added if no native value was sent
+func addNativeValueEntryInVMOutput(vmOutput *vmcommon.VMOutput, vmInput *vmcommon.ContractCallInput, receiver []byte) {
+	if vmInput.CallValue == nil || vmInput.CallValue.Sign() <= 0 {
+		return
+	}
+
+	addDCTEntryInVMOutput(vmOutput, []byte(vmcommon.DCTTransferNativeValueIdentifier), nil, 0, vmInput.CallValue, vmInput.CallerAddr, receiver)
+}
+
+// markLastEntryAsSelfTransfer sets the self transfer indicator as the data of the last log entry, if the transfer
+// which added it had the same sender and receiver
+func markLastEntryAsSelfTransfer(vmOutput *vmcommon.VMOutput, isSelfTransfer bool) {
+	if !isSelfTransfer || len(vmOutput.Logs) == 0 {
+		return
+	}
+
+	vmOutput.Logs[len(vmOutput.Logs)-1].Data = []byte(vmcommon.DCTSelfTransferLogData)
+}
+
+// addRolesChangedEntryInVMOutput appends the canonical roles changed entry, which the functions changing the roles of an
+// account emit right after their own entry, so the role grants can be tracked from the logs alone. The roles are listed
+// sorted, so granting the same roles in a different argument order produces the same entry
+func addRolesChangedEntryInVMOutput(vmOutput *vmcommon.VMOutput, function string, address []byte, tokenID []byte, set bool, roles [][]byte) {
+	operation := vmcommon.DCTRolesChangedUnset
+	if set {
+		operation = vmcommon.DCTRolesChangedSet
+	}
+
+	topics := make([][]byte, 0, len(roles)+2)
+	topics = append(topics, tokenID, []byte(operation))
+	topics = append(topics, roles...)
+	sortedRoles := topics[2:]
+	sort.Slice(sortedRoles, func(i, j int) bool {
+		return bytes.Compare(sortedRoles[i], sortedRoles[j]) < 0
+	})
+
+	vmOutput.Logs = append(vmOutput.Logs, &vmcommon.LogEntry{
+		Identifier: []byte(vmcommon.DCTRolesChangedIdentifier),
+		Address:    address,
+		Topics:     topics,
+		Data:       []byte(function),
+	})
+}
 
+func newEntryForDCT(identifier, tokenID []byte, nonce uint64, value *big.Int, args ...[]byte) *vmcommon.LogEntry {
 	logEntry := &vmcommon.LogEntry{
 		Identifier: identifier,
-		Topics:     [][]byte{tokenID, nonceBig.Bytes(), value.Bytes()},
+		Topics:     [][]byte{tokenID, uint64ToBytes(nonce), value.Bytes()},
 	}
 
 	if len(args) > 0 {
@@ -43,22 +85,6 @@ func newEntryForDCT(identifier, tokenID []byte, nonce uint64, value *big.Int, ar
 	return logEntry
 }
 
-func extractTokenIdentifierAndNonceDCTWipe(args []byte) ([]byte, uint64) {
-	argsSplit := bytes.Split(args, []byte(dctIdentifierSeparator))
-	if len(argsSplit) < 2 {
-		return args, 0
-	}
-
-	if len(argsSplit[1]) <= dctRandomSequenceLength {
-		return args, 0
-	}
-
-	identifier := []byte(fmt.Sprintf("%s-%s", argsSplit[0], argsSplit[1][:dctRandomSequenceLength]))
-	nonce := big.NewInt(0).SetBytes(argsSplit[1][dctRandomSequenceLength:])
-
-	return identifier, nonce.Uint64()
-}
-
 func boolToSlice(b bool) []byte {
 	return []byte(strconv.FormatBool(b))
 }
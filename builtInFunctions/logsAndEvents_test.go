@@ -6,7 +6,9 @@ import (
 	"testing"
 
 	"github.com/Reshusk23/sr-me-core/core"
+	"github.com/Reshusk23/sr-me-core/data/dct"
 	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -26,17 +28,49 @@ func TestNewEntryForNFT(t *testing.T) {
 func TestExtractTokenIdentifierAndNonceDCTWipe(t *testing.T) {
 	t.Parallel()
 
+	wipeAndGetLogTopics := func(args []byte) [][]byte {
+		marshaller := &mock.MarshalizerMock{}
+		wipe, _ := NewDCTFreezeWipeFunc(ArgsNewDCTFreezeWipe{
+			Config: Config{
+				DCTStorageHandler:   createNewDCTDataStorageHandler(),
+				EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+				Marshalizer:         marshaller,
+			},
+			Wipe: true,
+		})
+		acnt := mock.NewUserAccount([]byte("dst"))
+		metaData := DCTUserMetadata{Frozen: true}
+		dctToken := &dct.DCToken{
+			Value:      big.NewInt(1),
+			Properties: metaData.ToBytes(),
+		}
+		marshaledData, _ := marshaller.Marshal(dctToken)
+		_ = acnt.AccountDataHandler().SaveKeyValue(append(wipe.keyPrefix, args...), marshaledData)
+
+		vmOutput, err := wipe.ProcessBuiltinFunction(nil, acnt, &vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallerAddr: core.DCTSCAddress,
+				CallValue:  big.NewInt(0),
+				Arguments:  [][]byte{args},
+			},
+		})
+		require.Nil(t, err)
+		require.Len(t, vmOutput.Logs, 1)
+
+		return vmOutput.Logs[0].Topics
+	}
+
 	hexArg := "534b4537592d37336262636404"
 	args, _ := hex.DecodeString(hexArg)
 
-	identifier, nonce := extractTokenIdentifierAndNonceDCTWipe(args)
-	require.Equal(t, uint64(4), nonce)
-	require.Equal(t, []byte("SKE7Y-73bbcd"), identifier)
+	topics := wipeAndGetLogTopics(args)
+	require.Equal(t, []byte("SKE7Y-73bbcd"), topics[0])
+	require.Equal(t, big.NewInt(4).Bytes(), topics[1])
 
 	hexArg = "57414e442d376662623930"
 	args, _ = hex.DecodeString(hexArg)
 
-	identifier, nonce = extractTokenIdentifierAndNonceDCTWipe(args)
-	require.Equal(t, uint64(0), nonce)
-	require.Equal(t, []byte("WAND-7fbb90"), identifier)
+	topics = wipeAndGetLogTopics(args)
+	require.Equal(t, []byte("WAND-7fbb90"), topics[0])
+	require.Equal(t, big.NewInt(0).Bytes(), topics[1])
 }
@@ -0,0 +1,68 @@
+package builtInFunctions
+
+import (
+	"github.com/Reshusk23/sr-me-core/core/check"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+)
+
+const (
+	// compressedPayloadVersion prefixes the compressed payloads. A marshalled token can not start with a byte below 0x08,
+	// as it would encode the field number 0, hence the compressed payloads are told apart from the plain ones
+	compressedPayloadVersion = byte(1)
+
+	defaultMetaDataCompressionThreshold = 1024
+)
+
+// metaDataCompression compresses the marshalled tokens larger than the threshold before they are stored and
+// decompresses them transparently on load. Without a compressor the tokens are stored as they are
+type metaDataCompression struct {
+	compressor vmcommon.Compressor
+	threshold  int
+}
+
+func newMetaDataCompression(compressor vmcommon.Compressor, threshold uint32) *metaDataCompression {
+	if threshold == 0 {
+		threshold = defaultMetaDataCompressionThreshold
+	}
+
+	return &metaDataCompression{
+		compressor: compressor,
+		threshold:  int(threshold),
+	}
+}
+
+// compress returns the payload to be stored for the marshalled token. The payload is compressed only if the
+// compression saves bytes
+func (mc *metaDataCompression) compress(marshaledData []byte) ([]byte, error) {
+	if check.IfNil(mc.compressor) || len(marshaledData) <= mc.threshold {
+		return marshaledData, nil
+	}
+
+	compressedData, err := mc.compressor.Compress(marshaledData)
+	if err != nil {
+		return nil, err
+	}
+	if len(compressedData)+1 >= len(marshaledData) {
+		return marshaledData, nil
+	}
+
+	payload := make([]byte, 0, len(compressedData)+1)
+	payload = append(payload, compressedPayloadVersion)
+	return append(payload, compressedData...), nil
+}
+
+// decompress returns the marshalled token held by the stored payload
+func (mc *metaDataCompression) decompress(payload []byte) ([]byte, error) {
+	if !isCompressedPayload(payload) {
+		return payload, nil
+	}
+	if check.IfNil(mc.compressor) {
+		return nil, ErrCompressorNotSet
+	}
+
+	return mc.compressor.Decompress(payload[1:])
+}
+
+func isCompressedPayload(payload []byte) bool {
+	return len(payload) > 0 && payload[0] == compressedPayloadVersion
+}
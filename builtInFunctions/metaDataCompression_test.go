@@ -0,0 +1,101 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"compress/flate"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createFlateCompressor() *mock.CompressorStub {
+	return &mock.CompressorStub{
+		CompressCalled: func(data []byte) ([]byte, error) {
+			buff := bytes.NewBuffer(nil)
+			writer, _ := flate.NewWriter(buff, flate.BestCompression)
+			_, _ = writer.Write(data)
+			err := writer.Close()
+			return buff.Bytes(), err
+		},
+		DecompressCalled: func(data []byte) ([]byte, error) {
+			return io.ReadAll(flate.NewReader(bytes.NewReader(data)))
+		},
+	}
+}
+
+func TestMetaDataCompression_Compress(t *testing.T) {
+	t.Parallel()
+
+	largePayload := []byte("{" + string(bytes.Repeat([]byte("attributes"), 20)) + "}")
+
+	t.Run("without compressor the payload should be kept", func(t *testing.T) {
+		t.Parallel()
+
+		mc := newMetaDataCompression(nil, 10)
+		payload, err := mc.compress(largePayload)
+		require.Nil(t, err)
+		assert.Equal(t, largePayload, payload)
+	})
+	t.Run("payload up to the threshold should not be compressed", func(t *testing.T) {
+		t.Parallel()
+
+		mc := newMetaDataCompression(createFlateCompressor(), 0)
+		assert.Equal(t, defaultMetaDataCompressionThreshold, mc.threshold)
+		payload, err := mc.compress(largePayload)
+		require.Nil(t, err)
+		assert.Equal(t, largePayload, payload)
+	})
+	t.Run("payload not shrinking should not be compressed", func(t *testing.T) {
+		t.Parallel()
+
+		mc := newMetaDataCompression(&mock.CompressorStub{}, 10)
+		payload, err := mc.compress(largePayload)
+		require.Nil(t, err)
+		assert.Equal(t, largePayload, payload)
+	})
+	t.Run("compressor error should err", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		mc := newMetaDataCompression(&mock.CompressorStub{
+			CompressCalled: func(data []byte) ([]byte, error) {
+				return nil, expectedErr
+			},
+		}, 10)
+		payload, err := mc.compress(largePayload)
+		assert.Equal(t, expectedErr, err)
+		assert.Nil(t, payload)
+	})
+	t.Run("large payload should be compressed and restored", func(t *testing.T) {
+		t.Parallel()
+
+		mc := newMetaDataCompression(createFlateCompressor(), 10)
+		payload, err := mc.compress(largePayload)
+		require.Nil(t, err)
+		assert.Equal(t, compressedPayloadVersion, payload[0])
+		assert.Less(t, len(payload), len(largePayload))
+
+		decompressed, err := mc.decompress(payload)
+		require.Nil(t, err)
+		assert.Equal(t, largePayload, decompressed)
+
+		decompressed, err = newMetaDataCompression(nil, 0).decompress(payload)
+		assert.Equal(t, ErrCompressorNotSet, err)
+		assert.Nil(t, decompressed)
+	})
+}
+
+func TestMetaDataCompression_DecompressPlainPayload(t *testing.T) {
+	t.Parallel()
+
+	mc := newMetaDataCompression(nil, 0)
+	for _, payload := range [][]byte{nil, {}, []byte("{}"), {0x08, 1}} {
+		decompressed, err := mc.decompress(payload)
+		require.Nil(t, err)
+		assert.Equal(t, payload, decompressed)
+	}
+}
@@ -0,0 +1,77 @@
+package builtInFunctions
+
+import (
+	"context"
+	"errors"
+
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+)
+
+// metricsFunction wraps a built-in function and reports the call count, the errors and the gas used for each call
+type metricsFunction struct {
+	baseFunctionWrapper
+	name    string
+	metrics vmcommon.Metrics
+}
+
+func newMetricsFunction(name string, function vmcommon.BuiltinFunction, metrics vmcommon.Metrics) *metricsFunction {
+	return &metricsFunction{
+		baseFunctionWrapper: baseFunctionWrapper{function: function},
+		name:                name,
+		metrics:             metrics,
+	}
+}
+
+// ProcessBuiltinFunction calls the wrapped function and reports the outcome
+func (mf *metricsFunction) ProcessBuiltinFunction(
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	return mf.processBuiltinFunctionWithContext(context.Background(), acntSnd, acntDst, vmInput)
+}
+
+func (mf *metricsFunction) processBuiltinFunctionWithContext(
+	ctx context.Context,
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	vmOutput, err := callWithContext(ctx, mf.function, acntSnd, acntDst, vmInput)
+
+	labels := vmcommon.MetricLabels{
+		Function:   mf.name,
+		ReturnCode: vmcommon.Ok,
+	}
+	gasUsed := uint64(0)
+	if vmInput != nil {
+		gasUsed = vmInput.GasProvided
+	}
+
+	switch {
+	case err != nil:
+		labels.ReturnCode = returnCodeFromError(err)
+	case vmOutput != nil:
+		labels.ReturnCode = vmOutput.ReturnCode
+		gasUsed, _ = vmcommon.SafeSubUint64(gasUsed, vmOutput.GasRemaining)
+	}
+
+	if labels.ReturnCode != vmcommon.Ok {
+		mf.metrics.IncrementCounter(vmcommon.MetricBuiltInFunctionErrors, labels)
+	}
+	mf.metrics.IncrementCounter(vmcommon.MetricBuiltInFunctionCalls, labels)
+	mf.metrics.ObserveHistogram(vmcommon.MetricBuiltInFunctionGasUsed, float64(gasUsed), labels)
+
+	return vmOutput, err
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (mf *metricsFunction) IsInterfaceNil() bool {
+	return mf == nil
+}
+
+func returnCodeFromError(err error) vmcommon.ReturnCode {
+	if errors.Is(err, ErrInsufficientFunds) {
+		return vmcommon.OutOfFunds
+	}
+
+	return vmcommon.ReturnCodeFromError(err)
+}
@@ -0,0 +1,108 @@
+package builtInFunctions
+
+import (
+	"fmt"
+	"testing"
+
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+type reportedMetrics struct {
+	counters   map[string]vmcommon.MetricLabels
+	histograms map[string]float64
+}
+
+func createRecordingMetricsStub(reported *reportedMetrics) *mock.MetricsStub {
+	reported.counters = make(map[string]vmcommon.MetricLabels)
+	reported.histograms = make(map[string]float64)
+
+	return &mock.MetricsStub{
+		IncrementCounterCalled: func(name string, labels vmcommon.MetricLabels) {
+			reported.counters[name] = labels
+		},
+		ObserveHistogramCalled: func(name string, value float64, labels vmcommon.MetricLabels) {
+			reported.histograms[name] = value
+		},
+	}
+}
+
+func TestMetricsFunction_ProcessBuiltinFunction(t *testing.T) {
+	t.Parallel()
+
+	t.Run("successful call should report call and gas used", func(t *testing.T) {
+		t.Parallel()
+
+		reported := &reportedMetrics{}
+		function := &mock.BuiltInFunctionStub{
+			ProcessBuiltinFunctionCalled: func(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+				return &vmcommon.VMOutput{ReturnCode: vmcommon.Ok, GasRemaining: 70}, nil
+			},
+		}
+		mf := newMetricsFunction("function", function, createRecordingMetricsStub(reported))
+
+		vmOutput, err := mf.ProcessBuiltinFunction(nil, nil, &vmcommon.ContractCallInput{VMInput: vmcommon.VMInput{GasProvided: 100}})
+		assert.Nil(t, err)
+		assert.Equal(t, uint64(70), vmOutput.GasRemaining)
+
+		expectedLabels := vmcommon.MetricLabels{Function: "function", ReturnCode: vmcommon.Ok}
+		assert.Equal(t, map[string]vmcommon.MetricLabels{vmcommon.MetricBuiltInFunctionCalls: expectedLabels}, reported.counters)
+		assert.Equal(t, map[string]float64{vmcommon.MetricBuiltInFunctionGasUsed: 30}, reported.histograms)
+	})
+	t.Run("failed call should report the error return code", func(t *testing.T) {
+		t.Parallel()
+
+		reported := &reportedMetrics{}
+		function := &mock.BuiltInFunctionStub{
+			ProcessBuiltinFunctionCalled: func(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+				return nil, fmt.Errorf("%w for transfer", ErrNotEnoughGas)
+			},
+		}
+		mf := newMetricsFunction("function", function, createRecordingMetricsStub(reported))
+
+		_, err := mf.ProcessBuiltinFunction(nil, nil, &vmcommon.ContractCallInput{VMInput: vmcommon.VMInput{GasProvided: 100}})
+		assert.ErrorIs(t, err, ErrNotEnoughGas)
+
+		expectedLabels := vmcommon.MetricLabels{Function: "function", ReturnCode: vmcommon.OutOfGas}
+		assert.Equal(t, expectedLabels, reported.counters[vmcommon.MetricBuiltInFunctionCalls])
+		assert.Equal(t, expectedLabels, reported.counters[vmcommon.MetricBuiltInFunctionErrors])
+		assert.Equal(t, float64(100), reported.histograms[vmcommon.MetricBuiltInFunctionGasUsed])
+	})
+}
+
+func TestMetricsFunction_ForwardsCalls(t *testing.T) {
+	t.Parallel()
+
+	gasConfigSet := false
+	function := &mock.BuiltInFunctionStub{
+		SetNewGasConfigCalled: func(gasCost *vmcommon.GasCost) {
+			gasConfigSet = true
+		},
+		IsActiveCalled: func() bool {
+			return false
+		},
+	}
+	mf := newMetricsFunction("function", function, &mock.MetricsStub{})
+
+	mf.SetNewGasConfig(&vmcommon.GasCost{})
+	assert.True(t, gasConfigSet)
+	assert.False(t, mf.IsActive())
+	assert.Equal(t, ErrWrongTypeAssertion, mf.SetPayableChecker(&mock.PayableHandlerStub{}))
+	assert.Equal(t, ErrWrongTypeAssertion, mf.SetLatestNonceCache(&disabledLatestNonceCache{}))
+
+	nftCreate := createNftCreateWithStubArguments()
+	mf = newMetricsFunction("function", nftCreate, &mock.MetricsStub{})
+	nonceCache, _ := NewLatestNonceCache(&mock.RoundNotifierStub{})
+	nftCreate.accounts, _ = NewAccountsAdapterWithLatestNonceCache(nftCreate.accounts, nonceCache)
+	assert.Nil(t, mf.SetLatestNonceCache(nonceCache))
+	assert.True(t, nftCreate.nonceCache == nonceCache)
+}
+
+func TestReturnCodeFromError(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, vmcommon.OutOfGas, returnCodeFromError(ErrNotEnoughGas))
+	assert.Equal(t, vmcommon.OutOfFunds, returnCodeFromError(ErrInsufficientFunds))
+	assert.Equal(t, vmcommon.UserError, returnCodeFromError(ErrInvalidArguments))
+}
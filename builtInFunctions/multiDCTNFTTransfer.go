@@ -2,6 +2,7 @@ package builtInFunctions
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"math/big"
@@ -15,69 +16,62 @@ import (
 
 type dctNFTMultiTransfer struct {
 	baseActiveHandler
-	keyPrefix             []byte
-	marshaller            vmcommon.Marshalizer
-	globalSettingsHandler vmcommon.ExtendedDCTGlobalSettingsHandler
-	payableHandler        vmcommon.PayableChecker
-	funcGasCost           uint64
-	accounts              vmcommon.AccountsAdapter
-	shardCoordinator      vmcommon.Coordinator
-	gasConfig             vmcommon.BaseOperationCost
-	mutExecution          sync.RWMutex
-	dctStorageHandler     vmcommon.DCTNFTStorageHandler
-	rolesHandler          vmcommon.DCTRoleHandler
-	enableEpochsHandler   vmcommon.EnableEpochsHandler
+	baseAddressLengthHandler
+	freezeAccountChecker
+	lockedBalanceChecker
+	transferInterceptorChecker
+	keyPrefix              []byte
+	marshaller             vmcommon.Marshalizer
+	globalSettingsHandler  vmcommon.ExtendedDCTGlobalSettingsHandler
+	payableHandler         vmcommon.PayableChecker
+	funcGasCost            uint64
+	transferGasCost        uint64
+	accounts               vmcommon.AccountsAdapter
+	shardCoordinator       vmcommon.Coordinator
+	addressClassifier      vmcommon.AddressClassifier
+	aliasResolver          vmcommon.AliasResolver
+	gasConfig              vmcommon.BaseOperationCost
+	asyncCallbackCost      vmcommon.AsyncCallbackCost
+	mutExecution           sync.RWMutex
+	dctStorageHandler      vmcommon.DCTNFTStorageHandler
+	rolesHandler           vmcommon.DCTRoleHandler
+	enableEpochsHandler    vmcommon.EnableEpochsHandler
+	sameShardCallReceivers bool
 }
 
 const argumentsPerTransfer = uint64(3)
 
+// ArgsNewDCTNFTMultiTransfer defines the argument list for new dct NFT multi transfer built in function
+type ArgsNewDCTNFTMultiTransfer struct {
+	Config
+	FuncGasCost uint64
+	GasConfig   vmcommon.BaseOperationCost
+}
+
 // NewDCTNFTMultiTransferFunc returns the dct NFT multi transfer built-in function component
-func NewDCTNFTMultiTransferFunc(
-	funcGasCost uint64,
-	marshaller vmcommon.Marshalizer,
-	globalSettingsHandler vmcommon.ExtendedDCTGlobalSettingsHandler,
-	accounts vmcommon.AccountsAdapter,
-	shardCoordinator vmcommon.Coordinator,
-	gasConfig vmcommon.BaseOperationCost,
-	enableEpochsHandler vmcommon.EnableEpochsHandler,
-	roleHandler vmcommon.DCTRoleHandler,
-	dctStorageHandler vmcommon.DCTNFTStorageHandler,
-) (*dctNFTMultiTransfer, error) {
-	if check.IfNil(marshaller) {
-		return nil, ErrNilMarshalizer
-	}
-	if check.IfNil(globalSettingsHandler) {
-		return nil, ErrNilGlobalSettingsHandler
-	}
-	if check.IfNil(accounts) {
-		return nil, ErrNilAccountsAdapter
-	}
-	if check.IfNil(shardCoordinator) {
-		return nil, ErrNilShardCoordinator
-	}
-	if check.IfNil(enableEpochsHandler) {
-		return nil, ErrNilEnableEpochsHandler
-	}
-	if check.IfNil(roleHandler) {
-		return nil, ErrNilRolesHandler
-	}
-	if check.IfNil(dctStorageHandler) {
-		return nil, ErrNilDCTNFTStorageHandler
+func NewDCTNFTMultiTransferFunc(args ArgsNewDCTNFTMultiTransfer) (*dctNFTMultiTransfer, error) {
+	err := args.checkComponents(core.BuiltInFunctionMultiDCTNFTTransfer, requireMarshalizer|requireAccounts|requireShardCoordinator|
+		requireGlobalSettingsHandler|requireRolesHandler|requireDCTStorageHandler|requireEnableEpochsHandler)
+	if err != nil {
+		return nil, err
 	}
 
 	e := &dctNFTMultiTransfer{
+		lockedBalanceChecker:  lockedBalanceChecker{enableEpochsHandler: args.EnableEpochsHandler},
 		keyPrefix:             []byte(baseDCTKeyPrefix),
-		marshaller:            marshaller,
-		globalSettingsHandler: globalSettingsHandler,
-		funcGasCost:           funcGasCost,
-		accounts:              accounts,
-		shardCoordinator:      shardCoordinator,
-		gasConfig:             gasConfig,
+		marshaller:            args.Marshalizer,
+		globalSettingsHandler: args.GlobalSettingsHandler,
+		funcGasCost:           args.FuncGasCost,
+		transferGasCost:       args.FuncGasCost,
+		accounts:              args.Accounts,
+		shardCoordinator:      args.ShardCoordinator,
+		gasConfig:             args.GasConfig,
 		mutExecution:          sync.RWMutex{},
 		payableHandler:        &disabledPayableHandler{},
-		rolesHandler:          roleHandler,
-		dctStorageHandler:     dctStorageHandler,
-		enableEpochsHandler:   enableEpochsHandler,
+		addressClassifier:     vmcommon.NewDefaultAddressClassifier(),
+		rolesHandler:          args.RolesHandler,
+		dctStorageHandler:     args.DCTStorageHandler,
+		enableEpochsHandler:   args.EnableEpochsHandler,
 	}
 
 	e.baseActiveHandler.activeHandler = e.enableEpochsHandler.IsDCTNFTImprovementV1FlagEnabled
@@ -95,6 +89,40 @@ func (e *dctNFTMultiTransfer) SetPayableChecker(payableHandler vmcommon.PayableC
 	return nil
 }
 
+// SetAddressClassifier sets the classifier deciding whether the receiver of the transfer is a smart contract
+func (e *dctNFTMultiTransfer) SetAddressClassifier(addressClassifier vmcommon.AddressClassifier) error {
+	if check.IfNil(addressClassifier) {
+		return ErrNilAddressClassifier
+	}
+
+	e.mutExecution.Lock()
+	e.addressClassifier = addressClassifier
+	e.mutExecution.Unlock()
+
+	return nil
+}
+
+// SetAliasResolver sets the resolver of the destinations referenced by alias
+func (e *dctNFTMultiTransfer) SetAliasResolver(aliasResolver vmcommon.AliasResolver) error {
+	if check.IfNil(aliasResolver) {
+		return ErrNilAliasResolver
+	}
+
+	e.mutExecution.Lock()
+	e.aliasResolver = aliasResolver
+	e.mutExecution.Unlock()
+
+	return nil
+}
+
+// SetSameShardCallReceivers enables the check requiring the receivers of a transfer carrying a smart contract call to be
+// in the shard of the sender, so the cross shard calls are rejected before any token is moved
+func (e *dctNFTMultiTransfer) SetSameShardCallReceivers(enabled bool) {
+	e.mutExecution.Lock()
+	e.sameShardCallReceivers = enabled
+	e.mutExecution.Unlock()
+}
+
 // SetNewGasConfig is called whenever gas cost is changed
 func (e *dctNFTMultiTransfer) SetNewGasConfig(gasCost *vmcommon.GasCost) {
 	if gasCost == nil {
@@ -103,10 +131,64 @@ func (e *dctNFTMultiTransfer) SetNewGasConfig(gasCost *vmcommon.GasCost) {
 
 	e.mutExecution.Lock()
 	e.funcGasCost = gasCost.BuiltInCost.DCTNFTMultiTransfer
+	e.transferGasCost = gasCost.BuiltInCost.DCTNFTTransfer
 	e.gasConfig = gasCost.BaseOperationCost
+	e.asyncCallbackCost = gasCost.AsyncCallbackCost
 	e.mutExecution.Unlock()
 }
 
+// CheckIsExecutable validates the arguments, the destination and the gas of the DCT NFT multi transfer, without reading
+// any account or global setting. Only the calls made on the shard of the sender are validated
+func (e *dctNFTMultiTransfer) CheckIsExecutable(vmInput *vmcommon.ContractCallInput) error {
+	e.mutExecution.RLock()
+	defer e.mutExecution.RUnlock()
+
+	err := checkBasicDCTArguments(vmInput)
+	if err != nil {
+		return err
+	}
+	if len(vmInput.Arguments) < 4 {
+		return ErrInvalidArguments
+	}
+	if !bytes.Equal(vmInput.CallerAddr, vmInput.RecipientAddr) {
+		return nil
+	}
+
+	dstAddress, err := checkAddressArgument(e.aliasResolver, vmInput.Arguments, 0, e.getAddressLength(vmInput))
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(dstAddress, vmInput.CallerAddr) {
+		return fmt.Errorf("%w, can not transfer to self", ErrInvalidArguments)
+	}
+	isInvalidTransferToMeta := len(dstAddress) > 0 && e.shardCoordinator.ComputeId(dstAddress) == core.MetachainShardId && !e.enableEpochsHandler.IsTransferToMetaFlagEnabled()
+	if isInvalidTransferToMeta {
+		return ErrInvalidRcvAddr
+	}
+
+	numOfTransfers, err := flaggedUint64Argument(e.enableEpochsHandler, vmInput.Arguments, 1)
+	if err != nil {
+		return err
+	}
+	if numOfTransfers == 0 {
+		return fmt.Errorf("%w, 0 tokens to transfer", ErrInvalidArguments)
+	}
+	minNumOfArguments := numOfTransfers*argumentsPerTransfer + 2
+	if uint64(len(vmInput.Arguments)) < minNumOfArguments {
+		return fmt.Errorf("%w, invalid number of arguments", ErrInvalidArguments)
+	}
+
+	multiTransferCost, err := e.computeMultiTransferCost(numOfTransfers, vmInput.Arguments)
+	if err != nil {
+		return err
+	}
+	if vmInput.GasProvided < multiTransferCost {
+		return ErrNotEnoughGas
+	}
+
+	return nil
+}
+
 // ProcessBuiltinFunction resolves DCT NFT transfer roles function call
 // Requires the following arguments:
 // arg0 - destination address
@@ -120,6 +202,15 @@ func (e *dctNFTMultiTransfer) SetNewGasConfig(gasCost *vmcommon.GasCost) {
 func (e *dctNFTMultiTransfer) ProcessBuiltinFunction(
 	acntSnd, acntDst vmcommon.UserAccountHandler,
 	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	return e.processBuiltinFunctionWithContext(context.Background(), acntSnd, acntDst, vmInput)
+}
+
+// processBuiltinFunctionWithContext processes the transfers one by one, stopping as soon as the context is done
+func (e *dctNFTMultiTransfer) processBuiltinFunctionWithContext(
+	ctx context.Context,
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
 ) (*vmcommon.VMOutput, error) {
 	e.mutExecution.RLock()
 	defer e.mutExecution.RUnlock()
@@ -133,7 +224,12 @@ func (e *dctNFTMultiTransfer) ProcessBuiltinFunction(
 	}
 
 	if bytes.Equal(vmInput.CallerAddr, vmInput.RecipientAddr) {
-		return e.processDCTNFTMultiTransferOnSenderShard(acntSnd, vmInput)
+		err = e.checkAccountIsNotFrozen(vmInput.CallerAddr, vmInput.ReturnCallAfterError)
+		if err != nil {
+			return nil, err
+		}
+
+		return e.processDCTNFTMultiTransferOnSenderShard(ctx, acntSnd, vmInput)
 	}
 
 	// in cross shard NFT transfer the sender account must be nil
@@ -144,7 +240,10 @@ func (e *dctNFTMultiTransfer) ProcessBuiltinFunction(
 		return nil, ErrInvalidRcvAddr
 	}
 
-	numOfTransfers := big.NewInt(0).SetBytes(vmInput.Arguments[0]).Uint64()
+	numOfTransfers, err := flaggedUint64Argument(e.enableEpochsHandler, vmInput.Arguments, 0)
+	if err != nil {
+		return nil, err
+	}
 	if numOfTransfers == 0 {
 		return nil, fmt.Errorf("%w, 0 tokens to transfer", ErrInvalidArguments)
 	}
@@ -155,6 +254,7 @@ func (e *dctNFTMultiTransfer) ProcessBuiltinFunction(
 
 	vmOutput := &vmcommon.VMOutput{GasRemaining: vmInput.GasProvided}
 	vmOutput.Logs = make([]*vmcommon.LogEntry, 0, numOfTransfers)
+	dctTransfers := make([]*vmcommon.DCTTransfer, 0, numOfTransfers)
 	startIndex := uint64(1)
 
 	err = e.payableHandler.CheckPayable(vmInput, vmInput.RecipientAddr, int(minNumOfArguments))
@@ -163,13 +263,22 @@ func (e *dctNFTMultiTransfer) ProcessBuiltinFunction(
 	}
 
 	for i := uint64(0); i < numOfTransfers; i++ {
+		err = ctx.Err()
+		if err != nil {
+			return nil, err
+		}
+
 		tokenStartIndex := startIndex + i*argumentsPerTransfer
 		tokenID := vmInput.Arguments[tokenStartIndex]
-		nonce := big.NewInt(0).SetBytes(vmInput.Arguments[tokenStartIndex+1]).Uint64()
+		nonce, err := flaggedUint64Argument(e.enableEpochsHandler, vmInput.Arguments, int(tokenStartIndex+1))
+		if err != nil {
+			return nil, err
+		}
 
 		dctTokenKey := append(e.keyPrefix, tokenID...)
 
 		value := big.NewInt(0)
+		tokenType := uint32(core.Fungible)
 		if nonce > 0 {
 			dctTransferData := &dct.DCToken{}
 			if len(vmInput.Arguments[tokenStartIndex+2]) > vmcommon.MaxLengthForValueToOptTransfer {
@@ -180,10 +289,11 @@ func (e *dctNFTMultiTransfer) ProcessBuiltinFunction(
 				}
 			} else {
 				dctTransferData.Value = big.NewInt(0).SetBytes(vmInput.Arguments[tokenStartIndex+2])
-				dctTransferData.Type = uint32(core.NonFungible)
+				dctTransferData.Type = getNFTTokenType(e.globalSettingsHandler, e.enableEpochsHandler, tokenID)
 			}
 
 			value.Set(dctTransferData.Value)
+			tokenType = dctTransferData.Type
 			err = e.addNFTToDestination(
 				vmInput.CallerAddr,
 				vmInput.RecipientAddr,
@@ -198,29 +308,35 @@ func (e *dctNFTMultiTransfer) ProcessBuiltinFunction(
 		} else {
 			transferredValue := big.NewInt(0).SetBytes(vmInput.Arguments[tokenStartIndex+2])
 			value.Set(transferredValue)
-			err = addToDCTBalance(acntDst, dctTokenKey, transferredValue, e.marshaller, e.globalSettingsHandler, vmInput.ReturnCallAfterError)
+			err = addToDCTBalance(acntDst, dctTokenKey, transferredValue, e.marshaller, e.globalSettingsHandler, e.getSystemAddresses(), vmInput.ReturnCallAfterError)
 			if err != nil {
 				return nil, fmt.Errorf("%w for token %s", err, string(tokenID))
 			}
 		}
 
 		addDCTEntryInVMOutput(vmOutput, []byte(core.BuiltInFunctionMultiDCTNFTTransfer), tokenID, nonce, value, vmInput.CallerAddr, acntDst.AddressBytes())
+		dctTransfers = append(dctTransfers, &vmcommon.DCTTransfer{
+			DCTValue:      value,
+			DCTTokenName:  tokenID,
+			DCTTokenType:  tokenType,
+			DCTTokenNonce: nonce,
+		})
 	}
 
 	// no need to consume gas on destination - sender already paid for it
-	if len(vmInput.Arguments) > int(minNumOfArguments) && vmcommon.IsSmartContractAddress(vmInput.RecipientAddr) {
+	if len(vmInput.Arguments) > int(minNumOfArguments) && e.addressClassifier.IsSmartContract(vmInput.RecipientAddr) {
 		var callArgs [][]byte
 		if len(vmInput.Arguments) > int(minNumOfArguments)+1 {
 			callArgs = vmInput.Arguments[minNumOfArguments+1:]
 		}
 
-		addOutputTransferToVMOutput(
-			vmInput.CallerAddr,
+		addSCCallAfterTransferToVMOutput(
+			vmInput,
 			string(vmInput.Arguments[minNumOfArguments]),
 			callArgs,
 			vmInput.RecipientAddr,
+			dctTransfers,
 			vmInput.GasLocked,
-			vmInput.CallType,
 			vmOutput)
 	}
 
@@ -228,12 +344,13 @@ func (e *dctNFTMultiTransfer) ProcessBuiltinFunction(
 }
 
 func (e *dctNFTMultiTransfer) processDCTNFTMultiTransferOnSenderShard(
+	ctx context.Context,
 	acntSnd vmcommon.UserAccountHandler,
 	vmInput *vmcommon.ContractCallInput,
 ) (*vmcommon.VMOutput, error) {
-	dstAddress := vmInput.Arguments[0]
-	if len(dstAddress) != len(vmInput.CallerAddr) {
-		return nil, fmt.Errorf("%w, not a valid destination address", ErrInvalidArguments)
+	dstAddress, err := resolveAddressArgument(e.aliasResolver, vmInput.Arguments, 0, e.getAddressLength(vmInput))
+	if err != nil {
+		return nil, err
 	}
 	if bytes.Equal(dstAddress, vmInput.CallerAddr) {
 		return nil, fmt.Errorf("%w, can not transfer to self", ErrInvalidArguments)
@@ -243,7 +360,10 @@ func (e *dctNFTMultiTransfer) processDCTNFTMultiTransferOnSenderShard(
 	if isInvalidTransferToMeta {
 		return nil, ErrInvalidRcvAddr
 	}
-	numOfTransfers := big.NewInt(0).SetBytes(vmInput.Arguments[1]).Uint64()
+	numOfTransfers, err := flaggedUint64Argument(e.enableEpochsHandler, vmInput.Arguments, 1)
+	if err != nil {
+		return nil, err
+	}
 	if numOfTransfers == 0 {
 		return nil, fmt.Errorf("%w, 0 tokens to transfer", ErrInvalidArguments)
 	}
@@ -251,8 +371,18 @@ func (e *dctNFTMultiTransfer) processDCTNFTMultiTransferOnSenderShard(
 	if uint64(len(vmInput.Arguments)) < minNumOfArguments {
 		return nil, fmt.Errorf("%w, invalid number of arguments", ErrInvalidArguments)
 	}
+	hasSCCall := uint64(len(vmInput.Arguments)) > minNumOfArguments
+	if e.sameShardCallReceivers && hasSCCall {
+		err = checkReceiversInShard(e.shardCoordinator, e.shardCoordinator.SelfId(), [][]byte{dstAddress})
+		if err != nil {
+			return nil, err
+		}
+	}
 
-	multiTransferCost := numOfTransfers * e.funcGasCost
+	multiTransferCost, err := e.computeMultiTransferCost(numOfTransfers, vmInput.Arguments)
+	if err != nil {
+		return nil, err
+	}
 	if vmInput.GasProvided < multiTransferCost {
 		return nil, ErrNotEnoughGas
 	}
@@ -274,18 +404,28 @@ func (e *dctNFTMultiTransfer) processDCTNFTMultiTransferOnSenderShard(
 		GasRemaining: vmInput.GasProvided - multiTransferCost,
 		Logs:         make([]*vmcommon.LogEntry, 0, numOfTransfers),
 	}
+	addGasBreakdownToVMOutput(vmInput, vmOutput, vmcommon.GasBreakdown{TransferCost: multiTransferCost})
 
 	startIndex := uint64(2)
 	listDctData := make([]*dct.DCToken, numOfTransfers)
 	listTransferData := make([]*vmcommon.DCTTransfer, numOfTransfers)
 
 	for i := uint64(0); i < numOfTransfers; i++ {
+		err = ctx.Err()
+		if err != nil {
+			return nil, err
+		}
+
 		tokenStartIndex := startIndex + i*argumentsPerTransfer
+		nonce, err := flaggedUint64Argument(e.enableEpochsHandler, vmInput.Arguments, int(tokenStartIndex+1))
+		if err != nil {
+			return nil, err
+		}
 		listTransferData[i] = &vmcommon.DCTTransfer{
 			DCTValue:      big.NewInt(0).SetBytes(vmInput.Arguments[tokenStartIndex+2]),
 			DCTTokenName:  vmInput.Arguments[tokenStartIndex],
 			DCTTokenType:  0,
-			DCTTokenNonce: big.NewInt(0).SetBytes(vmInput.Arguments[tokenStartIndex+1]).Uint64(),
+			DCTTokenNonce: nonce,
 		}
 		if listTransferData[i].DCTTokenNonce > 0 {
 			listTransferData[i].DCTTokenType = uint32(core.NonFungible)
@@ -300,6 +440,9 @@ func (e *dctNFTMultiTransfer) processDCTNFTMultiTransferOnSenderShard(
 		if err != nil {
 			return nil, fmt.Errorf("%w for token %s", err, string(listTransferData[i].DCTTokenName))
 		}
+		if listDctData[i].Type == vmcommon.MetaFungible {
+			listTransferData[i].DCTTokenType = vmcommon.MetaFungible
+		}
 
 		addDCTEntryInVMOutput(vmOutput, []byte(core.BuiltInFunctionMultiDCTNFTTransfer), listTransferData[i].DCTTokenName, listTransferData[i].DCTTokenNonce, listTransferData[i].DCTValue, vmInput.CallerAddr, dstAddress)
 	}
@@ -319,6 +462,30 @@ func (e *dctNFTMultiTransfer) processDCTNFTMultiTransferOnSenderShard(
 	return vmOutput, nil
 }
 
+func (e *dctNFTMultiTransfer) computeMultiTransferCost(numOfTransfers uint64, arguments [][]byte) (uint64, error) {
+	if !e.enableEpochsHandler.IsMultiTransferGasRepriceFlagEnabled() {
+		return numOfTransfers * e.funcGasCost, nil
+	}
+
+	dataLength := uint64(0)
+	for _, arg := range arguments {
+		dataLength += uint64(len(arg))
+	}
+	gasCost := vmcommon.GasCost{
+		BaseOperationCost: e.gasConfig,
+		BuiltInCost: vmcommon.BuiltInCost{
+			DCTNFTMultiTransfer: e.funcGasCost,
+			DCTNFTTransfer:      e.transferGasCost,
+		},
+	}
+	multiTransferCost, err := vmcommon.ComputeMultiTransferGas(numOfTransfers, dataLength, gasCost)
+	if err != nil {
+		return 0, fmt.Errorf("%w, %s", ErrNotEnoughGas, err.Error())
+	}
+
+	return multiTransferCost, nil
+}
+
 func (e *dctNFTMultiTransfer) transferOneTokenOnSenderShard(
 	acntSnd vmcommon.UserAccountHandler,
 	acntDst vmcommon.UserAccountHandler,
@@ -335,11 +502,27 @@ func (e *dctNFTMultiTransfer) transferOneTokenOnSenderShard(
 	if err != nil {
 		return nil, err
 	}
+	err = checkNFTIsNotRented(dctData, isReturnCallWithError)
+	if err != nil {
+		return nil, err
+	}
+	err = checkMetaDCTValueLength(dctData.Type, transferData.DCTValue.Bytes())
+	if err != nil {
+		return nil, err
+	}
 
 	if dctData.Value.Cmp(transferData.DCTValue) < 0 {
 		return nil, computeInsufficientQuantityDCTError(transferData.DCTTokenName, transferData.DCTTokenNonce)
 	}
+	err = e.checkTransferIsAllowed(acntSnd.AddressBytes(), dstAddress, transferData.DCTTokenName, transferData.DCTTokenNonce, transferData.DCTValue, isReturnCallWithError)
+	if err != nil {
+		return nil, err
+	}
 	dctData.Value.Sub(dctData.Value, transferData.DCTValue)
+	err = e.checkBalanceIsNotLocked(dctData, isReturnCallWithError)
+	if err != nil {
+		return nil, err
+	}
 
 	_, err = e.dctStorageHandler.SaveDCTNFTToken(acntSnd.AddressBytes(), acntSnd, dctTokenKey, transferData.DCTTokenNonce, dctData, false, isReturnCallWithError)
 	if err != nil {
@@ -358,6 +541,11 @@ func (e *dctNFTMultiTransfer) transferOneTokenOnSenderShard(
 		return nil, err
 	}
 
+	err = checkIfTransferCanHappenWithSoulbound(dctTokenKey, e.globalSettingsHandler, acntSnd, isReturnCallWithError)
+	if err != nil {
+		return nil, err
+	}
+
 	if !check.IfNil(acntDst) {
 		err = e.addNFTToDestination(acntSnd.AddressBytes(), dstAddress, acntDst, dctData, dctTokenKey, transferData.DCTTokenNonce, isReturnCallWithError)
 		if err != nil {
@@ -414,7 +602,7 @@ func (e *dctNFTMultiTransfer) createDCTNFTOutputTransfers(
 		multiTransferCallArgs = append(multiTransferCallArgs, dctTransfer.DCTTokenName)
 		nonceAsBytes := []byte{0}
 		if dctTransfer.DCTTokenNonce > 0 {
-			nonceAsBytes = big.NewInt(0).SetUint64(dctTransfer.DCTTokenNonce).Bytes()
+			nonceAsBytes = uint64ToBytes(dctTransfer.DCTTokenNonce)
 		}
 		multiTransferCallArgs = append(multiTransferCallArgs, nonceAsBytes)
 
@@ -437,6 +625,7 @@ func (e *dctNFTMultiTransfer) createDCTNFTOutputTransfers(
 					return ErrNotEnoughGas
 				}
 				vmOutput.GasRemaining -= gasForTransfer
+				addGasBreakdownToVMOutput(vmInput, vmOutput, vmcommon.GasBreakdown{PerByteCost: gasForTransfer})
 
 				multiTransferCallArgs = append(multiTransferCallArgs, marshaledNFTTransfer)
 			} else {
@@ -457,18 +646,22 @@ func (e *dctNFTMultiTransfer) createDCTNFTOutputTransfers(
 
 	if e.shardCoordinator.SelfId() != e.shardCoordinator.ComputeId(dstAddress) {
 		gasToTransfer := uint64(0)
+		gasLocked := vmInput.GasLocked
 		if isSCCallAfter {
-			gasToTransfer = vmOutput.GasRemaining
+			var err error
+			gasToTransfer, gasLocked, err = lockGasForCallback(vmInput, vmOutput.GasRemaining, e.asyncCallbackCost)
+			if err != nil {
+				return err
+			}
 			vmOutput.GasRemaining = 0
 		}
 		addNFTTransferToVMOutput(
-			vmInput.CallerAddr,
+			vmInput,
 			dstAddress,
 			core.BuiltInFunctionMultiDCTNFTTransfer,
 			multiTransferCallArgs,
-			vmInput.GasLocked,
 			gasToTransfer,
-			vmInput.CallType,
+			gasLocked,
 			vmOutput,
 		)
 
@@ -481,13 +674,18 @@ func (e *dctNFTMultiTransfer) createDCTNFTOutputTransfers(
 			callArgs = vmInput.Arguments[minNumOfArguments+1:]
 		}
 
-		addOutputTransferToVMOutput(
-			vmInput.CallerAddr,
+		gasRemaining, gasLocked, err := lockGasForCallback(vmInput, vmOutput.GasRemaining, e.asyncCallbackCost)
+		if err != nil {
+			return err
+		}
+		vmOutput.GasRemaining = gasRemaining
+		addSCCallAfterTransferToVMOutput(
+			vmInput,
 			string(vmInput.Arguments[minNumOfArguments]),
 			callArgs,
 			dstAddress,
-			vmInput.GasLocked,
-			vmInput.CallType,
+			listDCTTransfers,
+			gasLocked,
 			vmOutput)
 	}
 
@@ -507,13 +705,17 @@ func (e *dctNFTMultiTransfer) addNFTToDestination(
 	if err != nil && !errors.Is(err, ErrNFTTokenDoesNotExist) {
 		return err
 	}
-	err = checkFrozeAndPause(dstAddress, dctTokenKey, currentDCTData, e.globalSettingsHandler, isReturnCallWithError)
+	err = checkFrozeAndPause(dstAddress, dctTokenKey, currentDCTData, e.globalSettingsHandler, e.getSystemAddresses(), isReturnCallWithError)
 	if err != nil {
 		return err
 	}
 
 	transferValue := big.NewInt(0).Set(dctDataToTransfer.Value)
 	dctDataToTransfer.Value.Add(dctDataToTransfer.Value, currentDCTData.Value)
+	if e.enableEpochsHandler.IsDCTTransferAndLockFlagEnabled() {
+		// the user metadata, holding the locks, belongs to the receiver and is never taken from the sender
+		dctDataToTransfer.Properties = currentDCTData.Properties
+	}
 	_, err = e.dctStorageHandler.SaveDCTNFTToken(sndAddress, userAccount, dctTokenKey, nonce, dctDataToTransfer, false, isReturnCallWithError)
 	if err != nil {
 		return err
@@ -5,6 +5,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
 	"testing"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/Reshusk23/sr-me-core/core/check"
 	"github.com/Reshusk23/sr-me-core/data/dct"
 	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/dctkeys"
 	"github.com/Reshusk23/sr-vm-common-go/mock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -24,17 +26,17 @@ func createDCTNFTMultiTransferWithStubArguments() *dctNFTMultiTransfer {
 		IsCheckCorrectTokenIDForTransferRoleFlagEnabledField: true,
 	}
 
-	multiTransfer, _ := NewDCTNFTMultiTransferFunc(
-		0,
-		&mock.MarshalizerMock{},
-		&mock.GlobalSettingsHandlerStub{},
-		&mock.AccountsStub{},
-		&mock.ShardCoordinatorStub{},
-		vmcommon.BaseOperationCost{},
-		enableEpochsHandler,
-		&mock.DCTRoleHandlerStub{},
-		createNewDCTDataStorageHandler(),
-	)
+	multiTransfer, _ := NewDCTNFTMultiTransferFunc(ArgsNewDCTNFTMultiTransfer{
+		Config: Config{
+			Marshalizer:           &mock.MarshalizerMock{},
+			Accounts:              &mock.AccountsStub{},
+			ShardCoordinator:      &mock.ShardCoordinatorStub{},
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			DCTStorageHandler:     createNewDCTDataStorageHandler(),
+			EnableEpochsHandler:   enableEpochsHandler,
+		},
+	})
 
 	return multiTransfer
 }
@@ -79,24 +81,25 @@ func createDCTNFTMultiTransferWithMockArguments(selfShard uint32, numShards uint
 		IsTransferToMetaFlagEnabledField:                     false,
 		IsCheckCorrectTokenIDForTransferRoleFlagEnabledField: true,
 	}
-	multiTransfer, _ := NewDCTNFTMultiTransferFunc(
-		1,
-		marshaller,
-		globalSettingsHandler,
-		accounts,
-		shardCoordinator,
-		vmcommon.BaseOperationCost{},
-		enableEpochsHandler,
-		&mock.DCTRoleHandlerStub{
-			CheckAllowedToExecuteCalled: func(account vmcommon.UserAccountHandler, tokenID []byte, action []byte) error {
-				if bytes.Equal(action, []byte(core.DCTRoleTransfer)) {
-					return ErrActionNotAllowed
-				}
-				return nil
+	multiTransfer, _ := NewDCTNFTMultiTransferFunc(ArgsNewDCTNFTMultiTransfer{
+		Config: Config{
+			Marshalizer:           marshaller,
+			Accounts:              accounts,
+			ShardCoordinator:      shardCoordinator,
+			GlobalSettingsHandler: globalSettingsHandler,
+			RolesHandler: &mock.DCTRoleHandlerStub{
+				CheckAllowedToExecuteCalled: func(account vmcommon.UserAccountHandler, tokenID []byte, action []byte) error {
+					if bytes.Equal(action, []byte(core.DCTRoleTransfer)) {
+						return ErrActionNotAllowed
+					}
+					return nil
+				},
 			},
+			DCTStorageHandler:   createNewDCTDataStorageHandlerWithArgs(globalSettingsHandler, accounts, enableEpochsHandler),
+			EnableEpochsHandler: enableEpochsHandler,
 		},
-		createNewDCTDataStorageHandlerWithArgs(globalSettingsHandler, accounts, enableEpochsHandler),
-	)
+		FuncGasCost: 1,
+	})
 
 	return multiTransfer
 }
@@ -107,136 +110,129 @@ func TestNewDCTNFTMultiTransferFunc(t *testing.T) {
 	t.Run("nil marshaller should error", func(t *testing.T) {
 		t.Parallel()
 
-		multiTransfer, err := NewDCTNFTMultiTransferFunc(
-			0,
-			nil,
-			&mock.GlobalSettingsHandlerStub{},
-			&mock.AccountsStub{},
-			&mock.ShardCoordinatorStub{},
-			vmcommon.BaseOperationCost{},
-			&mock.EnableEpochsHandlerStub{},
-			&mock.DCTRoleHandlerStub{},
-			createNewDCTDataStorageHandler(),
-		)
+		multiTransfer, err := NewDCTNFTMultiTransferFunc(ArgsNewDCTNFTMultiTransfer{
+			Config: Config{
+				Accounts:              &mock.AccountsStub{},
+				ShardCoordinator:      &mock.ShardCoordinatorStub{},
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+				DCTStorageHandler:     createNewDCTDataStorageHandler(),
+				EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			},
+		})
 		assert.True(t, check.IfNil(multiTransfer))
-		assert.Equal(t, ErrNilMarshalizer, err)
+		assert.ErrorIs(t, err, ErrNilMarshalizer)
 	})
 	t.Run("nil global settings should error", func(t *testing.T) {
 		t.Parallel()
 
-		multiTransfer, err := NewDCTNFTMultiTransferFunc(
-			0,
-			&mock.MarshalizerMock{},
-			nil,
-			&mock.AccountsStub{},
-			&mock.ShardCoordinatorStub{},
-			vmcommon.BaseOperationCost{},
-			&mock.EnableEpochsHandlerStub{},
-			&mock.DCTRoleHandlerStub{},
-			createNewDCTDataStorageHandler(),
-		)
+		multiTransfer, err := NewDCTNFTMultiTransferFunc(ArgsNewDCTNFTMultiTransfer{
+			Config: Config{
+				Marshalizer:         &mock.MarshalizerMock{},
+				Accounts:            &mock.AccountsStub{},
+				ShardCoordinator:    &mock.ShardCoordinatorStub{},
+				RolesHandler:        &mock.DCTRoleHandlerStub{},
+				DCTStorageHandler:   createNewDCTDataStorageHandler(),
+				EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+			},
+		})
 		assert.True(t, check.IfNil(multiTransfer))
-		assert.Equal(t, ErrNilGlobalSettingsHandler, err)
+		assert.ErrorIs(t, err, ErrNilGlobalSettingsHandler)
 	})
 	t.Run("nil accounts adapter should error", func(t *testing.T) {
 		t.Parallel()
 
-		multiTransfer, err := NewDCTNFTMultiTransferFunc(
-			0,
-			&mock.MarshalizerMock{},
-			&mock.GlobalSettingsHandlerStub{},
-			nil,
-			&mock.ShardCoordinatorStub{},
-			vmcommon.BaseOperationCost{},
-			&mock.EnableEpochsHandlerStub{},
-			&mock.DCTRoleHandlerStub{},
-			createNewDCTDataStorageHandler(),
-		)
+		multiTransfer, err := NewDCTNFTMultiTransferFunc(ArgsNewDCTNFTMultiTransfer{
+			Config: Config{
+				Marshalizer:           &mock.MarshalizerMock{},
+				ShardCoordinator:      &mock.ShardCoordinatorStub{},
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+				DCTStorageHandler:     createNewDCTDataStorageHandler(),
+				EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			},
+		})
 		assert.True(t, check.IfNil(multiTransfer))
-		assert.Equal(t, ErrNilAccountsAdapter, err)
+		assert.ErrorIs(t, err, ErrNilAccountsAdapter)
 	})
 	t.Run("nil shard coordinator should error", func(t *testing.T) {
 		t.Parallel()
 
-		multiTransfer, err := NewDCTNFTMultiTransferFunc(
-			0,
-			&mock.MarshalizerMock{},
-			&mock.GlobalSettingsHandlerStub{},
-			&mock.AccountsStub{},
-			nil,
-			vmcommon.BaseOperationCost{},
-			&mock.EnableEpochsHandlerStub{},
-			&mock.DCTRoleHandlerStub{},
-			createNewDCTDataStorageHandler(),
-		)
+		multiTransfer, err := NewDCTNFTMultiTransferFunc(ArgsNewDCTNFTMultiTransfer{
+			Config: Config{
+				Marshalizer:           &mock.MarshalizerMock{},
+				Accounts:              &mock.AccountsStub{},
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+				DCTStorageHandler:     createNewDCTDataStorageHandler(),
+				EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			},
+		})
 		assert.True(t, check.IfNil(multiTransfer))
-		assert.Equal(t, ErrNilShardCoordinator, err)
+		assert.ErrorIs(t, err, ErrNilShardCoordinator)
 	})
 	t.Run("nil enable epochs handler should error", func(t *testing.T) {
 		t.Parallel()
 
-		multiTransfer, err := NewDCTNFTMultiTransferFunc(
-			0,
-			&mock.MarshalizerMock{},
-			&mock.GlobalSettingsHandlerStub{},
-			&mock.AccountsStub{},
-			&mock.ShardCoordinatorStub{},
-			vmcommon.BaseOperationCost{},
-			nil,
-			&mock.DCTRoleHandlerStub{},
-			createNewDCTDataStorageHandler(),
-		)
+		multiTransfer, err := NewDCTNFTMultiTransferFunc(ArgsNewDCTNFTMultiTransfer{
+			Config: Config{
+				Marshalizer:           &mock.MarshalizerMock{},
+				Accounts:              &mock.AccountsStub{},
+				ShardCoordinator:      &mock.ShardCoordinatorStub{},
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+				DCTStorageHandler:     createNewDCTDataStorageHandler(),
+			},
+		})
 		assert.True(t, check.IfNil(multiTransfer))
-		assert.Equal(t, ErrNilEnableEpochsHandler, err)
+		assert.ErrorIs(t, err, ErrNilEnableEpochsHandler)
 	})
 	t.Run("nil roles handler should error", func(t *testing.T) {
 		t.Parallel()
 
-		multiTransfer, err := NewDCTNFTMultiTransferFunc(
-			0,
-			&mock.MarshalizerMock{},
-			&mock.GlobalSettingsHandlerStub{},
-			&mock.AccountsStub{},
-			&mock.ShardCoordinatorStub{},
-			vmcommon.BaseOperationCost{},
-			&mock.EnableEpochsHandlerStub{},
-			nil,
-			createNewDCTDataStorageHandler(),
-		)
+		multiTransfer, err := NewDCTNFTMultiTransferFunc(ArgsNewDCTNFTMultiTransfer{
+			Config: Config{
+				Marshalizer:           &mock.MarshalizerMock{},
+				Accounts:              &mock.AccountsStub{},
+				ShardCoordinator:      &mock.ShardCoordinatorStub{},
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				DCTStorageHandler:     createNewDCTDataStorageHandler(),
+				EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			},
+		})
 		assert.True(t, check.IfNil(multiTransfer))
-		assert.Equal(t, ErrNilRolesHandler, err)
+		assert.ErrorIs(t, err, ErrNilRolesHandler)
 	})
 	t.Run("nil storage handler should error", func(t *testing.T) {
 		t.Parallel()
 
-		multiTransfer, err := NewDCTNFTMultiTransferFunc(
-			0,
-			&mock.MarshalizerMock{},
-			&mock.GlobalSettingsHandlerStub{},
-			&mock.AccountsStub{},
-			&mock.ShardCoordinatorStub{},
-			vmcommon.BaseOperationCost{},
-			&mock.EnableEpochsHandlerStub{},
-			&mock.DCTRoleHandlerStub{},
-			nil,
-		)
+		multiTransfer, err := NewDCTNFTMultiTransferFunc(ArgsNewDCTNFTMultiTransfer{
+			Config: Config{
+				Marshalizer:           &mock.MarshalizerMock{},
+				Accounts:              &mock.AccountsStub{},
+				ShardCoordinator:      &mock.ShardCoordinatorStub{},
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+				EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			},
+		})
 		assert.True(t, check.IfNil(multiTransfer))
-		assert.Equal(t, ErrNilDCTNFTStorageHandler, err)
+		assert.ErrorIs(t, err, ErrNilDCTNFTStorageHandler)
 	})
 	t.Run("should work", func(t *testing.T) {
 		t.Parallel()
 
-		multiTransfer, err := NewDCTNFTMultiTransferFunc(
-			0,
-			&mock.MarshalizerMock{},
-			&mock.GlobalSettingsHandlerStub{},
-			&mock.AccountsStub{},
-			&mock.ShardCoordinatorStub{},
-			vmcommon.BaseOperationCost{},
-			&mock.EnableEpochsHandlerStub{},
-			&mock.DCTRoleHandlerStub{},
-			createNewDCTDataStorageHandler(),
-		)
+		multiTransfer, err := NewDCTNFTMultiTransferFunc(ArgsNewDCTNFTMultiTransfer{
+			Config: Config{
+				Marshalizer:           &mock.MarshalizerMock{},
+				Accounts:              &mock.AccountsStub{},
+				ShardCoordinator:      &mock.ShardCoordinatorStub{},
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+				DCTStorageHandler:     createNewDCTDataStorageHandler(),
+				EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			},
+		})
 		assert.False(t, check.IfNil(multiTransfer))
 		assert.Nil(t, err)
 	})
@@ -266,6 +262,7 @@ func TestDCTNFTMultiTransfer_SetNewGasConfig(t *testing.T) {
 	gasCost := createMockGasCost()
 	multiTransfer.SetNewGasConfig(&gasCost)
 	assert.Equal(t, gasCost.BuiltInCost.DCTNFTMultiTransfer, multiTransfer.funcGasCost)
+	assert.Equal(t, gasCost.BuiltInCost.DCTNFTTransfer, multiTransfer.transferGasCost)
 	assert.Equal(t, gasCost.BaseOperationCost, multiTransfer.gasConfig)
 }
 
@@ -307,6 +304,47 @@ func TestDCTNFTMultiTransfer_ProcessBuiltinFunctionInvalidArgumentsShouldErr(t *
 	assert.Equal(t, ErrInvalidRcvAddr, err)
 }
 
+func TestDCTNFTMultiTransfer_CheckIsExecutable(t *testing.T) {
+	t.Parallel()
+
+	multiTransfer := createDCTNFTMultiTransferWithStubArguments()
+	multiTransfer.funcGasCost = 10
+	err := multiTransfer.CheckIsExecutable(nil)
+	assert.Equal(t, ErrNilVmInput, err)
+
+	senderAddress := bytes.Repeat([]byte{2}, 32)
+	destinationAddress := bytes.Repeat([]byte{1}, 32)
+	quantityBytes := big.NewInt(1).Bytes()
+	vmInput := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallValue:   big.NewInt(0),
+			CallerAddr:  senderAddress,
+			Arguments:   [][]byte{destinationAddress, big.NewInt(0).Bytes(), []byte("token1"), big.NewInt(1).Bytes(), quantityBytes},
+			GasProvided: 20,
+		},
+		RecipientAddr: senderAddress,
+	}
+	err = multiTransfer.CheckIsExecutable(vmInput)
+	assert.ErrorIs(t, err, ErrInvalidArguments)
+
+	vmInput.Arguments[1] = big.NewInt(2).Bytes()
+	err = multiTransfer.CheckIsExecutable(vmInput)
+	assert.ErrorIs(t, err, ErrInvalidArguments)
+
+	vmInput.Arguments = append(vmInput.Arguments, []byte("token2"), big.NewInt(0).Bytes(), quantityBytes)
+	vmInput.GasProvided = 19
+	err = multiTransfer.CheckIsExecutable(vmInput)
+	assert.Equal(t, ErrNotEnoughGas, err)
+
+	vmInput.GasProvided = 20
+	err = multiTransfer.CheckIsExecutable(vmInput)
+	assert.Nil(t, err)
+
+	vmInput.Arguments[0] = senderAddress
+	err = multiTransfer.CheckIsExecutable(vmInput)
+	assert.ErrorIs(t, err, ErrInvalidArguments)
+}
+
 func TestDCTNFTMultiTransfer_ProcessBuiltinFunctionOnSameShardWithScCall(t *testing.T) {
 	t.Parallel()
 
@@ -390,6 +428,122 @@ func TestDCTNFTMultiTransfer_ProcessBuiltinFunctionOnSameShardWithScCall(t *test
 	require.Equal(t, []byte(scCallArg), args[0])
 }
 
+func TestDCTNFTMultiTransfer_ProcessBuiltinFunctionLogsFollowTheArgumentsOrder(t *testing.T) {
+	t.Parallel()
+
+	senderAddress := bytes.Repeat([]byte{2}, 32)
+	destinationAddress := bytes.Repeat([]byte{1}, 32)
+	token1 := []byte("token1")
+	token2 := []byte("token2")
+	tokenNonce := uint64(1)
+
+	transfer := func() *vmcommon.VMOutput {
+		multiTransfer := createDCTNFTMultiTransferWithMockArguments(0, 1, &mock.GlobalSettingsHandlerStub{})
+		sender, err := multiTransfer.accounts.LoadAccount(senderAddress)
+		require.Nil(t, err)
+		destination, err := multiTransfer.accounts.LoadAccount(destinationAddress)
+		require.Nil(t, err)
+
+		createDCTNFTToken(token1, core.NonFungible, tokenNonce, big.NewInt(3), multiTransfer.marshaller, sender.(vmcommon.UserAccountHandler))
+		createDCTNFTToken(token2, core.Fungible, 0, big.NewInt(3), multiTransfer.marshaller, sender.(vmcommon.UserAccountHandler))
+		_ = multiTransfer.accounts.SaveAccount(sender)
+		_, _ = multiTransfer.accounts.Commit()
+
+		sender, err = multiTransfer.accounts.LoadAccount(senderAddress)
+		require.Nil(t, err)
+
+		vmInput := &vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallValue:   big.NewInt(0),
+				CallerAddr:  senderAddress,
+				Arguments:   [][]byte{destinationAddress, big.NewInt(2).Bytes(), token2, big.NewInt(0).Bytes(), big.NewInt(1).Bytes(), token1, big.NewInt(int64(tokenNonce)).Bytes(), big.NewInt(1).Bytes()},
+				GasProvided: 100000,
+			},
+			RecipientAddr: senderAddress,
+		}
+		vmOutput, err := multiTransfer.ProcessBuiltinFunction(sender.(vmcommon.UserAccountHandler), destination.(vmcommon.UserAccountHandler), vmInput)
+		require.Nil(t, err)
+		vmOutput.Sort()
+
+		return vmOutput
+	}
+
+	vmOutput := transfer()
+	require.Len(t, vmOutput.Logs, 2)
+	assert.Equal(t, token2, vmOutput.Logs[0].Topics[0])
+	assert.Equal(t, token1, vmOutput.Logs[1].Topics[0])
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, vmOutput.Logs, transfer().Logs)
+	}
+}
+
+func TestDCTNFTMultiTransfer_ProcessBuiltinFunctionKeepsTheLocksOfEachAccount(t *testing.T) {
+	t.Parallel()
+
+	senderAddress := bytes.Repeat([]byte{2}, 32)
+	destinationAddress := bytes.Repeat([]byte{1}, 32)
+	destinationAddress[31] = 0
+	token := []byte("token")
+	dctTokenKey := append(keyPrefix, token...)
+	lockedMetadata := func(value int64, unlockEpoch uint32) []byte {
+		metadata := DCTUserMetadata{Locks: []DCTLock{{Value: big.NewInt(value), UnlockEpoch: unlockEpoch}}}
+		return metadata.ToBytes()
+	}
+
+	transfer := func(senderProperties []byte, destinationProperties []byte) *dct.DCToken {
+		multiTransfer := createDCTNFTMultiTransferWithMockArguments(0, 1, &mock.GlobalSettingsHandlerStub{})
+		multiTransfer.enableEpochsHandler.(*mock.EnableEpochsHandlerStub).IsDCTTransferAndLockFlagEnabledField = true
+		multiTransfer.enableEpochsHandler.(*mock.EnableEpochsHandlerStub).CurrentEpochField = 5
+		_ = multiTransfer.SetPayableChecker(&mock.PayableHandlerStub{})
+		sender, err := multiTransfer.accounts.LoadAccount(senderAddress)
+		require.Nil(t, err)
+		destination, err := multiTransfer.accounts.LoadAccount(destinationAddress)
+		require.Nil(t, err)
+
+		saveBalance := func(account vmcommon.AccountHandler, value int64, properties []byte) {
+			marshaledData, _ := multiTransfer.marshaller.Marshal(&dct.DCToken{Value: big.NewInt(value), Properties: properties})
+			_ = account.(vmcommon.UserAccountHandler).AccountDataHandler().SaveKeyValue(dctTokenKey, marshaledData)
+		}
+		saveBalance(sender, 100, senderProperties)
+		saveBalance(destination, 40, destinationProperties)
+		_ = multiTransfer.accounts.SaveAccount(destination)
+
+		vmInput := &vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallValue:   big.NewInt(0),
+				CallerAddr:  senderAddress,
+				Arguments:   [][]byte{destinationAddress, big.NewInt(1).Bytes(), token, big.NewInt(0).Bytes(), big.NewInt(1).Bytes()},
+				GasProvided: 100000,
+			},
+			RecipientAddr: senderAddress,
+		}
+		_, err = multiTransfer.ProcessBuiltinFunction(sender.(vmcommon.UserAccountHandler), destination.(vmcommon.UserAccountHandler), vmInput)
+		require.Nil(t, err)
+
+		destination, err = multiTransfer.accounts.LoadAccount(destinationAddress)
+		require.Nil(t, err)
+		dctData, err := getDCTDataFromKey(destination.(vmcommon.UserAccountHandler), dctTokenKey, multiTransfer.marshaller)
+		require.Nil(t, err)
+
+		return dctData
+	}
+
+	t.Run("the receiver keeps its locks", func(t *testing.T) {
+		t.Parallel()
+
+		dctData := transfer(nil, lockedMetadata(30, 10))
+		assert.Equal(t, big.NewInt(41), dctData.Value)
+		assert.Equal(t, lockedMetadata(30, 10), dctData.Properties)
+	})
+	t.Run("the locks of the sender are not copied to the receiver", func(t *testing.T) {
+		t.Parallel()
+
+		dctData := transfer(lockedMetadata(20, 10), nil)
+		assert.Equal(t, big.NewInt(41), dctData.Value)
+		assert.Empty(t, dctData.Properties)
+	})
+}
+
 func TestDCTNFTMultiTransfer_ProcessBuiltinFunctionOnCrossShardsDestinationDoesNotHoldingNFTWithSCCall(t *testing.T) {
 	t.Parallel()
 
@@ -485,6 +639,52 @@ func TestDCTNFTMultiTransfer_ProcessBuiltinFunctionOnCrossShardsDestinationDoesN
 	require.Equal(t, []byte(scCallArg), args[0])
 }
 
+func TestDCTNFTMultiTransfer_ProcessBuiltinFunctionSameShardCallReceivers(t *testing.T) {
+	t.Parallel()
+
+	multiTransfer := createDCTNFTMultiTransferWithMockArguments(1, 2, &mock.GlobalSettingsHandlerStub{})
+	multiTransfer.SetSameShardCallReceivers(true)
+
+	senderAddress := bytes.Repeat([]byte{1}, 32)
+	crossShardDestination := bytes.Repeat([]byte{0}, 32)
+	crossShardDestination[25] = 1
+	sender, err := multiTransfer.accounts.LoadAccount(senderAddress)
+	require.Nil(t, err)
+
+	token := []byte("token")
+	createDCTNFTToken(token, core.Fungible, 0, big.NewInt(3), multiTransfer.marshaller, sender.(vmcommon.UserAccountHandler))
+
+	createInput := func(destination []byte, scCallArgs ...[]byte) *vmcommon.ContractCallInput {
+		arguments := [][]byte{destination, big.NewInt(1).Bytes(), token, big.NewInt(0).Bytes(), big.NewInt(1).Bytes()}
+		return &vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallValue:   big.NewInt(0),
+				CallerAddr:  senderAddress,
+				Arguments:   append(arguments, scCallArgs...),
+				GasProvided: 1000000,
+			},
+			RecipientAddr: senderAddress,
+		}
+	}
+
+	_, err = multiTransfer.ProcessBuiltinFunction(sender.(vmcommon.UserAccountHandler), nil, createInput(crossShardDestination, []byte("functionToCall")))
+	require.ErrorIs(t, err, ErrReceiversInAnotherShard)
+	shardErr, ok := err.(*ReceiversShardError)
+	require.True(t, ok)
+	assert.Equal(t, uint32(1), shardErr.ShardID)
+	assert.Equal(t, []int{0}, shardErr.Indices)
+	assert.Equal(t, ErrReceiversInAnotherShard.Error(), vmcommon.ReturnMessageFromError(err))
+
+	vmOutput, err := multiTransfer.ProcessBuiltinFunction(sender.(vmcommon.UserAccountHandler), nil, createInput(crossShardDestination))
+	require.Nil(t, err)
+	assert.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+
+	multiTransfer.SetSameShardCallReceivers(false)
+	vmOutput, err = multiTransfer.ProcessBuiltinFunction(sender.(vmcommon.UserAccountHandler), nil, createInput(crossShardDestination, []byte("functionToCall")))
+	require.Nil(t, err)
+	assert.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+}
+
 func TestDCTNFTMultiTransfer_ProcessBuiltinFunctionOnCrossShardsDestinationAddToDctBalanceShouldErr(t *testing.T) {
 	t.Parallel()
 
@@ -914,7 +1114,7 @@ func TestDCTNFTMultiTransfer_SndDstFrozen(t *testing.T) {
 
 	destination, _ := transferFunc.accounts.LoadAccount(destinationAddress)
 	tokenId := append(keyPrefix, token1...)
-	dctKey := computeDCTNFTTokenKey(tokenId, tokenNonce)
+	dctKey := dctkeys.ComputeDCTNFTTokenKey(tokenId, tokenNonce)
 	dctToken := &dct.DCToken{Value: big.NewInt(0), Properties: dctFrozen.ToBytes()}
 	marshaledData, _ := transferFunc.marshaller.Marshal(dctToken)
 	_ = destination.(vmcommon.UserAccountHandler).AccountDataHandler().SaveKeyValue(dctKey, marshaledData)
@@ -980,11 +1180,87 @@ func TestDCTNFTMultiTransfer_NotEnoughGas(t *testing.T) {
 	assert.Equal(t, err, ErrNotEnoughGas)
 }
 
+func TestDCTNFTMultiTransfer_RepricedGas(t *testing.T) {
+	t.Parallel()
+
+	createArguments := func(numTransfers int, destinationAddress []byte) [][]byte {
+		arguments := [][]byte{destinationAddress, big.NewInt(int64(numTransfers)).Bytes()}
+		for i := 0; i < numTransfers; i++ {
+			arguments = append(arguments, []byte("token1"), nil, big.NewInt(1).Bytes())
+		}
+		return arguments
+	}
+	argumentsLength := func(arguments [][]byte) uint64 {
+		length := uint64(0)
+		for _, arg := range arguments {
+			length += uint64(len(arg))
+		}
+		return length
+	}
+
+	for _, repriceEnabled := range []bool{false, true} {
+		repriceEnabled := repriceEnabled
+		t.Run(fmt.Sprintf("reprice enabled %v", repriceEnabled), func(t *testing.T) {
+			t.Parallel()
+
+			multiTransfer := createDCTNFTMultiTransferWithMockArguments(0, 2, &mock.GlobalSettingsHandlerStub{})
+			_ = multiTransfer.SetPayableChecker(&mock.PayableHandlerStub{})
+			multiTransfer.enableEpochsHandler.(*mock.EnableEpochsHandlerStub).IsMultiTransferGasRepriceFlagEnabledField = repriceEnabled
+			gasCost := createMockGasCost()
+			multiTransfer.SetNewGasConfig(&gasCost)
+
+			senderAddress := bytes.Repeat([]byte{2}, 32)
+			senderAddress[31] = 0
+			destinationAddress := bytes.Repeat([]byte{1}, 32)
+			destinationAddress[31] = 0
+			sender, err := multiTransfer.accounts.LoadAccount(senderAddress)
+			require.Nil(t, err)
+			createDCTNFTToken([]byte("token1"), core.Fungible, 0, big.NewInt(100), multiTransfer.marshaller, sender.(vmcommon.UserAccountHandler))
+
+			arguments := createArguments(10, destinationAddress)
+			expectedCost := 10 * gasCost.BuiltInCost.DCTNFTMultiTransfer
+			if repriceEnabled {
+				expectedCost, err = vmcommon.ComputeMultiTransferGas(10, argumentsLength(arguments), gasCost)
+				require.Nil(t, err)
+			}
+
+			vmInput := &vmcommon.ContractCallInput{
+				VMInput: vmcommon.VMInput{
+					CallValue:   big.NewInt(0),
+					CallerAddr:  senderAddress,
+					Arguments:   arguments,
+					GasProvided: expectedCost - 1,
+				},
+				RecipientAddr: senderAddress,
+			}
+			_, err = multiTransfer.ProcessBuiltinFunction(sender.(vmcommon.UserAccountHandler), nil, vmInput)
+			assert.Equal(t, ErrNotEnoughGas, err)
+
+			vmInput.GasProvided = expectedCost + 1
+			vmOutput, err := multiTransfer.ProcessBuiltinFunction(sender.(vmcommon.UserAccountHandler), nil, vmInput)
+			require.Nil(t, err)
+			assert.Equal(t, uint64(1), vmOutput.GasRemaining)
+		})
+	}
+	t.Run("overflowing cost should err", func(t *testing.T) {
+		t.Parallel()
+
+		multiTransfer := createDCTNFTMultiTransferWithMockArguments(0, 2, &mock.GlobalSettingsHandlerStub{})
+		multiTransfer.enableEpochsHandler.(*mock.EnableEpochsHandlerStub).IsMultiTransferGasRepriceFlagEnabledField = true
+		multiTransfer.transferGasCost = math.MaxUint64
+
+		_, err := multiTransfer.computeMultiTransferCost(2, nil)
+		assert.ErrorIs(t, err, ErrNotEnoughGas)
+	})
+}
+
 func TestDCTNFTMultiTransfer_WithAndValue(t *testing.T) {
 	t.Parallel()
 
 	transferFunc := createDCTNFTMultiTransferWithMockArguments(0, 1, &mock.GlobalSettingsHandlerStub{})
 	_ = transferFunc.SetPayableChecker(&mock.PayableHandlerStub{})
+	// only DCTTransfer accepts native value
+	transferFunc.enableEpochsHandler.(*mock.EnableEpochsHandlerStub).IsDCTTransferWithValueFlagEnabledField = true
 
 	senderAddress := bytes.Repeat([]byte{2}, 32)
 	destinationAddress := bytes.Repeat([]byte{1}, 32)
@@ -1019,6 +1295,7 @@ func TestDCTNFTMultiTransfer_WithAndValue(t *testing.T) {
 	output, err := transferFunc.ProcessBuiltinFunction(sender.(vmcommon.UserAccountHandler), sender.(vmcommon.UserAccountHandler), vmInput)
 	require.Nil(t, output)
 	require.Equal(t, ErrBuiltInFunctionCalledWithValue, err)
+	require.Equal(t, ErrBuiltInFunctionCalledWithValue, transferFunc.CheckIsExecutable(vmInput))
 }
 
 func TestComputeInsufficientQuantityDCTError(t *testing.T) {
@@ -1032,3 +1309,43 @@ func TestComputeInsufficientQuantityDCTError(t *testing.T) {
 	require.NotNil(t, resErr)
 	require.Equal(t, errors.New("insufficient quantity for token: my-token-2 nonce 5").Error(), resErr.Error())
 }
+
+func BenchmarkDCTNFTMultiTransfer_ProcessBuiltinFunction(b *testing.B) {
+	multiTransfer := createDCTNFTMultiTransferWithMockArguments(0, 2, &mock.GlobalSettingsHandlerStub{})
+	_ = multiTransfer.SetPayableChecker(&mock.PayableHandlerStub{})
+	multiTransfer.enableEpochsHandler.(*mock.EnableEpochsHandlerStub).IsMultiTransferGasRepriceFlagEnabledField = true
+	gasCost := createMockGasCost()
+	multiTransfer.SetNewGasConfig(&gasCost)
+
+	senderAddress := bytes.Repeat([]byte{2}, 32)
+	senderAddress[31] = 0
+	destinationAddress := bytes.Repeat([]byte{1}, 32)
+	destinationAddress[31] = 0
+	sender, _ := multiTransfer.accounts.LoadAccount(senderAddress)
+	userSender := sender.(vmcommon.UserAccountHandler)
+
+	numTransfers := 100
+	arguments := [][]byte{destinationAddress, big.NewInt(int64(numTransfers)).Bytes()}
+	for i := 0; i < numTransfers; i++ {
+		tokenID := []byte(fmt.Sprintf("token%d", i))
+		createDCTNFTToken(tokenID, core.Fungible, 0, big.NewInt(math.MaxInt64), multiTransfer.marshaller, userSender)
+		arguments = append(arguments, tokenID, nil, big.NewInt(1).Bytes())
+	}
+	vmInput := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallValue:   big.NewInt(0),
+			CallerAddr:  senderAddress,
+			Arguments:   arguments,
+			GasProvided: math.MaxUint64,
+		},
+		RecipientAddr: senderAddress,
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := multiTransfer.ProcessBuiltinFunction(userSender, nil, vmInput)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package builtInFunctions
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"sync"
+
+	"github.com/Reshusk23/sr-me-core/core/check"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/protectedkeys"
+	"github.com/Reshusk23/sr-vm-common-go/tokenident"
+)
+
+var multiSigNonceKeyPrefix = []byte(protectedkeys.MultiSigNoncePrefix)
+
+// multiSigFunction wraps a token management built-in function and, for the tokens configured as multisig managed,
+// requires the signatures appended to the arguments to be verified before calling the wrapped function. The last
+// argument holds the number of signatures, which precede it. The signed message is bound to the chain ID and to a
+// nonce kept on the system account for each token and recipient, incremented on each successful call, so the
+// signatures can not be replayed.
+type multiSigFunction struct {
+	baseFunctionWrapper
+	baseSystemAddressesHandler
+	globalSettingsHandler vmcommon.ExtendedDCTGlobalSettingsHandler
+	accounts              vmcommon.AccountsAdapter
+	chainID               []byte
+	mutVerifier           sync.RWMutex
+	multiSigVerifier      vmcommon.MultiSigVerifier
+	keyPrefix             []byte
+}
+
+func newMultiSigFunction(
+	function vmcommon.BuiltinFunction,
+	globalSettingsHandler vmcommon.ExtendedDCTGlobalSettingsHandler,
+	accounts vmcommon.AccountsAdapter,
+	chainID []byte,
+) *multiSigFunction {
+	return &multiSigFunction{
+		baseFunctionWrapper:   baseFunctionWrapper{function: function},
+		globalSettingsHandler: globalSettingsHandler,
+		accounts:              accounts,
+		chainID:               chainID,
+		keyPrefix:             []byte(baseDCTKeyPrefix),
+	}
+}
+
+// SetSystemAddresses sets the system addresses used to store the multisig nonces and forwards them to the wrapped
+// function
+func (msf *multiSigFunction) SetSystemAddresses(systemAddresses vmcommon.SystemAddresses) error {
+	err := msf.baseSystemAddressesHandler.SetSystemAddresses(systemAddresses)
+	if err != nil {
+		return err
+	}
+
+	return msf.baseFunctionWrapper.SetSystemAddresses(systemAddresses)
+}
+
+// SetMultiSigVerifier sets the verifier of the signatures required by the multisig managed tokens
+func (msf *multiSigFunction) SetMultiSigVerifier(multiSigVerifier vmcommon.MultiSigVerifier) error {
+	if check.IfNil(multiSigVerifier) {
+		return ErrNilMultiSigVerifier
+	}
+
+	msf.mutVerifier.Lock()
+	msf.multiSigVerifier = multiSigVerifier
+	msf.mutVerifier.Unlock()
+
+	return nil
+}
+
+// ProcessBuiltinFunction verifies the signatures for multisig managed tokens and calls the wrapped function
+// without them
+func (msf *multiSigFunction) ProcessBuiltinFunction(
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	return msf.processBuiltinFunctionWithContext(context.Background(), acntSnd, acntDst, vmInput)
+}
+
+func (msf *multiSigFunction) processBuiltinFunctionWithContext(
+	ctx context.Context,
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	if vmInput == nil || len(vmInput.Arguments) == 0 {
+		return callWithContext(ctx, msf.function, acntSnd, acntDst, vmInput)
+	}
+
+	collection, _ := tokenident.SplitCollectionAndNonce(vmInput.Arguments[0])
+	dctTokenKey := append(msf.keyPrefix, collection...)
+	if !msf.globalSettingsHandler.IsMultiSigManaged(dctTokenKey) {
+		return callWithContext(ctx, msf.function, acntSnd, acntDst, vmInput)
+	}
+
+	arguments, signatures, err := splitArgumentsAndSignatures(vmInput.Arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	nonceKey := computeMultiSigNonceKey(collection, vmInput.RecipientAddr)
+	nonce, err := msf.getMultiSigNonce(nonceKey)
+	if err != nil {
+		return nil, err
+	}
+
+	message := computeMultiSigMessage(msf.chainID, nonce, vmInput, arguments)
+	err = msf.verifySignatures(collection, message, signatures)
+	if err != nil {
+		return nil, err
+	}
+
+	inputWithoutSignatures := *vmInput
+	inputWithoutSignatures.Arguments = arguments
+
+	vmOutput, err := callWithContext(ctx, msf.function, acntSnd, acntDst, &inputWithoutSignatures)
+	if err != nil {
+		return nil, err
+	}
+
+	// the system account is loaded again, as the wrapped function might have saved it
+	err = msf.saveMultiSigNonce(nonceKey, nonce+1)
+	if err != nil {
+		vmcommon.ReleaseVMOutput(vmOutput)
+		return nil, err
+	}
+
+	return vmOutput, nil
+}
+
+func (msf *multiSigFunction) getMultiSigNonce(nonceKey []byte) (uint64, error) {
+	systemAcc, err := getSystemAccount(msf.accounts, msf.getSystemAddresses().SystemAccountAddress)
+	if err != nil {
+		return 0, err
+	}
+
+	val, _, err := systemAcc.AccountDataHandler().RetrieveValue(nonceKey)
+	if err != nil {
+		return 0, err
+	}
+
+	return big.NewInt(0).SetBytes(val).Uint64(), nil
+}
+
+func (msf *multiSigFunction) saveMultiSigNonce(nonceKey []byte, nonce uint64) error {
+	systemAcc, err := getSystemAccount(msf.accounts, msf.getSystemAddresses().SystemAccountAddress)
+	if err != nil {
+		return err
+	}
+
+	err = systemAcc.AccountDataHandler().SaveKeyValue(nonceKey, big.NewInt(0).SetUint64(nonce).Bytes())
+	if err != nil {
+		return err
+	}
+
+	return msf.accounts.SaveAccount(systemAcc)
+}
+
+// CheckIsExecutable strips the signatures of the multisig managed tokens and forwards the validation to the wrapped
+// function. The signatures are verified only when processing
+func (msf *multiSigFunction) CheckIsExecutable(vmInput *vmcommon.ContractCallInput) error {
+	if vmInput == nil || len(vmInput.Arguments) == 0 {
+		return checkIsExecutable(msf.function, vmInput)
+	}
+
+	collection, _ := tokenident.SplitCollectionAndNonce(vmInput.Arguments[0])
+	dctTokenKey := append(msf.keyPrefix, collection...)
+	if !msf.globalSettingsHandler.IsMultiSigManaged(dctTokenKey) {
+		return checkIsExecutable(msf.function, vmInput)
+	}
+
+	arguments, _, err := splitArgumentsAndSignatures(vmInput.Arguments)
+	if err != nil {
+		return err
+	}
+
+	inputWithoutSignatures := *vmInput
+	inputWithoutSignatures.Arguments = arguments
+
+	return checkIsExecutable(msf.function, &inputWithoutSignatures)
+}
+
+func (msf *multiSigFunction) verifySignatures(tokenID []byte, message []byte, signatures [][]byte) error {
+	msf.mutVerifier.RLock()
+	defer msf.mutVerifier.RUnlock()
+
+	if check.IfNil(msf.multiSigVerifier) {
+		return ErrMultiSigVerifierNotSet
+	}
+
+	return msf.multiSigVerifier.VerifySignatures(tokenID, message, signatures)
+}
+
+func splitArgumentsAndSignatures(arguments [][]byte) ([][]byte, [][]byte, error) {
+	lastIndex := len(arguments) - 1
+	numSignatures, err := uint64Argument(arguments, lastIndex)
+	if err != nil {
+		return nil, nil, err
+	}
+	if numSignatures == 0 || numSignatures >= uint64(lastIndex) {
+		return nil, nil, ErrInvalidNumberOfSignatures
+	}
+
+	firstSignatureIndex := lastIndex - int(numSignatures)
+	return arguments[:firstSignatureIndex], arguments[firstSignatureIndex:lastIndex], nil
+}
+
+func computeMultiSigNonceKey(tokenID []byte, recipient []byte) []byte {
+	key := make([]byte, 0, len(multiSigNonceKeyPrefix)+len(tokenID)+len(recipient))
+	key = append(key, multiSigNonceKeyPrefix...)
+	key = append(key, tokenID...)
+
+	return append(key, recipient...)
+}
+
+func computeMultiSigMessage(chainID []byte, nonce uint64, vmInput *vmcommon.ContractCallInput, arguments [][]byte) []byte {
+	message := hex.EncodeToString(chainID) + "@" + vmInput.Function + "@" + hex.EncodeToString(vmInput.RecipientAddr)
+	message += "@" + hex.EncodeToString(big.NewInt(0).SetUint64(nonce).Bytes())
+	for _, arg := range arguments {
+		message += "@" + hex.EncodeToString(arg)
+	}
+
+	return []byte(message)
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (msf *multiSigFunction) IsInterfaceNil() bool {
+	return msf == nil
+}
@@ -0,0 +1,193 @@
+package builtInFunctions
+
+import (
+	"errors"
+	"testing"
+
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createMultiSigManagedGlobalSettings(managed bool) *mock.GlobalSettingsHandlerStub {
+	return &mock.GlobalSettingsHandlerStub{
+		IsMultiSigManagedCalled: func(token []byte) bool {
+			return managed
+		},
+	}
+}
+
+func createMultiSigInput(arguments ...[]byte) *vmcommon.ContractCallInput {
+	return &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			Arguments: arguments,
+		},
+		RecipientAddr: []byte{0xaa},
+		Function:      "DCTFreeze",
+	}
+}
+
+func createMultiSigAccounts(systemAcc vmcommon.UserAccountHandler) *mock.AccountsStub {
+	return &mock.AccountsStub{
+		LoadAccountCalled: func(address []byte) (vmcommon.AccountHandler, error) {
+			return systemAcc, nil
+		},
+	}
+}
+
+func TestMultiSigFunction_SetMultiSigVerifier(t *testing.T) {
+	t.Parallel()
+
+	msf := newMultiSigFunction(&mock.BuiltInFunctionStub{}, createMultiSigManagedGlobalSettings(true), createMultiSigAccounts(mock.NewUserAccount(vmcommon.SystemAccountAddress)), []byte("chain"))
+	assert.Equal(t, ErrNilMultiSigVerifier, msf.SetMultiSigVerifier(nil))
+	assert.Nil(t, msf.SetMultiSigVerifier(&mock.MultiSigVerifierStub{}))
+	assert.False(t, msf.IsInterfaceNil())
+}
+
+func TestMultiSigFunction_ProcessBuiltinFunction(t *testing.T) {
+	t.Parallel()
+
+	t.Run("token not multisig managed should forward the input", func(t *testing.T) {
+		t.Parallel()
+
+		input := createMultiSigInput([]byte("TKN-abcdef"), []byte("address"))
+		wasCalled := false
+		msf := newMultiSigFunction(&mock.BuiltInFunctionStub{
+			ProcessBuiltinFunctionCalled: func(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+				wasCalled = true
+				assert.Equal(t, input, vmInput)
+				return &vmcommon.VMOutput{}, nil
+			},
+		}, createMultiSigManagedGlobalSettings(false), createMultiSigAccounts(mock.NewUserAccount(vmcommon.SystemAccountAddress)), []byte("chain"))
+		_ = msf.SetMultiSigVerifier(&mock.MultiSigVerifierStub{
+			VerifySignaturesCalled: func(tokenID []byte, message []byte, signatures [][]byte) error {
+				assert.Fail(t, "should have not been called")
+				return nil
+			},
+		})
+
+		_, err := msf.ProcessBuiltinFunction(nil, nil, input)
+		assert.Nil(t, err)
+		assert.True(t, wasCalled)
+	})
+	t.Run("invalid number of signatures should error", func(t *testing.T) {
+		t.Parallel()
+
+		msf := newMultiSigFunction(&mock.BuiltInFunctionStub{}, createMultiSigManagedGlobalSettings(true), createMultiSigAccounts(mock.NewUserAccount(vmcommon.SystemAccountAddress)), []byte("chain"))
+		_ = msf.SetMultiSigVerifier(&mock.MultiSigVerifierStub{})
+
+		_, err := msf.ProcessBuiltinFunction(nil, nil, createMultiSigInput([]byte("TKN-abcdef"), []byte("sig"), []byte{0}))
+		assert.Equal(t, ErrInvalidNumberOfSignatures, err)
+
+		_, err = msf.ProcessBuiltinFunction(nil, nil, createMultiSigInput([]byte("TKN-abcdef"), []byte("sig"), []byte{2}))
+		assert.Equal(t, ErrInvalidNumberOfSignatures, err)
+	})
+	t.Run("verifier not set should error", func(t *testing.T) {
+		t.Parallel()
+
+		msf := newMultiSigFunction(&mock.BuiltInFunctionStub{}, createMultiSigManagedGlobalSettings(true), createMultiSigAccounts(mock.NewUserAccount(vmcommon.SystemAccountAddress)), []byte("chain"))
+
+		_, err := msf.ProcessBuiltinFunction(nil, nil, createMultiSigInput([]byte("TKN-abcdef"), []byte("sig"), []byte{1}))
+		assert.Equal(t, ErrMultiSigVerifierNotSet, err)
+	})
+	t.Run("verifier error should error", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		msf := newMultiSigFunction(&mock.BuiltInFunctionStub{
+			ProcessBuiltinFunctionCalled: func(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+				assert.Fail(t, "should have not been called")
+				return nil, nil
+			},
+		}, createMultiSigManagedGlobalSettings(true), createMultiSigAccounts(mock.NewUserAccount(vmcommon.SystemAccountAddress)), []byte("chain"))
+		_ = msf.SetMultiSigVerifier(&mock.MultiSigVerifierStub{
+			VerifySignaturesCalled: func(tokenID []byte, message []byte, signatures [][]byte) error {
+				return expectedErr
+			},
+		})
+
+		_, err := msf.ProcessBuiltinFunction(nil, nil, createMultiSigInput([]byte("TKN-abcdef"), []byte("sig"), []byte{1}))
+		assert.Equal(t, expectedErr, err)
+	})
+	t.Run("should verify the signatures and strip them from the arguments", func(t *testing.T) {
+		t.Parallel()
+
+		systemAcc := mock.NewUserAccount(vmcommon.SystemAccountAddress)
+		wasCalled := false
+		msf := newMultiSigFunction(&mock.BuiltInFunctionStub{
+			ProcessBuiltinFunctionCalled: func(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+				wasCalled = true
+				assert.Equal(t, [][]byte{[]byte("TKN-abcdef"), {0x01}}, vmInput.Arguments)
+				return &vmcommon.VMOutput{}, nil
+			},
+		}, &mock.GlobalSettingsHandlerStub{
+			IsMultiSigManagedCalled: func(token []byte) bool {
+				assert.Equal(t, []byte(baseDCTKeyPrefix+"TKN-abcdef"), token)
+				return true
+			},
+		}, createMultiSigAccounts(systemAcc), []byte("chain"))
+		_ = msf.SetMultiSigVerifier(&mock.MultiSigVerifierStub{
+			VerifySignaturesCalled: func(tokenID []byte, message []byte, signatures [][]byte) error {
+				assert.Equal(t, []byte("TKN-abcdef"), tokenID)
+				assert.Equal(t, []byte("636861696e@DCTFreeze@aa@@544b4e2d616263646566@01"), message)
+				assert.Equal(t, [][]byte{[]byte("sig1"), []byte("sig2")}, signatures)
+				return nil
+			},
+		})
+
+		input := createMultiSigInput([]byte("TKN-abcdef"), []byte{0x01}, []byte("sig1"), []byte("sig2"), []byte{2})
+		_, err := msf.ProcessBuiltinFunction(nil, nil, input)
+		require.Nil(t, err)
+		assert.True(t, wasCalled)
+		assert.Equal(t, 5, len(input.Arguments))
+
+		nonceKey := computeMultiSigNonceKey([]byte("TKN-abcdef"), input.RecipientAddr)
+		assert.Equal(t, []byte{1}, systemAcc.Storage[string(nonceKey)])
+	})
+}
+
+func TestMultiSigFunction_SignaturesCanNotBeReplayed(t *testing.T) {
+	t.Parallel()
+
+	systemAcc := mock.NewUserAccount(vmcommon.SystemAccountAddress)
+	wrappedErr := errors.New("wrapped function error")
+	var errWrapped error
+	msf := newMultiSigFunction(&mock.BuiltInFunctionStub{
+		ProcessBuiltinFunctionCalled: func(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+			return &vmcommon.VMOutput{}, errWrapped
+		},
+	}, createMultiSigManagedGlobalSettings(true), createMultiSigAccounts(systemAcc), []byte("chain"))
+
+	var signedMessages [][]byte
+	_ = msf.SetMultiSigVerifier(&mock.MultiSigVerifierStub{
+		VerifySignaturesCalled: func(tokenID []byte, message []byte, signatures [][]byte) error {
+			signedMessages = append(signedMessages, message)
+			return nil
+		},
+	})
+
+	errWrapped = wrappedErr
+	_, err := msf.ProcessBuiltinFunction(nil, nil, createMultiSigInput([]byte("TKN-abcdef"), []byte("sig"), []byte{1}))
+	assert.Equal(t, wrappedErr, err)
+
+	errWrapped = nil
+	_, err = msf.ProcessBuiltinFunction(nil, nil, createMultiSigInput([]byte("TKN-abcdef"), []byte("sig"), []byte{1}))
+	require.Nil(t, err)
+	_, err = msf.ProcessBuiltinFunction(nil, nil, createMultiSigInput([]byte("TKN-abcdef"), []byte("sig"), []byte{1}))
+	require.Nil(t, err)
+
+	require.Equal(t, 3, len(signedMessages))
+	assert.Equal(t, signedMessages[0], signedMessages[1], "a failed call should not consume the nonce")
+	assert.NotEqual(t, signedMessages[1], signedMessages[2], "the signatures of a call should not be valid for the next one")
+
+	otherChain := newMultiSigFunction(&mock.BuiltInFunctionStub{}, createMultiSigManagedGlobalSettings(true), createMultiSigAccounts(mock.NewUserAccount(vmcommon.SystemAccountAddress)), []byte("other chain"))
+	_ = otherChain.SetMultiSigVerifier(&mock.MultiSigVerifierStub{
+		VerifySignaturesCalled: func(tokenID []byte, message []byte, signatures [][]byte) error {
+			assert.NotEqual(t, signedMessages[0], message)
+			return nil
+		},
+	})
+	_, err = otherChain.ProcessBuiltinFunction(nil, nil, createMultiSigInput([]byte("TKN-abcdef"), []byte("sig"), []byte{1}))
+	require.Nil(t, err)
+}
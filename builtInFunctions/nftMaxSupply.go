@@ -0,0 +1,48 @@
+package builtInFunctions
+
+import (
+	"encoding/binary"
+	"math/big"
+
+	"github.com/Reshusk23/sr-vm-common-go/protectedkeys"
+)
+
+const lenMaxSupplyLength = 4
+
+var nftMaxSupplyKeyPrefix = []byte(protectedkeys.NFTMaxSupplyPrefix)
+
+// nftMaxSupply holds the max supply set at the creation of an NFT nonce and the quantity minted so far. Burning does
+// not release supply, the max supply being the size of the edition
+type nftMaxSupply struct {
+	maxSupply *big.Int
+	minted    *big.Int
+}
+
+func computeNFTMaxSupplyKey(tokenID []byte, nonce uint64) []byte {
+	key := append([]byte{}, nftMaxSupplyKeyPrefix...)
+	key = append(key, tokenID...)
+	return append(key, uint64ToBytes(nonce)...)
+}
+
+func (s *nftMaxSupply) toBytes() []byte {
+	maxSupplyBytes := s.maxSupply.Bytes()
+	buff := binary.BigEndian.AppendUint32(nil, uint32(len(maxSupplyBytes)))
+	buff = append(buff, maxSupplyBytes...)
+	return append(buff, s.minted.Bytes()...)
+}
+
+func nftMaxSupplyFromBytes(buff []byte) (*nftMaxSupply, error) {
+	if len(buff) < lenMaxSupplyLength {
+		return nil, ErrInvalidNFTMaxSupplyData
+	}
+	maxSupplyLength := uint64(binary.BigEndian.Uint32(buff[:lenMaxSupplyLength]))
+	if uint64(len(buff)-lenMaxSupplyLength) < maxSupplyLength {
+		return nil, ErrInvalidNFTMaxSupplyData
+	}
+
+	mintedStart := lenMaxSupplyLength + maxSupplyLength
+	return &nftMaxSupply{
+		maxSupply: big.NewInt(0).SetBytes(buff[lenMaxSupplyLength:mintedStart]),
+		minted:    big.NewInt(0).SetBytes(buff[mintedStart:]),
+	}, nil
+}
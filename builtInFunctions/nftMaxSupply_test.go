@@ -0,0 +1,34 @@
+package builtInFunctions
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNftMaxSupply_ToBytesFromBytes(t *testing.T) {
+	t.Parallel()
+
+	supply := &nftMaxSupply{
+		maxSupply: big.NewInt(1000),
+		minted:    big.NewInt(37),
+	}
+	decoded, err := nftMaxSupplyFromBytes(supply.toBytes())
+	require.Nil(t, err)
+	assert.Equal(t, supply, decoded)
+
+	_, err = nftMaxSupplyFromBytes([]byte{0, 0})
+	assert.Equal(t, ErrInvalidNFTMaxSupplyData, err)
+
+	_, err = nftMaxSupplyFromBytes([]byte{0, 0, 0, 5, 1})
+	assert.Equal(t, ErrInvalidNFTMaxSupplyData, err)
+}
+
+func TestComputeNFTMaxSupplyKey(t *testing.T) {
+	t.Parallel()
+
+	key := computeNFTMaxSupplyKey([]byte("token"), 5)
+	assert.Equal(t, append([]byte(nftMaxSupplyKeyPrefix), append([]byte("token"), 5)...), key)
+}
@@ -0,0 +1,29 @@
+package builtInFunctions
+
+import (
+	"encoding/binary"
+
+	"github.com/Reshusk23/sr-vm-common-go/protectedkeys"
+)
+
+const lenRoyaltiesDenominator = 4
+
+var nftRoyaltiesDenominatorKeyPrefix = []byte(protectedkeys.NFTRoyaltiesDenominatorPrefix)
+
+func computeNFTRoyaltiesDenominatorKey(tokenID []byte, nonce uint64) []byte {
+	key := append([]byte{}, nftRoyaltiesDenominatorKeyPrefix...)
+	key = append(key, tokenID...)
+	return append(key, uint64ToBytes(nonce)...)
+}
+
+func nftRoyaltiesDenominatorToBytes(royaltiesDenominator uint32) []byte {
+	return binary.BigEndian.AppendUint32(nil, royaltiesDenominator)
+}
+
+func nftRoyaltiesDenominatorFromBytes(buff []byte) (uint32, error) {
+	if len(buff) != lenRoyaltiesDenominator {
+		return 0, ErrInvalidNFTRoyaltiesDenominatorData
+	}
+
+	return binary.BigEndian.Uint32(buff), nil
+}
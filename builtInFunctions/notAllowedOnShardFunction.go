@@ -0,0 +1,54 @@
+package builtInFunctions
+
+import (
+	"context"
+	"fmt"
+
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+)
+
+// notAllowedOnShardFunction wraps a built-in function disabled on the current shard, rejecting all the calls so the
+// hosts do not need to filter them
+type notAllowedOnShardFunction struct {
+	baseFunctionWrapper
+	name    string
+	shardID uint32
+}
+
+func newNotAllowedOnShardFunction(name string, function vmcommon.BuiltinFunction, shardID uint32) *notAllowedOnShardFunction {
+	return &notAllowedOnShardFunction{
+		baseFunctionWrapper: baseFunctionWrapper{function: function},
+		name:                name,
+		shardID:             shardID,
+	}
+}
+
+// ProcessBuiltinFunction returns ErrFunctionNotAllowedOnShard without calling the wrapped function
+func (naf *notAllowedOnShardFunction) ProcessBuiltinFunction(
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	return naf.processBuiltinFunctionWithContext(context.Background(), acntSnd, acntDst, vmInput)
+}
+
+func (naf *notAllowedOnShardFunction) processBuiltinFunctionWithContext(
+	_ context.Context,
+	_, _ vmcommon.UserAccountHandler,
+	_ *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	return nil, naf.notAllowedError()
+}
+
+// CheckIsExecutable returns ErrFunctionNotAllowedOnShard without calling the wrapped function
+func (naf *notAllowedOnShardFunction) CheckIsExecutable(_ *vmcommon.ContractCallInput) error {
+	return naf.notAllowedError()
+}
+
+func (naf *notAllowedOnShardFunction) notAllowedError() error {
+	return fmt.Errorf("%w, function %s on shard %d", ErrFunctionNotAllowedOnShard, naf.name, naf.shardID)
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (naf *notAllowedOnShardFunction) IsInterfaceNil() bool {
+	return naf == nil
+}
@@ -0,0 +1,45 @@
+package builtInFunctions
+
+import (
+	"testing"
+
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotAllowedOnShardFunction_ProcessBuiltinFunction(t *testing.T) {
+	t.Parallel()
+
+	wasCalled := false
+	naf := newNotAllowedOnShardFunction("key", &mock.BuiltInFunctionStub{
+		ProcessBuiltinFunctionCalled: func(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+			wasCalled = true
+			return &vmcommon.VMOutput{}, nil
+		},
+		IsActiveCalled: func() bool {
+			return true
+		},
+	}, 1)
+
+	vmOutput, err := naf.ProcessBuiltinFunction(nil, nil, &vmcommon.ContractCallInput{})
+	assert.Nil(t, vmOutput)
+	assert.ErrorIs(t, err, ErrFunctionNotAllowedOnShard)
+	assert.Contains(t, err.Error(), "function key on shard 1")
+	assert.False(t, wasCalled)
+	assert.True(t, naf.IsActive())
+}
+
+func TestNotAllowedOnShardFunction_CheckIsExecutable(t *testing.T) {
+	t.Parallel()
+
+	naf := newNotAllowedOnShardFunction("key", &mock.BuiltInFunctionStub{
+		CheckIsExecutableCalled: func(vmInput *vmcommon.ContractCallInput) error {
+			assert.Fail(t, "should not forward the validation")
+			return nil
+		},
+	}, 1)
+
+	err := naf.CheckIsExecutable(&vmcommon.ContractCallInput{})
+	assert.ErrorIs(t, err, ErrFunctionNotAllowedOnShard)
+}
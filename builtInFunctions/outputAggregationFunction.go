@@ -0,0 +1,49 @@
+package builtInFunctions
+
+import (
+	"context"
+
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+)
+
+// outputAggregationFunction wraps a built-in function and merges the output transfers of its output accounts as
+// configured, so a call moving funds to the same receiver many times produces a single smart contract result
+type outputAggregationFunction struct {
+	baseFunctionWrapper
+	config vmcommon.OutputTransferAggregationConfig
+}
+
+func newOutputAggregationFunction(function vmcommon.BuiltinFunction, config vmcommon.OutputTransferAggregationConfig) *outputAggregationFunction {
+	return &outputAggregationFunction{
+		baseFunctionWrapper: baseFunctionWrapper{function: function},
+		config:              config,
+	}
+}
+
+// ProcessBuiltinFunction calls the wrapped function and aggregates the output transfers of the output
+func (oaf *outputAggregationFunction) ProcessBuiltinFunction(
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	return oaf.processBuiltinFunctionWithContext(context.Background(), acntSnd, acntDst, vmInput)
+}
+
+func (oaf *outputAggregationFunction) processBuiltinFunctionWithContext(
+	ctx context.Context,
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	vmOutput, err := callWithContext(ctx, oaf.function, acntSnd, acntDst, vmInput)
+	if err != nil || vmOutput == nil {
+		return vmOutput, err
+	}
+
+	vmOutput.AggregateOutputTransfers(oaf.config)
+
+	return vmOutput, nil
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (oaf *outputAggregationFunction) IsInterfaceNil() bool {
+	return oaf == nil
+}
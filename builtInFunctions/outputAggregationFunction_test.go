@@ -0,0 +1,86 @@
+package builtInFunctions
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func createOutputWithTransfersTo(receiver []byte, values ...int64) *vmcommon.VMOutput {
+	outAcc := &vmcommon.OutputAccount{Address: receiver}
+	for _, value := range values {
+		outAcc.OutputTransfers = append(outAcc.OutputTransfers, vmcommon.OutputTransfer{
+			Value:         big.NewInt(value),
+			GasLimit:      10,
+			SenderAddress: []byte("sender"),
+		})
+	}
+
+	return &vmcommon.VMOutput{
+		ReturnCode:     vmcommon.Ok,
+		OutputAccounts: map[string]*vmcommon.OutputAccount{string(receiver): outAcc},
+	}
+}
+
+func TestOutputAggregationFunction_ProcessBuiltinFunction(t *testing.T) {
+	t.Parallel()
+
+	receiver := []byte("receiver")
+	createFunction := func(vmOutput *vmcommon.VMOutput, err error) *mock.BuiltInFunctionStub {
+		return &mock.BuiltInFunctionStub{
+			ProcessBuiltinFunctionCalled: func(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+				return vmOutput, err
+			},
+		}
+	}
+
+	t.Run("error should be returned as it is", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		oaf := newOutputAggregationFunction(createFunction(nil, expectedErr), vmcommon.OutputTransferAggregationConfig{AggregateValueTransfers: true})
+
+		vmOutput, err := oaf.ProcessBuiltinFunction(nil, nil, &vmcommon.ContractCallInput{})
+		assert.Equal(t, expectedErr, err)
+		assert.Nil(t, vmOutput)
+	})
+	t.Run("output transfers should be merged", func(t *testing.T) {
+		t.Parallel()
+
+		oaf := newOutputAggregationFunction(createFunction(createOutputWithTransfersTo(receiver, 5, 7), nil), vmcommon.OutputTransferAggregationConfig{AggregateValueTransfers: true})
+
+		vmOutput, err := oaf.ProcessBuiltinFunction(nil, nil, &vmcommon.ContractCallInput{})
+		assert.Nil(t, err)
+		outTransfers := vmOutput.OutputAccounts[string(receiver)].OutputTransfers
+		assert.Equal(t, 1, len(outTransfers))
+		assert.Equal(t, big.NewInt(12), outTransfers[0].Value)
+		assert.Equal(t, uint64(20), outTransfers[0].GasLimit)
+	})
+}
+
+func TestBuiltInFunctionContainer_SetOutputTransferAggregationConfig(t *testing.T) {
+	t.Parallel()
+
+	receiver := []byte("receiver")
+	c := NewBuiltInFunctionContainer()
+	_ = c.Add("key", &mock.BuiltInFunctionStub{
+		ProcessBuiltinFunctionCalled: func(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+			return createOutputWithTransfersTo(receiver, 5, 7), nil
+		},
+	})
+
+	function, _ := c.Get("key")
+	vmOutput, err := function.ProcessBuiltinFunction(nil, nil, &vmcommon.ContractCallInput{})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(vmOutput.OutputAccounts[string(receiver)].OutputTransfers))
+
+	c.SetOutputTransferAggregationConfig(vmcommon.OutputTransferAggregationConfig{AggregateValueTransfers: true})
+	function, _ = c.Get("key")
+	vmOutput, err = function.ProcessBuiltinFunction(nil, nil, &vmcommon.ContractCallInput{})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(vmOutput.OutputAccounts[string(receiver)].OutputTransfers))
+}
@@ -1,15 +1,13 @@
 package builtInFunctions
 
 import (
-	"bytes"
-
-	"github.com/Reshusk23/sr-me-core/core"
 	"github.com/Reshusk23/sr-me-core/core/check"
 	"github.com/Reshusk23/sr-me-core/data/vm"
 	vmcommon "github.com/Reshusk23/sr-vm-common-go"
 )
 
 type payableCheck struct {
+	baseSystemAddressesHandler
 	payableHandler      vmcommon.PayableHandler
 	enableEpochsHandler vmcommon.EnableEpochsHandler
 }
@@ -43,7 +41,7 @@ func (p *payableCheck) mustVerifyPayable(vmInput *vmcommon.ContractCallInput, mi
 	if vmInput.CallType == typeToVerify || vmInput.CallType == vm.DCTTransferAndExecute {
 		return false
 	}
-	if bytes.Equal(vmInput.CallerAddr, core.DCTSCAddress) {
+	if p.getSystemAddresses().IsDCTSCAddress(vmInput.CallerAddr) {
 		return false
 	}
 	if len(vmInput.Arguments) > minLenArguments {
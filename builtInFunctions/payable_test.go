@@ -29,7 +29,7 @@ func TestNewPayableCheckFunc(t *testing.T) {
 	assert.Equal(t, err, ErrNilPayableHandler)
 
 	_, err = NewPayableCheckFunc(&mock.PayableHandlerStub{}, nil)
-	assert.Equal(t, err, ErrNilEnableEpochsHandler)
+	assert.ErrorIs(t, err, ErrNilEnableEpochsHandler)
 
 	p := createMockPayableChecker(false, false)
 	assert.False(t, p.IsInterfaceNil())
@@ -0,0 +1,61 @@
+package builtInFunctions
+
+import (
+	"context"
+
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+)
+
+// quotaFunction wraps a built-in function and rejects the calls exceeding its per block quota. Only the successful
+// calls count towards the quota
+type quotaFunction struct {
+	baseFunctionWrapper
+	name         string
+	quotaHandler vmcommon.QuotaHandler
+}
+
+func newQuotaFunction(name string, function vmcommon.BuiltinFunction, quotaHandler vmcommon.QuotaHandler) *quotaFunction {
+	return &quotaFunction{
+		baseFunctionWrapper: baseFunctionWrapper{function: function},
+		name:                name,
+		quotaHandler:        quotaHandler,
+	}
+}
+
+// ProcessBuiltinFunction checks the quota, calls the wrapped function and records the usage of the successful call
+func (qf *quotaFunction) ProcessBuiltinFunction(
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	return qf.processBuiltinFunctionWithContext(context.Background(), acntSnd, acntDst, vmInput)
+}
+
+func (qf *quotaFunction) processBuiltinFunctionWithContext(
+	ctx context.Context,
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	if vmInput == nil {
+		return callWithContext(ctx, qf.function, acntSnd, acntDst, vmInput)
+	}
+
+	err := qf.quotaHandler.CheckQuota(qf.name, vmInput.GasProvided)
+	if err != nil {
+		return nil, err
+	}
+
+	vmOutput, err := callWithContext(ctx, qf.function, acntSnd, acntDst, vmInput)
+	if err != nil || vmOutput == nil || vmOutput.ReturnCode != vmcommon.Ok {
+		return vmOutput, err
+	}
+
+	gasUsed, _ := vmcommon.SafeSubUint64(vmInput.GasProvided, vmOutput.GasRemaining)
+	qf.quotaHandler.RecordUsage(qf.name, gasUsed)
+
+	return vmOutput, nil
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (qf *quotaFunction) IsInterfaceNil() bool {
+	return qf == nil
+}
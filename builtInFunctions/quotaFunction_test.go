@@ -0,0 +1,76 @@
+package builtInFunctions
+
+import (
+	"errors"
+	"testing"
+
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuotaFunction_ProcessBuiltinFunction(t *testing.T) {
+	t.Parallel()
+
+	vmInput := &vmcommon.ContractCallInput{VMInput: vmcommon.VMInput{GasProvided: 100}}
+
+	t.Run("quota exceeded should not call the function", func(t *testing.T) {
+		t.Parallel()
+
+		function := &mock.BuiltInFunctionStub{
+			ProcessBuiltinFunctionCalled: func(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+				assert.Fail(t, "should not have been called")
+				return nil, nil
+			},
+		}
+		qf := newQuotaFunction("function", function, &mock.QuotaHandlerStub{
+			CheckQuotaCalled: func(name string, gasProvided uint64) error {
+				assert.Equal(t, "function", name)
+				assert.Equal(t, uint64(100), gasProvided)
+				return ErrQuotaExceeded
+			},
+		})
+
+		vmOutput, err := qf.ProcessBuiltinFunction(nil, nil, vmInput)
+		assert.Nil(t, vmOutput)
+		assert.Equal(t, ErrQuotaExceeded, err)
+	})
+	t.Run("successful call should record the gas used", func(t *testing.T) {
+		t.Parallel()
+
+		function := &mock.BuiltInFunctionStub{
+			ProcessBuiltinFunctionCalled: func(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+				return &vmcommon.VMOutput{ReturnCode: vmcommon.Ok, GasRemaining: 70}, nil
+			},
+		}
+		recordedGas := uint64(0)
+		qf := newQuotaFunction("function", function, &mock.QuotaHandlerStub{
+			RecordUsageCalled: func(name string, gasUsed uint64) {
+				recordedGas += gasUsed
+			},
+		})
+
+		vmOutput, err := qf.ProcessBuiltinFunction(nil, nil, vmInput)
+		assert.Nil(t, err)
+		assert.Equal(t, uint64(70), vmOutput.GasRemaining)
+		assert.Equal(t, uint64(30), recordedGas)
+	})
+	t.Run("failed call should not record the usage", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		function := &mock.BuiltInFunctionStub{
+			ProcessBuiltinFunctionCalled: func(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+				return nil, expectedErr
+			},
+		}
+		qf := newQuotaFunction("function", function, &mock.QuotaHandlerStub{
+			RecordUsageCalled: func(name string, gasUsed uint64) {
+				assert.Fail(t, "should not have been called")
+			},
+		})
+
+		_, err := qf.ProcessBuiltinFunction(nil, nil, vmInput)
+		assert.Equal(t, expectedErr, err)
+	})
+}
@@ -0,0 +1,109 @@
+package builtInFunctions
+
+import (
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/Reshusk23/sr-me-core/core/check"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+)
+
+type functionUsage struct {
+	numCalls uint64
+	gasUsed  uint64
+}
+
+// quotaHandler enforces the per block quotas of the built-in functions. The usage is reset on each confirmed round,
+// so the quotas apply to the calls of a single block. The functions without a quota are never limited
+type quotaHandler struct {
+	mutUsage sync.Mutex
+	quotas   map[string]vmcommon.QuotaConfig
+	usage    map[string]*functionUsage
+}
+
+// NewQuotaHandler creates a new quota handler enforcing the provided quotas, keyed by built-in function name
+func NewQuotaHandler(quotas map[string]vmcommon.QuotaConfig, roundNotifier vmcommon.RoundNotifier) (*quotaHandler, error) {
+	if check.IfNil(roundNotifier) {
+		return nil, ErrNilRoundNotifier
+	}
+
+	handler := &quotaHandler{
+		quotas: make(map[string]vmcommon.QuotaConfig, len(quotas)),
+		usage:  make(map[string]*functionUsage, len(quotas)),
+	}
+	for function, quota := range quotas {
+		if quota.HasQuota() {
+			handler.quotas[function] = quota
+		}
+	}
+	roundNotifier.RegisterRoundHandler(handler)
+
+	return handler, nil
+}
+
+// CheckQuota returns ErrQuotaExceeded if one more call of the function, consuming at most the provided gas, would
+// exceed its quota in the current block
+func (qh *quotaHandler) CheckQuota(function string, gasProvided uint64) error {
+	quota, ok := qh.quotas[function]
+	if !ok {
+		return nil
+	}
+
+	qh.mutUsage.Lock()
+	defer qh.mutUsage.Unlock()
+
+	usage := qh.getUsage(function)
+	if quota.MaxCallsPerBlock > 0 && usage.numCalls >= quota.MaxCallsPerBlock {
+		return fmt.Errorf("%w, function %s reached %d calls per block", ErrQuotaExceeded, function, quota.MaxCallsPerBlock)
+	}
+	if quota.MaxGasPerBlock > 0 {
+		gasAfterCall, err := vmcommon.SafeAddUint64(usage.gasUsed, gasProvided)
+		if err != nil || gasAfterCall > quota.MaxGasPerBlock {
+			return fmt.Errorf("%w, function %s would exceed %d gas per block", ErrQuotaExceeded, function, quota.MaxGasPerBlock)
+		}
+	}
+
+	return nil
+}
+
+// RecordUsage accounts for a successful call of the function consuming the provided gas
+func (qh *quotaHandler) RecordUsage(function string, gasUsed uint64) {
+	_, ok := qh.quotas[function]
+	if !ok {
+		return
+	}
+
+	qh.mutUsage.Lock()
+	defer qh.mutUsage.Unlock()
+
+	usage := qh.getUsage(function)
+	usage.numCalls++
+	totalGasUsed, err := vmcommon.SafeAddUint64(usage.gasUsed, gasUsed)
+	if err != nil {
+		totalGasUsed = math.MaxUint64
+	}
+	usage.gasUsed = totalGasUsed
+}
+
+func (qh *quotaHandler) getUsage(function string) *functionUsage {
+	usage, ok := qh.usage[function]
+	if !ok {
+		usage = &functionUsage{}
+		qh.usage[function] = usage
+	}
+
+	return usage
+}
+
+// RoundConfirmed is called whenever a new round is started and resets the usage of all the functions
+func (qh *quotaHandler) RoundConfirmed(_ uint64, _ uint64) {
+	qh.mutUsage.Lock()
+	qh.usage = make(map[string]*functionUsage, len(qh.quotas))
+	qh.mutUsage.Unlock()
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (qh *quotaHandler) IsInterfaceNil() bool {
+	return qh == nil
+}
@@ -0,0 +1,77 @@
+package builtInFunctions
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Reshusk23/sr-me-core/core/check"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewQuotaHandler(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil round notifier should error", func(t *testing.T) {
+		t.Parallel()
+
+		handler, err := NewQuotaHandler(nil, nil)
+		assert.True(t, check.IfNil(handler))
+		assert.Equal(t, ErrNilRoundNotifier, err)
+	})
+	t.Run("should work and register", func(t *testing.T) {
+		t.Parallel()
+
+		var registered vmcommon.RoundSubscriberHandler
+		handler, err := NewQuotaHandler(map[string]vmcommon.QuotaConfig{
+			"create": {MaxCallsPerBlock: 1},
+			"free":   {},
+		}, &mock.RoundNotifierStub{
+			RegisterRoundHandlerCalled: func(handler vmcommon.RoundSubscriberHandler) {
+				registered = handler
+			},
+		})
+		assert.False(t, check.IfNil(handler))
+		assert.Nil(t, err)
+		assert.True(t, registered == handler)
+		assert.Equal(t, 1, len(handler.quotas))
+	})
+}
+
+func TestQuotaHandler_MaxCallsPerBlock(t *testing.T) {
+	t.Parallel()
+
+	handler, _ := NewQuotaHandler(map[string]vmcommon.QuotaConfig{"create": {MaxCallsPerBlock: 2}}, &mock.RoundNotifierStub{})
+
+	for i := 0; i < 2; i++ {
+		assert.Nil(t, handler.CheckQuota("create", 1000))
+		handler.RecordUsage("create", 1000)
+	}
+	assert.ErrorIs(t, handler.CheckQuota("create", 0), ErrQuotaExceeded)
+
+	handler.RecordUsage("other", 1000)
+	assert.Nil(t, handler.CheckQuota("other", math.MaxUint64))
+
+	handler.RoundConfirmed(1, 0)
+	assert.Nil(t, handler.CheckQuota("create", 1000))
+}
+
+func TestQuotaHandler_MaxGasPerBlock(t *testing.T) {
+	t.Parallel()
+
+	handler, _ := NewQuotaHandler(map[string]vmcommon.QuotaConfig{"create": {MaxGasPerBlock: 100}}, &mock.RoundNotifierStub{})
+
+	assert.Nil(t, handler.CheckQuota("create", 100))
+	assert.ErrorIs(t, handler.CheckQuota("create", 101), ErrQuotaExceeded)
+
+	handler.RecordUsage("create", 60)
+	assert.Nil(t, handler.CheckQuota("create", 40))
+	assert.ErrorIs(t, handler.CheckQuota("create", 41), ErrQuotaExceeded)
+
+	handler.RecordUsage("create", math.MaxUint64)
+	assert.ErrorIs(t, handler.CheckQuota("create", 0), ErrQuotaExceeded)
+
+	handler.RoundConfirmed(1, 0)
+	assert.Nil(t, handler.CheckQuota("create", 100))
+}
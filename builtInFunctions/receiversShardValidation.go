@@ -0,0 +1,42 @@
+package builtInFunctions
+
+import (
+	"fmt"
+
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+)
+
+// ReceiversShardError is returned when receivers of a transfer carrying a smart contract call are not in the
+// expected shard. It lists the indices of the offending receivers
+type ReceiversShardError struct {
+	ShardID uint32
+	Indices []int
+}
+
+// Error returns the error message, listing the indices of the offending receivers
+func (e *ReceiversShardError) Error() string {
+	return fmt.Sprintf("%s: receivers %v are not in shard %d", ErrReceiversInAnotherShard.Error(), e.Indices, e.ShardID)
+}
+
+// Unwrap returns the underlying error, so the error can be checked with errors.Is
+func (e *ReceiversShardError) Unwrap() error {
+	return ErrReceiversInAnotherShard
+}
+
+// checkReceiversInShard returns a ReceiversShardError listing all the receivers not in the provided shard
+func checkReceiversInShard(shardCoordinator vmcommon.Coordinator, shardID uint32, receivers [][]byte) error {
+	var indices []int
+	for index, receiver := range receivers {
+		if shardCoordinator.ComputeId(receiver) != shardID {
+			indices = append(indices, index)
+		}
+	}
+	if len(indices) == 0 {
+		return nil
+	}
+
+	return &ReceiversShardError{
+		ShardID: shardID,
+		Indices: indices,
+	}
+}
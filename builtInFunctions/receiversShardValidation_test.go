@@ -0,0 +1,34 @@
+package builtInFunctions
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckReceiversInShard(t *testing.T) {
+	t.Parallel()
+
+	shardCoordinator := &mock.ShardCoordinatorStub{
+		ComputeIdCalled: func(address []byte) uint32 {
+			return uint32(address[len(address)-1])
+		},
+	}
+
+	err := checkReceiversInShard(shardCoordinator, 1, nil)
+	assert.Nil(t, err)
+
+	err = checkReceiversInShard(shardCoordinator, 1, [][]byte{{5, 1}, {6, 1}})
+	assert.Nil(t, err)
+
+	err = checkReceiversInShard(shardCoordinator, 1, [][]byte{{5, 1}, {6, 0}, {7, 1}, {8, 2}})
+	require.True(t, errors.Is(err, ErrReceiversInAnotherShard))
+	shardErr, ok := err.(*ReceiversShardError)
+	require.True(t, ok)
+	assert.Equal(t, uint32(1), shardErr.ShardID)
+	assert.Equal(t, []int{1, 3}, shardErr.Indices)
+	assert.Equal(t, "receivers of transfer with smart contract call in another shard: receivers [1 3] are not in shard 1", err.Error())
+}
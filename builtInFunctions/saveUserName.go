@@ -63,8 +63,9 @@ func (s *saveUserName) ProcessBuiltinFunction(
 	if vmInput == nil {
 		return nil, ErrNilVmInput
 	}
-	if vmInput.CallValue.Cmp(zero) != 0 {
-		return nil, ErrBuiltInFunctionCalledWithValue
+	err := checkCallValue(vmcommon.CallValueForbidden, vmInput.CallValue)
+	if err != nil {
+		return nil, err
 	}
 	if vmInput.GasProvided < s.gasCost {
 		return nil, ErrNotEnoughGas
@@ -94,6 +95,7 @@ func (s *saveUserName) ProcessBuiltinFunction(
 			Address:         vmInput.RecipientAddr,
 			OutputTransfers: []vmcommon.OutputTransfer{outTransfer},
 		}
+		addAsyncMetadataToVMOutput(vmInput.CurrentTxHash, vmInput.RecipientAddr, outTransfer, vmOutput)
 		return vmOutput, nil
 	}
 
@@ -1,6 +1,7 @@
 package builtInFunctions
 
 import (
+	"encoding/hex"
 	"math/big"
 	"testing"
 
@@ -44,6 +45,9 @@ func TestSaveUserName_ProcessBuiltinFunction(t *testing.T) {
 	vmOutput, err := coa.ProcessBuiltinFunction(nil, nil, vmInput)
 	require.Nil(t, err)
 	require.Equal(t, 1, len(vmOutput.OutputAccounts))
+	require.Equal(t, 1, len(vmOutput.AsyncCalls))
+	require.Equal(t, []byte("SetUserName@"+hex.EncodeToString(newUserName)), vmOutput.AsyncCalls[0].Data)
+	require.Equal(t, uint64(50), vmOutput.AsyncCalls[0].GasLimit)
 
 	_, err = coa.ProcessBuiltinFunction(nil, acc, vmInput)
 	require.Nil(t, err)
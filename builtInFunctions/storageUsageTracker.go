@@ -0,0 +1,78 @@
+package builtInFunctions
+
+import (
+	"fmt"
+
+	"github.com/Reshusk23/sr-me-core/core/check"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/protectedkeys"
+)
+
+var storageUsageKey = []byte(protectedkeys.StorageUsageKey)
+
+// storageUsageTracker meters the bytes the built-in functions store on behalf of the accounts. The usage of an account
+// is kept in its own data trie, under a protected key, so it is part of the state and it is reverted together with the
+// writes it accounts for
+type storageUsageTracker struct {
+	config vmcommon.StorageUsageConfig
+}
+
+// NewStorageUsageTracker creates a new storage usage tracker enforcing the provided per account limit
+func NewStorageUsageTracker(config vmcommon.StorageUsageConfig) *storageUsageTracker {
+	return &storageUsageTracker{
+		config: config,
+	}
+}
+
+// TrackStorageUsage adds the change of the bytes stored on behalf of the account to its usage, returning
+// ErrStorageLimitExceeded if the usage would exceed the limit. Releasing bytes is never rejected
+func (t *storageUsageTracker) TrackStorageUsage(account vmcommon.UserAccountHandler, bytesDelta int64) error {
+	if check.IfNil(account) {
+		return ErrNilUserAccount
+	}
+	if bytesDelta == 0 {
+		return nil
+	}
+
+	usage := t.GetStorageUsage(account)
+	newUsage := uint64(0)
+	if bytesDelta < 0 {
+		releasedBytes := uint64(-bytesDelta)
+		if releasedBytes < usage {
+			newUsage = usage - releasedBytes
+		}
+	} else {
+		var err error
+		newUsage, err = vmcommon.SafeAddUint64(usage, uint64(bytesDelta))
+		if err != nil || (t.config.HasLimit() && newUsage > t.config.MaxBytesPerAccount) {
+			return fmt.Errorf("%w, storing %d more bytes exceeds the limit of %d bytes, %d bytes being already stored",
+				ErrStorageLimitExceeded, bytesDelta, t.config.MaxBytesPerAccount, usage)
+		}
+	}
+
+	var usageBytes []byte
+	if newUsage > 0 {
+		usageBytes = uint64ToBytes(newUsage)
+	}
+
+	return account.AccountDataHandler().SaveKeyValue(storageUsageKey, usageBytes)
+}
+
+// GetStorageUsage returns the number of bytes the built-in functions stored on behalf of the account
+func (t *storageUsageTracker) GetStorageUsage(account vmcommon.UserAccountHandler) uint64 {
+	if check.IfNil(account) {
+		return 0
+	}
+
+	usageBytes, _, err := account.AccountDataHandler().RetrieveValue(storageUsageKey)
+	if err != nil {
+		return 0
+	}
+
+	return bytesToUint64(usageBytes)
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (t *storageUsageTracker) IsInterfaceNil() bool {
+	return t == nil
+}
@@ -0,0 +1,74 @@
+package builtInFunctions
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Reshusk23/sr-me-core/core/check"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/Reshusk23/sr-vm-common-go/protectedkeys"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewStorageUsageTracker(t *testing.T) {
+	t.Parallel()
+
+	tracker := NewStorageUsageTracker(vmcommon.StorageUsageConfig{MaxBytesPerAccount: 100})
+	assert.False(t, check.IfNil(tracker))
+	assert.Equal(t, ErrNilUserAccount, tracker.TrackStorageUsage(nil, 10))
+	assert.Equal(t, uint64(0), tracker.GetStorageUsage(nil))
+}
+
+func TestStorageUsageTracker_TrackStorageUsage(t *testing.T) {
+	t.Parallel()
+
+	t.Run("usage should be kept in the account under the protected key", func(t *testing.T) {
+		t.Parallel()
+
+		tracker := NewStorageUsageTracker(vmcommon.StorageUsageConfig{})
+		account := mock.NewUserAccount([]byte("addr"))
+		require.Nil(t, tracker.TrackStorageUsage(account, 300))
+		require.Nil(t, tracker.TrackStorageUsage(account, 0))
+		assert.Equal(t, uint64(300), tracker.GetStorageUsage(account))
+		assert.Equal(t, uint64ToBytes(300), account.Storage[protectedkeys.StorageUsageKey])
+		assert.True(t, protectedkeys.IsProtectedKey([]byte(protectedkeys.StorageUsageKey)))
+		assert.Equal(t, uint64(0), tracker.GetStorageUsage(mock.NewUserAccount([]byte("other"))))
+	})
+	t.Run("released bytes should decrease the usage down to zero", func(t *testing.T) {
+		t.Parallel()
+
+		tracker := NewStorageUsageTracker(vmcommon.StorageUsageConfig{MaxBytesPerAccount: 100})
+		account := mock.NewUserAccount([]byte("addr"))
+		require.Nil(t, tracker.TrackStorageUsage(account, 80))
+		require.Nil(t, tracker.TrackStorageUsage(account, -30))
+		assert.Equal(t, uint64(50), tracker.GetStorageUsage(account))
+
+		require.Nil(t, tracker.TrackStorageUsage(account, -70))
+		assert.Equal(t, uint64(0), tracker.GetStorageUsage(account))
+		assert.Empty(t, account.Storage[protectedkeys.StorageUsageKey])
+	})
+	t.Run("usage above the limit should err", func(t *testing.T) {
+		t.Parallel()
+
+		tracker := NewStorageUsageTracker(vmcommon.StorageUsageConfig{MaxBytesPerAccount: 100})
+		account := mock.NewUserAccount([]byte("addr"))
+		require.Nil(t, tracker.TrackStorageUsage(account, 100))
+
+		err := tracker.TrackStorageUsage(account, 1)
+		assert.ErrorIs(t, err, ErrStorageLimitExceeded)
+		assert.Equal(t, uint64(100), tracker.GetStorageUsage(account))
+	})
+	t.Run("overflowing usage should err even without limit", func(t *testing.T) {
+		t.Parallel()
+
+		tracker := NewStorageUsageTracker(vmcommon.StorageUsageConfig{})
+		account := mock.NewUserAccount([]byte("addr"))
+		require.Nil(t, tracker.TrackStorageUsage(account, math.MaxInt64))
+		require.Nil(t, tracker.TrackStorageUsage(account, math.MaxInt64))
+
+		err := tracker.TrackStorageUsage(account, 2)
+		assert.ErrorIs(t, err, ErrStorageLimitExceeded)
+	})
+}
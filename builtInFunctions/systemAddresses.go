@@ -0,0 +1,31 @@
+package builtInFunctions
+
+import (
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+)
+
+// baseSystemAddressesHandler holds the addresses of the system accounts a function depends on
+type baseSystemAddressesHandler struct {
+	systemAddresses vmcommon.SystemAddresses
+}
+
+// SetSystemAddresses sets the addresses of the system accounts the function depends on
+func (b *baseSystemAddressesHandler) SetSystemAddresses(systemAddresses vmcommon.SystemAddresses) error {
+	err := systemAddresses.Check()
+	if err != nil {
+		return err
+	}
+
+	b.systemAddresses = systemAddresses
+	return nil
+}
+
+// getSystemAddresses returns the configured addresses. Functions created outside of the built-in functions factory
+// are not configured, so they fall back to the default addresses
+func (b *baseSystemAddressesHandler) getSystemAddresses() vmcommon.SystemAddresses {
+	if len(b.systemAddresses.DCTSCAddress) == 0 {
+		return vmcommon.DefaultSystemAddresses()
+	}
+
+	return b.systemAddresses
+}
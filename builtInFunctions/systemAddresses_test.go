@@ -0,0 +1,28 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"testing"
+
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBaseSystemAddressesHandler(t *testing.T) {
+	t.Parallel()
+
+	handler := &baseSystemAddressesHandler{}
+	assert.Equal(t, vmcommon.DefaultSystemAddresses(), handler.getSystemAddresses())
+
+	err := handler.SetSystemAddresses(vmcommon.SystemAddresses{})
+	assert.Equal(t, vmcommon.ErrInvalidDCTSCAddress, err)
+	assert.Equal(t, vmcommon.DefaultSystemAddresses(), handler.getSystemAddresses())
+
+	systemAddresses := vmcommon.SystemAddresses{
+		DCTSCAddress:         bytes.Repeat([]byte{1}, 20),
+		SystemAccountAddress: bytes.Repeat([]byte{2}, 20),
+	}
+	err = handler.SetSystemAddresses(systemAddresses)
+	assert.Nil(t, err)
+	assert.Equal(t, systemAddresses, handler.getSystemAddresses())
+}
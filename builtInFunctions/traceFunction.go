@@ -0,0 +1,150 @@
+package builtInFunctions
+
+import (
+	"bytes"
+
+	"github.com/Reshusk23/sr-me-core/core/check"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+)
+
+// ProcessBuiltinFunctionWithTrace executes the built-in function and returns, besides its output, the trace of the
+// storage reads and writes, role checks and gas consumption. Only the operations made on the provided accounts are
+// traced, the accounts being wrapped for the duration of the call, so the accounts adapter of the function should
+// accept any user account handler, as simulation adapters do
+func ProcessBuiltinFunctionWithTrace(
+	function vmcommon.BuiltinFunction,
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, *vmcommon.ExecutionTrace, error) {
+	if check.IfNil(function) {
+		return nil, nil, ErrNilBuiltInFunction
+	}
+	if vmInput == nil {
+		return nil, nil, ErrNilVmInput
+	}
+
+	trace := &vmcommon.ExecutionTrace{
+		Function:    vmInput.Function,
+		GasProvided: vmInput.GasProvided,
+		Steps:       make([]vmcommon.TraceStep, 0),
+	}
+
+	vmOutput, err := function.ProcessBuiltinFunction(newTracingAccount(acntSnd, trace), newTracingAccount(acntDst, trace), vmInput)
+	if err != nil {
+		return nil, trace, err
+	}
+
+	addGasStepsToTrace(trace, vmInput, vmOutput)
+
+	return vmOutput, trace, nil
+}
+
+func addGasStepsToTrace(trace *vmcommon.ExecutionTrace, vmInput *vmcommon.ContractCallInput, vmOutput *vmcommon.VMOutput) {
+	if vmOutput == nil {
+		return
+	}
+
+	trace.GasRemaining = vmOutput.GasRemaining
+	gasUsed, _ := vmcommon.SafeSubUint64(vmInput.GasProvided, vmOutput.GasRemaining)
+
+	forwardSteps := make([]vmcommon.TraceStep, 0)
+	for _, outAcc := range vmOutput.SortedOutputAccounts() {
+		for _, outTransfer := range outAcc.OutputTransfers {
+			if outTransfer.GasLimit == 0 {
+				continue
+			}
+
+			forwardSteps = append(forwardSteps, vmcommon.TraceStep{
+				Type:    vmcommon.TraceGasForwarded,
+				Address: outAcc.Address,
+				Gas:     outTransfer.GasLimit,
+			})
+			gasUsed, _ = vmcommon.SafeSubUint64(gasUsed, outTransfer.GasLimit)
+		}
+	}
+
+	trace.AddStep(vmcommon.TraceStep{Type: vmcommon.TraceGasConsumed, Gas: gasUsed})
+	trace.Steps = append(trace.Steps, forwardSteps...)
+}
+
+// tracingAccount records in the trace the storage operations made through its data handler
+type tracingAccount struct {
+	vmcommon.UserAccountHandler
+	dataHandler *tracingDataHandler
+}
+
+func newTracingAccount(account vmcommon.UserAccountHandler, trace *vmcommon.ExecutionTrace) vmcommon.UserAccountHandler {
+	if check.IfNil(account) {
+		return nil
+	}
+
+	return &tracingAccount{
+		UserAccountHandler: account,
+		dataHandler: &tracingDataHandler{
+			AccountDataHandler: account.AccountDataHandler(),
+			address:            account.AddressBytes(),
+			trace:              trace,
+		},
+	}
+}
+
+// AccountDataHandler returns the tracing data handler
+func (ta *tracingAccount) AccountDataHandler() vmcommon.AccountDataHandler {
+	return ta.dataHandler
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (ta *tracingAccount) IsInterfaceNil() bool {
+	return ta == nil
+}
+
+type tracingDataHandler struct {
+	vmcommon.AccountDataHandler
+	address []byte
+	trace   *vmcommon.ExecutionTrace
+}
+
+// RetrieveValue reads the value and records the read, the reads of the role keys being recorded as role checks
+func (tdh *tracingDataHandler) RetrieveValue(key []byte) ([]byte, uint32, error) {
+	value, trieDepth, err := tdh.AccountDataHandler.RetrieveValue(key)
+
+	step := vmcommon.TraceStep{
+		Type:    vmcommon.TraceStorageRead,
+		Address: tdh.address,
+		Key:     key,
+		Value:   value,
+	}
+	if bytes.HasPrefix(key, roleKeyPrefix) {
+		step.Type = vmcommon.TraceRoleCheck
+		step.Key = key[len(roleKeyPrefix):]
+	}
+	if err != nil {
+		step.Error = err.Error()
+	}
+	tdh.trace.AddStep(step)
+
+	return value, trieDepth, err
+}
+
+// SaveKeyValue writes the value and records the write
+func (tdh *tracingDataHandler) SaveKeyValue(key []byte, value []byte) error {
+	err := tdh.AccountDataHandler.SaveKeyValue(key, value)
+
+	step := vmcommon.TraceStep{
+		Type:    vmcommon.TraceStorageWrite,
+		Address: tdh.address,
+		Key:     key,
+		Value:   value,
+	}
+	if err != nil {
+		step.Error = err.Error()
+	}
+	tdh.trace.AddStep(step)
+
+	return err
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (tdh *tracingDataHandler) IsInterfaceNil() bool {
+	return tdh == nil
+}
@@ -0,0 +1,127 @@
+package builtInFunctions
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/Reshusk23/sr-me-core/core"
+	"github.com/Reshusk23/sr-me-core/data/dct"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessBuiltinFunctionWithTrace(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil function should err", func(t *testing.T) {
+		t.Parallel()
+
+		vmOutput, trace, err := ProcessBuiltinFunctionWithTrace(nil, nil, nil, &vmcommon.ContractCallInput{})
+		assert.Nil(t, vmOutput)
+		assert.Nil(t, trace)
+		assert.Equal(t, ErrNilBuiltInFunction, err)
+	})
+	t.Run("nil input should err", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, err := ProcessBuiltinFunctionWithTrace(&mock.BuiltInFunctionStub{}, nil, nil, nil)
+		assert.Equal(t, ErrNilVmInput, err)
+	})
+	t.Run("function error should return the partial trace", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		function := &mock.BuiltInFunctionStub{
+			ProcessBuiltinFunctionCalled: func(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+				_, _, _ = acntSnd.AccountDataHandler().RetrieveValue([]byte("key"))
+				assert.Nil(t, acntDst)
+				return nil, expectedErr
+			},
+		}
+
+		vmOutput, trace, err := ProcessBuiltinFunctionWithTrace(function, mock.NewUserAccount([]byte("snd")), nil, &vmcommon.ContractCallInput{})
+		assert.Nil(t, vmOutput)
+		assert.Equal(t, expectedErr, err)
+		require.Len(t, trace.Steps, 1)
+		assert.Equal(t, vmcommon.TraceStorageRead, trace.Steps[0].Type)
+	})
+	t.Run("local mint should trace the role check, storage and gas", func(t *testing.T) {
+		t.Parallel()
+
+		marshaller := &mock.MarshalizerMock{}
+		rolesHandler, _ := NewDCTRolesFunc(ArgsNewDCTRoles{
+			Config: Config{
+				Marshalizer: marshaller,
+			},
+			Set: false,
+		})
+		localMint, _ := NewDCTLocalMintFunc(ArgsNewDCTLocalMint{
+			Config: Config{
+				Marshalizer:           marshaller,
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          rolesHandler,
+			},
+			FuncGasCost: 10,
+		})
+
+		tokenID := []byte("TKN-abcdef")
+		acntSnd := mock.NewUserAccount([]byte("snd"))
+		roles, _ := marshaller.Marshal(&dct.DCTRoles{Roles: [][]byte{[]byte(core.DCTRoleLocalMint)}})
+		_ = acntSnd.SaveKeyValue(append(roleKeyPrefix, tokenID...), roles)
+
+		vmInput := &vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallerAddr:  []byte("snd"),
+				CallValue:   big.NewInt(0),
+				GasProvided: 50,
+				Arguments:   [][]byte{tokenID, big.NewInt(100).Bytes()},
+			},
+			RecipientAddr: []byte("snd"),
+			Function:      core.BuiltInFunctionDCTLocalMint,
+		}
+		vmOutput, trace, err := ProcessBuiltinFunctionWithTrace(localMint, acntSnd, nil, vmInput)
+		require.Nil(t, err)
+		require.NotNil(t, vmOutput)
+
+		assert.Equal(t, core.BuiltInFunctionDCTLocalMint, trace.Function)
+		assert.Equal(t, uint64(50), trace.GasProvided)
+		assert.Equal(t, uint64(40), trace.GasRemaining)
+
+		roleChecks := trace.StepsOfType(vmcommon.TraceRoleCheck)
+		require.Len(t, roleChecks, 1)
+		assert.Equal(t, tokenID, roleChecks[0].Key)
+		assert.Equal(t, []byte("snd"), roleChecks[0].Address)
+
+		writes := trace.StepsOfType(vmcommon.TraceStorageWrite)
+		require.Len(t, writes, 1)
+		assert.Equal(t, append([]byte(baseDCTKeyPrefix), tokenID...), writes[0].Key)
+		assert.NotEmpty(t, trace.StepsOfType(vmcommon.TraceStorageRead))
+
+		assert.Equal(t, []vmcommon.TraceStep{{Type: vmcommon.TraceGasConsumed, Gas: 10}}, trace.StepsOfType(vmcommon.TraceGasConsumed))
+		assert.Equal(t, vmcommon.TraceGasConsumed, trace.Steps[len(trace.Steps)-1].Type)
+	})
+	t.Run("forwarded gas should be traced apart", func(t *testing.T) {
+		t.Parallel()
+
+		function := &mock.BuiltInFunctionStub{
+			ProcessBuiltinFunctionCalled: func(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+				return &vmcommon.VMOutput{
+					GasRemaining: 0,
+					OutputAccounts: map[string]*vmcommon.OutputAccount{
+						"dst": {Address: []byte("dst"), OutputTransfers: []vmcommon.OutputTransfer{{GasLimit: 30}, {GasLimit: 0}}},
+					},
+				}, nil
+			},
+		}
+
+		_, trace, err := ProcessBuiltinFunctionWithTrace(function, nil, nil, &vmcommon.ContractCallInput{VMInput: vmcommon.VMInput{GasProvided: 50}})
+		require.Nil(t, err)
+		assert.Equal(t, []vmcommon.TraceStep{
+			{Type: vmcommon.TraceGasConsumed, Gas: 20},
+			{Type: vmcommon.TraceGasForwarded, Address: []byte("dst"), Gas: 30},
+		}, trace.Steps)
+	})
+}
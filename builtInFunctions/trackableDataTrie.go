@@ -0,0 +1,171 @@
+package builtInFunctions
+
+import (
+	"bytes"
+
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+)
+
+// trackableDataTrie buffers the writes made to the storage of an account during a built-in function call. The reads
+// see the buffered values, while the underlying data handler is only updated on Commit, so that a call failing
+// halfway does not leave the account partially updated
+type trackableDataTrie struct {
+	vmcommon.AccountDataHandler
+	dirtyData map[string][]byte
+	dirtyKeys [][]byte
+}
+
+func newTrackableDataTrie(dataHandler vmcommon.AccountDataHandler) *trackableDataTrie {
+	return &trackableDataTrie{
+		AccountDataHandler: dataHandler,
+		dirtyData:          make(map[string][]byte),
+	}
+}
+
+// RetrieveValue returns the buffered value of the key, if any, otherwise reads it from the underlying data handler
+func (tdt *trackableDataTrie) RetrieveValue(key []byte) ([]byte, uint32, error) {
+	value, ok := tdt.dirtyData[string(key)]
+	if ok {
+		return value, 0, nil
+	}
+
+	return tdt.AccountDataHandler.RetrieveValue(key)
+}
+
+// SaveKeyValue buffers the value until Commit is called
+func (tdt *trackableDataTrie) SaveKeyValue(key []byte, value []byte) error {
+	_, ok := tdt.dirtyData[string(key)]
+	if !ok {
+		tdt.dirtyKeys = append(tdt.dirtyKeys, bytes.Clone(key))
+	}
+	tdt.dirtyData[string(key)] = bytes.Clone(value)
+
+	return nil
+}
+
+// Commit writes the buffered values to the underlying data handler, in the order the keys were first written
+func (tdt *trackableDataTrie) Commit() error {
+	for _, key := range tdt.dirtyKeys {
+		err := tdt.AccountDataHandler.SaveKeyValue(key, tdt.dirtyData[string(key)])
+		if err != nil {
+			return err
+		}
+	}
+
+	tdt.Revert()
+	return nil
+}
+
+// Revert drops the buffered values
+func (tdt *trackableDataTrie) Revert() {
+	tdt.dirtyData = make(map[string][]byte)
+	tdt.dirtyKeys = nil
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (tdt *trackableDataTrie) IsInterfaceNil() bool {
+	return tdt == nil
+}
+
+// trackableAccount exposes the storage of an account through a trackableDataTrie. The wrapped account must be the one
+// handed to the accounts adapter when saving, after the changes are committed
+type trackableAccount struct {
+	vmcommon.UserAccountHandler
+	dataTrie *trackableDataTrie
+}
+
+func newTrackableAccount(account vmcommon.UserAccountHandler) *trackableAccount {
+	return &trackableAccount{
+		UserAccountHandler: account,
+		dataTrie:           newTrackableDataTrie(account.AccountDataHandler()),
+	}
+}
+
+// AccountDataHandler returns the trackable data trie
+func (ta *trackableAccount) AccountDataHandler() vmcommon.AccountDataHandler {
+	return ta.dataTrie
+}
+
+// Commit writes the buffered storage changes to the wrapped account
+func (ta *trackableAccount) Commit() error {
+	return ta.dataTrie.Commit()
+}
+
+// Revert drops the buffered storage changes
+func (ta *trackableAccount) Revert() {
+	ta.dataTrie.Revert()
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (ta *trackableAccount) IsInterfaceNil() bool {
+	return ta == nil
+}
+
+// systemAccountBuffer is an accounts adapter handing out the system account as a trackableAccount, so the writes made to
+// it through the adapter are buffered. Saving the system account is deferred until commitSystemAccount, all the other
+// accounts being loaded and saved as usual
+type systemAccountBuffer struct {
+	vmcommon.AccountsAdapter
+	systemAddress []byte
+	systemAccount *trackableAccount
+}
+
+func newSystemAccountBuffer(accounts vmcommon.AccountsAdapter, systemAddress []byte) *systemAccountBuffer {
+	return &systemAccountBuffer{
+		AccountsAdapter: accounts,
+		systemAddress:   systemAddress,
+	}
+}
+
+// LoadAccount returns the buffered system account, loading it on first use, or the account as loaded by the wrapped
+// accounts adapter for any other address
+func (sab *systemAccountBuffer) LoadAccount(address []byte) (vmcommon.AccountHandler, error) {
+	if !bytes.Equal(address, sab.systemAddress) {
+		return sab.AccountsAdapter.LoadAccount(address)
+	}
+	if sab.systemAccount != nil {
+		return sab.systemAccount, nil
+	}
+
+	account, err := sab.AccountsAdapter.LoadAccount(address)
+	if err != nil {
+		return nil, err
+	}
+	userAccount, ok := account.(vmcommon.UserAccountHandler)
+	if !ok {
+		return nil, ErrWrongTypeAssertion
+	}
+
+	sab.systemAccount = newTrackableAccount(userAccount)
+	return sab.systemAccount, nil
+}
+
+// SaveAccount does nothing for the buffered system account and saves any other account through the wrapped accounts
+// adapter
+func (sab *systemAccountBuffer) SaveAccount(account vmcommon.AccountHandler) error {
+	trackable, ok := account.(*trackableAccount)
+	if ok && trackable == sab.systemAccount {
+		return nil
+	}
+
+	return sab.AccountsAdapter.SaveAccount(account)
+}
+
+// commitSystemAccount writes the buffered changes to the system account and saves it, if it was loaded at all
+func (sab *systemAccountBuffer) commitSystemAccount() error {
+	if sab.systemAccount == nil {
+		return nil
+	}
+
+	err := sab.systemAccount.Commit()
+	if err != nil {
+		return err
+	}
+
+	return sab.AccountsAdapter.SaveAccount(sab.systemAccount.UserAccountHandler)
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (sab *systemAccountBuffer) IsInterfaceNil() bool {
+	return sab == nil
+}
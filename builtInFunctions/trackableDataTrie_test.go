@@ -0,0 +1,256 @@
+package builtInFunctions
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/Reshusk23/sr-me-core/data/dct"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/dctkeys"
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrackableDataTrie(t *testing.T) {
+	t.Parallel()
+
+	t.Run("writes should be buffered until commit", func(t *testing.T) {
+		t.Parallel()
+
+		account := mock.NewUserAccount([]byte("address"))
+		_ = account.SaveKeyValue([]byte("key"), []byte("old"))
+		tdt := newTrackableDataTrie(account)
+
+		err := tdt.SaveKeyValue([]byte("key"), []byte("new"))
+		require.Nil(t, err)
+		err = tdt.SaveKeyValue([]byte("other"), []byte("value"))
+		require.Nil(t, err)
+
+		value, _, _ := tdt.RetrieveValue([]byte("key"))
+		assert.Equal(t, []byte("new"), value)
+		value, _, _ = account.RetrieveValue([]byte("key"))
+		assert.Equal(t, []byte("old"), value)
+
+		err = tdt.Commit()
+		require.Nil(t, err)
+		value, _, _ = account.RetrieveValue([]byte("key"))
+		assert.Equal(t, []byte("new"), value)
+		value, _, _ = account.RetrieveValue([]byte("other"))
+		assert.Equal(t, []byte("value"), value)
+	})
+	t.Run("revert should drop the buffered writes", func(t *testing.T) {
+		t.Parallel()
+
+		account := mock.NewUserAccount([]byte("address"))
+		_ = account.SaveKeyValue([]byte("key"), []byte("old"))
+		tdt := newTrackableDataTrie(account)
+
+		_ = tdt.SaveKeyValue([]byte("key"), []byte("new"))
+		tdt.Revert()
+
+		value, _, _ := tdt.RetrieveValue([]byte("key"))
+		assert.Equal(t, []byte("old"), value)
+
+		err := tdt.Commit()
+		require.Nil(t, err)
+		value, _, _ = account.RetrieveValue([]byte("key"))
+		assert.Equal(t, []byte("old"), value)
+	})
+	t.Run("commit should write the keys in order and return the error", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		writtenKeys := make([]string, 0)
+		tdt := newTrackableDataTrie(&mock.DataTrieTrackerStub{
+			SaveKeyValueCalled: func(key []byte, value []byte) error {
+				writtenKeys = append(writtenKeys, string(key))
+				if string(key) == "c" {
+					return expectedErr
+				}
+				return nil
+			},
+		})
+
+		_ = tdt.SaveKeyValue([]byte("b"), []byte("1"))
+		_ = tdt.SaveKeyValue([]byte("a"), []byte("2"))
+		_ = tdt.SaveKeyValue([]byte("b"), []byte("3"))
+		assert.Nil(t, tdt.Commit())
+		assert.Equal(t, []string{"b", "a"}, writtenKeys)
+
+		_ = tdt.SaveKeyValue([]byte("c"), []byte("4"))
+		assert.Equal(t, expectedErr, tdt.Commit())
+	})
+	t.Run("buffered value should not alias the caller slice", func(t *testing.T) {
+		t.Parallel()
+
+		tdt := newTrackableDataTrie(mock.NewUserAccount([]byte("address")))
+		value := []byte("value")
+		_ = tdt.SaveKeyValue([]byte("key"), value)
+		value[0] = 'x'
+
+		retrieved, _, _ := tdt.RetrieveValue([]byte("key"))
+		assert.Equal(t, []byte("value"), retrieved)
+	})
+}
+
+func TestDctNFTCreate_ProcessBuiltinFunctionFailureShouldNotSaveLatestNonce(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("expected error")
+	nftCreate, _ := NewDCTNFTCreateFunc(ArgsNewDCTNFTCreate{
+		Config: Config{
+			Marshalizer:           &mock.MarshalizerMock{},
+			Accounts:              &mock.AccountsStub{},
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			DCTStorageHandler: &mock.DCTNFTStorageHandlerStub{
+				SaveDCTNFTTokenCalled: func(senderAddress []byte, acnt vmcommon.UserAccountHandler, dctTokenKey []byte, nonce uint64, dctData *dct.DCToken, mustUpdateAllFields bool, isReturnWithError bool) ([]byte, error) {
+					return nil, acnt.AccountDataHandler().SaveKeyValue(dctkeys.ComputeDCTNFTTokenKey(dctTokenKey, nonce), []byte("token"))
+				},
+				AddToLiquiditySystemAccCalled: func(dctTokenKey []byte, nonce uint64, transferValue *big.Int) error {
+					return expectedErr
+				},
+			},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+				IsValueLengthCheckFlagEnabledField: true,
+			},
+		},
+	})
+	sender := mock.NewUserAccount([]byte("address"))
+	token := []byte("token")
+	vmInput := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr: sender.AddressBytes(),
+			CallValue:  big.NewInt(0),
+			Arguments:  [][]byte{token, big.NewInt(1).Bytes(), []byte("name"), big.NewInt(100).Bytes(), []byte("hash"), []byte("attributes"), []byte("uri")},
+		},
+		RecipientAddr: sender.AddressBytes(),
+	}
+
+	vmOutput, err := nftCreate.ProcessBuiltinFunction(sender, nil, vmInput)
+	assert.Nil(t, vmOutput)
+	assert.Equal(t, expectedErr, err)
+	assert.Empty(t, sender.Storage)
+
+	nonce, _ := getLatestNonce(sender, token)
+	assert.Zero(t, nonce)
+}
+
+func TestSystemAccountBuffer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("system account writes should be buffered until commit", func(t *testing.T) {
+		t.Parallel()
+
+		systemAccount := mock.NewUserAccount(vmcommon.SystemAccountAddress)
+		savedAddresses := make([]string, 0)
+		accounts := &mock.AccountsStub{
+			LoadAccountCalled: func(address []byte) (vmcommon.AccountHandler, error) {
+				if string(address) == string(vmcommon.SystemAccountAddress) {
+					return systemAccount, nil
+				}
+				return mock.NewUserAccount(address), nil
+			},
+			SaveAccountCalled: func(account vmcommon.AccountHandler) error {
+				savedAddresses = append(savedAddresses, string(account.AddressBytes()))
+				return nil
+			},
+		}
+		sab := newSystemAccountBuffer(accounts, vmcommon.SystemAccountAddress)
+
+		loaded, err := sab.LoadAccount(vmcommon.SystemAccountAddress)
+		require.Nil(t, err)
+		err = loaded.(vmcommon.UserAccountHandler).AccountDataHandler().SaveKeyValue([]byte("key"), []byte("value"))
+		require.Nil(t, err)
+		err = sab.SaveAccount(loaded)
+		require.Nil(t, err)
+
+		loadedAgain, _ := sab.LoadAccount(vmcommon.SystemAccountAddress)
+		assert.True(t, loaded == loadedAgain)
+		value, _, _ := loadedAgain.(vmcommon.UserAccountHandler).AccountDataHandler().RetrieveValue([]byte("key"))
+		assert.Equal(t, []byte("value"), value)
+		assert.Empty(t, systemAccount.Storage)
+		assert.Empty(t, savedAddresses)
+
+		other, _ := sab.LoadAccount([]byte("other"))
+		err = sab.SaveAccount(other)
+		require.Nil(t, err)
+		assert.Equal(t, []string{"other"}, savedAddresses)
+
+		err = sab.commitSystemAccount()
+		require.Nil(t, err)
+		assert.Equal(t, []byte("value"), systemAccount.Storage["key"])
+		assert.Equal(t, []string{"other", string(vmcommon.SystemAccountAddress)}, savedAddresses)
+	})
+	t.Run("commit without loading the system account should not save it", func(t *testing.T) {
+		t.Parallel()
+
+		sab := newSystemAccountBuffer(&mock.AccountsStub{
+			SaveAccountCalled: func(account vmcommon.AccountHandler) error {
+				require.Fail(t, "should not have saved the system account")
+				return nil
+			},
+		}, vmcommon.SystemAccountAddress)
+
+		assert.Nil(t, sab.commitSystemAccount())
+	})
+}
+
+func TestDctNFTCreate_ProcessBuiltinFunctionFailureShouldNotTouchTheSystemAccount(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("expected error")
+	systemAccount := mock.NewUserAccount(vmcommon.SystemAccountAddress)
+	systemAccountSaved := false
+	accounts := &mock.AccountsStub{
+		LoadAccountCalled: func(address []byte) (vmcommon.AccountHandler, error) {
+			return systemAccount, nil
+		},
+		SaveAccountCalled: func(account vmcommon.AccountHandler) error {
+			systemAccountSaved = true
+			return nil
+		},
+	}
+	enableEpochsHandler := &mock.EnableEpochsHandlerStub{
+		IsValueLengthCheckFlagEnabledField:    true,
+		IsSaveToSystemAccountFlagEnabledField: true,
+		IsSendAlwaysFlagEnabledField:          true,
+	}
+	globalSettingsHandler := &mock.GlobalSettingsHandlerStub{}
+	nftCreate, _ := NewDCTNFTCreateFunc(ArgsNewDCTNFTCreate{
+		Config: Config{
+			Marshalizer:           &mock.MarshalizerMock{},
+			Accounts:              accounts,
+			GlobalSettingsHandler: globalSettingsHandler,
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			DCTStorageHandler:     createNewDCTDataStorageHandlerWithArgs(globalSettingsHandler, accounts, enableEpochsHandler),
+			EnableEpochsHandler:   enableEpochsHandler,
+		},
+	})
+	sender := &mock.UserAccountStub{
+		AccountDataHandlerCalled: func() vmcommon.AccountDataHandler {
+			return &mock.DataTrieTrackerStub{
+				RetrieveValueCalled: func(key []byte) ([]byte, uint32, error) {
+					return nil, 0, nil
+				},
+				SaveKeyValueCalled: func(key []byte, value []byte) error {
+					return expectedErr
+				},
+			}
+		},
+	}
+	vmInput := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallValue: big.NewInt(0),
+			Arguments: [][]byte{[]byte("token"), big.NewInt(1).Bytes(), []byte("name"), big.NewInt(100).Bytes(), []byte("hash"), []byte("attributes"), []byte("uri")},
+		},
+	}
+
+	vmOutput, err := nftCreate.ProcessBuiltinFunction(sender, nil, vmInput)
+	assert.Nil(t, vmOutput)
+	assert.Equal(t, expectedErr, err)
+	assert.Empty(t, systemAccount.Storage)
+	assert.False(t, systemAccountSaved)
+}
@@ -0,0 +1,52 @@
+package builtInFunctions
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/Reshusk23/sr-me-core/core/check"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+)
+
+// transferInterceptorChecker is embedded by the built-in functions moving tokens between accounts. Until a transfer
+// interceptor is set, the check only reads the unset interceptor, so the transfers do not pay for the hook.
+type transferInterceptorChecker struct {
+	mutTransferInterceptor sync.RWMutex
+	transferInterceptor    vmcommon.TransferInterceptor
+}
+
+// SetTransferInterceptor sets the interceptor able to veto the transfers
+func (tic *transferInterceptorChecker) SetTransferInterceptor(transferInterceptor vmcommon.TransferInterceptor) error {
+	if check.IfNil(transferInterceptor) {
+		return ErrNilTransferInterceptor
+	}
+
+	tic.mutTransferInterceptor.Lock()
+	tic.transferInterceptor = transferInterceptor
+	tic.mutTransferInterceptor.Unlock()
+
+	return nil
+}
+
+func (tic *transferInterceptorChecker) checkTransferIsAllowed(
+	sender []byte,
+	receiver []byte,
+	token []byte,
+	nonce uint64,
+	amount *big.Int,
+	isReturnWithError bool,
+) error {
+	if isReturnWithError {
+		return nil
+	}
+
+	tic.mutTransferInterceptor.RLock()
+	transferInterceptor := tic.transferInterceptor
+	tic.mutTransferInterceptor.RUnlock()
+
+	if transferInterceptor == nil {
+		return nil
+	}
+
+	return transferInterceptor.PreTransfer(sender, receiver, token, nonce, amount)
+}
@@ -0,0 +1,95 @@
+package builtInFunctions
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/Reshusk23/sr-me-core/data/dct"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+var errTransferRejected = errors.New("transfer rejected")
+
+func TestTransferInterceptorChecker_CheckTransferIsAllowed(t *testing.T) {
+	t.Parallel()
+
+	tic := &transferInterceptorChecker{}
+	assert.Nil(t, tic.checkTransferIsAllowed([]byte("snd"), []byte("dst"), []byte("TKN"), 0, big.NewInt(1), false))
+
+	err := tic.SetTransferInterceptor(nil)
+	assert.Equal(t, ErrNilTransferInterceptor, err)
+
+	var intercepted []interface{}
+	err = tic.SetTransferInterceptor(&mock.TransferInterceptorStub{
+		PreTransferCalled: func(sender []byte, receiver []byte, token []byte, nonce uint64, amount *big.Int) error {
+			intercepted = []interface{}{string(sender), string(receiver), string(token), nonce, amount}
+			return errTransferRejected
+		},
+	})
+	assert.Nil(t, err)
+
+	assert.Nil(t, tic.checkTransferIsAllowed([]byte("snd"), []byte("dst"), []byte("TKN"), 2, big.NewInt(5), true))
+	assert.Nil(t, intercepted)
+
+	err = tic.checkTransferIsAllowed([]byte("snd"), []byte("dst"), []byte("TKN"), 2, big.NewInt(5), false)
+	assert.Equal(t, errTransferRejected, err)
+	assert.Equal(t, []interface{}{"snd", "dst", "TKN", uint64(2), big.NewInt(5)}, intercepted)
+}
+
+func TestDCTTransfer_InterceptorCanRejectTheTransfer(t *testing.T) {
+	t.Parallel()
+
+	marshaller := &mock.MarshalizerMock{}
+	transferFunc, _ := NewDCTTransferFunc(ArgsNewDCTTransfer{
+		Config: Config{
+			Marshalizer:           marshaller,
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			ShardCoordinator:      &mock.ShardCoordinatorStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+		},
+		FuncGasCost: 10,
+	})
+	_ = transferFunc.SetPayableChecker(&mock.PayableHandlerStub{})
+	numCalls := 0
+	_ = transferFunc.SetTransferInterceptor(&mock.TransferInterceptorStub{
+		PreTransferCalled: func(sender []byte, receiver []byte, token []byte, nonce uint64, amount *big.Int) error {
+			numCalls++
+			if amount.Cmp(big.NewInt(50)) > 0 {
+				return errTransferRejected
+			}
+			return nil
+		},
+	})
+
+	key := []byte("key")
+	input := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			GasProvided: 50,
+			CallValue:   big.NewInt(0),
+			Arguments:   [][]byte{key, big.NewInt(60).Bytes()},
+		},
+		RecipientAddr: []byte("dst"),
+	}
+	accSnd := mock.NewUserAccount([]byte("snd"))
+	dctKey := append(transferFunc.keyPrefix, key...)
+	marshaledData, _ := marshaller.Marshal(&dct.DCToken{Value: big.NewInt(100)})
+	_ = accSnd.AccountDataHandler().SaveKeyValue(dctKey, marshaledData)
+
+	_, err := transferFunc.ProcessBuiltinFunction(accSnd, nil, input)
+	assert.Equal(t, errTransferRejected, err)
+	assert.Equal(t, big.NewInt(100), getDCTBalanceForTest(t, accSnd, dctKey, marshaller))
+
+	input.Arguments[1] = big.NewInt(40).Bytes()
+	_, err = transferFunc.ProcessBuiltinFunction(accSnd, nil, input)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(60), getDCTBalanceForTest(t, accSnd, dctKey, marshaller))
+
+	// the destination shard does not intercept the transfer again
+	_, err = transferFunc.ProcessBuiltinFunction(nil, mock.NewUserAccount([]byte("dst")), input)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, numCalls)
+}
@@ -16,43 +16,39 @@ type dctNFTupdate struct {
 	globalSettingsHandler vmcommon.DCTGlobalSettingsHandler
 	rolesHandler          vmcommon.DCTRoleHandler
 	gasConfig             vmcommon.BaseOperationCost
+	storageUsageTracker   vmcommon.StorageUsageTracker
+	enableEpochsHandler   vmcommon.EnableEpochsHandler
 	funcGasCost           uint64
 	mutExecution          sync.RWMutex
 }
 
+// ArgsNewDCTNFTUpdateAttributes defines the argument list for new dct NFT update attributes built in function
+type ArgsNewDCTNFTUpdateAttributes struct {
+	Config
+	FuncGasCost uint64
+	GasConfig   vmcommon.BaseOperationCost
+}
+
 // NewDCTNFTUpdateAttributesFunc returns the dct NFT update attribute built-in function component
-func NewDCTNFTUpdateAttributesFunc(
-	funcGasCost uint64,
-	gasConfig vmcommon.BaseOperationCost,
-	dctStorageHandler vmcommon.DCTNFTStorageHandler,
-	globalSettingsHandler vmcommon.DCTGlobalSettingsHandler,
-	rolesHandler vmcommon.DCTRoleHandler,
-	enableEpochsHandler vmcommon.EnableEpochsHandler,
-) (*dctNFTupdate, error) {
-	if check.IfNil(dctStorageHandler) {
-		return nil, ErrNilDCTNFTStorageHandler
-	}
-	if check.IfNil(globalSettingsHandler) {
-		return nil, ErrNilGlobalSettingsHandler
-	}
-	if check.IfNil(rolesHandler) {
-		return nil, ErrNilRolesHandler
-	}
-	if check.IfNil(enableEpochsHandler) {
-		return nil, ErrNilEnableEpochsHandler
+func NewDCTNFTUpdateAttributesFunc(args ArgsNewDCTNFTUpdateAttributes) (*dctNFTupdate, error) {
+	err := args.checkComponents(core.BuiltInFunctionDCTNFTUpdateAttributes, requireGlobalSettingsHandler|requireRolesHandler|requireDCTStorageHandler|requireEnableEpochsHandler)
+	if err != nil {
+		return nil, err
 	}
 
 	e := &dctNFTupdate{
 		keyPrefix:             []byte(baseDCTKeyPrefix),
-		dctStorageHandler:     dctStorageHandler,
-		funcGasCost:           funcGasCost,
+		dctStorageHandler:     args.DCTStorageHandler,
+		funcGasCost:           args.FuncGasCost,
 		mutExecution:          sync.RWMutex{},
-		globalSettingsHandler: globalSettingsHandler,
-		gasConfig:             gasConfig,
-		rolesHandler:          rolesHandler,
+		globalSettingsHandler: args.GlobalSettingsHandler,
+		gasConfig:             args.GasConfig,
+		rolesHandler:          args.RolesHandler,
+		storageUsageTracker:   &disabledStorageUsageTracker{},
+		enableEpochsHandler:   args.EnableEpochsHandler,
 	}
 
-	e.baseActiveHandler.activeHandler = enableEpochsHandler.IsDCTNFTImprovementV1FlagEnabled
+	e.baseActiveHandler.activeHandler = args.EnableEpochsHandler.IsDCTNFTImprovementV1FlagEnabled
 
 	return e, nil
 }
@@ -69,6 +65,19 @@ func (e *dctNFTupdate) SetNewGasConfig(gasCost *vmcommon.GasCost) {
 	e.mutExecution.Unlock()
 }
 
+// SetStorageUsageTracker sets the tracker metering the bytes the new attributes add over the replaced ones, which are accounted to the caller
+func (e *dctNFTupdate) SetStorageUsageTracker(storageUsageTracker vmcommon.StorageUsageTracker) error {
+	if check.IfNil(storageUsageTracker) {
+		return ErrNilStorageUsageTracker
+	}
+
+	e.mutExecution.Lock()
+	e.storageUsageTracker = storageUsageTracker
+	e.mutExecution.Unlock()
+
+	return nil
+}
+
 // ProcessBuiltinFunction resolves DCT NFT update attributes function call
 // Requires 3 arguments:
 // arg0 - token identifier
@@ -94,13 +103,21 @@ func (e *dctNFTupdate) ProcessBuiltinFunction(
 		return nil, err
 	}
 
+	err = checkCollectionAttributes(e.globalSettingsHandler, vmInput.Arguments[0], vmInput.Arguments[2])
+	if err != nil {
+		return nil, err
+	}
+
 	gasCostForStore := uint64(len(vmInput.Arguments[2])) * e.gasConfig.StorePerByte
 	if vmInput.GasProvided < e.funcGasCost+gasCostForStore {
 		return nil, ErrNotEnoughGas
 	}
 
 	dctTokenKey := append(e.keyPrefix, vmInput.Arguments[0]...)
-	nonce := big.NewInt(0).SetBytes(vmInput.Arguments[1]).Uint64()
+	nonce, err := flaggedUint64Argument(e.enableEpochsHandler, vmInput.Arguments, 1)
+	if err != nil {
+		return nil, err
+	}
 	if nonce == 0 {
 		return nil, ErrNFTDoesNotHaveMetadata
 	}
@@ -109,6 +126,10 @@ func (e *dctNFTupdate) ProcessBuiltinFunction(
 		return nil, err
 	}
 
+	err = e.storageUsageTracker.TrackStorageUsage(acntSnd, int64(len(vmInput.Arguments[2])-len(dctData.TokenMetaData.Attributes)))
+	if err != nil {
+		return nil, err
+	}
 	dctData.TokenMetaData.Attributes = vmInput.Arguments[2]
 
 	_, err = e.dctStorageHandler.SaveDCTNFTToken(acntSnd.AddressBytes(), acntSnd, dctTokenKey, nonce, dctData, true, vmInput.ReturnCallAfterError)
@@ -17,38 +17,75 @@ import (
 func TestNewDCTNFTUpdateAttributesFunc(t *testing.T) {
 	t.Parallel()
 
-	t.Run("nil marshaller should error", func(t *testing.T) {
+	t.Run("nil dct storage handler should error", func(t *testing.T) {
 		t.Parallel()
 
-		e, err := NewDCTNFTUpdateAttributesFunc(10, vmcommon.BaseOperationCost{}, nil, nil, nil, nil)
+		e, err := NewDCTNFTUpdateAttributesFunc(ArgsNewDCTNFTUpdateAttributes{
+			Config: Config{
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+			},
+			FuncGasCost: 10,
+			GasConfig:   vmcommon.BaseOperationCost{},
+		})
 		require.True(t, check.IfNil(e))
-		require.Equal(t, ErrNilDCTNFTStorageHandler, err)
+		require.ErrorIs(t, err, ErrNilDCTNFTStorageHandler)
 	})
 	t.Run("nil global settings handler should error", func(t *testing.T) {
 		t.Parallel()
 
-		e, err := NewDCTNFTUpdateAttributesFunc(10, vmcommon.BaseOperationCost{}, createNewDCTDataStorageHandler(), nil, nil, nil)
+		e, err := NewDCTNFTUpdateAttributesFunc(ArgsNewDCTNFTUpdateAttributes{
+			Config: Config{
+				DCTStorageHandler: createNewDCTDataStorageHandler(),
+			},
+			FuncGasCost: 10,
+			GasConfig:   vmcommon.BaseOperationCost{},
+		})
 		require.True(t, check.IfNil(e))
-		require.Equal(t, ErrNilGlobalSettingsHandler, err)
+		require.ErrorIs(t, err, ErrNilGlobalSettingsHandler)
 	})
 	t.Run("nil roles handler should error", func(t *testing.T) {
 		t.Parallel()
 
-		e, err := NewDCTNFTUpdateAttributesFunc(10, vmcommon.BaseOperationCost{}, createNewDCTDataStorageHandler(), &mock.GlobalSettingsHandlerStub{}, nil, nil)
+		e, err := NewDCTNFTUpdateAttributesFunc(ArgsNewDCTNFTUpdateAttributes{
+			Config: Config{
+				DCTStorageHandler:     createNewDCTDataStorageHandler(),
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			},
+			FuncGasCost: 10,
+			GasConfig:   vmcommon.BaseOperationCost{},
+		})
 		require.True(t, check.IfNil(e))
-		require.Equal(t, ErrNilRolesHandler, err)
+		require.ErrorIs(t, err, ErrNilRolesHandler)
 	})
 	t.Run("nil enable epochs handler should error", func(t *testing.T) {
 		t.Parallel()
 
-		e, err := NewDCTNFTUpdateAttributesFunc(10, vmcommon.BaseOperationCost{}, createNewDCTDataStorageHandler(), &mock.GlobalSettingsHandlerStub{}, &mock.DCTRoleHandlerStub{}, nil)
+		e, err := NewDCTNFTUpdateAttributesFunc(ArgsNewDCTNFTUpdateAttributes{
+			Config: Config{
+				DCTStorageHandler:     createNewDCTDataStorageHandler(),
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+			},
+			FuncGasCost: 10,
+			GasConfig:   vmcommon.BaseOperationCost{},
+		})
 		require.True(t, check.IfNil(e))
-		require.Equal(t, ErrNilEnableEpochsHandler, err)
+		require.ErrorIs(t, err, ErrNilEnableEpochsHandler)
 	})
 	t.Run("should work", func(t *testing.T) {
 		t.Parallel()
 
-		e, err := NewDCTNFTUpdateAttributesFunc(10, vmcommon.BaseOperationCost{}, createNewDCTDataStorageHandler(), &mock.GlobalSettingsHandlerStub{}, &mock.DCTRoleHandlerStub{}, &mock.EnableEpochsHandlerStub{})
+		e, err := NewDCTNFTUpdateAttributesFunc(ArgsNewDCTNFTUpdateAttributes{
+			Config: Config{
+				DCTStorageHandler:     createNewDCTDataStorageHandler(),
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				RolesHandler:          &mock.DCTRoleHandlerStub{},
+				EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			},
+			FuncGasCost: 10,
+			GasConfig:   vmcommon.BaseOperationCost{},
+		})
 		require.False(t, check.IfNil(e))
 		require.NoError(t, err)
 		require.False(t, e.IsActive())
@@ -59,8 +96,17 @@ func TestDCTNFTUpdateAttributes_SetNewGasConfig_NilGasCost(t *testing.T) {
 	t.Parallel()
 
 	defaultGasCost := uint64(10)
-	e, _ := NewDCTNFTUpdateAttributesFunc(defaultGasCost, vmcommon.BaseOperationCost{}, createNewDCTDataStorageHandler(), &mock.GlobalSettingsHandlerStub{}, &mock.DCTRoleHandlerStub{}, &mock.EnableEpochsHandlerStub{
-		IsDCTNFTImprovementV1FlagEnabledField: true,
+	e, _ := NewDCTNFTUpdateAttributesFunc(ArgsNewDCTNFTUpdateAttributes{
+		Config: Config{
+			DCTStorageHandler:     createNewDCTDataStorageHandler(),
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+				IsDCTNFTImprovementV1FlagEnabledField: true,
+			},
+		},
+		FuncGasCost: defaultGasCost,
+		GasConfig:   vmcommon.BaseOperationCost{},
 	})
 
 	e.SetNewGasConfig(nil)
@@ -72,8 +118,17 @@ func TestDCTNFTUpdateAttributes_SetNewGasConfig_ShouldWork(t *testing.T) {
 
 	defaultGasCost := uint64(10)
 	newGasCost := uint64(37)
-	e, _ := NewDCTNFTUpdateAttributesFunc(defaultGasCost, vmcommon.BaseOperationCost{}, createNewDCTDataStorageHandler(), &mock.GlobalSettingsHandlerStub{}, &mock.DCTRoleHandlerStub{}, &mock.EnableEpochsHandlerStub{
-		IsDCTNFTImprovementV1FlagEnabledField: true,
+	e, _ := NewDCTNFTUpdateAttributesFunc(ArgsNewDCTNFTUpdateAttributes{
+		Config: Config{
+			DCTStorageHandler:     createNewDCTDataStorageHandler(),
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+				IsDCTNFTImprovementV1FlagEnabledField: true,
+			},
+		},
+		FuncGasCost: defaultGasCost,
+		GasConfig:   vmcommon.BaseOperationCost{},
 	})
 
 	e.SetNewGasConfig(
@@ -90,8 +145,17 @@ func TestDCTNFTUpdateAttributes_SetNewGasConfig_ShouldWork(t *testing.T) {
 func TestDCTNFTUpdateAttributes_ProcessBuiltinFunctionErrorOnCheckInput(t *testing.T) {
 	t.Parallel()
 
-	e, _ := NewDCTNFTUpdateAttributesFunc(10, vmcommon.BaseOperationCost{}, createNewDCTDataStorageHandler(), &mock.GlobalSettingsHandlerStub{}, &mock.DCTRoleHandlerStub{}, &mock.EnableEpochsHandlerStub{
-		IsDCTNFTImprovementV1FlagEnabledField: true,
+	e, _ := NewDCTNFTUpdateAttributesFunc(ArgsNewDCTNFTUpdateAttributes{
+		Config: Config{
+			DCTStorageHandler:     createNewDCTDataStorageHandler(),
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+				IsDCTNFTImprovementV1FlagEnabledField: true,
+			},
+		},
+		FuncGasCost: 10,
+		GasConfig:   vmcommon.BaseOperationCost{},
 	})
 
 	// nil vm input
@@ -193,8 +257,17 @@ func TestDCTNFTUpdateAttributes_ProcessBuiltinFunctionErrorOnCheckInput(t *testi
 func TestDCTNFTUpdateAttributes_ProcessBuiltinFunctionInvalidNumberOfArguments(t *testing.T) {
 	t.Parallel()
 
-	e, _ := NewDCTNFTUpdateAttributesFunc(10, vmcommon.BaseOperationCost{}, createNewDCTDataStorageHandler(), &mock.GlobalSettingsHandlerStub{}, &mock.DCTRoleHandlerStub{}, &mock.EnableEpochsHandlerStub{
-		IsDCTNFTImprovementV1FlagEnabledField: true,
+	e, _ := NewDCTNFTUpdateAttributesFunc(ArgsNewDCTNFTUpdateAttributes{
+		Config: Config{
+			DCTStorageHandler:     createNewDCTDataStorageHandler(),
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+				IsDCTNFTImprovementV1FlagEnabledField: true,
+			},
+		},
+		FuncGasCost: 10,
+		GasConfig:   vmcommon.BaseOperationCost{},
 	})
 	output, err := e.ProcessBuiltinFunction(
 		mock.NewAccountWrapMock([]byte("addr")),
@@ -222,8 +295,17 @@ func TestDCTNFTUpdateAttributes_ProcessBuiltinFunctionCheckAllowedToExecuteError
 			return localErr
 		},
 	}
-	e, _ := NewDCTNFTUpdateAttributesFunc(10, vmcommon.BaseOperationCost{}, createNewDCTDataStorageHandler(), &mock.GlobalSettingsHandlerStub{}, rolesHandler, &mock.EnableEpochsHandlerStub{
-		IsDCTNFTImprovementV1FlagEnabledField: true,
+	e, _ := NewDCTNFTUpdateAttributesFunc(ArgsNewDCTNFTUpdateAttributes{
+		Config: Config{
+			DCTStorageHandler:     createNewDCTDataStorageHandler(),
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          rolesHandler,
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+				IsDCTNFTImprovementV1FlagEnabledField: true,
+			},
+		},
+		FuncGasCost: 10,
+		GasConfig:   vmcommon.BaseOperationCost{},
 	})
 	output, err := e.ProcessBuiltinFunction(
 		mock.NewAccountWrapMock([]byte("addr")),
@@ -246,8 +328,17 @@ func TestDCTNFTUpdateAttributes_ProcessBuiltinFunctionCheckAllowedToExecuteError
 func TestDCTNFTUpdateAttributes_ProcessBuiltinFunctionNewSenderShouldErr(t *testing.T) {
 	t.Parallel()
 
-	e, _ := NewDCTNFTUpdateAttributesFunc(10, vmcommon.BaseOperationCost{}, createNewDCTDataStorageHandler(), &mock.GlobalSettingsHandlerStub{}, &mock.DCTRoleHandlerStub{}, &mock.EnableEpochsHandlerStub{
-		IsDCTNFTImprovementV1FlagEnabledField: true,
+	e, _ := NewDCTNFTUpdateAttributesFunc(ArgsNewDCTNFTUpdateAttributes{
+		Config: Config{
+			DCTStorageHandler:     createNewDCTDataStorageHandler(),
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+				IsDCTNFTImprovementV1FlagEnabledField: true,
+			},
+		},
+		FuncGasCost: 10,
+		GasConfig:   vmcommon.BaseOperationCost{},
 	})
 	output, err := e.ProcessBuiltinFunction(
 		mock.NewAccountWrapMock([]byte("addr")),
@@ -272,8 +363,17 @@ func TestDCTNFTUpdateAttributes_ProcessBuiltinFunctionMetaDataMissing(t *testing
 	t.Parallel()
 
 	marshaller := &mock.MarshalizerMock{}
-	e, _ := NewDCTNFTUpdateAttributesFunc(10, vmcommon.BaseOperationCost{}, createNewDCTDataStorageHandler(), &mock.GlobalSettingsHandlerStub{}, &mock.DCTRoleHandlerStub{}, &mock.EnableEpochsHandlerStub{
-		IsDCTNFTImprovementV1FlagEnabledField: true,
+	e, _ := NewDCTNFTUpdateAttributesFunc(ArgsNewDCTNFTUpdateAttributes{
+		Config: Config{
+			DCTStorageHandler:     createNewDCTDataStorageHandler(),
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+				IsDCTNFTImprovementV1FlagEnabledField: true,
+			},
+		},
+		FuncGasCost: 10,
+		GasConfig:   vmcommon.BaseOperationCost{},
 	})
 
 	userAcc := mock.NewAccountWrapMock([]byte("addr"))
@@ -308,8 +408,17 @@ func TestDCTNFTUpdateAttributes_ProcessBuiltinFunctionShouldErrOnSaveBecauseToke
 		},
 	}
 	var enableEpochsHandler = &mock.EnableEpochsHandlerStub{}
-	e, _ := NewDCTNFTUpdateAttributesFunc(10, vmcommon.BaseOperationCost{}, createNewDCTDataStorageHandlerWithArgs(globalSettingsHandler, &mock.AccountsStub{}, enableEpochsHandler), globalSettingsHandler, &mock.DCTRoleHandlerStub{}, &mock.EnableEpochsHandlerStub{
-		IsDCTNFTImprovementV1FlagEnabledField: true,
+	e, _ := NewDCTNFTUpdateAttributesFunc(ArgsNewDCTNFTUpdateAttributes{
+		Config: Config{
+			DCTStorageHandler:     createNewDCTDataStorageHandlerWithArgs(globalSettingsHandler, &mock.AccountsStub{}, enableEpochsHandler),
+			GlobalSettingsHandler: globalSettingsHandler,
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+				IsDCTNFTImprovementV1FlagEnabledField: true,
+			},
+		},
+		FuncGasCost: 10,
+		GasConfig:   vmcommon.BaseOperationCost{},
 	})
 
 	userAcc := mock.NewAccountWrapMock([]byte("addr"))
@@ -358,8 +467,17 @@ func TestDCTNFTUpdateAttributes_ProcessBuiltinFunctionShouldWork(t *testing.T) {
 			return nil
 		},
 	}
-	e, _ := NewDCTNFTUpdateAttributesFunc(10, vmcommon.BaseOperationCost{}, dctDataStorage, &mock.GlobalSettingsHandlerStub{}, dctRoleHandler, &mock.EnableEpochsHandlerStub{
-		IsDCTNFTImprovementV1FlagEnabledField: true,
+	e, _ := NewDCTNFTUpdateAttributesFunc(ArgsNewDCTNFTUpdateAttributes{
+		Config: Config{
+			DCTStorageHandler:     dctDataStorage,
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          dctRoleHandler,
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+				IsDCTNFTImprovementV1FlagEnabledField: true,
+			},
+		},
+		FuncGasCost: 10,
+		GasConfig:   vmcommon.BaseOperationCost{},
 	})
 
 	userAcc := mock.NewAccountWrapMock([]byte("addr"))
@@ -398,3 +516,97 @@ func TestDCTNFTUpdateAttributes_ProcessBuiltinFunctionShouldWork(t *testing.T) {
 	metaData, _ := dctDataStorage.getDCTMetaDataFromSystemAccount(tokenKey, defaultQueryOptions())
 	require.Equal(t, metaData.Attributes, newAttributes)
 }
+
+func TestDCTNFTUpdateAttributes_ProcessBuiltinFunctionCollectionAttributesLimitShouldErr(t *testing.T) {
+	t.Parallel()
+
+	globalSettingsHandler := &mock.GlobalSettingsHandlerStub{
+		GetCollectionConfigCalled: func(tokenID []byte) vmcommon.CollectionConfig {
+			return vmcommon.CollectionConfig{MaxAttributesLength: 3}
+		},
+	}
+	e, _ := NewDCTNFTUpdateAttributesFunc(ArgsNewDCTNFTUpdateAttributes{
+		Config: Config{
+			DCTStorageHandler:     createNewDCTDataStorageHandler(),
+			GlobalSettingsHandler: globalSettingsHandler,
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+				IsDCTNFTImprovementV1FlagEnabledField: true,
+			},
+		},
+		FuncGasCost: 10,
+		GasConfig:   vmcommon.BaseOperationCost{},
+	})
+	output, err := e.ProcessBuiltinFunction(
+		mock.NewAccountWrapMock([]byte("addr")),
+		nil,
+		&vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallValue:   big.NewInt(0),
+				Arguments:   [][]byte{[]byte("arg0"), []byte("arg1"), []byte("long attributes")},
+				CallerAddr:  []byte("address 1"),
+				GasProvided: 12,
+			},
+			RecipientAddr: []byte("address 1"),
+		},
+	)
+
+	require.Nil(t, output)
+	require.Equal(t, ErrAttributesTooLong, err)
+}
+
+func TestDCTNFTUpdateAttributes_ProcessBuiltinFunctionStorageUsageTracker(t *testing.T) {
+	t.Parallel()
+
+	tokenIdentifier := "testTkn"
+	nonce := big.NewInt(33)
+	dctDataStorage := createNewDCTDataStorageHandler()
+	e, _ := NewDCTNFTUpdateAttributesFunc(ArgsNewDCTNFTUpdateAttributes{
+		Config: Config{
+			DCTStorageHandler:     dctDataStorage,
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			RolesHandler:          &mock.DCTRoleHandlerStub{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+				IsDCTNFTImprovementV1FlagEnabledField: true,
+			},
+		},
+		FuncGasCost: 10,
+		GasConfig:   vmcommon.BaseOperationCost{},
+	})
+	require.Equal(t, ErrNilStorageUsageTracker, e.SetStorageUsageTracker(nil))
+	tracker := NewStorageUsageTracker(vmcommon.StorageUsageConfig{})
+	require.Nil(t, e.SetStorageUsageTracker(tracker))
+
+	userAcc := mock.NewAccountWrapMock([]byte("addr"))
+	dctData := &dct.DCToken{
+		TokenMetaData: &dct.MetaData{
+			Name:       []byte("test"),
+			Attributes: []byte("attributes"),
+		},
+		Value: big.NewInt(1),
+	}
+	dctDataBytes, _ := (&mock.MarshalizerMock{}).Marshal(dctData)
+	tokenKey := append([]byte(baseDCTKeyPrefix+tokenIdentifier), nonce.Bytes()...)
+	_ = userAcc.AccountDataHandler().SaveKeyValue(tokenKey, dctDataBytes)
+	require.Nil(t, tracker.TrackStorageUsage(userAcc, 14))
+
+	updateAttributes := func(attributes []byte) {
+		output, err := e.ProcessBuiltinFunction(userAcc, nil, &vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallValue:   big.NewInt(0),
+				Arguments:   [][]byte{[]byte(tokenIdentifier), nonce.Bytes(), attributes},
+				CallerAddr:  []byte("address 1"),
+				GasProvided: 12,
+			},
+			RecipientAddr: []byte("address 1"),
+		})
+		require.Nil(t, err)
+		require.Equal(t, vmcommon.Ok, output.ReturnCode)
+	}
+
+	updateAttributes([]byte("longer attributes"))
+	assert.Equal(t, uint64(21), tracker.GetStorageUsage(userAcc))
+
+	updateAttributes([]byte("attr"))
+	assert.Equal(t, uint64(8), tracker.GetStorageUsage(userAcc))
+}
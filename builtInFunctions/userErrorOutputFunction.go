@@ -0,0 +1,59 @@
+package builtInFunctions
+
+import (
+	"context"
+
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+)
+
+// userErrorOutputFunction wraps a built-in function and converts the errors caused by the user (invalid input,
+// insufficient gas, missing roles or invalid state) into a VMOutput carrying the return code and the return message
+// of the error. The return message depends only on the error code, so it is deterministic. Internal errors, which are not coded or are caused by the configuration, are still returned as errors
+type userErrorOutputFunction struct {
+	baseFunctionWrapper
+}
+
+func newUserErrorOutputFunction(function vmcommon.BuiltinFunction) *userErrorOutputFunction {
+	return &userErrorOutputFunction{
+		baseFunctionWrapper: baseFunctionWrapper{function: function},
+	}
+}
+
+// ProcessBuiltinFunction calls the wrapped function and converts the user errors into a VMOutput
+func (uef *userErrorOutputFunction) ProcessBuiltinFunction(
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	return uef.processBuiltinFunctionWithContext(context.Background(), acntSnd, acntDst, vmInput)
+}
+
+func (uef *userErrorOutputFunction) processBuiltinFunctionWithContext(
+	ctx context.Context,
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	vmOutput, err := callWithContext(ctx, uef.function, acntSnd, acntDst, vmInput)
+	if err == nil || !isUserError(err) {
+		return vmOutput, err
+	}
+
+	return &vmcommon.VMOutput{
+		ReturnCode:    vmcommon.ReturnCodeFromError(err),
+		ReturnMessage: vmcommon.ReturnMessageFromError(err),
+		GasRemaining:  0,
+	}, nil
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (uef *userErrorOutputFunction) IsInterfaceNil() bool {
+	return uef == nil
+}
+
+func isUserError(err error) bool {
+	switch vmcommon.ErrorCategoryOf(err) {
+	case vmcommon.ErrorCategoryValidation, vmcommon.ErrorCategoryGas, vmcommon.ErrorCategoryRole, vmcommon.ErrorCategoryState:
+		return true
+	default:
+		return false
+	}
+}
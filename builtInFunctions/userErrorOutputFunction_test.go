@@ -0,0 +1,85 @@
+package builtInFunctions
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func createFunctionStubReturningError(err error) *mock.BuiltInFunctionStub {
+	return &mock.BuiltInFunctionStub{
+		ProcessBuiltinFunctionCalled: func(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+			return nil, err
+		},
+	}
+}
+
+func TestUserErrorOutputFunction_ProcessBuiltinFunction(t *testing.T) {
+	t.Parallel()
+
+	t.Run("validation error should return vm output", func(t *testing.T) {
+		t.Parallel()
+
+		uef := newUserErrorOutputFunction(createFunctionStubReturningError(fmt.Errorf("%w, invalid max royality value", ErrInvalidArguments)))
+
+		vmOutput, err := uef.ProcessBuiltinFunction(nil, nil, &vmcommon.ContractCallInput{})
+		assert.Nil(t, err)
+		assert.Equal(t, &vmcommon.VMOutput{
+			ReturnCode:    vmcommon.UserError,
+			ReturnMessage: "invalid arguments to process built-in function",
+		}, vmOutput)
+	})
+	t.Run("gas error should return out of gas vm output", func(t *testing.T) {
+		t.Parallel()
+
+		uef := newUserErrorOutputFunction(createFunctionStubReturningError(ErrNotEnoughGas))
+
+		vmOutput, err := uef.ProcessBuiltinFunction(nil, nil, &vmcommon.ContractCallInput{})
+		assert.Nil(t, err)
+		assert.Equal(t, vmcommon.OutOfGas, vmOutput.ReturnCode)
+		assert.Equal(t, ErrNotEnoughGas.Error(), vmOutput.ReturnMessage)
+	})
+	t.Run("internal errors should be returned", func(t *testing.T) {
+		t.Parallel()
+
+		internalErr := errors.New("trie error")
+		uef := newUserErrorOutputFunction(createFunctionStubReturningError(internalErr))
+		vmOutput, err := uef.ProcessBuiltinFunction(nil, nil, &vmcommon.ContractCallInput{})
+		assert.Nil(t, vmOutput)
+		assert.Equal(t, internalErr, err)
+
+		uef = newUserErrorOutputFunction(createFunctionStubReturningError(ErrNilMarshalizer))
+		vmOutput, err = uef.ProcessBuiltinFunction(nil, nil, &vmcommon.ContractCallInput{})
+		assert.Nil(t, vmOutput)
+		assert.ErrorIs(t, err, ErrNilMarshalizer)
+	})
+	t.Run("successful call should return the output", func(t *testing.T) {
+		t.Parallel()
+
+		uef := newUserErrorOutputFunction(createFunctionStubReturning("message"))
+		vmOutput, err := uef.ProcessBuiltinFunction(nil, nil, &vmcommon.ContractCallInput{})
+		assert.Nil(t, err)
+		assert.Equal(t, "message", vmOutput.ReturnMessage)
+	})
+}
+
+func TestBuiltInFunctionContainer_SetUserErrorsAsVMOutputs(t *testing.T) {
+	t.Parallel()
+
+	c := NewBuiltInFunctionContainer()
+	_ = c.Add("key", createFunctionStubReturningError(ErrInvalidArguments))
+
+	function, _ := c.Get("key")
+	_, err := function.ProcessBuiltinFunction(nil, nil, &vmcommon.ContractCallInput{})
+	assert.Equal(t, ErrInvalidArguments, err)
+
+	c.SetUserErrorsAsVMOutputs(true)
+	function, _ = c.Get("key")
+	vmOutput, err := function.ProcessBuiltinFunction(nil, nil, &vmcommon.ContractCallInput{})
+	assert.Nil(t, err)
+	assert.Equal(t, vmcommon.UserError, vmOutput.ReturnCode)
+}
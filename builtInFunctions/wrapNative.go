@@ -0,0 +1,225 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"math/big"
+	"sync"
+
+	"github.com/Reshusk23/sr-me-core/core"
+	"github.com/Reshusk23/sr-me-core/core/check"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/protectedkeys"
+	"github.com/Reshusk23/sr-vm-common-go/validation"
+)
+
+const numArgumentsWrapNative = 1
+
+var wrappedSupplyKey = []byte(protectedkeys.WrappedNativeSupplyPrefix)
+
+type wrapNative struct {
+	baseActiveHandler
+	freezeAccountChecker
+	lockedBalanceChecker
+	transferInterceptorChecker
+	wrap                  bool
+	keyPrefix             []byte
+	wrappedTokenID        []byte
+	marshaller            vmcommon.Marshalizer
+	globalSettingsHandler vmcommon.DCTGlobalSettingsHandler
+	accounts              vmcommon.AccountsAdapter
+	funcGasCost           uint64
+	mutExecution          sync.RWMutex
+}
+
+// ArgsNewWrapNative defines the argument list for new wrap/unwrap native built in functions
+type ArgsNewWrapNative struct {
+	Config
+	FuncGasCost    uint64
+	WrappedTokenID []byte
+	Wrap           bool
+}
+
+// NewWrapNativeFunc returns the wrap/unwrap native built-in function component. Wrapping locks the native balance
+// on the system account and credits the same amount of the wrapped token, unwrapping reverts it. The wrapped supply is
+// tracked per shard, on the system account of each shard, so the wrapped tokens moved to another shard can only be
+// unwrapped up to the native balance locked on that shard
+func NewWrapNativeFunc(args ArgsNewWrapNative) (*wrapNative, error) {
+	function := vmcommon.BuiltInFunctionUnwrapNative
+	if args.Wrap {
+		function = vmcommon.BuiltInFunctionWrapNative
+	}
+	err := args.checkComponents(function, requireMarshalizer|requireAccounts|requireGlobalSettingsHandler|requireEnableEpochsHandler)
+	if err != nil {
+		return nil, err
+	}
+	if !vmcommon.ValidateToken(args.WrappedTokenID) {
+		return nil, ErrInvalidTokenID
+	}
+
+	e := &wrapNative{
+		lockedBalanceChecker:  lockedBalanceChecker{enableEpochsHandler: args.EnableEpochsHandler},
+		wrap:                  args.Wrap,
+		keyPrefix:             []byte(baseDCTKeyPrefix),
+		wrappedTokenID:        args.WrappedTokenID,
+		marshaller:            args.Marshalizer,
+		globalSettingsHandler: args.GlobalSettingsHandler,
+		accounts:              args.Accounts,
+		funcGasCost:           args.FuncGasCost,
+	}
+
+	e.baseActiveHandler.activeHandler = args.EnableEpochsHandler.IsWrapNativeFlagEnabled
+
+	return e, nil
+}
+
+// SetNewGasConfig is called whenever gas cost is changed
+func (e *wrapNative) SetNewGasConfig(gasCost *vmcommon.GasCost) {
+	if gasCost == nil {
+		return
+	}
+
+	e.mutExecution.Lock()
+	e.funcGasCost = gasCost.BuiltInCost.DCTLocalMint
+	if !e.wrap {
+		e.funcGasCost = gasCost.BuiltInCost.DCTLocalBurn
+	}
+	e.mutExecution.Unlock()
+}
+
+// ProcessBuiltinFunction resolves wrap/unwrap native function call
+// Requires 1 argument:
+// arg0 - amount to wrap or unwrap
+func (e *wrapNative) ProcessBuiltinFunction(
+	acntSnd, _ vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	e.mutExecution.RLock()
+	defer e.mutExecution.RUnlock()
+
+	if vmInput == nil {
+		return nil, ErrNilVmInput
+	}
+	err := checkCallValue(vmcommon.CallValueForbidden, vmInput.CallValue)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(vmInput.CallerAddr, vmInput.RecipientAddr) {
+		return nil, ErrInvalidRcvAddr
+	}
+	if check.IfNil(acntSnd) {
+		return nil, ErrNilUserAccount
+	}
+	if len(vmInput.Arguments) != numArgumentsWrapNative {
+		return nil, ErrInvalidArguments
+	}
+	if vmInput.GasProvided < e.funcGasCost {
+		return nil, ErrNotEnoughGas
+	}
+	err = checkFunctionArguments(vmInput.Arguments, validation.RequireBigIntMaxBytes(0, core.MaxLenForDCTIssueMint))
+	if err != nil {
+		return nil, err
+	}
+	amount := big.NewInt(0).SetBytes(vmInput.Arguments[0])
+	if amount.Cmp(zero) <= 0 {
+		return nil, ErrNegativeValue
+	}
+
+	err = e.checkAccountIsNotFrozen(vmInput.CallerAddr, vmInput.ReturnCallAfterError)
+	if err != nil {
+		return nil, err
+	}
+	err = e.checkTransferIsAllowed(vmInput.CallerAddr, vmInput.RecipientAddr, e.wrappedTokenID, 0, amount, vmInput.ReturnCallAfterError)
+	if err != nil {
+		return nil, err
+	}
+
+	systemAcc, err := getSystemAccount(e.accounts, e.getSystemAddresses().SystemAccountAddress)
+	if err != nil {
+		return nil, err
+	}
+	wrappedSupply, err := checkWrappedSupply(systemAcc)
+	if err != nil {
+		return nil, err
+	}
+
+	delta := big.NewInt(0).Set(amount)
+	identifier := vmcommon.BuiltInFunctionWrapNative
+	if !e.wrap {
+		if wrappedSupply.Cmp(amount) < 0 {
+			return nil, ErrInsufficientWrappedSupply
+		}
+		delta.Neg(delta)
+		identifier = vmcommon.BuiltInFunctionUnwrapNative
+	}
+
+	dctTokenKey := append(e.keyPrefix, e.wrappedTokenID...)
+	if e.wrap {
+		err = addToDCTBalance(acntSnd, dctTokenKey, amount, e.marshaller, e.globalSettingsHandler, e.getSystemAddresses(), vmInput.ReturnCallAfterError)
+	} else {
+		err = e.subFromDCTBalance(acntSnd, dctTokenKey, amount, e.marshaller, e.globalSettingsHandler, e.getSystemAddresses(), vmInput.ReturnCallAfterError)
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = moveNativeBalance(acntSnd, systemAcc, delta)
+	if err != nil {
+		return nil, err
+	}
+
+	wrappedSupply.Add(wrappedSupply, delta)
+	err = systemAcc.AccountDataHandler().SaveKeyValue(wrappedSupplyKey, wrappedSupply.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	_, err = checkWrappedSupply(systemAcc)
+	if err != nil {
+		return nil, err
+	}
+
+	err = e.accounts.SaveAccount(systemAcc)
+	if err != nil {
+		return nil, err
+	}
+
+	vmOutput := &vmcommon.VMOutput{ReturnCode: vmcommon.Ok, GasRemaining: vmInput.GasProvided - e.funcGasCost}
+	addDCTEntryInVMOutput(vmOutput, []byte(identifier), e.wrappedTokenID, 0, amount, vmInput.CallerAddr)
+
+	return vmOutput, nil
+}
+
+// moveNativeBalance moves the provided native amount from the account to the system account, a negative amount
+// moving it back
+func moveNativeBalance(acnt vmcommon.UserAccountHandler, systemAcc vmcommon.UserAccountHandler, amount *big.Int) error {
+	err := acnt.AddToBalance(big.NewInt(0).Neg(amount))
+	if err != nil {
+		return ErrInsufficientFunds
+	}
+
+	err = systemAcc.AddToBalance(amount)
+	if err != nil {
+		return ErrInsufficientFunds
+	}
+
+	return nil
+}
+
+// checkWrappedSupply returns the supply of the wrapped native token of the shard, provided it is covered by the native
+// balance of the system account, which might also hold native tokens received otherwise
+func checkWrappedSupply(systemAcc vmcommon.UserAccountHandler) (*big.Int, error) {
+	val, _, err := systemAcc.AccountDataHandler().RetrieveValue(wrappedSupplyKey)
+	if err != nil {
+		return nil, err
+	}
+
+	wrappedSupply := big.NewInt(0).SetBytes(val)
+	if wrappedSupply.Cmp(vmcommon.ZeroValueIfNil(systemAcc.GetBalance())) > 0 {
+		return nil, ErrWrappedSupplyMismatch
+	}
+
+	return wrappedSupply, nil
+}
+
+// IsInterfaceNil returns true if underlying object in nil
+func (e *wrapNative) IsInterfaceNil() bool {
+	return e == nil
+}
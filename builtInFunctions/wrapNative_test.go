@@ -0,0 +1,352 @@
+package builtInFunctions
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/Reshusk23/sr-me-core/core"
+	"github.com/Reshusk23/sr-me-core/core/check"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var wrappedTokenID = []byte("WREWA-abcdef")
+
+func createWrapNativeInput(caller []byte, amount int64) *vmcommon.ContractCallInput {
+	return &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  caller,
+			CallValue:   big.NewInt(0),
+			Arguments:   [][]byte{big.NewInt(amount).Bytes()},
+			GasProvided: 100,
+		},
+		RecipientAddr: caller,
+	}
+}
+
+func createWrapNativeFuncs(systemAcc vmcommon.UserAccountHandler) (*wrapNative, *wrapNative) {
+	accounts := &mock.AccountsStub{
+		LoadAccountCalled: func(address []byte) (vmcommon.AccountHandler, error) {
+			return systemAcc, nil
+		},
+	}
+	wrapFunc, _ := NewWrapNativeFunc(ArgsNewWrapNative{
+		Config: Config{
+			Marshalizer:           &mock.MarshalizerMock{},
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			Accounts:              accounts,
+			EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+		},
+		FuncGasCost:    10,
+		WrappedTokenID: wrappedTokenID,
+		Wrap:           true,
+	})
+	unwrapFunc, _ := NewWrapNativeFunc(ArgsNewWrapNative{
+		Config: Config{
+			Marshalizer:           &mock.MarshalizerMock{},
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			Accounts:              accounts,
+			EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+		},
+		FuncGasCost:    10,
+		WrappedTokenID: wrappedTokenID,
+		Wrap:           false,
+	})
+
+	return wrapFunc, unwrapFunc
+}
+
+func TestNewWrapNativeFunc(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid token should error", func(t *testing.T) {
+		t.Parallel()
+
+		e, err := NewWrapNativeFunc(ArgsNewWrapNative{
+			Config: Config{
+				Marshalizer:           &mock.MarshalizerMock{},
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				Accounts:              &mock.AccountsStub{},
+				EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			},
+			FuncGasCost:    10,
+			WrappedTokenID: []byte("WREWA"),
+			Wrap:           true,
+		})
+		assert.True(t, check.IfNil(e))
+		assert.Equal(t, ErrInvalidTokenID, err)
+	})
+	t.Run("nil marshaller should error", func(t *testing.T) {
+		t.Parallel()
+
+		e, err := NewWrapNativeFunc(ArgsNewWrapNative{
+			Config: Config{
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				Accounts:              &mock.AccountsStub{},
+				EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			},
+			FuncGasCost:    10,
+			WrappedTokenID: wrappedTokenID,
+			Wrap:           true,
+		})
+		assert.True(t, check.IfNil(e))
+		assert.ErrorIs(t, err, ErrNilMarshalizer)
+	})
+	t.Run("nil global settings handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		e, err := NewWrapNativeFunc(ArgsNewWrapNative{
+			Config: Config{
+				Marshalizer:         &mock.MarshalizerMock{},
+				Accounts:            &mock.AccountsStub{},
+				EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+			},
+			FuncGasCost:    10,
+			WrappedTokenID: wrappedTokenID,
+			Wrap:           true,
+		})
+		assert.True(t, check.IfNil(e))
+		assert.ErrorIs(t, err, ErrNilGlobalSettingsHandler)
+	})
+	t.Run("nil accounts should error", func(t *testing.T) {
+		t.Parallel()
+
+		e, err := NewWrapNativeFunc(ArgsNewWrapNative{
+			Config: Config{
+				Marshalizer:           &mock.MarshalizerMock{},
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			},
+			FuncGasCost:    10,
+			WrappedTokenID: wrappedTokenID,
+			Wrap:           true,
+		})
+		assert.True(t, check.IfNil(e))
+		assert.ErrorIs(t, err, ErrNilAccountsAdapter)
+	})
+	t.Run("nil enable epochs handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		e, err := NewWrapNativeFunc(ArgsNewWrapNative{
+			Config: Config{
+				Marshalizer:           &mock.MarshalizerMock{},
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				Accounts:              &mock.AccountsStub{},
+			},
+			FuncGasCost:    10,
+			WrappedTokenID: wrappedTokenID,
+			Wrap:           true,
+		})
+		assert.True(t, check.IfNil(e))
+		assert.ErrorIs(t, err, ErrNilEnableEpochsHandler)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		enableEpochsHandler := &mock.EnableEpochsHandlerStub{}
+		e, err := NewWrapNativeFunc(ArgsNewWrapNative{
+			Config: Config{
+				Marshalizer:           &mock.MarshalizerMock{},
+				GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+				Accounts:              &mock.AccountsStub{},
+				EnableEpochsHandler:   enableEpochsHandler,
+			},
+			FuncGasCost:    10,
+			WrappedTokenID: wrappedTokenID,
+			Wrap:           true,
+		})
+		assert.False(t, check.IfNil(e))
+		assert.Nil(t, err)
+		assert.False(t, e.IsActive())
+
+		enableEpochsHandler.IsWrapNativeFlagEnabledField = true
+		assert.True(t, e.IsActive())
+	})
+}
+
+func TestWrapNative_SetNewGasConfig(t *testing.T) {
+	t.Parallel()
+
+	wrapFunc, unwrapFunc := createWrapNativeFuncs(mock.NewUserAccount(vmcommon.SystemAccountAddress))
+	gasCost := &vmcommon.GasCost{BuiltInCost: vmcommon.BuiltInCost{DCTLocalMint: 20, DCTLocalBurn: 30}}
+
+	wrapFunc.SetNewGasConfig(gasCost)
+	assert.Equal(t, uint64(20), wrapFunc.funcGasCost)
+	unwrapFunc.SetNewGasConfig(gasCost)
+	assert.Equal(t, uint64(30), unwrapFunc.funcGasCost)
+}
+
+func TestWrapNative_ProcessBuiltinFunctionErrors(t *testing.T) {
+	t.Parallel()
+
+	caller := mock.NewUserAccount([]byte("caller"))
+	_ = caller.AddToBalance(big.NewInt(100))
+	wrapFunc, unwrapFunc := createWrapNativeFuncs(mock.NewUserAccount(vmcommon.SystemAccountAddress))
+
+	input := createWrapNativeInput(caller.AddressBytes(), 10)
+	input.RecipientAddr = []byte("recipient")
+	_, err := wrapFunc.ProcessBuiltinFunction(caller, nil, input)
+	assert.Equal(t, ErrInvalidRcvAddr, err)
+
+	input = createWrapNativeInput(caller.AddressBytes(), 10)
+	input.Arguments = append(input.Arguments, []byte("extra"))
+	_, err = wrapFunc.ProcessBuiltinFunction(caller, nil, input)
+	assert.Equal(t, ErrInvalidArguments, err)
+
+	_, err = wrapFunc.ProcessBuiltinFunction(caller, nil, createWrapNativeInput(caller.AddressBytes(), 0))
+	assert.Equal(t, ErrNegativeValue, err)
+
+	input = createWrapNativeInput(caller.AddressBytes(), 10)
+	input.GasProvided = 5
+	_, err = wrapFunc.ProcessBuiltinFunction(caller, nil, input)
+	assert.Equal(t, ErrNotEnoughGas, err)
+
+	_, err = wrapFunc.ProcessBuiltinFunction(caller, nil, createWrapNativeInput(caller.AddressBytes(), 101))
+	assert.Equal(t, ErrInsufficientFunds, err)
+
+	_, err = unwrapFunc.ProcessBuiltinFunction(caller, nil, createWrapNativeInput(caller.AddressBytes(), 1))
+	assert.Equal(t, ErrInsufficientWrappedSupply, err)
+
+	_ = wrapFunc.SetFreezeAccountHandler(&mock.FreezeAccountHandlerStub{
+		IsAccountFrozenCalled: func(address []byte) bool {
+			return true
+		},
+	})
+	_, err = wrapFunc.ProcessBuiltinFunction(caller, nil, createWrapNativeInput(caller.AddressBytes(), 10))
+	assert.Equal(t, ErrAccountIsFrozen, err)
+	assert.Equal(t, big.NewInt(100), caller.GetBalance())
+
+	expectedErr := errors.New("wrap vetoed")
+	wrapFunc, _ = createWrapNativeFuncs(mock.NewUserAccount(vmcommon.SystemAccountAddress))
+	_ = wrapFunc.SetTransferInterceptor(&mock.TransferInterceptorStub{
+		PreTransferCalled: func(sender []byte, receiver []byte, token []byte, nonce uint64, amount *big.Int) error {
+			assert.Equal(t, caller.AddressBytes(), sender)
+			assert.Equal(t, caller.AddressBytes(), receiver)
+			assert.Equal(t, wrappedTokenID, token)
+			assert.Equal(t, big.NewInt(10), amount)
+			return expectedErr
+		},
+	})
+	_, err = wrapFunc.ProcessBuiltinFunction(caller, nil, createWrapNativeInput(caller.AddressBytes(), 10))
+	assert.Equal(t, expectedErr, err)
+	assert.Equal(t, big.NewInt(100), caller.GetBalance())
+}
+
+func TestWrapNative_ProcessBuiltinFunctionShouldWork(t *testing.T) {
+	t.Parallel()
+
+	caller := mock.NewUserAccount([]byte("caller"))
+	_ = caller.AddToBalance(big.NewInt(100))
+	systemAcc := mock.NewUserAccount(vmcommon.SystemAccountAddress)
+	wrapFunc, unwrapFunc := createWrapNativeFuncs(systemAcc)
+	marshaller := &mock.MarshalizerMock{}
+	dctTokenKey := append([]byte(baseDCTKeyPrefix), wrappedTokenID...)
+
+	vmOutput, err := wrapFunc.ProcessBuiltinFunction(caller, nil, createWrapNativeInput(caller.AddressBytes(), 60))
+	require.Nil(t, err)
+	assert.Equal(t, uint64(90), vmOutput.GasRemaining)
+	assert.Equal(t, big.NewInt(40), caller.GetBalance())
+	assert.Equal(t, big.NewInt(60), systemAcc.GetBalance())
+	assert.Equal(t, big.NewInt(60), getDCTBalanceForTest(t, caller, dctTokenKey, marshaller))
+	require.Equal(t, 1, len(vmOutput.Logs))
+	assert.Equal(t, []byte(vmcommon.BuiltInFunctionWrapNative), vmOutput.Logs[0].Identifier)
+	assert.Equal(t, [][]byte{wrappedTokenID, {}, big.NewInt(60).Bytes()}, vmOutput.Logs[0].Topics)
+
+	vmOutput, err = unwrapFunc.ProcessBuiltinFunction(caller, nil, createWrapNativeInput(caller.AddressBytes(), 25))
+	require.Nil(t, err)
+	assert.Equal(t, big.NewInt(65), caller.GetBalance())
+	assert.Equal(t, big.NewInt(35), systemAcc.GetBalance())
+	assert.Equal(t, big.NewInt(35), getDCTBalanceForTest(t, caller, dctTokenKey, marshaller))
+	assert.Equal(t, []byte(vmcommon.BuiltInFunctionUnwrapNative), vmOutput.Logs[0].Identifier)
+
+	_, err = unwrapFunc.ProcessBuiltinFunction(caller, nil, createWrapNativeInput(caller.AddressBytes(), 36))
+	assert.Equal(t, ErrInsufficientWrappedSupply, err)
+
+	// native tokens received otherwise by the system account do not break the supply check
+	_ = systemAcc.AddToBalance(big.NewInt(7))
+	_, err = unwrapFunc.ProcessBuiltinFunction(caller, nil, createWrapNativeInput(caller.AddressBytes(), 35))
+	require.Nil(t, err)
+	assert.Equal(t, big.NewInt(7), systemAcc.GetBalance())
+}
+
+func TestWrapNative_ProcessBuiltinFunctionLockedBalanceShouldErr(t *testing.T) {
+	t.Parallel()
+
+	caller := mock.NewUserAccount([]byte("caller"))
+	_ = caller.AddToBalance(big.NewInt(100))
+	systemAcc := mock.NewUserAccount(vmcommon.SystemAccountAddress)
+	wrapFunc, unwrapFunc := createWrapNativeFuncs(systemAcc)
+	unwrapFunc.enableEpochsHandler.(*mock.EnableEpochsHandlerStub).CurrentEpochField = 5
+	marshaller := &mock.MarshalizerMock{}
+	dctTokenKey := append([]byte(baseDCTKeyPrefix), wrappedTokenID...)
+
+	_, err := wrapFunc.ProcessBuiltinFunction(caller, nil, createWrapNativeInput(caller.AddressBytes(), 30))
+	require.Nil(t, err)
+	lockDCTBalanceForTest(t, caller, dctTokenKey, marshaller, 20, 10)
+
+	_, err = unwrapFunc.ProcessBuiltinFunction(caller, nil, createWrapNativeInput(caller.AddressBytes(), 15))
+	assert.Equal(t, ErrDCTBalanceIsLocked, err)
+
+	_, err = unwrapFunc.ProcessBuiltinFunction(caller, nil, createWrapNativeInput(caller.AddressBytes(), 10))
+	require.Nil(t, err)
+	assert.Equal(t, big.NewInt(20), getDCTBalanceForTest(t, caller, dctTokenKey, marshaller))
+	assert.Equal(t, big.NewInt(80), caller.GetBalance())
+}
+
+func TestWrapNative_ProcessBuiltinFunctionWrappedOnAnotherShard(t *testing.T) {
+	t.Parallel()
+
+	caller := mock.NewUserAccount([]byte("caller"))
+	systemAcc := mock.NewUserAccount(vmcommon.SystemAccountAddress)
+	_ = systemAcc.AddToBalance(big.NewInt(100))
+	_, unwrapFunc := createWrapNativeFuncs(systemAcc)
+	marshaller := &mock.MarshalizerMock{}
+	dctTokenKey := append([]byte(baseDCTKeyPrefix), wrappedTokenID...)
+	// the wrapped tokens were received from another shard, the native balance being locked there
+	err := addToDCTBalance(caller, dctTokenKey, big.NewInt(10), marshaller, &mock.GlobalSettingsHandlerStub{}, vmcommon.DefaultSystemAddresses(), false)
+	require.Nil(t, err)
+
+	_, err = unwrapFunc.ProcessBuiltinFunction(caller, nil, createWrapNativeInput(caller.AddressBytes(), 10))
+	assert.Equal(t, ErrInsufficientWrappedSupply, err)
+	assert.Equal(t, big.NewInt(100), systemAcc.GetBalance())
+	assert.Equal(t, big.NewInt(10), getDCTBalanceForTest(t, caller, dctTokenKey, marshaller))
+}
+
+func TestWrapNative_ProcessBuiltinFunctionSupplyMismatch(t *testing.T) {
+	t.Parallel()
+
+	caller := mock.NewUserAccount([]byte("caller"))
+	_ = caller.AddToBalance(big.NewInt(100))
+	systemAcc := mock.NewUserAccount(vmcommon.SystemAccountAddress)
+	_ = systemAcc.AddToBalance(big.NewInt(1))
+	_ = systemAcc.SaveKeyValue(wrappedSupplyKey, big.NewInt(2).Bytes())
+	wrapFunc, _ := createWrapNativeFuncs(systemAcc)
+
+	_, err := wrapFunc.ProcessBuiltinFunction(caller, nil, createWrapNativeInput(caller.AddressBytes(), 10))
+	assert.Equal(t, ErrWrappedSupplyMismatch, err)
+	assert.Equal(t, big.NewInt(100), caller.GetBalance())
+}
+
+func getDCTBalanceForTest(t *testing.T, acnt vmcommon.UserAccountHandler, dctTokenKey []byte, marshaller vmcommon.Marshalizer) *big.Int {
+	dctData, err := getDCTDataFromKey(acnt, dctTokenKey, marshaller)
+	require.Nil(t, err)
+	require.Equal(t, uint32(core.Fungible), dctData.Type)
+
+	return dctData.Value
+}
+
+func lockDCTBalanceForTest(t *testing.T, acnt vmcommon.UserAccountHandler, dctTokenKey []byte, marshaller vmcommon.Marshalizer, value int64, unlockEpoch uint32) {
+	dctData, err := getDCTDataFromKey(acnt, dctTokenKey, marshaller)
+	require.Nil(t, err)
+
+	dctUserMetadata := DCTUserMetadataFromBytes(dctData.Properties)
+	err = dctUserMetadata.addLockedValue(big.NewInt(value), unlockEpoch, 0)
+	require.Nil(t, err)
+	dctData.Properties = dctUserMetadata.ToBytes()
+
+	err = saveDCTData(acnt, dctData, dctTokenKey, marshaller)
+	require.Nil(t, err)
+}
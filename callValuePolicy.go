@@ -0,0 +1,27 @@
+package vmcommon
+
+// CallValuePolicy defines whether a built-in function can be called with native value
+type CallValuePolicy int
+
+const (
+	// CallValueForbidden rejects the calls carrying native value
+	CallValueForbidden CallValuePolicy = iota
+	// CallValueAllowed accepts the calls with or without native value
+	CallValueAllowed
+	// CallValueRequired rejects the calls which do not carry native value
+	CallValueRequired
+)
+
+// String returns the human readable name of the policy
+func (policy CallValuePolicy) String() string {
+	switch policy {
+	case CallValueForbidden:
+		return "forbidden"
+	case CallValueAllowed:
+		return "allowed"
+	case CallValueRequired:
+		return "required"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,16 @@
+package vmcommon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCallValuePolicy_String(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "forbidden", CallValueForbidden.String())
+	assert.Equal(t, "allowed", CallValueAllowed.String())
+	assert.Equal(t, "required", CallValueRequired.String())
+	assert.Equal(t, "unknown", CallValuePolicy(10).String())
+}
@@ -0,0 +1,11 @@
+package main
+
+import "errors"
+
+var errInvalidHex = errors.New("invalid hex value")
+
+var errInvalidBigInt = errors.New("invalid big integer")
+
+var errUnknownFlag = errors.New("unknown enable epochs flag")
+
+var errMissingFunction = errors.New("missing function")
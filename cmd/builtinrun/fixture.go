@@ -0,0 +1,218 @@
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"reflect"
+
+	"github.com/Reshusk23/sr-me-core/core"
+	"github.com/Reshusk23/sr-me-core/data/dct"
+	"github.com/Reshusk23/sr-me-core/marshal"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/benchmarks"
+	"github.com/Reshusk23/sr-vm-common-go/dctkeys"
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/Reshusk23/sr-vm-common-go/protectedkeys"
+)
+
+// stateFixture describes the accounts the call is executed on. The addresses, the storage keys and the storage values
+// are hex encoded, while the balances are decimal strings
+type stateFixture struct {
+	// GasPerUnit is the value of every cost of the gas schedule, 1 when missing
+	GasPerUnit uint64 `json:"gasPerUnit"`
+	// EnabledFlags holds the names of the enable epochs handler methods returning true, e.g. "IsSaveToSystemAccountFlagEnabled"
+	EnabledFlags []string         `json:"enabledFlags"`
+	Accounts     []accountFixture `json:"accounts"`
+}
+
+type accountFixture struct {
+	Address string            `json:"address"`
+	Balance string            `json:"balance"`
+	Nonce   uint64            `json:"nonce"`
+	Tokens  []tokenFixture    `json:"tokens"`
+	Storage map[string]string `json:"storage"`
+}
+
+// tokenFixture describes a token balance held by an account. The tokens with a nonce are stored as NFTs holding their
+// metadata, and the roles are written only when provided
+type tokenFixture struct {
+	Identifier string   `json:"identifier"`
+	Nonce      uint64   `json:"nonce"`
+	Value      string   `json:"value"`
+	Roles      []string `json:"roles"`
+}
+
+// callFixture describes the built-in function call, the addresses and the arguments being hex encoded
+type callFixture struct {
+	Function    string   `json:"function"`
+	Caller      string   `json:"caller"`
+	Recipient   string   `json:"recipient"`
+	CallValue   string   `json:"callValue"`
+	GasProvided uint64   `json:"gasProvided"`
+	Arguments   []string `json:"arguments"`
+}
+
+func loadJSONFile(path string, value interface{}) error {
+	buff, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(buff, value)
+}
+
+func decodeHex(value string, field string) ([]byte, error) {
+	decoded, err := hex.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w for %s: %s", errInvalidHex, field, value)
+	}
+
+	return decoded, nil
+}
+
+func decodeBigInt(value string, field string) (*big.Int, error) {
+	if len(value) == 0 {
+		return big.NewInt(0), nil
+	}
+
+	decoded, ok := big.NewInt(0).SetString(value, 10)
+	if !ok {
+		return nil, fmt.Errorf("%w for %s: %s", errInvalidBigInt, field, value)
+	}
+
+	return decoded, nil
+}
+
+func createEnableEpochsHandler(enabledFlags []string) (*mock.EnableEpochsHandlerStub, error) {
+	handler := &mock.EnableEpochsHandlerStub{}
+	fields := reflect.ValueOf(handler).Elem()
+	for _, flag := range enabledFlags {
+		field := fields.FieldByName(flag + "Field")
+		if !field.IsValid() || field.Kind() != reflect.Bool {
+			return nil, fmt.Errorf("%w: %s", errUnknownFlag, flag)
+		}
+
+		field.SetBool(true)
+	}
+
+	return handler, nil
+}
+
+func loadAccounts(state *stateFixture, marshaller marshal.Marshalizer) (*benchmarks.InMemoryAccounts, error) {
+	accounts := benchmarks.NewInMemoryAccounts(len(state.Accounts))
+	for i, accountData := range state.Accounts {
+		field := fmt.Sprintf("account %d", i)
+		address, err := decodeHex(accountData.Address, field+" address")
+		if err != nil {
+			return nil, err
+		}
+		balance, err := decodeBigInt(accountData.Balance, field+" balance")
+		if err != nil {
+			return nil, err
+		}
+
+		account := accounts.LoadUserAccount(address)
+		account.Balance = balance
+		account.Nonce = accountData.Nonce
+		for key, value := range accountData.Storage {
+			decodedKey, errDecode := decodeHex(key, field+" storage key")
+			if errDecode != nil {
+				return nil, errDecode
+			}
+			decodedValue, errDecode := decodeHex(value, field+" storage value")
+			if errDecode != nil {
+				return nil, errDecode
+			}
+
+			account.Storage[string(decodedKey)] = decodedValue
+		}
+
+		err = addTokensToAccount(account, accountData.Tokens, marshaller, field)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return accounts, nil
+}
+
+func addTokensToAccount(account *mock.Account, tokens []tokenFixture, marshaller marshal.Marshalizer, field string) error {
+	for _, token := range tokens {
+		value, err := decodeBigInt(token.Value, field+" token "+token.Identifier)
+		if err != nil {
+			return err
+		}
+
+		dctData := &dct.DCToken{
+			Type:  uint32(core.Fungible),
+			Value: value,
+		}
+		if token.Nonce > 0 {
+			dctData.Type = uint32(core.NonFungible)
+			dctData.TokenMetaData = &dct.MetaData{Nonce: token.Nonce}
+		}
+		marshaledData, err := marshaller.Marshal(dctData)
+		if err != nil {
+			return err
+		}
+		tokenKey := dctkeys.ComputeDCTNFTTokenKey(dctkeys.ComputeDCTTokenKey([]byte(token.Identifier)), token.Nonce)
+		account.Storage[string(tokenKey)] = marshaledData
+
+		if len(token.Roles) == 0 {
+			continue
+		}
+		roles := &dct.DCTRoles{Roles: make([][]byte, 0, len(token.Roles))}
+		for _, role := range token.Roles {
+			roles.Roles = append(roles.Roles, []byte(role))
+		}
+		marshaledRoles, err := marshaller.Marshal(roles)
+		if err != nil {
+			return err
+		}
+		account.Storage[protectedkeys.DCTRolePrefix+token.Identifier] = marshaledRoles
+	}
+
+	return nil
+}
+
+func createContractCallInput(call *callFixture) (*vmcommon.ContractCallInput, error) {
+	if len(call.Function) == 0 {
+		return nil, errMissingFunction
+	}
+	caller, err := decodeHex(call.Caller, "caller")
+	if err != nil {
+		return nil, err
+	}
+	recipient, err := decodeHex(call.Recipient, "recipient")
+	if err != nil {
+		return nil, err
+	}
+	callValue, err := decodeBigInt(call.CallValue, "call value")
+	if err != nil {
+		return nil, err
+	}
+
+	arguments := make([][]byte, 0, len(call.Arguments))
+	for i, argument := range call.Arguments {
+		decoded, errDecode := decodeHex(argument, fmt.Sprintf("argument %d", i))
+		if errDecode != nil {
+			return nil, errDecode
+		}
+
+		arguments = append(arguments, decoded)
+	}
+
+	return &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  caller,
+			Arguments:   arguments,
+			CallValue:   callValue,
+			GasProvided: call.GasProvided,
+		},
+		RecipientAddr: recipient,
+		Function:      call.Function,
+	}, nil
+}
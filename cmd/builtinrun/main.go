@@ -0,0 +1,70 @@
+// Command builtinrun executes a single built-in function call on an in-memory state loaded from a JSON fixture and
+// prints, as JSON, the resulting VMOutput together with the execution trace. It is meant for debugging the gas
+// consumption and the argument layouts while integrating the built-in functions:
+//
+//	builtinrun -state state.json -call call.json
+//
+// The state is discarded after the call, so the same fixtures always print the same result. The command exits with
+// a non-zero status when the fixtures are invalid or the built-in function fails
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+func main() {
+	statePath := flag.String("state", "", "path of the JSON file describing the accounts")
+	callPath := flag.String("call", "", "path of the JSON file describing the built-in function call")
+	flag.Parse()
+
+	if len(*statePath) == 0 || len(*callPath) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	result, err := runFixtures(*statePath, *callPath)
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	err = printResult(os.Stdout, result)
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if len(result.Error) > 0 {
+		os.Exit(1)
+	}
+}
+
+func runFixtures(statePath string, callPath string) (*runResult, error) {
+	state := &stateFixture{}
+	err := loadJSONFile(statePath, state)
+	if err != nil {
+		return nil, fmt.Errorf("%w while loading the state", err)
+	}
+	call := &callFixture{}
+	err = loadJSONFile(callPath, call)
+	if err != nil {
+		return nil, fmt.Errorf("%w while loading the call", err)
+	}
+
+	r, err := newRunner(state)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.run(call)
+}
+
+func printResult(writer io.Writer, result *runResult) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(result)
+}
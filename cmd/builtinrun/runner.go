@@ -0,0 +1,103 @@
+package main
+
+import (
+	"github.com/Reshusk23/sr-me-core/marshal"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/benchmarks"
+	"github.com/Reshusk23/sr-vm-common-go/builtInFunctions"
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+)
+
+const (
+	defaultGasPerUnit                = 1
+	maxNumOfAddressesForTransferRole = 100
+)
+
+// runner executes the built-in function calls on a single shard state loaded from a fixture
+type runner struct {
+	accounts  *benchmarks.InMemoryAccounts
+	container vmcommon.BuiltInFunctionContainer
+}
+
+// runResult holds everything the execution produced, the trace being filled in even when the call fails
+type runResult struct {
+	VMOutput *vmOutputView `json:"vmOutput,omitempty"`
+	Trace    *traceView    `json:"trace,omitempty"`
+	Error    string        `json:"error,omitempty"`
+}
+
+func newRunner(state *stateFixture) (*runner, error) {
+	enableEpochsHandler, err := createEnableEpochsHandler(state.EnabledFlags)
+	if err != nil {
+		return nil, err
+	}
+
+	marshaller := &marshal.GogoProtoMarshalizer{}
+	accounts, err := loadAccounts(state, marshaller)
+	if err != nil {
+		return nil, err
+	}
+
+	gasPerUnit := state.GasPerUnit
+	if gasPerUnit == 0 {
+		gasPerUnit = defaultGasPerUnit
+	}
+
+	creator, err := builtInFunctions.NewBuiltInFunctionsCreator(builtInFunctions.ArgsCreateBuiltInFunctionContainer{
+		GasMap:                           benchmarks.CreateGasMap(gasPerUnit),
+		MapDNSAddresses:                  make(map[string]struct{}),
+		Marshalizer:                      marshaller,
+		Accounts:                         accounts,
+		ShardCoordinator:                 mock.NewMultiShardsCoordinatorMock(1),
+		EnableEpochsHandler:              enableEpochsHandler,
+		MaxNumOfAddressesForTransferRole: maxNumOfAddressesForTransferRole,
+		ChainID:                          []byte("local"),
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = creator.CreateBuiltInFunctionContainer()
+	if err != nil {
+		return nil, err
+	}
+	// the accounts of the fixtures do not hold code, hence they are all considered payable
+	err = creator.SetPayableHandler(&mock.PayableHandlerStub{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &runner{
+		accounts:  accounts,
+		container: creator.BuiltInFunctionContainer(),
+	}, nil
+}
+
+// run executes the call as a node would on the shard holding both the caller and the recipient. The errors of the
+// built-in function are returned in the result, only the invalid calls being returned as errors
+func (r *runner) run(call *callFixture) (*runResult, error) {
+	vmInput, err := createContractCallInput(call)
+	if err != nil {
+		return nil, err
+	}
+
+	function, err := r.container.Get(vmInput.Function)
+	if err != nil {
+		return nil, err
+	}
+
+	acntSnd := r.accounts.LoadUserAccount(vmInput.CallerAddr)
+	acntDst := r.accounts.LoadUserAccount(vmInput.RecipientAddr)
+	vmOutput, trace, err := builtInFunctions.ProcessBuiltinFunctionWithTrace(function, acntSnd, acntDst, vmInput)
+
+	result := &runResult{
+		VMOutput: newVMOutputView(vmOutput),
+		Trace:    newTraceView(trace),
+	}
+	// the view holds copies of the output fields, so the output is no longer needed
+	vmcommon.ReleaseVMOutput(vmOutput)
+	if err != nil {
+		result.Error = err.Error()
+	}
+
+	return result, nil
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/Reshusk23/sr-me-core/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	stateFixturePath = "testdata/state.json"
+	callerAddress    = "0100000000000000000000000000000000000000000000000000000000000001"
+	recipientAddress = "0100000000000000000000000000000000000000000000000000000000000002"
+)
+
+func createTransferCall(value string) *callFixture {
+	return &callFixture{
+		Function:    core.BuiltInFunctionDCTTransfer,
+		Caller:      callerAddress,
+		Recipient:   recipientAddress,
+		GasProvided: 100000,
+		Arguments:   []string{"544b4e2d616263646566", value},
+	}
+}
+
+func TestRunFixtures(t *testing.T) {
+	t.Parallel()
+
+	t.Run("successful call should print the output and the trace", func(t *testing.T) {
+		t.Parallel()
+
+		result, err := runFixtures(stateFixturePath, "testdata/transfer.json")
+		require.Nil(t, err)
+		assert.Empty(t, result.Error)
+		require.NotNil(t, result.VMOutput)
+		assert.Equal(t, "ok", result.VMOutput.ReturnCode)
+		assert.Equal(t, uint64(99999), result.VMOutput.GasRemaining)
+		require.Len(t, result.VMOutput.Logs, 1)
+		assert.Equal(t, core.BuiltInFunctionDCTTransfer, result.VMOutput.Logs[0].Identifier)
+
+		require.NotNil(t, result.Trace)
+		assert.Len(t, result.Trace.Steps, 5)
+		assert.Equal(t, "gasConsumed", result.Trace.Steps[4].Type)
+		assert.Equal(t, uint64(1), result.Trace.Steps[4].Gas)
+	})
+	t.Run("failing call should print the error and the trace", func(t *testing.T) {
+		t.Parallel()
+
+		result, err := runFixtures(stateFixturePath, "testdata/insufficientFunds.json")
+		require.Nil(t, err)
+		assert.Equal(t, "insufficient funds", result.Error)
+		assert.Nil(t, result.VMOutput)
+		require.NotNil(t, result.Trace)
+		assert.Len(t, result.Trace.Steps, 1)
+	})
+	t.Run("missing fixture should error", func(t *testing.T) {
+		t.Parallel()
+
+		result, err := runFixtures("testdata/missing.json", "testdata/transfer.json")
+		assert.NotNil(t, err)
+		assert.Nil(t, result)
+	})
+}
+
+func TestRunner_Run(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unknown flag should error", func(t *testing.T) {
+		t.Parallel()
+
+		r, err := newRunner(&stateFixture{EnabledFlags: []string{"IsUnknownFlagEnabled"}})
+		assert.ErrorIs(t, err, errUnknownFlag)
+		assert.Nil(t, r)
+	})
+	t.Run("invalid account should error", func(t *testing.T) {
+		t.Parallel()
+
+		r, err := newRunner(&stateFixture{Accounts: []accountFixture{{Address: "zz"}}})
+		assert.ErrorIs(t, err, errInvalidHex)
+		assert.Nil(t, r)
+
+		r, err = newRunner(&stateFixture{Accounts: []accountFixture{{Address: callerAddress, Balance: "one"}}})
+		assert.ErrorIs(t, err, errInvalidBigInt)
+		assert.Nil(t, r)
+	})
+	t.Run("invalid call should error", func(t *testing.T) {
+		t.Parallel()
+
+		r, err := newRunner(&stateFixture{})
+		require.Nil(t, err)
+
+		_, err = r.run(&callFixture{})
+		assert.Equal(t, errMissingFunction, err)
+
+		call := createTransferCall("zz")
+		_, err = r.run(call)
+		assert.ErrorIs(t, err, errInvalidHex)
+
+		call = createTransferCall("64")
+		call.Function = "unknownFunction"
+		_, err = r.run(call)
+		assert.NotNil(t, err)
+	})
+	t.Run("enabled flags and storage should be loaded", func(t *testing.T) {
+		t.Parallel()
+
+		state := &stateFixture{
+			EnabledFlags: []string{"IsSaveToSystemAccountFlagEnabled"},
+			Accounts: []accountFixture{
+				{
+					Address: callerAddress,
+					Storage: map[string]string{"6b6579": "76616c7565"},
+				},
+			},
+		}
+		r, err := newRunner(state)
+		require.Nil(t, err)
+
+		account := r.accounts.LoadUserAccount([]byte{1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1})
+		assert.Equal(t, []byte("value"), account.Storage["key"])
+
+		result, err := r.run(createTransferCall("64"))
+		require.Nil(t, err)
+		assert.Equal(t, "insufficient funds", result.Error)
+	})
+}
+
+func TestPrintResult(t *testing.T) {
+	t.Parallel()
+
+	result, err := runFixtures(stateFixturePath, "testdata/transfer.json")
+	require.Nil(t, err)
+
+	buff := bytes.NewBuffer(nil)
+	err = printResult(buff, result)
+	require.Nil(t, err)
+
+	printed := &runResult{}
+	err = json.Unmarshal(buff.Bytes(), printed)
+	require.Nil(t, err)
+	assert.Equal(t, result, printed)
+}
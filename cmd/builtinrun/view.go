@@ -0,0 +1,162 @@
+package main
+
+import (
+	"encoding/hex"
+	"math/big"
+
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+)
+
+// vmOutputView is the printable form of the VMOutput: the addresses, keys and values are hex encoded, while the
+// transfer data and the log identifiers, which are human readable, are kept as text. The output accounts are sorted
+// by address, so the same call always prints the same output
+type vmOutputView struct {
+	ReturnCode     string              `json:"returnCode"`
+	ReturnMessage  string              `json:"returnMessage,omitempty"`
+	ReturnData     []string            `json:"returnData,omitempty"`
+	GasRemaining   uint64              `json:"gasRemaining"`
+	OutputAccounts []outputAccountView `json:"outputAccounts,omitempty"`
+	Logs           []logEntryView      `json:"logs,omitempty"`
+}
+
+type outputAccountView struct {
+	Address         string               `json:"address"`
+	BalanceDelta    string               `json:"balanceDelta,omitempty"`
+	StorageUpdates  []storageUpdateView  `json:"storageUpdates,omitempty"`
+	OutputTransfers []outputTransferView `json:"outputTransfers,omitempty"`
+}
+
+type storageUpdateView struct {
+	Offset string `json:"offset"`
+	Data   string `json:"data"`
+}
+
+type outputTransferView struct {
+	Value         string `json:"value"`
+	GasLimit      uint64 `json:"gasLimit"`
+	GasLocked     uint64 `json:"gasLocked,omitempty"`
+	Data          string `json:"data"`
+	CallType      int    `json:"callType"`
+	SenderAddress string `json:"senderAddress"`
+}
+
+type logEntryView struct {
+	Identifier string   `json:"identifier"`
+	Address    string   `json:"address"`
+	Topics     []string `json:"topics,omitempty"`
+	Data       string   `json:"data,omitempty"`
+}
+
+// traceView is the printable form of the execution trace
+type traceView struct {
+	Function     string          `json:"function"`
+	GasProvided  uint64          `json:"gasProvided"`
+	GasRemaining uint64          `json:"gasRemaining"`
+	Steps        []traceStepView `json:"steps"`
+}
+
+type traceStepView struct {
+	Type    string `json:"type"`
+	Address string `json:"address,omitempty"`
+	Key     string `json:"key,omitempty"`
+	Value   string `json:"value,omitempty"`
+	Gas     uint64 `json:"gas,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+func newVMOutputView(vmOutput *vmcommon.VMOutput) *vmOutputView {
+	if vmOutput == nil {
+		return nil
+	}
+
+	view := &vmOutputView{
+		ReturnCode:    vmOutput.ReturnCode.String(),
+		ReturnMessage: vmOutput.ReturnMessage,
+		ReturnData:    hexSlice(vmOutput.ReturnData),
+		GasRemaining:  vmOutput.GasRemaining,
+	}
+	for _, outAcc := range vmOutput.SortedOutputAccounts() {
+		view.OutputAccounts = append(view.OutputAccounts, newOutputAccountView(outAcc))
+	}
+	for _, logEntry := range vmOutput.Logs {
+		view.Logs = append(view.Logs, logEntryView{
+			Identifier: string(logEntry.Identifier),
+			Address:    hex.EncodeToString(logEntry.Address),
+			Topics:     hexSlice(logEntry.Topics),
+			Data:       hex.EncodeToString(logEntry.Data),
+		})
+	}
+
+	return view
+}
+
+func newOutputAccountView(outAcc *vmcommon.OutputAccount) outputAccountView {
+	view := outputAccountView{
+		Address:      hex.EncodeToString(outAcc.Address),
+		BalanceDelta: bigIntString(outAcc.BalanceDelta),
+	}
+	for _, storageUpdate := range outAcc.SortedStorageUpdates() {
+		view.StorageUpdates = append(view.StorageUpdates, storageUpdateView{
+			Offset: hex.EncodeToString(storageUpdate.Offset),
+			Data:   hex.EncodeToString(storageUpdate.Data),
+		})
+	}
+	for _, outTransfer := range outAcc.OutputTransfers {
+		view.OutputTransfers = append(view.OutputTransfers, outputTransferView{
+			Value:         bigIntString(vmcommon.ZeroValueIfNil(outTransfer.Value)),
+			GasLimit:      outTransfer.GasLimit,
+			GasLocked:     outTransfer.GasLocked,
+			Data:          string(outTransfer.Data),
+			CallType:      int(outTransfer.CallType),
+			SenderAddress: hex.EncodeToString(outTransfer.SenderAddress),
+		})
+	}
+
+	return view
+}
+
+func newTraceView(trace *vmcommon.ExecutionTrace) *traceView {
+	if trace == nil {
+		return nil
+	}
+
+	view := &traceView{
+		Function:     trace.Function,
+		GasProvided:  trace.GasProvided,
+		GasRemaining: trace.GasRemaining,
+		Steps:        make([]traceStepView, 0, len(trace.Steps)),
+	}
+	for _, step := range trace.Steps {
+		view.Steps = append(view.Steps, traceStepView{
+			Type:    string(step.Type),
+			Address: hex.EncodeToString(step.Address),
+			Key:     hex.EncodeToString(step.Key),
+			Value:   hex.EncodeToString(step.Value),
+			Gas:     step.Gas,
+			Error:   step.Error,
+		})
+	}
+
+	return view
+}
+
+func hexSlice(values [][]byte) []string {
+	if len(values) == 0 {
+		return nil
+	}
+
+	encoded := make([]string, 0, len(values))
+	for _, value := range values {
+		encoded = append(encoded, hex.EncodeToString(value))
+	}
+
+	return encoded
+}
+
+func bigIntString(value *big.Int) string {
+	if value == nil {
+		return ""
+	}
+
+	return value.String()
+}
@@ -0,0 +1,163 @@
+package vmcommon
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/Reshusk23/sr-me-core/core"
+)
+
+const lengthOfCollectionConfig = 9
+
+const lengthOfNonceRange = 16
+
+const collectionConfigAddQuantityDisabled = 1
+
+const collectionConfigMetaDCT = 2
+
+const collectionConfigContentHashRequired = 4
+
+const collectionConfigRoyaltiesDenominator = 8
+
+const lengthOfRoyaltiesDenominator = 4
+
+// DefaultRoyaltiesDenominator is the royalties value meaning 100%, used by the collections without a stored one
+const DefaultRoyaltiesDenominator = core.MaxRoyalty
+
+// NonceRange is an inclusive range of NFT nonces
+type NonceRange struct {
+	Start uint64
+	End   uint64
+}
+
+// CollectionConfig holds the limits set by a collection owner for the tokens of the collection. Zero limits mean
+// the collection is not constrained. The reserved nonce ranges are skipped when creating new tokens. A meta dct
+// collection holds fungible amounts of each nonce, using the number of decimals of the collection. A collection requiring
+// the content hash accepts only the tokens created with the hash of their attributes. The royalties denominator is the
+// royalties value meaning 100% for the tokens created in the collection, DefaultRoyaltiesDenominator being used when it
+// is 0. Each token keeps the denominator it was created with.
+type CollectionConfig struct {
+	MaxNumURIs           uint32
+	MaxAttributesLength  uint32
+	AddQuantityDisabled  bool
+	IsMetaDCT            bool
+	ContentHashRequired  bool
+	NumDecimals          uint8
+	RoyaltiesDenominator uint32
+	ReservedNonceRanges  []NonceRange
+}
+
+// CollectionConfigFromBytes creates a collection config object from bytes
+func CollectionConfigFromBytes(bytes []byte) CollectionConfig {
+	if len(bytes) < lengthOfCollectionConfig {
+		return CollectionConfig{}
+	}
+	// the number of decimals of a meta dct collection follows the flags, the royalties denominator following them
+	isMetaDCT := (bytes[8] & collectionConfigMetaDCT) != 0
+	hasRoyaltiesDenominator := (bytes[8] & collectionConfigRoyaltiesDenominator) != 0
+	lengthOfHeader := lengthOfCollectionConfig
+	if isMetaDCT {
+		lengthOfHeader++
+	}
+	royaltiesDenominatorOffset := lengthOfHeader
+	if hasRoyaltiesDenominator {
+		lengthOfHeader += lengthOfRoyaltiesDenominator
+	}
+	if len(bytes) < lengthOfHeader || (len(bytes)-lengthOfHeader)%lengthOfNonceRange != 0 {
+		return CollectionConfig{}
+	}
+
+	config := CollectionConfig{
+		MaxNumURIs:          binary.BigEndian.Uint32(bytes[:4]),
+		MaxAttributesLength: binary.BigEndian.Uint32(bytes[4:8]),
+		AddQuantityDisabled: (bytes[8] & collectionConfigAddQuantityDisabled) != 0,
+		IsMetaDCT:           isMetaDCT,
+		ContentHashRequired: (bytes[8] & collectionConfigContentHashRequired) != 0,
+	}
+	if isMetaDCT {
+		config.NumDecimals = bytes[lengthOfCollectionConfig]
+	}
+	if hasRoyaltiesDenominator {
+		config.RoyaltiesDenominator = binary.BigEndian.Uint32(bytes[royaltiesDenominatorOffset:lengthOfHeader])
+	}
+	for offset := lengthOfHeader; offset < len(bytes); offset += lengthOfNonceRange {
+		config.ReservedNonceRanges = append(config.ReservedNonceRanges, NonceRange{
+			Start: binary.BigEndian.Uint64(bytes[offset : offset+8]),
+			End:   binary.BigEndian.Uint64(bytes[offset+8 : offset+lengthOfNonceRange]),
+		})
+	}
+
+	return config
+}
+
+// ToBytes converts the collection config to bytes
+func (config *CollectionConfig) ToBytes() []byte {
+	bytes := make([]byte, lengthOfCollectionConfig)
+	binary.BigEndian.PutUint32(bytes[:4], config.MaxNumURIs)
+	binary.BigEndian.PutUint32(bytes[4:8], config.MaxAttributesLength)
+	if config.AddQuantityDisabled {
+		bytes[8] |= collectionConfigAddQuantityDisabled
+	}
+	if config.ContentHashRequired {
+		bytes[8] |= collectionConfigContentHashRequired
+	}
+	if config.IsMetaDCT {
+		bytes[8] |= collectionConfigMetaDCT
+		bytes = append(bytes, config.NumDecimals)
+	}
+	if config.RoyaltiesDenominator != 0 {
+		bytes[8] |= collectionConfigRoyaltiesDenominator
+		bytes = binary.BigEndian.AppendUint32(bytes, config.RoyaltiesDenominator)
+	}
+	for _, nonceRange := range config.ReservedNonceRanges {
+		bytes = binary.BigEndian.AppendUint64(bytes, nonceRange.Start)
+		bytes = binary.BigEndian.AppendUint64(bytes, nonceRange.End)
+	}
+
+	return bytes
+}
+
+// IsNumURIsAllowed returns true if the collection accepts tokens holding the provided number of URIs
+func (config *CollectionConfig) IsNumURIsAllowed(numURIs int) bool {
+	return config.MaxNumURIs == 0 || uint64(numURIs) <= uint64(config.MaxNumURIs)
+}
+
+// IsAttributesLengthAllowed returns true if the collection accepts tokens holding attributes of the provided length
+func (config *CollectionConfig) IsAttributesLengthAllowed(attributesLength int) bool {
+	return config.MaxAttributesLength == 0 || uint64(attributesLength) <= uint64(config.MaxAttributesLength)
+}
+
+// GetRoyaltiesDenominator returns the royalties value meaning 100% for the tokens of the collection
+func (config *CollectionConfig) GetRoyaltiesDenominator() uint32 {
+	if config.RoyaltiesDenominator == 0 {
+		return DefaultRoyaltiesDenominator
+	}
+
+	return config.RoyaltiesDenominator
+}
+
+// NextAvailableNonce returns the first nonce following the provided one which is not part of a reserved range. It
+// returns false if no such nonce fits an uint64.
+func (config *CollectionConfig) NextAvailableNonce(nonce uint64) (uint64, bool) {
+	if nonce == math.MaxUint64 {
+		return 0, false
+	}
+
+	candidate := nonce + 1
+	for skipped := true; skipped; {
+		skipped = false
+		for _, nonceRange := range config.ReservedNonceRanges {
+			if candidate < nonceRange.Start || candidate > nonceRange.End {
+				continue
+			}
+			if nonceRange.End == math.MaxUint64 {
+				return 0, false
+			}
+
+			candidate = nonceRange.End + 1
+			skipped = true
+		}
+	}
+
+	return candidate, true
+}
@@ -0,0 +1,136 @@
+package vmcommon
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectionConfig_ToBytesFromBytes(t *testing.T) {
+	t.Parallel()
+
+	config := CollectionConfig{
+		MaxNumURIs:          3,
+		MaxAttributesLength: 1024,
+		AddQuantityDisabled: true,
+	}
+	assert.Equal(t, config, CollectionConfigFromBytes(config.ToBytes()))
+
+	config.ContentHashRequired = true
+	assert.Equal(t, config, CollectionConfigFromBytes(config.ToBytes()))
+	assert.Equal(t, CollectionConfig{}, CollectionConfigFromBytes(nil))
+	assert.Equal(t, CollectionConfig{}, CollectionConfigFromBytes([]byte{1, 2}))
+}
+
+func TestCollectionConfig_MetaDCTToBytesFromBytes(t *testing.T) {
+	t.Parallel()
+
+	config := CollectionConfig{
+		MaxNumURIs:  3,
+		IsMetaDCT:   true,
+		NumDecimals: 18,
+		ReservedNonceRanges: []NonceRange{
+			{Start: 10, End: 20},
+		},
+	}
+	configBytes := config.ToBytes()
+	assert.Equal(t, lengthOfCollectionConfig+1+lengthOfNonceRange, len(configBytes))
+	assert.Equal(t, config, CollectionConfigFromBytes(configBytes))
+	assert.Equal(t, CollectionConfig{}, CollectionConfigFromBytes(configBytes[:lengthOfCollectionConfig]))
+}
+
+func TestCollectionConfig_RoyaltiesDenominator(t *testing.T) {
+	t.Parallel()
+
+	config := CollectionConfig{
+		MaxNumURIs:  3,
+		IsMetaDCT:   true,
+		NumDecimals: 18,
+	}
+	assert.Equal(t, DefaultRoyaltiesDenominator, config.GetRoyaltiesDenominator())
+
+	config.RoyaltiesDenominator = 1000000
+	configBytes := config.ToBytes()
+	assert.Equal(t, lengthOfCollectionConfig+1+lengthOfRoyaltiesDenominator, len(configBytes))
+	assert.Equal(t, config, CollectionConfigFromBytes(configBytes))
+	decodedConfig := CollectionConfigFromBytes(configBytes)
+	assert.Equal(t, uint32(1000000), decodedConfig.GetRoyaltiesDenominator())
+	assert.Equal(t, CollectionConfig{}, CollectionConfigFromBytes(configBytes[:len(configBytes)-1]))
+}
+
+func TestCollectionConfig_Limits(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty config should not limit", func(t *testing.T) {
+		t.Parallel()
+
+		config := CollectionConfig{}
+		assert.True(t, config.IsNumURIsAllowed(1000))
+		assert.True(t, config.IsAttributesLengthAllowed(1000))
+	})
+	t.Run("limits should apply", func(t *testing.T) {
+		t.Parallel()
+
+		config := CollectionConfig{
+			MaxNumURIs:          2,
+			MaxAttributesLength: 10,
+		}
+		assert.True(t, config.IsNumURIsAllowed(2))
+		assert.False(t, config.IsNumURIsAllowed(3))
+		assert.True(t, config.IsAttributesLengthAllowed(10))
+		assert.False(t, config.IsAttributesLengthAllowed(11))
+	})
+}
+
+func TestCollectionConfig_ReservedNonceRanges(t *testing.T) {
+	t.Parallel()
+
+	config := CollectionConfig{
+		MaxNumURIs: 3,
+		ReservedNonceRanges: []NonceRange{
+			{Start: 10, End: 20},
+			{Start: 21, End: 21},
+			{Start: 5, End: 9},
+			{Start: math.MaxUint64 - 1, End: math.MaxUint64},
+		},
+	}
+	assert.Equal(t, config, CollectionConfigFromBytes(config.ToBytes()))
+	assert.Equal(t, CollectionConfig{}, CollectionConfigFromBytes(append(config.ToBytes(), 1)))
+
+	t.Run("nonces outside the ranges should be kept", func(t *testing.T) {
+		t.Parallel()
+
+		nonce, ok := config.NextAvailableNonce(0)
+		assert.True(t, ok)
+		assert.Equal(t, uint64(1), nonce)
+
+		nonce, ok = config.NextAvailableNonce(21)
+		assert.True(t, ok)
+		assert.Equal(t, uint64(22), nonce)
+	})
+	t.Run("adjacent reserved ranges should be skipped", func(t *testing.T) {
+		t.Parallel()
+
+		nonce, ok := config.NextAvailableNonce(4)
+		assert.True(t, ok)
+		assert.Equal(t, uint64(22), nonce)
+	})
+	t.Run("nonces near the max uint64 should overflow", func(t *testing.T) {
+		t.Parallel()
+
+		nonce, ok := config.NextAvailableNonce(math.MaxUint64 - 3)
+		assert.True(t, ok)
+		assert.Equal(t, uint64(math.MaxUint64-2), nonce)
+
+		_, ok = config.NextAvailableNonce(math.MaxUint64 - 2)
+		assert.False(t, ok)
+
+		_, ok = (&CollectionConfig{}).NextAvailableNonce(math.MaxUint64)
+		assert.False(t, ok)
+
+		nonce, ok = (&CollectionConfig{}).NextAvailableNonce(math.MaxUint64 - 1)
+		assert.True(t, ok)
+		assert.Equal(t, uint64(math.MaxUint64), nonce)
+	})
+}
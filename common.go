@@ -1,12 +1,10 @@
 package vmcommon
 
-import "math/big"
+import (
+	"math/big"
 
-const tickerMinLength = 3
-const tickerMaxLength = 10
-const additionalRandomCharsLength = 6
-const identifierMinLength = tickerMinLength + additionalRandomCharsLength + 1
-const identifierMaxLength = tickerMaxLength + additionalRandomCharsLength + 1
+	"github.com/Reshusk23/sr-vm-common-go/tokenident"
+)
 
 // DCTDeleteMetadata represents the defined built in function name for dct delete metadata
 const DCTDeleteMetadata = "DCTDeleteMetadata"
@@ -26,66 +24,136 @@ const BuiltInFunctionDCTTransferRoleAddAddress = "DCTTransferRoleAddAddress"
 // BuiltInFunctionDCTTransferRoleDeleteAddress represents the defined built in function name for transfer role delete address
 const BuiltInFunctionDCTTransferRoleDeleteAddress = "DCTTransferRoleDeleteAddress"
 
-// DCTRoleBurnForAll represents the role for burn for all
-const DCTRoleBurnForAll = "DCTRoleBurnForAll"
+// BuiltInFunctionDCTSetCollectionConfig represents the defined built in function name for dct set collection config
+const BuiltInFunctionDCTSetCollectionConfig = "DCTSetCollectionConfig"
 
-// ValidateToken - validates the token ID
-func ValidateToken(tokenID []byte) bool {
-	tokenIDLen := len(tokenID)
-	if tokenIDLen < identifierMinLength || tokenIDLen > identifierMaxLength {
-		return false
-	}
+// BuiltInFunctionDCTUnSetCollectionConfig represents the defined built in function name for dct unset collection config
+const BuiltInFunctionDCTUnSetCollectionConfig = "DCTUnSetCollectionConfig"
 
-	tickerLen := tokenIDLen - additionalRandomCharsLength
+// BuiltInFunctionDCTSetDormantSweep represents the defined built in function name for dct set dormant sweep
+const BuiltInFunctionDCTSetDormantSweep = "DCTSetDormantSweep"
 
-	if !isTickerValid(tokenID[0 : tickerLen-1]) {
-		return false
-	}
+// BuiltInFunctionDCTUnSetDormantSweep represents the defined built in function name for dct unset dormant sweep
+const BuiltInFunctionDCTUnSetDormantSweep = "DCTUnSetDormantSweep"
 
-	// dash char between the random chars and the ticker
-	if tokenID[tickerLen-1] != '-' {
-		return false
-	}
+// BuiltInFunctionDCTSweepDormant represents the defined built in function name for dct sweep dormant balance
+const BuiltInFunctionDCTSweepDormant = "DCTSweepDormant"
 
-	if !randomCharsAreValid(tokenID[tickerLen:tokenIDLen]) {
-		return false
-	}
+// BuiltInFunctionDCTSetMultiSigManaged represents the defined built in function name for dct set multisig managed
+const BuiltInFunctionDCTSetMultiSigManaged = "DCTSetMultiSigManaged"
 
-	return true
-}
+// BuiltInFunctionDCTUnSetMultiSigManaged represents the defined built in function name for dct unset multisig managed
+const BuiltInFunctionDCTUnSetMultiSigManaged = "DCTUnSetMultiSigManaged"
 
-// ticker must be all uppercase alphanumeric
-func isTickerValid(tickerName []byte) bool {
-	if len(tickerName) < tickerMinLength || len(tickerName) > tickerMaxLength {
-		return false
-	}
-	for _, ch := range tickerName {
-		isBigCharacter := ch >= 'A' && ch <= 'Z'
-		isNumber := ch >= '0' && ch <= '9'
-		isReadable := isBigCharacter || isNumber
-		if !isReadable {
-			return false
-		}
-	}
+// BuiltInFunctionDCTSetSoulbound represents the defined built in function name for dct set soulbound
+const BuiltInFunctionDCTSetSoulbound = "DCTSetSoulbound"
 
-	return true
-}
+// BuiltInFunctionDCTUnSetSoulbound represents the defined built in function name for dct unset soulbound
+const BuiltInFunctionDCTUnSetSoulbound = "DCTUnSetSoulbound"
 
-// random chars are alphanumeric lowercase
-func randomCharsAreValid(chars []byte) bool {
-	if len(chars) != additionalRandomCharsLength {
-		return false
-	}
-	for _, ch := range chars {
-		isSmallCharacter := ch >= 'a' && ch <= 'f'
-		isNumber := ch >= '0' && ch <= '9'
-		isReadable := isSmallCharacter || isNumber
-		if !isReadable {
-			return false
-		}
-	}
+// BuiltInFunctionDCTRentNFT represents the defined built in function name for dct rent NFT
+const BuiltInFunctionDCTRentNFT = "DCTRentNFT"
+
+// BuiltInFunctionDCTReclaimRentedNFT represents the defined built in function name for dct reclaim rented NFT
+const BuiltInFunctionDCTReclaimRentedNFT = "DCTReclaimRentedNFT"
+
+// BuiltInFunctionDCTNFTCreateOnBehalf represents the defined built in function name for dct NFT create on behalf of a creator
+const BuiltInFunctionDCTNFTCreateOnBehalf = "DCTNFTCreateOnBehalf"
+
+// DCTNFTCreateNotifyMarker separates the URIs of a dct NFT create from the arguments of the call notifying a registry
+// contract of the created nonce
+const DCTNFTCreateNotifyMarker = "DCTNFTCreateNotify"
+
+// DCTTransferNativeValueIdentifier represents the log identifier for the native value moved together with a dct transfer
+const DCTTransferNativeValueIdentifier = "DCTTransferNativeValue"
+
+// DCTRolesChangedIdentifier represents the log identifier of the canonical event emitted whenever roles of an account
+// for a token are set or unset. The address of the entry is the account, the topics hold the token identifier, the
+// operation and the changed roles and the data holds the name of the function which changed the roles
+const DCTRolesChangedIdentifier = "DCTRolesChanged"
+
+// DCTRolesChangedSet represents the operation topic of the roles changed event for the set roles
+const DCTRolesChangedSet = "set"
+
+// DCTRolesChangedUnset represents the operation topic of the roles changed event for the unset roles
+const DCTRolesChangedUnset = "unset"
+
+// DCTSelfTransferLogData represents the data of the transfer log entry of a dct transfer whose sender is also the
+// receiver. Such a transfer is validated as any other transfer but leaves the balance untouched
+const DCTSelfTransferLogData = "IsSelfTransfer"
+
+// BuiltInFunctionDCTStopNFTCreate represents the defined built in function name for dct stop NFT create
+const BuiltInFunctionDCTStopNFTCreate = "DCTStopNFTCreate"
 
-	return true
+// BuiltInFunctionDCTResumeNFTCreate represents the defined built in function name for dct resume NFT create
+const BuiltInFunctionDCTResumeNFTCreate = "DCTResumeNFTCreate"
+
+// BuiltInFunctionDCTModifyCreator represents the defined built in function name for dct modify creator
+const BuiltInFunctionDCTModifyCreator = "DCTModifyCreator"
+
+// BuiltInFunctionDCTSetNewURIs represents the defined built in function name for dct set new URIs
+const BuiltInFunctionDCTSetNewURIs = "DCTSetNewURIs"
+
+// BuiltInFunctionDCTNFTUpdate represents the defined built in function name for dct NFT update, patching the metadata
+// fields selected by a bitmask
+const BuiltInFunctionDCTNFTUpdate = "DCTNFTUpdate"
+
+// BuiltInFunctionDCTTransferAndLock represents the defined built in function name for dct transfer and lock, crediting
+// the receiver with tokens which can not be spent before an unlock epoch
+const BuiltInFunctionDCTTransferAndLock = "DCTTransferAndLock"
+
+// The bits of the DCTNFTUpdate mask selecting the updated metadata fields. The new values follow the mask in the
+// order of the bits, the URIs, when selected, taking all the remaining arguments
+const (
+	DCTNFTUpdateName uint64 = 1 << iota
+	DCTNFTUpdateRoyalties
+	DCTNFTUpdateHash
+	DCTNFTUpdateAttributes
+	DCTNFTUpdateURIs
+)
+
+// BuiltInFunctionDCTSetMetaDCT represents the defined built in function name for dct set meta dct collection
+const BuiltInFunctionDCTSetMetaDCT = "DCTSetMetaDCT"
+
+// BuiltInFunctionWrapNative represents the defined built in function name for wrapping native balance
+const BuiltInFunctionWrapNative = "WrapNative"
+
+// BuiltInFunctionUnwrapNative represents the defined built in function name for unwrapping native balance
+const BuiltInFunctionUnwrapNative = "UnwrapNative"
+
+// BuiltInFunctionDCTBridgeMint represents the defined built in function name for dct bridge mint
+const BuiltInFunctionDCTBridgeMint = "DCTBridgeMint"
+
+// BuiltInFunctionDCTBridgeBurn represents the defined built in function name for dct bridge burn
+const BuiltInFunctionDCTBridgeBurn = "DCTBridgeBurn"
+
+// BuiltInFunctionDCTApprove represents the defined built in function name for dct approve
+const BuiltInFunctionDCTApprove = "DCTApprove"
+
+// BuiltInFunctionDCTTransferFrom represents the defined built in function name for dct transfer from
+const BuiltInFunctionDCTTransferFrom = "DCTTransferFrom"
+
+// MetaFungible is the dct token type of the meta dct tokens, holding fungible amounts of each nonce
+const MetaFungible = 2
+
+// DCTRoleBurnForAll represents the role for burn for all
+const DCTRoleBurnForAll = "DCTRoleBurnForAll"
+
+// DCTRoleNFTCreateOnBehalf represents the role for creating NFTs recording another address as creator
+const DCTRoleNFTCreateOnBehalf = "DCTRoleNFTCreateOnBehalf"
+
+// DCTRoleModifyCreator represents the role for changing the creator recorded in the metadata of an NFT
+const DCTRoleModifyCreator = "DCTRoleModifyCreator"
+
+// DCTRoleSetNewURI represents the role for replacing all the URIs of an NFT
+const DCTRoleSetNewURI = "DCTRoleSetNewURI"
+
+// DCTRoleNFTUpdate represents the role for updating any metadata field of an NFT through the DCTNFTUpdate function
+const DCTRoleNFTUpdate = "DCTRoleNFTUpdate"
+
+// ValidateToken - validates the token ID
+func ValidateToken(tokenID []byte) bool {
+	return tokenident.ValidateTokenIdentifier(tokenID)
 }
 
 // ZeroValueIfNil returns 0 if the input is nil, otherwise returns the input
@@ -0,0 +1,42 @@
+package vmcommon
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+)
+
+const (
+	maxBytesInUint64 = 8
+	maxBytesInUint32 = 4
+)
+
+// SafeUint64FromBytes interprets the provided big endian bytes as an uint64, returning ErrValueOutOfRange instead of
+// truncating the values which do not fit. Leading zero bytes are allowed
+func SafeUint64FromBytes(buff []byte) (uint64, error) {
+	significant := bytes.TrimLeft(buff, "\x00")
+	if len(significant) > maxBytesInUint64 {
+		return 0, fmt.Errorf("%w, %d significant bytes, maximum is %d", ErrValueOutOfRange, len(significant), maxBytesInUint64)
+	}
+
+	value := uint64(0)
+	for _, b := range significant {
+		value = value<<8 | uint64(b)
+	}
+
+	return value, nil
+}
+
+// SafeUint32FromBytes interprets the provided big endian bytes as an uint32, returning ErrValueOutOfRange instead of
+// truncating the values which do not fit. Leading zero bytes are allowed
+func SafeUint32FromBytes(buff []byte) (uint32, error) {
+	value, err := SafeUint64FromBytes(buff)
+	if err != nil {
+		return 0, err
+	}
+	if value > math.MaxUint32 {
+		return 0, fmt.Errorf("%w, %d does not fit %d bytes", ErrValueOutOfRange, value, maxBytesInUint32)
+	}
+
+	return uint32(value), nil
+}
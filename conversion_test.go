@@ -0,0 +1,52 @@
+package vmcommon
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSafeUint64FromBytes(t *testing.T) {
+	t.Parallel()
+
+	value, err := SafeUint64FromBytes(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0), value)
+
+	value, err = SafeUint64FromBytes([]byte{0, 0, 1, 2})
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(258), value)
+
+	value, err = SafeUint64FromBytes(big.NewInt(0).SetUint64(math.MaxUint64).Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(math.MaxUint64), value)
+
+	value, err = SafeUint64FromBytes(append(make([]byte, 10), 255, 255, 255, 255, 255, 255, 255, 255))
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(math.MaxUint64), value)
+
+	value, err = SafeUint64FromBytes([]byte{1, 0, 0, 0, 0, 0, 0, 0, 0})
+	assert.ErrorIs(t, err, ErrValueOutOfRange)
+	assert.Equal(t, uint64(0), value)
+}
+
+func TestSafeUint32FromBytes(t *testing.T) {
+	t.Parallel()
+
+	value, err := SafeUint32FromBytes([]byte{0x27, 0x10})
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(10000), value)
+
+	value, err = SafeUint32FromBytes([]byte{255, 255, 255, 255})
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(math.MaxUint32), value)
+
+	value, err = SafeUint32FromBytes([]byte{1, 0, 0, 0, 0})
+	assert.ErrorIs(t, err, ErrValueOutOfRange)
+	assert.Equal(t, uint32(0), value)
+
+	_, err = SafeUint32FromBytes([]byte{1, 0, 0, 0, 0, 0, 0, 0, 0})
+	assert.ErrorIs(t, err, ErrValueOutOfRange)
+}
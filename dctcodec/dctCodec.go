@@ -0,0 +1,174 @@
+package dctcodec
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/Reshusk23/sr-me-core/core/check"
+	"github.com/Reshusk23/sr-me-core/data/dct"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+)
+
+// Version defines the encoding version of a DCToken
+type Version uint8
+
+const (
+	// VersionLegacy is the original encoding, the Reserved field of the token not carrying any extension
+	VersionLegacy Version = 1
+
+	// VersionV2 is the encoding carrying the extension fields inside the Reserved field of the token
+	VersionV2 Version = 2
+)
+
+const extensionMarker = byte(0xDC)
+
+const lengthOfCreatorRoyaltySplit = 4
+
+// lengthOfExtensionHeader accounts for the marker, the version and the creator royalty split
+const lengthOfExtensionHeader = 2 + lengthOfCreatorRoyaltySplit
+
+// Token is a decoded DCToken together with the fields added by the newer encodings
+type Token struct {
+	DCToken *dct.DCToken
+	Version Version
+
+	// CreatorRoyaltySplit is the part of the royalties, in basis points, going to the creator of the token
+	CreatorRoyaltySplit uint32
+
+	// Reserved holds the bytes reserved for future extensions, the DCToken Reserved field being used by the encoding
+	Reserved []byte
+}
+
+// ArgsDCTCodec holds the components needed to create a DCToken codec
+type ArgsDCTCodec struct {
+	Marshalizer         vmcommon.Marshalizer
+	EnableEpochsHandler vmcommon.EnableEpochsHandler
+}
+
+// dctCodec reads all the DCToken encodings and writes the one selected by the enable epochs handler
+type dctCodec struct {
+	marshaller          vmcommon.Marshalizer
+	enableEpochsHandler vmcommon.EnableEpochsHandler
+}
+
+// NewDCTCodec creates a new DCToken codec. The codec is also a marshalizer, so it can be provided to the built-in
+// functions instead of the plain one, the DCTokens they write being upgraded to the active encoding while keeping
+// the extension fields already stored
+func NewDCTCodec(args ArgsDCTCodec) (*dctCodec, error) {
+	if check.IfNil(args.Marshalizer) {
+		return nil, ErrNilMarshalizer
+	}
+	if check.IfNil(args.EnableEpochsHandler) {
+		return nil, ErrNilEnableEpochsHandler
+	}
+
+	return &dctCodec{
+		marshaller:          args.Marshalizer,
+		enableEpochsHandler: args.EnableEpochsHandler,
+	}, nil
+}
+
+// ActiveVersion returns the version used when writing tokens
+func (codec *dctCodec) ActiveVersion() Version {
+	if codec.enableEpochsHandler.IsDCTokenV2EncodingFlagEnabled() {
+		return VersionV2
+	}
+
+	return VersionLegacy
+}
+
+// Decode reads a token written with any of the known encodings
+func (codec *dctCodec) Decode(buff []byte) (*Token, error) {
+	dctData := &dct.DCToken{}
+	err := codec.marshaller.Unmarshal(dctData, buff)
+	if err != nil {
+		return nil, err
+	}
+
+	if !hasExtension(dctData.Reserved) {
+		return &Token{
+			DCToken:  dctData,
+			Version:  VersionLegacy,
+			Reserved: dctData.Reserved,
+		}, nil
+	}
+
+	token, err := decodeExtension(dctData.Reserved)
+	if err != nil {
+		return nil, err
+	}
+	dctData.Reserved = nil
+	token.DCToken = dctData
+
+	return token, nil
+}
+
+// Encode writes the token with the active encoding. The legacy encoding drops the creator royalty split, having no
+// place for it, and keeps the reserved bytes in the Reserved field of the token
+func (codec *dctCodec) Encode(token *Token) ([]byte, error) {
+	if token == nil || token.DCToken == nil {
+		return nil, ErrNilToken
+	}
+
+	dctData := *token.DCToken
+	dctData.Reserved = token.Reserved
+	if codec.ActiveVersion() == VersionV2 {
+		dctData.Reserved = encodeExtension(token.CreatorRoyaltySplit, token.Reserved)
+	}
+
+	return codec.marshaller.Marshal(&dctData)
+}
+
+// Marshal encodes the provided object. DCTokens are written with the active encoding, their Reserved field being
+// expected to hold the extension as read by Unmarshal, while any other object is marshalled as it is
+func (codec *dctCodec) Marshal(obj interface{}) ([]byte, error) {
+	dctData, ok := obj.(*dct.DCToken)
+	if !ok || dctData == nil || codec.ActiveVersion() != VersionV2 || hasExtension(dctData.Reserved) {
+		return codec.marshaller.Marshal(obj)
+	}
+
+	upgraded := *dctData
+	upgraded.Reserved = encodeExtension(0, dctData.Reserved)
+
+	return codec.marshaller.Marshal(&upgraded)
+}
+
+// Unmarshal decodes the provided buffer in the object. The Reserved field of the DCTokens is left untouched, so
+// a later Marshal keeps the extension fields
+func (codec *dctCodec) Unmarshal(obj interface{}, buff []byte) error {
+	return codec.marshaller.Unmarshal(obj, buff)
+}
+
+func hasExtension(reserved []byte) bool {
+	return len(reserved) > 1 && reserved[0] == extensionMarker && Version(reserved[1]) == VersionV2
+}
+
+func encodeExtension(creatorRoyaltySplit uint32, reserved []byte) []byte {
+	extension := make([]byte, lengthOfExtensionHeader, lengthOfExtensionHeader+len(reserved))
+	extension[0] = extensionMarker
+	extension[1] = byte(VersionV2)
+	binary.BigEndian.PutUint32(extension[2:lengthOfExtensionHeader], creatorRoyaltySplit)
+
+	return append(extension, reserved...)
+}
+
+func decodeExtension(extension []byte) (*Token, error) {
+	if len(extension) < lengthOfExtensionHeader {
+		return nil, ErrInvalidExtension
+	}
+
+	token := &Token{
+		Version:             VersionV2,
+		CreatorRoyaltySplit: binary.BigEndian.Uint32(extension[2:lengthOfExtensionHeader]),
+	}
+	if len(extension) > lengthOfExtensionHeader {
+		token.Reserved = bytes.Clone(extension[lengthOfExtensionHeader:])
+	}
+
+	return token, nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (codec *dctCodec) IsInterfaceNil() bool {
+	return codec == nil
+}
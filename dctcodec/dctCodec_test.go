@@ -0,0 +1,195 @@
+package dctcodec
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Reshusk23/sr-me-core/core/check"
+	"github.com/Reshusk23/sr-me-core/data/dct"
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createCodec(v2Enabled bool) *dctCodec {
+	codec, _ := NewDCTCodec(ArgsDCTCodec{
+		Marshalizer:         &mock.MarshalizerMock{},
+		EnableEpochsHandler: &mock.EnableEpochsHandlerStub{IsDCTokenV2EncodingFlagEnabledField: v2Enabled},
+	})
+	return codec
+}
+
+func createToken() *dct.DCToken {
+	return &dct.DCToken{
+		Type:          1,
+		Value:         big.NewInt(10),
+		TokenMetaData: &dct.MetaData{Nonce: 2, Name: []byte("name")},
+	}
+}
+
+func TestNewDCTCodec(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil marshalizer should err", func(t *testing.T) {
+		t.Parallel()
+
+		codec, err := NewDCTCodec(ArgsDCTCodec{EnableEpochsHandler: &mock.EnableEpochsHandlerStub{}})
+		assert.True(t, check.IfNil(codec))
+		assert.Equal(t, ErrNilMarshalizer, err)
+	})
+	t.Run("nil enable epochs handler should err", func(t *testing.T) {
+		t.Parallel()
+
+		codec, err := NewDCTCodec(ArgsDCTCodec{Marshalizer: &mock.MarshalizerMock{}})
+		assert.True(t, check.IfNil(codec))
+		assert.Equal(t, ErrNilEnableEpochsHandler, err)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		codec := createCodec(false)
+		assert.False(t, check.IfNil(codec))
+		assert.Equal(t, VersionLegacy, codec.ActiveVersion())
+		assert.Equal(t, VersionV2, createCodec(true).ActiveVersion())
+	})
+}
+
+func TestDCTCodec_EncodeDecode(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil token should err", func(t *testing.T) {
+		t.Parallel()
+
+		codec := createCodec(true)
+		_, err := codec.Encode(nil)
+		assert.Equal(t, ErrNilToken, err)
+		_, err = codec.Encode(&Token{})
+		assert.Equal(t, ErrNilToken, err)
+	})
+	t.Run("legacy encoding should be read", func(t *testing.T) {
+		t.Parallel()
+
+		legacy := createToken()
+		legacy.Reserved = []byte("reserved")
+		buff, _ := (&mock.MarshalizerMock{}).Marshal(legacy)
+
+		token, err := createCodec(true).Decode(buff)
+		require.Nil(t, err)
+		assert.Equal(t, VersionLegacy, token.Version)
+		assert.Equal(t, []byte("reserved"), token.Reserved)
+		assert.Equal(t, legacy, token.DCToken)
+	})
+	t.Run("v2 encoding should round trip", func(t *testing.T) {
+		t.Parallel()
+
+		codec := createCodec(true)
+		buff, err := codec.Encode(&Token{DCToken: createToken(), CreatorRoyaltySplit: 2500, Reserved: []byte("extra")})
+		require.Nil(t, err)
+
+		token, err := codec.Decode(buff)
+		require.Nil(t, err)
+		assert.Equal(t, &Token{
+			DCToken:             createToken(),
+			Version:             VersionV2,
+			CreatorRoyaltySplit: 2500,
+			Reserved:            []byte("extra"),
+		}, token)
+
+		token, err = createCodec(false).Decode(buff)
+		require.Nil(t, err)
+		assert.Equal(t, uint32(2500), token.CreatorRoyaltySplit)
+	})
+	t.Run("legacy encoding should drop the creator royalty split", func(t *testing.T) {
+		t.Parallel()
+
+		codec := createCodec(false)
+		buff, err := codec.Encode(&Token{DCToken: createToken(), CreatorRoyaltySplit: 2500, Reserved: []byte("extra")})
+		require.Nil(t, err)
+
+		token, err := codec.Decode(buff)
+		require.Nil(t, err)
+		assert.Equal(t, VersionLegacy, token.Version)
+		assert.Zero(t, token.CreatorRoyaltySplit)
+		assert.Equal(t, []byte("extra"), token.Reserved)
+	})
+	t.Run("truncated extension should err", func(t *testing.T) {
+		t.Parallel()
+
+		dctData := createToken()
+		dctData.Reserved = []byte{extensionMarker, byte(VersionV2), 0}
+		buff, _ := (&mock.MarshalizerMock{}).Marshal(dctData)
+
+		_, err := createCodec(true).Decode(buff)
+		assert.Equal(t, ErrInvalidExtension, err)
+	})
+	t.Run("unmarshal error should err", func(t *testing.T) {
+		t.Parallel()
+
+		codec, _ := NewDCTCodec(ArgsDCTCodec{
+			Marshalizer:         &mock.MarshalizerMock{Fail: true},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+		})
+		_, err := codec.Decode([]byte("data"))
+		assert.NotNil(t, err)
+	})
+}
+
+func TestDCTCodec_Marshalizer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("legacy version should marshal as it is", func(t *testing.T) {
+		t.Parallel()
+
+		codec := createCodec(false)
+		buff, err := codec.Marshal(createToken())
+		require.Nil(t, err)
+
+		expected, _ := (&mock.MarshalizerMock{}).Marshal(createToken())
+		assert.Equal(t, expected, buff)
+	})
+	t.Run("v2 version should upgrade the token", func(t *testing.T) {
+		t.Parallel()
+
+		codec := createCodec(true)
+		buff, err := codec.Marshal(createToken())
+		require.Nil(t, err)
+
+		token, err := codec.Decode(buff)
+		require.Nil(t, err)
+		assert.Equal(t, VersionV2, token.Version)
+		assert.Equal(t, createToken(), token.DCToken)
+	})
+	t.Run("v2 version should keep the stored extension", func(t *testing.T) {
+		t.Parallel()
+
+		codec := createCodec(true)
+		buff, _ := codec.Encode(&Token{DCToken: createToken(), CreatorRoyaltySplit: 100})
+
+		dctData := &dct.DCToken{}
+		err := codec.Unmarshal(dctData, buff)
+		require.Nil(t, err)
+		dctData.Value = big.NewInt(5)
+
+		buff, err = codec.Marshal(dctData)
+		require.Nil(t, err)
+		token, _ := codec.Decode(buff)
+		assert.Equal(t, uint32(100), token.CreatorRoyaltySplit)
+		assert.Equal(t, big.NewInt(5), token.DCToken.Value)
+	})
+	t.Run("other objects should be marshalled as they are", func(t *testing.T) {
+		t.Parallel()
+
+		codec := createCodec(true)
+		obj := &dct.MetaData{Nonce: 1}
+		buff, err := codec.Marshal(obj)
+		require.Nil(t, err)
+
+		expected, _ := (&mock.MarshalizerMock{}).Marshal(obj)
+		assert.Equal(t, expected, buff)
+
+		recovered := &dct.MetaData{}
+		err = codec.Unmarshal(recovered, buff)
+		require.Nil(t, err)
+		assert.Equal(t, obj, recovered)
+	})
+}
@@ -0,0 +1,15 @@
+package dctcodec
+
+import "errors"
+
+// ErrNilMarshalizer signals that a nil marshalizer has been provided
+var ErrNilMarshalizer = errors.New("nil marshalizer")
+
+// ErrNilEnableEpochsHandler signals that a nil enable epochs handler has been provided
+var ErrNilEnableEpochsHandler = errors.New("nil enable epochs handler")
+
+// ErrNilToken signals that a nil token has been provided
+var ErrNilToken = errors.New("nil token")
+
+// ErrInvalidExtension signals that the extension of a versioned token encoding is malformed
+var ErrInvalidExtension = errors.New("invalid token encoding extension")
@@ -0,0 +1,63 @@
+package dctkeys
+
+import (
+	"math/bits"
+
+	"github.com/Reshusk23/sr-vm-common-go/protectedkeys"
+)
+
+const (
+	// DCTKeyPrefix is the prefix of the keys holding the DCT balances and data of an account
+	DCTKeyPrefix = protectedkeys.DCTPrefix
+
+	// NFTLatestNonceKeyPrefix is the prefix of the key holding the latest created NFT nonce of a token
+	NFTLatestNonceKeyPrefix = protectedkeys.DCTNFTLatestNoncePrefix
+)
+
+// ComputeDCTTokenKey returns the key under which the balance of a fungible token, or the base of the keys of the
+// NFT nonces, is stored: the DCT key prefix followed by the token identifier
+func ComputeDCTTokenKey(tokenID []byte) []byte {
+	key := make([]byte, 0, len(DCTKeyPrefix)+len(tokenID))
+	key = append(key, DCTKeyPrefix...)
+
+	return append(key, tokenID...)
+}
+
+// ComputeDCTNFTTokenKey returns the key under which an NFT nonce is stored: the provided DCT token key followed by
+// the minimal big endian representation of the nonce. A zero nonce adds nothing, so the fungible token key is
+// returned. The provided key is never modified
+func ComputeDCTNFTTokenKey(dctTokenKey []byte, nonce uint64) []byte {
+	nonceBytes := NonceToBytes(nonce)
+	key := make([]byte, 0, len(dctTokenKey)+len(nonceBytes))
+	key = append(key, dctTokenKey...)
+
+	return append(key, nonceBytes...)
+}
+
+// ComputeDCTBalanceKey returns the key of the account data trie under which the balance of the token nonce is stored,
+// the fungible tokens using a zero nonce
+func ComputeDCTBalanceKey(tokenID []byte, nonce uint64) []byte {
+	return ComputeDCTNFTTokenKey(ComputeDCTTokenKey(tokenID), nonce)
+}
+
+// ComputeNonceKey returns the key under which the latest created NFT nonce of a token is stored: the latest nonce
+// key prefix followed by the token identifier
+func ComputeNonceKey(tokenID []byte) []byte {
+	key := make([]byte, 0, len(NFTLatestNonceKeyPrefix)+len(tokenID))
+	key = append(key, NFTLatestNonceKeyPrefix...)
+
+	return append(key, tokenID...)
+}
+
+// NonceToBytes returns the minimal big endian representation of the nonce, as used in the storage keys. A zero
+// nonce is represented by an empty slice
+func NonceToBytes(nonce uint64) []byte {
+	numBytes := (bits.Len64(nonce) + 7) / 8
+	buff := make([]byte, numBytes)
+	for i := numBytes - 1; i >= 0; i-- {
+		buff[i] = byte(nonce)
+		nonce >>= 8
+	}
+
+	return buff
+}
@@ -0,0 +1,79 @@
+package dctkeys
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrefixes(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "ELRONDdct", DCTKeyPrefix)
+	assert.Equal(t, "ELRONDnonce", NFTLatestNonceKeyPrefix)
+}
+
+func TestComputeDCTTokenKey(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []byte("ELRONDdctTKN-abcdef"), ComputeDCTTokenKey([]byte("TKN-abcdef")))
+	assert.Equal(t, []byte(DCTKeyPrefix), ComputeDCTTokenKey(nil))
+}
+
+func TestComputeDCTNFTTokenKey(t *testing.T) {
+	t.Parallel()
+
+	tokenKey := []byte("ELRONDdctNFT-abcdef")
+	testCases := []struct {
+		nonce    uint64
+		expected []byte
+	}{
+		{nonce: 0, expected: []byte("ELRONDdctNFT-abcdef")},
+		{nonce: 1, expected: append([]byte("ELRONDdctNFT-abcdef"), 0x01)},
+		{nonce: 255, expected: append([]byte("ELRONDdctNFT-abcdef"), 0xff)},
+		{nonce: 256, expected: append([]byte("ELRONDdctNFT-abcdef"), 0x01, 0x00)},
+		{nonce: 0x0102030405, expected: append([]byte("ELRONDdctNFT-abcdef"), 0x01, 0x02, 0x03, 0x04, 0x05)},
+		{nonce: math.MaxUint64, expected: append([]byte("ELRONDdctNFT-abcdef"), 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff)},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, ComputeDCTNFTTokenKey(tokenKey, tc.nonce), "nonce %d", tc.nonce)
+	}
+}
+
+func TestComputeDCTNFTTokenKey_DoesNotModifyTheTokenKey(t *testing.T) {
+	t.Parallel()
+
+	tokenKey := make([]byte, 0, 64)
+	tokenKey = append(tokenKey, "ELRONDdctNFT-abcdef"...)
+
+	first := ComputeDCTNFTTokenKey(tokenKey, 1)
+	second := ComputeDCTNFTTokenKey(tokenKey, 2)
+	assert.Equal(t, append([]byte("ELRONDdctNFT-abcdef"), 0x01), first)
+	assert.Equal(t, append([]byte("ELRONDdctNFT-abcdef"), 0x02), second)
+	assert.Equal(t, []byte("ELRONDdctNFT-abcdef"), tokenKey)
+}
+
+func TestComputeDCTBalanceKey(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []byte("ELRONDdctTKN-abcdef"), ComputeDCTBalanceKey([]byte("TKN-abcdef"), 0))
+	assert.Equal(t, append([]byte("ELRONDdctNFT-abcdef"), 0x01, 0x00), ComputeDCTBalanceKey([]byte("NFT-abcdef"), 256))
+}
+
+func TestComputeNonceKey(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []byte("ELRONDnonceNFT-abcdef"), ComputeNonceKey([]byte("NFT-abcdef")))
+	assert.Equal(t, []byte(NFTLatestNonceKeyPrefix), ComputeNonceKey(nil))
+}
+
+func TestNonceToBytes(t *testing.T) {
+	t.Parallel()
+
+	for _, nonce := range []uint64{0, 1, 127, 128, 255, 256, 65535, 65536, 1 << 32, math.MaxUint64 - 1, math.MaxUint64} {
+		assert.Equal(t, big.NewInt(0).SetUint64(nonce).Bytes(), NonceToBytes(nonce), "nonce %d", nonce)
+	}
+	assert.Empty(t, NonceToBytes(0))
+}
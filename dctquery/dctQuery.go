@@ -0,0 +1,134 @@
+package dctquery
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/Reshusk23/sr-me-core/core/check"
+	"github.com/Reshusk23/sr-me-core/data/dct"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/builtInFunctions"
+	"github.com/Reshusk23/sr-vm-common-go/dctkeys"
+	"github.com/Reshusk23/sr-vm-common-go/tokenident"
+)
+
+const dctKeyPrefix = dctkeys.DCTKeyPrefix
+
+// ArgsDCTQuery holds the components needed to create a dct query component
+type ArgsDCTQuery struct {
+	Accounts          vmcommon.AccountsAdapter
+	DCTStorageHandler vmcommon.DCTNFTStorageHandler
+	Marshalizer       vmcommon.Marshalizer
+}
+
+// dctQuery reads the DCT balances and metadata from the accounts state without altering it
+type dctQuery struct {
+	accounts          vmcommon.AccountsAdapter
+	dctStorageHandler vmcommon.DCTNFTStorageHandler
+	marshaller        vmcommon.Marshalizer
+}
+
+// NewDCTQuery creates a new read-only dct query component
+func NewDCTQuery(args ArgsDCTQuery) (*dctQuery, error) {
+	if check.IfNil(args.Accounts) {
+		return nil, ErrNilAccountsAdapter
+	}
+	if check.IfNil(args.DCTStorageHandler) {
+		return nil, ErrNilDCTNFTStorageHandler
+	}
+	if check.IfNil(args.Marshalizer) {
+		return nil, ErrNilMarshalizer
+	}
+
+	return &dctQuery{
+		accounts:          args.Accounts,
+		dctStorageHandler: args.DCTStorageHandler,
+		marshaller:        args.Marshalizer,
+	}, nil
+}
+
+// GetTokenBalance returns the balance the address holds for the token, 0 being returned for missing tokens
+func (dq *dctQuery) GetTokenBalance(address []byte, tokenID []byte, nonce uint64) (*big.Int, error) {
+	account, err := dq.getUserAccount(address)
+	if err != nil {
+		return nil, err
+	}
+
+	dctData, _, err := dq.dctStorageHandler.GetDCTNFTTokenOnDestination(account, dctkeys.ComputeDCTTokenKey(tokenID), nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return vmcommon.ZeroValueIfNil(dctData.Value), nil
+}
+
+// GetAllTokens returns all the tokens held by the address, mapped by their identifier. NFT identifiers are made of
+// the collection identifier followed by the hex encoded nonce, their metadata being read from the system account
+func (dq *dctQuery) GetAllTokens(address []byte) (map[string]*dct.DCToken, error) {
+	account, err := dq.getUserAccount(address)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := make(map[string]*dct.DCToken)
+	err = builtInFunctions.IterateDCTTokens(context.Background(), account, dq.marshaller, func(tokenKey builtInFunctions.AccountDCTTokenKey, token *dct.DCToken) error {
+		if tokenKey.Nonce == 0 {
+			tokens[string(tokenKey.TokenIdentifier)] = token
+			return nil
+		}
+
+		nftData, _, errGet := dq.dctStorageHandler.GetDCTNFTTokenOnDestination(account, dctkeys.ComputeDCTTokenKey(tokenKey.TokenIdentifier), tokenKey.Nonce)
+		if errGet != nil {
+			return errGet
+		}
+		tokens[tokenident.BuildNFTIdentifier(string(tokenKey.TokenIdentifier), tokenKey.Nonce)] = nftData
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+// GetTokenMetaData returns the metadata of the token with the provided nonce, as saved on the system account
+func (dq *dctQuery) GetTokenMetaData(tokenID []byte, nonce uint64) (*dct.MetaData, error) {
+	if nonce == 0 {
+		return nil, ErrZeroNonce
+	}
+
+	systemAccount, err := dq.getUserAccount(vmcommon.SystemAccountAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	dctData, _, err := dq.dctStorageHandler.GetDCTNFTTokenOnDestination(systemAccount, dctkeys.ComputeDCTTokenKey(tokenID), nonce)
+	if err != nil {
+		return nil, err
+	}
+	if dctData.TokenMetaData == nil {
+		return nil, ErrTokenMetaDataNotFound
+	}
+
+	return dctData.TokenMetaData, nil
+}
+
+func (dq *dctQuery) getUserAccount(address []byte) (vmcommon.UserAccountHandler, error) {
+	account, err := dq.accounts.GetExistingAccount(address)
+	if err != nil {
+		return nil, err
+	}
+
+	userAccount, ok := account.(vmcommon.UserAccountHandler)
+	if !ok {
+		return nil, ErrWrongTypeAssertion
+	}
+
+	return userAccount, nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (dq *dctQuery) IsInterfaceNil() bool {
+	return dq == nil
+}
@@ -0,0 +1,165 @@
+package dctquery
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/Reshusk23/sr-me-core/core"
+	"github.com/Reshusk23/sr-me-core/core/check"
+	"github.com/Reshusk23/sr-me-core/data/dct"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/builtInFunctions"
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var holderAddress = []byte("holder address")
+
+func computeNFTKey(tokenID string, nonce uint64) []byte {
+	key := []byte(dctKeyPrefix + tokenID)
+	if nonce == 0 {
+		return key
+	}
+
+	return append(key, big.NewInt(0).SetUint64(nonce).Bytes()...)
+}
+
+func createMockArgs() ArgsDCTQuery {
+	marshaller := &mock.MarshalizerMock{}
+	holder := mock.NewUserAccount(holderAddress)
+	systemAccount := mock.NewUserAccount(vmcommon.SystemAccountAddress)
+	mapAccounts := map[string]vmcommon.AccountHandler{
+		string(holderAddress):                 holder,
+		string(vmcommon.SystemAccountAddress): systemAccount,
+	}
+	accounts := &mock.AccountsStub{
+		GetExistingAccountCalled: func(address []byte) (vmcommon.AccountHandler, error) {
+			account, ok := mapAccounts[string(address)]
+			if !ok {
+				return nil, errors.New("account not found")
+			}
+			return account, nil
+		},
+	}
+	accounts.LoadAccountCalled = accounts.GetExistingAccountCalled
+
+	fungibleData, _ := marshaller.Marshal(&dct.DCToken{Value: big.NewInt(100)})
+	_ = holder.SaveKeyValue(computeNFTKey("FNG-abcdef", 0), fungibleData)
+	nftData, _ := marshaller.Marshal(&dct.DCToken{Type: uint32(core.NonFungible), Value: big.NewInt(1)})
+	_ = holder.SaveKeyValue(computeNFTKey("NFT-abcdef", 2), nftData)
+	metaData, _ := marshaller.Marshal(&dct.DCToken{
+		Type:          uint32(core.NonFungible),
+		TokenMetaData: &dct.MetaData{Nonce: 2, Name: []byte("name")},
+	})
+	_ = systemAccount.SaveKeyValue(computeNFTKey("NFT-abcdef", 2), metaData)
+
+	storageHandler, _ := builtInFunctions.NewDCTDataStorage(builtInFunctions.ArgsNewDCTDataStorage{
+		Accounts:              accounts,
+		GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+		Marshalizer:           marshaller,
+		EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{IsSaveToSystemAccountFlagEnabledField: true},
+		ShardCoordinator:      &mock.ShardCoordinatorStub{},
+	})
+
+	return ArgsDCTQuery{
+		Accounts:          accounts,
+		DCTStorageHandler: storageHandler,
+		Marshalizer:       marshaller,
+	}
+}
+
+func TestNewDCTQuery(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil accounts should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgs()
+		args.Accounts = nil
+		dq, err := NewDCTQuery(args)
+		assert.True(t, check.IfNil(dq))
+		assert.Equal(t, ErrNilAccountsAdapter, err)
+	})
+	t.Run("nil storage handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgs()
+		args.DCTStorageHandler = nil
+		dq, err := NewDCTQuery(args)
+		assert.True(t, check.IfNil(dq))
+		assert.Equal(t, ErrNilDCTNFTStorageHandler, err)
+	})
+	t.Run("nil marshaller should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgs()
+		args.Marshalizer = nil
+		dq, err := NewDCTQuery(args)
+		assert.True(t, check.IfNil(dq))
+		assert.Equal(t, ErrNilMarshalizer, err)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		dq, err := NewDCTQuery(createMockArgs())
+		assert.Nil(t, err)
+		assert.False(t, check.IfNil(dq))
+	})
+}
+
+func TestDctQuery_GetTokenBalance(t *testing.T) {
+	t.Parallel()
+
+	dq, _ := NewDCTQuery(createMockArgs())
+
+	balance, err := dq.GetTokenBalance([]byte("missing"), []byte("FNG-abcdef"), 0)
+	assert.Nil(t, balance)
+	assert.NotNil(t, err)
+
+	balance, err = dq.GetTokenBalance(holderAddress, []byte("FNG-abcdef"), 0)
+	require.Nil(t, err)
+	assert.Equal(t, big.NewInt(100), balance)
+
+	balance, err = dq.GetTokenBalance(holderAddress, []byte("NFT-abcdef"), 2)
+	require.Nil(t, err)
+	assert.Equal(t, big.NewInt(1), balance)
+
+	balance, err = dq.GetTokenBalance(holderAddress, []byte("NFT-abcdef"), 3)
+	require.Nil(t, err)
+	assert.Equal(t, big.NewInt(0), balance)
+}
+
+func TestDctQuery_GetAllTokens(t *testing.T) {
+	t.Parallel()
+
+	dq, _ := NewDCTQuery(createMockArgs())
+
+	tokens, err := dq.GetAllTokens(holderAddress)
+	require.Nil(t, err)
+	require.Len(t, tokens, 2)
+	assert.Equal(t, big.NewInt(100), tokens["FNG-abcdef"].Value)
+	nft := tokens["NFT-abcdef-02"]
+	require.NotNil(t, nft)
+	assert.Equal(t, big.NewInt(1), nft.Value)
+	assert.Equal(t, []byte("name"), nft.TokenMetaData.Name)
+}
+
+func TestDctQuery_GetTokenMetaData(t *testing.T) {
+	t.Parallel()
+
+	dq, _ := NewDCTQuery(createMockArgs())
+
+	metaData, err := dq.GetTokenMetaData([]byte("NFT-abcdef"), 0)
+	assert.Nil(t, metaData)
+	assert.Equal(t, ErrZeroNonce, err)
+
+	metaData, err = dq.GetTokenMetaData([]byte("NFT-abcdef"), 3)
+	assert.Nil(t, metaData)
+	assert.Equal(t, ErrTokenMetaDataNotFound, err)
+
+	metaData, err = dq.GetTokenMetaData([]byte("NFT-abcdef"), 2)
+	require.Nil(t, err)
+	assert.Equal(t, []byte("name"), metaData.Name)
+}
@@ -0,0 +1,21 @@
+package dctquery
+
+import "errors"
+
+// ErrNilAccountsAdapter signals that a nil accounts adapter has been provided
+var ErrNilAccountsAdapter = errors.New("nil accounts adapter")
+
+// ErrNilDCTNFTStorageHandler signals that a nil nft storage handler has been provided
+var ErrNilDCTNFTStorageHandler = errors.New("nil dct nft storage handler")
+
+// ErrNilMarshalizer signals that a nil marshalizer has been provided
+var ErrNilMarshalizer = errors.New("nil marshalizer")
+
+// ErrWrongTypeAssertion signals that a type assertion failed
+var ErrWrongTypeAssertion = errors.New("wrong type assertion")
+
+// ErrZeroNonce signals that the metadata of a token was requested with a 0 nonce
+var ErrZeroNonce = errors.New("only tokens with a nonce greater than 0 have metadata")
+
+// ErrTokenMetaDataNotFound signals that no metadata is stored for the requested token
+var ErrTokenMetaDataNotFound = errors.New("token metadata not found")
@@ -0,0 +1,9 @@
+package dctroles
+
+import "errors"
+
+// ErrUnknownRole signals that the provided role name is not one of the DCT roles
+var ErrUnknownRole = errors.New("unknown role")
+
+// ErrNilMarshalizer signals that a nil marshalizer has been provided
+var ErrNilMarshalizer = errors.New("nil marshalizer")
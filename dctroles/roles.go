@@ -0,0 +1,215 @@
+// Package dctroles exposes the roles an account can hold for a DCT token, as stored under the roles key of the
+// account, so that the wallets and the SDKs decode the on-chain roles the same way the built-in functions do
+package dctroles
+
+import (
+	"fmt"
+	"math/bits"
+
+	"github.com/Reshusk23/sr-me-core/core"
+	"github.com/Reshusk23/sr-me-core/core/check"
+	"github.com/Reshusk23/sr-me-core/data/dct"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/protectedkeys"
+)
+
+// RoleKeyPrefix is the prefix of the keys holding the DCT roles of an account
+const RoleKeyPrefix = protectedkeys.DCTRolePrefix
+
+// Role is a role an account can hold for a DCT token
+type Role uint8
+
+const (
+	// LocalMint allows minting the fungible token
+	LocalMint Role = iota
+	// LocalBurn allows burning the fungible token
+	LocalBurn
+	// NFTCreate allows creating new nonces of the collection
+	NFTCreate
+	// NFTCreateMultiShard allows creating new nonces of the collection on every shard
+	NFTCreateMultiShard
+	// NFTAddQuantity allows adding quantity to the existing nonces of the collection
+	NFTAddQuantity
+	// NFTBurn allows burning the nonces of the collection
+	NFTBurn
+	// NFTAddURI allows adding URIs to the nonces of the collection
+	NFTAddURI
+	// NFTUpdateAttributes allows changing the attributes of the nonces of the collection
+	NFTUpdateAttributes
+	// Transfer allows transferring a token whose transfers are restricted
+	Transfer
+
+	numRoles
+)
+
+var roleNames = [numRoles]string{
+	LocalMint:           core.DCTRoleLocalMint,
+	LocalBurn:           core.DCTRoleLocalBurn,
+	NFTCreate:           core.DCTRoleNFTCreate,
+	NFTCreateMultiShard: core.DCTRoleNFTCreateMultiShard,
+	NFTAddQuantity:      core.DCTRoleNFTAddQuantity,
+	NFTBurn:             core.DCTRoleNFTBurn,
+	NFTAddURI:           core.DCTRoleNFTAddURI,
+	NFTUpdateAttributes: core.DCTRoleNFTUpdateAttributes,
+	Transfer:            core.DCTRoleTransfer,
+}
+
+// AllRoles returns every role, in the order of their bits in a RolesSet
+func AllRoles() []Role {
+	roles := make([]Role, 0, numRoles)
+	for role := Role(0); role < numRoles; role++ {
+		roles = append(roles, role)
+	}
+
+	return roles
+}
+
+// ParseRole returns the role having the provided name, as stored on chain
+func ParseRole(name []byte) (Role, error) {
+	for role, roleName := range roleNames {
+		if roleName == string(name) {
+			return Role(role), nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: %s", ErrUnknownRole, name)
+}
+
+// IsValid returns true if the role is one of the DCT roles
+func (r Role) IsValid() bool {
+	return r < numRoles
+}
+
+// String returns the name of the role, as stored on chain
+func (r Role) String() string {
+	if !r.IsValid() {
+		return fmt.Sprintf("Role(%d)", uint8(r))
+	}
+
+	return roleNames[r]
+}
+
+// RolesSet is a bitmask of the roles an account holds for a DCT token
+type RolesSet uint32
+
+// NewRolesSet returns the set holding the provided roles
+func NewRolesSet(roles ...Role) RolesSet {
+	set := RolesSet(0)
+	for _, role := range roles {
+		set = set.Add(role)
+	}
+
+	return set
+}
+
+// ParseRolesSet returns the set holding the provided role names. Duplicated names are accepted, while an unknown
+// name fails the parsing as the set would not describe the stored roles
+func ParseRolesSet(names [][]byte) (RolesSet, error) {
+	set := RolesSet(0)
+	for _, name := range names {
+		role, err := ParseRole(name)
+		if err != nil {
+			return 0, err
+		}
+
+		set = set.Add(role)
+	}
+
+	return set, nil
+}
+
+// Has returns true if the set holds the provided role
+func (s RolesSet) Has(role Role) bool {
+	return role.IsValid() && s&roleBit(role) != 0
+}
+
+// Add returns the set holding the provided role as well, the invalid roles being ignored
+func (s RolesSet) Add(role Role) RolesSet {
+	if !role.IsValid() {
+		return s
+	}
+
+	return s | roleBit(role)
+}
+
+// Remove returns the set without the provided role
+func (s RolesSet) Remove(role Role) RolesSet {
+	if !role.IsValid() {
+		return s
+	}
+
+	return s &^ roleBit(role)
+}
+
+// Len returns the number of roles held by the set
+func (s RolesSet) Len() int {
+	return bits.OnesCount32(uint32(s))
+}
+
+// IsEmpty returns true if the set holds no role
+func (s RolesSet) IsEmpty() bool {
+	return s == 0
+}
+
+// Roles returns the roles held by the set, in the order of their bits
+func (s RolesSet) Roles() []Role {
+	roles := make([]Role, 0, s.Len())
+	for role := Role(0); role < numRoles; role++ {
+		if s.Has(role) {
+			roles = append(roles, role)
+		}
+	}
+
+	return roles
+}
+
+// Names returns the names of the roles held by the set, in the order of their bits
+func (s RolesSet) Names() [][]byte {
+	names := make([][]byte, 0, s.Len())
+	for _, role := range s.Roles() {
+		names = append(names, []byte(role.String()))
+	}
+
+	return names
+}
+
+// Marshal returns the set in the storage format of the roles key. The built-in functions store the roles in the
+// order they were set, so the result matches the stored value only when the roles were set in the order of their bits
+func (s RolesSet) Marshal(marshaller vmcommon.Marshalizer) ([]byte, error) {
+	if check.IfNil(marshaller) {
+		return nil, ErrNilMarshalizer
+	}
+
+	return marshaller.Marshal(&dct.DCTRoles{Roles: s.Names()})
+}
+
+// UnmarshalRolesSet returns the set stored under the roles key. An empty value, as read for a missing key, holds no
+// role
+func UnmarshalRolesSet(marshaller vmcommon.Marshalizer, buff []byte) (RolesSet, error) {
+	if check.IfNil(marshaller) {
+		return 0, ErrNilMarshalizer
+	}
+	if len(buff) == 0 {
+		return 0, nil
+	}
+
+	roles := &dct.DCTRoles{}
+	err := marshaller.Unmarshal(roles, buff)
+	if err != nil {
+		return 0, err
+	}
+
+	return ParseRolesSet(roles.Roles)
+}
+
+// ComputeRoleKey returns the key under which the roles of an account for the provided token are stored
+func ComputeRoleKey(tokenID []byte) []byte {
+	key := make([]byte, 0, len(RoleKeyPrefix)+len(tokenID))
+	key = append(key, RoleKeyPrefix...)
+
+	return append(key, tokenID...)
+}
+
+func roleBit(role Role) RolesSet {
+	return RolesSet(1) << role
+}
@@ -0,0 +1,120 @@
+package dctroles
+
+import (
+	"testing"
+
+	"github.com/Reshusk23/sr-me-core/core"
+	"github.com/Reshusk23/sr-me-core/data/dct"
+	"github.com/Reshusk23/sr-me-core/marshal"
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRole(t *testing.T) {
+	t.Parallel()
+
+	for _, role := range AllRoles() {
+		parsed, err := ParseRole([]byte(role.String()))
+		require.Nil(t, err)
+		assert.Equal(t, role, parsed)
+	}
+
+	role, err := ParseRole([]byte(core.DCTRoleTransfer))
+	assert.Nil(t, err)
+	assert.Equal(t, Transfer, role)
+
+	_, err = ParseRole([]byte("DCTRoleUnknown"))
+	assert.ErrorIs(t, err, ErrUnknownRole)
+}
+
+func TestRole_String(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, core.DCTRoleNFTCreate, NFTCreate.String())
+	assert.Equal(t, "Role(200)", Role(200).String())
+	assert.False(t, Role(200).IsValid())
+}
+
+func TestRolesSet(t *testing.T) {
+	t.Parallel()
+
+	set := NewRolesSet(NFTCreate, NFTBurn)
+	assert.True(t, set.Has(NFTCreate))
+	assert.True(t, set.Has(NFTBurn))
+	assert.False(t, set.Has(LocalMint))
+	assert.Equal(t, 2, set.Len())
+
+	set = set.Add(LocalMint).Add(LocalMint).Add(Role(200))
+	assert.Equal(t, []Role{LocalMint, NFTCreate, NFTBurn}, set.Roles())
+
+	set = set.Remove(NFTCreate).Remove(Transfer)
+	assert.Equal(t, [][]byte{[]byte(core.DCTRoleLocalMint), []byte(core.DCTRoleNFTBurn)}, set.Names())
+
+	assert.True(t, RolesSet(0).IsEmpty())
+	assert.Empty(t, RolesSet(0).Roles())
+}
+
+func TestParseRolesSet(t *testing.T) {
+	t.Parallel()
+
+	set, err := ParseRolesSet([][]byte{[]byte(core.DCTRoleNFTBurn), []byte(core.DCTRoleLocalBurn), []byte(core.DCTRoleNFTBurn)})
+	require.Nil(t, err)
+	assert.Equal(t, NewRolesSet(LocalBurn, NFTBurn), set)
+
+	set, err = ParseRolesSet([][]byte{[]byte(core.DCTRoleNFTBurn), []byte("unknown")})
+	assert.ErrorIs(t, err, ErrUnknownRole)
+	assert.Equal(t, RolesSet(0), set)
+}
+
+func TestRolesSet_Marshal(t *testing.T) {
+	t.Parallel()
+
+	marshaller := &marshal.GogoProtoMarshalizer{}
+
+	t.Run("nil marshaller should err", func(t *testing.T) {
+		t.Parallel()
+
+		buff, err := NewRolesSet(LocalMint).Marshal(nil)
+		assert.Equal(t, ErrNilMarshalizer, err)
+		assert.Nil(t, buff)
+
+		_, err = UnmarshalRolesSet(nil, buff)
+		assert.Equal(t, ErrNilMarshalizer, err)
+	})
+	t.Run("should match the stored roles", func(t *testing.T) {
+		t.Parallel()
+
+		stored, err := marshaller.Marshal(&dct.DCTRoles{Roles: [][]byte{[]byte(core.DCTRoleNFTCreate), []byte(core.DCTRoleNFTAddURI)}})
+		require.Nil(t, err)
+
+		set, err := UnmarshalRolesSet(marshaller, stored)
+		require.Nil(t, err)
+		assert.Equal(t, NewRolesSet(NFTCreate, NFTAddURI), set)
+
+		buff, err := set.Marshal(marshaller)
+		require.Nil(t, err)
+		assert.Equal(t, stored, buff)
+	})
+	t.Run("missing key should hold no role", func(t *testing.T) {
+		t.Parallel()
+
+		set, err := UnmarshalRolesSet(marshaller, nil)
+		assert.Nil(t, err)
+		assert.True(t, set.IsEmpty())
+	})
+	t.Run("unmarshal error should err", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := UnmarshalRolesSet(&mock.MarshalizerMock{Fail: true}, []byte("roles"))
+		assert.NotNil(t, err)
+	})
+}
+
+func TestComputeRoleKey(t *testing.T) {
+	t.Parallel()
+
+	tokenID := []byte("TKN-abcdef")
+	assert.Equal(t, []byte(core.ProtectedKeyPrefix+core.DCTRoleIdentifier+core.DCTKeyIdentifier+"TKN-abcdef"), ComputeRoleKey(tokenID))
+	assert.Equal(t, []byte("TKN-abcdef"), tokenID)
+}
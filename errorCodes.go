@@ -0,0 +1,117 @@
+package vmcommon
+
+import "errors"
+
+// ErrorCategory groups the errors by their cause
+type ErrorCategory int
+
+const (
+	// ErrorCategoryUnknown is the category of the errors which do not carry a code
+	ErrorCategoryUnknown ErrorCategory = iota
+	// ErrorCategoryValidation is the category of the errors caused by invalid input
+	ErrorCategoryValidation
+	// ErrorCategoryGas is the category of the errors caused by insufficient gas
+	ErrorCategoryGas
+	// ErrorCategoryRole is the category of the errors caused by missing roles or permissions
+	ErrorCategoryRole
+	// ErrorCategoryState is the category of the errors caused by the current state of the accounts or tokens
+	ErrorCategoryState
+	// ErrorCategoryConfiguration is the category of the errors caused by wrongly built or configured components
+	ErrorCategoryConfiguration
+)
+
+// ErrorCodeUnknown is the code of the errors which do not carry a code
+const ErrorCodeUnknown = 0
+
+// ReturnMessageInternalError is the return message of the errors which do not carry a code
+const ReturnMessageInternalError = "internal error"
+
+// String returns the human readable name of the category
+func (category ErrorCategory) String() string {
+	switch category {
+	case ErrorCategoryValidation:
+		return "validation"
+	case ErrorCategoryGas:
+		return "gas"
+	case ErrorCategoryRole:
+		return "role"
+	case ErrorCategoryState:
+		return "state"
+	case ErrorCategoryConfiguration:
+		return "configuration"
+	default:
+		return "unknown"
+	}
+}
+
+// codedError is an error carrying a stable numeric code and a category
+type codedError struct {
+	code     int
+	category ErrorCategory
+	message  string
+}
+
+// NewCodedError creates a new error with the given code, category and message. The code must be unique and
+// must never change once released, as hosts rely on it instead of the message
+func NewCodedError(code int, category ErrorCategory, message string) error {
+	return &codedError{
+		code:     code,
+		category: category,
+		message:  message,
+	}
+}
+
+// Error returns the error message
+func (e *codedError) Error() string {
+	return e.message
+}
+
+// ErrorCode returns the code of the first coded error found in the chain of the provided error, or
+// ErrorCodeUnknown if there is none
+func ErrorCode(err error) int {
+	var coded *codedError
+	if !errors.As(err, &coded) {
+		return ErrorCodeUnknown
+	}
+
+	return coded.code
+}
+
+// ErrorCategoryOf returns the category of the first coded error found in the chain of the provided error, or
+// ErrorCategoryUnknown if there is none
+func ErrorCategoryOf(err error) ErrorCategory {
+	var coded *codedError
+	if !errors.As(err, &coded) {
+		return ErrorCategoryUnknown
+	}
+
+	return coded.category
+}
+
+// ReturnCodeFromError maps the provided error to the return code presented to the user
+func ReturnCodeFromError(err error) ReturnCode {
+	if err == nil {
+		return Ok
+	}
+	if ErrorCategoryOf(err) == ErrorCategoryGas {
+		return OutOfGas
+	}
+
+	return UserError
+}
+
+// ReturnMessageFromError maps the provided error to the message presented to the user. The message of the first coded
+// error found in the chain is used, without the context added when wrapping it, so the message only changes with the
+// code and stays the same across versions
+func ReturnMessageFromError(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var coded *codedError
+	if !errors.As(err, &coded) {
+		return ReturnMessageInternalError
+	}
+
+	return coded.message
+}
@@ -0,0 +1,61 @@
+package vmcommon
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCodedError(t *testing.T) {
+	t.Parallel()
+
+	err := NewCodedError(1001, ErrorCategoryValidation, "invalid argument")
+	assert.Equal(t, "invalid argument", err.Error())
+	assert.Equal(t, 1001, ErrorCode(err))
+	assert.Equal(t, ErrorCategoryValidation, ErrorCategoryOf(err))
+
+	wrapped := fmt.Errorf("%w for token", err)
+	assert.True(t, errors.Is(wrapped, err))
+	assert.Equal(t, 1001, ErrorCode(wrapped))
+	assert.Equal(t, ErrorCategoryValidation, ErrorCategoryOf(wrapped))
+}
+
+func TestErrorCode_UncodedErrors(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, ErrorCodeUnknown, ErrorCode(nil))
+	assert.Equal(t, ErrorCodeUnknown, ErrorCode(errors.New("plain error")))
+	assert.Equal(t, ErrorCategoryUnknown, ErrorCategoryOf(errors.New("plain error")))
+}
+
+func TestReturnCodeFromError(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, Ok, ReturnCodeFromError(nil))
+	assert.Equal(t, OutOfGas, ReturnCodeFromError(NewCodedError(2001, ErrorCategoryGas, "not enough gas")))
+	assert.Equal(t, UserError, ReturnCodeFromError(NewCodedError(4001, ErrorCategoryState, "insufficient funds")))
+	assert.Equal(t, UserError, ReturnCodeFromError(errors.New("plain error")))
+}
+
+func TestReturnMessageFromError(t *testing.T) {
+	t.Parallel()
+
+	err := NewCodedError(1001, ErrorCategoryValidation, "invalid argument")
+	assert.Equal(t, "", ReturnMessageFromError(nil))
+	assert.Equal(t, "invalid argument", ReturnMessageFromError(err))
+	assert.Equal(t, "invalid argument", ReturnMessageFromError(fmt.Errorf("%w, argument 2 has 40 bytes", err)))
+	assert.Equal(t, ReturnMessageInternalError, ReturnMessageFromError(errors.New("trie error")))
+}
+
+func TestErrorCategory_String(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "validation", ErrorCategoryValidation.String())
+	assert.Equal(t, "gas", ErrorCategoryGas.String())
+	assert.Equal(t, "role", ErrorCategoryRole.String())
+	assert.Equal(t, "state", ErrorCategoryState.String())
+	assert.Equal(t, "configuration", ErrorCategoryConfiguration.String())
+	assert.Equal(t, "unknown", ErrorCategoryUnknown.String())
+}
@@ -4,3 +4,48 @@ import "errors"
 
 // ErrSubtractionOverflow signals that uint64 subtraction overflowed
 var ErrSubtractionOverflow = errors.New("uint64 subtraction overflowed")
+
+// ErrAdditionOverflow signals that uint64 addition overflowed
+var ErrAdditionOverflow = errors.New("uint64 addition overflowed")
+
+// ErrMultiplicationOverflow signals that uint64 multiplication overflowed
+var ErrMultiplicationOverflow = errors.New("uint64 multiplication overflowed")
+
+// ErrInvalidAddressLength signals that an invalid address length has been provided
+var ErrInvalidAddressLength = errors.New("invalid address length")
+
+// ErrInvalidSCAddressPrefixLength signals that an invalid smart contract address prefix length has been provided
+var ErrInvalidSCAddressPrefixLength = errors.New("invalid smart contract address prefix length")
+
+// ErrValueOutOfRange signals that a big endian encoded value does not fit the requested integer type
+var ErrValueOutOfRange = errors.New("value out of range")
+
+// ErrEmptyFunctionName signals that an empty function name has been provided
+var ErrEmptyFunctionName = errors.New("empty function name")
+
+// ErrUnknownCanonicalFunction signals that an alias points to a function which is not canonical
+var ErrUnknownCanonicalFunction = errors.New("alias of an unknown canonical function")
+
+// ErrAmbiguousFunctionName signals that a function name resolves to more than one function
+var ErrAmbiguousFunctionName = errors.New("ambiguous function name")
+
+// ErrNilAccount signals that a nil account has been provided
+var ErrNilAccount = errors.New("nil account")
+
+// ErrAccountDataNotIterable signals that the data handler of an account does not implement the account data iterator
+var ErrAccountDataNotIterable = errors.New("account data can not be iterated")
+
+// ErrNilAccountSnapshot signals that a nil account snapshot has been provided
+var ErrNilAccountSnapshot = errors.New("nil account snapshot")
+
+// ErrDuplicatedAccountSnapshot signals that more than one snapshot of the same account has been provided
+var ErrDuplicatedAccountSnapshot = errors.New("duplicated account snapshot")
+
+// ErrMissingPostSnapshot signals that an account of the pre state is missing from the post state
+var ErrMissingPostSnapshot = errors.New("missing post state snapshot of account")
+
+// ErrInvalidDCTSCAddress signals that an invalid DCT system smart contract address has been provided
+var ErrInvalidDCTSCAddress = errors.New("invalid DCT system smart contract address")
+
+// ErrInvalidSystemAccountAddress signals that an invalid system account address has been provided
+var ErrInvalidSystemAccountAddress = errors.New("invalid system account address")
@@ -0,0 +1,95 @@
+package vmcommon
+
+import "strings"
+
+// ArgsFunctionResolver holds the arguments needed to create a function resolver
+type ArgsFunctionResolver struct {
+	// CanonicalFunctions are the built-in function identifiers the names are resolved to
+	CanonicalFunctions []string
+	// Aliases maps the legacy and deprecated names to their canonical function
+	Aliases map[string]string
+	// CaseInsensitive resolves the names regardless of their letter case
+	CaseInsensitive bool
+}
+
+// functionResolver maps the function names found in data fields to the canonical built-in function identifiers
+type functionResolver struct {
+	names           map[string]string
+	foldedNames     map[string]string
+	caseInsensitive bool
+}
+
+// NewFunctionResolver creates a new function resolver. The aliases must point to canonical functions and none of
+// the names may be ambiguous, not even when the case is ignored
+func NewFunctionResolver(args ArgsFunctionResolver) (*functionResolver, error) {
+	fr := &functionResolver{
+		names:           make(map[string]string, len(args.CanonicalFunctions)+len(args.Aliases)),
+		foldedNames:     make(map[string]string),
+		caseInsensitive: args.CaseInsensitive,
+	}
+
+	for _, function := range args.CanonicalFunctions {
+		err := fr.addName(function, function)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for alias, function := range args.Aliases {
+		if fr.names[function] != function {
+			return nil, ErrUnknownCanonicalFunction
+		}
+
+		err := fr.addName(alias, function)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return fr, nil
+}
+
+func (fr *functionResolver) addName(name string, function string) error {
+	if len(name) == 0 {
+		return ErrEmptyFunctionName
+	}
+	if _, exists := fr.names[name]; exists {
+		return ErrAmbiguousFunctionName
+	}
+	fr.names[name] = function
+
+	if !fr.caseInsensitive {
+		return nil
+	}
+
+	foldedName := strings.ToLower(name)
+	if _, exists := fr.foldedNames[foldedName]; exists {
+		return ErrAmbiguousFunctionName
+	}
+	fr.foldedNames[foldedName] = function
+
+	return nil
+}
+
+// ResolveFunction returns the canonical built-in function for the provided name. The name is returned unchanged,
+// together with false, when it does not resolve to a built-in function
+func (fr *functionResolver) ResolveFunction(name string) (string, bool) {
+	function, ok := fr.names[name]
+	if ok {
+		return function, true
+	}
+	if !fr.caseInsensitive {
+		return name, false
+	}
+
+	function, ok = fr.foldedNames[strings.ToLower(name)]
+	if ok {
+		return function, true
+	}
+
+	return name, false
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (fr *functionResolver) IsInterfaceNil() bool {
+	return fr == nil
+}
@@ -0,0 +1,117 @@
+package vmcommon
+
+import (
+	"testing"
+
+	"github.com/Reshusk23/sr-me-core/core"
+	"github.com/Reshusk23/sr-me-core/core/check"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createMockArgsFunctionResolver() ArgsFunctionResolver {
+	return ArgsFunctionResolver{
+		CanonicalFunctions: []string{core.BuiltInFunctionDCTTransfer, core.BuiltInFunctionDCTNFTTransfer},
+		Aliases:            map[string]string{"DCTNFTSend": core.BuiltInFunctionDCTNFTTransfer},
+	}
+}
+
+func TestNewFunctionResolver(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty function name should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsFunctionResolver()
+		args.CanonicalFunctions = append(args.CanonicalFunctions, "")
+		fr, err := NewFunctionResolver(args)
+		assert.True(t, check.IfNil(fr))
+		assert.Equal(t, ErrEmptyFunctionName, err)
+	})
+	t.Run("alias of an unknown function should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsFunctionResolver()
+		args.Aliases["DCTSend"] = "unknown"
+		fr, err := NewFunctionResolver(args)
+		assert.True(t, check.IfNil(fr))
+		assert.Equal(t, ErrUnknownCanonicalFunction, err)
+	})
+	t.Run("alias shadowing a canonical function should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsFunctionResolver()
+		args.Aliases[core.BuiltInFunctionDCTTransfer] = core.BuiltInFunctionDCTNFTTransfer
+		fr, err := NewFunctionResolver(args)
+		assert.True(t, check.IfNil(fr))
+		assert.Equal(t, ErrAmbiguousFunctionName, err)
+	})
+	t.Run("names differing only in case should error when the case is ignored", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsFunctionResolver()
+		args.Aliases["dctTransfer"] = core.BuiltInFunctionDCTTransfer
+		fr, err := NewFunctionResolver(args)
+		assert.False(t, check.IfNil(fr))
+		assert.Nil(t, err)
+
+		args.CaseInsensitive = true
+		fr, err = NewFunctionResolver(args)
+		assert.True(t, check.IfNil(fr))
+		assert.Equal(t, ErrAmbiguousFunctionName, err)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		fr, err := NewFunctionResolver(createMockArgsFunctionResolver())
+		assert.False(t, check.IfNil(fr))
+		assert.Nil(t, err)
+	})
+}
+
+func TestFunctionResolver_ResolveFunction(t *testing.T) {
+	t.Parallel()
+
+	t.Run("case sensitive", func(t *testing.T) {
+		t.Parallel()
+
+		fr, err := NewFunctionResolver(createMockArgsFunctionResolver())
+		require.Nil(t, err)
+
+		function, ok := fr.ResolveFunction(core.BuiltInFunctionDCTTransfer)
+		assert.True(t, ok)
+		assert.Equal(t, core.BuiltInFunctionDCTTransfer, function)
+
+		function, ok = fr.ResolveFunction("DCTNFTSend")
+		assert.True(t, ok)
+		assert.Equal(t, core.BuiltInFunctionDCTNFTTransfer, function)
+
+		function, ok = fr.ResolveFunction("dctnfttransfer")
+		assert.False(t, ok)
+		assert.Equal(t, "dctnfttransfer", function)
+
+		function, ok = fr.ResolveFunction("callMe")
+		assert.False(t, ok)
+		assert.Equal(t, "callMe", function)
+	})
+	t.Run("case insensitive", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsFunctionResolver()
+		args.CaseInsensitive = true
+		fr, err := NewFunctionResolver(args)
+		require.Nil(t, err)
+
+		function, ok := fr.ResolveFunction("dctnfttransfer")
+		assert.True(t, ok)
+		assert.Equal(t, core.BuiltInFunctionDCTNFTTransfer, function)
+
+		function, ok = fr.ResolveFunction("DCTNFTSEND")
+		assert.True(t, ok)
+		assert.Equal(t, core.BuiltInFunctionDCTNFTTransfer, function)
+
+		function, ok = fr.ResolveFunction("callMe")
+		assert.False(t, ok)
+		assert.Equal(t, "callMe", function)
+	})
+}
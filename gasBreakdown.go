@@ -0,0 +1,27 @@
+package vmcommon
+
+// GasBreakdown splits the gas consumed by a built-in function by what it was paid for. The gas forwarded with the
+// output transfers is not part of the breakdown, being consumed by the calls on the destination
+type GasBreakdown struct {
+	// BaseCost is the fixed cost of the function
+	BaseCost uint64
+	// PerByteCost is the cost of the bytes copied or persisted while processing the call
+	PerByteCost uint64
+	// StorageCost is the cost of the bytes newly stored on behalf of the accounts
+	StorageCost uint64
+	// TransferCost is the cost of moving the tokens between the accounts
+	TransferCost uint64
+}
+
+// Add adds the costs of the provided breakdown to the current one
+func (gb *GasBreakdown) Add(other GasBreakdown) {
+	gb.BaseCost += other.BaseCost
+	gb.PerByteCost += other.PerByteCost
+	gb.StorageCost += other.StorageCost
+	gb.TransferCost += other.TransferCost
+}
+
+// Total returns the gas consumed by the function
+func (gb *GasBreakdown) Total() uint64 {
+	return gb.BaseCost + gb.PerByteCost + gb.StorageCost + gb.TransferCost
+}
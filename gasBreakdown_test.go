@@ -0,0 +1,19 @@
+package vmcommon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGasBreakdown_AddAndTotal(t *testing.T) {
+	t.Parallel()
+
+	breakdown := &GasBreakdown{BaseCost: 10, StorageCost: 5}
+	breakdown.Add(GasBreakdown{PerByteCost: 3, TransferCost: 2})
+	breakdown.Add(GasBreakdown{StorageCost: 1})
+
+	assert.Equal(t, GasBreakdown{BaseCost: 10, PerByteCost: 3, StorageCost: 6, TransferCost: 2}, *breakdown)
+	assert.Equal(t, uint64(21), breakdown.Total())
+	assert.Equal(t, uint64(0), (&GasBreakdown{}).Total())
+}
@@ -30,10 +30,72 @@ type BuiltInCost struct {
 	DCTNFTUpdateAttributes  uint64
 }
 
+// AsyncCallbackCostString is the gas schedule section holding the costs of asynchronous callbacks
+const AsyncCallbackCostString = "AsyncCallbackCost"
+
+// AsyncCallbackCost defines the gas kept aside for the callback of an asynchronous call
+type AsyncCallbackCost struct {
+	AsyncCallStep        uint64
+	AsyncCallbackGasLock uint64
+}
+
 // GasCost holds all the needed gas costs for system smart contracts
 type GasCost struct {
 	BaseOperationCost BaseOperationCost
 	BuiltInCost       BuiltInCost
+	AsyncCallbackCost AsyncCallbackCost
+}
+
+// ComputeGasLockedForCallback returns the gas to be locked out of the provided gas for the callback of an
+// asynchronous call, as configured in the gas schedule. The result never exceeds the provided gas.
+func ComputeGasLockedForCallback(gasProvided uint64, gasSchedule AsyncCallbackCost) uint64 {
+	gasToLock := gasSchedule.AsyncCallStep + gasSchedule.AsyncCallbackGasLock
+	if gasToLock < gasSchedule.AsyncCallStep || gasToLock > gasProvided {
+		return gasProvided
+	}
+
+	return gasToLock
+}
+
+// ComputeMultiTransferGas returns the gas charged by a multi token transfer of numTransfers entries carrying dataLength
+// bytes of arguments: the DCTNFTMultiTransfer cost once, the DCTNFTTransfer cost for each entry and the
+// DataCopyPerByte cost for each byte of arguments. It errors if the result does not fit an uint64.
+func ComputeMultiTransferGas(numTransfers uint64, dataLength uint64, gasCost GasCost) (uint64, error) {
+	entriesCost, err := SafeMulUint64(numTransfers, gasCost.BuiltInCost.DCTNFTTransfer)
+	if err != nil {
+		return 0, err
+	}
+	dataCost, err := SafeMulUint64(dataLength, gasCost.BaseOperationCost.DataCopyPerByte)
+	if err != nil {
+		return 0, err
+	}
+	totalCost, err := SafeAddUint64(gasCost.BuiltInCost.DCTNFTMultiTransfer, entriesCost)
+	if err != nil {
+		return 0, err
+	}
+
+	return SafeAddUint64(totalCost, dataCost)
+}
+
+// SafeAddUint64 performs addition on uint64 and returns an error if it overflows
+func SafeAddUint64(a, b uint64) (uint64, error) {
+	sum := a + b
+	if sum < a {
+		return 0, ErrAdditionOverflow
+	}
+	return sum, nil
+}
+
+// SafeMulUint64 performs multiplication on uint64 and returns an error if it overflows
+func SafeMulUint64(a, b uint64) (uint64, error) {
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+	product := a * b
+	if product/b != a {
+		return 0, ErrMultiplicationOverflow
+	}
+	return product, nil
 }
 
 // SafeSubUint64 performs subtraction on uint64 and returns an error if it overflows
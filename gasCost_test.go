@@ -0,0 +1,131 @@
+package vmcommon
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeGasLockedForCallback(t *testing.T) {
+	t.Parallel()
+
+	gasSchedule := AsyncCallbackCost{
+		AsyncCallStep:        100,
+		AsyncCallbackGasLock: 4000,
+	}
+
+	t.Run("empty schedule should not lock gas", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, uint64(0), ComputeGasLockedForCallback(10000, AsyncCallbackCost{}))
+	})
+	t.Run("enough gas provided should lock the configured gas", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, uint64(4100), ComputeGasLockedForCallback(10000, gasSchedule))
+	})
+	t.Run("not enough gas provided should lock all the provided gas", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, uint64(1000), ComputeGasLockedForCallback(1000, gasSchedule))
+	})
+	t.Run("overflowing schedule should lock all the provided gas", func(t *testing.T) {
+		t.Parallel()
+
+		overflowingSchedule := AsyncCallbackCost{
+			AsyncCallStep:        math.MaxUint64,
+			AsyncCallbackGasLock: 1,
+		}
+		assert.Equal(t, uint64(1000), ComputeGasLockedForCallback(1000, overflowingSchedule))
+	})
+}
+
+func TestComputeMultiTransferGas(t *testing.T) {
+	t.Parallel()
+
+	gasCost := GasCost{
+		BaseOperationCost: BaseOperationCost{DataCopyPerByte: 3},
+		BuiltInCost: BuiltInCost{
+			DCTNFTTransfer:      200,
+			DCTNFTMultiTransfer: 1000,
+		},
+	}
+
+	goldens := []struct {
+		numTransfers uint64
+		dataLength   uint64
+		expectedGas  uint64
+	}{
+		{numTransfers: 0, dataLength: 0, expectedGas: 1000},
+		{numTransfers: 1, dataLength: 0, expectedGas: 1200},
+		{numTransfers: 1, dataLength: 45, expectedGas: 1335},
+		{numTransfers: 10, dataLength: 450, expectedGas: 4350},
+		{numTransfers: 100, dataLength: 4500, expectedGas: 34500},
+		{numTransfers: 1000, dataLength: 45000, expectedGas: 336000},
+	}
+	for _, golden := range goldens {
+		gas, err := ComputeMultiTransferGas(golden.numTransfers, golden.dataLength, gasCost)
+		assert.Nil(t, err)
+		assert.Equal(t, golden.expectedGas, gas, "%d transfers, %d bytes", golden.numTransfers, golden.dataLength)
+	}
+
+	t.Run("overflowing entries cost should err", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ComputeMultiTransferGas(math.MaxUint64, 0, gasCost)
+		assert.Equal(t, ErrMultiplicationOverflow, err)
+	})
+	t.Run("overflowing data cost should err", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ComputeMultiTransferGas(1, math.MaxUint64, gasCost)
+		assert.Equal(t, ErrMultiplicationOverflow, err)
+	})
+	t.Run("overflowing sum should err", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ComputeMultiTransferGas(1, math.MaxUint64/3, gasCost)
+		assert.Equal(t, ErrAdditionOverflow, err)
+	})
+}
+
+func TestSafeAddUint64(t *testing.T) {
+	t.Parallel()
+
+	sum, err := SafeAddUint64(math.MaxUint64-1, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(math.MaxUint64), sum)
+
+	_, err = SafeAddUint64(math.MaxUint64, 1)
+	assert.Equal(t, ErrAdditionOverflow, err)
+}
+
+func TestSafeMulUint64(t *testing.T) {
+	t.Parallel()
+
+	product, err := SafeMulUint64(0, math.MaxUint64)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0), product)
+
+	product, err = SafeMulUint64(math.MaxUint64/2, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(math.MaxUint64-1), product)
+
+	_, err = SafeMulUint64(math.MaxUint64/2+1, 2)
+	assert.Equal(t, ErrMultiplicationOverflow, err)
+}
+
+func BenchmarkComputeMultiTransferGas(b *testing.B) {
+	gasCost := GasCost{
+		BaseOperationCost: BaseOperationCost{DataCopyPerByte: 3},
+		BuiltInCost: BuiltInCost{
+			DCTNFTTransfer:      200,
+			DCTNFTMultiTransfer: 1000,
+		},
+	}
+
+	for i := 0; i < b.N; i++ {
+		_, _ = ComputeMultiTransferGas(uint64(i), uint64(i)*45, gasCost)
+	}
+}
@@ -0,0 +1,191 @@
+package goldens
+
+import (
+	"math/big"
+
+	"github.com/Reshusk23/sr-me-core/core"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/benchmarks"
+	"github.com/Reshusk23/sr-vm-common-go/protectedkeys"
+)
+
+const (
+	sender   = 0
+	receiver = 1
+
+	tokenWithoutRoles = "NOROLES-abcdef"
+)
+
+func newCall(function string, caller []byte, recipient []byte, arguments ...[]byte) *benchmarks.Call {
+	return &benchmarks.Call{
+		Function: function,
+		Input: &vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallerAddr:  caller,
+				Arguments:   arguments,
+				CallValue:   big.NewInt(0),
+				GasProvided: gasProvided,
+			},
+			RecipientAddr: recipient,
+			Function:      function,
+		},
+	}
+}
+
+func newSelfCall(state *benchmarks.State, function string, arguments ...[]byte) *benchmarks.Call {
+	return newCall(function, state.Address(sender), state.Address(sender), arguments...)
+}
+
+func newTransferCall(state *benchmarks.State, function string, arguments ...[]byte) *benchmarks.Call {
+	return newCall(function, state.Address(sender), state.Address(receiver), arguments...)
+}
+
+func withGas(call *benchmarks.Call, gas uint64) *benchmarks.Call {
+	call.Input.GasProvided = gas
+	return call
+}
+
+func withCallValue(call *benchmarks.Call, value int64) *benchmarks.Call {
+	call.Input.CallValue = big.NewInt(value)
+	return call
+}
+
+func value(v int64) []byte {
+	return big.NewInt(v).Bytes()
+}
+
+func nftCreateArguments(tokenID string, quantity int64, royalties int64) [][]byte {
+	return [][]byte{
+		[]byte(tokenID),
+		value(quantity),
+		[]byte("name"),
+		value(royalties),
+		[]byte("hash"),
+		[]byte("attributes"),
+		[]byte("uri"),
+	}
+}
+
+// DefaultCases returns the cases exercising the documented error paths of the built-in functions
+func DefaultCases() []Case {
+	fungible := []byte(benchmarks.FungibleTokenID)
+	nft := []byte(benchmarks.NFTTokenID)
+
+	return []Case{
+		// DCTTransfer
+		{"DCTTransfer/missing value", func(s *benchmarks.State) *benchmarks.Call {
+			return newTransferCall(s, core.BuiltInFunctionDCTTransfer, fungible)
+		}},
+		{"DCTTransfer/insufficient funds", func(s *benchmarks.State) *benchmarks.Call {
+			return newTransferCall(s, core.BuiltInFunctionDCTTransfer, fungible, value(initialBalance+1))
+		}},
+		{"DCTTransfer/not enough gas", func(s *benchmarks.State) *benchmarks.Call {
+			return withGas(newTransferCall(s, core.BuiltInFunctionDCTTransfer, fungible, value(1)), 0)
+		}},
+		{"DCTTransfer/call value", func(s *benchmarks.State) *benchmarks.Call {
+			return withCallValue(newTransferCall(s, core.BuiltInFunctionDCTTransfer, fungible, value(1)), 1)
+		}},
+
+		// DCTLocalMint
+		{"DCTLocalMint/missing value", func(s *benchmarks.State) *benchmarks.Call {
+			return newSelfCall(s, core.BuiltInFunctionDCTLocalMint, fungible)
+		}},
+		{"DCTLocalMint/missing role", func(s *benchmarks.State) *benchmarks.Call {
+			return newSelfCall(s, core.BuiltInFunctionDCTLocalMint, []byte(tokenWithoutRoles), value(1))
+		}},
+
+		// DCTLocalBurn
+		{"DCTLocalBurn/missing role", func(s *benchmarks.State) *benchmarks.Call {
+			return newSelfCall(s, core.BuiltInFunctionDCTLocalBurn, []byte(tokenWithoutRoles), value(1))
+		}},
+		{"DCTLocalBurn/insufficient funds", func(s *benchmarks.State) *benchmarks.Call {
+			return newSelfCall(s, core.BuiltInFunctionDCTLocalBurn, fungible, value(initialBalance+1))
+		}},
+
+		// DCTNFTCreate
+		{"DCTNFTCreate/missing arguments", func(s *benchmarks.State) *benchmarks.Call {
+			return newSelfCall(s, core.BuiltInFunctionDCTNFTCreate, nft, value(1))
+		}},
+		{"DCTNFTCreate/missing role", func(s *benchmarks.State) *benchmarks.Call {
+			return newSelfCall(s, core.BuiltInFunctionDCTNFTCreate, nftCreateArguments(tokenWithoutRoles, 1, 0)...)
+		}},
+		{"DCTNFTCreate/royalties above max", func(s *benchmarks.State) *benchmarks.Call {
+			return newSelfCall(s, core.BuiltInFunctionDCTNFTCreate, nftCreateArguments(benchmarks.NFTTokenID, 1, int64(core.MaxRoyalty)+1)...)
+		}},
+		{"DCTNFTCreate/zero quantity", func(s *benchmarks.State) *benchmarks.Call {
+			return newSelfCall(s, core.BuiltInFunctionDCTNFTCreate, nftCreateArguments(benchmarks.NFTTokenID, 0, 0)...)
+		}},
+
+		// DCTNFTAddQuantity
+		{"DCTNFTAddQuantity/missing nonce", func(s *benchmarks.State) *benchmarks.Call {
+			return newSelfCall(s, core.BuiltInFunctionDCTNFTAddQuantity, nft, value(1), value(1))
+		}},
+		{"DCTNFTAddQuantity/missing role", func(s *benchmarks.State) *benchmarks.Call {
+			return newSelfCall(s, core.BuiltInFunctionDCTNFTAddQuantity, []byte(tokenWithoutRoles), value(1), value(1))
+		}},
+
+		// DCTNFTBurn
+		{"DCTNFTBurn/missing nonce", func(s *benchmarks.State) *benchmarks.Call {
+			return newSelfCall(s, core.BuiltInFunctionDCTNFTBurn, nft, value(1), value(1))
+		}},
+		{"DCTNFTBurn/missing arguments", func(s *benchmarks.State) *benchmarks.Call {
+			return newSelfCall(s, core.BuiltInFunctionDCTNFTBurn, nft)
+		}},
+
+		// DCTNFTTransfer
+		{"DCTNFTTransfer/missing arguments", func(s *benchmarks.State) *benchmarks.Call {
+			return newSelfCall(s, core.BuiltInFunctionDCTNFTTransfer, nft, value(1), value(1))
+		}},
+		{"DCTNFTTransfer/missing nonce", func(s *benchmarks.State) *benchmarks.Call {
+			return newSelfCall(s, core.BuiltInFunctionDCTNFTTransfer, nft, value(1), value(1), s.Address(receiver))
+		}},
+
+		// MultiDCTNFTTransfer
+		{"MultiDCTNFTTransfer/missing arguments", func(s *benchmarks.State) *benchmarks.Call {
+			return newSelfCall(s, core.BuiltInFunctionMultiDCTNFTTransfer, s.Address(receiver))
+		}},
+		{"MultiDCTNFTTransfer/insufficient funds", func(s *benchmarks.State) *benchmarks.Call {
+			return newSelfCall(s, core.BuiltInFunctionMultiDCTNFTTransfer, s.Address(receiver), value(1), fungible, nil, value(initialBalance+1))
+		}},
+
+		// SaveKeyValue
+		{"SaveKeyValue/protected key", func(s *benchmarks.State) *benchmarks.Call {
+			return newSelfCall(s, core.BuiltInFunctionSaveKeyValue, []byte(protectedkeys.DCTPrefix+benchmarks.FungibleTokenID), []byte("value"))
+		}},
+		{"SaveKeyValue/not the owner", func(s *benchmarks.State) *benchmarks.Call {
+			return newTransferCall(s, core.BuiltInFunctionSaveKeyValue, []byte("key"), []byte("value"))
+		}},
+		{"SaveKeyValue/odd number of arguments", func(s *benchmarks.State) *benchmarks.Call {
+			return newSelfCall(s, core.BuiltInFunctionSaveKeyValue, []byte("key"))
+		}},
+
+		// management functions, callable only by the DCT system smart contract
+		{"DCTSetRole/caller not the system smart contract", func(s *benchmarks.State) *benchmarks.Call {
+			return newSelfCall(s, core.BuiltInFunctionSetDCTRole, fungible, []byte(core.DCTRoleLocalMint))
+		}},
+		{"DCTUnSetRole/caller not the system smart contract", func(s *benchmarks.State) *benchmarks.Call {
+			return newSelfCall(s, core.BuiltInFunctionUnSetDCTRole, fungible, []byte(core.DCTRoleLocalMint))
+		}},
+		{"DCTPause/caller not the system smart contract", func(s *benchmarks.State) *benchmarks.Call {
+			return newCall(core.BuiltInFunctionDCTPause, s.Address(sender), vmcommon.SystemAccountAddress, fungible)
+		}},
+		{"DCTFreeze/caller not the system smart contract", func(s *benchmarks.State) *benchmarks.Call {
+			return newTransferCall(s, core.BuiltInFunctionDCTFreeze, fungible)
+		}},
+		{"DCTWipe/caller not the system smart contract", func(s *benchmarks.State) *benchmarks.Call {
+			return newTransferCall(s, core.BuiltInFunctionDCTWipe, fungible)
+		}},
+
+		// account management
+		{"ChangeOwnerAddress/invalid address", func(s *benchmarks.State) *benchmarks.Call {
+			return newSelfCall(s, core.BuiltInFunctionChangeOwnerAddress, []byte("short"))
+		}},
+		{"ClaimDeveloperRewards/not the owner", func(s *benchmarks.State) *benchmarks.Call {
+			return newTransferCall(s, core.BuiltInFunctionClaimDeveloperRewards)
+		}},
+
+		{"unknown function", func(s *benchmarks.State) *benchmarks.Call {
+			return newSelfCall(s, "unknownFunction")
+		}},
+	}
+}
@@ -0,0 +1,9 @@
+package goldens
+
+import "errors"
+
+// ErrCaseDidNotFail signals that a case expected to exercise an error path was processed successfully
+var ErrCaseDidNotFail = errors.New("case did not fail")
+
+// ErrDuplicatedCaseName signals that two cases share the same name, so their records could not be told apart
+var ErrDuplicatedCaseName = errors.New("duplicated case name")
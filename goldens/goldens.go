@@ -0,0 +1,106 @@
+// Package goldens exercises the documented error paths of the built-in functions against fixed inputs and records the
+// resulting error codes and return messages. The hosts rely on the codes and the messages instead of the errors, so
+// the records are compared against a golden file and any change of them has to be explicit
+package goldens
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/benchmarks"
+)
+
+const (
+	numAccounts     = 2
+	initialBalance  = 100
+	gasProvided     = 1_000_000
+	recordSeparator = "\t"
+)
+
+// Case is a built-in function call expected to fail. The call is created on a fresh benchmarks state, in which every
+// account holds a balance of the fungible token and the local roles on both the fungible token and the NFT collection
+type Case struct {
+	Name       string
+	CreateCall func(state *benchmarks.State) *benchmarks.Call
+}
+
+// Record holds the error returned by the built-in function for a case, as presented to the user
+type Record struct {
+	Case          string
+	Code          int
+	Category      vmcommon.ErrorCategory
+	ReturnCode    vmcommon.ReturnCode
+	ReturnMessage string
+}
+
+// String returns the record as a line of the golden file
+func (r Record) String() string {
+	return strings.Join([]string{
+		r.Case,
+		fmt.Sprintf("%d", r.Code),
+		r.Category.String(),
+		r.ReturnCode.String(),
+		r.ReturnMessage,
+	}, recordSeparator)
+}
+
+// Run processes each case on a fresh state and records the returned error. It fails if any case is processed
+// successfully, as it would not exercise an error path anymore
+func Run(cases []Case, enableEpochsHandler vmcommon.EnableEpochsHandler) ([]Record, error) {
+	records := make([]Record, 0, len(cases))
+	names := make(map[string]struct{}, len(cases))
+	for _, c := range cases {
+		_, exists := names[c.Name]
+		if exists {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicatedCaseName, c.Name)
+		}
+		names[c.Name] = struct{}{}
+
+		record, err := runCase(c, enableEpochsHandler)
+		if err != nil {
+			return nil, err
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+func runCase(c Case, enableEpochsHandler vmcommon.EnableEpochsHandler) (Record, error) {
+	state, err := benchmarks.NewState(benchmarks.ArgsNewState{
+		NumAccounts:         numAccounts,
+		InitialBalance:      big.NewInt(initialBalance),
+		EnableEpochsHandler: enableEpochsHandler,
+	})
+	if err != nil {
+		return Record{}, err
+	}
+
+	_, err = state.Execute(c.CreateCall(state))
+	if err == nil {
+		return Record{}, fmt.Errorf("%w: %s", ErrCaseDidNotFail, c.Name)
+	}
+
+	return Record{
+		Case:          c.Name,
+		Code:          vmcommon.ErrorCode(err),
+		Category:      vmcommon.ErrorCategoryOf(err),
+		ReturnCode:    vmcommon.ReturnCodeFromError(err),
+		ReturnMessage: vmcommon.ReturnMessageFromError(err),
+	}, nil
+}
+
+// FormatRecords returns the content of the golden file holding the provided records, one per line in the order of
+// the cases
+func FormatRecords(records []Record) string {
+	builder := strings.Builder{}
+	for _, record := range records {
+		builder.WriteString(record.String())
+		builder.WriteString("\n")
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,87 @@
+package goldens
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/benchmarks"
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var updateGoldenFiles = flag.Bool("update", false, "update the golden files")
+
+func createEnableEpochsHandler() *mock.EnableEpochsHandlerStub {
+	return &mock.EnableEpochsHandlerStub{
+		IsGlobalMintBurnFlagEnabledField:                     true,
+		IsDCTTransferRoleFlagEnabledField:                    true,
+		IsBuiltInFunctionsFlagEnabledField:                   true,
+		IsCheckCorrectTokenIDForTransferRoleFlagEnabledField: true,
+		IsCheckFunctionArgumentFlagEnabledField:              true,
+		IsSaveToSystemAccountFlagEnabledField:                true,
+		IsCheckFrozenCollectionFlagEnabledField:              true,
+		IsSendAlwaysFlagEnabledField:                         true,
+		IsValueLengthCheckFlagEnabledField:                   true,
+		IsCheckTransferFlagEnabledField:                      true,
+		IsDCTNFTImprovementV1FlagEnabledField:                true,
+		IsFixOldTokenLiquidityEnabledField:                   true,
+	}
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	t.Run("successful case should err", func(t *testing.T) {
+		t.Parallel()
+
+		cases := []Case{{"transfer", func(s *benchmarks.State) *benchmarks.Call {
+			return s.TransferCall(sender, receiver, 1)
+		}}}
+		records, err := Run(cases, createEnableEpochsHandler())
+		assert.ErrorIs(t, err, ErrCaseDidNotFail)
+		assert.Nil(t, records)
+	})
+	t.Run("duplicated case name should err", func(t *testing.T) {
+		t.Parallel()
+
+		cases := DefaultCases()
+		cases = append(cases, cases[0])
+		records, err := Run(cases, createEnableEpochsHandler())
+		assert.ErrorIs(t, err, ErrDuplicatedCaseName)
+		assert.Nil(t, records)
+	})
+}
+
+func TestRecord_String(t *testing.T) {
+	t.Parallel()
+
+	record := Record{
+		Case:          "case",
+		Code:          1005,
+		Category:      vmcommon.ErrorCategoryValidation,
+		ReturnCode:    vmcommon.UserError,
+		ReturnMessage: "invalid arguments",
+	}
+	assert.Equal(t, "case\t1005\tvalidation\tuser error\tinvalid arguments", record.String())
+}
+
+func TestDefaultCases_ShouldMatchTheGoldenFile(t *testing.T) {
+	t.Parallel()
+
+	records, err := Run(DefaultCases(), createEnableEpochsHandler())
+	require.Nil(t, err)
+	actual := FormatRecords(records)
+
+	goldenFile := filepath.Join("testdata", "errors.golden")
+	if *updateGoldenFiles {
+		require.Nil(t, os.WriteFile(goldenFile, []byte(actual), 0644))
+	}
+
+	expected, err := os.ReadFile(goldenFile)
+	require.Nil(t, err)
+	assert.Equal(t, string(expected), actual, "the errors of the built-in functions changed, run the test with -update only if the change is intended")
+}
@@ -0,0 +1,6 @@
+package hashing
+
+import "errors"
+
+// ErrUnknownHasher signals that the provided hasher name is not known
+var ErrUnknownHasher = errors.New("unknown hasher")
@@ -0,0 +1,68 @@
+package hashing
+
+import (
+	"fmt"
+
+	"github.com/Reshusk23/sr-me-core/hashing/blake2b"
+	"github.com/Reshusk23/sr-me-core/hashing/keccak"
+	"github.com/Reshusk23/sr-me-core/hashing/sha256"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+)
+
+const (
+	// Keccak is the name of the keccak 256 hasher
+	Keccak = "keccak"
+	// Blake2b is the name of the blake2b 256 hasher
+	Blake2b = "blake2b"
+	// Sha256 is the name of the sha 256 hasher
+	Sha256 = "sha256"
+)
+
+var (
+	keccakHasher  = keccak.NewKeccak()
+	blake2bHasher = blake2b.NewBlake2b()
+)
+
+// NewKeccak returns the keccak 256 hasher of sr-me-core
+func NewKeccak() vmcommon.Hasher {
+	return keccak.NewKeccak()
+}
+
+// NewBlake2b returns the blake2b 256 hasher of sr-me-core
+func NewBlake2b() vmcommon.Hasher {
+	return blake2b.NewBlake2b()
+}
+
+// NewBlake2bWithSize returns the blake2b hasher of sr-me-core computing hashes of the provided size in bytes
+func NewBlake2bWithSize(hashSize int) (vmcommon.Hasher, error) {
+	hasher, err := blake2b.NewBlake2bWithSize(hashSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return hasher, nil
+}
+
+// NewHasher returns the sr-me-core hasher with the provided name, so the hasher can be selected from a config file
+func NewHasher(name string) (vmcommon.Hasher, error) {
+	switch name {
+	case Keccak:
+		return NewKeccak(), nil
+	case Blake2b:
+		return NewBlake2b(), nil
+	case Sha256:
+		return sha256.NewSha256(), nil
+	}
+
+	return nil, fmt.Errorf("%w, %s", ErrUnknownHasher, name)
+}
+
+// ComputeKeccak returns the keccak 256 hash of the provided data
+func ComputeKeccak(data []byte) []byte {
+	return keccakHasher.Compute(string(data))
+}
+
+// ComputeBlake2b returns the blake2b 256 hash of the provided data
+func ComputeBlake2b(data []byte) []byte {
+	return blake2bHasher.Compute(string(data))
+}
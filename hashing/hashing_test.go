@@ -0,0 +1,52 @@
+package hashing
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/Reshusk23/sr-me-core/core/check"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	emptyKeccak  = "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470"
+	emptyBlake2b = "0e5751c026e543b2e8ab2eb06099daa1d1e5df47778f7787faab45cdf12fe3a8"
+)
+
+func TestNewHasher(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{Keccak, Blake2b, Sha256} {
+		hasher, err := NewHasher(name)
+		require.Nil(t, err)
+		assert.False(t, check.IfNil(hasher))
+		assert.Equal(t, 32, hasher.Size())
+	}
+
+	hasher, err := NewHasher("md5")
+	assert.True(t, check.IfNil(hasher))
+	assert.ErrorIs(t, err, ErrUnknownHasher)
+	assert.Contains(t, err.Error(), "md5")
+}
+
+func TestCompute(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, emptyKeccak, hex.EncodeToString(ComputeKeccak(nil)))
+	assert.Equal(t, emptyBlake2b, hex.EncodeToString(ComputeBlake2b(nil)))
+	assert.Equal(t, NewKeccak().Compute("data"), ComputeKeccak([]byte("data")))
+	assert.Equal(t, NewBlake2b().Compute("data"), ComputeBlake2b([]byte("data")))
+}
+
+func TestNewBlake2bWithSize(t *testing.T) {
+	t.Parallel()
+
+	hasher, err := NewBlake2bWithSize(16)
+	require.Nil(t, err)
+	assert.Equal(t, 16, hasher.Size())
+	assert.Equal(t, 16, len(hasher.Compute("data")))
+
+	_, err = NewBlake2bWithSize(-1)
+	assert.NotNil(t, err)
+}
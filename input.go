@@ -22,6 +22,8 @@ type VMInput struct {
 	// Before reaching the VM this value is subtracted from sender balance (CallerAddr)
 	// and to added to the smart contract balance.
 	// It is often, but not always zero in SC calls.
+	// It can be sent together with the tokens of a DCTTransfer, the recipient receiving both the value and the tokens.
+	// The NFT transfers, single or multiple, do not accept any value.
 	CallValue *big.Int
 
 	// CallType is the type of SmartContract call
@@ -67,6 +69,11 @@ type VMInput struct {
 	ReturnCallAfterError bool
 }
 
+// IsValueWithDCTTransfer returns true if the input moves native value together with DCT tokens
+func (input *VMInput) IsValueWithDCTTransfer() bool {
+	return input.CallValue != nil && input.CallValue.Sign() > 0 && len(input.DCTTransfers) > 0
+}
+
 // DCTTransfer defines the structure for and DCT / NFT transfer
 type DCTTransfer struct {
 	// DCTValue is the value (amount of tokens) transferred by the transaction.
@@ -114,6 +121,14 @@ type ContractCallInput struct {
 	// AllowInitFunction specifies whether calling the initialization method of
 	// the smart contract is allowed or not
 	AllowInitFunction bool
+
+	// RecordGasBreakdown requests the built-in functions to report in VMOutput.GasBreakdown what the consumed gas
+	// was paid for
+	RecordGasBreakdown bool
+
+	// CorrelationID identifies the call in the log lines and in the log entries produced by the built-in functions,
+	// so the activity of one transaction can be followed across functions. It is usually the transaction hash
+	CorrelationID string
 }
 
 // ParsedDCTTransfers defines the struct for the parsed dct transfers
@@ -0,0 +1,20 @@
+package vmcommon
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVMInput_IsValueWithDCTTransfer(t *testing.T) {
+	t.Parallel()
+
+	transfers := []*DCTTransfer{{DCTValue: big.NewInt(1), DCTTokenName: []byte("TKN")}}
+
+	assert.False(t, (&VMInput{}).IsValueWithDCTTransfer())
+	assert.False(t, (&VMInput{CallValue: big.NewInt(1)}).IsValueWithDCTTransfer())
+	assert.False(t, (&VMInput{CallValue: big.NewInt(0), DCTTransfers: transfers}).IsValueWithDCTTransfer())
+	assert.False(t, (&VMInput{CallValue: big.NewInt(-1), DCTTransfers: transfers}).IsValueWithDCTTransfer())
+	assert.True(t, (&VMInput{CallValue: big.NewInt(1), DCTTransfers: transfers}).IsValueWithDCTTransfer())
+}
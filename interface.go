@@ -1,6 +1,7 @@
 package vmcommon
 
 import (
+	"context"
 	"math/big"
 
 	"github.com/Reshusk23/sr-me-core/core/closing"
@@ -176,6 +177,17 @@ type AccountDataHandler interface {
 	IsInterfaceNil() bool
 }
 
+// LeafHandler is called for each key-value pair found while iterating the data of an account
+type LeafHandler func(key []byte, value []byte) error
+
+// AccountDataIterator is implemented by the account data handlers able to stream all the stored key-value pairs
+type AccountDataIterator interface {
+	// GetAllLeaves calls the handler for every stored key starting with the provided prefix. The iteration stops
+	// on the first handler error, which is returned, or when the context is done, returning the context error
+	GetAllLeaves(ctx context.Context, prefix []byte, handler LeafHandler) error
+	IsInterfaceNil() bool
+}
+
 // AccountHandler models a state account, which can journalize and revert
 // It knows about code and data, as data structures not hashes
 type AccountHandler interface {
@@ -196,6 +208,8 @@ type Marshalizer interface {
 type DCTGlobalSettingsHandler interface {
 	IsPaused(dctTokenKey []byte) bool
 	IsLimitedTransfer(dctTokenKey []byte) bool
+	IsNFTCreateStopped(dctTokenKey []byte) bool
+	GetCollectionConfig(tokenID []byte) CollectionConfig
 	IsInterfaceNil() bool
 }
 
@@ -205,6 +219,17 @@ type ExtendedDCTGlobalSettingsHandler interface {
 	IsLimitedTransfer(dctTokenKey []byte) bool
 	IsBurnForAll(dctTokenKey []byte) bool
 	IsSenderOrDestinationWithTransferRole(sender, destination, tokenID []byte) bool
+	IsDormantSweepAllowed(dctTokenKey []byte) bool
+	IsMultiSigManaged(dctTokenKey []byte) bool
+	IsSoulbound(dctTokenKey []byte) bool
+	IsNFTCreateStopped(dctTokenKey []byte) bool
+	GetCollectionConfig(tokenID []byte) CollectionConfig
+	IsInterfaceNil() bool
+}
+
+// MetaDCTChecker tells if a collection holds meta dct tokens
+type MetaDCTChecker interface {
+	IsMetaDCT(tokenID []byte) bool
 	IsInterfaceNil() bool
 }
 
@@ -246,7 +271,9 @@ type AccountsAdapter interface {
 	IsInterfaceNil() bool
 }
 
-// BuiltinFunction defines the methods for the built-in protocol smart contract functions
+// BuiltinFunction defines the methods for the built-in protocol smart contract functions. The VMOutput returned by
+// ProcessBuiltinFunction is owned by the caller, who may give it back with ReleaseVMOutput once consumed so its
+// allocations are reused. Releasing is optional, but a released output must not be used anymore
 type BuiltinFunction interface {
 	ProcessBuiltinFunction(acntSnd, acntDst UserAccountHandler, vmInput *ContractCallInput) (*VMOutput, error)
 	SetNewGasConfig(gasCost *GasCost)
@@ -254,6 +281,23 @@ type BuiltinFunction interface {
 	IsInterfaceNil() bool
 }
 
+// BuiltinFunctionV2 defines the methods for the built-in protocol smart contract functions which stop processing when
+// the provided context is cancelled or its deadline is exceeded. The returned VMOutput is owned by the caller, as for
+// BuiltinFunction
+type BuiltinFunctionV2 interface {
+	ProcessBuiltinFunction(ctx context.Context, acntSnd, acntDst UserAccountHandler, vmInput *ContractCallInput) (*VMOutput, error)
+	SetNewGasConfig(gasCost *GasCost)
+	IsActive() bool
+	IsInterfaceNil() bool
+}
+
+// StatelessExecutableChecker defines a built-in function able to validate a call without reading or changing any
+// state, so the transactions can be rejected cheaply before they are included in a block. A nil error does not
+// guarantee the processing succeeds
+type StatelessExecutableChecker interface {
+	CheckIsExecutable(vmInput *ContractCallInput) error
+}
+
 // BuiltInFunctionContainer defines the methods for the built-in protocol container
 type BuiltInFunctionContainer interface {
 	Get(key string) (BuiltinFunction, error)
@@ -265,18 +309,314 @@ type BuiltInFunctionContainer interface {
 	IsInterfaceNil() bool
 }
 
+// HistoricalReplayContainer defines a built-in functions container able to execute the functions as of a past epoch,
+// all the flags and the current epoch resolving against the provided epoch for the duration of the call. A replayed
+// call runs alone, the other calls of the container waiting for it to finish
+type HistoricalReplayContainer interface {
+	ProcessBuiltinFunctionAsOfEpoch(key string, epoch uint32, acntSnd, acntDst UserAccountHandler, vmInput *ContractCallInput) (*VMOutput, error)
+	IsInterfaceNil() bool
+}
+
+// GasConfigurableContainer defines a built-in functions container able to change the gas costs of all its functions
+// at once, without any call in progress observing a mix of old and new costs
+type GasConfigurableContainer interface {
+	ApplyGasConfig(gasCost *GasCost)
+	IsInterfaceNil() bool
+}
+
+// StatelessCheckContainer defines a built-in functions container able to validate a call without reading or changing
+// any state. The functions which are not a StatelessExecutableChecker accept all the calls
+type StatelessCheckContainer interface {
+	CheckIsExecutable(key string, vmInput *ContractCallInput) error
+	IsInterfaceNil() bool
+}
+
+// BatchProcessingContainer defines a built-in functions container able to execute a batch of calls atomically, the
+// state changes of all the calls being reverted if any of them fails. The returned VMOutputs are owned by the caller,
+// who may release each of them with ReleaseVMOutput
+type BatchProcessingContainer interface {
+	ProcessBuiltinFunctions(calls []*ContractCallInput) ([]*VMOutput, error)
+	IsInterfaceNil() bool
+}
+
+// CallValuePolicyContainer defines a built-in functions container enforcing the call value policies declared for
+// its functions before dispatching the calls
+type CallValuePolicyContainer interface {
+	SetCallValuePolicy(key string, policy CallValuePolicy)
+	CallValuePolicy(key string) (CallValuePolicy, bool)
+	IsInterfaceNil() bool
+}
+
+// EnableEpochsHandlerFactory creates enable epochs handlers resolving all the flags as of a provided epoch
+type EnableEpochsHandlerFactory interface {
+	CreateEnableEpochsHandler(epoch uint32) (EnableEpochsHandler, error)
+	IsInterfaceNil() bool
+}
+
 // EpochSubscriberHandler defines the behavior of a component that can be notified if a new epoch was confirmed
 type EpochSubscriberHandler interface {
 	EpochConfirmed(epoch uint32, timestamp uint64)
 	IsInterfaceNil() bool
 }
 
+// EpochConfirmedHandler defines the built-in functions reacting to the epoch changes. They are notified by the
+// container holding them, so they do not need to subscribe to the epoch notifier themselves
+type EpochConfirmedHandler interface {
+	EpochConfirmed(epoch uint32, timestamp uint64)
+	IsInterfaceNil() bool
+}
+
 // EpochNotifier can notify upon an epoch change and provide the current epoch
 type EpochNotifier interface {
 	RegisterNotifyHandler(handler EpochSubscriberHandler)
 	IsInterfaceNil() bool
 }
 
+// RoundSubscriberHandler defines the behavior of a component that can be notified if a new round was started
+type RoundSubscriberHandler interface {
+	RoundConfirmed(round uint64, timestamp uint64)
+	IsInterfaceNil() bool
+}
+
+// RoundNotifier can notify upon a round change
+type RoundNotifier interface {
+	RegisterRoundHandler(handler RoundSubscriberHandler)
+	IsInterfaceNil() bool
+}
+
+// LatestNonceCache keeps the latest created NFT nonce for (account, token) pairs so that consecutive creates
+// do not have to read it from the account data trie. It has to be bound to the accounts adapter used by the built-in
+// functions, so that it is emptied whenever the account state is reverted
+type LatestNonceCache interface {
+	Get(address []byte, tokenID []byte) (uint64, bool)
+	Put(address []byte, tokenID []byte, nonce uint64)
+	Remove(address []byte, tokenID []byte)
+	Clear()
+	IsInterfaceNil() bool
+}
+
+// AcceptLatestNonceCache defines the functions which accept a latest nonce cache
+type AcceptLatestNonceCache interface {
+	SetLatestNonceCache(nonceCache LatestNonceCache) error
+	IsInterfaceNil() bool
+}
+
+// AccountCache keeps the accounts loaded by the built-in functions so that repeated calls on behalf of the same
+// account do not have to load it again. It has to be bound to the accounts adapter used by the built-in functions, so
+// that it is updated on each account change, commit and revert
+type AccountCache interface {
+	Get(address []byte) (UserAccountHandler, bool)
+	Put(account UserAccountHandler)
+	Remove(address []byte)
+	Clear()
+	IsInterfaceNil() bool
+}
+
+// AcceptAccountCache defines the functions which accept an account cache
+type AcceptAccountCache interface {
+	SetAccountCache(accountCache AccountCache) error
+	IsInterfaceNil() bool
+}
+
+// QuotaHandler tracks the usage of the built-in functions within the current block. CheckQuota rejects the calls
+// which would exceed the quota of the function and RecordUsage accounts for the successful ones
+type QuotaHandler interface {
+	CheckQuota(function string, gasProvided uint64) error
+	RecordUsage(function string, gasUsed uint64)
+	IsInterfaceNil() bool
+}
+
+// StorageUsageTracker meters the bytes the built-in functions store on behalf of the accounts. TrackStorageUsage
+// accounts for the change of the bytes stored by the account, a negative change releasing bytes, and rejects the
+// changes which would exceed the limit of the account
+type StorageUsageTracker interface {
+	TrackStorageUsage(account UserAccountHandler, bytesDelta int64) error
+	IsInterfaceNil() bool
+}
+
+// AcceptStorageUsageTracker defines the functions which accept a storage usage tracker
+type AcceptStorageUsageTracker interface {
+	SetStorageUsageTracker(storageUsageTracker StorageUsageTracker) error
+	IsInterfaceNil() bool
+}
+
+// AddressClassifier decides the type and the shard of an address
+type AddressClassifier interface {
+	IsSmartContract(address []byte) bool
+	IsSystemSC(address []byte) bool
+	IsDCTSystemSC(address []byte) bool
+	IsUserAddress(address []byte) bool
+	IsEmpty(address []byte) bool
+	ShardOf(address []byte, numOfShards uint32) uint32
+	IsInterfaceNil() bool
+}
+
+// AcceptAddressLength defines the functions which accept the length of the addresses they validate
+type AcceptAddressLength interface {
+	SetAddressLength(addressLength int) error
+	IsInterfaceNil() bool
+}
+
+// AcceptSystemAddresses defines the functions which accept the addresses of the system accounts they depend on
+type AcceptSystemAddresses interface {
+	SetSystemAddresses(systemAddresses SystemAddresses) error
+	IsInterfaceNil() bool
+}
+
+// AcceptAddressClassifier defines the functions which accept an address classifier
+type AcceptAddressClassifier interface {
+	SetAddressClassifier(addressClassifier AddressClassifier) error
+	IsInterfaceNil() bool
+}
+
+// FreezeAccountHandler tells whether an account is frozen as a whole, such an account not being able to move any asset
+type FreezeAccountHandler interface {
+	IsAccountFrozen(address []byte) bool
+	IsInterfaceNil() bool
+}
+
+// AcceptFreezeAccountHandler defines the functions which accept a freeze account handler
+type AcceptFreezeAccountHandler interface {
+	SetFreezeAccountHandler(freezeAccountHandler FreezeAccountHandler) error
+	IsInterfaceNil() bool
+}
+
+// TransferInterceptor lets the host veto the transfers before any balance is changed, e.g. to plug compliance checks.
+// PreTransfer is called on the sender shard for every token moved by the transfer built-in functions, the nonce being
+// 0 for the fungible tokens, and any returned error aborts the transfer
+type TransferInterceptor interface {
+	PreTransfer(sender []byte, receiver []byte, token []byte, nonce uint64, amount *big.Int) error
+	IsInterfaceNil() bool
+}
+
+// AcceptTransferInterceptor defines the functions which accept a transfer interceptor
+type AcceptTransferInterceptor interface {
+	SetTransferInterceptor(transferInterceptor TransferInterceptor) error
+	IsInterfaceNil() bool
+}
+
+// MultiSigVerifier checks the M-of-N signatures required by the management operations of multisig managed tokens.
+// The message is made of the hex encoded chain ID, the function name, the hex encoded recipient, the hex encoded
+// nonce of the token and recipient pair and the hex encoded arguments, joined by @
+type MultiSigVerifier interface {
+	VerifySignatures(tokenID []byte, message []byte, signatures [][]byte) error
+	IsInterfaceNil() bool
+}
+
+// AcceptMultiSigVerifier defines the functions which accept a multisig verifier
+type AcceptMultiSigVerifier interface {
+	SetMultiSigVerifier(multiSigVerifier MultiSigVerifier) error
+	IsInterfaceNil() bool
+}
+
+// ProofVerifier validates the external chain proofs backing the bridge operations. The operation is the name of
+// the bridge built-in function and the address is the bridge account minting or burning the tokens. The returned
+// identifier is unique for each external chain operation (e.g. the deposit nonce), so a proof can be consumed once
+type ProofVerifier interface {
+	VerifyProof(operation string, tokenID []byte, amount *big.Int, address []byte, proof []byte) ([]byte, error)
+	IsInterfaceNil() bool
+}
+
+// AcceptProofVerifier defines the functions which accept a proof verifier
+type AcceptProofVerifier interface {
+	SetProofVerifier(proofVerifier ProofVerifier) error
+	IsInterfaceNil() bool
+}
+
+// StateProofProvider generates the Merkle proofs of the values stored in the data tries of the accounts, so the light
+// clients may verify the state left by the built-in function calls. The proof is generated against the provided state
+// root hash, hence the host has to commit the state before requesting it
+type StateProofProvider interface {
+	GetProof(rootHash []byte, address []byte, key []byte) ([][]byte, error)
+	IsInterfaceNil() bool
+}
+
+// AliasResolver resolves the fixed length aliases registered for addresses, so the data fields may reference the
+// receivers by alias instead of by full address
+type AliasResolver interface {
+	IsAlias(value []byte) bool
+	ResolveAlias(alias []byte) ([]byte, error)
+	IsInterfaceNil() bool
+}
+
+// AcceptAliasResolver defines the functions which accept an alias resolver
+type AcceptAliasResolver interface {
+	SetAliasResolver(aliasResolver AliasResolver) error
+	IsInterfaceNil() bool
+}
+
+// Hasher computes the digest of the provided data
+type Hasher interface {
+	Compute(string) []byte
+	Size() int
+	IsInterfaceNil() bool
+}
+
+// Compressor compresses the payloads stored by the built-in functions, e.g. with snappy or zstd
+type Compressor interface {
+	Compress(data []byte) ([]byte, error)
+	Decompress(data []byte) ([]byte, error)
+	IsInterfaceNil() bool
+}
+
+// StoredMetaDataSizeHandler reports the number of bytes the metadata of an NFT nonce occupies on the system account,
+// after the compression, so the hosts can charge the gas for the stored size
+type StoredMetaDataSizeHandler interface {
+	GetStoredMetaDataSize(dctTokenKey []byte, nonce uint64) (int, error)
+	IsInterfaceNil() bool
+}
+
+// AcceptHasher defines the functions which accept a hasher
+type AcceptHasher interface {
+	SetHasher(hasher Hasher) error
+	IsInterfaceNil() bool
+}
+
+// ProtectedKeysHandler decides which storage keys can be written only by the built-in functions
+type ProtectedKeysHandler interface {
+	IsProtectedKey(key []byte) bool
+	IsInterfaceNil() bool
+}
+
+// AcceptProtectedKeysHandler defines the functions which accept a protected keys handler
+type AcceptProtectedKeysHandler interface {
+	SetProtectedKeysHandler(protectedKeysHandler ProtectedKeysHandler) error
+	IsInterfaceNil() bool
+}
+
+// AccountActivityHandler provides the number of epochs an account had been inactive for as of the provided epoch, so
+// the answer does not depend on when a call is processed or replayed
+type AccountActivityHandler interface {
+	GetInactiveEpochs(address []byte, epoch uint32) (uint32, error)
+	IsInterfaceNil() bool
+}
+
+// AcceptAccountActivityHandler defines the functions which accept an account activity handler
+type AcceptAccountActivityHandler interface {
+	SetAccountActivityHandler(accountActivityHandler AccountActivityHandler) error
+	IsInterfaceNil() bool
+}
+
+// Metrics receives the counters and histograms reported by the built-in functions
+type Metrics interface {
+	IncrementCounter(name string, labels MetricLabels)
+	ObserveHistogram(name string, value float64, labels MetricLabels)
+	IsInterfaceNil() bool
+}
+
+// LogPublisher receives the log entries of the successful built-in function calls
+type LogPublisher interface {
+	PublishLogs(function string, logs []*LogEntry)
+	IsInterfaceNil() bool
+}
+
+// FunctionResolver maps the function names found in data fields, such as legacy aliases, deprecated names or names
+// differing only in case, to the canonical built-in function identifiers
+type FunctionResolver interface {
+	ResolveFunction(name string) (string, bool)
+	IsInterfaceNil() bool
+}
+
 // DCTTransferParser can parse single and multi DCT / NFT transfers
 type DCTTransferParser interface {
 	ParseDCTTransfers(sndAddr []byte, rcvAddr []byte, function string, args [][]byte) (*ParsedDCTTransfers, error)
@@ -292,6 +632,10 @@ type DCTNFTStorageHandler interface {
 	WasAlreadySentToDestinationShardAndUpdateState(tickerID []byte, nonce uint64, dstAddress []byte) (bool, error)
 	SaveNFTMetaDataToSystemAccount(tx data.TransactionHandler) error
 	AddToLiquiditySystemAcc(dctTokenKey []byte, nonce uint64, transferValue *big.Int) error
+	SaveNFTMaxSupply(dctTokenKey []byte, nonce uint64, maxSupply *big.Int, initialQuantity *big.Int) error
+	AddToNFTMintedSupply(dctTokenKey []byte, nonce uint64, value *big.Int) error
+	SaveNFTRoyaltiesDenominator(dctTokenKey []byte, nonce uint64, royaltiesDenominator uint32) error
+	GetNFTRoyaltiesDenominator(dctTokenKey []byte, nonce uint64) (uint32, error)
 	IsInterfaceNil() bool
 }
 
@@ -365,6 +709,33 @@ type EnableEpochsHandler interface {
 	IsMaxBlockchainHookCountersFlagEnabled() bool
 	IsWipeSingleNFTLiquidityDecreaseEnabled() bool
 	IsAlwaysSaveTokenMetaDataEnabled() bool
+	IsFreezeAccountFlagEnabled() bool
+	IsGlobalSettingsVersioningFlagEnabled() bool
+	IsCollectionConfigFlagEnabled() bool
+	IsDormantSweepFlagEnabled() bool
+	IsMultiSigManagementFlagEnabled() bool
+	IsSoulboundFlagEnabled() bool
+	IsNFTRentalFlagEnabled() bool
+	IsDCTTransferWithValueFlagEnabled() bool
+	IsDCTokenV2EncodingFlagEnabled() bool
+	IsNFTCreateOnBehalfFlagEnabled() bool
+	IsStopNFTCreateFlagEnabled() bool
+	IsMultiTransferGasRepriceFlagEnabled() bool
+	IsNFTMaxSupplyFlagEnabled() bool
+	IsNFTNonceRangesFlagEnabled() bool
+	IsDCTModifyCreatorFlagEnabled() bool
+	IsDCTSetNewURIsFlagEnabled() bool
+	IsDCTNFTUpdateFlagEnabled() bool
+	IsDCTTransferAndLockFlagEnabled() bool
+	IsMetaDCTFlagEnabled() bool
+	IsWrapNativeFlagEnabled() bool
+	IsDCTBridgeFlagEnabled() bool
+	IsDCTAllowanceFlagEnabled() bool
+	IsNFTCreateNotifyFlagEnabled() bool
+	IsNFTContentHashFlagEnabled() bool
+	IsDCTSelfTransferFlagEnabled() bool
+	IsDCTTransferCallbackGasLockFlagEnabled() bool
+	IsSafeArgumentConversionFlagEnabled() bool
 
 	MultiDCTTransferAsyncCallBackEnableEpoch() uint32
 	FixOOGReturnCodeEnableEpoch() uint32
@@ -377,5 +748,7 @@ type EnableEpochsHandler interface {
 	CheckExecuteReadOnlyEnableEpoch() uint32
 	StorageAPICostOptimizationEnableEpoch() uint32
 
+	GetCurrentEpoch() uint32
+
 	IsInterfaceNil() bool
 }
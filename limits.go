@@ -0,0 +1,15 @@
+package vmcommon
+
+// LimitsConfig defines the hard limits on the input of the built-in functions, which are enforced before any gas is
+// computed. A zero value disables the corresponding limit
+type LimitsConfig struct {
+	MaxNumArguments uint32
+	MaxArgumentSize uint32
+	MaxDataSize     uint64
+	MaxNumURIs      uint32
+}
+
+// HasLimits returns true if at least one of the limits is set
+func (config LimitsConfig) HasLimits() bool {
+	return config.MaxNumArguments > 0 || config.MaxArgumentSize > 0 || config.MaxDataSize > 0 || config.MaxNumURIs > 0
+}
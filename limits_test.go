@@ -0,0 +1,17 @@
+package vmcommon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimitsConfig_HasLimits(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, LimitsConfig{}.HasLimits())
+	assert.True(t, LimitsConfig{MaxNumArguments: 1}.HasLimits())
+	assert.True(t, LimitsConfig{MaxArgumentSize: 1}.HasLimits())
+	assert.True(t, LimitsConfig{MaxDataSize: 1}.HasLimits())
+	assert.True(t, LimitsConfig{MaxNumURIs: 1}.HasLimits())
+}
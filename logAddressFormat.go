@@ -0,0 +1,24 @@
+package vmcommon
+
+// LogAddressFormat selects how the built-in functions write the addresses carried by the topics of their log entries
+type LogAddressFormat uint8
+
+const (
+	// LogAddressRaw keeps the raw addresses in the topics
+	LogAddressRaw LogAddressFormat = iota
+	// LogAddressEncoded replaces the raw addresses of the topics with their encoded form, e.g. bech32
+	LogAddressEncoded
+	// LogAddressRawAndEncoded keeps the raw addresses in the topics and sets the encoded topics of the log entries,
+	// holding the addresses in their encoded form
+	LogAddressRawAndEncoded
+)
+
+// IsValid returns true if the format is one of the defined ones
+func (format LogAddressFormat) IsValid() bool {
+	return format <= LogAddressRawAndEncoded
+}
+
+// HasEncodedAddresses returns true if the log entries hold the encoded form of the addresses
+func (format LogAddressFormat) HasEncodedAddresses() bool {
+	return format == LogAddressEncoded || format == LogAddressRawAndEncoded
+}
@@ -0,0 +1,21 @@
+package vmcommon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogAddressFormat(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, LogAddressRaw.IsValid())
+	assert.True(t, LogAddressEncoded.IsValid())
+	assert.True(t, LogAddressRawAndEncoded.IsValid())
+	assert.False(t, LogAddressFormat(3).IsValid())
+
+	assert.False(t, LogAddressRaw.HasEncodedAddresses())
+	assert.True(t, LogAddressEncoded.HasEncodedAddresses())
+	assert.True(t, LogAddressRawAndEncoded.HasEncodedAddresses())
+	assert.False(t, LogAddressFormat(3).HasEncodedAddresses())
+}
@@ -0,0 +1,9 @@
+package logsubscriber
+
+import "errors"
+
+// ErrInvalidBufferSize signals that a negative subscription buffer size has been provided
+var ErrInvalidBufferSize = errors.New("invalid buffer size")
+
+// ErrSubscriberClosed signals that a subscription was requested after the log subscriber was closed
+var ErrSubscriberClosed = errors.New("log subscriber closed")
@@ -0,0 +1,69 @@
+package logsubscriber
+
+import (
+	"bytes"
+
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+)
+
+const tokenTopicIndex = 0
+
+// Filter selects the log entries delivered to a subscription. Each list restricts the entries to the ones holding one
+// of its values, an empty list matching all the entries
+type Filter struct {
+	// Identifiers holds the event identifiers, such as the names of the built-in functions
+	Identifiers [][]byte
+	// Tokens holds the token identifiers, matched against the first topic of the entries
+	Tokens [][]byte
+	// Addresses holds the addresses, matched against the address and the topics of the entries
+	Addresses [][]byte
+}
+
+// Matches returns true if the log entry passes all the lists of the filter
+func (f Filter) Matches(logEntry *vmcommon.LogEntry) bool {
+	if logEntry == nil {
+		return false
+	}
+	if len(f.Identifiers) > 0 && !containsValue(f.Identifiers, logEntry.Identifier) {
+		return false
+	}
+	if len(f.Tokens) > 0 && !f.matchesToken(logEntry) {
+		return false
+	}
+	if len(f.Addresses) > 0 && !f.matchesAddress(logEntry) {
+		return false
+	}
+
+	return true
+}
+
+func (f Filter) matchesToken(logEntry *vmcommon.LogEntry) bool {
+	if len(logEntry.Topics) <= tokenTopicIndex {
+		return false
+	}
+
+	return containsValue(f.Tokens, logEntry.Topics[tokenTopicIndex])
+}
+
+func (f Filter) matchesAddress(logEntry *vmcommon.LogEntry) bool {
+	if containsValue(f.Addresses, logEntry.Address) {
+		return true
+	}
+	for _, topic := range logEntry.Topics {
+		if containsValue(f.Addresses, topic) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsValue(values [][]byte, value []byte) bool {
+	for _, v := range values {
+		if bytes.Equal(v, value) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,39 @@
+package logsubscriber
+
+import (
+	"testing"
+
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilter_Matches(t *testing.T) {
+	t.Parallel()
+
+	logEntry := &vmcommon.LogEntry{
+		Identifier: []byte("DCTTransfer"),
+		Address:    []byte("sender"),
+		Topics:     [][]byte{[]byte("TKN-abcdef"), {}, {10}, []byte("receiver")},
+	}
+
+	assert.False(t, Filter{}.Matches(nil))
+	assert.True(t, Filter{}.Matches(logEntry))
+
+	assert.True(t, Filter{Identifiers: [][]byte{[]byte("DCTBurn"), []byte("DCTTransfer")}}.Matches(logEntry))
+	assert.False(t, Filter{Identifiers: [][]byte{[]byte("DCTBurn")}}.Matches(logEntry))
+
+	assert.True(t, Filter{Tokens: [][]byte{[]byte("TKN-abcdef")}}.Matches(logEntry))
+	assert.False(t, Filter{Tokens: [][]byte{[]byte("OTHER-abcdef")}}.Matches(logEntry))
+	assert.False(t, Filter{Tokens: [][]byte{[]byte("TKN-abcdef")}}.Matches(&vmcommon.LogEntry{}))
+
+	assert.True(t, Filter{Addresses: [][]byte{[]byte("sender")}}.Matches(logEntry))
+	assert.True(t, Filter{Addresses: [][]byte{[]byte("receiver")}}.Matches(logEntry))
+	assert.False(t, Filter{Addresses: [][]byte{[]byte("other")}}.Matches(logEntry))
+
+	filter := Filter{
+		Identifiers: [][]byte{[]byte("DCTTransfer")},
+		Tokens:      [][]byte{[]byte("TKN-abcdef")},
+		Addresses:   [][]byte{[]byte("other")},
+	}
+	assert.False(t, filter.Matches(logEntry))
+}
@@ -0,0 +1,202 @@
+package logsubscriber
+
+import (
+	"sync"
+	"sync/atomic"
+
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+)
+
+const defaultBufferSize = 1024
+
+// Event is a log entry published by a built-in function
+type Event struct {
+	Function string
+	LogEntry *vmcommon.LogEntry
+}
+
+// ArgsLogSubscriber holds the arguments needed to create a log subscriber
+type ArgsLogSubscriber struct {
+	// BufferSize is the number of events each subscription buffers, 0 meaning the default size
+	BufferSize int
+}
+
+// logSubscriber receives the log entries published by the built-in functions and delivers them to the subscriptions
+// whose filter they match. Publishing never blocks: the events not fitting the buffer of a subscription are dropped
+type logSubscriber struct {
+	mut           sync.RWMutex
+	bufferSize    int
+	subscriptions map[uint64]*Subscription
+	lastID        uint64
+	closed        bool
+}
+
+// NewLogSubscriber creates a new log subscriber
+func NewLogSubscriber(args ArgsLogSubscriber) (*logSubscriber, error) {
+	if args.BufferSize < 0 {
+		return nil, ErrInvalidBufferSize
+	}
+
+	bufferSize := args.BufferSize
+	if bufferSize == 0 {
+		bufferSize = defaultBufferSize
+	}
+
+	return &logSubscriber{
+		bufferSize:    bufferSize,
+		subscriptions: make(map[uint64]*Subscription),
+	}, nil
+}
+
+// Subscribe returns a new subscription receiving the events matching the provided filter
+func (ls *logSubscriber) Subscribe(filter Filter) (*Subscription, error) {
+	ls.mut.Lock()
+	defer ls.mut.Unlock()
+
+	if ls.closed {
+		return nil, ErrSubscriberClosed
+	}
+
+	ls.lastID++
+	subscription := &Subscription{
+		id:         ls.lastID,
+		filter:     filter,
+		events:     make(chan Event, ls.bufferSize),
+		subscriber: ls,
+	}
+	ls.subscriptions[subscription.id] = subscription
+
+	return subscription, nil
+}
+
+// PublishLogs delivers the log entries of a built-in function call to the matching subscriptions. Each delivered
+// entry is a copy, so the subscribers never share it with the caller
+func (ls *logSubscriber) PublishLogs(function string, logs []*vmcommon.LogEntry) {
+	ls.mut.RLock()
+	defer ls.mut.RUnlock()
+
+	if len(ls.subscriptions) == 0 {
+		return
+	}
+
+	for _, logEntry := range logs {
+		var event *Event
+		for _, subscription := range ls.subscriptions {
+			if !subscription.filter.Matches(logEntry) {
+				continue
+			}
+			if event == nil {
+				event = &Event{Function: function, LogEntry: copyLogEntry(logEntry)}
+			}
+
+			subscription.deliver(*event)
+		}
+	}
+}
+
+// NumSubscriptions returns the number of active subscriptions
+func (ls *logSubscriber) NumSubscriptions() int {
+	ls.mut.RLock()
+	defer ls.mut.RUnlock()
+
+	return len(ls.subscriptions)
+}
+
+// Close ends all the subscriptions and rejects the new ones
+func (ls *logSubscriber) Close() {
+	ls.mut.Lock()
+	defer ls.mut.Unlock()
+
+	ls.closed = true
+	for id, subscription := range ls.subscriptions {
+		close(subscription.events)
+		delete(ls.subscriptions, id)
+	}
+}
+
+func (ls *logSubscriber) unsubscribe(id uint64) {
+	ls.mut.Lock()
+	defer ls.mut.Unlock()
+
+	subscription, ok := ls.subscriptions[id]
+	if !ok {
+		return
+	}
+
+	close(subscription.events)
+	delete(ls.subscriptions, id)
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (ls *logSubscriber) IsInterfaceNil() bool {
+	return ls == nil
+}
+
+// Subscription delivers the events matching its filter on a buffered channel
+type Subscription struct {
+	id         uint64
+	filter     Filter
+	events     chan Event
+	dropped    uint64
+	subscriber *logSubscriber
+}
+
+// Events returns the channel on which the events are delivered. The channel is closed once the subscription ends
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Dropped returns the number of events dropped because the buffer of the subscription was full
+func (s *Subscription) Dropped() uint64 {
+	return atomic.LoadUint64(&s.dropped)
+}
+
+// Unsubscribe ends the subscription, closing its events channel. It can be called more than once
+func (s *Subscription) Unsubscribe() {
+	s.subscriber.unsubscribe(s.id)
+}
+
+func (s *Subscription) deliver(event Event) {
+	select {
+	case s.events <- event:
+	default:
+		atomic.AddUint64(&s.dropped, 1)
+	}
+}
+
+func copyLogEntry(logEntry *vmcommon.LogEntry) *vmcommon.LogEntry {
+	topics := make([][]byte, 0, len(logEntry.Topics))
+	for _, topic := range logEntry.Topics {
+		topics = append(topics, copyBytes(topic))
+	}
+
+	return &vmcommon.LogEntry{
+		Identifier:    copyBytes(logEntry.Identifier),
+		Address:       copyBytes(logEntry.Address),
+		Topics:        topics,
+		Data:          copyBytes(logEntry.Data),
+		CorrelationID: logEntry.CorrelationID,
+		EncodedTopics: copyTopics(logEntry.EncodedTopics),
+	}
+}
+
+func copyTopics(topics [][]byte) [][]byte {
+	if topics == nil {
+		return nil
+	}
+
+	copied := make([][]byte, 0, len(topics))
+	for _, topic := range topics {
+		copied = append(copied, copyBytes(topic))
+	}
+
+	return copied
+}
+
+func copyBytes(value []byte) []byte {
+	if value == nil {
+		return nil
+	}
+
+	return append(make([]byte, 0, len(value)), value...)
+}
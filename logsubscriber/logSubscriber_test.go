@@ -0,0 +1,134 @@
+package logsubscriber
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/Reshusk23/sr-me-core/core/check"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createLogEntry(identifier string, token string) *vmcommon.LogEntry {
+	return &vmcommon.LogEntry{
+		Identifier: []byte(identifier),
+		Address:    []byte("sender"),
+		Topics:     [][]byte{[]byte(token), {}, {10}},
+	}
+}
+
+func TestNewLogSubscriber(t *testing.T) {
+	t.Parallel()
+
+	ls, err := NewLogSubscriber(ArgsLogSubscriber{BufferSize: -1})
+	assert.True(t, check.IfNil(ls))
+	assert.Equal(t, ErrInvalidBufferSize, err)
+
+	ls, err = NewLogSubscriber(ArgsLogSubscriber{})
+	assert.False(t, check.IfNil(ls))
+	assert.Nil(t, err)
+	assert.Equal(t, defaultBufferSize, ls.bufferSize)
+}
+
+func TestLogSubscriber_PublishLogs(t *testing.T) {
+	t.Parallel()
+
+	ls, _ := NewLogSubscriber(ArgsLogSubscriber{BufferSize: 10})
+	all, err := ls.Subscribe(Filter{})
+	require.Nil(t, err)
+	tokenOnly, err := ls.Subscribe(Filter{Tokens: [][]byte{[]byte("TKN-abcdef")}})
+	require.Nil(t, err)
+	assert.Equal(t, 2, ls.NumSubscriptions())
+
+	transferEntry := createLogEntry("DCTTransfer", "TKN-abcdef")
+	burnEntry := createLogEntry("DCTBurn", "OTHER-abcdef")
+	ls.PublishLogs("DCTTransfer", []*vmcommon.LogEntry{transferEntry, nil, burnEntry})
+
+	event := <-all.Events()
+	assert.Equal(t, Event{Function: "DCTTransfer", LogEntry: transferEntry}, event)
+	assert.False(t, event.LogEntry == transferEntry)
+	event = <-all.Events()
+	assert.Equal(t, burnEntry, event.LogEntry)
+	event = <-tokenOnly.Events()
+	assert.Equal(t, transferEntry, event.LogEntry)
+
+	assert.Equal(t, 0, len(all.Events()))
+	assert.Equal(t, 0, len(tokenOnly.Events()))
+}
+
+func TestLogSubscriber_FullBufferShouldDrop(t *testing.T) {
+	t.Parallel()
+
+	ls, _ := NewLogSubscriber(ArgsLogSubscriber{BufferSize: 1})
+	subscription, _ := ls.Subscribe(Filter{})
+
+	logs := []*vmcommon.LogEntry{createLogEntry("DCTTransfer", "TKN-abcdef"), createLogEntry("DCTBurn", "TKN-abcdef")}
+	ls.PublishLogs("function", logs)
+
+	assert.Equal(t, uint64(1), subscription.Dropped())
+	event := <-subscription.Events()
+	assert.Equal(t, logs[0], event.LogEntry)
+}
+
+func TestLogSubscriber_UnsubscribeAndClose(t *testing.T) {
+	t.Parallel()
+
+	ls, _ := NewLogSubscriber(ArgsLogSubscriber{})
+	first, _ := ls.Subscribe(Filter{})
+	second, _ := ls.Subscribe(Filter{})
+
+	first.Unsubscribe()
+	first.Unsubscribe()
+	_, ok := <-first.Events()
+	assert.False(t, ok)
+	assert.Equal(t, 1, ls.NumSubscriptions())
+
+	ls.PublishLogs("function", []*vmcommon.LogEntry{createLogEntry("DCTTransfer", "TKN-abcdef")})
+	assert.Equal(t, 1, len(second.Events()))
+
+	ls.Close()
+	assert.Equal(t, 0, ls.NumSubscriptions())
+	_, ok = <-second.Events()
+	assert.True(t, ok)
+	_, ok = <-second.Events()
+	assert.False(t, ok)
+	second.Unsubscribe()
+
+	_, err := ls.Subscribe(Filter{})
+	assert.Equal(t, ErrSubscriberClosed, err)
+}
+
+func TestLogSubscriber_ConcurrentOperations(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		r := recover()
+		assert.Nil(t, r)
+	}()
+
+	ls, _ := NewLogSubscriber(ArgsLogSubscriber{BufferSize: 5})
+	logs := []*vmcommon.LogEntry{createLogEntry("DCTTransfer", "TKN-abcdef")}
+
+	numCalls := 100
+	wg := sync.WaitGroup{}
+	wg.Add(numCalls)
+	for i := 0; i < numCalls; i++ {
+		go func(idx int) {
+			defer wg.Done()
+
+			switch idx % 3 {
+			case 0:
+				subscription, err := ls.Subscribe(Filter{})
+				if err == nil {
+					subscription.Unsubscribe()
+				}
+			case 1:
+				ls.PublishLogs("function", logs)
+			default:
+				_ = ls.NumSubscriptions()
+			}
+		}(i)
+	}
+	wg.Wait()
+}
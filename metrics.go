@@ -0,0 +1,17 @@
+package vmcommon
+
+const (
+	// MetricBuiltInFunctionCalls is the counter incremented on each built-in function call
+	MetricBuiltInFunctionCalls = "builtin_function_calls_total"
+	// MetricBuiltInFunctionErrors is the counter incremented on each failed built-in function call
+	MetricBuiltInFunctionErrors = "builtin_function_errors_total"
+	// MetricBuiltInFunctionGasUsed is the histogram of the gas used by the built-in function calls
+	MetricBuiltInFunctionGasUsed = "builtin_function_gas_used"
+)
+
+// MetricLabels holds the labels attached to a built-in function metric. The labels are restricted to the
+// function name and the return code so that the number of reported series stays bounded
+type MetricLabels struct {
+	Function   string
+	ReturnCode ReturnCode
+}
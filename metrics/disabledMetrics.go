@@ -0,0 +1,24 @@
+package metrics
+
+import vmcommon "github.com/Reshusk23/sr-vm-common-go"
+
+type disabledMetrics struct {
+}
+
+// NewDisabledMetrics returns a metrics handler which discards everything
+func NewDisabledMetrics() *disabledMetrics {
+	return &disabledMetrics{}
+}
+
+// IncrementCounter does nothing
+func (dm *disabledMetrics) IncrementCounter(_ string, _ vmcommon.MetricLabels) {
+}
+
+// ObserveHistogram does nothing
+func (dm *disabledMetrics) ObserveHistogram(_ string, _ float64, _ vmcommon.MetricLabels) {
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (dm *disabledMetrics) IsInterfaceNil() bool {
+	return dm == nil
+}
@@ -0,0 +1,24 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/Reshusk23/sr-me-core/core/check"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisabledMetrics(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		r := recover()
+		assert.Nil(t, r)
+	}()
+
+	dm := NewDisabledMetrics()
+	assert.False(t, check.IfNil(dm))
+
+	dm.IncrementCounter(vmcommon.MetricBuiltInFunctionCalls, vmcommon.MetricLabels{})
+	dm.ObserveHistogram(vmcommon.MetricBuiltInFunctionGasUsed, 10, vmcommon.MetricLabels{})
+}
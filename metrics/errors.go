@@ -0,0 +1,6 @@
+package metrics
+
+import "errors"
+
+// ErrInvalidHistogramBuckets signals that the provided histogram buckets are not strictly increasing
+var ErrInvalidHistogramBuckets = errors.New("histogram buckets must be strictly increasing")
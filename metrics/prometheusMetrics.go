@@ -0,0 +1,201 @@
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+)
+
+const (
+	counterType   = "counter"
+	histogramType = "histogram"
+)
+
+var defaultGasBuckets = []float64{1e3, 1e4, 1e5, 1e6, 1e7, 1e8, 1e9}
+
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// ArgsPrometheusMetrics holds the arguments needed to create a prometheus friendly metrics handler
+type ArgsPrometheusMetrics struct {
+	// Namespace, if set, prefixes all the metric names
+	Namespace string
+	// Buckets are the upper bounds of the histogram buckets. The default gas buckets are used if empty
+	Buckets []float64
+}
+
+type seriesKey struct {
+	name   string
+	labels vmcommon.MetricLabels
+}
+
+type histogram struct {
+	bucketCounts []uint64
+	sum          float64
+	count        uint64
+}
+
+// prometheusMetrics aggregates the built-in function metrics in memory and exposes them in the prometheus
+// text exposition format. Each series is labelled only with the function name and the return code
+type prometheusMetrics struct {
+	mut        sync.Mutex
+	namespace  string
+	buckets    []float64
+	counters   map[seriesKey]uint64
+	histograms map[seriesKey]*histogram
+}
+
+// NewPrometheusMetrics creates a new prometheus friendly metrics handler
+func NewPrometheusMetrics(args ArgsPrometheusMetrics) (*prometheusMetrics, error) {
+	buckets := args.Buckets
+	if len(buckets) == 0 {
+		buckets = defaultGasBuckets
+	}
+	for i := 1; i < len(buckets); i++ {
+		if buckets[i] <= buckets[i-1] {
+			return nil, ErrInvalidHistogramBuckets
+		}
+	}
+
+	return &prometheusMetrics{
+		namespace:  args.Namespace,
+		buckets:    append([]float64(nil), buckets...),
+		counters:   make(map[seriesKey]uint64),
+		histograms: make(map[seriesKey]*histogram),
+	}, nil
+}
+
+// IncrementCounter increments the counter with the given name and labels
+func (pm *prometheusMetrics) IncrementCounter(name string, labels vmcommon.MetricLabels) {
+	pm.mut.Lock()
+	pm.counters[seriesKey{name: name, labels: labels}]++
+	pm.mut.Unlock()
+}
+
+// ObserveHistogram adds the value to the histogram with the given name and labels
+func (pm *prometheusMetrics) ObserveHistogram(name string, value float64, labels vmcommon.MetricLabels) {
+	pm.mut.Lock()
+	defer pm.mut.Unlock()
+
+	key := seriesKey{name: name, labels: labels}
+	h, found := pm.histograms[key]
+	if !found {
+		h = &histogram{
+			bucketCounts: make([]uint64, len(pm.buckets)),
+		}
+		pm.histograms[key] = h
+	}
+
+	for i, upperBound := range pm.buckets {
+		if value <= upperBound {
+			h.bucketCounts[i]++
+		}
+	}
+	h.sum += value
+	h.count++
+}
+
+// CounterValue returns the current value of the counter with the given name and labels
+func (pm *prometheusMetrics) CounterValue(name string, labels vmcommon.MetricLabels) uint64 {
+	pm.mut.Lock()
+	defer pm.mut.Unlock()
+
+	return pm.counters[seriesKey{name: name, labels: labels}]
+}
+
+// WriteTo writes all the metrics in the prometheus text exposition format, sorted by name and labels
+func (pm *prometheusMetrics) WriteTo(w io.Writer) (int64, error) {
+	pm.mut.Lock()
+	buff := &bytes.Buffer{}
+	pm.writeCounters(buff)
+	pm.writeHistograms(buff)
+	pm.mut.Unlock()
+
+	n, err := w.Write(buff.Bytes())
+	return int64(n), err
+}
+
+func (pm *prometheusMetrics) writeCounters(buff *bytes.Buffer) {
+	keys := make([]seriesKey, 0, len(pm.counters))
+	for key := range pm.counters {
+		keys = append(keys, key)
+	}
+	sortSeriesKeys(keys)
+
+	lastName := ""
+	for _, key := range keys {
+		name := pm.fullName(key.name)
+		if name != lastName {
+			_, _ = fmt.Fprintf(buff, "# TYPE %s %s\n", name, counterType)
+			lastName = name
+		}
+		_, _ = fmt.Fprintf(buff, "%s{%s} %d\n", name, formatLabels(key.labels), pm.counters[key])
+	}
+}
+
+func (pm *prometheusMetrics) writeHistograms(buff *bytes.Buffer) {
+	keys := make([]seriesKey, 0, len(pm.histograms))
+	for key := range pm.histograms {
+		keys = append(keys, key)
+	}
+	sortSeriesKeys(keys)
+
+	lastName := ""
+	for _, key := range keys {
+		name := pm.fullName(key.name)
+		if name != lastName {
+			_, _ = fmt.Fprintf(buff, "# TYPE %s %s\n", name, histogramType)
+			lastName = name
+		}
+
+		h := pm.histograms[key]
+		labels := formatLabels(key.labels)
+		for i, upperBound := range pm.buckets {
+			_, _ = fmt.Fprintf(buff, "%s_bucket{%s,le=\"%s\"} %d\n", name, labels, formatFloat(upperBound), h.bucketCounts[i])
+		}
+		_, _ = fmt.Fprintf(buff, "%s_bucket{%s,le=\"+Inf\"} %d\n", name, labels, h.count)
+		_, _ = fmt.Fprintf(buff, "%s_sum{%s} %s\n", name, labels, formatFloat(h.sum))
+		_, _ = fmt.Fprintf(buff, "%s_count{%s} %d\n", name, labels, h.count)
+	}
+}
+
+func (pm *prometheusMetrics) fullName(name string) string {
+	if len(pm.namespace) == 0 {
+		return name
+	}
+
+	return pm.namespace + "_" + name
+}
+
+func sortSeriesKeys(keys []seriesKey) {
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].name != keys[j].name {
+			return keys[i].name < keys[j].name
+		}
+		if keys[i].labels.Function != keys[j].labels.Function {
+			return keys[i].labels.Function < keys[j].labels.Function
+		}
+
+		return keys[i].labels.ReturnCode < keys[j].labels.ReturnCode
+	})
+}
+
+func formatLabels(labels vmcommon.MetricLabels) string {
+	return fmt.Sprintf("function=\"%s\",return_code=\"%s\"",
+		labelValueEscaper.Replace(labels.Function),
+		labelValueEscaper.Replace(labels.ReturnCode.String()))
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (pm *prometheusMetrics) IsInterfaceNil() bool {
+	return pm == nil
+}
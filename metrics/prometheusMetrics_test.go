@@ -0,0 +1,86 @@
+package metrics
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Reshusk23/sr-me-core/core/check"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPrometheusMetrics(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unsorted buckets should error", func(t *testing.T) {
+		t.Parallel()
+
+		pm, err := NewPrometheusMetrics(ArgsPrometheusMetrics{Buckets: []float64{10, 10}})
+		assert.True(t, check.IfNil(pm))
+		assert.Equal(t, ErrInvalidHistogramBuckets, err)
+	})
+	t.Run("default buckets should work", func(t *testing.T) {
+		t.Parallel()
+
+		pm, err := NewPrometheusMetrics(ArgsPrometheusMetrics{})
+		assert.False(t, check.IfNil(pm))
+		assert.Nil(t, err)
+		assert.Equal(t, defaultGasBuckets, pm.buckets)
+	})
+}
+
+func TestPrometheusMetrics_IncrementCounter(t *testing.T) {
+	t.Parallel()
+
+	pm, _ := NewPrometheusMetrics(ArgsPrometheusMetrics{})
+	labels := vmcommon.MetricLabels{Function: "DCTTransfer", ReturnCode: vmcommon.Ok}
+	pm.IncrementCounter(vmcommon.MetricBuiltInFunctionCalls, labels)
+	pm.IncrementCounter(vmcommon.MetricBuiltInFunctionCalls, labels)
+	pm.IncrementCounter(vmcommon.MetricBuiltInFunctionCalls, vmcommon.MetricLabels{Function: "DCTTransfer", ReturnCode: vmcommon.UserError})
+
+	assert.Equal(t, uint64(2), pm.CounterValue(vmcommon.MetricBuiltInFunctionCalls, labels))
+	assert.Equal(t, uint64(0), pm.CounterValue(vmcommon.MetricBuiltInFunctionErrors, labels))
+}
+
+func TestPrometheusMetrics_WriteTo(t *testing.T) {
+	t.Parallel()
+
+	pm, _ := NewPrometheusMetrics(ArgsPrometheusMetrics{
+		Namespace: "vm",
+		Buckets:   []float64{100, 1000},
+	})
+	okLabels := vmcommon.MetricLabels{Function: "DCTTransfer", ReturnCode: vmcommon.Ok}
+	errLabels := vmcommon.MetricLabels{Function: "DCTNFTCreate", ReturnCode: vmcommon.OutOfGas}
+	pm.IncrementCounter(vmcommon.MetricBuiltInFunctionCalls, okLabels)
+	pm.IncrementCounter(vmcommon.MetricBuiltInFunctionCalls, errLabels)
+	pm.IncrementCounter(vmcommon.MetricBuiltInFunctionErrors, errLabels)
+	pm.ObserveHistogram(vmcommon.MetricBuiltInFunctionGasUsed, 50, okLabels)
+	pm.ObserveHistogram(vmcommon.MetricBuiltInFunctionGasUsed, 500, okLabels)
+
+	buff := &bytes.Buffer{}
+	n, err := pm.WriteTo(buff)
+	require.Nil(t, err)
+	assert.Equal(t, int64(buff.Len()), n)
+
+	expected := `# TYPE vm_builtin_function_calls_total counter
+vm_builtin_function_calls_total{function="DCTNFTCreate",return_code="out of gas"} 1
+vm_builtin_function_calls_total{function="DCTTransfer",return_code="ok"} 1
+# TYPE vm_builtin_function_errors_total counter
+vm_builtin_function_errors_total{function="DCTNFTCreate",return_code="out of gas"} 1
+# TYPE vm_builtin_function_gas_used histogram
+vm_builtin_function_gas_used_bucket{function="DCTTransfer",return_code="ok",le="100"} 1
+vm_builtin_function_gas_used_bucket{function="DCTTransfer",return_code="ok",le="1000"} 2
+vm_builtin_function_gas_used_bucket{function="DCTTransfer",return_code="ok",le="+Inf"} 2
+vm_builtin_function_gas_used_sum{function="DCTTransfer",return_code="ok"} 550
+vm_builtin_function_gas_used_count{function="DCTTransfer",return_code="ok"} 2
+`
+	assert.Equal(t, expected, buff.String())
+}
+
+func TestFormatLabels_ShouldEscape(t *testing.T) {
+	t.Parallel()
+
+	labels := formatLabels(vmcommon.MetricLabels{Function: "a\"b\\c\nd", ReturnCode: vmcommon.Ok})
+	assert.Equal(t, `function="a\"b\\c\nd",return_code="ok"`, labels)
+}
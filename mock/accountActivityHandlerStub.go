@@ -0,0 +1,19 @@
+package mock
+
+// AccountActivityHandlerStub -
+type AccountActivityHandlerStub struct {
+	GetInactiveEpochsCalled func(address []byte, epoch uint32) (uint32, error)
+}
+
+// GetInactiveEpochs -
+func (stub *AccountActivityHandlerStub) GetInactiveEpochs(address []byte, epoch uint32) (uint32, error) {
+	if stub.GetInactiveEpochsCalled != nil {
+		return stub.GetInactiveEpochsCalled(address, epoch)
+	}
+	return 0, nil
+}
+
+// IsInterfaceNil -
+func (stub *AccountActivityHandlerStub) IsInterfaceNil() bool {
+	return stub == nil
+}
@@ -0,0 +1,64 @@
+package mock
+
+// AddressClassifierStub -
+type AddressClassifierStub struct {
+	IsSmartContractCalled func(address []byte) bool
+	IsSystemSCCalled      func(address []byte) bool
+	IsDCTSystemSCCalled   func(address []byte) bool
+	IsUserAddressCalled   func(address []byte) bool
+	IsEmptyCalled         func(address []byte) bool
+	ShardOfCalled         func(address []byte, numOfShards uint32) uint32
+}
+
+// IsSmartContract -
+func (acs *AddressClassifierStub) IsSmartContract(address []byte) bool {
+	if acs.IsSmartContractCalled != nil {
+		return acs.IsSmartContractCalled(address)
+	}
+	return false
+}
+
+// IsSystemSC -
+func (acs *AddressClassifierStub) IsSystemSC(address []byte) bool {
+	if acs.IsSystemSCCalled != nil {
+		return acs.IsSystemSCCalled(address)
+	}
+	return false
+}
+
+// IsDCTSystemSC -
+func (acs *AddressClassifierStub) IsDCTSystemSC(address []byte) bool {
+	if acs.IsDCTSystemSCCalled != nil {
+		return acs.IsDCTSystemSCCalled(address)
+	}
+	return false
+}
+
+// IsUserAddress -
+func (acs *AddressClassifierStub) IsUserAddress(address []byte) bool {
+	if acs.IsUserAddressCalled != nil {
+		return acs.IsUserAddressCalled(address)
+	}
+	return false
+}
+
+// IsEmpty -
+func (acs *AddressClassifierStub) IsEmpty(address []byte) bool {
+	if acs.IsEmptyCalled != nil {
+		return acs.IsEmptyCalled(address)
+	}
+	return false
+}
+
+// ShardOf -
+func (acs *AddressClassifierStub) ShardOf(address []byte, numOfShards uint32) uint32 {
+	if acs.ShardOfCalled != nil {
+		return acs.ShardOfCalled(address, numOfShards)
+	}
+	return 0
+}
+
+// IsInterfaceNil -
+func (acs *AddressClassifierStub) IsInterfaceNil() bool {
+	return acs == nil
+}
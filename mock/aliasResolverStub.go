@@ -0,0 +1,28 @@
+package mock
+
+// AliasResolverStub -
+type AliasResolverStub struct {
+	IsAliasCalled      func(value []byte) bool
+	ResolveAliasCalled func(alias []byte) ([]byte, error)
+}
+
+// IsAlias -
+func (stub *AliasResolverStub) IsAlias(value []byte) bool {
+	if stub.IsAliasCalled != nil {
+		return stub.IsAliasCalled(value)
+	}
+	return false
+}
+
+// ResolveAlias -
+func (stub *AliasResolverStub) ResolveAlias(alias []byte) ([]byte, error) {
+	if stub.ResolveAliasCalled != nil {
+		return stub.ResolveAliasCalled(alias)
+	}
+	return alias, nil
+}
+
+// IsInterfaceNil -
+func (stub *AliasResolverStub) IsInterfaceNil() bool {
+	return stub == nil
+}
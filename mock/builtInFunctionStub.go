@@ -7,6 +7,7 @@ import (
 // BuiltInFunctionStub -
 type BuiltInFunctionStub struct {
 	ProcessBuiltinFunctionCalled func(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error)
+	CheckIsExecutableCalled      func(vmInput *vmcommon.ContractCallInput) error
 	SetNewGasConfigCalled        func(gasCost *vmcommon.GasCost)
 	IsActiveCalled               func() bool
 }
@@ -19,6 +20,14 @@ func (b *BuiltInFunctionStub) ProcessBuiltinFunction(acntSnd, acntDst vmcommon.U
 	return &vmcommon.VMOutput{}, nil
 }
 
+// CheckIsExecutable -
+func (b *BuiltInFunctionStub) CheckIsExecutable(vmInput *vmcommon.ContractCallInput) error {
+	if b.CheckIsExecutableCalled != nil {
+		return b.CheckIsExecutableCalled(vmInput)
+	}
+	return nil
+}
+
 // SetNewGasConfig -
 func (b *BuiltInFunctionStub) SetNewGasConfig(gasCost *vmcommon.GasCost) {
 	if b.SetNewGasConfigCalled != nil {
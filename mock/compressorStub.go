@@ -0,0 +1,30 @@
+package mock
+
+// CompressorStub -
+type CompressorStub struct {
+	CompressCalled   func(data []byte) ([]byte, error)
+	DecompressCalled func(data []byte) ([]byte, error)
+}
+
+// Compress -
+func (stub *CompressorStub) Compress(data []byte) ([]byte, error) {
+	if stub.CompressCalled != nil {
+		return stub.CompressCalled(data)
+	}
+
+	return data, nil
+}
+
+// Decompress -
+func (stub *CompressorStub) Decompress(data []byte) ([]byte, error) {
+	if stub.DecompressCalled != nil {
+		return stub.DecompressCalled(data)
+	}
+
+	return data, nil
+}
+
+// IsInterfaceNil -
+func (stub *CompressorStub) IsInterfaceNil() bool {
+	return stub == nil
+}
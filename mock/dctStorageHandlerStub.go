@@ -17,6 +17,10 @@ type DCTNFTStorageHandlerStub struct {
 	WasAlreadySentToDestinationShardAndUpdateStateCalled     func(tickerID []byte, nonce uint64, dstAddress []byte) (bool, error)
 	SaveNFTMetaDataToSystemAccountCalled                     func(tx data.TransactionHandler) error
 	AddToLiquiditySystemAccCalled                            func(dctTokenKey []byte, nonce uint64, transferValue *big.Int) error
+	SaveNFTMaxSupplyCalled                                   func(dctTokenKey []byte, nonce uint64, maxSupply *big.Int, initialQuantity *big.Int) error
+	AddToNFTMintedSupplyCalled                               func(dctTokenKey []byte, nonce uint64, value *big.Int) error
+	SaveNFTRoyaltiesDenominatorCalled                        func(dctTokenKey []byte, nonce uint64, royaltiesDenominator uint32) error
+	GetNFTRoyaltiesDenominatorCalled                         func(dctTokenKey []byte, nonce uint64) (uint32, error)
 }
 
 // SaveDCTNFTToken -
@@ -75,6 +79,38 @@ func (stub *DCTNFTStorageHandlerStub) AddToLiquiditySystemAcc(dctTokenKey []byte
 	return nil
 }
 
+// SaveNFTMaxSupply -
+func (stub *DCTNFTStorageHandlerStub) SaveNFTMaxSupply(dctTokenKey []byte, nonce uint64, maxSupply *big.Int, initialQuantity *big.Int) error {
+	if stub.SaveNFTMaxSupplyCalled != nil {
+		return stub.SaveNFTMaxSupplyCalled(dctTokenKey, nonce, maxSupply, initialQuantity)
+	}
+	return nil
+}
+
+// AddToNFTMintedSupply -
+func (stub *DCTNFTStorageHandlerStub) AddToNFTMintedSupply(dctTokenKey []byte, nonce uint64, value *big.Int) error {
+	if stub.AddToNFTMintedSupplyCalled != nil {
+		return stub.AddToNFTMintedSupplyCalled(dctTokenKey, nonce, value)
+	}
+	return nil
+}
+
+// SaveNFTRoyaltiesDenominator -
+func (stub *DCTNFTStorageHandlerStub) SaveNFTRoyaltiesDenominator(dctTokenKey []byte, nonce uint64, royaltiesDenominator uint32) error {
+	if stub.SaveNFTRoyaltiesDenominatorCalled != nil {
+		return stub.SaveNFTRoyaltiesDenominatorCalled(dctTokenKey, nonce, royaltiesDenominator)
+	}
+	return nil
+}
+
+// GetNFTRoyaltiesDenominator -
+func (stub *DCTNFTStorageHandlerStub) GetNFTRoyaltiesDenominator(dctTokenKey []byte, nonce uint64) (uint32, error) {
+	if stub.GetNFTRoyaltiesDenominatorCalled != nil {
+		return stub.GetNFTRoyaltiesDenominatorCalled(dctTokenKey, nonce)
+	}
+	return vmcommon.DefaultRoyaltiesDenominator, nil
+}
+
 // IsInterfaceNil -
 func (stub *DCTNFTStorageHandlerStub) IsInterfaceNil() bool {
 	return stub == nil
@@ -0,0 +1,22 @@
+package mock
+
+import vmcommon "github.com/Reshusk23/sr-vm-common-go"
+
+// EnableEpochsHandlerFactoryStub -
+type EnableEpochsHandlerFactoryStub struct {
+	CreateEnableEpochsHandlerCalled func(epoch uint32) (vmcommon.EnableEpochsHandler, error)
+}
+
+// CreateEnableEpochsHandler -
+func (stub *EnableEpochsHandlerFactoryStub) CreateEnableEpochsHandler(epoch uint32) (vmcommon.EnableEpochsHandler, error) {
+	if stub.CreateEnableEpochsHandlerCalled != nil {
+		return stub.CreateEnableEpochsHandlerCalled(epoch)
+	}
+
+	return &EnableEpochsHandlerStub{CurrentEpochField: epoch}, nil
+}
+
+// IsInterfaceNil -
+func (stub *EnableEpochsHandlerFactoryStub) IsInterfaceNil() bool {
+	return stub == nil
+}
@@ -33,6 +33,33 @@ type EnableEpochsHandlerStub struct {
 	IsMaxBlockchainHookCountersFlagEnabledField          bool
 	IsWipeSingleNFTLiquidityDecreaseEnabledField         bool
 	IsAlwaysSaveTokenMetaDataEnabledField                bool
+	IsFreezeAccountFlagEnabledField                      bool
+	IsGlobalSettingsVersioningFlagEnabledField           bool
+	IsCollectionConfigFlagEnabledField                   bool
+	IsDormantSweepFlagEnabledField                       bool
+	IsMultiSigManagementFlagEnabledField                 bool
+	IsSoulboundFlagEnabledField                          bool
+	IsNFTRentalFlagEnabledField                          bool
+	IsDCTTransferWithValueFlagEnabledField               bool
+	IsDCTokenV2EncodingFlagEnabledField                  bool
+	IsNFTCreateOnBehalfFlagEnabledField                  bool
+	IsStopNFTCreateFlagEnabledField                      bool
+	IsMultiTransferGasRepriceFlagEnabledField            bool
+	IsNFTMaxSupplyFlagEnabledField                       bool
+	IsNFTNonceRangesFlagEnabledField                     bool
+	IsDCTModifyCreatorFlagEnabledField                   bool
+	IsDCTSetNewURIsFlagEnabledField                      bool
+	IsDCTNFTUpdateFlagEnabledField                       bool
+	IsDCTTransferAndLockFlagEnabledField                 bool
+	IsMetaDCTFlagEnabledField                            bool
+	IsWrapNativeFlagEnabledField                         bool
+	IsDCTBridgeFlagEnabledField                          bool
+	IsDCTAllowanceFlagEnabledField                       bool
+	IsNFTCreateNotifyFlagEnabledField                    bool
+	IsNFTContentHashFlagEnabledField                     bool
+	IsDCTSelfTransferFlagEnabledField                    bool
+	IsDCTTransferCallbackGasLockFlagEnabledField         bool
+	IsSafeArgumentConversionFlagEnabledField             bool
 	MultiDCTTransferAsyncCallBackEnableEpochField        uint32
 	FixOOGReturnCodeEnableEpochField                     uint32
 	RemoveNonUpdatedStorageEnableEpochField              uint32
@@ -45,6 +72,7 @@ type EnableEpochsHandlerStub struct {
 	RefactorContextEnableEpochField                      uint32
 	CheckExecuteReadOnlyEnableEpochField                 uint32
 	StorageAPICostOptimizationEnableEpochField           uint32
+	CurrentEpochField                                    uint32
 }
 
 // IsGlobalMintBurnFlagEnabled -
@@ -237,6 +265,11 @@ func (stub *EnableEpochsHandlerStub) StorageAPICostOptimizationEnableEpoch() uin
 	return stub.StorageAPICostOptimizationEnableEpochField
 }
 
+// GetCurrentEpoch -
+func (stub *EnableEpochsHandlerStub) GetCurrentEpoch() uint32 {
+	return stub.CurrentEpochField
+}
+
 // IsMaxBlockchainHookCountersFlagEnabled -
 func (stub *EnableEpochsHandlerStub) IsMaxBlockchainHookCountersFlagEnabled() bool {
 	return stub.IsMaxBlockchainHookCountersFlagEnabledField
@@ -252,6 +285,141 @@ func (stub *EnableEpochsHandlerStub) IsAlwaysSaveTokenMetaDataEnabled() bool {
 	return stub.IsAlwaysSaveTokenMetaDataEnabledField
 }
 
+// IsFreezeAccountFlagEnabled -
+func (stub *EnableEpochsHandlerStub) IsFreezeAccountFlagEnabled() bool {
+	return stub.IsFreezeAccountFlagEnabledField
+}
+
+// IsCollectionConfigFlagEnabled -
+func (stub *EnableEpochsHandlerStub) IsCollectionConfigFlagEnabled() bool {
+	return stub.IsCollectionConfigFlagEnabledField
+}
+
+// IsDormantSweepFlagEnabled -
+func (stub *EnableEpochsHandlerStub) IsDormantSweepFlagEnabled() bool {
+	return stub.IsDormantSweepFlagEnabledField
+}
+
+// IsMultiSigManagementFlagEnabled -
+func (stub *EnableEpochsHandlerStub) IsMultiSigManagementFlagEnabled() bool {
+	return stub.IsMultiSigManagementFlagEnabledField
+}
+
+// IsSoulboundFlagEnabled -
+func (stub *EnableEpochsHandlerStub) IsSoulboundFlagEnabled() bool {
+	return stub.IsSoulboundFlagEnabledField
+}
+
+// IsNFTRentalFlagEnabled -
+func (stub *EnableEpochsHandlerStub) IsNFTRentalFlagEnabled() bool {
+	return stub.IsNFTRentalFlagEnabledField
+}
+
+// IsDCTTransferWithValueFlagEnabled -
+func (stub *EnableEpochsHandlerStub) IsDCTTransferWithValueFlagEnabled() bool {
+	return stub.IsDCTTransferWithValueFlagEnabledField
+}
+
+// IsDCTokenV2EncodingFlagEnabled -
+func (stub *EnableEpochsHandlerStub) IsDCTokenV2EncodingFlagEnabled() bool {
+	return stub.IsDCTokenV2EncodingFlagEnabledField
+}
+
+// IsNFTCreateOnBehalfFlagEnabled -
+func (stub *EnableEpochsHandlerStub) IsNFTCreateOnBehalfFlagEnabled() bool {
+	return stub.IsNFTCreateOnBehalfFlagEnabledField
+}
+
+// IsStopNFTCreateFlagEnabled -
+func (stub *EnableEpochsHandlerStub) IsStopNFTCreateFlagEnabled() bool {
+	return stub.IsStopNFTCreateFlagEnabledField
+}
+
+// IsMultiTransferGasRepriceFlagEnabled -
+func (stub *EnableEpochsHandlerStub) IsMultiTransferGasRepriceFlagEnabled() bool {
+	return stub.IsMultiTransferGasRepriceFlagEnabledField
+}
+
+// IsNFTMaxSupplyFlagEnabled -
+func (stub *EnableEpochsHandlerStub) IsNFTMaxSupplyFlagEnabled() bool {
+	return stub.IsNFTMaxSupplyFlagEnabledField
+}
+
+// IsNFTNonceRangesFlagEnabled -
+func (stub *EnableEpochsHandlerStub) IsNFTNonceRangesFlagEnabled() bool {
+	return stub.IsNFTNonceRangesFlagEnabledField
+}
+
+// IsDCTModifyCreatorFlagEnabled -
+func (stub *EnableEpochsHandlerStub) IsDCTModifyCreatorFlagEnabled() bool {
+	return stub.IsDCTModifyCreatorFlagEnabledField
+}
+
+// IsDCTSetNewURIsFlagEnabled -
+func (stub *EnableEpochsHandlerStub) IsDCTSetNewURIsFlagEnabled() bool {
+	return stub.IsDCTSetNewURIsFlagEnabledField
+}
+
+// IsDCTNFTUpdateFlagEnabled -
+func (stub *EnableEpochsHandlerStub) IsDCTNFTUpdateFlagEnabled() bool {
+	return stub.IsDCTNFTUpdateFlagEnabledField
+}
+
+// IsDCTTransferAndLockFlagEnabled -
+func (stub *EnableEpochsHandlerStub) IsDCTTransferAndLockFlagEnabled() bool {
+	return stub.IsDCTTransferAndLockFlagEnabledField
+}
+
+// IsMetaDCTFlagEnabled -
+func (stub *EnableEpochsHandlerStub) IsMetaDCTFlagEnabled() bool {
+	return stub.IsMetaDCTFlagEnabledField
+}
+
+// IsWrapNativeFlagEnabled -
+func (stub *EnableEpochsHandlerStub) IsWrapNativeFlagEnabled() bool {
+	return stub.IsWrapNativeFlagEnabledField
+}
+
+// IsDCTBridgeFlagEnabled -
+func (stub *EnableEpochsHandlerStub) IsDCTBridgeFlagEnabled() bool {
+	return stub.IsDCTBridgeFlagEnabledField
+}
+
+// IsDCTAllowanceFlagEnabled -
+func (stub *EnableEpochsHandlerStub) IsDCTAllowanceFlagEnabled() bool {
+	return stub.IsDCTAllowanceFlagEnabledField
+}
+
+// IsNFTCreateNotifyFlagEnabled -
+func (stub *EnableEpochsHandlerStub) IsNFTCreateNotifyFlagEnabled() bool {
+	return stub.IsNFTCreateNotifyFlagEnabledField
+}
+
+// IsNFTContentHashFlagEnabled -
+func (stub *EnableEpochsHandlerStub) IsNFTContentHashFlagEnabled() bool {
+	return stub.IsNFTContentHashFlagEnabledField
+}
+
+// IsDCTSelfTransferFlagEnabled -
+func (stub *EnableEpochsHandlerStub) IsDCTSelfTransferFlagEnabled() bool {
+	return stub.IsDCTSelfTransferFlagEnabledField
+}
+
+// IsDCTTransferCallbackGasLockFlagEnabled -
+func (stub *EnableEpochsHandlerStub) IsDCTTransferCallbackGasLockFlagEnabled() bool {
+	return stub.IsDCTTransferCallbackGasLockFlagEnabledField
+}
+
+// IsSafeArgumentConversionFlagEnabled -
+func (stub *EnableEpochsHandlerStub) IsSafeArgumentConversionFlagEnabled() bool {
+	return stub.IsSafeArgumentConversionFlagEnabledField
+}
+
+// IsGlobalSettingsVersioningFlagEnabled -
+func (stub *EnableEpochsHandlerStub) IsGlobalSettingsVersioningFlagEnabled() bool {
+	return stub.IsGlobalSettingsVersioningFlagEnabledField
+}
+
 // IsInterfaceNil -
 func (stub *EnableEpochsHandlerStub) IsInterfaceNil() bool {
 	return stub == nil
@@ -0,0 +1,20 @@
+package mock
+
+import vmcommon "github.com/Reshusk23/sr-vm-common-go"
+
+// EpochNotifierStub -
+type EpochNotifierStub struct {
+	RegisterNotifyHandlerCalled func(handler vmcommon.EpochSubscriberHandler)
+}
+
+// RegisterNotifyHandler -
+func (ens *EpochNotifierStub) RegisterNotifyHandler(handler vmcommon.EpochSubscriberHandler) {
+	if ens.RegisterNotifyHandlerCalled != nil {
+		ens.RegisterNotifyHandlerCalled(handler)
+	}
+}
+
+// IsInterfaceNil -
+func (ens *EpochNotifierStub) IsInterfaceNil() bool {
+	return ens == nil
+}
@@ -0,0 +1,19 @@
+package mock
+
+// FreezeAccountHandlerStub -
+type FreezeAccountHandlerStub struct {
+	IsAccountFrozenCalled func(address []byte) bool
+}
+
+// IsAccountFrozen -
+func (stub *FreezeAccountHandlerStub) IsAccountFrozen(address []byte) bool {
+	if stub.IsAccountFrozenCalled != nil {
+		return stub.IsAccountFrozenCalled(address)
+	}
+	return false
+}
+
+// IsInterfaceNil -
+func (stub *FreezeAccountHandlerStub) IsInterfaceNil() bool {
+	return stub == nil
+}
@@ -0,0 +1,20 @@
+package mock
+
+// FunctionResolverStub -
+type FunctionResolverStub struct {
+	ResolveFunctionCalled func(name string) (string, bool)
+}
+
+// ResolveFunction -
+func (stub *FunctionResolverStub) ResolveFunction(name string) (string, bool) {
+	if stub.ResolveFunctionCalled != nil {
+		return stub.ResolveFunctionCalled(name)
+	}
+
+	return name, false
+}
+
+// IsInterfaceNil -
+func (stub *FunctionResolverStub) IsInterfaceNil() bool {
+	return stub == nil
+}
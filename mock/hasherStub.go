@@ -0,0 +1,28 @@
+package mock
+
+// HasherStub -
+type HasherStub struct {
+	ComputeCalled func(s string) []byte
+	SizeCalled    func() int
+}
+
+// Compute -
+func (stub *HasherStub) Compute(s string) []byte {
+	if stub.ComputeCalled != nil {
+		return stub.ComputeCalled(s)
+	}
+	return nil
+}
+
+// Size -
+func (stub *HasherStub) Size() int {
+	if stub.SizeCalled != nil {
+		return stub.SizeCalled()
+	}
+	return 0
+}
+
+// IsInterfaceNil -
+func (stub *HasherStub) IsInterfaceNil() bool {
+	return stub == nil
+}
@@ -0,0 +1,20 @@
+package mock
+
+import vmcommon "github.com/Reshusk23/sr-vm-common-go"
+
+// LogPublisherStub -
+type LogPublisherStub struct {
+	PublishLogsCalled func(function string, logs []*vmcommon.LogEntry)
+}
+
+// PublishLogs -
+func (stub *LogPublisherStub) PublishLogs(function string, logs []*vmcommon.LogEntry) {
+	if stub.PublishLogsCalled != nil {
+		stub.PublishLogsCalled(function, logs)
+	}
+}
+
+// IsInterfaceNil -
+func (stub *LogPublisherStub) IsInterfaceNil() bool {
+	return stub == nil
+}
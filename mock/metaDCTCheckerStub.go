@@ -0,0 +1,19 @@
+package mock
+
+// MetaDCTCheckerStub -
+type MetaDCTCheckerStub struct {
+	IsMetaDCTCalled func(tokenID []byte) bool
+}
+
+// IsMetaDCT -
+func (stub *MetaDCTCheckerStub) IsMetaDCT(tokenID []byte) bool {
+	if stub.IsMetaDCTCalled != nil {
+		return stub.IsMetaDCTCalled(tokenID)
+	}
+	return false
+}
+
+// IsInterfaceNil -
+func (stub *MetaDCTCheckerStub) IsInterfaceNil() bool {
+	return stub == nil
+}
@@ -0,0 +1,28 @@
+package mock
+
+import vmcommon "github.com/Reshusk23/sr-vm-common-go"
+
+// MetricsStub -
+type MetricsStub struct {
+	IncrementCounterCalled func(name string, labels vmcommon.MetricLabels)
+	ObserveHistogramCalled func(name string, value float64, labels vmcommon.MetricLabels)
+}
+
+// IncrementCounter -
+func (ms *MetricsStub) IncrementCounter(name string, labels vmcommon.MetricLabels) {
+	if ms.IncrementCounterCalled != nil {
+		ms.IncrementCounterCalled(name, labels)
+	}
+}
+
+// ObserveHistogram -
+func (ms *MetricsStub) ObserveHistogram(name string, value float64, labels vmcommon.MetricLabels) {
+	if ms.ObserveHistogramCalled != nil {
+		ms.ObserveHistogramCalled(name, value, labels)
+	}
+}
+
+// IsInterfaceNil -
+func (ms *MetricsStub) IsInterfaceNil() bool {
+	return ms == nil
+}
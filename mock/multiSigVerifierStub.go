@@ -0,0 +1,19 @@
+package mock
+
+// MultiSigVerifierStub -
+type MultiSigVerifierStub struct {
+	VerifySignaturesCalled func(tokenID []byte, message []byte, signatures [][]byte) error
+}
+
+// VerifySignatures -
+func (stub *MultiSigVerifierStub) VerifySignatures(tokenID []byte, message []byte, signatures [][]byte) error {
+	if stub.VerifySignaturesCalled != nil {
+		return stub.VerifySignaturesCalled(tokenID, message, signatures)
+	}
+	return nil
+}
+
+// IsInterfaceNil -
+func (stub *MultiSigVerifierStub) IsInterfaceNil() bool {
+	return stub == nil
+}
@@ -1,11 +1,18 @@
 package mock
 
+import vmcommon "github.com/Reshusk23/sr-vm-common-go"
+
 // GlobalSettingsHandlerStub -
 type GlobalSettingsHandlerStub struct {
 	IsPausedCalled                              func(token []byte) bool
 	IsLimiterTransferCalled                     func(token []byte) bool
 	IsBurnForAllCalled                          func(token []byte) bool
 	IsSenderOrDestinationWithTransferRoleCalled func(sender, destionation, tokenID []byte) bool
+	IsDormantSweepAllowedCalled                 func(token []byte) bool
+	IsMultiSigManagedCalled                     func(token []byte) bool
+	IsSoulboundCalled                           func(token []byte) bool
+	IsNFTCreateStoppedCalled                    func(token []byte) bool
+	GetCollectionConfigCalled                   func(tokenID []byte) vmcommon.CollectionConfig
 }
 
 // IsPaused -
@@ -40,6 +47,46 @@ func (p *GlobalSettingsHandlerStub) IsSenderOrDestinationWithTransferRole(sender
 	return false
 }
 
+// IsDormantSweepAllowed -
+func (p *GlobalSettingsHandlerStub) IsDormantSweepAllowed(token []byte) bool {
+	if p.IsDormantSweepAllowedCalled != nil {
+		return p.IsDormantSweepAllowedCalled(token)
+	}
+	return false
+}
+
+// IsMultiSigManaged -
+func (p *GlobalSettingsHandlerStub) IsMultiSigManaged(token []byte) bool {
+	if p.IsMultiSigManagedCalled != nil {
+		return p.IsMultiSigManagedCalled(token)
+	}
+	return false
+}
+
+// IsSoulbound -
+func (p *GlobalSettingsHandlerStub) IsSoulbound(token []byte) bool {
+	if p.IsSoulboundCalled != nil {
+		return p.IsSoulboundCalled(token)
+	}
+	return false
+}
+
+// IsNFTCreateStopped -
+func (p *GlobalSettingsHandlerStub) IsNFTCreateStopped(token []byte) bool {
+	if p.IsNFTCreateStoppedCalled != nil {
+		return p.IsNFTCreateStoppedCalled(token)
+	}
+	return false
+}
+
+// GetCollectionConfig -
+func (p *GlobalSettingsHandlerStub) GetCollectionConfig(tokenID []byte) vmcommon.CollectionConfig {
+	if p.GetCollectionConfigCalled != nil {
+		return p.GetCollectionConfigCalled(tokenID)
+	}
+	return vmcommon.CollectionConfig{}
+}
+
 // IsInterfaceNil -
 func (p *GlobalSettingsHandlerStub) IsInterfaceNil() bool {
 	return p == nil
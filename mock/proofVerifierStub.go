@@ -0,0 +1,21 @@
+package mock
+
+import "math/big"
+
+// ProofVerifierStub -
+type ProofVerifierStub struct {
+	VerifyProofCalled func(operation string, tokenID []byte, amount *big.Int, address []byte, proof []byte) ([]byte, error)
+}
+
+// VerifyProof -
+func (stub *ProofVerifierStub) VerifyProof(operation string, tokenID []byte, amount *big.Int, address []byte, proof []byte) ([]byte, error) {
+	if stub.VerifyProofCalled != nil {
+		return stub.VerifyProofCalled(operation, tokenID, amount, address, proof)
+	}
+	return proof, nil
+}
+
+// IsInterfaceNil -
+func (stub *ProofVerifierStub) IsInterfaceNil() bool {
+	return stub == nil
+}
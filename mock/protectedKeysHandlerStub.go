@@ -0,0 +1,19 @@
+package mock
+
+// ProtectedKeysHandlerStub -
+type ProtectedKeysHandlerStub struct {
+	IsProtectedKeyCalled func(key []byte) bool
+}
+
+// IsProtectedKey -
+func (stub *ProtectedKeysHandlerStub) IsProtectedKey(key []byte) bool {
+	if stub.IsProtectedKeyCalled != nil {
+		return stub.IsProtectedKeyCalled(key)
+	}
+	return false
+}
+
+// IsInterfaceNil -
+func (stub *ProtectedKeysHandlerStub) IsInterfaceNil() bool {
+	return stub == nil
+}
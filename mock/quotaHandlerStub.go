@@ -0,0 +1,28 @@
+package mock
+
+// QuotaHandlerStub -
+type QuotaHandlerStub struct {
+	CheckQuotaCalled  func(function string, gasProvided uint64) error
+	RecordUsageCalled func(function string, gasUsed uint64)
+}
+
+// CheckQuota -
+func (stub *QuotaHandlerStub) CheckQuota(function string, gasProvided uint64) error {
+	if stub.CheckQuotaCalled != nil {
+		return stub.CheckQuotaCalled(function, gasProvided)
+	}
+
+	return nil
+}
+
+// RecordUsage -
+func (stub *QuotaHandlerStub) RecordUsage(function string, gasUsed uint64) {
+	if stub.RecordUsageCalled != nil {
+		stub.RecordUsageCalled(function, gasUsed)
+	}
+}
+
+// IsInterfaceNil -
+func (stub *QuotaHandlerStub) IsInterfaceNil() bool {
+	return stub == nil
+}
@@ -0,0 +1,20 @@
+package mock
+
+import vmcommon "github.com/Reshusk23/sr-vm-common-go"
+
+// RoundNotifierStub -
+type RoundNotifierStub struct {
+	RegisterRoundHandlerCalled func(handler vmcommon.RoundSubscriberHandler)
+}
+
+// RegisterRoundHandler -
+func (rns *RoundNotifierStub) RegisterRoundHandler(handler vmcommon.RoundSubscriberHandler) {
+	if rns.RegisterRoundHandlerCalled != nil {
+		rns.RegisterRoundHandlerCalled(handler)
+	}
+}
+
+// IsInterfaceNil -
+func (rns *RoundNotifierStub) IsInterfaceNil() bool {
+	return rns == nil
+}
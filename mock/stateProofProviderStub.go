@@ -0,0 +1,19 @@
+package mock
+
+// StateProofProviderStub -
+type StateProofProviderStub struct {
+	GetProofCalled func(rootHash []byte, address []byte, key []byte) ([][]byte, error)
+}
+
+// GetProof -
+func (stub *StateProofProviderStub) GetProof(rootHash []byte, address []byte, key []byte) ([][]byte, error) {
+	if stub.GetProofCalled != nil {
+		return stub.GetProofCalled(rootHash, address, key)
+	}
+	return nil, nil
+}
+
+// IsInterfaceNil -
+func (stub *StateProofProviderStub) IsInterfaceNil() bool {
+	return stub == nil
+}
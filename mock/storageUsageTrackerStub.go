@@ -0,0 +1,22 @@
+package mock
+
+import vmcommon "github.com/Reshusk23/sr-vm-common-go"
+
+// StorageUsageTrackerStub -
+type StorageUsageTrackerStub struct {
+	TrackStorageUsageCalled func(account vmcommon.UserAccountHandler, bytesDelta int64) error
+}
+
+// TrackStorageUsage -
+func (stub *StorageUsageTrackerStub) TrackStorageUsage(account vmcommon.UserAccountHandler, bytesDelta int64) error {
+	if stub.TrackStorageUsageCalled != nil {
+		return stub.TrackStorageUsageCalled(account, bytesDelta)
+	}
+
+	return nil
+}
+
+// IsInterfaceNil -
+func (stub *StorageUsageTrackerStub) IsInterfaceNil() bool {
+	return stub == nil
+}
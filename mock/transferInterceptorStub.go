@@ -0,0 +1,21 @@
+package mock
+
+import "math/big"
+
+// TransferInterceptorStub -
+type TransferInterceptorStub struct {
+	PreTransferCalled func(sender []byte, receiver []byte, token []byte, nonce uint64, amount *big.Int) error
+}
+
+// PreTransfer -
+func (stub *TransferInterceptorStub) PreTransfer(sender []byte, receiver []byte, token []byte, nonce uint64, amount *big.Int) error {
+	if stub.PreTransferCalled != nil {
+		return stub.PreTransferCalled(sender, receiver, token, nonce, amount)
+	}
+	return nil
+}
+
+// IsInterfaceNil -
+func (stub *TransferInterceptorStub) IsInterfaceNil() bool {
+	return stub == nil
+}
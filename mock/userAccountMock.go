@@ -2,8 +2,10 @@ package mock
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"math/big"
+	"sort"
 
 	vmcommon "github.com/Reshusk23/sr-vm-common-go"
 )
@@ -30,6 +32,9 @@ type Account struct {
 	OwnerAddress    []byte
 	Username        []byte
 	DeveloperReward *big.Int
+
+	// ProtectedKeys, if set, makes SaveKeyValue reject the protected keys as it would happen for a user write
+	ProtectedKeys vmcommon.ProtectedKeysHandler
 }
 
 var storageDefaultValue = []byte{}
@@ -230,10 +235,38 @@ func (a *Account) RetrieveValue(key []byte) ([]byte, uint32, error) {
 
 // SaveKeyValue -
 func (a *Account) SaveKeyValue(key []byte, value []byte) error {
+	if a.ProtectedKeys != nil && a.ProtectedKeys.IsProtectedKey(key) {
+		return ErrOperationNotPermitted
+	}
+
 	a.Storage[string(key)] = value
 	return nil
 }
 
+// GetAllLeaves - iterates the storage in the order of the keys
+func (a *Account) GetAllLeaves(ctx context.Context, prefix []byte, handler vmcommon.LeafHandler) error {
+	keys := make([]string, 0, len(a.Storage))
+	for key, value := range a.Storage {
+		if len(value) > 0 && bytes.HasPrefix([]byte(key), prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		err := handler([]byte(key), a.Storage[key])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // ClearDataCaches -
 func (a *Account) ClearDataCaches() {
 }
@@ -1,9 +1,11 @@
 package vmcommon
 
 import (
+	"bytes"
 	"encoding/hex"
 	"fmt"
 	"math/big"
+	"sort"
 
 	"github.com/Reshusk23/sr-me-core/data/vm"
 )
@@ -92,6 +94,34 @@ type OutputTransfer struct {
 	SenderAddress []byte
 }
 
+// AsyncCall holds an asynchronous call issued during the execution, to be executed on the destination
+type AsyncCall struct {
+	// CallID identifies the call, being the hash of the transaction which issued it
+	CallID []byte
+	// Destination is the address the call is sent to
+	Destination []byte
+	// Data holds the function to be called and its arguments, the same as in the output transfer
+	Data []byte
+	// GasLimit is the gas provided for the call execution
+	GasLimit uint64
+	// GasLocked is the gas kept aside for the execution of the callback
+	GasLocked uint64
+}
+
+// AsyncCallback holds the callback which returns the control to the issuer of an asynchronous call
+type AsyncCallback struct {
+	// CallID identifies the asynchronous call the callback answers, being the hash of that call
+	CallID []byte
+	// Destination is the address of the issuer of the asynchronous call
+	Destination []byte
+	// Data holds the callback arguments, the same as in the output transfer
+	Data []byte
+	// GasLimit is the gas provided for the callback execution, including the gas locked by the asynchronous call
+	GasLimit uint64
+	// ReturnCode is the result of the asynchronous call
+	ReturnCode ReturnCode
+}
+
 // LogEntry represents an entry in the contract execution log.
 // TODO: document all fields.
 type LogEntry struct {
@@ -99,6 +129,11 @@ type LogEntry struct {
 	Address    []byte
 	Topics     [][]byte
 	Data       []byte
+	// CorrelationID is the correlation ID of the call which produced the entry, empty when the call had none
+	CorrelationID string
+	// EncodedTopics holds the topics with the addresses in their encoded form. It is set only when the built-in
+	// functions are configured to emit both address forms, see LogAddressRawAndEncoded
+	EncodedTopics [][]byte
 }
 
 // VMOutput is the return data and final account state after a SC execution.
@@ -155,6 +190,22 @@ type VMOutput struct {
 	// The logs should be accessible to the UI.
 	// The logs are part of the transaction receipt.
 	Logs []*LogEntry
+
+	// FollowUpCall is set by the transfer built-in functions when the transferred tokens have to be followed by a
+	// smart contract call on the destination. It holds the call ready to be executed by the host, the same call
+	// being also encoded in the output transfer of the destination account.
+	FollowUpCall *ContractCallInput
+
+	// AsyncCalls holds the asynchronous calls issued during the execution
+	AsyncCalls []*AsyncCall
+
+	// AsyncCallback is set when the execution was an asynchronous call and a callback has to return the control
+	// to the issuer of that call
+	AsyncCallback *AsyncCallback
+
+	// GasBreakdown splits the consumed gas by what it was paid for. It is set only when requested through
+	// ContractCallInput.RecordGasBreakdown and only by the built-in functions reporting it, being nil otherwise
+	GasBreakdown *GasBreakdown
 }
 
 // GetFirstReturnData is a helper function that returns the first ReturnData of VMOutput, interpreted as specified.
@@ -179,6 +230,87 @@ func (vmOutput *VMOutput) GetFirstReturnData(asType vm.ReturnDataKind) (interfac
 	return nil, fmt.Errorf("can't interpret return data")
 }
 
+// Reset empties the VMOutput so the instance can be reused. The return data, the output accounts, the deleted and
+// touched accounts, the logs and the async calls are emptied but remain allocated, so a reused instance fills them
+// without allocating again. Such an instance holds empty, non-nil collections where a newly created one holds nil ones.
+// The collections are shared with whoever kept a reference to them, who must not use them after the reset
+func (vmOutput *VMOutput) Reset() {
+	for key := range vmOutput.OutputAccounts {
+		delete(vmOutput.OutputAccounts, key)
+	}
+	for index := range vmOutput.Logs {
+		vmOutput.Logs[index] = nil
+	}
+	for index := range vmOutput.AsyncCalls {
+		vmOutput.AsyncCalls[index] = nil
+	}
+
+	*vmOutput = VMOutput{
+		ReturnData:      emptyByteSlices(vmOutput.ReturnData),
+		OutputAccounts:  vmOutput.OutputAccounts,
+		DeletedAccounts: emptyByteSlices(vmOutput.DeletedAccounts),
+		TouchedAccounts: emptyByteSlices(vmOutput.TouchedAccounts),
+		Logs:            vmOutput.Logs[:0],
+		AsyncCalls:      vmOutput.AsyncCalls[:0],
+	}
+}
+
+func emptyByteSlices(slices [][]byte) [][]byte {
+	for index := range slices {
+		slices[index] = nil
+	}
+
+	return slices[:0]
+}
+
+// Sort brings the VMOutput to its canonical form so that two executions producing the same effects also produce
+// equal outputs. The deleted and touched accounts are sorted by address. Logs, async calls and the output transfers of
+// each account are not reordered, as their order carries meaning: the built-in functions append them one per processed
+// argument, in the arguments order, and never while iterating a map. OutputAccounts and StorageUpdates remain maps and
+// should be iterated through SortedOutputAccounts and SortedStorageUpdates whenever the order matters
+func (vmOutput *VMOutput) Sort() {
+	sortByteSlices(vmOutput.DeletedAccounts)
+	sortByteSlices(vmOutput.TouchedAccounts)
+}
+
+// SortedOutputAccounts returns the output accounts sorted by address
+func (vmOutput *VMOutput) SortedOutputAccounts() []*OutputAccount {
+	keys := make([]string, 0, len(vmOutput.OutputAccounts))
+	for key := range vmOutput.OutputAccounts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	outputAccounts := make([]*OutputAccount, 0, len(keys))
+	for _, key := range keys {
+		outputAccounts = append(outputAccounts, vmOutput.OutputAccounts[key])
+	}
+
+	return outputAccounts
+}
+
+// SortedStorageUpdates returns the storage updates of the account sorted by their offset
+func (o *OutputAccount) SortedStorageUpdates() []*StorageUpdate {
+	keys := make([]string, 0, len(o.StorageUpdates))
+	for key := range o.StorageUpdates {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	storageUpdates := make([]*StorageUpdate, 0, len(keys))
+	for _, key := range keys {
+		storageUpdates = append(storageUpdates, o.StorageUpdates[key])
+	}
+
+	return storageUpdates
+}
+
+func sortByteSlices(slices [][]byte) {
+	sort.SliceStable(slices, func(i, j int) bool {
+		return bytes.Compare(slices[i], slices[j]) < 0
+	})
+}
+
 // MergeOutputAccounts merges the given account into the current one
 func (o *OutputAccount) MergeOutputAccounts(outAcc *OutputAccount) {
 	if len(outAcc.Address) != 0 {
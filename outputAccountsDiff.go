@@ -0,0 +1,167 @@
+package vmcommon
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+
+	"github.com/Reshusk23/sr-me-core/core/check"
+)
+
+// AccountSnapshot holds the state of an account at a point in time, as needed to compute the output accounts of
+// the calls changing it
+type AccountSnapshot struct {
+	Address []byte
+	Nonce   uint64
+	Balance *big.Int
+	Storage map[string][]byte
+}
+
+// NewAccountSnapshot copies the nonce, the balance and all the stored key-value pairs of the account. The data
+// handler of the account must implement AccountDataIterator
+func NewAccountSnapshot(ctx context.Context, account UserAccountHandler) (*AccountSnapshot, error) {
+	if check.IfNil(account) {
+		return nil, ErrNilAccount
+	}
+	iterator, ok := account.AccountDataHandler().(AccountDataIterator)
+	if !ok || check.IfNil(iterator) {
+		return nil, ErrAccountDataNotIterable
+	}
+
+	snapshot := &AccountSnapshot{
+		Address: copyBytes(account.AddressBytes()),
+		Nonce:   account.GetNonce(),
+		Balance: big.NewInt(0),
+		Storage: make(map[string][]byte),
+	}
+	if account.GetBalance() != nil {
+		snapshot.Balance.Set(account.GetBalance())
+	}
+
+	err := iterator.GetAllLeaves(ctx, nil, func(key []byte, value []byte) error {
+		snapshot.Storage[string(key)] = copyBytes(value)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+// ComputeOutputAccounts returns the output accounts turning the pre state into the post state: the changed and the
+// deleted storage keys, the balance delta and the new nonce of each changed account. The accounts missing from the
+// pre state are considered empty, while all the accounts of the pre state must be found in the post state. The
+// accounts left unchanged are not part of the output
+func ComputeOutputAccounts(pre []*AccountSnapshot, post []*AccountSnapshot) (map[string]*OutputAccount, error) {
+	preByAddress, err := snapshotsByAddress(pre)
+	if err != nil {
+		return nil, err
+	}
+	postByAddress, err := snapshotsByAddress(post)
+	if err != nil {
+		return nil, err
+	}
+	for address := range preByAddress {
+		_, found := postByAddress[address]
+		if !found {
+			return nil, ErrMissingPostSnapshot
+		}
+	}
+
+	outputAccounts := make(map[string]*OutputAccount)
+	for address, postSnapshot := range postByAddress {
+		preSnapshot, found := preByAddress[address]
+		if !found {
+			preSnapshot = &AccountSnapshot{Address: postSnapshot.Address}
+		}
+
+		outputAccount := computeOutputAccount(preSnapshot, postSnapshot)
+		if outputAccount != nil {
+			outputAccounts[address] = outputAccount
+		}
+	}
+
+	return outputAccounts, nil
+}
+
+func snapshotsByAddress(snapshots []*AccountSnapshot) (map[string]*AccountSnapshot, error) {
+	byAddress := make(map[string]*AccountSnapshot, len(snapshots))
+	for _, snapshot := range snapshots {
+		if snapshot == nil {
+			return nil, ErrNilAccountSnapshot
+		}
+		_, found := byAddress[string(snapshot.Address)]
+		if found {
+			return nil, ErrDuplicatedAccountSnapshot
+		}
+
+		byAddress[string(snapshot.Address)] = snapshot
+	}
+
+	return byAddress, nil
+}
+
+func computeOutputAccount(pre *AccountSnapshot, post *AccountSnapshot) *OutputAccount {
+	balanceDelta := big.NewInt(0).Sub(bigIntOrZero(post.Balance), bigIntOrZero(pre.Balance))
+	outputAccount := &OutputAccount{
+		Address:        copyBytes(post.Address),
+		Nonce:          post.Nonce,
+		Balance:        big.NewInt(0).Set(bigIntOrZero(post.Balance)),
+		BalanceDelta:   balanceDelta,
+		StorageUpdates: make(map[string]*StorageUpdate),
+	}
+
+	for key, value := range post.Storage {
+		previous := pre.Storage[key]
+		if bytes.Equal(previous, value) {
+			continue
+		}
+
+		addStorageUpdate(outputAccount, key, previous, value)
+	}
+	for key, previous := range pre.Storage {
+		_, found := post.Storage[key]
+		if found || len(previous) == 0 {
+			continue
+		}
+
+		addStorageUpdate(outputAccount, key, previous, nil)
+	}
+
+	isChanged := len(outputAccount.StorageUpdates) > 0 || balanceDelta.Sign() != 0 || pre.Nonce != post.Nonce
+	if !isChanged {
+		return nil
+	}
+
+	return outputAccount
+}
+
+func addStorageUpdate(outputAccount *OutputAccount, key string, previous []byte, value []byte) {
+	outputAccount.StorageUpdates[key] = &StorageUpdate{
+		Offset: []byte(key),
+		Data:   copyBytes(value),
+	}
+
+	if len(value) > len(previous) {
+		outputAccount.BytesAddedToStorage += uint64(len(value) - len(previous))
+		return
+	}
+	outputAccount.BytesDeletedFromStorage += uint64(len(previous) - len(value))
+}
+
+func bigIntOrZero(value *big.Int) *big.Int {
+	if value == nil {
+		return big.NewInt(0)
+	}
+
+	return value
+}
+
+func copyBytes(value []byte) []byte {
+	if value == nil {
+		return nil
+	}
+
+	return append(make([]byte, 0, len(value)), value...)
+}
@@ -0,0 +1,224 @@
+package vmcommon
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type accountDataForTest struct {
+	storage map[string][]byte
+}
+
+func (adt *accountDataForTest) RetrieveValue(key []byte) ([]byte, uint32, error) {
+	return adt.storage[string(key)], 0, nil
+}
+
+func (adt *accountDataForTest) SaveKeyValue(key []byte, value []byte) error {
+	adt.storage[string(key)] = value
+	return nil
+}
+
+func (adt *accountDataForTest) GetAllLeaves(_ context.Context, _ []byte, handler LeafHandler) error {
+	for key, value := range adt.storage {
+		err := handler([]byte(key), value)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (adt *accountDataForTest) IsInterfaceNil() bool {
+	return adt == nil
+}
+
+type accountForTest struct {
+	UserAccountHandler
+	address     []byte
+	nonce       uint64
+	balance     *big.Int
+	dataHandler AccountDataHandler
+}
+
+func (aft *accountForTest) AddressBytes() []byte {
+	return aft.address
+}
+
+func (aft *accountForTest) GetNonce() uint64 {
+	return aft.nonce
+}
+
+func (aft *accountForTest) GetBalance() *big.Int {
+	return aft.balance
+}
+
+func (aft *accountForTest) AccountDataHandler() AccountDataHandler {
+	return aft.dataHandler
+}
+
+func (aft *accountForTest) IsInterfaceNil() bool {
+	return aft == nil
+}
+
+func TestNewAccountSnapshot(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil account should error", func(t *testing.T) {
+		t.Parallel()
+
+		snapshot, err := NewAccountSnapshot(context.Background(), nil)
+		assert.Nil(t, snapshot)
+		assert.Equal(t, ErrNilAccount, err)
+	})
+	t.Run("not iterable account data should error", func(t *testing.T) {
+		t.Parallel()
+
+		account := &accountForTest{address: []byte("addr")}
+		snapshot, err := NewAccountSnapshot(context.Background(), account)
+		assert.Nil(t, snapshot)
+		assert.Equal(t, ErrAccountDataNotIterable, err)
+	})
+	t.Run("iteration error should error", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		account := &accountForTest{
+			address:     []byte("addr"),
+			dataHandler: &accountDataForTest{storage: map[string][]byte{"key": []byte("value")}},
+		}
+		snapshot, err := NewAccountSnapshot(ctx, &accountWithFailingIteration{accountForTest: account})
+		assert.Nil(t, snapshot)
+		assert.True(t, errors.Is(err, context.Canceled))
+	})
+	t.Run("should copy the state", func(t *testing.T) {
+		t.Parallel()
+
+		dataHandler := &accountDataForTest{storage: map[string][]byte{"key": []byte("value")}}
+		account := &accountForTest{
+			address:     []byte("addr"),
+			nonce:       3,
+			balance:     big.NewInt(100),
+			dataHandler: dataHandler,
+		}
+		snapshot, err := NewAccountSnapshot(context.Background(), account)
+		require.Nil(t, err)
+		assert.Equal(t, &AccountSnapshot{
+			Address: []byte("addr"),
+			Nonce:   3,
+			Balance: big.NewInt(100),
+			Storage: map[string][]byte{"key": []byte("value")},
+		}, snapshot)
+
+		account.balance.SetInt64(50)
+		dataHandler.storage["key"][0] = 'x'
+		assert.Equal(t, big.NewInt(100), snapshot.Balance)
+		assert.Equal(t, []byte("value"), snapshot.Storage["key"])
+	})
+}
+
+type accountWithFailingIteration struct {
+	*accountForTest
+}
+
+func (awfi *accountWithFailingIteration) AccountDataHandler() AccountDataHandler {
+	return &failingAccountData{accountDataForTest: awfi.dataHandler.(*accountDataForTest)}
+}
+
+type failingAccountData struct {
+	*accountDataForTest
+}
+
+func (fad *failingAccountData) GetAllLeaves(ctx context.Context, _ []byte, _ LeafHandler) error {
+	return ctx.Err()
+}
+
+func TestComputeOutputAccounts(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid snapshots should error", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ComputeOutputAccounts([]*AccountSnapshot{nil}, nil)
+		assert.Equal(t, ErrNilAccountSnapshot, err)
+
+		_, err = ComputeOutputAccounts(nil, []*AccountSnapshot{{Address: []byte("addr")}, {Address: []byte("addr")}})
+		assert.Equal(t, ErrDuplicatedAccountSnapshot, err)
+
+		_, err = ComputeOutputAccounts([]*AccountSnapshot{{Address: []byte("addr")}}, nil)
+		assert.Equal(t, ErrMissingPostSnapshot, err)
+	})
+	t.Run("unchanged accounts should be omitted", func(t *testing.T) {
+		t.Parallel()
+
+		pre := []*AccountSnapshot{{Address: []byte("addr"), Nonce: 1, Balance: big.NewInt(10), Storage: map[string][]byte{"key": []byte("value")}}}
+		post := []*AccountSnapshot{{Address: []byte("addr"), Nonce: 1, Balance: big.NewInt(10), Storage: map[string][]byte{"key": []byte("value")}}}
+		outputAccounts, err := ComputeOutputAccounts(pre, post)
+		require.Nil(t, err)
+		assert.Empty(t, outputAccounts)
+	})
+	t.Run("should compute the differences", func(t *testing.T) {
+		t.Parallel()
+
+		pre := []*AccountSnapshot{
+			{
+				Address: []byte("addr1"),
+				Nonce:   1,
+				Balance: big.NewInt(10),
+				Storage: map[string][]byte{"changed": []byte("old"), "deleted": []byte("value"), "same": []byte("same")},
+			},
+			{
+				Address: []byte("addr2"),
+				Nonce:   4,
+				Balance: big.NewInt(10),
+			},
+		}
+		post := []*AccountSnapshot{
+			{
+				Address: []byte("addr1"),
+				Nonce:   1,
+				Balance: big.NewInt(7),
+				Storage: map[string][]byte{"changed": []byte("newer"), "added": []byte("v"), "same": []byte("same")},
+			},
+			{
+				Address: []byte("addr2"),
+				Nonce:   5,
+				Balance: big.NewInt(10),
+			},
+			{
+				Address: []byte("addr3"),
+				Balance: big.NewInt(3),
+			},
+		}
+
+		outputAccounts, err := ComputeOutputAccounts(pre, post)
+		require.Nil(t, err)
+		require.Len(t, outputAccounts, 3)
+
+		assert.Equal(t, &OutputAccount{
+			Address:      []byte("addr1"),
+			Nonce:        1,
+			Balance:      big.NewInt(7),
+			BalanceDelta: big.NewInt(-3),
+			StorageUpdates: map[string]*StorageUpdate{
+				"changed": {Offset: []byte("changed"), Data: []byte("newer")},
+				"added":   {Offset: []byte("added"), Data: []byte("v")},
+				"deleted": {Offset: []byte("deleted")},
+			},
+			BytesAddedToStorage:     3,
+			BytesDeletedFromStorage: 5,
+		}, outputAccounts["addr1"])
+
+		assert.Equal(t, uint64(5), outputAccounts["addr2"].Nonce)
+		assert.Equal(t, 0, outputAccounts["addr2"].BalanceDelta.Sign())
+		assert.Empty(t, outputAccounts["addr2"].StorageUpdates)
+
+		assert.Equal(t, big.NewInt(3), outputAccounts["addr3"].BalanceDelta)
+	})
+}
@@ -0,0 +1,134 @@
+package vmcommon
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strings"
+
+	"github.com/Reshusk23/sr-me-core/core"
+	"github.com/Reshusk23/sr-me-core/data/vm"
+)
+
+// OutputTransferAggregationConfig defines which output transfers of an account can be merged into a single one, so
+// that a call moving funds to the same receiver many times produces a single smart contract result. A zero value
+// disables the aggregation
+type OutputTransferAggregationConfig struct {
+	AggregateValueTransfers bool
+	AggregateDCTTransfers   bool
+}
+
+// IsEnabled returns true if at least one kind of output transfer can be aggregated
+func (config OutputTransferAggregationConfig) IsEnabled() bool {
+	return config.AggregateValueTransfers || config.AggregateDCTTransfers
+}
+
+type aggregationKey struct {
+	sender  string
+	tokenID string
+}
+
+type aggregatedTransfer struct {
+	index   int
+	tokenID []byte
+	amount  *big.Int
+}
+
+// AggregateOutputTransfers merges the output transfers of all the output accounts and returns how many transfers
+// were removed
+func (vmOutput *VMOutput) AggregateOutputTransfers(config OutputTransferAggregationConfig) int {
+	numRemoved := 0
+	for _, outAcc := range vmOutput.OutputAccounts {
+		numRemoved += outAcc.AggregateOutputTransfers(config)
+	}
+
+	return numRemoved
+}
+
+// AggregateOutputTransfers merges the direct transfers of the account which share the sender and the transferred
+// token, summing up their values and gas. Only plain value transfers and fungible DCTTransfer calls without any
+// further arguments are merged, and never across a transfer which cannot be merged, as that transfer might observe
+// the balances. The merged transfer takes the position of the first transfer it replaces, so the result only depends
+// on the initial order. It returns how many transfers were removed
+func (o *OutputAccount) AggregateOutputTransfers(config OutputTransferAggregationConfig) int {
+	if !config.IsEnabled() || len(o.OutputTransfers) < 2 {
+		return 0
+	}
+
+	aggregated := make([]OutputTransfer, 0, len(o.OutputTransfers))
+	run := make(map[aggregationKey]*aggregatedTransfer)
+	for _, outTransfer := range o.OutputTransfers {
+		tokenID, amount, ok := parseAggregableTransfer(outTransfer, config)
+		if !ok {
+			aggregated = append(aggregated, outTransfer)
+			run = make(map[aggregationKey]*aggregatedTransfer)
+			continue
+		}
+
+		key := aggregationKey{
+			sender:  string(outTransfer.SenderAddress),
+			tokenID: string(tokenID),
+		}
+		existing, found := run[key]
+		if !found {
+			run[key] = &aggregatedTransfer{
+				index:   len(aggregated),
+				tokenID: tokenID,
+				amount:  amount,
+			}
+			aggregated = append(aggregated, copyOutputTransfer(outTransfer))
+			continue
+		}
+
+		merged := &aggregated[existing.index]
+		merged.Value.Add(merged.Value, ZeroValueIfNil(outTransfer.Value))
+		merged.GasLimit += outTransfer.GasLimit
+		if len(existing.tokenID) > 0 {
+			existing.amount.Add(existing.amount, amount)
+			merged.Data = createDCTTransferData(existing.tokenID, existing.amount)
+		}
+	}
+
+	numRemoved := len(o.OutputTransfers) - len(aggregated)
+	o.OutputTransfers = aggregated
+
+	return numRemoved
+}
+
+func parseAggregableTransfer(outTransfer OutputTransfer, config OutputTransferAggregationConfig) ([]byte, *big.Int, bool) {
+	if outTransfer.CallType != vm.DirectCall || outTransfer.GasLocked > 0 {
+		return nil, nil, false
+	}
+	if len(outTransfer.Data) == 0 {
+		return nil, nil, config.AggregateValueTransfers
+	}
+	if !config.AggregateDCTTransfers {
+		return nil, nil, false
+	}
+
+	parts := strings.Split(string(outTransfer.Data), "@")
+	if len(parts) != 3 || parts[0] != core.BuiltInFunctionDCTTransfer {
+		return nil, nil, false
+	}
+	tokenID, err := hex.DecodeString(parts[1])
+	if err != nil || len(tokenID) == 0 {
+		return nil, nil, false
+	}
+	amountBytes, err := hex.DecodeString(parts[2])
+	if err != nil {
+		return nil, nil, false
+	}
+
+	return tokenID, big.NewInt(0).SetBytes(amountBytes), true
+}
+
+func createDCTTransferData(tokenID []byte, amount *big.Int) []byte {
+	return []byte(core.BuiltInFunctionDCTTransfer + "@" + hex.EncodeToString(tokenID) + "@" + hex.EncodeToString(amount.Bytes()))
+}
+
+func copyOutputTransfer(outTransfer OutputTransfer) OutputTransfer {
+	outTransfer.Value = big.NewInt(0).Set(ZeroValueIfNil(outTransfer.Value))
+	outTransfer.Data = copyBytes(outTransfer.Data)
+	outTransfer.SenderAddress = copyBytes(outTransfer.SenderAddress)
+
+	return outTransfer
+}
@@ -0,0 +1,210 @@
+package vmcommon
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/Reshusk23/sr-me-core/data/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var aggregateAll = OutputTransferAggregationConfig{
+	AggregateValueTransfers: true,
+	AggregateDCTTransfers:   true,
+}
+
+func createDCTOutputTransfer(sender string, tokenID string, amount int64, gasLimit uint64) OutputTransfer {
+	return OutputTransfer{
+		Value:         big.NewInt(0),
+		GasLimit:      gasLimit,
+		Data:          createDCTTransferData([]byte(tokenID), big.NewInt(amount)),
+		CallType:      vm.DirectCall,
+		SenderAddress: []byte(sender),
+	}
+}
+
+func createValueOutputTransfer(sender string, value int64) OutputTransfer {
+	return OutputTransfer{
+		Value:         big.NewInt(value),
+		CallType:      vm.DirectCall,
+		SenderAddress: []byte(sender),
+	}
+}
+
+func sumOutputTransfers(outTransfers []OutputTransfer) (*big.Int, map[string]*big.Int, uint64) {
+	value := big.NewInt(0)
+	tokens := make(map[string]*big.Int)
+	gasLimit := uint64(0)
+	for _, outTransfer := range outTransfers {
+		value.Add(value, outTransfer.Value)
+		gasLimit += outTransfer.GasLimit
+
+		tokenID, amount, ok := parseAggregableTransfer(outTransfer, aggregateAll)
+		if !ok || len(tokenID) == 0 {
+			continue
+		}
+		if tokens[string(tokenID)] == nil {
+			tokens[string(tokenID)] = big.NewInt(0)
+		}
+		tokens[string(tokenID)].Add(tokens[string(tokenID)], amount)
+	}
+
+	return value, tokens, gasLimit
+}
+
+func TestOutputTransferAggregationConfig_IsEnabled(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, OutputTransferAggregationConfig{}.IsEnabled())
+	assert.True(t, OutputTransferAggregationConfig{AggregateValueTransfers: true}.IsEnabled())
+	assert.True(t, OutputTransferAggregationConfig{AggregateDCTTransfers: true}.IsEnabled())
+}
+
+func TestOutputAccount_AggregateOutputTransfers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("disabled config should not change the transfers", func(t *testing.T) {
+		t.Parallel()
+
+		outAcc := &OutputAccount{
+			OutputTransfers: []OutputTransfer{
+				createValueOutputTransfer("sender", 1),
+				createValueOutputTransfer("sender", 2),
+			},
+		}
+
+		numRemoved := outAcc.AggregateOutputTransfers(OutputTransferAggregationConfig{})
+		assert.Zero(t, numRemoved)
+		assert.Len(t, outAcc.OutputTransfers, 2)
+	})
+	t.Run("should merge the transfers of the same token and conserve the values", func(t *testing.T) {
+		t.Parallel()
+
+		outTransfers := []OutputTransfer{
+			createDCTOutputTransfer("sender", "TKN-abcdef", 10, 100),
+			createValueOutputTransfer("sender", 5),
+			createDCTOutputTransfer("sender", "OTHER-abcdef", 3, 0),
+			createDCTOutputTransfer("sender", "TKN-abcdef", 250, 50),
+			createValueOutputTransfer("sender", 7),
+			createDCTOutputTransfer("sender", "TKN-abcdef", 1, 0),
+		}
+		outAcc := &OutputAccount{OutputTransfers: outTransfers}
+		expectedValue, expectedTokens, expectedGas := sumOutputTransfers(outTransfers)
+
+		numRemoved := outAcc.AggregateOutputTransfers(aggregateAll)
+		assert.Equal(t, 3, numRemoved)
+		require.Len(t, outAcc.OutputTransfers, 3)
+
+		value, tokens, gasLimit := sumOutputTransfers(outAcc.OutputTransfers)
+		assert.Equal(t, expectedValue, value)
+		assert.Equal(t, expectedTokens, tokens)
+		assert.Equal(t, expectedGas, gasLimit)
+
+		assert.Equal(t, "DCTTransfer@"+hex.EncodeToString([]byte("TKN-abcdef"))+"@0105", string(outAcc.OutputTransfers[0].Data))
+		assert.Equal(t, uint64(150), outAcc.OutputTransfers[0].GasLimit)
+		assert.Equal(t, big.NewInt(12), outAcc.OutputTransfers[1].Value)
+		assert.Equal(t, createDCTTransferData([]byte("OTHER-abcdef"), big.NewInt(3)), outAcc.OutputTransfers[2].Data)
+	})
+	t.Run("should not merge across a transfer which cannot be merged", func(t *testing.T) {
+		t.Parallel()
+
+		scCall := OutputTransfer{
+			Value:         big.NewInt(0),
+			Data:          []byte("DCTTransfer@544b4e2d616263646566@01@66756e63"),
+			CallType:      vm.DirectCall,
+			SenderAddress: []byte("sender"),
+		}
+		outAcc := &OutputAccount{
+			OutputTransfers: []OutputTransfer{
+				createValueOutputTransfer("sender", 1),
+				scCall,
+				createValueOutputTransfer("sender", 2),
+				createValueOutputTransfer("sender", 3),
+			},
+		}
+
+		numRemoved := outAcc.AggregateOutputTransfers(aggregateAll)
+		assert.Equal(t, 1, numRemoved)
+		require.Len(t, outAcc.OutputTransfers, 3)
+		assert.Equal(t, big.NewInt(1), outAcc.OutputTransfers[0].Value)
+		assert.Equal(t, scCall, outAcc.OutputTransfers[1])
+		assert.Equal(t, big.NewInt(5), outAcc.OutputTransfers[2].Value)
+	})
+	t.Run("should not merge the transfers of different senders or call types", func(t *testing.T) {
+		t.Parallel()
+
+		asyncTransfer := createValueOutputTransfer("sender", 3)
+		asyncTransfer.CallType = vm.AsynchronousCall
+		outAcc := &OutputAccount{
+			OutputTransfers: []OutputTransfer{
+				createValueOutputTransfer("sender", 1),
+				createValueOutputTransfer("other", 2),
+				asyncTransfer,
+			},
+		}
+
+		numRemoved := outAcc.AggregateOutputTransfers(aggregateAll)
+		assert.Zero(t, numRemoved)
+		assert.Len(t, outAcc.OutputTransfers, 3)
+	})
+	t.Run("should only merge the configured kinds of transfers", func(t *testing.T) {
+		t.Parallel()
+
+		outAcc := &OutputAccount{
+			OutputTransfers: []OutputTransfer{
+				createValueOutputTransfer("sender", 1),
+				createValueOutputTransfer("sender", 2),
+				createDCTOutputTransfer("sender", "TKN-abcdef", 10, 0),
+				createDCTOutputTransfer("sender", "TKN-abcdef", 20, 0),
+			},
+		}
+
+		numRemoved := outAcc.AggregateOutputTransfers(OutputTransferAggregationConfig{AggregateDCTTransfers: true})
+		assert.Equal(t, 1, numRemoved)
+		require.Len(t, outAcc.OutputTransfers, 3)
+		assert.Equal(t, createDCTTransferData([]byte("TKN-abcdef"), big.NewInt(30)), outAcc.OutputTransfers[2].Data)
+	})
+	t.Run("should not modify the initial transfers", func(t *testing.T) {
+		t.Parallel()
+
+		first := createValueOutputTransfer("sender", 1)
+		outAcc := &OutputAccount{
+			OutputTransfers: []OutputTransfer{first, createValueOutputTransfer("sender", 2)},
+		}
+
+		_ = outAcc.AggregateOutputTransfers(aggregateAll)
+		assert.Equal(t, big.NewInt(1), first.Value)
+		assert.Equal(t, big.NewInt(3), outAcc.OutputTransfers[0].Value)
+	})
+}
+
+func TestVMOutput_AggregateOutputTransfers(t *testing.T) {
+	t.Parallel()
+
+	vmOutput := &VMOutput{
+		OutputAccounts: map[string]*OutputAccount{
+			"first": {
+				Address: []byte("first"),
+				OutputTransfers: []OutputTransfer{
+					createValueOutputTransfer("sender", 1),
+					createValueOutputTransfer("sender", 2),
+				},
+			},
+			"second": {
+				Address: []byte("second"),
+				OutputTransfers: []OutputTransfer{
+					createDCTOutputTransfer("sender", "TKN-abcdef", 1, 0),
+					createDCTOutputTransfer("sender", "TKN-abcdef", 1, 0),
+					createDCTOutputTransfer("sender", "TKN-abcdef", 1, 0),
+				},
+			},
+		},
+	}
+
+	numRemoved := vmOutput.AggregateOutputTransfers(aggregateAll)
+	assert.Equal(t, 3, numRemoved)
+	assert.Len(t, vmOutput.OutputAccounts["first"].OutputTransfers, 1)
+	assert.Len(t, vmOutput.OutputAccounts["second"].OutputTransfers, 1)
+}
@@ -87,3 +87,158 @@ func TestOutputContext_MergeCompleteAccounts(t *testing.T) {
 	left.MergeOutputAccounts(right)
 	require.Equal(t, expected, left)
 }
+
+func TestVMOutput_Reset(t *testing.T) {
+	t.Parallel()
+
+	logEntry := &LogEntry{Identifier: []byte("identifier")}
+	vmOutput := &VMOutput{
+		ReturnData:      [][]byte{[]byte("data")},
+		ReturnCode:      UserError,
+		ReturnMessage:   "message",
+		GasRemaining:    10,
+		GasRefund:       big.NewInt(1),
+		OutputAccounts:  map[string]*OutputAccount{"addr": {}},
+		DeletedAccounts: [][]byte{[]byte("deleted")},
+		TouchedAccounts: [][]byte{[]byte("touched")},
+		Logs:            []*LogEntry{logEntry},
+		AsyncCalls:      []*AsyncCall{{}},
+		GasBreakdown:    &GasBreakdown{},
+	}
+	outputAccounts := vmOutput.OutputAccounts
+	logs := vmOutput.Logs
+
+	vmOutput.Reset()
+	require.Equal(t, &VMOutput{
+		ReturnData:      [][]byte{},
+		OutputAccounts:  map[string]*OutputAccount{},
+		DeletedAccounts: [][]byte{},
+		TouchedAccounts: [][]byte{},
+		Logs:            []*LogEntry{},
+		AsyncCalls:      []*AsyncCall{},
+	}, vmOutput)
+
+	// the collections remain allocated, without referencing their previous content
+	vmOutput.OutputAccounts["other"] = &OutputAccount{}
+	assert.Len(t, outputAccounts, 1)
+	assert.Equal(t, 1, cap(vmOutput.Logs))
+	assert.Nil(t, logs[0])
+
+	vmOutput = &VMOutput{}
+	vmOutput.Reset()
+	require.Equal(t, &VMOutput{}, vmOutput)
+}
+
+func TestVMOutputPool_ReleasedInstancesAreEmpty(t *testing.T) {
+	t.Parallel()
+
+	ReleaseVMOutput(nil)
+
+	vmOutput := NewVMOutputFromPool()
+	vmOutput.ReturnMessage = "message"
+	vmOutput.Logs = append(vmOutput.Logs, &LogEntry{})
+	ReleaseVMOutput(vmOutput)
+
+	vmOutput = NewVMOutputFromPool()
+	assert.Empty(t, vmOutput.ReturnMessage)
+	assert.Empty(t, vmOutput.Logs)
+}
+
+func BenchmarkVMOutputPool(b *testing.B) {
+	logEntry := &LogEntry{Identifier: []byte("identifier")}
+	outputAccount := &OutputAccount{Address: []byte("address")}
+	fill := func(vmOutput *VMOutput) {
+		vmOutput.ReturnData = append(vmOutput.ReturnData, []byte("data"))
+		vmOutput.Logs = append(vmOutput.Logs, logEntry, logEntry)
+		if vmOutput.OutputAccounts == nil {
+			vmOutput.OutputAccounts = make(map[string]*OutputAccount)
+		}
+		vmOutput.OutputAccounts["address"] = outputAccount
+	}
+
+	b.Run("new instance", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			vmOutput := &VMOutput{}
+			fill(vmOutput)
+			benchmarkVMOutputSink = vmOutput
+		}
+	})
+	b.Run("pooled instance", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			vmOutput := NewVMOutputFromPool()
+			fill(vmOutput)
+			benchmarkVMOutputSink = vmOutput
+			ReleaseVMOutput(vmOutput)
+		}
+	})
+}
+
+var benchmarkVMOutputSink *VMOutput
+
+func TestVMOutput_Sort(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty output should not panic", func(t *testing.T) {
+		t.Parallel()
+
+		vmOutput := &VMOutput{}
+		vmOutput.Sort()
+		require.Equal(t, &VMOutput{}, vmOutput)
+	})
+	t.Run("should sort accounts lists and keep the execution order of logs and transfers", func(t *testing.T) {
+		t.Parallel()
+
+		logs := []*LogEntry{{Identifier: []byte("second")}, {Identifier: []byte("first")}}
+		transfers := []OutputTransfer{{Data: []byte("b")}, {Data: []byte("a")}}
+		vmOutput := &VMOutput{
+			DeletedAccounts: [][]byte{[]byte("c"), []byte("a"), []byte("b")},
+			TouchedAccounts: [][]byte{[]byte("z"), []byte("y")},
+			Logs:            logs,
+			OutputAccounts: map[string]*OutputAccount{
+				"addr": {Address: []byte("addr"), OutputTransfers: transfers},
+			},
+		}
+
+		vmOutput.Sort()
+		assert.Equal(t, [][]byte{[]byte("a"), []byte("b"), []byte("c")}, vmOutput.DeletedAccounts)
+		assert.Equal(t, [][]byte{[]byte("y"), []byte("z")}, vmOutput.TouchedAccounts)
+		assert.Equal(t, logs, vmOutput.Logs)
+		assert.Equal(t, transfers, vmOutput.OutputAccounts["addr"].OutputTransfers)
+	})
+}
+
+func TestVMOutput_SortedOutputAccounts(t *testing.T) {
+	t.Parallel()
+
+	vmOutput := &VMOutput{
+		OutputAccounts: map[string]*OutputAccount{
+			"c": {Address: []byte("c")},
+			"a": {Address: []byte("a")},
+			"b": {Address: []byte("b")},
+		},
+	}
+
+	for i := 0; i < 10; i++ {
+		sorted := vmOutput.SortedOutputAccounts()
+		require.Equal(t, []*OutputAccount{{Address: []byte("a")}, {Address: []byte("b")}, {Address: []byte("c")}}, sorted)
+	}
+	require.Empty(t, (&VMOutput{}).SortedOutputAccounts())
+}
+
+func TestOutputAccount_SortedStorageUpdates(t *testing.T) {
+	t.Parallel()
+
+	outAcc := &OutputAccount{
+		StorageUpdates: map[string]*StorageUpdate{
+			"key2": {Offset: []byte("key2")},
+			"key1": {Offset: []byte("key1")},
+			"key3": {Offset: []byte("key3")},
+		},
+	}
+
+	sorted := outAcc.SortedStorageUpdates()
+	require.Equal(t, []*StorageUpdate{{Offset: []byte("key1")}, {Offset: []byte("key2")}, {Offset: []byte("key3")}}, sorted)
+	require.Empty(t, (&OutputAccount{}).SortedStorageUpdates())
+}
@@ -0,0 +1,135 @@
+package parsers
+
+import (
+	"bytes"
+	"encoding/hex"
+)
+
+const escapeChar = '\\'
+
+// ArgumentsEncoding selects how the arguments are encoded in the @ separated data
+type ArgumentsEncoding uint8
+
+const (
+	// HexArguments encodes every argument as hex, the format of the transactions data
+	HexArguments ArgumentsEncoding = iota
+	// EscapedArguments keeps the function and the arguments raw, the separator and the escape character being
+	// preceded by a backslash, so that the arguments can carry any byte, including the separator
+	EscapedArguments
+)
+
+// IsValid returns true if the encoding is one of the known encodings
+func (encoding ArgumentsEncoding) IsValid() bool {
+	return encoding == HexArguments || encoding == EscapedArguments
+}
+
+// EncodeFunction returns the function as written in data using the provided encoding. The function is written raw
+// in the hex encoding
+func EncodeFunction(function string, encoding ArgumentsEncoding) string {
+	if encoding == EscapedArguments {
+		return string(escape([]byte(function)))
+	}
+
+	return function
+}
+
+// EncodeArgument returns the argument as written in data using the provided encoding
+func EncodeArgument(argument []byte, encoding ArgumentsEncoding) string {
+	if encoding == EscapedArguments {
+		return string(escape(argument))
+	}
+
+	return hex.EncodeToString(argument)
+}
+
+// JoinArguments builds the data holding the function followed by the arguments, using the provided encoding
+func JoinArguments(function string, arguments [][]byte, encoding ArgumentsEncoding) (string, error) {
+	if !encoding.IsValid() {
+		return "", ErrInvalidArgumentsEncoding
+	}
+	if len(function) == 0 {
+		return "", ErrNilFunction
+	}
+
+	data := EncodeFunction(function, encoding)
+	for _, argument := range arguments {
+		data += atSeparator + EncodeArgument(argument, encoding)
+	}
+
+	return data, nil
+}
+
+// SplitArguments returns the function and the decoded arguments of the data written using the provided encoding
+func SplitArguments(data string, encoding ArgumentsEncoding) (string, [][]byte, error) {
+	if !encoding.IsValid() {
+		return "", nil, ErrInvalidArgumentsEncoding
+	}
+
+	tokens, err := splitTokens(data, encoding)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(tokens) == 0 || len(tokens[0]) == 0 {
+		return "", nil, ErrTokenizeFailed
+	}
+
+	arguments := make([][]byte, 0, len(tokens)-1)
+	for _, token := range tokens[1:] {
+		argument, errDecode := decodeEncodedToken(token, encoding)
+		if errDecode != nil {
+			return "", nil, errDecode
+		}
+
+		arguments = append(arguments, argument)
+	}
+
+	return string(tokens[0]), arguments, nil
+}
+
+// splitTokens splits the data by the separators. The escaped tokens are returned already unescaped, while the hex
+// ones are returned as found in data
+func splitTokens(data string, encoding ArgumentsEncoding) ([][]byte, error) {
+	if encoding == HexArguments {
+		return bytes.Split([]byte(data), []byte(atSeparator)), nil
+	}
+
+	tokens := make([][]byte, 0)
+	token := make([]byte, 0)
+	for i := 0; i < len(data); i++ {
+		switch data[i] {
+		case atSeparatorChar:
+			tokens = append(tokens, token)
+			token = make([]byte, 0)
+		case escapeChar:
+			i++
+			if i == len(data) || (data[i] != atSeparatorChar && data[i] != escapeChar) {
+				return nil, ErrTokenizeFailed
+			}
+			token = append(token, data[i])
+		default:
+			token = append(token, data[i])
+		}
+	}
+
+	return append(tokens, token), nil
+}
+
+func decodeEncodedToken(token []byte, encoding ArgumentsEncoding) ([]byte, error) {
+	if encoding == EscapedArguments {
+		return token, nil
+	}
+
+	return decodeToken(string(token))
+}
+
+func escape(data []byte) []byte {
+	escaped := make([]byte, 0, len(data))
+	for _, c := range data {
+		if c == atSeparatorChar || c == escapeChar {
+			escaped = append(escaped, escapeChar)
+		}
+		escaped = append(escaped, c)
+	}
+
+	return escaped
+}
@@ -0,0 +1,122 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArgumentsEncoding_IsValid(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, HexArguments.IsValid())
+	assert.True(t, EscapedArguments.IsValid())
+	assert.False(t, ArgumentsEncoding(2).IsValid())
+}
+
+func TestEncodeArgument(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "6140625c", EncodeArgument([]byte(`a@b\`), HexArguments))
+	assert.Equal(t, `a\@b\\`, EncodeArgument([]byte(`a@b\`), EscapedArguments))
+	assert.Equal(t, "", EncodeArgument(nil, EscapedArguments))
+	assert.Equal(t, "foo@", EncodeFunction("foo@", HexArguments))
+	assert.Equal(t, `foo\@`, EncodeFunction("foo@", EscapedArguments))
+}
+
+func TestJoinArguments(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid encoding should error", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := JoinArguments("foo", nil, ArgumentsEncoding(2))
+		assert.Equal(t, ErrInvalidArgumentsEncoding, err)
+		assert.Empty(t, data)
+	})
+	t.Run("empty function should error", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := JoinArguments("", nil, HexArguments)
+		assert.Equal(t, ErrNilFunction, err)
+		assert.Empty(t, data)
+	})
+	t.Run("should join the encoded arguments", func(t *testing.T) {
+		t.Parallel()
+
+		arguments := [][]byte{[]byte("a@b"), {}, []byte(`\`)}
+		data, err := JoinArguments("foo", arguments, HexArguments)
+		require.Nil(t, err)
+		assert.Equal(t, "foo@614062@@5c", data)
+
+		data, err = JoinArguments("foo", arguments, EscapedArguments)
+		require.Nil(t, err)
+		assert.Equal(t, `foo@a\@b@@\\`, data)
+	})
+}
+
+func TestSplitArguments(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid encoding should error", func(t *testing.T) {
+		t.Parallel()
+
+		function, arguments, err := SplitArguments("foo", ArgumentsEncoding(2))
+		assert.Equal(t, ErrInvalidArgumentsEncoding, err)
+		assert.Empty(t, function)
+		assert.Nil(t, arguments)
+	})
+	t.Run("malformed data should error", func(t *testing.T) {
+		t.Parallel()
+
+		malformed := []struct {
+			data     string
+			encoding ArgumentsEncoding
+		}{
+			{data: "", encoding: HexArguments},
+			{data: "@00", encoding: HexArguments},
+			{data: "foo@zz", encoding: HexArguments},
+			{data: "", encoding: EscapedArguments},
+			{data: "@a", encoding: EscapedArguments},
+			{data: `foo@a\`, encoding: EscapedArguments},
+			{data: `foo@a\b`, encoding: EscapedArguments},
+		}
+		for _, tc := range malformed {
+			function, arguments, err := SplitArguments(tc.data, tc.encoding)
+			assert.Equal(t, ErrTokenizeFailed, err, tc.data)
+			assert.Empty(t, function)
+			assert.Nil(t, arguments)
+		}
+	})
+	t.Run("hex data should split as the existing format", func(t *testing.T) {
+		t.Parallel()
+
+		function, arguments, err := SplitArguments("foo@0a0a@@0b", HexArguments)
+		require.Nil(t, err)
+		assert.Equal(t, "foo", function)
+		assert.Equal(t, [][]byte{{10, 10}, {}, {11}}, arguments)
+	})
+	t.Run("escaped data should unescape the function and the arguments", func(t *testing.T) {
+		t.Parallel()
+
+		function, arguments, err := SplitArguments(`f\\oo@a\@b@@\\\@`, EscapedArguments)
+		require.Nil(t, err)
+		assert.Equal(t, `f\oo`, function)
+		assert.Equal(t, [][]byte{[]byte("a@b"), {}, []byte(`\@`)}, arguments)
+	})
+	t.Run("joined arguments should split back", func(t *testing.T) {
+		t.Parallel()
+
+		arguments := [][]byte{{0, '@', '\\', 255}, []byte("@@"), {}}
+		for _, encoding := range []ArgumentsEncoding{HexArguments, EscapedArguments} {
+			data, err := JoinArguments("foo", arguments, encoding)
+			require.Nil(t, err)
+
+			function, splitArguments, err := SplitArguments(data, encoding)
+			require.Nil(t, err)
+			assert.Equal(t, "foo", function)
+			assert.Equal(t, arguments, splitArguments)
+		}
+	})
+}
@@ -3,16 +3,35 @@ package parsers
 import "strings"
 
 type callArgsParser struct {
+	encoding ArgumentsEncoding
 }
 
-// NewCallArgsParser creates a new parser
+// NewCallArgsParser creates a new parser of the hex encoded arguments
 func NewCallArgsParser() *callArgsParser {
-	return &callArgsParser{}
+	return &callArgsParser{
+		encoding: HexArguments,
+	}
+}
+
+// NewCallArgsParserWithEncoding creates a new parser of the arguments written using the provided encoding
+func NewCallArgsParserWithEncoding(encoding ArgumentsEncoding) (*callArgsParser, error) {
+	if !encoding.IsValid() {
+		return nil, ErrInvalidArgumentsEncoding
+	}
+
+	return &callArgsParser{
+		encoding: encoding,
+	}, nil
 }
 
 // ParseData parses strings of the following format:
 // functionRaw@argFooHex@argBarHex...
+// or, for the escaped encoding, functionEscaped@argFooEscaped@argBarEscaped...
 func (parser *callArgsParser) ParseData(data string) (string, [][]byte, error) {
+	if parser.encoding != HexArguments {
+		return SplitArguments(data, parser.encoding)
+	}
+
 	var function string
 	var arguments [][]byte
 
@@ -37,6 +56,10 @@ func (parser *callArgsParser) ParseData(data string) (string, [][]byte, error) {
 // ParseArguments parses strings of the following format:
 // argFoo@hex(argBarHex)...
 func (parser *callArgsParser) ParseArguments(data string) ([][]byte, error) {
+	if parser.encoding != HexArguments {
+		return splitTokens(data, parser.encoding)
+	}
+
 	tokens := strings.Split(data, atSeparator)
 	arguments := make([][]byte, 0, len(tokens))
 	arguments = append(arguments, []byte(tokens[0]))
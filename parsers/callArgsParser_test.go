@@ -74,3 +74,36 @@ func TestCallArgsParser_ParseArgsWhenErrorneousInput(t *testing.T) {
 	require.Equal(t, ErrTokenizeFailed, err)
 	require.Nil(t, arguments)
 }
+
+func TestNewCallArgsParserWithEncoding(t *testing.T) {
+	t.Parallel()
+
+	parser, err := NewCallArgsParserWithEncoding(ArgumentsEncoding(2))
+	require.Equal(t, ErrInvalidArgumentsEncoding, err)
+	require.Nil(t, parser)
+
+	parser, err = NewCallArgsParserWithEncoding(HexArguments)
+	require.Nil(t, err)
+	require.Equal(t, NewCallArgsParser(), parser)
+}
+
+func TestCallArgsParser_ParseDataWithEscapedEncoding(t *testing.T) {
+	t.Parallel()
+
+	parser, err := NewCallArgsParserWithEncoding(EscapedArguments)
+	require.Nil(t, err)
+
+	function, arguments, err := parser.ParseData(`fooBar@a\@b@\\`)
+	require.Nil(t, err)
+	require.Equal(t, "fooBar", function)
+	require.Equal(t, [][]byte{[]byte("a@b"), []byte(`\`)}, arguments)
+
+	function, arguments, err = parser.ParseData(`fooBar@a\b`)
+	require.Equal(t, ErrTokenizeFailed, err)
+	require.Equal(t, "", function)
+	require.Nil(t, arguments)
+
+	args, err := parser.ParseArguments(`1\@@a\@b`)
+	require.Nil(t, err)
+	require.Equal(t, [][]byte{[]byte("1@"), []byte("a@b")}, args)
+}
@@ -2,10 +2,24 @@ package datafield
 
 import (
 	"github.com/Reshusk23/sr-me-core/marshal"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
 )
 
-// ArgsOperationDataFieldParser holds all the components required to create a new instance of data field parser
+// ArgsOperationDataFieldParser holds all the components required to create a new instance of data field parser.
+// AddressClassifier is optional, when missing one is created for the provided address length.
+// MaxDataSize and MaxArgs bound the data fields that get split and decoded, a zero value meaning no limit.
+// MetaDCTChecker is optional, when missing the operations with meta dct tokens are not classified as such.
+// AliasResolver is optional, when missing the receivers referenced by alias are not resolved.
+// FunctionResolver is optional, when missing the functions are matched only by their canonical names.
+// ValueFormat describes how the values of the responses are written, the zero value writing plain decimal strings
 type ArgsOperationDataFieldParser struct {
-	AddressLength int
-	Marshalizer   marshal.Marshalizer
+	AddressLength     int
+	Marshalizer       marshal.Marshalizer
+	AddressClassifier vmcommon.AddressClassifier
+	MetaDCTChecker    vmcommon.MetaDCTChecker
+	AliasResolver     vmcommon.AliasResolver
+	FunctionResolver  vmcommon.FunctionResolver
+	MaxDataSize       int
+	MaxArgs           int
+	ValueFormat       ValueFormat
 }
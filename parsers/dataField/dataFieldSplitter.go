@@ -0,0 +1,165 @@
+package datafield
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"sync"
+
+	"github.com/Reshusk23/sr-vm-common-go/parsers"
+)
+
+const (
+	atSeparatorChar          = '@'
+	initialDecodeBufferSize  = 256
+	maxPooledDecodeBufferCap = 64 * 1024
+)
+
+var errTooManyArguments = errors.New("too many arguments")
+
+var dataFieldSplitterPool = sync.Pool{
+	New: func() interface{} {
+		return &dataFieldSplitter{
+			buffer: make([]byte, 0, initialDecodeBufferSize),
+		}
+	},
+}
+
+// dataFieldSplitter splits a data field into the function and its hex encoded arguments without copying
+// the data field. The arguments are hex decoded only when requested, into a buffer reused between parse calls
+type dataFieldSplitter struct {
+	rawArgs     [][]byte
+	decodedArgs [][]byte
+	buffer      []byte
+	isDecoded   bool
+}
+
+func newDataFieldSplitter() *dataFieldSplitter {
+	return dataFieldSplitterPool.Get().(*dataFieldSplitter)
+}
+
+// split returns the function from the data field and validates, without decoding them, all the arguments.
+// A positive maxArgs stops the splitting as soon as the data field is found to hold more arguments
+func (dfs *dataFieldSplitter) split(dataField []byte, maxArgs int) (string, error) {
+	dfs.rawArgs = dfs.rawArgs[:0]
+	dfs.isDecoded = false
+
+	functionEnd := bytes.IndexByte(dataField, atSeparatorChar)
+	if functionEnd < 0 {
+		functionEnd = len(dataField)
+	}
+	if functionEnd == 0 {
+		return "", parsers.ErrTokenizeFailed
+	}
+
+	remaining := dataField[functionEnd:]
+	for len(remaining) > 0 {
+		if maxArgs > 0 && len(dfs.rawArgs) == maxArgs {
+			return "", errTooManyArguments
+		}
+
+		remaining = remaining[1:]
+		argEnd := bytes.IndexByte(remaining, atSeparatorChar)
+		if argEnd < 0 {
+			argEnd = len(remaining)
+		}
+
+		rawArg := remaining[:argEnd]
+		if !isHexEncoded(rawArg) {
+			return "", parsers.ErrTokenizeFailed
+		}
+
+		dfs.rawArgs = append(dfs.rawArgs, rawArg)
+		remaining = remaining[argEnd:]
+	}
+
+	return string(dataField[:functionEnd]), nil
+}
+
+// arguments returns the decoded arguments. The returned slices are only valid until the splitter is released
+func (dfs *dataFieldSplitter) arguments() [][]byte {
+	if dfs.isDecoded {
+		return dfs.decodedArgs
+	}
+
+	decodedLength := 0
+	for _, rawArg := range dfs.rawArgs {
+		decodedLength += hex.DecodedLen(len(rawArg))
+	}
+	if cap(dfs.buffer) < decodedLength {
+		dfs.buffer = make([]byte, 0, decodedLength)
+	}
+
+	buffer := dfs.buffer[:decodedLength]
+	dfs.decodedArgs = dfs.decodedArgs[:0]
+	offset := 0
+	for _, rawArg := range dfs.rawArgs {
+		argLength := hex.DecodedLen(len(rawArg))
+		arg := buffer[offset : offset+argLength : offset+argLength]
+		// the raw arguments were validated when splitting
+		_, _ = hex.Decode(arg, rawArg)
+
+		dfs.decodedArgs = append(dfs.decodedArgs, arg)
+		offset += argLength
+	}
+	dfs.isDecoded = true
+
+	return dfs.decodedArgs
+}
+
+func (dfs *dataFieldSplitter) release() {
+	for i := range dfs.rawArgs {
+		dfs.rawArgs[i] = nil
+	}
+	for i := range dfs.decodedArgs {
+		dfs.decodedArgs[i] = nil
+	}
+	dfs.rawArgs = dfs.rawArgs[:0]
+	dfs.decodedArgs = dfs.decodedArgs[:0]
+	dfs.isDecoded = false
+	if cap(dfs.buffer) > maxPooledDecodeBufferCap {
+		dfs.buffer = make([]byte, 0, initialDecodeBufferSize)
+	}
+
+	dataFieldSplitterPool.Put(dfs)
+}
+
+func isHexEncoded(data []byte) bool {
+	if len(data)%2 != 0 {
+		return false
+	}
+
+	for _, c := range data {
+		isDigit := c >= '0' && c <= '9'
+		isLowerHex := c >= 'a' && c <= 'f'
+		isUpperHex := c >= 'A' && c <= 'F'
+		if !isDigit && !isLowerHex && !isUpperHex {
+			return false
+		}
+	}
+
+	return true
+}
+
+func copyBytes(data []byte) []byte {
+	return append(make([]byte, 0, len(data)), data...)
+}
+
+// copyArguments copies the provided arguments into a single allocation, as the arguments of the splitter are only
+// valid until the splitter is released
+func copyArguments(args [][]byte) [][]byte {
+	totalLength := 0
+	for _, arg := range args {
+		totalLength += len(arg)
+	}
+
+	buffer := make([]byte, 0, totalLength)
+	copied := make([][]byte, 0, len(args))
+	for _, arg := range args {
+		offset := len(buffer)
+		buffer = append(buffer, arg...)
+		copied = append(copied, buffer[offset:len(buffer):len(buffer)])
+	}
+
+	return copied
+}
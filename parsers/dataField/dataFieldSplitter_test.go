@@ -0,0 +1,110 @@
+package datafield
+
+import (
+	"testing"
+
+	"github.com/Reshusk23/sr-vm-common-go/parsers"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataFieldSplitter_Split(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty function should error", func(t *testing.T) {
+		t.Parallel()
+
+		splitter := newDataFieldSplitter()
+		defer splitter.release()
+
+		_, err := splitter.split([]byte(""), 0)
+		require.Equal(t, parsers.ErrTokenizeFailed, err)
+
+		_, err = splitter.split([]byte("@01"), 0)
+		require.Equal(t, parsers.ErrTokenizeFailed, err)
+	})
+	t.Run("invalid hex argument should error", func(t *testing.T) {
+		t.Parallel()
+
+		splitter := newDataFieldSplitter()
+		defer splitter.release()
+
+		_, err := splitter.split([]byte("function@01@1"), 0)
+		require.Equal(t, parsers.ErrTokenizeFailed, err)
+
+		_, err = splitter.split([]byte("function@0g"), 0)
+		require.Equal(t, parsers.ErrTokenizeFailed, err)
+	})
+	t.Run("should split and decode the arguments", func(t *testing.T) {
+		t.Parallel()
+
+		splitter := newDataFieldSplitter()
+		defer splitter.release()
+
+		function, err := splitter.split([]byte("function@0A0b@@ff"), 0)
+		require.Nil(t, err)
+		require.Equal(t, "function", function)
+		require.Equal(t, [][]byte{{10, 11}, {}, {255}}, splitter.arguments())
+	})
+	t.Run("function without arguments", func(t *testing.T) {
+		t.Parallel()
+
+		splitter := newDataFieldSplitter()
+		defer splitter.release()
+
+		function, err := splitter.split([]byte("function"), 0)
+		require.Nil(t, err)
+		require.Equal(t, "function", function)
+		require.Len(t, splitter.arguments(), 0)
+	})
+	t.Run("too many arguments should error", func(t *testing.T) {
+		t.Parallel()
+
+		splitter := newDataFieldSplitter()
+		defer splitter.release()
+
+		function, err := splitter.split([]byte("function@01@02"), 2)
+		require.Nil(t, err)
+		require.Equal(t, "function", function)
+
+		_, err = splitter.split([]byte("function@01@02@03"), 2)
+		require.Equal(t, errTooManyArguments, err)
+	})
+}
+
+func TestDataFieldSplitter_ReuseShouldNotLeakPreviousArguments(t *testing.T) {
+	t.Parallel()
+
+	splitter := newDataFieldSplitter()
+	_, _ = splitter.split([]byte("first@0102030405"), 0)
+	_ = splitter.arguments()
+
+	_, _ = splitter.split([]byte("second@aa"), 0)
+	require.Equal(t, [][]byte{{0xaa}}, splitter.arguments())
+
+	args := splitter.arguments()
+	args[0] = append(args[0], 0xbb)
+	splitter.release()
+}
+
+func TestCallArgsParserAndSplitter_ShouldBeEquivalent(t *testing.T) {
+	t.Parallel()
+
+	dataFields := []string{
+		"DCTTransfer@4d4949552d61626364@05",
+		"function@@",
+		"function@00@FFee",
+		"MultiDCTNFTTransfer@@@@@@@",
+	}
+
+	argsParser := parsers.NewCallArgsParser()
+	for _, dataField := range dataFields {
+		expectedFunction, expectedArgs, expectedErr := argsParser.ParseData(dataField)
+
+		splitter := newDataFieldSplitter()
+		function, err := splitter.split([]byte(dataField), 0)
+		require.Equal(t, expectedErr, err)
+		require.Equal(t, expectedFunction, function)
+		require.Equal(t, expectedArgs, splitter.arguments())
+		splitter.release()
+	}
+}
@@ -1,17 +1,66 @@
 package datafield
 
+import "math/big"
+
 // ResponseParseData is the response with results after the data field was parsed
 type ResponseParseData struct {
 	// Operation field is used to store the name of the operation that the transaction will try to do
 	// an example of operation is `transfer` or `DCTTransfer etc
 	Operation string
 	// Function field is used to store the function name that the transaction will try to call from a smart contract
-	Function         string
+	Function string
+	// IsSCCall is set when the data field is a call of a smart contract function, other than a built-in function
+	IsSCCall bool
+	// Arguments field holds the decoded arguments of the smart contract function call
+	Arguments        [][]byte
 	DCTValues        []string
 	Tokens           []string
 	Receivers        [][]byte
 	ReceiversShardID []uint32
 	IsRelayed        bool
+	// IsMetaDCT is set when the operation moves, creates or burns meta dct tokens
+	IsMetaDCT bool
+	// IsLimitExceeded is set when the data field was rejected, without being decoded, for exceeding the maximum
+	// data size or the maximum number of arguments of the parser
+	IsLimitExceeded bool
+	// ReceiverAliases holds, for each of the Receivers, the alias it was referenced by in the data field. It is
+	// populated only when the receiver of an NFT transfer was referenced by alias
+	ReceiverAliases [][]byte
+	// IsGuarded is set when the operation was co-signed by the guardian of the account executing it. For the relayed
+	// transactions it refers to the inner transaction, as the guardian of the relayer only guards the relayer
+	IsGuarded bool
+	// Relayer holds the address of the relayer, populated only for the relayed transactions
+	Relayer []byte
+	// Nonces holds, for each of the Tokens, its nonce, 0 for the fungible tokens. It is populated together with the
+	// Tokens, only for the NFT and multi transfers, so the indexers do not have to split the NFT identifiers
+	Nonces []uint64
+	// DisplayIdentifiers holds, for each of the transfers, the identifier of the transferred token in the display form
+	// used by the explorers, built with tokenident.FormatDisplayIdentifier. It is populated only when requested
+	DisplayIdentifiers []string
+	// IsDelegation is set when the data field is a delegation operation of the delegation and staking system smart
+	// contracts: delegate, unDelegate, claimRewards, reDelegateRewards or withdraw
+	IsDelegation bool
+	// StakedValue holds the value delegated by the delegate operations, populated only when the value of the
+	// transaction is provided through ParseOptions
+	StakedValue string
+	// UnstakedValue holds the value undelegated by the unDelegate operations
+	UnstakedValue string
+}
+
+// ParseOptions describes the transaction or the smart contract result holding the data field, as the guardian and
+// relayer information is not part of the data field itself
+type ParseOptions struct {
+	// IsGuarded marks the transaction as co-signed by the guardian of its sender
+	IsGuarded bool
+	// Relayer marks the transaction as relayed by the provided address, the data field being the one of the user.
+	// Relayed transactions built into the data field are not allowed in such a transaction
+	Relayer []byte
+	// IsSCResult marks the data field as the one of a smart contract result. The transfers it carries are executed on
+	// its receiver, so the receiver is never read from the data field. The guardian and relayer options are ignored
+	IsSCResult bool
+	// Value is the value of the transaction or smart contract result, used as the staked value of the delegate
+	// operations
+	Value *big.Int
 }
 
 func NewResponseParseDataAsRelayed() *ResponseParseData {
@@ -19,3 +68,29 @@ func NewResponseParseDataAsRelayed() *ResponseParseData {
 		IsRelayed: true,
 	}
 }
+
+// ResponseFields selects the optional fields of ResponseParseData that a parse call should materialize.
+// Operation, Function, IsSCCall, IsRelayed, IsMetaDCT, IsLimitExceeded, IsDelegation, StakedValue and UnstakedValue
+// are always populated
+type ResponseFields uint8
+
+const (
+	// FieldTokens requests the Tokens field
+	FieldTokens ResponseFields = 1 << iota
+	// FieldDCTValues requests the DCTValues field
+	FieldDCTValues
+	// FieldReceivers requests the Receivers, ReceiversShardID and ReceiverAliases fields
+	FieldReceivers
+	// FieldArguments requests the Arguments field
+	FieldArguments
+	// FieldDisplayIdentifiers requests the DisplayIdentifiers field. It is not part of AllResponseFields, as the Tokens
+	// already hold the same identifiers for most operations, so it must be requested explicitly
+	FieldDisplayIdentifiers
+
+	// AllResponseFields requests all the optional fields populated by default
+	AllResponseFields = FieldTokens | FieldDCTValues | FieldReceivers | FieldArguments
+)
+
+func (fields ResponseFields) has(field ResponseFields) bool {
+	return fields&field == field
+}
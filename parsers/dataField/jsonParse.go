@@ -0,0 +1,137 @@
+package datafield
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+
+	"github.com/Reshusk23/sr-me-core/core"
+	"github.com/Reshusk23/sr-me-core/core/check"
+	"github.com/Reshusk23/sr-me-core/marshal"
+	"github.com/Reshusk23/sr-vm-common-go/tokenident"
+)
+
+const defaultAddressLength = 32
+
+var (
+	defaultParser     *operationDataFieldParser
+	defaultParserErr  error
+	defaultParserOnce sync.Once
+)
+
+// JSONOptions configures the encoding of the JSON parse responses
+type JSONOptions struct {
+	// PubkeyConverter encodes the receivers, for example as bech32. When missing, the receivers are hex encoded
+	PubkeyConverter core.PubkeyConverter
+}
+
+// ResponseParseDataJSON is the JSON representation of ResponseParseData. The byte fields are encoded, so the output
+// can be consumed by tools written in other languages. The order of the fields never changes
+type ResponseParseDataJSON struct {
+	Operation        string   `json:"operation"`
+	Function         string   `json:"function"`
+	IsSCCall         bool     `json:"isSCCall"`
+	Arguments        []string `json:"arguments"`
+	DCTValues        []string `json:"dctValues"`
+	Tokens           []string `json:"tokens"`
+	Receivers        []string `json:"receivers"`
+	ReceiversShardID []uint32 `json:"receiversShardID"`
+	ReceiverAliases  []string `json:"receiverAliases"`
+	IsRelayed        bool     `json:"isRelayed"`
+	IsMetaDCT        bool     `json:"isMetaDCT"`
+	IsLimitExceeded  bool     `json:"isLimitExceeded"`
+	IsGuarded        bool     `json:"isGuarded"`
+	Relayer          string   `json:"relayer"`
+	Nonces           []uint64 `json:"nonces"`
+	NoncesHex        []string `json:"noncesHex"`
+}
+
+// ParseToJSON parses the provided data field with a parser using the default address length and marshaller, returning
+// the response as JSON with the arguments and the receivers hex encoded
+func ParseToJSON(dataField []byte, sender, receiver []byte, numOfShards uint32) ([]byte, error) {
+	defaultParserOnce.Do(func() {
+		defaultParser, defaultParserErr = NewOperationDataFieldParser(&ArgsOperationDataFieldParser{
+			AddressLength: defaultAddressLength,
+			Marshalizer:   &marshal.GogoProtoMarshalizer{},
+		})
+	})
+	if defaultParserErr != nil {
+		return nil, defaultParserErr
+	}
+
+	return defaultParser.ParseToJSON(dataField, sender, receiver, numOfShards, JSONOptions{})
+}
+
+// ParseToJSON parses the provided data field, returning the response as JSON encoded with the provided options
+func (odp *operationDataFieldParser) ParseToJSON(dataField []byte, sender, receiver []byte, numOfShards uint32, options JSONOptions) ([]byte, error) {
+	res := odp.Parse(dataField, sender, receiver, numOfShards)
+
+	return json.Marshal(NewResponseParseDataJSON(res, options))
+}
+
+// NewResponseParseDataJSON converts the provided response to its JSON representation. The nil slices are converted
+// to empty ones, so the JSON output always holds arrays
+func NewResponseParseDataJSON(res *ResponseParseData, options JSONOptions) *ResponseParseDataJSON {
+	encodeAddress := hex.EncodeToString
+	if !check.IfNil(options.PubkeyConverter) {
+		encodeAddress = options.PubkeyConverter.Encode
+	}
+
+	relayer := ""
+	if len(res.Relayer) > 0 {
+		relayer = encodeAddress(res.Relayer)
+	}
+
+	noncesHex := make([]string, 0, len(res.Nonces))
+	for _, nonce := range res.Nonces {
+		noncesHex = append(noncesHex, tokenident.EncodeNonce(nonce))
+	}
+
+	receiverAliases := make([]string, 0, len(res.ReceiverAliases))
+	for _, alias := range res.ReceiverAliases {
+		receiverAliases = append(receiverAliases, string(alias))
+	}
+
+	return &ResponseParseDataJSON{
+		Operation:        res.Operation,
+		Function:         res.Function,
+		IsSCCall:         res.IsSCCall,
+		Arguments:        EncodeBytesSlice(hex.EncodeToString, res.Arguments),
+		DCTValues:        nonNilStrings(res.DCTValues),
+		Tokens:           nonNilStrings(res.Tokens),
+		Receivers:        EncodeBytesSlice(encodeAddress, res.Receivers),
+		ReceiversShardID: nonNilShardIDs(res.ReceiversShardID),
+		ReceiverAliases:  receiverAliases,
+		IsRelayed:        res.IsRelayed,
+		IsMetaDCT:        res.IsMetaDCT,
+		IsLimitExceeded:  res.IsLimitExceeded,
+		IsGuarded:        res.IsGuarded,
+		Relayer:          relayer,
+		Nonces:           nonNilNonces(res.Nonces),
+		NoncesHex:        noncesHex,
+	}
+}
+
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return make([]string, 0)
+	}
+
+	return values
+}
+
+func nonNilNonces(nonces []uint64) []uint64 {
+	if nonces == nil {
+		return make([]uint64, 0)
+	}
+
+	return nonces
+}
+
+func nonNilShardIDs(shardIDs []uint32) []uint32 {
+	if shardIDs == nil {
+		return make([]uint32, 0)
+	}
+
+	return shardIDs
+}
@@ -0,0 +1,100 @@
+package datafield
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var jsonSender = bytes.Repeat([]byte{1}, 32)
+var jsonReceiver = bytes.Repeat([]byte{2}, 32)
+
+func TestParseToJSON(t *testing.T) {
+	t.Parallel()
+
+	t.Run("NFTTransferWithHexReceivers", func(t *testing.T) {
+		t.Parallel()
+
+		dataField := []byte("DCTNFTTransfer@4e46542d616263646566@02@05@" + hex.EncodeToString(jsonReceiver))
+		output, err := ParseToJSON(dataField, jsonSender, jsonSender, 3)
+		require.Nil(t, err)
+
+		expected := `{"operation":"DCTNFTTransfer","function":"","isSCCall":false,"arguments":[],"dctValues":["5"],` +
+			`"tokens":["NFT-abcdef-02"],"receivers":["` + hex.EncodeToString(jsonReceiver) + `"],"receiversShardID":[2],` +
+			`"receiverAliases":[],"isRelayed":false,"isMetaDCT":false,"isLimitExceeded":false,"isGuarded":false,"relayer":"",` +
+			`"nonces":[2],"noncesHex":["02"]}`
+		assert.Equal(t, expected, string(output))
+	})
+
+	t.Run("SCCallArgumentsAreHexEncoded", func(t *testing.T) {
+		t.Parallel()
+
+		scAddress, _ := hex.DecodeString("0000000000000000050029db735b3741223dae79a2ce284ccfad5f53d0e3ab19")
+		output, err := ParseToJSON([]byte("callMe@01@abcd"), jsonSender, scAddress, 3)
+		require.Nil(t, err)
+
+		res := &ResponseParseDataJSON{}
+		require.Nil(t, json.Unmarshal(output, res))
+		assert.Equal(t, "callMe", res.Function)
+		assert.True(t, res.IsSCCall)
+		assert.Equal(t, []string{"01", "abcd"}, res.Arguments)
+		assert.Empty(t, res.Receivers)
+	})
+
+	t.Run("SameOutputForRepeatedCalls", func(t *testing.T) {
+		t.Parallel()
+
+		dataField := []byte("DCTTransfer@544f4b454e@0a")
+		first, err := ParseToJSON(dataField, jsonSender, jsonReceiver, 3)
+		require.Nil(t, err)
+		second, err := ParseToJSON(dataField, jsonSender, jsonReceiver, 3)
+		require.Nil(t, err)
+		assert.Equal(t, first, second)
+	})
+}
+
+func TestOperationDataFieldParser_ParseToJSON(t *testing.T) {
+	t.Parallel()
+
+	parser, _ := NewOperationDataFieldParser(createMockArgumentsOperationParser())
+
+	dataField := []byte("DCTNFTTransfer@4e46542d616263646566@02@05@" + hex.EncodeToString(jsonReceiver))
+	output, err := parser.ParseToJSON(dataField, jsonSender, jsonSender, 3, JSONOptions{PubkeyConverter: pubKeyConv})
+	require.Nil(t, err)
+
+	res := &ResponseParseDataJSON{}
+	require.Nil(t, json.Unmarshal(output, res))
+	assert.Equal(t, "DCTNFTTransfer", res.Operation)
+	assert.Equal(t, []string{pubKeyConv.Encode(jsonReceiver)}, res.Receivers)
+	assert.Equal(t, []string{"NFT-abcdef-02"}, res.Tokens)
+}
+
+func TestNewResponseParseDataJSON(t *testing.T) {
+	t.Parallel()
+
+	res := NewResponseParseDataJSON(&ResponseParseData{
+		Operation:        "DCTNFTTransfer",
+		Receivers:        [][]byte{jsonReceiver},
+		ReceiversShardID: []uint32{1},
+		ReceiverAliases:  [][]byte{[]byte("alias")},
+	}, JSONOptions{})
+	assert.Equal(t, []string{hex.EncodeToString(jsonReceiver)}, res.Receivers)
+	assert.Equal(t, []string{"alias"}, res.ReceiverAliases)
+	assert.Equal(t, []string{}, res.Arguments)
+	assert.Equal(t, []string{}, res.Tokens)
+	assert.Equal(t, []string{}, res.DCTValues)
+	assert.Equal(t, []uint64{}, res.Nonces)
+	assert.Equal(t, []string{}, res.NoncesHex)
+
+	res = NewResponseParseDataJSON(&ResponseParseData{
+		Operation: "MultiDCTNFTTransfer",
+		Tokens:    []string{"TOKEN-abcdef", "NFT-abcdef-0d3d"},
+		Nonces:    []uint64{0, 0x0d3d},
+	}, JSONOptions{})
+	assert.Equal(t, []uint64{0, 3389}, res.Nonces)
+	assert.Equal(t, []string{"", "0d3d"}, res.NoncesHex)
+}
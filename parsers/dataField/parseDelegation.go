@@ -0,0 +1,51 @@
+package datafield
+
+import "math/big"
+
+const (
+	delegationFunctionDelegate          = "delegate"
+	delegationFunctionUnDelegate        = "unDelegate"
+	delegationFunctionClaimRewards      = "claimRewards"
+	delegationFunctionReDelegateRewards = "reDelegateRewards"
+	delegationFunctionWithdraw          = "withdraw"
+
+	argsUnDelegateValuePosition = 0
+)
+
+func isDelegationFunction(function string) bool {
+	switch function {
+	case delegationFunctionDelegate, delegationFunctionUnDelegate, delegationFunctionClaimRewards,
+		delegationFunctionReDelegateRewards, delegationFunctionWithdraw:
+		return true
+	default:
+		return false
+	}
+}
+
+// parseDelegationOperation parses a call of the delegation and staking system smart contracts. The delegated value is
+// the value of the transaction, known only if provided, while the undelegated one is the first argument. The rewards
+// claimed, redelegated or withdrawn are computed by the smart contract, so they are not part of the response
+func parseDelegationOperation(args [][]byte, function string, value *big.Int, fields ResponseFields, valueFormat ValueFormat) *ResponseParseData {
+	responseData := &ResponseParseData{
+		Operation:    function,
+		Function:     function,
+		IsSCCall:     true,
+		IsDelegation: true,
+	}
+	if fields.has(FieldArguments) {
+		responseData.Arguments = copyArguments(args)
+	}
+
+	switch function {
+	case delegationFunctionDelegate:
+		if value != nil {
+			responseData.StakedValue = valueFormat.Format(value)
+		}
+	case delegationFunctionUnDelegate:
+		if len(args) > argsUnDelegateValuePosition {
+			responseData.UnstakedValue = valueFormat.Format(big.NewInt(0).SetBytes(args[argsUnDelegateValuePosition]))
+		}
+	}
+
+	return responseData
+}
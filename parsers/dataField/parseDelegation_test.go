@@ -0,0 +1,95 @@
+package datafield
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/Reshusk23/sr-me-core/core"
+	"github.com/Reshusk23/sr-me-core/data/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDelegationOperations(t *testing.T) {
+	t.Parallel()
+
+	arguments := createMockArgumentsOperationParser()
+	parser, _ := NewOperationDataFieldParser(arguments)
+
+	delegationContract, _ := hex.DecodeString("000000000000000000010000000000000000000000000000000000000006ffff")
+	scAddress, _ := hex.DecodeString("0000000000000000050029db735b3741223dae79a2ce284ccfad5f53d0e3ab19")
+
+	t.Run("delegate with value", func(t *testing.T) {
+		t.Parallel()
+
+		res := parser.ParseWithOptions([]byte("delegate"), sender, delegationContract, 3, ParseOptions{Value: big.NewInt(1000)})
+		require.Equal(t, &ResponseParseData{
+			Operation:    "delegate",
+			Function:     "delegate",
+			IsSCCall:     true,
+			IsDelegation: true,
+			Arguments:    [][]byte{},
+			StakedValue:  "1000",
+		}, res)
+	})
+	t.Run("delegate without value", func(t *testing.T) {
+		t.Parallel()
+
+		res := parser.Parse([]byte("delegate"), sender, delegationContract, 3)
+		require.True(t, res.IsDelegation)
+		require.Empty(t, res.StakedValue)
+	})
+	t.Run("unDelegate", func(t *testing.T) {
+		t.Parallel()
+
+		res := parser.ParseFields([]byte("unDelegate@0de0b6b3a7640000"), sender, delegationContract, 3, FieldTokens)
+		require.Equal(t, &ResponseParseData{
+			Operation:     "unDelegate",
+			Function:      "unDelegate",
+			IsSCCall:      true,
+			IsDelegation:  true,
+			UnstakedValue: "1000000000000000000",
+		}, res)
+	})
+	t.Run("rewards and withdraw", func(t *testing.T) {
+		t.Parallel()
+
+		for _, function := range []string{"claimRewards", "reDelegateRewards", "withdraw"} {
+			res := parser.ParseWithOptions([]byte(function), sender, delegationContract, 3, ParseOptions{Value: big.NewInt(0)})
+			require.Equal(t, function, res.Operation)
+			require.True(t, res.IsDelegation, function)
+			require.Empty(t, res.StakedValue, function)
+			require.Empty(t, res.UnstakedValue, function)
+		}
+	})
+	t.Run("regular smart contract should not be a delegation", func(t *testing.T) {
+		t.Parallel()
+
+		res := parser.ParseWithOptions([]byte("delegate"), sender, scAddress, 3, ParseOptions{Value: big.NewInt(1000)})
+		require.Equal(t, &ResponseParseData{
+			Operation: operationTransfer,
+			Function:  "delegate",
+			IsSCCall:  true,
+			Arguments: [][]byte{},
+		}, res)
+	})
+	t.Run("relayed delegate should use the value of the inner transaction", func(t *testing.T) {
+		t.Parallel()
+
+		innerTx := &transaction.Transaction{
+			SndAddr: sender,
+			RcvAddr: delegationContract,
+			Value:   big.NewInt(500),
+			Data:    []byte("delegate"),
+		}
+		txBytes, _ := json.Marshal(innerTx)
+		dataField := []byte(core.RelayedTransaction + "@" + hex.EncodeToString(txBytes))
+
+		res := parser.Parse(dataField, sender, receiver, 3)
+		require.True(t, res.IsRelayed)
+		require.True(t, res.IsDelegation)
+		require.Equal(t, "delegate", res.Operation)
+		require.Equal(t, "500", res.StakedValue)
+	})
+}
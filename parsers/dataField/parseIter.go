@@ -0,0 +1,96 @@
+package datafield
+
+import "errors"
+
+var errNilTransferHandler = errors.New("nil transfer handler")
+var errInvalidTokenName = errors.New("invalid token name")
+
+// ParsedTransfer is one of the token entries of a data field, passed by ParseIter to its handler. It holds, for a
+// single entry, the values the Tokens, Nonces, DCTValues, Receivers, ReceiversShardID and ReceiverAliases fields of
+// ResponseParseData hold for all of them
+type ParsedTransfer struct {
+	// Token is the identifier of the token in the display form, as in the Tokens field
+	Token string
+	// Nonce is the nonce of the token, 0 for the fungible tokens and for the operations not populating the Nonces
+	Nonce uint64
+	Value string
+	// Receiver and ReceiverShardID are set only for the operations populating the Receivers field
+	Receiver        []byte
+	ReceiverShardID uint32
+	// ReceiverAlias is set only when the receiver was referenced by alias in the data field
+	ReceiverAlias []byte
+}
+
+// TransferHandler is called by ParseIter for each of the token entries of the data field, in order
+type TransferHandler func(transfer ParsedTransfer) error
+
+// ParseIter parses the provided data field as Parse does, passing the token entries to the handler one at a time
+// instead of materializing them in the response, which then holds none of the Tokens, Nonces, DCTValues, Receivers,
+// ReceiversShardID and ReceiverAliases fields. The transfers of the multi transfers are decoded one at a time, so the
+// transactions holding many transfers are parsed in bounded memory. The parsing stops on the first handler error,
+// which is returned. A data field found invalid after some of its entries were passed to the handler is reported as
+// Parse does, by a response holding only the operation
+func (odp *operationDataFieldParser) ParseIter(dataField []byte, sender, receiver []byte, numOfShards uint32, handler TransferHandler) (*ResponseParseData, error) {
+	if handler == nil {
+		return nil, errNilTransferHandler
+	}
+
+	var errHandler error
+	iterHandler := func(transfer ParsedTransfer) error {
+		errHandler = handler(transfer)
+		return errHandler
+	}
+
+	res := odp.parse(dataField, sender, receiver, nil, parseModeTransaction, numOfShards, AllResponseFields, iterHandler)
+	if errHandler != nil {
+		return nil, errHandler
+	}
+
+	err := passMaterializedTransfers(res, handler)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// passMaterializedTransfers passes to the handler the token entries the operations other than the multi transfers
+// materialized in the response, and removes them from the response
+func passMaterializedTransfers(res *ResponseParseData, handler TransferHandler) error {
+	if len(res.Tokens) == 0 {
+		return nil
+	}
+
+	for i, token := range res.Tokens {
+		transfer := ParsedTransfer{
+			Token: token,
+		}
+		if i < len(res.Nonces) {
+			transfer.Nonce = res.Nonces[i]
+		}
+		if i < len(res.DCTValues) {
+			transfer.Value = res.DCTValues[i]
+		}
+		if i < len(res.Receivers) {
+			transfer.Receiver = res.Receivers[i]
+			transfer.ReceiverShardID = res.ReceiversShardID[i]
+		}
+		if i < len(res.ReceiverAliases) {
+			transfer.ReceiverAlias = res.ReceiverAliases[i]
+		}
+
+		err := handler(transfer)
+		if err != nil {
+			return err
+		}
+	}
+
+	res.Tokens = nil
+	res.Nonces = nil
+	res.DCTValues = nil
+	res.Receivers = nil
+	res.ReceiversShardID = nil
+	res.ReceiverAliases = nil
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package datafield
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/Reshusk23/sr-me-core/core"
+	"github.com/stretchr/testify/require"
+)
+
+func collectParsedTransfers(transfers *[]ParsedTransfer) TransferHandler {
+	return func(transfer ParsedTransfer) error {
+		*transfers = append(*transfers, transfer)
+		return nil
+	}
+}
+
+func TestOperationDataFieldParser_ParseIter(t *testing.T) {
+	t.Parallel()
+
+	parser, _ := NewOperationDataFieldParser(createMockArgumentsOperationParser())
+
+	t.Run("nil handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := parser.ParseIter([]byte("DCTTransfer@544f4b454e@0a"), sender, receiver, 3, nil)
+		require.Equal(t, errNilTransferHandler, err)
+		require.Nil(t, res)
+	})
+	t.Run("multi transfer should pass the transfers one at a time", func(t *testing.T) {
+		t.Parallel()
+
+		block, txSender := createMultiDCTNFTTransferBlock(1, 3)
+		expectedResponse := parser.Parse(block[0], txSender, txSender, 3)
+
+		var transfers []ParsedTransfer
+		res, err := parser.ParseIter(block[0], txSender, txSender, 3, collectParsedTransfers(&transfers))
+		require.Nil(t, err)
+		require.Equal(t, &ResponseParseData{
+			Operation: core.BuiltInFunctionMultiDCTNFTTransfer,
+		}, res)
+
+		require.Len(t, transfers, len(expectedResponse.Tokens))
+		for i, transfer := range transfers {
+			require.Equal(t, ParsedTransfer{
+				Token:           expectedResponse.Tokens[i],
+				Nonce:           expectedResponse.Nonces[i],
+				Value:           expectedResponse.DCTValues[i],
+				Receiver:        expectedResponse.Receivers[i],
+				ReceiverShardID: expectedResponse.ReceiversShardID[i],
+			}, transfer)
+		}
+	})
+	t.Run("handler error should stop the parsing", func(t *testing.T) {
+		t.Parallel()
+
+		block, txSender := createMultiDCTNFTTransferBlock(1, 3)
+		expectedErr := errors.New("expected error")
+		numCalls := 0
+		res, err := parser.ParseIter(block[0], txSender, txSender, 3, func(transfer ParsedTransfer) error {
+			numCalls++
+			return expectedErr
+		})
+		require.Equal(t, expectedErr, err)
+		require.Nil(t, res)
+		require.Equal(t, 1, numCalls)
+	})
+	t.Run("invalid token name should return only the operation", func(t *testing.T) {
+		t.Parallel()
+
+		txSender := bytes.Repeat([]byte{1}, 32)
+		dataField := []byte("MultiDCTNFTTransfer@" + "0202020202020202020202020202020202020202020202020202020202020202" +
+			"@02@544b4e2d616263646566@@0a@ff@@0a")
+
+		var transfers []ParsedTransfer
+		res, err := parser.ParseIter(dataField, txSender, txSender, 3, collectParsedTransfers(&transfers))
+		require.Nil(t, err)
+		require.Equal(t, &ResponseParseData{
+			Operation: core.BuiltInFunctionMultiDCTNFTTransfer,
+		}, res)
+		require.Len(t, transfers, 1)
+		require.Equal(t, parser.Parse(dataField, txSender, txSender, 3), res)
+	})
+	t.Run("single transfer should pass the materialized transfer", func(t *testing.T) {
+		t.Parallel()
+
+		var transfers []ParsedTransfer
+		res, err := parser.ParseIter([]byte("DCTTransfer@544f4b454e2d616263646566@0a"), sender, receiver, 3, collectParsedTransfers(&transfers))
+		require.Nil(t, err)
+		require.Equal(t, &ResponseParseData{
+			Operation: core.BuiltInFunctionDCTTransfer,
+		}, res)
+		require.Equal(t, []ParsedTransfer{{
+			Token:           "TOKEN-abcdef",
+			Value:           "10",
+			Receiver:        receiver,
+			ReceiverShardID: 0,
+		}}, transfers)
+	})
+}
@@ -1,20 +1,31 @@
 package datafield
 
 import (
-	"github.com/Reshusk23/sr-me-core/core"
-	"github.com/Reshusk23/sr-me-core/core/sharding"
+	"bytes"
+
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/tokenident"
 )
 
-func (odp *operationDataFieldParser) parseMultiDCTNFTTransfer(args [][]byte, function string, sender, receiver []byte, numOfShards uint32) *ResponseParseData {
-	responseParse, parsedDCTTransfers, ok := odp.extractDCTData(args, function, sender, receiver)
+func (odp *operationDataFieldParser) parseMultiDCTNFTTransfer(args [][]byte, function string, sender, receiver []byte, mode parseMode, numOfShards uint32, fields ResponseFields, handler TransferHandler) *ResponseParseData {
+	var alias []byte
+	if bytes.Equal(sender, receiver) && mode != parseModeSCResult {
+		args, alias = odp.resolveReceiverAlias(args, receiverIndexMultiDCTNFTTransfer)
+	}
+	if handler != nil {
+		return odp.iterateMultiDCTNFTTransfer(args, function, receiver, alias, mode, numOfShards, handler)
+	}
+
+	responseParse, parsedDCTTransfers, ok := odp.extractDCTData(args, function, sender, receiver, mode)
 	if !ok {
 		return responseParse
 	}
-	if core.IsSmartContractAddress(parsedDCTTransfers.RcvAddr) && isASCIIString(parsedDCTTransfers.CallFunction) {
+	if odp.addressClassifier.IsSmartContract(parsedDCTTransfers.RcvAddr) && isASCIIString(parsedDCTTransfers.CallFunction) {
 		responseParse.Function = parsedDCTTransfers.CallFunction
 	}
 
-	receiverShardID := sharding.ComputeShardID(parsedDCTTransfers.RcvAddr, numOfShards)
+	var receiverAddress []byte
+	receiverShardID := odp.addressClassifier.ShardOf(parsedDCTTransfers.RcvAddr, numOfShards)
 	for _, dctTransferData := range parsedDCTTransfers.DCTTransfers {
 		if !isASCIIString(string(dctTransferData.DCTTokenName)) {
 			return &ResponseParseData{
@@ -22,15 +33,75 @@ func (odp *operationDataFieldParser) parseMultiDCTNFTTransfer(args [][]byte, fun
 			}
 		}
 
-		token := string(dctTransferData.DCTTokenName)
-		if dctTransferData.DCTTokenNonce != 0 {
-			token = computeTokenIdentifier(token, dctTransferData.DCTTokenNonce)
+		if dctTransferData.DCTTokenNonce != 0 && !responseParse.IsMetaDCT {
+			responseParse.IsMetaDCT = odp.isMetaDCT(dctTransferData.DCTTokenName)
+		}
+		displayIdentifier := tokenident.FormatDisplayIdentifier(string(dctTransferData.DCTTokenName), dctTransferData.DCTTokenNonce)
+		if fields.has(FieldTokens) {
+			responseParse.Tokens = append(responseParse.Tokens, displayIdentifier)
+			responseParse.Nonces = append(responseParse.Nonces, dctTransferData.DCTTokenNonce)
+		}
+		if fields.has(FieldDisplayIdentifiers) {
+			responseParse.DisplayIdentifiers = append(responseParse.DisplayIdentifiers, displayIdentifier)
+		}
+		if fields.has(FieldDCTValues) {
+			responseParse.DCTValues = append(responseParse.DCTValues, odp.valueFormat.Format(dctTransferData.DCTValue))
 		}
+		if fields.has(FieldReceivers) {
+			if receiverAddress == nil {
+				receiverAddress = copyBytes(parsedDCTTransfers.RcvAddr)
+			}
+			responseParse.Receivers = append(responseParse.Receivers, receiverAddress)
+			responseParse.ReceiversShardID = append(responseParse.ReceiversShardID, receiverShardID)
+			if alias != nil {
+				responseParse.ReceiverAliases = append(responseParse.ReceiverAliases, alias)
+			}
+		}
+	}
+
+	return responseParse
+}
 
-		responseParse.Tokens = append(responseParse.Tokens, token)
-		responseParse.DCTValues = append(responseParse.DCTValues, dctTransferData.DCTValue.String())
-		responseParse.Receivers = append(responseParse.Receivers, parsedDCTTransfers.RcvAddr)
-		responseParse.ReceiversShardID = append(responseParse.ReceiversShardID, receiverShardID)
+// iterateMultiDCTNFTTransfer passes the transfers of the multi transfer to the handler one at a time. As with the
+// materialized transfers, a token with an invalid name stops the parsing and the response holds only the operation
+func (odp *operationDataFieldParser) iterateMultiDCTNFTTransfer(args [][]byte, function string, receiver []byte, alias []byte, mode parseMode, numOfShards uint32, handler TransferHandler) *ResponseParseData {
+	responseParse := &ResponseParseData{
+		Operation: function,
+	}
+
+	var receiverAddress []byte
+	var receiverShardID uint32
+	transferHandler := func(rcvAddr []byte, dctTransferData *vmcommon.DCTTransfer) error {
+		if !isASCIIString(string(dctTransferData.DCTTokenName)) {
+			return errInvalidTokenName
+		}
+
+		if dctTransferData.DCTTokenNonce != 0 && !responseParse.IsMetaDCT {
+			responseParse.IsMetaDCT = odp.isMetaDCT(dctTransferData.DCTTokenName)
+		}
+		if receiverAddress == nil {
+			receiverAddress = copyBytes(rcvAddr)
+			receiverShardID = odp.addressClassifier.ShardOf(rcvAddr, numOfShards)
+		}
+
+		return handler(ParsedTransfer{
+			Token:           tokenident.FormatDisplayIdentifier(string(dctTransferData.DCTTokenName), dctTransferData.DCTTokenNonce),
+			Nonce:           dctTransferData.DCTTokenNonce,
+			Value:           odp.valueFormat.Format(dctTransferData.DCTValue),
+			Receiver:        receiverAddress,
+			ReceiverShardID: receiverShardID,
+			ReceiverAlias:   alias,
+		})
+	}
+
+	parsedDCTTransfers, err := odp.dctTransferParser.IterateMultiDCTNFTTransfers(receiver, args, mode == parseModeSCResult, transferHandler)
+	if err != nil {
+		return &ResponseParseData{
+			Operation: function,
+		}
+	}
+	if odp.addressClassifier.IsSmartContract(parsedDCTTransfers.RcvAddr) && isASCIIString(parsedDCTTransfers.CallFunction) {
+		responseParse.Function = parsedDCTTransfers.CallFunction
 	}
 
 	return responseParse
@@ -1,8 +1,14 @@
 package datafield
 
 import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math/big"
 	"testing"
 
+	"github.com/Reshusk23/sr-me-core/core"
+	"github.com/Reshusk23/sr-me-core/data/dct"
 	"github.com/stretchr/testify/require"
 )
 
@@ -92,3 +98,128 @@ func TestMultiDCTNFTTransferParse(t *testing.T) {
 		}, res)
 	})
 }
+
+func createMultiDCTNFTTransferBlock(numTxs int, numTransfersPerTx int) ([][]byte, []byte) {
+	txSender := bytes.Repeat([]byte{1}, 32)
+	dataField := "MultiDCTNFTTransfer@" + hex.EncodeToString(bytes.Repeat([]byte{2}, 32))
+	dataField += "@" + hex.EncodeToString(big.NewInt(int64(numTransfersPerTx)).Bytes())
+	for i := 0; i < numTransfersPerTx; i++ {
+		dataField += "@" + hex.EncodeToString([]byte(fmt.Sprintf("TOKEN%d-abcdef", i)))
+		dataField += "@" + hex.EncodeToString(big.NewInt(int64(i)).Bytes())
+		dataField += "@" + hex.EncodeToString(big.NewInt(1000000).Bytes())
+	}
+
+	block := make([][]byte, numTxs)
+	for i := range block {
+		block[i] = []byte(dataField)
+	}
+
+	return block, txSender
+}
+
+func TestMultiDCTNFTTransferParse_WithFields(t *testing.T) {
+	t.Parallel()
+
+	parser, _ := NewOperationDataFieldParser(createMockArgumentsOperationParser())
+	block, txSender := createMultiDCTNFTTransferBlock(1, 2)
+
+	res := parser.Parse(block[0], txSender, txSender, 3)
+	require.Equal(t, []string{"TOKEN0-abcdef", "TOKEN1-abcdef-01"}, res.Tokens)
+	require.Equal(t, []string{"1000000", "1000000"}, res.DCTValues)
+	require.Equal(t, [][]byte{bytes.Repeat([]byte{2}, 32), bytes.Repeat([]byte{2}, 32)}, res.Receivers)
+
+	res = parser.ParseFields(block[0], txSender, txSender, 3, FieldTokens)
+	require.Equal(t, &ResponseParseData{
+		Operation: core.BuiltInFunctionMultiDCTNFTTransfer,
+		Tokens:    []string{"TOKEN0-abcdef", "TOKEN1-abcdef-01"},
+		Nonces:    []uint64{0, 1},
+	}, res)
+	res = parser.ParseFields(block[0], txSender, txSender, 3, FieldDisplayIdentifiers)
+	require.Equal(t, &ResponseParseData{
+		Operation:          core.BuiltInFunctionMultiDCTNFTTransfer,
+		DisplayIdentifiers: []string{"TOKEN0-abcdef", "TOKEN1-abcdef-01"},
+	}, res)
+}
+
+func TestMultiDCTNFTTransferParse_SCResult(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgumentsOperationParser()
+	parser, _ := NewOperationDataFieldParser(args)
+	scrSender := bytes.Repeat([]byte{1}, 32)
+	scrReceiver := bytes.Repeat([]byte{2}, 32)
+	marshaledNFT, _ := args.Marshalizer.Marshal(&dct.DCToken{Value: big.NewInt(7)})
+	dataField := []byte("MultiDCTNFTTransfer@02" +
+		"@" + hex.EncodeToString([]byte("NFT-abcdef")) + "@05@" + hex.EncodeToString(marshaledNFT) +
+		"@" + hex.EncodeToString([]byte("TKN-abcdef")) + "@@0a")
+	expectedResponse := &ResponseParseData{
+		Operation:        core.BuiltInFunctionMultiDCTNFTTransfer,
+		Tokens:           []string{"NFT-abcdef-05", "TKN-abcdef"},
+		Nonces:           []uint64{5, 0},
+		DCTValues:        []string{"7", "10"},
+		Receivers:        [][]byte{scrReceiver, scrReceiver},
+		ReceiversShardID: []uint32{0, 0},
+	}
+
+	t.Run("should populate the transfers to the receiver of the smart contract result", func(t *testing.T) {
+		t.Parallel()
+
+		res := parser.ParseWithOptions(dataField, scrSender, scrReceiver, 1, ParseOptions{IsSCResult: true})
+		require.Equal(t, expectedResponse, res)
+	})
+	t.Run("should populate the transfers of an account to itself", func(t *testing.T) {
+		t.Parallel()
+
+		res := parser.ParseWithOptions(dataField, scrReceiver, scrReceiver, 1, ParseOptions{IsSCResult: true})
+		require.Equal(t, expectedResponse, res)
+	})
+	t.Run("should ignore the guardian and relayer options", func(t *testing.T) {
+		t.Parallel()
+
+		options := ParseOptions{IsSCResult: true, IsGuarded: true, Relayer: scrSender}
+		res := parser.ParseWithOptions(dataField, scrSender, scrReceiver, 1, options)
+		require.Equal(t, expectedResponse, res)
+	})
+	t.Run("should not read the receiver from the data field", func(t *testing.T) {
+		t.Parallel()
+
+		block, txSender := createMultiDCTNFTTransferBlock(1, 1)
+		res := parser.ParseWithOptions(block[0], txSender, txSender, 1, ParseOptions{IsSCResult: true})
+		require.Equal(t, &ResponseParseData{
+			Operation: core.BuiltInFunctionMultiDCTNFTTransfer,
+		}, res)
+	})
+}
+
+func BenchmarkOperationDataFieldParser_ParseMultiDCTNFTTransferBlock(b *testing.B) {
+	parser, _ := NewOperationDataFieldParser(createMockArgumentsOperationParser())
+	block, txSender := createMultiDCTNFTTransferBlock(1000, 10)
+
+	b.Run("all fields", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for _, dataField := range block {
+				_ = parser.Parse(dataField, txSender, txSender, 3)
+			}
+		}
+	})
+	b.Run("tokens only", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for _, dataField := range block {
+				_ = parser.ParseFields(dataField, txSender, txSender, 3, FieldTokens)
+			}
+		}
+	})
+	b.Run("iterator", func(b *testing.B) {
+		b.ReportAllocs()
+		handler := func(transfer ParsedTransfer) error {
+			return nil
+		}
+		for i := 0; i < b.N; i++ {
+			for _, dataField := range block {
+				_, _ = parser.ParseIter(dataField, txSender, txSender, 3, handler)
+			}
+		}
+	})
+}
@@ -0,0 +1,90 @@
+package datafield
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+)
+
+const literalReturnCodeOk = "ok"
+
+var knownReturnCodes = []vmcommon.ReturnCode{
+	vmcommon.Ok,
+	vmcommon.FunctionNotFound,
+	vmcommon.FunctionWrongSignature,
+	vmcommon.ContractNotFound,
+	vmcommon.UserError,
+	vmcommon.OutOfGas,
+	vmcommon.AccountCollision,
+	vmcommon.OutOfFunds,
+	vmcommon.CallStackOverFlow,
+	vmcommon.ContractInvalid,
+	vmcommon.ExecutionFailed,
+	vmcommon.UpgradeFailed,
+	vmcommon.SimulateFailed,
+}
+
+// ResponseParseSCResult is the response with the results decoded from the data field of a smart contract result
+type ResponseParseSCResult struct {
+	ReturnCode vmcommon.ReturnCode
+	ReturnData [][]byte
+}
+
+// ParseSCResult decodes the data field of a smart contract result holding the outcome of an execution, as
+// "@<return code>@<return data>...". The return code is either the hex encoded return code message, as in "@6f6b",
+// the hex encoded numeric return code, as in the "@00" callback payloads, or the literal "ok". The second value is
+// false if the data field is not an execution outcome, in which case it should be parsed as a regular data field
+func (odp *operationDataFieldParser) ParseSCResult(dataField []byte) (*ResponseParseSCResult, bool) {
+	if len(dataField) == 0 || dataField[0] != atSeparatorChar {
+		return nil, false
+	}
+
+	tokens := bytes.Split(dataField[1:], []byte{atSeparatorChar})
+	returnCode, ok := parseReturnCode(tokens[0])
+	if !ok {
+		return nil, false
+	}
+
+	returnData := make([][]byte, 0, len(tokens)-1)
+	for _, token := range tokens[1:] {
+		decoded, err := hex.DecodeString(string(token))
+		if err != nil {
+			return nil, false
+		}
+
+		returnData = append(returnData, decoded)
+	}
+
+	return &ResponseParseSCResult{
+		ReturnCode: returnCode,
+		ReturnData: returnData,
+	}, true
+}
+
+func parseReturnCode(token []byte) (vmcommon.ReturnCode, bool) {
+	if string(token) == literalReturnCodeOk {
+		return vmcommon.Ok, true
+	}
+
+	decoded, err := hex.DecodeString(string(token))
+	if err != nil {
+		return 0, false
+	}
+
+	for _, returnCode := range knownReturnCodes {
+		if string(decoded) == returnCode.String() {
+			return returnCode, true
+		}
+	}
+
+	numericCode := big.NewInt(0).SetBytes(decoded)
+	for _, returnCode := range knownReturnCodes {
+		if numericCode.IsInt64() && numericCode.Int64() == int64(returnCode) {
+			return returnCode, true
+		}
+	}
+
+	return 0, false
+}
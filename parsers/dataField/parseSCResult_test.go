@@ -0,0 +1,66 @@
+package datafield
+
+import (
+	"testing"
+
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperationDataFieldParser_ParseSCResult(t *testing.T) {
+	t.Parallel()
+
+	parser, _ := NewOperationDataFieldParser(createMockArgumentsOperationParser())
+
+	t.Run("hex encoded ok", func(t *testing.T) {
+		t.Parallel()
+
+		res, ok := parser.ParseSCResult([]byte("@6f6b"))
+		require.True(t, ok)
+		require.Equal(t, &ResponseParseSCResult{
+			ReturnCode: vmcommon.Ok,
+			ReturnData: [][]byte{},
+		}, res)
+	})
+	t.Run("literal ok with return data", func(t *testing.T) {
+		t.Parallel()
+
+		res, ok := parser.ParseSCResult([]byte("@ok@01@6869"))
+		require.True(t, ok)
+		require.Equal(t, vmcommon.Ok, res.ReturnCode)
+		require.Equal(t, [][]byte{{1}, []byte("hi")}, res.ReturnData)
+	})
+	t.Run("hex encoded return code message", func(t *testing.T) {
+		t.Parallel()
+
+		res, ok := parser.ParseSCResult([]byte("@75736572206572726f72@6e6f7420656e6f7567682066756e6473"))
+		require.True(t, ok)
+		require.Equal(t, vmcommon.UserError, res.ReturnCode)
+		require.Equal(t, [][]byte{[]byte("not enough funds")}, res.ReturnData)
+	})
+	t.Run("numeric return code of callback payloads", func(t *testing.T) {
+		t.Parallel()
+
+		res, ok := parser.ParseSCResult([]byte("@00@0a"))
+		require.True(t, ok)
+		require.Equal(t, vmcommon.Ok, res.ReturnCode)
+		require.Equal(t, [][]byte{{10}}, res.ReturnData)
+
+		res, ok = parser.ParseSCResult([]byte("@04"))
+		require.True(t, ok)
+		require.Equal(t, vmcommon.UserError, res.ReturnCode)
+
+		res, ok = parser.ParseSCResult([]byte("@@0a"))
+		require.True(t, ok)
+		require.Equal(t, vmcommon.Ok, res.ReturnCode)
+	})
+	t.Run("user data fields should not be parsed as results", func(t *testing.T) {
+		t.Parallel()
+
+		for _, dataField := range []string{"", "ok", "DCTTransfer@746f6b656e@01", "@6869", "@ff", "@6f6b@zz", "@xyz"} {
+			res, ok := parser.ParseSCResult([]byte(dataField))
+			require.False(t, ok, dataField)
+			require.Nil(t, res)
+		}
+	})
+}
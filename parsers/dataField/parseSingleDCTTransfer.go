@@ -1,17 +1,17 @@
 package datafield
 
 import (
-	"github.com/Reshusk23/sr-me-core/core"
 	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/tokenident"
 )
 
-func (odp *operationDataFieldParser) parseSingleDCTTransfer(args [][]byte, function string, sender, receiver []byte) *ResponseParseData {
-	responseParse, parsedDCTTransfers, ok := odp.extractDCTData(args, function, sender, receiver)
+func (odp *operationDataFieldParser) parseSingleDCTTransfer(args [][]byte, function string, sender, receiver []byte, mode parseMode, fields ResponseFields) *ResponseParseData {
+	responseParse, parsedDCTTransfers, ok := odp.extractDCTData(args, function, sender, receiver, mode)
 	if !ok {
 		return responseParse
 	}
 
-	if core.IsSmartContractAddress(receiver) && isASCIIString(parsedDCTTransfers.CallFunction) {
+	if odp.addressClassifier.IsSmartContract(receiver) && isASCIIString(parsedDCTTransfers.CallFunction) {
 		responseParse.Function = parsedDCTTransfers.CallFunction
 	}
 
@@ -20,18 +20,34 @@ func (odp *operationDataFieldParser) parseSingleDCTTransfer(args [][]byte, funct
 	}
 
 	firstTransfer := parsedDCTTransfers.DCTTransfers[0]
-	responseParse.Tokens = append(responseParse.Tokens, string(firstTransfer.DCTTokenName))
-	responseParse.DCTValues = append(responseParse.DCTValues, firstTransfer.DCTValue.String())
+	if fields.has(FieldTokens) {
+		responseParse.Tokens = append(responseParse.Tokens, string(firstTransfer.DCTTokenName))
+	}
+	if fields.has(FieldDCTValues) {
+		responseParse.DCTValues = append(responseParse.DCTValues, odp.valueFormat.Format(firstTransfer.DCTValue))
+	}
+	if fields.has(FieldDisplayIdentifiers) {
+		displayIdentifier := tokenident.FormatDisplayIdentifier(string(firstTransfer.DCTTokenName), firstTransfer.DCTTokenNonce)
+		responseParse.DisplayIdentifiers = append(responseParse.DisplayIdentifiers, displayIdentifier)
+	}
 
 	return responseParse
 }
 
-func (odp *operationDataFieldParser) extractDCTData(args [][]byte, function string, sender, receiver []byte) (*ResponseParseData, *vmcommon.ParsedDCTTransfers, bool) {
+// extractDCTData parses the transfers of the data field. The data field of a smart contract result is always executed
+// on its receiver, so the receiver is never read from the arguments
+func (odp *operationDataFieldParser) extractDCTData(args [][]byte, function string, sender, receiver []byte, mode parseMode) (*ResponseParseData, *vmcommon.ParsedDCTTransfers, bool) {
 	responseParse := &ResponseParseData{
 		Operation: function,
 	}
 
-	parsedDCTTransfers, err := odp.dctTransferParser.ParseDCTTransfers(sender, receiver, function, args)
+	var parsedDCTTransfers *vmcommon.ParsedDCTTransfers
+	var err error
+	if mode == parseModeSCResult {
+		parsedDCTTransfers, err = odp.dctTransferParser.ParseDCTTransfersOnDestination(receiver, function, args)
+	} else {
+		parsedDCTTransfers, err = odp.dctTransferParser.ParseDCTTransfers(sender, receiver, function, args)
+	}
 	if err != nil {
 		return responseParse, nil, false
 	}
@@ -44,6 +44,17 @@ func TestParseDCTTransfer(t *testing.T) {
 		}, res)
 	})
 
+	t.Run("TransferWithDisplayIdentifiers", func(t *testing.T) {
+		t.Parallel()
+
+		dataField := []byte("DCTTransfer@544f4b454e2d616263646566@0a")
+		res := parser.ParseFields(dataField, sender, receiver, 3, FieldDisplayIdentifiers)
+		require.Equal(t, &ResponseParseData{
+			Operation:          "DCTTransfer",
+			DisplayIdentifiers: []string{"TOKEN-abcdef"},
+		}, res)
+	})
+
 	t.Run("TransferWithSCCall", func(t *testing.T) {
 		t.Parallel()
 
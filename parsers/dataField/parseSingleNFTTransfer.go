@@ -3,17 +3,23 @@ package datafield
 import (
 	"bytes"
 
-	"github.com/Reshusk23/sr-me-core/core"
-	"github.com/Reshusk23/sr-me-core/core/sharding"
+	"github.com/Reshusk23/sr-vm-common-go/tokenident"
 )
 
-func (odp *operationDataFieldParser) parseSingleDCTNFTTransfer(args [][]byte, function string, sender, receiver []byte, numOfShards uint32) *ResponseParseData {
-	responseParse, parsedDCTTransfers, ok := odp.extractDCTData(args, function, sender, receiver)
+func (odp *operationDataFieldParser) parseSingleDCTNFTTransfer(args [][]byte, function string, sender, receiver []byte, mode parseMode, numOfShards uint32, fields ResponseFields) *ResponseParseData {
+	isOnSender := bytes.Equal(sender, receiver) && mode != parseModeSCResult
+
+	var alias []byte
+	if isOnSender {
+		args, alias = odp.resolveReceiverAlias(args, receiverIndexDCTNFTTransfer)
+	}
+
+	responseParse, parsedDCTTransfers, ok := odp.extractDCTData(args, function, sender, receiver, mode)
 	if !ok {
 		return responseParse
 	}
 
-	if core.IsSmartContractAddress(parsedDCTTransfers.RcvAddr) && isASCIIString(parsedDCTTransfers.CallFunction) {
+	if odp.addressClassifier.IsSmartContract(parsedDCTTransfers.RcvAddr) && isASCIIString(parsedDCTTransfers.CallFunction) {
 		responseParse.Function = parsedDCTTransfers.CallFunction
 	}
 
@@ -22,23 +28,35 @@ func (odp *operationDataFieldParser) parseSingleDCTNFTTransfer(args [][]byte, fu
 	}
 
 	rcvAddr := receiver
-	if bytes.Equal(sender, receiver) {
+	if isOnSender {
 		rcvAddr = parsedDCTTransfers.RcvAddr
 	}
 
 	dctNFTTransfer := parsedDCTTransfers.DCTTransfers[0]
-	receiverShardID := sharding.ComputeShardID(rcvAddr, numOfShards)
-	token := computeTokenIdentifier(string(dctNFTTransfer.DCTTokenName), dctNFTTransfer.DCTTokenNonce)
-
-	responseParse.Tokens = append(responseParse.Tokens, token)
-	responseParse.DCTValues = append(responseParse.DCTValues, dctNFTTransfer.DCTValue.String())
+	responseParse.IsMetaDCT = odp.isMetaDCT(dctNFTTransfer.DCTTokenName)
+	if fields.has(FieldTokens) {
+		token := tokenident.BuildNFTIdentifier(string(dctNFTTransfer.DCTTokenName), dctNFTTransfer.DCTTokenNonce)
+		responseParse.Tokens = append(responseParse.Tokens, token)
+		responseParse.Nonces = append(responseParse.Nonces, dctNFTTransfer.DCTTokenNonce)
+	}
+	if fields.has(FieldDCTValues) {
+		responseParse.DCTValues = append(responseParse.DCTValues, odp.valueFormat.Format(dctNFTTransfer.DCTValue))
+	}
+	if fields.has(FieldDisplayIdentifiers) {
+		displayIdentifier := tokenident.FormatDisplayIdentifier(string(dctNFTTransfer.DCTTokenName), dctNFTTransfer.DCTTokenNonce)
+		responseParse.DisplayIdentifiers = append(responseParse.DisplayIdentifiers, displayIdentifier)
+	}
 
-	if len(rcvAddr) != len(sender) {
+	if len(rcvAddr) != len(sender) || !fields.has(FieldReceivers) {
 		return responseParse
 	}
 
-	responseParse.Receivers = append(responseParse.Receivers, rcvAddr)
+	receiverShardID := odp.addressClassifier.ShardOf(rcvAddr, numOfShards)
+	responseParse.Receivers = append(responseParse.Receivers, copyBytes(rcvAddr))
 	responseParse.ReceiversShardID = append(responseParse.ReceiversShardID, receiverShardID)
+	if alias != nil {
+		responseParse.ReceiverAliases = append(responseParse.ReceiverAliases, alias)
+	}
 
 	return responseParse
 }
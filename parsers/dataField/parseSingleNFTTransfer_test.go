@@ -1,6 +1,7 @@
 package datafield
 
 import (
+	"encoding/hex"
 	"testing"
 
 	"github.com/Reshusk23/sr-me-core/core/pubkeyConverter"
@@ -52,6 +53,7 @@ func TestDCTNFTTransfer(t *testing.T) {
 			Function:         "",
 			DCTValues:        []string{"1"},
 			Tokens:           []string{"DEAD-79f8d1-1136"},
+			Nonces:           []uint64{0x1136},
 			Receivers:        [][]uint8(nil),
 			ReceiversShardID: []uint32(nil),
 			IsRelayed:        false,
@@ -69,6 +71,7 @@ func TestDCTNFTTransfer(t *testing.T) {
 			Function:         "claimRewardsProxy",
 			DCTValues:        []string{"28573236528289506375"},
 			Tokens:           []string{"LKFARM-9d1ea8-1e47f1"},
+			Nonces:           []uint64{0x1e47f1},
 			Receivers:        [][]uint8(nil),
 			ReceiversShardID: []uint32(nil),
 			IsRelayed:        false,
@@ -86,6 +89,7 @@ func TestDCTNFTTransfer(t *testing.T) {
 			Function:         "",
 			DCTValues:        []string{"1000000000000000000"},
 			Tokens:           []string{"SCOVE-5a636e-01-0de0b6b3a7640000"},
+			Nonces:           []uint64{0x0de0b6b3a7640000},
 			Receivers:        [][]uint8(nil),
 			ReceiversShardID: []uint32(nil),
 			IsRelayed:        false,
@@ -101,10 +105,24 @@ func TestDCTNFTTransfer(t *testing.T) {
 			Function:         "",
 			DCTValues:        []string{"1"},
 			Tokens:           []string{"TEST1-75ca3a-01"},
+			Nonces:           []uint64{1},
 			Receivers:        [][]byte{{}},
 			ReceiversShardID: []uint32{0},
 			IsRelayed:        false,
 		}, res)
 
 	})
+
+	t.Run("NFTTransferWithDisplayIdentifiers", func(t *testing.T) {
+		t.Parallel()
+
+		dataField := []byte("DCTNFTTransfer@444541442d373966386431@0d3d@01@" + hex.EncodeToString(receiver))
+		res := parser.ParseFields(dataField, sender, sender, 3, FieldTokens|FieldDisplayIdentifiers)
+		require.Equal(t, &ResponseParseData{
+			Operation:          "DCTNFTTransfer",
+			Tokens:             []string{"DEAD-79f8d1-0d3d"},
+			Nonces:             []uint64{0x0d3d},
+			DisplayIdentifiers: []string{"DEAD-79f8d1-0d3d"},
+		}, res)
+	})
 }
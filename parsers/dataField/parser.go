@@ -1,16 +1,15 @@
 package datafield
 
 import (
-	"encoding/json"
 	"errors"
 	"math/big"
 
 	"github.com/Reshusk23/sr-me-core/core"
 	"github.com/Reshusk23/sr-me-core/core/check"
-	"github.com/Reshusk23/sr-me-core/core/sharding"
-	"github.com/Reshusk23/sr-me-core/data/transaction"
 	vmcommon "github.com/Reshusk23/sr-vm-common-go"
 	"github.com/Reshusk23/sr-vm-common-go/parsers"
+	"github.com/Reshusk23/sr-vm-common-go/relayedbuilder"
+	"github.com/Reshusk23/sr-vm-common-go/tokenident"
 )
 
 const (
@@ -19,9 +18,8 @@ const (
 
 	minArgumentsQuantityOperationDCT = 2
 	minArgumentsQuantityOperationNFT = 3
-	numArgsRelayedV2                 = 4
-	receiverAddressIndexRelayedV2    = 0
-	dataFieldIndexRelayedV2          = 2
+	receiverIndexDCTNFTTransfer      = 3
+	receiverIndexMultiDCTNFTTransfer = 0
 
 	argsTokenPosition                   = 0
 	argsNoncePosition                   = 1
@@ -29,14 +27,41 @@ const (
 	argsValuePositionFungible           = 1
 )
 
+// parseMode describes the context of the parsed data field, as some layouts depend on where the data field is executed
+type parseMode uint8
+
+const (
+	// parseModeTransaction is the data field of a transaction, parsed together with the relayed transactions it builds
+	parseModeTransaction parseMode = iota
+	// parseModeInnerTransaction is the data field of the inner transaction of a relayed transaction, which cannot be
+	// relayed again
+	parseModeInnerTransaction
+	// parseModeSCResult is the data field of a smart contract result, executed on its receiver
+	parseModeSCResult
+)
+
 var errInvalidAddressLength = errors.New("invalid address length")
+var errInvalidMaxDataSize = errors.New("invalid max data size")
+var errInvalidMaxArgs = errors.New("invalid max args")
+
+type dctTransfersParser interface {
+	ParseDCTTransfers(sndAddr []byte, rcvAddr []byte, function string, args [][]byte) (*vmcommon.ParsedDCTTransfers, error)
+	ParseDCTTransfersOnDestination(rcvAddr []byte, function string, args [][]byte) (*vmcommon.ParsedDCTTransfers, error)
+	IterateMultiDCTNFTTransfers(rcvAddr []byte, args [][]byte, isOnDestination bool, handler parsers.DCTTransferHandler) (*vmcommon.ParsedDCTTransfers, error)
+	IsInterfaceNil() bool
+}
 
 type operationDataFieldParser struct {
 	builtInFunctionsList []string
-
-	addressLength     int
-	argsParser        vmcommon.CallArgsParser
-	dctTransferParser vmcommon.DCTTransferParser
+	maxDataSize          int
+	maxArgs              int
+	valueFormat          ValueFormat
+
+	addressClassifier vmcommon.AddressClassifier
+	metaDCTChecker    vmcommon.MetaDCTChecker
+	aliasResolver     vmcommon.AliasResolver
+	functionResolver  vmcommon.FunctionResolver
+	dctTransferParser dctTransfersParser
 }
 
 // NewOperationDataFieldParser will return a new instance of operationDataFieldParser
@@ -47,60 +72,152 @@ func NewOperationDataFieldParser(args *ArgsOperationDataFieldParser) (*operation
 	if args.AddressLength == 0 {
 		return nil, errInvalidAddressLength
 	}
+	if args.MaxDataSize < 0 {
+		return nil, errInvalidMaxDataSize
+	}
+	if args.MaxArgs < 0 {
+		return nil, errInvalidMaxArgs
+	}
+	err := args.ValueFormat.check()
+	if err != nil {
+		return nil, err
+	}
 
-	argsParser := parsers.NewCallArgsParser()
 	dctTransferParser, err := parsers.NewDCTTransferParser(args.Marshalizer)
 	if err != nil {
 		return nil, err
 	}
 
+	addressClassifier := args.AddressClassifier
+	if check.IfNil(addressClassifier) {
+		addressClassifier, err = vmcommon.NewAddressClassifier(vmcommon.ArgsAddressClassifier{
+			AddressLength:                 args.AddressLength,
+			NumInitCharactersForScAddress: vmcommon.NumInitCharactersForScAddress,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &operationDataFieldParser{
-		argsParser:           argsParser,
 		dctTransferParser:    dctTransferParser,
-		addressLength:        args.AddressLength,
+		addressClassifier:    addressClassifier,
+		metaDCTChecker:       args.MetaDCTChecker,
+		aliasResolver:        args.AliasResolver,
+		functionResolver:     args.FunctionResolver,
 		builtInFunctionsList: getAllBuiltInFunctions(),
+		maxDataSize:          args.MaxDataSize,
+		maxArgs:              args.MaxArgs,
+		valueFormat:          args.ValueFormat,
 	}, nil
 }
 
 // Parse will parse the provided data field
 func (odp *operationDataFieldParser) Parse(dataField []byte, sender, receiver []byte, numOfShards uint32) *ResponseParseData {
-	return odp.parse(dataField, sender, receiver, false, numOfShards)
+	return odp.parse(dataField, sender, receiver, nil, parseModeTransaction, numOfShards, AllResponseFields, nil)
+}
+
+// ParseFields will parse the provided data field, materializing only the requested optional fields of the response
+func (odp *operationDataFieldParser) ParseFields(dataField []byte, sender, receiver []byte, numOfShards uint32, fields ResponseFields) *ResponseParseData {
+	return odp.parse(dataField, sender, receiver, nil, parseModeTransaction, numOfShards, fields, nil)
 }
 
-func (odp *operationDataFieldParser) parse(dataField []byte, sender, receiver []byte, ignoreRelayed bool, numOfShards uint32) *ResponseParseData {
+// ParseWithOptions will parse the provided data field of a transaction marked as guarded or relayed by the options, or
+// of a smart contract result
+func (odp *operationDataFieldParser) ParseWithOptions(dataField []byte, sender, receiver []byte, numOfShards uint32, options ParseOptions) *ResponseParseData {
+	if options.IsSCResult {
+		return odp.parse(dataField, sender, receiver, options.Value, parseModeSCResult, numOfShards, AllResponseFields, nil)
+	}
+	if len(options.Relayer) == 0 {
+		res := odp.parse(dataField, sender, receiver, options.Value, parseModeTransaction, numOfShards, AllResponseFields, nil)
+		if res.IsRelayed {
+			// the sender of a relayed transaction built into the data field is the relayer
+			res.Relayer = copyBytes(sender)
+			return res
+		}
+
+		res.IsGuarded = options.IsGuarded
+		return res
+	}
+
+	res := odp.parse(dataField, sender, receiver, options.Value, parseModeInnerTransaction, numOfShards, AllResponseFields, nil)
+	if res.IsRelayed {
+		return &ResponseParseData{
+			IsRelayed: true,
+			Relayer:   copyBytes(options.Relayer),
+		}
+	}
+
+	res = odp.newRelayedResponse(res, receiver, numOfShards, AllResponseFields)
+	res.IsGuarded = options.IsGuarded
+	res.Relayer = copyBytes(options.Relayer)
+
+	return res
+}
+
+// parse parses the data field. A non nil handler receives the transfers of the multi transfers one at a time, instead
+// of them being materialized in the response
+func (odp *operationDataFieldParser) parse(
+	dataField []byte,
+	sender, receiver []byte,
+	value *big.Int,
+	mode parseMode,
+	numOfShards uint32,
+	fields ResponseFields,
+	handler TransferHandler,
+) *ResponseParseData {
 	responseParse := &ResponseParseData{
 		Operation: operationTransfer,
 	}
 
-	isSCDeploy := len(dataField) > 0 && isEmptyAddr(odp.addressLength, receiver)
+	isSCDeploy := len(dataField) > 0 && odp.addressClassifier.IsEmpty(receiver)
 	if isSCDeploy {
 		responseParse.Operation = operationDeploy
 		return responseParse
 	}
 
-	function, args, err := odp.argsParser.ParseData(string(dataField))
+	if odp.maxDataSize > 0 && len(dataField) > odp.maxDataSize {
+		responseParse.IsLimitExceeded = true
+		return responseParse
+	}
+
+	splitter := newDataFieldSplitter()
+	defer splitter.release()
+
+	function, err := splitter.split(dataField, odp.maxArgs)
+	if err == errTooManyArguments {
+		responseParse.IsLimitExceeded = true
+		return responseParse
+	}
 	if err != nil {
 		return responseParse
 	}
+	if !check.IfNil(odp.functionResolver) {
+		function, _ = odp.functionResolver.ResolveFunction(function)
+	}
 
 	switch function {
 	case core.BuiltInFunctionDCTTransfer:
-		return odp.parseSingleDCTTransfer(args, function, sender, receiver)
+		return odp.parseSingleDCTTransfer(splitter.arguments(), function, sender, receiver, mode, fields)
 	case core.BuiltInFunctionDCTNFTTransfer:
-		return odp.parseSingleDCTNFTTransfer(args, function, sender, receiver, numOfShards)
+		return odp.parseSingleDCTNFTTransfer(splitter.arguments(), function, sender, receiver, mode, numOfShards, fields)
 	case core.BuiltInFunctionMultiDCTNFTTransfer:
-		return odp.parseMultiDCTNFTTransfer(args, function, sender, receiver, numOfShards)
+		return odp.parseMultiDCTNFTTransfer(splitter.arguments(), function, sender, receiver, mode, numOfShards, fields, handler)
 	case core.BuiltInFunctionDCTLocalBurn, core.BuiltInFunctionDCTLocalMint:
-		return parseQuantityOperationDCT(args, function)
+		return parseQuantityOperationDCT(splitter.arguments(), function, fields, odp.valueFormat)
 	case core.BuiltInFunctionDCTWipe, core.BuiltInFunctionDCTFreeze, core.BuiltInFunctionDCTUnFreeze:
-		return parseBlockingOperationDCT(args, function)
+		return parseBlockingOperationDCT(splitter.arguments(), function, fields)
 	case core.BuiltInFunctionDCTNFTCreate, core.BuiltInFunctionDCTNFTBurn, core.BuiltInFunctionDCTNFTAddQuantity:
-		return parseQuantityOperationNFT(args, function)
+		return odp.parseQuantityOperationNFT(splitter.arguments(), function, fields)
 	case core.RelayedTransaction, core.RelayedTransactionV2:
-		if ignoreRelayed {
+		if mode == parseModeInnerTransaction {
 			return NewResponseParseDataAsRelayed()
 		}
-		return odp.parseRelayed(function, args, receiver, numOfShards)
+		return odp.parseRelayed(function, splitter.arguments(), receiver, numOfShards, fields, handler)
+	}
+
+	if isDelegationFunction(function) && odp.addressClassifier.IsSystemSC(receiver) {
+		return parseDelegationOperation(splitter.arguments(), function, value, fields, odp.valueFormat)
 	}
 
 	isBuiltInFunc := isBuiltInFunction(odp.builtInFunctionsList, function)
@@ -108,96 +225,106 @@ func (odp *operationDataFieldParser) parse(dataField []byte, sender, receiver []
 		responseParse.Operation = function
 	}
 
-	if function != "" && core.IsSmartContractAddress(receiver) && isASCIIString(function) {
+	if function != "" && odp.addressClassifier.IsSmartContract(receiver) && isASCIIString(function) {
 		responseParse.Function = function
+		responseParse.IsSCCall = !isBuiltInFunc
+		if responseParse.IsSCCall && fields.has(FieldArguments) {
+			responseParse.Arguments = copyArguments(splitter.arguments())
+		}
 	}
 
 	return responseParse
 }
 
-func (odp *operationDataFieldParser) parseRelayed(function string, args [][]byte, receiver []byte, numOfShards uint32) *ResponseParseData {
+func (odp *operationDataFieldParser) parseRelayed(function string, args [][]byte, receiver []byte, numOfShards uint32, fields ResponseFields, handler TransferHandler) *ResponseParseData {
 	if len(args) == 0 {
 		return &ResponseParseData{
 			IsRelayed: true,
 		}
 	}
 
-	tx, ok := extractInnerTx(function, args, receiver)
-	if !ok {
+	tx, err := relayedbuilder.ExtractInnerTransaction(function, args, receiver)
+	if err != nil {
 		return &ResponseParseData{
 			IsRelayed: true,
 		}
 	}
 
-	res := odp.parse(tx.Data, tx.SndAddr, tx.RcvAddr, true, numOfShards)
+	res := odp.parse(tx.Data, tx.SndAddr, tx.RcvAddr, tx.Value, parseModeInnerTransaction, numOfShards, fields, handler)
 	if res.IsRelayed {
 		return &ResponseParseData{
 			IsRelayed: true,
 		}
 	}
 
-	receivers := [][]byte{tx.RcvAddr}
-	receiversShardID := []uint32{sharding.ComputeShardID(tx.RcvAddr, numOfShards)}
-	if res.Operation == core.BuiltInFunctionMultiDCTNFTTransfer || res.Operation == core.BuiltInFunctionDCTNFTTransfer {
-		receivers = res.Receivers
-		receiversShardID = res.ReceiversShardID
-	}
+	relayedRes := odp.newRelayedResponse(res, tx.RcvAddr, numOfShards, fields)
+	relayedRes.IsGuarded = relayedbuilder.IsGuarded(tx)
 
-	return &ResponseParseData{
-		Operation:        res.Operation,
-		Function:         res.Function,
-		DCTValues:        res.DCTValues,
-		Tokens:           res.Tokens,
-		Receivers:        receivers,
-		ReceiversShardID: receiversShardID,
-		IsRelayed:        true,
-	}
+	return relayedRes
 }
 
-func extractInnerTx(function string, args [][]byte, receiver []byte) (*transaction.Transaction, bool) {
-	tx := &transaction.Transaction{}
-
-	if function == core.RelayedTransaction {
-		err := json.Unmarshal(args[0], &tx)
-
-		return tx, err == nil
+// newRelayedResponse builds the response of a relayed transaction from the response of the parsed inner data field
+func (odp *operationDataFieldParser) newRelayedResponse(res *ResponseParseData, innerReceiver []byte, numOfShards uint32, fields ResponseFields) *ResponseParseData {
+	if res.IsLimitExceeded {
+		return &ResponseParseData{
+			Operation:       res.Operation,
+			IsRelayed:       true,
+			IsLimitExceeded: true,
+		}
 	}
 
-	if len(args) != numArgsRelayedV2 {
-		return nil, false
+	var receivers [][]byte
+	var receiversShardID []uint32
+	var receiverAliases [][]byte
+	if fields.has(FieldReceivers) {
+		receivers = [][]byte{copyBytes(innerReceiver)}
+		receiversShardID = []uint32{odp.addressClassifier.ShardOf(innerReceiver, numOfShards)}
+	}
+	if res.Operation == core.BuiltInFunctionMultiDCTNFTTransfer || res.Operation == core.BuiltInFunctionDCTNFTTransfer {
+		receivers = res.Receivers
+		receiversShardID = res.ReceiversShardID
+		receiverAliases = res.ReceiverAliases
 	}
 
-	// sender of the inner tx is the receiver of the relayed tx
-	tx.SndAddr = receiver
-	tx.RcvAddr = args[receiverAddressIndexRelayedV2]
-	tx.Data = args[dataFieldIndexRelayedV2]
-
-	return tx, true
+	return &ResponseParseData{
+		Operation:          res.Operation,
+		Function:           res.Function,
+		IsSCCall:           res.IsSCCall,
+		Arguments:          res.Arguments,
+		DCTValues:          res.DCTValues,
+		Tokens:             res.Tokens,
+		Nonces:             res.Nonces,
+		DisplayIdentifiers: res.DisplayIdentifiers,
+		Receivers:          receivers,
+		ReceiversShardID:   receiversShardID,
+		ReceiverAliases:    receiverAliases,
+		IsRelayed:          true,
+		IsMetaDCT:          res.IsMetaDCT,
+		IsDelegation:       res.IsDelegation,
+		StakedValue:        res.StakedValue,
+		UnstakedValue:      res.UnstakedValue,
+	}
 }
 
-func parseBlockingOperationDCT(args [][]byte, funcName string) *ResponseParseData {
+func parseBlockingOperationDCT(args [][]byte, funcName string, fields ResponseFields) *ResponseParseData {
 	responseData := &ResponseParseData{
 		Operation: funcName,
 	}
 
-	if len(args) == 0 {
+	if len(args) == 0 || !fields.has(FieldTokens) {
 		return responseData
 	}
 
-	token, nonce := extractTokenAndNonce(args[argsTokenPosition])
-	if !isASCIIString(token) {
+	collection, nonce := tokenident.SplitCollectionAndNonce(args[argsTokenPosition])
+	if !isASCIIString(string(collection)) {
 		return responseData
 	}
 
-	if nonce != 0 {
-		token = computeTokenIdentifier(token, nonce)
-	}
-
-	responseData.Tokens = append(responseData.Tokens, token)
+	responseData.Tokens = append(responseData.Tokens, tokenident.FormatDisplayIdentifier(string(collection), nonce))
 	return responseData
 }
 
-func parseQuantityOperationDCT(args [][]byte, funcName string) *ResponseParseData {
+func parseQuantityOperationDCT(args [][]byte, funcName string, fields ResponseFields, valueFormat ValueFormat) *ResponseParseData {
 	responseData := &ResponseParseData{
 		Operation: funcName,
 	}
@@ -211,13 +338,17 @@ func parseQuantityOperationDCT(args [][]byte, funcName string) *ResponseParseDat
 		return responseData
 	}
 
-	responseData.Tokens = append(responseData.Tokens, token)
-	responseData.DCTValues = append(responseData.DCTValues, big.NewInt(0).SetBytes(args[argsValuePositionFungible]).String())
+	if fields.has(FieldTokens) {
+		responseData.Tokens = append(responseData.Tokens, token)
+	}
+	if fields.has(FieldDCTValues) {
+		responseData.DCTValues = append(responseData.DCTValues, valueFormat.Format(big.NewInt(0).SetBytes(args[argsValuePositionFungible])))
+	}
 
 	return responseData
 }
 
-func parseQuantityOperationNFT(args [][]byte, funcName string) *ResponseParseData {
+func (odp *operationDataFieldParser) parseQuantityOperationNFT(args [][]byte, funcName string, fields ResponseFields) *ResponseParseData {
 	responseData := &ResponseParseData{
 		Operation: funcName,
 	}
@@ -231,17 +362,53 @@ func parseQuantityOperationNFT(args [][]byte, funcName string) *ResponseParseDat
 		return responseData
 	}
 
-	nonce := big.NewInt(0).SetBytes(args[argsNoncePosition]).Uint64()
-	tokenIdentifier := computeTokenIdentifier(token, nonce)
+	responseData.IsMetaDCT = odp.isMetaDCT(args[argsTokenPosition])
 
-	value := big.NewInt(0).SetBytes(args[argsValuePositionNonAndSemiFungible]).String()
+	valuePosition := argsValuePositionNonAndSemiFungible
 	if funcName == core.BuiltInFunctionDCTNFTCreate {
-		value = big.NewInt(0).SetBytes(args[argsValuePositionNonAndSemiFungible-1]).String()
-		tokenIdentifier = token
+		valuePosition = argsValuePositionNonAndSemiFungible - 1
+	}
+	if fields.has(FieldDCTValues) {
+		responseData.DCTValues = append(responseData.DCTValues, odp.valueFormat.Format(big.NewInt(0).SetBytes(args[valuePosition])))
+	}
+
+	if !fields.has(FieldTokens) {
+		return responseData
 	}
 
-	responseData.DCTValues = append(responseData.DCTValues, value)
+	tokenIdentifier := token
+	if funcName != core.BuiltInFunctionDCTNFTCreate {
+		nonce := big.NewInt(0).SetBytes(args[argsNoncePosition]).Uint64()
+		tokenIdentifier = tokenident.BuildNFTIdentifier(token, nonce)
+	}
 	responseData.Tokens = append(responseData.Tokens, tokenIdentifier)
 
 	return responseData
 }
+
+func (odp *operationDataFieldParser) isMetaDCT(tokenID []byte) bool {
+	if check.IfNil(odp.metaDCTChecker) {
+		return false
+	}
+
+	return odp.metaDCTChecker.IsMetaDCT(tokenID)
+}
+
+// resolveReceiverAlias replaces the receiver found at the provided argument index with its address, if the receiver is
+// referenced by alias. The alias is returned as well, nil if the receiver is referenced by address
+func (odp *operationDataFieldParser) resolveReceiverAlias(args [][]byte, index int) ([][]byte, []byte) {
+	if check.IfNil(odp.aliasResolver) || index >= len(args) || !odp.aliasResolver.IsAlias(args[index]) {
+		return args, nil
+	}
+
+	address, err := odp.aliasResolver.ResolveAlias(args[index])
+	if err != nil {
+		return args, nil
+	}
+
+	resolvedArgs := make([][]byte, len(args))
+	copy(resolvedArgs, args)
+	resolvedArgs[index] = address
+
+	return resolvedArgs, copyBytes(args[index])
+}
@@ -1,11 +1,18 @@
 package datafield
 
 import (
+	"bytes"
 	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/Reshusk23/sr-me-core/core"
+	"github.com/Reshusk23/sr-me-core/data/transaction"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
 	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/Reshusk23/sr-vm-common-go/relayedbuilder"
 	"github.com/stretchr/testify/require"
 )
 
@@ -29,6 +36,36 @@ func TestNewOperationDataFieldParser(t *testing.T) {
 		require.Equal(t, core.ErrNilMarshalizer, err)
 	})
 
+	t.Run("NegativeMaxDataSize", func(t *testing.T) {
+		t.Parallel()
+
+		arguments := createMockArgumentsOperationParser()
+		arguments.MaxDataSize = -1
+
+		_, err := NewOperationDataFieldParser(arguments)
+		require.Equal(t, errInvalidMaxDataSize, err)
+	})
+
+	t.Run("NegativeMaxArgs", func(t *testing.T) {
+		t.Parallel()
+
+		arguments := createMockArgumentsOperationParser()
+		arguments.MaxArgs = -1
+
+		_, err := NewOperationDataFieldParser(arguments)
+		require.Equal(t, errInvalidMaxArgs, err)
+	})
+
+	t.Run("InvalidValueFormat", func(t *testing.T) {
+		t.Parallel()
+
+		arguments := createMockArgumentsOperationParser()
+		arguments.ValueFormat.MaxLength = -1
+
+		_, err := NewOperationDataFieldParser(arguments)
+		require.Equal(t, errInvalidValueFormat, err)
+	})
+
 	t.Run("ShouldWork", func(t *testing.T) {
 		t.Parallel()
 
@@ -134,6 +171,134 @@ func TestParseQuantityOperationsNFT(t *testing.T) {
 	})
 }
 
+func TestParseMetaDCTOperations(t *testing.T) {
+	t.Parallel()
+
+	arguments := createMockArgumentsOperationParser()
+	arguments.MetaDCTChecker = &mock.MetaDCTCheckerStub{
+		IsMetaDCTCalled: func(tokenID []byte) bool {
+			return string(tokenID) == "MTA-abcdef"
+		},
+	}
+	parser, _ := NewOperationDataFieldParser(arguments)
+	metaSender := bytes.Repeat([]byte{1}, 32)
+	metaReceiver := bytes.Repeat([]byte{2}, 32)
+
+	t.Run("DCTNFTBurn", func(t *testing.T) {
+		t.Parallel()
+
+		dataField := []byte("DCTNFTBurn@4d54412d616263646566@02@0de0b6b3a7640000")
+		res := parser.Parse(dataField, metaSender, metaSender, 3)
+		require.Equal(t, &ResponseParseData{
+			Operation: "DCTNFTBurn",
+			DCTValues: []string{"1000000000000000000"},
+			Tokens:    []string{"MTA-abcdef-02"},
+			IsMetaDCT: true,
+		}, res)
+	})
+
+	t.Run("DCTNFTTransfer", func(t *testing.T) {
+		t.Parallel()
+
+		dataField := []byte("DCTNFTTransfer@4d54412d616263646566@02@05@" + hex.EncodeToString(metaReceiver))
+		res := parser.Parse(dataField, metaSender, metaSender, 3)
+		require.True(t, res.IsMetaDCT)
+		require.Equal(t, []string{"MTA-abcdef-02"}, res.Tokens)
+	})
+
+	t.Run("MultiDCTNFTTransfer", func(t *testing.T) {
+		t.Parallel()
+
+		dataField := []byte("MultiDCTNFTTransfer@" + hex.EncodeToString(metaReceiver) + "@02@4e46542d616263646566@01@01@4d54412d616263646566@02@05")
+		res := parser.Parse(dataField, metaSender, metaSender, 3)
+		require.True(t, res.IsMetaDCT)
+		require.Equal(t, []string{"NFT-abcdef-01", "MTA-abcdef-02"}, res.Tokens)
+	})
+
+	t.Run("other collections should not be classified", func(t *testing.T) {
+		t.Parallel()
+
+		dataField := []byte("DCTNFTAddQuantity@4e46542d616263646566@02@03")
+		res := parser.Parse(dataField, metaSender, metaSender, 3)
+		require.Equal(t, &ResponseParseData{
+			Operation: "DCTNFTAddQuantity",
+			DCTValues: []string{"3"},
+			Tokens:    []string{"NFT-abcdef-02"},
+		}, res)
+	})
+}
+
+func TestParseOperationsWithReceiverAlias(t *testing.T) {
+	t.Parallel()
+
+	aliasSender := bytes.Repeat([]byte{1}, 32)
+	aliasReceiver := bytes.Repeat([]byte{2}, 32)
+	alias := []byte("alias")
+	arguments := createMockArgumentsOperationParser()
+	arguments.AliasResolver = &mock.AliasResolverStub{
+		IsAliasCalled: func(value []byte) bool {
+			return len(value) == len(alias)
+		},
+		ResolveAliasCalled: func(value []byte) ([]byte, error) {
+			if !bytes.Equal(value, alias) {
+				return nil, errors.New("unknown alias")
+			}
+			return aliasReceiver, nil
+		},
+	}
+	parser, _ := NewOperationDataFieldParser(arguments)
+
+	t.Run("DCTNFTTransfer", func(t *testing.T) {
+		t.Parallel()
+
+		dataField := []byte("DCTNFTTransfer@4e46542d616263646566@02@05@" + hex.EncodeToString(alias))
+		res := parser.Parse(dataField, aliasSender, aliasSender, 3)
+		require.Equal(t, &ResponseParseData{
+			Operation:        "DCTNFTTransfer",
+			DCTValues:        []string{"5"},
+			Tokens:           []string{"NFT-abcdef-02"},
+			Nonces:           []uint64{2},
+			Receivers:        [][]byte{aliasReceiver},
+			ReceiversShardID: []uint32{2},
+			ReceiverAliases:  [][]byte{alias},
+		}, res)
+	})
+
+	t.Run("MultiDCTNFTTransfer", func(t *testing.T) {
+		t.Parallel()
+
+		dataField := []byte("MultiDCTNFTTransfer@" + hex.EncodeToString(alias) + "@02@4e46542d616263646566@01@01@4d54412d616263646566@00@05")
+		res := parser.Parse(dataField, aliasSender, aliasSender, 3)
+		require.Equal(t, &ResponseParseData{
+			Operation:        "MultiDCTNFTTransfer",
+			DCTValues:        []string{"1", "5"},
+			Tokens:           []string{"NFT-abcdef-01", "MTA-abcdef"},
+			Nonces:           []uint64{1, 0},
+			Receivers:        [][]byte{aliasReceiver, aliasReceiver},
+			ReceiversShardID: []uint32{2, 2},
+			ReceiverAliases:  [][]byte{alias, alias},
+		}, res)
+	})
+
+	t.Run("unknown alias should not resolve", func(t *testing.T) {
+		t.Parallel()
+
+		dataField := []byte("DCTNFTTransfer@4e46542d616263646566@02@05@" + hex.EncodeToString([]byte("other")))
+		res := parser.Parse(dataField, aliasSender, aliasSender, 3)
+		require.Empty(t, res.Receivers)
+		require.Empty(t, res.ReceiverAliases)
+	})
+
+	t.Run("receiver referenced by address", func(t *testing.T) {
+		t.Parallel()
+
+		dataField := []byte("DCTNFTTransfer@4e46542d616263646566@02@05@" + hex.EncodeToString(aliasReceiver))
+		res := parser.Parse(dataField, aliasSender, aliasSender, 3)
+		require.Equal(t, [][]byte{aliasReceiver}, res.Receivers)
+		require.Nil(t, res.ReceiverAliases)
+	})
+}
+
 func TestParseBlockingOperationDCT(t *testing.T) {
 	t.Parallel()
 
@@ -218,6 +383,8 @@ func TestOperationDataFieldParser_ParseRelayed(t *testing.T) {
 			IsRelayed:        true,
 			Operation:        "transfer",
 			Function:         "ESDTTransfer",
+			IsSCCall:         true,
+			Arguments:        [][]byte{[]byte("CGLD-928492"), {0x03, 0xe8}, []byte("buyChest"), {0xa0, 0x00, 0x00, 0x00}},
 			Tokens:           []string(nil),
 			DCTValues:        []string(nil),
 			Receivers:        [][]byte{rcv},
@@ -308,6 +475,7 @@ func TestOperationDataFieldParser_ParseRelayed(t *testing.T) {
 			Operation:        "DCTNFTTransfer",
 			DCTValues:        []string{"138495980998569893315957691"},
 			Tokens:           []string{"LKFARM-9d1ea8-34ae14"},
+			Nonces:           []uint64{0x34ae14},
 			Receivers:        [][]uint8(nil),
 			ReceiversShardID: []uint32(nil),
 			Function:         "claimRewardsProxy",
@@ -325,6 +493,124 @@ func TestOperationDataFieldParser_ParseRelayed(t *testing.T) {
 	})
 }
 
+func TestOperationDataFieldParser_ParseWithOptions(t *testing.T) {
+	t.Parallel()
+
+	parser, _ := NewOperationDataFieldParser(createMockArgumentsOperationParser())
+	relayer := bytes.Repeat([]byte{7}, 32)
+	userSender := bytes.Repeat([]byte{1}, 32)
+	userReceiver := bytes.Repeat([]byte{2}, 32)
+	scAddress, _ := hex.DecodeString("0000000000000000050029db735b3741223dae79a2ce284ccfad5f53d0e3ab19")
+
+	t.Run("GuardedTransaction", func(t *testing.T) {
+		t.Parallel()
+
+		res := parser.ParseWithOptions([]byte("DCTTransfer@544f4b454e@0a"), userSender, userReceiver, 3, ParseOptions{IsGuarded: true})
+		require.Equal(t, &ResponseParseData{
+			Operation: "DCTTransfer",
+			Tokens:    []string{"TOKEN"},
+			DCTValues: []string{"10"},
+			IsGuarded: true,
+		}, res)
+	})
+
+	t.Run("GuardedRelayedTxRefersToTheInnerTransaction", func(t *testing.T) {
+		t.Parallel()
+
+		innerTx := &transaction.Transaction{
+			SndAddr: userReceiver,
+			RcvAddr: scAddress,
+			Data:    []byte("DCTTransfer@544f4b454e@0a"),
+			Options: relayedbuilder.GuardedTxOptionMask,
+		}
+		marshalledTx, _ := json.Marshal(innerTx)
+		dataField := []byte(core.RelayedTransaction + "@" + hex.EncodeToString(marshalledTx))
+
+		res := parser.ParseWithOptions(dataField, userSender, userReceiver, 3, ParseOptions{IsGuarded: true})
+		require.Equal(t, &ResponseParseData{
+			Operation:        "DCTTransfer",
+			Tokens:           []string{"TOKEN"},
+			DCTValues:        []string{"10"},
+			Receivers:        [][]byte{scAddress},
+			ReceiversShardID: []uint32{parser.addressClassifier.ShardOf(scAddress, 3)},
+			IsRelayed:        true,
+			IsGuarded:        true,
+			Relayer:          userSender,
+		}, res)
+
+		innerTx.Options = 0
+		marshalledTx, _ = json.Marshal(innerTx)
+		dataField = []byte(core.RelayedTransaction + "@" + hex.EncodeToString(marshalledTx))
+
+		res = parser.ParseWithOptions(dataField, userSender, userReceiver, 3, ParseOptions{IsGuarded: true})
+		require.True(t, res.IsRelayed)
+		require.False(t, res.IsGuarded)
+	})
+
+	t.Run("RelayedTxComposedByTheRelayedBuilder", func(t *testing.T) {
+		t.Parallel()
+
+		innerTx := &transaction.Transaction{
+			SndAddr: userReceiver,
+			RcvAddr: scAddress,
+			Data:    []byte("DCTTransfer@544f4b454e@0a"),
+		}
+		relayedbuilder.SetGuarded(innerTx, true)
+		dataField, err := relayedbuilder.ComposeRelayedV1(innerTx)
+		require.Nil(t, err)
+
+		expectedResponse := &ResponseParseData{
+			Operation:        "DCTTransfer",
+			Tokens:           []string{"TOKEN"},
+			DCTValues:        []string{"10"},
+			Receivers:        [][]byte{scAddress},
+			ReceiversShardID: []uint32{parser.addressClassifier.ShardOf(scAddress, 3)},
+			IsRelayed:        true,
+			IsGuarded:        true,
+			Relayer:          userSender,
+		}
+		res := parser.ParseWithOptions(dataField, userSender, userReceiver, 3, ParseOptions{})
+		require.Equal(t, expectedResponse, res)
+
+		dataField, err = relayedbuilder.ComposeRelayedV2(relayedbuilder.ArgsRelayedV2{
+			Receiver: scAddress,
+			Data:     innerTx.Data,
+		})
+		require.Nil(t, err)
+
+		expectedResponse.IsGuarded = false
+		res = parser.ParseWithOptions(dataField, userSender, userReceiver, 3, ParseOptions{})
+		require.Equal(t, expectedResponse, res)
+	})
+
+	t.Run("TransactionMarkedAsRelayed", func(t *testing.T) {
+		t.Parallel()
+
+		res := parser.ParseWithOptions([]byte("DCTTransfer@544f4b454e@0a"), userSender, userReceiver, 3, ParseOptions{IsGuarded: true, Relayer: relayer})
+		require.Equal(t, &ResponseParseData{
+			Operation:        "DCTTransfer",
+			Tokens:           []string{"TOKEN"},
+			DCTValues:        []string{"10"},
+			Receivers:        [][]byte{userReceiver},
+			ReceiversShardID: []uint32{parser.addressClassifier.ShardOf(userReceiver, 3)},
+			IsRelayed:        true,
+			IsGuarded:        true,
+			Relayer:          relayer,
+		}, res)
+	})
+
+	t.Run("TransactionMarkedAsRelayedWithRelayedDataField", func(t *testing.T) {
+		t.Parallel()
+
+		dataField := []byte(core.RelayedTransactionV2 + "@" + hex.EncodeToString(scAddress) + "@0a@" + hex.EncodeToString([]byte("callMe")) + "@01a2")
+		res := parser.ParseWithOptions(dataField, userSender, userReceiver, 3, ParseOptions{Relayer: relayer})
+		require.Equal(t, &ResponseParseData{
+			IsRelayed: true,
+			Relayer:   relayer,
+		}, res)
+	})
+}
+
 func TestParseSCDeploy(t *testing.T) {
 	arguments := createMockArgumentsOperationParser()
 	parser, _ := NewOperationDataFieldParser(arguments)
@@ -341,3 +627,227 @@ func TestParseSCDeploy(t *testing.T) {
 		}, res)
 	})
 }
+
+func TestParseSCCall(t *testing.T) {
+	t.Parallel()
+
+	arguments := createMockArgumentsOperationParser()
+	parser, _ := NewOperationDataFieldParser(arguments)
+
+	scAddress, _ := hex.DecodeString("0000000000000000050029db735b3741223dae79a2ce284ccfad5f53d0e3ab19")
+
+	t.Run("FunctionAndArguments", func(t *testing.T) {
+		t.Parallel()
+
+		dataField := []byte("callMe@01@abcd")
+		res := parser.Parse(dataField, sender, scAddress, 3)
+		require.Equal(t, &ResponseParseData{
+			Operation: operationTransfer,
+			Function:  "callMe",
+			IsSCCall:  true,
+			Arguments: [][]byte{{0x01}, {0xab, 0xcd}},
+		}, res)
+	})
+
+	t.Run("FunctionWithoutArguments", func(t *testing.T) {
+		t.Parallel()
+
+		dataField := []byte("callMe")
+		res := parser.Parse(dataField, sender, scAddress, 3)
+		require.Equal(t, &ResponseParseData{
+			Operation: operationTransfer,
+			Function:  "callMe",
+			IsSCCall:  true,
+			Arguments: [][]byte{},
+		}, res)
+	})
+
+	t.Run("ArgumentsNotRequested", func(t *testing.T) {
+		t.Parallel()
+
+		dataField := []byte("callMe@01@abcd")
+		res := parser.ParseFields(dataField, sender, scAddress, 3, FieldTokens)
+		require.Equal(t, &ResponseParseData{
+			Operation: operationTransfer,
+			Function:  "callMe",
+			IsSCCall:  true,
+		}, res)
+	})
+
+	t.Run("BuiltInFunctionIsNotSCCall", func(t *testing.T) {
+		t.Parallel()
+
+		dataField := []byte(core.BuiltInFunctionClaimDeveloperRewards)
+		res := parser.Parse(dataField, sender, scAddress, 3)
+		require.Equal(t, &ResponseParseData{
+			Operation: core.BuiltInFunctionClaimDeveloperRewards,
+			Function:  core.BuiltInFunctionClaimDeveloperRewards,
+		}, res)
+	})
+
+	t.Run("UserReceiverIsNotSCCall", func(t *testing.T) {
+		t.Parallel()
+
+		dataField := []byte("callMe@01")
+		res := parser.Parse(dataField, sender, receiver, 3)
+		require.Equal(t, &ResponseParseData{
+			Operation: operationTransfer,
+		}, res)
+	})
+}
+
+func TestOperationDataFieldParser_ParseWithFunctionResolver(t *testing.T) {
+	t.Parallel()
+
+	functionResolver, err := vmcommon.NewFunctionResolver(vmcommon.ArgsFunctionResolver{
+		CanonicalFunctions: []string{core.BuiltInFunctionDCTLocalBurn, core.BuiltInFunctionClaimDeveloperRewards},
+		Aliases:            map[string]string{"DCTBurn": core.BuiltInFunctionDCTLocalBurn},
+		CaseInsensitive:    true,
+	})
+	require.Nil(t, err)
+
+	arguments := createMockArgumentsOperationParser()
+	arguments.FunctionResolver = functionResolver
+	parser, _ := NewOperationDataFieldParser(arguments)
+
+	scAddress, _ := hex.DecodeString("0000000000000000050029db735b3741223dae79a2ce284ccfad5f53d0e3ab19")
+
+	t.Run("Alias", func(t *testing.T) {
+		t.Parallel()
+
+		dataField := []byte("DCTBurn@4d4949552d616263646566@0102")
+		res := parser.Parse(dataField, sender, sender, 3)
+		require.Equal(t, &ResponseParseData{
+			Operation: core.BuiltInFunctionDCTLocalBurn,
+			Tokens:    []string{"MIIU-abcdef"},
+			DCTValues: []string{"258"},
+		}, res)
+	})
+
+	t.Run("DifferentCase", func(t *testing.T) {
+		t.Parallel()
+
+		dataField := []byte("claimdeveloperrewards")
+		res := parser.Parse(dataField, sender, scAddress, 3)
+		require.Equal(t, &ResponseParseData{
+			Operation: core.BuiltInFunctionClaimDeveloperRewards,
+			Function:  core.BuiltInFunctionClaimDeveloperRewards,
+		}, res)
+	})
+
+	t.Run("UnknownFunctionIsNotResolved", func(t *testing.T) {
+		t.Parallel()
+
+		dataField := []byte("callMe")
+		res := parser.Parse(dataField, sender, scAddress, 3)
+		require.Equal(t, &ResponseParseData{
+			Operation: operationTransfer,
+			Function:  "callMe",
+			IsSCCall:  true,
+			Arguments: [][]byte{},
+		}, res)
+	})
+}
+
+func TestOperationDataFieldParser_ParseWithLimits(t *testing.T) {
+	t.Parallel()
+
+	arguments := createMockArgumentsOperationParser()
+	arguments.MaxDataSize = 64
+	arguments.MaxArgs = 3
+	parser, _ := NewOperationDataFieldParser(arguments)
+
+	t.Run("WithinLimits", func(t *testing.T) {
+		t.Parallel()
+
+		dataField := []byte("DCTLocalMint@4d4949552d61626364@12")
+		res := parser.Parse(dataField, sender, receiver, 3)
+		require.Equal(t, &ResponseParseData{
+			Operation: core.BuiltInFunctionDCTLocalMint,
+			Tokens:    []string{"MIIU-abcd"},
+			DCTValues: []string{"18"},
+		}, res)
+	})
+
+	t.Run("DataSizeExceeded", func(t *testing.T) {
+		t.Parallel()
+
+		dataField := []byte("DCTLocalMint@" + strings.Repeat("ab", 32))
+		res := parser.Parse(dataField, sender, receiver, 3)
+		require.Equal(t, &ResponseParseData{
+			Operation:       operationTransfer,
+			IsLimitExceeded: true,
+		}, res)
+	})
+
+	t.Run("ArgsExceeded", func(t *testing.T) {
+		t.Parallel()
+
+		dataField := []byte("DCTLocalMint@01@02@03@04")
+		res := parser.Parse(dataField, sender, receiver, 3)
+		require.Equal(t, &ResponseParseData{
+			Operation:       operationTransfer,
+			IsLimitExceeded: true,
+		}, res)
+	})
+
+	t.Run("RelayedInnerArgsExceeded", func(t *testing.T) {
+		t.Parallel()
+
+		dataField := []byte(core.RelayedTransactionV2 +
+			"@" +
+			hex.EncodeToString(receiver) +
+			"@" +
+			"0A" +
+			"@" +
+			hex.EncodeToString([]byte("f@01@02@03@04@05")) +
+			"@" +
+			"01a2")
+		// the relayed data field holds exactly 4 arguments, while the inner one holds 5
+		largeLimitsArguments := createMockArgumentsOperationParser()
+		largeLimitsArguments.MaxArgs = 4
+		largeLimitsParser, _ := NewOperationDataFieldParser(largeLimitsArguments)
+
+		res := largeLimitsParser.Parse(dataField, sender, receiver, 3)
+		require.Equal(t, &ResponseParseData{
+			Operation:       operationTransfer,
+			IsRelayed:       true,
+			IsLimitExceeded: true,
+		}, res)
+	})
+
+	t.Run("DeployIsNotLimited", func(t *testing.T) {
+		t.Parallel()
+
+		dataField := []byte(strings.Repeat("01", 64))
+		res := parser.Parse(dataField, sender, make([]byte, 32), 3)
+		require.Equal(t, &ResponseParseData{
+			Operation: operationDeploy,
+		}, res)
+	})
+}
+
+func BenchmarkOperationDataFieldParser_ParseHostileDataField(b *testing.B) {
+	hostileDataField := []byte("callMe" + strings.Repeat("@"+strings.Repeat("ab", 1024), 512))
+	scAddress, _ := hex.DecodeString("0000000000000000050029db735b3741223dae79a2ce284ccfad5f53d0e3ab19")
+
+	b.Run("unbounded", func(b *testing.B) {
+		parser, _ := NewOperationDataFieldParser(createMockArgumentsOperationParser())
+
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = parser.Parse(hostileDataField, sender, scAddress, 3)
+		}
+	})
+	b.Run("bounded", func(b *testing.B) {
+		arguments := createMockArgumentsOperationParser()
+		arguments.MaxDataSize = 64 * 1024
+		arguments.MaxArgs = 64
+		parser, _ := NewOperationDataFieldParser(arguments)
+
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = parser.Parse(hostileDataField, sender, scAddress, 3)
+		}
+	})
+}
@@ -0,0 +1,120 @@
+package datafield
+
+import "sync"
+
+// OperationStatistics holds the aggregates computed over a batch of parsed data fields
+type OperationStatistics struct {
+	NumParsed  uint64
+	NumRelayed uint64
+	// Operations holds the number of data fields per operation, `transfer` and `scDeploy` included
+	Operations map[string]uint64
+	// Tokens holds the number of appearances of each token identifier, a multi transfer counting each of its tokens
+	Tokens map[string]uint64
+	// Functions holds the number of data fields per smart contract function
+	Functions map[string]uint64
+}
+
+func newOperationStatistics() OperationStatistics {
+	return OperationStatistics{
+		Operations: make(map[string]uint64),
+		Tokens:     make(map[string]uint64),
+		Functions:  make(map[string]uint64),
+	}
+}
+
+func (stats *OperationStatistics) add(res *ResponseParseData) {
+	stats.NumParsed++
+	if res.IsRelayed {
+		stats.NumRelayed++
+	}
+	if len(res.Operation) > 0 {
+		stats.Operations[res.Operation]++
+	}
+	if len(res.Function) > 0 {
+		stats.Functions[res.Function]++
+	}
+	for _, token := range res.Tokens {
+		stats.Tokens[token]++
+	}
+}
+
+func (stats *OperationStatistics) clone() OperationStatistics {
+	cloned := OperationStatistics{
+		NumParsed:  stats.NumParsed,
+		NumRelayed: stats.NumRelayed,
+		Operations: make(map[string]uint64, len(stats.Operations)),
+		Tokens:     make(map[string]uint64, len(stats.Tokens)),
+		Functions:  make(map[string]uint64, len(stats.Functions)),
+	}
+	for operation, count := range stats.Operations {
+		cloned.Operations[operation] = count
+	}
+	for token, count := range stats.Tokens {
+		cloned.Tokens[token] = count
+	}
+	for function, count := range stats.Functions {
+		cloned.Functions[function] = count
+	}
+
+	return cloned
+}
+
+type statisticsAccumulator struct {
+	parser *operationDataFieldParser
+	mut    sync.Mutex
+	stats  OperationStatistics
+}
+
+// NewStatisticsAccumulator returns an accumulator which tallies the operations, the tokens and the functions of all
+// the data fields parsed through it. The accumulator is safe for concurrent use, so a batch can be parsed in parallel
+func (odp *operationDataFieldParser) NewStatisticsAccumulator() *statisticsAccumulator {
+	return &statisticsAccumulator{
+		parser: odp,
+		stats:  newOperationStatistics(),
+	}
+}
+
+// Parse parses the provided data field, as the parser does, and accumulates the result
+func (sa *statisticsAccumulator) Parse(dataField []byte, sender, receiver []byte, numOfShards uint32) *ResponseParseData {
+	res := sa.parser.Parse(dataField, sender, receiver, numOfShards)
+	sa.Add(res)
+
+	return res
+}
+
+// Accumulate parses the provided data field only to accumulate the result, skipping the fields not needed by the
+// statistics
+func (sa *statisticsAccumulator) Accumulate(dataField []byte, sender, receiver []byte, numOfShards uint32) {
+	sa.Add(sa.parser.ParseFields(dataField, sender, receiver, numOfShards, FieldTokens))
+}
+
+// Add accumulates an already parsed data field
+func (sa *statisticsAccumulator) Add(res *ResponseParseData) {
+	if res == nil {
+		return
+	}
+
+	sa.mut.Lock()
+	sa.stats.add(res)
+	sa.mut.Unlock()
+}
+
+// Summary returns a copy of the statistics accumulated so far
+func (sa *statisticsAccumulator) Summary() OperationStatistics {
+	sa.mut.Lock()
+	defer sa.mut.Unlock()
+
+	return sa.stats.clone()
+}
+
+// Reset discards the statistics accumulated so far, so the accumulator can be reused for the next batch
+func (sa *statisticsAccumulator) Reset() {
+	sa.mut.Lock()
+	sa.stats = newOperationStatistics()
+	sa.mut.Unlock()
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (sa *statisticsAccumulator) IsInterfaceNil() bool {
+	return sa == nil
+}
@@ -0,0 +1,110 @@
+package datafield
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/Reshusk23/sr-me-core/core/check"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatisticsAccumulator(t *testing.T) {
+	t.Parallel()
+
+	parser, _ := NewOperationDataFieldParser(createMockArgumentsOperationParser())
+	block, txSender := createMultiDCTNFTTransferBlock(2, 2)
+	scAddress := append(make([]byte, 8), bytes.Repeat([]byte{1}, 24)...)
+
+	t.Run("should tally operations, tokens and functions", func(t *testing.T) {
+		t.Parallel()
+
+		accumulator := parser.NewStatisticsAccumulator()
+		require.False(t, check.IfNil(accumulator))
+
+		res := accumulator.Parse([]byte("DCTLocalBurn@4d4949552d616263646566@0102"), sender, sender, 3)
+		require.Equal(t, []string{"258"}, res.DCTValues)
+		accumulator.Accumulate([]byte("DCTLocalMint@4d4949552d616263646566@1122"), sender, sender, 3)
+		accumulator.Accumulate([]byte("claim@01"), sender, scAddress, 3)
+		accumulator.Accumulate([]byte("claim"), sender, scAddress, 3)
+		accumulator.Accumulate(nil, sender, sender, 3)
+		for _, dataField := range block {
+			accumulator.Accumulate(dataField, txSender, txSender, 3)
+		}
+		accumulator.Add(NewResponseParseDataAsRelayed())
+		accumulator.Add(nil)
+
+		require.Equal(t, OperationStatistics{
+			NumParsed:  8,
+			NumRelayed: 1,
+			Operations: map[string]uint64{
+				"DCTLocalBurn":        1,
+				"DCTLocalMint":        1,
+				"transfer":            3,
+				"MultiDCTNFTTransfer": 2,
+			},
+			Tokens: map[string]uint64{
+				"MIIU-abcdef":      2,
+				"TOKEN0-abcdef":    2,
+				"TOKEN1-abcdef-01": 2,
+			},
+			Functions: map[string]uint64{
+				"claim": 2,
+			},
+		}, accumulator.Summary())
+	})
+	t.Run("summary should be a copy", func(t *testing.T) {
+		t.Parallel()
+
+		accumulator := parser.NewStatisticsAccumulator()
+		accumulator.Accumulate(block[0], txSender, txSender, 3)
+
+		summary := accumulator.Summary()
+		summary.Tokens["TOKEN0-abcdef"] = 100
+		require.Equal(t, uint64(1), accumulator.Summary().Tokens["TOKEN0-abcdef"])
+	})
+	t.Run("reset should discard the statistics", func(t *testing.T) {
+		t.Parallel()
+
+		accumulator := parser.NewStatisticsAccumulator()
+		accumulator.Accumulate(block[0], txSender, txSender, 3)
+		accumulator.Reset()
+
+		require.Equal(t, newOperationStatistics(), accumulator.Summary())
+	})
+	t.Run("concurrent accumulation should work", func(t *testing.T) {
+		t.Parallel()
+
+		accumulator := parser.NewStatisticsAccumulator()
+		numWorkers := 10
+		wg := sync.WaitGroup{}
+		wg.Add(numWorkers)
+		for i := 0; i < numWorkers; i++ {
+			go func() {
+				defer wg.Done()
+				for _, dataField := range block {
+					accumulator.Accumulate(dataField, txSender, txSender, 3)
+				}
+			}()
+		}
+		wg.Wait()
+
+		summary := accumulator.Summary()
+		require.Equal(t, uint64(numWorkers*len(block)), summary.NumParsed)
+		require.Equal(t, uint64(numWorkers*len(block)), summary.Tokens["TOKEN0-abcdef"])
+	})
+}
+
+func BenchmarkStatisticsAccumulator_Accumulate(b *testing.B) {
+	parser, _ := NewOperationDataFieldParser(createMockArgumentsOperationParser())
+	block, txSender := createMultiDCTNFTTransferBlock(1000, 10)
+	accumulator := parser.NewStatisticsAccumulator()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, dataField := range block {
+			accumulator.Accumulate(dataField, txSender, txSender, 3)
+		}
+	}
+}
@@ -1,20 +1,11 @@
 package datafield
 
 import (
-	"bytes"
-	"encoding/hex"
-	"fmt"
-	"math/big"
 	"unicode"
 
 	"github.com/Reshusk23/sr-me-core/core"
 )
 
-const (
-	dctIdentifierSeparator  = "-"
-	dctRandomSequenceLength = 6
-)
-
 func getAllBuiltInFunctions() []string {
 	return []string{
 		core.BuiltInFunctionClaimDeveloperRewards,
@@ -78,38 +69,6 @@ func EncodeBytesSlice(encodeFunc func(b []byte) string, rcvs [][]byte) []string
 	return encodedSlice
 }
 
-func computeTokenIdentifier(token string, nonce uint64) string {
-	if token == "" || nonce == 0 {
-		return ""
-	}
-
-	nonceBig := big.NewInt(0).SetUint64(nonce)
-	hexEncodedNonce := hex.EncodeToString(nonceBig.Bytes())
-	return fmt.Sprintf("%s-%s", token, hexEncodedNonce)
-}
-
-func extractTokenAndNonce(arg []byte) (string, uint64) {
-	argsSplit := bytes.Split(arg, []byte(dctIdentifierSeparator))
-	if len(argsSplit) < 2 {
-		return string(arg), 0
-	}
-
-	if len(argsSplit[1]) <= dctRandomSequenceLength {
-		return string(arg), 0
-	}
-
-	identifier := []byte(fmt.Sprintf("%s-%s", argsSplit[0], argsSplit[1][:dctRandomSequenceLength]))
-	nonce := big.NewInt(0).SetBytes(argsSplit[1][dctRandomSequenceLength:])
-
-	return string(identifier), nonce.Uint64()
-}
-
-func isEmptyAddr(addrLength int, address []byte) bool {
-	emptyAddr := make([]byte, addrLength)
-
-	return bytes.Equal(address, emptyAddr)
-}
-
 func isASCIIString(input string) bool {
 	for i := 0; i < len(input); i++ {
 		if input[i] > unicode.MaxASCII {
@@ -4,6 +4,7 @@ import (
 	"encoding/hex"
 	"testing"
 
+	"github.com/Reshusk23/sr-me-core/core"
 	"github.com/stretchr/testify/require"
 )
 
@@ -13,16 +14,17 @@ func TestExtractTokenAndNonce(t *testing.T) {
 	hexArg := "534b4537592d37336262636404"
 	args, _ := hex.DecodeString(hexArg)
 
-	token, nonce := extractTokenAndNonce(args)
-	require.Equal(t, uint64(4), nonce)
-	require.Equal(t, "SKE7Y-73bbcd", token)
+	responseData := parseBlockingOperationDCT([][]byte{args}, core.BuiltInFunctionDCTWipe, AllResponseFields)
+	require.Equal(t, []string{"SKE7Y-73bbcd-04"}, responseData.Tokens)
 }
 
 func TestComputeTokenIdentifier(t *testing.T) {
 	t.Parallel()
 
-	identifier := computeTokenIdentifier("MYTOKEN-abcd", 10)
-	require.Equal(t, "MYTOKEN-abcd-0a", identifier)
+	parser, _ := NewOperationDataFieldParser(createMockArgumentsOperationParser())
+
+	responseData := parser.parseQuantityOperationNFT([][]byte{[]byte("MYTOKEN-abcd"), {10}, {1}}, core.BuiltInFunctionDCTNFTAddQuantity, AllResponseFields)
+	require.Equal(t, []string{"MYTOKEN-abcd-0a"}, responseData.Tokens)
 }
 
 func TestIsASCIIString(t *testing.T) {
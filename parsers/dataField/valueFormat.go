@@ -0,0 +1,109 @@
+package datafield
+
+import (
+	"errors"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// ValueOverflowMarker is written instead of the formatted values exceeding the maximum length of the value format
+const ValueOverflowMarker = "overflow"
+
+var errInvalidValueFormat = errors.New("invalid value format")
+
+// ValueFormat describes how the parser writes the values of the response, e.g. the DCTValues. The values are always
+// written with '.' as decimal separator and without digit grouping, whatever the locale. The zero value writes the
+// plain decimal strings of the values
+type ValueFormat struct {
+	// Decimals writes the values as fixed point numbers with the provided number of decimals, e.g. 1500 with 3
+	// decimals is written as 1.500
+	Decimals uint32
+	// ScientificAboveDigits writes the values with more integer digits in scientific notation, e.g. 1.25e+30, a zero
+	// value disabling the scientific notation
+	ScientificAboveDigits int
+	// ScientificDigits is the maximum number of fractional digits of the mantissa in scientific notation. The extra
+	// digits are truncated and the trailing zeros are removed
+	ScientificDigits int
+	// MaxLength caps the length of the written values, a zero value disabling the cap. The longer values are written
+	// as ValueOverflowMarker, so adversarial values can not overflow the columns of the indexers
+	MaxLength int
+	// TruncateOnOverflow writes the values longer than MaxLength as their leading characters followed by
+	// ValueOverflowMarker, within MaxLength, instead of writing only the marker
+	TruncateOnOverflow bool
+}
+
+func (format ValueFormat) check() error {
+	if format.ScientificAboveDigits < 0 || format.ScientificDigits < 0 || format.MaxLength < 0 {
+		return errInvalidValueFormat
+	}
+
+	return nil
+}
+
+func (format ValueFormat) isPlain() bool {
+	return format.Decimals == 0 && format.ScientificAboveDigits == 0 && format.MaxLength == 0
+}
+
+// Format returns the value written using the format
+func (format ValueFormat) Format(value *big.Int) string {
+	if format.isPlain() {
+		return value.String()
+	}
+
+	digits := value.String()
+	sign := ""
+	if value.Sign() < 0 {
+		sign = "-"
+		digits = digits[1:]
+	}
+
+	var formatted string
+	numIntegerDigits := len(digits) - int(format.Decimals)
+	if format.ScientificAboveDigits > 0 && numIntegerDigits > format.ScientificAboveDigits {
+		formatted = sign + format.scientific(digits, numIntegerDigits-1)
+	} else {
+		formatted = sign + format.fixedPoint(digits)
+	}
+
+	return format.capLength(formatted)
+}
+
+func (format ValueFormat) fixedPoint(digits string) string {
+	if format.Decimals == 0 {
+		return digits
+	}
+
+	decimals := int(format.Decimals)
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+
+	return digits[:len(digits)-decimals] + "." + digits[len(digits)-decimals:]
+}
+
+func (format ValueFormat) scientific(digits string, exponent int) string {
+	fractional := digits[1:]
+	if len(fractional) > format.ScientificDigits {
+		fractional = fractional[:format.ScientificDigits]
+	}
+	fractional = strings.TrimRight(fractional, "0")
+
+	mantissa := digits[:1]
+	if len(fractional) > 0 {
+		mantissa += "." + fractional
+	}
+
+	return mantissa + "e+" + strconv.Itoa(exponent)
+}
+
+func (format ValueFormat) capLength(formatted string) string {
+	if format.MaxLength == 0 || len(formatted) <= format.MaxLength {
+		return formatted
+	}
+	if !format.TruncateOnOverflow || format.MaxLength <= len(ValueOverflowMarker) {
+		return ValueOverflowMarker
+	}
+
+	return formatted[:format.MaxLength-len(ValueOverflowMarker)] + ValueOverflowMarker
+}
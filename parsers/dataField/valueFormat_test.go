@@ -0,0 +1,56 @@
+package datafield
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValueFormat_Format(t *testing.T) {
+	t.Parallel()
+
+	hugeValue, _ := big.NewInt(0).SetString("1"+strings.Repeat("0", 10000), 10)
+	testCases := []struct {
+		name     string
+		format   ValueFormat
+		value    *big.Int
+		expected string
+	}{
+		{name: "plain", format: ValueFormat{}, value: big.NewInt(1500), expected: "1500"},
+		{name: "plain negative", format: ValueFormat{}, value: big.NewInt(-1500), expected: "-1500"},
+		{name: "fixed point", format: ValueFormat{Decimals: 3}, value: big.NewInt(1500), expected: "1.500"},
+		{name: "fixed point below one", format: ValueFormat{Decimals: 3}, value: big.NewInt(15), expected: "0.015"},
+		{name: "fixed point zero", format: ValueFormat{Decimals: 2}, value: big.NewInt(0), expected: "0.00"},
+		{name: "fixed point negative", format: ValueFormat{Decimals: 3}, value: big.NewInt(-15), expected: "-0.015"},
+		{name: "below scientific threshold", format: ValueFormat{ScientificAboveDigits: 4}, value: big.NewInt(1250), expected: "1250"},
+		{name: "scientific", format: ValueFormat{ScientificAboveDigits: 3, ScientificDigits: 4}, value: big.NewInt(1250), expected: "1.25e+3"},
+		{name: "scientific truncated", format: ValueFormat{ScientificAboveDigits: 3, ScientificDigits: 2}, value: big.NewInt(12599), expected: "1.25e+4"},
+		{name: "scientific without fraction", format: ValueFormat{ScientificAboveDigits: 3}, value: big.NewInt(12599), expected: "1e+4"},
+		{name: "scientific with decimals", format: ValueFormat{Decimals: 2, ScientificAboveDigits: 2, ScientificDigits: 2}, value: big.NewInt(123456), expected: "1.23e+3"},
+		{name: "scientific huge value", format: ValueFormat{ScientificAboveDigits: 30, ScientificDigits: 6}, value: hugeValue, expected: "1e+10000"},
+		{name: "within max length", format: ValueFormat{MaxLength: 4}, value: big.NewInt(1500), expected: "1500"},
+		{name: "overflow", format: ValueFormat{MaxLength: 20}, value: hugeValue, expected: ValueOverflowMarker},
+		{name: "truncated overflow", format: ValueFormat{MaxLength: 12, TruncateOnOverflow: true}, value: hugeValue, expected: "1000" + ValueOverflowMarker},
+		{name: "truncated overflow shorter than marker", format: ValueFormat{MaxLength: 4, TruncateOnOverflow: true}, value: hugeValue, expected: ValueOverflowMarker},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, tc.expected, tc.format.Format(tc.value), tc.name)
+	}
+}
+
+func TestOperationDataFieldParser_ParseWithValueFormat(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgumentsOperationParser()
+	args.ValueFormat = ValueFormat{Decimals: 2, MaxLength: 10}
+	parser, _ := NewOperationDataFieldParser(args)
+
+	res := parser.Parse([]byte("DCTTransfer@544f4b454e2d616263646566@0a"), sender, receiver, 3)
+	require.Equal(t, []string{"0.10"}, res.DCTValues)
+
+	res = parser.Parse([]byte("DCTTransfer@544f4b454e2d616263646566@"+strings.Repeat("ff", 32)), sender, receiver, 3)
+	require.Equal(t, []string{ValueOverflowMarker}, res.DCTValues)
+}
@@ -22,6 +22,9 @@ const MinArgsForMultiDCTNFTTransfer = 4
 // ArgsPerTransfer defines the number of arguments per transfer in multi transfer
 const ArgsPerTransfer = 3
 
+// DCTTransferHandler is called for each of the transfers of a multi transfer, together with the receiver of the transfers
+type DCTTransferHandler func(rcvAddr []byte, transfer *vmcommon.DCTTransfer) error
+
 type dctTransferParser struct {
 	marshaller vmcommon.Marshalizer
 }
@@ -48,9 +51,29 @@ func (e *dctTransferParser) ParseDCTTransfers(
 	case core.BuiltInFunctionDCTTransfer:
 		return e.parseSingleDCTTransfer(rcvAddr, args)
 	case core.BuiltInFunctionDCTNFTTransfer:
-		return e.parseSingleDCTNFTTransfer(sndAddr, rcvAddr, args)
+		return e.parseSingleDCTNFTTransfer(rcvAddr, args, !bytes.Equal(sndAddr, rcvAddr))
+	case core.BuiltInFunctionMultiDCTNFTTransfer:
+		return e.parseMultiDCTNFTTransfer(rcvAddr, args, false)
+	default:
+		return nil, ErrNotDCTTransferInput
+	}
+}
+
+// ParseDCTTransfersOnDestination returns the list of dct transfers, the callFunction and callArgs from the given
+// arguments of a transfer executed on its receiver, as the ones carried by the smart contract results. The receiver
+// is never read from the arguments, so the transfers an account makes to itself are parsed as well
+func (e *dctTransferParser) ParseDCTTransfersOnDestination(
+	rcvAddr []byte,
+	function string,
+	args [][]byte,
+) (*vmcommon.ParsedDCTTransfers, error) {
+	switch function {
+	case core.BuiltInFunctionDCTTransfer:
+		return e.parseSingleDCTTransfer(rcvAddr, args)
+	case core.BuiltInFunctionDCTNFTTransfer:
+		return e.parseSingleDCTNFTTransfer(rcvAddr, args, true)
 	case core.BuiltInFunctionMultiDCTNFTTransfer:
-		return e.parseMultiDCTNFTTransfer(rcvAddr, args)
+		return e.parseMultiDCTNFTTransfer(rcvAddr, args, true)
 	default:
 		return nil, ErrNotDCTTransferInput
 	}
@@ -82,7 +105,7 @@ func (e *dctTransferParser) parseSingleDCTTransfer(rcvAddr []byte, args [][]byte
 	return dctTransfers, nil
 }
 
-func (e *dctTransferParser) parseSingleDCTNFTTransfer(sndAddr, rcvAddr []byte, args [][]byte) (*vmcommon.ParsedDCTTransfers, error) {
+func (e *dctTransferParser) parseSingleDCTNFTTransfer(rcvAddr []byte, args [][]byte, isOnDestination bool) (*vmcommon.ParsedDCTTransfers, error) {
 	if len(args) < MinArgsForDCTNFTTransfer {
 		return nil, ErrNotEnoughArguments
 	}
@@ -93,7 +116,7 @@ func (e *dctTransferParser) parseSingleDCTNFTTransfer(sndAddr, rcvAddr []byte, a
 		CallFunction: "",
 	}
 
-	if bytes.Equal(sndAddr, rcvAddr) {
+	if !isOnDestination {
 		dctTransfers.RcvAddr = args[3]
 	}
 	if len(args) > MinArgsForDCTNFTTransfer {
@@ -112,7 +135,32 @@ func (e *dctTransferParser) parseSingleDCTNFTTransfer(sndAddr, rcvAddr []byte, a
 	return dctTransfers, nil
 }
 
-func (e *dctTransferParser) parseMultiDCTNFTTransfer(rcvAddr []byte, args [][]byte) (*vmcommon.ParsedDCTTransfers, error) {
+func (e *dctTransferParser) parseMultiDCTNFTTransfer(rcvAddr []byte, args [][]byte, isOnDestination bool) (*vmcommon.ParsedDCTTransfers, error) {
+	transfers := make([]*vmcommon.DCTTransfer, 0)
+	dctTransfers, err := e.IterateMultiDCTNFTTransfers(rcvAddr, args, isOnDestination, func(_ []byte, transfer *vmcommon.DCTTransfer) error {
+		transfers = append(transfers, transfer)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dctTransfers.DCTTransfers = transfers
+
+	return dctTransfers, nil
+}
+
+// IterateMultiDCTNFTTransfers parses the arguments of a multi transfer, calling the handler for each of the transfers,
+// in order, instead of materializing them, so the multi transfers holding many transfers are parsed in bounded memory.
+// Unless the transfer is known to be executed on its receiver, a first argument which looks like an address marks the
+// transfer as executed on its sender. The returned transfers hold the receiver and the call, but no DCTTransfers. The
+// iteration stops on the first handler error, which is returned
+func (e *dctTransferParser) IterateMultiDCTNFTTransfers(
+	rcvAddr []byte,
+	args [][]byte,
+	isOnDestination bool,
+	handler DCTTransferHandler,
+) (*vmcommon.ParsedDCTTransfers, error) {
 	if len(args) < MinArgsForMultiDCTNFTTransfer {
 		return nil, ErrNotEnoughArguments
 	}
@@ -126,7 +174,7 @@ func (e *dctTransferParser) parseMultiDCTNFTTransfer(rcvAddr []byte, args [][]by
 	startIndex := uint64(1)
 	isTxAtSender := false
 
-	isFirstArgumentAnAddress := len(args[0]) == len(rcvAddr) && !numOfTransfer.IsUint64()
+	isFirstArgumentAnAddress := !isOnDestination && len(args[0]) == len(rcvAddr) && !numOfTransfer.IsUint64()
 	if isFirstArgumentAnAddress {
 		dctTransfers.RcvAddr = args[0]
 		numOfTransfer.SetBytes(args[1])
@@ -146,11 +194,14 @@ func (e *dctTransferParser) parseMultiDCTNFTTransfer(rcvAddr []byte, args [][]by
 		dctTransfers.CallArgs = append(dctTransfers.CallArgs, args[minLenArgs+1:]...)
 	}
 
-	var err error
-	dctTransfers.DCTTransfers = make([]*vmcommon.DCTTransfer, numOfTransfer.Uint64())
 	for i := uint64(0); i < numOfTransfer.Uint64(); i++ {
 		tokenStartIndex := startIndex + i*ArgsPerTransfer
-		dctTransfers.DCTTransfers[i], err = e.createNewDCTTransfer(tokenStartIndex, args, isTxAtSender)
+		dctTransfer, err := e.createNewDCTTransfer(tokenStartIndex, args, isTxAtSender)
+		if err != nil {
+			return nil, err
+		}
+
+		err = handler(dctTransfers.RcvAddr, dctTransfer)
 		if err != nil {
 			return nil, err
 		}
@@ -2,11 +2,13 @@ package parsers
 
 import (
 	"bytes"
+	"errors"
 	"math/big"
 	"testing"
 
 	"github.com/Reshusk23/sr-me-core/core"
 	"github.com/Reshusk23/sr-me-core/data/dct"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
 	"github.com/Reshusk23/sr-vm-common-go/mock"
 	"github.com/stretchr/testify/assert"
 )
@@ -251,3 +253,101 @@ func TestDctTransferParser_ParseMultiNFTTransferTransferMore(t *testing.T) {
 	assert.Equal(t, len(parsedData.CallArgs), 1)
 	assert.Equal(t, parsedData.CallFunction, "function")
 }
+
+func TestDctTransferParser_ParseDCTTransfersOnDestination(t *testing.T) {
+	t.Parallel()
+
+	dctParser, _ := NewDCTTransferParser(&mock.MarshalizerMock{})
+
+	t.Run("wrong function should error", func(t *testing.T) {
+		t.Parallel()
+
+		parsedData, err := dctParser.ParseDCTTransfersOnDestination(dstAddr, "function", [][]byte{})
+		assert.Equal(t, ErrNotDCTTransferInput, err)
+		assert.Nil(t, parsedData)
+	})
+	t.Run("single transfers should use the provided receiver", func(t *testing.T) {
+		t.Parallel()
+
+		parsedData, err := dctParser.ParseDCTTransfersOnDestination(
+			dstAddr,
+			core.BuiltInFunctionDCTTransfer,
+			[][]byte{[]byte("tokenID"), big.NewInt(20).Bytes()},
+		)
+		assert.Nil(t, err)
+		assert.Equal(t, dstAddr, parsedData.RcvAddr)
+		assert.Equal(t, big.NewInt(20), parsedData.DCTTransfers[0].DCTValue)
+
+		parsedData, err = dctParser.ParseDCTTransfersOnDestination(
+			dstAddr,
+			core.BuiltInFunctionDCTNFTTransfer,
+			[][]byte{[]byte("tokenID"), big.NewInt(10).Bytes(), big.NewInt(20).Bytes(), []byte("marshaled data"), []byte("function")},
+		)
+		assert.Nil(t, err)
+		assert.Equal(t, dstAddr, parsedData.RcvAddr)
+		assert.Equal(t, "function", parsedData.CallFunction)
+		assert.Equal(t, uint64(10), parsedData.DCTTransfers[0].DCTTokenNonce)
+	})
+	t.Run("multi transfer should use the provided receiver and decode the marshaled values", func(t *testing.T) {
+		t.Parallel()
+
+		marshaled, _ := dctParser.marshaller.Marshal(&dct.DCToken{Value: big.NewInt(20)})
+		parsedData, err := dctParser.ParseDCTTransfersOnDestination(
+			dstAddr,
+			core.BuiltInFunctionMultiDCTNFTTransfer,
+			[][]byte{big.NewInt(2).Bytes(), []byte("tokenID"), big.NewInt(10).Bytes(), marshaled, []byte("tokenID"), big.NewInt(0).Bytes(), big.NewInt(30).Bytes()},
+		)
+		assert.Nil(t, err)
+		assert.Equal(t, dstAddr, parsedData.RcvAddr)
+		assert.Equal(t, 2, len(parsedData.DCTTransfers))
+		assert.Equal(t, big.NewInt(20), parsedData.DCTTransfers[0].DCTValue)
+		assert.Equal(t, big.NewInt(30), parsedData.DCTTransfers[1].DCTValue)
+	})
+	t.Run("multi transfer should not read the receiver from the arguments", func(t *testing.T) {
+		t.Parallel()
+
+		parsedData, err := dctParser.ParseDCTTransfersOnDestination(
+			dstAddr,
+			core.BuiltInFunctionMultiDCTNFTTransfer,
+			[][]byte{bytes.Repeat([]byte{2}, 32), big.NewInt(1).Bytes(), []byte("tokenID"), big.NewInt(0).Bytes(), big.NewInt(20).Bytes()},
+		)
+		assert.Equal(t, ErrNotEnoughArguments, err)
+		assert.Nil(t, parsedData)
+	})
+}
+
+func TestDctTransferParser_IterateMultiDCTNFTTransfers(t *testing.T) {
+	t.Parallel()
+
+	dctParser, _ := NewDCTTransferParser(&mock.MarshalizerMock{})
+	args := [][]byte{dstAddr, big.NewInt(2).Bytes(), []byte("tokenID"), big.NewInt(10).Bytes(), big.NewInt(20).Bytes(), []byte("tokenID"), big.NewInt(0).Bytes(), big.NewInt(30).Bytes(), []byte("function")}
+
+	t.Run("should pass the transfers in order", func(t *testing.T) {
+		t.Parallel()
+
+		var values []uint64
+		parsedData, err := dctParser.IterateMultiDCTNFTTransfers(sndAddr, args, false, func(rcvAddr []byte, transfer *vmcommon.DCTTransfer) error {
+			assert.Equal(t, dstAddr, rcvAddr)
+			values = append(values, transfer.DCTValue.Uint64())
+			return nil
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, []uint64{20, 30}, values)
+		assert.Nil(t, parsedData.DCTTransfers)
+		assert.Equal(t, dstAddr, parsedData.RcvAddr)
+		assert.Equal(t, "function", parsedData.CallFunction)
+	})
+	t.Run("handler error should stop the iteration", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		numCalls := 0
+		parsedData, err := dctParser.IterateMultiDCTNFTTransfers(sndAddr, args, false, func(rcvAddr []byte, transfer *vmcommon.DCTTransfer) error {
+			numCalls++
+			return expectedErr
+		})
+		assert.Equal(t, expectedErr, err)
+		assert.Nil(t, parsedData)
+		assert.Equal(t, 1, numCalls)
+	})
+}
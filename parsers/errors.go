@@ -33,3 +33,15 @@ var ErrNotEnoughArguments = errors.New("not enough arguments")
 
 // ErrNilMarshalizer signals that marshaller is nil
 var ErrNilMarshalizer = errors.New("nil marshaller")
+
+// ErrNilLogEntry signals that a nil log entry was provided
+var ErrNilLogEntry = errors.New("nil log entry")
+
+// ErrNotRolesChangedEvent signals that the log entry is not a roles changed event
+var ErrNotRolesChangedEvent = errors.New("not a roles changed event")
+
+// ErrInvalidRolesChangedEvent signals that the roles changed event does not follow the canonical layout
+var ErrInvalidRolesChangedEvent = errors.New("invalid roles changed event")
+
+// ErrInvalidArgumentsEncoding signals that an unknown arguments encoding was provided
+var ErrInvalidArgumentsEncoding = errors.New("invalid arguments encoding")
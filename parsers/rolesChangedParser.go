@@ -0,0 +1,64 @@
+package parsers
+
+import (
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+)
+
+const minTopicsRolesChanged = 3
+
+// RolesChangedEvent is the decoded canonical roles changed log entry
+type RolesChangedEvent struct {
+	Function string
+	Address  []byte
+	TokenID  []byte
+	IsSet    bool
+	Roles    [][]byte
+}
+
+// ParseRolesChangedEvent decodes the canonical roles changed log entry emitted by the built-in functions changing the
+// roles of an account. It returns ErrNotRolesChangedEvent for the entries with another identifier
+func ParseRolesChangedEvent(logEntry *vmcommon.LogEntry) (*RolesChangedEvent, error) {
+	if logEntry == nil {
+		return nil, ErrNilLogEntry
+	}
+	if string(logEntry.Identifier) != vmcommon.DCTRolesChangedIdentifier {
+		return nil, ErrNotRolesChangedEvent
+	}
+	if len(logEntry.Topics) < minTopicsRolesChanged || len(logEntry.Topics[0]) == 0 || len(logEntry.Address) == 0 {
+		return nil, ErrInvalidRolesChangedEvent
+	}
+
+	event := &RolesChangedEvent{
+		Function: string(logEntry.Data),
+		Address:  logEntry.Address,
+		TokenID:  logEntry.Topics[0],
+		Roles:    logEntry.Topics[2:],
+	}
+	switch string(logEntry.Topics[1]) {
+	case vmcommon.DCTRolesChangedSet:
+		event.IsSet = true
+	case vmcommon.DCTRolesChangedUnset:
+	default:
+		return nil, ErrInvalidRolesChangedEvent
+	}
+
+	return event, nil
+}
+
+// ParseRolesChangedEvents decodes all the roles changed events of the provided log entries, skipping the other entries
+func ParseRolesChangedEvents(logs []*vmcommon.LogEntry) ([]*RolesChangedEvent, error) {
+	events := make([]*RolesChangedEvent, 0)
+	for _, logEntry := range logs {
+		event, err := ParseRolesChangedEvent(logEntry)
+		if err == ErrNotRolesChangedEvent {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, event)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,90 @@
+package parsers
+
+import (
+	"testing"
+
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createRolesChangedLogEntry(operation string, roles ...[]byte) *vmcommon.LogEntry {
+	return &vmcommon.LogEntry{
+		Identifier: []byte(vmcommon.DCTRolesChangedIdentifier),
+		Address:    []byte("address"),
+		Topics:     append([][]byte{[]byte("TKN-abcdef"), []byte(operation)}, roles...),
+		Data:       []byte("DCTSetRole"),
+	}
+}
+
+func TestParseRolesChangedEvent(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil log entry should error", func(t *testing.T) {
+		t.Parallel()
+
+		event, err := ParseRolesChangedEvent(nil)
+		assert.Nil(t, event)
+		assert.Equal(t, ErrNilLogEntry, err)
+	})
+	t.Run("other identifier should error", func(t *testing.T) {
+		t.Parallel()
+
+		logEntry := createRolesChangedLogEntry(vmcommon.DCTRolesChangedSet, []byte("role"))
+		logEntry.Identifier = []byte("DCTTransfer")
+		event, err := ParseRolesChangedEvent(logEntry)
+		assert.Nil(t, event)
+		assert.Equal(t, ErrNotRolesChangedEvent, err)
+	})
+	t.Run("invalid layout should error", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ParseRolesChangedEvent(createRolesChangedLogEntry(vmcommon.DCTRolesChangedSet))
+		assert.Equal(t, ErrInvalidRolesChangedEvent, err)
+
+		_, err = ParseRolesChangedEvent(createRolesChangedLogEntry("grant", []byte("role")))
+		assert.Equal(t, ErrInvalidRolesChangedEvent, err)
+
+		logEntry := createRolesChangedLogEntry(vmcommon.DCTRolesChangedSet, []byte("role"))
+		logEntry.Address = nil
+		_, err = ParseRolesChangedEvent(logEntry)
+		assert.Equal(t, ErrInvalidRolesChangedEvent, err)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		event, err := ParseRolesChangedEvent(createRolesChangedLogEntry(vmcommon.DCTRolesChangedSet, []byte("role1"), []byte("role2")))
+		require.Nil(t, err)
+		assert.Equal(t, &RolesChangedEvent{
+			Function: "DCTSetRole",
+			Address:  []byte("address"),
+			TokenID:  []byte("TKN-abcdef"),
+			IsSet:    true,
+			Roles:    [][]byte{[]byte("role1"), []byte("role2")},
+		}, event)
+
+		event, err = ParseRolesChangedEvent(createRolesChangedLogEntry(vmcommon.DCTRolesChangedUnset, []byte("role1")))
+		require.Nil(t, err)
+		assert.False(t, event.IsSet)
+	})
+}
+
+func TestParseRolesChangedEvents(t *testing.T) {
+	t.Parallel()
+
+	logs := []*vmcommon.LogEntry{
+		{Identifier: []byte("DCTSetRole")},
+		createRolesChangedLogEntry(vmcommon.DCTRolesChangedSet, []byte("role1")),
+		createRolesChangedLogEntry(vmcommon.DCTRolesChangedUnset, []byte("role2")),
+	}
+	events, err := ParseRolesChangedEvents(logs)
+	require.Nil(t, err)
+	require.Len(t, events, 2)
+	assert.True(t, events[0].IsSet)
+	assert.False(t, events[1].IsSet)
+
+	logs = append(logs, nil)
+	events, err = ParseRolesChangedEvents(logs)
+	assert.Nil(t, events)
+	assert.Equal(t, ErrNilLogEntry, err)
+}
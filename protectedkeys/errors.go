@@ -0,0 +1,6 @@
+package protectedkeys
+
+import "errors"
+
+// ErrEmptyPrefix signals that an empty prefix was provided
+var ErrEmptyPrefix = errors.New("empty prefix")
@@ -0,0 +1,158 @@
+package protectedkeys
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+
+	"github.com/Reshusk23/sr-me-core/core"
+)
+
+const (
+	transferIdentifier         = "transfer"
+	collectionConfigIdentifier = "collectionConfig"
+	nftMaxSupplyIdentifier     = "nftMaxSupply"
+	nftRoyaltiesIdentifier     = "nftRoyaltiesDenominator"
+	wrappedSupplyIdentifier    = "wrappedNativeSupply"
+	allowanceIdentifier        = "allowance"
+	storageUsageIdentifier     = "storageUsage"
+	bridgeProofIdentifier      = "bridgeProof"
+	multiSigNonceIdentifier    = "multiSigNonce"
+)
+
+const (
+	// DCTPrefix is the prefix of the keys holding the DCT balances and data of an account
+	DCTPrefix = core.ProtectedKeyPrefix + core.DCTKeyIdentifier
+
+	// DCTRolePrefix is the prefix of the keys holding the DCT roles of an account
+	DCTRolePrefix = core.ProtectedKeyPrefix + core.DCTRoleIdentifier + core.DCTKeyIdentifier
+
+	// DCTNFTLatestNoncePrefix is the prefix of the keys holding the latest created NFT nonce of a token
+	DCTNFTLatestNoncePrefix = core.ProtectedKeyPrefix + core.DCTNFTLatestNonceIdentifier
+
+	// TransferAddressesPrefix is the prefix of the keys holding the addresses with transfer role of a token
+	TransferAddressesPrefix = core.ProtectedKeyPrefix + transferIdentifier + core.DCTKeyIdentifier
+
+	// CollectionConfigPrefix is the prefix of the keys holding the configuration of a collection
+	CollectionConfigPrefix = core.ProtectedKeyPrefix + collectionConfigIdentifier + core.DCTKeyIdentifier
+
+	// NFTMaxSupplyPrefix is the prefix of the keys holding the max supply and the minted quantity of an NFT nonce
+	NFTMaxSupplyPrefix = core.ProtectedKeyPrefix + nftMaxSupplyIdentifier + core.DCTKeyIdentifier
+
+	// NFTRoyaltiesDenominatorPrefix is the prefix of the keys holding the royalties denominator an NFT nonce was created with
+	NFTRoyaltiesDenominatorPrefix = core.ProtectedKeyPrefix + nftRoyaltiesIdentifier + core.DCTKeyIdentifier
+
+	// WrappedNativeSupplyPrefix is the prefix of the key holding the supply of the wrapped native token
+	WrappedNativeSupplyPrefix = core.ProtectedKeyPrefix + wrappedSupplyIdentifier + core.DCTKeyIdentifier
+	// AllowancePrefix is the prefix of the keys holding the amounts an account allowed others to spend from its balances
+	AllowancePrefix = core.ProtectedKeyPrefix + allowanceIdentifier + core.DCTKeyIdentifier
+
+	// StorageUsageKey is the key holding the number of bytes the built-in functions stored on behalf of an account
+	StorageUsageKey = core.ProtectedKeyPrefix + storageUsageIdentifier
+
+	// BridgeProofPrefix is the prefix of the keys marking the external chain operations already consumed by the bridge
+	BridgeProofPrefix = core.ProtectedKeyPrefix + bridgeProofIdentifier + core.DCTKeyIdentifier
+
+	// MultiSigNoncePrefix is the prefix of the keys holding the nonces signed by the managers of multisig managed tokens
+	MultiSigNoncePrefix = core.ProtectedKeyPrefix + multiSigNonceIdentifier + core.DCTKeyIdentifier
+)
+
+var reservedPrefixes = []string{
+	DCTPrefix,
+	DCTRolePrefix,
+	DCTNFTLatestNoncePrefix,
+	TransferAddressesPrefix,
+	CollectionConfigPrefix,
+	NFTMaxSupplyPrefix,
+	NFTRoyaltiesDenominatorPrefix,
+	WrappedNativeSupplyPrefix,
+	AllowancePrefix,
+	StorageUsageKey,
+	BridgeProofPrefix,
+	MultiSigNoncePrefix,
+}
+
+// ReservedPrefixes returns the storage prefixes reserved by the built-in functions. All of them start with the
+// protected key prefix, so any key starting with it is protected, even if not used yet
+func ReservedPrefixes() [][]byte {
+	prefixes := make([][]byte, 0, len(reservedPrefixes))
+	for _, prefix := range reservedPrefixes {
+		prefixes = append(prefixes, []byte(prefix))
+	}
+
+	return prefixes
+}
+
+// IsProtectedKey returns true if the key starts with the protected key prefix and can be written only by the
+// built-in functions
+func IsProtectedKey(key []byte) bool {
+	return bytes.HasPrefix(key, []byte(core.ProtectedKeyPrefix))
+}
+
+type protectedKeysRegistry struct {
+	mutPrefixes sync.RWMutex
+	prefixes    map[string]struct{}
+}
+
+// NewProtectedKeysRegistry creates a registry of the protected keys which, besides the keys protected by default,
+// also protects the chain specific prefixes registered by the host
+func NewProtectedKeysRegistry() *protectedKeysRegistry {
+	return &protectedKeysRegistry{
+		prefixes: make(map[string]struct{}),
+	}
+}
+
+// RegisterPrefix reserves the provided prefix, all the keys starting with it becoming protected
+func (r *protectedKeysRegistry) RegisterPrefix(prefix []byte) error {
+	if len(prefix) == 0 {
+		return ErrEmptyPrefix
+	}
+
+	r.mutPrefixes.Lock()
+	r.prefixes[string(prefix)] = struct{}{}
+	r.mutPrefixes.Unlock()
+
+	return nil
+}
+
+// IsProtectedKey returns true if the key is protected by default or starts with one of the registered prefixes
+func (r *protectedKeysRegistry) IsProtectedKey(key []byte) bool {
+	if IsProtectedKey(key) {
+		return true
+	}
+
+	r.mutPrefixes.RLock()
+	defer r.mutPrefixes.RUnlock()
+
+	for prefix := range r.prefixes {
+		if bytes.HasPrefix(key, []byte(prefix)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Prefixes returns the reserved prefixes followed by the registered ones, the latter being sorted
+func (r *protectedKeysRegistry) Prefixes() [][]byte {
+	r.mutPrefixes.RLock()
+	registered := make([]string, 0, len(r.prefixes))
+	for prefix := range r.prefixes {
+		registered = append(registered, prefix)
+	}
+	r.mutPrefixes.RUnlock()
+
+	sort.Strings(registered)
+
+	prefixes := ReservedPrefixes()
+	for _, prefix := range registered {
+		prefixes = append(prefixes, []byte(prefix))
+	}
+
+	return prefixes
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (r *protectedKeysRegistry) IsInterfaceNil() bool {
+	return r == nil
+}
@@ -0,0 +1,94 @@
+package protectedkeys
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/Reshusk23/sr-me-core/core"
+	"github.com/Reshusk23/sr-me-core/core/check"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsProtectedKey(t *testing.T) {
+	t.Parallel()
+
+	for _, prefix := range ReservedPrefixes() {
+		assert.True(t, IsProtectedKey(append(prefix, []byte("TKN-abcdef")...)))
+	}
+	assert.True(t, IsProtectedKey([]byte(core.ProtectedKeyPrefix)))
+	assert.True(t, IsProtectedKey([]byte(core.ProtectedKeyPrefix+"unusedIdentifier")))
+	assert.False(t, IsProtectedKey(nil))
+	assert.False(t, IsProtectedKey([]byte("key")))
+	assert.False(t, IsProtectedKey([]byte(core.ProtectedKeyPrefix)[:3]))
+}
+
+func TestReservedPrefixes(t *testing.T) {
+	t.Parallel()
+
+	prefixes := ReservedPrefixes()
+	require.Len(t, prefixes, 12)
+	assert.Equal(t, []byte(DCTPrefix), prefixes[0])
+
+	prefixes[0][0] = 'x'
+	assert.Equal(t, []byte(DCTPrefix), ReservedPrefixes()[0])
+}
+
+func TestProtectedKeysRegistry_RegisterPrefix(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty prefix should err", func(t *testing.T) {
+		t.Parallel()
+
+		registry := NewProtectedKeysRegistry()
+		assert.Equal(t, ErrEmptyPrefix, registry.RegisterPrefix(nil))
+	})
+	t.Run("registered prefix should be protected", func(t *testing.T) {
+		t.Parallel()
+
+		registry := NewProtectedKeysRegistry()
+		assert.False(t, check.IfNil(registry))
+		assert.False(t, registry.IsProtectedKey([]byte("chainKey1")))
+		assert.True(t, registry.IsProtectedKey([]byte(DCTPrefix+"TKN")))
+
+		require.Nil(t, registry.RegisterPrefix([]byte("chain")))
+		assert.True(t, registry.IsProtectedKey([]byte("chainKey1")))
+		assert.False(t, registry.IsProtectedKey([]byte("chai")))
+	})
+}
+
+func TestProtectedKeysRegistry_Prefixes(t *testing.T) {
+	t.Parallel()
+
+	registry := NewProtectedKeysRegistry()
+	_ = registry.RegisterPrefix([]byte("zz"))
+	_ = registry.RegisterPrefix([]byte("aa"))
+	_ = registry.RegisterPrefix([]byte("aa"))
+
+	expected := append(ReservedPrefixes(), []byte("aa"), []byte("zz"))
+	assert.Equal(t, expected, registry.Prefixes())
+}
+
+func TestProtectedKeysRegistry_ConcurrentOperations(t *testing.T) {
+	t.Parallel()
+
+	registry := NewProtectedKeysRegistry()
+	numCalls := 100
+	wg := sync.WaitGroup{}
+	wg.Add(numCalls)
+	for i := 0; i < numCalls; i++ {
+		go func(idx int) {
+			defer wg.Done()
+
+			switch idx % 3 {
+			case 0:
+				_ = registry.RegisterPrefix([]byte{byte(idx)})
+			case 1:
+				_ = registry.IsProtectedKey([]byte{byte(idx)})
+			default:
+				_ = registry.Prefixes()
+			}
+		}(i)
+	}
+	wg.Wait()
+}
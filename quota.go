@@ -0,0 +1,13 @@
+package vmcommon
+
+// QuotaConfig defines the per block quota of a built-in function, which chains can use to throttle the expensive
+// functions. A zero value disables the corresponding quota
+type QuotaConfig struct {
+	MaxCallsPerBlock uint64
+	MaxGasPerBlock   uint64
+}
+
+// HasQuota returns true if at least one of the quotas is set
+func (config QuotaConfig) HasQuota() bool {
+	return config.MaxCallsPerBlock > 0 || config.MaxGasPerBlock > 0
+}
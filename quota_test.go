@@ -0,0 +1,15 @@
+package vmcommon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuotaConfig_HasQuota(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, QuotaConfig{}.HasQuota())
+	assert.True(t, QuotaConfig{MaxCallsPerBlock: 1}.HasQuota())
+	assert.True(t, QuotaConfig{MaxGasPerBlock: 1}.HasQuota())
+}
@@ -0,0 +1,15 @@
+package relayedbuilder
+
+import "errors"
+
+// ErrNilInnerTransaction signals that a nil inner transaction has been provided
+var ErrNilInnerTransaction = errors.New("nil inner transaction")
+
+// ErrNotRelayedTransaction signals that the data field is not the one of a relayed transaction
+var ErrNotRelayedTransaction = errors.New("not a relayed transaction")
+
+// ErrInvalidRelayedArguments signals that the arguments of the relayed transaction do not hold an inner transaction
+var ErrInvalidRelayedArguments = errors.New("invalid relayed transaction arguments")
+
+// ErrNestedRelayedTransaction signals that the inner transaction is itself a relayed transaction
+var ErrNestedRelayedTransaction = errors.New("nested relayed transaction")
@@ -0,0 +1,160 @@
+package relayedbuilder
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+
+	"github.com/Reshusk23/sr-me-core/core"
+	"github.com/Reshusk23/sr-me-core/data/transaction"
+	"github.com/Reshusk23/sr-vm-common-go/parsers"
+	"github.com/Reshusk23/sr-vm-common-go/txDataBuilder"
+)
+
+const (
+	// GuardedTxOptionMask is the bit of the transaction options set when the transaction is co-signed by the guardian
+	// of its sender
+	GuardedTxOptionMask = 1 << 1
+
+	// NumArgsRelayedV2 is the number of arguments of a relayed v2 transaction: receiver, nonce, data and signature
+	NumArgsRelayedV2 = 4
+
+	receiverIndexRelayedV2  = 0
+	nonceIndexRelayedV2     = 1
+	dataIndexRelayedV2      = 2
+	signatureIndexRelayedV2 = 3
+)
+
+// ArgsRelayedV2 holds the fields of the inner transaction carried by a relayed v2 transaction. The sender of the inner
+// transaction is the receiver of the relayed transaction, while the value, the gas and the options, hence the guardian
+// option as well, are not carried
+type ArgsRelayedV2 struct {
+	Receiver  []byte
+	Nonce     uint64
+	Data      []byte
+	Signature []byte
+}
+
+// IsRelayedFunction returns true if the function is one of the relayed transaction functions
+func IsRelayedFunction(function string) bool {
+	return function == core.RelayedTransaction || function == core.RelayedTransactionV2
+}
+
+// IsGuarded returns true if the transaction options mark the transaction as co-signed by the guardian of its sender
+func IsGuarded(tx *transaction.Transaction) bool {
+	return tx.Options&GuardedTxOptionMask != 0
+}
+
+// SetGuarded marks, or unmarks, the transaction as co-signed by the guardian of its sender
+func SetGuarded(tx *transaction.Transaction, isGuarded bool) {
+	if isGuarded {
+		tx.Options |= GuardedTxOptionMask
+		return
+	}
+
+	tx.Options &^= GuardedTxOptionMask
+}
+
+// ComposeRelayedV1 returns the data field of the relayed v1 transaction carrying the provided inner transaction, the
+// guardian option of the inner transaction being relayed as is
+func ComposeRelayedV1(innerTx *transaction.Transaction) ([]byte, error) {
+	if innerTx == nil {
+		return nil, ErrNilInnerTransaction
+	}
+	err := checkInnerData(innerTx.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	marshaledTx, err := json.Marshal(innerTx)
+	if err != nil {
+		return nil, err
+	}
+
+	return txDataBuilder.NewBuilder().Func(core.RelayedTransaction).Bytes(marshaledTx).ToBytes(), nil
+}
+
+// ComposeRelayedV2 returns the data field of the relayed v2 transaction carrying the provided inner transaction
+func ComposeRelayedV2(args ArgsRelayedV2) ([]byte, error) {
+	err := checkInnerData(args.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	return txDataBuilder.NewBuilder().
+		Func(core.RelayedTransactionV2).
+		Bytes(args.Receiver).
+		BigInt(big.NewInt(0).SetUint64(args.Nonce)).
+		Bytes(args.Data).
+		Bytes(args.Signature).
+		ToBytes(), nil
+}
+
+// Decompose returns the inner transaction carried by the data field of a relayed transaction. The relayedReceiver is
+// the receiver of the relayed transaction, which is the sender of the inner transaction of a relayed v2 transaction
+func Decompose(dataField []byte, relayedReceiver []byte) (*transaction.Transaction, error) {
+	function, args, err := parsers.NewCallArgsParser().ParseData(string(dataField))
+	if err != nil {
+		return nil, err
+	}
+
+	return ExtractInnerTransaction(function, args, relayedReceiver)
+}
+
+// ExtractInnerTransaction returns the inner transaction carried by the decoded arguments of a relayed transaction
+func ExtractInnerTransaction(function string, args [][]byte, relayedReceiver []byte) (*transaction.Transaction, error) {
+	switch function {
+	case core.RelayedTransaction:
+		return extractRelayedV1(args)
+	case core.RelayedTransactionV2:
+		return extractRelayedV2(args, relayedReceiver)
+	default:
+		return nil, ErrNotRelayedTransaction
+	}
+}
+
+func extractRelayedV1(args [][]byte) (*transaction.Transaction, error) {
+	if len(args) == 0 {
+		return nil, ErrInvalidRelayedArguments
+	}
+
+	tx := &transaction.Transaction{}
+	err := json.Unmarshal(args[0], tx)
+	if err != nil {
+		return nil, ErrInvalidRelayedArguments
+	}
+
+	return tx, nil
+}
+
+func extractRelayedV2(args [][]byte, relayedReceiver []byte) (*transaction.Transaction, error) {
+	if len(args) != NumArgsRelayedV2 {
+		return nil, ErrInvalidRelayedArguments
+	}
+
+	return &transaction.Transaction{
+		Nonce:     big.NewInt(0).SetBytes(args[nonceIndexRelayedV2]).Uint64(),
+		SndAddr:   relayedReceiver,
+		RcvAddr:   args[receiverIndexRelayedV2],
+		Data:      args[dataIndexRelayedV2],
+		Signature: args[signatureIndexRelayedV2],
+	}, nil
+}
+
+// checkInnerData rejects the inner transactions which are themselves relayed transactions, as they are not executed
+func checkInnerData(data []byte) error {
+	if len(data) == 0 {
+		return nil
+	}
+
+	function := data
+	functionEnd := bytes.IndexByte(data, '@')
+	if functionEnd >= 0 {
+		function = data[:functionEnd]
+	}
+	if IsRelayedFunction(string(function)) {
+		return ErrNestedRelayedTransaction
+	}
+
+	return nil
+}
@@ -0,0 +1,161 @@
+package relayedbuilder
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/Reshusk23/sr-me-core/core"
+	"github.com/Reshusk23/sr-me-core/data/transaction"
+	"github.com/Reshusk23/sr-vm-common-go/txDataBuilder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	relayer   = bytes.Repeat([]byte{1}, 32)
+	user      = bytes.Repeat([]byte{2}, 32)
+	recipient = bytes.Repeat([]byte{3}, 32)
+)
+
+func createInnerTransaction() *transaction.Transaction {
+	return &transaction.Transaction{
+		Nonce:     7,
+		Value:     big.NewInt(0),
+		RcvAddr:   recipient,
+		SndAddr:   user,
+		GasPrice:  1000000000,
+		GasLimit:  500000,
+		Data:      txDataBuilder.NewBuilder().TransferDCT("TKN-abcdef", 10).ToBytes(),
+		ChainID:   []byte("T"),
+		Version:   1,
+		Signature: []byte("signature"),
+	}
+}
+
+func TestSetGuarded(t *testing.T) {
+	t.Parallel()
+
+	tx := &transaction.Transaction{Options: 1}
+	assert.False(t, IsGuarded(tx))
+
+	SetGuarded(tx, true)
+	assert.True(t, IsGuarded(tx))
+	assert.Equal(t, uint32(1|GuardedTxOptionMask), tx.Options)
+
+	SetGuarded(tx, false)
+	assert.False(t, IsGuarded(tx))
+	assert.Equal(t, uint32(1), tx.Options)
+}
+
+func TestComposeRelayedV1(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil inner transaction should error", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := ComposeRelayedV1(nil)
+		assert.Equal(t, ErrNilInnerTransaction, err)
+		assert.Nil(t, data)
+	})
+	t.Run("nested relayed transaction should error", func(t *testing.T) {
+		t.Parallel()
+
+		innerTx := createInnerTransaction()
+		innerTx.Data = []byte(core.RelayedTransactionV2 + "@abcd")
+		data, err := ComposeRelayedV1(innerTx)
+		assert.Equal(t, ErrNestedRelayedTransaction, err)
+		assert.Nil(t, data)
+
+		innerTx.Data = []byte(core.RelayedTransaction)
+		data, err = ComposeRelayedV1(innerTx)
+		assert.Equal(t, ErrNestedRelayedTransaction, err)
+		assert.Nil(t, data)
+	})
+	t.Run("guarded inner transaction should decompose as composed", func(t *testing.T) {
+		t.Parallel()
+
+		innerTx := createInnerTransaction()
+		SetGuarded(innerTx, true)
+		data, err := ComposeRelayedV1(innerTx)
+		require.Nil(t, err)
+		assert.True(t, bytes.HasPrefix(data, []byte(core.RelayedTransaction+"@")))
+
+		decomposed, err := Decompose(data, user)
+		require.Nil(t, err)
+		assert.Equal(t, innerTx, decomposed)
+		assert.True(t, IsGuarded(decomposed))
+	})
+}
+
+func TestComposeRelayedV2(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nested relayed transaction should error", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := ComposeRelayedV2(ArgsRelayedV2{Data: []byte(core.RelayedTransaction + "@7b7d")})
+		assert.Equal(t, ErrNestedRelayedTransaction, err)
+		assert.Nil(t, data)
+	})
+	t.Run("should decompose as composed", func(t *testing.T) {
+		t.Parallel()
+
+		args := ArgsRelayedV2{
+			Receiver:  recipient,
+			Nonce:     10,
+			Data:      []byte("callMe@02"),
+			Signature: []byte("signature"),
+		}
+		data, err := ComposeRelayedV2(args)
+		require.Nil(t, err)
+		expectedData := core.RelayedTransactionV2 + "@" + hex.EncodeToString(recipient) + "@0a@" +
+			hex.EncodeToString([]byte("callMe@02")) + "@" + hex.EncodeToString([]byte("signature"))
+		assert.Equal(t, expectedData, string(data))
+
+		decomposed, err := Decompose(data, user)
+		require.Nil(t, err)
+		assert.Equal(t, &transaction.Transaction{
+			Nonce:     10,
+			RcvAddr:   recipient,
+			SndAddr:   user,
+			Data:      []byte("callMe@02"),
+			Signature: []byte("signature"),
+		}, decomposed)
+	})
+}
+
+func TestDecompose(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid data field should error", func(t *testing.T) {
+		t.Parallel()
+
+		tx, err := Decompose([]byte(core.RelayedTransaction+"@zz"), relayer)
+		assert.NotNil(t, err)
+		assert.Nil(t, tx)
+	})
+	t.Run("not relayed transaction should error", func(t *testing.T) {
+		t.Parallel()
+
+		tx, err := Decompose([]byte(core.BuiltInFunctionDCTTransfer+"@01@02"), relayer)
+		assert.Equal(t, ErrNotRelayedTransaction, err)
+		assert.Nil(t, tx)
+	})
+	t.Run("invalid arguments should error", func(t *testing.T) {
+		t.Parallel()
+
+		tx, err := Decompose([]byte(core.RelayedTransaction), relayer)
+		assert.Equal(t, ErrInvalidRelayedArguments, err)
+		assert.Nil(t, tx)
+
+		tx, err = Decompose([]byte(core.RelayedTransaction+"@abcd"), relayer)
+		assert.Equal(t, ErrInvalidRelayedArguments, err)
+		assert.Nil(t, tx)
+
+		tx, err = Decompose([]byte(core.RelayedTransactionV2+"@abcd"), relayer)
+		assert.Equal(t, ErrInvalidRelayedArguments, err)
+		assert.Nil(t, tx)
+	})
+}
@@ -0,0 +1,29 @@
+package vmcommon
+
+// ShardFunctionsConfig defines the shards on which the built-in functions can be executed. A function having allowed
+// shards can only be executed on those shards, while a function having denied shards can not be executed on any of
+// them. The functions not configured can be executed on any shard
+type ShardFunctionsConfig struct {
+	AllowedShards map[string][]uint32
+	DeniedShards  map[string][]uint32
+}
+
+// IsFunctionAllowed returns true if the function can be executed on the provided shard
+func (config ShardFunctionsConfig) IsFunctionAllowed(function string, shardID uint32) bool {
+	if containsShard(config.DeniedShards[function], shardID) {
+		return false
+	}
+
+	allowedShards, hasAllowedShards := config.AllowedShards[function]
+	return !hasAllowedShards || containsShard(allowedShards, shardID)
+}
+
+func containsShard(shards []uint32, shardID uint32) bool {
+	for _, shard := range shards {
+		if shard == shardID {
+			return true
+		}
+	}
+
+	return false
+}
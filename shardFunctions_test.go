@@ -0,0 +1,42 @@
+package vmcommon
+
+import (
+	"testing"
+
+	"github.com/Reshusk23/sr-me-core/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShardFunctionsConfig_IsFunctionAllowed(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty config should allow all", func(t *testing.T) {
+		t.Parallel()
+
+		config := ShardFunctionsConfig{}
+		assert.True(t, config.IsFunctionAllowed("function", 0))
+		assert.True(t, config.IsFunctionAllowed("function", core.MetachainShardId))
+	})
+	t.Run("allowed shards should restrict the function", func(t *testing.T) {
+		t.Parallel()
+
+		config := ShardFunctionsConfig{
+			AllowedShards: map[string][]uint32{"metaOnly": {core.MetachainShardId}},
+		}
+		assert.True(t, config.IsFunctionAllowed("metaOnly", core.MetachainShardId))
+		assert.False(t, config.IsFunctionAllowed("metaOnly", 0))
+		assert.True(t, config.IsFunctionAllowed("other", 0))
+	})
+	t.Run("denied shards should disable the function", func(t *testing.T) {
+		t.Parallel()
+
+		config := ShardFunctionsConfig{
+			AllowedShards: map[string][]uint32{"function": {0, 1}},
+			DeniedShards:  map[string][]uint32{"function": {1}, "notOnMeta": {core.MetachainShardId}},
+		}
+		assert.True(t, config.IsFunctionAllowed("function", 0))
+		assert.False(t, config.IsFunctionAllowed("function", 1))
+		assert.False(t, config.IsFunctionAllowed("notOnMeta", core.MetachainShardId))
+		assert.True(t, config.IsFunctionAllowed("notOnMeta", 2))
+	})
+}
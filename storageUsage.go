@@ -0,0 +1,13 @@
+package vmcommon
+
+// StorageUsageConfig defines the maximum number of bytes the built-in functions can store on behalf of an account,
+// which chains can use to prevent the unbounded growth of the state. A zero value disables the limit, the usage being
+// still metered
+type StorageUsageConfig struct {
+	MaxBytesPerAccount uint64
+}
+
+// HasLimit returns true if the number of bytes stored on behalf of an account is limited
+func (config StorageUsageConfig) HasLimit() bool {
+	return config.MaxBytesPerAccount > 0
+}
@@ -0,0 +1,14 @@
+package vmcommon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorageUsageConfig_HasLimit(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, StorageUsageConfig{}.HasLimit())
+	assert.True(t, StorageUsageConfig{MaxBytesPerAccount: 1}.HasLimit())
+}
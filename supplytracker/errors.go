@@ -0,0 +1,12 @@
+package supplytracker
+
+import "errors"
+
+// ErrNilStorer signals that a nil storer has been provided
+var ErrNilStorer = errors.New("nil storer")
+
+// ErrInvalidLogEntry signals that a supply changing log entry does not hold the token, the nonce and the value topics
+var ErrInvalidLogEntry = errors.New("invalid log entry")
+
+// ErrInvalidSupplyData signals that the supply data read from the storer can not be decoded
+var ErrInvalidSupplyData = errors.New("invalid supply data")
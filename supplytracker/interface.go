@@ -0,0 +1,8 @@
+package supplytracker
+
+// Storer defines the persistence used by the supply tracker. Get must return a nil value and no error for missing keys
+type Storer interface {
+	Get(key []byte) ([]byte, error)
+	Put(key []byte, value []byte) error
+	IsInterfaceNil() bool
+}
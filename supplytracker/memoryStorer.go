@@ -0,0 +1,41 @@
+package supplytracker
+
+import (
+	"bytes"
+	"sync"
+)
+
+// memoryStorer is a Storer keeping the data in memory
+type memoryStorer struct {
+	mut  sync.RWMutex
+	data map[string][]byte
+}
+
+// NewMemoryStorer creates a storer keeping the data in memory
+func NewMemoryStorer() *memoryStorer {
+	return &memoryStorer{
+		data: make(map[string][]byte),
+	}
+}
+
+// Get returns the value stored under the key, or nil if the key is missing
+func (ms *memoryStorer) Get(key []byte) ([]byte, error) {
+	ms.mut.RLock()
+	defer ms.mut.RUnlock()
+
+	return bytes.Clone(ms.data[string(key)]), nil
+}
+
+// Put stores the value under the key
+func (ms *memoryStorer) Put(key []byte, value []byte) error {
+	ms.mut.Lock()
+	ms.data[string(key)] = bytes.Clone(value)
+	ms.mut.Unlock()
+
+	return nil
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (ms *memoryStorer) IsInterfaceNil() bool {
+	return ms == nil
+}
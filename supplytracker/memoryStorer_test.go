@@ -0,0 +1,28 @@
+package supplytracker
+
+import (
+	"testing"
+
+	"github.com/Reshusk23/sr-me-core/core/check"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStorer(t *testing.T) {
+	t.Parallel()
+
+	ms := NewMemoryStorer()
+	assert.False(t, check.IfNil(ms))
+
+	value, err := ms.Get([]byte("key"))
+	assert.Nil(t, err)
+	assert.Nil(t, value)
+
+	stored := []byte("value")
+	err = ms.Put([]byte("key"), stored)
+	assert.Nil(t, err)
+	stored[0] = 'x'
+
+	value, err = ms.Get([]byte("key"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("value"), value)
+}
@@ -0,0 +1,190 @@
+package supplytracker
+
+import (
+	"encoding/binary"
+	"math/big"
+	"sync"
+
+	"github.com/Reshusk23/sr-me-core/core"
+	"github.com/Reshusk23/sr-me-core/core/check"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+)
+
+const (
+	lengthOfNonce       = 8
+	lengthOfMintedSize  = 4
+	numOfRequiredTopics = 3
+)
+
+var mintIdentifiers = map[string]struct{}{
+	core.BuiltInFunctionDCTNFTCreate:      {},
+	core.BuiltInFunctionDCTNFTAddQuantity: {},
+	core.BuiltInFunctionDCTLocalMint:      {},
+}
+
+var burnIdentifiers = map[string]struct{}{
+	core.BuiltInFunctionDCTNFTBurn:   {},
+	core.BuiltInFunctionDCTLocalBurn: {},
+	core.BuiltInFunctionDCTBurn:      {},
+	core.BuiltInFunctionDCTWipe:      {},
+}
+
+// TokenSupply holds the supply totals of a token
+type TokenSupply struct {
+	Minted      *big.Int
+	Burned      *big.Int
+	Circulating *big.Int
+}
+
+// ArgsSupplyTracker holds the components needed to create a supply tracker
+type ArgsSupplyTracker struct {
+	Storer Storer
+}
+
+// supplyTracker maintains the supply totals of the tokens out of the log events of the built-in functions
+type supplyTracker struct {
+	mut    sync.RWMutex
+	storer Storer
+}
+
+// NewSupplyTracker creates a new supply tracker
+func NewSupplyTracker(args ArgsSupplyTracker) (*supplyTracker, error) {
+	if check.IfNil(args.Storer) {
+		return nil, ErrNilStorer
+	}
+
+	return &supplyTracker{
+		storer: args.Storer,
+	}, nil
+}
+
+// ProcessLogs updates the supply totals out of the provided log entries, the entries not changing the supply being
+// ignored. The supply of an NFT or SFT is tracked for its nonce and, as a whole, for its collection
+func (st *supplyTracker) ProcessLogs(logs []*vmcommon.LogEntry) error {
+	st.mut.Lock()
+	defer st.mut.Unlock()
+
+	for _, logEntry := range logs {
+		err := st.processLogEntry(logEntry)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (st *supplyTracker) processLogEntry(logEntry *vmcommon.LogEntry) error {
+	if logEntry == nil {
+		return nil
+	}
+
+	_, isMint := mintIdentifiers[string(logEntry.Identifier)]
+	_, isBurn := burnIdentifiers[string(logEntry.Identifier)]
+	if !isMint && !isBurn {
+		return nil
+	}
+	if len(logEntry.Topics) < numOfRequiredTopics {
+		return ErrInvalidLogEntry
+	}
+
+	tokenID := logEntry.Topics[0]
+	nonce := big.NewInt(0).SetBytes(logEntry.Topics[1])
+	if !nonce.IsUint64() {
+		return ErrInvalidLogEntry
+	}
+	value := big.NewInt(0).SetBytes(logEntry.Topics[2])
+
+	err := st.addToSupply(tokenID, 0, value, isMint)
+	if err != nil {
+		return err
+	}
+	if nonce.Uint64() == 0 {
+		return nil
+	}
+
+	return st.addToSupply(tokenID, nonce.Uint64(), value, isMint)
+}
+
+func (st *supplyTracker) addToSupply(tokenID []byte, nonce uint64, value *big.Int, isMint bool) error {
+	key := computeKey(tokenID, nonce)
+	supply, err := st.getSupply(key)
+	if err != nil {
+		return err
+	}
+
+	if isMint {
+		supply.Minted.Add(supply.Minted, value)
+	} else {
+		supply.Burned.Add(supply.Burned, value)
+	}
+
+	return st.storer.Put(key, encodeSupply(supply))
+}
+
+// GetSupply returns the supply totals of the token. The nonce 0 returns the totals of the whole collection for NFTs
+// and SFTs. The circulating supply is negative if the tracking started after tokens were already minted
+func (st *supplyTracker) GetSupply(tokenID []byte, nonce uint64) (*TokenSupply, error) {
+	st.mut.RLock()
+	defer st.mut.RUnlock()
+
+	supply, err := st.getSupply(computeKey(tokenID, nonce))
+	if err != nil {
+		return nil, err
+	}
+
+	supply.Circulating = big.NewInt(0).Sub(supply.Minted, supply.Burned)
+	return supply, nil
+}
+
+func (st *supplyTracker) getSupply(key []byte) (*TokenSupply, error) {
+	data, err := st.storer.Get(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeSupply(data)
+}
+
+func computeKey(tokenID []byte, nonce uint64) []byte {
+	key := make([]byte, 0, len(tokenID)+lengthOfNonce)
+	key = append(key, tokenID...)
+	return binary.BigEndian.AppendUint64(key, nonce)
+}
+
+func encodeSupply(supply *TokenSupply) []byte {
+	minted := supply.Minted.Bytes()
+	data := make([]byte, 0, lengthOfMintedSize+len(minted)+len(supply.Burned.Bytes()))
+	data = binary.BigEndian.AppendUint32(data, uint32(len(minted)))
+	data = append(data, minted...)
+	return append(data, supply.Burned.Bytes()...)
+}
+
+func decodeSupply(data []byte) (*TokenSupply, error) {
+	supply := &TokenSupply{
+		Minted: big.NewInt(0),
+		Burned: big.NewInt(0),
+	}
+	if len(data) == 0 {
+		return supply, nil
+	}
+	if len(data) < lengthOfMintedSize {
+		return nil, ErrInvalidSupplyData
+	}
+
+	mintedSize := uint64(binary.BigEndian.Uint32(data))
+	if uint64(len(data)-lengthOfMintedSize) < mintedSize {
+		return nil, ErrInvalidSupplyData
+	}
+
+	data = data[lengthOfMintedSize:]
+	supply.Minted.SetBytes(data[:mintedSize])
+	supply.Burned.SetBytes(data[mintedSize:])
+
+	return supply, nil
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (st *supplyTracker) IsInterfaceNil() bool {
+	return st == nil
+}
@@ -0,0 +1,151 @@
+package supplytracker
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/Reshusk23/sr-me-core/core"
+	"github.com/Reshusk23/sr-me-core/core/check"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type storerStub struct {
+	getCalled func(key []byte) ([]byte, error)
+	putCalled func(key []byte, value []byte) error
+}
+
+func (ss *storerStub) Get(key []byte) ([]byte, error) {
+	return ss.getCalled(key)
+}
+
+func (ss *storerStub) Put(key []byte, value []byte) error {
+	return ss.putCalled(key, value)
+}
+
+func (ss *storerStub) IsInterfaceNil() bool {
+	return ss == nil
+}
+
+func createLogEntry(identifier string, tokenID string, nonce uint64, value int64) *vmcommon.LogEntry {
+	return &vmcommon.LogEntry{
+		Identifier: []byte(identifier),
+		Topics:     [][]byte{[]byte(tokenID), binary.BigEndian.AppendUint64(nil, nonce), big.NewInt(value).Bytes()},
+	}
+}
+
+func createSupply(minted int64, burned int64) *TokenSupply {
+	return &TokenSupply{
+		Minted:      big.NewInt(minted),
+		Burned:      big.NewInt(burned),
+		Circulating: big.NewInt(minted - burned),
+	}
+}
+
+func TestNewSupplyTracker(t *testing.T) {
+	t.Parallel()
+
+	st, err := NewSupplyTracker(ArgsSupplyTracker{})
+	assert.Equal(t, ErrNilStorer, err)
+	assert.True(t, check.IfNil(st))
+
+	st, err = NewSupplyTracker(ArgsSupplyTracker{Storer: NewMemoryStorer()})
+	assert.Nil(t, err)
+	assert.False(t, check.IfNil(st))
+}
+
+func TestSupplyTracker_ProcessLogs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("fungible token should track mints and burns", func(t *testing.T) {
+		t.Parallel()
+
+		st, _ := NewSupplyTracker(ArgsSupplyTracker{Storer: NewMemoryStorer()})
+		err := st.ProcessLogs([]*vmcommon.LogEntry{
+			createLogEntry(core.BuiltInFunctionDCTLocalMint, "TKN-abcdef", 0, 100),
+			createLogEntry(core.BuiltInFunctionDCTTransfer, "TKN-abcdef", 0, 40),
+			createLogEntry(core.BuiltInFunctionDCTLocalBurn, "TKN-abcdef", 0, 10),
+			createLogEntry(core.BuiltInFunctionDCTBurn, "TKN-abcdef", 0, 5),
+			createLogEntry(core.BuiltInFunctionDCTWipe, "TKN-abcdef", 0, 15),
+			nil,
+		})
+		require.Nil(t, err)
+
+		supply, err := st.GetSupply([]byte("TKN-abcdef"), 0)
+		require.Nil(t, err)
+		assert.Equal(t, createSupply(100, 30), supply)
+	})
+	t.Run("NFTs should be tracked per nonce and per collection", func(t *testing.T) {
+		t.Parallel()
+
+		st, _ := NewSupplyTracker(ArgsSupplyTracker{Storer: NewMemoryStorer()})
+		err := st.ProcessLogs([]*vmcommon.LogEntry{
+			createLogEntry(core.BuiltInFunctionDCTNFTCreate, "SFT-abcdef", 1, 10),
+			createLogEntry(core.BuiltInFunctionDCTNFTCreate, "SFT-abcdef", 2, 5),
+			createLogEntry(core.BuiltInFunctionDCTNFTAddQuantity, "SFT-abcdef", 1, 3),
+			createLogEntry(core.BuiltInFunctionDCTNFTBurn, "SFT-abcdef", 2, 4),
+		})
+		require.Nil(t, err)
+
+		supply, _ := st.GetSupply([]byte("SFT-abcdef"), 1)
+		assert.Equal(t, createSupply(13, 0), supply)
+		supply, _ = st.GetSupply([]byte("SFT-abcdef"), 2)
+		assert.Equal(t, createSupply(5, 4), supply)
+		supply, _ = st.GetSupply([]byte("SFT-abcdef"), 0)
+		assert.Equal(t, createSupply(18, 4), supply)
+	})
+	t.Run("unknown token should return zero supply", func(t *testing.T) {
+		t.Parallel()
+
+		st, _ := NewSupplyTracker(ArgsSupplyTracker{Storer: NewMemoryStorer()})
+		supply, err := st.GetSupply([]byte("TKN-abcdef"), 0)
+		require.Nil(t, err)
+		assert.Equal(t, createSupply(0, 0), supply)
+	})
+	t.Run("supply changing entry without topics should err", func(t *testing.T) {
+		t.Parallel()
+
+		st, _ := NewSupplyTracker(ArgsSupplyTracker{Storer: NewMemoryStorer()})
+		err := st.ProcessLogs([]*vmcommon.LogEntry{{Identifier: []byte(core.BuiltInFunctionDCTLocalMint)}})
+		assert.Equal(t, ErrInvalidLogEntry, err)
+
+		err = st.ProcessLogs([]*vmcommon.LogEntry{{Identifier: []byte(core.BuiltInFunctionDCTTransfer)}})
+		assert.Nil(t, err)
+	})
+	t.Run("storer errors should be returned", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		st, _ := NewSupplyTracker(ArgsSupplyTracker{Storer: &storerStub{
+			getCalled: func(key []byte) ([]byte, error) {
+				return nil, nil
+			},
+			putCalled: func(key []byte, value []byte) error {
+				return expectedErr
+			},
+		}})
+		err := st.ProcessLogs([]*vmcommon.LogEntry{createLogEntry(core.BuiltInFunctionDCTLocalMint, "TKN-abcdef", 0, 1)})
+		assert.Equal(t, expectedErr, err)
+
+		st, _ = NewSupplyTracker(ArgsSupplyTracker{Storer: &storerStub{
+			getCalled: func(key []byte) ([]byte, error) {
+				return nil, expectedErr
+			},
+		}})
+		supply, err := st.GetSupply([]byte("TKN-abcdef"), 0)
+		assert.Nil(t, supply)
+		assert.Equal(t, expectedErr, err)
+	})
+	t.Run("corrupted data should err", func(t *testing.T) {
+		t.Parallel()
+
+		storer := NewMemoryStorer()
+		_ = storer.Put(computeKey([]byte("TKN-abcdef"), 0), []byte{0, 0, 0, 5, 1})
+		st, _ := NewSupplyTracker(ArgsSupplyTracker{Storer: storer})
+		_, err := st.GetSupply([]byte("TKN-abcdef"), 0)
+		assert.Equal(t, ErrInvalidSupplyData, err)
+	})
+}
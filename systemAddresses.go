@@ -0,0 +1,60 @@
+package vmcommon
+
+import (
+	"bytes"
+
+	"github.com/Reshusk23/sr-me-core/core"
+)
+
+// SystemAddresses holds the addresses of the system accounts the built-in functions depend on, so chains with a
+// different address layout can provide their own
+type SystemAddresses struct {
+	// DCTSCAddress is the address of the system smart contract managing the tokens, the only caller allowed to change
+	// the roles and the global settings of a token
+	DCTSCAddress []byte
+	// SystemAccountAddress is the address of the account holding the global settings and the token metadata on every
+	// shard. Its last ShardIdentiferLen bytes are ignored when checking an address against it
+	SystemAccountAddress []byte
+}
+
+// DefaultSystemAddresses returns the addresses of the system accounts used when none are configured
+func DefaultSystemAddresses() SystemAddresses {
+	return SystemAddresses{
+		DCTSCAddress:         core.DCTSCAddress,
+		SystemAccountAddress: SystemAccountAddress,
+	}
+}
+
+// Check returns an error if the addresses are missing, have different lengths or if the DCT system smart contract
+// address would be recognized as the system account address
+func (addresses SystemAddresses) Check() error {
+	if len(addresses.DCTSCAddress) == 0 {
+		return ErrInvalidDCTSCAddress
+	}
+	if len(addresses.SystemAccountAddress) != len(addresses.DCTSCAddress) {
+		return ErrInvalidSystemAccountAddress
+	}
+	if len(addresses.SystemAccountAddress) <= ShardIdentiferLen {
+		return ErrInvalidSystemAccountAddress
+	}
+	if addresses.IsSystemAccountAddress(addresses.DCTSCAddress) {
+		return ErrInvalidSystemAccountAddress
+	}
+
+	return nil
+}
+
+// IsDCTSCAddress returns true if the provided address is the DCT system smart contract address
+func (addresses SystemAddresses) IsDCTSCAddress(address []byte) bool {
+	return bytes.Equal(address, addresses.DCTSCAddress)
+}
+
+// IsSystemAccountAddress returns true if the provided address is the system account address of any shard
+func (addresses SystemAddresses) IsSystemAccountAddress(address []byte) bool {
+	prefixLength := len(addresses.SystemAccountAddress) - ShardIdentiferLen
+	if prefixLength <= 0 || len(address) < prefixLength {
+		return false
+	}
+
+	return bytes.Equal(address[:prefixLength], addresses.SystemAccountAddress[:prefixLength])
+}
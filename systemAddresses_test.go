@@ -0,0 +1,54 @@
+package vmcommon
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Reshusk23/sr-me-core/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSystemAddresses_Check(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, DefaultSystemAddresses().Check())
+
+	addresses := SystemAddresses{SystemAccountAddress: SystemAccountAddress}
+	assert.Equal(t, ErrInvalidDCTSCAddress, addresses.Check())
+
+	addresses = SystemAddresses{DCTSCAddress: core.DCTSCAddress, SystemAccountAddress: SystemAccountAddress[:20]}
+	assert.Equal(t, ErrInvalidSystemAccountAddress, addresses.Check())
+
+	addresses = SystemAddresses{DCTSCAddress: []byte{1, 2}, SystemAccountAddress: []byte{3, 4}}
+	assert.Equal(t, ErrInvalidSystemAccountAddress, addresses.Check())
+
+	addresses = SystemAddresses{DCTSCAddress: SystemAccountAddress, SystemAccountAddress: SystemAccountAddress}
+	assert.Equal(t, ErrInvalidSystemAccountAddress, addresses.Check())
+
+	addresses = SystemAddresses{DCTSCAddress: bytes.Repeat([]byte{1}, 20), SystemAccountAddress: bytes.Repeat([]byte{2}, 20)}
+	assert.Nil(t, addresses.Check())
+}
+
+func TestSystemAddresses_IsDCTSCAddress(t *testing.T) {
+	t.Parallel()
+
+	addresses := DefaultSystemAddresses()
+	assert.True(t, addresses.IsDCTSCAddress(core.DCTSCAddress))
+	assert.False(t, addresses.IsDCTSCAddress(SystemAccountAddress))
+	assert.False(t, addresses.IsDCTSCAddress(nil))
+}
+
+func TestSystemAddresses_IsSystemAccountAddress(t *testing.T) {
+	t.Parallel()
+
+	addresses := SystemAddresses{DCTSCAddress: bytes.Repeat([]byte{1}, 20), SystemAccountAddress: bytes.Repeat([]byte{2}, 20)}
+	assert.True(t, addresses.IsSystemAccountAddress(bytes.Repeat([]byte{2}, 20)))
+
+	otherShard := append(bytes.Repeat([]byte{2}, 18), 0, 1)
+	assert.True(t, addresses.IsSystemAccountAddress(otherShard))
+
+	assert.False(t, addresses.IsSystemAccountAddress(bytes.Repeat([]byte{1}, 20)))
+	assert.False(t, addresses.IsSystemAccountAddress([]byte{2}))
+	assert.False(t, DefaultSystemAddresses().IsSystemAccountAddress(otherShard))
+	assert.True(t, DefaultSystemAddresses().IsSystemAccountAddress(SystemAccountAddress))
+}
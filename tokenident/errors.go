@@ -0,0 +1,12 @@
+package tokenident
+
+import "errors"
+
+// ErrInvalidTicker signals that the provided ticker is not valid
+var ErrInvalidTicker = errors.New("invalid ticker")
+
+// ErrNotEnoughRandomness signals that the provided randomness is too short for generating the random suffix
+var ErrNotEnoughRandomness = errors.New("not enough randomness")
+
+// ErrInvalidDisplayIdentifier signals that the provided identifier is not in the TICKER-random-nonceHex display form
+var ErrInvalidDisplayIdentifier = errors.New("invalid display identifier")
@@ -0,0 +1,187 @@
+package tokenident
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"strings"
+)
+
+const (
+	// Separator is the character placed between the ticker, the random suffix and the nonce of a token identifier
+	Separator = "-"
+	// TickerMinLength is the minimum length of a token ticker
+	TickerMinLength = 3
+	// TickerMaxLength is the maximum length of a token ticker
+	TickerMaxLength = 10
+	// RandomSuffixLength is the length of the random suffix appended to the ticker
+	RandomSuffixLength = 6
+
+	separatorChar         = '-'
+	identifierMinLength   = TickerMinLength + RandomSuffixLength + 1
+	identifierMaxLength   = TickerMaxLength + RandomSuffixLength + 1
+	maxEncodedNonceLength = 16
+)
+
+// ValidateTokenIdentifier returns true if the provided token ID has the TICKER-suffix format, the ticker being
+// uppercase alphanumeric and the suffix being lowercase hex
+func ValidateTokenIdentifier(tokenID []byte) bool {
+	tokenIDLen := len(tokenID)
+	if tokenIDLen < identifierMinLength || tokenIDLen > identifierMaxLength {
+		return false
+	}
+
+	tickerLen := tokenIDLen - RandomSuffixLength
+
+	if !IsTickerValid(tokenID[0 : tickerLen-1]) {
+		return false
+	}
+
+	// dash char between the random chars and the ticker
+	if tokenID[tickerLen-1] != separatorChar {
+		return false
+	}
+
+	return isRandomSuffixValid(tokenID[tickerLen:tokenIDLen])
+}
+
+// IsTickerValid returns true if the ticker has a valid length and is all uppercase alphanumeric
+func IsTickerValid(ticker []byte) bool {
+	if len(ticker) < TickerMinLength || len(ticker) > TickerMaxLength {
+		return false
+	}
+	for _, ch := range ticker {
+		isBigCharacter := ch >= 'A' && ch <= 'Z'
+		isNumber := ch >= '0' && ch <= '9'
+		isReadable := isBigCharacter || isNumber
+		if !isReadable {
+			return false
+		}
+	}
+
+	return true
+}
+
+// random chars are alphanumeric lowercase
+func isRandomSuffixValid(chars []byte) bool {
+	if len(chars) != RandomSuffixLength {
+		return false
+	}
+	for _, ch := range chars {
+		isSmallCharacter := ch >= 'a' && ch <= 'f'
+		isNumber := ch >= '0' && ch <= '9'
+		isReadable := isSmallCharacter || isNumber
+		if !isReadable {
+			return false
+		}
+	}
+
+	return true
+}
+
+// SplitCollectionAndNonce splits a token key argument, made of the collection identifier directly followed by the
+// big endian nonce bytes, into the collection identifier and the nonce. Identifiers without a nonce are returned as
+// they are, with a 0 nonce.
+func SplitCollectionAndNonce(tokenKey []byte) ([]byte, uint64) {
+	argsSplit := bytes.Split(tokenKey, []byte(Separator))
+	if len(argsSplit) < 2 {
+		return tokenKey, 0
+	}
+
+	if len(argsSplit[1]) <= RandomSuffixLength {
+		return tokenKey, 0
+	}
+
+	collectionLen := len(argsSplit[0]) + len(Separator) + RandomSuffixLength
+	collection := make([]byte, collectionLen)
+	copy(collection, tokenKey[:collectionLen])
+	nonce := big.NewInt(0).SetBytes(argsSplit[1][RandomSuffixLength:])
+
+	return collection, nonce.Uint64()
+}
+
+// BuildNFTIdentifier returns the human readable identifier of an NFT, the collection identifier followed by the
+// hex encoded nonce. An empty string is returned for an empty collection or a 0 nonce.
+func BuildNFTIdentifier(collection string, nonce uint64) string {
+	if collection == "" || nonce == 0 {
+		return ""
+	}
+
+	return collection + Separator + EncodeNonce(nonce)
+}
+
+// EncodeNonce returns the nonce as it appears in the NFT identifiers, hex encoded without leading zeros. An empty
+// string is returned for a 0 nonce.
+func EncodeNonce(nonce uint64) string {
+	nonceBig := big.NewInt(0).SetUint64(nonce)
+	return hex.EncodeToString(nonceBig.Bytes())
+}
+
+// FormatDisplayIdentifier returns the identifier of a token in the TICKER-random-nonceHex display form used by the
+// explorers. The collection identifier is returned as it is for a 0 nonce, as the fungible tokens have no nonce suffix.
+func FormatDisplayIdentifier(collection string, nonce uint64) string {
+	if nonce == 0 {
+		return collection
+	}
+
+	return collection + Separator + EncodeNonce(nonce)
+}
+
+// ParseDisplayIdentifier splits an identifier in the display form into the collection identifier and the nonce, the
+// nonce being 0 for an identifier without a nonce suffix. Only the identifiers returned by FormatDisplayIdentifier for
+// a valid collection are accepted, so the nonce suffix must be lowercase hex without leading zeros.
+func ParseDisplayIdentifier(identifier string) (string, uint64, error) {
+	if ValidateTokenIdentifier([]byte(identifier)) {
+		return identifier, 0, nil
+	}
+
+	separatorIndex := strings.LastIndex(identifier, Separator)
+	if separatorIndex < 0 {
+		return "", 0, ErrInvalidDisplayIdentifier
+	}
+	collection := identifier[:separatorIndex]
+	if !ValidateTokenIdentifier([]byte(collection)) {
+		return "", 0, ErrInvalidDisplayIdentifier
+	}
+
+	encodedNonce := identifier[separatorIndex+1:]
+	if len(encodedNonce) > maxEncodedNonceLength {
+		return "", 0, ErrInvalidDisplayIdentifier
+	}
+	nonceBytes, err := hex.DecodeString(encodedNonce)
+	if err != nil {
+		return "", 0, ErrInvalidDisplayIdentifier
+	}
+	nonce := big.NewInt(0).SetBytes(nonceBytes).Uint64()
+	if nonce == 0 || EncodeNonce(nonce) != encodedNonce {
+		return "", 0, ErrInvalidDisplayIdentifier
+	}
+
+	return collection, nonce, nil
+}
+
+// GenerateRandomSuffix returns the random suffix of a token identifier, built by hex encoding the first bytes of
+// the provided randomness
+func GenerateRandomSuffix(randomness []byte) ([]byte, error) {
+	numBytes := RandomSuffixLength / 2
+	if len(randomness) < numBytes {
+		return nil, ErrNotEnoughRandomness
+	}
+
+	return []byte(hex.EncodeToString(randomness[:numBytes])), nil
+}
+
+// BuildTokenIdentifier returns a new token identifier composed of the ticker and a random suffix generated from
+// the provided randomness
+func BuildTokenIdentifier(ticker []byte, randomness []byte) ([]byte, error) {
+	if !IsTickerValid(ticker) {
+		return nil, ErrInvalidTicker
+	}
+
+	suffix, err := GenerateRandomSuffix(randomness)
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(strings.Join([]string{string(ticker), string(suffix)}, Separator)), nil
+}
@@ -0,0 +1,135 @@
+package tokenident
+
+import (
+	"encoding/hex"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateTokenIdentifier(t *testing.T) {
+	t.Parallel()
+
+	invalid := []string{
+		"ANDRIDEFL-08d8eff",
+		"ANDRIDEFL-08d8e",
+		"ANDRIDEFL08d8ef",
+		"ANDRIDEFl-08d8ef",
+		"ANDRIDEF*-08d8ef",
+		"ANDRIDEFL-08d8eF",
+		"ANDRIDEFL-08d*ef",
+		"AL-6258d2",
+		"ALCCCCCCCCC-6258d2",
+	}
+	for _, tokenID := range invalid {
+		assert.False(t, ValidateTokenIdentifier([]byte(tokenID)), tokenID)
+	}
+
+	valid := []string{
+		"ANDRIDEF2-08d8ef",
+		"ALC-6258d2",
+		"12345-6258d2",
+	}
+	for _, tokenID := range valid {
+		assert.True(t, ValidateTokenIdentifier([]byte(tokenID)), tokenID)
+	}
+}
+
+func TestSplitCollectionAndNonce(t *testing.T) {
+	t.Parallel()
+
+	args, _ := hex.DecodeString("534b4537592d37336262636404")
+	collection, nonce := SplitCollectionAndNonce(args)
+	require.Equal(t, uint64(4), nonce)
+	require.Equal(t, []byte("SKE7Y-73bbcd"), collection)
+
+	collection[0] = 'X'
+	require.Equal(t, byte('S'), args[0])
+
+	args, _ = hex.DecodeString("57414e442d376662623930")
+	collection, nonce = SplitCollectionAndNonce(args)
+	require.Equal(t, uint64(0), nonce)
+	require.Equal(t, []byte("WAND-7fbb90"), collection)
+
+	collection, nonce = SplitCollectionAndNonce([]byte("EGLD"))
+	require.Equal(t, uint64(0), nonce)
+	require.Equal(t, []byte("EGLD"), collection)
+}
+
+func TestBuildNFTIdentifier(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "MYTOKEN-abcd-0a", BuildNFTIdentifier("MYTOKEN-abcd", 10))
+	require.Equal(t, "", BuildNFTIdentifier("MYTOKEN-abcd", 0))
+	require.Equal(t, "", BuildNFTIdentifier("", 10))
+}
+
+func TestFormatDisplayIdentifier(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "MYTOKEN-abcdef", FormatDisplayIdentifier("MYTOKEN-abcdef", 0))
+	require.Equal(t, "MYTOKEN-abcdef-0d3d", FormatDisplayIdentifier("MYTOKEN-abcdef", 0x0d3d))
+}
+
+func TestParseDisplayIdentifier(t *testing.T) {
+	t.Parallel()
+
+	collection, nonce, err := ParseDisplayIdentifier("MYTOKEN-abcdef")
+	require.Nil(t, err)
+	require.Equal(t, "MYTOKEN-abcdef", collection)
+	require.Equal(t, uint64(0), nonce)
+
+	collection, nonce, err = ParseDisplayIdentifier("MYTOKEN-abcdef-0d3d")
+	require.Nil(t, err)
+	require.Equal(t, "MYTOKEN-abcdef", collection)
+	require.Equal(t, uint64(0x0d3d), nonce)
+
+	collection, nonce, err = ParseDisplayIdentifier(FormatDisplayIdentifier("MYTOKEN-abcdef", math.MaxUint64))
+	require.Nil(t, err)
+	require.Equal(t, "MYTOKEN-abcdef", collection)
+	require.Equal(t, uint64(math.MaxUint64), nonce)
+
+	invalidIdentifiers := []string{
+		"",
+		"MYTOKEN",
+		"mytoken-abcdef-01",
+		"MYTOKEN-abcdef-",
+		"MYTOKEN-abcdef-00",
+		"MYTOKEN-abcdef-d3d",
+		"MYTOKEN-abcdef-000d3d",
+		"MYTOKEN-abcdef-0D3D",
+		"MYTOKEN-abcdef-zz",
+		"MYTOKEN-abcdef-010000000000000000",
+	}
+	for _, identifier := range invalidIdentifiers {
+		_, _, err = ParseDisplayIdentifier(identifier)
+		require.Equal(t, ErrInvalidDisplayIdentifier, err, identifier)
+	}
+}
+
+func TestEncodeNonce(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "", EncodeNonce(0))
+	require.Equal(t, "0a", EncodeNonce(10))
+	require.Equal(t, "0d3d", EncodeNonce(0x0d3d))
+}
+
+func TestBuildTokenIdentifier(t *testing.T) {
+	t.Parallel()
+
+	tokenID, err := BuildTokenIdentifier([]byte("TKN"), []byte{0xab, 0x01, 0xff, 0x22})
+	require.Nil(t, err)
+	require.Equal(t, []byte("TKN-ab01ff"), tokenID)
+	require.True(t, ValidateTokenIdentifier(tokenID))
+
+	tokenID, err = BuildTokenIdentifier([]byte("tkn"), []byte{0xab, 0x01, 0xff})
+	require.Nil(t, tokenID)
+	require.Equal(t, ErrInvalidTicker, err)
+
+	tokenID, err = BuildTokenIdentifier([]byte("TKN"), []byte{0xab})
+	require.Nil(t, tokenID)
+	require.Equal(t, ErrNotEnoughRandomness, err)
+}
@@ -0,0 +1,56 @@
+package vmcommon
+
+// TraceStepType defines the kind of operation recorded in an execution trace
+type TraceStepType string
+
+const (
+	// TraceStorageRead is a value read from the storage of an account
+	TraceStorageRead TraceStepType = "storageRead"
+
+	// TraceStorageWrite is a value written in the storage of an account
+	TraceStorageWrite TraceStepType = "storageWrite"
+
+	// TraceRoleCheck is a read of the roles an account has for a token
+	TraceRoleCheck TraceStepType = "roleCheck"
+
+	// TraceGasConsumed is the gas consumed by the function itself
+	TraceGasConsumed TraceStepType = "gasConsumed"
+
+	// TraceGasForwarded is the gas forwarded together with an output transfer
+	TraceGasForwarded TraceStepType = "gasForwarded"
+)
+
+// TraceStep is a single operation performed while executing a built-in function
+type TraceStep struct {
+	Type    TraceStepType
+	Address []byte
+	Key     []byte
+	Value   []byte
+	Gas     uint64
+	Error   string
+}
+
+// ExecutionTrace holds, in execution order, the operations performed by a built-in function
+type ExecutionTrace struct {
+	Function     string
+	GasProvided  uint64
+	GasRemaining uint64
+	Steps        []TraceStep
+}
+
+// AddStep appends a step at the end of the trace
+func (trace *ExecutionTrace) AddStep(step TraceStep) {
+	trace.Steps = append(trace.Steps, step)
+}
+
+// StepsOfType returns, in execution order, the steps of the provided type
+func (trace *ExecutionTrace) StepsOfType(stepType TraceStepType) []TraceStep {
+	steps := make([]TraceStep, 0)
+	for _, step := range trace.Steps {
+		if step.Type == stepType {
+			steps = append(steps, step)
+		}
+	}
+
+	return steps
+}
@@ -0,0 +1,25 @@
+package vmcommon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecutionTrace_StepsOfType(t *testing.T) {
+	t.Parallel()
+
+	trace := &ExecutionTrace{}
+	assert.Empty(t, trace.StepsOfType(TraceStorageRead))
+
+	trace.AddStep(TraceStep{Type: TraceStorageRead, Key: []byte("key1")})
+	trace.AddStep(TraceStep{Type: TraceStorageWrite, Key: []byte("key1")})
+	trace.AddStep(TraceStep{Type: TraceStorageRead, Key: []byte("key2")})
+
+	assert.Len(t, trace.Steps, 3)
+	assert.Equal(t, []TraceStep{
+		{Type: TraceStorageRead, Key: []byte("key1")},
+		{Type: TraceStorageRead, Key: []byte("key2")},
+	}, trace.StepsOfType(TraceStorageRead))
+	assert.Empty(t, trace.StepsOfType(TraceGasConsumed))
+}
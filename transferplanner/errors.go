@@ -0,0 +1,9 @@
+package transferplanner
+
+import "errors"
+
+// ErrNilMarshalizer signals that a nil marshalizer has been provided
+var ErrNilMarshalizer = errors.New("nil marshalizer")
+
+// ErrNilVMInput signals that a nil vm input has been provided
+var ErrNilVMInput = errors.New("nil vm input")
@@ -0,0 +1,139 @@
+package transferplanner
+
+import (
+	"github.com/Reshusk23/sr-me-core/core/check"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/dctkeys"
+	"github.com/Reshusk23/sr-vm-common-go/parsers"
+)
+
+const dctKeyPrefix = dctkeys.DCTKeyPrefix
+
+// AccessKey identifies a DCT balance entry that is read and written by a transfer
+type AccessKey struct {
+	Address  string
+	TokenKey string
+	Nonce    uint64
+}
+
+// ArgsTransferPlanner holds the components needed to create a transfer planner
+type ArgsTransferPlanner struct {
+	Marshalizer vmcommon.Marshalizer
+}
+
+// transferPlanner groups DCT transfers in sets that can be executed in parallel
+type transferPlanner struct {
+	transferParser vmcommon.DCTTransferParser
+}
+
+// NewTransferPlanner creates a new transfer planner
+func NewTransferPlanner(args ArgsTransferPlanner) (*transferPlanner, error) {
+	if check.IfNil(args.Marshalizer) {
+		return nil, ErrNilMarshalizer
+	}
+
+	transferParser, err := parsers.NewDCTTransferParser(args.Marshalizer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &transferPlanner{
+		transferParser: transferParser,
+	}, nil
+}
+
+// AccessKeys returns the keys touched by the DCT transfer held in the provided input. The balances of the sender
+// and of the receiver are touched for every transferred token, while the system account is also touched for NFTs
+// as it holds their metadata and liquidity
+func (tp *transferPlanner) AccessKeys(vmInput *vmcommon.ContractCallInput) ([]AccessKey, error) {
+	if vmInput == nil {
+		return nil, ErrNilVMInput
+	}
+
+	parsedTransfers, err := tp.transferParser.ParseDCTTransfers(vmInput.CallerAddr, vmInput.RecipientAddr, vmInput.Function, vmInput.Arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]AccessKey, 0, 3*len(parsedTransfers.DCTTransfers))
+	for _, transfer := range parsedTransfers.DCTTransfers {
+		tokenKey := dctKeyPrefix + string(transfer.DCTTokenName)
+		keys = append(keys,
+			AccessKey{Address: string(vmInput.CallerAddr), TokenKey: tokenKey, Nonce: transfer.DCTTokenNonce},
+			AccessKey{Address: string(parsedTransfers.RcvAddr), TokenKey: tokenKey, Nonce: transfer.DCTTokenNonce},
+		)
+		if transfer.DCTTokenNonce > 0 {
+			keys = append(keys, AccessKey{Address: string(vmcommon.SystemAccountAddress), TokenKey: tokenKey, Nonce: transfer.DCTTokenNonce})
+		}
+	}
+
+	return keys, nil
+}
+
+// HasConflict returns true if the two inputs touch at least one common key. Inputs which are not DCT transfers
+// conflict with everything
+func (tp *transferPlanner) HasConflict(first *vmcommon.ContractCallInput, second *vmcommon.ContractCallInput) bool {
+	firstKeys, err := tp.AccessKeys(first)
+	if err != nil {
+		return true
+	}
+	secondKeys, err := tp.AccessKeys(second)
+	if err != nil {
+		return true
+	}
+
+	touchedKeys := make(map[AccessKey]struct{}, len(firstKeys))
+	for _, key := range firstKeys {
+		touchedKeys[key] = struct{}{}
+	}
+	for _, key := range secondKeys {
+		_, found := touchedKeys[key]
+		if found {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Plan splits the provided batch in consecutive sets of input indexes. The inputs in the same set do not conflict
+// and can be executed in parallel, while the sets have to be executed in the returned order. An input always lands
+// in a later set than all the previous inputs it conflicts with, so the result matches the sequential execution.
+// Inputs which are not DCT transfers are placed alone in their own set, acting as a barrier
+func (tp *transferPlanner) Plan(vmInputs []*vmcommon.ContractCallInput) [][]int {
+	sets := make([][]int, 0)
+	lastSetForKey := make(map[AccessKey]int)
+	barrier := -1
+
+	for index, vmInput := range vmInputs {
+		keys, err := tp.AccessKeys(vmInput)
+		if err != nil {
+			sets = append(sets, []int{index})
+			barrier = len(sets) - 1
+			continue
+		}
+
+		setIndex := barrier + 1
+		for _, key := range keys {
+			lastSet, found := lastSetForKey[key]
+			if found && lastSet >= setIndex {
+				setIndex = lastSet + 1
+			}
+		}
+
+		if setIndex == len(sets) {
+			sets = append(sets, make([]int, 0))
+		}
+		sets[setIndex] = append(sets[setIndex], index)
+		for _, key := range keys {
+			lastSetForKey[key] = setIndex
+		}
+	}
+
+	return sets
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (tp *transferPlanner) IsInterfaceNil() bool {
+	return tp == nil
+}
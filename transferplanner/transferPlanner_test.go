@@ -0,0 +1,152 @@
+package transferplanner
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/Reshusk23/sr-me-core/core"
+	"github.com/Reshusk23/sr-me-core/core/check"
+	vmcommon "github.com/Reshusk23/sr-vm-common-go"
+	"github.com/Reshusk23/sr-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createAddress(b byte) []byte {
+	return bytes.Repeat([]byte{b}, 32)
+}
+
+func createDCTTransferInput(sender, receiver []byte, token string) *vmcommon.ContractCallInput {
+	return &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr: sender,
+			Arguments:  [][]byte{[]byte(token), big.NewInt(10).Bytes()},
+		},
+		RecipientAddr: receiver,
+		Function:      core.BuiltInFunctionDCTTransfer,
+	}
+}
+
+func createNFTTransferInput(sender, receiver []byte, token string, nonce int64) *vmcommon.ContractCallInput {
+	return &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr: sender,
+			Arguments:  [][]byte{[]byte(token), big.NewInt(nonce).Bytes(), big.NewInt(1).Bytes(), receiver},
+		},
+		RecipientAddr: sender,
+		Function:      core.BuiltInFunctionDCTNFTTransfer,
+	}
+}
+
+func createPlanner() *transferPlanner {
+	planner, _ := NewTransferPlanner(ArgsTransferPlanner{Marshalizer: &mock.MarshalizerMock{}})
+	return planner
+}
+
+func TestNewTransferPlanner(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil marshalizer should error", func(t *testing.T) {
+		t.Parallel()
+
+		planner, err := NewTransferPlanner(ArgsTransferPlanner{})
+		assert.True(t, check.IfNil(planner))
+		assert.Equal(t, ErrNilMarshalizer, err)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		planner, err := NewTransferPlanner(ArgsTransferPlanner{Marshalizer: &mock.MarshalizerMock{}})
+		assert.False(t, check.IfNil(planner))
+		assert.Nil(t, err)
+	})
+}
+
+func TestTransferPlanner_AccessKeys(t *testing.T) {
+	t.Parallel()
+
+	planner := createPlanner()
+
+	_, err := planner.AccessKeys(nil)
+	assert.Equal(t, ErrNilVMInput, err)
+
+	_, err = planner.AccessKeys(&vmcommon.ContractCallInput{Function: "function"})
+	assert.NotNil(t, err)
+
+	keys, err := planner.AccessKeys(createDCTTransferInput(createAddress(1), createAddress(2), "TKN-abcdef"))
+	require.Nil(t, err)
+	assert.Equal(t, []AccessKey{
+		{Address: string(createAddress(1)), TokenKey: dctKeyPrefix + "TKN-abcdef"},
+		{Address: string(createAddress(2)), TokenKey: dctKeyPrefix + "TKN-abcdef"},
+	}, keys)
+
+	keys, err = planner.AccessKeys(createNFTTransferInput(createAddress(1), createAddress(2), "NFT-abcdef", 5))
+	require.Nil(t, err)
+	assert.Equal(t, []AccessKey{
+		{Address: string(createAddress(1)), TokenKey: dctKeyPrefix + "NFT-abcdef", Nonce: 5},
+		{Address: string(createAddress(2)), TokenKey: dctKeyPrefix + "NFT-abcdef", Nonce: 5},
+		{Address: string(vmcommon.SystemAccountAddress), TokenKey: dctKeyPrefix + "NFT-abcdef", Nonce: 5},
+	}, keys)
+}
+
+func TestTransferPlanner_HasConflict(t *testing.T) {
+	t.Parallel()
+
+	planner := createPlanner()
+
+	first := createDCTTransferInput(createAddress(1), createAddress(2), "TKN-abcdef")
+	assert.True(t, planner.HasConflict(first, createDCTTransferInput(createAddress(2), createAddress(3), "TKN-abcdef")))
+	assert.False(t, planner.HasConflict(first, createDCTTransferInput(createAddress(2), createAddress(3), "OTHER-abcdef")))
+	assert.False(t, planner.HasConflict(first, createDCTTransferInput(createAddress(3), createAddress(4), "TKN-abcdef")))
+	assert.True(t, planner.HasConflict(first, &vmcommon.ContractCallInput{Function: "function"}))
+
+	nft := createNFTTransferInput(createAddress(1), createAddress(2), "NFT-abcdef", 1)
+	assert.False(t, planner.HasConflict(nft, createNFTTransferInput(createAddress(1), createAddress(2), "NFT-abcdef", 2)))
+	assert.True(t, planner.HasConflict(nft, createNFTTransferInput(createAddress(3), createAddress(4), "NFT-abcdef", 1)))
+}
+
+func TestTransferPlanner_Plan(t *testing.T) {
+	t.Parallel()
+
+	planner := createPlanner()
+
+	t.Run("empty batch", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, [][]int{}, planner.Plan(nil))
+	})
+	t.Run("independent transfers should be in the same set", func(t *testing.T) {
+		t.Parallel()
+
+		sets := planner.Plan([]*vmcommon.ContractCallInput{
+			createDCTTransferInput(createAddress(1), createAddress(2), "TKN-abcdef"),
+			createDCTTransferInput(createAddress(3), createAddress(4), "TKN-abcdef"),
+			createDCTTransferInput(createAddress(1), createAddress(2), "OTHER-abcdef"),
+		})
+		assert.Equal(t, [][]int{{0, 1, 2}}, sets)
+	})
+	t.Run("conflicting transfers should keep the sequential order", func(t *testing.T) {
+		t.Parallel()
+
+		sets := planner.Plan([]*vmcommon.ContractCallInput{
+			createDCTTransferInput(createAddress(1), createAddress(2), "TKN-abcdef"),
+			createDCTTransferInput(createAddress(2), createAddress(3), "TKN-abcdef"),
+			createDCTTransferInput(createAddress(5), createAddress(6), "TKN-abcdef"),
+			createDCTTransferInput(createAddress(3), createAddress(4), "TKN-abcdef"),
+			createDCTTransferInput(createAddress(6), createAddress(7), "TKN-abcdef"),
+		})
+		assert.Equal(t, [][]int{{0, 2}, {1, 4}, {3}}, sets)
+	})
+	t.Run("non transfer inputs should act as barriers", func(t *testing.T) {
+		t.Parallel()
+
+		sets := planner.Plan([]*vmcommon.ContractCallInput{
+			createDCTTransferInput(createAddress(1), createAddress(2), "TKN-abcdef"),
+			{Function: "function"},
+			createDCTTransferInput(createAddress(3), createAddress(4), "TKN-abcdef"),
+			createDCTTransferInput(createAddress(5), createAddress(6), "TKN-abcdef"),
+		})
+		assert.Equal(t, [][]int{{0}, {1}, {2, 3}}, sets)
+	})
+}
@@ -1,10 +1,10 @@
 package txDataBuilder
 
 import (
-	"encoding/hex"
 	"math/big"
 
 	"github.com/Reshusk23/sr-me-core/core"
+	"github.com/Reshusk23/sr-vm-common-go/parsers"
 )
 
 // txDataBuilder constructs a string to be used for transaction arguments
@@ -12,6 +12,7 @@ type txDataBuilder struct {
 	function  string
 	elements  []string
 	separator string
+	encoding  parsers.ArgumentsEncoding
 }
 
 // NewBuilder creates a new txDataBuilder instance.
@@ -20,9 +21,23 @@ func NewBuilder() *txDataBuilder {
 		function:  "",
 		elements:  make([]string, 0),
 		separator: "@",
+		encoding:  parsers.HexArguments,
 	}
 }
 
+// NewBuilderWithEncoding creates a new txDataBuilder instance writing the function and the arguments using the
+// provided encoding.
+func NewBuilderWithEncoding(encoding parsers.ArgumentsEncoding) (*txDataBuilder, error) {
+	if !encoding.IsValid() {
+		return nil, parsers.ErrInvalidArgumentsEncoding
+	}
+
+	builder := NewBuilder()
+	builder.encoding = encoding
+
+	return builder, nil
+}
+
 // Clear resets the internal state of the txDataBuilder, allowing a new data
 // string to be built.
 func (builder *txDataBuilder) Clear() *txDataBuilder {
@@ -67,14 +82,14 @@ func (builder *txDataBuilder) SetLast(element string) {
 
 // Func sets the function to be invoked by the data string.
 func (builder *txDataBuilder) Func(function string) *txDataBuilder {
-	builder.function = function
+	builder.function = parsers.EncodeFunction(function, builder.encoding)
 
 	return builder
 }
 
 // Byte appends a single byte to the data string.
 func (builder *txDataBuilder) Byte(value byte) *txDataBuilder {
-	element := hex.EncodeToString([]byte{value})
+	element := builder.encode([]byte{value})
 	builder.elements = append(builder.elements, element)
 
 	return builder
@@ -82,7 +97,7 @@ func (builder *txDataBuilder) Byte(value byte) *txDataBuilder {
 
 // Bytes appends a slice of bytes to the data string.
 func (builder *txDataBuilder) Bytes(bytes []byte) *txDataBuilder {
-	element := hex.EncodeToString(bytes)
+	element := builder.encode(bytes)
 	builder.elements = append(builder.elements, element)
 
 	return builder
@@ -90,7 +105,7 @@ func (builder *txDataBuilder) Bytes(bytes []byte) *txDataBuilder {
 
 // Str appends a string to the data string.
 func (builder *txDataBuilder) Str(str string) *txDataBuilder {
-	element := hex.EncodeToString([]byte(str))
+	element := builder.encode([]byte(str))
 	builder.elements = append(builder.elements, element)
 
 	return builder
@@ -98,7 +113,7 @@ func (builder *txDataBuilder) Str(str string) *txDataBuilder {
 
 // Int appends an integer to the data string.
 func (builder *txDataBuilder) Int(value int) *txDataBuilder {
-	element := hex.EncodeToString(big.NewInt(int64(value)).Bytes())
+	element := builder.encode(big.NewInt(int64(value)).Bytes())
 	builder.elements = append(builder.elements, element)
 
 	return builder
@@ -106,7 +121,7 @@ func (builder *txDataBuilder) Int(value int) *txDataBuilder {
 
 // Int64 appends an int64 to the data string.
 func (builder *txDataBuilder) Int64(value int64) *txDataBuilder {
-	element := hex.EncodeToString(big.NewInt(value).Bytes())
+	element := builder.encode(big.NewInt(value).Bytes())
 	builder.elements = append(builder.elements, element)
 
 	return builder
@@ -192,6 +207,10 @@ func (builder *txDataBuilder) CanAddSpecialRoles(prop bool) *txDataBuilder {
 	return builder.Str("canAddSpecialRoles").Bool(prop)
 }
 
+func (builder *txDataBuilder) encode(value []byte) string {
+	return parsers.EncodeArgument(value, builder.encoding)
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (builder *txDataBuilder) IsInterfaceNil() bool {
 	return builder == nil
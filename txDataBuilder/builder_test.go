@@ -0,0 +1,48 @@
+package txDataBuilder
+
+import (
+	"testing"
+
+	"github.com/Reshusk23/sr-vm-common-go/parsers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBuilderWithEncoding(t *testing.T) {
+	t.Parallel()
+
+	builder, err := NewBuilderWithEncoding(parsers.ArgumentsEncoding(2))
+	assert.Equal(t, parsers.ErrInvalidArgumentsEncoding, err)
+	assert.Nil(t, builder)
+
+	builder, err = NewBuilderWithEncoding(parsers.HexArguments)
+	require.Nil(t, err)
+	assert.Equal(t, NewBuilder(), builder)
+}
+
+func TestTxDataBuilder_ToString(t *testing.T) {
+	t.Parallel()
+
+	t.Run("hex encoding", func(t *testing.T) {
+		t.Parallel()
+
+		data := NewBuilder().Func("foo").Str("a@b").Int(10).Bool(true).ToString()
+		assert.Equal(t, "foo@614062@0a@74727565", data)
+	})
+	t.Run("escaped encoding should be parsed back", func(t *testing.T) {
+		t.Parallel()
+
+		builder, err := NewBuilderWithEncoding(parsers.EscapedArguments)
+		require.Nil(t, err)
+
+		data := builder.Func("foo").Str("a@b").Bytes([]byte(`\`)).ToString()
+		assert.Equal(t, `foo@a\@b@\\`, data)
+
+		parser, err := parsers.NewCallArgsParserWithEncoding(parsers.EscapedArguments)
+		require.Nil(t, err)
+		function, arguments, err := parser.ParseData(data)
+		require.Nil(t, err)
+		assert.Equal(t, "foo", function)
+		assert.Equal(t, [][]byte{[]byte("a@b"), []byte(`\`)}, arguments)
+	})
+}
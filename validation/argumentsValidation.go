@@ -0,0 +1,104 @@
+package validation
+
+import (
+	"fmt"
+
+	"github.com/Reshusk23/sr-vm-common-go/tokenident"
+)
+
+const maxLenForUint64 = 8
+
+// Validator checks the arguments of a function call
+type Validator func(arguments [][]byte) error
+
+// ArgumentError is the error returned by the validators of a single argument, naming the argument
+type ArgumentError struct {
+	Index int
+	Err   error
+}
+
+// Error returns the error message, prefixed by the index of the argument
+func (e *ArgumentError) Error() string {
+	return fmt.Sprintf("argument %d: %s", e.Index, e.Err.Error())
+}
+
+// Unwrap returns the underlying error, so the sentinel errors of the package can be checked with errors.Is
+func (e *ArgumentError) Unwrap() error {
+	return e.Err
+}
+
+// CheckFunctionArguments runs the validators in the provided order and returns the first error
+func CheckFunctionArguments(arguments [][]byte, validators ...Validator) error {
+	for _, validator := range validators {
+		err := validator(arguments)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RequireMinArgs requires at least the provided number of arguments
+func RequireMinArgs(minNumArguments int) Validator {
+	return func(arguments [][]byte) error {
+		if len(arguments) < minNumArguments {
+			return fmt.Errorf("%w, expected at least %d, got %d", ErrNotEnoughArguments, minNumArguments, len(arguments))
+		}
+
+		return nil
+	}
+}
+
+// RequireHexToken requires the argument to be a token identifier made of a ticker and a hex encoded random suffix
+func RequireHexToken(index int) Validator {
+	return requireArgument(index, func(argument []byte) error {
+		if !tokenident.ValidateTokenIdentifier(argument) {
+			return ErrInvalidTokenIdentifier
+		}
+
+		return nil
+	})
+}
+
+// RequireAddress requires the argument to be an address of the provided length
+func RequireAddress(index int, addressLength int) Validator {
+	return requireArgument(index, func(argument []byte) error {
+		if len(argument) != addressLength {
+			return fmt.Errorf("%w, expected length %d, got %d", ErrInvalidAddress, addressLength, len(argument))
+		}
+
+		return nil
+	})
+}
+
+// RequireUint64 requires the argument to be a big endian encoded value fitting an uint64
+func RequireUint64(index int) Validator {
+	return RequireBigIntMaxBytes(index, maxLenForUint64)
+}
+
+// RequireBigIntMaxBytes requires the argument to be a big endian encoded value of at most the provided length
+func RequireBigIntMaxBytes(index int, maxBytes int) Validator {
+	return requireArgument(index, func(argument []byte) error {
+		if len(argument) > maxBytes {
+			return fmt.Errorf("%w, max length is %d", ErrValueTooLong, maxBytes)
+		}
+
+		return nil
+	})
+}
+
+func requireArgument(index int, checkArgument func(argument []byte) error) Validator {
+	return func(arguments [][]byte) error {
+		if index < 0 || index >= len(arguments) {
+			return &ArgumentError{Index: index, Err: ErrMissingArgument}
+		}
+
+		err := checkArgument(arguments[index])
+		if err != nil {
+			return &ArgumentError{Index: index, Err: err}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,95 @@
+package validation
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckFunctionArguments(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no validators should work", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Nil(t, CheckFunctionArguments(nil))
+	})
+	t.Run("should return the first error", func(t *testing.T) {
+		t.Parallel()
+
+		arguments := [][]byte{[]byte("TKN-abcdef"), []byte("addr")}
+		err := CheckFunctionArguments(arguments, RequireMinArgs(2), RequireAddress(1, 5), RequireMinArgs(3))
+		assert.True(t, errors.Is(err, ErrInvalidAddress))
+		assert.Equal(t, "argument 1: invalid address, expected length 5, got 4", err.Error())
+
+		argErr := &ArgumentError{}
+		assert.True(t, errors.As(err, &argErr))
+		assert.Equal(t, 1, argErr.Index)
+	})
+	t.Run("all passing should work", func(t *testing.T) {
+		t.Parallel()
+
+		arguments := [][]byte{[]byte("TKN-abcdef"), []byte("addr"), {1, 2}}
+		err := CheckFunctionArguments(arguments, RequireMinArgs(3), RequireHexToken(0), RequireAddress(1, 4), RequireUint64(2))
+		assert.Nil(t, err)
+	})
+}
+
+func TestRequireMinArgs(t *testing.T) {
+	t.Parallel()
+
+	err := RequireMinArgs(2)([][]byte{{1}})
+	assert.True(t, errors.Is(err, ErrNotEnoughArguments))
+	assert.Equal(t, "not enough arguments, expected at least 2, got 1", err.Error())
+
+	assert.Nil(t, RequireMinArgs(2)([][]byte{{1}, {2}}))
+	assert.Nil(t, RequireMinArgs(0)(nil))
+}
+
+func TestRequireHexToken(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, RequireHexToken(0)([][]byte{[]byte("TKN-abcdef")}))
+
+	err := RequireHexToken(0)([][]byte{[]byte("TKN-ABCDEF")})
+	assert.True(t, errors.Is(err, ErrInvalidTokenIdentifier))
+	assert.Equal(t, "argument 0: invalid token identifier", err.Error())
+}
+
+func TestRequireAddress(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, RequireAddress(0, 3)([][]byte{[]byte("abc")}))
+	assert.True(t, errors.Is(RequireAddress(0, 3)([][]byte{[]byte("ab")}), ErrInvalidAddress))
+	assert.True(t, errors.Is(RequireAddress(0, 3)([][]byte{nil}), ErrInvalidAddress))
+}
+
+func TestRequireUint64(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, RequireUint64(0)([][]byte{nil}))
+	assert.Nil(t, RequireUint64(0)([][]byte{make([]byte, 8)}))
+
+	err := RequireUint64(0)([][]byte{make([]byte, 9)})
+	assert.True(t, errors.Is(err, ErrValueTooLong))
+	assert.Equal(t, "argument 0: value too long, max length is 8", err.Error())
+}
+
+func TestRequireBigIntMaxBytes(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, RequireBigIntMaxBytes(1, 2)([][]byte{nil, {1, 2}}))
+	assert.True(t, errors.Is(RequireBigIntMaxBytes(1, 2)([][]byte{nil, {1, 2, 3}}), ErrValueTooLong))
+}
+
+func TestRequireArgument_MissingArgument(t *testing.T) {
+	t.Parallel()
+
+	validators := []Validator{RequireHexToken(2), RequireAddress(2, 3), RequireUint64(2), RequireBigIntMaxBytes(-1, 3)}
+	for _, validator := range validators {
+		err := validator([][]byte{{1}})
+		assert.True(t, errors.Is(err, ErrMissingArgument))
+	}
+	assert.Equal(t, "argument 2: missing argument", RequireUint64(2)(nil).Error())
+}
@@ -0,0 +1,18 @@
+package validation
+
+import "errors"
+
+// ErrNotEnoughArguments signals that fewer arguments than required have been provided
+var ErrNotEnoughArguments = errors.New("not enough arguments")
+
+// ErrMissingArgument signals that the argument to be validated has not been provided
+var ErrMissingArgument = errors.New("missing argument")
+
+// ErrInvalidAddress signals that the argument is not an address of the expected length
+var ErrInvalidAddress = errors.New("invalid address")
+
+// ErrInvalidTokenIdentifier signals that the argument is not a valid token identifier
+var ErrInvalidTokenIdentifier = errors.New("invalid token identifier")
+
+// ErrValueTooLong signals that the argument is longer than allowed for the value it encodes
+var ErrValueTooLong = errors.New("value too long")
@@ -0,0 +1,29 @@
+package vmcommon
+
+import "sync"
+
+var vmOutputPool = sync.Pool{
+	New: func() interface{} {
+		return &VMOutput{}
+	},
+}
+
+// NewVMOutputFromPool returns an empty VMOutput instance, reusing a released one whenever possible. A built-in function
+// returning such an instance hands it over to its caller, usually the host, which owns it from then on and gives it
+// back with ReleaseVMOutput once consumed. A built-in function dropping the instance because of an error releases it
+// itself. Not releasing an instance is always safe, it is then simply collected by the garbage collector
+func NewVMOutputFromPool() *VMOutput {
+	return vmOutputPool.Get().(*VMOutput)
+}
+
+// ReleaseVMOutput resets the provided VMOutput and makes it available for reuse, together with the slices and maps it
+// holds, like the logs or the output accounts. Only the owner of the instance may release it, and neither the instance
+// nor any of its collections may be used afterwards, so whatever has to outlive the release must be copied first
+func ReleaseVMOutput(vmOutput *VMOutput) {
+	if vmOutput == nil {
+		return
+	}
+
+	vmOutput.Reset()
+	vmOutputPool.Put(vmOutput)
+}